@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// neverPruneGroupKinds are the kinds that the built-in addons do not prune,
+// because deleting them also deletes other objects.
+var neverPruneGroupKinds = map[schema.GroupKind]bool{
+	{Group: "", Kind: "Namespace"}:                                    true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: true,
+}
+
+// Lint checks the addons of the channel for mistakes that stop them from being applied, updated or pruned.
+// The errors make the channel unusable, while the warnings point out addons that do not follow
+// the lifecycle of the built-in addons.
+func (a *Addons) Lint(vfsContext *vfs.VFSContext) (field.ErrorList, []string) {
+	var allErrs field.ErrorList
+	var warnings []string
+
+	addons, err := a.wrapInAddons()
+	if err != nil {
+		return append(allErrs, field.InternalError(field.NewPath("spec", "addons"), err)), nil
+	}
+
+	fldPath := field.NewPath("spec", "addons")
+	unversioned := make(map[string]bool)
+	for i, addon := range addons {
+		if addon.Spec == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), ""))
+			continue
+		}
+		errs, addonWarnings := addon.lint(vfsContext, fldPath.Index(i))
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, addonWarnings...)
+
+		// The same addon may only appear more than once for different Kubernetes versions
+		key := addon.GetNamespace() + ":" + addon.Name
+		if addon.Spec.KubernetesVersion == "" {
+			if unversioned[key] {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), addon.Name))
+			}
+			unversioned[key] = true
+		}
+	}
+
+	return allErrs, warnings
+}
+
+func (a *Addon) lint(vfsContext *vfs.VFSContext, fldPath *field.Path) (field.ErrorList, []string) {
+	var allErrs field.ErrorList
+	var warnings []string
+
+	if a.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		// The installed version is recorded in an annotation of the namespace
+		for _, msg := range validation.IsQualifiedName(AnnotationPrefix + a.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), a.Name, msg))
+		}
+	}
+
+	if a.Spec.KubernetesVersion != "" {
+		if _, err := semver.ParseRange(a.Spec.KubernetesVersion); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("kubernetesVersion"), a.Spec.KubernetesVersion, err.Error()))
+		}
+	}
+
+	for k, v := range a.Spec.Selector {
+		for _, msg := range validation.IsQualifiedName(k) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(v) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("selector").Key(k), v, msg))
+		}
+	}
+
+	switch a.Spec.NeedsRollingUpdate {
+	case "", api.NeedsRollingUpdateControlPlane, api.NeedsRollingUpdateWorkers, api.NeedsRollingUpdateAll:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("needsRollingUpdate"), a.Spec.NeedsRollingUpdate, []string{
+			string(api.NeedsRollingUpdateControlPlane),
+			string(api.NeedsRollingUpdateWorkers),
+			string(api.NeedsRollingUpdateAll),
+		}))
+	}
+
+	var objects kubemanifest.ObjectList
+	if a.Spec.Manifest == nil || *a.Spec.Manifest == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("manifest"), ""))
+	} else {
+		manifestURL, err := a.GetManifestFullUrl()
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("manifest"), *a.Spec.Manifest, "not a valid URL"))
+		} else {
+			data, err := vfsContext.ReadFile(manifestURL.String())
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("manifest"), *a.Spec.Manifest, fmt.Sprintf("cannot read manifest: %v", err)))
+			} else {
+				objects, err = kubemanifest.LoadObjectsFrom(data)
+				if err != nil {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("manifest"), *a.Spec.Manifest, fmt.Sprintf("cannot parse manifest: %v", err)))
+				}
+
+				// Updates are only applied when the manifest hash changes
+				manifestHash, err := utils.HashString(strings.TrimSpace(string(data)))
+				if err != nil {
+					return append(allErrs, field.InternalError(fldPath.Child("manifestHash"), err)), warnings
+				}
+				if a.Spec.ManifestHash == "" {
+					allErrs = append(allErrs, field.Required(fldPath.Child("manifestHash"), fmt.Sprintf("updates are only applied when the manifest hash changes; the hash of the manifest is %s", manifestHash)))
+				} else if a.Spec.ManifestHash != manifestHash {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("manifestHash"), a.Spec.ManifestHash, fmt.Sprintf("does not match the hash of the manifest %s", manifestHash)))
+				}
+			}
+		}
+	}
+
+	if a.Spec.Prune == nil {
+		warnings = append(warnings, fmt.Sprintf("addon %q does not prune objects that are removed from its manifest", a.Name))
+		return allErrs, warnings
+	}
+
+	selectors := make(map[schema.GroupKind]labels.Selector)
+	for i, kind := range a.Spec.Prune.Kinds {
+		kindPath := fldPath.Child("prune", "kinds").Index(i)
+		if kind.Kind == "" {
+			allErrs = append(allErrs, field.Required(kindPath.Child("kind"), ""))
+		}
+		gk := schema.GroupKind{Group: kind.Group, Kind: kind.Kind}
+		if neverPruneGroupKinds[gk] {
+			warnings = append(warnings, fmt.Sprintf("addon %q prunes objects of kind %v, which deletes the objects they contain", a.Name, gk))
+		}
+		// Objects of kinds with an invalid selector are not reported as unpruned
+		selectors[gk] = nil
+		if kind.LabelSelector == "" {
+			allErrs = append(allErrs, field.Required(kindPath.Child("labelSelector"), "pruning without a label selector deletes all the objects of the kind that are not in the manifest"))
+		} else {
+			selector, err := labels.Parse(kind.LabelSelector)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(kindPath.Child("labelSelector"), kind.LabelSelector, err.Error()))
+			} else {
+				selectors[gk] = selector
+			}
+		}
+		if kind.FieldSelector != "" {
+			if _, err := fields.ParseSelector(kind.FieldSelector); err != nil {
+				allErrs = append(allErrs, field.Invalid(kindPath.Child("fieldSelector"), kind.FieldSelector, err.Error()))
+			}
+		}
+	}
+
+	// Objects are only pruned once removed from the manifest if the prune selector finds them
+	for _, object := range objects {
+		gv, err := schema.ParseGroupVersion(object.APIVersion())
+		if err != nil {
+			continue
+		}
+		gk := schema.GroupKind{Group: gv.Group, Kind: object.Kind()}
+		id := fmt.Sprintf("%s %s/%s", gk, object.GetNamespace(), object.GetName())
+		selector, found := selectors[gk]
+		if !found {
+			if !neverPruneGroupKinds[gk] {
+				warnings = append(warnings, fmt.Sprintf("addon %q includes %s, whose kind is not pruned", a.Name, id))
+			}
+			continue
+		}
+		if selector != nil && !selector.Matches(labels.Set(object.GetLabels())) {
+			warnings = append(warnings, fmt.Sprintf("addon %q includes %s, which does not match the prune label selector %q", a.Name, id, selector))
+		}
+	}
+
+	return allErrs, warnings
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func Test_Lint(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: kube-system
+  labels:
+    app: foo
+`
+	manifestHash, err := utils.HashString(strings.TrimSpace(manifest))
+	if err != nil {
+		t.Fatalf("error hashing manifest: %v", err)
+	}
+
+	grid := []struct {
+		Description string
+		Addons      string
+		Errors      []string
+		Warnings    []string
+	}{
+		{
+			Description: "valid",
+			Addons: `
+  - name: foo.example.com
+    manifest: foo.yaml
+    manifestHash: ` + manifestHash + `
+    prune:
+      kinds:
+      - kind: ConfigMap
+        labelSelector: app=foo
+`,
+		},
+		{
+			Description: "missing manifest hash",
+			Addons: `
+  - name: foo.example.com
+    manifest: foo.yaml
+    prune:
+      kinds:
+      - kind: ConfigMap
+        labelSelector: app=foo
+`,
+			Errors: []string{"spec.addons[0].manifestHash: Required value"},
+		},
+		{
+			Description: "stale manifest hash",
+			Addons: `
+  - name: foo.example.com
+    manifest: foo.yaml
+    manifestHash: abc
+    prune:
+      kinds:
+      - kind: ConfigMap
+        labelSelector: app=foo
+`,
+			Errors: []string{"spec.addons[0].manifestHash: Invalid value: \"abc\""},
+		},
+		{
+			Description: "duplicate addon",
+			Addons: `
+  - name: foo.example.com
+    manifest: foo.yaml
+    manifestHash: ` + manifestHash + `
+  - name: foo.example.com
+    manifest: foo.yaml
+    manifestHash: ` + manifestHash + `
+`,
+			Errors:   []string{"spec.addons[1].name: Duplicate value"},
+			Warnings: []string{"does not prune objects", "does not prune objects"},
+		},
+		{
+			Description: "invalid kubernetes version and selector",
+			Addons: `
+  - name: foo.example.com
+    manifest: foo.yaml
+    manifestHash: ` + manifestHash + `
+    kubernetesVersion: "not-a-range"
+    selector:
+      k8s-addon: "not a value"
+    needsRollingUpdate: sometimes
+    prune:
+      kinds:
+      - kind: ConfigMap
+        labelSelector: app=foo
+`,
+			Errors: []string{
+				"spec.addons[0].kubernetesVersion: Invalid value",
+				"spec.addons[0].selector[k8s-addon]: Invalid value",
+				"spec.addons[0].needsRollingUpdate: Unsupported value",
+			},
+		},
+		{
+			Description: "prune without label selector",
+			Addons: `
+  - name: foo.example.com
+    manifest: foo.yaml
+    manifestHash: ` + manifestHash + `
+    prune:
+      kinds:
+      - kind: ConfigMap
+      - group: apiextensions.k8s.io
+        kind: CustomResourceDefinition
+        labelSelector: app=foo
+`,
+			Errors:   []string{"spec.addons[0].prune.kinds[0].labelSelector: Required value"},
+			Warnings: []string{"prunes objects of kind CustomResourceDefinition.apiextensions.k8s.io"},
+		},
+		{
+			Description: "objects not pruned",
+			Addons: `
+  - name: foo.example.com
+    manifest: foo.yaml
+    manifestHash: ` + manifestHash + `
+    prune:
+      kinds:
+      - kind: ConfigMap
+        labelSelector: app=bar
+      - kind: Secret
+        labelSelector: app=foo
+`,
+			Warnings: []string{"does not match the prune label selector \"app=bar\""},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "foo.yaml"), []byte(manifest), 0o644); err != nil {
+				t.Fatalf("error writing manifest: %v", err)
+			}
+
+			location := &url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(dir, "addon.yaml"))}
+			addons, err := ParseAddons("test", location, []byte("kind: Addons\nspec:\n  addons:"+g.Addons))
+			if err != nil {
+				t.Fatalf("error parsing addons: %v", err)
+			}
+
+			errs, warnings := addons.Lint(vfs.NewVFSContext())
+			if len(errs) != len(g.Errors) {
+				t.Errorf("expected %d errors, got %v", len(g.Errors), errs)
+			}
+			for i := 0; i < len(errs) && i < len(g.Errors); i++ {
+				if !strings.Contains(errs[i].Error(), g.Errors[i]) {
+					t.Errorf("expected error %q, got %q", g.Errors[i], errs[i].Error())
+				}
+			}
+			if len(warnings) != len(g.Warnings) {
+				t.Errorf("expected %d warnings, got %q", len(g.Warnings), warnings)
+			}
+			for i := 0; i < len(warnings) && i < len(g.Warnings); i++ {
+				if !strings.Contains(warnings[i], g.Warnings[i]) {
+					t.Errorf("expected warning %q, got %q", g.Warnings[i], warnings[i])
+				}
+			}
+		})
+	}
+}
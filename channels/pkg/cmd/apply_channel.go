@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net/url"
 	"os"
 
 	"github.com/blang/semver/v4"
@@ -220,9 +219,9 @@ func getChannelVersions(ctx context.Context, k8sClient kubernetes.Interface) (ma
 func buildMenu(vfsContext *vfs.VFSContext, kubernetesVersion semver.Version, channelLocation string) (*channels.AddonMenu, error) {
 	menu := channels.NewAddonMenu()
 
-	location, err := url.Parse(channelLocation)
+	location, err := parseChannelLocation(channelLocation)
 	if err != nil {
-		return nil, fmt.Errorf("unable to parse argument %q as url", channelLocation)
+		return nil, err
 	}
 	if !location.IsAbs() {
 		expanded := "https://raw.githubusercontent.com/kubernetes/kops/master/addons/" + channelLocation + "/addon.yaml"
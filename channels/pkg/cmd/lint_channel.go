@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"k8s.io/kops/channels/pkg/channels"
+)
+
+type LintChannelOptions struct{}
+
+// RunLintChannel checks a channel and the manifests of its addons, printing the warnings and errors that it finds.
+func RunLintChannel(f Factory, out io.Writer, options *LintChannelOptions, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("unexpected number of arguments. Only one channel may be processed at the same time.")
+	}
+	channelLocation := args[0]
+
+	location, err := parseChannelLocation(channelLocation)
+	if err != nil {
+		return err
+	}
+	o, err := channels.LoadAddons(f.VFSContext(), channelLocation, location)
+	if err != nil {
+		return fmt.Errorf("error loading channel %q: %v", location, err)
+	}
+
+	errs, warnings := o.Lint(f.VFSContext())
+	for _, warning := range warnings {
+		fmt.Fprintf(out, "Warning: %s\n", warning)
+	}
+	for _, err := range errs {
+		fmt.Fprintf(out, "Error: %v\n", err)
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("channel %q has %d error(s)", channelLocation, len(errs))
+	}
+
+	fmt.Fprintf(out, "Channel %q is valid\n", channelLocation)
+	return nil
+}
+
+// parseChannelLocation parses the location of a channel, which may be a local file for channels under development.
+func parseChannelLocation(channelLocation string) (*url.URL, error) {
+	location, err := url.Parse(channelLocation)
+	if err == nil && location.IsAbs() {
+		return location, nil
+	}
+
+	if _, statErr := os.Stat(channelLocation); statErr == nil {
+		p, err := filepath.Abs(channelLocation)
+		if err != nil {
+			return nil, fmt.Errorf("cannot determine the path of %q: %w", channelLocation, err)
+		}
+		return &url.URL{Scheme: "file", Path: filepath.ToSlash(p)}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse argument %q as url", channelLocation)
+	}
+	return location, nil
+}
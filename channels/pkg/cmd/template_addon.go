@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/upup/pkg/fi/utils"
+)
+
+type TemplateAddonOptions struct {
+	// Name is the name of the addon, for example my-addon.example.com
+	Name string
+	// Namespace is the namespace of the objects of the addon
+	Namespace string
+	// OutputDir is the directory of the channel, which defaults to the name of the addon
+	OutputDir string
+}
+
+const (
+	// addonManagedByLabel and addonNameLabel are the labels that the built-in addons use to select the objects to prune.
+	addonManagedByLabel = "app.kubernetes.io/managed-by"
+	addonNameLabel      = "addon.kops.k8s.io/name"
+)
+
+// templatePruneKinds are the kinds that the template prunes, so that objects are deleted
+// when they are removed from the manifest.
+var templatePruneKinds = []api.PruneKindSpec{
+	{Group: "", Kind: "ConfigMap"},
+	{Group: "", Kind: "Secret"},
+	{Group: "", Kind: "Service"},
+	{Group: "", Kind: "ServiceAccount"},
+	{Group: "apps", Kind: "DaemonSet"},
+	{Group: "apps", Kind: "Deployment"},
+	{Group: "apps", Kind: "StatefulSet"},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"},
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"},
+	{Group: "rbac.authorization.k8s.io", Kind: "Role"},
+	{Group: "rbac.authorization.k8s.io", Kind: "RoleBinding"},
+}
+
+// templateNamespace creates the namespace of the addon, which records the installed version of the addon.
+const templateNamespace = `apiVersion: v1
+kind: Namespace
+metadata:
+  name: {{NAMESPACE}}
+  labels:
+    app.kubernetes.io/managed-by: kops
+    addon.kops.k8s.io/name: {{NAME}}
+---
+`
+
+const templateManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{NAME}}
+  namespace: {{NAMESPACE}}
+  labels:
+    app.kubernetes.io/managed-by: kops
+    addon.kops.k8s.io/name: {{NAME}}
+data:
+  README: Replace this ConfigMap with the objects of the addon, keeping the labels so that they are pruned.
+`
+
+// RunTemplateAddon writes a channel with a single addon, which can be used as a starting point for private addons.
+func RunTemplateAddon(out io.Writer, options *TemplateAddonOptions) error {
+	if options.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if msgs := validation.IsQualifiedName(channels.AnnotationPrefix + options.Name); len(msgs) != 0 {
+		return fmt.Errorf("invalid addon name %q: %s", options.Name, strings.Join(msgs, ", "))
+	}
+	if options.Namespace == "" {
+		options.Namespace = "kube-system"
+	}
+	if options.OutputDir == "" {
+		options.OutputDir = options.Name
+	}
+
+	manifestPath := options.Name + "/manifest.yaml"
+	manifest := templateManifest
+	if options.Namespace != "kube-system" {
+		manifest = templateNamespace + manifest
+	}
+	manifest = strings.NewReplacer("{{NAME}}", options.Name, "{{NAMESPACE}}", options.Namespace).Replace(manifest)
+	manifestHash, err := utils.HashString(strings.TrimSpace(manifest))
+	if err != nil {
+		return fmt.Errorf("error hashing manifest: %w", err)
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{
+		addonManagedByLabel: "kops",
+		addonNameLabel:      options.Name,
+	})
+	addon := &api.AddonSpec{
+		Name:         &options.Name,
+		Selector:     map[string]string{"k8s-addon": options.Name},
+		Manifest:     &manifestPath,
+		ManifestHash: manifestHash,
+		Prune:        &api.PruneSpec{},
+	}
+	if options.Namespace != "kube-system" {
+		addon.Namespace = &options.Namespace
+	}
+	for _, kind := range templatePruneKinds {
+		kind.LabelSelector = selector.String()
+		addon.Prune.Kinds = append(addon.Prune.Kinds, kind)
+	}
+
+	addons := &api.Addons{}
+	addons.Kind = "Addons"
+	addons.ObjectMeta.Name = options.Name
+	addons.Spec.Addons = append(addons.Spec.Addons, addon)
+	channel, err := utils.YamlMarshal(addons)
+	if err != nil {
+		return fmt.Errorf("error building channel: %w", err)
+	}
+
+	files := map[string][]byte{
+		filepath.Join(options.OutputDir, "addon.yaml"):                     channel,
+		filepath.Join(options.OutputDir, filepath.FromSlash(manifestPath)): []byte(manifest),
+	}
+	for p := range files {
+		if _, err := os.Stat(p); err == nil {
+			return fmt.Errorf("file %q already exists", p)
+		}
+	}
+	for p, data := range files {
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return fmt.Errorf("error creating directory for %q: %w", p, err)
+		}
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			return fmt.Errorf("error writing %q: %w", p, err)
+		}
+	}
+
+	fmt.Fprintf(out, "Wrote the channel of addon %q to %s\n", options.Name, filepath.Join(options.OutputDir, "addon.yaml"))
+	fmt.Fprintf(out, "After changing the manifest, update manifestHash with the hash that \"kops toolbox addons lint\" reports.\n")
+	return nil
+}
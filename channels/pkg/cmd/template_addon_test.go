@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestRunTemplateAddon(t *testing.T) {
+	for _, namespace := range []string{"kube-system", "my-addon"} {
+		t.Run(namespace, func(t *testing.T) {
+			options := &TemplateAddonOptions{
+				Name:      "my-addon.example.com",
+				Namespace: namespace,
+				OutputDir: filepath.Join(t.TempDir(), "channel"),
+			}
+			var out bytes.Buffer
+			if err := RunTemplateAddon(&out, options); err != nil {
+				t.Fatalf("error writing template: %v", err)
+			}
+
+			// The template is a valid starting point for an addon
+			channelLocation := filepath.Join(options.OutputDir, "addon.yaml")
+			location, err := parseChannelLocation(channelLocation)
+			if err != nil {
+				t.Fatalf("error parsing channel location: %v", err)
+			}
+			vfsContext := vfs.NewVFSContext()
+			addons, err := channels.LoadAddons(vfsContext, channelLocation, location)
+			if err != nil {
+				t.Fatalf("error loading channel: %v", err)
+			}
+			errs, warnings := addons.Lint(vfsContext)
+			if len(errs) != 0 || len(warnings) != 0 {
+				t.Errorf("unexpected lint errors %v and warnings %q", errs, warnings)
+			}
+
+			// Existing addons are not overwritten
+			if err := RunTemplateAddon(&out, options); err == nil {
+				t.Errorf("expected error when the template already exists")
+			}
+		})
+	}
+}
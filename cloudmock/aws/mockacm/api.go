@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockacm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+type MockACM struct {
+	awsinterfaces.ACMAPI
+	mutex sync.Mutex
+
+	Certificates map[string]*mockCertificate
+
+	certificateCount int
+}
+
+type mockCertificate struct {
+	detail acmtypes.CertificateDetail
+	tags   map[string]string
+
+	// described counts the DescribeCertificate calls, used to simulate DNS validation
+	described int
+}
+
+var _ awsinterfaces.ACMAPI = &MockACM{}
+
+func (m *MockACM) RequestCertificate(ctx context.Context, request *acm.RequestCertificateInput, optFns ...func(*acm.Options)) (*acm.RequestCertificateOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("RequestCertificate: %v", request)
+
+	if request.ValidationMethod != acmtypes.ValidationMethodDns {
+		return nil, fmt.Errorf("only DNS validation is implemented by the mock")
+	}
+
+	m.certificateCount++
+	arn := fmt.Sprintf("arn:aws-test:acm:us-test-1:000000000000:certificate/%d", m.certificateCount)
+
+	names := []string{aws.ToString(request.DomainName)}
+	for _, name := range request.SubjectAlternativeNames {
+		if name != names[0] {
+			names = append(names, name)
+		}
+	}
+
+	var validations []acmtypes.DomainValidation
+	for _, name := range names {
+		recordName := "_validation." + strings.TrimPrefix(name, "*.") + "."
+		validations = append(validations, acmtypes.DomainValidation{
+			DomainName:       aws.String(name),
+			ValidationMethod: acmtypes.ValidationMethodDns,
+			ValidationStatus: acmtypes.DomainStatusPendingValidation,
+			ResourceRecord: &acmtypes.ResourceRecord{
+				Name:  aws.String(recordName),
+				Type:  acmtypes.RecordTypeCname,
+				Value: aws.String("_validation.acm-validations.aws."),
+			},
+		})
+	}
+
+	certificate := &mockCertificate{
+		detail: acmtypes.CertificateDetail{
+			CertificateArn:          aws.String(arn),
+			DomainName:              request.DomainName,
+			DomainValidationOptions: validations,
+			Status:                  acmtypes.CertificateStatusPendingValidation,
+			SubjectAlternativeNames: names,
+			Type:                    acmtypes.CertificateTypeAmazonIssued,
+		},
+		tags: make(map[string]string),
+	}
+	for _, tag := range request.Tags {
+		certificate.tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+
+	if m.Certificates == nil {
+		m.Certificates = make(map[string]*mockCertificate)
+	}
+	m.Certificates[arn] = certificate
+
+	return &acm.RequestCertificateOutput{CertificateArn: aws.String(arn)}, nil
+}
+
+// DescribeCertificate returns the certificate. Pending certificates are issued the second time
+// they are described, simulating DNS validation.
+func (m *MockACM) DescribeCertificate(ctx context.Context, request *acm.DescribeCertificateInput, optFns ...func(*acm.Options)) (*acm.DescribeCertificateOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeCertificate: %v", request)
+
+	certificate := m.Certificates[aws.ToString(request.CertificateArn)]
+	if certificate == nil {
+		return nil, &acmtypes.ResourceNotFoundException{Message: aws.String("certificate not found")}
+	}
+
+	certificate.described++
+	if certificate.detail.Status == acmtypes.CertificateStatusPendingValidation && certificate.described > 1 {
+		certificate.detail.Status = acmtypes.CertificateStatusIssued
+		for i := range certificate.detail.DomainValidationOptions {
+			certificate.detail.DomainValidationOptions[i].ValidationStatus = acmtypes.DomainStatusSuccess
+		}
+	}
+
+	detail := certificate.detail
+	return &acm.DescribeCertificateOutput{Certificate: &detail}, nil
+}
+
+func (m *MockACM) ListCertificates(ctx context.Context, request *acm.ListCertificatesInput, optFns ...func(*acm.Options)) (*acm.ListCertificatesOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("ListCertificates: %v", request)
+
+	var arns []string
+	for arn := range m.Certificates {
+		arns = append(arns, arn)
+	}
+	sort.Strings(arns)
+
+	response := &acm.ListCertificatesOutput{}
+	for _, arn := range arns {
+		certificate := m.Certificates[arn]
+		response.CertificateSummaryList = append(response.CertificateSummaryList, acmtypes.CertificateSummary{
+			CertificateArn: certificate.detail.CertificateArn,
+			DomainName:     certificate.detail.DomainName,
+			InUse:          aws.Bool(len(certificate.detail.InUseBy) != 0),
+			Status:         certificate.detail.Status,
+		})
+	}
+	return response, nil
+}
+
+func (m *MockACM) ListTagsForCertificate(ctx context.Context, request *acm.ListTagsForCertificateInput, optFns ...func(*acm.Options)) (*acm.ListTagsForCertificateOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("ListTagsForCertificate: %v", request)
+
+	certificate := m.Certificates[aws.ToString(request.CertificateArn)]
+	if certificate == nil {
+		return nil, &acmtypes.ResourceNotFoundException{Message: aws.String("certificate not found")}
+	}
+
+	response := &acm.ListTagsForCertificateOutput{}
+	for k, v := range certificate.tags {
+		response.Tags = append(response.Tags, acmtypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	return response, nil
+}
+
+func (m *MockACM) AddTagsToCertificate(ctx context.Context, request *acm.AddTagsToCertificateInput, optFns ...func(*acm.Options)) (*acm.AddTagsToCertificateOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("AddTagsToCertificate: %v", request)
+
+	certificate := m.Certificates[aws.ToString(request.CertificateArn)]
+	if certificate == nil {
+		return nil, &acmtypes.ResourceNotFoundException{Message: aws.String("certificate not found")}
+	}
+	for _, tag := range request.Tags {
+		certificate.tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return &acm.AddTagsToCertificateOutput{}, nil
+}
+
+func (m *MockACM) DeleteCertificate(ctx context.Context, request *acm.DeleteCertificateInput, optFns ...func(*acm.Options)) (*acm.DeleteCertificateOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteCertificate: %v", request)
+
+	arn := aws.ToString(request.CertificateArn)
+	certificate := m.Certificates[arn]
+	if certificate == nil {
+		return nil, &acmtypes.ResourceNotFoundException{Message: aws.String("certificate not found")}
+	}
+	if len(certificate.detail.InUseBy) != 0 {
+		return nil, &acmtypes.ResourceInUseException{Message: aws.String("certificate is in use")}
+	}
+	delete(m.Certificates, arn)
+	return &acm.DeleteCertificateOutput{}, nil
+}
+
+// MockSetInUseBy records the resources using a certificate, as ELB does when a listener uses it.
+func (m *MockACM) MockSetInUseBy(arn string, inUseBy []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if certificate := m.Certificates[arn]; certificate != nil {
+		certificate.detail.InUseBy = inUseBy
+	}
+}
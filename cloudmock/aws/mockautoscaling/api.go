@@ -30,7 +30,9 @@ type MockAutoscaling struct {
 	mutex             sync.Mutex
 	Groups            map[string]*autoscalingtypes.AutoScalingGroup
 	WarmPoolInstances map[string][]autoscalingtypes.Instance
+	WarmPools         map[string]*autoscalingtypes.WarmPoolConfiguration
 	LifecycleHooks    map[string]*autoscalingtypes.LifecycleHook
+	ScalingPolicies   map[string]*autoscalingtypes.ScalingPolicy
 }
 
 var _ awsinterfaces.AutoScalingAPI = &MockAutoscaling{}
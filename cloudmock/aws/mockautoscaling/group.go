@@ -99,6 +99,9 @@ func (m *MockAutoscaling) CreateAutoScalingGroup(ctx context.Context, input *aut
 		TerminationPolicies: input.TerminationPolicies,
 		VPCZoneIdentifier:   input.VPCZoneIdentifier,
 		MaxInstanceLifetime: input.MaxInstanceLifetime,
+
+		DefaultInstanceWarmup:     input.DefaultInstanceWarmup,
+		InstanceMaintenancePolicy: input.InstanceMaintenancePolicy,
 	}
 
 	if input.LaunchTemplate != nil {
@@ -145,12 +148,18 @@ func (m *MockAutoscaling) UpdateAutoScalingGroup(ctx context.Context, request *a
 	if request.DesiredCapacity != nil {
 		group.DesiredCapacity = request.DesiredCapacity
 	}
+	if request.DefaultInstanceWarmup != nil {
+		group.DefaultInstanceWarmup = request.DefaultInstanceWarmup
+	}
 	if request.HealthCheckGracePeriod != nil {
 		group.HealthCheckGracePeriod = request.HealthCheckGracePeriod
 	}
 	if request.HealthCheckType != nil {
 		group.HealthCheckType = request.HealthCheckType
 	}
+	if request.InstanceMaintenancePolicy != nil {
+		group.InstanceMaintenancePolicy = request.InstanceMaintenancePolicy
+	}
 	if request.LaunchConfigurationName != nil {
 		group.LaunchConfigurationName = request.LaunchConfigurationName
 	}
@@ -359,3 +368,62 @@ func (m *MockAutoscaling) DescribeLifecycleHooks(ctx context.Context, input *aut
 	}
 	return response, nil
 }
+
+func (m *MockAutoscaling) PutScalingPolicy(ctx context.Context, input *autoscaling.PutScalingPolicyInput, optFns ...func(*autoscaling.Options)) (*autoscaling.PutScalingPolicyOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.V(2).Infof("Mock PutScalingPolicy %v", input)
+
+	name := *input.AutoScalingGroupName + "::" + *input.PolicyName
+	arn := "arn:aws-test:autoscaling:us-test-1:123456789012:scalingPolicy:" + name
+	policy := &autoscalingtypes.ScalingPolicy{
+		AdjustmentType:              input.AdjustmentType,
+		AutoScalingGroupName:        input.AutoScalingGroupName,
+		EstimatedInstanceWarmup:     input.EstimatedInstanceWarmup,
+		PolicyARN:                   aws.String(arn),
+		PolicyName:                  input.PolicyName,
+		PolicyType:                  input.PolicyType,
+		StepAdjustments:             input.StepAdjustments,
+		TargetTrackingConfiguration: input.TargetTrackingConfiguration,
+	}
+
+	if m.ScalingPolicies == nil {
+		m.ScalingPolicies = make(map[string]*autoscalingtypes.ScalingPolicy)
+	}
+	m.ScalingPolicies[name] = policy
+
+	return &autoscaling.PutScalingPolicyOutput{PolicyARN: aws.String(arn)}, nil
+}
+
+func (m *MockAutoscaling) DescribePolicies(ctx context.Context, input *autoscaling.DescribePoliciesInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DescribePoliciesOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	response := &autoscaling.DescribePoliciesOutput{}
+	for _, policy := range m.ScalingPolicies {
+		if input.AutoScalingGroupName != nil && aws.ToString(policy.AutoScalingGroupName) != aws.ToString(input.AutoScalingGroupName) {
+			continue
+		}
+		if len(input.PolicyNames) != 0 && !slices.Contains(input.PolicyNames, aws.ToString(policy.PolicyName)) {
+			continue
+		}
+		response.ScalingPolicies = append(response.ScalingPolicies, *policy)
+	}
+	return response, nil
+}
+
+func (m *MockAutoscaling) DeletePolicy(ctx context.Context, input *autoscaling.DeletePolicyInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DeletePolicyOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.V(2).Infof("Mock DeletePolicy %v", input)
+
+	name := aws.ToString(input.AutoScalingGroupName) + "::" + aws.ToString(input.PolicyName)
+	if m.ScalingPolicies[name] == nil {
+		return nil, fmt.Errorf("ScalingPolicy %q not found", name)
+	}
+	delete(m.ScalingPolicies, name)
+
+	return &autoscaling.DeletePolicyOutput{}, nil
+}
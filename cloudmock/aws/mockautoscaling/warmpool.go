@@ -18,17 +18,20 @@ package mockautoscaling
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 )
 
 func (m *MockAutoscaling) DescribeWarmPool(ctx context.Context, input *autoscaling.DescribeWarmPoolInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DescribeWarmPoolOutput, error) {
-	instances, found := m.WarmPoolInstances[*input.AutoScalingGroupName]
-	if !found {
-		return &autoscaling.DescribeWarmPoolOutput{}, nil
-	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	ret := &autoscaling.DescribeWarmPoolOutput{
-		Instances: instances,
+		Instances:             m.WarmPoolInstances[*input.AutoScalingGroupName],
+		WarmPoolConfiguration: m.WarmPools[*input.AutoScalingGroupName],
 	}
 	return ret, nil
 }
@@ -36,3 +39,32 @@ func (m *MockAutoscaling) DescribeWarmPool(ctx context.Context, input *autoscali
 func (m *MockAutoscaling) DeleteWarmPool(ctx context.Context, input *autoscaling.DeleteWarmPoolInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DeleteWarmPoolOutput, error) {
 	return &autoscaling.DeleteWarmPoolOutput{}, nil
 }
+
+// PutWarmPool sets the configuration of the warm pool, and launches warmed instances until the warm pool reaches its minimum size.
+func (m *MockAutoscaling) PutWarmPool(ctx context.Context, input *autoscaling.PutWarmPoolInput, optFns ...func(*autoscaling.Options)) (*autoscaling.PutWarmPoolOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	name := aws.ToString(input.AutoScalingGroupName)
+	if m.WarmPools == nil {
+		m.WarmPools = make(map[string]*autoscalingtypes.WarmPoolConfiguration)
+	}
+	m.WarmPools[name] = &autoscalingtypes.WarmPoolConfiguration{
+		MaxGroupPreparedCapacity: input.MaxGroupPreparedCapacity,
+		MinSize:                  input.MinSize,
+		PoolState:                input.PoolState,
+		InstanceReusePolicy:      input.InstanceReusePolicy,
+	}
+
+	if m.WarmPoolInstances == nil {
+		m.WarmPoolInstances = make(map[string][]autoscalingtypes.Instance)
+	}
+	for i := len(m.WarmPoolInstances[name]); i < int(aws.ToInt32(input.MinSize)); i++ {
+		m.WarmPoolInstances[name] = append(m.WarmPoolInstances[name], autoscalingtypes.Instance{
+			InstanceId:     aws.String(fmt.Sprintf("%s-warm-%d", name, i)),
+			LifecycleState: autoscalingtypes.LifecycleStateWarmedStopped,
+		})
+	}
+
+	return &autoscaling.PutWarmPoolOutput{}, nil
+}
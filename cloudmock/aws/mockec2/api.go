@@ -48,6 +48,8 @@ type MockEC2 struct {
 
 	Volumes map[string]*ec2types.Volume
 
+	Snapshots map[string]*ec2types.Snapshot
+
 	KeyPairs map[string]*ec2types.KeyPairInfo
 
 	Tags []*ec2types.TagDescription
@@ -62,6 +64,12 @@ type MockEC2 struct {
 
 	NatGateways map[string]*ec2types.NatGateway
 
+	TransitGatewayVpcAttachments map[string]*ec2types.TransitGatewayVpcAttachment
+
+	VpcEndpoints map[string]*ec2types.VpcEndpoint
+
+	NetworkInterfaces map[string]*ec2types.NetworkInterface
+
 	idsMutex sync.Mutex
 	ids      map[string]*idAllocator
 }
@@ -92,6 +100,9 @@ func (m *MockEC2) All() map[string]interface{} {
 	for id, o := range m.Volumes {
 		all[id] = o
 	}
+	for id, o := range m.Snapshots {
+		all[id] = o
+	}
 	for id, o := range m.KeyPairs {
 		all[id] = o
 	}
@@ -110,6 +121,15 @@ func (m *MockEC2) All() map[string]interface{} {
 	for id, o := range m.NatGateways {
 		all[id] = o
 	}
+	for id, o := range m.TransitGatewayVpcAttachments {
+		all[id] = o
+	}
+	for id, o := range m.VpcEndpoints {
+		all[id] = o
+	}
+	for id, o := range m.NetworkInterfaces {
+		all[id] = o
+	}
 
 	return all
 }
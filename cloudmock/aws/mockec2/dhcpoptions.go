@@ -115,6 +115,10 @@ func (m *MockEC2) CreateDhcpOptions(ctx context.Context, request *ec2.CreateDhcp
 
 	n := len(m.DhcpOptions) + 1
 	id := fmt.Sprintf("dopt-%d", n)
+	for m.DhcpOptions[id] != nil {
+		n++
+		id = fmt.Sprintf("dopt-%d", n)
+	}
 
 	dhcpOptions := &ec2types.DhcpOptions{
 		DhcpOptionsId: s(id),
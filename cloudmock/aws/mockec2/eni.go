@@ -18,11 +18,180 @@ package mockec2
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/klog/v2"
 )
 
-func (m *MockEC2) DescribeNetworkInterfaces(ctx context.Context, params *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
-	output := &ec2.DescribeNetworkInterfacesOutput{}
-	return output, nil
+func (m *MockEC2) CreateNetworkInterface(ctx context.Context, request *ec2.CreateNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("CreateNetworkInterface: %v", request)
+
+	subnet := m.subnets[aws.ToString(request.SubnetId)]
+	if subnet == nil {
+		return nil, fmt.Errorf("subnet %q not found", aws.ToString(request.SubnetId))
+	}
+
+	id := m.allocateId("eni")
+	tags := tagSpecificationsToTags(request.TagSpecifications, ec2types.ResourceTypeNetworkInterface)
+
+	eni := &ec2types.NetworkInterface{
+		NetworkInterfaceId: s(id),
+		AvailabilityZone:   subnet.main.AvailabilityZone,
+		Description:        request.Description,
+		PrivateIpAddress:   request.PrivateIpAddress,
+		Status:             ec2types.NetworkInterfaceStatusAvailable,
+		SubnetId:           request.SubnetId,
+		VpcId:              subnet.main.VpcId,
+	}
+	if eni.PrivateIpAddress == nil {
+		eni.PrivateIpAddress = s(fmt.Sprintf("10.0.0.%d", len(m.NetworkInterfaces)+10))
+	}
+	for _, groupID := range request.Groups {
+		eni.Groups = append(eni.Groups, ec2types.GroupIdentifier{GroupId: s(groupID)})
+	}
+
+	if m.NetworkInterfaces == nil {
+		m.NetworkInterfaces = make(map[string]*ec2types.NetworkInterface)
+	}
+	m.NetworkInterfaces[id] = eni
+
+	m.addTags(id, tags...)
+
+	copy := *eni
+	copy.TagSet = tags
+	return &ec2.CreateNetworkInterfaceOutput{NetworkInterface: &copy}, nil
+}
+
+func (m *MockEC2) DescribeNetworkInterfaces(ctx context.Context, request *ec2.DescribeNetworkInterfacesInput, optFns ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeNetworkInterfaces: %v", request)
+
+	var enis []ec2types.NetworkInterface
+
+	for id, eni := range m.NetworkInterfaces {
+		if len(request.NetworkInterfaceIds) != 0 {
+			match := false
+			for _, v := range request.NetworkInterfaceIds {
+				if id == v {
+					match = true
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+
+		allFiltersMatch := true
+		for _, filter := range request.Filters {
+			match := false
+			switch *filter.Name {
+			case "vpc-id":
+				for _, v := range filter.Values {
+					if aws.ToString(eni.VpcId) == v {
+						match = true
+					}
+				}
+			case "status":
+				for _, v := range filter.Values {
+					if string(eni.Status) == v {
+						match = true
+					}
+				}
+			default:
+				if strings.HasPrefix(*filter.Name, "tag:") || *filter.Name == "tag-key" {
+					match = m.hasTag(ec2types.ResourceTypeNetworkInterface, id, filter)
+				} else {
+					return nil, fmt.Errorf("unknown filter name: %q", *filter.Name)
+				}
+			}
+
+			if !match {
+				allFiltersMatch = false
+				break
+			}
+		}
+
+		if !allFiltersMatch {
+			continue
+		}
+
+		copy := *eni
+		copy.TagSet = m.getTags(ec2types.ResourceTypeNetworkInterface, id)
+		enis = append(enis, copy)
+	}
+
+	return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: enis}, nil
+}
+
+func (m *MockEC2) ModifyNetworkInterfaceAttribute(ctx context.Context, request *ec2.ModifyNetworkInterfaceAttributeInput, optFns ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("ModifyNetworkInterfaceAttribute: %v", request)
+
+	id := aws.ToString(request.NetworkInterfaceId)
+	eni := m.NetworkInterfaces[id]
+	if eni == nil {
+		return nil, fmt.Errorf("NetworkInterface %q not found", id)
+	}
+
+	if request.Groups != nil {
+		eni.Groups = nil
+		for _, groupID := range request.Groups {
+			eni.Groups = append(eni.Groups, ec2types.GroupIdentifier{GroupId: s(groupID)})
+		}
+	}
+
+	return &ec2.ModifyNetworkInterfaceAttributeOutput{}, nil
+}
+
+func (m *MockEC2) AttachNetworkInterface(ctx context.Context, request *ec2.AttachNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("AttachNetworkInterface: %v", request)
+
+	id := aws.ToString(request.NetworkInterfaceId)
+	eni := m.NetworkInterfaces[id]
+	if eni == nil {
+		return nil, fmt.Errorf("NetworkInterface %q not found", id)
+	}
+	if eni.Attachment != nil {
+		return nil, fmt.Errorf("NetworkInterface %q is already attached", id)
+	}
+
+	attachmentID := m.allocateId("eni-attach")
+	eni.Attachment = &ec2types.NetworkInterfaceAttachment{
+		AttachmentId: s(attachmentID),
+		DeviceIndex:  request.DeviceIndex,
+		InstanceId:   request.InstanceId,
+		Status:       ec2types.AttachmentStatusAttached,
+	}
+	eni.Status = ec2types.NetworkInterfaceStatusInUse
+
+	return &ec2.AttachNetworkInterfaceOutput{AttachmentId: s(attachmentID)}, nil
+}
+
+func (m *MockEC2) DeleteNetworkInterface(ctx context.Context, request *ec2.DeleteNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteNetworkInterface: %v", request)
+
+	id := aws.ToString(request.NetworkInterfaceId)
+	if m.NetworkInterfaces[id] == nil {
+		return nil, fmt.Errorf("NetworkInterface %q not found", id)
+	}
+	delete(m.NetworkInterfaces, id)
+
+	return &ec2.DeleteNetworkInterfaceOutput{}, nil
 }
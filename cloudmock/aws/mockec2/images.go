@@ -68,6 +68,13 @@ func (m *MockEC2) imageMatchesFilter(image *ec2types.Image, filters []ec2types.F
 				}
 			}
 
+		case "image-id":
+			for _, v := range filter.Values {
+				if aws.ToString(image.ImageId) == v {
+					match = true
+				}
+			}
+
 		default:
 			if strings.HasPrefix(*filter.Name, "tag:") {
 				match = m.hasTag(ec2types.ResourceTypeImage, *image.ImageId, filter)
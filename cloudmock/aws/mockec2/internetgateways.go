@@ -150,6 +150,9 @@ func (m *MockEC2) AttachInternetGateway(ctx context.Context, request *ec2.Attach
 
 	for id, internetGateway := range m.InternetGateways {
 		if id == *request.InternetGatewayId {
+			if len(internetGateway.Attachments) != 0 {
+				return nil, fmt.Errorf("InternetGateway %q is already attached to a VPC", id)
+			}
 			internetGateway.Attachments = append(internetGateway.Attachments,
 				ec2types.InternetGatewayAttachment{
 					VpcId: request.VpcId,
@@ -170,7 +173,7 @@ func (m *MockEC2) DetachInternetGateway(ctx context.Context, request *ec2.Detach
 			found := false
 			var newAttachments []ec2types.InternetGatewayAttachment
 			for _, a := range igw.Attachments {
-				if a.VpcId == request.VpcId {
+				if aws.ToString(a.VpcId) == aws.ToString(request.VpcId) {
 					found = true
 					continue
 				}
@@ -19,6 +19,7 @@ package mockec2
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -31,6 +32,8 @@ type launchTemplateInfo struct {
 	data    *ec2types.ResponseLaunchTemplateData
 	name    *string
 	version int
+	// versionDescriptions holds the description of each version, if set
+	versionDescriptions map[int]string
 }
 
 // DescribeLaunchTemplates mocks the describing the launch templates
@@ -79,7 +82,8 @@ func (m *MockEC2) DescribeLaunchTemplates(ctx context.Context, request *ec2.Desc
 	return o, nil
 }
 
-// DescribeLaunchTemplateVersions mocks the retrieval of launch template versions - we don't use this at the moment so we can just return the template
+// DescribeLaunchTemplateVersions mocks the retrieval of launch template versions - only the description is kept for
+// earlier versions, so the current template data is returned for the latest version
 func (m *MockEC2) DescribeLaunchTemplateVersions(ctx context.Context, request *ec2.DescribeLaunchTemplateVersionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -93,15 +97,72 @@ func (m *MockEC2) DescribeLaunchTemplateVersions(ctx context.Context, request *e
 	}
 
 	for id, ltInfo := range m.LaunchTemplates {
-		if aws.ToString(ltInfo.name) != aws.ToString(request.LaunchTemplateName) {
+		if request.LaunchTemplateId != nil {
+			if id != aws.ToString(request.LaunchTemplateId) {
+				continue
+			}
+		} else if aws.ToString(ltInfo.name) != aws.ToString(request.LaunchTemplateName) {
 			continue
 		}
-		o.LaunchTemplateVersions = append(o.LaunchTemplateVersions, ec2types.LaunchTemplateVersion{
-			DefaultVersion:     aws.Bool(true),
-			LaunchTemplateId:   aws.String(id),
-			LaunchTemplateData: ltInfo.data,
-			LaunchTemplateName: request.LaunchTemplateName,
-		})
+
+		versions := request.Versions
+		if len(versions) == 0 {
+			// Without explicit versions, every version in the (optional) range is returned
+			minVersion, maxVersion := 1, ltInfo.version
+			if request.MinVersion != nil {
+				if n, err := strconv.Atoi(aws.ToString(request.MinVersion)); err == nil && n > minVersion {
+					minVersion = n
+				}
+			}
+			if request.MaxVersion != nil {
+				if n, err := strconv.Atoi(aws.ToString(request.MaxVersion)); err == nil && n < maxVersion {
+					maxVersion = n
+				}
+			}
+			for n := minVersion; n <= maxVersion; n++ {
+				versions = append(versions, strconv.Itoa(n))
+			}
+		}
+		for _, v := range versions {
+			version := ltInfo.version
+			if v != "$Latest" && v != "$Default" {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 1 || n > ltInfo.version {
+					continue
+				}
+				version = n
+			}
+			ltVersion := ec2types.LaunchTemplateVersion{
+				DefaultVersion:     aws.Bool(version == ltInfo.version),
+				LaunchTemplateId:   aws.String(id),
+				LaunchTemplateName: ltInfo.name,
+				VersionNumber:      aws.Int64(int64(version)),
+				VersionDescription: aws.String(ltInfo.versionDescriptions[version]),
+			}
+			if version == ltInfo.version {
+				ltVersion.LaunchTemplateData = ltInfo.data
+			}
+			o.LaunchTemplateVersions = append(o.LaunchTemplateVersions, ltVersion)
+		}
+	}
+
+	// Results are paged when MaxResults is set, with the token holding the offset of the next page
+	if request.MaxResults != nil {
+		offset := 0
+		if request.NextToken != nil {
+			n, err := strconv.Atoi(aws.ToString(request.NextToken))
+			if err != nil || n < 0 || n > len(o.LaunchTemplateVersions) {
+				return nil, fmt.Errorf("invalid NextToken %q", aws.ToString(request.NextToken))
+			}
+			offset = n
+		}
+		end := offset + int(aws.ToInt32(request.MaxResults))
+		if end < len(o.LaunchTemplateVersions) {
+			o.NextToken = aws.String(strconv.Itoa(end))
+		} else {
+			end = len(o.LaunchTemplateVersions)
+		}
+		o.LaunchTemplateVersions = o.LaunchTemplateVersions[offset:end]
 	}
 	return o, nil
 }
@@ -153,6 +214,12 @@ func (m *MockEC2) CreateLaunchTemplateVersion(ctx context.Context, request *ec2.
 			ltInfo.data = responseLaunchTemplateData(request.LaunchTemplateData)
 			ltInfo.version++
 			ltVersion = ltInfo.version
+			if request.VersionDescription != nil {
+				if ltInfo.versionDescriptions == nil {
+					ltInfo.versionDescriptions = make(map[int]string)
+				}
+				ltInfo.versionDescriptions[ltVersion] = aws.ToString(request.VersionDescription)
+			}
 			ltID = id
 		}
 	}
@@ -216,10 +283,20 @@ func responseLaunchTemplateData(req *ec2types.RequestLaunchTemplateData) *ec2typ
 	}
 	if req.CpuOptions != nil {
 		resp.CpuOptions = &ec2types.LaunchTemplateCpuOptions{
+			AmdSevSnp:      req.CpuOptions.AmdSevSnp,
 			CoreCount:      req.CpuOptions.CoreCount,
 			ThreadsPerCore: req.CpuOptions.ThreadsPerCore,
 		}
 	}
+	if req.Placement != nil {
+		resp.Placement = &ec2types.LaunchTemplatePlacement{
+			HostResourceGroupArn: req.Placement.HostResourceGroupArn,
+			Tenancy:              req.Placement.Tenancy,
+		}
+	}
+	if req.EnclaveOptions != nil {
+		resp.EnclaveOptions = &ec2types.LaunchTemplateEnclaveOptions{Enabled: req.EnclaveOptions.Enabled}
+	}
 	if len(req.BlockDeviceMappings) > 0 {
 		for _, x := range req.BlockDeviceMappings {
 			var ebs *ec2types.LaunchTemplateEbsBlockDevice
@@ -272,7 +349,9 @@ func responseLaunchTemplateData(req *ec2types.RequestLaunchTemplateData) *ec2typ
 				Description:                    x.Description,
 				DeviceIndex:                    x.DeviceIndex,
 				Groups:                         x.Groups,
+				InterfaceType:                  x.InterfaceType,
 				Ipv6AddressCount:               x.Ipv6AddressCount,
+				NetworkCardIndex:               x.NetworkCardIndex,
 				NetworkInterfaceId:             x.NetworkInterfaceId,
 				PrivateIpAddress:               x.PrivateIpAddress,
 				PrivateIpAddresses:             x.PrivateIpAddresses,
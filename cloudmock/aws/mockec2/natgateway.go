@@ -34,9 +34,13 @@ func (m *MockEC2) CreateNatGatewayWithId(request *ec2.CreateNatGatewayInput, id
 	tags := tagSpecificationsToTags(request.TagSpecifications, ec2types.ResourceTypeNatgateway)
 
 	ngw := &ec2types.NatGateway{
-		NatGatewayId: s(id),
-		SubnetId:     request.SubnetId,
-		Tags:         tags,
+		NatGatewayId:     s(id),
+		SubnetId:         request.SubnetId,
+		ConnectivityType: request.ConnectivityType,
+		Tags:             tags,
+	}
+	if ngw.ConnectivityType == "" {
+		ngw.ConnectivityType = ec2types.ConnectivityTypePublic
 	}
 
 	if request.AllocationId != nil {
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockec2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/klog/v2"
+)
+
+func (m *MockEC2) CreateSnapshot(ctx context.Context, request *ec2.CreateSnapshotInput, optFns ...func(*ec2.Options)) (*ec2.CreateSnapshotOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("CreateSnapshot: %v", request)
+
+	if request.DryRun != nil {
+		klog.Fatalf("DryRun")
+	}
+
+	volume := m.Volumes[aws.ToString(request.VolumeId)]
+	if volume == nil {
+		return nil, fmt.Errorf("Volume %q not found", aws.ToString(request.VolumeId))
+	}
+
+	n := len(m.Snapshots) + 1
+	id := fmt.Sprintf("snap-%d", n)
+
+	// Snapshots complete immediately
+	snapshot := &ec2types.Snapshot{
+		SnapshotId:  s(id),
+		Description: request.Description,
+		Encrypted:   volume.Encrypted,
+		KmsKeyId:    volume.KmsKeyId,
+		State:       ec2types.SnapshotStateCompleted,
+		VolumeId:    volume.VolumeId,
+		VolumeSize:  volume.Size,
+	}
+
+	if m.Snapshots == nil {
+		m.Snapshots = make(map[string]*ec2types.Snapshot)
+	}
+	m.Snapshots[id] = snapshot
+
+	m.addTags(id, tagSpecificationsToTags(request.TagSpecifications, ec2types.ResourceTypeSnapshot)...)
+
+	copy := *snapshot
+	copy.Tags = m.getTags(ec2types.ResourceTypeSnapshot, id)
+	return &ec2.CreateSnapshotOutput{
+		SnapshotId:  copy.SnapshotId,
+		Description: copy.Description,
+		Encrypted:   copy.Encrypted,
+		KmsKeyId:    copy.KmsKeyId,
+		State:       copy.State,
+		Tags:        copy.Tags,
+		VolumeId:    copy.VolumeId,
+		VolumeSize:  copy.VolumeSize,
+	}, nil
+}
+
+func (m *MockEC2) DescribeSnapshots(ctx context.Context, request *ec2.DescribeSnapshotsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSnapshotsOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeSnapshots: %v", request)
+
+	if len(request.Filters) != 0 {
+		klog.Fatalf("Filters")
+	}
+
+	var snapshots []ec2types.Snapshot
+	for id, snapshot := range m.Snapshots {
+		if len(request.SnapshotIds) != 0 && !slices.Contains(request.SnapshotIds, id) {
+			continue
+		}
+
+		copy := *snapshot
+		copy.Tags = m.getTags(ec2types.ResourceTypeSnapshot, id)
+		snapshots = append(snapshots, copy)
+	}
+
+	return &ec2.DescribeSnapshotsOutput{
+		Snapshots: snapshots,
+	}, nil
+}
+
+func (m *MockEC2) DeleteSnapshot(ctx context.Context, request *ec2.DeleteSnapshotInput, optFns ...func(*ec2.Options)) (*ec2.DeleteSnapshotOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteSnapshot: %v", request)
+
+	id := aws.ToString(request.SnapshotId)
+	if m.Snapshots[id] == nil {
+		return nil, fmt.Errorf("Snapshot %q not found", id)
+	}
+	delete(m.Snapshots, id)
+
+	return &ec2.DeleteSnapshotOutput{}, nil
+}
@@ -41,6 +41,46 @@ func (m *MockEC2) CreateTags(ctx context.Context, request *ec2.CreateTagsInput,
 	return response, nil
 }
 
+func (m *MockEC2) DeleteTags(ctx context.Context, request *ec2.DeleteTagsInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteTags %v", request)
+
+	resources := make(map[string]bool)
+	for _, v := range request.Resources {
+		resources[v] = true
+	}
+
+	var tags []*ec2types.TagDescription
+	for _, tag := range m.Tags {
+		if resources[aws.ToString(tag.ResourceId)] && tagsMatch(request.Tags, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	m.Tags = tags
+
+	return &ec2.DeleteTagsOutput{}, nil
+}
+
+// tagsMatch returns true if the tag is selected by the list of tags of a DeleteTags request;
+// an empty list selects all tags, and a tag without a value selects all values.
+func tagsMatch(selectors []ec2types.Tag, tag *ec2types.TagDescription) bool {
+	if len(selectors) == 0 {
+		return true
+	}
+	for _, selector := range selectors {
+		if aws.ToString(selector.Key) != aws.ToString(tag.Key) {
+			continue
+		}
+		if selector.Value == nil || aws.ToString(selector.Value) == aws.ToString(tag.Value) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *MockEC2) addTags(resourceId string, tags ...ec2types.Tag) {
 	var resourceType ec2types.ResourceType
 	if strings.HasPrefix(resourceId, "subnet-") {
@@ -51,6 +91,8 @@ func (m *MockEC2) addTags(resourceId string, tags ...ec2types.Tag) {
 		resourceType = ec2types.ResourceTypeSecurityGroup
 	} else if strings.HasPrefix(resourceId, "vol-") {
 		resourceType = ec2types.ResourceTypeVolume
+	} else if strings.HasPrefix(resourceId, "snap-") {
+		resourceType = ec2types.ResourceTypeSnapshot
 	} else if strings.HasPrefix(resourceId, "igw-") {
 		resourceType = ec2types.ResourceTypeInternetGateway
 	} else if strings.HasPrefix(resourceId, "eigw-") {
@@ -67,10 +109,26 @@ func (m *MockEC2) addTags(resourceId string, tags ...ec2types.Tag) {
 		resourceType = ec2types.ResourceTypeLaunchTemplate
 	} else if strings.HasPrefix(resourceId, "key-") {
 		resourceType = ec2types.ResourceTypeKeyPair
+	} else if strings.HasPrefix(resourceId, "eni-") {
+		resourceType = ec2types.ResourceTypeNetworkInterface
+	} else if strings.HasPrefix(resourceId, "tgw-attach-") {
+		resourceType = ec2types.ResourceTypeTransitGatewayAttachment
+	} else if strings.HasPrefix(resourceId, "vpce-") {
+		resourceType = ec2types.ResourceTypeVpcEndpoint
 	} else {
 		klog.Fatalf("Unknown resource-type in create tags: %v", resourceId)
 	}
 	for _, tag := range tags {
+		replaced := false
+		for _, existing := range m.Tags {
+			if aws.ToString(existing.ResourceId) == resourceId && aws.ToString(existing.Key) == aws.ToString(tag.Key) {
+				existing.Value = tag.Value
+				replaced = true
+			}
+		}
+		if replaced {
+			continue
+		}
 		t := &ec2types.TagDescription{
 			Key:          tag.Key,
 			Value:        tag.Value,
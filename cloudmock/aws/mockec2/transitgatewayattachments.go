@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockec2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/klog/v2"
+)
+
+func (m *MockEC2) CreateTransitGatewayVpcAttachment(ctx context.Context, request *ec2.CreateTransitGatewayVpcAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.CreateTransitGatewayVpcAttachmentOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("CreateTransitGatewayVpcAttachment: %v", request)
+
+	if request.TransitGatewayId == nil {
+		return nil, fmt.Errorf("TransitGatewayId is required")
+	}
+	if request.VpcId == nil {
+		return nil, fmt.Errorf("VpcId is required")
+	}
+	if len(request.SubnetIds) == 0 {
+		return nil, fmt.Errorf("SubnetIds is required")
+	}
+
+	id := m.allocateId("tgw-attach")
+	tags := tagSpecificationsToTags(request.TagSpecifications, ec2types.ResourceTypeTransitGatewayAttachment)
+
+	attachment := &ec2types.TransitGatewayVpcAttachment{
+		TransitGatewayAttachmentId: s(id),
+		TransitGatewayId:           request.TransitGatewayId,
+		VpcId:                      request.VpcId,
+		SubnetIds:                  append([]string(nil), request.SubnetIds...),
+		State:                      ec2types.TransitGatewayAttachmentStateAvailable,
+	}
+
+	if m.TransitGatewayVpcAttachments == nil {
+		m.TransitGatewayVpcAttachments = make(map[string]*ec2types.TransitGatewayVpcAttachment)
+	}
+	m.TransitGatewayVpcAttachments[id] = attachment
+
+	m.addTags(id, tags...)
+
+	copy := *attachment
+	copy.Tags = m.getTags(ec2types.ResourceTypeTransitGatewayAttachment, id)
+	return &ec2.CreateTransitGatewayVpcAttachmentOutput{TransitGatewayVpcAttachment: &copy}, nil
+}
+
+func (m *MockEC2) DescribeTransitGatewayVpcAttachments(ctx context.Context, request *ec2.DescribeTransitGatewayVpcAttachmentsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeTransitGatewayVpcAttachmentsOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeTransitGatewayVpcAttachments: %v", request)
+
+	var attachments []ec2types.TransitGatewayVpcAttachment
+
+	for id, attachment := range m.TransitGatewayVpcAttachments {
+		if len(request.TransitGatewayAttachmentIds) != 0 {
+			found := false
+			for _, requestID := range request.TransitGatewayAttachmentIds {
+				if requestID == id {
+					found = true
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+
+		allFiltersMatch := true
+		for _, filter := range request.Filters {
+			match := false
+			switch aws.ToString(filter.Name) {
+			case "transit-gateway-attachment-id":
+				for _, v := range filter.Values {
+					if id == v {
+						match = true
+					}
+				}
+			case "transit-gateway-id":
+				for _, v := range filter.Values {
+					if aws.ToString(attachment.TransitGatewayId) == v {
+						match = true
+					}
+				}
+			case "vpc-id":
+				for _, v := range filter.Values {
+					if aws.ToString(attachment.VpcId) == v {
+						match = true
+					}
+				}
+			case "state":
+				for _, v := range filter.Values {
+					if string(attachment.State) == v {
+						match = true
+					}
+				}
+
+			default:
+				if strings.HasPrefix(aws.ToString(filter.Name), "tag:") {
+					match = m.hasTag(ec2types.ResourceTypeTransitGatewayAttachment, id, filter)
+				} else {
+					return nil, fmt.Errorf("unknown filter name: %q", aws.ToString(filter.Name))
+				}
+			}
+
+			if !match {
+				allFiltersMatch = false
+				break
+			}
+		}
+
+		if !allFiltersMatch {
+			continue
+		}
+
+		copy := *attachment
+		copy.SubnetIds = append([]string(nil), attachment.SubnetIds...)
+		copy.Tags = m.getTags(ec2types.ResourceTypeTransitGatewayAttachment, id)
+		attachments = append(attachments, copy)
+	}
+
+	return &ec2.DescribeTransitGatewayVpcAttachmentsOutput{TransitGatewayVpcAttachments: attachments}, nil
+}
+
+func (m *MockEC2) ModifyTransitGatewayVpcAttachment(ctx context.Context, request *ec2.ModifyTransitGatewayVpcAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.ModifyTransitGatewayVpcAttachmentOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("ModifyTransitGatewayVpcAttachment: %v", request)
+
+	id := aws.ToString(request.TransitGatewayAttachmentId)
+	attachment := m.TransitGatewayVpcAttachments[id]
+	if attachment == nil {
+		return nil, fmt.Errorf("TransitGatewayAttachment %q not found", id)
+	}
+
+	var subnetIDs []string
+	for _, subnetID := range attachment.SubnetIds {
+		removed := false
+		for _, removeID := range request.RemoveSubnetIds {
+			if subnetID == removeID {
+				removed = true
+			}
+		}
+		if !removed {
+			subnetIDs = append(subnetIDs, subnetID)
+		}
+	}
+	subnetIDs = append(subnetIDs, request.AddSubnetIds...)
+	attachment.SubnetIds = subnetIDs
+
+	copy := *attachment
+	copy.Tags = m.getTags(ec2types.ResourceTypeTransitGatewayAttachment, id)
+	return &ec2.ModifyTransitGatewayVpcAttachmentOutput{TransitGatewayVpcAttachment: &copy}, nil
+}
+
+func (m *MockEC2) DeleteTransitGatewayVpcAttachment(ctx context.Context, request *ec2.DeleteTransitGatewayVpcAttachmentInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTransitGatewayVpcAttachmentOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteTransitGatewayVpcAttachment: %v", request)
+
+	id := aws.ToString(request.TransitGatewayAttachmentId)
+	attachment := m.TransitGatewayVpcAttachments[id]
+	if attachment == nil {
+		return nil, fmt.Errorf("TransitGatewayAttachment %q not found", id)
+	}
+	delete(m.TransitGatewayVpcAttachments, id)
+
+	return &ec2.DeleteTransitGatewayVpcAttachmentOutput{TransitGatewayVpcAttachment: attachment}, nil
+}
@@ -19,6 +19,7 @@ package mockec2
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -48,6 +49,7 @@ func (m *MockEC2) CreateVolume(ctx context.Context, request *ec2.CreateVolumeInp
 		KmsKeyId:         request.KmsKeyId,
 		Size:             request.Size,
 		SnapshotId:       request.SnapshotId,
+		State:            ec2types.VolumeStateAvailable,
 		Throughput:       request.Throughput,
 		VolumeType:       request.VolumeType,
 	}
@@ -82,19 +84,19 @@ func (m *MockEC2) DescribeVolumes(ctx context.Context, request *ec2.DescribeVolu
 
 	klog.Infof("DescribeVolumes: %v", request)
 
-	if request.VolumeIds != nil {
-		klog.Fatalf("VolumeIds")
-	}
-
 	var volumes []ec2types.Volume
 
 	for _, volume := range m.Volumes {
+		if len(request.VolumeIds) != 0 && !slices.Contains(request.VolumeIds, aws.ToString(volume.VolumeId)) {
+			continue
+		}
+
 		allFiltersMatch := true
 		for _, filter := range request.Filters {
 			match := false
 			switch *filter.Name {
 			default:
-				if strings.HasPrefix(*filter.Name, "tag:") {
+				if strings.HasPrefix(*filter.Name, "tag:") || *filter.Name == "tag-key" {
 					match = m.hasTag(ec2types.ResourceTypeVolume, *volume.VolumeId, filter)
 				} else {
 					return nil, fmt.Errorf("unknown filter name: %q", *filter.Name)
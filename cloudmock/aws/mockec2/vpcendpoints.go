@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockec2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/klog/v2"
+)
+
+func (m *MockEC2) CreateVpcEndpoint(ctx context.Context, request *ec2.CreateVpcEndpointInput, optFns ...func(*ec2.Options)) (*ec2.CreateVpcEndpointOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("CreateVpcEndpoint: %v", request)
+
+	if request.VpcId == nil {
+		return nil, fmt.Errorf("VpcId is required")
+	}
+	if request.ServiceName == nil {
+		return nil, fmt.Errorf("ServiceName is required")
+	}
+
+	id := m.allocateId("vpce")
+	tags := tagSpecificationsToTags(request.TagSpecifications, ec2types.ResourceTypeVpcEndpoint)
+
+	endpoint := &ec2types.VpcEndpoint{
+		VpcEndpointId:     s(id),
+		VpcId:             request.VpcId,
+		ServiceName:       request.ServiceName,
+		VpcEndpointType:   request.VpcEndpointType,
+		RouteTableIds:     append([]string(nil), request.RouteTableIds...),
+		SubnetIds:         append([]string(nil), request.SubnetIds...),
+		PrivateDnsEnabled: aws.Bool(aws.ToBool(request.PrivateDnsEnabled)),
+		State:             ec2types.StateAvailable,
+	}
+	if endpoint.VpcEndpointType == "" {
+		endpoint.VpcEndpointType = ec2types.VpcEndpointTypeGateway
+	}
+	for _, groupID := range request.SecurityGroupIds {
+		endpoint.Groups = append(endpoint.Groups, ec2types.SecurityGroupIdentifier{GroupId: aws.String(groupID)})
+	}
+
+	if m.VpcEndpoints == nil {
+		m.VpcEndpoints = make(map[string]*ec2types.VpcEndpoint)
+	}
+	m.VpcEndpoints[id] = endpoint
+
+	m.addTags(id, tags...)
+
+	copy := *endpoint
+	copy.Tags = m.getTags(ec2types.ResourceTypeVpcEndpoint, id)
+	return &ec2.CreateVpcEndpointOutput{VpcEndpoint: &copy}, nil
+}
+
+func (m *MockEC2) DescribeVpcEndpoints(ctx context.Context, request *ec2.DescribeVpcEndpointsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeVpcEndpointsOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DescribeVpcEndpoints: %v", request)
+
+	var endpoints []ec2types.VpcEndpoint
+
+	for id, endpoint := range m.VpcEndpoints {
+		if len(request.VpcEndpointIds) != 0 {
+			found := false
+			for _, requestID := range request.VpcEndpointIds {
+				if requestID == id {
+					found = true
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+
+		allFiltersMatch := true
+		for _, filter := range request.Filters {
+			match := false
+			switch aws.ToString(filter.Name) {
+			case "vpc-endpoint-id":
+				for _, v := range filter.Values {
+					if id == v {
+						match = true
+					}
+				}
+			case "vpc-id":
+				for _, v := range filter.Values {
+					if aws.ToString(endpoint.VpcId) == v {
+						match = true
+					}
+				}
+			case "service-name":
+				for _, v := range filter.Values {
+					if aws.ToString(endpoint.ServiceName) == v {
+						match = true
+					}
+				}
+			case "vpc-endpoint-type":
+				for _, v := range filter.Values {
+					if string(endpoint.VpcEndpointType) == v {
+						match = true
+					}
+				}
+
+			default:
+				if strings.HasPrefix(aws.ToString(filter.Name), "tag:") {
+					match = m.hasTag(ec2types.ResourceTypeVpcEndpoint, id, filter)
+				} else {
+					return nil, fmt.Errorf("unknown filter name: %q", aws.ToString(filter.Name))
+				}
+			}
+
+			if !match {
+				allFiltersMatch = false
+				break
+			}
+		}
+
+		if !allFiltersMatch {
+			continue
+		}
+
+		copy := *endpoint
+		copy.RouteTableIds = append([]string(nil), endpoint.RouteTableIds...)
+		copy.SubnetIds = append([]string(nil), endpoint.SubnetIds...)
+		copy.Groups = append([]ec2types.SecurityGroupIdentifier(nil), endpoint.Groups...)
+		copy.Tags = m.getTags(ec2types.ResourceTypeVpcEndpoint, id)
+		endpoints = append(endpoints, copy)
+	}
+
+	return &ec2.DescribeVpcEndpointsOutput{VpcEndpoints: endpoints}, nil
+}
+
+func (m *MockEC2) ModifyVpcEndpoint(ctx context.Context, request *ec2.ModifyVpcEndpointInput, optFns ...func(*ec2.Options)) (*ec2.ModifyVpcEndpointOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("ModifyVpcEndpoint: %v", request)
+
+	id := aws.ToString(request.VpcEndpointId)
+	endpoint := m.VpcEndpoints[id]
+	if endpoint == nil {
+		return nil, fmt.Errorf("VpcEndpoint %q not found", id)
+	}
+
+	endpoint.RouteTableIds = modifyIDs(endpoint.RouteTableIds, request.AddRouteTableIds, request.RemoveRouteTableIds)
+	endpoint.SubnetIds = modifyIDs(endpoint.SubnetIds, request.AddSubnetIds, request.RemoveSubnetIds)
+
+	var groupIDs []string
+	for _, group := range endpoint.Groups {
+		groupIDs = append(groupIDs, aws.ToString(group.GroupId))
+	}
+	endpoint.Groups = nil
+	for _, groupID := range modifyIDs(groupIDs, request.AddSecurityGroupIds, request.RemoveSecurityGroupIds) {
+		endpoint.Groups = append(endpoint.Groups, ec2types.SecurityGroupIdentifier{GroupId: aws.String(groupID)})
+	}
+
+	if request.PrivateDnsEnabled != nil {
+		endpoint.PrivateDnsEnabled = request.PrivateDnsEnabled
+	}
+
+	return &ec2.ModifyVpcEndpointOutput{Return: aws.Bool(true)}, nil
+}
+
+// modifyIDs removes the remove IDs from ids and appends the add IDs.
+func modifyIDs(ids, add, remove []string) []string {
+	var result []string
+	for _, id := range ids {
+		removed := false
+		for _, removeID := range remove {
+			if id == removeID {
+				removed = true
+			}
+		}
+		if !removed {
+			result = append(result, id)
+		}
+	}
+	return append(result, add...)
+}
+
+func (m *MockEC2) DeleteVpcEndpoints(ctx context.Context, request *ec2.DeleteVpcEndpointsInput, optFns ...func(*ec2.Options)) (*ec2.DeleteVpcEndpointsOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("DeleteVpcEndpoints: %v", request)
+
+	for _, id := range request.VpcEndpointIds {
+		if m.VpcEndpoints[id] == nil {
+			return nil, fmt.Errorf("VpcEndpoint %q not found", id)
+		}
+		delete(m.VpcEndpoints, id)
+	}
+
+	return &ec2.DeleteVpcEndpointsOutput{}, nil
+}
@@ -64,6 +64,7 @@ func (m *MockELBV2) CreateListener(ctx context.Context, request *elbv2.CreateLis
 		Certificates:    request.Certificates,
 		Protocol:        request.Protocol,
 		SslPolicy:       request.SslPolicy,
+		AlpnPolicy:      request.AlpnPolicy,
 	}
 
 	lbARN := aws.ToString(request.LoadBalancerArn)
@@ -112,9 +113,28 @@ func (m *MockELBV2) DeleteListener(ctx context.Context, request *elbv2.DeleteLis
 	return nil, nil
 }
 
-func (m *MockELBV2) ModifyListener(request *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
+func (m *MockELBV2) ModifyListener(ctx context.Context, request *elbv2.ModifyListenerInput, optFns ...func(*elbv2.Options)) (*elbv2.ModifyListenerOutput, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	klog.Fatalf("elbv2.ModifyListener() not implemented")
-	return nil, nil
+
+	klog.Infof("ModifyListener v2 %v", request)
+
+	lARN := aws.ToString(request.ListenerArn)
+	l, ok := m.Listeners[lARN]
+	if !ok {
+		return nil, fmt.Errorf("Listener not found %v", lARN)
+	}
+	if request.Certificates != nil {
+		l.description.Certificates = request.Certificates
+	}
+	if request.Protocol != "" {
+		l.description.Protocol = request.Protocol
+	}
+	if request.SslPolicy != nil {
+		l.description.SslPolicy = request.SslPolicy
+	}
+	if request.AlpnPolicy != nil {
+		l.description.AlpnPolicy = request.AlpnPolicy
+	}
+	return &elbv2.ModifyListenerOutput{Listeners: []elbv2types.Listener{l.description}}, nil
 }
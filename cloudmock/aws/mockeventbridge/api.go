@@ -90,6 +90,28 @@ func (m *MockEventBridge) ListTagsForResource(ctx context.Context, input *eventb
 	return response, nil
 }
 
+func (m *MockEventBridge) TagResource(ctx context.Context, input *eventbridge.TagResourceInput, optFns ...func(*eventbridge.Options)) (*eventbridge.TagResourceOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	tags := m.TagsByArn[*input.ResourceARN]
+	for _, tag := range input.Tags {
+		replaced := false
+		for i := range tags {
+			if *tags[i].Key == *tag.Key {
+				tags[i].Value = tag.Value
+				replaced = true
+			}
+		}
+		if !replaced {
+			tags = append(tags, tag)
+		}
+	}
+	m.TagsByArn[*input.ResourceARN] = tags
+
+	return &eventbridge.TagResourceOutput{}, nil
+}
+
 func (m *MockEventBridge) PutTargets(ctx context.Context, input *eventbridge.PutTargetsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutTargetsOutput, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
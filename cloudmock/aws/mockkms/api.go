@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockkms
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+type MockKMS struct {
+	awsinterfaces.KMSAPI
+	mutex sync.Mutex
+
+	Keys map[string]*MockKey
+}
+
+// MockKey is a KMS key, found by its ID, its ARN or one of its aliases.
+type MockKey struct {
+	Metadata kmstypes.KeyMetadata
+	Aliases  []string
+	Policy   string
+	Grants   []kmstypes.GrantListEntry
+}
+
+var _ awsinterfaces.KMSAPI = &MockKMS{}
+
+// AddKey adds a customer managed key with the ARN and the policy.
+func (m *MockKMS) AddKey(keyARN string, policy string, aliases ...string) *MockKey {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.Keys == nil {
+		m.Keys = make(map[string]*MockKey)
+	}
+	keyID := keyARN[strings.LastIndex(keyARN, "/")+1:]
+	account := ""
+	if tokens := strings.Split(keyARN, ":"); len(tokens) > 4 {
+		account = tokens[4]
+	}
+	key := &MockKey{
+		Metadata: kmstypes.KeyMetadata{
+			AWSAccountId: aws.String(account),
+			Arn:          aws.String(keyARN),
+			KeyId:        aws.String(keyID),
+			KeyManager:   kmstypes.KeyManagerTypeCustomer,
+			KeyState:     kmstypes.KeyStateEnabled,
+		},
+		Aliases: aliases,
+		Policy:  policy,
+	}
+	m.Keys[keyID] = key
+	return key
+}
+
+func (m *MockKMS) findKey(id string) *MockKey {
+	for _, key := range m.Keys {
+		if id == aws.ToString(key.Metadata.KeyId) || id == aws.ToString(key.Metadata.Arn) {
+			return key
+		}
+		for _, alias := range key.Aliases {
+			if id == alias || strings.HasSuffix(id, ":"+alias) {
+				return key
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockKMS) DescribeKey(ctx context.Context, request *kms.DescribeKeyInput, optFns ...func(*kms.Options)) (*kms.DescribeKeyOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := m.findKey(aws.ToString(request.KeyId))
+	if key == nil {
+		return nil, &kmstypes.NotFoundException{Message: aws.String("key not found")}
+	}
+	metadata := key.Metadata
+	return &kms.DescribeKeyOutput{KeyMetadata: &metadata}, nil
+}
+
+func (m *MockKMS) GetKeyPolicy(ctx context.Context, request *kms.GetKeyPolicyInput, optFns ...func(*kms.Options)) (*kms.GetKeyPolicyOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := m.findKey(aws.ToString(request.KeyId))
+	if key == nil || strings.HasPrefix(aws.ToString(request.KeyId), "alias/") {
+		return nil, &kmstypes.NotFoundException{Message: aws.String("key not found")}
+	}
+	return &kms.GetKeyPolicyOutput{
+		Policy:     aws.String(key.Policy),
+		PolicyName: aws.String("default"),
+	}, nil
+}
+
+func (m *MockKMS) ListGrants(ctx context.Context, request *kms.ListGrantsInput, optFns ...func(*kms.Options)) (*kms.ListGrantsOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	key := m.findKey(aws.ToString(request.KeyId))
+	if key == nil {
+		return nil, &kmstypes.NotFoundException{Message: aws.String("key not found")}
+	}
+
+	// The grants are returned in pages of Limit grants, 50 by default, with the index of the next grant as marker
+	start := 0
+	if request.Marker != nil {
+		var err error
+		start, err = strconv.Atoi(aws.ToString(request.Marker))
+		if err != nil || start < 0 || start > len(key.Grants) {
+			return nil, &kmstypes.InvalidMarkerException{Message: aws.String("invalid marker")}
+		}
+	}
+	limit := 50
+	if request.Limit != nil {
+		limit = int(*request.Limit)
+	}
+	end := min(start+limit, len(key.Grants))
+
+	response := &kms.ListGrantsOutput{Grants: key.Grants[start:end]}
+	if end < len(key.Grants) {
+		response.NextMarker = aws.String(strconv.Itoa(end))
+		response.Truncated = true
+	}
+	return response, nil
+}
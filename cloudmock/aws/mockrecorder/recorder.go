@@ -0,0 +1,377 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockrecorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"sigs.k8s.io/yaml"
+)
+
+// redactedFields are input fields whose value differs between runs, such as user data embedding generated keys.
+var redactedFields = map[string]bool{
+	"UserData": true,
+}
+
+const redacted = "<redacted>"
+
+// Call is an API call that changes state.
+type Call struct {
+	Service   string      `json:"service"`
+	Operation string      `json:"operation"`
+	Input     interface{} `json:"input,omitempty"`
+}
+
+type recordedCall struct {
+	Call
+
+	output interface{}
+}
+
+// Recorder records the API calls that change state made to a mock AWS cloud.
+type Recorder struct {
+	mutex sync.Mutex
+	calls []*recordedCall
+}
+
+// Install replaces the APIs of the mock cloud with ones recording the calls made to them.
+func (r *Recorder) Install(cloud *awsup.MockAWSCloud) {
+	if cloud.MockACM != nil {
+		cloud.MockACM = &recordingACM{ACMAPI: cloud.MockACM, recorder: r}
+	}
+	if cloud.MockAutoscaling != nil {
+		cloud.MockAutoscaling = &recordingAutoscaling{AutoScalingAPI: cloud.MockAutoscaling, recorder: r}
+	}
+	if cloud.MockEC2 != nil {
+		cloud.MockEC2 = &recordingEC2{EC2API: cloud.MockEC2, recorder: r}
+	}
+	if cloud.MockELB != nil {
+		cloud.MockELB = &recordingELB{ELBAPI: cloud.MockELB, recorder: r}
+	}
+	if cloud.MockELBV2 != nil {
+		cloud.MockELBV2 = &recordingELBV2{ELBV2API: cloud.MockELBV2, recorder: r}
+	}
+	if cloud.MockEventBridge != nil {
+		cloud.MockEventBridge = &recordingEventBridge{EventBridgeAPI: cloud.MockEventBridge, recorder: r}
+	}
+	if cloud.MockIAM != nil {
+		cloud.MockIAM = &recordingIAM{IAMAPI: cloud.MockIAM, recorder: r}
+	}
+	if cloud.MockRoute53 != nil {
+		cloud.MockRoute53 = &recordingRoute53{Route53API: cloud.MockRoute53, recorder: r}
+	}
+	if cloud.MockSQS != nil {
+		cloud.MockSQS = &recordingSQS{SQSAPI: cloud.MockSQS, recorder: r}
+	}
+	if cloud.MockSSM != nil {
+		cloud.MockSSM = &recordingSSM{SSMAPI: cloud.MockSSM, recorder: r}
+	}
+}
+
+// record makes the call, and records it if it succeeds.
+func record[I, O any](r *Recorder, service, operation string, input *I, call func() (*O, error)) (*O, error) {
+	output, err := call()
+	if err == nil {
+		r.mutex.Lock()
+		r.calls = append(r.calls, &recordedCall{
+			Call:   Call{Service: service, Operation: operation, Input: input},
+			output: output,
+		})
+		r.mutex.Unlock()
+	}
+	return output, err
+}
+
+// Calls returns the recorded calls in a canonical form, which does not depend on the order the calls were made in.
+// Calls are sorted, and the IDs allocated by the mocks are renumbered in that order.
+func (r *Recorder) Calls() ([]*Call, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	type canonicalCall struct {
+		Call
+		created []string
+		sortKey string
+	}
+
+	var calls []*canonicalCall
+	for _, c := range r.calls {
+		input, err := toGeneric(c.Input)
+		if err != nil {
+			return nil, fmt.Errorf("error converting input of %s %s: %w", c.Service, c.Operation, err)
+		}
+		output, err := toGeneric(c.output)
+		if err != nil {
+			return nil, fmt.Errorf("error converting output of %s %s: %w", c.Service, c.Operation, err)
+		}
+		input = sortTags(redact(input))
+
+		// Allocated IDs are values in the output that were not part of the input, and end with a counter
+		inputValues := map[string]bool{}
+		walkStrings(input, func(s string) { inputValues[s] = true })
+		var created []string
+		walkStrings(output, func(s string) {
+			if !inputValues[s] && endsWithDigit(s) {
+				created = append(created, s)
+			}
+		})
+
+		calls = append(calls, &canonicalCall{
+			Call:    Call{Service: c.Service, Operation: c.Operation, Input: input},
+			created: created,
+		})
+	}
+
+	// Only values referenced by other calls are treated as IDs, so that unrelated output values are left alone
+	referenced := map[string]bool{}
+	for _, c := range calls {
+		walkStrings(c.Input, func(s string) { referenced[s] = true })
+	}
+	isID := map[string]bool{}
+	for _, c := range calls {
+		var ids []string
+		for _, s := range c.created {
+			if referenced[s] && !isID[s] {
+				isID[s] = true
+				ids = append(ids, s)
+			}
+		}
+		c.created = ids
+	}
+
+	for _, c := range calls {
+		masked := replaceStrings(c.Input, func(s string) string {
+			if isID[s] {
+				return strings.TrimRight(s, "0123456789")
+			}
+			return s
+		})
+		b, err := json.Marshal(masked)
+		if err != nil {
+			return nil, err
+		}
+		c.sortKey = string(b)
+	}
+	sort.SliceStable(calls, func(i, j int) bool {
+		if calls[i].Service != calls[j].Service {
+			return calls[i].Service < calls[j].Service
+		}
+		if calls[i].Operation != calls[j].Operation {
+			return calls[i].Operation < calls[j].Operation
+		}
+		return calls[i].sortKey < calls[j].sortKey
+	})
+
+	renamed := map[string]string{}
+	counters := map[string]int{}
+	for _, c := range calls {
+		for _, id := range c.created {
+			prefix := strings.TrimRight(id, "0123456789")
+			counters[prefix]++
+			renamed[id] = prefix + strconv.Itoa(counters[prefix])
+		}
+	}
+
+	var result []*Call
+	for _, c := range calls {
+		input := replaceStrings(c.Input, func(s string) string {
+			if r, found := renamed[s]; found {
+				return r
+			}
+			return s
+		})
+		result = append(result, &Call{Service: c.Service, Operation: c.Operation, Input: input})
+	}
+	return result, nil
+}
+
+// MarshalCalls renders the calls as YAML.
+func MarshalCalls(calls []*Call) ([]byte, error) {
+	if len(calls) == 0 {
+		return []byte("[]\n"), nil
+	}
+	return yaml.Marshal(calls)
+}
+
+// UnmarshalCalls parses calls rendered by MarshalCalls.
+func UnmarshalCalls(data []byte) ([]*Call, error) {
+	var calls []*Call
+	if err := yaml.Unmarshal(data, &calls); err != nil {
+		return nil, fmt.Errorf("error parsing recorded calls: %w", err)
+	}
+	return calls, nil
+}
+
+// toGeneric converts the value to its JSON representation, made of maps, slices and scalars.
+func toGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return removeEmpty(generic), nil
+}
+
+// removeEmpty removes null values and empty collections, which the SDK types render for unset fields.
+func removeEmpty(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			value = removeEmpty(value)
+			if isEmpty(value) {
+				delete(v, k)
+			} else {
+				v[k] = value
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = removeEmpty(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if redactedFields[k] {
+				v[k] = redacted
+			} else {
+				v[k] = redact(value)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// sortTags sorts lists of tags by key, as tags are usually built from maps and their order does not matter.
+func sortTags(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			v[k] = sortTags(value)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = sortTags(v[i])
+		}
+		if isTagList(v) {
+			sort.SliceStable(v, func(i, j int) bool {
+				return v[i].(map[string]interface{})["Key"].(string) < v[j].(map[string]interface{})["Key"].(string)
+			})
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isTagList returns true if every item of the list is a tag, with a string key.
+func isTagList(list []interface{}) bool {
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m["Key"].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// walkStrings calls fn for every string in the value, visiting map keys in sorted order.
+func walkStrings(v interface{}, fn func(s string)) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			walkStrings(v[k], fn)
+		}
+	case []interface{}:
+		for _, value := range v {
+			walkStrings(value, fn)
+		}
+	case string:
+		fn(v)
+	}
+}
+
+// replaceStrings returns a copy of the value, with every string replaced by the result of fn.
+func replaceStrings(v interface{}, fn func(s string) string) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		replaced := make(map[string]interface{}, len(v))
+		for k, value := range v {
+			replaced[k] = replaceStrings(value, fn)
+		}
+		return replaced
+	case []interface{}:
+		replaced := make([]interface{}, len(v))
+		for i, value := range v {
+			replaced[i] = replaceStrings(value, fn)
+		}
+		return replaced
+	case string:
+		return fn(v)
+	default:
+		return v
+	}
+}
+
+func endsWithDigit(s string) bool {
+	return s != "" && s[len(s)-1] >= '0' && s[len(s)-1] <= '9'
+}
@@ -0,0 +1,967 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockrecorder
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+// recordingACM records the ACM API calls that change state.
+type recordingACM struct {
+	awsinterfaces.ACMAPI
+
+	recorder *Recorder
+}
+
+func (m *recordingACM) AddTagsToCertificate(ctx context.Context, params *acm.AddTagsToCertificateInput, optFns ...func(*acm.Options)) (*acm.AddTagsToCertificateOutput, error) {
+	return record(m.recorder, "ACM", "AddTagsToCertificate", params, func() (*acm.AddTagsToCertificateOutput, error) {
+		return m.ACMAPI.AddTagsToCertificate(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingACM) DeleteCertificate(ctx context.Context, params *acm.DeleteCertificateInput, optFns ...func(*acm.Options)) (*acm.DeleteCertificateOutput, error) {
+	return record(m.recorder, "ACM", "DeleteCertificate", params, func() (*acm.DeleteCertificateOutput, error) {
+		return m.ACMAPI.DeleteCertificate(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingACM) RequestCertificate(ctx context.Context, params *acm.RequestCertificateInput, optFns ...func(*acm.Options)) (*acm.RequestCertificateOutput, error) {
+	return record(m.recorder, "ACM", "RequestCertificate", params, func() (*acm.RequestCertificateOutput, error) {
+		return m.ACMAPI.RequestCertificate(ctx, params, optFns...)
+	})
+}
+
+// recordingAutoscaling records the Autoscaling API calls that change state.
+type recordingAutoscaling struct {
+	awsinterfaces.AutoScalingAPI
+
+	recorder *Recorder
+}
+
+func (m *recordingAutoscaling) AttachInstances(ctx context.Context, params *autoscaling.AttachInstancesInput, optFns ...func(*autoscaling.Options)) (*autoscaling.AttachInstancesOutput, error) {
+	return record(m.recorder, "Autoscaling", "AttachInstances", params, func() (*autoscaling.AttachInstancesOutput, error) {
+		return m.AutoScalingAPI.AttachInstances(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) AttachLoadBalancers(ctx context.Context, params *autoscaling.AttachLoadBalancersInput, optFns ...func(*autoscaling.Options)) (*autoscaling.AttachLoadBalancersOutput, error) {
+	return record(m.recorder, "Autoscaling", "AttachLoadBalancers", params, func() (*autoscaling.AttachLoadBalancersOutput, error) {
+		return m.AutoScalingAPI.AttachLoadBalancers(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) AttachLoadBalancerTargetGroups(ctx context.Context, params *autoscaling.AttachLoadBalancerTargetGroupsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.AttachLoadBalancerTargetGroupsOutput, error) {
+	return record(m.recorder, "Autoscaling", "AttachLoadBalancerTargetGroups", params, func() (*autoscaling.AttachLoadBalancerTargetGroupsOutput, error) {
+		return m.AutoScalingAPI.AttachLoadBalancerTargetGroups(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) CompleteLifecycleAction(ctx context.Context, params *autoscaling.CompleteLifecycleActionInput, optFns ...func(*autoscaling.Options)) (*autoscaling.CompleteLifecycleActionOutput, error) {
+	return record(m.recorder, "Autoscaling", "CompleteLifecycleAction", params, func() (*autoscaling.CompleteLifecycleActionOutput, error) {
+		return m.AutoScalingAPI.CompleteLifecycleAction(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) CreateAutoScalingGroup(ctx context.Context, params *autoscaling.CreateAutoScalingGroupInput, optFns ...func(*autoscaling.Options)) (*autoscaling.CreateAutoScalingGroupOutput, error) {
+	return record(m.recorder, "Autoscaling", "CreateAutoScalingGroup", params, func() (*autoscaling.CreateAutoScalingGroupOutput, error) {
+		return m.AutoScalingAPI.CreateAutoScalingGroup(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) CreateOrUpdateTags(ctx context.Context, params *autoscaling.CreateOrUpdateTagsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.CreateOrUpdateTagsOutput, error) {
+	return record(m.recorder, "Autoscaling", "CreateOrUpdateTags", params, func() (*autoscaling.CreateOrUpdateTagsOutput, error) {
+		return m.AutoScalingAPI.CreateOrUpdateTags(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DeleteAutoScalingGroup(ctx context.Context, params *autoscaling.DeleteAutoScalingGroupInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DeleteAutoScalingGroupOutput, error) {
+	return record(m.recorder, "Autoscaling", "DeleteAutoScalingGroup", params, func() (*autoscaling.DeleteAutoScalingGroupOutput, error) {
+		return m.AutoScalingAPI.DeleteAutoScalingGroup(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DeleteLaunchConfiguration(ctx context.Context, params *autoscaling.DeleteLaunchConfigurationInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DeleteLaunchConfigurationOutput, error) {
+	return record(m.recorder, "Autoscaling", "DeleteLaunchConfiguration", params, func() (*autoscaling.DeleteLaunchConfigurationOutput, error) {
+		return m.AutoScalingAPI.DeleteLaunchConfiguration(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DeleteLifecycleHook(ctx context.Context, params *autoscaling.DeleteLifecycleHookInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DeleteLifecycleHookOutput, error) {
+	return record(m.recorder, "Autoscaling", "DeleteLifecycleHook", params, func() (*autoscaling.DeleteLifecycleHookOutput, error) {
+		return m.AutoScalingAPI.DeleteLifecycleHook(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DeletePolicy(ctx context.Context, params *autoscaling.DeletePolicyInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DeletePolicyOutput, error) {
+	return record(m.recorder, "Autoscaling", "DeletePolicy", params, func() (*autoscaling.DeletePolicyOutput, error) {
+		return m.AutoScalingAPI.DeletePolicy(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DeleteTags(ctx context.Context, params *autoscaling.DeleteTagsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DeleteTagsOutput, error) {
+	return record(m.recorder, "Autoscaling", "DeleteTags", params, func() (*autoscaling.DeleteTagsOutput, error) {
+		return m.AutoScalingAPI.DeleteTags(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DeleteWarmPool(ctx context.Context, params *autoscaling.DeleteWarmPoolInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DeleteWarmPoolOutput, error) {
+	return record(m.recorder, "Autoscaling", "DeleteWarmPool", params, func() (*autoscaling.DeleteWarmPoolOutput, error) {
+		return m.AutoScalingAPI.DeleteWarmPool(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DetachInstances(ctx context.Context, params *autoscaling.DetachInstancesInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DetachInstancesOutput, error) {
+	return record(m.recorder, "Autoscaling", "DetachInstances", params, func() (*autoscaling.DetachInstancesOutput, error) {
+		return m.AutoScalingAPI.DetachInstances(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DetachLoadBalancers(ctx context.Context, params *autoscaling.DetachLoadBalancersInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DetachLoadBalancersOutput, error) {
+	return record(m.recorder, "Autoscaling", "DetachLoadBalancers", params, func() (*autoscaling.DetachLoadBalancersOutput, error) {
+		return m.AutoScalingAPI.DetachLoadBalancers(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) DetachLoadBalancerTargetGroups(ctx context.Context, params *autoscaling.DetachLoadBalancerTargetGroupsInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DetachLoadBalancerTargetGroupsOutput, error) {
+	return record(m.recorder, "Autoscaling", "DetachLoadBalancerTargetGroups", params, func() (*autoscaling.DetachLoadBalancerTargetGroupsOutput, error) {
+		return m.AutoScalingAPI.DetachLoadBalancerTargetGroups(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) EnableMetricsCollection(ctx context.Context, params *autoscaling.EnableMetricsCollectionInput, optFns ...func(*autoscaling.Options)) (*autoscaling.EnableMetricsCollectionOutput, error) {
+	return record(m.recorder, "Autoscaling", "EnableMetricsCollection", params, func() (*autoscaling.EnableMetricsCollectionOutput, error) {
+		return m.AutoScalingAPI.EnableMetricsCollection(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) PutLifecycleHook(ctx context.Context, params *autoscaling.PutLifecycleHookInput, optFns ...func(*autoscaling.Options)) (*autoscaling.PutLifecycleHookOutput, error) {
+	return record(m.recorder, "Autoscaling", "PutLifecycleHook", params, func() (*autoscaling.PutLifecycleHookOutput, error) {
+		return m.AutoScalingAPI.PutLifecycleHook(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) PutScalingPolicy(ctx context.Context, params *autoscaling.PutScalingPolicyInput, optFns ...func(*autoscaling.Options)) (*autoscaling.PutScalingPolicyOutput, error) {
+	return record(m.recorder, "Autoscaling", "PutScalingPolicy", params, func() (*autoscaling.PutScalingPolicyOutput, error) {
+		return m.AutoScalingAPI.PutScalingPolicy(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) PutWarmPool(ctx context.Context, params *autoscaling.PutWarmPoolInput, optFns ...func(*autoscaling.Options)) (*autoscaling.PutWarmPoolOutput, error) {
+	return record(m.recorder, "Autoscaling", "PutWarmPool", params, func() (*autoscaling.PutWarmPoolOutput, error) {
+		return m.AutoScalingAPI.PutWarmPool(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) ResumeProcesses(ctx context.Context, params *autoscaling.ResumeProcessesInput, optFns ...func(*autoscaling.Options)) (*autoscaling.ResumeProcessesOutput, error) {
+	return record(m.recorder, "Autoscaling", "ResumeProcesses", params, func() (*autoscaling.ResumeProcessesOutput, error) {
+		return m.AutoScalingAPI.ResumeProcesses(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) SuspendProcesses(ctx context.Context, params *autoscaling.SuspendProcessesInput, optFns ...func(*autoscaling.Options)) (*autoscaling.SuspendProcessesOutput, error) {
+	return record(m.recorder, "Autoscaling", "SuspendProcesses", params, func() (*autoscaling.SuspendProcessesOutput, error) {
+		return m.AutoScalingAPI.SuspendProcesses(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) TerminateInstanceInAutoScalingGroup(ctx context.Context, params *autoscaling.TerminateInstanceInAutoScalingGroupInput, optFns ...func(*autoscaling.Options)) (*autoscaling.TerminateInstanceInAutoScalingGroupOutput, error) {
+	return record(m.recorder, "Autoscaling", "TerminateInstanceInAutoScalingGroup", params, func() (*autoscaling.TerminateInstanceInAutoScalingGroupOutput, error) {
+		return m.AutoScalingAPI.TerminateInstanceInAutoScalingGroup(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingAutoscaling) UpdateAutoScalingGroup(ctx context.Context, params *autoscaling.UpdateAutoScalingGroupInput, optFns ...func(*autoscaling.Options)) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+	return record(m.recorder, "Autoscaling", "UpdateAutoScalingGroup", params, func() (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+		return m.AutoScalingAPI.UpdateAutoScalingGroup(ctx, params, optFns...)
+	})
+}
+
+// recordingEC2 records the EC2 API calls that change state.
+type recordingEC2 struct {
+	awsinterfaces.EC2API
+
+	recorder *Recorder
+}
+
+func (m *recordingEC2) AllocateAddress(ctx context.Context, params *ec2.AllocateAddressInput, optFns ...func(*ec2.Options)) (*ec2.AllocateAddressOutput, error) {
+	return record(m.recorder, "EC2", "AllocateAddress", params, func() (*ec2.AllocateAddressOutput, error) {
+		return m.EC2API.AllocateAddress(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AssignIpv6Addresses(ctx context.Context, params *ec2.AssignIpv6AddressesInput, optFns ...func(*ec2.Options)) (*ec2.AssignIpv6AddressesOutput, error) {
+	return record(m.recorder, "EC2", "AssignIpv6Addresses", params, func() (*ec2.AssignIpv6AddressesOutput, error) {
+		return m.EC2API.AssignIpv6Addresses(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AssociateDhcpOptions(ctx context.Context, params *ec2.AssociateDhcpOptionsInput, optFns ...func(*ec2.Options)) (*ec2.AssociateDhcpOptionsOutput, error) {
+	return record(m.recorder, "EC2", "AssociateDhcpOptions", params, func() (*ec2.AssociateDhcpOptionsOutput, error) {
+		return m.EC2API.AssociateDhcpOptions(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AssociateRouteTable(ctx context.Context, params *ec2.AssociateRouteTableInput, optFns ...func(*ec2.Options)) (*ec2.AssociateRouteTableOutput, error) {
+	return record(m.recorder, "EC2", "AssociateRouteTable", params, func() (*ec2.AssociateRouteTableOutput, error) {
+		return m.EC2API.AssociateRouteTable(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AssociateSubnetCidrBlock(ctx context.Context, params *ec2.AssociateSubnetCidrBlockInput, optFns ...func(*ec2.Options)) (*ec2.AssociateSubnetCidrBlockOutput, error) {
+	return record(m.recorder, "EC2", "AssociateSubnetCidrBlock", params, func() (*ec2.AssociateSubnetCidrBlockOutput, error) {
+		return m.EC2API.AssociateSubnetCidrBlock(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AssociateVpcCidrBlock(ctx context.Context, params *ec2.AssociateVpcCidrBlockInput, optFns ...func(*ec2.Options)) (*ec2.AssociateVpcCidrBlockOutput, error) {
+	return record(m.recorder, "EC2", "AssociateVpcCidrBlock", params, func() (*ec2.AssociateVpcCidrBlockOutput, error) {
+		return m.EC2API.AssociateVpcCidrBlock(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AttachInternetGateway(ctx context.Context, params *ec2.AttachInternetGatewayInput, optFns ...func(*ec2.Options)) (*ec2.AttachInternetGatewayOutput, error) {
+	return record(m.recorder, "EC2", "AttachInternetGateway", params, func() (*ec2.AttachInternetGatewayOutput, error) {
+		return m.EC2API.AttachInternetGateway(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AttachNetworkInterface(ctx context.Context, params *ec2.AttachNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	return record(m.recorder, "EC2", "AttachNetworkInterface", params, func() (*ec2.AttachNetworkInterfaceOutput, error) {
+		return m.EC2API.AttachNetworkInterface(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AuthorizeSecurityGroupEgress(ctx context.Context, params *ec2.AuthorizeSecurityGroupEgressInput, optFns ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	return record(m.recorder, "EC2", "AuthorizeSecurityGroupEgress", params, func() (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+		return m.EC2API.AuthorizeSecurityGroupEgress(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) AuthorizeSecurityGroupIngress(ctx context.Context, params *ec2.AuthorizeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	return record(m.recorder, "EC2", "AuthorizeSecurityGroupIngress", params, func() (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+		return m.EC2API.AuthorizeSecurityGroupIngress(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateDhcpOptions(ctx context.Context, params *ec2.CreateDhcpOptionsInput, optFns ...func(*ec2.Options)) (*ec2.CreateDhcpOptionsOutput, error) {
+	return record(m.recorder, "EC2", "CreateDhcpOptions", params, func() (*ec2.CreateDhcpOptionsOutput, error) {
+		return m.EC2API.CreateDhcpOptions(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateEgressOnlyInternetGateway(ctx context.Context, params *ec2.CreateEgressOnlyInternetGatewayInput, optFns ...func(*ec2.Options)) (*ec2.CreateEgressOnlyInternetGatewayOutput, error) {
+	return record(m.recorder, "EC2", "CreateEgressOnlyInternetGateway", params, func() (*ec2.CreateEgressOnlyInternetGatewayOutput, error) {
+		return m.EC2API.CreateEgressOnlyInternetGateway(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateInternetGateway(ctx context.Context, params *ec2.CreateInternetGatewayInput, optFns ...func(*ec2.Options)) (*ec2.CreateInternetGatewayOutput, error) {
+	return record(m.recorder, "EC2", "CreateInternetGateway", params, func() (*ec2.CreateInternetGatewayOutput, error) {
+		return m.EC2API.CreateInternetGateway(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateLaunchTemplate(ctx context.Context, params *ec2.CreateLaunchTemplateInput, optFns ...func(*ec2.Options)) (*ec2.CreateLaunchTemplateOutput, error) {
+	return record(m.recorder, "EC2", "CreateLaunchTemplate", params, func() (*ec2.CreateLaunchTemplateOutput, error) {
+		return m.EC2API.CreateLaunchTemplate(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateLaunchTemplateVersion(ctx context.Context, params *ec2.CreateLaunchTemplateVersionInput, optFns ...func(*ec2.Options)) (*ec2.CreateLaunchTemplateVersionOutput, error) {
+	return record(m.recorder, "EC2", "CreateLaunchTemplateVersion", params, func() (*ec2.CreateLaunchTemplateVersionOutput, error) {
+		return m.EC2API.CreateLaunchTemplateVersion(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateNatGateway(ctx context.Context, params *ec2.CreateNatGatewayInput, optFns ...func(*ec2.Options)) (*ec2.CreateNatGatewayOutput, error) {
+	return record(m.recorder, "EC2", "CreateNatGateway", params, func() (*ec2.CreateNatGatewayOutput, error) {
+		return m.EC2API.CreateNatGateway(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateNetworkInterface(ctx context.Context, params *ec2.CreateNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+	return record(m.recorder, "EC2", "CreateNetworkInterface", params, func() (*ec2.CreateNetworkInterfaceOutput, error) {
+		return m.EC2API.CreateNetworkInterface(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateRoute(ctx context.Context, params *ec2.CreateRouteInput, optFns ...func(*ec2.Options)) (*ec2.CreateRouteOutput, error) {
+	return record(m.recorder, "EC2", "CreateRoute", params, func() (*ec2.CreateRouteOutput, error) {
+		return m.EC2API.CreateRoute(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateRouteTable(ctx context.Context, params *ec2.CreateRouteTableInput, optFns ...func(*ec2.Options)) (*ec2.CreateRouteTableOutput, error) {
+	return record(m.recorder, "EC2", "CreateRouteTable", params, func() (*ec2.CreateRouteTableOutput, error) {
+		return m.EC2API.CreateRouteTable(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateSecurityGroup(ctx context.Context, params *ec2.CreateSecurityGroupInput, optFns ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	return record(m.recorder, "EC2", "CreateSecurityGroup", params, func() (*ec2.CreateSecurityGroupOutput, error) {
+		return m.EC2API.CreateSecurityGroup(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateSubnet(ctx context.Context, params *ec2.CreateSubnetInput, optFns ...func(*ec2.Options)) (*ec2.CreateSubnetOutput, error) {
+	return record(m.recorder, "EC2", "CreateSubnet", params, func() (*ec2.CreateSubnetOutput, error) {
+		return m.EC2API.CreateSubnet(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateTags(ctx context.Context, params *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	return record(m.recorder, "EC2", "CreateTags", params, func() (*ec2.CreateTagsOutput, error) {
+		return m.EC2API.CreateTags(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateVolume(ctx context.Context, params *ec2.CreateVolumeInput, optFns ...func(*ec2.Options)) (*ec2.CreateVolumeOutput, error) {
+	return record(m.recorder, "EC2", "CreateVolume", params, func() (*ec2.CreateVolumeOutput, error) {
+		return m.EC2API.CreateVolume(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) CreateVpc(ctx context.Context, params *ec2.CreateVpcInput, optFns ...func(*ec2.Options)) (*ec2.CreateVpcOutput, error) {
+	return record(m.recorder, "EC2", "CreateVpc", params, func() (*ec2.CreateVpcOutput, error) {
+		return m.EC2API.CreateVpc(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteDhcpOptions(ctx context.Context, params *ec2.DeleteDhcpOptionsInput, optFns ...func(*ec2.Options)) (*ec2.DeleteDhcpOptionsOutput, error) {
+	return record(m.recorder, "EC2", "DeleteDhcpOptions", params, func() (*ec2.DeleteDhcpOptionsOutput, error) {
+		return m.EC2API.DeleteDhcpOptions(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteEgressOnlyInternetGateway(ctx context.Context, params *ec2.DeleteEgressOnlyInternetGatewayInput, optFns ...func(*ec2.Options)) (*ec2.DeleteEgressOnlyInternetGatewayOutput, error) {
+	return record(m.recorder, "EC2", "DeleteEgressOnlyInternetGateway", params, func() (*ec2.DeleteEgressOnlyInternetGatewayOutput, error) {
+		return m.EC2API.DeleteEgressOnlyInternetGateway(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteInternetGateway(ctx context.Context, params *ec2.DeleteInternetGatewayInput, optFns ...func(*ec2.Options)) (*ec2.DeleteInternetGatewayOutput, error) {
+	return record(m.recorder, "EC2", "DeleteInternetGateway", params, func() (*ec2.DeleteInternetGatewayOutput, error) {
+		return m.EC2API.DeleteInternetGateway(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteKeyPair(ctx context.Context, params *ec2.DeleteKeyPairInput, optFns ...func(*ec2.Options)) (*ec2.DeleteKeyPairOutput, error) {
+	return record(m.recorder, "EC2", "DeleteKeyPair", params, func() (*ec2.DeleteKeyPairOutput, error) {
+		return m.EC2API.DeleteKeyPair(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteLaunchTemplate(ctx context.Context, params *ec2.DeleteLaunchTemplateInput, optFns ...func(*ec2.Options)) (*ec2.DeleteLaunchTemplateOutput, error) {
+	return record(m.recorder, "EC2", "DeleteLaunchTemplate", params, func() (*ec2.DeleteLaunchTemplateOutput, error) {
+		return m.EC2API.DeleteLaunchTemplate(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteNatGateway(ctx context.Context, params *ec2.DeleteNatGatewayInput, optFns ...func(*ec2.Options)) (*ec2.DeleteNatGatewayOutput, error) {
+	return record(m.recorder, "EC2", "DeleteNatGateway", params, func() (*ec2.DeleteNatGatewayOutput, error) {
+		return m.EC2API.DeleteNatGateway(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteNetworkInterface(ctx context.Context, params *ec2.DeleteNetworkInterfaceInput, optFns ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	return record(m.recorder, "EC2", "DeleteNetworkInterface", params, func() (*ec2.DeleteNetworkInterfaceOutput, error) {
+		return m.EC2API.DeleteNetworkInterface(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteRouteTable(ctx context.Context, params *ec2.DeleteRouteTableInput, optFns ...func(*ec2.Options)) (*ec2.DeleteRouteTableOutput, error) {
+	return record(m.recorder, "EC2", "DeleteRouteTable", params, func() (*ec2.DeleteRouteTableOutput, error) {
+		return m.EC2API.DeleteRouteTable(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteSecurityGroup(ctx context.Context, params *ec2.DeleteSecurityGroupInput, optFns ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	return record(m.recorder, "EC2", "DeleteSecurityGroup", params, func() (*ec2.DeleteSecurityGroupOutput, error) {
+		return m.EC2API.DeleteSecurityGroup(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteSubnet(ctx context.Context, params *ec2.DeleteSubnetInput, optFns ...func(*ec2.Options)) (*ec2.DeleteSubnetOutput, error) {
+	return record(m.recorder, "EC2", "DeleteSubnet", params, func() (*ec2.DeleteSubnetOutput, error) {
+		return m.EC2API.DeleteSubnet(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteTags(ctx context.Context, params *ec2.DeleteTagsInput, optFns ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	return record(m.recorder, "EC2", "DeleteTags", params, func() (*ec2.DeleteTagsOutput, error) {
+		return m.EC2API.DeleteTags(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteVolume(ctx context.Context, params *ec2.DeleteVolumeInput, optFns ...func(*ec2.Options)) (*ec2.DeleteVolumeOutput, error) {
+	return record(m.recorder, "EC2", "DeleteVolume", params, func() (*ec2.DeleteVolumeOutput, error) {
+		return m.EC2API.DeleteVolume(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DeleteVpc(ctx context.Context, params *ec2.DeleteVpcInput, optFns ...func(*ec2.Options)) (*ec2.DeleteVpcOutput, error) {
+	return record(m.recorder, "EC2", "DeleteVpc", params, func() (*ec2.DeleteVpcOutput, error) {
+		return m.EC2API.DeleteVpc(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DetachInternetGateway(ctx context.Context, params *ec2.DetachInternetGatewayInput, optFns ...func(*ec2.Options)) (*ec2.DetachInternetGatewayOutput, error) {
+	return record(m.recorder, "EC2", "DetachInternetGateway", params, func() (*ec2.DetachInternetGatewayOutput, error) {
+		return m.EC2API.DetachInternetGateway(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DisassociateRouteTable(ctx context.Context, params *ec2.DisassociateRouteTableInput, optFns ...func(*ec2.Options)) (*ec2.DisassociateRouteTableOutput, error) {
+	return record(m.recorder, "EC2", "DisassociateRouteTable", params, func() (*ec2.DisassociateRouteTableOutput, error) {
+		return m.EC2API.DisassociateRouteTable(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DisassociateSubnetCidrBlock(ctx context.Context, params *ec2.DisassociateSubnetCidrBlockInput, optFns ...func(*ec2.Options)) (*ec2.DisassociateSubnetCidrBlockOutput, error) {
+	return record(m.recorder, "EC2", "DisassociateSubnetCidrBlock", params, func() (*ec2.DisassociateSubnetCidrBlockOutput, error) {
+		return m.EC2API.DisassociateSubnetCidrBlock(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) DisassociateVpcCidrBlock(ctx context.Context, params *ec2.DisassociateVpcCidrBlockInput, optFns ...func(*ec2.Options)) (*ec2.DisassociateVpcCidrBlockOutput, error) {
+	return record(m.recorder, "EC2", "DisassociateVpcCidrBlock", params, func() (*ec2.DisassociateVpcCidrBlockOutput, error) {
+		return m.EC2API.DisassociateVpcCidrBlock(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ImportKeyPair(ctx context.Context, params *ec2.ImportKeyPairInput, optFns ...func(*ec2.Options)) (*ec2.ImportKeyPairOutput, error) {
+	return record(m.recorder, "EC2", "ImportKeyPair", params, func() (*ec2.ImportKeyPairOutput, error) {
+		return m.EC2API.ImportKeyPair(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ModifyInstanceAttribute(ctx context.Context, params *ec2.ModifyInstanceAttributeInput, optFns ...func(*ec2.Options)) (*ec2.ModifyInstanceAttributeOutput, error) {
+	return record(m.recorder, "EC2", "ModifyInstanceAttribute", params, func() (*ec2.ModifyInstanceAttributeOutput, error) {
+		return m.EC2API.ModifyInstanceAttribute(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ModifyLaunchTemplate(ctx context.Context, params *ec2.ModifyLaunchTemplateInput, optFns ...func(*ec2.Options)) (*ec2.ModifyLaunchTemplateOutput, error) {
+	return record(m.recorder, "EC2", "ModifyLaunchTemplate", params, func() (*ec2.ModifyLaunchTemplateOutput, error) {
+		return m.EC2API.ModifyLaunchTemplate(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ModifyNetworkInterfaceAttribute(ctx context.Context, params *ec2.ModifyNetworkInterfaceAttributeInput, optFns ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	return record(m.recorder, "EC2", "ModifyNetworkInterfaceAttribute", params, func() (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+		return m.EC2API.ModifyNetworkInterfaceAttribute(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ModifySubnetAttribute(ctx context.Context, params *ec2.ModifySubnetAttributeInput, optFns ...func(*ec2.Options)) (*ec2.ModifySubnetAttributeOutput, error) {
+	return record(m.recorder, "EC2", "ModifySubnetAttribute", params, func() (*ec2.ModifySubnetAttributeOutput, error) {
+		return m.EC2API.ModifySubnetAttribute(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ModifyVolume(ctx context.Context, params *ec2.ModifyVolumeInput, optFns ...func(*ec2.Options)) (*ec2.ModifyVolumeOutput, error) {
+	return record(m.recorder, "EC2", "ModifyVolume", params, func() (*ec2.ModifyVolumeOutput, error) {
+		return m.EC2API.ModifyVolume(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ModifyVpcAttribute(ctx context.Context, params *ec2.ModifyVpcAttributeInput, optFns ...func(*ec2.Options)) (*ec2.ModifyVpcAttributeOutput, error) {
+	return record(m.recorder, "EC2", "ModifyVpcAttribute", params, func() (*ec2.ModifyVpcAttributeOutput, error) {
+		return m.EC2API.ModifyVpcAttribute(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ReleaseAddress(ctx context.Context, params *ec2.ReleaseAddressInput, optFns ...func(*ec2.Options)) (*ec2.ReleaseAddressOutput, error) {
+	return record(m.recorder, "EC2", "ReleaseAddress", params, func() (*ec2.ReleaseAddressOutput, error) {
+		return m.EC2API.ReleaseAddress(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) ReplaceRoute(ctx context.Context, params *ec2.ReplaceRouteInput, optFns ...func(*ec2.Options)) (*ec2.ReplaceRouteOutput, error) {
+	return record(m.recorder, "EC2", "ReplaceRoute", params, func() (*ec2.ReplaceRouteOutput, error) {
+		return m.EC2API.ReplaceRoute(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) RevokeSecurityGroupIngress(ctx context.Context, params *ec2.RevokeSecurityGroupIngressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return record(m.recorder, "EC2", "RevokeSecurityGroupIngress", params, func() (*ec2.RevokeSecurityGroupIngressOutput, error) {
+		return m.EC2API.RevokeSecurityGroupIngress(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) RevokeSecurityGroupEgress(ctx context.Context, params *ec2.RevokeSecurityGroupEgressInput, optFns ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	return record(m.recorder, "EC2", "RevokeSecurityGroupEgress", params, func() (*ec2.RevokeSecurityGroupEgressOutput, error) {
+		return m.EC2API.RevokeSecurityGroupEgress(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) RunInstances(ctx context.Context, params *ec2.RunInstancesInput, optFns ...func(*ec2.Options)) (*ec2.RunInstancesOutput, error) {
+	return record(m.recorder, "EC2", "RunInstances", params, func() (*ec2.RunInstancesOutput, error) {
+		return m.EC2API.RunInstances(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEC2) TerminateInstances(ctx context.Context, params *ec2.TerminateInstancesInput, optFns ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error) {
+	return record(m.recorder, "EC2", "TerminateInstances", params, func() (*ec2.TerminateInstancesOutput, error) {
+		return m.EC2API.TerminateInstances(ctx, params, optFns...)
+	})
+}
+
+// recordingELB records the ELB API calls that change state.
+type recordingELB struct {
+	awsinterfaces.ELBAPI
+
+	recorder *Recorder
+}
+
+func (m *recordingELB) AddTags(ctx context.Context, params *elb.AddTagsInput, optFns ...func(*elb.Options)) (*elb.AddTagsOutput, error) {
+	return record(m.recorder, "ELB", "AddTags", params, func() (*elb.AddTagsOutput, error) {
+		return m.ELBAPI.AddTags(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) ApplySecurityGroupsToLoadBalancer(ctx context.Context, params *elb.ApplySecurityGroupsToLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.ApplySecurityGroupsToLoadBalancerOutput, error) {
+	return record(m.recorder, "ELB", "ApplySecurityGroupsToLoadBalancer", params, func() (*elb.ApplySecurityGroupsToLoadBalancerOutput, error) {
+		return m.ELBAPI.ApplySecurityGroupsToLoadBalancer(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) AttachLoadBalancerToSubnets(ctx context.Context, params *elb.AttachLoadBalancerToSubnetsInput, optFns ...func(*elb.Options)) (*elb.AttachLoadBalancerToSubnetsOutput, error) {
+	return record(m.recorder, "ELB", "AttachLoadBalancerToSubnets", params, func() (*elb.AttachLoadBalancerToSubnetsOutput, error) {
+		return m.ELBAPI.AttachLoadBalancerToSubnets(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) ConfigureHealthCheck(ctx context.Context, params *elb.ConfigureHealthCheckInput, optFns ...func(*elb.Options)) (*elb.ConfigureHealthCheckOutput, error) {
+	return record(m.recorder, "ELB", "ConfigureHealthCheck", params, func() (*elb.ConfigureHealthCheckOutput, error) {
+		return m.ELBAPI.ConfigureHealthCheck(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) CreateLoadBalancer(ctx context.Context, params *elb.CreateLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerOutput, error) {
+	return record(m.recorder, "ELB", "CreateLoadBalancer", params, func() (*elb.CreateLoadBalancerOutput, error) {
+		return m.ELBAPI.CreateLoadBalancer(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) CreateLoadBalancerListeners(ctx context.Context, params *elb.CreateLoadBalancerListenersInput, optFns ...func(*elb.Options)) (*elb.CreateLoadBalancerListenersOutput, error) {
+	return record(m.recorder, "ELB", "CreateLoadBalancerListeners", params, func() (*elb.CreateLoadBalancerListenersOutput, error) {
+		return m.ELBAPI.CreateLoadBalancerListeners(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) DeleteLoadBalancer(ctx context.Context, params *elb.DeleteLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.DeleteLoadBalancerOutput, error) {
+	return record(m.recorder, "ELB", "DeleteLoadBalancer", params, func() (*elb.DeleteLoadBalancerOutput, error) {
+		return m.ELBAPI.DeleteLoadBalancer(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) DeleteLoadBalancerListeners(ctx context.Context, params *elb.DeleteLoadBalancerListenersInput, optFns ...func(*elb.Options)) (*elb.DeleteLoadBalancerListenersOutput, error) {
+	return record(m.recorder, "ELB", "DeleteLoadBalancerListeners", params, func() (*elb.DeleteLoadBalancerListenersOutput, error) {
+		return m.ELBAPI.DeleteLoadBalancerListeners(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) DeregisterInstancesFromLoadBalancer(ctx context.Context, params *elb.DeregisterInstancesFromLoadBalancerInput, optFns ...func(*elb.Options)) (*elb.DeregisterInstancesFromLoadBalancerOutput, error) {
+	return record(m.recorder, "ELB", "DeregisterInstancesFromLoadBalancer", params, func() (*elb.DeregisterInstancesFromLoadBalancerOutput, error) {
+		return m.ELBAPI.DeregisterInstancesFromLoadBalancer(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) DetachLoadBalancerFromSubnets(ctx context.Context, params *elb.DetachLoadBalancerFromSubnetsInput, optFns ...func(*elb.Options)) (*elb.DetachLoadBalancerFromSubnetsOutput, error) {
+	return record(m.recorder, "ELB", "DetachLoadBalancerFromSubnets", params, func() (*elb.DetachLoadBalancerFromSubnetsOutput, error) {
+		return m.ELBAPI.DetachLoadBalancerFromSubnets(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) ModifyLoadBalancerAttributes(ctx context.Context, params *elb.ModifyLoadBalancerAttributesInput, optFns ...func(*elb.Options)) (*elb.ModifyLoadBalancerAttributesOutput, error) {
+	return record(m.recorder, "ELB", "ModifyLoadBalancerAttributes", params, func() (*elb.ModifyLoadBalancerAttributesOutput, error) {
+		return m.ELBAPI.ModifyLoadBalancerAttributes(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELB) RemoveTags(ctx context.Context, params *elb.RemoveTagsInput, optFns ...func(*elb.Options)) (*elb.RemoveTagsOutput, error) {
+	return record(m.recorder, "ELB", "RemoveTags", params, func() (*elb.RemoveTagsOutput, error) {
+		return m.ELBAPI.RemoveTags(ctx, params, optFns...)
+	})
+}
+
+// recordingELBV2 records the ELBV2 API calls that change state.
+type recordingELBV2 struct {
+	awsinterfaces.ELBV2API
+
+	recorder *Recorder
+}
+
+func (m *recordingELBV2) AddTags(ctx context.Context, params *elbv2.AddTagsInput, optFns ...func(*elbv2.Options)) (*elbv2.AddTagsOutput, error) {
+	return record(m.recorder, "ELBV2", "AddTags", params, func() (*elbv2.AddTagsOutput, error) {
+		return m.ELBV2API.AddTags(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) CreateListener(ctx context.Context, params *elbv2.CreateListenerInput, optFns ...func(*elbv2.Options)) (*elbv2.CreateListenerOutput, error) {
+	return record(m.recorder, "ELBV2", "CreateListener", params, func() (*elbv2.CreateListenerOutput, error) {
+		return m.ELBV2API.CreateListener(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) CreateLoadBalancer(ctx context.Context, params *elbv2.CreateLoadBalancerInput, optFns ...func(*elbv2.Options)) (*elbv2.CreateLoadBalancerOutput, error) {
+	return record(m.recorder, "ELBV2", "CreateLoadBalancer", params, func() (*elbv2.CreateLoadBalancerOutput, error) {
+		return m.ELBV2API.CreateLoadBalancer(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) CreateTargetGroup(ctx context.Context, params *elbv2.CreateTargetGroupInput, optFns ...func(*elbv2.Options)) (*elbv2.CreateTargetGroupOutput, error) {
+	return record(m.recorder, "ELBV2", "CreateTargetGroup", params, func() (*elbv2.CreateTargetGroupOutput, error) {
+		return m.ELBV2API.CreateTargetGroup(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) DeleteListener(ctx context.Context, params *elbv2.DeleteListenerInput, optFns ...func(*elbv2.Options)) (*elbv2.DeleteListenerOutput, error) {
+	return record(m.recorder, "ELBV2", "DeleteListener", params, func() (*elbv2.DeleteListenerOutput, error) {
+		return m.ELBV2API.DeleteListener(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) DeleteLoadBalancer(ctx context.Context, params *elbv2.DeleteLoadBalancerInput, optFns ...func(*elbv2.Options)) (*elbv2.DeleteLoadBalancerOutput, error) {
+	return record(m.recorder, "ELBV2", "DeleteLoadBalancer", params, func() (*elbv2.DeleteLoadBalancerOutput, error) {
+		return m.ELBV2API.DeleteLoadBalancer(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) DeleteTargetGroup(ctx context.Context, params *elbv2.DeleteTargetGroupInput, optFns ...func(*elbv2.Options)) (*elbv2.DeleteTargetGroupOutput, error) {
+	return record(m.recorder, "ELBV2", "DeleteTargetGroup", params, func() (*elbv2.DeleteTargetGroupOutput, error) {
+		return m.ELBV2API.DeleteTargetGroup(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) DeregisterTargets(ctx context.Context, params *elbv2.DeregisterTargetsInput, optFns ...func(*elbv2.Options)) (*elbv2.DeregisterTargetsOutput, error) {
+	return record(m.recorder, "ELBV2", "DeregisterTargets", params, func() (*elbv2.DeregisterTargetsOutput, error) {
+		return m.ELBV2API.DeregisterTargets(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) ModifyListener(ctx context.Context, params *elbv2.ModifyListenerInput, optFns ...func(*elbv2.Options)) (*elbv2.ModifyListenerOutput, error) {
+	return record(m.recorder, "ELBV2", "ModifyListener", params, func() (*elbv2.ModifyListenerOutput, error) {
+		return m.ELBV2API.ModifyListener(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) ModifyLoadBalancerAttributes(ctx context.Context, params *elbv2.ModifyLoadBalancerAttributesInput, optFns ...func(*elbv2.Options)) (*elbv2.ModifyLoadBalancerAttributesOutput, error) {
+	return record(m.recorder, "ELBV2", "ModifyLoadBalancerAttributes", params, func() (*elbv2.ModifyLoadBalancerAttributesOutput, error) {
+		return m.ELBV2API.ModifyLoadBalancerAttributes(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) ModifyTargetGroupAttributes(ctx context.Context, params *elbv2.ModifyTargetGroupAttributesInput, optFns ...func(*elbv2.Options)) (*elbv2.ModifyTargetGroupAttributesOutput, error) {
+	return record(m.recorder, "ELBV2", "ModifyTargetGroupAttributes", params, func() (*elbv2.ModifyTargetGroupAttributesOutput, error) {
+		return m.ELBV2API.ModifyTargetGroupAttributes(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) RemoveTags(ctx context.Context, params *elbv2.RemoveTagsInput, optFns ...func(*elbv2.Options)) (*elbv2.RemoveTagsOutput, error) {
+	return record(m.recorder, "ELBV2", "RemoveTags", params, func() (*elbv2.RemoveTagsOutput, error) {
+		return m.ELBV2API.RemoveTags(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) SetIpAddressType(ctx context.Context, params *elbv2.SetIpAddressTypeInput, optFns ...func(*elbv2.Options)) (*elbv2.SetIpAddressTypeOutput, error) {
+	return record(m.recorder, "ELBV2", "SetIpAddressType", params, func() (*elbv2.SetIpAddressTypeOutput, error) {
+		return m.ELBV2API.SetIpAddressType(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) SetSecurityGroups(ctx context.Context, params *elbv2.SetSecurityGroupsInput, optFns ...func(*elbv2.Options)) (*elbv2.SetSecurityGroupsOutput, error) {
+	return record(m.recorder, "ELBV2", "SetSecurityGroups", params, func() (*elbv2.SetSecurityGroupsOutput, error) {
+		return m.ELBV2API.SetSecurityGroups(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingELBV2) SetSubnets(ctx context.Context, params *elbv2.SetSubnetsInput, optFns ...func(*elbv2.Options)) (*elbv2.SetSubnetsOutput, error) {
+	return record(m.recorder, "ELBV2", "SetSubnets", params, func() (*elbv2.SetSubnetsOutput, error) {
+		return m.ELBV2API.SetSubnets(ctx, params, optFns...)
+	})
+}
+
+// recordingEventBridge records the EventBridge API calls that change state.
+type recordingEventBridge struct {
+	awsinterfaces.EventBridgeAPI
+
+	recorder *Recorder
+}
+
+func (m *recordingEventBridge) PutTargets(ctx context.Context, params *eventbridge.PutTargetsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutTargetsOutput, error) {
+	return record(m.recorder, "EventBridge", "PutTargets", params, func() (*eventbridge.PutTargetsOutput, error) {
+		return m.EventBridgeAPI.PutTargets(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEventBridge) PutRule(ctx context.Context, params *eventbridge.PutRuleInput, optFns ...func(*eventbridge.Options)) (*eventbridge.PutRuleOutput, error) {
+	return record(m.recorder, "EventBridge", "PutRule", params, func() (*eventbridge.PutRuleOutput, error) {
+		return m.EventBridgeAPI.PutRule(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEventBridge) DeleteRule(ctx context.Context, params *eventbridge.DeleteRuleInput, optFns ...func(*eventbridge.Options)) (*eventbridge.DeleteRuleOutput, error) {
+	return record(m.recorder, "EventBridge", "DeleteRule", params, func() (*eventbridge.DeleteRuleOutput, error) {
+		return m.EventBridgeAPI.DeleteRule(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingEventBridge) RemoveTargets(ctx context.Context, params *eventbridge.RemoveTargetsInput, optFns ...func(*eventbridge.Options)) (*eventbridge.RemoveTargetsOutput, error) {
+	return record(m.recorder, "EventBridge", "RemoveTargets", params, func() (*eventbridge.RemoveTargetsOutput, error) {
+		return m.EventBridgeAPI.RemoveTargets(ctx, params, optFns...)
+	})
+}
+
+// recordingIAM records the IAM API calls that change state.
+type recordingIAM struct {
+	awsinterfaces.IAMAPI
+
+	recorder *Recorder
+}
+
+func (m *recordingIAM) AddClientIDToOpenIDConnectProvider(ctx context.Context, params *iam.AddClientIDToOpenIDConnectProviderInput, optFns ...func(*iam.Options)) (*iam.AddClientIDToOpenIDConnectProviderOutput, error) {
+	return record(m.recorder, "IAM", "AddClientIDToOpenIDConnectProvider", params, func() (*iam.AddClientIDToOpenIDConnectProviderOutput, error) {
+		return m.IAMAPI.AddClientIDToOpenIDConnectProvider(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) AddRoleToInstanceProfile(ctx context.Context, params *iam.AddRoleToInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.AddRoleToInstanceProfileOutput, error) {
+	return record(m.recorder, "IAM", "AddRoleToInstanceProfile", params, func() (*iam.AddRoleToInstanceProfileOutput, error) {
+		return m.IAMAPI.AddRoleToInstanceProfile(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) AttachRolePolicy(ctx context.Context, params *iam.AttachRolePolicyInput, optFns ...func(*iam.Options)) (*iam.AttachRolePolicyOutput, error) {
+	return record(m.recorder, "IAM", "AttachRolePolicy", params, func() (*iam.AttachRolePolicyOutput, error) {
+		return m.IAMAPI.AttachRolePolicy(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) CreateRole(ctx context.Context, params *iam.CreateRoleInput, optFns ...func(*iam.Options)) (*iam.CreateRoleOutput, error) {
+	return record(m.recorder, "IAM", "CreateRole", params, func() (*iam.CreateRoleOutput, error) {
+		return m.IAMAPI.CreateRole(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) CreateInstanceProfile(ctx context.Context, params *iam.CreateInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.CreateInstanceProfileOutput, error) {
+	return record(m.recorder, "IAM", "CreateInstanceProfile", params, func() (*iam.CreateInstanceProfileOutput, error) {
+		return m.IAMAPI.CreateInstanceProfile(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) CreateOpenIDConnectProvider(ctx context.Context, params *iam.CreateOpenIDConnectProviderInput, optFns ...func(*iam.Options)) (*iam.CreateOpenIDConnectProviderOutput, error) {
+	return record(m.recorder, "IAM", "CreateOpenIDConnectProvider", params, func() (*iam.CreateOpenIDConnectProviderOutput, error) {
+		return m.IAMAPI.CreateOpenIDConnectProvider(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) DeleteInstanceProfile(ctx context.Context, params *iam.DeleteInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.DeleteInstanceProfileOutput, error) {
+	return record(m.recorder, "IAM", "DeleteInstanceProfile", params, func() (*iam.DeleteInstanceProfileOutput, error) {
+		return m.IAMAPI.DeleteInstanceProfile(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) DeleteOpenIDConnectProvider(ctx context.Context, params *iam.DeleteOpenIDConnectProviderInput, optFns ...func(*iam.Options)) (*iam.DeleteOpenIDConnectProviderOutput, error) {
+	return record(m.recorder, "IAM", "DeleteOpenIDConnectProvider", params, func() (*iam.DeleteOpenIDConnectProviderOutput, error) {
+		return m.IAMAPI.DeleteOpenIDConnectProvider(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) DeleteRole(ctx context.Context, params *iam.DeleteRoleInput, optFns ...func(*iam.Options)) (*iam.DeleteRoleOutput, error) {
+	return record(m.recorder, "IAM", "DeleteRole", params, func() (*iam.DeleteRoleOutput, error) {
+		return m.IAMAPI.DeleteRole(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) DeleteRolePermissionsBoundary(ctx context.Context, params *iam.DeleteRolePermissionsBoundaryInput, optFns ...func(*iam.Options)) (*iam.DeleteRolePermissionsBoundaryOutput, error) {
+	return record(m.recorder, "IAM", "DeleteRolePermissionsBoundary", params, func() (*iam.DeleteRolePermissionsBoundaryOutput, error) {
+		return m.IAMAPI.DeleteRolePermissionsBoundary(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) DeleteRolePolicy(ctx context.Context, params *iam.DeleteRolePolicyInput, optFns ...func(*iam.Options)) (*iam.DeleteRolePolicyOutput, error) {
+	return record(m.recorder, "IAM", "DeleteRolePolicy", params, func() (*iam.DeleteRolePolicyOutput, error) {
+		return m.IAMAPI.DeleteRolePolicy(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) DetachRolePolicy(ctx context.Context, params *iam.DetachRolePolicyInput, optFns ...func(*iam.Options)) (*iam.DetachRolePolicyOutput, error) {
+	return record(m.recorder, "IAM", "DetachRolePolicy", params, func() (*iam.DetachRolePolicyOutput, error) {
+		return m.IAMAPI.DetachRolePolicy(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) PutRolePermissionsBoundary(ctx context.Context, params *iam.PutRolePermissionsBoundaryInput, optFns ...func(*iam.Options)) (*iam.PutRolePermissionsBoundaryOutput, error) {
+	return record(m.recorder, "IAM", "PutRolePermissionsBoundary", params, func() (*iam.PutRolePermissionsBoundaryOutput, error) {
+		return m.IAMAPI.PutRolePermissionsBoundary(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) PutRolePolicy(ctx context.Context, params *iam.PutRolePolicyInput, optFns ...func(*iam.Options)) (*iam.PutRolePolicyOutput, error) {
+	return record(m.recorder, "IAM", "PutRolePolicy", params, func() (*iam.PutRolePolicyOutput, error) {
+		return m.IAMAPI.PutRolePolicy(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) RemoveClientIDFromOpenIDConnectProvider(ctx context.Context, params *iam.RemoveClientIDFromOpenIDConnectProviderInput, optFns ...func(*iam.Options)) (*iam.RemoveClientIDFromOpenIDConnectProviderOutput, error) {
+	return record(m.recorder, "IAM", "RemoveClientIDFromOpenIDConnectProvider", params, func() (*iam.RemoveClientIDFromOpenIDConnectProviderOutput, error) {
+		return m.IAMAPI.RemoveClientIDFromOpenIDConnectProvider(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) RemoveRoleFromInstanceProfile(ctx context.Context, params *iam.RemoveRoleFromInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.RemoveRoleFromInstanceProfileOutput, error) {
+	return record(m.recorder, "IAM", "RemoveRoleFromInstanceProfile", params, func() (*iam.RemoveRoleFromInstanceProfileOutput, error) {
+		return m.IAMAPI.RemoveRoleFromInstanceProfile(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) TagInstanceProfile(ctx context.Context, params *iam.TagInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.TagInstanceProfileOutput, error) {
+	return record(m.recorder, "IAM", "TagInstanceProfile", params, func() (*iam.TagInstanceProfileOutput, error) {
+		return m.IAMAPI.TagInstanceProfile(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) TagOpenIDConnectProvider(ctx context.Context, params *iam.TagOpenIDConnectProviderInput, optFns ...func(*iam.Options)) (*iam.TagOpenIDConnectProviderOutput, error) {
+	return record(m.recorder, "IAM", "TagOpenIDConnectProvider", params, func() (*iam.TagOpenIDConnectProviderOutput, error) {
+		return m.IAMAPI.TagOpenIDConnectProvider(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) TagRole(ctx context.Context, params *iam.TagRoleInput, optFns ...func(*iam.Options)) (*iam.TagRoleOutput, error) {
+	return record(m.recorder, "IAM", "TagRole", params, func() (*iam.TagRoleOutput, error) {
+		return m.IAMAPI.TagRole(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) UntagInstanceProfile(ctx context.Context, params *iam.UntagInstanceProfileInput, optFns ...func(*iam.Options)) (*iam.UntagInstanceProfileOutput, error) {
+	return record(m.recorder, "IAM", "UntagInstanceProfile", params, func() (*iam.UntagInstanceProfileOutput, error) {
+		return m.IAMAPI.UntagInstanceProfile(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) UntagOpenIDConnectProvider(ctx context.Context, params *iam.UntagOpenIDConnectProviderInput, optFns ...func(*iam.Options)) (*iam.UntagOpenIDConnectProviderOutput, error) {
+	return record(m.recorder, "IAM", "UntagOpenIDConnectProvider", params, func() (*iam.UntagOpenIDConnectProviderOutput, error) {
+		return m.IAMAPI.UntagOpenIDConnectProvider(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) UpdateOpenIDConnectProviderThumbprint(ctx context.Context, params *iam.UpdateOpenIDConnectProviderThumbprintInput, optFns ...func(*iam.Options)) (*iam.UpdateOpenIDConnectProviderThumbprintOutput, error) {
+	return record(m.recorder, "IAM", "UpdateOpenIDConnectProviderThumbprint", params, func() (*iam.UpdateOpenIDConnectProviderThumbprintOutput, error) {
+		return m.IAMAPI.UpdateOpenIDConnectProviderThumbprint(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) UntagRole(ctx context.Context, params *iam.UntagRoleInput, optFns ...func(*iam.Options)) (*iam.UntagRoleOutput, error) {
+	return record(m.recorder, "IAM", "UntagRole", params, func() (*iam.UntagRoleOutput, error) {
+		return m.IAMAPI.UntagRole(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingIAM) UpdateAssumeRolePolicy(ctx context.Context, params *iam.UpdateAssumeRolePolicyInput, optFns ...func(*iam.Options)) (*iam.UpdateAssumeRolePolicyOutput, error) {
+	return record(m.recorder, "IAM", "UpdateAssumeRolePolicy", params, func() (*iam.UpdateAssumeRolePolicyOutput, error) {
+		return m.IAMAPI.UpdateAssumeRolePolicy(ctx, params, optFns...)
+	})
+}
+
+// recordingRoute53 records the Route53 API calls that change state.
+type recordingRoute53 struct {
+	awsinterfaces.Route53API
+
+	recorder *Recorder
+}
+
+func (m *recordingRoute53) AssociateVPCWithHostedZone(ctx context.Context, params *route53.AssociateVPCWithHostedZoneInput, optFns ...func(*route53.Options)) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	return record(m.recorder, "Route53", "AssociateVPCWithHostedZone", params, func() (*route53.AssociateVPCWithHostedZoneOutput, error) {
+		return m.Route53API.AssociateVPCWithHostedZone(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingRoute53) ChangeResourceRecordSets(ctx context.Context, params *route53.ChangeResourceRecordSetsInput, optFns ...func(*route53.Options)) (*route53.ChangeResourceRecordSetsOutput, error) {
+	return record(m.recorder, "Route53", "ChangeResourceRecordSets", params, func() (*route53.ChangeResourceRecordSetsOutput, error) {
+		return m.Route53API.ChangeResourceRecordSets(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingRoute53) CreateHostedZone(ctx context.Context, params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+	return record(m.recorder, "Route53", "CreateHostedZone", params, func() (*route53.CreateHostedZoneOutput, error) {
+		return m.Route53API.CreateHostedZone(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingRoute53) DeleteHostedZone(ctx context.Context, params *route53.DeleteHostedZoneInput, optFns ...func(*route53.Options)) (*route53.DeleteHostedZoneOutput, error) {
+	return record(m.recorder, "Route53", "DeleteHostedZone", params, func() (*route53.DeleteHostedZoneOutput, error) {
+		return m.Route53API.DeleteHostedZone(ctx, params, optFns...)
+	})
+}
+
+// recordingSQS records the SQS API calls that change state.
+type recordingSQS struct {
+	awsinterfaces.SQSAPI
+
+	recorder *Recorder
+}
+
+func (m *recordingSQS) CreateQueue(ctx context.Context, params *sqs.CreateQueueInput, optFns ...func(*sqs.Options)) (*sqs.CreateQueueOutput, error) {
+	return record(m.recorder, "SQS", "CreateQueue", params, func() (*sqs.CreateQueueOutput, error) {
+		return m.SQSAPI.CreateQueue(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingSQS) DeleteQueue(ctx context.Context, params *sqs.DeleteQueueInput, optFns ...func(*sqs.Options)) (*sqs.DeleteQueueOutput, error) {
+	return record(m.recorder, "SQS", "DeleteQueue", params, func() (*sqs.DeleteQueueOutput, error) {
+		return m.SQSAPI.DeleteQueue(ctx, params, optFns...)
+	})
+}
+
+// recordingSSM records the SSM API calls that change state.
+type recordingSSM struct {
+	awsinterfaces.SSMAPI
+
+	recorder *Recorder
+}
+
+func (m *recordingSSM) CreateDocument(ctx context.Context, params *ssm.CreateDocumentInput, optFns ...func(*ssm.Options)) (*ssm.CreateDocumentOutput, error) {
+	return record(m.recorder, "SSM", "CreateDocument", params, func() (*ssm.CreateDocumentOutput, error) {
+		return m.SSMAPI.CreateDocument(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingSSM) UpdateDocument(ctx context.Context, params *ssm.UpdateDocumentInput, optFns ...func(*ssm.Options)) (*ssm.UpdateDocumentOutput, error) {
+	return record(m.recorder, "SSM", "UpdateDocument", params, func() (*ssm.UpdateDocumentOutput, error) {
+		return m.SSMAPI.UpdateDocument(ctx, params, optFns...)
+	})
+}
+
+func (m *recordingSSM) UpdateDocumentDefaultVersion(ctx context.Context, params *ssm.UpdateDocumentDefaultVersionInput, optFns ...func(*ssm.Options)) (*ssm.UpdateDocumentDefaultVersionOutput, error) {
+	return record(m.recorder, "SSM", "UpdateDocumentDefaultVersion", params, func() (*ssm.UpdateDocumentDefaultVersionOutput, error) {
+		return m.SSMAPI.UpdateDocumentDefaultVersion(ctx, params, optFns...)
+	})
+}
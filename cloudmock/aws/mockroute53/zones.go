@@ -83,3 +83,27 @@ func (m *MockRoute53) ListHostedZonesByName(ctx context.Context, request *route5
 		HostedZones: zones,
 	}, nil
 }
+
+func (m *MockRoute53) AssociateVPCWithHostedZone(ctx context.Context, request *route53.AssociateVPCWithHostedZoneInput, optFns ...func(*route53.Options)) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("AssociateVPCWithHostedZone %v", request)
+
+	if request.HostedZoneId == nil || request.VPC == nil {
+		// TODO: Use correct error
+		return nil, fmt.Errorf("HostedZoneId and VPC are required")
+	}
+	zone := m.findZone(*request.HostedZoneId)
+	if zone == nil {
+		// TODO: Use correct error
+		return nil, fmt.Errorf("NOT FOUND")
+	}
+
+	vpc := *request.VPC
+	zone.vpcs = append(zone.vpcs, &vpc)
+
+	return &route53.AssociateVPCWithHostedZoneOutput{
+		ChangeInfo: &route53types.ChangeInfo{Status: route53types.ChangeStatusInsync},
+	}, nil
+}
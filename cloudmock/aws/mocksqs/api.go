@@ -110,6 +110,21 @@ func (m *MockSQS) ListQueueTags(ctx context.Context, input *sqs.ListQueueTagsInp
 	return response, nil
 }
 
+func (m *MockSQS) TagQueue(ctx context.Context, input *sqs.TagQueueInput, optFns ...func(*sqs.Options)) (*sqs.TagQueueOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, v := range m.Queues {
+		if *v.url == *input.QueueUrl {
+			for k, value := range input.Tags {
+				v.tags[k] = aws.String(value)
+			}
+			return &sqs.TagQueueOutput{}, nil
+		}
+	}
+	return nil, fmt.Errorf("queue %q not found", aws.ToString(input.QueueUrl))
+}
+
 func (m *MockSQS) DeleteQueue(ctx context.Context, input *sqs.DeleteQueueInput, optFns ...func(*sqs.Options)) (*sqs.DeleteQueueOutput, error) {
 	panic("Not implemented")
 }
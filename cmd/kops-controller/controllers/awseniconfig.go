@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/awslog"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+var eniConfigGVK = schema.GroupVersionKind{
+	Group:   "crd.k8s.amazonaws.com",
+	Version: "v1alpha1",
+	Kind:    "ENIConfig",
+}
+
+// NewAWSENIConfigReconciler is the constructor for an AWSENIConfigReconciler
+func NewAWSENIConfigReconciler(ctx context.Context, mgr manager.Manager, clusterName string) (*AWSENIConfigReconciler, error) {
+	klog.Info("Starting aws eniconfig controller")
+	r := &AWSENIConfigReconciler{
+		client:      mgr.GetClient(),
+		log:         ctrl.Log.WithName("controllers").WithName("ENIConfig"),
+		clusterName: clusterName,
+	}
+
+	config, err := awsconfig.LoadDefaultConfig(ctx, awslog.WithAWSLogger())
+	if err != nil {
+		return nil, fmt.Errorf("error loading default AWS config: %v", err)
+	}
+
+	metadata := imds.NewFromConfig(config)
+
+	resp, err := metadata.GetRegion(ctx, &imds.GetRegionInput{})
+	if err != nil {
+		return nil, fmt.Errorf("error querying ec2 metadata service (for region): %v", err)
+	}
+
+	ec2Config := config.Copy()
+	ec2Config.Region = resp.Region
+	r.ec2Client = ec2.NewFromConfig(ec2Config)
+
+	return r, nil
+}
+
+// AWSENIConfigReconciler observes Node objects, and maintains an ENIConfig for the instancegroup of each node.
+// The ENIConfig is named after the instancegroup and holds the security group kOps created for its pods,
+// so that the Amazon VPC CNI attaches that security group to the network interfaces of the pods.
+type AWSENIConfigReconciler struct {
+	// client is the controller-runtime client
+	client client.Client
+
+	// log is a logr
+	log logr.Logger
+
+	// clusterName is the name of the cluster, used to find the security groups of the cluster
+	clusterName string
+
+	ec2Client *ec2.Client
+}
+
+// +kubebuilder:rbac:groups=,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=crd.k8s.amazonaws.com,resources=eniconfigs,verbs=get;create;update
+// Reconcile is the main reconciler function that observes node changes.
+func (r *AWSENIConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.log.WithValues("eniconfig-controller", req.NamespacedName)
+
+	node := &corev1.Node{}
+	if err := r.client.Get(ctx, req.NamespacedName, node); err != nil {
+		klog.Warningf("unable to fetch node %s: %v", node.Name, err)
+		if apierrors.IsNotFound(err) {
+			// we'll ignore not-found errors, since they can't be fixed by an immediate
+			// requeue (we'll need to wait for a new notification), and we can get them
+			// on deleted requests.
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	igName := node.Labels[kops.NodeLabelInstanceGroup]
+	if igName == "" {
+		// The node controller has not labeled the node yet
+		return ctrl.Result{}, nil
+	}
+
+	securityGroups, err := r.findPodSecurityGroups(ctx, igName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(securityGroups) == 0 {
+		klog.Infof("No pod security group found for instance group %q", igName)
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.ensureENIConfig(ctx, igName, securityGroups); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// findPodSecurityGroups returns the IDs of the security groups for the pods of an instance group.
+func (r *AWSENIConfigReconciler) findPodSecurityGroups(ctx context.Context, igName string) ([]string, error) {
+	response, err := r.ec2Client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("tag:" + awsup.TagClusterName),
+				Values: []string{r.clusterName},
+			},
+			{
+				Name:   aws.String("tag:" + awsup.TagNamePodSecurityGroup),
+				Values: []string{igName},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pod security groups for instance group %q: %w", igName, err)
+	}
+
+	var ids []string
+	for _, sg := range response.SecurityGroups {
+		ids = append(ids, aws.ToString(sg.GroupId))
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// ensureENIConfig creates or updates the ENIConfig with the given name to hold the given security groups.
+func (r *AWSENIConfigReconciler) ensureENIConfig(ctx context.Context, name string, securityGroups []string) error {
+	eniConfig := &unstructured.Unstructured{}
+	eniConfig.SetGroupVersionKind(eniConfigGVK)
+
+	err := r.client.Get(ctx, types.NamespacedName{Name: name}, eniConfig)
+	if apierrors.IsNotFound(err) {
+		eniConfig.SetName(name)
+		if err := unstructured.SetNestedStringSlice(eniConfig.Object, securityGroups, "spec", "securityGroups"); err != nil {
+			return err
+		}
+		klog.Infof("creating ENIConfig %q with security groups %v", name, securityGroups)
+		if err := r.client.Create(ctx, eniConfig); err != nil {
+			return fmt.Errorf("error creating ENIConfig %q: %w", name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error getting ENIConfig %q: %w", name, err)
+	}
+
+	existing, _, _ := unstructured.NestedStringSlice(eniConfig.Object, "spec", "securityGroups")
+	if slices.Equal(existing, securityGroups) {
+		return nil
+	}
+	if err := unstructured.SetNestedStringSlice(eniConfig.Object, securityGroups, "spec", "securityGroups"); err != nil {
+		return err
+	}
+	klog.Infof("updating ENIConfig %q with security groups %v", name, securityGroups)
+	if err := r.client.Update(ctx, eniConfig); err != nil {
+		return fmt.Errorf("error updating ENIConfig %q: %w", name, err)
+	}
+	return nil
+}
+
+func (r *AWSENIConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("eniconfig").
+		For(&corev1.Node{}, builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/rbac"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// nodeUserPrefix is the prefix of the usernames of the kubelets.
+const nodeUserPrefix = "system:node:"
+
+// NewKubeletServingCSRReconciler is the constructor for a KubeletServingCSRReconciler
+func NewKubeletServingCSRReconciler(mgr manager.Manager) (*KubeletServingCSRReconciler, error) {
+	klog.Info("Starting kubelet serving certificate approver")
+	r := &KubeletServingCSRReconciler{
+		client: mgr.GetClient(),
+		log:    ctrl.Log.WithName("controllers").WithName("KubeletServingCSR"),
+	}
+	return r, nil
+}
+
+// KubeletServingCSRReconciler observes CertificateSigningRequest objects, and approves the requests of kubelets
+// for their serving certificates. A request is only approved if it was made by the node that the certificate is for,
+// and the certificate only names the addresses of that node. Other requests are left for an administrator.
+type KubeletServingCSRReconciler struct {
+	// client is the controller-runtime client
+	client client.Client
+
+	// log is a logr
+	log logr.Logger
+}
+
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests,verbs=get;list;watch
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=certificatesigningrequests/approval,verbs=update
+// +kubebuilder:rbac:groups=certificates.k8s.io,resources=signers,resourceNames=kubernetes.io/kubelet-serving,verbs=approve
+// +kubebuilder:rbac:groups=,resources=nodes,verbs=get
+// Reconcile is the main reconciler function that observes certificate signing request changes.
+func (r *KubeletServingCSRReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.log.WithValues("kubeletservingcsr-controller", req.NamespacedName)
+
+	csr := &certificatesv1.CertificateSigningRequest{}
+	if err := r.client.Get(ctx, req.NamespacedName, csr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName || isCSRDecided(csr) {
+		return ctrl.Result{}, nil
+	}
+
+	nodeName := strings.TrimPrefix(csr.Spec.Username, nodeUserPrefix)
+	if nodeName == csr.Spec.Username || nodeName == "" {
+		klog.Warningf("not approving certificate signing request %q: requested by %q, which is not a node", csr.Name, csr.Spec.Username)
+		return ctrl.Result{}, nil
+	}
+
+	node := &corev1.Node{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
+		// The node may not be registered yet, so we retry
+		return ctrl.Result{}, fmt.Errorf("error getting node %q of certificate signing request %q: %w", nodeName, csr.Name, err)
+	}
+
+	if err := validateKubeletServingCSR(csr, node); err != nil {
+		klog.Warningf("not approving certificate signing request %q: %v", csr.Name, err)
+		return ctrl.Result{}, nil
+	}
+
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:           certificatesv1.CertificateApproved,
+		Status:         corev1.ConditionTrue,
+		Reason:         "KopsKubeletServingApprove",
+		Message:        "Auto approved by kops-controller, the certificate only names the addresses of the node",
+		LastUpdateTime: metav1.Now(),
+	})
+	klog.Infof("approving certificate signing request %q of node %q", csr.Name, nodeName)
+	if err := r.client.SubResource("approval").Update(ctx, csr); err != nil {
+		return ctrl.Result{}, fmt.Errorf("error approving certificate signing request %q: %w", csr.Name, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// isCSRDecided returns true if the certificate signing request was already approved or denied.
+func isCSRDecided(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, c := range csr.Status.Conditions {
+		switch c.Type {
+		case certificatesv1.CertificateApproved, certificatesv1.CertificateDenied, certificatesv1.CertificateFailed:
+			return true
+		}
+	}
+	return false
+}
+
+// validateKubeletServingCSR checks that the kubelet of the node requests a serving certificate for itself,
+// which only names the addresses that the node reports.
+func validateKubeletServingCSR(csr *certificatesv1.CertificateSigningRequest, node *corev1.Node) error {
+	username := nodeUserPrefix + node.Name
+	if csr.Spec.Username != username {
+		return fmt.Errorf("requested by %q, not by node %q", csr.Spec.Username, node.Name)
+	}
+	if !slices.Contains(csr.Spec.Groups, rbac.NodesGroup) {
+		return fmt.Errorf("requester is not in group %q", rbac.NodesGroup)
+	}
+
+	for _, usage := range csr.Spec.Usages {
+		switch usage {
+		case certificatesv1.UsageDigitalSignature, certificatesv1.UsageKeyEncipherment, certificatesv1.UsageServerAuth:
+		default:
+			return fmt.Errorf("usage %q is not allowed", usage)
+		}
+	}
+	if !slices.Contains(csr.Spec.Usages, certificatesv1.UsageServerAuth) {
+		return fmt.Errorf("usage %q is required", certificatesv1.UsageServerAuth)
+	}
+
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return fmt.Errorf("request is not a PEM encoded certificate request")
+	}
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("error parsing certificate request: %w", err)
+	}
+	if err := request.CheckSignature(); err != nil {
+		return fmt.Errorf("invalid signature of certificate request: %w", err)
+	}
+
+	if request.Subject.CommonName != username {
+		return fmt.Errorf("common name %q is not %q", request.Subject.CommonName, username)
+	}
+	if !slices.Equal(request.Subject.Organization, []string{rbac.NodesGroup}) {
+		return fmt.Errorf("organization %v is not [%s]", request.Subject.Organization, rbac.NodesGroup)
+	}
+	if len(request.EmailAddresses) != 0 || len(request.URIs) != 0 {
+		return fmt.Errorf("email and URI subject alternative names are not allowed")
+	}
+	if len(request.DNSNames) == 0 && len(request.IPAddresses) == 0 {
+		return fmt.Errorf("no DNS name or IP address is requested")
+	}
+
+	dnsNames := make(map[string]bool)
+	ipAddresses := make(map[string]bool)
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+			dnsNames[address.Address] = true
+		case corev1.NodeInternalIP, corev1.NodeExternalIP:
+			if ip := net.ParseIP(address.Address); ip != nil {
+				ipAddresses[ip.String()] = true
+			}
+		}
+	}
+	for _, dnsName := range request.DNSNames {
+		if !dnsNames[dnsName] {
+			return fmt.Errorf("DNS name %q is not an address of node %q", dnsName, node.Name)
+		}
+	}
+	for _, ip := range request.IPAddresses {
+		if !ipAddresses[ip.String()] {
+			return fmt.Errorf("IP address %q is not an address of node %q", ip, node.Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *KubeletServingCSRReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("kubeletservingcsr").
+		For(&certificatesv1.CertificateSigningRequest{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+			csr, ok := o.(*certificatesv1.CertificateSigningRequest)
+			return ok && csr.Spec.SignerName == certificatesv1.KubeletServingSignerName
+		}))).
+		Complete(r)
+}
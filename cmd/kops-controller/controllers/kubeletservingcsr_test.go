@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateKubeletServingCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+				{Type: corev1.NodeHostName, Address: "node-a.ec2.internal"},
+				{Type: corev1.NodeInternalDNS, Address: "node-a.ec2.internal"},
+			},
+		},
+	}
+
+	validTemplate := func() *x509.CertificateRequest {
+		return &x509.CertificateRequest{
+			Subject: pkix.Name{
+				CommonName:   "system:node:node-a",
+				Organization: []string{"system:nodes"},
+			},
+			DNSNames:    []string{"node-a.ec2.internal"},
+			IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+		}
+	}
+	validCSR := func(template *x509.CertificateRequest) *certificatesv1.CertificateSigningRequest {
+		der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+		if err != nil {
+			t.Fatalf("error creating certificate request: %v", err)
+		}
+		return &certificatesv1.CertificateSigningRequest{
+			Spec: certificatesv1.CertificateSigningRequestSpec{
+				Request:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+				SignerName: certificatesv1.KubeletServingSignerName,
+				Usages:     []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageServerAuth},
+				Username:   "system:node:node-a",
+				Groups:     []string{"system:nodes", "system:authenticated"},
+			},
+		}
+	}
+
+	grid := []struct {
+		name     string
+		template func(template *x509.CertificateRequest)
+		csr      func(csr *certificatesv1.CertificateSigningRequest)
+		expected string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name: "other node",
+			csr: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Username = "system:node:node-b"
+			},
+			expected: "not by node",
+		},
+		{
+			name: "not in nodes group",
+			csr: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Groups = []string{"system:authenticated"}
+			},
+			expected: "not in group",
+		},
+		{
+			name: "client auth usage",
+			csr: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Usages = append(csr.Spec.Usages, certificatesv1.UsageClientAuth)
+			},
+			expected: "is not allowed",
+		},
+		{
+			name: "no server auth usage",
+			csr: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Usages = []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature}
+			},
+			expected: "is required",
+		},
+		{
+			name: "invalid request",
+			csr: func(csr *certificatesv1.CertificateSigningRequest) {
+				csr.Spec.Request = []byte("not a request")
+			},
+			expected: "not a PEM encoded certificate request",
+		},
+		{
+			name: "other common name",
+			template: func(template *x509.CertificateRequest) {
+				template.Subject.CommonName = "system:node:node-b"
+			},
+			expected: "common name",
+		},
+		{
+			name: "additional organization",
+			template: func(template *x509.CertificateRequest) {
+				template.Subject.Organization = append(template.Subject.Organization, "system:masters")
+			},
+			expected: "organization",
+		},
+		{
+			name: "email address",
+			template: func(template *x509.CertificateRequest) {
+				template.EmailAddresses = []string{"admin@example.com"}
+			},
+			expected: "email and URI",
+		},
+		{
+			name: "URI",
+			template: func(template *x509.CertificateRequest) {
+				template.URIs = []*url.URL{{Scheme: "spiffe", Host: "example.com"}}
+			},
+			expected: "email and URI",
+		},
+		{
+			name: "no names",
+			template: func(template *x509.CertificateRequest) {
+				template.DNSNames = nil
+				template.IPAddresses = nil
+			},
+			expected: "no DNS name or IP address",
+		},
+		{
+			name: "other DNS name",
+			template: func(template *x509.CertificateRequest) {
+				template.DNSNames = append(template.DNSNames, "kubernetes.default.svc")
+			},
+			expected: "DNS name \"kubernetes.default.svc\" is not an address",
+		},
+		{
+			name: "other IP address",
+			template: func(template *x509.CertificateRequest) {
+				template.IPAddresses = append(template.IPAddresses, net.ParseIP("10.0.0.2"))
+			},
+			expected: "IP address \"10.0.0.2\" is not an address",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			template := validTemplate()
+			if g.template != nil {
+				g.template(template)
+			}
+			csr := validCSR(template)
+			if g.csr != nil {
+				g.csr(csr)
+			}
+
+			err := validateKubeletServingCSR(csr, node)
+			if g.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got none", g.expected)
+			}
+			if !strings.Contains(err.Error(), g.expected) {
+				t.Errorf("expected error containing %q, got %v", g.expected, err)
+			}
+		})
+	}
+}
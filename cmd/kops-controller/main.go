@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 
+	certificatesv1 "k8s.io/api/certificates/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -46,6 +47,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
 	"k8s.io/kops/upup/pkg/fi/cloudup/do"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce/gceidtoken"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm/gcetpmverifier"
 	"k8s.io/kops/upup/pkg/fi/cloudup/hetzner"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
@@ -99,6 +101,10 @@ func main() {
 		}
 	}
 
+	if opt.MetricsAddress != "" {
+		metricsAddress = opt.MetricsAddress
+	}
+
 	ctrl.SetLogger(klogr.New())
 
 	scheme, err := buildScheme()
@@ -199,6 +205,22 @@ func main() {
 			klog.Fatalf("server verifiers not provided")
 		}
 
+		var identityVerifier bootstrap.IdentityVerifier
+		if opt.Server.AdminCredentials != nil {
+			switch {
+			case opt.Server.Provider.AWS != nil:
+				identityVerifier, err = awsup.NewAWSIdentityVerifier(ctx, opt.Server.Provider.AWS)
+			case opt.Server.Provider.GCE != nil:
+				identityVerifier, err = gceidtoken.NewIDTokenVerifier(ctx, opt.ClusterName)
+			default:
+				err = fmt.Errorf("admin credentials are not supported for cloud provider %q", opt.Cloud)
+			}
+			if err != nil {
+				setupLog.Error(err, "unable to create identity verifier")
+				os.Exit(1)
+			}
+		}
+
 		uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
 			Scheme: mgr.GetScheme(),
 			Mapper: mgr.GetRESTMapper(),
@@ -210,7 +232,7 @@ func main() {
 
 		verifier := bootstrap.NewChainVerifier(verifiers...)
 
-		srv, err := server.NewServer(vfsContext, &opt, verifier, uncachedClient)
+		srv, err := server.NewServer(vfsContext, &opt, verifier, identityVerifier, uncachedClient)
 		if err != nil {
 			setupLog.Error(err, "unable to create server")
 			os.Exit(1)
@@ -226,6 +248,20 @@ func main() {
 		}
 	}
 
+	if opt.EnableENIConfigs {
+		if err := setupENIConfigs(ctx, mgr, &opt); err != nil {
+			setupLog.Error(err, "unable to setup ENIConfig controller")
+			os.Exit(1)
+		}
+	}
+
+	if opt.EnableKubeletServingCertificateApprover {
+		if err := setupKubeletServingCertificateApprover(mgr); err != nil {
+			setupLog.Error(err, "unable to setup kubelet serving certificate approver")
+			os.Exit(1)
+		}
+	}
+
 	if err := addNodeController(ctx, mgr, vfsContext, &opt); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeController")
 		os.Exit(1)
@@ -253,6 +289,9 @@ func buildScheme() (*runtime.Scheme, error) {
 	if err := v1alpha2.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("error registering kops/v1alpha2 API: %v", err)
 	}
+	if err := certificatesv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error registering certificatesv1: %v", err)
+	}
 	// Needed so that the leader-election system can post events
 	if err := coordinationv1.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("error registering coordinationv1: %v", err)
@@ -364,6 +403,39 @@ func addGossipController(mgr manager.Manager, opt *config.Options) error {
 	return nil
 }
 
+func setupENIConfigs(ctx context.Context, mgr manager.Manager, opt *config.Options) error {
+	setupLog.Info("enabling ENIConfig controller")
+	if opt.Cloud != "aws" {
+		return fmt.Errorf("kOps ENIConfig controller is not supported on cloud %q", opt.Cloud)
+	}
+
+	controller, err := controllers.NewAWSENIConfigReconciler(ctx, mgr, opt.ClusterName)
+	if err != nil {
+		return fmt.Errorf("creating aws ENIConfig controller: %w", err)
+	}
+
+	if err := controller.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("registering ENIConfig controller: %w", err)
+	}
+
+	return nil
+}
+
+func setupKubeletServingCertificateApprover(mgr manager.Manager) error {
+	setupLog.Info("enabling kubelet serving certificate approver")
+
+	controller, err := controllers.NewKubeletServingCSRReconciler(mgr)
+	if err != nil {
+		return fmt.Errorf("creating kubelet serving certificate approver: %w", err)
+	}
+
+	if err := controller.SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("registering kubelet serving certificate approver: %w", err)
+	}
+
+	return nil
+}
+
 // Reconciler is the interface for a standard Reconciler.
 type Reconciler interface {
 	SetupWithManager(mgr manager.Manager) error
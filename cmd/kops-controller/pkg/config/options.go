@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
@@ -38,8 +39,17 @@ type Options struct {
 	// EnableCloudIPAM enables the cloud IPAM controller.
 	EnableCloudIPAM bool `json:"enableCloudIPAM,omitempty"`
 
+	// EnableENIConfigs enables the controller that maintains the Amazon VPC CNI ENIConfig of each instance group.
+	EnableENIConfigs bool `json:"enableENIConfigs,omitempty"`
+
+	// EnableKubeletServingCertificateApprover enables the controller that approves the requests of kubelets for their serving certificates.
+	EnableKubeletServingCertificateApprover bool `json:"enableKubeletServingCertificateApprover,omitempty"`
+
 	// Discovery configures options relating to discovery, particularly for gossip mode.
 	Discovery *DiscoveryOptions `json:"discovery,omitempty"`
+
+	// MetricsAddress is the address the metrics endpoint binds to. Metrics are disabled if empty.
+	MetricsAddress string `json:"metricsAddress,omitempty"`
 }
 
 func (o *Options) PopulateDefaults() {
@@ -66,6 +76,17 @@ type ServerOptions struct {
 	SigningCAs []string `json:"signingCAs"`
 	// CertNames is the list of active certificate names.
 	CertNames []string `json:"certNames"`
+
+	// AdminCredentials configures the issuance of short-lived admin credentials in exchange for cloud identities.
+	AdminCredentials *AdminCredentialsOptions `json:"adminCredentials,omitempty"`
+}
+
+// AdminCredentialsOptions configures the issuance of short-lived admin credentials in exchange for cloud identities.
+type AdminCredentialsOptions struct {
+	// AllowedIdentities are the cloud identities that may obtain admin credentials.
+	AllowedIdentities []string `json:"allowedIdentities"`
+	// MaxLifetime is the maximum lifetime of the issued admin credentials.
+	MaxLifetime metav1.Duration `json:"maxLifetime"`
 }
 
 type ServerProviderOptions struct {
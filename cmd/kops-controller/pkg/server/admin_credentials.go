@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/pkg/rbac"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// adminCredentials issues short-lived admin credentials to operators in exchange for their cloud identity.
+// Every issued or denied credential is logged, so that the use of admin credentials can be audited.
+func (s *Server) adminCredentials(w http.ResponseWriter, r *http.Request) {
+	if r.Body == nil {
+		klog.Infof("admin-credentials %s no body", r.RemoteAddr)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		klog.Infof("admin-credentials %s read err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(fmt.Sprintf("admin-credentials %s failed to read body: %v", r.RemoteAddr, err)))
+		return
+	}
+
+	ctx := r.Context()
+
+	id, err := s.identityVerifier.VerifyIdentity(ctx, r, r.Header.Get("Authorization"), body)
+	if err != nil {
+		klog.Infof("admin-credentials %s verify err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusForbidden)
+		// don't return the error; this allows us to have richer errors without security implications
+		_, _ = w.Write([]byte("failed to verify token"))
+		return
+	}
+
+	if !s.adminIdentities.Has(id.Identity) {
+		klog.Infof("admin-credentials %s denied to %q: identity %q is not allowed", r.RemoteAddr, id.Principal, id.Identity)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(fmt.Sprintf("identity %q is not allowed to obtain admin credentials", id.Identity)))
+		return
+	}
+
+	req := &nodeup.AdminCredentialsRequest{}
+	if err := json.Unmarshal(body, req); err != nil {
+		klog.Infof("admin-credentials %s decode err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(fmt.Sprintf("failed to decode: %v", err)))
+		return
+	}
+
+	if req.APIVersion != nodeup.AdminCredentialsAPIVersion {
+		klog.Infof("admin-credentials %s wrong APIVersion", r.RemoteAddr)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("unexpected APIVersion"))
+		return
+	}
+
+	// Don't issue credentials to requests that were signed for another cluster
+	if req.ClusterName != s.opt.ClusterName {
+		klog.Infof("admin-credentials %s denied to %q: request is for cluster %q", r.RemoteAddr, id.Principal, req.ClusterName)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("unexpected cluster name"))
+		return
+	}
+
+	lifetime := s.opt.Server.AdminCredentials.MaxLifetime.Duration
+	if requested := time.Duration(req.LifetimeSeconds) * time.Second; requested > 0 && requested < lifetime {
+		lifetime = requested
+	}
+
+	cert, err := s.issueAdminCert(ctx, req.PublicKey, id.Principal, lifetime)
+	if err != nil {
+		klog.Infof("admin-credentials %s issue err for %q: %v", r.RemoteAddr, id.Principal, err)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(fmt.Sprintf("failed to issue admin credentials: %v", err)))
+		return
+	}
+
+	certData, err := cert.AsString()
+	if err != nil {
+		klog.Infof("admin-credentials %s encode err: %v", r.RemoteAddr, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("internal error"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&nodeup.AdminCredentialsResponse{Certificate: certData})
+	klog.Infof("admin-credentials %s issued to %q (identity %q) with serial %s, valid until %s", r.RemoteAddr, id.Principal, id.Identity, cert.Certificate.SerialNumber, cert.Certificate.NotAfter.Format(time.RFC3339))
+}
+
+// issueAdminCert issues a client certificate for the system:masters group, for the principal of an operator.
+func (s *Server) issueAdminCert(ctx context.Context, pubKey string, principal string, lifetime time.Duration) (*pki.Certificate, error) {
+	key, err := parsePublicKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	issueReq := &pki.IssueCertRequest{
+		Signer: fi.CertificateIDCA,
+		Type:   "client",
+		Subject: pkix.Name{
+			CommonName:   principal,
+			Organization: []string{rbac.SystemPrivilegedGroup},
+		},
+		PublicKey: key,
+		Validity:  lifetime,
+	}
+
+	cert, _, _, err := pki.IssueCert(ctx, issueReq, s.keystore)
+	if err != nil {
+		return nil, fmt.Errorf("issuing certificate: %v", err)
+	}
+	return cert, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/pkg/rbac"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// fakeIdentityVerifier accepts tokens that are the principal of the caller.
+type fakeIdentityVerifier struct{}
+
+func (fakeIdentityVerifier) VerifyIdentity(ctx context.Context, rawRequest *http.Request, token string, body []byte) (*bootstrap.IdentityResult, error) {
+	principal, found := strings.CutPrefix(token, "fake ")
+	if !found {
+		return nil, bootstrap.ErrNotThisVerifier
+	}
+	return &bootstrap.IdentityResult{
+		Identity:  strings.Split(principal, "/")[0],
+		Principal: principal,
+	}, nil
+}
+
+func TestAdminCredentials(t *testing.T) {
+	ctx := context.TODO()
+
+	ca, caKey, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+		Type:    "ca",
+		Subject: pkix.Name{CommonName: "kubernetes-ca"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error creating CA: %v", err)
+	}
+
+	s := &Server{
+		opt: &config.Options{
+			ClusterName: "cluster.example.com",
+			Server: &config.ServerOptions{
+				AdminCredentials: &config.AdminCredentialsOptions{
+					AllowedIdentities: []string{"admin"},
+					MaxLifetime:       metav1.Duration{Duration: time.Hour},
+				},
+			},
+		},
+		keystore: keystore{
+			keys: map[string]keystoreEntry{
+				fi.CertificateIDCA: {certificate: ca, key: caKey},
+			},
+		},
+		identityVerifier: fakeIdentityVerifier{},
+		adminIdentities:  sets.New("admin"),
+	}
+
+	key, err := pki.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	pkData, err := x509.MarshalPKIXPublicKey(key.Key.Public())
+	if err != nil {
+		t.Fatalf("error marshalling public key: %v", err)
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: pkData}))
+
+	grid := []struct {
+		name             string
+		token            string
+		request          nodeup.AdminCredentialsRequest
+		expectedStatus   int
+		expectedLifetime time.Duration
+	}{
+		{
+			name:  "allowed identity",
+			token: "fake admin/alice",
+			request: nodeup.AdminCredentialsRequest{
+				APIVersion:      nodeup.AdminCredentialsAPIVersion,
+				ClusterName:     "cluster.example.com",
+				PublicKey:       publicKey,
+				LifetimeSeconds: 900,
+			},
+			expectedStatus:   http.StatusOK,
+			expectedLifetime: 15 * time.Minute,
+		},
+		{
+			name:  "lifetime capped at maximum",
+			token: "fake admin/alice",
+			request: nodeup.AdminCredentialsRequest{
+				APIVersion:      nodeup.AdminCredentialsAPIVersion,
+				ClusterName:     "cluster.example.com",
+				PublicKey:       publicKey,
+				LifetimeSeconds: 86400,
+			},
+			expectedStatus:   http.StatusOK,
+			expectedLifetime: time.Hour,
+		},
+		{
+			name:  "identity not allowed",
+			token: "fake developer/bob",
+			request: nodeup.AdminCredentialsRequest{
+				APIVersion:  nodeup.AdminCredentialsAPIVersion,
+				ClusterName: "cluster.example.com",
+				PublicKey:   publicKey,
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:  "invalid token",
+			token: "other admin/alice",
+			request: nodeup.AdminCredentialsRequest{
+				APIVersion:  nodeup.AdminCredentialsAPIVersion,
+				ClusterName: "cluster.example.com",
+				PublicKey:   publicKey,
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:  "other cluster",
+			token: "fake admin/alice",
+			request: nodeup.AdminCredentialsRequest{
+				APIVersion:  nodeup.AdminCredentialsAPIVersion,
+				ClusterName: "other.example.com",
+				PublicKey:   publicKey,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid public key",
+			token: "fake admin/alice",
+			request: nodeup.AdminCredentialsRequest{
+				APIVersion:  nodeup.AdminCredentialsAPIVersion,
+				ClusterName: "cluster.example.com",
+				PublicKey:   "invalid",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			body, err := json.Marshal(g.request)
+			if err != nil {
+				t.Fatalf("error marshalling request: %v", err)
+			}
+			r := httptest.NewRequest("POST", "/admin-credentials", bytes.NewReader(body))
+			r.Header.Set("Authorization", g.token)
+			w := httptest.NewRecorder()
+
+			s.adminCredentials(w, r)
+
+			if w.Code != g.expectedStatus {
+				t.Fatalf("unexpected status; got %d, want %d: %s", w.Code, g.expectedStatus, w.Body.String())
+			}
+			if g.expectedStatus != http.StatusOK {
+				return
+			}
+
+			response := &nodeup.AdminCredentialsResponse{}
+			if err := json.Unmarshal(w.Body.Bytes(), response); err != nil {
+				t.Fatalf("error decoding response: %v", err)
+			}
+			cert, err := pki.ParsePEMCertificate([]byte(response.Certificate))
+			if err != nil {
+				t.Fatalf("error parsing certificate: %v", err)
+			}
+			if got, want := fmt.Sprint(cert.Subject), "CN=admin/alice,O="+rbac.SystemPrivilegedGroup; got != want {
+				t.Errorf("unexpected subject; got %q, want %q", got, want)
+			}
+			if err := cert.Certificate.CheckSignatureFrom(ca.Certificate); err != nil {
+				t.Errorf("certificate not signed by CA: %v", err)
+			}
+			lifetime := cert.Certificate.NotAfter.Sub(cert.Certificate.NotBefore) - pki.CertificateBackdate
+			if lifetime.Round(time.Minute) != g.expectedLifetime {
+				t.Errorf("unexpected lifetime; got %v, want %v", lifetime, g.expectedLifetime)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 
 	"k8s.io/kops/pkg/pki"
 	"sigs.k8s.io/yaml"
@@ -85,5 +86,7 @@ func newKeystore(basePath string, cas []string) (pki.Keystore, map[string]string
 		return nil, nil, fmt.Errorf("parsing keypair-ids.yaml")
 	}
 
+	recordCAExpiry(*keystore, keypairIDs, time.Now())
+
 	return keystore, keypairIDs, nil
 }
@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// caExpiryWarningPeriod is how long before the expiry of a signing CA a warning is logged.
+const caExpiryWarningPeriod = 90 * 24 * time.Hour
+
+// caCertificateExpiration reports the expiry of the CAs that kops-controller signs certificates with.
+var caCertificateExpiration = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "kops_controller_ca_certificate_expiration_timestamp_seconds",
+		Help: "Time at which the certificate of a signing CA expires, in seconds since the epoch.",
+	},
+	[]string{"keyset", "id"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(caCertificateExpiration)
+}
+
+// recordCAExpiry exposes the expiry of the signing CAs as metrics, and warns about the CAs that expire soon.
+func recordCAExpiry(ks keystore, keypairIDs map[string]string, now time.Time) {
+	for name, entry := range ks.keys {
+		notAfter := entry.certificate.Certificate.NotAfter
+		caCertificateExpiration.WithLabelValues(name, keypairIDs[name]).Set(float64(notAfter.Unix()))
+		if notAfter.Sub(now) < caExpiryWarningPeriod {
+			klog.Warningf("certificate of CA %q expires at %v and must be rotated", name, notAfter.UTC())
+		}
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/kops/pkg/pki"
+)
+
+func TestRecordCAExpiry(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := now.Add(365 * 24 * time.Hour)
+
+	ks := keystore{
+		keys: map[string]keystoreEntry{
+			"kubernetes-ca": {
+				certificate: &pki.Certificate{Certificate: &x509.Certificate{NotAfter: notAfter}},
+			},
+		},
+	}
+	recordCAExpiry(ks, map[string]string{"kubernetes-ca": "1234"}, now)
+
+	var m dto.Metric
+	if err := caCertificateExpiration.WithLabelValues("kubernetes-ca", "1234").Write(&m); err != nil {
+		t.Fatalf("error reading metric: %v", err)
+	}
+	if actual := m.GetGauge().GetValue(); actual != float64(notAfter.Unix()) {
+		t.Errorf("expected expiry %v, got %v", float64(notAfter.Unix()), actual)
+	}
+}
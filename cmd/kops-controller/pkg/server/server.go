@@ -18,6 +18,7 @@ package server
 
 import (
 	"context"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -66,11 +67,16 @@ type Server struct {
 
 	// challengeClient performs our callback-challenge into the node
 	challengeClient *bootstrap.ChallengeClient
+
+	// identityVerifier verifies the cloud identity of operators requesting admin credentials
+	identityVerifier bootstrap.IdentityVerifier
+	// adminIdentities are the cloud identities that may obtain admin credentials
+	adminIdentities sets.Set[string]
 }
 
 var _ manager.LeaderElectionRunnable = &Server{}
 
-func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstrap.Verifier, uncachedClient client.Client) (*Server, error) {
+func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstrap.Verifier, identityVerifier bootstrap.IdentityVerifier, uncachedClient client.Client) (*Server, error) {
 	server := &http.Server{
 		Addr: opt.Server.Listen,
 		TLSConfig: &tls.Config{
@@ -111,6 +117,11 @@ func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstr
 
 	r := http.NewServeMux()
 	r.Handle("/bootstrap", http.HandlerFunc(s.bootstrap))
+	if opt.Server.AdminCredentials != nil && identityVerifier != nil {
+		s.identityVerifier = identityVerifier
+		s.adminIdentities = sets.New(opt.Server.AdminCredentials.AllowedIdentities...)
+		r.Handle("/admin-credentials", http.HandlerFunc(s.adminCredentials))
+	}
 	server.Handler = recovery(r)
 
 	return s, nil
@@ -260,13 +271,9 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) issueCert(ctx context.Context, name string, pubKey string, id *bootstrap.VerifyResult, validHours uint32, keypairIDs map[string]string) (string, error) {
-	block, _ := pem.Decode([]byte(pubKey))
-	if block.Type != "RSA PUBLIC KEY" {
-		return "", fmt.Errorf("unexpected key type %q", block.Type)
-	}
-	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	key, err := parsePublicKey(pubKey)
 	if err != nil {
-		return "", fmt.Errorf("parsing key: %v", err)
+		return "", err
 	}
 
 	issueReq := &pki.IssueCertRequest{
@@ -323,6 +330,22 @@ func (s *Server) issueCert(ctx context.Context, name string, pubKey string, id *
 	return cert.AsString()
 }
 
+// parsePublicKey parses a public key sent by a client for the certificate it requests.
+func parsePublicKey(pubKey string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pubKey))
+	if block == nil {
+		return nil, fmt.Errorf("key is not PEM encoded")
+	}
+	if block.Type != "RSA PUBLIC KEY" {
+		return nil, fmt.Errorf("unexpected key type %q", block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing key: %v", err)
+	}
+	return key, nil
+}
+
 // recovery is responsible for ensuring we don't exit on a panic.
 func recovery(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
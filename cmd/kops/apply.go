@@ -0,0 +1,480 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	kopsinternalversion "k8s.io/kops/pkg/client/clientset_generated/clientset/typed/kops/internalversion"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/sdk"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/text"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	applyLong = templates.LongDesc(i18n.T(`
+	Apply the cluster, instance group, SSH credential and keyset manifests in files or directories.
+	Keysets of type Secret are stored as secrets, other keysets as keypairs.
+
+	Every object is created or replaced in the state store, then the cloud resources of each
+	cluster are updated, as with "kops replace --force" followed by "kops update cluster --yes".
+	Without --yes, the objects are listed and the changes to the cloud resources of existing
+	clusters are previewed, as with "kops update cluster".
+
+	Instance groups with the annotation ` + kopsapi.AnnotationNameRollingUpdatePolicy + `=` + kopsapi.AnnotationValueRollingUpdatePolicyAuto + `
+	are then rolled if they need updating, as with "kops rolling-update cluster --yes".
+	Instance groups without the annotation use the annotation of their Cluster, and
+	the value ` + kopsapi.AnnotationValueRollingUpdatePolicyNever + ` on an instance group overrides ` + kopsapi.AnnotationValueRollingUpdatePolicyAuto + ` on its Cluster.
+	`))
+
+	applyExample = templates.Examples(i18n.T(`
+	# Preview the changes
+	kops apply -f ./clusters/prod/
+
+	# Apply the manifests and update the cloud resources
+	kops apply -f ./clusters/prod/ --yes
+	`))
+
+	applyShort = i18n.T(`Apply cluster manifests and update the cluster.`)
+)
+
+// ApplyOptions is the options for the command
+type ApplyOptions struct {
+	// Filenames is a list of files or directories containing resources to apply.
+	Filenames []string
+	// Yes applies the changes; otherwise the changes are only previewed.
+	Yes bool
+	// CloudOnly performs any rolling update without validating the cluster.
+	CloudOnly bool
+}
+
+// NewCmdApply returns a new apply command
+func NewCmdApply(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ApplyOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "apply {-f FILENAME}...",
+		Short:             applyShort,
+		Long:              applyLong,
+		Example:           applyExample,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunApply(cmd.Context(), f, out, options)
+		},
+	}
+	cmd.Flags().StringSliceVarP(&options.Filenames, "filename", "f", options.Filenames, "Files or directories containing the manifests to apply, or - for stdin")
+	cmd.MarkFlagRequired("filename")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Apply the changes; without --yes the changes are only previewed")
+	cmd.Flags().BoolVar(&options.CloudOnly, "cloudonly", options.CloudOnly, "Perform any rolling update without validating cluster status (will cause downtime)")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+	commandutils.SetApplyFlag(cmd, "yes")
+
+	return cmd
+}
+
+// RunApply processes the apply command
+func RunApply(ctx context.Context, f *util.Factory, out io.Writer, options *ApplyOptions) error {
+	filenames, err := expandManifestPaths(options.Filenames)
+	if err != nil {
+		return err
+	}
+
+	var objects []runtime.Object
+	for _, filename := range filenames {
+		var contents []byte
+		if filename == "-" {
+			contents, err = ConsumeStdin()
+			if err != nil {
+				return err
+			}
+		} else {
+			contents, err = f.VFSContext().ReadFile(filename)
+			if err != nil {
+				return fmt.Errorf("error reading file %q: %w", filename, err)
+			}
+		}
+
+		for _, section := range text.SplitContentToSections(contents) {
+			o, gvk, err := kopscodecs.Decode(section, nil)
+			if err != nil {
+				return fmt.Errorf("error parsing file %q: %w", filename, err)
+			}
+			switch o.(type) {
+			case *kopsapi.Cluster, *kopsapi.InstanceGroup, *kopsapi.SSHCredential, *kopsapi.Keyset:
+				objects = append(objects, o)
+			default:
+				klog.V(2).Infof("Type of object was %T", o)
+				return fmt.Errorf("unhandled kind %q in %q", gvk, filename)
+			}
+		}
+	}
+
+	// Clusters must exist before the objects that belong to them.
+	sort.SliceStable(objects, func(i, j int) bool {
+		_, iCluster := objects[i].(*kopsapi.Cluster)
+		_, jCluster := objects[j].(*kopsapi.Cluster)
+		return iCluster && !jCluster
+	})
+	clusterNames := applyClusterNames(objects)
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		for _, o := range objects {
+			fmt.Fprintf(out, "Will apply %s\n", describeApplyObject(o))
+		}
+		if err := previewApply(ctx, clientset, out, objects, clusterNames); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "\nMust specify --yes to apply changes\n")
+		return nil
+	}
+
+	for _, o := range objects {
+		if keyset, ok := o.(*kopsapi.Keyset); ok {
+			if err := applyKeyset(ctx, clientset, keyset); err != nil {
+				return err
+			}
+		} else if _, err := replaceObject(ctx, clientset, f.VFSContext(), o, true); err != nil {
+			return err
+		}
+		klog.Infof("applied %s", describeApplyObject(o))
+	}
+
+	for _, clusterName := range clusterNames {
+		updateOptions := &UpdateClusterOptions{}
+		updateOptions.InitDefaults()
+		updateOptions.Yes = true
+		updateOptions.ClusterName = clusterName
+		if _, err := RunUpdateCluster(ctx, f, out, updateOptions); err != nil {
+			return err
+		}
+
+		// The policies of all the instance groups of the cluster apply, including those not in the manifests
+		cluster, err := clientset.GetCluster(ctx, clusterName)
+		if err != nil {
+			return fmt.Errorf("error reading cluster %q: %w", clusterName, err)
+		}
+		list, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("error listing instance groups of cluster %q: %w", clusterName, err)
+		}
+		var instanceGroups []*kopsapi.InstanceGroup
+		for i := range list.Items {
+			instanceGroups = append(instanceGroups, &list.Items[i])
+		}
+		igNames, err := rollingUpdateTargets(cluster, instanceGroups)
+		if err != nil {
+			return err
+		}
+		if len(igNames) == 0 {
+			continue
+		}
+
+		rollingUpdateOptions := &RollingUpdateOptions{}
+		rollingUpdateOptions.InitDefaults()
+		rollingUpdateOptions.Yes = true
+		rollingUpdateOptions.CloudOnly = options.CloudOnly
+		rollingUpdateOptions.ClusterName = clusterName
+		rollingUpdateOptions.InstanceGroups = igNames
+		if err := RunRollingUpdateCluster(ctx, f, out, rollingUpdateOptions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyClusterNames returns the names of the clusters the objects belong to, in the order they first appear.
+func applyClusterNames(objects []runtime.Object) []string {
+	var clusterNames []string
+	seen := make(map[string]bool)
+	for _, o := range objects {
+		var clusterName string
+		if cluster, ok := o.(*kopsapi.Cluster); ok {
+			clusterName = cluster.ObjectMeta.Name
+		} else if accessor, err := meta.Accessor(o); err == nil {
+			clusterName = accessor.GetLabels()[kopsapi.LabelClusterName]
+		}
+		if clusterName != "" && !seen[clusterName] {
+			seen[clusterName] = true
+			clusterNames = append(clusterNames, clusterName)
+		}
+	}
+	return clusterNames
+}
+
+// previewApply prints the changes to the cloud resources of the existing clusters, as if the objects had been applied.
+func previewApply(ctx context.Context, clientset simple.Clientset, out io.Writer, objects []runtime.Object, clusterNames []string) error {
+	client := sdk.NewClient(newApplyPreviewClientset(clientset, objects))
+	for _, clusterName := range clusterNames {
+		cluster, err := clientset.GetCluster(ctx, clusterName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error reading cluster %q: %w", clusterName, err)
+		}
+		if cluster == nil {
+			fmt.Fprintf(out, "\nCluster %q does not exist yet, so the changes to its cloud resources are not previewed\n", clusterName)
+			continue
+		}
+
+		fmt.Fprintf(out, "\nChanges to the cloud resources of cluster %q:\n", clusterName)
+		if _, err := client.UpdateCluster(ctx, clusterName, &sdk.UpdateClusterOptions{
+			Target:       cloudup.TargetDryRun,
+			DryRunReport: out,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPreviewClientset is a read-only view of a state store, with the clusters and instance groups of the
+// manifests replacing the stored ones. Keysets and SSH credentials are not part of the view.
+type applyPreviewClientset struct {
+	simple.Clientset
+
+	clusters       map[string]*kopsapi.Cluster
+	instanceGroups map[string]map[string]*kopsapi.InstanceGroup
+}
+
+var _ simple.Clientset = &applyPreviewClientset{}
+
+func newApplyPreviewClientset(clientset simple.Clientset, objects []runtime.Object) *applyPreviewClientset {
+	c := &applyPreviewClientset{
+		Clientset:      clientset,
+		clusters:       make(map[string]*kopsapi.Cluster),
+		instanceGroups: make(map[string]map[string]*kopsapi.InstanceGroup),
+	}
+	for _, o := range objects {
+		switch v := o.(type) {
+		case *kopsapi.Cluster:
+			c.clusters[v.ObjectMeta.Name] = v
+		case *kopsapi.InstanceGroup:
+			clusterName := v.ObjectMeta.Labels[kopsapi.LabelClusterName]
+			if c.instanceGroups[clusterName] == nil {
+				c.instanceGroups[clusterName] = make(map[string]*kopsapi.InstanceGroup)
+			}
+			c.instanceGroups[clusterName][v.ObjectMeta.Name] = v
+		}
+	}
+	return c
+}
+
+// GetCluster returns the cluster of the manifests, or the stored one.
+func (c *applyPreviewClientset) GetCluster(ctx context.Context, name string) (*kopsapi.Cluster, error) {
+	if cluster, found := c.clusters[name]; found {
+		return cluster.DeepCopy(), nil
+	}
+	return c.Clientset.GetCluster(ctx, name)
+}
+
+// InstanceGroupsFor returns the instance groups of the manifests, and the stored ones they don't replace.
+func (c *applyPreviewClientset) InstanceGroupsFor(cluster *kopsapi.Cluster) kopsinternalversion.InstanceGroupInterface {
+	return &applyPreviewInstanceGroups{
+		InstanceGroupInterface: c.Clientset.InstanceGroupsFor(cluster),
+		instanceGroups:         c.instanceGroups[cluster.ObjectMeta.Name],
+	}
+}
+
+type applyPreviewInstanceGroups struct {
+	kopsinternalversion.InstanceGroupInterface
+
+	instanceGroups map[string]*kopsapi.InstanceGroup
+}
+
+func (c *applyPreviewInstanceGroups) Get(ctx context.Context, name string, options metav1.GetOptions) (*kopsapi.InstanceGroup, error) {
+	if ig, found := c.instanceGroups[name]; found {
+		return ig.DeepCopy(), nil
+	}
+	return c.InstanceGroupInterface.Get(ctx, name, options)
+}
+
+func (c *applyPreviewInstanceGroups) List(ctx context.Context, options metav1.ListOptions) (*kopsapi.InstanceGroupList, error) {
+	list, err := c.InstanceGroupInterface.List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := make(map[string]bool)
+	for i := range list.Items {
+		if ig, found := c.instanceGroups[list.Items[i].ObjectMeta.Name]; found {
+			list.Items[i] = *ig.DeepCopy()
+			replaced[ig.ObjectMeta.Name] = true
+		}
+	}
+	var added []string
+	for name := range c.instanceGroups {
+		if !replaced[name] {
+			added = append(added, name)
+		}
+	}
+	sort.Strings(added)
+	for _, name := range added {
+		list.Items = append(list.Items, *c.instanceGroups[name].DeepCopy())
+	}
+	return list, nil
+}
+
+// applyKeyset stores a keyset of type Secret in the secret store of its cluster, and other keysets in its key store.
+func applyKeyset(ctx context.Context, clientset simple.Clientset, v *kopsapi.Keyset) error {
+	clusterName := v.ObjectMeta.Labels[kopsapi.LabelClusterName]
+	if clusterName == "" {
+		return fmt.Errorf("must specify %q label with cluster name to apply Keyset %q", kopsapi.LabelClusterName, v.ObjectMeta.Name)
+	}
+	if len(v.Spec.Keys) == 0 {
+		return fmt.Errorf("Keyset %q has no keys", v.ObjectMeta.Name)
+	}
+
+	cluster, err := clientset.GetCluster(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("error fetching cluster %q: %w", clusterName, err)
+	}
+
+	switch v.Spec.Type {
+	case kopsapi.SecretTypeSecret:
+		secretStore, err := clientset.SecretStore(cluster)
+		if err != nil {
+			return err
+		}
+		secret := &fi.Secret{Data: fi.FindPrimary(v).PrivateMaterial}
+		if _, err := secretStore.ReplaceSecret(v.ObjectMeta.Name, secret); err != nil {
+			return fmt.Errorf("error replacing secret %q: %w", v.ObjectMeta.Name, err)
+		}
+	case kopsapi.SecretTypeKeypair, "":
+		keyset, err := fi.ParseKeyset(v)
+		if err != nil {
+			return err
+		}
+		keyStore, err := clientset.KeyStore(cluster)
+		if err != nil {
+			return err
+		}
+		if err := keyStore.StoreKeyset(ctx, v.ObjectMeta.Name, keyset); err != nil {
+			return fmt.Errorf("error replacing keyset %q: %w", v.ObjectMeta.Name, err)
+		}
+	default:
+		return fmt.Errorf("unknown type %q for Keyset %q, expected %s or %s", v.Spec.Type, v.ObjectMeta.Name, kopsapi.SecretTypeKeypair, kopsapi.SecretTypeSecret)
+	}
+	return nil
+}
+
+// expandManifestPaths replaces each directory with the manifest files it contains, in lexical order.
+func expandManifestPaths(paths []string) ([]string, error) {
+	var filenames []string
+	for _, p := range paths {
+		if p == "-" {
+			filenames = append(filenames, p)
+			continue
+		}
+
+		stat, err := os.Stat(p)
+		if err != nil || !stat.IsDir() {
+			// Not a local directory; it may be a file or a remote vfs path.
+			filenames = append(filenames, p)
+			continue
+		}
+
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			return nil, fmt.Errorf("error reading directory %q: %w", p, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			switch strings.ToLower(filepath.Ext(entry.Name())) {
+			case ".yaml", ".yml", ".json":
+				filenames = append(filenames, filepath.Join(p, entry.Name()))
+			}
+		}
+	}
+	return filenames, nil
+}
+
+// rollingUpdateTargets returns the instance groups to roll. An instance group's rolling update policy is
+// its annotation, or else the annotation of its cluster; only instance groups whose policy is auto are rolled.
+func rollingUpdateTargets(cluster *kopsapi.Cluster, instanceGroups []*kopsapi.InstanceGroup) ([]string, error) {
+	clusterPolicy, err := rollingUpdatePolicy(cluster.ObjectMeta.Annotations)
+	if err != nil {
+		return nil, fmt.Errorf("cluster %q: %w", cluster.ObjectMeta.Name, err)
+	}
+
+	var igNames []string
+	for _, ig := range instanceGroups {
+		policy, err := rollingUpdatePolicy(ig.ObjectMeta.Annotations)
+		if err != nil {
+			return nil, fmt.Errorf("instance group %q: %w", ig.ObjectMeta.Name, err)
+		}
+		if policy == "" {
+			policy = clusterPolicy
+		}
+		if policy == kopsapi.AnnotationValueRollingUpdatePolicyAuto {
+			igNames = append(igNames, ig.ObjectMeta.Name)
+		}
+	}
+	return igNames, nil
+}
+
+func rollingUpdatePolicy(annotations map[string]string) (string, error) {
+	policy := annotations[kopsapi.AnnotationNameRollingUpdatePolicy]
+	switch policy {
+	case "", kopsapi.AnnotationValueRollingUpdatePolicyAuto, kopsapi.AnnotationValueRollingUpdatePolicyNever:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unknown value %q for annotation %s, expected %s or %s", policy, kopsapi.AnnotationNameRollingUpdatePolicy, kopsapi.AnnotationValueRollingUpdatePolicyAuto, kopsapi.AnnotationValueRollingUpdatePolicyNever)
+	}
+}
+
+func describeApplyObject(o runtime.Object) string {
+	switch v := o.(type) {
+	case *kopsapi.Cluster:
+		return fmt.Sprintf("Cluster %q", v.ObjectMeta.Name)
+	case *kopsapi.InstanceGroup:
+		return fmt.Sprintf("InstanceGroup %q in cluster %q", v.ObjectMeta.Name, v.ObjectMeta.Labels[kopsapi.LabelClusterName])
+	case *kopsapi.SSHCredential:
+		return fmt.Sprintf("SSHCredential in cluster %q", v.ObjectMeta.Labels[kopsapi.LabelClusterName])
+	case *kopsapi.Keyset:
+		return fmt.Sprintf("Keyset %q in cluster %q", v.ObjectMeta.Name, v.ObjectMeta.Labels[kopsapi.LabelClusterName])
+	default:
+		return fmt.Sprintf("%T", o)
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestExpandManifestPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b-nodes.yaml", "a-cluster.yaml", "c.json", "README.md"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("error writing file: %v", err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub.yaml"), 0o755); err != nil {
+		t.Fatalf("error creating directory: %v", err)
+	}
+
+	actual, err := expandManifestPaths([]string{"-", dir, "s3://bucket/ig.yaml"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"-",
+		filepath.Join(dir, "a-cluster.yaml"),
+		filepath.Join(dir, "b-nodes.yaml"),
+		filepath.Join(dir, "c.json"),
+		"s3://bucket/ig.yaml",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected paths\nactual:   %q\nexpected: %q", actual, expected)
+	}
+}
+
+func TestRollingUpdateTargets(t *testing.T) {
+	cluster := func(policy string) *kopsapi.Cluster {
+		c := &kopsapi.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"}}
+		if policy != "" {
+			c.ObjectMeta.Annotations = map[string]string{kopsapi.AnnotationNameRollingUpdatePolicy: policy}
+		}
+		return c
+	}
+	ig := func(name, policy string) *kopsapi.InstanceGroup {
+		ig := &kopsapi.InstanceGroup{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if policy != "" {
+			ig.ObjectMeta.Annotations = map[string]string{kopsapi.AnnotationNameRollingUpdatePolicy: policy}
+		}
+		return ig
+	}
+
+	grid := []struct {
+		name           string
+		cluster        *kopsapi.Cluster
+		instanceGroups []*kopsapi.InstanceGroup
+		igNames        []string
+		expectError    bool
+	}{
+		{
+			name:           "no policy",
+			cluster:        cluster(""),
+			instanceGroups: []*kopsapi.InstanceGroup{ig("nodes", "")},
+		},
+		{
+			name:           "never",
+			cluster:        cluster("never"),
+			instanceGroups: []*kopsapi.InstanceGroup{ig("nodes", "")},
+		},
+		{
+			name:           "auto rolls all instance groups",
+			cluster:        cluster("auto"),
+			instanceGroups: []*kopsapi.InstanceGroup{ig("control-plane", ""), ig("nodes", "auto")},
+			igNames:        []string{"control-plane", "nodes"},
+		},
+		{
+			name:           "auto skips instance groups set to never",
+			cluster:        cluster("auto"),
+			instanceGroups: []*kopsapi.InstanceGroup{ig("control-plane", "never"), ig("nodes", "")},
+			igNames:        []string{"nodes"},
+		},
+		{
+			name:           "auto with every instance group set to never",
+			cluster:        cluster("auto"),
+			instanceGroups: []*kopsapi.InstanceGroup{ig("nodes", "never")},
+		},
+		{
+			name:           "instance group set to auto without cluster policy",
+			cluster:        cluster(""),
+			instanceGroups: []*kopsapi.InstanceGroup{ig("control-plane", ""), ig("nodes", "auto")},
+			igNames:        []string{"nodes"},
+		},
+		{
+			name:           "instance group set to auto in cluster set to never",
+			cluster:        cluster("never"),
+			instanceGroups: []*kopsapi.InstanceGroup{ig("control-plane", ""), ig("nodes", "auto")},
+			igNames:        []string{"nodes"},
+		},
+		{
+			name:        "invalid cluster policy",
+			cluster:     cluster("always"),
+			expectError: true,
+		},
+		{
+			name:           "invalid instance group policy",
+			cluster:        cluster("auto"),
+			instanceGroups: []*kopsapi.InstanceGroup{ig("nodes", "sometimes")},
+			expectError:    true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			igNames, err := rollingUpdateTargets(g.cluster, g.instanceGroups)
+			if g.expectError {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(igNames, g.igNames) {
+				t.Errorf("expected instance groups %q, got %q", g.igNames, igNames)
+			}
+		})
+	}
+}
+
+func TestApplyPreviewClientset(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "test.k8s.io"
+
+	clientset := newApplyTestClientset(t)
+	cluster := testutils.BuildMinimalCluster(clusterName)
+	cluster, err := clientset.CreateCluster(ctx, cluster)
+	if err != nil {
+		t.Fatalf("could not create cluster: %v", err)
+	}
+	for _, name := range []string{"a-nodes", "b-nodes"} {
+		ig := testutils.BuildMinimalNodeInstanceGroup(name, "subnet-us-test-1a")
+		if _, err := clientset.InstanceGroupsFor(cluster).Create(ctx, &ig, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("could not create instance group: %v", err)
+		}
+	}
+
+	updatedCluster := cluster.DeepCopy()
+	updatedCluster.Spec.KubernetesVersion = "1.31.0"
+	updatedIG := testutils.BuildMinimalNodeInstanceGroup("b-nodes", "subnet-us-test-1a")
+	updatedIG.Spec.MaxSize = fi.PtrTo(int32(10))
+	updatedIG.ObjectMeta.Labels = map[string]string{kopsapi.LabelClusterName: clusterName}
+	newIG := testutils.BuildMinimalNodeInstanceGroup("c-nodes", "subnet-us-test-1a")
+	newIG.ObjectMeta.Labels = map[string]string{kopsapi.LabelClusterName: clusterName}
+
+	preview := newApplyPreviewClientset(clientset, []runtime.Object{updatedCluster, &updatedIG, &newIG})
+
+	previewCluster, err := preview.GetCluster(ctx, clusterName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previewCluster.Spec.KubernetesVersion != "1.31.0" {
+		t.Errorf("expected the cluster of the manifests, got kubernetesVersion %q", previewCluster.Spec.KubernetesVersion)
+	}
+
+	list, err := preview.InstanceGroupsFor(previewCluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	maxSizes := make(map[string]int32)
+	var names []string
+	for _, ig := range list.Items {
+		names = append(names, ig.ObjectMeta.Name)
+		maxSizes[ig.ObjectMeta.Name] = fi.ValueOf(ig.Spec.MaxSize)
+	}
+	if expected := []string{"a-nodes", "b-nodes", "c-nodes"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected instance groups %q, got %q", expected, names)
+	}
+	if maxSizes["b-nodes"] != 10 {
+		t.Errorf("expected the instance group of the manifests, got maxSize %d", maxSizes["b-nodes"])
+	}
+
+	// The state store is left alone
+	stored, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stored.Items) != 2 {
+		t.Errorf("expected 2 stored instance groups, got %d", len(stored.Items))
+	}
+}
+
+func TestApplyKeyset(t *testing.T) {
+	ctx := context.Background()
+	clusterName := "test.k8s.io"
+
+	clientset := newApplyTestClientset(t)
+	cluster, err := clientset.CreateCluster(ctx, testutils.BuildMinimalCluster(clusterName))
+	if err != nil {
+		t.Fatalf("could not create cluster: %v", err)
+	}
+
+	secret := &kopsapi.Keyset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "admin",
+			Labels: map[string]string{kopsapi.LabelClusterName: clusterName},
+		},
+		Spec: kopsapi.KeysetSpec{
+			Type: kopsapi.SecretTypeSecret,
+			Keys: []kopsapi.KeysetItem{{Id: "1", PrivateMaterial: []byte("token")}},
+		},
+	}
+	if err := applyKeyset(ctx, clientset, secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stored, err := secretStore.FindSecret("admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored == nil || string(stored.Data) != "token" {
+		t.Errorf("expected secret to be stored, got %v", stored)
+	}
+
+	unlabelled := secret.DeepCopy()
+	unlabelled.ObjectMeta.Labels = nil
+	if err := applyKeyset(ctx, clientset, unlabelled); err == nil {
+		t.Errorf("expected error for Keyset without cluster label")
+	}
+
+	unknown := secret.DeepCopy()
+	unknown.Spec.Type = "Token"
+	if err := applyKeyset(ctx, clientset, unknown); err == nil {
+		t.Errorf("expected error for Keyset of unknown type")
+	}
+}
+
+func newApplyTestClientset(t *testing.T) simple.Clientset {
+	t.Setenv("SKIP_REGION_CHECK", "1")
+	testutils.NewIntegrationTestHarness(t).SetupMockAWS()
+
+	factoryOptions := &util.FactoryOptions{}
+	factoryOptions.RegistryPath = "memfs://tests"
+	clientset, err := util.NewFactory(factoryOptions).KopsClient()
+	if err != nil {
+		t.Fatalf("could not create clientset: %v", err)
+	}
+	return clientset
+}
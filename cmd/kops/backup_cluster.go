@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/statebackup"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	backupClusterLong = templates.LongDesc(i18n.T(`
+	Back up all the state store objects of a cluster, including the cluster and instance group specs,
+	the keysets, the secrets and the SSH public keys, to a single archive file.
+
+	The archive holds the SHA-256 hash of every file, which kops restore cluster verifies
+	before it changes the state store. The archive contains the private keys and secrets of the
+	cluster, so store it as securely as the state store itself.
+	`))
+
+	backupClusterExample = templates.Examples(i18n.T(`
+	# Back up the state of a cluster to a file named after the cluster and the current time
+	kops backup cluster k8s-cluster.example.com
+
+	# Back up the state of a cluster to a given file
+	kops backup cluster k8s-cluster.example.com --out backup.tar.gz
+	`))
+
+	backupClusterShort = i18n.T(`Back up the state of a cluster.`)
+)
+
+type BackupClusterOptions struct {
+	ClusterName string
+	// Out is the file to write the backup to
+	Out string
+}
+
+func NewCmdBackupCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &BackupClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             backupClusterShort,
+		Long:              backupClusterLong,
+		Example:           backupClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunBackupCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Out, "out", "o", options.Out, "File to write the backup to, which must not exist. Defaults to <cluster>-<timestamp>.tar.gz")
+	cmd.MarkFlagFilename("out", "tar.gz")
+
+	return cmd
+}
+
+func RunBackupCluster(ctx context.Context, f *util.Factory, out io.Writer, options *BackupClusterOptions) error {
+	if strings.HasPrefix(f.KopsStateStore(), "k8s://") {
+		return fmt.Errorf("backups are only supported for state stores in object storage or on the local filesystem")
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return fmt.Errorf("error reading full cluster spec for %q: %v", cluster.ObjectMeta.Name, err)
+	}
+	stores := []statebackup.StorePath{{Name: statebackup.StoreConfig, Path: configBase}}
+
+	// The keypairs and secrets can be stored outside of the config base
+	for _, store := range []struct{ name, location string }{
+		{statebackup.StoreKeypairs, cluster.Spec.ConfigStore.Keypairs},
+		{statebackup.StoreSecrets, cluster.Spec.ConfigStore.Secrets},
+	} {
+		name, location := store.name, store.location
+		if location == "" {
+			continue
+		}
+		p, err := f.VFSContext().BuildVfsPath(location)
+		if err != nil {
+			return fmt.Errorf("error building path for %q: %w", location, err)
+		}
+		if isUnderPath(p, configBase) {
+			continue
+		}
+		stores = append(stores, statebackup.StorePath{Name: name, Path: p})
+	}
+
+	backup, err := statebackup.Create(ctx, cluster.ObjectMeta.Name, kops.Version, stores)
+	if err != nil {
+		return err
+	}
+
+	filename := options.Out
+	if filename == "" {
+		filename = fmt.Sprintf("%s-%s.tar.gz", cluster.ObjectMeta.Name, backup.Manifest.CreationTimestamp.Format("20060102150405"))
+	}
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("error creating backup file: %w", err)
+	}
+	if _, err := backup.WriteTo(file); err != nil {
+		file.Close()
+		os.Remove(filename)
+		return fmt.Errorf("error writing backup file %q: %w", filename, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing backup file %q: %w", filename, err)
+	}
+
+	files := 0
+	for _, store := range backup.Manifest.Stores {
+		files += len(store.Files)
+	}
+	fmt.Fprintf(out, "Backed up %d files of cluster %q to %s\n", files, cluster.ObjectMeta.Name, filename)
+	return nil
+}
+
+// isUnderPath returns true if p is parent or a subdirectory of parent.
+func isUnderPath(p vfs.Path, parent vfs.Path) bool {
+	return p.Path() == parent.Path() || strings.HasPrefix(p.Path(), strings.TrimSuffix(parent.Path(), "/")+"/")
+}
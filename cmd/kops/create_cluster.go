@@ -26,6 +26,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -46,6 +47,7 @@ import (
 	kopsutil "k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/apis/kops/validation"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/clouds"
 	"k8s.io/kops/pkg/clusteraddons"
 	"k8s.io/kops/pkg/commands"
@@ -56,6 +58,7 @@ import (
 	"k8s.io/kops/pkg/zones"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/utils"
 )
 
@@ -99,6 +102,9 @@ type CreateClusterOptions struct {
 
 	// AddonPaths specify paths to additional components that we can add to a cluster
 	AddonPaths []string
+
+	// FromCluster is the name of an existing cluster whose spec and instance groups are copied to the new cluster.
+	FromCluster string
 }
 
 func (o *CreateClusterOptions) InitDefaults() {
@@ -152,6 +158,11 @@ var (
 		--node-count 3 \
 		--yes
 
+	# Create a copy of an existing cluster in another region, for example as a DR replica.
+	kops create cluster --name=k8s-dr.example.com \
+		--from-cluster=k8s-cluster.example.com \
+		--zones=us-west-2a,us-west-2b,us-west-2c
+
 	# Generate a cluster spec to apply later.
 	# Run the following, then: kops create -f filename.yaml
 	kops create cluster --name=k8s-cluster.example.com \
@@ -466,6 +477,9 @@ func NewCmdCreateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.OpenstackNetworkID, "os-network", options.OpenstackNetworkID, "ID of the existing OpenStack network to use")
 	cmd.RegisterFlagCompletionFunc("os-network", completeOpenstackNetworkID)
 
+	cmd.Flags().StringVar(&options.FromCluster, "from-cluster", options.FromCluster, "Name of an existing cluster to copy, remapping its zones to --zones")
+	cmd.RegisterFlagCompletionFunc("from-cluster", commandutils.CompleteClusterName(f, false, false))
+
 	cmd.Flags().StringVar(&options.InstanceManager, "instance-manager", options.InstanceManager, "Instance manager to use (cloudgroups or karpenter. Default: cloudgroups)")
 	cmd.RegisterFlagCompletionFunc("instance-manager", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"cloudgroups", "karpenter"}, cobra.ShellCompDirectiveNoFileComp
@@ -533,7 +547,12 @@ func RunCreateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Cr
 		c.NetworkID = c.OpenstackNetworkID
 	}
 
-	clusterResult, err := cloudup.NewCluster(&c.NewClusterOptions, clientset)
+	var clusterResult *cloudup.NewClusterResult
+	if c.FromCluster != "" {
+		clusterResult, err = cloneCluster(ctx, clientset, c)
+	} else {
+		clusterResult, err = cloudup.NewCluster(&c.NewClusterOptions, clientset)
+	}
 	if err != nil {
 		return err
 	}
@@ -868,6 +887,93 @@ func parseCloudLabels(s string) (map[string]string, error) {
 	return m, nil
 }
 
+// cloneCluster builds the new cluster from the cluster named by --from-cluster.
+func cloneCluster(ctx context.Context, clientset simple.Clientset, c *CreateClusterOptions) (*cloudup.NewClusterResult, error) {
+	source, err := clientset.GetCluster(ctx, c.FromCluster)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster %q: %w", c.FromCluster, err)
+	}
+	list, err := clientset.InstanceGroupsFor(source).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error reading instance groups of cluster %q: %w", c.FromCluster, err)
+	}
+	var sourceGroups []*api.InstanceGroup
+	for i := range list.Items {
+		sourceGroups = append(sourceGroups, &list.Items[i])
+	}
+
+	opt := &cloudup.CloneClusterOptions{
+		ClusterName: c.ClusterName,
+		ConfigBase:  c.ConfigBase,
+		Zones:       c.Zones,
+		DNSZone:     c.DNSZone,
+	}
+	if source.Spec.GetCloudProvider() == api.CloudProviderAWS {
+		opt.ResolveImage = func(image string) (string, error) {
+			region, err := awsup.FindRegion(source)
+			if err != nil {
+				return "", err
+			}
+			cloud, err := awsup.NewAWSCloud(region, nil)
+			if err != nil {
+				return "", err
+			}
+			resolved, err := cloud.ResolveImage(image)
+			if err != nil {
+				return "", err
+			}
+			return aws.ToString(resolved.OwnerId) + "/" + aws.ToString(resolved.Name), nil
+		}
+	}
+
+	clusterResult, err := cloudup.CloneCluster(opt, source, sourceGroups, clientset)
+	if err != nil {
+		return nil, err
+	}
+	c.CloudProvider = string(clusterResult.Cluster.Spec.GetCloudProvider())
+
+	for _, group := range clusterResult.InstanceGroups {
+		image := c.Image
+		switch group.Spec.Role {
+		case api.InstanceGroupRoleControlPlane:
+			if c.ControlPlaneImage != "" {
+				image = c.ControlPlaneImage
+			}
+		case api.InstanceGroupRoleNode:
+			if c.NodeImage != "" {
+				image = c.NodeImage
+			}
+		case api.InstanceGroupRoleBastion:
+			if c.BastionImage != "" {
+				image = c.BastionImage
+			}
+		}
+		if image != "" {
+			group.Spec.Image = image
+		}
+	}
+
+	// The new cluster accepts the same SSH keys, unless other keys were given
+	if len(c.SSHPublicKeys) == 0 {
+		sshCredentialStore, err := clientset.SSHCredentialStore(source)
+		if err != nil {
+			return nil, err
+		}
+		sshCredentials, err := sshCredentialStore.FindSSHPublicKeys()
+		if err != nil {
+			return nil, fmt.Errorf("error reading SSH public keys of cluster %q: %w", c.FromCluster, err)
+		}
+		for _, sshCredential := range sshCredentials {
+			if c.SSHPublicKeys == nil {
+				c.SSHPublicKeys = make(map[string][]byte)
+			}
+			c.SSHPublicKeys[sshCredential.Name] = []byte(sshCredential.Spec.PublicKey)
+		}
+	}
+
+	return clusterResult, nil
+}
+
 func loadSSHPublicKeys(sshPublicKey string) (map[string][]byte, error) {
 	sshPublicKeys := make(map[string][]byte)
 	if sshPublicKey != "" {
@@ -0,0 +1,217 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/kubeconfig"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+// maxEmergencyAdminTTL is the longest lifetime of an emergency admin credential.
+const maxEmergencyAdminTTL = 24 * time.Hour
+
+var (
+	createSecretEmergencyAdminLong = templates.LongDesc(i18n.T(`
+	Create a time-limited cluster admin credential for emergencies, such as when the
+	OIDC or cloud IAM authentication of the cluster is not available.
+
+	The credential is a client certificate signed by the cluster CA, which is written
+	to a new kubeconfig file to be handed over out-of-band. The credential is not
+	revoked: it stays valid until its TTL expires, and it can only be invalidated
+	earlier by rotating the cluster CA.
+
+	Every credential is recorded in the state store, under emergency-admin/ in the
+	configuration of the cluster, with the serial number of the certificate, the
+	user that created it, its reason and its lifetime. The requests made with the
+	credential appear in the API server audit log with the common name of the
+	certificate, kops-emergency-admin-<user>.`))
+
+	createSecretEmergencyAdminExample = templates.Examples(i18n.T(`
+	# Create a credential that is valid for 2 hours.
+	kops create secret emergency-admin k8s-cluster.example.com \
+		--ttl 2h --reason "OIDC provider outage" --kubeconfig ./emergency.kubeconfig
+	`))
+
+	createSecretEmergencyAdminShort = i18n.T(`Create a time-limited emergency admin credential.`)
+)
+
+type CreateSecretEmergencyAdminOptions struct {
+	ClusterName    string
+	TTL            time.Duration
+	Reason         string
+	KubeConfigPath string
+	Internal       bool
+}
+
+// emergencyAdminRecord is the record of an emergency admin credential in the state store.
+type emergencyAdminRecord struct {
+	SerialNumber string    `json:"serialNumber"`
+	CommonName   string    `json:"commonName"`
+	CreatedBy    string    `json:"createdBy,omitempty"`
+	Reason       string    `json:"reason"`
+	NotBefore    time.Time `json:"notBefore"`
+	NotAfter     time.Time `json:"notAfter"`
+}
+
+func NewCmdCreateSecretEmergencyAdmin(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateSecretEmergencyAdminOptions{
+		TTL: 2 * time.Hour,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "emergency-admin [CLUSTER] --reason REASON --kubeconfig FILENAME",
+		Short:             createSecretEmergencyAdminShort,
+		Long:              createSecretEmergencyAdminLong,
+		Example:           createSecretEmergencyAdminExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateSecretEmergencyAdmin(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().DurationVar(&options.TTL, "ttl", options.TTL, "Lifetime of the credential, at most "+maxEmergencyAdminTTL.String())
+	cmd.Flags().StringVar(&options.Reason, "reason", options.Reason, "Reason for the emergency access, recorded in the state store")
+	cmd.MarkFlagRequired("reason")
+	cmd.Flags().StringVar(&options.KubeConfigPath, "kubeconfig", options.KubeConfigPath, "Filename of the kubeconfig to create; the file must not exist")
+	cmd.MarkFlagRequired("kubeconfig")
+	cmd.Flags().BoolVar(&options.Internal, "internal", options.Internal, "Use the cluster's internal DNS name")
+
+	return cmd
+}
+
+func RunCreateSecretEmergencyAdmin(ctx context.Context, f *util.Factory, out io.Writer, options *CreateSecretEmergencyAdminOptions) error {
+	if options.TTL <= 0 || options.TTL > maxEmergencyAdminTTL {
+		return fmt.Errorf("--ttl must be positive and at most %v", maxEmergencyAdminTTL)
+	}
+
+	if _, err := os.Stat(options.KubeConfigPath); err == nil {
+		return fmt.Errorf("kubeconfig %q already exists", options.KubeConfigPath)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	keyStore, err := clientset.KeyStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return err
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	// Asking for an admin credential selects an API endpoint that accepts client certificates;
+	// the credential is replaced below
+	conf, err := kubeconfig.BuildKubecfg(ctx, cluster, keyStore, secretStore, cloud, options.TTL, "", options.Internal, f.KopsStateStore(), false, false)
+	if err != nil {
+		return err
+	}
+	conf.Context = cluster.ObjectMeta.Name + "-emergency-admin"
+	conf.User = conf.Context
+
+	createdBy := ""
+	if u, err := user.Current(); err == nil && u != nil {
+		createdBy = u.Username
+	}
+
+	// The credential has its own common name, to tell its requests apart from those of other admin users
+	commonName := "kops-emergency-admin"
+	if createdBy != "" {
+		commonName += "-" + createdBy
+	}
+	conf.ClientCert, conf.ClientKey, err = kubeconfig.IssueAdminCredential(ctx, keyStore, commonName, options.TTL)
+	if err != nil {
+		return fmt.Errorf("issuing emergency admin credential: %w", err)
+	}
+
+	cert, err := pki.ParsePEMCertificate(conf.ClientCert)
+	if err != nil {
+		return fmt.Errorf("parsing emergency admin certificate: %w", err)
+	}
+
+	record := &emergencyAdminRecord{
+		SerialNumber: cert.Certificate.SerialNumber.String(),
+		CommonName:   cert.Subject.CommonName,
+		CreatedBy:    createdBy,
+		Reason:       options.Reason,
+		NotBefore:    cert.Certificate.NotBefore.UTC(),
+		NotAfter:     cert.Certificate.NotAfter.UTC(),
+	}
+
+	// The credential is recorded before it is handed out, so that no credential exists without a record
+	data, err := yaml.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("marshaling emergency admin record: %w", err)
+	}
+	recordPath := configBase.Join("emergency-admin", record.NotBefore.Format("20060102T150405Z")+"-"+record.SerialNumber+".yaml")
+	acl, err := acls.GetACL(ctx, recordPath, cluster)
+	if err != nil {
+		return err
+	}
+	if err := recordPath.CreateFile(ctx, bytes.NewReader(data), acl); err != nil {
+		return fmt.Errorf("recording emergency admin credential in %s: %w", recordPath, err)
+	}
+
+	pathOptions := clientcmd.NewDefaultPathOptions()
+	pathOptions.GlobalFile = options.KubeConfigPath
+	pathOptions.EnvVar = ""
+	pathOptions.GlobalFileSubpath = ""
+	if err := conf.WriteKubecfg(pathOptions); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Emergency admin credential %s for %q expires at %s and is recorded in %s\n", record.SerialNumber, record.CommonName, record.NotAfter.Format(time.RFC3339), recordPath)
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdexternal"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	createSecretEtcdClientLong = templates.LongDesc(i18n.T(`
+	Create the client credentials of external etcd clusters and store them in the state store.
+	These are used by the API server to connect to the etcd clusters that are not managed by kOps.`))
+
+	createSecretEtcdClientExample = templates.Examples(i18n.T(`
+	# Create the client credentials of the external etcd clusters.
+	kops create secret etcd-client --ca etcd-ca.crt --cert etcd-client.crt --key etcd-client.key \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Replace the existing client credentials of the external etcd clusters.
+	kops create secret etcd-client --ca etcd-ca.crt --cert etcd-client.crt --key etcd-client.key --force \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	`))
+
+	createSecretEtcdClientShort = i18n.T(`Create the client credentials of external etcd clusters.`)
+)
+
+type CreateSecretEtcdClientOptions struct {
+	ClusterName string
+	SecretName  string
+	CAPath      string
+	CertPath    string
+	KeyPath     string
+	Force       bool
+}
+
+func NewCmdCreateSecretEtcdClient(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateSecretEtcdClientOptions{
+		SecretName: etcdexternal.DefaultClientSecret,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "etcd-client [CLUSTER] --ca FILENAME --cert FILENAME --key FILENAME",
+		Short:             createSecretEtcdClientShort,
+		Long:              createSecretEtcdClientLong,
+		Example:           createSecretEtcdClientExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateSecretEtcdClient(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.CAPath, "ca", "", "Path to the PEM-encoded certificate of the CA of the etcd clusters")
+	cmd.MarkFlagRequired("ca")
+	cmd.Flags().StringVar(&options.CertPath, "cert", "", "Path to the PEM-encoded client certificate")
+	cmd.MarkFlagRequired("cert")
+	cmd.Flags().StringVar(&options.KeyPath, "key", "", "Path to the PEM-encoded private key of the client certificate")
+	cmd.MarkFlagRequired("key")
+	cmd.Flags().StringVar(&options.SecretName, "secret-name", options.SecretName, "Name of the secret, as referenced by the clientSecret of the external etcd clusters")
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force replace the secret if it already exists")
+
+	return cmd
+}
+
+func RunCreateSecretEtcdClient(ctx context.Context, f commandutils.Factory, out io.Writer, options *CreateSecretEtcdClientOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	credentials := &etcdexternal.ClientCredentials{}
+	for _, file := range []struct {
+		path  string
+		value *string
+	}{
+		{options.CAPath, &credentials.CA},
+		{options.CertPath, &credentials.Certificate},
+		{options.KeyPath, &credentials.Key},
+	} {
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			return fmt.Errorf("reading %v: %v", file.path, err)
+		}
+		*file.value = string(data)
+	}
+	if err := credentials.Validate(); err != nil {
+		return err
+	}
+
+	data, err := credentials.Encode()
+	if err != nil {
+		return err
+	}
+	secret := &fi.Secret{
+		Data: data,
+	}
+
+	if !options.Force {
+		_, created, err := secretStore.GetOrCreateSecret(ctx, options.SecretName, secret)
+		if err != nil {
+			return fmt.Errorf("error adding etcd client secret: %v", err)
+		}
+		if !created {
+			return fmt.Errorf("failed to create the etcd client secret as it already exists. Pass the `--force` flag to replace an existing secret")
+		}
+	} else {
+		_, err := secretStore.ReplaceSecret(options.SecretName, secret)
+		if err != nil {
+			return fmt.Errorf("updating etcd client secret: %v", err)
+		}
+	}
+
+	return nil
+}
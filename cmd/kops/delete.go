@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/util/pkg/text"
 	"k8s.io/kubectl/pkg/util/i18n"
@@ -74,6 +75,9 @@ func NewCmdDelete(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdDeleteSecret(f, out))
 	cmd.AddCommand(NewCmdDeleteSSHPublicKey(f, out))
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+	commandutils.SetApplyFlag(cmd, "yes")
+
 	return cmd
 }
 
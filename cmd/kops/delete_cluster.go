@@ -20,8 +20,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"time"
 
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
@@ -99,10 +102,12 @@ func NewCmdDeleteCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().IntVar(&options.count, "count", options.count, "Number of consecutive failures to make progress deleting the cluster resources")
 	cmd.Flags().DurationVar(&options.interval, "interval", options.interval, "Time in duration to wait between deletion attempts")
 
+	commandutils.SetApplyFlag(cmd, "yes")
+
 	return cmd
 }
 
-func RunDeleteCluster(ctx context.Context, f *util.Factory, out io.Writer, options *DeleteClusterOptions) error {
+func RunDeleteCluster(ctx context.Context, f commandutils.Factory, out io.Writer, options *DeleteClusterOptions) error {
 	clusterName := options.ClusterName
 	if clusterName == "" {
 		return fmt.Errorf("--name is required (for safety)")
@@ -128,6 +133,12 @@ func RunDeleteCluster(ctx context.Context, f *util.Factory, out io.Writer, optio
 		if err != nil {
 			return err
 		}
+
+		if options.Yes {
+			if err := checkDeletionProtection(ctx, cluster, cloud, clusterName, nil); err != nil {
+				return err
+			}
+		}
 	}
 
 	wouldDeleteCloudResources := false
@@ -184,6 +195,12 @@ func RunDeleteCluster(ctx context.Context, f *util.Factory, out io.Writer, optio
 				return nil
 			}
 
+			if options.External {
+				if err := checkDeletionProtection(ctx, cluster, cloud, clusterName, clusterResources); err != nil {
+					return err
+				}
+			}
+
 			fmt.Fprintf(out, "\n")
 
 			err = resourceops.DeleteResources(cloud, clusterResources, options.count, options.interval, options.wait)
@@ -227,3 +244,34 @@ func completeRegion(cmd *cobra.Command, args []string, toComplete string) ([]str
 	// TODO call into cloud provider(s) to get list of valid regions
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
+
+// checkDeletionProtection returns an error if the cluster has deletion protection enabled.
+// External clusters have no spec, so the termination protection of their instances is checked instead.
+func checkDeletionProtection(ctx context.Context, cluster *kopsapi.Cluster, cloud fi.Cloud, clusterName string, clusterResources map[string]*resources.Resource) error {
+	if cluster != nil {
+		if fi.ValueOf(cluster.Spec.DeletionProtection) {
+			return fmt.Errorf("cluster %q has deletion protection enabled; set spec.deletionProtection to false with \"kops edit cluster\" before deleting it", clusterName)
+		}
+		return nil
+	}
+
+	awsCloud, ok := cloud.(awsup.AWSCloud)
+	if !ok {
+		return nil
+	}
+	var ids []string
+	for _, r := range clusterResources {
+		if r.Type == string(ec2types.ResourceTypeInstance) {
+			ids = append(ids, r.ID)
+		}
+	}
+	sort.Strings(ids)
+	protected, err := awsup.FindTerminationProtectedInstances(ctx, awsCloud, ids...)
+	if err != nil {
+		return err
+	}
+	if len(protected) != 0 {
+		return fmt.Errorf("cluster %q has deletion protection enabled on instances %s; disable their termination protection before deleting it", clusterName, strings.Join(protected, ", "))
+	}
+	return nil
+}
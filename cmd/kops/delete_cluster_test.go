@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// terminationProtectionEC2 reports the termination protection of instances.
+type terminationProtectionEC2 struct {
+	*mockec2.MockEC2
+
+	protected map[string]bool
+}
+
+func (m *terminationProtectionEC2) DescribeInstanceAttribute(ctx context.Context, request *ec2.DescribeInstanceAttributeInput, optFns ...func(*ec2.Options)) (*ec2.DescribeInstanceAttributeOutput, error) {
+	return &ec2.DescribeInstanceAttributeOutput{
+		InstanceId:            request.InstanceId,
+		DisableApiTermination: &ec2types.AttributeBooleanValue{Value: aws.Bool(m.protected[aws.ToString(request.InstanceId)])},
+	}, nil
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	instances := map[string]*resources.Resource{
+		"instance:i-control-plane": {ID: "i-control-plane", Type: string(ec2types.ResourceTypeInstance)},
+		"instance:i-node":          {ID: "i-node", Type: string(ec2types.ResourceTypeInstance)},
+		"vpc:vpc-1234":             {ID: "vpc-1234", Type: "vpc"},
+	}
+
+	grid := []struct {
+		name          string
+		cluster       *kopsapi.Cluster
+		protected     map[string]bool
+		expectedError string
+	}{
+		{
+			name:    "cluster without deletion protection",
+			cluster: &kopsapi.Cluster{},
+		},
+		{
+			name: "cluster with deletion protection",
+			cluster: &kopsapi.Cluster{
+				Spec: kopsapi.ClusterSpec{DeletionProtection: fi.PtrTo(true)},
+			},
+			expectedError: "has deletion protection enabled; set spec.deletionProtection to false",
+		},
+		{
+			name: "external cluster without termination protection",
+		},
+		{
+			name:          "external cluster with termination protection",
+			protected:     map[string]bool{"i-control-plane": true},
+			expectedError: "has deletion protection enabled on instances i-control-plane",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cloud := awsup.BuildMockAWSCloud("us-test-1", "abc")
+			cloud.MockEC2 = &terminationProtectionEC2{
+				MockEC2:   &mockec2.MockEC2{},
+				protected: g.protected,
+			}
+
+			err := checkDeletionProtection(context.Background(), g.cluster, cloud, "minimal.example.com", instances)
+			if g.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+				t.Errorf("expected error containing %q, got %v", g.expectedError, err)
+			}
+		})
+	}
+}
@@ -21,21 +21,26 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/cloudinstances"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/instancegroups"
 	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -67,11 +72,28 @@ type DeleteInstanceOptions struct {
 
 	ClusterName string
 
-	InstanceID string
+	// InstanceIDs are the IDs of the instances or the names of the nodes to delete
+	InstanceIDs []string
+
+	// Selector selects the instances to delete by the labels of their nodes
+	Selector string
+
+	// InstanceGroup selects Count instances of the named instance group to delete
+	InstanceGroup string
+	Count         int
+
+	// Filename is the name of a file listing the IDs of the instances or the names of the nodes to delete, one per line
+	Filename string
 
 	Surge bool
 }
 
+// isBatch returns true if more than a single named instance was requested. Batches are deleted
+// following the rolling update settings of their instance groups.
+func (o *DeleteInstanceOptions) isBatch() bool {
+	return len(o.InstanceIDs) > 1 || o.Selector != "" || o.InstanceGroup != "" || o.Filename != ""
+}
+
 func (o *DeleteInstanceOptions) initDefaults() {
 	d := &RollingUpdateOptions{}
 	d.InitDefaults()
@@ -102,6 +124,18 @@ func NewCmdDeleteInstance(f *util.Factory, out io.Writer) *cobra.Command {
 		# Delete an instance from the currently active cluster without
 		validation or draining.
 		kops delete instance --cloudonly i-0a5ed581b862d3425 --yes
+
+		# Delete several instances, following the rolling update settings of their instance groups.
+		kops delete instance i-0a5ed581b862d3425 i-0e2d1a5b1c1a2b3c4 --yes
+
+		# Delete the instances whose nodes match a label selector.
+		kops delete instance --selector topology.kubernetes.io/zone=us-east-1a --yes
+
+		# Delete three instances of an instance group.
+		kops delete instance --instance-group nodes-us-east-1a --count 3 --yes
+
+		# Delete the instances of the nodes listed in a file.
+		kops delete instance --filename bad-nodes.txt --yes
 		`))
 
 	deleteInstanceShort := i18n.T(`Delete an instance.`)
@@ -110,7 +144,7 @@ func NewCmdDeleteInstance(f *util.Factory, out io.Writer) *cobra.Command {
 	options.initDefaults()
 
 	cmd := &cobra.Command{
-		Use:     "instance INSTANCE|NODE",
+		Use:     "instance [INSTANCE|NODE]...",
 		Short:   deleteInstanceShort,
 		Long:    deleteInstanceLong,
 		Example: deleteInstanceExample,
@@ -120,13 +154,28 @@ func NewCmdDeleteInstance(f *util.Factory, out io.Writer) *cobra.Command {
 				return fmt.Errorf("--name is required")
 			}
 
-			if len(args) == 0 {
+			options.InstanceIDs = args
+
+			sources := 0
+			for _, set := range []bool{len(args) != 0, options.Selector != "", options.InstanceGroup != "", options.Filename != ""} {
+				if set {
+					sources++
+				}
+			}
+			if sources == 0 {
 				return fmt.Errorf("must specify ID of instance or name of node to delete")
 			}
-			options.InstanceID = args[0]
-
-			if len(args) != 1 {
-				return fmt.Errorf("can only delete one instance at a time")
+			if sources > 1 {
+				return fmt.Errorf("can only specify one of instances, --selector, --instance-group or --filename")
+			}
+			if options.InstanceGroup != "" && options.Count < 1 {
+				return fmt.Errorf("--count must be at least 1 when using --instance-group")
+			}
+			if options.InstanceGroup == "" && options.Count != 0 {
+				return fmt.Errorf("--count can only be used with --instance-group")
+			}
+			if options.Selector != "" && options.CloudOnly {
+				return fmt.Errorf("--selector cannot be used with --cloudonly")
 			}
 
 			return nil
@@ -138,7 +187,15 @@ func NewCmdDeleteInstance(f *util.Factory, out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&options.CloudOnly, "cloudonly", options.CloudOnly, "Perform deletion update without confirming progress with Kubernetes")
-	cmd.Flags().BoolVar(&options.Surge, "surge", options.Surge, "Surge by detaching the node from the ASG before deletion")
+	cmd.Flags().BoolVar(&options.Surge, "surge", options.Surge, "Surge by detaching the node from the ASG before deletion. Batches of instances surge according to the rolling update settings of their instance group; --surge=false deletes them without surging")
+	cmd.Flags().StringVarP(&options.Selector, "selector", "l", options.Selector, "Delete the instances whose nodes match this label selector")
+	cmd.Flags().StringVar(&options.InstanceGroup, "instance-group", options.InstanceGroup, "Delete instances of this instance group")
+	cmd.RegisterFlagCompletionFunc("instance-group", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// The arguments are instances, not the cluster name
+		return completeInstanceGroup(f, nil, nil)(cmd, nil, toComplete)
+	})
+	cmd.Flags().IntVar(&options.Count, "count", options.Count, "Number of instances of the instance group to delete")
+	cmd.Flags().StringVarP(&options.Filename, "filename", "f", options.Filename, "File listing the IDs of the instances or names of the nodes to delete, one per line")
 
 	cmd.Flags().DurationVar(&options.ValidationTimeout, "validation-timeout", options.ValidationTimeout, "Maximum time to wait for a cluster to validate")
 	cmd.Flags().Int32Var(&options.ValidateCount, "validate-count", options.ValidateCount, "Number of times that a cluster needs to be validated after single node update")
@@ -149,6 +206,8 @@ func NewCmdDeleteInstance(f *util.Factory, out io.Writer) *cobra.Command {
 
 	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to immediately delete the instance")
 
+	commandutils.SetApplyFlag(cmd, "yes")
+
 	return cmd
 }
 
@@ -193,10 +252,14 @@ func RunDeleteInstance(ctx context.Context, f *util.Factory, out io.Writer, opti
 		return err
 	}
 
-	cloudMember := findDeletionNode(groups, options)
+	if options.isBatch() {
+		return runDeleteInstances(ctx, clientSet, cluster, cloud, k8sClient, host, list, groups, out, options)
+	}
+
+	cloudMember := findDeletionNode(groups, options.InstanceIDs[0], options.CloudOnly)
 
 	if cloudMember == nil {
-		return fmt.Errorf("could not find instance %v", options.InstanceID)
+		return fmt.Errorf("could not find instance %v", options.InstanceIDs[0])
 	}
 
 	if options.CloudOnly {
@@ -284,20 +347,20 @@ func getNodes(ctx context.Context, cluster *kopsapi.Cluster, verbose bool) (kube
 	return k8sClient, config.Host, nodes, nil
 }
 
-func deleteNodeMatch(cloudMember *cloudinstances.CloudInstance, options *DeleteInstanceOptions) bool {
-	return cloudMember.ID == options.InstanceID ||
-		(!options.CloudOnly && cloudMember.Node != nil && cloudMember.Node.Name == options.InstanceID)
+func deleteNodeMatch(cloudMember *cloudinstances.CloudInstance, id string, cloudOnly bool) bool {
+	return cloudMember.ID == id ||
+		(!cloudOnly && cloudMember.Node != nil && cloudMember.Node.Name == id)
 }
 
-func findDeletionNode(groups map[string]*cloudinstances.CloudInstanceGroup, options *DeleteInstanceOptions) *cloudinstances.CloudInstance {
+func findDeletionNode(groups map[string]*cloudinstances.CloudInstanceGroup, id string, cloudOnly bool) *cloudinstances.CloudInstance {
 	for _, group := range groups {
 		for _, r := range group.Ready {
-			if deleteNodeMatch(r, options) {
+			if deleteNodeMatch(r, id, cloudOnly) {
 				return r
 			}
 		}
 		for _, r := range group.NeedUpdate {
-			if deleteNodeMatch(r, options) {
+			if deleteNodeMatch(r, id, cloudOnly) {
 				return r
 			}
 		}
@@ -305,14 +368,205 @@ func findDeletionNode(groups map[string]*cloudinstances.CloudInstanceGroup, opti
 	return nil
 }
 
+// runDeleteInstances deletes a batch of instances. The instances of each instance group are
+// replaced following the rolling update settings of the group, as if they needed updating.
+func runDeleteInstances(ctx context.Context, clientSet simple.Clientset, cluster *kopsapi.Cluster, cloud fi.Cloud, k8sClient kubernetes.Interface, host string, list *kopsapi.InstanceGroupList, groups map[string]*cloudinstances.CloudInstanceGroup, out io.Writer, options *DeleteInstanceOptions) error {
+	selected, err := selectDeletionInstances(groups, options)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no instances found for deletion")
+	}
+
+	for _, cloudMember := range selected {
+		if cloudMember.Node != nil {
+			fmt.Fprintf(out, "Instance %v (%v) of instance group %v found for deletion\n", cloudMember.ID, cloudMember.Node.Name, cloudMember.CloudInstanceGroup.HumanName)
+		} else if options.CloudOnly {
+			fmt.Fprintf(out, "Instance %v of instance group %v found for deletion\n", cloudMember.ID, cloudMember.CloudInstanceGroup.HumanName)
+		} else {
+			fmt.Fprintf(os.Stderr, "Instance is not a member of the cluster\n")
+			fmt.Fprintf(os.Stderr, "Use --cloudonly to do a deletion without confirming progress with the k8s API\n\n")
+			return fmt.Errorf("error finding node name for instance: %v", cloudMember.ID)
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to delete instances\n")
+		return nil
+	}
+
+	d := &instancegroups.RollingUpdateCluster{
+		Clientset:               clientSet,
+		Cluster:                 cluster,
+		Ctx:                     ctx,
+		Cloud:                   cloud,
+		K8sClient:               k8sClient,
+		FailOnDrainError:        options.FailOnDrainError,
+		FailOnValidate:          options.FailOnValidate,
+		CloudOnly:               options.CloudOnly,
+		ClusterName:             options.ClusterName,
+		PostDrainDelay:          options.PostDrainDelay,
+		ValidationTimeout:       options.ValidationTimeout,
+		ValidateCount:           int(options.ValidateCount),
+		ValidateTickDuration:    30 * time.Second,
+		ValidateSuccessDuration: 10 * time.Second,
+	}
+
+	if !options.CloudOnly {
+		d.ClusterValidator, err = validation.NewClusterValidator(cluster, cloud, list, host, k8sClient)
+		if err != nil {
+			return fmt.Errorf("cannot create cluster validator: %v", err)
+		}
+	}
+
+	return d.RollingUpdate(deletionGroups(cluster, groups, selected, options.Surge), list)
+}
+
+// selectDeletionInstances returns the instances selected for deletion by the options.
+func selectDeletionInstances(groups map[string]*cloudinstances.CloudInstanceGroup, options *DeleteInstanceOptions) ([]*cloudinstances.CloudInstance, error) {
+	var selected []*cloudinstances.CloudInstance
+
+	ids := options.InstanceIDs
+	if options.Filename != "" {
+		contents, err := os.ReadFile(options.Filename)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %w", options.Filename, err)
+		}
+		for _, line := range strings.Split(string(contents), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				ids = append(ids, line)
+			}
+		}
+	}
+	for _, id := range ids {
+		cloudMember := findDeletionNode(groups, id, options.CloudOnly)
+		if cloudMember == nil {
+			return nil, fmt.Errorf("could not find instance %v", id)
+		}
+		selected = append(selected, cloudMember)
+	}
+
+	if options.Selector != "" {
+		selector, err := labels.Parse(options.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing selector %q: %w", options.Selector, err)
+		}
+		for _, name := range sortGroupNames(groups) {
+			group := groups[name]
+			for _, cloudMember := range groupInstances(group) {
+				if cloudMember.Node != nil && selector.Matches(labels.Set(cloudMember.Node.Labels)) {
+					selected = append(selected, cloudMember)
+				}
+			}
+		}
+	}
+
+	if options.InstanceGroup != "" {
+		group := groups[options.InstanceGroup]
+		if group == nil {
+			return nil, fmt.Errorf("could not find instance group %q", options.InstanceGroup)
+		}
+		// Prefer instances that need updating anyway
+		candidates := groupInstances(group)
+		if len(candidates) < options.Count {
+			return nil, fmt.Errorf("instance group %q has only %d instances", options.InstanceGroup, len(candidates))
+		}
+		selected = candidates[:options.Count]
+	}
+
+	// Drop duplicates, which a file or a mix of instance IDs and node names may contain
+	seen := make(map[string]bool)
+	var unique []*cloudinstances.CloudInstance
+	for _, cloudMember := range selected {
+		if !seen[cloudMember.ID] {
+			seen[cloudMember.ID] = true
+			unique = append(unique, cloudMember)
+		}
+	}
+
+	// A rolling update skips suspended instance groups, which would leave their instances in place
+	for _, cloudMember := range unique {
+		if ig := cloudMember.CloudInstanceGroup.InstanceGroup; ig != nil && ig.IsSuspended() {
+			return nil, fmt.Errorf("instance %v belongs to the suspended instance group %q; resume the instance group or delete the instance on its own", cloudMember.ID, ig.Name)
+		}
+	}
+	return unique, nil
+}
+
+// deletionGroups returns copies of the groups of the selected instances, in which only the selected instances need updating.
+// Without surge, the copies of the instance groups do not surge, whatever their rolling update settings.
+func deletionGroups(cluster *kopsapi.Cluster, groups map[string]*cloudinstances.CloudInstanceGroup, selected []*cloudinstances.CloudInstance, surge bool) map[string]*cloudinstances.CloudInstanceGroup {
+	isSelected := make(map[string]bool)
+	for _, cloudMember := range selected {
+		isSelected[cloudMember.ID] = true
+	}
+
+	result := make(map[string]*cloudinstances.CloudInstanceGroup)
+	for name, group := range groups {
+		deletion := *group
+		deletion.Ready = nil
+		deletion.NeedUpdate = nil
+		for _, cloudMember := range groupInstances(group) {
+			if isSelected[cloudMember.ID] {
+				deletion.NeedUpdate = append(deletion.NeedUpdate, cloudMember)
+			} else {
+				deletion.Ready = append(deletion.Ready, cloudMember)
+			}
+		}
+		if len(deletion.NeedUpdate) == 0 {
+			continue
+		}
+		if !surge && deletion.InstanceGroup != nil {
+			deletion.InstanceGroup = withoutSurge(cluster, deletion.InstanceGroup)
+		}
+		result[name] = &deletion
+	}
+	return result
+}
+
+// withoutSurge returns a copy of the instance group whose rolling update does not surge. As in a rolling update
+// without surge, at least one instance is then unavailable at a time.
+func withoutSurge(cluster *kopsapi.Cluster, ig *kopsapi.InstanceGroup) *kopsapi.InstanceGroup {
+	ig = ig.DeepCopy()
+	if ig.Spec.RollingUpdate == nil {
+		ig.Spec.RollingUpdate = &kopsapi.RollingUpdate{}
+	}
+	noSurge := intstr.FromInt(0)
+	ig.Spec.RollingUpdate.MaxSurge = &noSurge
+
+	maxUnavailable := ig.Spec.RollingUpdate.MaxUnavailable
+	if maxUnavailable == nil && cluster.Spec.RollingUpdate != nil {
+		maxUnavailable = cluster.Spec.RollingUpdate.MaxUnavailable
+	}
+	if maxUnavailable != nil && maxUnavailable.Type == intstr.Int && maxUnavailable.IntVal == 0 {
+		one := intstr.FromInt(1)
+		ig.Spec.RollingUpdate.MaxUnavailable = &one
+	}
+	return ig
+}
+
+// groupInstances returns the instances of a group, starting with those that need updating.
+func groupInstances(group *cloudinstances.CloudInstanceGroup) []*cloudinstances.CloudInstance {
+	var instances []*cloudinstances.CloudInstance
+	instances = append(instances, group.NeedUpdate...)
+	return append(instances, group.Ready...)
+}
+
+func sortGroupNames(groups map[string]*cloudinstances.CloudInstanceGroup) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func completeInstanceOrNode(f commandutils.Factory, options *DeleteInstanceOptions) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		ctx := cmd.Context()
 
-		if len(args) > 0 {
-			return nil, cobra.ShellCompDirectiveNoFileComp
-		}
-
 		commandutils.ConfigureKlogForCompletion()
 
 		cluster, clientSet, completions, directive := GetClusterForCompletion(ctx, f, nil)
@@ -0,0 +1,232 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func buildDeletionTestGroups() map[string]*cloudinstances.CloudInstanceGroup {
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	for _, name := range []string{"nodes-a", "nodes-b"} {
+		group := &cloudinstances.CloudInstanceGroup{
+			HumanName: name,
+			InstanceGroup: &kopsapi.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       kopsapi.InstanceGroupSpec{Role: kopsapi.InstanceGroupRoleNode},
+			},
+		}
+		for i, status := range []string{cloudinstances.CloudInstanceStatusUpToDate, cloudinstances.CloudInstanceStatusNeedsUpdate, cloudinstances.CloudInstanceStatusUpToDate} {
+			id := "i-" + name + "-" + string(rune('0'+i))
+			node := &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-" + name + "-" + string(rune('0'+i)),
+					Labels: map[string]string{"zone": name},
+				},
+			}
+			if i == 2 {
+				node.Labels["bad"] = "true"
+			}
+			if _, err := group.NewCloudInstance(id, status, node); err != nil {
+				panic(err)
+			}
+		}
+		groups[name] = group
+	}
+	return groups
+}
+
+func TestSelectDeletionInstances(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "nodes.txt")
+	if err := os.WriteFile(filename, []byte("# bad nodes\nnode-nodes-a-0\n\ni-nodes-b-1\ni-nodes-a-0\n"), 0o644); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	grid := []struct {
+		name        string
+		options     DeleteInstanceOptions
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "instance IDs and node names",
+			options:  DeleteInstanceOptions{InstanceIDs: []string{"i-nodes-a-1", "node-nodes-b-2"}},
+			expected: []string{"i-nodes-a-1", "i-nodes-b-2"},
+		},
+		{
+			name:        "unknown instance",
+			options:     DeleteInstanceOptions{InstanceIDs: []string{"i-nodes-a-1", "i-unknown"}},
+			expectedErr: true,
+		},
+		{
+			name:        "node names are not matched when cloud only",
+			options:     DeleteInstanceOptions{InstanceIDs: []string{"node-nodes-a-1"}, CloudOnly: true},
+			expectedErr: true,
+		},
+		{
+			name:     "selector",
+			options:  DeleteInstanceOptions{Selector: "bad=true"},
+			expected: []string{"i-nodes-a-2", "i-nodes-b-2"},
+		},
+		{
+			name:     "selector within zone",
+			options:  DeleteInstanceOptions{Selector: "zone=nodes-b"},
+			expected: []string{"i-nodes-b-0", "i-nodes-b-1", "i-nodes-b-2"},
+		},
+		{
+			name:     "instance group prefers instances needing update",
+			options:  DeleteInstanceOptions{InstanceGroup: "nodes-a", Count: 2},
+			expected: []string{"i-nodes-a-0", "i-nodes-a-1"},
+		},
+		{
+			name:        "instance group too small",
+			options:     DeleteInstanceOptions{InstanceGroup: "nodes-a", Count: 4},
+			expectedErr: true,
+		},
+		{
+			name:        "unknown instance group",
+			options:     DeleteInstanceOptions{InstanceGroup: "nodes-c", Count: 1},
+			expectedErr: true,
+		},
+		{
+			name:     "file with duplicates",
+			options:  DeleteInstanceOptions{Filename: filename},
+			expected: []string{"i-nodes-a-0", "i-nodes-b-1"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			selected, err := selectDeletionInstances(buildDeletionTestGroups(), &g.options)
+			if g.expectedErr {
+				if err == nil {
+					t.Fatalf("expected error, got %d instances", len(selected))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var actual []string
+			for _, cloudMember := range selected {
+				actual = append(actual, cloudMember.ID)
+			}
+			sort.Strings(actual)
+			if !reflect.DeepEqual(actual, g.expected) {
+				t.Errorf("expected %v, got %v", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDeletionGroups(t *testing.T) {
+	groups := buildDeletionTestGroups()
+	selected, err := selectDeletionInstances(groups, &DeleteInstanceOptions{InstanceIDs: []string{"i-nodes-a-0", "i-nodes-a-2"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := deletionGroups(&kopsapi.Cluster{}, groups, selected, true)
+	if len(result) != 1 || result["nodes-a"] == nil {
+		t.Fatalf("expected only group nodes-a, got %v", result)
+	}
+	group := result["nodes-a"]
+
+	ids := func(instances []*cloudinstances.CloudInstance) []string {
+		var ids []string
+		for _, instance := range instances {
+			ids = append(ids, instance.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+	if actual, expected := ids(group.NeedUpdate), []string{"i-nodes-a-0", "i-nodes-a-2"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected NeedUpdate %v, got %v", expected, actual)
+	}
+	if actual, expected := ids(group.Ready), []string{"i-nodes-a-1"}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected Ready %v, got %v", expected, actual)
+	}
+	if len(groups["nodes-a"].NeedUpdate) != 1 || len(groups["nodes-a"].Ready) != 2 {
+		t.Errorf("original group was modified")
+	}
+}
+
+func TestSelectDeletionInstancesSuspendedGroup(t *testing.T) {
+	groups := buildDeletionTestGroups()
+	groups["nodes-b"].InstanceGroup.Spec.Suspended = fi.PtrTo(true)
+
+	if _, err := selectDeletionInstances(groups, &DeleteInstanceOptions{InstanceIDs: []string{"i-nodes-a-0", "i-nodes-a-2"}}); err != nil {
+		t.Errorf("unexpected error for instances of an active group: %v", err)
+	}
+	for _, options := range []DeleteInstanceOptions{
+		{InstanceIDs: []string{"i-nodes-a-0", "i-nodes-b-2"}},
+		{Selector: "bad=true"},
+		{InstanceGroup: "nodes-b", Count: 1},
+	} {
+		if selected, err := selectDeletionInstances(groups, &options); err == nil {
+			t.Errorf("expected error for instances of a suspended group, got %d instances for %+v", len(selected), options)
+		}
+	}
+}
+
+func TestDeletionGroupsWithoutSurge(t *testing.T) {
+	groups := buildDeletionTestGroups()
+	maxUnavailable := intstr.FromInt(0)
+	maxSurge := intstr.FromString("50%")
+	groups["nodes-a"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{MaxSurge: &maxSurge}
+	cluster := &kopsapi.Cluster{Spec: kopsapi.ClusterSpec{RollingUpdate: &kopsapi.RollingUpdate{MaxUnavailable: &maxUnavailable}}}
+
+	selected, err := selectDeletionInstances(groups, &DeleteInstanceOptions{InstanceIDs: []string{"i-nodes-a-0", "i-nodes-b-0"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := deletionGroups(cluster, groups, selected, true)
+	if actual := result["nodes-a"].InstanceGroup.Spec.RollingUpdate.MaxSurge; actual.String() != "50%" {
+		t.Errorf("expected the surge of the instance group with --surge, got %v", actual)
+	}
+	if result["nodes-b"].InstanceGroup.Spec.RollingUpdate != nil {
+		t.Errorf("expected the rolling update settings of the instance group with --surge, got %v", result["nodes-b"].InstanceGroup.Spec.RollingUpdate)
+	}
+
+	result = deletionGroups(cluster, groups, selected, false)
+	for _, name := range []string{"nodes-a", "nodes-b"} {
+		rollingUpdate := result[name].InstanceGroup.Spec.RollingUpdate
+		if rollingUpdate == nil || rollingUpdate.MaxSurge.String() != "0" {
+			t.Errorf("expected no surge for %s with --surge=false, got %v", name, rollingUpdate)
+			continue
+		}
+		// Without surge, the instances can only be replaced one after the other
+		if rollingUpdate.MaxUnavailable == nil || rollingUpdate.MaxUnavailable.String() != "1" {
+			t.Errorf("expected an unavailable instance for %s with --surge=false, got %v", name, rollingUpdate.MaxUnavailable)
+		}
+	}
+	if groups["nodes-a"].InstanceGroup.Spec.RollingUpdate.MaxSurge.String() != "50%" || groups["nodes-b"].InstanceGroup.Spec.RollingUpdate != nil {
+		t.Errorf("original instance groups were modified")
+	}
+}
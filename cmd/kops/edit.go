@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
 
@@ -34,5 +35,7 @@ func NewCmdEdit(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdEditCluster(f, out))
 	cmd.AddCommand(NewCmdEditInstanceGroup(f, out))
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+
 	return cmd
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
 
@@ -32,6 +33,9 @@ func NewCmdExport(f *util.Factory, out io.Writer) *cobra.Command {
 		Short: exportShort,
 	}
 
+	// Exporting only writes the local kubeconfig
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
 	// create subcommands
 	cmd.AddCommand(NewCmdExportKubeconfig(f, out))
 
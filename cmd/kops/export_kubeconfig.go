@@ -49,6 +49,12 @@ var (
 
 	# export using the internal DNS name, bypassing the cloud load balancer
 	kops export kubeconfig k8s-cluster.example.com --internal
+
+	# export a cluster admin user whose 1 hour credential is refreshed automatically when it expires
+	kops export kubeconfig k8s-cluster.example.com --admin=1h --auto-refresh
+
+	# export a cluster admin user whose 15 minute credential is issued by kops-controller for your cloud identity
+	kops export kubeconfig k8s-cluster.example.com --admin=15m --cloud-identity
 	`))
 
 	exportKubeconfigShort = i18n.T(`Export kubeconfig.`)
@@ -64,6 +70,12 @@ type ExportKubeconfigOptions struct {
 
 	// UseKopsAuthenticationPlugin controls whether we should use the kOps auth helper instead of a static credential
 	UseKopsAuthenticationPlugin bool
+
+	// AutoRefresh uses the kOps auth helper to issue admin credentials with the admin lifetime when they expire
+	AutoRefresh bool
+
+	// CloudIdentity uses the kOps auth helper to obtain admin credentials from kops-controller in exchange for the cloud identity
+	CloudIdentity bool
 }
 
 func NewCmdExportKubeconfig(f *util.Factory, out io.Writer) *cobra.Command {
@@ -79,6 +91,12 @@ func NewCmdExportKubeconfig(f *util.Factory, out io.Writer) *cobra.Command {
 			if options.admin != 0 && options.user != "" {
 				return fmt.Errorf("cannot use both --admin and --user")
 			}
+			if options.AutoRefresh && options.user != "" {
+				return fmt.Errorf("cannot use both --auto-refresh and --user")
+			}
+			if options.CloudIdentity && options.user != "" {
+				return fmt.Errorf("cannot use both --cloud-identity and --user")
+			}
 			if options.all {
 				if len(args) != 0 {
 					return fmt.Errorf("cannot use both --all flag and positional arguments")
@@ -102,6 +120,8 @@ func NewCmdExportKubeconfig(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("user", completeKubecfgUser)
 	cmd.Flags().BoolVar(&options.internal, "internal", options.internal, "Use the cluster's internal DNS name")
 	cmd.Flags().BoolVar(&options.UseKopsAuthenticationPlugin, "auth-plugin", options.UseKopsAuthenticationPlugin, "Use the kOps authentication plugin")
+	cmd.Flags().BoolVar(&options.AutoRefresh, "auto-refresh", options.AutoRefresh, "Refresh the admin credential automatically when it expires, using the kOps authentication plugin")
+	cmd.Flags().BoolVar(&options.CloudIdentity, "cloud-identity", options.CloudIdentity, "Obtain short-lived admin credentials from kops-controller in exchange for your cloud identity, using the kOps authentication plugin")
 
 	return cmd
 }
@@ -154,7 +174,8 @@ func RunExportKubeconfig(ctx context.Context, f *util.Factory, out io.Writer, op
 			options.user,
 			options.internal,
 			f.KopsStateStore(),
-			options.UseKopsAuthenticationPlugin)
+			options.UseKopsAuthenticationPlugin || options.AutoRefresh,
+			options.CloudIdentity)
 		if err != nil {
 			return err
 		}
@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 )
 
 const fileHeader = `
@@ -50,6 +51,8 @@ func NewCmdGenCLIDocs(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.OutDir, "out", "", "path to write out to.")
 	cmd.MarkFlagDirname("out")
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
 	return cmd
 }
 
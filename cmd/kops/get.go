@@ -39,6 +39,7 @@ const (
 	OutputYaml  = "yaml"
 	OutputTable = "table"
 	OutputJSON  = "json"
+	OutputWide  = "wide"
 )
 
 func NewCmdGet(f *util.Factory, out io.Writer) *cobra.Command {
@@ -56,14 +57,15 @@ func NewCmdGet(f *util.Factory, out io.Writer) *cobra.Command {
 		},
 	}
 
-	cmd.PersistentFlags().StringVarP(&options.Output, "output", "o", options.Output, "output format. One of: table, yaml, json")
+	cmd.PersistentFlags().StringVarP(&options.Output, "output", "o", options.Output, "output format. One of: table, yaml, json. Instances can also be listed as wide")
 	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{OutputTable, OutputJSON, OutputYaml}, cobra.ShellCompDirectiveNoFileComp
+		return []string{OutputTable, OutputJSON, OutputYaml, OutputWide}, cobra.ShellCompDirectiveNoFileComp
 	})
 
 	// create subcommands
 	cmd.AddCommand(NewCmdGetAll(f, out, options))
 	cmd.AddCommand(NewCmdGetAssets(f, out, options))
+	cmd.AddCommand(NewCmdGetCloudResources(f, out, options))
 	cmd.AddCommand(NewCmdGetCluster(f, out, options))
 	cmd.AddCommand(NewCmdGetInstanceGroups(f, out, options))
 	cmd.AddCommand(NewCmdGetInstances(f, out, options))
@@ -71,6 +73,8 @@ func NewCmdGet(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdGetSecrets(f, out, options))
 	cmd.AddCommand(NewCmdGetSSHPublicKeys(f, out, options))
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
 	return cmd
 }
 
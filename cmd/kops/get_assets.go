@@ -97,6 +97,10 @@ func NewCmdGetAssets(f *util.Factory, out io.Writer, getOptions *GetOptions) *co
 
 	cmd.Flags().BoolVar(&options.Copy, "copy", options.Copy, "copy assets to local repository")
 
+	// Copying the assets writes to the asset repositories
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+	commandutils.SetApplyFlag(cmd, "copy")
+
 	return cmd
 }
 
@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	resourceops "k8s.io/kops/pkg/resources/ops"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/tables"
+)
+
+var (
+	getCloudResourcesLong = templates.LongDesc(i18n.T(`
+	Display the cloud resources owned by a cluster, which are the resources that
+	kops delete cluster would delete.
+
+	On AWS, the age and the estimated monthly cost of each resource are also shown.
+	Instances are priced at the on-demand price of their instance type, which is
+	queried from the AWS Pricing API and requires the pricing:GetProducts permission.
+	Other resources are priced at their list price in us-east-1. Usage-based charges,
+	such as data transfer, are not included.
+	`))
+
+	getCloudResourcesExample = templates.Examples(i18n.T(`
+	# Display the cloud resources of a cluster.
+	kops get cloudresources k8s-cluster.example.com
+
+	# Display the cloud resources as YAML, without querying prices.
+	kops get cloudresources k8s-cluster.example.com -o yaml --estimate-cost=false
+	`))
+
+	getCloudResourcesShort = i18n.T(`Display the cloud resources owned by a cluster.`)
+)
+
+type GetCloudResourcesOptions struct {
+	*GetOptions
+	// EstimateCost enables the estimation of the monthly cost of each resource.
+	EstimateCost bool
+}
+
+func NewCmdGetCloudResources(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
+	options := &GetCloudResourcesOptions{
+		GetOptions:   getOptions,
+		EstimateCost: true,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "cloudresources [CLUSTER]",
+		Aliases:           []string{"cloudresource"},
+		Short:             getCloudResourcesShort,
+		Long:              getCloudResourcesLong,
+		Example:           getCloudResourcesExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetCloudResources(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.EstimateCost, "estimate-cost", options.EstimateCost, "Estimate the monthly cost of each resource")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierPlan)
+
+	return cmd
+}
+
+type renderableCloudResource struct {
+	Type              string       `json:"type"`
+	ID                string       `json:"id"`
+	Name              string       `json:"name,omitempty"`
+	CreationTimestamp *metav1.Time `json:"creationTimestamp,omitempty"`
+	// EstimatedMonthlyCost is in USD.
+	EstimatedMonthlyCost *float64 `json:"estimatedMonthlyCost,omitempty"`
+}
+
+func RunGetCloudResources(ctx context.Context, f *util.Factory, out io.Writer, options *GetCloudResourcesOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	items, err := resourceops.Inventory(ctx, cloud, cluster, resourceops.InventoryOptions{
+		EstimateCost: options.EstimateCost,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch options.Output {
+	case OutputTable:
+		return cloudResourcesOutputTable(items, out)
+	case OutputYaml:
+		y, err := yaml.Marshal(asRenderableCloudResources(items))
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	case OutputJSON:
+		j, err := json.Marshal(asRenderableCloudResources(items))
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+}
+
+func cloudResourcesOutputTable(items []*resourceops.InventoryItem, out io.Writer) error {
+	if len(items) == 0 {
+		fmt.Fprintf(out, "No cloud resources found\n")
+		return nil
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("TYPE", func(i *resourceops.InventoryItem) string {
+		return i.Resource.Type
+	})
+	t.AddColumn("ID", func(i *resourceops.InventoryItem) string {
+		return i.Resource.ID
+	})
+	t.AddColumn("NAME", func(i *resourceops.InventoryItem) string {
+		return i.Resource.Name
+	})
+	t.AddColumn("AGE", func(i *resourceops.InventoryItem) string {
+		return humanAge(i.CreationTimestamp)
+	})
+	t.AddColumn("MONTHLY-COST", func(i *resourceops.InventoryItem) string {
+		if i.MonthlyCost == nil {
+			return ""
+		}
+		return formatUSD(*i.MonthlyCost)
+	})
+	if err := t.Render(items, out, "TYPE", "ID", "NAME", "AGE", "MONTHLY-COST"); err != nil {
+		return err
+	}
+
+	total := 0.0
+	estimated := false
+	for _, item := range items {
+		if item.MonthlyCost != nil {
+			total += *item.MonthlyCost
+			estimated = true
+		}
+	}
+	if estimated {
+		fmt.Fprintf(out, "\nEstimated monthly cost: %s\n", formatUSD(total))
+	}
+	return nil
+}
+
+func asRenderableCloudResources(items []*resourceops.InventoryItem) []*renderableCloudResource {
+	arr := make([]*renderableCloudResource, len(items))
+	for i, item := range items {
+		arr[i] = &renderableCloudResource{
+			Type:                 item.Resource.Type,
+			ID:                   item.Resource.ID,
+			Name:                 item.Resource.Name,
+			EstimatedMonthlyCost: item.MonthlyCost,
+		}
+		if item.CreationTimestamp != nil {
+			arr[i].CreationTimestamp = &metav1.Time{Time: *item.CreationTimestamp}
+		}
+	}
+	return arr
+}
+
+func formatUSD(amount float64) string {
+	return fmt.Sprintf("$%.2f", amount)
+}
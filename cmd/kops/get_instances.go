@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"k8s.io/kops/pkg/cloudinstances"
 	"k8s.io/kops/pkg/commands/commandutils"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"k8s.io/kops/util/pkg/tables"
@@ -43,12 +45,16 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
 
 var (
 	getInstancesExample = templates.Examples(i18n.T(`
 	# Display all instances.
 	kops get instances
+
+	# Display all instances, including their image and why each outdated instance needs an update.
+	kops get instances -o wide
 	`))
 
 	getInstancesShort = i18n.T(`Display cluster instances.`)
@@ -64,6 +70,13 @@ type renderableCloudInstance struct {
 	InstanceGroup string   `json:"instanceGroup"`
 	MachineType   string   `json:"machineType"`
 	State         string   `json:"state"`
+
+	Lifecycle         string       `json:"lifecycle,omitempty"`
+	ImageID           string       `json:"imageID,omitempty"`
+	DesiredImageID    string       `json:"desiredImageID,omitempty"`
+	ImageCreationTime *metav1.Time `json:"imageCreationTime,omitempty"`
+	LaunchTime        *metav1.Time `json:"launchTime,omitempty"`
+	NeedUpdateReasons []string     `json:"needUpdateReasons,omitempty"`
 }
 
 func NewCmdGetInstances(f *util.Factory, out io.Writer, options *GetOptions) *cobra.Command {
@@ -128,6 +141,13 @@ func RunGetInstances(ctx context.Context, f *util.Factory, out io.Writer, option
 		return err
 	}
 
+	// The update details cost extra API requests, so the default table, which does not show them, skips them
+	if awsCloud, ok := cloud.(awsup.AWSCloud); ok && options.Output != OutputTable {
+		if err := awsup.DescribeInstanceUpdates(ctx, awsCloud, cloudGroups); err != nil {
+			klog.Warningf("cannot describe pending instance updates: %v", err)
+		}
+	}
+
 	for _, cg := range cloudGroups {
 		cloudInstances = append(cloudInstances, cg.Ready...)
 		cloudInstances = append(cloudInstances, cg.NeedUpdate...)
@@ -135,8 +155,8 @@ func RunGetInstances(ctx context.Context, f *util.Factory, out io.Writer, option
 	}
 
 	switch options.Output {
-	case OutputTable:
-		return instanceOutputTable(cloudInstances, out)
+	case OutputTable, OutputWide:
+		return instanceOutputTable(cloudInstances, options.Output == OutputWide, out)
 	case OutputYaml:
 		y, err := yaml.Marshal(asRenderable(cloudInstances))
 		if err != nil {
@@ -160,7 +180,7 @@ func RunGetInstances(ctx context.Context, f *util.Factory, out io.Writer, option
 	}
 }
 
-func instanceOutputTable(instances []*cloudinstances.CloudInstance, out io.Writer) error {
+func instanceOutputTable(instances []*cloudinstances.CloudInstance, wide bool, out io.Writer) error {
 	fmt.Println("")
 	t := &tables.Table{}
 	t.AddColumn("ID", func(i *cloudinstances.CloudInstance) string {
@@ -195,11 +215,37 @@ func instanceOutputTable(instances []*cloudinstances.CloudInstance, out io.Write
 	t.AddColumn("STATE", func(i *cloudinstances.CloudInstance) string {
 		return string(i.State)
 	})
+	t.AddColumn("LIFECYCLE", func(i *cloudinstances.CloudInstance) string {
+		return i.Lifecycle
+	})
+	t.AddColumn("IMAGE", func(i *cloudinstances.CloudInstance) string {
+		return i.ImageID
+	})
+	t.AddColumn("IMAGE-AGE", func(i *cloudinstances.CloudInstance) string {
+		return humanAge(i.ImageCreationTime)
+	})
+	t.AddColumn("UPTIME", func(i *cloudinstances.CloudInstance) string {
+		return humanAge(i.LaunchTime)
+	})
+	t.AddColumn("UPDATE-REASONS", func(i *cloudinstances.CloudInstance) string {
+		return strings.Join(i.NeedUpdateReasons, ", ")
+	})
 
 	columns := []string{"ID", "NODE-NAME", "STATUS", "ROLES", "STATE", "INTERNAL-IP", "EXTERNAL-IP", "INSTANCE-GROUP", "MACHINE-TYPE"}
+	if wide {
+		columns = append(columns, "LIFECYCLE", "IMAGE", "IMAGE-AGE", "UPTIME", "UPDATE-REASONS")
+	}
 	return t.Render(instances, out, columns...)
 }
 
+// humanAge returns how long ago t was, in the style used by kubectl
+func humanAge(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return duration.HumanDuration(time.Since(*t))
+}
+
 func createK8sClient(cluster *kops.Cluster) (*kubernetes.Clientset, error) {
 	contextName := cluster.ObjectMeta.Name
 	clientGetter := genericclioptions.NewConfigFlags(true)
@@ -228,10 +274,21 @@ func asRenderable(instances []*cloudinstances.CloudInstance) []*renderableCloudI
 			InstanceGroup: ci.CloudInstanceGroup.HumanName,
 			MachineType:   ci.MachineType,
 			State:         string(ci.State),
+
+			Lifecycle:         ci.Lifecycle,
+			ImageID:           ci.ImageID,
+			DesiredImageID:    ci.CloudInstanceGroup.DesiredImageID,
+			NeedUpdateReasons: ci.NeedUpdateReasons,
 		}
 		if ci.Node != nil {
 			arr[i].NodeName = ci.Node.Name
 		}
+		if ci.ImageCreationTime != nil {
+			arr[i].ImageCreationTime = &metav1.Time{Time: *ci.ImageCreationTime}
+		}
+		if ci.LaunchTime != nil {
+			arr[i].LaunchTime = &metav1.Time{Time: *ci.LaunchTime}
+		}
 	}
 	return arr
 }
@@ -42,7 +42,10 @@ var (
 	kops get keypairs kubernetes-ca
 
 	# List the service-account keypairs, including distrusted ones.
-	kops get keypairs service-account --distrusted`))
+	kops get keypairs service-account --distrusted
+
+	# List the keypairs that expire within the next 90 days.
+	kops get keypairs --expiring-within 2160h`))
 
 	getKeypairShort = i18n.T(`Get one or many keypairs.`)
 )
@@ -51,6 +54,8 @@ type GetKeypairsOptions struct {
 	*GetOptions
 	KeysetNames []string
 	Distrusted  bool
+	// ExpiringWithin restricts the listing to the keypairs whose certificates expire within this duration.
+	ExpiringWithin time.Duration
 }
 
 func NewCmdGetKeypairs(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
@@ -80,6 +85,7 @@ func NewCmdGetKeypairs(f *util.Factory, out io.Writer, getOptions *GetOptions) *
 	}
 
 	cmd.Flags().BoolVar(&options.Distrusted, "distrusted", options.Distrusted, "Include distrusted keypairs")
+	cmd.Flags().DurationVar(&options.ExpiringWithin, "expiring-within", options.ExpiringWithin, "Only list keypairs with certificates that expire within this duration")
 
 	return cmd
 }
@@ -188,6 +194,22 @@ func RunGetKeypairs(ctx context.Context, f commandutils.Factory, out io.Writer,
 	if len(items) == 0 {
 		return fmt.Errorf("no keypairs found")
 	}
+
+	if options.ExpiringWithin != 0 {
+		deadline := time.Now().Add(options.ExpiringWithin)
+		expiring := []*keypairItem{}
+		for _, item := range items {
+			if item.NotAfter != nil && item.NotAfter.Before(deadline) {
+				expiring = append(expiring, item)
+			}
+		}
+		if len(expiring) == 0 && options.Output == OutputTable {
+			fmt.Fprintf(out, "No keypairs expire within %v.\n", options.ExpiringWithin)
+			return nil
+		}
+		items = expiring
+	}
+
 	switch options.Output {
 
 	case OutputTable:
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
 
@@ -35,5 +36,7 @@ func NewCmdPromote(f *util.Factory, out io.Writer) *cobra.Command {
 	// create subcommands
 	cmd.AddCommand(NewCmdPromoteKeypair(f, out))
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+
 	return cmd
 }
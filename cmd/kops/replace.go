@@ -24,12 +24,16 @@ import (
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kops/util/pkg/text"
+	"k8s.io/kops/util/pkg/vfs"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
@@ -79,6 +83,8 @@ func NewCmdReplace(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.MarkFlagRequired("filename")
 	cmd.Flags().BoolVarP(&options.Force, "force", "", false, "Force any changes, which will also create any non-existing resource")
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+
 	return cmd
 }
 
@@ -112,118 +118,132 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 				return fmt.Errorf("error parsing file %q: %v", f, err)
 			}
 
-			switch v := o.(type) {
-			case *kopsapi.Cluster:
-				{
-					// Retrieve the current status of the cluster.  This will eventually be part of the cluster object.
-					cloud, err := cloudup.BuildCloud(v)
-					if err != nil {
-						return err
-					}
-					status, err := cloud.FindClusterStatus(v)
-					if err != nil {
-						return err
-					}
-
-					// Check if the cluster exists already
-					clusterName := v.Name
-					cluster, err := clientset.GetCluster(ctx, clusterName)
-					if err != nil {
-						if errors.IsNotFound(err) {
-							cluster = nil
-						} else {
-							return fmt.Errorf("error fetching cluster %q: %v", clusterName, err)
-						}
-					}
-					if cluster == nil {
-						if !c.Force {
-							return fmt.Errorf("cluster %v does not exist (try adding --force flag)", clusterName)
-						}
-
-						err = cloudup.PerformAssignments(v, vfsContext, cloud)
-						if err != nil {
-							return fmt.Errorf("error populating configuration: %w", err)
-						}
-
-						_, err = clientset.CreateCluster(ctx, v)
-						if err != nil {
-							return fmt.Errorf("error creating cluster: %v", err)
-						}
-					} else {
-						_, err = clientset.UpdateCluster(ctx, v, status)
-						if err != nil {
-							return fmt.Errorf("error replacing cluster: %v", err)
-						}
-					}
-				}
+			handled, err := replaceObject(ctx, clientset, vfsContext, o, c.Force)
+			if err != nil {
+				return err
+			}
+			if !handled {
+				klog.V(2).Infof("Type of object was %T", o)
+				return fmt.Errorf("unhandled kind %q in %q", gvk, f)
+			}
+		}
+	}
+
+	return nil
+}
 
-			case *kopsapi.InstanceGroup:
-				clusterName := v.ObjectMeta.Labels[kopsapi.LabelClusterName]
-				if clusterName == "" {
-					return fmt.Errorf("must specify %q label with cluster name to replace instanceGroup", kopsapi.LabelClusterName)
+// replaceObject replaces a single decoded object in the state store, creating it if force is set and it does not exist.
+// It returns false if the kind of object is not handled.
+func replaceObject(ctx context.Context, clientset simple.Clientset, vfsContext *vfs.VFSContext, o runtime.Object, force bool) (bool, error) {
+	switch v := o.(type) {
+	case *kopsapi.Cluster:
+		{
+			// Retrieve the current status of the cluster.  This will eventually be part of the cluster object.
+			cloud, err := cloudup.BuildCloud(v)
+			if err != nil {
+				return false, err
+			}
+			status, err := cloud.FindClusterStatus(v)
+			if err != nil {
+				return false, err
+			}
+
+			// Check if the cluster exists already
+			clusterName := v.Name
+			cluster, err := clientset.GetCluster(ctx, clusterName)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					cluster = nil
+				} else {
+					return false, fmt.Errorf("error fetching cluster %q: %v", clusterName, err)
 				}
-				cluster, err := clientset.GetCluster(ctx, clusterName)
-				if err != nil {
-					if errors.IsNotFound(err) {
-						return fmt.Errorf("cluster %q not found", clusterName)
-					}
-					return fmt.Errorf("error fetching cluster %q: %v", clusterName, err)
+			}
+			if cluster == nil {
+				if !force {
+					return false, fmt.Errorf("cluster %v does not exist (try adding --force flag)", clusterName)
 				}
-				// check if the instancegroup exists already
-				igName := v.ObjectMeta.Name
-				ig, err := clientset.InstanceGroupsFor(cluster).Get(ctx, igName, metav1.GetOptions{})
+
+				err = cloudup.PerformAssignments(v, vfsContext, cloud)
 				if err != nil {
-					if errors.IsNotFound(err) {
-						if !c.Force {
-							return fmt.Errorf("instanceGroup: %v does not exist (try adding --force flag)", igName)
-						}
-					} else {
-						return fmt.Errorf("unable to check for instanceGroup: %v", err)
-					}
-				}
-				switch ig {
-				case nil:
-					klog.Infof("instanceGroup: %v was not found, creating resource now", igName)
-					_, err = clientset.InstanceGroupsFor(cluster).Create(ctx, v, metav1.CreateOptions{})
-					if err != nil {
-						return fmt.Errorf("error creating instanceGroup: %v", err)
-					}
-				default:
-					_, err = clientset.InstanceGroupsFor(cluster).Update(ctx, v, metav1.UpdateOptions{})
-					if err != nil {
-						return fmt.Errorf("error replacing instanceGroup: %v", err)
-					}
-				}
-			case *kopsapi.SSHCredential:
-				clusterName := v.ObjectMeta.Labels[kopsapi.LabelClusterName]
-				if clusterName == "" {
-					return fmt.Errorf("must specify %q label with cluster name to replace SSHCredential", kopsapi.LabelClusterName)
-				}
-				if v.Spec.PublicKey == "" {
-					return fmt.Errorf("spec.PublicKey is required")
+					return false, fmt.Errorf("error populating configuration: %w", err)
 				}
 
-				cluster, err := clientset.GetCluster(ctx, clusterName)
+				_, err = clientset.CreateCluster(ctx, v)
 				if err != nil {
-					return err
+					return false, fmt.Errorf("error creating cluster: %v", err)
 				}
-
-				sshCredentialStore, err := clientset.SSHCredentialStore(cluster)
+			} else {
+				_, err = clientset.UpdateCluster(ctx, v, status)
 				if err != nil {
-					return err
+					return false, fmt.Errorf("error replacing cluster: %v", err)
 				}
+			}
+		}
 
-				sshKeyArr := []byte(v.Spec.PublicKey)
-				err = sshCredentialStore.AddSSHPublicKey(ctx, sshKeyArr)
-				if err != nil {
-					return fmt.Errorf("error replacing SSHCredential: %v", err)
+	case *kopsapi.InstanceGroup:
+		clusterName := v.ObjectMeta.Labels[kopsapi.LabelClusterName]
+		if clusterName == "" {
+			return false, fmt.Errorf("must specify %q label with cluster name to replace instanceGroup", kopsapi.LabelClusterName)
+		}
+		cluster, err := clientset.GetCluster(ctx, clusterName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, fmt.Errorf("cluster %q not found", clusterName)
+			}
+			return false, fmt.Errorf("error fetching cluster %q: %v", clusterName, err)
+		}
+		// check if the instancegroup exists already
+		igName := v.ObjectMeta.Name
+		ig, err := clientset.InstanceGroupsFor(cluster).Get(ctx, igName, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				if !force {
+					return false, fmt.Errorf("instanceGroup: %v does not exist (try adding --force flag)", igName)
 				}
-			default:
-				klog.V(2).Infof("Type of object was %T", v)
-				return fmt.Errorf("unhandled kind %q in %q", gvk, f)
+			} else {
+				return false, fmt.Errorf("unable to check for instanceGroup: %v", err)
 			}
 		}
+		switch ig {
+		case nil:
+			klog.Infof("instanceGroup: %v was not found, creating resource now", igName)
+			_, err = clientset.InstanceGroupsFor(cluster).Create(ctx, v, metav1.CreateOptions{})
+			if err != nil {
+				return false, fmt.Errorf("error creating instanceGroup: %v", err)
+			}
+		default:
+			_, err = clientset.InstanceGroupsFor(cluster).Update(ctx, v, metav1.UpdateOptions{})
+			if err != nil {
+				return false, fmt.Errorf("error replacing instanceGroup: %v", err)
+			}
+		}
+	case *kopsapi.SSHCredential:
+		clusterName := v.ObjectMeta.Labels[kopsapi.LabelClusterName]
+		if clusterName == "" {
+			return false, fmt.Errorf("must specify %q label with cluster name to replace SSHCredential", kopsapi.LabelClusterName)
+		}
+		if v.Spec.PublicKey == "" {
+			return false, fmt.Errorf("spec.PublicKey is required")
+		}
+
+		cluster, err := clientset.GetCluster(ctx, clusterName)
+		if err != nil {
+			return false, err
+		}
+
+		sshCredentialStore, err := clientset.SSHCredentialStore(cluster)
+		if err != nil {
+			return false, err
+		}
+
+		sshKeyArr := []byte(v.Spec.PublicKey)
+		err = sshCredentialStore.AddSSHPublicKey(ctx, sshKeyArr)
+		if err != nil {
+			return false, fmt.Errorf("error replacing SSHCredential: %v", err)
+		}
+	default:
+		return false, nil
 	}
 
-	return nil
+	return true, nil
 }
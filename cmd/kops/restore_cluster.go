@@ -0,0 +1,358 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/acls"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/controlplanerestart"
+	"k8s.io/kops/pkg/etcdbackup"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/statebackup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	restoreClusterLong = templates.LongDesc(i18n.T(`
+	Restore all the state store objects of a cluster from a backup created by kops backup cluster.
+
+	The hashes of all the files of the backup are verified before the state store is changed.
+	Files of the cluster that are not in the backup are deleted, so that the state store matches
+	the backup exactly. If a change fails, the changes that were already made are reverted.
+
+	The cloud resources of the cluster are not changed; run kops update cluster afterwards
+	if they need to match the restored state.
+
+	With --backup, the etcd clusters are restored instead, from the backups that etcd-manager
+	took of them. A restore-backup command is added to the backup store of each etcd cluster,
+	as etcd-manager-ctl restore-backup does, and the etcd-manager pods are restarted so that
+	they run it. If the kubernetes API is unavailable, --recreate-control-plane replaces the
+	control plane instances instead. The command then waits for etcd-manager to restore the
+	backups. Resources created after the backups were taken are lost.
+	`))
+
+	restoreClusterExample = templates.Examples(i18n.T(`
+	# Preview the changes to the state store
+	kops restore cluster --from k8s-cluster.example.com-20240101120000.tar.gz
+
+	# Restore the state of the cluster
+	kops restore cluster --from k8s-cluster.example.com-20240101120000.tar.gz --yes
+
+	# Restore the etcd clusters from their latest backups taken before a time
+	kops restore cluster k8s-cluster.example.com --backup 2024-01-01T12:00:00Z --yes
+
+	# Restore the main etcd cluster from its latest backup, replacing the control plane instances
+	kops restore cluster k8s-cluster.example.com --backup latest --etcd-cluster main --recreate-control-plane --yes
+	`))
+
+	restoreClusterShort = i18n.T(`Restore the state or the etcd clusters of a cluster from a backup.`)
+)
+
+type RestoreClusterOptions struct {
+	ClusterName string
+	// From is the backup file to restore
+	From string
+	Yes  bool
+
+	// Backup is the name of the etcd backups to restore, or a time to restore the latest backups taken at or before it, or "latest".
+	Backup string
+	// EtcdClusters are the names of the etcd clusters to restore; all the etcd clusters managed by kOps if empty.
+	EtcdClusters []string
+	// RecreateControlPlane replaces the control plane instances instead of restarting the etcd-manager pods.
+	RecreateControlPlane bool
+	// Timeout is the maximum time to wait for etcd-manager to restore the backups.
+	Timeout time.Duration
+}
+
+func (o *RestoreClusterOptions) InitDefaults() {
+	o.Timeout = 30 * time.Minute
+}
+
+func NewCmdRestoreCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RestoreClusterOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             restoreClusterShort,
+		Long:              restoreClusterLong,
+		Example:           restoreClusterExample,
+		Args:              rootCommand.clusterNameArgsAllowNoCluster(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.Backup != "" {
+				return RunRestoreClusterEtcd(cmd.Context(), f, out, options)
+			}
+			return RunRestoreCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.From, "from", options.From, "Backup file to restore")
+	cmd.MarkFlagFilename("from", "tar.gz")
+	cmd.Flags().StringVar(&options.Backup, "backup", options.Backup, "Restore the etcd clusters from the etcd backups with this name, or the latest ones taken at or before this RFC 3339 time, or \"latest\"")
+	cmd.Flags().StringSliceVar(&options.EtcdClusters, "etcd-cluster", options.EtcdClusters, "Names of the etcd clusters to restore with --backup. Defaults to all the etcd clusters managed by kOps")
+	cmd.Flags().BoolVar(&options.RecreateControlPlane, "recreate-control-plane", options.RecreateControlPlane, "With --backup, replace the control plane instances instead of restarting the etcd-manager pods through the kubernetes API")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", options.Timeout, "Maximum time to wait for etcd-manager to restore the etcd backups")
+	cmd.MarkFlagsOneRequired("from", "backup")
+	cmd.MarkFlagsMutuallyExclusive("from", "backup")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Restore the state store or the etcd clusters. Without --yes, the changes are only previewed")
+
+	commandutils.SetApplyFlag(cmd, "yes")
+
+	return cmd
+}
+
+func RunRestoreCluster(ctx context.Context, f *util.Factory, out io.Writer, options *RestoreClusterOptions) error {
+	if strings.HasPrefix(f.KopsStateStore(), "k8s://") {
+		return fmt.Errorf("backups are only supported for state stores in object storage or on the local filesystem")
+	}
+
+	file, err := os.Open(options.From)
+	if err != nil {
+		return fmt.Errorf("error opening backup file: %w", err)
+	}
+	defer file.Close()
+
+	backup, err := statebackup.Read(file)
+	if err != nil {
+		return fmt.Errorf("error reading backup file %q: %w", options.From, err)
+	}
+
+	// The cluster spec of the backup determines the ACLs of the restored files
+	config := backup.File(statebackup.StoreConfig, "config")
+	if config == nil {
+		return fmt.Errorf("backup file %q does not contain a cluster spec", options.From)
+	}
+	obj, _, err := kopscodecs.Decode(config, nil)
+	if err != nil {
+		return fmt.Errorf("error parsing cluster spec in backup: %w", err)
+	}
+	cluster, ok := obj.(*kopsapi.Cluster)
+	if !ok {
+		return fmt.Errorf("unexpected object type in backup cluster spec: %T", obj)
+	}
+	if cluster.ObjectMeta.Name != backup.Manifest.ClusterName {
+		return fmt.Errorf("backup of cluster %q contains the spec of cluster %q", backup.Manifest.ClusterName, cluster.ObjectMeta.Name)
+	}
+	if options.ClusterName != "" && options.ClusterName != backup.Manifest.ClusterName {
+		return fmt.Errorf("backup file %q is a backup of cluster %q, not %q", options.From, backup.Manifest.ClusterName, options.ClusterName)
+	}
+
+	plan, err := backup.PlanRestore(ctx, f.VFSContext())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Backup of cluster %q created at %s by kOps %s\n\n", backup.Manifest.ClusterName, backup.Manifest.CreationTimestamp, backup.Manifest.KopsVersion)
+	if len(plan.Changes) == 0 {
+		fmt.Fprintf(out, "The state store matches the backup\n")
+		return nil
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("CHANGE", func(c *statebackup.Change) string {
+		return string(c.Type)
+	})
+	t.AddColumn("PATH", func(c *statebackup.Change) string {
+		return c.Path.Path()
+	})
+	if err := t.Render(plan.Changes, out, "CHANGE", "PATH"); err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to restore the cluster state\n")
+		return nil
+	}
+
+	aclOracle := func(p vfs.Path) (vfs.ACL, error) {
+		return acls.GetACL(ctx, p, cluster)
+	}
+	if err := plan.Apply(ctx, aclOracle); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nRestored the state of cluster %q\n", backup.Manifest.ClusterName)
+	return nil
+}
+
+// etcdRestore is the restore of a backup of an etcd cluster.
+type etcdRestore struct {
+	EtcdCluster string
+	Store       vfs.Path
+	Backup      string
+	// command is the restore command, once it is added
+	command vfs.Path
+}
+
+func RunRestoreClusterEtcd(ctx context.Context, f *util.Factory, out io.Writer, options *RestoreClusterOptions) error {
+	if options.ClusterName == "" {
+		return fmt.Errorf("--name is required")
+	}
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	restores, err := planEtcdRestores(ctx, f.VFSContext(), cluster, options)
+	if err != nil {
+		return err
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("ETCD CLUSTER", func(r *etcdRestore) string {
+		return r.EtcdCluster
+	})
+	t.AddColumn("BACKUP", func(r *etcdRestore) string {
+		return r.Backup
+	})
+	t.AddColumn("BACKUP STORE", func(r *etcdRestore) string {
+		return r.Store.Path()
+	})
+	if err := t.Render(restores, out, "ETCD CLUSTER", "BACKUP", "BACKUP STORE"); err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to restore the etcd clusters\n")
+		return nil
+	}
+
+	// The pods are planned before any command is added, so that nothing is changed if the API is unavailable
+	var restarts []*controlplanerestart.StaticPodRestart
+	var restart *controlplanerestart.Restart
+	if !options.RecreateControlPlane {
+		k8sClient, _, _, err := getNodes(ctx, cluster, false)
+		if err != nil {
+			return fmt.Errorf("%w; use --recreate-control-plane if the kubernetes API is unavailable", err)
+		}
+		restart = &controlplanerestart.Restart{
+			K8sClient:    k8sClient,
+			Cluster:      cluster,
+			Out:          out,
+			Components:   []string{controlplanerestart.ComponentEtcd},
+			Image:        controlplanerestart.DefaultImage,
+			Timeout:      5 * time.Minute,
+			PollInterval: 5 * time.Second,
+		}
+		for _, r := range restores {
+			plan, err := etcdbackup.PlanRestarts(ctx, restart, r.EtcdCluster)
+			if err != nil {
+				return err
+			}
+			if len(plan) == 0 {
+				return fmt.Errorf("no etcd-manager pods of etcd cluster %q found; use --recreate-control-plane to replace the control plane instances", r.EtcdCluster)
+			}
+			restarts = append(restarts, plan...)
+		}
+	}
+
+	for _, r := range restores {
+		r.command, err = etcdbackup.AddRestoreCommand(ctx, r.Store, r.Backup, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Added command to restore backup %q of etcd cluster %q\n", r.Backup, r.EtcdCluster)
+	}
+
+	if options.RecreateControlPlane {
+		// The cluster cannot be validated, nor the nodes drained, until etcd is restored
+		rollingUpdateOptions := &RollingUpdateOptions{}
+		rollingUpdateOptions.InitDefaults()
+		rollingUpdateOptions.Yes = true
+		rollingUpdateOptions.Force = true
+		rollingUpdateOptions.CloudOnly = true
+		rollingUpdateOptions.ClusterName = cluster.ObjectMeta.Name
+		rollingUpdateOptions.InstanceGroupRoles = []string{kopsapi.InstanceGroupRoleControlPlane.ToLowerString()}
+		if err := RunRollingUpdateCluster(ctx, f, out, rollingUpdateOptions); err != nil {
+			return err
+		}
+	} else if err := restart.Run(ctx, restarts); err != nil {
+		return err
+	}
+
+	for _, r := range restores {
+		fmt.Fprintf(out, "Waiting for etcd-manager to restore backup %q of etcd cluster %q\n", r.Backup, r.EtcdCluster)
+		if err := etcdbackup.WaitForRestore(ctx, r.command, 10*time.Second, options.Timeout); err != nil {
+			return fmt.Errorf("backup %q of etcd cluster %q was not restored within %v, check the logs of etcd-manager: %w", r.Backup, r.EtcdCluster, options.Timeout, err)
+		}
+		fmt.Fprintf(out, "Restored backup %q of etcd cluster %q\n", r.Backup, r.EtcdCluster)
+	}
+
+	fmt.Fprintf(out, "\nThe state of the nodes may differ from the restored etcd clusters; consider running kops rolling-update cluster --force --yes\n")
+	return nil
+}
+
+// planEtcdRestores finds the backup to restore of each etcd cluster.
+func planEtcdRestores(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kopsapi.Cluster, options *RestoreClusterOptions) ([]*etcdRestore, error) {
+	var restores []*etcdRestore
+	for i := range cluster.Spec.EtcdClusters {
+		etcdCluster := &cluster.Spec.EtcdClusters[i]
+		if len(options.EtcdClusters) != 0 && !slices.Contains(options.EtcdClusters, etcdCluster.Name) {
+			continue
+		}
+		if etcdCluster.External != nil {
+			if len(options.EtcdClusters) != 0 {
+				return nil, fmt.Errorf("etcd cluster %q is external, it is not backed up by kOps", etcdCluster.Name)
+			}
+			continue
+		}
+
+		store, err := etcdbackup.BackupStore(vfsContext, etcdCluster)
+		if err != nil {
+			return nil, err
+		}
+		backups, err := etcdbackup.ListBackups(ctx, store)
+		if err != nil {
+			return nil, err
+		}
+		backup, err := etcdbackup.SelectBackup(backups, options.Backup)
+		if err != nil {
+			return nil, fmt.Errorf("error selecting backup of etcd cluster %q in %s: %w", etcdCluster.Name, store, err)
+		}
+
+		restores = append(restores, &etcdRestore{
+			EtcdCluster: etcdCluster.Name,
+			Store:       store,
+			Backup:      backup,
+		})
+	}
+
+	for _, name := range options.EtcdClusters {
+		if !slices.ContainsFunc(restores, func(r *etcdRestore) bool { return r.EtcdCluster == name }) {
+			return nil, fmt.Errorf("etcd cluster %q not found", name)
+		}
+	}
+	if len(restores) == 0 {
+		return nil, fmt.Errorf("cluster %q has no etcd clusters managed by kOps", cluster.Name)
+	}
+	return restores, nil
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 )
 
 func NewCmdRollingUpdate(f *util.Factory, out io.Writer) *cobra.Command {
@@ -31,6 +32,10 @@ func NewCmdRollingUpdate(f *util.Factory, out io.Writer) *cobra.Command {
 
 	// create subcommands
 	cmd.AddCommand(NewCmdRollingUpdateCluster(f, out))
+	cmd.AddCommand(NewCmdRollingUpdateControlPlane(f, out))
+	cmd.AddCommand(NewCmdRollingUpdateEtcdVolumes(f, out))
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
 
 	return cmd
 }
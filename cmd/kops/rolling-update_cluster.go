@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -31,7 +32,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
-	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
@@ -39,8 +39,7 @@ import (
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/instancegroups"
 	"k8s.io/kops/pkg/pretty"
-	"k8s.io/kops/pkg/validation"
-	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/pkg/sdk"
 	"k8s.io/kops/util/pkg/tables"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -89,6 +88,11 @@ var (
 		# Update only the "nodes-1a" instance group of the k8s-cluster.example.com kOps cluster.
 		kops rolling-update cluster k8s-cluster.example.com --yes \
 		  --instance-group nodes-1a
+
+		# Update the k8s-cluster.example.com kOps cluster for at most an hour.
+		# Running the same command again resumes the rolling update.
+		kops rolling-update cluster k8s-cluster.example.com --yes \
+		  --max-duration 1h
 		`))
 
 	rollingupdateShort = i18n.T(`Rolling update a cluster.`)
@@ -113,6 +117,9 @@ type RollingUpdateOptions struct {
 	// DrainTimeout is the maximum time to wait while draining a node.
 	DrainTimeout time.Duration
 
+	// MaxDuration is the maximum time to spend replacing instances before pausing the rolling update.
+	MaxDuration time.Duration
+
 	// PostDrainDelay is the duration of a pause after a drain operation
 	PostDrainDelay time.Duration
 
@@ -196,6 +203,7 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 
 	cmd.Flags().DurationVar(&options.ValidationTimeout, "validation-timeout", options.ValidationTimeout, "Maximum time to wait for a cluster to validate")
 	cmd.Flags().DurationVar(&options.DrainTimeout, "drain-timeout", options.DrainTimeout, "Maximum time to wait for a node to drain")
+	cmd.Flags().DurationVar(&options.MaxDuration, "max-duration", options.MaxDuration, "Maximum time to spend replacing instances before pausing the rolling update; the next rolling update resumes it")
 	cmd.Flags().Int32Var(&options.ValidateCount, "validate-count", options.ValidateCount, "Number of times that a cluster needs to be validated after single node update")
 	cmd.Flags().DurationVar(&options.ControlPlaneInterval, "master-interval", options.ControlPlaneInterval, "Time to wait between restarting control plane nodes")
 	cmd.Flags().MarkDeprecated("master-interval", "use --control-plane-interval instead")
@@ -224,6 +232,8 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 		return pflag.NormalizedName(name)
 	})
 
+	commandutils.SetApplyFlag(cmd, "yes")
+
 	return cmd
 }
 
@@ -247,136 +257,37 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		return fmt.Errorf("cannot load kubecfg settings for %q: %v", contextName, err)
 	}
 
-	var nodes []v1.Node
-	var k8sClient kubernetes.Interface
-	if !options.CloudOnly {
-		k8sClient, err = kubernetes.NewForConfig(config)
-		if err != nil {
-			return fmt.Errorf("cannot build kube client for %q: %v", contextName, err)
-		}
-
-		nodeList, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-		if err != nil {
+	client := sdk.NewClient(clientset)
+	plan, err := client.PlanRollingUpdate(ctx, cluster.ObjectMeta.Name, &sdk.RollingUpdateClusterOptions{
+		RESTConfig:           config,
+		CloudOnly:            options.CloudOnly,
+		Force:                options.Force,
+		Interactive:          options.Interactive,
+		FailOnDrainError:     options.FailOnDrainError,
+		FailOnValidate:       options.FailOnValidate,
+		DrainTimeout:         options.DrainTimeout,
+		MaxDuration:          options.MaxDuration,
+		PostDrainDelay:       options.PostDrainDelay,
+		ValidationTimeout:    options.ValidationTimeout,
+		ValidateCount:        int(options.ValidateCount),
+		ControlPlaneInterval: options.ControlPlaneInterval,
+		NodeInterval:         options.NodeInterval,
+		BastionInterval:      options.BastionInterval,
+		InstanceGroups:       options.InstanceGroups,
+		InstanceGroupRoles:   options.InstanceGroupRoles,
+		RollingUpdateOptions: options.RollingUpdateOptions,
+	})
+	if err != nil {
+		if errors.Is(err, sdk.ErrKubernetesAPIUnreachable) {
 			fmt.Fprintf(os.Stderr, "Unable to reach the kubernetes API.\n")
 			fmt.Fprintf(os.Stderr, "Use --cloudonly to do a rolling-update without confirming progress with the k8s API\n\n")
-			return fmt.Errorf("error listing nodes in cluster: %v", err)
-		}
-
-		if nodeList != nil {
-			nodes = nodeList.Items
 		}
-	}
-
-	list, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
-	if err != nil {
 		return err
 	}
-
-	countByRole := make(map[kopsapi.InstanceGroupRole]int32)
-	var instanceGroups []*kopsapi.InstanceGroup
-	for i := range list.Items {
-		instanceGroup := &list.Items[i]
-		instanceGroups = append(instanceGroups, instanceGroup)
-
-		minSize := int32(1)
-		if instanceGroup.Spec.MinSize != nil {
-			minSize = *instanceGroup.Spec.MinSize
-		}
-		countByRole[instanceGroup.Spec.Role] = countByRole[instanceGroup.Spec.Role] + minSize
-	}
-	if countByRole[kopsapi.InstanceGroupRoleAPIServer]+countByRole[kopsapi.InstanceGroupRoleControlPlane] <= 1 {
+	if plan.SingleControlPlane {
 		fmt.Fprintf(out, "Detected single-control-plane cluster; won't detach before draining\n")
-		options.DeregisterControlPlaneNodes = false
-	}
-
-	warnUnmatched := true
-
-	if len(options.InstanceGroups) != 0 {
-		var filtered []*kopsapi.InstanceGroup
-
-		for _, instanceGroupName := range options.InstanceGroups {
-			var found *kopsapi.InstanceGroup
-			for _, ig := range instanceGroups {
-				if ig.ObjectMeta.Name == instanceGroupName {
-					found = ig
-					break
-				}
-			}
-			if found == nil {
-				return fmt.Errorf("InstanceGroup %q not found", instanceGroupName)
-			}
-
-			filtered = append(filtered, found)
-		}
-
-		instanceGroups = filtered
-
-		// Don't warn if we find more ASGs than IGs
-		warnUnmatched = false
-	}
-
-	if len(options.InstanceGroupRoles) != 0 {
-		var filtered []*kopsapi.InstanceGroup
-
-		for _, role := range options.InstanceGroupRoles {
-			s, f := kopsapi.ParseInstanceGroupRole(role, true)
-			if !f {
-				return fmt.Errorf("invalid instance group role %q", role)
-			}
-			for _, ig := range instanceGroups {
-				if ig.Spec.Role == s {
-					filtered = append(filtered, ig)
-				}
-			}
-		}
-
-		instanceGroups = filtered
-
-		// Don't warn if we find more ASGs than IGs
-		warnUnmatched = false
-	}
-
-	cloud, err := cloudup.BuildCloud(cluster)
-	if err != nil {
-		return err
-	}
-
-	groups, err := cloud.GetCloudGroups(cluster, instanceGroups, warnUnmatched, nodes)
-	if err != nil {
-		return err
-	}
-
-	d := &instancegroups.RollingUpdateCluster{
-		Clientset:         clientset,
-		Ctx:               ctx,
-		Cluster:           cluster,
-		MasterInterval:    options.ControlPlaneInterval,
-		NodeInterval:      options.NodeInterval,
-		BastionInterval:   options.BastionInterval,
-		Interactive:       options.Interactive,
-		Force:             options.Force,
-		Cloud:             cloud,
-		K8sClient:         k8sClient,
-		FailOnDrainError:  options.FailOnDrainError,
-		FailOnValidate:    options.FailOnValidate,
-		CloudOnly:         options.CloudOnly,
-		ClusterName:       options.ClusterName,
-		PostDrainDelay:    options.PostDrainDelay,
-		ValidationTimeout: options.ValidationTimeout,
-		ValidateCount:     int(options.ValidateCount),
-		DrainTimeout:      options.DrainTimeout,
-		// TODO should we expose this to the UI?
-		ValidateTickDuration:    30 * time.Second,
-		ValidateSuccessDuration: 10 * time.Second,
-
-		// TODO: Move more of the passthrough options here, instead of duplicating them.
-		Options: options.RollingUpdateOptions,
-	}
-
-	err = d.AdjustNeedUpdate(groups)
-	if err != nil {
-		return err
 	}
+	groups := plan.Groups
 
 	{
 		t := &tables.Table{}
@@ -430,14 +341,7 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		}
 	}
 
-	needUpdate := false
-	for _, group := range groups {
-		if len(group.NeedUpdate) != 0 {
-			needUpdate = true
-		}
-	}
-
-	if !needUpdate && !options.Force {
+	if !plan.NeedsUpdate() {
 		fmt.Printf("\nNo rolling-update required.\n")
 		return nil
 	}
@@ -447,16 +351,7 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		return nil
 	}
 
-	var clusterValidator validation.ClusterValidator
-	if !options.CloudOnly {
-		clusterValidator, err = validation.NewClusterValidator(cluster, cloud, list, config.Host, k8sClient)
-		if err != nil {
-			return fmt.Errorf("cannot create cluster validator: %v", err)
-		}
-	}
-	d.ClusterValidator = clusterValidator
-
-	return d.RollingUpdate(groups, list)
+	return client.RollingUpdate(ctx, plan)
 }
 
 func completeInstanceGroup(f commandutils.Factory, selectedInstanceGroups *[]string, selectedInstanceGroupRoles *[]string) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/controlplanerestart"
+	"k8s.io/kops/pkg/instancegroups"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rollingUpdateControlPlaneLong = templates.LongDesc(i18n.T(`
+	Rolling update the control plane nodes of a cluster.
+
+	By default, the control plane instances that need to be updated are replaced,
+	as with kops rolling-update cluster --instance-group-roles=control-plane.
+
+	With --restart-only, the instances are not replaced. Instead, the static pods of
+	etcd, kube-apiserver, kube-controller-manager and kube-scheduler are restarted
+	in place, so that they pick up changes to their configuration files and reload
+	their certificates. The control plane nodes are restarted one at a time, and the
+	cluster is validated before moving on to the next node.
+
+	Each static pod is restarted by a pod scheduled on its node, which moves the manifest
+	of the static pod out of the manifest directory until the kubelet has stopped the static
+	pod, and then moves it back. The image of this pod must provide a shell.`))
+
+	rollingUpdateControlPlaneExample = templates.Examples(i18n.T(`
+		# Preview the static pods that would be restarted.
+		kops rolling-update control-plane k8s-cluster.example.com --restart-only
+
+		# Restart the static pods of all control plane nodes, one node at a time.
+		kops rolling-update control-plane k8s-cluster.example.com --restart-only --yes
+
+		# Only restart kube-apiserver, for example to reload its certificates.
+		kops rolling-update control-plane k8s-cluster.example.com --restart-only --yes \
+		  --component kube-apiserver
+		`))
+
+	rollingUpdateControlPlaneShort = i18n.T(`Rolling update or restart the control plane nodes of a cluster.`)
+)
+
+// RollingUpdateControlPlaneOptions is the command Object for a rolling update of the control plane.
+type RollingUpdateControlPlaneOptions struct {
+	RollingUpdateOptions
+
+	// RestartOnly restarts the static pods of the control plane nodes instead of replacing the instances.
+	RestartOnly bool
+
+	// Components are the components whose static pods are restarted; all components are restarted if empty.
+	Components []string
+
+	// RestartImage is the image of the pods that restart the static pods.
+	RestartImage string
+
+	// RestartTimeout is the maximum time to wait for a static pod to stop and to become ready again.
+	RestartTimeout time.Duration
+}
+
+func (o *RollingUpdateControlPlaneOptions) InitDefaults() {
+	o.RollingUpdateOptions.InitDefaults()
+	o.FailOnDrainError = true
+
+	o.RestartOnly = false
+	o.RestartImage = controlplanerestart.DefaultImage
+	o.RestartTimeout = 5 * time.Minute
+}
+
+func NewCmdRollingUpdateControlPlane(f *util.Factory, out io.Writer) *cobra.Command {
+	var options RollingUpdateControlPlaneOptions
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "control-plane [CLUSTER]",
+		Short:             rollingUpdateControlPlaneShort,
+		Long:              rollingUpdateControlPlaneLong,
+		Example:           rollingUpdateControlPlaneExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRollingUpdateControlPlane(cmd.Context(), f, out, &options)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Perform rolling update immediately; without --yes rolling-update executes a dry-run")
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force rolling update, even if no changes")
+	cmd.Flags().BoolVar(&options.CloudOnly, "cloudonly", options.CloudOnly, "Perform rolling update without validating cluster status (will cause downtime)")
+
+	cmd.Flags().BoolVar(&options.RestartOnly, "restart-only", options.RestartOnly, "Restart the static pods of the control plane nodes instead of replacing the instances")
+	cmd.Flags().StringSliceVar(&options.Components, "component", options.Components, "Components to restart with --restart-only ("+strings.Join(controlplanerestart.AllComponents, ",")+"); defaults to all")
+	cmd.RegisterFlagCompletionFunc("component", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return controlplanerestart.AllComponents, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVar(&options.RestartImage, "restart-image", options.RestartImage, "Image with a shell, used to restart the static pods with --restart-only")
+	cmd.Flags().DurationVar(&options.RestartTimeout, "restart-timeout", options.RestartTimeout, "Maximum time to wait for a static pod to stop and to become ready again")
+
+	cmd.Flags().DurationVar(&options.ValidationTimeout, "validation-timeout", options.ValidationTimeout, "Maximum time to wait for a cluster to validate")
+	cmd.Flags().DurationVar(&options.DrainTimeout, "drain-timeout", options.DrainTimeout, "Maximum time to wait for a node to drain")
+	cmd.Flags().Int32Var(&options.ValidateCount, "validate-count", options.ValidateCount, "Number of times that a cluster needs to be validated after single node update")
+	cmd.Flags().DurationVar(&options.ControlPlaneInterval, "control-plane-interval", options.ControlPlaneInterval, "Time to wait between restarting control plane nodes")
+	cmd.Flags().DurationVar(&options.PostDrainDelay, "post-drain-delay", options.PostDrainDelay, "Time to wait after draining each node")
+	cmd.Flags().BoolVarP(&options.Interactive, "interactive", "i", options.Interactive, "Prompt to continue after each instance is updated")
+
+	cmd.Flags().BoolVar(&options.FailOnDrainError, "fail-on-drain-error", options.FailOnDrainError, "Fail if draining a node fails")
+	cmd.Flags().BoolVar(&options.FailOnValidate, "fail-on-validate-error", options.FailOnValidate, "Fail if the cluster fails to validate")
+
+	commandutils.SetApplyFlag(cmd, "yes")
+
+	return cmd
+}
+
+func RunRollingUpdateControlPlane(ctx context.Context, f *util.Factory, out io.Writer, options *RollingUpdateControlPlaneOptions) error {
+	if !options.RestartOnly {
+		if len(options.Components) != 0 {
+			return fmt.Errorf("--component can only be used with --restart-only")
+		}
+		rollingUpdateOptions := options.RollingUpdateOptions
+		rollingUpdateOptions.InstanceGroupRoles = []string{kopsapi.InstanceGroupRoleControlPlane.ToLowerString()}
+		return RunRollingUpdateCluster(ctx, f, out, &rollingUpdateOptions)
+	}
+
+	if options.CloudOnly {
+		return fmt.Errorf("--cloudonly cannot be used with --restart-only, which restarts the static pods through the kubernetes API")
+	}
+
+	clientSet, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, host, _, err := getNodes(ctx, cluster, false)
+	if err != nil {
+		return err
+	}
+
+	restart := &controlplanerestart.Restart{
+		K8sClient:    k8sClient,
+		Cluster:      cluster,
+		Out:          out,
+		Components:   options.Components,
+		Image:        options.RestartImage,
+		Timeout:      options.RestartTimeout,
+		PollInterval: 5 * time.Second,
+	}
+
+	plan, err := restart.Plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(plan) == 0 {
+		fmt.Fprintf(out, "No static pods to restart.\n")
+		return nil
+	}
+
+	{
+		t := &tables.Table{}
+		t.AddColumn("NODE", func(r *controlplanerestart.StaticPodRestart) string {
+			return r.NodeName
+		})
+		t.AddColumn("INSTANCE GROUP", func(r *controlplanerestart.StaticPodRestart) string {
+			return r.InstanceGroup
+		})
+		t.AddColumn("COMPONENT", func(r *controlplanerestart.StaticPodRestart) string {
+			return r.Component
+		})
+		t.AddColumn("POD", func(r *controlplanerestart.StaticPodRestart) string {
+			return r.PodName
+		})
+		if err := t.Render(plan, out, "NODE", "INSTANCE GROUP", "COMPONENT", "POD"); err != nil {
+			return err
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to restart the static pods.\n")
+		return nil
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	list, err := clientSet.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	instanceGroups := make(map[string]*kopsapi.InstanceGroup)
+	for i := range list.Items {
+		instanceGroups[list.Items[i].Name] = &list.Items[i]
+	}
+
+	d := &instancegroups.RollingUpdateCluster{
+		Clientset:               clientSet,
+		Cluster:                 cluster,
+		Ctx:                     ctx,
+		Cloud:                   cloud,
+		K8sClient:               k8sClient,
+		FailOnValidate:          options.FailOnValidate,
+		ClusterName:             cluster.ObjectMeta.Name,
+		ValidationTimeout:       options.ValidationTimeout,
+		ValidateCount:           int(options.ValidateCount),
+		ValidateTickDuration:    30 * time.Second,
+		ValidateSuccessDuration: 10 * time.Second,
+	}
+
+	d.ClusterValidator, err = validation.NewClusterValidator(cluster, cloud, list, host, k8sClient)
+	if err != nil {
+		return fmt.Errorf("cannot create cluster validator: %v", err)
+	}
+
+	restart.ValidateCluster = func(instanceGroup string) error {
+		ig := instanceGroups[instanceGroup]
+		if ig == nil {
+			return fmt.Errorf("instance group %q not found", instanceGroup)
+		}
+		if err := d.ValidateAfterRestart(&cloudinstances.CloudInstanceGroup{InstanceGroup: ig}); err != nil {
+			return err
+		}
+		time.Sleep(options.ControlPlaneInterval)
+		return nil
+	}
+
+	return restart.Run(ctx, plan)
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/etcdvolumes"
+	"k8s.io/kops/pkg/instancegroups"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rollingUpdateEtcdVolumesLong = templates.LongDesc(i18n.T(`
+	Re-encrypt the etcd volumes of a cluster with the KMS keys set in
+	spec.etcdClusters[].etcdMembers[].kmsKeyID.
+
+	EBS volumes cannot be re-encrypted in place, so changing the KMS key of an
+	etcd member has no effect on an existing volume. This command replaces one
+	control plane instance at a time: the etcd volumes attached to it are hidden
+	from etcd-manager, the instance is drained and terminated, the detached
+	volumes are snapshotted and copied to new volumes encrypted with the new key,
+	and the cluster is validated before moving on to the next instance.
+
+	The replaced volumes are renamed with a "-replaced" suffix and tagged with
+	kops.k8s.io/etcd-volume-replaced-by; they are kept unless --delete-old-volumes is set.
+
+	Only AWS is supported. KMS key aliases cannot be compared with the key of
+	existing volumes; use the key ID or ARN.`))
+
+	rollingUpdateEtcdVolumesExample = templates.Examples(i18n.T(`
+		# Preview the etcd volumes that are not encrypted with the key from the cluster spec.
+		kops rolling-update etcd-volumes k8s-cluster.example.com
+
+		# Copy the etcd volumes to volumes encrypted with the new key, deleting the old volumes.
+		kops rolling-update etcd-volumes k8s-cluster.example.com --yes --delete-old-volumes
+		`))
+
+	rollingUpdateEtcdVolumesShort = i18n.T(`Re-encrypt etcd volumes with the KMS keys from the cluster spec.`)
+)
+
+// RollingUpdateEtcdVolumesOptions is the command Object for migrating etcd volumes to new KMS keys.
+type RollingUpdateEtcdVolumesOptions struct {
+	Yes       bool
+	CloudOnly bool
+
+	// DeleteOldVolumes deletes the replaced volumes once the cluster has validated.
+	DeleteOldVolumes bool
+
+	// FailOnDrainError fail the migration if drain errors.
+	FailOnDrainError bool
+
+	// FailOnValidate fail the migration when the cluster
+	// does not validate, after a validation period.
+	FailOnValidate bool
+
+	// DrainTimeout is the maximum time to wait while draining a node.
+	DrainTimeout time.Duration
+
+	// PostDrainDelay is the duration of a pause after a drain operation
+	PostDrainDelay time.Duration
+
+	// ValidationTimeout is the timeout for validation to succeed after an instance is replaced
+	ValidationTimeout time.Duration
+
+	// ValidateCount is the amount of time that a cluster needs to be validated after an instance is replaced
+	ValidateCount int32
+
+	ClusterName string
+}
+
+func (o *RollingUpdateEtcdVolumesOptions) initDefaults() {
+	d := &RollingUpdateOptions{}
+	d.InitDefaults()
+
+	o.Yes = false
+	o.CloudOnly = false
+	o.DeleteOldVolumes = false
+	o.FailOnDrainError = true
+	o.FailOnValidate = true
+
+	o.DrainTimeout = d.DrainTimeout
+	o.PostDrainDelay = d.PostDrainDelay
+	o.ValidationTimeout = d.ValidationTimeout
+	o.ValidateCount = d.ValidateCount
+}
+
+func NewCmdRollingUpdateEtcdVolumes(f *util.Factory, out io.Writer) *cobra.Command {
+	var options RollingUpdateEtcdVolumesOptions
+	options.initDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "etcd-volumes [CLUSTER]",
+		Short:             rollingUpdateEtcdVolumesShort,
+		Long:              rollingUpdateEtcdVolumesLong,
+		Example:           rollingUpdateEtcdVolumesExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRollingUpdateEtcdVolumes(cmd.Context(), f, out, &options)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Migrate the etcd volumes immediately; without --yes only the volumes to migrate are listed")
+	cmd.Flags().BoolVar(&options.CloudOnly, "cloudonly", options.CloudOnly, "Replace instances without draining them or validating cluster status (will cause downtime)")
+	cmd.Flags().BoolVar(&options.DeleteOldVolumes, "delete-old-volumes", options.DeleteOldVolumes, "Delete the replaced volumes once the cluster has validated")
+
+	cmd.Flags().DurationVar(&options.ValidationTimeout, "validation-timeout", options.ValidationTimeout, "Maximum time to wait for a cluster to validate")
+	cmd.Flags().DurationVar(&options.DrainTimeout, "drain-timeout", options.DrainTimeout, "Maximum time to wait for a node to drain")
+	cmd.Flags().Int32Var(&options.ValidateCount, "validate-count", options.ValidateCount, "Number of times that a cluster needs to be validated after an instance is replaced")
+	cmd.Flags().DurationVar(&options.PostDrainDelay, "post-drain-delay", options.PostDrainDelay, "Time to wait after draining each node")
+
+	cmd.Flags().BoolVar(&options.FailOnDrainError, "fail-on-drain-error", options.FailOnDrainError, "Fail if draining a node fails")
+	cmd.Flags().BoolVar(&options.FailOnValidate, "fail-on-validate-error", options.FailOnValidate, "Fail if the cluster fails to validate")
+
+	commandutils.SetApplyFlag(cmd, "yes")
+
+	return cmd
+}
+
+func RunRollingUpdateEtcdVolumes(ctx context.Context, f *util.Factory, out io.Writer, options *RollingUpdateEtcdVolumesOptions) error {
+	clientSet, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if cluster.Spec.GetCloudProvider() != kopsapi.CloudProviderAWS {
+		return fmt.Errorf("migrating etcd volumes to new KMS keys is only supported on AWS")
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	migration := &etcdvolumes.KMSKeyMigration{
+		Cloud:            cloud.(awsup.AWSCloud),
+		Cluster:          cluster,
+		Out:              out,
+		DeleteOldVolumes: options.DeleteOldVolumes,
+		PollInterval:     15 * time.Second,
+	}
+
+	plan, err := migration.Plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(plan) == 0 {
+		fmt.Fprintf(out, "All etcd volumes are encrypted with the KMS keys from the cluster spec.\n")
+		return nil
+	}
+
+	{
+		t := &tables.Table{}
+		t.AddColumn("VOLUME", func(v *etcdvolumes.VolumeMigration) string {
+			return v.VolumeID
+		})
+		t.AddColumn("NAME", func(v *etcdvolumes.VolumeMigration) string {
+			return v.Name
+		})
+		t.AddColumn("INSTANCE", func(v *etcdvolumes.VolumeMigration) string {
+			return v.InstanceID
+		})
+		t.AddColumn("CURRENT KEY", func(v *etcdvolumes.VolumeMigration) string {
+			if v.CurrentKMSKeyID == "" {
+				return "<unencrypted>"
+			}
+			return v.CurrentKMSKeyID
+		})
+		t.AddColumn("TARGET KEY", func(v *etcdvolumes.VolumeMigration) string {
+			return v.TargetKMSKeyID
+		})
+		if err := t.Render(plan, out, "VOLUME", "NAME", "INSTANCE", "CURRENT KEY", "TARGET KEY"); err != nil {
+			return err
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to migrate etcd volumes.\n")
+		return nil
+	}
+
+	var nodes []v1.Node
+	var k8sClient kubernetes.Interface
+	var host string
+	if !options.CloudOnly {
+		k8sClient, host, nodes, err = getNodes(ctx, cluster, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	list, err := clientSet.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var instanceGroups []*kopsapi.InstanceGroup
+	for i := range list.Items {
+		instanceGroups = append(instanceGroups, &list.Items[i])
+	}
+
+	groups, err := cloud.GetCloudGroups(cluster, instanceGroups, false, nodes)
+	if err != nil {
+		return err
+	}
+
+	// Look up the instances before any is terminated, as the groups are not refreshed.
+	cloudMembers := make(map[string]*cloudinstances.CloudInstance)
+	for _, v := range plan {
+		if v.InstanceID == "" {
+			continue
+		}
+		cloudMember := findDeletionNode(groups, v.InstanceID, true)
+		if cloudMember == nil {
+			return fmt.Errorf("could not find instance %v, which volume %v is attached to", v.InstanceID, v.VolumeID)
+		}
+		cloudMembers[v.InstanceID] = cloudMember
+	}
+
+	d := &instancegroups.RollingUpdateCluster{
+		Clientset:               clientSet,
+		Cluster:                 cluster,
+		Ctx:                     ctx,
+		Cloud:                   cloud,
+		K8sClient:               k8sClient,
+		FailOnDrainError:        options.FailOnDrainError,
+		FailOnValidate:          options.FailOnValidate,
+		CloudOnly:               options.CloudOnly,
+		ClusterName:             cluster.ObjectMeta.Name,
+		PostDrainDelay:          options.PostDrainDelay,
+		ValidationTimeout:       options.ValidationTimeout,
+		ValidateCount:           int(options.ValidateCount),
+		DrainTimeout:            options.DrainTimeout,
+		ValidateTickDuration:    30 * time.Second,
+		ValidateSuccessDuration: 10 * time.Second,
+	}
+
+	if !options.CloudOnly {
+		d.ClusterValidator, err = validation.NewClusterValidator(cluster, cloud, list, host, k8sClient)
+		if err != nil {
+			return fmt.Errorf("cannot create cluster validator: %v", err)
+		}
+	}
+
+	migration.TerminateInstance = func(instanceID string) error {
+		return d.UpdateSingleInstance(cloudMembers[instanceID], false)
+	}
+	migration.ValidateCluster = func(instanceID string) error {
+		return d.ValidateAfterUpdate(cloudMembers[instanceID].CloudInstanceGroup)
+	}
+
+	return migration.Run(ctx, plan)
+}
@@ -39,11 +39,15 @@ import (
 	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/commands"
 	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/kubeconfig"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
 
 const (
+	// kubectlPluginName is the name of the kOps binary when it is installed as a kubectl plugin
+	kubectlPluginName = "kubectl-kops"
+
 	validResources = `
 
 	* cluster
@@ -85,9 +89,6 @@ var rootCommand = RootCmd{
 		Use:   "kops",
 		Short: rootShort,
 		Long:  rootLong,
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			cmd.SilenceUsage = true
-		},
 	},
 }
 
@@ -97,6 +98,15 @@ func Execute(ctx context.Context) error {
 
 	goflag.Set("logtostderr", "true")
 	goflag.CommandLine.Parse([]string{})
+
+	// When installed as kubectl-kops, kOps runs as a kubectl plugin: "kubectl kops"
+	if name := strings.TrimSuffix(filepath.Base(os.Args[0]), filepath.Ext(os.Args[0])); name == kubectlPluginName {
+		rootCommand.cobraCommand.Annotations = map[string]string{
+			cobra.CommandDisplayNameAnnotation: "kubectl kops",
+		}
+		kubeconfig.AuthenticationPluginCommand = kubectlPluginName
+	}
+
 	return rootCommand.cobraCommand.ExecuteContext(ctx)
 }
 
@@ -114,6 +124,16 @@ func init() {
 func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd := rootCommand.cobraCommand
 
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		if rootCommand.ReadOnly {
+			if tier := commandutils.RequiredPermissionTier(cmd); !commandutils.PermissionTierPlan.Allows(tier) {
+				return fmt.Errorf("%q needs the %s permission tier, which is not allowed in read-only mode", cmd.CommandPath(), tier)
+			}
+		}
+		return nil
+	}
 	// cmd.PersistentFlags().AddGoFlagSet(goflag.CommandLine)
 	goflag.CommandLine.VisitAll(func(goflag *goflag.Flag) {
 		switch goflag.Name {
@@ -157,11 +177,17 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	viper.BindEnv("KOPS_STATE_STORE")
 	// TODO implement completion against VFS
 
+	cmd.PersistentFlags().BoolVar(&rootCommand.ReadOnly, "read-only", false, "Only allow commands that do not change the state store or the cloud resources. Overrides KOPS_READ_ONLY environment variable")
+	viper.BindPFlag("KOPS_READ_ONLY", cmd.PersistentFlags().Lookup("read-only"))
+	viper.BindEnv("KOPS_READ_ONLY")
+
 	defaultClusterName := os.Getenv("KOPS_CLUSTER_NAME")
 	cmd.PersistentFlags().StringVarP(&rootCommand.clusterName, "name", "", defaultClusterName, "Name of cluster. Overrides KOPS_CLUSTER_NAME environment variable")
 	cmd.RegisterFlagCompletionFunc("name", commandutils.CompleteClusterName(rootCommand.factory, false, false))
 
 	// create subcommands
+	cmd.AddCommand(NewCmdApply(f, out))
+	cmd.AddCommand(NewCmdBackup(f, out))
 	cmd.AddCommand(NewCmdCreate(f, out))
 	cmd.AddCommand(NewCmdDelete(f, out))
 	cmd.AddCommand(NewCmdDistrust(f, out))
@@ -172,11 +198,13 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(commands.NewCmdHelpers(f, out))
 	cmd.AddCommand(NewCmdPromote(f, out))
 	cmd.AddCommand(NewCmdReplace(f, out))
+	cmd.AddCommand(NewCmdRestore(f, out))
 	cmd.AddCommand(NewCmdRollingUpdate(f, out))
 	cmd.AddCommand(NewCmdToolbox(f, out))
 	cmd.AddCommand(NewCmdTrust(f, out))
 	cmd.AddCommand(NewCmdUpdate(f, out))
 	cmd.AddCommand(NewCmdUpgrade(f, out))
+	cmd.AddCommand(NewCmdUse(f, out))
 	cmd.AddCommand(NewCmdValidate(f, out))
 	cmd.AddCommand(NewCmdVersion(f, out))
 
@@ -214,6 +242,7 @@ func initConfig() {
 	}
 
 	rootCommand.RegistryPath = viper.GetString("KOPS_STATE_STORE")
+	rootCommand.ReadOnly = viper.GetBool("KOPS_READ_ONLY")
 
 	// Tolerate multiple slashes at end
 	rootCommand.RegistryPath = strings.TrimSuffix(rootCommand.RegistryPath, "/")
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/kops/pkg/commands/commandutils"
+)
+
+func TestRequiredPermissionTier(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected commandutils.PermissionTier
+	}{
+		{args: []string{"version"}, expected: commandutils.PermissionTierView},
+		{args: []string{"get", "clusters"}, expected: commandutils.PermissionTierView},
+		{args: []string{"get", "assets"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"get", "assets", "--copy"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"export", "kubeconfig"}, expected: commandutils.PermissionTierView},
+		{args: []string{"validate", "cluster"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"update", "cluster"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"update", "cluster", "--yes"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"rolling-update", "cluster", "-y"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"create", "cluster"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"delete", "secret"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"delete", "instancegroup"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"edit", "cluster"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"toolbox", "operator-policy"}, expected: commandutils.PermissionTierView},
+		{args: []string{"toolbox", "dump"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"toolbox", "drift"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"toolbox", "plan-subnets"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"toolbox", "plan-subnets", "--yes"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"toolbox", "probe-vpc"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"toolbox", "reap-clusters"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"toolbox", "reap-clusters", "--yes"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"toolbox", "enroll"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"toolbox", "smoke-test"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"toolbox", "etcd-backup"}, expected: commandutils.PermissionTierPlan},
+		{args: []string{"toolbox", "etcd-backup", "--yes"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"toolbox", "addons", "list"}, expected: commandutils.PermissionTierView},
+		{args: []string{"toolbox", "addons", "apply"}, expected: commandutils.PermissionTierApply},
+		{args: []string{"toolbox", "schema"}, expected: commandutils.PermissionTierView},
+		{args: []string{"toolbox", "template"}, expected: commandutils.PermissionTierView},
+		{args: []string{"use", "cluster"}, expected: commandutils.PermissionTierView},
+		{args: []string{"helpers", "kubectl-auth"}, expected: commandutils.PermissionTierView},
+		{args: []string{"gen-cli-docs"}, expected: commandutils.PermissionTierView},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.args), func(t *testing.T) {
+			cmd, flags, err := rootCommand.cobraCommand.Find(tt.args)
+			if err != nil {
+				t.Fatalf("error finding command: %v", err)
+			}
+			// Reset the flags afterwards, to leave the options of the command unchanged
+			flagSet := cmd.Flags()
+			if err := flagSet.Parse(flags); err != nil {
+				t.Fatalf("error parsing flags: %v", err)
+			}
+			defer flagSet.Visit(func(f *pflag.Flag) {
+				f.Value.Set(f.DefValue)
+				f.Changed = false
+			})
+
+			if tier := commandutils.RequiredPermissionTier(cmd); tier != tt.expected {
+				t.Errorf("RequiredPermissionTier(%v) = %q, expected %q", tt.args, tier, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRequiredPermissionTierDefaultsToApply(t *testing.T) {
+	// Commands that do not set a permission tier may change the state store or the cloud resources
+	cmd := &cobra.Command{Use: "untagged"}
+	rootCommand.cobraCommand.AddCommand(cmd)
+	defer rootCommand.cobraCommand.RemoveCommand(cmd)
+
+	if tier := commandutils.RequiredPermissionTier(cmd); tier != commandutils.PermissionTierApply {
+		t.Errorf("RequiredPermissionTier of a command without a permission tier = %q, expected %q", tier, commandutils.PermissionTierApply)
+	}
+}
@@ -33,10 +33,23 @@ func NewCmdToolbox(f commandutils.Factory, out io.Writer) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewCmdToolboxDump(f, out))
+	cmd.AddCommand(NewCmdToolboxDrift(f, out))
+	cmd.AddCommand(NewCmdToolboxEtcdBackup(f, out))
+	cmd.AddCommand(NewCmdToolboxPlanSubnets(f, out))
+	cmd.AddCommand(NewCmdToolboxProbeVPC(out))
+	cmd.AddCommand(NewCmdToolboxReapClusters(f, out))
+	cmd.AddCommand(NewCmdToolboxSmokeTest(f, out))
 	cmd.AddCommand(NewCmdToolboxEnroll(f, out))
 	cmd.AddCommand(NewCmdToolboxTemplate(f, out))
 	cmd.AddCommand(NewCmdToolboxInstanceSelector(f, out))
+	cmd.AddCommand(NewCmdToolboxOperatorPolicy(f, out))
 	cmd.AddCommand(NewCmdToolboxAddons(out))
+	cmd.AddCommand(NewCmdToolboxConvert(out))
+	cmd.AddCommand(NewCmdToolboxSchema(out))
+	cmd.AddCommand(NewCmdToolboxSSM(f, out))
+	for _, newCmd := range extraToolboxCommands {
+		cmd.AddCommand(newCmd(f, out))
+	}
 
 	return cmd
 }
@@ -18,10 +18,13 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"os"
 
 	channelscmd "k8s.io/kops/channels/pkg/cmd"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 
 	"github.com/spf13/cobra"
 )
@@ -34,18 +37,77 @@ func NewCmdToolboxAddons(out io.Writer) *cobra.Command {
 		SilenceUsage:  true,
 	}
 
+	// Only the apply subcommand changes the cluster
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
 	f := util.NewFactory(nil)
 	ctx := context.Background()
 
 	// create subcommands
+	var applyOptions channelscmd.ApplyChannelOptions
+	var kubeconfig, kubeContext, kindCluster string
+	applyCmd := &cobra.Command{
+		Use:   "apply CHANNEL",
+		Short: "Applies updates from the given channel",
+		Example: `kops toolbox addons apply s3://<state_store>/<cluster_name>/addons/bootstrap-channel.yaml
+kops toolbox addons apply my-addon.example.com/addon.yaml --kind-cluster kind --yes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if kindCluster != "" {
+				if kubeContext != "" {
+					return fmt.Errorf("--kind-cluster and --context cannot be used together")
+				}
+				kubeContext = "kind-" + kindCluster
+			}
+			if kubeconfig != "" {
+				f.ConfigFlags.KubeConfig = &kubeconfig
+			}
+			if kubeContext != "" {
+				f.ConfigFlags.Context = &kubeContext
+			}
+
+			// Local channels are usually under development, so check them before applying them
+			if len(args) == 1 {
+				if _, err := os.Stat(args[0]); err == nil {
+					if err := channelscmd.RunLintChannel(f, out, &channelscmd.LintChannelOptions{}, args); err != nil {
+						return err
+					}
+				}
+			}
+
+			return channelscmd.RunApplyChannel(ctx, f, out, &applyOptions, args)
+		},
+	}
+	applyCmd.Flags().BoolVarP(&applyOptions.Yes, "yes", "y", false, "Apply the updates, instead of only listing them")
+	applyCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
+	applyCmd.Flags().StringVar(&kubeContext, "context", "", "Name of the kubeconfig context")
+	applyCmd.Flags().StringVar(&kindCluster, "kind-cluster", "", "Name of the kind cluster, for testing addons locally")
+	commandutils.SetPermissionTier(applyCmd, commandutils.PermissionTierApply)
+	cmd.AddCommand(applyCmd)
+
 	cmd.AddCommand(&cobra.Command{
-		Use:     "apply CHANNEL",
-		Short:   "Applies updates from the given channel",
-		Example: "kops toolbox addons apply s3://<state_store>/<cluster_name>/addons/bootstrap-channel.yaml",
+		Use:     "lint CHANNEL",
+		Short:   "Checks the addons of the given channel",
+		Example: "kops toolbox addons lint my-addon.example.com/addon.yaml",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return channelscmd.RunApplyChannel(ctx, f, out, &channelscmd.ApplyChannelOptions{}, args)
+			return channelscmd.RunLintChannel(f, out, &channelscmd.LintChannelOptions{}, args)
 		},
 	})
+
+	templateOptions := &channelscmd.TemplateAddonOptions{}
+	templateCmd := &cobra.Command{
+		Use:     "template NAME",
+		Short:   "Writes a channel with a new addon",
+		Example: "kops toolbox addons template my-addon.example.com --namespace my-addon",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			templateOptions.Name = args[0]
+			return channelscmd.RunTemplateAddon(out, templateOptions)
+		},
+	}
+	templateCmd.Flags().StringVar(&templateOptions.Namespace, "namespace", "kube-system", "Namespace of the objects of the addon")
+	templateCmd.Flags().StringVar(&templateOptions.OutputDir, "output-dir", "", "Directory to write the channel to (default is the name of the addon)")
+	cmd.AddCommand(templateCmd)
+
 	cmd.AddCommand(&cobra.Command{
 		Use:   "list",
 		Short: "Lists installed addons",
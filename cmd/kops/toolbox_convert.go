@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/try"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/text"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxConvertLong = templates.LongDesc(i18n.T(`
+	Convert kOps manifests to another version of the kops.k8s.io API.
+
+	Every kOps object in the manifests is converted to the requested version; other objects
+	are copied unchanged.
+	`))
+
+	toolboxConvertExample = templates.Examples(i18n.T(`
+	# Convert a cluster manifest to v1alpha3
+	kops toolbox convert -f cluster.yaml --to-version v1alpha3
+
+	# Convert a manifest in place
+	kops toolbox convert -f cluster.yaml --to-version v1alpha3 --out cluster.yaml
+	`))
+
+	toolboxConvertShort = i18n.T(`Convert manifests between versions of the kOps API`)
+)
+
+type ToolboxConvertOptions struct {
+	// Filenames is the list of manifests to convert, or - for stdin.
+	Filenames []string
+	// ToVersion is the version of the kops.k8s.io API to convert to.
+	ToVersion string
+	// OutputPath is the file to write the converted manifests to; defaults to stdout.
+	OutputPath string
+}
+
+func NewCmdToolboxConvert(out io.Writer) *cobra.Command {
+	options := &ToolboxConvertOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "convert",
+		Short:   toolboxConvertShort,
+		Long:    toolboxConvertLong,
+		Example: toolboxConvertExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxConvert(out, options)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&options.Filenames, "filename", "f", options.Filenames, "Filename of the manifests to convert, or - for stdin")
+	cmd.MarkFlagRequired("filename")
+	cmd.RegisterFlagCompletionFunc("filename", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "yaml"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+	cmd.Flags().StringVar(&options.ToVersion, "to-version", options.ToVersion, "Version of the kops.k8s.io API to convert to")
+	cmd.MarkFlagRequired("to-version")
+	cmd.RegisterFlagCompletionFunc("to-version", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var versions []string
+		for _, gv := range kopscodecs.Scheme.PrioritizedVersionsForGroup(kops.GroupName) {
+			if gv.Version != runtime.APIVersionInternal {
+				versions = append(versions, gv.Version)
+			}
+		}
+		return versions, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVar(&options.OutputPath, "out", options.OutputPath, "Path to output file. Defaults to stdout")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
+	return cmd
+}
+
+// RunToolboxConvert converts the kOps objects in the manifests to the requested API version.
+func RunToolboxConvert(out io.Writer, options *ToolboxConvertOptions) error {
+	gv := schema.GroupVersion{Group: kops.GroupName, Version: options.ToVersion}
+	if options.ToVersion == "" || !kopscodecs.Scheme.IsVersionRegistered(gv) {
+		return fmt.Errorf("unknown version %q of the %s API", options.ToVersion, kops.GroupName)
+	}
+
+	var documents []string
+	for _, f := range options.Filenames {
+		var contents []byte
+		var err error
+		if f == "-" {
+			contents, err = ConsumeStdin()
+			if err != nil {
+				return err
+			}
+		} else {
+			contents, err = vfs.Context.ReadFile(f)
+			if err != nil {
+				return fmt.Errorf("error reading file %q: %w", f, err)
+			}
+		}
+
+		for _, section := range text.SplitContentToSections(contents) {
+			o, _, err := kopscodecs.Decode(section, nil)
+			if err != nil {
+				return fmt.Errorf("error parsing file %q: %w", f, err)
+			}
+
+			if _, ok := o.(*unstructured.Unstructured); ok {
+				documents = append(documents, string(section))
+				continue
+			}
+
+			converted, err := kopscodecs.ToVersionedYamlWithVersion(o, gv)
+			if err != nil {
+				return fmt.Errorf("error converting object in file %q: %w", f, err)
+			}
+			documents = append(documents, string(converted))
+		}
+	}
+
+	for i := range documents {
+		if !strings.HasSuffix(documents[i], "\n") {
+			documents[i] += "\n"
+		}
+	}
+	content := strings.Join(documents, "---\n")
+
+	w := out
+	if options.OutputPath != "" {
+		f, err := os.OpenFile(utils.ExpandPath(options.OutputPath), os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0o660)
+		if err != nil {
+			return fmt.Errorf("unable to open file: %s, error: %v", options.OutputPath, err)
+		}
+		defer try.CloseFile(f)
+		w = f
+	}
+
+	if _, err := w.Write([]byte(content)); err != nil {
+		return fmt.Errorf("error writing to output: %w", err)
+	}
+
+	return nil
+}
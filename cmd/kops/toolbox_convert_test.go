@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/util/pkg/text"
+)
+
+func TestToolboxConvertRoundTrip(t *testing.T) {
+	input := "../../tests/integration/update_cluster/minimal-1.26/in-v1alpha2.yaml"
+	converted := filepath.Join(t.TempDir(), "converted.yaml")
+
+	require.NoError(t, RunToolboxConvert(nil, &ToolboxConvertOptions{
+		Filenames:  []string{input},
+		ToVersion:  "v1alpha3",
+		OutputPath: converted,
+	}))
+
+	b, err := os.ReadFile(converted)
+	require.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(string(b), "apiVersion: kops.k8s.io/v1alpha3"))
+	assert.NotContains(t, string(b), "v1alpha2")
+
+	var out bytes.Buffer
+	require.NoError(t, RunToolboxConvert(&out, &ToolboxConvertOptions{
+		Filenames: []string{converted},
+		ToVersion: "v1alpha2",
+	}))
+
+	original, err := os.ReadFile(input)
+	require.NoError(t, err)
+	assert.Equal(t, decodeAll(t, original), decodeAll(t, out.Bytes()))
+}
+
+func TestToolboxConvertUnknownVersion(t *testing.T) {
+	err := RunToolboxConvert(&bytes.Buffer{}, &ToolboxConvertOptions{
+		Filenames: []string{"../../tests/integration/update_cluster/minimal-1.26/in-v1alpha2.yaml"},
+		ToVersion: "v1",
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `unknown version "v1"`)
+	}
+}
+
+func decodeAll(t *testing.T, data []byte) []runtime.Object {
+	var objects []runtime.Object
+	for _, section := range text.SplitContentToSections(data) {
+		o, _, err := kopscodecs.Decode(section, nil)
+		require.NoError(t, err)
+		objects = append(objects, o)
+	}
+	return objects
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/sdk"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	toolboxDriftLong = templates.LongDesc(i18n.T(`
+	Detect the differences between the cloud resources of a cluster and the resources
+	that kOps would build for it, such as tags removed or security group rules added
+	outside of kOps, or a detached internet gateway.
+
+	The cloud resources are compared as in a dry run of kops update cluster, so the
+	differences also include the changes of the cluster spec that have not been applied
+	yet. Nothing is changed.
+
+	Fields holding files, such as the user data of launch templates, are shown as
+	a diff in the json and yaml output.`))
+
+	toolboxDriftExample = templates.Examples(i18n.T(`
+	# List the differences of a cluster.
+	kops toolbox drift k8s-cluster.example.com
+
+	# Print the differences as JSON, for processing by other tools.
+	kops toolbox drift k8s-cluster.example.com -o json
+	`))
+
+	toolboxDriftShort = i18n.T(`Detect changes made to the cloud resources outside of kOps.`)
+)
+
+type ToolboxDriftOptions struct {
+	ClusterName string
+	Output      string
+}
+
+func (o *ToolboxDriftOptions) InitDefaults() {
+	o.Output = OutputTable
+}
+
+func NewCmdToolboxDrift(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxDriftOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "drift [CLUSTER]",
+		Short:             toolboxDriftShort,
+		Long:              toolboxDriftLong,
+		Example:           toolboxDriftExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxDrift(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format.  One of table, json or yaml")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputTable, OutputJSON, OutputYaml}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierPlan)
+
+	return cmd
+}
+
+func RunToolboxDrift(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxDriftOptions) error {
+	switch options.Output {
+	case OutputTable, OutputJSON, OutputYaml:
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	result, err := sdk.NewClient(clientset).UpdateCluster(ctx, options.ClusterName, &sdk.UpdateClusterOptions{
+		Target:       cloudup.TargetDryRun,
+		DryRunReport: io.Discard,
+	})
+	if err != nil {
+		return err
+	}
+
+	target, ok := result.Target.(*fi.CloudupDryRunTarget)
+	if !ok {
+		return fmt.Errorf("unexpected target type %T", result.Target)
+	}
+	changes, err := target.TaskChanges(result.TaskMap)
+	if err != nil {
+		return err
+	}
+	if changes == nil {
+		changes = []*fi.TaskChange{}
+	}
+
+	switch options.Output {
+	case OutputJSON:
+		b, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling json: %w", err)
+		}
+		_, err = out.Write(append(b, '\n'))
+		return err
+
+	case OutputYaml:
+		b, err := yaml.Marshal(changes)
+		if err != nil {
+			return fmt.Errorf("error marshaling yaml: %w", err)
+		}
+		_, err = out.Write(b)
+		return err
+
+	default:
+		if len(changes) == 0 {
+			fmt.Fprintf(out, "No drift detected\n")
+			return nil
+		}
+		return driftOutputTable(changes, out)
+	}
+}
+
+// driftRow is a row of the drift table: a created or deleted task, or a modified field of a task.
+type driftRow struct {
+	*fi.TaskChange
+	Field *fi.FieldChange
+}
+
+func driftOutputTable(changes []*fi.TaskChange, out io.Writer) error {
+	var rows []*driftRow
+	for _, change := range changes {
+		if len(change.Fields) == 0 {
+			rows = append(rows, &driftRow{TaskChange: change})
+		}
+		for i := range change.Fields {
+			rows = append(rows, &driftRow{TaskChange: change, Field: &change.Fields[i]})
+		}
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("TASK", func(r *driftRow) string {
+		return r.Task
+	})
+	t.AddColumn("NAME", func(r *driftRow) string {
+		return r.Name
+	})
+	t.AddColumn("ACTION", func(r *driftRow) string {
+		return r.Action
+	})
+	t.AddColumn("FIELD", func(r *driftRow) string {
+		if r.Field == nil {
+			return ""
+		}
+		return r.Field.Field
+	})
+	t.AddColumn("ACTUAL", func(r *driftRow) string {
+		if r.Field == nil {
+			return ""
+		}
+		if r.Field.Diff != "" {
+			return "(diff)"
+		}
+		return r.Field.Actual
+	})
+	t.AddColumn("EXPECTED", func(r *driftRow) string {
+		if r.Field == nil {
+			return ""
+		}
+		if r.Field.Diff != "" {
+			return "(diff)"
+		}
+		return r.Field.Expected
+	})
+	return t.Render(rows, out, "TASK", "NAME", "ACTION", "FIELD", "ACTUAL", "EXPECTED")
+}
@@ -109,6 +109,8 @@ func NewCmdToolboxDump(f commandutils.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.SSHUser, "ssh-user", options.SSHUser, "The remote user for SSH access to instances")
 	cmd.RegisterFlagCompletionFunc("ssh-user", cobra.NoFileCompletions)
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierPlan)
+
 	return cmd
 }
 
@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/controlplanerestart"
+	"k8s.io/kops/pkg/etcdbackup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxEtcdBackupLong = templates.LongDesc(i18n.T(`
+	Take a backup of the etcd clusters, and copy it to another store, such as a bucket in another region.
+
+	etcd-manager has no API to request a backup, but the etcd-manager leader takes one when it is elected.
+	The etcd-manager pods of each etcd cluster are therefore restarted one at a time, until a new backup
+	appears in the backup store of the etcd cluster. With --backup, an existing backup is copied instead,
+	and nothing is restarted.
+
+	The backups are copied to --to, in a directory named after the etcd cluster, or to the
+	spec.etcdClusters[].backups.secondaryBackupStore of each etcd cluster. The copies have the layout of
+	the backup store, so that etcd-manager can restore them. Nothing is changed unless --yes is set.`))
+
+	toolboxEtcdBackupExample = templates.Examples(i18n.T(`
+	# Take a backup of the etcd clusters and copy it to another bucket.
+	kops toolbox etcd-backup --name k8s-cluster.example.com --to s3://dr-bucket/k8s-cluster.example.com --yes
+
+	# Copy the latest backup of the main etcd cluster to its secondary backup store, without taking a new one.
+	kops toolbox etcd-backup --name k8s-cluster.example.com --etcd-cluster main --backup latest --yes
+	`))
+
+	toolboxEtcdBackupShort = i18n.T(`Take a backup of the etcd clusters and copy it to another store.`)
+)
+
+type ToolboxEtcdBackupOptions struct {
+	ClusterName string
+	// To is the store to copy the backups to; the secondary backup stores of the etcd clusters are used if empty.
+	To string
+	// EtcdClusters are the names of the etcd clusters to back up; all the etcd clusters managed by kOps if empty.
+	EtcdClusters []string
+	// Backup is the name of an existing backup to copy, or "latest", instead of taking a new backup.
+	Backup string
+	// Timeout is the maximum time to wait for a backup after restarting each etcd-manager pod.
+	Timeout time.Duration
+	// Yes must be set to take and copy the backups.
+	Yes bool
+}
+
+func (o *ToolboxEtcdBackupOptions) InitDefaults() {
+	o.Timeout = 2 * time.Minute
+}
+
+func NewCmdToolboxEtcdBackup(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxEtcdBackupOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "etcd-backup [CLUSTER]",
+		Short:             toolboxEtcdBackupShort,
+		Long:              toolboxEtcdBackupLong,
+		Example:           toolboxEtcdBackupExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxEtcdBackup(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.To, "to", options.To, "VFS path to copy the backups to, such as s3://bucket/prefix. Defaults to the secondary backup store of each etcd cluster")
+	cmd.Flags().StringSliceVar(&options.EtcdClusters, "etcd-cluster", options.EtcdClusters, "Names of the etcd clusters to back up. Defaults to all the etcd clusters managed by kOps")
+	cmd.Flags().StringVar(&options.Backup, "backup", options.Backup, "Name of an existing backup to copy, or \"latest\", instead of taking a new backup")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", options.Timeout, "Maximum time to wait for a backup after restarting each etcd-manager pod")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Take and copy the backups, without --yes the command only previews them")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+	commandutils.SetApplyFlag(cmd, "yes")
+
+	return cmd
+}
+
+// etcdBackupCopy is the copy of a backup of an etcd cluster.
+type etcdBackupCopy struct {
+	EtcdCluster string
+	Store       vfs.Path
+	Destination vfs.Path
+	// Backup is the name of the backup to copy, empty until it is taken if a new backup is taken.
+	Backup string
+}
+
+func RunToolboxEtcdBackup(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxEtcdBackupOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	copies, err := planEtcdBackupCopies(ctx, f.VFSContext(), cluster, options)
+	if err != nil {
+		return err
+	}
+
+	{
+		t := &tables.Table{}
+		t.AddColumn("ETCD CLUSTER", func(c *etcdBackupCopy) string {
+			return c.EtcdCluster
+		})
+		t.AddColumn("BACKUP", func(c *etcdBackupCopy) string {
+			if c.Backup == "" {
+				return "<new>"
+			}
+			return c.Backup
+		})
+		t.AddColumn("BACKUP STORE", func(c *etcdBackupCopy) string {
+			return c.Store.Path()
+		})
+		t.AddColumn("DESTINATION", func(c *etcdBackupCopy) string {
+			return c.Destination.Path()
+		})
+		if err := t.Render(copies, out, "ETCD CLUSTER", "BACKUP", "BACKUP STORE", "DESTINATION"); err != nil {
+			return err
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to take and copy the backups.\n")
+		return nil
+	}
+
+	var trigger *etcdbackup.Trigger
+	if options.Backup == "" {
+		k8sClient, _, _, err := getNodes(ctx, cluster, false)
+		if err != nil {
+			return err
+		}
+		trigger = &etcdbackup.Trigger{
+			Restart: &controlplanerestart.Restart{
+				K8sClient:    k8sClient,
+				Cluster:      cluster,
+				Out:          out,
+				Components:   []string{controlplanerestart.ComponentEtcd},
+				Image:        controlplanerestart.DefaultImage,
+				Timeout:      5 * time.Minute,
+				PollInterval: 5 * time.Second,
+			},
+			Timeout:      options.Timeout,
+			PollInterval: 5 * time.Second,
+		}
+	}
+
+	for _, c := range copies {
+		if trigger != nil {
+			plan, err := trigger.Plan(ctx, c.EtcdCluster)
+			if err != nil {
+				return err
+			}
+			if len(plan) == 0 {
+				return fmt.Errorf("no etcd-manager pods of etcd cluster %q found", c.EtcdCluster)
+			}
+			fmt.Fprintf(out, "Taking a backup of etcd cluster %q\n", c.EtcdCluster)
+			c.Backup, err = trigger.Run(ctx, c.Store, plan)
+			if err != nil {
+				return fmt.Errorf("error taking a backup of etcd cluster %q: %w", c.EtcdCluster, err)
+			}
+		}
+
+		if err := etcdbackup.Copy(ctx, c.Store, c.Backup, c.Destination); err != nil {
+			return fmt.Errorf("error copying backup %q of etcd cluster %q: %w", c.Backup, c.EtcdCluster, err)
+		}
+		fmt.Fprintf(out, "Copied backup %q of etcd cluster %q to %s\n", c.Backup, c.EtcdCluster, c.Destination)
+	}
+
+	return nil
+}
+
+// planEtcdBackupCopies finds the backup store and the destination of the backups of each etcd cluster,
+// and the existing backups to copy if a backup is selected.
+func planEtcdBackupCopies(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster, options *ToolboxEtcdBackupOptions) ([]*etcdBackupCopy, error) {
+	var to vfs.Path
+	if options.To != "" {
+		var err error
+		to, err = vfsContext.BuildVfsPath(options.To)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing --to: %w", err)
+		}
+	}
+
+	var copies []*etcdBackupCopy
+	for i := range cluster.Spec.EtcdClusters {
+		etcdCluster := &cluster.Spec.EtcdClusters[i]
+		if len(options.EtcdClusters) != 0 && !slices.Contains(options.EtcdClusters, etcdCluster.Name) {
+			continue
+		}
+		if etcdCluster.External != nil {
+			if len(options.EtcdClusters) != 0 {
+				return nil, fmt.Errorf("etcd cluster %q is external, it is not backed up by kOps", etcdCluster.Name)
+			}
+			continue
+		}
+
+		store, err := etcdbackup.BackupStore(vfsContext, etcdCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		var destination vfs.Path
+		switch {
+		case to != nil:
+			destination = to.Join(etcdCluster.Name)
+		case etcdCluster.Backups.SecondaryBackupStore != "":
+			destination, err = vfsContext.BuildVfsPath(etcdCluster.Backups.SecondaryBackupStore)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing secondary backup store of etcd cluster %q: %w", etcdCluster.Name, err)
+			}
+		default:
+			return nil, fmt.Errorf("etcd cluster %q does not have a secondary backup store, --to must be set", etcdCluster.Name)
+		}
+
+		backup := options.Backup
+		if backup != "" {
+			backups, err := etcdbackup.ListBackups(ctx, store)
+			if err != nil {
+				return nil, err
+			}
+			if backup == etcdbackup.LatestBackup && len(backups) != 0 {
+				backup = backups[len(backups)-1]
+			}
+			if !slices.Contains(backups, backup) {
+				return nil, fmt.Errorf("backup %q of etcd cluster %q not found in %s", options.Backup, etcdCluster.Name, store)
+			}
+		}
+
+		copies = append(copies, &etcdBackupCopy{
+			EtcdCluster: etcdCluster.Name,
+			Store:       store,
+			Destination: destination,
+			Backup:      backup,
+		})
+	}
+
+	for _, name := range options.EtcdClusters {
+		if !slices.ContainsFunc(copies, func(c *etcdBackupCopy) bool { return c.EtcdCluster == name }) {
+			return nil, fmt.Errorf("etcd cluster %q not found", name)
+		}
+	}
+	if len(copies) == 0 {
+		return nil, fmt.Errorf("cluster %q has no etcd clusters managed by kOps", cluster.Name)
+	}
+	return copies, nil
+}
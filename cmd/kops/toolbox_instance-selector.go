@@ -228,6 +228,8 @@ func NewCmdToolboxInstanceSelector(f commandutils.Factory, out io.Writer) *cobra
 		return []string{"json", "yaml"}, cobra.ShellCompDirectiveNoFileComp
 	})
 
+	commandutils.SetPermissionTier(commandline.Command, commandutils.PermissionTierApply)
+
 	return commandline.Command
 }
 
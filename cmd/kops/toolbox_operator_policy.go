@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/pkg/util/stringorset"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxOperatorPolicyLong = templates.LongDesc(i18n.T(`
+	Print the AWS IAM policy granting an operator a permission tier for a cluster.
+
+	The view tier reads the cluster configuration from the state store, except for the
+	private keys and secrets. The plan tier also reads the private keys, secrets and cloud
+	resources, to preview changes with commands such as "kops update cluster" without --yes.
+	The apply tier also writes the state store and changes the cloud resources.
+
+	Access to the state store is restricted to the objects of the cluster with IAM conditions.
+	Only S3 state stores are supported. Operators with the view or plan tier should run kOps
+	with --read-only, so that commands needing the apply tier fail before making any change.
+	`))
+
+	toolboxOperatorPolicyExample = templates.Examples(i18n.T(`
+	# Print the policy of operators allowed to preview changes
+	kops toolbox operator-policy --name k8s-cluster.example.com --tier plan
+
+	# Create the policy of read-only operators
+	aws iam create-policy --policy-name kops-view \
+	  --policy-document "$(kops toolbox operator-policy --name k8s-cluster.example.com --tier view)"
+	`))
+
+	toolboxOperatorPolicyShort = i18n.T(`Print the IAM policy of a kOps permission tier`)
+)
+
+type ToolboxOperatorPolicyOptions struct {
+	ClusterName string
+
+	// Tier is the permission tier to grant.
+	Tier string
+	// Partition is the AWS partition of the ARNs in the policy.
+	Partition string
+}
+
+func NewCmdToolboxOperatorPolicy(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxOperatorPolicyOptions{
+		Tier:      string(commandutils.PermissionTierView),
+		Partition: "aws",
+	}
+
+	cmd := &cobra.Command{
+		Use:               "operator-policy [CLUSTER]",
+		Short:             toolboxOperatorPolicyShort,
+		Long:              toolboxOperatorPolicyLong,
+		Example:           toolboxOperatorPolicyExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxOperatorPolicy(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Tier, "tier", options.Tier, "Permission tier to grant: view, plan or apply")
+	cmd.RegisterFlagCompletionFunc("tier", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var tiers []string
+		for _, tier := range commandutils.PermissionTiers {
+			tiers = append(tiers, string(tier))
+		}
+		return tiers, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVar(&options.Partition, "partition", options.Partition, "AWS partition of the state store bucket, such as aws-cn or aws-us-gov")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
+	return cmd
+}
+
+func RunToolboxOperatorPolicy(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxOperatorPolicyOptions) error {
+	tier, err := commandutils.ParsePermissionTier(options.Tier)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return err
+	}
+	s3Path, ok := configBase.(*vfs.S3Path)
+	if !ok {
+		return fmt.Errorf("operator policies are only supported for S3 state stores, not %q", configBase)
+	}
+
+	policy, err := buildOperatorPolicy(cluster.ObjectMeta.Name, options.Partition, s3Path.Bucket(), s3Path.Key(), tier).AsJSON()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, policy)
+	return err
+}
+
+// operatorPlanActions are the read-only cloud actions used to preview changes.
+var operatorPlanActions = []string{
+	"autoscaling:Describe*",
+	"ec2:Describe*",
+	"elasticloadbalancing:Describe*",
+	"events:Describe*",
+	"events:List*",
+	"iam:Get*",
+	"iam:List*",
+	"kms:DescribeKey",
+	"kms:GetKeyPolicy",
+	"kms:ListGrants",
+	"route53:Get*",
+	"route53:List*",
+	"sqs:Get*",
+	"sqs:List*",
+}
+
+// operatorApplyActions are the cloud actions used to create, update and delete clusters.
+var operatorApplyActions = []string{
+	"autoscaling:*",
+	"ec2:*",
+	"elasticloadbalancing:*",
+	"events:*",
+	"iam:*",
+	"route53:*",
+	"sqs:*",
+}
+
+// buildOperatorPolicy builds the policy granting a permission tier for the cluster stored under the key of the bucket.
+func buildOperatorPolicy(clusterName, partition, bucket, key string, tier commandutils.PermissionTier) *iam.Policy {
+	key = strings.Trim(key, "/")
+	bucketARN := fmt.Sprintf("arn:%s:s3:::%s", partition, bucket)
+	objectsARN := bucketARN + "/" + key + "/*"
+
+	// Listing the clusters lists the parent of the cluster
+	storePrefix := path.Dir(key) + "/"
+	if storePrefix == "./" {
+		storePrefix = ""
+	}
+
+	p := iam.NewPolicy(clusterName, partition)
+	p.Statement = append(p.Statement,
+		&iam.Statement{
+			Effect:   iam.StatementEffectAllow,
+			Action:   stringorset.Of("s3:GetBucketLocation", "s3:GetEncryptionConfiguration"),
+			Resource: stringorset.String(bucketARN),
+		},
+		&iam.Statement{
+			Effect:   iam.StatementEffectAllow,
+			Action:   stringorset.Of("s3:ListBucket", "s3:ListBucketVersions"),
+			Resource: stringorset.String(bucketARN),
+			Condition: iam.Condition{
+				"StringLike": map[string]interface{}{
+					"s3:prefix": []string{storePrefix, key, key + "/*"},
+				},
+			},
+		},
+		&iam.Statement{
+			Effect:   iam.StatementEffectAllow,
+			Action:   stringorset.Of("s3:GetObject", "s3:GetObjectVersion"),
+			Resource: stringorset.String(objectsARN),
+		},
+	)
+
+	switch tier {
+	case commandutils.PermissionTierView:
+		p.Statement = append(p.Statement, &iam.Statement{
+			Effect: iam.StatementEffectDeny,
+			Action: stringorset.Of("s3:GetObject", "s3:GetObjectVersion"),
+			Resource: stringorset.Of(
+				bucketARN+"/"+key+"/pki/private/*",
+				bucketARN+"/"+key+"/secrets/*",
+			),
+		})
+
+	case commandutils.PermissionTierPlan:
+		p.AddUnconditionalActions(operatorPlanActions...)
+
+	case commandutils.PermissionTierApply:
+		p.Statement = append(p.Statement, &iam.Statement{
+			Effect:   iam.StatementEffectAllow,
+			Action:   stringorset.Of("s3:PutObject", "s3:DeleteObject", "s3:DeleteObjectVersion"),
+			Resource: stringorset.String(objectsARN),
+		})
+		p.AddUnconditionalActions(operatorPlanActions...)
+		p.AddUnconditionalActions(operatorApplyActions...)
+	}
+
+	return p
+}
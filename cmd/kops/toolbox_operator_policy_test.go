@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/kops/pkg/commands/commandutils"
+)
+
+func TestBuildOperatorPolicy(t *testing.T) {
+	tests := []struct {
+		tier    commandutils.PermissionTier
+		key     string
+		allowed []string
+		denied  []string
+	}{
+		{
+			tier:    commandutils.PermissionTierView,
+			key:     "k8s-cluster.example.com",
+			allowed: []string{"s3:GetObject", "s3:ListBucket"},
+			denied:  []string{"s3:PutObject", "ec2:Describe*", "ec2:*"},
+		},
+		{
+			tier:    commandutils.PermissionTierPlan,
+			key:     "kops/k8s-cluster.example.com",
+			allowed: []string{"s3:GetObject", "s3:ListBucket", "ec2:Describe*"},
+			denied:  []string{"s3:PutObject", "ec2:*"},
+		},
+		{
+			tier:    commandutils.PermissionTierApply,
+			key:     "k8s-cluster.example.com/",
+			allowed: []string{"s3:GetObject", "s3:PutObject", "s3:DeleteObject", "ec2:Describe*", "ec2:*", "iam:*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.tier), func(t *testing.T) {
+			p := buildOperatorPolicy("k8s-cluster.example.com", "aws", "state-store", tt.key, tt.tier)
+			policyJSON, err := p.AsJSON()
+			if err != nil {
+				t.Fatalf("error building policy: %v", err)
+			}
+
+			var policy struct {
+				Statement []struct {
+					Effect    string
+					Action    interface{}
+					Condition map[string]map[string][]string
+				}
+			}
+			if err := json.Unmarshal([]byte(policyJSON), &policy); err != nil {
+				t.Fatalf("error parsing policy: %v", err)
+			}
+
+			allowed := map[string]bool{}
+			for _, statement := range policy.Statement {
+				var actions []string
+				switch a := statement.Action.(type) {
+				case string:
+					actions = []string{a}
+				case []interface{}:
+					for _, action := range a {
+						actions = append(actions, action.(string))
+					}
+				}
+				for _, action := range actions {
+					if statement.Effect == "Allow" {
+						allowed[action] = true
+					}
+				}
+				if prefixes, found := statement.Condition["StringLike"]["s3:prefix"]; found {
+					expected := "k8s-cluster.example.com/*"
+					if tt.key == "kops/k8s-cluster.example.com" {
+						expected = "kops/k8s-cluster.example.com/*"
+					}
+					if prefixes[len(prefixes)-1] != expected {
+						t.Errorf("unexpected s3:prefix condition %v", prefixes)
+					}
+				}
+			}
+			for _, action := range tt.allowed {
+				if !allowed[action] {
+					t.Errorf("action %q is not allowed:\n%s", action, policyJSON)
+				}
+			}
+			for _, action := range tt.denied {
+				if allowed[action] {
+					t.Errorf("action %q is allowed:\n%s", action, policyJSON)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/util/subnet"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxPlanSubnetsLong = templates.LongDesc(i18n.T(`
+	Compute non-overlapping CIDRs for the subnets of a cluster, within its network CIDR.
+
+	Each subnet gets a share of the network CIDR according to the weight of its type, rounded
+	down to a power of two. Subnets that already have a CIDR keep it, unless --replace is set.
+	The CIDRs of the shared subnets, and of the subnets outside of the network CIDR, are always kept.
+
+	The planned CIDRs are written to the cluster spec when --yes is set.`))
+
+	toolboxPlanSubnetsExample = templates.Examples(i18n.T(`
+	# Preview the CIDRs of the subnets of a cluster.
+	kops toolbox plan-subnets k8s-cluster.example.com
+
+	# Make the private subnets 8 times as large as the utility subnets, and write the CIDRs.
+	kops toolbox plan-subnets k8s-cluster.example.com --weight private=8,utility=1 --replace --yes
+	`))
+
+	toolboxPlanSubnetsShort = i18n.T(`Compute the CIDRs of the subnets of a cluster.`)
+)
+
+type ToolboxPlanSubnetsOptions struct {
+	ClusterName string
+	// NetworkCIDR is the CIDR to allocate the subnets from; defaults to the network CIDR of the cluster.
+	NetworkCIDR string
+	// Weights overrides the relative size of the subnets of each type, as type=weight.
+	Weights []string
+	// Replace plans the CIDRs of the subnets that already have one.
+	Replace bool
+	// Yes writes the planned CIDRs to the cluster spec.
+	Yes bool
+}
+
+// defaultSubnetWeights is the relative size of the subnets of each type, unless overridden.
+var defaultSubnetWeights = map[string]int{
+	"public":    4,
+	"private":   4,
+	"dualstack": 4,
+	"utility":   1,
+}
+
+func NewCmdToolboxPlanSubnets(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxPlanSubnetsOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "plan-subnets [CLUSTER]",
+		Short:             toolboxPlanSubnetsShort,
+		Long:              toolboxPlanSubnetsLong,
+		Example:           toolboxPlanSubnetsExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxPlanSubnets(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.NetworkCIDR, "network-cidr", options.NetworkCIDR, "Network CIDR to allocate the subnets from. Defaults to the network CIDR of the cluster")
+	cmd.Flags().StringSliceVar(&options.Weights, "weight", options.Weights, "Relative size of the subnets of each type. Defaults to public=4,private=4,dualstack=4,utility=1")
+	cmd.Flags().BoolVar(&options.Replace, "replace", options.Replace, "Replace the CIDRs of the subnets that already have one")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Write the planned CIDRs to the cluster spec")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+	commandutils.SetApplyFlag(cmd, "yes")
+
+	return cmd
+}
+
+// plannedSubnet is a row of the plan-subnets table.
+type plannedSubnet struct {
+	Name string
+	Zone string
+	Type kops.SubnetType
+	Old  string
+	New  string
+}
+
+func RunToolboxPlanSubnets(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxPlanSubnetsOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	planned, err := planClusterSubnets(cluster, options)
+	if err != nil {
+		return err
+	}
+
+	if len(planned) == 0 {
+		fmt.Fprintf(out, "No subnets to plan\n")
+		return nil
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("SUBNET", func(s *plannedSubnet) string {
+		return s.Name
+	})
+	t.AddColumn("ZONE", func(s *plannedSubnet) string {
+		return s.Zone
+	})
+	t.AddColumn("TYPE", func(s *plannedSubnet) string {
+		return string(s.Type)
+	})
+	t.AddColumn("OLD", func(s *plannedSubnet) string {
+		return s.Old
+	})
+	t.AddColumn("NEW", func(s *plannedSubnet) string {
+		return s.New
+	})
+	if err := t.Render(planned, out, "SUBNET", "ZONE", "TYPE", "OLD", "NEW"); err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to write the CIDRs to the cluster spec\n")
+		return nil
+	}
+
+	newCIDRs := make(map[string]string)
+	for _, s := range planned {
+		newCIDRs[s.Name] = s.New
+	}
+	for i := range cluster.Spec.Networking.Subnets {
+		s := &cluster.Spec.Networking.Subnets[i]
+		if cidr, found := newCIDRs[s.Name]; found {
+			s.CIDR = cidr
+		}
+	}
+	if options.NetworkCIDR != "" {
+		cluster.Spec.Networking.NetworkCIDR = options.NetworkCIDR
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+	if err := commands.UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nCIDRs written to the cluster spec.\n")
+	fmt.Fprintf(out, "You can now apply these changes, using `kops update cluster %s`\n", cluster.ObjectMeta.Name)
+
+	return nil
+}
+
+// planClusterSubnets plans the CIDRs of the subnets of the cluster, without changing the cluster.
+func planClusterSubnets(cluster *kops.Cluster, options *ToolboxPlanSubnetsOptions) ([]*plannedSubnet, error) {
+	networkCIDR := options.NetworkCIDR
+	if networkCIDR == "" {
+		networkCIDR = cluster.Spec.Networking.NetworkCIDR
+	}
+	_, parent, err := net.ParseCIDR(networkCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network CIDR %q", networkCIDR)
+	}
+
+	weights := make(map[string]int)
+	for k, v := range defaultSubnetWeights {
+		weights[k] = v
+	}
+	for _, w := range options.Weights {
+		subnetType, value, found := strings.Cut(w, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid weight %q, expected type=weight", w)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q, expected type=weight", w)
+		}
+		weights[strings.ToLower(subnetType)] = weight
+	}
+
+	var requests []subnet.PlanRequest
+	var reserved []*net.IPNet
+	var planned []*plannedSubnet
+	for i := range cluster.Spec.Networking.Subnets {
+		s := &cluster.Spec.Networking.Subnets[i]
+
+		var cidr *net.IPNet
+		if s.CIDR != "" {
+			_, cidr, err = net.ParseCIDR(s.CIDR)
+			if err != nil {
+				return nil, fmt.Errorf("subnet %q has invalid CIDR %q", s.Name, s.CIDR)
+			}
+		}
+
+		// Shared subnets and subnets outside of the network CIDR keep their CIDRs,
+		// without taking a share of the network CIDR
+		if s.ID != "" || (cidr != nil && !parent.Contains(cidr.IP)) {
+			if cidr != nil {
+				reserved = append(reserved, cidr)
+			}
+			continue
+		}
+		// IPv6-only subnets do not need an IPv4 CIDR
+		if s.IPv6CIDR != "" && s.Type == kops.SubnetTypePrivate && cidr == nil {
+			continue
+		}
+
+		weight, found := weights[strings.ToLower(string(s.Type))]
+		if !found {
+			return nil, fmt.Errorf("no weight for subnet %q of type %q", s.Name, s.Type)
+		}
+
+		// The other subnets keep their CIDRs unless replaced, but still take their share of the network CIDR
+		if cidr != nil && !options.Replace {
+			requests = append(requests, subnet.PlanRequest{Name: s.Name, Weight: weight, CIDR: cidr})
+			continue
+		}
+
+		requests = append(requests, subnet.PlanRequest{Name: s.Name, Weight: weight})
+		planned = append(planned, &plannedSubnet{
+			Name: s.Name,
+			Zone: s.Zone,
+			Type: s.Type,
+			Old:  s.CIDR,
+		})
+	}
+
+	// Plan in a consistent order, so that the same spec gets the same CIDRs
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].Name < requests[j].Name
+	})
+
+	cidrs, err := subnet.Plan(parent, requests, reserved)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range planned {
+		s.New = cidrs[s.Name].String()
+	}
+
+	return planned, nil
+}
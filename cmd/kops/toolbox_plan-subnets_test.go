@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestPlanClusterSubnets(t *testing.T) {
+	newCluster := func() *kops.Cluster {
+		cluster := &kops.Cluster{}
+		cluster.Spec.Networking.NetworkCIDR = "10.0.0.0/16"
+		cluster.Spec.Networking.Subnets = []kops.ClusterSubnetSpec{
+			{Name: "us-test-1a", Zone: "us-test-1a", Type: kops.SubnetTypePrivate},
+			{Name: "us-test-1b", Zone: "us-test-1b", Type: kops.SubnetTypePrivate, CIDR: "10.0.64.0/18"},
+			{Name: "utility-us-test-1a", Zone: "us-test-1a", Type: kops.SubnetTypeUtility},
+			{Name: "utility-us-test-1b", Zone: "us-test-1b", Type: kops.SubnetTypeUtility},
+			{Name: "shared", Zone: "us-test-1a", Type: kops.SubnetTypePrivate, ID: "subnet-1", CIDR: "10.0.255.0/24"},
+		}
+		return cluster
+	}
+
+	tests := []struct {
+		name     string
+		options  func(o *ToolboxPlanSubnetsOptions)
+		expected map[string]string
+	}{
+		{
+			name: "keep existing CIDRs",
+			expected: map[string]string{
+				"us-test-1a":         "10.0.0.0/18",
+				"utility-us-test-1a": "10.0.128.0/20",
+				"utility-us-test-1b": "10.0.144.0/20",
+			},
+		},
+		{
+			name: "replace existing CIDRs",
+			options: func(o *ToolboxPlanSubnetsOptions) {
+				o.Replace = true
+			},
+			expected: map[string]string{
+				"us-test-1a":         "10.0.0.0/18",
+				"us-test-1b":         "10.0.64.0/18",
+				"utility-us-test-1a": "10.0.128.0/20",
+				"utility-us-test-1b": "10.0.144.0/20",
+			},
+		},
+		{
+			name: "custom weights and network CIDR",
+			options: func(o *ToolboxPlanSubnetsOptions) {
+				o.NetworkCIDR = "10.0.0.0/17"
+				o.Replace = true
+				o.Weights = []string{"Utility=4"}
+			},
+			expected: map[string]string{
+				"us-test-1a":         "10.0.0.0/19",
+				"us-test-1b":         "10.0.32.0/19",
+				"utility-us-test-1a": "10.0.64.0/19",
+				"utility-us-test-1b": "10.0.96.0/19",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := &ToolboxPlanSubnetsOptions{}
+			if tt.options != nil {
+				tt.options(options)
+			}
+
+			planned, err := planClusterSubnets(newCluster(), options)
+			if err != nil {
+				t.Fatalf("error planning subnets: %v", err)
+			}
+			actual := make(map[string]string)
+			for _, s := range planned {
+				actual[s.Name] = s.New
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("unexpected plan: actual=%v, expected=%v", actual, tt.expected)
+			}
+		})
+	}
+}
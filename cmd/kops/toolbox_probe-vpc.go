@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	toolboxProbeVPCLong = templates.LongDesc(i18n.T(`
+	Inspect an existing AWS VPC and report the cluster topologies that it can host.
+
+	The subnets are classified as public, if their default route is an internet gateway,
+	or private otherwise. The report lists the changes needed to the VPC, the load balancer
+	tags missing from the subnets, and the subnets of the cluster spec that use the existing
+	subnets for each supported topology. Nothing is changed.`))
+
+	toolboxProbeVPCExample = templates.Examples(i18n.T(`
+	# Report the topologies that a VPC can host.
+	kops toolbox probe-vpc --vpc vpc-123 --region us-east-1
+	`))
+
+	toolboxProbeVPCShort = i18n.T(`Inspect an existing VPC for use by a cluster.`)
+)
+
+type ToolboxProbeVPCOptions struct {
+	VPCID  string
+	Region string
+	Output string
+}
+
+func (o *ToolboxProbeVPCOptions) InitDefaults() {
+	o.Output = OutputTable
+}
+
+func NewCmdToolboxProbeVPC(out io.Writer) *cobra.Command {
+	options := &ToolboxProbeVPCOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:     "probe-vpc",
+		Short:   toolboxProbeVPCShort,
+		Long:    toolboxProbeVPCLong,
+		Example: toolboxProbeVPCExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxProbeVPC(cmd.Context(), out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.VPCID, "vpc", options.VPCID, "ID of the VPC to inspect")
+	cmd.MarkFlagRequired("vpc")
+	cmd.RegisterFlagCompletionFunc("vpc", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&options.Region, "region", options.Region, "Region of the VPC")
+	cmd.MarkFlagRequired("region")
+	cmd.RegisterFlagCompletionFunc("region", cobra.NoFileCompletions)
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format.  One of table, json or yaml")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputTable, OutputJSON, OutputYaml}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierPlan)
+
+	return cmd
+}
+
+func RunToolboxProbeVPC(ctx context.Context, out io.Writer, options *ToolboxProbeVPCOptions) error {
+	switch options.Output {
+	case OutputTable, OutputJSON, OutputYaml:
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+
+	cloud, err := awsup.NewAWSCloud(options.Region, nil)
+	if err != nil {
+		return fmt.Errorf("error initializing AWS client: %w", err)
+	}
+
+	probe, err := awsup.ProbeVPC(ctx, cloud, options.VPCID)
+	if err != nil {
+		return err
+	}
+
+	switch options.Output {
+	case OutputJSON:
+		b, err := json.MarshalIndent(probe, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling json: %w", err)
+		}
+		_, err = out.Write(append(b, '\n'))
+		return err
+
+	case OutputYaml:
+		b, err := yaml.Marshal(probe)
+		if err != nil {
+			return fmt.Errorf("error marshaling yaml: %w", err)
+		}
+		_, err = out.Write(b)
+		return err
+
+	default:
+		return probeVPCOutputTable(probe, out)
+	}
+}
+
+func probeVPCOutputTable(probe *awsup.VPCProbe, out io.Writer) error {
+	fmt.Fprintf(out, "VPC %s (%s)\n", probe.VPCID, probe.CIDR)
+	fmt.Fprintf(out, "  enableDnsHostnames: %t\n", probe.EnableDNSHostnames)
+	fmt.Fprintf(out, "  enableDnsSupport: %t\n", probe.EnableDNSSupport)
+	internetGateway := probe.InternetGatewayID
+	if internetGateway == "" {
+		internetGateway = "none"
+	}
+	fmt.Fprintf(out, "  internet gateway: %s\n\n", internetGateway)
+
+	{
+		t := &tables.Table{}
+		t.AddColumn("SUBNET", func(s *awsup.SubnetProbe) string {
+			return s.ID
+		})
+		t.AddColumn("ZONE", func(s *awsup.SubnetProbe) string {
+			return s.Zone
+		})
+		t.AddColumn("CIDR", func(s *awsup.SubnetProbe) string {
+			return s.CIDR
+		})
+		t.AddColumn("ROUTE TABLE", func(s *awsup.SubnetProbe) string {
+			return s.RouteTableID
+		})
+		t.AddColumn("DEFAULT ROUTE", func(s *awsup.SubnetProbe) string {
+			return s.DefaultRoute
+		})
+		t.AddColumn("PUBLIC", func(s *awsup.SubnetProbe) string {
+			return fmt.Sprintf("%t", s.Public)
+		})
+		t.AddColumn("MISSING TAGS", func(s *awsup.SubnetProbe) string {
+			var tags []string
+			for k, v := range s.MissingTags {
+				tags = append(tags, k+"="+v)
+			}
+			sort.Strings(tags)
+			return strings.Join(tags, ",")
+		})
+		if err := t.Render(probe.Subnets, out, "SUBNET", "ZONE", "CIDR", "ROUTE TABLE", "DEFAULT ROUTE", "PUBLIC", "MISSING TAGS"); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "\n")
+	{
+		t := &tables.Table{}
+		t.AddColumn("TOPOLOGY", func(p *awsup.TopologyProbe) string {
+			return p.Topology
+		})
+		t.AddColumn("SUPPORTED", func(p *awsup.TopologyProbe) string {
+			return fmt.Sprintf("%t", p.Supported)
+		})
+		t.AddColumn("ZONES", func(p *awsup.TopologyProbe) string {
+			return strings.Join(p.Zones, ",")
+		})
+		t.AddColumn("PROBLEMS", func(p *awsup.TopologyProbe) string {
+			return strings.Join(p.Problems, "; ")
+		})
+		if err := t.Render(probe.Topologies, out, "TOPOLOGY", "SUPPORTED", "ZONES", "PROBLEMS"); err != nil {
+			return err
+		}
+	}
+
+	for _, topology := range probe.Topologies {
+		if len(topology.Subnets) == 0 {
+			continue
+		}
+		b, err := yaml.Marshal(map[string]interface{}{"subnets": topology.Subnets})
+		if err != nil {
+			return fmt.Errorf("error marshaling yaml: %w", err)
+		}
+		fmt.Fprintf(out, "\nSubnets of the cluster spec for the %s topology, with networkID %s:\n\n%s", topology.Topology, probe.VPCID, b)
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/clusterttl"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxReapClustersLong = templates.LongDesc(i18n.T(`
+	Delete the clusters of the state store whose spec.ttl has passed since their creation.
+
+	When spec.ttlNotification lists webhooks, they receive a JSON POST request before the
+	cluster is deleted, and the time of the notification is recorded in the
+	kops.k8s.io/ttl-notified annotation of the cluster. The cluster is then deleted once it
+	has expired, and no sooner than spec.ttlNotification.before after the notification.
+
+	Clusters cannot delete themselves, so this command is meant to run periodically, for
+	example as a scheduled CI job. Clusters with deletion protection are never deleted.
+	Nothing is changed unless --yes is set.`))
+
+	toolboxReapClustersExample = templates.Examples(i18n.T(`
+	# Preview the clusters that have expired.
+	kops toolbox reap-clusters
+
+	# Notify the webhooks of expiring clusters, and delete the expired clusters.
+	kops toolbox reap-clusters --yes
+	`))
+
+	toolboxReapClustersShort = i18n.T(`Delete the clusters whose TTL has expired.`)
+)
+
+type ToolboxReapClustersOptions struct {
+	// Yes sends the notifications and deletes the expired clusters.
+	Yes bool
+	// WebhookTimeout is the timeout of the requests to the notification webhooks.
+	WebhookTimeout time.Duration
+}
+
+func (o *ToolboxReapClustersOptions) InitDefaults() {
+	o.WebhookTimeout = 30 * time.Second
+}
+
+func NewCmdToolboxReapClusters(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxReapClustersOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:     "reap-clusters",
+		Short:   toolboxReapClustersShort,
+		Long:    toolboxReapClustersLong,
+		Example: toolboxReapClustersExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxReapClusters(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Notify the webhooks and delete the expired clusters")
+	cmd.Flags().DurationVar(&options.WebhookTimeout, "webhook-timeout", options.WebhookTimeout, "Timeout of the requests to the notification webhooks")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+	commandutils.SetApplyFlag(cmd, "yes")
+
+	return cmd
+}
+
+// expiringCluster is a row of the reap-clusters table.
+type expiringCluster struct {
+	Cluster *kops.Cluster
+	Status  *clusterttl.Status
+}
+
+func RunToolboxReapClusters(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxReapClustersOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	clusters, err := clientset.ListClusters(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var expiring []*expiringCluster
+	var errs []error
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		// A cluster with an invalid TTL must not stop the other clusters from being reaped
+		status, err := clusterttl.Evaluate(cluster, now)
+		if err != nil {
+			klog.Warningf("error evaluating the ttl of cluster %q: %v", cluster.Name, err)
+			errs = append(errs, fmt.Errorf("error evaluating the ttl of cluster %q: %w", cluster.Name, err))
+			continue
+		}
+		if status == nil {
+			continue
+		}
+		expiring = append(expiring, &expiringCluster{Cluster: cluster, Status: status})
+	}
+
+	if len(expiring) == 0 {
+		fmt.Fprintf(out, "No clusters with a TTL found\n")
+		return errors.Join(errs...)
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("NAME", func(c *expiringCluster) string {
+		return c.Cluster.Name
+	})
+	t.AddColumn("EXPIRES", func(c *expiringCluster) string {
+		return c.Status.ExpirationTimestamp.UTC().Format(time.RFC3339)
+	})
+	t.AddColumn("DELETES", func(c *expiringCluster) string {
+		return c.Status.DeletionTimestamp.UTC().Format(time.RFC3339)
+	})
+	t.AddColumn("ACTION", func(c *expiringCluster) string {
+		return string(c.Status.Action)
+	})
+	if err := t.Render(expiring, out, "NAME", "EXPIRES", "DELETES", "ACTION"); err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to notify and delete the expired clusters\n")
+		return errors.Join(errs...)
+	}
+
+	httpClient := &http.Client{Timeout: options.WebhookTimeout}
+	for _, c := range expiring {
+		switch c.Status.Action {
+		case clusterttl.ActionNotify:
+			// A webhook that keeps failing must not keep the cluster alive, so the notification
+			// is recorded even when some of the webhooks failed.
+			if err := clusterttl.Notify(ctx, httpClient, c.Cluster, c.Status); err != nil {
+				klog.Warningf("error notifying about the expiration of cluster %q: %v", c.Cluster.Name, err)
+			}
+			if c.Cluster.Annotations == nil {
+				c.Cluster.Annotations = make(map[string]string)
+			}
+			c.Cluster.Annotations[clusterttl.AnnotationNotified] = now.UTC().Format(time.RFC3339)
+			if _, err := clientset.UpdateCluster(ctx, c.Cluster, nil); err != nil {
+				errs = append(errs, fmt.Errorf("error recording the notification of cluster %q: %w", c.Cluster.Name, err))
+				continue
+			}
+			fmt.Fprintf(out, "\nNotified the webhooks of cluster %q\n", c.Cluster.Name)
+
+		case clusterttl.ActionDelete:
+			fmt.Fprintf(out, "\nDeleting expired cluster %q\n", c.Cluster.Name)
+			deleteOptions := &DeleteClusterOptions{}
+			deleteOptions.InitDefaults()
+			deleteOptions.ClusterName = c.Cluster.Name
+			deleteOptions.Yes = true
+			if err := RunDeleteCluster(ctx, f, out, deleteOptions); err != nil {
+				errs = append(errs, fmt.Errorf("error deleting cluster %q: %w", c.Cluster.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/clusterttl"
+	"k8s.io/kops/pkg/testutils"
+)
+
+func TestReapClustersInvalidTTL(t *testing.T) {
+	ctx := context.Background()
+
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	factoryOptions := &util.FactoryOptions{}
+	factoryOptions.RegistryPath = "memfs://tests"
+	factory := util.NewFactory(factoryOptions)
+	clientSet, err := factory.KopsClient()
+	if err != nil {
+		t.Fatalf("could not create clientset: %v", err)
+	}
+
+	invalid := testutils.BuildMinimalCluster("invalid.example.com")
+	invalid.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+	invalid.Spec.TTLNotification = &kops.TTLNotificationSpec{WebhookURLs: []string{"https://hooks.example.com/ci"}}
+	invalid.Annotations = map[string]string{clusterttl.AnnotationNotified: "yesterday"}
+	valid := testutils.BuildMinimalCluster("valid.example.com")
+	valid.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+	for _, cluster := range []*kops.Cluster{invalid, valid} {
+		if _, err := clientSet.CreateCluster(ctx, cluster); err != nil {
+			t.Fatalf("could not create cluster: %v", err)
+		}
+	}
+
+	var stdout bytes.Buffer
+	err = RunToolboxReapClusters(ctx, factory, &stdout, &ToolboxReapClustersOptions{})
+	if err == nil || !strings.Contains(err.Error(), "invalid.example.com") {
+		t.Errorf("expected an error for cluster invalid.example.com, got %v", err)
+	}
+	if !strings.Contains(stdout.String(), "valid.example.com") {
+		t.Errorf("expected cluster valid.example.com to be listed, got %q", stdout.String())
+	}
+}
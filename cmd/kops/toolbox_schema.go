@@ -0,0 +1,308 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops"
+	"k8s.io/kops/k8s/crds"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	SchemaFormatOpenAPI    = "openapi"
+	SchemaFormatJSONSchema = "jsonschema"
+)
+
+var (
+	toolboxSchemaLong = templates.LongDesc(i18n.T(`
+	Print the schema of the kOps API types.
+
+	The schemas are taken from the CustomResourceDefinitions generated from the Go types,
+	so they always match the kOps version in use. The OpenAPI format emits a document with
+	the types as components; the JSON Schema format emits a schema that can be used by
+	editors to validate manifests.
+	`))
+
+	toolboxSchemaExample = templates.Examples(i18n.T(`
+	# Print the OpenAPI schema of the Cluster and InstanceGroup types
+	kops toolbox schema
+
+	# Print a JSON Schema of the InstanceGroup type
+	kops toolbox schema --format jsonschema --kind InstanceGroup > instancegroup.schema.json
+
+	# Print the OpenAPI schema of the v1alpha3 types
+	kops toolbox schema --api-version v1alpha3
+	`))
+
+	toolboxSchemaShort = i18n.T(`Print the schema of the kOps API types`)
+)
+
+type ToolboxSchemaOptions struct {
+	// Format is the schema format, one of openapi or jsonschema.
+	Format string
+	// APIVersion is the version of the kops.k8s.io API group.
+	APIVersion string
+	// Kinds restricts the output to the given kinds.
+	Kinds []string
+}
+
+func (o *ToolboxSchemaOptions) InitDefaults() {
+	o.Format = SchemaFormatOpenAPI
+	o.APIVersion = "v1alpha2"
+	o.Kinds = []string{"Cluster", "InstanceGroup"}
+}
+
+func NewCmdToolboxSchema(out io.Writer) *cobra.Command {
+	options := &ToolboxSchemaOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:     "schema",
+		Short:   toolboxSchemaShort,
+		Long:    toolboxSchemaLong,
+		Example: toolboxSchemaExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxSchema(out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Format, "format", options.Format, "Schema format. One of openapi|jsonschema.")
+	cmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{SchemaFormatOpenAPI, SchemaFormatJSONSchema}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVar(&options.APIVersion, "api-version", options.APIVersion, "Version of the kops.k8s.io API. One of v1alpha2|v1alpha3.")
+	cmd.RegisterFlagCompletionFunc("api-version", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"v1alpha2", "v1alpha3"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringSliceVar(&options.Kinds, "kind", options.Kinds, "Kinds to include in the schema")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
+	return cmd
+}
+
+func RunToolboxSchema(out io.Writer, options *ToolboxSchemaOptions) error {
+	schemas, err := loadCRDSchemas(options.APIVersion)
+	if err != nil {
+		return err
+	}
+
+	selected := make(map[string]map[string]interface{})
+	for _, kind := range options.Kinds {
+		schema, found := schemas[kind]
+		if !found {
+			var known []string
+			for k := range schemas {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("unknown kind %q for API version %q, available kinds: %s", kind, options.APIVersion, strings.Join(known, ","))
+		}
+		selected[kind] = schema
+	}
+
+	var document map[string]interface{}
+	switch options.Format {
+	case SchemaFormatOpenAPI:
+		document = buildOpenAPIDocument(options.APIVersion, selected)
+	case SchemaFormatJSONSchema:
+		document = buildJSONSchemaDocument(options.APIVersion, selected)
+	default:
+		return fmt.Errorf("unknown schema format %q, available formats: %s,%s", options.Format, SchemaFormatOpenAPI, SchemaFormatJSONSchema)
+	}
+
+	b, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling schema: %w", err)
+	}
+	b = append(b, '\n')
+	if _, err := out.Write(b); err != nil {
+		return fmt.Errorf("error writing to output: %w", err)
+	}
+
+	return nil
+}
+
+// loadCRDSchemas returns the OpenAPI v3 schemas of the given API version, keyed by kind.
+func loadCRDSchemas(apiVersion string) (map[string]map[string]interface{}, error) {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "v1alpha3/*.yaml"} {
+		matches, err := fs.Glob(crds.Content, pattern)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+
+	schemas := make(map[string]map[string]interface{})
+	for _, file := range files {
+		b, err := crds.Content.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %q: %w", file, err)
+		}
+
+		var crd struct {
+			Spec struct {
+				Names struct {
+					Kind string `json:"kind"`
+				} `json:"names"`
+				Versions []struct {
+					Name   string `json:"name"`
+					Schema struct {
+						OpenAPIV3Schema map[string]interface{} `json:"openAPIV3Schema"`
+					} `json:"schema"`
+				} `json:"versions"`
+			} `json:"spec"`
+		}
+		if err := yaml.Unmarshal(b, &crd); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %w", file, err)
+		}
+
+		for _, version := range crd.Spec.Versions {
+			if version.Name == apiVersion {
+				schemas[crd.Spec.Names.Kind] = version.Schema.OpenAPIV3Schema
+			}
+		}
+	}
+
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("unknown API version %q", apiVersion)
+	}
+
+	return schemas, nil
+}
+
+func schemaName(apiVersion, kind string) string {
+	return "io.k8s.kops." + apiVersion + "." + kind
+}
+
+func buildOpenAPIDocument(apiVersion string, schemas map[string]map[string]interface{}) map[string]interface{} {
+	components := make(map[string]interface{})
+	for kind, schema := range schemas {
+		schema = withGroupVersionKind(schema, apiVersion, kind)
+		components[schemaName(apiVersion, kind)] = schema
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "kOps",
+			"version": kops.Version,
+		},
+		"paths": map[string]interface{}{},
+		"components": map[string]interface{}{
+			"schemas": components,
+		},
+	}
+}
+
+func buildJSONSchemaDocument(apiVersion string, schemas map[string]map[string]interface{}) map[string]interface{} {
+	var kinds []string
+	for kind := range schemas {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	definitions := make(map[string]interface{})
+	var oneOf []interface{}
+	for _, kind := range kinds {
+		schema := withGroupVersionKind(schemas[kind], apiVersion, kind)
+		definitions[schemaName(apiVersion, kind)] = toJSONSchema(schema)
+		oneOf = append(oneOf, map[string]interface{}{
+			"$ref": "#/definitions/" + schemaName(apiVersion, kind),
+		})
+	}
+
+	return map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"title":       "kOps " + apiVersion,
+		"oneOf":       oneOf,
+		"definitions": definitions,
+	}
+}
+
+// withGroupVersionKind restricts apiVersion and kind to the values of the type,
+// so that a manifest matches exactly one schema.
+func withGroupVersionKind(schema map[string]interface{}, apiVersion, kind string) map[string]interface{} {
+	out := make(map[string]interface{}, len(schema)+1)
+	for k, v := range schema {
+		out[k] = v
+	}
+	required := []interface{}{"apiVersion", "kind"}
+	if r, ok := schema["required"].([]interface{}); ok {
+		for _, field := range r {
+			if field != "apiVersion" && field != "kind" {
+				required = append(required, field)
+			}
+		}
+	}
+	out["required"] = required
+
+	properties := make(map[string]interface{})
+	if p, ok := schema["properties"].(map[string]interface{}); ok {
+		for k, v := range p {
+			properties[k] = v
+		}
+	}
+	properties["apiVersion"] = map[string]interface{}{
+		"type": "string",
+		"enum": []interface{}{"kops.k8s.io/" + apiVersion},
+	}
+	properties["kind"] = map[string]interface{}{
+		"type": "string",
+		"enum": []interface{}{kind},
+	}
+	out["properties"] = properties
+
+	return out
+}
+
+// toJSONSchema strips the kubernetes extensions from an OpenAPI v3 schema, which is otherwise
+// compatible with JSON Schema; int-or-string fields already carry an equivalent anyOf.
+func toJSONSchema(schema interface{}) interface{} {
+	switch v := schema.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if strings.HasPrefix(key, "x-kubernetes-") {
+				continue
+			}
+			out[key] = toJSONSchema(value)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, value := range v {
+			out[i] = toJSONSchema(value)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolboxSchemaOpenAPI(t *testing.T) {
+	options := &ToolboxSchemaOptions{}
+	options.InitDefaults()
+
+	var out bytes.Buffer
+	require.NoError(t, RunToolboxSchema(&out, options))
+
+	var document struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &document))
+
+	assert.Equal(t, "3.0.0", document.OpenAPI)
+	assert.Len(t, document.Components.Schemas, 2)
+	for _, name := range []string{"io.k8s.kops.v1alpha2.Cluster", "io.k8s.kops.v1alpha2.InstanceGroup"} {
+		schema, found := document.Components.Schemas[name]
+		if assert.True(t, found, "schema %q not found", name) {
+			assert.Contains(t, schema.Properties, "spec")
+			assert.Contains(t, schema.Properties, "apiVersion")
+		}
+	}
+}
+
+func TestToolboxSchemaV1Alpha3(t *testing.T) {
+	options := &ToolboxSchemaOptions{}
+	options.InitDefaults()
+	options.APIVersion = "v1alpha3"
+	options.Kinds = []string{"Cluster"}
+
+	var out bytes.Buffer
+	require.NoError(t, RunToolboxSchema(&out, options))
+
+	var document struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties struct {
+					Spec struct {
+						Properties map[string]json.RawMessage `json:"properties"`
+					} `json:"spec"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &document))
+
+	schema, found := document.Components.Schemas["io.k8s.kops.v1alpha3.Cluster"]
+	require.True(t, found, "v1alpha3 Cluster schema not found")
+	assert.Contains(t, schema.Properties.Spec.Properties, "controlPlaneKubelet")
+	assert.NotContains(t, schema.Properties.Spec.Properties, "masterKubelet")
+}
+
+func TestWithGroupVersionKindKeepsRequired(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"spec", "kind"},
+		"properties": map[string]interface{}{
+			"spec": map[string]interface{}{"type": "object"},
+		},
+	}
+
+	out := withGroupVersionKind(schema, "v1alpha2", "Cluster")
+
+	assert.Equal(t, []interface{}{"apiVersion", "kind", "spec"}, out["required"])
+	assert.Equal(t, []interface{}{"spec", "kind"}, schema["required"], "input schema must not be modified")
+	assert.Contains(t, out["properties"], "spec")
+}
+
+func TestToolboxSchemaJSONSchema(t *testing.T) {
+	options := &ToolboxSchemaOptions{}
+	options.InitDefaults()
+	options.Format = SchemaFormatJSONSchema
+	options.Kinds = []string{"InstanceGroup"}
+
+	var out bytes.Buffer
+	require.NoError(t, RunToolboxSchema(&out, options))
+
+	assert.NotContains(t, out.String(), "x-kubernetes-")
+	assert.Contains(t, out.String(), `"#/definitions/io.k8s.kops.v1alpha2.InstanceGroup"`)
+	assert.NotContains(t, out.String(), "io.k8s.kops.v1alpha2.Cluster")
+}
+
+func TestToolboxSchemaErrors(t *testing.T) {
+	grid := []struct {
+		name     string
+		mutate   func(options *ToolboxSchemaOptions)
+		expected string
+	}{
+		{
+			name:     "unknown version",
+			mutate:   func(options *ToolboxSchemaOptions) { options.APIVersion = "v1" },
+			expected: `unknown API version "v1"`,
+		},
+		{
+			name:     "unknown kind",
+			mutate:   func(options *ToolboxSchemaOptions) { options.Kinds = []string{"Pod"} },
+			expected: `unknown kind "Pod"`,
+		},
+		{
+			name:     "unknown format",
+			mutate:   func(options *ToolboxSchemaOptions) { options.Format = "xml" },
+			expected: `unknown schema format "xml"`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			options := &ToolboxSchemaOptions{}
+			options.InitDefaults()
+			g.mutate(options)
+
+			err := RunToolboxSchema(&bytes.Buffer{}, options)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), g.expected)
+			}
+		})
+	}
+}
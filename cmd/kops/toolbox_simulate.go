@@ -0,0 +1,338 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/cloudmock/aws/mockacm"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/cloudmock/aws/mockelb"
+	"k8s.io/kops/cloudmock/aws/mockelbv2"
+	"k8s.io/kops/cloudmock/aws/mockeventbridge"
+	"k8s.io/kops/cloudmock/aws/mockiam"
+	"k8s.io/kops/cloudmock/aws/mockkms"
+	"k8s.io/kops/cloudmock/aws/mockrecorder"
+	"k8s.io/kops/cloudmock/aws/mockroute53"
+	"k8s.io/kops/cloudmock/aws/mocksqs"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/dns"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/text"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxSimulateLong = templates.LongDesc(i18n.T(`
+	Simulate creating a cluster against mocked cloud APIs.
+
+	The cluster and instance group manifests are loaded into an in-memory state store,
+	and the cloud APIs are replaced by the same mocks used by the kOps integration tests.
+	The cluster is created against the mocks, and every API call changing state is printed.
+	The cluster is then checked for convergence. Nothing is written to the real state store
+	or to the cloud provider.
+
+	The calls can be recorded to a file with --record, and later compared against it
+	with --replay, to review how a change to the manifests or to kOps changes them.
+
+	Only AWS is currently supported. Channels and file assets are still resolved as usual.
+
+	This command replaces global state, and is only included in kOps when built with
+	the "simulate" build tag.
+	`))
+
+	toolboxSimulateExample = templates.Examples(i18n.T(`
+	# Print the API calls kOps would make when creating the cluster in cluster.yaml
+	kops toolbox simulate -f cluster.yaml --ssh-public-key ~/.ssh/id_rsa.pub
+
+	# Check that the API calls match the ones previously recorded
+	kops toolbox simulate -f cluster.yaml --record calls.yaml
+	kops toolbox simulate -f cluster.yaml --replay calls.yaml
+	`))
+
+	toolboxSimulateShort = i18n.T(`Simulate creating a cluster against mocked cloud APIs`)
+)
+
+// simulateRegistryPath is the in-memory state store used for simulations.
+const simulateRegistryPath = "memfs://simulate"
+
+type ToolboxSimulateOptions struct {
+	// Filenames is the list of manifests holding the cluster and its instance groups.
+	Filenames []string
+	// SSHPublicKey is the path to an SSH public key to register for the cluster.
+	SSHPublicKey string
+	// Record is the path of a file to write the API calls to.
+	Record string
+	// Replay is the path of a file with previously recorded API calls, which the API calls must match.
+	Replay string
+}
+
+func NewCmdToolboxSimulate(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxSimulateOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "simulate",
+		Short:   toolboxSimulateShort,
+		Long:    toolboxSimulateLong,
+		Example: toolboxSimulateExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxSimulate(cmd.Context(), out, options)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&options.Filenames, "filename", "f", options.Filenames, "Filename of the cluster and instance group manifests to simulate")
+	cmd.MarkFlagRequired("filename")
+	cmd.RegisterFlagCompletionFunc("filename", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "yaml"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+	cmd.Flags().StringVarP(&options.SSHPublicKey, "ssh-public-key", "i", options.SSHPublicKey, "Path to an SSH public key to register for the cluster")
+	cmd.Flags().StringVar(&options.Record, "record", options.Record, "Write the API calls to this file")
+	cmd.Flags().StringVar(&options.Replay, "replay", options.Replay, "Fail if the API calls differ from the ones recorded in this file")
+	cmd.MarkFlagsMutuallyExclusive("record", "replay")
+
+	return cmd
+}
+
+// RunToolboxSimulate loads the manifests into an in-memory state store and creates the cluster against mocked cloud APIs,
+// printing the API calls changing state.
+func RunToolboxSimulate(ctx context.Context, out io.Writer, options *ToolboxSimulateOptions) error {
+	if len(options.Filenames) == 0 {
+		return fmt.Errorf("must specify at least one manifest with --filename")
+	}
+
+	cluster, instanceGroups, err := readSimulateManifests(options.Filenames)
+	if err != nil {
+		return err
+	}
+
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return fmt.Errorf("simulation is not supported for cloud provider %q", cluster.Spec.GetCloudProvider())
+	}
+
+	var expected []byte
+	if options.Replay != "" {
+		expected, err = os.ReadFile(options.Replay)
+		if err != nil {
+			return fmt.Errorf("error reading recorded API calls: %w", err)
+		}
+	}
+
+	// The mocks must be in place before the manifests are loaded, because loading them queries the cloud
+	cloud, err := setupSimulatedAWS(cluster, instanceGroups)
+	if err != nil {
+		return err
+	}
+
+	vfs.Context.ResetMemfsContext(true)
+
+	factory := util.NewFactory(&util.FactoryOptions{
+		RegistryPath: simulateRegistryPath,
+	})
+
+	if err := RunCreate(ctx, factory, io.Discard, &CreateOptions{Filenames: options.Filenames}); err != nil {
+		return fmt.Errorf("error loading manifests: %w", err)
+	}
+
+	if options.SSHPublicKey != "" {
+		if err := RunCreateSSHPublicKey(ctx, factory, io.Discard, &CreateSSHPublicKeyOptions{
+			ClusterName:   cluster.ObjectMeta.Name,
+			PublicKeyPath: options.SSHPublicKey,
+		}); err != nil {
+			return err
+		}
+	}
+
+	recorder := &mockrecorder.Recorder{}
+	recorder.Install(cloud)
+
+	if _, err := runSimulatedUpdate(ctx, factory, io.Discard, cluster.ObjectMeta.Name, true); err != nil {
+		return fmt.Errorf("error applying changes to the mocked cloud: %w", err)
+	}
+
+	calls, err := recorder.Calls()
+	if err != nil {
+		return err
+	}
+	actual, err := mockrecorder.MarshalCalls(calls)
+	if err != nil {
+		return err
+	}
+
+	var report bytes.Buffer
+	results, err := runSimulatedUpdate(ctx, factory, &report, cluster.ObjectMeta.Name, false)
+	if err != nil {
+		return err
+	}
+	if results.Target.(*fi.CloudupDryRunTarget).HasChanges() {
+		return fmt.Errorf("cluster did not converge after applying changes to the mocked cloud:\n%s", report.String())
+	}
+
+	fmt.Fprintf(out, "Expected API calls for cluster %q:\n\n", cluster.ObjectMeta.Name)
+	if _, err := out.Write(actual); err != nil {
+		return err
+	}
+
+	if options.Record != "" {
+		if err := os.WriteFile(options.Record, actual, 0o644); err != nil {
+			return fmt.Errorf("error writing recorded API calls: %w", err)
+		}
+	}
+
+	if options.Replay != "" && string(expected) != string(actual) {
+		return fmt.Errorf("API calls differ from the ones recorded in %q:\n%s", options.Replay, diff.FormatDiff(string(expected), string(actual)))
+	}
+
+	return nil
+}
+
+// readSimulateManifests decodes the cluster and instance groups from the manifests.
+func readSimulateManifests(filenames []string) (*kops.Cluster, []*kops.InstanceGroup, error) {
+	var clusters []*kops.Cluster
+	var instanceGroups []*kops.InstanceGroup
+
+	for _, f := range filenames {
+		contents, err := vfs.Context.ReadFile(f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading file %q: %w", f, err)
+		}
+		for _, section := range text.SplitContentToSections(contents) {
+			o, _, err := kopscodecs.Decode(section, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error parsing file %q: %w", f, err)
+			}
+			switch v := o.(type) {
+			case *kops.Cluster:
+				clusters = append(clusters, v)
+			case *kops.InstanceGroup:
+				instanceGroups = append(instanceGroups, v)
+			}
+		}
+	}
+
+	if len(clusters) != 1 {
+		return nil, nil, fmt.Errorf("expected exactly one cluster in the manifests, found %d", len(clusters))
+	}
+
+	return clusters[0], instanceGroups, nil
+}
+
+func runSimulatedUpdate(ctx context.Context, factory *util.Factory, out io.Writer, clusterName string, apply bool) (*UpdateClusterResults, error) {
+	options := &UpdateClusterOptions{}
+	options.InitDefaults()
+	options.ClusterName = clusterName
+	options.CreateKubecfg = false
+	options.RunTasksOptions.MaxTaskDuration = 10 * time.Second
+	if apply {
+		options.Yes = true
+	} else {
+		options.Target = cloudup.TargetDryRun
+	}
+
+	return RunUpdateCluster(ctx, factory, out, options)
+}
+
+// setupSimulatedAWS installs a mock AWS cloud for the cluster's region and zones,
+// seeded with the images and DNS zone the cluster refers to.
+func setupSimulatedAWS(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) (*awsup.MockAWSCloud, error) {
+	region, err := awsup.FindRegion(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	zoneLetters := sets.New[string]()
+	for _, subnet := range cluster.Spec.Networking.Subnets {
+		if strings.HasPrefix(subnet.Zone, region) {
+			zoneLetters.Insert(strings.TrimPrefix(subnet.Zone, region))
+		}
+	}
+
+	cloud := awsup.InstallMockAWSCloud(region, strings.Join(sets.List(zoneLetters), ""))
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+	mockRoute53 := &mockroute53.MockRoute53{}
+	cloud.MockRoute53 = mockRoute53
+	cloud.MockELB = &mockelb.MockELB{}
+	cloud.MockELBV2 = &mockelbv2.MockELBV2{EC2: mockEC2}
+	cloud.MockIAM = &mockiam.MockIAM{}
+	cloud.MockAutoscaling = &mockautoscaling.MockAutoscaling{}
+	cloud.MockSQS = &mocksqs.MockSQS{}
+	cloud.MockEventBridge = &mockeventbridge.MockEventBridge{}
+	cloud.MockACM = &mockacm.MockACM{}
+	cloud.MockKMS = &mockkms.MockKMS{}
+
+	images := sets.New[string]()
+	for _, ig := range instanceGroups {
+		if ig.Spec.Image != "" && !strings.HasPrefix(ig.Spec.Image, "ami-") && !strings.HasPrefix(ig.Spec.Image, "ssm:") {
+			images.Insert(ig.Spec.Image)
+		}
+	}
+	for i, image := range sets.List(images) {
+		owner, name := "self", image
+		if tokens := strings.SplitN(image, "/", 2); len(tokens) == 2 {
+			owner, name = tokens[0], tokens[1]
+		}
+		architecture := ec2types.ArchitectureValuesX8664
+		if strings.Contains(name, "arm64") {
+			architecture = ec2types.ArchitectureValuesArm64
+		}
+		mockEC2.Images = append(mockEC2.Images, &ec2types.Image{
+			CreationDate:   aws.String("2024-01-01T00:00:00.000Z"),
+			ImageId:        aws.String(fmt.Sprintf("ami-%08d", i+1)),
+			Name:           aws.String(name),
+			OwnerId:        aws.String(owner),
+			RootDeviceName: aws.String("/dev/xvda"),
+			Architecture:   architecture,
+		})
+	}
+
+	if !dns.IsGossipClusterName(cluster.ObjectMeta.Name) && !cluster.UsesNoneDNS() {
+		zoneName := cluster.Spec.DNSZone
+		if zoneName == "" {
+			zoneName = cluster.ObjectMeta.Name[strings.Index(cluster.ObjectMeta.Name, ".")+1:]
+		}
+		mockRoute53.MockCreateZone(&route53types.HostedZone{
+			Id:   aws.String("/hostedzone/ZSIMULATED"),
+			Name: aws.String(strings.TrimSuffix(zoneName, ".") + "."),
+			Config: &route53types.HostedZoneConfig{
+				PrivateZone: false,
+			},
+		}, nil)
+	}
+
+	return cloud, nil
+}
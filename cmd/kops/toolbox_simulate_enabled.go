@@ -0,0 +1,33 @@
+//go:build simulate
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/commands/commandutils"
+)
+
+// extraToolboxCommands are toolbox commands only included by some build tags.
+// The simulate command replaces the cloud provider and the in-memory filesystem with mocks,
+// so it is kept out of release builds.
+var extraToolboxCommands = []func(f commandutils.Factory, out io.Writer) *cobra.Command{
+	NewCmdToolboxSimulate,
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/pkg/testutils/golden"
+)
+
+func TestSetupSimulatedAWS(t *testing.T) {
+	cluster, instanceGroups, err := readSimulateManifests([]string{"../../tests/integration/update_cluster/minimal-1.26/in-v1alpha2.yaml"})
+	require.NoError(t, err)
+	assert.Equal(t, "minimal.example.com", cluster.ObjectMeta.Name)
+	assert.Len(t, instanceGroups, 2)
+
+	cloud, err := setupSimulatedAWS(cluster, instanceGroups)
+	require.NoError(t, err)
+
+	image, err := cloud.ResolveImage(instanceGroups[0].Spec.Image)
+	require.NoError(t, err)
+	assert.NotEmpty(t, aws.ToString(image.ImageId))
+
+	zones, err := cloud.Route53().ListHostedZonesByName(context.TODO(), &route53.ListHostedZonesByNameInput{
+		DNSName: aws.String("example.com."),
+	})
+	require.NoError(t, err)
+	require.Len(t, zones.HostedZones, 1)
+	assert.Equal(t, "example.com.", aws.ToString(zones.HostedZones[0].Name))
+
+	assert.Equal(t, "us-test-1", cloud.Region())
+}
+
+func TestRunToolboxSimulate(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	// Serve the kOps assets from a local directory, so that their hashes are known without network access
+	baseDir := t.TempDir()
+	for _, asset := range []string{
+		"linux/amd64/nodeup",
+		"linux/arm64/nodeup",
+		"linux/amd64/protokube",
+		"linux/arm64/protokube",
+		"linux/amd64/channels",
+		"linux/arm64/channels",
+		"images/kops-utils-cp-amd64.tar.gz",
+		"images/kops-utils-cp-arm64.tar.gz",
+		"images/kops-controller-amd64.tar.gz",
+		"images/kops-controller-arm64.tar.gz",
+		"images/dns-controller-amd64.tar.gz",
+		"images/dns-controller-arm64.tar.gz",
+		"images/kube-apiserver-healthcheck-amd64.tar.gz",
+		"images/kube-apiserver-healthcheck-arm64.tar.gz",
+	} {
+		p := filepath.Join(baseDir, asset+".sha256")
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(strings.Repeat("0", 64)), 0o644))
+	}
+	t.Setenv("KOPS_BASE_URL", "file://"+filepath.ToSlash(baseDir)+"/")
+
+	srcDir := "../../tests/integration/toolbox_simulate/minimal"
+	expectedPath := filepath.Join(srcDir, "expected-api-calls.yaml")
+	options := &ToolboxSimulateOptions{
+		Filenames:    []string{filepath.Join(srcDir, "in-v1alpha2.yaml")},
+		SSHPublicKey: filepath.Join(srcDir, "id_rsa.pub"),
+		Record:       filepath.Join(t.TempDir(), "api-calls.yaml"),
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, RunToolboxSimulate(context.TODO(), &out, options))
+	assert.Contains(t, out.String(), "Expected API calls for cluster \"minimal.k8s.local\"")
+
+	recorded, err := os.ReadFile(options.Record)
+	require.NoError(t, err)
+	golden.AssertMatchesFile(t, string(recorded), expectedPath)
+
+	// A second simulation must make the same calls
+	options.Record = ""
+	options.Replay = expectedPath
+	require.NoError(t, RunToolboxSimulate(context.TODO(), &bytes.Buffer{}, options))
+}
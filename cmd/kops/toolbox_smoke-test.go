@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/smoketest"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxSmokeTestLong = templates.LongDesc(i18n.T(`
+	Check that a cluster works end-to-end by deploying a canary workload.
+
+	The smoke test runs a deployment behind a service, connects to the service by its
+	DNS name from a job, waits for a service of type LoadBalancer to get an address and
+	for a pod to mount a persistent volume claim of the default storage class. The
+	namespace of the canary workload is deleted at the end, which also deletes the cloud
+	load balancer and volume.
+
+	Checks that depend on a failed check are skipped. The command fails if any check
+	failed, and --junit-report writes the results as a JUnit XML report for CI pipelines.`))
+
+	toolboxSmokeTestExample = templates.Examples(i18n.T(`
+	# Run the smoke test against a newly created cluster.
+	kops toolbox smoke-test k8s-cluster.example.com
+
+	# Skip the volume check, and write a JUnit report.
+	kops toolbox smoke-test k8s-cluster.example.com --volume=false --junit-report=artifacts/junit_smoke-test.xml
+	`))
+
+	toolboxSmokeTestShort = i18n.T(`Check a cluster end-to-end with a canary workload.`)
+)
+
+type ToolboxSmokeTestOptions struct {
+	ClusterName string
+	Kubeconfig  string
+
+	Namespace    string
+	Image        string
+	LoadBalancer bool
+	Volume       bool
+	Keep         bool
+	Timeout      time.Duration
+	Interval     time.Duration
+
+	// JUnitReport is the path of the JUnit XML report, if set.
+	JUnitReport string
+}
+
+func (o *ToolboxSmokeTestOptions) InitDefaults() {
+	o.Namespace = smoketest.DefaultNamespace
+	o.Image = smoketest.DefaultImage
+	o.LoadBalancer = true
+	o.Volume = true
+	o.Timeout = 5 * time.Minute
+	o.Interval = 5 * time.Second
+}
+
+func NewCmdToolboxSmokeTest(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxSmokeTestOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "smoke-test [CLUSTER]",
+		Short:             toolboxSmokeTestShort,
+		Long:              toolboxSmokeTestLong,
+		Example:           toolboxSmokeTestExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxSmokeTest(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Kubeconfig, "kubeconfig", options.Kubeconfig, "Path to the kubeconfig file")
+	cmd.Flags().StringVar(&options.Namespace, "namespace", options.Namespace, "Namespace of the canary workload, which must not exist")
+	cmd.Flags().StringVar(&options.Image, "image", options.Image, "Image of the canary pods, providing the agnhost commands")
+	cmd.Flags().BoolVar(&options.LoadBalancer, "load-balancer", options.LoadBalancer, "Check services of type LoadBalancer")
+	cmd.Flags().BoolVar(&options.Volume, "volume", options.Volume, "Check persistent volumes of the default storage class")
+	cmd.Flags().BoolVar(&options.Keep, "keep", options.Keep, "Keep the canary workload after the checks, for troubleshooting")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", options.Timeout, "Maximum time to wait for each check")
+	cmd.Flags().DurationVar(&options.Interval, "interval", options.Interval, "Time to wait between polls of the canary workload")
+	cmd.Flags().StringVar(&options.JUnitReport, "junit-report", options.JUnitReport, "Path of the JUnit XML report to write")
+
+	// The canary workload creates objects in the cluster, and cloud load balancers and volumes
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+
+	return cmd
+}
+
+func RunToolboxSmokeTest(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxSmokeTestOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	contextName := cluster.ObjectMeta.Name
+	configLoadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if options.Kubeconfig != "" {
+		configLoadingRules.ExplicitPath = options.Kubeconfig
+	}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		configLoadingRules,
+		&clientcmd.ConfigOverrides{CurrentContext: contextName}).ClientConfig()
+	if err != nil {
+		return fmt.Errorf("cannot load kubecfg settings for %q: %w", contextName, err)
+	}
+	k8sClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("building kubernetes client: %w", err)
+	}
+
+	s := &smoketest.SmokeTest{
+		K8sClient:    k8sClient,
+		Namespace:    options.Namespace,
+		Image:        options.Image,
+		LoadBalancer: options.LoadBalancer,
+		Volume:       options.Volume,
+		Keep:         options.Keep,
+		Timeout:      options.Timeout,
+		PollInterval: options.Interval,
+	}
+	result := s.Run(ctx)
+
+	t := &tables.Table{}
+	t.AddColumn("CHECK", func(c *smoketest.Case) string {
+		return c.Name
+	})
+	t.AddColumn("RESULT", func(c *smoketest.Case) string {
+		switch {
+		case c.Err != nil:
+			return "FAIL"
+		case c.Skipped != "":
+			return "SKIP"
+		default:
+			return "PASS"
+		}
+	})
+	t.AddColumn("DURATION", func(c *smoketest.Case) string {
+		return c.Duration.Round(time.Second).String()
+	})
+	t.AddColumn("MESSAGE", func(c *smoketest.Case) string {
+		if c.Err != nil {
+			return c.Err.Error()
+		}
+		return c.Skipped
+	})
+	if err := t.Render(result.Cases, out, "CHECK", "RESULT", "DURATION", "MESSAGE"); err != nil {
+		return err
+	}
+
+	if options.JUnitReport != "" {
+		file, err := os.Create(options.JUnitReport)
+		if err != nil {
+			return fmt.Errorf("creating junit report: %w", err)
+		}
+		defer file.Close()
+		if err := result.WriteJUnit(file, "kops-smoke-test"); err != nil {
+			return err
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("writing junit report: %w", err)
+		}
+	}
+
+	if result.Failed() {
+		return fmt.Errorf("smoke test of cluster %q failed", cluster.ObjectMeta.Name)
+	}
+	return nil
+}
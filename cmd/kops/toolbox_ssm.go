@@ -0,0 +1,223 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxSSMLong = templates.LongDesc(i18n.T(`
+	Start an AWS Systems Manager session to a cluster instance.
+
+	This allows access to clusters with a private topology without a bastion instance group,
+	once SSM is enabled in the bastion spec. Without --port, an interactive shell is started,
+	which is logged according to the Session Manager preferences. With --port, a local port is
+	forwarded to the instance, for example port 22 for SSH or port 443 for the Kubernetes API.
+
+	The session is started with the AWS CLI, which requires the Session Manager plugin.
+	By default, a running control-plane instance is used.
+	`))
+
+	toolboxSSMExample = templates.Examples(i18n.T(`
+	# Start a shell on a control-plane instance
+	kops toolbox ssm --name k8s-cluster.example.com
+
+	# Forward local port 2222 to SSH on a specific instance
+	kops toolbox ssm --name k8s-cluster.example.com --instance i-0123456789abcdef0 --port 22 --local-port 2222
+	ssh -p 2222 ubuntu@127.0.0.1
+
+	# Forward local port 8443 to the Kubernetes API
+	kops toolbox ssm --name k8s-cluster.example.com --port 443 --local-port 8443
+	kubectl --server https://127.0.0.1:8443 --tls-server-name api.internal.k8s-cluster.example.com get nodes
+	`))
+
+	toolboxSSMShort = i18n.T(`Start an AWS Systems Manager session to a cluster instance`)
+)
+
+type ToolboxSSMOptions struct {
+	ClusterName string
+
+	// InstanceID is the instance to connect to; a running control-plane instance is used if empty.
+	InstanceID string
+	// Port is the remote port to forward; an interactive shell is started if zero.
+	Port int
+	// LocalPort is the local port to forward from; defaults to Port.
+	LocalPort int
+	// Print prints the AWS CLI command instead of running it.
+	Print bool
+}
+
+func NewCmdToolboxSSM(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxSSMOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "ssm [CLUSTER]",
+		Short:             toolboxSSMShort,
+		Long:              toolboxSSMLong,
+		Example:           toolboxSSMExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxSSM(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.InstanceID, "instance", options.InstanceID, "ID of the instance to connect to, instead of a control-plane instance")
+	cmd.RegisterFlagCompletionFunc("instance", cobra.NoFileCompletions)
+	cmd.Flags().IntVar(&options.Port, "port", options.Port, "Remote port to forward, for example 22 for SSH or 443 for the Kubernetes API")
+	cmd.Flags().IntVar(&options.LocalPort, "local-port", options.LocalPort, "Local port to forward from, defaults to the remote port")
+	cmd.Flags().BoolVar(&options.Print, "print", options.Print, "Print the AWS CLI command instead of running it")
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+
+	return cmd
+}
+
+func RunToolboxSSM(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxSSMOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return fmt.Errorf("SSM sessions are not supported for cloud provider %q", cluster.Spec.GetCloudProvider())
+	}
+	topology := cluster.Spec.Networking.Topology
+	if topology == nil || topology.Bastion == nil || topology.Bastion.SSM == nil || !fi.ValueOf(topology.Bastion.SSM.Enabled) {
+		return fmt.Errorf("SSM is not enabled for cluster %q; set spec.networking.topology.bastion.ssm.enabled", cluster.ObjectMeta.Name)
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+	awsCloud := cloud.(awsup.AWSCloud)
+
+	instanceID := options.InstanceID
+	if instanceID == "" {
+		instanceID, err = findControlPlaneInstance(ctx, awsCloud, cluster.ObjectMeta.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	args, err := buildSSMSessionArgs(awsCloud.Region(), instanceID, options.Port, options.LocalPort)
+	if err != nil {
+		return err
+	}
+
+	if options.Print {
+		fmt.Fprintf(out, "aws %s\n", strings.Join(args, " "))
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, "aws", args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("error running aws ssm start-session: %w", err)
+	}
+	return nil
+}
+
+// findControlPlaneInstance returns the ID of a running control-plane instance of the cluster.
+func findControlPlaneInstance(ctx context.Context, cloud awsup.AWSCloud, clusterName string) (string, error) {
+	request := &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
+			awsup.NewEC2Filter("tag:"+awsup.TagClusterName, clusterName),
+			awsup.NewEC2Filter("tag-key", awsup.TagNameRolePrefix+"control-plane"),
+			awsup.NewEC2Filter("instance-state-name", "running"),
+		},
+	}
+
+	var instanceIDs []string
+	paginator := ec2.NewDescribeInstancesPaginator(cloud.EC2(), request)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return "", fmt.Errorf("error listing control-plane instances: %w", err)
+		}
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				instanceIDs = append(instanceIDs, aws.ToString(instance.InstanceId))
+			}
+		}
+	}
+
+	if len(instanceIDs) == 0 {
+		return "", fmt.Errorf("no running control-plane instances found for cluster %q", clusterName)
+	}
+	sort.Strings(instanceIDs)
+	return instanceIDs[0], nil
+}
+
+// buildSSMSessionArgs builds the AWS CLI arguments to start a session to the instance.
+// A shell is started if port is zero, otherwise localPort (or port) is forwarded to port on the instance.
+func buildSSMSessionArgs(region, instanceID string, port, localPort int) ([]string, error) {
+	args := []string{"ssm", "start-session", "--region", region, "--target", instanceID}
+	if port == 0 {
+		if localPort != 0 {
+			return nil, fmt.Errorf("--local-port requires --port")
+		}
+		return args, nil
+	}
+
+	if port < 0 || port > 65535 {
+		return nil, fmt.Errorf("invalid port %d", port)
+	}
+	if localPort == 0 {
+		localPort = port
+	}
+	if localPort < 0 || localPort > 65535 {
+		return nil, fmt.Errorf("invalid local port %d", localPort)
+	}
+
+	args = append(args,
+		"--document-name", "AWS-StartPortForwardingSession",
+		"--parameters", fmt.Sprintf("portNumber=%s,localPortNumber=%s", strconv.Itoa(port), strconv.Itoa(localPort)),
+	)
+	return args, nil
+}
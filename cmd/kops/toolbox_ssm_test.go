@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildSSMSessionArgs(t *testing.T) {
+	grid := []struct {
+		name          string
+		port          int
+		localPort     int
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "shell",
+			expected: "ssm start-session --region us-test-1 --target i-0123",
+		},
+		{
+			name:     "ssh",
+			port:     22,
+			expected: "ssm start-session --region us-test-1 --target i-0123 --document-name AWS-StartPortForwardingSession --parameters portNumber=22,localPortNumber=22",
+		},
+		{
+			name:      "api",
+			port:      443,
+			localPort: 8443,
+			expected:  "ssm start-session --region us-test-1 --target i-0123 --document-name AWS-StartPortForwardingSession --parameters portNumber=443,localPortNumber=8443",
+		},
+		{
+			name:          "local port without port",
+			localPort:     8443,
+			expectedError: "--local-port requires --port",
+		},
+		{
+			name:          "invalid port",
+			port:          70000,
+			expectedError: "invalid port 70000",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			args, err := buildSSMSessionArgs("us-test-1", "i-0123", g.port, g.localPort)
+			if g.expectedError != "" {
+				if err == nil || err.Error() != g.expectedError {
+					t.Fatalf("expected error %q, got %v", g.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if expected := strings.Split(g.expected, " "); !reflect.DeepEqual(args, expected) {
+				t.Errorf("expected args %v, got %v", expected, args)
+			}
+		})
+	}
+}
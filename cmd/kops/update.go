@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
 
@@ -35,5 +36,7 @@ func NewCmdUpdate(f *util.Factory, out io.Writer) *cobra.Command {
 	//  subcommands
 	cmd.AddCommand(NewCmdUpdateCluster(f, out))
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierApply)
+
 	return cmd
 }
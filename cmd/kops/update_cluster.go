@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -34,8 +35,10 @@ import (
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kubeconfig"
+	"k8s.io/kops/pkg/sdk"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -47,12 +50,20 @@ var (
     If the cluster or cloud resources already exist this command may modify those resources.
 
 	If, such as during a Kubernetes upgrade, nodes need updating, a rolling-update may
-	be subsequently required.
+	be subsequently required. With --auto-roll, the rolling-update of the instance groups
+	that need updating is performed once the changes are applied, validating the cluster
+	as "kops rolling-update cluster" does.
 	`))
 
 	updateClusterExample = templates.Examples(i18n.T(`
 	# After the cluster has been edited or upgraded, update the cloud resources with:
 	kops update cluster k8s-cluster.example.com --yes --state=s3://my-state-store --yes
+
+	# Update the cloud resources, then roll the worker nodes that need updating:
+	kops update cluster k8s-cluster.example.com --yes --auto-roll --roll-filter=role=node
+
+	# Render the tasks that an update would run, and the changes they would make, as an image:
+	kops update cluster k8s-cluster.example.com --graph=dot | dot -Tsvg > tasks.svg
 	`))
 
 	updateClusterShort = i18n.T("Update a cluster.")
@@ -86,6 +97,24 @@ type UpdateClusterOptions struct {
 	// The goal is that the cluster can keep running even during more disruptive
 	// infrastructure changes.
 	Prune bool
+
+	// AutoRoll runs a rolling update of the instance groups that need updating once the changes are applied.
+	AutoRoll bool
+	// RollFilters restricts the automatic rolling update to instance groups matching key=value filters, on role or name.
+	RollFilters []string
+
+	// Incremental skips the tasks whose desired state has not changed since the last apply.
+	Incremental bool
+	// RetryFailed runs only the tasks that failed, or were blocked by failed tasks, in the last apply.
+	RetryFailed bool
+
+	// AWSRateLimit is the client-side limit of the requests to the AWS APIs while the tasks run.
+	AWSRateLimit awsup.RateLimit
+	// AWSResourceWaits are the timeouts of the waits for the AWS resources created by the tasks.
+	AWSResourceWaits awsup.ResourceWaitTimeouts
+
+	// Graph writes the dependency graph of the tasks, annotated with their changes, in this format instead of the changes.
+	Graph string
 }
 
 func (o *UpdateClusterOptions) InitDefaults() {
@@ -100,6 +129,8 @@ func (o *UpdateClusterOptions) InitDefaults() {
 	o.Prune = false
 
 	o.RunTasksOptions.InitDefaults()
+	o.AWSRateLimit = awsup.DefaultRateLimit()
+	o.AWSResourceWaits = awsup.DefaultResourceWaitTimeouts()
 }
 
 func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
@@ -136,12 +167,27 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("phase", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return cloudup.Phases.List(), cobra.ShellCompDirectiveNoFileComp
 	})
-	cmd.Flags().StringSliceVar(&options.LifecycleOverrides, "lifecycle-overrides", options.LifecycleOverrides, "comma separated list of phase overrides, example: SecurityGroups=Ignore,InternetGateway=ExistsAndWarnIfChanges")
+	cmd.Flags().StringSliceVar(&options.LifecycleOverrides, "lifecycle-overrides", options.LifecycleOverrides, "comma separated list of phase overrides, example: SecurityGroups=Ignore,InternetGateway=ExistsAndWarnIfChanges,SecurityGroupRule:api-elb*=ExistsAndWarnIfChanges")
 	viper.BindPFlag("lifecycle-overrides", cmd.Flags().Lookup("lifecycle-overrides"))
 	viper.BindEnv("lifecycle-overrides", "KOPS_LIFECYCLE_OVERRIDES")
 	cmd.RegisterFlagCompletionFunc("lifecycle-overrides", completeLifecycleOverrides)
 
 	cmd.Flags().BoolVar(&options.Prune, "prune", options.Prune, "Delete old revisions of cloud resources that were needed during an upgrade")
+	cmd.Flags().BoolVar(&options.AutoRoll, "auto-roll", options.AutoRoll, "Perform a rolling update of the instance groups that need updating once the changes are applied")
+	cmd.Flags().StringSliceVar(&options.RollFilters, "roll-filter", options.RollFilters, "Only roll instance groups matching these filters, such as role=node or name=nodes-us-east-1a. Requires --auto-roll")
+
+	cmd.Flags().BoolVar(&options.Incremental, "incremental", options.Incremental, "Skip the tasks whose desired state has not changed since the last apply, without checking their cloud resources")
+	cmd.Flags().BoolVar(&options.RetryFailed, "retry-failed", options.RetryFailed, "Run only the tasks that failed, or depend on tasks that failed, in the last apply")
+	cmd.Flags().Float64Var(&options.AWSRateLimit.RequestsPerSecond, "aws-api-rate-limit", options.AWSRateLimit.RequestsPerSecond, "Maximum rate of requests per second to the AWS APIs while the tasks run, or 0 for no limit")
+	cmd.Flags().IntVar(&options.AWSRateLimit.Burst, "aws-api-burst", options.AWSRateLimit.Burst, "Number of requests to the AWS APIs that can be made at once before --aws-api-rate-limit applies")
+	cmd.Flags().DurationVar(&options.AWSResourceWaits.IAMInstanceProfile, "aws-iam-instance-profile-wait", options.AWSResourceWaits.IAMInstanceProfile, "Maximum time to retry the instances and autoscaling groups rejected because their new IAM instance profile has not propagated yet, or 0 to not retry")
+	cmd.Flags().DurationVar(&options.AWSResourceWaits.SecurityGroup, "aws-security-group-wait", options.AWSResourceWaits.SecurityGroup, "Maximum time to wait for new security groups to be visible to the AWS API, or 0 to not wait")
+	cmd.Flags().StringVar(&options.Graph, "graph", options.Graph, "Output the dependency graph of the tasks, annotated with their changes, instead of the changes. One of: "+strings.Join(fi.TaskGraphFormats, ", "))
+	cmd.RegisterFlagCompletionFunc("graph", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return fi.TaskGraphFormats, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	commandutils.SetApplyFlag(cmd, "yes")
 
 	return cmd
 }
@@ -171,6 +217,10 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		return nil, fmt.Errorf("cannot use both --admin and --user")
 	}
 
+	if c.RetryFailed && c.Incremental {
+		return nil, fmt.Errorf("cannot use both --retry-failed and --incremental")
+	}
+
 	if c.admin != 0 && !c.CreateKubecfg {
 		klog.Info("--admin implies --create-kube-config")
 		c.CreateKubecfg = true
@@ -198,6 +248,26 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		targetName = cloudup.TargetDryRun
 	}
 
+	if c.Graph != "" {
+		if !slices.Contains(fi.TaskGraphFormats, c.Graph) {
+			return nil, fmt.Errorf("unknown graph format %q, available formats: %s", c.Graph, strings.Join(fi.TaskGraphFormats, ", "))
+		}
+		if !isDryrun {
+			return nil, fmt.Errorf("--graph is only supported in dry run mode, without --yes")
+		}
+	}
+
+	if len(c.RollFilters) != 0 && !c.AutoRoll {
+		return nil, fmt.Errorf("--roll-filter requires --auto-roll")
+	}
+	if c.AutoRoll && c.Target != cloudup.TargetDirect {
+		return nil, fmt.Errorf("--auto-roll is only supported with --target=%s", cloudup.TargetDirect)
+	}
+	rollInstanceGroups, rollRoles, err := parseRollFilters(c.RollFilters)
+	if err != nil {
+		return nil, err
+	}
+
 	if c.OutDir == "" {
 		if c.Target == cloudup.TargetTerraform {
 			c.OutDir = "out/terraform"
@@ -261,17 +331,12 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		}
 	}
 
-	deletionProcessing := fi.DeletionProcessingModeDeleteIfNotDeferrred
-	if c.Prune {
-		deletionProcessing = fi.DeletionProcessingModeDeleteIncludingDeferred
-	}
-
 	lifecycleOverrideMap := make(map[string]fi.Lifecycle)
 
 	for _, override := range c.LifecycleOverrides {
 		values := strings.Split(override, "=")
 		if len(values) != 2 {
-			return results, fmt.Errorf("incorrect syntax for lifecyle-overrides, correct syntax is TaskName=lifecycleName, override provided: %q", override)
+			return results, fmt.Errorf("incorrect syntax for lifecyle-overrides, correct syntax is TaskName=lifecycleName or TaskName:namePattern=lifecycleName, override provided: %q", override)
 		}
 
 		taskName := values[0]
@@ -285,39 +350,48 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		lifecycleOverrideMap[taskName] = lifecycleOverride
 	}
 
-	cloud, err := cloudup.BuildCloud(cluster)
-	if err != nil {
-		return nil, err
+	dryRunReport := out
+	if c.Graph != "" {
+		dryRunReport = io.Discard
 	}
 
-	applyCmd := &cloudup.ApplyClusterCmd{
-		Cloud:              cloud,
-		Clientset:          clientset,
-		Cluster:            cluster,
-		DryRun:             isDryrun,
-		AllowKopsDowngrade: c.AllowKopsDowngrade,
-		RunTasksOptions:    &c.RunTasksOptions,
+	client := sdk.NewClient(clientset)
+	updateResult, err := client.UpdateCluster(ctx, cluster.ObjectMeta.Name, &sdk.UpdateClusterOptions{
+		Target:             targetName,
 		OutDir:             c.OutDir,
 		Phase:              phase,
-		TargetName:         targetName,
 		LifecycleOverrides: lifecycleOverrideMap,
+		AllowKopsDowngrade: c.AllowKopsDowngrade,
+		Prune:              c.Prune,
 		GetAssets:          c.GetAssets,
-		DeletionProcessing: deletionProcessing,
-	}
-
-	if err := applyCmd.Run(ctx); err != nil {
+		RunTasksOptions:    &c.RunTasksOptions,
+		Incremental:        c.Incremental,
+		RetryFailed:        c.RetryFailed,
+		AWSRateLimit:       &c.AWSRateLimit,
+		AWSResourceWaits:   &c.AWSResourceWaits,
+		DryRunReport:       dryRunReport,
+	})
+	if err != nil {
 		return results, err
 	}
+	cloud := updateResult.Cloud
 
-	results.Target = applyCmd.Target
-	results.TaskMap = applyCmd.TaskMap
-	results.ImageAssets = applyCmd.ImageAssets
-	results.FileAssets = applyCmd.FileAssets
-	results.Cluster = cluster
+	results.Target = updateResult.Target
+	results.TaskMap = updateResult.TaskMap
+	results.ImageAssets = updateResult.ImageAssets
+	results.FileAssets = updateResult.FileAssets
+	results.Cluster = updateResult.Cluster
+
+	if c.Graph != "" {
+		target, ok := updateResult.Target.(*fi.CloudupDryRunTarget)
+		if !ok {
+			return results, fmt.Errorf("unexpected target type %T", updateResult.Target)
+		}
+		return results, target.TaskGraph(updateResult.TaskMap).Write(out, c.Graph)
+	}
 
 	if isDryrun && !c.GetAssets {
-		target := applyCmd.Target.(*fi.CloudupDryRunTarget)
-		if target.HasChanges() {
+		if updateResult.HasChanges() {
 			fmt.Fprintf(out, "Must specify --yes to apply changes\n")
 		} else {
 			fmt.Fprintf(out, "No changes need to be applied\n")
@@ -349,7 +423,8 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 			c.user,
 			c.internal,
 			f.KopsStateStore(),
-			useKopsAuthenticationPlugin)
+			useKopsAuthenticationPlugin,
+			false)
 		if err != nil {
 			return nil, err
 		}
@@ -394,7 +469,7 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 			fmt.Fprintf(sb, "Suggestions:\n")
 			fmt.Fprintf(sb, " * validate cluster: kops validate cluster --wait 10m\n")
 			fmt.Fprintf(sb, " * list nodes: kubectl get nodes --show-labels\n")
-			if !usesBastion(applyCmd.InstanceGroups) {
+			if !usesBastion(updateResult.InstanceGroups) {
 				fmt.Fprintf(sb, " * ssh to a control-plane node: ssh -i ~/.ssh/id_rsa ubuntu@%s\n", cluster.Spec.API.PublicName)
 			} else {
 				bastionPublicName := findBastionPublicName(cluster)
@@ -409,7 +484,7 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 			fmt.Fprintf(sb, "\n")
 		}
 
-		if !firstRun {
+		if !firstRun && !c.AutoRoll {
 			// TODO: Detect if rolling-update is needed
 			fmt.Fprintf(sb, "\n")
 			fmt.Fprintf(sb, "Changes may require instances to restart: kops rolling-update cluster\n")
@@ -422,9 +497,45 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		}
 	}
 
+	if !isDryrun && !firstRun && c.AutoRoll {
+		rollingUpdateOptions := &RollingUpdateOptions{}
+		rollingUpdateOptions.InitDefaults()
+		rollingUpdateOptions.Yes = true
+		rollingUpdateOptions.ClusterName = cluster.ObjectMeta.Name
+		rollingUpdateOptions.InstanceGroups = rollInstanceGroups
+		rollingUpdateOptions.InstanceGroupRoles = rollRoles
+		if err := RunRollingUpdateCluster(ctx, f, out, rollingUpdateOptions); err != nil {
+			return results, err
+		}
+	}
+
 	return results, nil
 }
 
+// parseRollFilters parses the --roll-filter flag into instance group names and roles.
+// Filters on the same key match any of their values; filters on different keys must all match.
+func parseRollFilters(filters []string) ([]string, []string, error) {
+	var names, roles []string
+	for _, filter := range filters {
+		key, value, found := strings.Cut(filter, "=")
+		if !found || value == "" {
+			return nil, nil, fmt.Errorf("incorrect syntax for roll-filter, correct syntax is key=value, filter provided: %q", filter)
+		}
+		switch key {
+		case "name":
+			names = append(names, value)
+		case "role":
+			if _, ok := kops.ParseInstanceGroupRole(value, true); !ok {
+				return nil, nil, fmt.Errorf("unknown instance group role %q in roll-filter", value)
+			}
+			roles = append(roles, value)
+		default:
+			return nil, nil, fmt.Errorf("unknown roll-filter key %q, available keys: name,role", key)
+		}
+	}
+	return names, roles, nil
+}
+
 func parseLifecycle(lifecycle string) (fi.Lifecycle, error) {
 	if v, ok := fi.LifecycleNameMap[lifecycle]; ok {
 		return v, nil
@@ -0,0 +1,76 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRollFilters(t *testing.T) {
+	grid := []struct {
+		filters     []string
+		names       []string
+		roles       []string
+		expectError bool
+	}{
+		{
+			filters: nil,
+		},
+		{
+			filters: []string{"role=node"},
+			roles:   []string{"node"},
+		},
+		{
+			filters: []string{"role=node", "role=control-plane", "name=nodes-us-test-1a"},
+			names:   []string{"nodes-us-test-1a"},
+			roles:   []string{"node", "control-plane"},
+		},
+		{
+			filters:     []string{"role=worker"},
+			expectError: true,
+		},
+		{
+			filters:     []string{"zone=us-test-1a"},
+			expectError: true,
+		},
+		{
+			filters:     []string{"node"},
+			expectError: true,
+		},
+	}
+
+	for _, g := range grid {
+		names, roles, err := parseRollFilters(g.filters)
+		if g.expectError {
+			if err == nil {
+				t.Errorf("expected error parsing %q", g.filters)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", g.filters, err)
+			continue
+		}
+		if !reflect.DeepEqual(names, g.names) {
+			t.Errorf("parsing %q: expected names %q, got %q", g.filters, g.names, names)
+		}
+		if !reflect.DeepEqual(roles, g.roles) {
+			t.Errorf("parsing %q: expected roles %q, got %q", g.filters, g.roles, roles)
+		}
+	}
+}
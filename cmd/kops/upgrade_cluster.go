@@ -85,6 +85,8 @@ func NewCmdUpgradeCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.KubernetesVersion, "kubernetes-version", "", "Kubernetes version to use for upgrade")
 	cmd.RegisterFlagCompletionFunc("kubernetes-version", completeKubernetesVersion)
 
+	commandutils.SetApplyFlag(cmd, "yes")
+
 	return cmd
 }
 
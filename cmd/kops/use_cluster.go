@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/kubeconfig"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	useClusterLong = templates.LongDesc(i18n.T(`
+	Switch the kubectl context to a cluster, exporting its kubeconfig when needed.
+
+	The kubeconfig is exported from the state store when it has no context for the cluster,
+	or when the admin credential of the context has expired or has less than a quarter of its
+	lifetime left. The renewed credential keeps the lifetime of the previous one, so that each
+	cluster can have its own admin credential lifetime; --admin changes it.
+
+	With --auto-refresh, the admin credential is issued by the kOps authentication plugin
+	whenever it expires, so that short-lived credentials never need to be exported again.
+	`))
+
+	useClusterExample = templates.Examples(i18n.T(`
+	# switch to a cluster, exporting an admin credential valid for 18 hours if the kubeconfig has no context for it
+	kops use cluster k8s-cluster.example.com
+
+	# switch to a cluster with an admin credential valid for 1 hour, refreshed automatically
+	kops use cluster k8s-cluster.example.com --admin=1h --auto-refresh
+	`))
+
+	useClusterShort = i18n.T(`Switch the kubectl context to a cluster.`)
+)
+
+type UseClusterOptions struct {
+	ClusterName    string
+	KubeConfigPath string
+
+	// Admin is the lifetime of the admin credential; zero keeps the lifetime of the existing credential.
+	Admin time.Duration
+	// AutoRefresh uses the kOps authentication plugin to issue admin credentials when they expire.
+	AutoRefresh bool
+	// Internal uses the cluster's internal DNS name when exporting the kubeconfig.
+	Internal bool
+}
+
+func NewCmdUseCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &UseClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             useClusterShort,
+		Long:              useClusterLong,
+		Example:           useClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunUseCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.KubeConfigPath, "kubeconfig", options.KubeConfigPath, "Filename of the kubeconfig to update")
+	cmd.Flags().DurationVar(&options.Admin, "admin", options.Admin, "Lifetime of the cluster admin credential; defaults to the lifetime of the existing credential")
+	cmd.Flags().BoolVar(&options.AutoRefresh, "auto-refresh", options.AutoRefresh, "Refresh the admin credential automatically when it expires, using the kOps authentication plugin")
+	cmd.Flags().BoolVar(&options.Internal, "internal", options.Internal, "Use the cluster's internal DNS name when exporting the kubeconfig")
+
+	return cmd
+}
+
+func RunUseCluster(ctx context.Context, f *util.Factory, out io.Writer, options *UseClusterOptions) error {
+	pathOptions := buildPathOptions(&ExportKubeconfigOptions{KubeConfigPath: options.KubeConfigPath})
+
+	config, err := pathOptions.GetStartingConfig()
+	if err != nil {
+		return fmt.Errorf("error reading kubeconfig: %w", err)
+	}
+
+	credential, err := kubeconfig.FindAdminCredential(config, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	_, hasContext := config.Contexts[options.ClusterName]
+
+	admin, autoRefresh, export := planUseCluster(options, hasContext, credential, time.Now())
+	if export {
+		exportOptions := &ExportKubeconfigOptions{
+			ClusterName:    options.ClusterName,
+			KubeConfigPath: options.KubeConfigPath,
+			admin:          admin,
+			internal:       options.Internal,
+			AutoRefresh:    autoRefresh,
+			// Keep obtaining credentials in exchange for the cloud identity if the context did
+			CloudIdentity: credential != nil && credential.CloudIdentity,
+		}
+		return RunExportKubeconfig(ctx, f, out, exportOptions, nil)
+	}
+
+	config.CurrentContext = options.ClusterName
+	if err := clientcmd.ModifyConfig(pathOptions, *config, true); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "kOps has set your kubectl context to %s\n", options.ClusterName)
+	return nil
+}
+
+// planUseCluster returns the admin credential lifetime and whether it is refreshed automatically,
+// and whether the kubeconfig of the cluster must be exported before switching to its context.
+func planUseCluster(options *UseClusterOptions, hasContext bool, credential *kubeconfig.AdminCredential, now time.Time) (time.Duration, bool, bool) {
+	admin := options.Admin
+	autoRefresh := options.AutoRefresh
+	if credential != nil {
+		if admin == 0 {
+			admin = credential.Lifetime
+		}
+		autoRefresh = autoRefresh || credential.AutoRefresh
+	}
+
+	var export bool
+	switch {
+	case !hasContext:
+		export = true
+	case credential == nil:
+		// The context uses another user, or no credential; only replace it when asked to
+		export = options.Admin != 0 || options.AutoRefresh
+	default:
+		export = credential.NeedsRenewal(now) || admin != credential.Lifetime || autoRefresh != credential.AutoRefresh
+	}
+
+	if export && admin == 0 && !autoRefresh {
+		admin = kubeconfig.DefaultKubecfgAdminLifetime
+	}
+
+	return admin, autoRefresh, export
+}
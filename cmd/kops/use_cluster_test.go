@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/kops/pkg/kubeconfig"
+)
+
+func TestPlanUseCluster(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	valid := &kubeconfig.AdminCredential{Lifetime: 8 * time.Hour, NotAfter: now.Add(6 * time.Hour)}
+	expiring := &kubeconfig.AdminCredential{Lifetime: 8 * time.Hour, NotAfter: now.Add(time.Hour)}
+	plugin := &kubeconfig.AdminCredential{Lifetime: time.Hour, AutoRefresh: true}
+
+	tests := []struct {
+		name        string
+		options     UseClusterOptions
+		hasContext  bool
+		credential  *kubeconfig.AdminCredential
+		admin       time.Duration
+		autoRefresh bool
+		export      bool
+	}{
+		{
+			name:   "no context",
+			admin:  kubeconfig.DefaultKubecfgAdminLifetime,
+			export: true,
+		},
+		{
+			name:        "no context with auto-refresh",
+			options:     UseClusterOptions{AutoRefresh: true},
+			autoRefresh: true,
+			export:      true,
+		},
+		{
+			name:       "context of another user",
+			hasContext: true,
+		},
+		{
+			name:       "context of another user with admin",
+			options:    UseClusterOptions{Admin: time.Hour},
+			hasContext: true,
+			admin:      time.Hour,
+			export:     true,
+		},
+		{
+			name:       "valid credential",
+			hasContext: true,
+			credential: valid,
+			admin:      8 * time.Hour,
+		},
+		{
+			name:       "expiring credential keeps its lifetime",
+			hasContext: true,
+			credential: expiring,
+			admin:      8 * time.Hour,
+			export:     true,
+		},
+		{
+			name:       "valid credential with another lifetime",
+			options:    UseClusterOptions{Admin: 2 * time.Hour},
+			hasContext: true,
+			credential: valid,
+			admin:      2 * time.Hour,
+			export:     true,
+		},
+		{
+			name:        "valid credential switched to auto-refresh",
+			options:     UseClusterOptions{AutoRefresh: true},
+			hasContext:  true,
+			credential:  valid,
+			admin:       8 * time.Hour,
+			autoRefresh: true,
+			export:      true,
+		},
+		{
+			name:        "authentication plugin",
+			hasContext:  true,
+			credential:  plugin,
+			admin:       time.Hour,
+			autoRefresh: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admin, autoRefresh, export := planUseCluster(&tt.options, tt.hasContext, tt.credential, now)
+			if admin != tt.admin || autoRefresh != tt.autoRefresh || export != tt.export {
+				t.Errorf("planUseCluster() = (%v, %v, %v), expected (%v, %v, %v)", admin, autoRefresh, export, tt.admin, tt.autoRefresh, tt.export)
+			}
+		})
+	}
+}
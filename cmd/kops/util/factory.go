@@ -41,6 +41,9 @@ import (
 
 type FactoryOptions struct {
 	RegistryPath string
+
+	// ReadOnly refuses writes to the state store.
+	ReadOnly bool
 }
 
 type Factory struct {
@@ -84,19 +87,19 @@ func (f *Factory) KopsClient() (simple.Clientset, error) {
 			return nil, field.Required(field.NewPath("State Store"), STATE_ERROR)
 		}
 
-		// We recognize a `k8s` scheme; this might change in future so we won't document it yet
-		// In practice nobody is going to hit this accidentally, so I don't think we need a feature flag.
+		// The `k8s` scheme stores the objects in a kubernetes cluster, as custom resources.
+		// The host selects the kubeconfig context and the path selects the namespace,
+		// e.g. k8s://management/kops-clusters
 		if strings.HasPrefix(registryPath, "k8s://") {
+			u, err := url.Parse(registryPath)
+			if err != nil {
+				return nil, fmt.Errorf("invalid kops server url: %q", registryPath)
+			}
+
 			loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 
 			configOverrides := &clientcmd.ConfigOverrides{}
-
-			if registryPath == "k8s://" {
-			} else {
-				u, err := url.Parse(registryPath)
-				if err != nil {
-					return nil, fmt.Errorf("invalid kops server url: %q", registryPath)
-				}
+			if u.Host != "" {
 				configOverrides.CurrentContext = u.Host
 			}
 
@@ -111,13 +114,7 @@ func (f *Factory) KopsClient() (simple.Clientset, error) {
 				return nil, fmt.Errorf("error building kops API client: %v", err)
 			}
 
-			f.clientset = api.NewRESTClientset(
-				f.VFSContext(),
-				&url.URL{
-					Scheme: "k8s",
-				},
-				kopsClient.Kops(),
-			)
+			f.clientset = api.NewRESTClientset(f.VFSContext(), u, kopsClient.Kops())
 		} else {
 			basePath, err := f.VFSContext().BuildVfsPath(registryPath)
 			if err != nil {
@@ -133,6 +130,9 @@ func (f *Factory) KopsClient() (simple.Clientset, error) {
 		if strings.HasPrefix(registryPath, "file://") {
 			klog.Warning("The local filesystem state store is not functional for running clusters")
 		}
+		if f.options.ReadOnly {
+			f.clientset = simple.NewReadOnlyClientset(f.clientset)
+		}
 	}
 
 	return f.clientset, nil
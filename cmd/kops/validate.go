@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 )
 
 func NewCmdValidate(f *util.Factory, out io.Writer) *cobra.Command {
@@ -31,6 +32,9 @@ func NewCmdValidate(f *util.Factory, out io.Writer) *cobra.Command {
 
 	// create subcommands
 	cmd.AddCommand(NewCmdValidateCluster(f, out))
+	cmd.AddCommand(NewCmdValidateInfra(f, out))
+
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierPlan)
 
 	return cmd
 }
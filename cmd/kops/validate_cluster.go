@@ -26,18 +26,17 @@ import (
 	"time"
 
 	"k8s.io/kops/pkg/commands/commandutils"
-	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/sdk"
 	"k8s.io/kops/pkg/validation"
 	"k8s.io/kops/util/pkg/tables"
 	"sigs.k8s.io/yaml"
@@ -51,12 +50,25 @@ var (
 		2. All worker nodes are running and have "Ready" status.
 		3. All control plane nodes have the expected pods.
 		4. All pods with a critical priority are running and have "Ready" status.
+		5. All control plane nodes run a ready etcd-manager pod for each etcd cluster.
+		6. The etcd members with metrics URLs are healthy, have a leader, have no alarms,
+		   and their databases are within their quota. Databases close to their quota,
+		   databases that need a defragmentation and frequent leader changes are warnings,
+		   which do not fail validation.
+		7. All pods of the networking daemonsets are ready.
+
+		Each of these is a named check, which can be selected with --checks or skipped
+		with --skip-checks. --junit-report writes the result of each check as a test case
+		of a JUnit XML report, so that CI pipelines can gate on specific checks.
 		`))
 
 	validateClusterExample = templates.Examples(i18n.T(`
 	# Validate the cluster set as the current context of the kube config.
 	# Kops will try for 10 minutes to validate the cluster 3 times.
-	kops validate cluster --wait 10m --count 3`))
+	kops validate cluster --wait 10m --count 3
+
+	# Validate the nodes and the etcd clusters only, and write a JUnit report.
+	kops validate cluster --checks nodes,etcd --junit-report=artifacts/junit_validate-cluster.xml`))
 
 	validateClusterShort = i18n.T(`Validate a kOps cluster.`)
 )
@@ -68,6 +80,9 @@ type ValidateClusterOptions struct {
 	count       int
 	interval    time.Duration
 	kubeconfig  string
+	checks      []string
+	skipChecks  []string
+	junitReport string
 }
 
 func (o *ValidateClusterOptions) InitDefaults() {
@@ -109,6 +124,14 @@ func NewCmdValidateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().IntVar(&options.count, "count", options.count, "Number of consecutive successful validations required")
 	cmd.Flags().DurationVar(&options.interval, "interval", options.interval, "Time in duration to wait between validation attempts")
 	cmd.Flags().StringVar(&options.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
+	cmd.Flags().StringSliceVar(&options.checks, "checks", options.checks, "Names of the only checks to run. One or more of "+strings.Join(validation.CheckNames(), "|"))
+	cmd.Flags().StringSliceVar(&options.skipChecks, "skip-checks", options.skipChecks, "Names of the checks not to run")
+	completeChecks := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return validation.CheckNames(), cobra.ShellCompDirectiveNoFileComp
+	}
+	cmd.RegisterFlagCompletionFunc("checks", completeChecks)
+	cmd.RegisterFlagCompletionFunc("skip-checks", completeChecks)
+	cmd.Flags().StringVar(&options.junitReport, "junit-report", options.junitReport, "Path of the JUnit XML report of the last validation attempt to write")
 
 	return cmd
 }
@@ -124,11 +147,6 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 		return nil, err
 	}
 
-	cloud, err := cloudup.BuildCloud(cluster)
-	if err != nil {
-		return nil, err
-	}
-
 	list, err := clientSet.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("cannot get InstanceGroups for %q: %v", cluster.ObjectMeta.Name, err)
@@ -161,85 +179,61 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 		return nil, fmt.Errorf("cannot load kubecfg settings for %q: %v", contextName, err)
 	}
 
-	k8sClient, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("cannot build kubernetes api client for %q: %v", contextName, err)
-	}
-
-	timeout := time.Now().Add(options.wait)
-
-	validator, err := validation.NewClusterValidator(cluster, cloud, list, config.Host, k8sClient)
-	if err != nil {
-		return nil, fmt.Errorf("unexpected error creating validatior: %v", err)
-	}
-
-	consecutive := 0
-	for {
-		if options.wait > 0 && time.Now().After(timeout) && consecutive == 0 {
-			return nil, fmt.Errorf("wait time exceeded during validation")
-		}
-
-		result, err := validator.Validate()
-		if err != nil {
-			consecutive = 0
-			if options.wait > 0 {
-				klog.Warningf("(will retry): unexpected error during validation: %v", err)
-				time.Sleep(options.interval)
-				continue
-			} else {
-				return nil, fmt.Errorf("unexpected error during validation: %v", err)
-			}
-		}
-
-		switch options.output {
-		case OutputTable:
-			if err := validateClusterOutputTable(result, cluster, instanceGroups, out); err != nil {
-				return nil, err
-			}
-		case OutputYaml:
-			y, err := yaml.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("unable to marshal YAML: %v", err)
-			}
-			if _, err := out.Write(y); err != nil {
-				return nil, fmt.Errorf("error writing to output: %v", err)
-			}
-		case OutputJSON:
-			j, err := json.Marshal(result)
-			if err != nil {
-				return nil, fmt.Errorf("unable to marshal JSON: %v", err)
-			}
-			if _, err := out.Write(j); err != nil {
-				return nil, fmt.Errorf("error writing to output: %v", err)
-			}
-		default:
-			return nil, fmt.Errorf("unknown output format: %q", options.output)
-		}
-
-		if len(result.Failures) == 0 {
-			consecutive++
-			if consecutive < options.count {
-				klog.Infof("(will retry): cluster passed validation %d consecutive times", consecutive)
-				if options.wait > 0 {
-					time.Sleep(options.interval)
-					continue
-				} else {
-					return nil, fmt.Errorf("cluster passed validation %d consecutive times", consecutive)
+	client := sdk.NewClient(clientSet)
+	return client.ValidateCluster(ctx, cluster.ObjectMeta.Name, &sdk.ValidateClusterOptions{
+		RESTConfig: config,
+		Wait:       options.wait,
+		Interval:   options.interval,
+		Count:      options.count,
+		Checks:     options.checks,
+		SkipChecks: options.skipChecks,
+		OnResult: func(result *validation.ValidationCluster) error {
+			if options.junitReport != "" {
+				if err := writeValidationJUnitReport(result, options.junitReport); err != nil {
+					return err
 				}
-			} else {
-				return result, nil
 			}
-		} else {
-			if options.wait > 0 {
-				klog.Warningf("(will retry): cluster not yet healthy")
-				consecutive = 0
-				time.Sleep(options.interval)
-				continue
-			} else {
-				return nil, fmt.Errorf("cluster not yet healthy")
+			switch options.output {
+			case OutputTable:
+				return validateClusterOutputTable(result, cluster, instanceGroups, out)
+			case OutputYaml:
+				y, err := yaml.Marshal(result)
+				if err != nil {
+					return fmt.Errorf("unable to marshal YAML: %v", err)
+				}
+				if _, err := out.Write(y); err != nil {
+					return fmt.Errorf("error writing to output: %v", err)
+				}
+			case OutputJSON:
+				j, err := json.Marshal(result)
+				if err != nil {
+					return fmt.Errorf("unable to marshal JSON: %v", err)
+				}
+				if _, err := out.Write(j); err != nil {
+					return fmt.Errorf("error writing to output: %v", err)
+				}
+			default:
+				return fmt.Errorf("unknown output format: %q", options.output)
 			}
-		}
+			return nil
+		},
+	})
+}
+
+// writeValidationJUnitReport writes the result as a JUnit XML report to the path, replacing the report of a previous attempt.
+func writeValidationJUnitReport(result *validation.ValidationCluster, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating junit report: %w", err)
+	}
+	defer file.Close()
+	if err := result.WriteJUnit(file, "kops-validate-cluster"); err != nil {
+		return err
 	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("writing junit report: %w", err)
+	}
+	return nil
 }
 
 func validateClusterOutputTable(result *validation.ValidationCluster, cluster *kopsapi.Cluster, instanceGroups []kopsapi.InstanceGroup, out io.Writer) error {
@@ -289,21 +283,24 @@ func validateClusterOutputTable(result *validation.ValidationCluster, cluster *k
 		}
 	}
 
-	if len(result.Failures) != 0 {
-		failuresTable := &tables.Table{}
-		failuresTable.AddColumn("KIND", func(e *validation.ValidationError) string {
-			return e.Kind
-		})
-		failuresTable.AddColumn("NAME", func(e *validation.ValidationError) string {
-			return e.Name
-		})
-		failuresTable.AddColumn("MESSAGE", func(e *validation.ValidationError) string {
-			return e.Message
-		})
+	if len(result.Ignored) != 0 {
+		fmt.Fprintln(out)
+		if err := renderValidationErrors(result.Ignored, "IGNORED DURING CLUSTER-AUTOSCALER SCALE-DOWN", out); err != nil {
+			return err
+		}
+	}
 
-		fmt.Fprintln(out, "\nVALIDATION ERRORS")
-		if err := failuresTable.Render(result.Failures, out, "KIND", "NAME", "MESSAGE"); err != nil {
-			return fmt.Errorf("error rendering failures table: %v", err)
+	if len(result.Warnings) != 0 {
+		fmt.Fprintln(out)
+		if err := renderValidationErrors(result.Warnings, "VALIDATION WARNINGS", out); err != nil {
+			return err
+		}
+	}
+
+	if len(result.Failures) != 0 {
+		fmt.Fprintln(out)
+		if err := renderValidationErrors(result.Failures, "VALIDATION ERRORS", out); err != nil {
+			return err
 		}
 
 		fmt.Fprintf(out, "\nValidation Failed\n")
@@ -313,3 +310,27 @@ func validateClusterOutputTable(result *validation.ValidationCluster, cluster *k
 
 	return nil
 }
+
+// renderValidationErrors renders the validation errors as a table under the title; it renders nothing if there are none.
+func renderValidationErrors(errors []*validation.ValidationError, title string, out io.Writer) error {
+	if len(errors) == 0 {
+		return nil
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("KIND", func(e *validation.ValidationError) string {
+		return e.Kind
+	})
+	t.AddColumn("NAME", func(e *validation.ValidationError) string {
+		return e.Name
+	})
+	t.AddColumn("MESSAGE", func(e *validation.ValidationError) string {
+		return e.Message
+	})
+
+	fmt.Fprintln(out, title)
+	if err := t.Render(errors, out, "KIND", "NAME", "MESSAGE"); err != nil {
+		return fmt.Errorf("error rendering %s table: %v", strings.ToLower(title), err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/sdk"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	validateInfraLong = templates.LongDesc(i18n.T(`
+		This command validates the cloud resources of a cluster without using the Kubernetes API,
+		to find out whether the infrastructure is at fault when the API is unreachable:
+
+		1. All instance groups have their target number of instances.
+		2. The load balancers attached to instance groups consider their instances healthy (AWS only).
+		3. All etcd volumes exist and are attached to an instance (AWS only).
+		4. The API DNS name resolves, and no longer points at the placeholder address.
+		`))
+
+	validateInfraExample = templates.Examples(i18n.T(`
+	# Validate the infrastructure of a cluster whose Kubernetes API does not respond.
+	kops validate infra --name k8s-cluster.example.com
+
+	# Wait up to 10 minutes for the infrastructure to become healthy.
+	kops validate infra --wait 10m`))
+
+	validateInfraShort = i18n.T(`Validate the cloud resources of a cluster.`)
+)
+
+type ValidateInfraOptions struct {
+	ClusterName string
+	output      string
+	wait        time.Duration
+	count       int
+	interval    time.Duration
+}
+
+func (o *ValidateInfraOptions) InitDefaults() {
+	o.output = OutputTable
+	o.interval = 10 * time.Second
+}
+
+func NewCmdValidateInfra(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ValidateInfraOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "infra [CLUSTER]",
+		Short:             validateInfraShort,
+		Long:              validateInfraLong,
+		Example:           validateInfraExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := RunValidateInfra(cmd.Context(), f, out, options)
+			if err != nil {
+				return fmt.Errorf("validation failed: %v", err)
+			}
+
+			if len(result.Failures) != 0 {
+				os.Exit(2)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.output, "output", "o", options.output, "Output format. One of json|yaml|table.")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "yaml", "table"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().DurationVar(&options.wait, "wait", options.wait, "Amount of time to wait for the infrastructure to become healthy")
+	cmd.Flags().IntVar(&options.count, "count", options.count, "Number of consecutive successful validations required")
+	cmd.Flags().DurationVar(&options.interval, "interval", options.interval, "Time in duration to wait between validation attempts")
+
+	return cmd
+}
+
+func RunValidateInfra(ctx context.Context, f *util.Factory, out io.Writer, options *ValidateInfraOptions) (*validation.ValidationCluster, error) {
+	clientSet, err := f.KopsClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.output == OutputTable {
+		fmt.Fprintf(out, "Validating the infrastructure of cluster %v\n\n", cluster.ObjectMeta.Name)
+	}
+
+	client := sdk.NewClient(clientSet)
+	return client.ValidateInfra(ctx, cluster.ObjectMeta.Name, &sdk.ValidateClusterOptions{
+		Wait:     options.wait,
+		Interval: options.interval,
+		Count:    options.count,
+		OnResult: func(result *validation.ValidationCluster) error {
+			switch options.output {
+			case OutputTable:
+				if err := renderValidationErrors(result.Failures, "VALIDATION ERRORS", out); err != nil {
+					return err
+				}
+				if len(result.Failures) != 0 {
+					fmt.Fprintf(out, "\nValidation Failed\n")
+				} else {
+					fmt.Fprintf(out, "The infrastructure of cluster %s is healthy\n", cluster.Name)
+				}
+			case OutputYaml:
+				y, err := yaml.Marshal(result)
+				if err != nil {
+					return fmt.Errorf("unable to marshal YAML: %v", err)
+				}
+				if _, err := out.Write(y); err != nil {
+					return fmt.Errorf("error writing to output: %v", err)
+				}
+			case OutputJSON:
+				j, err := json.Marshal(result)
+				if err != nil {
+					return fmt.Errorf("unable to marshal JSON: %v", err)
+				}
+				if _, err := out.Write(j); err != nil {
+					return fmt.Errorf("error writing to output: %v", err)
+				}
+			default:
+				return fmt.Errorf("unknown output format: %q", options.output)
+			}
+			return nil
+		},
+	})
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/kops"
 	"k8s.io/kops/cmd/kops/util"
 	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
@@ -58,6 +59,8 @@ func NewCmdVersion(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVar(&options.short, "short", options.short, "only print the main kOps version. Useful for scripting.")
 	cmd.Flags().BoolVar(&options.server, "server", options.server, "show the kOps version that made the last change to the state store.")
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
 	return cmd
 }
 
@@ -0,0 +1,28 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crds holds the CustomResourceDefinitions for the kops API types,
+// generated from the Go types by `make crds`.
+package crds
+
+import "embed"
+
+// Content holds the CustomResourceDefinition manifests.
+// The v1alpha3 directory holds the definitions of the v1alpha3 types; they are
+// only used for their schemas, as v1alpha3 is not served.
+//
+//go:embed *.yaml v1alpha3/*.yaml
+var Content embed.FS
@@ -25,6 +25,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
 	"github.com/blang/semver/v4"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/systemd"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
 	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kops/util/pkg/architectures"
@@ -611,6 +613,28 @@ func (c *NodeupModelContext) GetMetadataLocalIP(ctx context.Context) (string, er
 	return internalIP, nil
 }
 
+// StaticNetworkInterfaceAddress returns the private IP address of the static network interface of the instance group,
+// or "" if the instance group doesn't have one.
+func (c *NodeupModelContext) StaticNetworkInterfaceAddress(ctx context.Context) (string, error) {
+	eni := c.NodeupConfig.StaticNetworkInterface
+	if eni == nil || c.BootConfig.CloudProvider != kops.CloudProviderAWS {
+		return "", nil
+	}
+	if eni.ID == "" && eni.PrivateIPAddress != "" {
+		return eni.PrivateIPAddress, nil
+	}
+
+	cloud, ok := c.Cloud.(awsup.AWSCloud)
+	if !ok {
+		return "", fmt.Errorf("unexpected cloud type %T for static network interface", c.Cloud)
+	}
+	found, err := nodetasks.FindStaticNetworkInterface(ctx, cloud, eni.ID, c.NodeupConfig.ClusterName, c.BootConfig.InstanceGroupName)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(found.PrivateIpAddress), nil
+}
+
 func (c *NodeupModelContext) findStaticManifest(key string) *nodeup.StaticManifest {
 	if c == nil || c.NodeupConfig == nil {
 		return nil
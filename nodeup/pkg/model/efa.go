@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// defaultEFAHugePages is the number of 2 MiB huge pages reserved for EFA when not specified,
+// matching the configuration of the AWS EFA installer.
+const defaultEFAHugePages = 5128
+
+// EFABuilder loads the Elastic Fabric Adapter driver and reserves the huge pages used by libfabric.
+type EFABuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &EFABuilder{}
+
+// Build is responsible for configuring the Elastic Fabric Adapter driver
+func (b *EFABuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	spec := b.NodeupConfig.EFA
+	if spec == nil {
+		return nil
+	}
+
+	hugePages := defaultEFAHugePages
+	if spec.HugePages != nil {
+		hugePages = int(*spec.HugePages)
+	}
+
+	// The efa kernel module is shipped with the kernels of the distributions that run on AWS
+	c.AddTask(&nodetasks.File{
+		Path:            "/etc/modules-load.d/efa.conf",
+		Contents:        fi.NewStringResource("efa\n"),
+		Type:            nodetasks.FileType_File,
+		OnChangeExecute: [][]string{{"modprobe", "efa"}},
+	})
+
+	c.AddTask(&nodetasks.File{
+		Path:            "/etc/sysctl.d/99-efa.conf",
+		Contents:        fi.NewStringResource(fmt.Sprintf("# Huge pages used by libfabric for EFA\nvm.nr_hugepages=%d\n", hugePages)),
+		Type:            nodetasks.FileType_File,
+		OnChangeExecute: [][]string{{"sysctl", "--system"}},
+	})
+
+	// The user-space EFA provider is part of rdma-core
+	switch {
+	case b.Distribution.IsDebianFamily():
+		c.AddTask(&nodetasks.Package{Name: "rdma-core"})
+		c.AddTask(&nodetasks.Package{Name: "ibverbs-providers"})
+	case b.Distribution.IsRHELFamily():
+		c.AddTask(&nodetasks.Package{Name: "rdma-core"})
+		c.AddTask(&nodetasks.Package{Name: "libibverbs"})
+	default:
+		klog.Warningf("EFA user-space packages are not known for %v, they must be installed by the image", b.Distribution)
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/etcdexternal"
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/k8scodecs"
 	"k8s.io/kops/pkg/kubeconfig"
@@ -67,6 +68,20 @@ func (b *KubeAPIServerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		kubeAPIServer = *b.NodeupConfig.APIServerConfig.KubeAPIServer
 	}
 
+	// Rotate the audit log like the other logs, unless its rotation is configured
+	if logRotation := b.NodeupConfig.LogRotation; logRotation != nil {
+		auditLogPath := fi.ValueOf(kubeAPIServer.AuditLogPath)
+		if auditLogPath != "" && auditLogPath != "-" {
+			if kubeAPIServer.AuditLogMaxSize == nil {
+				// kube-apiserver rotates the audit log at a size in megabytes
+				kubeAPIServer.AuditLogMaxSize = fi.PtrTo(int32((logRotation.MaxSize.Value() + 1024*1024 - 1) / (1024 * 1024)))
+			}
+			if kubeAPIServer.AuditLogMaxBackups == nil {
+				kubeAPIServer.AuditLogMaxBackups = logRotation.MaxFiles
+			}
+		}
+	}
+
 	if b.CloudProvider() == kops.CloudProviderHetzner {
 		localIP, err := b.GetMetadataLocalIP(c.Context())
 		if err != nil {
@@ -77,6 +92,14 @@ func (b *KubeAPIServerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		}
 	}
 
+	staticAddress, err := b.StaticNetworkInterfaceAddress(ctx)
+	if err != nil {
+		return err
+	}
+	if staticAddress != "" {
+		kubeAPIServer.AdvertiseAddress = staticAddress
+	}
+
 	b.configureOIDC(&kubeAPIServer)
 	if err := b.writeAuthenticationConfig(c, &kubeAPIServer); err != nil {
 		return err
@@ -120,7 +143,12 @@ func (b *KubeAPIServerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		}
 	}
 
-	{
+	if b.NodeupConfig.APIServerConfig.EtcdClientSecret != "" {
+		if err := b.writeExternalEtcdCredentials(c, b.NodeupConfig.APIServerConfig.EtcdClientSecret); err != nil {
+			return err
+		}
+		kubeAPIServer.EtcdCAFile = filepath.Join(pathSrvKAPI, "etcd-ca.crt")
+	} else {
 		c.AddTask(&nodetasks.File{
 			Path:     filepath.Join(pathSrvKAPI, "etcd-ca.crt"),
 			Contents: fi.NewStringResource(b.NodeupConfig.CAs["etcd-clients-ca"]),
@@ -220,6 +248,40 @@ func (b *KubeAPIServerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 	return nil
 }
 
+// writeExternalEtcdCredentials writes the CA certificate and the client credentials of the external etcd clusters,
+// which are not issued by kOps but read from the secret store.
+func (b *KubeAPIServerBuilder) writeExternalEtcdCredentials(c *fi.NodeupModelBuilderContext, secretName string) error {
+	secret, err := b.SecretStore.Secret(secretName)
+	if err != nil {
+		return fmt.Errorf("external etcd enabled, but could not load %s secret: %v", secretName, err)
+	}
+	credentials, err := etcdexternal.ParseClientCredentials(secret.Data)
+	if err != nil {
+		return fmt.Errorf("invalid %s secret: %w", secretName, err)
+	}
+	pathSrvKAPI := filepath.Join(b.PathSrvKubernetes(), "kube-apiserver")
+
+	c.AddTask(&nodetasks.File{
+		Path:     filepath.Join(pathSrvKAPI, "etcd-ca.crt"),
+		Contents: fi.NewStringResource(credentials.CA),
+		Type:     nodetasks.FileType_File,
+		Mode:     fi.PtrTo("0644"),
+	})
+	c.AddTask(&nodetasks.File{
+		Path:     filepath.Join(pathSrvKAPI, "etcd-client.crt"),
+		Contents: fi.NewStringResource(credentials.Certificate),
+		Type:     nodetasks.FileType_File,
+		Mode:     fi.PtrTo("0644"),
+	})
+	c.AddTask(&nodetasks.File{
+		Path:     filepath.Join(pathSrvKAPI, "etcd-client.key"),
+		Contents: fi.NewStringResource(credentials.Key),
+		Type:     nodetasks.FileType_File,
+		Mode:     fi.PtrTo("0600"),
+	})
+	return nil
+}
+
 func (b *KubeAPIServerBuilder) configureOIDC(kubeAPIServer *kops.KubeAPIServerConfig) {
 	if b.NodeupConfig.APIServerConfig.Authentication == nil || b.NodeupConfig.APIServerConfig.Authentication.OIDC == nil {
 		return
@@ -23,11 +23,11 @@ import (
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/configbuilder"
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/k8scodecs"
 	"k8s.io/kops/pkg/kubemanifest"
-	"k8s.io/kops/pkg/model/components/kubescheduler"
 	"k8s.io/kops/pkg/rbac"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
@@ -94,7 +94,7 @@ func (b *KubeSchedulerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		kubeconfig := b.BuildIssuedKubeconfig("kube-scheduler", nodetasks.PKIXName{CommonName: rbac.KubeScheduler}, c)
 
 		c.AddTask(&nodetasks.File{
-			Path:     kubescheduler.KubeConfigPath,
+			Path:     nodeup.KubeSchedulerKubeConfigPath,
 			Contents: kubeconfig,
 			Type:     nodetasks.FileType_File,
 			Mode:     s("0400"),
@@ -102,7 +102,7 @@ func (b *KubeSchedulerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 	}
 
 	// Load the kube-scheduler config object if one has been provided.
-	kubeSchedulerConfigAsset := b.findFileAsset(kubescheduler.KubeSchedulerConfigPath)
+	kubeSchedulerConfigAsset := b.findFileAsset(nodeup.KubeSchedulerConfigPath)
 
 	if kubeSchedulerConfigAsset != nil {
 		klog.Infof("using kubescheduler configuration from file assets")
@@ -120,7 +120,7 @@ func (b *KubeSchedulerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 			return err
 		}
 		c.AddTask(&nodetasks.File{
-			Path:     kubescheduler.KubeSchedulerConfigPath,
+			Path:     nodeup.KubeSchedulerConfigPath,
 			Contents: fi.NewBytesResource(kubeSchedulerConfig),
 			Type:     nodetasks.FileType_File,
 			Mode:     s("0400"),
@@ -146,7 +146,7 @@ func NewSchedulerConfig(apiVersion string) *SchedulerConfig {
 	schedConfig.APIVersion = apiVersion
 	schedConfig.Kind = "KubeSchedulerConfiguration"
 	schedConfig.ClientConnection = ClientConnectionConfig{}
-	schedConfig.ClientConnection.Kubeconfig = kubescheduler.KubeConfigPath
+	schedConfig.ClientConnection.Kubeconfig = nodeup.KubeSchedulerKubeConfigPath
 	return schedConfig
 }
 
@@ -193,7 +193,7 @@ func (b *KubeSchedulerBuilder) buildPod(kubeScheduler *kops.KubeSchedulerConfig)
 
 	// Add kubeconfig flags
 	for _, flag := range []string{"authentication-", "authorization-"} {
-		flags = append(flags, "--"+flag+"kubeconfig="+kubescheduler.KubeConfigPath)
+		flags = append(flags, "--"+flag+"kubeconfig="+nodeup.KubeSchedulerKubeConfigPath)
 	}
 
 	pod := &v1.Pod{
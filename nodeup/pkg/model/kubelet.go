@@ -249,6 +249,7 @@ func buildKubeletComponentConfig(kubeletConfig *kops.KubeletConfigSpec, provider
 		componentConfig.ShutdownGracePeriodCriticalPods = *kubeletConfig.ShutdownGracePeriodCriticalPods
 	}
 	componentConfig.MemorySwap.SwapBehavior = kubeletConfig.MemorySwapBehavior
+	componentConfig.ServerTLSBootstrap = fi.ValueOf(kubeletConfig.ServerTLSBootstrap)
 
 	s := runtime.NewScheme()
 	if err := kubelet.AddToScheme(s); err != nil {
@@ -329,7 +330,15 @@ func (b *KubeletBuilder) buildSystemdEnvironmentFile(ctx context.Context, kubele
 	// We can always add this later if it is needed.
 	flags += " --cloud-config=" + InTreeCloudConfigFilePath
 
-	if b.UsesSecondaryIP() {
+	staticAddress, err := b.StaticNetworkInterfaceAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if staticAddress != "" {
+		// The node keeps the address of its static network interface when the instance is replaced
+		flags += " --node-ip=" + staticAddress
+	} else if b.UsesSecondaryIP() || b.NodeupConfig.MultipleNetworkInterfaces {
+		// The kubelet must not pick the address of another network interface
 		localIP, err := b.GetMetadataLocalIP(ctx)
 		if err != nil {
 			return nil, err
@@ -352,8 +361,11 @@ func (b *KubeletBuilder) buildSystemdEnvironmentFile(ctx context.Context, kubele
 		flags += " --container-runtime-endpoint=unix://" + fi.ValueOf(b.NodeupConfig.ContainerdConfig.Address)
 	}
 
-	flags += " --tls-cert-file=" + b.PathSrvKubernetes() + "/kubelet-server.crt"
-	flags += " --tls-private-key-file=" + b.PathSrvKubernetes() + "/kubelet-server.key"
+	// The serving certificate is requested from the cluster when bootstrapped
+	if !fi.ValueOf(kubeletConfig.ServerTLSBootstrap) {
+		flags += " --tls-cert-file=" + b.PathSrvKubernetes() + "/kubelet-server.crt"
+		flags += " --tls-private-key-file=" + b.PathSrvKubernetes() + "/kubelet-server.key"
+	}
 
 	if b.IsIPv6Only() {
 		flags += " --node-ip=::"
@@ -741,6 +753,11 @@ func (b *KubeletBuilder) buildControlPlaneKubeletKubeconfig(c *fi.NodeupModelBui
 }
 
 func (b *KubeletBuilder) buildKubeletServingCertificate(c *fi.NodeupModelBuilderContext) error {
+	if fi.ValueOf(b.NodeupConfig.KubeletConfig.ServerTLSBootstrap) {
+		// The kubelet requests its serving certificate from the cluster, which kops-controller approves
+		return nil
+	}
+
 	name := "kubelet-server"
 	dir := b.PathSrvKubernetes()
 
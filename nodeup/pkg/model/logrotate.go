@@ -17,8 +17,10 @@ limitations under the License.
 package model
 
 import (
+	"strconv"
 	"strings"
 
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/systemd"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
@@ -36,6 +38,10 @@ var _ fi.NodeupModelBuilder = &LogrotateBuilder{}
 
 // Build is responsible for configuring logrotate
 func (b *LogrotateBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if logRotation := b.NodeupConfig.LogRotation; logRotation != nil && logRotation.JournalMaxUse != nil {
+		b.addJournaldLimits(c, logRotation.JournalMaxUse.Value())
+	}
+
 	switch b.Distribution {
 	case distributions.DistributionContainerOS:
 		klog.Infof("Detected ContainerOS; won't install logrotate")
@@ -46,17 +52,26 @@ func (b *LogrotateBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		c.AddTask(&nodetasks.Package{Name: "logrotate"})
 	}
 
-	b.addLogRotate(c, "docker", "/var/log/docker.log", logRotateOptions{})
-	b.addLogRotate(c, "kube-addons", "/var/log/kube-addons.log", logRotateOptions{})
-	b.addLogRotate(c, "kube-apiserver", "/var/log/kube-apiserver.log", logRotateOptions{})
-	b.addLogRotate(c, "kube-controller-manager", "/var/log/kube-controller-manager.log", logRotateOptions{})
-	b.addLogRotate(c, "kube-proxy", "/var/log/kube-proxy.log", logRotateOptions{})
-	b.addLogRotate(c, "kube-scheduler", "/var/log/kube-scheduler.log", logRotateOptions{})
-	b.addLogRotate(c, "kubelet", "/var/log/kubelet.log", logRotateOptions{})
-	b.addLogRotate(c, "etcd", "/var/log/etcd.log", logRotateOptions{})
-	b.addLogRotate(c, "etcd-events", "/var/log/etcd-events.log", logRotateOptions{})
+	logRotation := b.NodeupConfig.LogRotation
+	if logRotation == nil {
+		logRotation = (&kops.LogRotationSpec{}).ResolveDefaults(&kops.InstanceGroup{})
+	}
+	options := logRotateOptions{
+		MaxSize: logrotateSize(logRotation.MaxSize.Value()),
+		Rotate:  int(fi.ValueOf(logRotation.MaxFiles)),
+	}
+
+	b.addLogRotate(c, "docker", "/var/log/docker.log", options)
+	b.addLogRotate(c, "kube-addons", "/var/log/kube-addons.log", options)
+	b.addLogRotate(c, "kube-apiserver", "/var/log/kube-apiserver.log", options)
+	b.addLogRotate(c, "kube-controller-manager", "/var/log/kube-controller-manager.log", options)
+	b.addLogRotate(c, "kube-proxy", "/var/log/kube-proxy.log", options)
+	b.addLogRotate(c, "kube-scheduler", "/var/log/kube-scheduler.log", options)
+	b.addLogRotate(c, "kubelet", "/var/log/kubelet.log", options)
+	b.addLogRotate(c, "etcd", "/var/log/etcd.log", options)
+	b.addLogRotate(c, "etcd-events", "/var/log/etcd-events.log", options)
 	if b.NodeupConfig.UseCiliumEtcd {
-		b.addLogRotate(c, "etcd-cilium", "/var/log/etcd-cilium.log", logRotateOptions{})
+		b.addLogRotate(c, "etcd-cilium", "/var/log/etcd-cilium.log", options)
 	}
 
 	if err := b.addLogrotateService(c); err != nil {
@@ -104,8 +119,34 @@ func (b *LogrotateBuilder) addLogrotateService(c *fi.NodeupModelBuilderContext)
 	return nil
 }
 
+// addJournaldLimits limits the disk space used by the systemd journal, which holds the logs of kubelet and containerd.
+func (b *LogrotateBuilder) addJournaldLimits(c *fi.NodeupModelBuilderContext, maxUse int64) {
+	contents := "# Built by kOps - do NOT edit\n\n[Journal]\nSystemMaxUse=" + strconv.FormatInt(maxUse, 10) + "\n"
+
+	c.AddTask(&nodetasks.File{
+		Path:            "/etc/systemd/journald.conf.d/99-kops.conf",
+		Contents:        fi.NewStringResource(contents),
+		Type:            nodetasks.FileType_File,
+		Mode:            s("0644"),
+		OnChangeExecute: [][]string{{"systemctl", "restart", "systemd-journald.service"}},
+	})
+}
+
+// logrotateSize formats a size in bytes for logrotate, which reads the "M" and "k" suffixes as powers of 1024.
+func logrotateSize(size int64) string {
+	switch {
+	case size%(1024*1024) == 0:
+		return strconv.FormatInt(size/(1024*1024), 10) + "M"
+	case size%1024 == 0:
+		return strconv.FormatInt(size/1024, 10) + "k"
+	default:
+		return strconv.FormatInt(size, 10)
+	}
+}
+
 type logRotateOptions struct {
 	MaxSize    string
+	Rotate     int
 	DateFormat string
 }
 
@@ -113,6 +154,9 @@ func (b *LogrotateBuilder) addLogRotate(c *fi.NodeupModelBuilderContext, name, p
 	if options.MaxSize == "" {
 		options.MaxSize = "100M"
 	}
+	if options.Rotate == 0 {
+		options.Rotate = 5
+	}
 
 	// Flatcar sets "dateext" options, and maxsize-based rotation will fail if
 	// the file has been previously rotated on the same calendar date.
@@ -122,7 +166,7 @@ func (b *LogrotateBuilder) addLogRotate(c *fi.NodeupModelBuilderContext, name, p
 
 	lines := []string{
 		path + "{",
-		"  rotate 5",
+		"  rotate " + strconv.Itoa(options.Rotate),
 		"  copytruncate",
 		"  missingok",
 		"  notifempty",
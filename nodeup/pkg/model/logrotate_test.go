@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestLogrotateBuilder(t *testing.T) {
+	RunGoldenTest(t, "tests/golden/logrotation", "logrotation", func(nodeupModelContext *NodeupModelContext, target *fi.NodeupModelBuilderContext) error {
+		builder := LogrotateBuilder{NodeupModelContext: nodeupModelContext}
+		return builder.Build(target)
+	})
+}
+
+func TestLogrotateSize(t *testing.T) {
+	grid := map[int64]string{
+		100 * 1024 * 1024: "100M",
+		512 * 1024:        "512k",
+		100000000:         "100000000",
+	}
+	for size, expected := range grid {
+		if actual := logrotateSize(size); actual != expected {
+			t.Errorf("logrotateSize(%d): expected %q, got %q", size, expected, actual)
+		}
+	}
+}
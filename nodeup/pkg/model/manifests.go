@@ -21,11 +21,17 @@ import (
 	"path/filepath"
 	"strings"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kops/pkg/k8scodecs"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
 	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/yaml"
 )
 
+// etcdManagerExec is how the etcd-manager command starts, before its flags
+const etcdManagerExec = "exec /etcd-manager "
+
 // ManifestsBuilder copies manifests from the store (e.g. etcdmanager)
 type ManifestsBuilder struct {
 	*NodeupModelContext
@@ -39,6 +45,11 @@ func (b *ManifestsBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 
 	// Write etcd manifests (currently etcd <=> master)
 	if b.IsMaster {
+		staticAddress, err := b.StaticNetworkInterfaceAddress(ctx)
+		if err != nil {
+			return err
+		}
+
 		for _, manifest := range b.NodeupConfig.EtcdManifests {
 			p, err := vfs.Context.BuildVfsPath(manifest)
 			if err != nil {
@@ -49,6 +60,13 @@ func (b *ManifestsBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 				return fmt.Errorf("error reading etcd manifest %s: %v", manifest, err)
 			}
 
+			if staticAddress != "" {
+				data, err = withEtcdManagerAddress(data, staticAddress)
+				if err != nil {
+					return fmt.Errorf("error setting address in etcd manifest %s: %w", manifest, err)
+				}
+			}
+
 			name := p.Base()
 			name = strings.TrimSuffix(name, filepath.Ext(name))
 
@@ -67,3 +85,31 @@ func (b *ManifestsBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 
 	return nil
 }
+
+// withEtcdManagerAddress sets the address etcd-manager uses for its peer and client URLs.
+func withEtcdManagerAddress(data []byte, address string) ([]byte, error) {
+	pod := &v1.Pod{}
+	if err := yaml.Unmarshal(data, pod); err != nil {
+		return nil, fmt.Errorf("error parsing manifest: %w", err)
+	}
+
+	found := false
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if container.Name != "etcd-manager" || len(container.Command) == 0 {
+			continue
+		}
+		// The command is run through a shell, to tee the output to a log file
+		last := len(container.Command) - 1
+		if !strings.Contains(container.Command[last], etcdManagerExec) {
+			return nil, fmt.Errorf("unexpected etcd-manager command %q", container.Command[last])
+		}
+		container.Command[last] = strings.Replace(container.Command[last], etcdManagerExec, etcdManagerExec+"--address="+address+" ", 1)
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("etcd-manager container not found")
+	}
+
+	return k8scodecs.ToVersionedYaml(pod)
+}
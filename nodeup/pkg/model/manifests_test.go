@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestWithEtcdManagerAddress(t *testing.T) {
+	data, err := os.ReadFile("../../../tests/integration/update_cluster/minimal-1.26/data/aws_s3_object_manifests-etcdmanager-main-master-us-test-1a_content")
+	if err != nil {
+		t.Fatalf("error reading manifest: %v", err)
+	}
+
+	actual, err := withEtcdManagerAddress(data, "172.20.32.10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pod := &v1.Pod{}
+	if err := yaml.Unmarshal(actual, pod); err != nil {
+		t.Fatalf("error parsing manifest: %v", err)
+	}
+	command := pod.Spec.Containers[0].Command
+	if !strings.Contains(command[len(command)-1], "exec /etcd-manager --address=172.20.32.10 --backup-store=") {
+		t.Errorf("expected address to be set in etcd-manager command, got %q", command[len(command)-1])
+	}
+
+	if _, err := withEtcdManagerAddress([]byte("apiVersion: v1\nkind: Pod\n"), "172.20.32.10"); err == nil {
+		t.Errorf("expected error for manifest without etcd-manager container")
+	}
+}
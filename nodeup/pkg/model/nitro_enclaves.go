@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+const (
+	// defaultNitroEnclavesCPUCount is the number of vCPUs reserved for enclaves when not specified.
+	defaultNitroEnclavesCPUCount = 2
+	// defaultNitroEnclavesMemoryMiB is the memory reserved for enclaves when not specified.
+	defaultNitroEnclavesMemoryMiB = 512
+)
+
+// NitroEnclavesBuilder configures the Nitro Enclaves allocator, which reserves CPUs and memory for enclaves.
+type NitroEnclavesBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &NitroEnclavesBuilder{}
+
+// Build is responsible for configuring the Nitro Enclaves allocator
+func (b *NitroEnclavesBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	spec := b.NodeupConfig.NitroEnclaves
+	if spec == nil {
+		return nil
+	}
+
+	cpuCount := defaultNitroEnclavesCPUCount
+	if spec.CPUCount != nil {
+		cpuCount = int(*spec.CPUCount)
+	}
+	memoryMiB := defaultNitroEnclavesMemoryMiB
+	if spec.MemoryMiB != nil {
+		memoryMiB = int(*spec.MemoryMiB)
+	}
+
+	conf := fmt.Sprintf(`# Built by kOps - do NOT edit
+---
+memory_mib: %d
+cpu_count: %d
+`, memoryMiB, cpuCount)
+
+	c.AddTask(&nodetasks.File{
+		Path:     "/etc/nitro_enclaves/allocator.yaml",
+		Contents: fi.NewStringResource(conf),
+		Type:     nodetasks.FileType_File,
+		Mode:     s("0644"),
+	})
+
+	// The allocator is packaged for Amazon Linux only
+	switch b.Distribution {
+	case distributions.DistributionAmazonLinux2, distributions.DistributionAmazonLinux2023:
+		c.AddTask(&nodetasks.Package{Name: "aws-nitro-enclaves-cli"})
+		c.AddTask((&nodetasks.Service{Name: "nitro-enclaves-allocator.service"}).InitDefaults())
+	default:
+		klog.Warningf("Nitro Enclaves allocator is not packaged for %v, it must be installed by the image", b.Distribution)
+	}
+
+	return nil
+}
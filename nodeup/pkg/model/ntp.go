@@ -17,6 +17,9 @@ limitations under the License.
 package model
 
 import (
+	"fmt"
+	"strings"
+
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
@@ -40,40 +43,36 @@ func (b *NTPBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		return nil
 	}
 
-	switch b.Distribution {
-	case distributions.DistributionContainerOS:
+	if b.Distribution == distributions.DistributionContainerOS {
 		klog.Infof("Detected ContainerOS; won't install ntp")
 		return nil
-	case distributions.DistributionFlatcar:
-		klog.Infof("Detected Flatcar; won't install ntp")
-		return nil
 	}
 
-	var ntpHost string
-	switch b.CloudProvider() {
-	case kops.CloudProviderAWS:
-		ntpHost = "169.254.169.123"
-	case kops.CloudProviderGCE:
-		ntpHost = "time.google.com"
-	default:
-		ntpHost = ""
-	}
+	ntpServers := b.ntpServers()
 
-	if !b.RunningOnGCE() && !b.RunningOnAzure() && b.Distribution.IsUbuntu() && b.Distribution.Version() <= 20.04 {
-		if ntpHost != "" {
-			c.AddTask(b.buildTimesyncdConf("/etc/systemd/timesyncd.conf", ntpHost))
+	if b.Distribution == distributions.DistributionFlatcar {
+		// Flatcar ships with systemd-timesyncd, so we only point it to the NTP servers
+		if len(ntpServers) == 0 {
+			klog.Infof("Detected Flatcar; won't install ntp")
+			return nil
+		}
+		c.AddTask(b.buildTimesyncdConf("/etc/systemd/timesyncd.conf", ntpServers))
+		c.AddTask((&nodetasks.Service{Name: "systemd-timesyncd"}).InitDefaults())
+	} else if !b.RunningOnGCE() && !b.RunningOnAzure() && b.Distribution.IsUbuntu() && b.Distribution.Version() <= 20.04 {
+		if len(ntpServers) != 0 {
+			c.AddTask(b.buildTimesyncdConf("/etc/systemd/timesyncd.conf", ntpServers))
 		}
 		c.AddTask((&nodetasks.Service{Name: "systemd-timesyncd"}).InitDefaults())
 	} else if b.Distribution.IsDebianFamily() {
 		c.AddTask(&nodetasks.Package{Name: "chrony"})
-		if ntpHost != "" {
-			c.AddTask(b.buildChronydConf("/etc/chrony/chrony.conf", ntpHost))
+		if len(ntpServers) != 0 {
+			c.AddTask(b.buildChronydConf("/etc/chrony/chrony.conf", ntpServers))
 		}
 		c.AddTask((&nodetasks.Service{Name: "chrony"}).InitDefaults())
 	} else if b.Distribution.IsRHELFamily() {
 		c.AddTask(&nodetasks.Package{Name: "chrony"})
-		if ntpHost != "" {
-			c.AddTask(b.buildChronydConf("/etc/chrony.conf", ntpHost))
+		if len(ntpServers) != 0 {
+			c.AddTask(b.buildChronydConf("/etc/chrony.conf", ntpServers))
 		}
 		c.AddTask((&nodetasks.Service{Name: "chronyd"}).InitDefaults())
 	} else {
@@ -84,11 +83,41 @@ func (b *NTPBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 	return nil
 }
 
-func (b *NTPBuilder) buildChronydConf(path string, host string) *nodetasks.File {
+// ntpServers returns the NTP servers of the cluster, defaulting to the NTP service of the cloud provider.
+// Without servers, the distribution's defaults are kept.
+func (b *NTPBuilder) ntpServers() []string {
+	if len(b.NodeupConfig.NTPServers) != 0 {
+		return b.NodeupConfig.NTPServers
+	}
+
+	switch b.CloudProvider() {
+	case kops.CloudProviderAWS:
+		// Amazon Time Sync Service
+		return []string{"169.254.169.123"}
+	case kops.CloudProviderGCE:
+		// The metadata server provides NTP
+		return []string{"metadata.google.internal"}
+	case kops.CloudProviderHetzner:
+		return []string{"ntp1.hetzner.de", "ntp2.hetzner.com", "ntp3.hetzner.net"}
+	default:
+		return nil
+	}
+}
+
+func (b *NTPBuilder) buildChronydConf(path string, hosts []string) *nodetasks.File {
+	var servers strings.Builder
+	for i, host := range hosts {
+		// The first server, by default the one of the cloud provider, is preferred
+		if i == 0 {
+			fmt.Fprintf(&servers, "server %s prefer iburst\n", host)
+		} else {
+			fmt.Fprintf(&servers, "server %s iburst\n", host)
+		}
+	}
+
 	conf := `# Built by kOps - do NOT edit
 
-pool ` + host + ` prefer iburst
-driftfile /var/lib/chrony/drift
+` + servers.String() + `driftfile /var/lib/chrony/drift
 leapsectz right/UTC
 logdir /var/log/chrony
 makestep 1.0 3
@@ -103,11 +132,11 @@ rtcsync
 	}
 }
 
-func (b *NTPBuilder) buildTimesyncdConf(path string, host string) *nodetasks.File {
+func (b *NTPBuilder) buildTimesyncdConf(path string, hosts []string) *nodetasks.File {
 	conf := `# Built by Kops - do NOT edit
 
 [Time]
-NTP=` + host + `
+NTP=` + strings.Join(hosts, " ") + `
 `
 	return &nodetasks.File{
 		Path:     path,
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// StaticNetworkInterfaceBuilder attaches the network interface with a stable address to the instance
+type StaticNetworkInterfaceBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &StaticNetworkInterfaceBuilder{}
+
+func (b *StaticNetworkInterfaceBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	eni := b.NodeupConfig.StaticNetworkInterface
+	if eni == nil || b.CloudProvider() != kops.CloudProviderAWS {
+		return nil
+	}
+
+	// A warming instance is stopped afterwards, it must not take over the network interface
+	if b.ConfigurationMode == "Warming" {
+		return nil
+	}
+
+	c.AddTask(&nodetasks.StaticNetworkInterface{
+		Name:              "static-network-interface",
+		ID:                eni.ID,
+		ClusterName:       b.NodeupConfig.ClusterName,
+		InstanceGroupName: b.BootConfig.InstanceGroupName,
+	})
+	return nil
+}
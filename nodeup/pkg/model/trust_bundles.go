@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+// TrustBundlesBuilder installs the additional trust bundles of the cluster in the trust store of the node.
+// The bootstrap script installs them before running nodeup, so that nodeup can download its configuration
+// and assets through TLS intercepting proxies; nodeup keeps them up to date.
+type TrustBundlesBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &TrustBundlesBuilder{}
+
+// Build is responsible for installing the additional trust bundles
+func (b *TrustBundlesBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if len(b.NodeupConfig.AdditionalTrustBundles) == 0 {
+		return nil
+	}
+
+	contents := nodeup.JoinTrustBundles(b.NodeupConfig.AdditionalTrustBundles)
+
+	// Components running in containers, like kops-controller and dns-controller, read this directory from SSL_CERT_DIR
+	c.AddTask(&nodetasks.File{
+		Path: nodeup.TrustBundlesDirectory,
+		Type: nodetasks.FileType_Directory,
+		Mode: s("0755"),
+	})
+	c.AddTask(&nodetasks.File{
+		Path:     filepath.Join(nodeup.TrustBundlesDirectory, nodeup.TrustBundlesFile),
+		Contents: fi.NewStringResource(contents),
+		Type:     nodetasks.FileType_File,
+		Mode:     s("0644"),
+	})
+
+	var path string
+	var update []string
+	switch {
+	case b.Distribution == distributions.DistributionFlatcar:
+		path = filepath.Join(nodeup.TrustBundlesFlatcarDirectory, nodeup.TrustBundlesFlatcarFile)
+		update = []string{"update-ca-certificates"}
+	case b.Distribution.IsDebianFamily():
+		path = filepath.Join(nodeup.TrustBundlesDebianDirectory, nodeup.TrustBundlesFile)
+		update = []string{"update-ca-certificates"}
+	case b.Distribution.IsRHELFamily():
+		path = filepath.Join(nodeup.TrustBundlesRHELDirectory, nodeup.TrustBundlesFile)
+		update = []string{"update-ca-trust", "extract"}
+	default:
+		klog.Warningf("unsupported distribution %v, the additional trust bundles are not added to the system trust store", b.Distribution)
+		return nil
+	}
+
+	// The container runtime and the kubelet load the system trust store when they start
+	c.AddTask(&nodetasks.File{
+		Path:            path,
+		Contents:        fi.NewStringResource(contents),
+		Type:            nodetasks.FileType_File,
+		Mode:            s("0644"),
+		OnChangeExecute: [][]string{update},
+		BeforeServices:  []string{"containerd.service", kubeletService},
+	})
+
+	return nil
+}
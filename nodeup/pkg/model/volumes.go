@@ -18,8 +18,12 @@ package model
 
 import (
 	"fmt"
+	"strings"
 
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/systemd"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
 
 	"k8s.io/klog/v2"
 	"k8s.io/mount-utils"
@@ -59,17 +63,58 @@ func (b *VolumesBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 			return fmt.Errorf("failed to check if device %q is mounted, error: %w", x.Device, err)
 		} else if found {
 			klog.V(3).Infof("Skipping device: %s, path: %s as already mounted", x.Device, x.Path)
-			continue
-		}
-
-		klog.Infof("Attempting to format and mount device: %s, path: %s", x.Device, x.Path)
+		} else {
+			klog.Infof("Attempting to format and mount device: %s, path: %s", x.Device, x.Path)
 
-		if err := m.FormatAndMount(x.Device, x.Path, x.Filesystem, x.MountOptions); err != nil {
-			klog.Errorf("failed to mount the device: %s on: %s, error: %s", x.Device, x.Path, err)
+			if err := m.FormatAndMountSensitiveWithFormatOptions(x.Device, x.Path, x.Filesystem, x.MountOptions, nil, x.FormatOptions); err != nil {
+				klog.Errorf("failed to mount the device: %s on: %s, error: %s", x.Device, x.Path, err)
 
-			return err
+				return err
+			}
 		}
+
+		// @step: mount the device again when the instance reboots, before the container runtime and kubelet start
+		c.AddTask(buildMountUnit(x, b.deviceByUUID(m, x.Device)))
 	}
 
 	return nil
 }
+
+// deviceByUUID returns the path of the device by the UUID of its filesystem, which does not change across reboots
+// unlike the names of NVMe devices. It returns the device itself when the UUID is unknown.
+func (b *VolumesBuilder) deviceByUUID(m *mount.SafeFormatAndMount, device string) string {
+	output, err := m.Exec.Command("blkid", "-s", "UUID", "-o", "value", device).CombinedOutput()
+	if err != nil {
+		klog.Warningf("unable to read the filesystem UUID of device %s: %v", device, err)
+		return device
+	}
+	uuid := strings.TrimSpace(string(output))
+	if uuid == "" {
+		return device
+	}
+	return "/dev/disk/by-uuid/" + uuid
+}
+
+// buildMountUnit builds the systemd mount unit of a volume mount.
+func buildMountUnit(x kops.VolumeMountSpec, what string) *nodetasks.Service {
+	manifest := &systemd.Manifest{}
+	manifest.Set("Unit", "Description", "Mount "+x.Device+" at "+x.Path)
+	manifest.Set("Unit", "Before", "local-fs.target containerd.service kubelet.service")
+	manifest.Set("Mount", "What", what)
+	manifest.Set("Mount", "Where", x.Path)
+	manifest.Set("Mount", "Type", x.Filesystem)
+	if len(x.MountOptions) != 0 {
+		manifest.Set("Mount", "Options", strings.Join(x.MountOptions, ","))
+	}
+	manifest.Set("Install", "WantedBy", "local-fs.target")
+
+	service := &nodetasks.Service{
+		Name:       systemd.EscapePath(x.Path) + ".mount",
+		Definition: fi.PtrTo(manifest.Render()),
+		// Restarting the mount would unmount the volume from under the running services
+		SmartRestart: fi.PtrTo(false),
+	}
+	service.InitDefaults()
+
+	return service
+}
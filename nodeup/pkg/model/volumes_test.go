@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestBuildMountUnit(t *testing.T) {
+	volumeMount := kops.VolumeMountSpec{
+		Device:       "/dev/xvdd",
+		Filesystem:   "xfs",
+		MountOptions: []string{"noatime", "nodiratime"},
+		Path:         "/var/lib/containerd",
+	}
+
+	service := buildMountUnit(volumeMount, "/dev/disk/by-uuid/3f9c1c6e-1f4b-4b2e-9d0a-5c2b7f1e8a90")
+
+	if service.Name != "var-lib-containerd.mount" {
+		t.Errorf("unexpected unit name %q", service.Name)
+	}
+	expected := `[Unit]
+Description=Mount /dev/xvdd at /var/lib/containerd
+Before=local-fs.target containerd.service kubelet.service
+
+[Mount]
+What=/dev/disk/by-uuid/3f9c1c6e-1f4b-4b2e-9d0a-5c2b7f1e8a90
+Where=/var/lib/containerd
+Type=xfs
+Options=noatime,nodiratime
+
+[Install]
+WantedBy=local-fs.target
+`
+	if definition := fi.ValueOf(service.Definition); definition != expected {
+		t.Errorf("unexpected unit definition:\n%s\nexpected:\n%s", definition, expected)
+	}
+	if fi.ValueOf(service.SmartRestart) {
+		t.Errorf("mount units must not be restarted")
+	}
+}
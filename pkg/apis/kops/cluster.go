@@ -114,6 +114,9 @@ type ClusterSpec struct {
 	CloudConfig         *CloudConfiguration `json:"cloudConfig,omitempty"`
 	ExternalDNS         *ExternalDNSConfig  `json:"externalDNS,omitempty"`
 	NTP                 *NTPConfig          `json:"ntp,omitempty"`
+	// LogRotation configures the rotation of the logs of the nodes.
+	// It can be overridden by the log rotation configuration specified in the instance group.
+	LogRotation *LogRotationSpec `json:"logRotation,omitempty"`
 	// Packages specifies additional packages to be installed.
 	Packages []string `json:"packages,omitempty"`
 
@@ -121,6 +124,12 @@ type ClusterSpec struct {
 	NodeProblemDetector *NodeProblemDetectorConfig `json:"nodeProblemDetector,omitempty"`
 	// MetricsServer determines the metrics server configuration.
 	MetricsServer *MetricsServerConfig `json:"metricsServer,omitempty"`
+	// EventExporter determines the event exporter configuration.
+	EventExporter *EventExporterConfig `json:"eventExporter,omitempty"`
+	// PrometheusAgent determines the configuration of the agent that remote-writes control plane metrics.
+	PrometheusAgent *PrometheusAgentConfig `json:"prometheusAgent,omitempty"`
+	// ClusterDefaults declares the namespaces and PriorityClasses that are managed by the bootstrap channel.
+	ClusterDefaults *ClusterDefaultsSpec `json:"clusterDefaults,omitempty"`
 	// CertManager determines the metrics server configuration.
 	CertManager *CertManagerConfig `json:"certManager,omitempty"`
 	// Networking configures networking.
@@ -145,9 +154,29 @@ type ClusterSpec struct {
 	EncryptionConfig *bool `json:"encryptionConfig,omitempty"`
 	// Target allows for us to nest extra config for targets such as terraform
 	Target *TargetSpec `json:"target,omitempty"`
+	// LifecycleOverrides overrides the lifecycle of tasks, in the same form as the --lifecycle-overrides flag.
+	// Keys are a task type, such as SecurityGroupRule, optionally followed by a pattern matching task names,
+	// such as SecurityGroupRule:api-elb*. Values are lifecycles, such as ExistsAndWarnIfChanges.
+	LifecycleOverrides map[string]string `json:"lifecycleOverrides,omitempty"`
+	// ExternalTasks are tasks run by external plugins during kops update cluster, alongside the tasks of kOps,
+	// to manage resources that kOps does not know about, such as the registration of the cluster in an inventory.
+	ExternalTasks []ExternalTaskSpec `json:"externalTasks,omitempty"`
+	// DeletionProtection protects the cluster against accidental deletion.
+	// It enables termination protection on control-plane instances, sets prevent_destroy on stateful
+	// terraform resources and makes kops delete cluster refuse to run until it is disabled again.
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
+	// TTL is the lifetime of an ephemeral cluster, such as a cluster created by a CI job.
+	// Once the TTL has passed since the creation of the cluster, kops toolbox reap-clusters deletes it.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// TTLNotification configures the notification sent before an expired cluster is deleted.
+	TTLNotification *TTLNotificationSpec `json:"ttlNotification,omitempty"`
 	// UseHostCertificates will mount /etc/ssl/certs to inside needed containers.
 	// This is needed if some APIs do have self-signed certs
 	UseHostCertificates *bool `json:"useHostCertificates,omitempty"`
+	// AdditionalTrustBundles are PEM-encoded CA certificates to trust, in addition to the ones of the distribution.
+	// They are installed on all nodes, where they are used by nodeup, the container runtime and the kubelet,
+	// and they are trusted by kops-controller and dns-controller; for example, the CA of a TLS intercepting proxy.
+	AdditionalTrustBundles []string `json:"additionalTrustBundles,omitempty"`
 	// SysctlParameters will configure kernel parameters using sysctl(8). When
 	// specified, each parameter must follow the form variable=value, the way
 	// it would appear in sysctl.conf.
@@ -164,6 +193,14 @@ type ClusterSpec struct {
 	Karpenter *KarpenterConfig `json:"karpenter,omitempty"`
 }
 
+// TTLNotificationSpec configures the notification sent before an expired cluster is deleted.
+type TTLNotificationSpec struct {
+	// WebhookURLs are the http(s) URLs that receive a JSON POST request before the cluster is deleted.
+	WebhookURLs []string `json:"webhookURLs,omitempty"`
+	// Before is how long before its deletion the notification about the cluster is sent. Defaults to 1h.
+	Before *metav1.Duration `json:"before,omitempty"`
+}
+
 // ConfigStoreSpec configures the stores that nodes use to get their configuration.
 type ConfigStoreSpec struct {
 	// Base is the VFS path where we store configuration for the cluster
@@ -230,6 +267,18 @@ type AWSSpec struct {
 
 	// BinariesLocation is the location of the AWS cloud provider binaries.
 	BinariesLocation *string `json:"binariesLocation,omitempty"`
+
+	// PrivateDNSZoneVPCs are VPCs associated with the private DNS zone of the cluster, in addition to
+	// the VPC of the cluster, so that clients in these VPCs can resolve the names of the cluster.
+	PrivateDNSZoneVPCs []PrivateDNSZoneVPCSpec `json:"privateDNSZoneVPCs,omitempty"`
+}
+
+// PrivateDNSZoneVPCSpec is a VPC associated with the private DNS zone of the cluster.
+type PrivateDNSZoneVPCSpec struct {
+	// ID is the ID of the VPC.
+	ID string `json:"id"`
+	// Region is the region of the VPC; defaults to the region of the cluster.
+	Region string `json:"region,omitempty"`
 }
 
 // DOSpec configures the Digital Ocean cloud provider.
@@ -402,10 +451,12 @@ type AuthenticationSpec struct {
 	Kopeio *KopeioAuthenticationSpec `json:"kopeio,omitempty"`
 	AWS    *AWSAuthenticationSpec    `json:"aws,omitempty"`
 	OIDC   *OIDCAuthenticationSpec   `json:"oidc,omitempty"`
+	// CloudIdentity configures the exchange of cloud identities for short-lived admin credentials.
+	CloudIdentity *CloudIdentityAuthenticationSpec `json:"cloudIdentity,omitempty"`
 }
 
 func (s *AuthenticationSpec) IsEmpty() bool {
-	return s.Kopeio == nil && s.AWS == nil && s.OIDC == nil
+	return s.Kopeio == nil && s.AWS == nil && s.OIDC == nil && s.CloudIdentity == nil
 }
 
 type KopeioAuthenticationSpec struct{}
@@ -438,6 +489,16 @@ type AWSAuthenticationIdentityMappingSpec struct {
 	Groups []string `json:"groups,omitempty"`
 }
 
+// CloudIdentityAuthenticationSpec configures kops-controller to issue short-lived admin credentials
+// in exchange for the cloud identity of an operator.
+type CloudIdentityAuthenticationSpec struct {
+	// AllowedIdentities are the cloud identities that may obtain admin credentials:
+	// ARNs of IAM roles or users on AWS, and emails of service accounts on GCE.
+	AllowedIdentities []string `json:"allowedIdentities,omitempty"`
+	// MaxLifetime is the maximum lifetime of the issued admin credentials. Default 1h
+	MaxLifetime *metav1.Duration `json:"maxLifetime,omitempty"`
+}
+
 type OIDCAuthenticationSpec struct {
 	// UsernameClaim is the OpenID claim to use as the username.
 	// Note that claims other than the default ('sub') are not guaranteed to be
@@ -509,6 +570,13 @@ const (
 	LoadBalancerClassNetwork LoadBalancerClass = "Network"
 )
 
+type LoadBalancerTLSMode string
+
+const (
+	LoadBalancerTLSModeTerminate   LoadBalancerTLSMode = "Terminate"
+	LoadBalancerTLSModePassthrough LoadBalancerTLSMode = "Passthrough"
+)
+
 type AccessLogSpec struct {
 	// Interval is the publishing interval in minutes. This parameter is only used with classic load balancer.
 	Interval int `json:"interval,omitempty"`
@@ -523,6 +591,11 @@ var SupportedLoadBalancerClasses = []LoadBalancerClass{
 	LoadBalancerClassNetwork,
 }
 
+var SupportedLoadBalancerTLSModes = []LoadBalancerTLSMode{
+	LoadBalancerTLSModeTerminate,
+	LoadBalancerTLSModePassthrough,
+}
+
 // LoadBalancerSubnetSpec provides configuration for subnets used for a load balancer
 type LoadBalancerSubnetSpec struct {
 	// Name specifies the name of the cluster subnet
@@ -551,12 +624,58 @@ type LoadBalancerAccessSpec struct {
 	SSLCertificate string `json:"sslCertificate,omitempty"`
 	// SSLPolicy allows you to overwrite the LB listener's Security Policy
 	SSLPolicy *string `json:"sslPolicy,omitempty"`
+	// ACMCertificate has kOps request an ACM certificate for the API name, validate it through
+	// the cluster's DNS zone and use it on the LB, instead of an existing sslCertificate.
+	ACMCertificate *ACMCertificateSpec `json:"acmCertificate,omitempty"`
+	// TLSMode selects whether the listener on port 443 terminates TLS with the custom certificate (Terminate,
+	// the default) or passes TLS through to the API server, keeping end-to-end mTLS (Passthrough).
+	// With Passthrough, the custom certificate is used on the listener on port 8443 instead.
+	TLSMode LoadBalancerTLSMode `json:"tlsMode,omitempty"`
+	// ALPNPolicy is the ALPN policy of the listener terminating TLS with the custom certificate:
+	// HTTP1Only, HTTP2Only, HTTP2Optional, HTTP2Preferred or None.
+	ALPNPolicy *string `json:"alpnPolicy,omitempty"`
 	// CrossZoneLoadBalancing allows you to enable the cross zone load balancing
 	CrossZoneLoadBalancing *bool `json:"crossZoneLoadBalancing,omitempty"`
 	// Subnets allows you to specify the subnets that must be used for the load balancer
 	Subnets []LoadBalancerSubnetSpec `json:"subnets,omitempty"`
 	// AccessLog is the configuration of access logs.
 	AccessLog *AccessLogSpec `json:"accessLog,omitempty"`
+	// Internal creates an additional internal network load balancer for clients inside the VPC,
+	// alongside the public load balancer. The internal API DNS name points to it.
+	Internal *InternalLoadBalancerAccessSpec `json:"internal,omitempty"`
+}
+
+// UsesSSLCertificate returns true if the load balancer terminates TLS with an ACM certificate,
+// either an existing one or one managed by kOps.
+func (s *LoadBalancerAccessSpec) UsesSSLCertificate() bool {
+	return s.SSLCertificate != "" || s.ACMCertificate != nil
+}
+
+// TerminatesTLS returns true if the listener on port 443 terminates TLS with the custom certificate,
+// rather than passing TLS through to the API server.
+func (s *LoadBalancerAccessSpec) TerminatesTLS() bool {
+	return s.UsesSSLCertificate() && s.TLSMode != LoadBalancerTLSModePassthrough
+}
+
+// ACMCertificateSpec configures the ACM certificate kOps manages for the API load balancer.
+type ACMCertificateSpec struct {
+	// SubjectAlternativeNames are additional names the certificate is valid for.
+	// The certificate is always valid for the public API name.
+	SubjectAlternativeNames []string `json:"subjectAlternativeNames,omitempty"`
+}
+
+// InternalLoadBalancerAccessSpec provides configuration for the internal API load balancer
+// created alongside a public one.
+type InternalLoadBalancerAccessSpec struct {
+	// Access is a list of the CIDRs that can access the internal load balancer.
+	// Defaults to the network CIDRs of the cluster.
+	Access []string `json:"access,omitempty"`
+	// SSLCertificate allows you to specify the ACM cert to be used by the internal load balancer.
+	SSLCertificate string `json:"sslCertificate,omitempty"`
+	// SSLPolicy allows you to overwrite the internal load balancer listener's Security Policy.
+	SSLPolicy *string `json:"sslPolicy,omitempty"`
+	// Subnets allows you to specify the subnets that must be used for the internal load balancer.
+	Subnets []LoadBalancerSubnetSpec `json:"subnets,omitempty"`
 }
 
 // KubeDNSConfig defines the kube dns configuration
@@ -657,9 +776,9 @@ type EtcdClusterSpec struct {
 	Members []EtcdMemberSpec `json:"etcdMembers,omitempty"`
 	// Version is the version of etcd to run.
 	Version string `json:"version,omitempty"`
-	// LeaderElectionTimeout is the time (in milliseconds) for an etcd leader election timeout
+	// LeaderElectionTimeout is the time that a follower waits without hearing from the leader before starting an election.
 	LeaderElectionTimeout *metav1.Duration `json:"leaderElectionTimeout,omitempty"`
-	// HeartbeatInterval is the time (in milliseconds) for an etcd heartbeat interval
+	// HeartbeatInterval is the interval at which the leader notifies the followers that it is still the leader.
 	HeartbeatInterval *metav1.Duration `json:"heartbeatInterval,omitempty"`
 	// Image is the etcd container image to use. Setting this will ignore the Version specified.
 	Image string `json:"image,omitempty"`
@@ -671,16 +790,57 @@ type EtcdClusterSpec struct {
 	MemoryRequest *resource.Quantity `json:"memoryRequest,omitempty"`
 	// CPURequest specifies the cpu requests of each etcd container in the cluster.
 	CPURequest *resource.Quantity `json:"cpuRequest,omitempty"`
+	// TLS configures the TLS versions and cipher suites of the etcd peer and client connections.
+	TLS *EtcdTLSSpec `json:"tls,omitempty"`
+	// GRPC tunes the flow control and keepalives of the etcd gRPC server, for example for members connected over high-latency links.
+	GRPC *EtcdGRPCSpec `json:"grpc,omitempty"`
+	// External references an etcd cluster that is managed outside of kOps, instead of provisioning one with etcd-manager.
+	External *EtcdExternalSpec `json:"external,omitempty"`
 }
 
 // EtcdBackupSpec describes how we want to do backups of etcd
 type EtcdBackupSpec struct {
 	// BackupStore is the VFS path where we will read/write backup data
 	BackupStore string `json:"backupStore,omitempty"`
+	// SecondaryBackupStore is the VFS path where kops toolbox etcd-backup copies the backups, for disaster recovery.
+	SecondaryBackupStore string `json:"secondaryBackupStore,omitempty"`
 	// Image is the etcd backup manager image to use.  Setting this will create a sidecar container in the etcd pod with the specified image.
 	Image string `json:"image,omitempty"`
 }
 
+// EtcdTLSSpec configures the TLS settings of etcd, which apply to both peer and client connections.
+type EtcdTLSSpec struct {
+	// MinVersion is the minimum TLS version, TLS1.2 or TLS1.3.
+	MinVersion string `json:"minVersion,omitempty"`
+	// MaxVersion is the maximum TLS version, TLS1.2 or TLS1.3.
+	MaxVersion string `json:"maxVersion,omitempty"`
+	// CipherSuites are the allowed TLS cipher suites, using the Go names. They cannot be set if only TLS1.3 is allowed.
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+}
+
+// EtcdGRPCSpec tunes the gRPC server of etcd.
+type EtcdGRPCSpec struct {
+	// MaxConcurrentStreams is the maximum number of concurrent streams of each client connection.
+	MaxConcurrentStreams *int32 `json:"maxConcurrentStreams,omitempty"`
+	// MaxRequestBytes is the maximum size of a client request in bytes.
+	MaxRequestBytes *int32 `json:"maxRequestBytes,omitempty"`
+	// KeepaliveMinTime is the minimum interval that a client should wait before pinging the server.
+	KeepaliveMinTime *metav1.Duration `json:"keepaliveMinTime,omitempty"`
+	// KeepaliveInterval is the interval after which the server pings an idle client to check the connection.
+	KeepaliveInterval *metav1.Duration `json:"keepaliveInterval,omitempty"`
+	// KeepaliveTimeout is the time that the server waits for the response to a ping before closing the connection.
+	KeepaliveTimeout *metav1.Duration `json:"keepaliveTimeout,omitempty"`
+}
+
+// EtcdExternalSpec references an etcd cluster that is managed outside of kOps.
+type EtcdExternalSpec struct {
+	// Endpoints are the client URLs of the members of the etcd cluster, such as https://etcd-a.example.com:2379.
+	Endpoints []string `json:"endpoints,omitempty"`
+	// ClientSecret is the name of the secret of the secret store holding the CA certificate, client certificate and
+	// client key that the API server uses to connect to the etcd cluster. Defaults to etcd-client.
+	ClientSecret string `json:"clientSecret,omitempty"`
+}
+
 // EtcdManagerSpec describes how we configure the etcd manager
 type EtcdManagerSpec struct {
 	// Image is the etcd manager image to use.
@@ -754,6 +914,13 @@ const (
 	EgressExternal = "External"
 )
 
+const (
+	// NATGatewayConnectivityTypePublic means that the NAT gateway of a subnet has an elastic IP, and routes to the internet
+	NATGatewayConnectivityTypePublic = "public"
+	// NATGatewayConnectivityTypePrivate means that the NAT gateway of a subnet has no elastic IP, and routes to other networks through a transit gateway
+	NATGatewayConnectivityTypePrivate = "private"
+)
+
 // ClusterSubnetSpec defines a subnet
 // TODO: move to networking.go
 type ClusterSubnetSpec struct {
@@ -775,10 +942,34 @@ type ClusterSubnetSpec struct {
 	Type SubnetType `json:"type,omitempty"`
 	// PublicIP to attach to NatGateway
 	PublicIP string `json:"publicIP,omitempty"`
+	// NATGatewayConnectivityType is the connectivity type of the NAT gateway created for the subnet, either "public" or "private" (AWS only).
+	// Private NAT gateways have no elastic IP, and route to other VPCs or networks through a transit gateway. Defaults to "public".
+	NATGatewayConnectivityType string `json:"natGatewayConnectivityType,omitempty"`
 	// AdditionalRoutes to attach to the subnet's route table
 	AdditionalRoutes []RouteSpec `json:"additionalRoutes,omitempty"`
+	// PrivateDNSNameOptions configures the hostnames of the instances launched into the subnet (AWS only).
+	PrivateDNSNameOptions *PrivateDNSNameOptionsSpec `json:"privateDNSNameOptions,omitempty"`
+	// Tags are added to the subnet and to the resources created for it, such as its NAT gateway (AWS only).
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// PrivateDNSNameOptionsSpec configures the hostnames of the instances launched into an AWS subnet.
+type PrivateDNSNameOptionsSpec struct {
+	// HostnameType is the type of hostname of the instances, either "ip-name" or "resource-name".
+	HostnameType string `json:"hostnameType,omitempty"`
+	// EnableResourceNameDNSARecord resolves the resource-name hostnames to the IPv4 addresses of the instances.
+	EnableResourceNameDNSARecord *bool `json:"enableResourceNameDNSARecord,omitempty"`
+	// EnableResourceNameDNSAAAARecord resolves the resource-name hostnames to the IPv6 addresses of the instances.
+	EnableResourceNameDNSAAAARecord *bool `json:"enableResourceNameDNSAAAARecord,omitempty"`
 }
 
+const (
+	// HostnameTypeIPName names the instances after their private IPv4 address.
+	HostnameTypeIPName = "ip-name"
+	// HostnameTypeResourceName names the instances after their instance ID.
+	HostnameTypeResourceName = "resource-name"
+)
+
 type RouteSpec struct {
 	// CIDR destination of the route
 	CIDR string `json:"cidr,omitempty"`
@@ -799,6 +990,25 @@ type HTTPProxy struct {
 	// Password string `json:"password,omitempty"`
 }
 
+// ExternalTaskSpec is a task run by an external plugin, an executable or a webhook.
+// The plugin is asked for the actual state of its resource, and to apply the desired state
+// only if it differs, so that the changes of external tasks are previewed like the others.
+type ExternalTaskSpec struct {
+	// Name is the name of the task, unique within the cluster.
+	Name string `json:"name,omitempty"`
+	// Command is the executable of the plugin and its arguments.
+	// Each request is written to its standard input, and the response read from its standard output.
+	Command []string `json:"command,omitempty"`
+	// URL is the endpoint of the webhook of the plugin, to which the requests are posted.
+	// Exactly one of command and url must be set.
+	URL string `json:"url,omitempty"`
+	// DependsOn are the tasks that must complete before this task runs: the names of other external tasks,
+	// or tasks of kOps as type/name, such as Keypair/kubernetes-ca.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Config is the desired state of the resource of the task, passed to the plugin.
+	Config map[string]string `json:"config,omitempty"`
+}
+
 // TargetSpec allows for specifying target config in an extensible way
 type TargetSpec struct {
 	Terraform *TerraformSpec `json:"terraform,omitempty"`
@@ -814,10 +1024,28 @@ type TerraformSpec struct {
 	ProviderExtraConfig map[string]string `json:"providerExtraConfig,omitempty"`
 	// FilesProviderExtraConfig contains key/value pairs to add to the terraform provider block used for managed files
 	FilesProviderExtraConfig map[string]string `json:"filesProviderExtraConfig,omitempty"`
+	// ManageAddons renders the bootstrap channel addon manifests as kubectl_manifest resources,
+	// so that the addons are installed by the same terraform apply that creates the cluster.
+	ManageAddons *bool `json:"manageAddons,omitempty"`
+	// SplitOutput writes each terraform resource type to its own file, instead of a single kubernetes.tf.
+	SplitOutput *bool `json:"splitOutput,omitempty"`
+	// DeduplicatePolicies writes identical policy documents to a single file shared by the resources that use them.
+	DeduplicatePolicies *bool `json:"deduplicatePolicies,omitempty"`
+	// Moved renders terraform moved blocks, so that terraform moves resources to their new addresses
+	// instead of destroying and recreating them when kOps renames them.
+	Moved []TerraformMovedSpec `json:"moved,omitempty"`
+}
+
+// TerraformMovedSpec records that a terraform resource was renamed.
+type TerraformMovedSpec struct {
+	// From is the previous address of the resource, such as aws_internet_gateway.old-example-com.
+	From string `json:"from"`
+	// To is the current address of the resource.
+	To string `json:"to"`
 }
 
 func (t *TerraformSpec) IsEmpty() bool {
-	return len(t.ProviderExtraConfig) == 0 && len(t.FilesProviderExtraConfig) == 0
+	return len(t.ProviderExtraConfig) == 0 && len(t.FilesProviderExtraConfig) == 0 && t.ManageAddons == nil && t.SplitOutput == nil && t.DeduplicatePolicies == nil && len(t.Moved) == 0
 }
 
 // FillDefaults populates default values.
@@ -944,6 +1172,21 @@ func (c *ClusterSpec) IsKopsControllerIPAM() bool {
 	return c.IsIPv6Only()
 }
 
+// UsesSecurityGroupsForPods returns true if the Amazon VPC CNI gives pods the security group of their instance group.
+func (c *ClusterSpec) UsesSecurityGroupsForPods() bool {
+	return c.Networking.AmazonVPC != nil && c.Networking.AmazonVPC.SecurityGroupsForPods
+}
+
+// UsesKubeletServerTLSBootstrap returns true if the kubelets request their serving certificates from the cluster.
+func (c *ClusterSpec) UsesKubeletServerTLSBootstrap() bool {
+	for _, kubelet := range []*KubeletConfigSpec{c.Kubelet, c.ControlPlaneKubelet} {
+		if kubelet != nil && kubelet.ServerTLSBootstrap != nil && *kubelet.ServerTLSBootstrap {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *ClusterSpec) GetCloudProvider() CloudProviderID {
 	if c.CloudProvider.AWS != nil {
 		return CloudProviderAWS
@@ -1037,8 +1280,111 @@ type RollingUpdate struct {
 	// nodes.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// Canary replaces a single instance of the instance group first, and only updates the
+	// remaining instances once the checks on its replacement node have passed.
+	// Has no effect on instance groups with role "ControlPlane" or "Bastion", or without validation.
+	// +optional
+	Canary *RollingUpdateCanary `json:"canary,omitempty"`
+	// ZoneSerial replaces the instances of the instance group one zone at a time, and validates the cluster
+	// before moving on to the next zone, so that nodes in two zones are never unavailable at the same time.
+	// Defaults to false.
+	// +optional
+	ZoneSerial *bool `json:"zoneSerial,omitempty"`
+	// MaxUnavailablePerZone is the maximum number of nodes of a zone that can be unavailable during the update,
+	// in place of MaxUnavailable.
+	// The value can be an absolute number (for example 2) or a percentage of the nodes in the zone
+	// (for example 50%).
+	// The absolute number is calculated from a percentage by rounding down, to a minimum of 1.
+	// Has no effect unless ZoneSerial is true.
+	// +optional
+	MaxUnavailablePerZone *intstr.IntOrString `json:"maxUnavailablePerZone,omitempty"`
+	// Strategy is the strategy used to replace the instances of the instance group.
+	// With "surge", the replacements of the instances are provisioned in the warm pool of the instance group first,
+	// then the outdated instances are detached, so that the warm instances join the cluster, and are terminated once
+	// the cluster validates. Only instance groups with a warm pool on AWS can surge; others are updated as usual.
+	// Defaults to "replace".
+	// +optional
+	Strategy RollingUpdateStrategy `json:"strategy,omitempty"`
+	// Drain configures how the nodes of the instance group are drained before their instances are terminated.
+	// +optional
+	Drain *RollingUpdateDrain `json:"drain,omitempty"`
 }
 
+// RollingUpdateStrategy is the strategy used to replace the instances of an instance group.
+type RollingUpdateStrategy string
+
+const (
+	// RollingUpdateStrategyReplace detaches up to MaxSurge instances at a time, and waits for the autoscaling group to launch their replacements.
+	RollingUpdateStrategyReplace RollingUpdateStrategy = "replace"
+	// RollingUpdateStrategySurge provisions the replacements of all the instances in the warm pool before detaching them.
+	RollingUpdateStrategySurge RollingUpdateStrategy = "surge"
+)
+
+// SupportedRollingUpdateStrategies are the supported strategies of a rolling update.
+var SupportedRollingUpdateStrategies = []RollingUpdateStrategy{RollingUpdateStrategyReplace, RollingUpdateStrategySurge}
+
+// RollingUpdateCanary configures the checks run against the node that replaces the first instance of a rolling update.
+type RollingUpdateCanary struct {
+	// NetworkReady checks that the network plugin (CNI) on the canary node is ready.
+	// Defaults to true.
+	NetworkReady *bool `json:"networkReady,omitempty"`
+	// PodScheduling checks that a pod can be scheduled and started on the canary node.
+	// Defaults to true.
+	PodScheduling *bool `json:"podScheduling,omitempty"`
+	// Job is a job that must run to completion on the canary node.
+	// +optional
+	Job *RollingUpdateCanaryJob `json:"job,omitempty"`
+	// Timeout is the maximum time to wait for the canary node to join the cluster and pass the checks.
+	// Defaults to 10m.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// RollingUpdateCanaryJob is a job run on the canary node of a rolling update.
+type RollingUpdateCanaryJob struct {
+	// Namespace is the namespace the job runs in.
+	// Defaults to kube-system.
+	Namespace string `json:"namespace,omitempty"`
+	// Image is the container image of the job.
+	Image string `json:"image,omitempty"`
+	// Command is the command run by the job's container.
+	Command []string `json:"command,omitempty"`
+}
+
+// RollingUpdateDrain configures how the nodes of an instance group are drained during rolling updates.
+type RollingUpdateDrain struct {
+	// GracePeriod is the time given to each evicted pod to terminate gracefully, in place of the
+	// terminationGracePeriodSeconds of the pod.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+	// Timeout is the maximum time to wait for a node to drain, in place of the --drain-timeout flag.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// SkipPDBLabels selects the pods that are deleted without waiting for their PodDisruptionBudgets.
+	// The pods having all the labels are deleted before the other pods of the node are evicted.
+	// +optional
+	SkipPDBLabels map[string]string `json:"skipPDBLabels,omitempty"`
+	// TimeoutPolicy is what happens when a node fails to drain, for example because PodDisruptionBudgets
+	// block the eviction of its pods until the timeout.
+	// With "fail", the drain fails. With "force", the pods left on the node are deleted without waiting
+	// for their PodDisruptionBudgets.
+	// Defaults to "fail".
+	// +optional
+	TimeoutPolicy RollingUpdateDrainTimeoutPolicy `json:"timeoutPolicy,omitempty"`
+}
+
+// RollingUpdateDrainTimeoutPolicy is what happens when a node fails to drain during a rolling update.
+type RollingUpdateDrainTimeoutPolicy string
+
+const (
+	// RollingUpdateDrainTimeoutPolicyFail fails the drain of the node.
+	RollingUpdateDrainTimeoutPolicyFail RollingUpdateDrainTimeoutPolicy = "fail"
+	// RollingUpdateDrainTimeoutPolicyForce deletes the pods left on the node, ignoring their PodDisruptionBudgets.
+	RollingUpdateDrainTimeoutPolicyForce RollingUpdateDrainTimeoutPolicy = "force"
+)
+
+// SupportedRollingUpdateDrainTimeoutPolicies are the supported timeout policies of the drain of a node.
+var SupportedRollingUpdateDrainTimeoutPolicies = []RollingUpdateDrainTimeoutPolicy{RollingUpdateDrainTimeoutPolicyFail, RollingUpdateDrainTimeoutPolicyForce}
+
 type PackagesConfig struct {
 	// HashAmd64 overrides the hash for the AMD64 package.
 	HashAmd64 *string `json:"hashAmd64,omitempty"`
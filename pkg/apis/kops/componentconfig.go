@@ -242,6 +242,9 @@ type KubeletConfigSpec struct {
 	// MemorySwapBehavior defines how swap is used by container workloads.
 	// Supported values: LimitedSwap, "UnlimitedSwap.
 	MemorySwapBehavior string `json:"memorySwapBehavior,omitempty"`
+	// ServerTLSBootstrap requests the serving certificate of the kubelet from the cluster, instead of using a certificate issued by kOps.
+	// The certificate signing requests are approved by kops-controller, and metrics-server verifies the serving certificates of the kubelets.
+	ServerTLSBootstrap *bool `json:"serverTLSBootstrap,omitempty"`
 }
 
 // KubeProxyConfig defines the configuration for a proxy
@@ -843,6 +846,46 @@ type OpenstackLoadbalancerConfig struct {
 	EnableIngressHostname *bool   `json:"enableIngressHostname,omitempty"`
 	IngressHostnameSuffix *string `json:"ingressHostnameSuffix,omitempty"`
 	FlavorID              *string `json:"flavorID,omitempty"`
+	// TLSContainerRef is the reference to the Barbican secret container holding the certificate of the API load balancer.
+	// When set, the API listener terminates TLS and re-encrypts the requests to the control plane nodes, so that
+	// L7 policies can route them. Clients must then authenticate with tokens instead of client certificates.
+	TLSContainerRef *string `json:"tlsContainerRef,omitempty"`
+	// L7Policies route the requests of the API listener to other ports of the control plane nodes, or reject them.
+	// They require TLSContainerRef.
+	L7Policies []OpenstackL7Policy `json:"l7Policies,omitempty"`
+}
+
+// OpenstackL7Policy is an Octavia L7 policy of the API listener, applied to the requests matching all its rules.
+type OpenstackL7Policy struct {
+	// Name is the name of the policy, unique within the cluster.
+	Name string `json:"name"`
+	// Action is either REDIRECT_TO_POOL (default) or REJECT.
+	Action string `json:"action,omitempty"`
+	// Position is the position of the policy in the list of policies of the listener, starting at 1.
+	// Defaults to the order of the policies.
+	Position *int `json:"position,omitempty"`
+	// PoolPort is the port of the control plane nodes that matching requests are sent to with REDIRECT_TO_POOL.
+	// Defaults to the port of kube-apiserver-healthcheck.
+	PoolPort *int `json:"poolPort,omitempty"`
+	// PoolTLS enables TLS to the control plane nodes for PoolPort.
+	PoolTLS *bool `json:"poolTLS,omitempty"`
+	// Rules are the conditions that requests must all match.
+	Rules []OpenstackL7Rule `json:"rules"`
+}
+
+// OpenstackL7Rule is a condition of an Octavia L7 policy.
+type OpenstackL7Rule struct {
+	// Type is the part of the request to compare, one of PATH, HOST_NAME, HEADER, COOKIE or FILE_TYPE.
+	// HOST_NAME matches the Host header of the request.
+	Type string `json:"type"`
+	// CompareType is one of EQUAL_TO (default), STARTS_WITH, ENDS_WITH, CONTAINS or REGEX.
+	CompareType string `json:"compareType,omitempty"`
+	// Key is the name of the header or cookie, for the HEADER and COOKIE types.
+	Key string `json:"key,omitempty"`
+	// Value is the value to compare with.
+	Value string `json:"value"`
+	// Invert inverts the result of the comparison.
+	Invert bool `json:"invert,omitempty"`
 }
 
 type OpenstackBlockStorageConfig struct {
@@ -1074,6 +1117,115 @@ type NodeProblemDetectorConfig struct {
 	CPULimit *resource.Quantity `json:"cpuLimit,omitempty"`
 }
 
+// EventExporterSink is the backend the event exporter ships events to.
+type EventExporterSink string
+
+const (
+	// EventExporterSinkCloudWatch ships events to an AWS CloudWatch Logs log group.
+	EventExporterSinkCloudWatch EventExporterSink = "CloudWatch"
+	// EventExporterSinkStackdriver ships events to Google Cloud Logging.
+	EventExporterSinkStackdriver EventExporterSink = "Stackdriver"
+	// EventExporterSinkLoki ships events to a Grafana Loki endpoint.
+	EventExporterSinkLoki EventExporterSink = "Loki"
+)
+
+// SupportedEventExporterSinks is the list of supported event exporter sinks.
+var SupportedEventExporterSinks = []EventExporterSink{
+	EventExporterSinkCloudWatch,
+	EventExporterSinkStackdriver,
+	EventExporterSinkLoki,
+}
+
+// EventExporterConfig determines the event exporter configuration.
+// The event exporter keeps a copy of cluster events, including those emitted by kOps components,
+// after the API server has expired them.
+type EventExporterConfig struct {
+	// Enabled enables the event exporter.
+	// Default: false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Image is the event exporter container image used.
+	Image *string `json:"image,omitempty"`
+	// ForwarderImage is the log forwarder container image used for the CloudWatch and Stackdriver sinks.
+	ForwarderImage *string `json:"forwarderImage,omitempty"`
+	// Sink is the backend events are shipped to.
+	// Supported values: CloudWatch, Stackdriver, Loki.
+	// Default: CloudWatch on AWS, Stackdriver on GCE.
+	Sink EventExporterSink `json:"sink,omitempty"`
+	// CloudWatch configures the CloudWatch sink.
+	CloudWatch *EventExporterCloudWatchSpec `json:"cloudWatch,omitempty"`
+	// Loki configures the Loki sink.
+	Loki *EventExporterLokiSpec `json:"loki,omitempty"`
+
+	// MemoryRequest of the event exporter container.
+	// Default: 64Mi
+	MemoryRequest *resource.Quantity `json:"memoryRequest,omitempty"`
+	// CPURequest of the event exporter container.
+	// Default: 10m
+	CPURequest *resource.Quantity `json:"cpuRequest,omitempty"`
+}
+
+// EventExporterCloudWatchSpec configures the CloudWatch sink of the event exporter.
+type EventExporterCloudWatchSpec struct {
+	// LogGroupName is the name of the log group events are written to. It is created if it does not exist.
+	// Default: /kops/<cluster name>/events
+	LogGroupName string `json:"logGroupName,omitempty"`
+	// RetentionInDays is the retention applied to the log group when it is created.
+	// Default: 30
+	RetentionInDays *int32 `json:"retentionInDays,omitempty"`
+}
+
+// EventExporterLokiSpec configures the Loki sink of the event exporter.
+type EventExporterLokiSpec struct {
+	// URL is the Loki push API endpoint, e.g. http://loki.monitoring:3100/loki/api/v1/push.
+	URL string `json:"url,omitempty"`
+	// TenantID is sent as the X-Scope-OrgID header for multi-tenant Loki installations.
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// PrometheusAgentConfig determines the configuration of the Prometheus agent, which scrapes
+// kube-apiserver, etcd, kubelet and kops-controller metrics and remote-writes them to an external endpoint.
+type PrometheusAgentConfig struct {
+	// Enabled enables the Prometheus agent.
+	// Default: false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Image is the Prometheus container image used.
+	Image *string `json:"image,omitempty"`
+	// ScrapeInterval is how often the targets are scraped.
+	// Default: 60s
+	ScrapeInterval *metav1.Duration `json:"scrapeInterval,omitempty"`
+	// ExternalLabels are added to every series sent to the remote endpoint.
+	// The cluster label is always set to the cluster name.
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+	// RemoteWrite configures the endpoint metrics are written to.
+	RemoteWrite *PrometheusRemoteWriteSpec `json:"remoteWrite,omitempty"`
+
+	// MemoryRequest of the Prometheus agent container.
+	// Default: 200Mi
+	MemoryRequest *resource.Quantity `json:"memoryRequest,omitempty"`
+	// CPURequest of the Prometheus agent container.
+	// Default: 50m
+	CPURequest *resource.Quantity `json:"cpuRequest,omitempty"`
+}
+
+// PrometheusRemoteWriteSpec configures the remote-write endpoint of the Prometheus agent.
+type PrometheusRemoteWriteSpec struct {
+	// URL is the remote-write endpoint, e.g. https://aps-workspaces.us-east-1.amazonaws.com/workspaces/ws-example/api/v1/remote_write.
+	URL string `json:"url,omitempty"`
+	// SigV4 signs requests with the AWS credentials of the agent, as required by Amazon Managed Service for Prometheus.
+	// kOps grants the aps:RemoteWrite permission.
+	SigV4 *PrometheusSigV4Spec `json:"sigV4,omitempty"`
+	// BasicAuthSecretName is the name of a Secret in kube-system with "username" and "password" keys,
+	// used for basic authentication, e.g. with Grafana Cloud.
+	BasicAuthSecretName string `json:"basicAuthSecretName,omitempty"`
+}
+
+// PrometheusSigV4Spec configures AWS Signature Version 4 signing of remote-write requests.
+type PrometheusSigV4Spec struct {
+	// Region is the region of the workspace.
+	// Default: the region of the cluster.
+	Region string `json:"region,omitempty"`
+}
+
 // ClusterAutoscalerConfig determines the cluster autoscaler configuration.
 type ClusterAutoscalerConfig struct {
 	// Enabled enables the cluster autoscaler.
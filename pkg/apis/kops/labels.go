@@ -23,6 +23,15 @@ const (
 	// AnnotationValueManagementImported is the annotation value that indicates a cluster was imported, typically as part of an upgrade
 	AnnotationValueManagementImported = "imported"
 
+	// AnnotationNameRollingUpdatePolicy is the annotation on a Cluster or InstanceGroup that controls whether `kops apply` performs a rolling update
+	AnnotationNameRollingUpdatePolicy = "kops.kubernetes.io/rolling-update-policy"
+
+	// AnnotationValueRollingUpdatePolicyAuto is the annotation value that makes `kops apply` roll instance groups that need updating
+	AnnotationValueRollingUpdatePolicyAuto = "auto"
+
+	// AnnotationValueRollingUpdatePolicyNever is the annotation value that stops `kops apply` from rolling instance groups
+	AnnotationValueRollingUpdatePolicyNever = "never"
+
 	// UpdatePolicyAutomatic is a value for ClusterSpec.UpdatePolicy and InstanceGroup.UpdatePolicy indicating that upgrades are performed automatically
 	UpdatePolicyAutomatic = "automatic"
 
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kops
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestLogRotationSpec_ResolveDefaults(t *testing.T) {
+	quantity := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+		return &q
+	}
+	int32Ptr := func(i int32) *int32 {
+		return &i
+	}
+
+	grid := []struct {
+		name     string
+		cluster  *LogRotationSpec
+		ig       *LogRotationSpec
+		expected LogRotationSpec
+	}{
+		{
+			name:     "defaults",
+			expected: LogRotationSpec{MaxSize: quantity("100Mi"), MaxFiles: int32Ptr(5)},
+		},
+		{
+			name:     "cluster",
+			cluster:  &LogRotationSpec{MaxSize: quantity("50Mi"), JournalMaxUse: quantity("1Gi")},
+			expected: LogRotationSpec{MaxSize: quantity("50Mi"), MaxFiles: int32Ptr(5), JournalMaxUse: quantity("1Gi")},
+		},
+		{
+			name:     "instance group overrides",
+			cluster:  &LogRotationSpec{MaxSize: quantity("50Mi"), JournalMaxUse: quantity("1Gi")},
+			ig:       &LogRotationSpec{MaxFiles: int32Ptr(2), JournalMaxUse: quantity("500Mi")},
+			expected: LogRotationSpec{MaxSize: quantity("50Mi"), MaxFiles: int32Ptr(2), JournalMaxUse: quantity("500Mi")},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &InstanceGroup{Spec: InstanceGroupSpec{LogRotation: g.ig}}
+			actual := g.cluster.ResolveDefaults(ig)
+
+			if actual.MaxSize.Cmp(*g.expected.MaxSize) != 0 {
+				t.Errorf("expected maxSize %v, got %v", g.expected.MaxSize, actual.MaxSize)
+			}
+			if *actual.MaxFiles != *g.expected.MaxFiles {
+				t.Errorf("expected maxFiles %d, got %d", *g.expected.MaxFiles, *actual.MaxFiles)
+			}
+			if (actual.JournalMaxUse == nil) != (g.expected.JournalMaxUse == nil) || (actual.JournalMaxUse != nil && actual.JournalMaxUse.Cmp(*g.expected.JournalMaxUse) != 0) {
+				t.Errorf("expected journalMaxUse %v, got %v", g.expected.JournalMaxUse, actual.JournalMaxUse)
+			}
+			if g.cluster != nil && g.ig != nil && g.cluster.MaxFiles != nil {
+				t.Errorf("the cluster configuration was modified")
+			}
+		})
+	}
+}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package kops
 
-import "k8s.io/apimachinery/pkg/api/resource"
+import (
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
 
 // NetworkingSpec configures networking.
 type NetworkingSpec struct {
@@ -59,6 +63,13 @@ type NetworkingSpec struct {
 	//  * run kube-proxy on the master
 	//  * enable debugging handlers on the master, so kubectl logs works
 	IsolateControlPlane *bool `json:"isolateControlPlane,omitempty"`
+	// DHCPOptions configures the DHCP options set of the network (AWS only).
+	// It can only be used when kOps manages the network.
+	DHCPOptions *DHCPOptionsSpec `json:"dhcpOptions,omitempty"`
+	// TransitGateway attaches the network to an existing transit gateway (AWS only).
+	TransitGateway *TransitGatewaySpec `json:"transitGateway,omitempty"`
+	// VPCEndpoints are the endpoints through which the network reaches AWS services without a NAT gateway (AWS only).
+	VPCEndpoints []VPCEndpointSpec `json:"vpcEndpoints,omitempty"`
 
 	// The following specify the selection and configuration of a networking plugin.
 	// Exactly one of the fields must be non-null.
@@ -101,6 +112,62 @@ func (n *NetworkingSpec) UsesKubenet() bool {
 	return false
 }
 
+// DHCPOptionsSpec configures the DHCP options set of the network created by kOps.
+type DHCPOptionsSpec struct {
+	// DomainName is the domain name that instances use to complete unqualified names.
+	// Defaults to the domain name of the EC2 instances of the region.
+	DomainName string `json:"domainName,omitempty"`
+	// DomainNameServers are the IP addresses of the DNS servers of the instances, or AmazonProvidedDNS.
+	// Defaults to AmazonProvidedDNS.
+	DomainNameServers []string `json:"domainNameServers,omitempty"`
+	// NTPServers are the IP addresses of the NTP servers of the instances.
+	NTPServers []string `json:"ntpServers,omitempty"`
+}
+
+// TransitGatewaySpec configures the attachment of the network to an existing transit gateway.
+type TransitGatewaySpec struct {
+	// ID is the ID of the existing transit gateway.
+	ID string `json:"id,omitempty"`
+	// Subnets are the names of the cluster subnets in which the attachment is placed, at most one per zone.
+	// Defaults to one private subnet per zone, or a utility or public subnet in zones without private subnets.
+	Subnets []string `json:"subnets,omitempty"`
+	// Routes are the CIDRs that are routed to the transit gateway from the route tables managed by kOps.
+	Routes []string `json:"routes,omitempty"`
+}
+
+// VPCEndpointSpec configures an endpoint through which the network reaches an AWS service.
+type VPCEndpointSpec struct {
+	// Service is the name of the service in the region of the cluster, for example s3, ec2, ssm or ecr.api.
+	Service string `json:"service"`
+	// Type is the type of the endpoint, Gateway or Interface.
+	// Defaults to Gateway for s3 and dynamodb, and to Interface for the other services.
+	Type VPCEndpointType `json:"type,omitempty"`
+}
+
+// VPCEndpointType is the type of a VPC endpoint.
+type VPCEndpointType string
+
+const (
+	// VPCEndpointTypeGateway is an endpoint that is the target of routes in the route tables of the network.
+	VPCEndpointTypeGateway VPCEndpointType = "Gateway"
+	// VPCEndpointTypeInterface is an endpoint with network interfaces in the subnets of the network.
+	VPCEndpointTypeInterface VPCEndpointType = "Interface"
+)
+
+// VPCEndpointGatewayServices are the services that support Gateway endpoints.
+var VPCEndpointGatewayServices = []string{"s3", "dynamodb"}
+
+// EndpointType returns the type of the endpoint, defaulting to Gateway for the services that support it.
+func (e *VPCEndpointSpec) EndpointType() VPCEndpointType {
+	if e.Type != "" {
+		return e.Type
+	}
+	if slices.Contains(VPCEndpointGatewayServices, e.Service) {
+		return VPCEndpointTypeGateway
+	}
+	return VPCEndpointTypeInterface
+}
+
 // ClassicNetworkingSpec is the specification of classic networking mode, integrated into kubernetes.
 // Support been removed since Kubernetes 1.4.
 type ClassicNetworkingSpec struct{}
@@ -318,6 +385,9 @@ type AmazonVPCNetworkingSpec struct {
 	InitImage string `json:"initImage,omitempty"`
 	// Env is a list of environment variables to set in the container.
 	Env []EnvVar `json:"env,omitempty"`
+	// SecurityGroupsForPods gives the pods of each instance group their own security group,
+	// managed by kOps, and enables trunk interfaces for per-pod security groups.
+	SecurityGroupsForPods bool `json:"securityGroupsForPods,omitempty"`
 }
 
 const CiliumIpamEni = "eni"
@@ -31,6 +31,12 @@ const (
 	PathClusterCompleted = "cluster-completed.spec"
 	// PathKopsVersionUpdated is the path for the version of kops last used to apply the cluster.
 	PathKopsVersionUpdated = "kops-version.txt"
+	// PathIncrementalApply is the path for the fingerprints of the tasks of the last apply to the cluster.
+	PathIncrementalApply = "incremental-apply.json"
+	// PathFailedApply is the path for the progress of the last apply to the cluster, if it failed part-way.
+	PathFailedApply = "failed-apply.json"
+	// PathRollingUpdateCheckpoint is the path for the progress of a rolling update that paused before completing.
+	PathRollingUpdateCheckpoint = "rolling-update-checkpoint.json"
 )
 
 func ConfigBase(vfsContext *vfs.VFSContext, c *api.Cluster) (vfs.Path, error) {
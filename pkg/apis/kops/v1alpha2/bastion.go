@@ -22,6 +22,12 @@ type BastionSpec struct {
 	// +k8s:conversion-gen=false
 	IdleTimeoutSeconds *int64                   `json:"idleTimeoutSeconds,omitempty"`
 	LoadBalancer       *BastionLoadBalancerSpec `json:"loadBalancer,omitempty"`
+	// SSM configures access to instances through AWS Systems Manager Session Manager.
+	// When enabled, SSH and API access can use SSM port forwarding, so private
+	// clusters do not need a bastion instance group.
+	SSM *BastionSSMSpec `json:"ssm,omitempty"`
+	// AuditLogging configures where session activity is logged.
+	AuditLogging *BastionAuditLoggingSpec `json:"auditLogging,omitempty"`
 }
 
 type BastionLoadBalancerSpec struct {
@@ -31,3 +37,19 @@ type BastionLoadBalancerSpec struct {
 	// Type of load balancer to create, it can be Public or Internal.
 	Type LoadBalancerType `json:"type,omitempty"`
 }
+
+// BastionSSMSpec configures AWS Systems Manager Session Manager access.
+type BastionSSMSpec struct {
+	// Enabled grants instances the permissions required to register with Session Manager.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// BastionAuditLoggingSpec configures the destinations that session logs are written to.
+type BastionAuditLoggingSpec struct {
+	// S3BucketName is the name of the S3 bucket that session logs are written to.
+	S3BucketName string `json:"s3BucketName,omitempty"`
+	// S3KeyPrefix is the prefix of the S3 keys that session logs are written to.
+	S3KeyPrefix string `json:"s3KeyPrefix,omitempty"`
+	// CloudWatchLogGroupName is the name of the CloudWatch log group that session logs are written to.
+	CloudWatchLogGroupName string `json:"cloudWatchLogGroupName,omitempty"`
+}
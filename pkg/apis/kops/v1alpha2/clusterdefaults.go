@@ -0,0 +1,56 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import corev1 "k8s.io/api/core/v1"
+
+// ClusterDefaultsSpec declares the namespaces and PriorityClasses that are managed by the bootstrap channel.
+type ClusterDefaultsSpec struct {
+	// Namespaces are the namespaces to create, with their default limits and quotas.
+	// Namespaces that are removed from the list are not deleted.
+	Namespaces []ClusterDefaultsNamespaceSpec `json:"namespaces,omitempty"`
+	// PriorityClasses are the PriorityClasses to create.
+	PriorityClasses []ClusterDefaultsPriorityClassSpec `json:"priorityClasses,omitempty"`
+}
+
+// ClusterDefaultsNamespaceSpec declares a namespace, along with its LimitRange and ResourceQuota.
+type ClusterDefaultsNamespaceSpec struct {
+	// Name is the name of the namespace.
+	Name string `json:"name,omitempty"`
+	// Labels are the labels of the namespace.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are the annotations of the namespace.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// LimitRange are the default and allowed resources of the containers, pods and persistent volume claims of the namespace.
+	LimitRange []corev1.LimitRangeItem `json:"limitRange,omitempty"`
+	// ResourceQuota is the total amount of resources that the namespace can use.
+	ResourceQuota corev1.ResourceList `json:"resourceQuota,omitempty"`
+}
+
+// ClusterDefaultsPriorityClassSpec declares a PriorityClass.
+type ClusterDefaultsPriorityClassSpec struct {
+	// Name is the name of the PriorityClass.
+	Name string `json:"name,omitempty"`
+	// Value is the priority of the pods that use the PriorityClass.
+	Value int32 `json:"value,omitempty"`
+	// GlobalDefault makes the PriorityClass the default of the pods without a PriorityClass.
+	GlobalDefault bool `json:"globalDefault,omitempty"`
+	// PreemptionPolicy is either PreemptLowerPriority, the default, or Never.
+	PreemptionPolicy string `json:"preemptionPolicy,omitempty"`
+	// Description describes when the PriorityClass should be used.
+	Description string `json:"description,omitempty"`
+}
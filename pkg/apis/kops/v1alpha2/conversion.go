@@ -297,6 +297,17 @@ func Convert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *kops
 			return err
 		}
 	}
+	if in.PrivateDNSZoneVPCs != nil {
+		if out.CloudProvider.AWS == nil {
+			return field.Forbidden(field.NewPath("spec", "privateDNSZoneVPCs"), "private DNS zone VPCs supported only on AWS")
+		}
+		out.CloudProvider.AWS.PrivateDNSZoneVPCs = make([]kops.PrivateDNSZoneVPCSpec, len(in.PrivateDNSZoneVPCs))
+		for i := range in.PrivateDNSZoneVPCs {
+			if err := autoConvert_v1alpha2_PrivateDNSZoneVPCSpec_To_kops_PrivateDNSZoneVPCSpec(&in.PrivateDNSZoneVPCs[i], &out.CloudProvider.AWS.PrivateDNSZoneVPCs[i], s); err != nil {
+				return err
+			}
+		}
+	}
 	for i, hook := range in.Hooks {
 		if hook.Enabled != nil {
 			out.Hooks[i].Enabled = values.Bool(!*hook.Enabled)
@@ -484,6 +495,14 @@ func Convert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec, out
 				return err
 			}
 		}
+		if aws.PrivateDNSZoneVPCs != nil {
+			out.PrivateDNSZoneVPCs = make([]PrivateDNSZoneVPCSpec, len(aws.PrivateDNSZoneVPCs))
+			for i := range aws.PrivateDNSZoneVPCs {
+				if err := autoConvert_kops_PrivateDNSZoneVPCSpec_To_v1alpha2_PrivateDNSZoneVPCSpec(&aws.PrivateDNSZoneVPCs[i], &out.PrivateDNSZoneVPCs[i], s); err != nil {
+					return err
+				}
+			}
+		}
 	case kops.CloudProviderAzure:
 		if out.CloudConfig == nil {
 			out.CloudConfig = &CloudConfiguration{}
@@ -124,9 +124,11 @@ type InstanceGroupSpec struct {
 	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
 	// FileAssets is a collection of file assets for this instance group
 	FileAssets []FileAssetSpec `json:"fileAssets,omitempty"`
-	// Describes the tenancy of this instance group. Can be either default or dedicated.
+	// Describes the tenancy of this instance group. Can be default, dedicated or host.
 	// Currently only applies to AWS.
 	Tenancy string `json:"tenancy,omitempty"`
+	// HostResourceGroupARN is the ARN of the host resource group to launch the instances into, when the tenancy is host (AWS only).
+	HostResourceGroupARN string `json:"hostResourceGroupARN,omitempty"`
 	// Kubelet overrides kubelet config from the ClusterSpec
 	Kubelet *KubeletConfigSpec `json:"kubelet,omitempty"`
 	// Taints indicates the kubernetes taints for nodes in this instance group
@@ -139,6 +141,9 @@ type InstanceGroupSpec struct {
 	AdditionalUserData []UserData `json:"additionalUserData,omitempty"`
 	// SuspendProcesses disables the listed Scaling Policies
 	SuspendProcesses []string `json:"suspendProcesses,omitempty"`
+	// Suspended scales the instance group to zero and suspends its autoscaling processes, keeping its configuration (AWS only).
+	// Cluster validation and rolling updates skip suspended instance groups.
+	Suspended *bool `json:"suspended,omitempty"`
 	// ExternalLoadBalancers define loadbalancers that should be attached to this instance group
 	ExternalLoadBalancers []LoadBalancerSpec `json:"externalLoadBalancers,omitempty"`
 	// DetailedInstanceMonitoring defines if detailed-monitoring is enabled (AWS only)
@@ -174,16 +179,64 @@ type InstanceGroupSpec struct {
 	Containerd *ContainerdConfig `json:"containerd,omitempty"`
 	// Packages specifies additional packages to be installed.
 	Packages []string `json:"packages,omitempty"`
+	// LogRotation overrides the log rotation configuration of the cluster.
+	LogRotation *LogRotationSpec `json:"logRotation,omitempty"`
 	// GuestAccelerators configures additional accelerators
 	GuestAccelerators []AcceleratorConfig `json:"guestAccelerators,omitempty"`
 	// MaxInstanceLifetime to the maximum amount of time, in seconds, that an instance can be in service.
 	// Value expected must be in form of duration ("ms", "s", "m", "h")
 	MaxInstanceLifetime *metav1.Duration `json:"maxInstanceLifetime,omitempty"`
+	// HealthCheck configures how the autoscaling group checks the health of its instances (AWS only).
+	HealthCheck *InstanceGroupHealthCheckSpec `json:"healthCheck,omitempty"`
+	// InstanceMaintenancePolicy configures how much healthy capacity the autoscaling group keeps
+	// while it replaces unhealthy instances (AWS only).
+	InstanceMaintenancePolicy *InstanceMaintenancePolicySpec `json:"instanceMaintenancePolicy,omitempty"`
+	// DefaultInstanceWarmup is the time after a new instance enters service before its metrics
+	// are used for scaling and it counts as healthy capacity (AWS only).
+	DefaultInstanceWarmup *metav1.Duration `json:"defaultInstanceWarmup,omitempty"`
+	// ScalingPolicies are target tracking or step scaling policies of the autoscaling group,
+	// for clusters that don't run an autoscaler (AWS only).
+	ScalingPolicies []ScalingPolicySpec `json:"scalingPolicies,omitempty"`
+	// StaticNetworkInterface attaches a network interface with a stable private IP address
+	// to the control-plane instance of this group, so that the address survives instance replacement (AWS only).
+	StaticNetworkInterface *StaticNetworkInterfaceSpec `json:"staticNetworkInterface,omitempty"`
+	// ConfidentialCompute enables confidential computing features on the instances of this group (AWS only).
+	ConfidentialCompute *ConfidentialComputeSpec `json:"confidentialCompute,omitempty"`
+	// EFA attaches Elastic Fabric Adapter interfaces to the instances of this group,
+	// for HPC and machine learning workloads that use NCCL or MPI (AWS only).
+	EFA *EFASpec `json:"efa,omitempty"`
+	// AdditionalNetworkInterfaces are network interfaces attached to the instances of this group
+	// in addition to the primary interface, in other subnets of the cluster (AWS only).
+	AdditionalNetworkInterfaces []AdditionalNetworkInterfaceSpec `json:"additionalNetworkInterfaces,omitempty"`
 	// GCPProvisioningModel: Specifies the provisioning model of the GCP instance.
 	// Valid values:
 	//   'STANDARD': (default) standard provisioning with user controlled run time, no discounts
 	//   'SPOT': heavily discounted, no guaranteed run time.
 	GCPProvisioningModel *string `json:"gcpProvisioningModel,omitempty"`
+	// Azure configures the VM Scale Set of this group (Azure only).
+	Azure *AzureInstanceGroupSpec `json:"azure,omitempty"`
+	// Hetzner configures the servers of this group (Hetzner only).
+	Hetzner *HetznerInstanceGroupSpec `json:"hetzner,omitempty"`
+}
+
+// AzureInstanceGroupSpec configures the VM Scale Set of an instance group on Azure.
+type AzureInstanceGroupSpec struct {
+	// OrchestrationMode is the orchestration mode of the VM Scale Set, either Uniform (default) or Flexible.
+	// It cannot be changed once the VM Scale Set is created.
+	OrchestrationMode string `json:"orchestrationMode,omitempty"`
+	// Priority is the priority of the VMs, either Regular or Spot.
+	// Defaults to Spot when spec.maxPrice is set, and to Regular otherwise.
+	Priority string `json:"priority,omitempty"`
+	// EvictionPolicy is what happens to Spot VMs when they are evicted, either Delete (default) or Deallocate.
+	EvictionPolicy string `json:"evictionPolicy,omitempty"`
+}
+
+// HetznerInstanceGroupSpec configures the servers of an instance group on Hetzner.
+type HetznerInstanceGroupSpec struct {
+	// PlacementGroup is the name of a spread placement group for the servers of this group, so that they run on different hosts.
+	// Instance groups with the same placement group share it, which is useful for the control plane instance groups.
+	// A spread placement group can hold at most 10 servers. Servers are only added to it when they are created.
+	PlacementGroup string `json:"placementGroup,omitempty"`
 }
 
 // InstanceMetadataOptions defines the EC2 instance metadata service options (AWS Only)
@@ -225,6 +278,12 @@ type MixedInstancesPolicySpec struct {
 type InstanceRequirementsSpec struct {
 	CPU    *MinMaxSpec `json:"cpu,omitempty"`
 	Memory *MinMaxSpec `json:"memory,omitempty"`
+	// Architectures are the CPU architectures of the instance types, amd64 or arm64.
+	// Defaults to the architecture of the machine type of the instance group.
+	Architectures []string `json:"architectures,omitempty"`
+	// ExcludedInstanceTypes are the instance types that must not be used, such as "t3.micro".
+	// Whole families are excluded with wildcards, such as "t2.*" or "*6g.*".
+	ExcludedInstanceTypes []string `json:"excludedInstanceTypes,omitempty"`
 }
 
 type MinMaxSpec struct {
@@ -277,6 +336,115 @@ type VolumeMountSpec struct {
 	Path string `json:"path,omitempty"`
 }
 
+// InstanceGroupHealthCheckSpec configures the health checks of an autoscaling group.
+type InstanceGroupHealthCheckSpec struct {
+	// Type is the type of health check: EC2, or ELB to also replace instances failing their load balancer health checks.
+	Type string `json:"type,omitempty"`
+	// GracePeriod is the time after an instance enters service before its health is checked.
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// InstanceMaintenancePolicySpec configures the healthy capacity kept by an autoscaling group while it replaces instances.
+type InstanceMaintenancePolicySpec struct {
+	// MinHealthyPercentage is the percentage of the desired capacity that must stay healthy, from 0 to 100.
+	MinHealthyPercentage *int32 `json:"minHealthyPercentage,omitempty"`
+	// MaxHealthyPercentage is the percentage of the desired capacity that can be running, from 100 to 200.
+	MaxHealthyPercentage *int32 `json:"maxHealthyPercentage,omitempty"`
+}
+
+// ScalingPolicySpec is a scaling policy of the autoscaling group of an instance group.
+// Exactly one of TargetTracking and Step must be set.
+type ScalingPolicySpec struct {
+	// Name is the name of the policy. It must be unique within the instance group.
+	Name string `json:"name,omitempty"`
+	// TargetTracking adjusts the capacity to keep a metric at a target value.
+	TargetTracking *TargetTrackingScalingPolicySpec `json:"targetTracking,omitempty"`
+	// Step adjusts the capacity in steps when a CloudWatch alarm invokes the policy.
+	Step *StepScalingPolicySpec `json:"step,omitempty"`
+}
+
+// TargetTrackingScalingPolicySpec keeps a predefined metric of the autoscaling group at a target value.
+type TargetTrackingScalingPolicySpec struct {
+	// PredefinedMetric is the metric to track:
+	// ASGAverageCPUUtilization, ASGAverageNetworkIn or ASGAverageNetworkOut.
+	PredefinedMetric string `json:"predefinedMetric,omitempty"`
+	// TargetValue is the value to keep the metric at, e.g. 60 for 60% CPU utilization.
+	TargetValue float64 `json:"targetValue,omitempty"`
+	// DisableScaleIn stops the policy from removing capacity.
+	DisableScaleIn *bool `json:"disableScaleIn,omitempty"`
+}
+
+// StepScalingPolicySpec adjusts the capacity of the autoscaling group in steps.
+type StepScalingPolicySpec struct {
+	// AdjustmentType is how Adjustment is applied:
+	// ChangeInCapacity, ExactCapacity or PercentChangeInCapacity.
+	AdjustmentType string `json:"adjustmentType,omitempty"`
+	// Steps are the adjustments, chosen by how far the metric is from the alarm threshold.
+	Steps []ScalingStepSpec `json:"steps,omitempty"`
+	// EstimatedInstanceWarmup is the time until a new instance contributes to the metric.
+	EstimatedInstanceWarmup *metav1.Duration `json:"estimatedInstanceWarmup,omitempty"`
+}
+
+// ScalingStepSpec is a single step of a step scaling policy.
+type ScalingStepSpec struct {
+	// LowerBound is the inclusive lower bound of the difference between the metric and the
+	// alarm threshold. If not set, the step has no lower bound.
+	LowerBound *float64 `json:"lowerBound,omitempty"`
+	// UpperBound is the exclusive upper bound of the difference between the metric and the
+	// alarm threshold. If not set, the step has no upper bound.
+	UpperBound *float64 `json:"upperBound,omitempty"`
+	// Adjustment is the amount by which to scale, interpreted according to AdjustmentType.
+	Adjustment int32 `json:"adjustment"`
+}
+
+// StaticNetworkInterfaceSpec configures the network interface that is attached to the instance of a control-plane group.
+type StaticNetworkInterfaceSpec struct {
+	// ID is the ID of an existing network interface to attach.
+	// If not set, kOps creates and manages the network interface.
+	ID string `json:"id,omitempty"`
+	// PrivateIPAddress is the private IPv4 address of the network interface created by kOps.
+	// If not set, an address of the subnet is picked when the network interface is created.
+	PrivateIPAddress string `json:"privateIPAddress,omitempty"`
+}
+
+// ConfidentialComputeSpec configures the confidential computing features of instances.
+type ConfidentialComputeSpec struct {
+	// NitroEnclaves enables AWS Nitro Enclaves and configures the resources reserved for them.
+	NitroEnclaves *NitroEnclavesSpec `json:"nitroEnclaves,omitempty"`
+	// AMDSevSnp enables AMD SEV-SNP, which encrypts the memory of the instances.
+	// It is only supported on some AMD instance types.
+	AMDSevSnp *bool `json:"amdSevSnp,omitempty"`
+}
+
+// NitroEnclavesSpec configures the resources that the Nitro Enclaves allocator reserves for enclaves.
+type NitroEnclavesSpec struct {
+	// CPUCount is the number of vCPUs reserved for enclaves. Defaults to 2.
+	CPUCount *int32 `json:"cpuCount,omitempty"`
+	// MemoryMiB is the memory reserved for enclaves, in MiB. Defaults to 512.
+	MemoryMiB *int32 `json:"memoryMiB,omitempty"`
+}
+
+// EFASpec configures the Elastic Fabric Adapter interfaces of instances.
+type EFASpec struct {
+	// InterfaceCount is the number of EFA interfaces to attach, one on each network card of the instance.
+	// Defaults to 1.
+	InterfaceCount *int32 `json:"interfaceCount,omitempty"`
+	// HugePages is the number of 2 MiB huge pages reserved for EFA. Defaults to 5128.
+	HugePages *int32 `json:"hugePages,omitempty"`
+}
+
+// AdditionalNetworkInterfaceSpec configures a network interface attached to instances in addition to the primary interface.
+type AdditionalNetworkInterfaceSpec struct {
+	// DeviceIndex is the device index of the interface on the instances. Must be 1 or greater.
+	DeviceIndex int32 `json:"deviceIndex"`
+	// Subnet is the name of the cluster subnet of the interface.
+	// It must be in the same zone as the subnets of the instance group.
+	Subnet string `json:"subnet"`
+	// SecurityGroups are the IDs of the security groups of the interface.
+	// Defaults to the security groups of the primary interface.
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+}
+
 // IAMProfileSpec is the AWS IAM Profile to attach to instances in this instance
 // group. Specify the ARN for the IAM instance profile (AWS only).
 type IAMProfileSpec struct {
@@ -0,0 +1,31 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import "k8s.io/apimachinery/pkg/api/resource"
+
+// LogRotationSpec configures the rotation of the logs of the nodes, to protect them from disk pressure.
+type LogRotationSpec struct {
+	// MaxSize is the size above which the log files in /var/log, such as the ones of kube-apiserver and etcd,
+	// and the audit log of kube-apiserver are rotated. Defaults to 100Mi.
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+	// MaxFiles is the number of rotated files kept for each log file. Defaults to 5.
+	MaxFiles *int32 `json:"maxFiles,omitempty"`
+	// JournalMaxUse is the maximum disk space used by the systemd journal, which holds the logs of kubelet and containerd.
+	// Defaults to the limit of the distribution, usually 10% of the filesystem and at most 4G.
+	JournalMaxUse *resource.Quantity `json:"journalMaxUse,omitempty"`
+}
@@ -33,6 +33,9 @@ type NetworkingSpec struct {
 	PodCIDR                string              `json:"-"`
 	ServiceClusterIPRange  string              `json:"-"`
 	IsolateControlPlane    *bool               `json:"-"`
+	DHCPOptions            *DHCPOptionsSpec    `json:"dhcpOptions,omitempty"`
+	TransitGateway         *TransitGatewaySpec `json:"transitGateway,omitempty"`
+	VPCEndpoints           []VPCEndpointSpec   `json:"vpcEndpoints,omitempty"`
 
 	Classic    *ClassicNetworkingSpec    `json:"classic,omitempty"`
 	Kubenet    *KubenetNetworkingSpec    `json:"kubenet,omitempty"`
@@ -54,9 +57,45 @@ type NetworkingSpec struct {
 func (s *NetworkingSpec) IsEmpty() bool {
 	return s.Classic == nil && s.Kubenet == nil && s.External == nil && s.CNI == nil && s.Kopeio == nil &&
 		s.Weave == nil && s.Flannel == nil && s.Calico == nil && s.Canal == nil && s.KubeRouter == nil &&
-		s.Romana == nil && s.AmazonVPC == nil && s.Cilium == nil && s.LyftVPC == nil && s.GCP == nil
+		s.Romana == nil && s.AmazonVPC == nil && s.Cilium == nil && s.LyftVPC == nil && s.GCP == nil &&
+		s.DHCPOptions == nil && s.TransitGateway == nil && len(s.VPCEndpoints) == 0
 }
 
+// DHCPOptionsSpec configures the DHCP options set of the network created by kOps.
+type DHCPOptionsSpec struct {
+	// DomainName is the domain name that instances use to complete unqualified names.
+	// Defaults to the domain name of the EC2 instances of the region.
+	DomainName string `json:"domainName,omitempty"`
+	// DomainNameServers are the IP addresses of the DNS servers of the instances, or AmazonProvidedDNS.
+	// Defaults to AmazonProvidedDNS.
+	DomainNameServers []string `json:"domainNameServers,omitempty"`
+	// NTPServers are the IP addresses of the NTP servers of the instances.
+	NTPServers []string `json:"ntpServers,omitempty"`
+}
+
+// TransitGatewaySpec configures the attachment of the network to an existing transit gateway.
+type TransitGatewaySpec struct {
+	// ID is the ID of the existing transit gateway.
+	ID string `json:"id,omitempty"`
+	// Subnets are the names of the cluster subnets in which the attachment is placed, at most one per zone.
+	// Defaults to one private subnet per zone, or a utility or public subnet in zones without private subnets.
+	Subnets []string `json:"subnets,omitempty"`
+	// Routes are the CIDRs that are routed to the transit gateway from the route tables managed by kOps.
+	Routes []string `json:"routes,omitempty"`
+}
+
+// VPCEndpointSpec configures an endpoint through which the network reaches an AWS service.
+type VPCEndpointSpec struct {
+	// Service is the name of the service in the region of the cluster, for example s3, ec2, ssm or ecr.api.
+	Service string `json:"service"`
+	// Type is the type of the endpoint, Gateway or Interface.
+	// Defaults to Gateway for s3 and dynamodb, and to Interface for the other services.
+	Type VPCEndpointType `json:"type,omitempty"`
+}
+
+// VPCEndpointType is the type of a VPC endpoint.
+type VPCEndpointType string
+
 // ClassicNetworkingSpec is the specification of classic networking mode, integrated into kubernetes.
 // Support been removed since Kubernetes 1.4.
 type ClassicNetworkingSpec struct{}
@@ -283,6 +322,9 @@ type AmazonVPCNetworkingSpec struct {
 	InitImage string `json:"initImageName,omitempty"`
 	// Env is a list of environment variables to set in the container.
 	Env []EnvVar `json:"env,omitempty"`
+	// SecurityGroupsForPods gives the pods of each instance group their own security group,
+	// managed by kOps, and enables trunk interfaces for per-pod security groups.
+	SecurityGroupsForPods bool `json:"securityGroupsForPods,omitempty"`
 }
 
 const CiliumIpamEni = "eni"
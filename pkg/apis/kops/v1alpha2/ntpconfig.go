@@ -21,4 +21,8 @@ type NTPConfig struct {
 	// Managed controls if the NTP configuration is managed by kOps.
 	// The NTP configuration task is skipped if this is set to false.
 	Managed *bool `json:"managed,omitempty"`
+	// Servers are the NTP servers to synchronize with.
+	// Defaults to the NTP service of the cloud provider, such as 169.254.169.123 on AWS
+	// and metadata.google.internal on GCE.
+	Servers []string `json:"servers,omitempty"`
 }
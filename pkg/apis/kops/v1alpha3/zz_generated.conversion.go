@@ -22,6 +22,9 @@ limitations under the License.
 package v1alpha3
 
 import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	kops "k8s.io/kops/pkg/apis/kops"
@@ -34,6 +37,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ACMCertificateSpec)(nil), (*kops.ACMCertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ACMCertificateSpec_To_kops_ACMCertificateSpec(a.(*ACMCertificateSpec), b.(*kops.ACMCertificateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ACMCertificateSpec)(nil), (*ACMCertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ACMCertificateSpec_To_v1alpha3_ACMCertificateSpec(a.(*kops.ACMCertificateSpec), b.(*ACMCertificateSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*APISpec)(nil), (*kops.APISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_APISpec_To_kops_APISpec(a.(*APISpec), b.(*kops.APISpec), scope)
 	}); err != nil {
@@ -104,6 +117,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AdditionalNetworkInterfaceSpec)(nil), (*kops.AdditionalNetworkInterfaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AdditionalNetworkInterfaceSpec_To_kops_AdditionalNetworkInterfaceSpec(a.(*AdditionalNetworkInterfaceSpec), b.(*kops.AdditionalNetworkInterfaceSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AdditionalNetworkInterfaceSpec)(nil), (*AdditionalNetworkInterfaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AdditionalNetworkInterfaceSpec_To_v1alpha3_AdditionalNetworkInterfaceSpec(a.(*kops.AdditionalNetworkInterfaceSpec), b.(*AdditionalNetworkInterfaceSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AddonSpec)(nil), (*kops.AddonSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AddonSpec_To_kops_AddonSpec(a.(*AddonSpec), b.(*kops.AddonSpec), scope)
 	}); err != nil {
@@ -164,6 +187,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AzureInstanceGroupSpec)(nil), (*kops.AzureInstanceGroupSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AzureInstanceGroupSpec_To_kops_AzureInstanceGroupSpec(a.(*AzureInstanceGroupSpec), b.(*kops.AzureInstanceGroupSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AzureInstanceGroupSpec)(nil), (*AzureInstanceGroupSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AzureInstanceGroupSpec_To_v1alpha3_AzureInstanceGroupSpec(a.(*kops.AzureInstanceGroupSpec), b.(*AzureInstanceGroupSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AzureSpec)(nil), (*kops.AzureSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AzureSpec_To_kops_AzureSpec(a.(*AzureSpec), b.(*kops.AzureSpec), scope)
 	}); err != nil {
@@ -174,6 +207,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BastionAuditLoggingSpec)(nil), (*kops.BastionAuditLoggingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_BastionAuditLoggingSpec_To_kops_BastionAuditLoggingSpec(a.(*BastionAuditLoggingSpec), b.(*kops.BastionAuditLoggingSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.BastionAuditLoggingSpec)(nil), (*BastionAuditLoggingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_BastionAuditLoggingSpec_To_v1alpha3_BastionAuditLoggingSpec(a.(*kops.BastionAuditLoggingSpec), b.(*BastionAuditLoggingSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*BastionLoadBalancerSpec)(nil), (*kops.BastionLoadBalancerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_BastionLoadBalancerSpec_To_kops_BastionLoadBalancerSpec(a.(*BastionLoadBalancerSpec), b.(*kops.BastionLoadBalancerSpec), scope)
 	}); err != nil {
@@ -184,6 +227,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*BastionSSMSpec)(nil), (*kops.BastionSSMSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_BastionSSMSpec_To_kops_BastionSSMSpec(a.(*BastionSSMSpec), b.(*kops.BastionSSMSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.BastionSSMSpec)(nil), (*BastionSSMSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_BastionSSMSpec_To_v1alpha3_BastionSSMSpec(a.(*kops.BastionSSMSpec), b.(*BastionSSMSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*BastionSpec)(nil), (*kops.BastionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_BastionSpec_To_kops_BastionSpec(a.(*BastionSpec), b.(*kops.BastionSpec), scope)
 	}); err != nil {
@@ -274,6 +327,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudIdentityAuthenticationSpec)(nil), (*kops.CloudIdentityAuthenticationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CloudIdentityAuthenticationSpec_To_kops_CloudIdentityAuthenticationSpec(a.(*CloudIdentityAuthenticationSpec), b.(*kops.CloudIdentityAuthenticationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CloudIdentityAuthenticationSpec)(nil), (*CloudIdentityAuthenticationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CloudIdentityAuthenticationSpec_To_v1alpha3_CloudIdentityAuthenticationSpec(a.(*kops.CloudIdentityAuthenticationSpec), b.(*CloudIdentityAuthenticationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CloudProviderSpec)(nil), (*kops.CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CloudProviderSpec_To_kops_CloudProviderSpec(a.(*CloudProviderSpec), b.(*kops.CloudProviderSpec), scope)
 	}); err != nil {
@@ -304,6 +367,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterDefaultsNamespaceSpec)(nil), (*kops.ClusterDefaultsNamespaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClusterDefaultsNamespaceSpec_To_kops_ClusterDefaultsNamespaceSpec(a.(*ClusterDefaultsNamespaceSpec), b.(*kops.ClusterDefaultsNamespaceSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ClusterDefaultsNamespaceSpec)(nil), (*ClusterDefaultsNamespaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ClusterDefaultsNamespaceSpec_To_v1alpha3_ClusterDefaultsNamespaceSpec(a.(*kops.ClusterDefaultsNamespaceSpec), b.(*ClusterDefaultsNamespaceSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterDefaultsPriorityClassSpec)(nil), (*kops.ClusterDefaultsPriorityClassSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClusterDefaultsPriorityClassSpec_To_kops_ClusterDefaultsPriorityClassSpec(a.(*ClusterDefaultsPriorityClassSpec), b.(*kops.ClusterDefaultsPriorityClassSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ClusterDefaultsPriorityClassSpec)(nil), (*ClusterDefaultsPriorityClassSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ClusterDefaultsPriorityClassSpec_To_v1alpha3_ClusterDefaultsPriorityClassSpec(a.(*kops.ClusterDefaultsPriorityClassSpec), b.(*ClusterDefaultsPriorityClassSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterDefaultsSpec)(nil), (*kops.ClusterDefaultsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClusterDefaultsSpec_To_kops_ClusterDefaultsSpec(a.(*ClusterDefaultsSpec), b.(*kops.ClusterDefaultsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ClusterDefaultsSpec)(nil), (*ClusterDefaultsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ClusterDefaultsSpec_To_v1alpha3_ClusterDefaultsSpec(a.(*kops.ClusterDefaultsSpec), b.(*ClusterDefaultsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClusterList)(nil), (*kops.ClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ClusterList_To_kops_ClusterList(a.(*ClusterList), b.(*kops.ClusterList), scope)
 	}); err != nil {
@@ -334,6 +427,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ConfidentialComputeSpec)(nil), (*kops.ConfidentialComputeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ConfidentialComputeSpec_To_kops_ConfidentialComputeSpec(a.(*ConfidentialComputeSpec), b.(*kops.ConfidentialComputeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ConfidentialComputeSpec)(nil), (*ConfidentialComputeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ConfidentialComputeSpec_To_v1alpha3_ConfidentialComputeSpec(a.(*kops.ConfidentialComputeSpec), b.(*ConfidentialComputeSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ConfigStoreSpec)(nil), (*kops.ConfigStoreSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ConfigStoreSpec_To_kops_ConfigStoreSpec(a.(*ConfigStoreSpec), b.(*kops.ConfigStoreSpec), scope)
 	}); err != nil {
@@ -364,6 +467,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DHCPOptionsSpec)(nil), (*kops.DHCPOptionsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_DHCPOptionsSpec_To_kops_DHCPOptionsSpec(a.(*DHCPOptionsSpec), b.(*kops.DHCPOptionsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.DHCPOptionsSpec)(nil), (*DHCPOptionsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_DHCPOptionsSpec_To_v1alpha3_DHCPOptionsSpec(a.(*kops.DHCPOptionsSpec), b.(*DHCPOptionsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSAccessSpec)(nil), (*kops.DNSAccessSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DNSAccessSpec_To_kops_DNSAccessSpec(a.(*DNSAccessSpec), b.(*kops.DNSAccessSpec), scope)
 	}); err != nil {
@@ -424,6 +537,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EFASpec)(nil), (*kops.EFASpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EFASpec_To_kops_EFASpec(a.(*EFASpec), b.(*kops.EFASpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EFASpec)(nil), (*EFASpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EFASpec_To_v1alpha3_EFASpec(a.(*kops.EFASpec), b.(*EFASpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*EgressProxySpec)(nil), (*kops.EgressProxySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_EgressProxySpec_To_kops_EgressProxySpec(a.(*EgressProxySpec), b.(*kops.EgressProxySpec), scope)
 	}); err != nil {
@@ -464,6 +587,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EtcdExternalSpec)(nil), (*kops.EtcdExternalSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdExternalSpec_To_kops_EtcdExternalSpec(a.(*EtcdExternalSpec), b.(*kops.EtcdExternalSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EtcdExternalSpec)(nil), (*EtcdExternalSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EtcdExternalSpec_To_v1alpha3_EtcdExternalSpec(a.(*kops.EtcdExternalSpec), b.(*EtcdExternalSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EtcdGRPCSpec)(nil), (*kops.EtcdGRPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdGRPCSpec_To_kops_EtcdGRPCSpec(a.(*EtcdGRPCSpec), b.(*kops.EtcdGRPCSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EtcdGRPCSpec)(nil), (*EtcdGRPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EtcdGRPCSpec_To_v1alpha3_EtcdGRPCSpec(a.(*kops.EtcdGRPCSpec), b.(*EtcdGRPCSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*EtcdManagerSpec)(nil), (*kops.EtcdManagerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_EtcdManagerSpec_To_kops_EtcdManagerSpec(a.(*EtcdManagerSpec), b.(*kops.EtcdManagerSpec), scope)
 	}); err != nil {
@@ -484,6 +627,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EtcdTLSSpec)(nil), (*kops.EtcdTLSSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdTLSSpec_To_kops_EtcdTLSSpec(a.(*EtcdTLSSpec), b.(*kops.EtcdTLSSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EtcdTLSSpec)(nil), (*EtcdTLSSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EtcdTLSSpec_To_v1alpha3_EtcdTLSSpec(a.(*kops.EtcdTLSSpec), b.(*EtcdTLSSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EventExporterCloudWatchSpec)(nil), (*kops.EventExporterCloudWatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EventExporterCloudWatchSpec_To_kops_EventExporterCloudWatchSpec(a.(*EventExporterCloudWatchSpec), b.(*kops.EventExporterCloudWatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EventExporterCloudWatchSpec)(nil), (*EventExporterCloudWatchSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EventExporterCloudWatchSpec_To_v1alpha3_EventExporterCloudWatchSpec(a.(*kops.EventExporterCloudWatchSpec), b.(*EventExporterCloudWatchSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EventExporterConfig)(nil), (*kops.EventExporterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EventExporterConfig_To_kops_EventExporterConfig(a.(*EventExporterConfig), b.(*kops.EventExporterConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EventExporterConfig)(nil), (*EventExporterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EventExporterConfig_To_v1alpha3_EventExporterConfig(a.(*kops.EventExporterConfig), b.(*EventExporterConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EventExporterLokiSpec)(nil), (*kops.EventExporterLokiSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EventExporterLokiSpec_To_kops_EventExporterLokiSpec(a.(*EventExporterLokiSpec), b.(*kops.EventExporterLokiSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EventExporterLokiSpec)(nil), (*EventExporterLokiSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EventExporterLokiSpec_To_v1alpha3_EventExporterLokiSpec(a.(*kops.EventExporterLokiSpec), b.(*EventExporterLokiSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ExecContainerAction)(nil), (*kops.ExecContainerAction)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ExecContainerAction_To_kops_ExecContainerAction(a.(*ExecContainerAction), b.(*kops.ExecContainerAction), scope)
 	}); err != nil {
@@ -514,6 +697,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ExternalTaskSpec)(nil), (*kops.ExternalTaskSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ExternalTaskSpec_To_kops_ExternalTaskSpec(a.(*ExternalTaskSpec), b.(*kops.ExternalTaskSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ExternalTaskSpec)(nil), (*ExternalTaskSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ExternalTaskSpec_To_v1alpha3_ExternalTaskSpec(a.(*kops.ExternalTaskSpec), b.(*ExternalTaskSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FileAssetSpec)(nil), (*kops.FileAssetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_FileAssetSpec_To_kops_FileAssetSpec(a.(*FileAssetSpec), b.(*kops.FileAssetSpec), scope)
 	}); err != nil {
@@ -584,6 +777,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HetznerInstanceGroupSpec)(nil), (*kops.HetznerInstanceGroupSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_HetznerInstanceGroupSpec_To_kops_HetznerInstanceGroupSpec(a.(*HetznerInstanceGroupSpec), b.(*kops.HetznerInstanceGroupSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.HetznerInstanceGroupSpec)(nil), (*HetznerInstanceGroupSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_HetznerInstanceGroupSpec_To_v1alpha3_HetznerInstanceGroupSpec(a.(*kops.HetznerInstanceGroupSpec), b.(*HetznerInstanceGroupSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*HetznerSpec)(nil), (*kops.HetznerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_HetznerSpec_To_kops_HetznerSpec(a.(*HetznerSpec), b.(*kops.HetznerSpec), scope)
 	}); err != nil {
@@ -644,6 +847,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InstanceGroupHealthCheckSpec)(nil), (*kops.InstanceGroupHealthCheckSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_InstanceGroupHealthCheckSpec_To_kops_InstanceGroupHealthCheckSpec(a.(*InstanceGroupHealthCheckSpec), b.(*kops.InstanceGroupHealthCheckSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.InstanceGroupHealthCheckSpec)(nil), (*InstanceGroupHealthCheckSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_InstanceGroupHealthCheckSpec_To_v1alpha3_InstanceGroupHealthCheckSpec(a.(*kops.InstanceGroupHealthCheckSpec), b.(*InstanceGroupHealthCheckSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceGroupList)(nil), (*kops.InstanceGroupList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_InstanceGroupList_To_kops_InstanceGroupList(a.(*InstanceGroupList), b.(*kops.InstanceGroupList), scope)
 	}); err != nil {
@@ -664,6 +877,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InstanceMaintenancePolicySpec)(nil), (*kops.InstanceMaintenancePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(a.(*InstanceMaintenancePolicySpec), b.(*kops.InstanceMaintenancePolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.InstanceMaintenancePolicySpec)(nil), (*InstanceMaintenancePolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_InstanceMaintenancePolicySpec_To_v1alpha3_InstanceMaintenancePolicySpec(a.(*kops.InstanceMaintenancePolicySpec), b.(*InstanceMaintenancePolicySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceMetadataOptions)(nil), (*kops.InstanceMetadataOptions)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_InstanceMetadataOptions_To_kops_InstanceMetadataOptions(a.(*InstanceMetadataOptions), b.(*kops.InstanceMetadataOptions), scope)
 	}); err != nil {
@@ -694,6 +917,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InternalLoadBalancerAccessSpec)(nil), (*kops.InternalLoadBalancerAccessSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_InternalLoadBalancerAccessSpec_To_kops_InternalLoadBalancerAccessSpec(a.(*InternalLoadBalancerAccessSpec), b.(*kops.InternalLoadBalancerAccessSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.InternalLoadBalancerAccessSpec)(nil), (*InternalLoadBalancerAccessSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_InternalLoadBalancerAccessSpec_To_v1alpha3_InternalLoadBalancerAccessSpec(a.(*kops.InternalLoadBalancerAccessSpec), b.(*InternalLoadBalancerAccessSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KarpenterConfig)(nil), (*kops.KarpenterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_KarpenterConfig_To_kops_KarpenterConfig(a.(*KarpenterConfig), b.(*kops.KarpenterConfig), scope)
 	}); err != nil {
@@ -894,6 +1127,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LogRotationSpec)(nil), (*kops.LogRotationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_LogRotationSpec_To_kops_LogRotationSpec(a.(*LogRotationSpec), b.(*kops.LogRotationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.LogRotationSpec)(nil), (*LogRotationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_LogRotationSpec_To_v1alpha3_LogRotationSpec(a.(*kops.LogRotationSpec), b.(*LogRotationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MetricsServerConfig)(nil), (*kops.MetricsServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_MetricsServerConfig_To_kops_MetricsServerConfig(a.(*MetricsServerConfig), b.(*kops.MetricsServerConfig), scope)
 	}); err != nil {
@@ -954,6 +1197,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NitroEnclavesSpec)(nil), (*kops.NitroEnclavesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NitroEnclavesSpec_To_kops_NitroEnclavesSpec(a.(*NitroEnclavesSpec), b.(*kops.NitroEnclavesSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NitroEnclavesSpec)(nil), (*NitroEnclavesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NitroEnclavesSpec_To_v1alpha3_NitroEnclavesSpec(a.(*kops.NitroEnclavesSpec), b.(*NitroEnclavesSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NodeLocalDNSConfig)(nil), (*kops.NodeLocalDNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_NodeLocalDNSConfig_To_kops_NodeLocalDNSConfig(a.(*NodeLocalDNSConfig), b.(*kops.NodeLocalDNSConfig), scope)
 	}); err != nil {
@@ -1014,6 +1267,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OpenstackL7Policy)(nil), (*kops.OpenstackL7Policy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_OpenstackL7Policy_To_kops_OpenstackL7Policy(a.(*OpenstackL7Policy), b.(*kops.OpenstackL7Policy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.OpenstackL7Policy)(nil), (*OpenstackL7Policy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_OpenstackL7Policy_To_v1alpha3_OpenstackL7Policy(a.(*kops.OpenstackL7Policy), b.(*OpenstackL7Policy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OpenstackL7Rule)(nil), (*kops.OpenstackL7Rule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_OpenstackL7Rule_To_kops_OpenstackL7Rule(a.(*OpenstackL7Rule), b.(*kops.OpenstackL7Rule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.OpenstackL7Rule)(nil), (*OpenstackL7Rule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_OpenstackL7Rule_To_v1alpha3_OpenstackL7Rule(a.(*kops.OpenstackL7Rule), b.(*OpenstackL7Rule), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*OpenstackLoadbalancerConfig)(nil), (*kops.OpenstackLoadbalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig(a.(*OpenstackLoadbalancerConfig), b.(*kops.OpenstackLoadbalancerConfig), scope)
 	}); err != nil {
@@ -1104,6 +1377,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PrivateDNSNameOptionsSpec)(nil), (*kops.PrivateDNSNameOptionsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PrivateDNSNameOptionsSpec_To_kops_PrivateDNSNameOptionsSpec(a.(*PrivateDNSNameOptionsSpec), b.(*kops.PrivateDNSNameOptionsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.PrivateDNSNameOptionsSpec)(nil), (*PrivateDNSNameOptionsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_PrivateDNSNameOptionsSpec_To_v1alpha3_PrivateDNSNameOptionsSpec(a.(*kops.PrivateDNSNameOptionsSpec), b.(*PrivateDNSNameOptionsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrivateDNSZoneVPCSpec)(nil), (*kops.PrivateDNSZoneVPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PrivateDNSZoneVPCSpec_To_kops_PrivateDNSZoneVPCSpec(a.(*PrivateDNSZoneVPCSpec), b.(*kops.PrivateDNSZoneVPCSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.PrivateDNSZoneVPCSpec)(nil), (*PrivateDNSZoneVPCSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_PrivateDNSZoneVPCSpec_To_v1alpha3_PrivateDNSZoneVPCSpec(a.(*kops.PrivateDNSZoneVPCSpec), b.(*PrivateDNSZoneVPCSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrometheusAgentConfig)(nil), (*kops.PrometheusAgentConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PrometheusAgentConfig_To_kops_PrometheusAgentConfig(a.(*PrometheusAgentConfig), b.(*kops.PrometheusAgentConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.PrometheusAgentConfig)(nil), (*PrometheusAgentConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_PrometheusAgentConfig_To_v1alpha3_PrometheusAgentConfig(a.(*kops.PrometheusAgentConfig), b.(*PrometheusAgentConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrometheusRemoteWriteSpec)(nil), (*kops.PrometheusRemoteWriteSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PrometheusRemoteWriteSpec_To_kops_PrometheusRemoteWriteSpec(a.(*PrometheusRemoteWriteSpec), b.(*kops.PrometheusRemoteWriteSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.PrometheusRemoteWriteSpec)(nil), (*PrometheusRemoteWriteSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_PrometheusRemoteWriteSpec_To_v1alpha3_PrometheusRemoteWriteSpec(a.(*kops.PrometheusRemoteWriteSpec), b.(*PrometheusRemoteWriteSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrometheusSigV4Spec)(nil), (*kops.PrometheusSigV4Spec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_PrometheusSigV4Spec_To_kops_PrometheusSigV4Spec(a.(*PrometheusSigV4Spec), b.(*kops.PrometheusSigV4Spec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.PrometheusSigV4Spec)(nil), (*PrometheusSigV4Spec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_PrometheusSigV4Spec_To_v1alpha3_PrometheusSigV4Spec(a.(*kops.PrometheusSigV4Spec), b.(*PrometheusSigV4Spec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RBACAuthorizationSpec)(nil), (*kops.RBACAuthorizationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_RBACAuthorizationSpec_To_kops_RBACAuthorizationSpec(a.(*RBACAuthorizationSpec), b.(*kops.RBACAuthorizationSpec), scope)
 	}); err != nil {
@@ -1124,6 +1447,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RollingUpdateCanary)(nil), (*kops.RollingUpdateCanary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RollingUpdateCanary_To_kops_RollingUpdateCanary(a.(*RollingUpdateCanary), b.(*kops.RollingUpdateCanary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.RollingUpdateCanary)(nil), (*RollingUpdateCanary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_RollingUpdateCanary_To_v1alpha3_RollingUpdateCanary(a.(*kops.RollingUpdateCanary), b.(*RollingUpdateCanary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RollingUpdateCanaryJob)(nil), (*kops.RollingUpdateCanaryJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RollingUpdateCanaryJob_To_kops_RollingUpdateCanaryJob(a.(*RollingUpdateCanaryJob), b.(*kops.RollingUpdateCanaryJob), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.RollingUpdateCanaryJob)(nil), (*RollingUpdateCanaryJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_RollingUpdateCanaryJob_To_v1alpha3_RollingUpdateCanaryJob(a.(*kops.RollingUpdateCanaryJob), b.(*RollingUpdateCanaryJob), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RollingUpdateDrain)(nil), (*kops.RollingUpdateDrain)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_RollingUpdateDrain_To_kops_RollingUpdateDrain(a.(*RollingUpdateDrain), b.(*kops.RollingUpdateDrain), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.RollingUpdateDrain)(nil), (*RollingUpdateDrain)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_RollingUpdateDrain_To_v1alpha3_RollingUpdateDrain(a.(*kops.RollingUpdateDrain), b.(*RollingUpdateDrain), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RouteSpec)(nil), (*kops.RouteSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_RouteSpec_To_kops_RouteSpec(a.(*RouteSpec), b.(*kops.RouteSpec), scope)
 	}); err != nil {
@@ -1184,6 +1537,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ScalingPolicySpec)(nil), (*kops.ScalingPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ScalingPolicySpec_To_kops_ScalingPolicySpec(a.(*ScalingPolicySpec), b.(*kops.ScalingPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ScalingPolicySpec)(nil), (*ScalingPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ScalingPolicySpec_To_v1alpha3_ScalingPolicySpec(a.(*kops.ScalingPolicySpec), b.(*ScalingPolicySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScalingStepSpec)(nil), (*kops.ScalingStepSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ScalingStepSpec_To_kops_ScalingStepSpec(a.(*ScalingStepSpec), b.(*kops.ScalingStepSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ScalingStepSpec)(nil), (*ScalingStepSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ScalingStepSpec_To_v1alpha3_ScalingStepSpec(a.(*kops.ScalingStepSpec), b.(*ScalingStepSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ServiceAccountExternalPermission)(nil), (*kops.ServiceAccountExternalPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ServiceAccountExternalPermission_To_kops_ServiceAccountExternalPermission(a.(*ServiceAccountExternalPermission), b.(*kops.ServiceAccountExternalPermission), scope)
 	}); err != nil {
@@ -1214,73 +1587,143 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*TargetSpec)(nil), (*kops.TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_TargetSpec_To_kops_TargetSpec(a.(*TargetSpec), b.(*kops.TargetSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*StaticNetworkInterfaceSpec)(nil), (*kops.StaticNetworkInterfaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_StaticNetworkInterfaceSpec_To_kops_StaticNetworkInterfaceSpec(a.(*StaticNetworkInterfaceSpec), b.(*kops.StaticNetworkInterfaceSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kops.TargetSpec)(nil), (*TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kops_TargetSpec_To_v1alpha3_TargetSpec(a.(*kops.TargetSpec), b.(*TargetSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kops.StaticNetworkInterfaceSpec)(nil), (*StaticNetworkInterfaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_StaticNetworkInterfaceSpec_To_v1alpha3_StaticNetworkInterfaceSpec(a.(*kops.StaticNetworkInterfaceSpec), b.(*StaticNetworkInterfaceSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*TerraformSpec)(nil), (*kops.TerraformSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(a.(*TerraformSpec), b.(*kops.TerraformSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*StepScalingPolicySpec)(nil), (*kops.StepScalingPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_StepScalingPolicySpec_To_kops_StepScalingPolicySpec(a.(*StepScalingPolicySpec), b.(*kops.StepScalingPolicySpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kops.TerraformSpec)(nil), (*TerraformSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kops_TerraformSpec_To_v1alpha3_TerraformSpec(a.(*kops.TerraformSpec), b.(*TerraformSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kops.StepScalingPolicySpec)(nil), (*StepScalingPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_StepScalingPolicySpec_To_v1alpha3_StepScalingPolicySpec(a.(*kops.StepScalingPolicySpec), b.(*StepScalingPolicySpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*TopologySpec)(nil), (*kops.TopologySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_TopologySpec_To_kops_TopologySpec(a.(*TopologySpec), b.(*kops.TopologySpec), scope)
+	if err := s.AddGeneratedConversionFunc((*TTLNotificationSpec)(nil), (*kops.TTLNotificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_TTLNotificationSpec_To_kops_TTLNotificationSpec(a.(*TTLNotificationSpec), b.(*kops.TTLNotificationSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kops.TopologySpec)(nil), (*TopologySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kops_TopologySpec_To_v1alpha3_TopologySpec(a.(*kops.TopologySpec), b.(*TopologySpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kops.TTLNotificationSpec)(nil), (*TTLNotificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TTLNotificationSpec_To_v1alpha3_TTLNotificationSpec(a.(*kops.TTLNotificationSpec), b.(*TTLNotificationSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*UserData)(nil), (*kops.UserData)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_UserData_To_kops_UserData(a.(*UserData), b.(*kops.UserData), scope)
+	if err := s.AddGeneratedConversionFunc((*TargetSpec)(nil), (*kops.TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_TargetSpec_To_kops_TargetSpec(a.(*TargetSpec), b.(*kops.TargetSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kops.UserData)(nil), (*UserData)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kops_UserData_To_v1alpha3_UserData(a.(*kops.UserData), b.(*UserData), scope)
+	if err := s.AddGeneratedConversionFunc((*kops.TargetSpec)(nil), (*TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TargetSpec_To_v1alpha3_TargetSpec(a.(*kops.TargetSpec), b.(*TargetSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*VolumeMountSpec)(nil), (*kops.VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VolumeMountSpec_To_kops_VolumeMountSpec(a.(*VolumeMountSpec), b.(*kops.VolumeMountSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*TargetTrackingScalingPolicySpec)(nil), (*kops.TargetTrackingScalingPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_TargetTrackingScalingPolicySpec_To_kops_TargetTrackingScalingPolicySpec(a.(*TargetTrackingScalingPolicySpec), b.(*kops.TargetTrackingScalingPolicySpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kops.VolumeMountSpec)(nil), (*VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kops_VolumeMountSpec_To_v1alpha3_VolumeMountSpec(a.(*kops.VolumeMountSpec), b.(*VolumeMountSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kops.TargetTrackingScalingPolicySpec)(nil), (*TargetTrackingScalingPolicySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TargetTrackingScalingPolicySpec_To_v1alpha3_TargetTrackingScalingPolicySpec(a.(*kops.TargetTrackingScalingPolicySpec), b.(*TargetTrackingScalingPolicySpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*VolumeSpec)(nil), (*kops.VolumeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_VolumeSpec_To_kops_VolumeSpec(a.(*VolumeSpec), b.(*kops.VolumeSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*TerraformMovedSpec)(nil), (*kops.TerraformMovedSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_TerraformMovedSpec_To_kops_TerraformMovedSpec(a.(*TerraformMovedSpec), b.(*kops.TerraformMovedSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kops.VolumeSpec)(nil), (*VolumeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kops_VolumeSpec_To_v1alpha3_VolumeSpec(a.(*kops.VolumeSpec), b.(*VolumeSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kops.TerraformMovedSpec)(nil), (*TerraformMovedSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TerraformMovedSpec_To_v1alpha3_TerraformMovedSpec(a.(*kops.TerraformMovedSpec), b.(*TerraformMovedSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*WarmPoolSpec)(nil), (*kops.WarmPoolSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha3_WarmPoolSpec_To_kops_WarmPoolSpec(a.(*WarmPoolSpec), b.(*kops.WarmPoolSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*TerraformSpec)(nil), (*kops.TerraformSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(a.(*TerraformSpec), b.(*kops.TerraformSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*kops.WarmPoolSpec)(nil), (*WarmPoolSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_kops_WarmPoolSpec_To_v1alpha3_WarmPoolSpec(a.(*kops.WarmPoolSpec), b.(*WarmPoolSpec), scope)
+	if err := s.AddGeneratedConversionFunc((*kops.TerraformSpec)(nil), (*TerraformSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TerraformSpec_To_v1alpha3_TerraformSpec(a.(*kops.TerraformSpec), b.(*TerraformSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TopologySpec)(nil), (*kops.TopologySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_TopologySpec_To_kops_TopologySpec(a.(*TopologySpec), b.(*kops.TopologySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TopologySpec)(nil), (*TopologySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TopologySpec_To_v1alpha3_TopologySpec(a.(*kops.TopologySpec), b.(*TopologySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TransitGatewaySpec)(nil), (*kops.TransitGatewaySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_TransitGatewaySpec_To_kops_TransitGatewaySpec(a.(*TransitGatewaySpec), b.(*kops.TransitGatewaySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.TransitGatewaySpec)(nil), (*TransitGatewaySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_TransitGatewaySpec_To_v1alpha3_TransitGatewaySpec(a.(*kops.TransitGatewaySpec), b.(*TransitGatewaySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*UserData)(nil), (*kops.UserData)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_UserData_To_kops_UserData(a.(*UserData), b.(*kops.UserData), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.UserData)(nil), (*UserData)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_UserData_To_v1alpha3_UserData(a.(*kops.UserData), b.(*UserData), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VPCEndpointSpec)(nil), (*kops.VPCEndpointSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VPCEndpointSpec_To_kops_VPCEndpointSpec(a.(*VPCEndpointSpec), b.(*kops.VPCEndpointSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.VPCEndpointSpec)(nil), (*VPCEndpointSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_VPCEndpointSpec_To_v1alpha3_VPCEndpointSpec(a.(*kops.VPCEndpointSpec), b.(*VPCEndpointSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VolumeMountSpec)(nil), (*kops.VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VolumeMountSpec_To_kops_VolumeMountSpec(a.(*VolumeMountSpec), b.(*kops.VolumeMountSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.VolumeMountSpec)(nil), (*VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_VolumeMountSpec_To_v1alpha3_VolumeMountSpec(a.(*kops.VolumeMountSpec), b.(*VolumeMountSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VolumeSpec)(nil), (*kops.VolumeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VolumeSpec_To_kops_VolumeSpec(a.(*VolumeSpec), b.(*kops.VolumeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.VolumeSpec)(nil), (*VolumeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_VolumeSpec_To_v1alpha3_VolumeSpec(a.(*kops.VolumeSpec), b.(*VolumeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*WarmPoolSpec)(nil), (*kops.WarmPoolSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_WarmPoolSpec_To_kops_WarmPoolSpec(a.(*WarmPoolSpec), b.(*kops.WarmPoolSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.WarmPoolSpec)(nil), (*WarmPoolSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_WarmPoolSpec_To_v1alpha3_WarmPoolSpec(a.(*kops.WarmPoolSpec), b.(*WarmPoolSpec), scope)
 	}); err != nil {
 		return err
 	}
@@ -1297,6 +1740,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha3_ACMCertificateSpec_To_kops_ACMCertificateSpec(in *ACMCertificateSpec, out *kops.ACMCertificateSpec, s conversion.Scope) error {
+	out.SubjectAlternativeNames = in.SubjectAlternativeNames
+	return nil
+}
+
+// Convert_v1alpha3_ACMCertificateSpec_To_kops_ACMCertificateSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ACMCertificateSpec_To_kops_ACMCertificateSpec(in *ACMCertificateSpec, out *kops.ACMCertificateSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ACMCertificateSpec_To_kops_ACMCertificateSpec(in, out, s)
+}
+
+func autoConvert_kops_ACMCertificateSpec_To_v1alpha3_ACMCertificateSpec(in *kops.ACMCertificateSpec, out *ACMCertificateSpec, s conversion.Scope) error {
+	out.SubjectAlternativeNames = in.SubjectAlternativeNames
+	return nil
+}
+
+// Convert_kops_ACMCertificateSpec_To_v1alpha3_ACMCertificateSpec is an autogenerated conversion function.
+func Convert_kops_ACMCertificateSpec_To_v1alpha3_ACMCertificateSpec(in *kops.ACMCertificateSpec, out *ACMCertificateSpec, s conversion.Scope) error {
+	return autoConvert_kops_ACMCertificateSpec_To_v1alpha3_ACMCertificateSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_APISpec_To_kops_APISpec(in *APISpec, out *kops.APISpec, s conversion.Scope) error {
 	if in.DNS != nil {
 		in, out := &in.DNS, &out.DNS
@@ -1509,6 +1972,17 @@ func autoConvert_v1alpha3_AWSSpec_To_kops_AWSSpec(in *AWSSpec, out *kops.AWSSpec
 	out.SpotinstProduct = in.SpotinstProduct
 	out.SpotinstOrientation = in.SpotinstOrientation
 	out.BinariesLocation = in.BinariesLocation
+	if in.PrivateDNSZoneVPCs != nil {
+		in, out := &in.PrivateDNSZoneVPCs, &out.PrivateDNSZoneVPCs
+		*out = make([]kops.PrivateDNSZoneVPCSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_PrivateDNSZoneVPCSpec_To_kops_PrivateDNSZoneVPCSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PrivateDNSZoneVPCs = nil
+	}
 	return nil
 }
 
@@ -1569,6 +2043,17 @@ func autoConvert_kops_AWSSpec_To_v1alpha3_AWSSpec(in *kops.AWSSpec, out *AWSSpec
 	out.SpotinstProduct = in.SpotinstProduct
 	out.SpotinstOrientation = in.SpotinstOrientation
 	out.BinariesLocation = in.BinariesLocation
+	if in.PrivateDNSZoneVPCs != nil {
+		in, out := &in.PrivateDNSZoneVPCs, &out.PrivateDNSZoneVPCs
+		*out = make([]PrivateDNSZoneVPCSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_PrivateDNSZoneVPCSpec_To_v1alpha3_PrivateDNSZoneVPCSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PrivateDNSZoneVPCs = nil
+	}
 	return nil
 }
 
@@ -1623,6 +2108,30 @@ func Convert_kops_AccessLogSpec_To_v1alpha3_AccessLogSpec(in *kops.AccessLogSpec
 	return autoConvert_kops_AccessLogSpec_To_v1alpha3_AccessLogSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AdditionalNetworkInterfaceSpec_To_kops_AdditionalNetworkInterfaceSpec(in *AdditionalNetworkInterfaceSpec, out *kops.AdditionalNetworkInterfaceSpec, s conversion.Scope) error {
+	out.DeviceIndex = in.DeviceIndex
+	out.Subnet = in.Subnet
+	out.SecurityGroups = in.SecurityGroups
+	return nil
+}
+
+// Convert_v1alpha3_AdditionalNetworkInterfaceSpec_To_kops_AdditionalNetworkInterfaceSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AdditionalNetworkInterfaceSpec_To_kops_AdditionalNetworkInterfaceSpec(in *AdditionalNetworkInterfaceSpec, out *kops.AdditionalNetworkInterfaceSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AdditionalNetworkInterfaceSpec_To_kops_AdditionalNetworkInterfaceSpec(in, out, s)
+}
+
+func autoConvert_kops_AdditionalNetworkInterfaceSpec_To_v1alpha3_AdditionalNetworkInterfaceSpec(in *kops.AdditionalNetworkInterfaceSpec, out *AdditionalNetworkInterfaceSpec, s conversion.Scope) error {
+	out.DeviceIndex = in.DeviceIndex
+	out.Subnet = in.Subnet
+	out.SecurityGroups = in.SecurityGroups
+	return nil
+}
+
+// Convert_kops_AdditionalNetworkInterfaceSpec_To_v1alpha3_AdditionalNetworkInterfaceSpec is an autogenerated conversion function.
+func Convert_kops_AdditionalNetworkInterfaceSpec_To_v1alpha3_AdditionalNetworkInterfaceSpec(in *kops.AdditionalNetworkInterfaceSpec, out *AdditionalNetworkInterfaceSpec, s conversion.Scope) error {
+	return autoConvert_kops_AdditionalNetworkInterfaceSpec_To_v1alpha3_AdditionalNetworkInterfaceSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AddonSpec_To_kops_AddonSpec(in *AddonSpec, out *kops.AddonSpec, s conversion.Scope) error {
 	out.Manifest = in.Manifest
 	return nil
@@ -1675,6 +2184,7 @@ func autoConvert_v1alpha3_AmazonVPCNetworkingSpec_To_kops_AmazonVPCNetworkingSpe
 	} else {
 		out.Env = nil
 	}
+	out.SecurityGroupsForPods = in.SecurityGroupsForPods
 	return nil
 }
 
@@ -1697,6 +2207,7 @@ func autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha3_AmazonVPCNetworkingSpe
 	} else {
 		out.Env = nil
 	}
+	out.SecurityGroupsForPods = in.SecurityGroupsForPods
 	return nil
 }
 
@@ -1757,6 +2268,15 @@ func autoConvert_v1alpha3_AuthenticationSpec_To_kops_AuthenticationSpec(in *Auth
 	} else {
 		out.OIDC = nil
 	}
+	if in.CloudIdentity != nil {
+		in, out := &in.CloudIdentity, &out.CloudIdentity
+		*out = new(kops.CloudIdentityAuthenticationSpec)
+		if err := Convert_v1alpha3_CloudIdentityAuthenticationSpec_To_kops_CloudIdentityAuthenticationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudIdentity = nil
+	}
 	return nil
 }
 
@@ -1793,6 +2313,15 @@ func autoConvert_kops_AuthenticationSpec_To_v1alpha3_AuthenticationSpec(in *kops
 	} else {
 		out.OIDC = nil
 	}
+	if in.CloudIdentity != nil {
+		in, out := &in.CloudIdentity, &out.CloudIdentity
+		*out = new(CloudIdentityAuthenticationSpec)
+		if err := Convert_kops_CloudIdentityAuthenticationSpec_To_v1alpha3_CloudIdentityAuthenticationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudIdentity = nil
+	}
 	return nil
 }
 
@@ -1855,6 +2384,30 @@ func Convert_kops_AuthorizationSpec_To_v1alpha3_AuthorizationSpec(in *kops.Autho
 	return autoConvert_kops_AuthorizationSpec_To_v1alpha3_AuthorizationSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AzureInstanceGroupSpec_To_kops_AzureInstanceGroupSpec(in *AzureInstanceGroupSpec, out *kops.AzureInstanceGroupSpec, s conversion.Scope) error {
+	out.OrchestrationMode = in.OrchestrationMode
+	out.Priority = in.Priority
+	out.EvictionPolicy = in.EvictionPolicy
+	return nil
+}
+
+// Convert_v1alpha3_AzureInstanceGroupSpec_To_kops_AzureInstanceGroupSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AzureInstanceGroupSpec_To_kops_AzureInstanceGroupSpec(in *AzureInstanceGroupSpec, out *kops.AzureInstanceGroupSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AzureInstanceGroupSpec_To_kops_AzureInstanceGroupSpec(in, out, s)
+}
+
+func autoConvert_kops_AzureInstanceGroupSpec_To_v1alpha3_AzureInstanceGroupSpec(in *kops.AzureInstanceGroupSpec, out *AzureInstanceGroupSpec, s conversion.Scope) error {
+	out.OrchestrationMode = in.OrchestrationMode
+	out.Priority = in.Priority
+	out.EvictionPolicy = in.EvictionPolicy
+	return nil
+}
+
+// Convert_kops_AzureInstanceGroupSpec_To_v1alpha3_AzureInstanceGroupSpec is an autogenerated conversion function.
+func Convert_kops_AzureInstanceGroupSpec_To_v1alpha3_AzureInstanceGroupSpec(in *kops.AzureInstanceGroupSpec, out *AzureInstanceGroupSpec, s conversion.Scope) error {
+	return autoConvert_kops_AzureInstanceGroupSpec_To_v1alpha3_AzureInstanceGroupSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AzureSpec_To_kops_AzureSpec(in *AzureSpec, out *kops.AzureSpec, s conversion.Scope) error {
 	out.SubscriptionID = in.SubscriptionID
 	out.StorageAccountID = in.StorageAccountID
@@ -1885,6 +2438,30 @@ func Convert_kops_AzureSpec_To_v1alpha3_AzureSpec(in *kops.AzureSpec, out *Azure
 	return autoConvert_kops_AzureSpec_To_v1alpha3_AzureSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_BastionAuditLoggingSpec_To_kops_BastionAuditLoggingSpec(in *BastionAuditLoggingSpec, out *kops.BastionAuditLoggingSpec, s conversion.Scope) error {
+	out.S3BucketName = in.S3BucketName
+	out.S3KeyPrefix = in.S3KeyPrefix
+	out.CloudWatchLogGroupName = in.CloudWatchLogGroupName
+	return nil
+}
+
+// Convert_v1alpha3_BastionAuditLoggingSpec_To_kops_BastionAuditLoggingSpec is an autogenerated conversion function.
+func Convert_v1alpha3_BastionAuditLoggingSpec_To_kops_BastionAuditLoggingSpec(in *BastionAuditLoggingSpec, out *kops.BastionAuditLoggingSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_BastionAuditLoggingSpec_To_kops_BastionAuditLoggingSpec(in, out, s)
+}
+
+func autoConvert_kops_BastionAuditLoggingSpec_To_v1alpha3_BastionAuditLoggingSpec(in *kops.BastionAuditLoggingSpec, out *BastionAuditLoggingSpec, s conversion.Scope) error {
+	out.S3BucketName = in.S3BucketName
+	out.S3KeyPrefix = in.S3KeyPrefix
+	out.CloudWatchLogGroupName = in.CloudWatchLogGroupName
+	return nil
+}
+
+// Convert_kops_BastionAuditLoggingSpec_To_v1alpha3_BastionAuditLoggingSpec is an autogenerated conversion function.
+func Convert_kops_BastionAuditLoggingSpec_To_v1alpha3_BastionAuditLoggingSpec(in *kops.BastionAuditLoggingSpec, out *BastionAuditLoggingSpec, s conversion.Scope) error {
+	return autoConvert_kops_BastionAuditLoggingSpec_To_v1alpha3_BastionAuditLoggingSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_BastionLoadBalancerSpec_To_kops_BastionLoadBalancerSpec(in *BastionLoadBalancerSpec, out *kops.BastionLoadBalancerSpec, s conversion.Scope) error {
 	out.Type = kops.LoadBalancerType(in.Type)
 	return nil
@@ -1905,6 +2482,26 @@ func Convert_kops_BastionLoadBalancerSpec_To_v1alpha3_BastionLoadBalancerSpec(in
 	return autoConvert_kops_BastionLoadBalancerSpec_To_v1alpha3_BastionLoadBalancerSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_BastionSSMSpec_To_kops_BastionSSMSpec(in *BastionSSMSpec, out *kops.BastionSSMSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_v1alpha3_BastionSSMSpec_To_kops_BastionSSMSpec is an autogenerated conversion function.
+func Convert_v1alpha3_BastionSSMSpec_To_kops_BastionSSMSpec(in *BastionSSMSpec, out *kops.BastionSSMSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_BastionSSMSpec_To_kops_BastionSSMSpec(in, out, s)
+}
+
+func autoConvert_kops_BastionSSMSpec_To_v1alpha3_BastionSSMSpec(in *kops.BastionSSMSpec, out *BastionSSMSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	return nil
+}
+
+// Convert_kops_BastionSSMSpec_To_v1alpha3_BastionSSMSpec is an autogenerated conversion function.
+func Convert_kops_BastionSSMSpec_To_v1alpha3_BastionSSMSpec(in *kops.BastionSSMSpec, out *BastionSSMSpec, s conversion.Scope) error {
+	return autoConvert_kops_BastionSSMSpec_To_v1alpha3_BastionSSMSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_BastionSpec_To_kops_BastionSpec(in *BastionSpec, out *kops.BastionSpec, s conversion.Scope) error {
 	out.PublicName = in.PublicName
 	if in.LoadBalancer != nil {
@@ -1916,6 +2513,24 @@ func autoConvert_v1alpha3_BastionSpec_To_kops_BastionSpec(in *BastionSpec, out *
 	} else {
 		out.LoadBalancer = nil
 	}
+	if in.SSM != nil {
+		in, out := &in.SSM, &out.SSM
+		*out = new(kops.BastionSSMSpec)
+		if err := Convert_v1alpha3_BastionSSMSpec_To_kops_BastionSSMSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SSM = nil
+	}
+	if in.AuditLogging != nil {
+		in, out := &in.AuditLogging, &out.AuditLogging
+		*out = new(kops.BastionAuditLoggingSpec)
+		if err := Convert_v1alpha3_BastionAuditLoggingSpec_To_kops_BastionAuditLoggingSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuditLogging = nil
+	}
 	return nil
 }
 
@@ -1935,6 +2550,24 @@ func autoConvert_kops_BastionSpec_To_v1alpha3_BastionSpec(in *kops.BastionSpec,
 	} else {
 		out.LoadBalancer = nil
 	}
+	if in.SSM != nil {
+		in, out := &in.SSM, &out.SSM
+		*out = new(BastionSSMSpec)
+		if err := Convert_kops_BastionSSMSpec_To_v1alpha3_BastionSSMSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SSM = nil
+	}
+	if in.AuditLogging != nil {
+		in, out := &in.AuditLogging, &out.AuditLogging
+		*out = new(BastionAuditLoggingSpec)
+		if err := Convert_kops_BastionAuditLoggingSpec_To_v1alpha3_BastionAuditLoggingSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuditLogging = nil
+	}
 	return nil
 }
 
@@ -2383,6 +3016,28 @@ func Convert_kops_CloudControllerManagerConfig_To_v1alpha3_CloudControllerManage
 	return autoConvert_kops_CloudControllerManagerConfig_To_v1alpha3_CloudControllerManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_CloudIdentityAuthenticationSpec_To_kops_CloudIdentityAuthenticationSpec(in *CloudIdentityAuthenticationSpec, out *kops.CloudIdentityAuthenticationSpec, s conversion.Scope) error {
+	out.AllowedIdentities = in.AllowedIdentities
+	out.MaxLifetime = in.MaxLifetime
+	return nil
+}
+
+// Convert_v1alpha3_CloudIdentityAuthenticationSpec_To_kops_CloudIdentityAuthenticationSpec is an autogenerated conversion function.
+func Convert_v1alpha3_CloudIdentityAuthenticationSpec_To_kops_CloudIdentityAuthenticationSpec(in *CloudIdentityAuthenticationSpec, out *kops.CloudIdentityAuthenticationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CloudIdentityAuthenticationSpec_To_kops_CloudIdentityAuthenticationSpec(in, out, s)
+}
+
+func autoConvert_kops_CloudIdentityAuthenticationSpec_To_v1alpha3_CloudIdentityAuthenticationSpec(in *kops.CloudIdentityAuthenticationSpec, out *CloudIdentityAuthenticationSpec, s conversion.Scope) error {
+	out.AllowedIdentities = in.AllowedIdentities
+	out.MaxLifetime = in.MaxLifetime
+	return nil
+}
+
+// Convert_kops_CloudIdentityAuthenticationSpec_To_v1alpha3_CloudIdentityAuthenticationSpec is an autogenerated conversion function.
+func Convert_kops_CloudIdentityAuthenticationSpec_To_v1alpha3_CloudIdentityAuthenticationSpec(in *kops.CloudIdentityAuthenticationSpec, out *CloudIdentityAuthenticationSpec, s conversion.Scope) error {
+	return autoConvert_kops_CloudIdentityAuthenticationSpec_To_v1alpha3_CloudIdentityAuthenticationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_CloudProviderSpec_To_kops_CloudProviderSpec(in *CloudProviderSpec, out *kops.CloudProviderSpec, s conversion.Scope) error {
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
@@ -2613,6 +3268,148 @@ func Convert_kops_ClusterAutoscalerConfig_To_v1alpha3_ClusterAutoscalerConfig(in
 	return autoConvert_kops_ClusterAutoscalerConfig_To_v1alpha3_ClusterAutoscalerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_ClusterDefaultsNamespaceSpec_To_kops_ClusterDefaultsNamespaceSpec(in *ClusterDefaultsNamespaceSpec, out *kops.ClusterDefaultsNamespaceSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Labels = in.Labels
+	out.Annotations = in.Annotations
+	out.LimitRange = in.LimitRange
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			newVal := new(resource.Quantity)
+			if err := metav1.Convert_resource_Quantity_To_resource_Quantity(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.ResourceQuota = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ClusterDefaultsNamespaceSpec_To_kops_ClusterDefaultsNamespaceSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ClusterDefaultsNamespaceSpec_To_kops_ClusterDefaultsNamespaceSpec(in *ClusterDefaultsNamespaceSpec, out *kops.ClusterDefaultsNamespaceSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ClusterDefaultsNamespaceSpec_To_kops_ClusterDefaultsNamespaceSpec(in, out, s)
+}
+
+func autoConvert_kops_ClusterDefaultsNamespaceSpec_To_v1alpha3_ClusterDefaultsNamespaceSpec(in *kops.ClusterDefaultsNamespaceSpec, out *ClusterDefaultsNamespaceSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Labels = in.Labels
+	out.Annotations = in.Annotations
+	out.LimitRange = in.LimitRange
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			newVal := new(resource.Quantity)
+			if err := metav1.Convert_resource_Quantity_To_resource_Quantity(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.ResourceQuota = nil
+	}
+	return nil
+}
+
+// Convert_kops_ClusterDefaultsNamespaceSpec_To_v1alpha3_ClusterDefaultsNamespaceSpec is an autogenerated conversion function.
+func Convert_kops_ClusterDefaultsNamespaceSpec_To_v1alpha3_ClusterDefaultsNamespaceSpec(in *kops.ClusterDefaultsNamespaceSpec, out *ClusterDefaultsNamespaceSpec, s conversion.Scope) error {
+	return autoConvert_kops_ClusterDefaultsNamespaceSpec_To_v1alpha3_ClusterDefaultsNamespaceSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_ClusterDefaultsPriorityClassSpec_To_kops_ClusterDefaultsPriorityClassSpec(in *ClusterDefaultsPriorityClassSpec, out *kops.ClusterDefaultsPriorityClassSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	out.GlobalDefault = in.GlobalDefault
+	out.PreemptionPolicy = in.PreemptionPolicy
+	out.Description = in.Description
+	return nil
+}
+
+// Convert_v1alpha3_ClusterDefaultsPriorityClassSpec_To_kops_ClusterDefaultsPriorityClassSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ClusterDefaultsPriorityClassSpec_To_kops_ClusterDefaultsPriorityClassSpec(in *ClusterDefaultsPriorityClassSpec, out *kops.ClusterDefaultsPriorityClassSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ClusterDefaultsPriorityClassSpec_To_kops_ClusterDefaultsPriorityClassSpec(in, out, s)
+}
+
+func autoConvert_kops_ClusterDefaultsPriorityClassSpec_To_v1alpha3_ClusterDefaultsPriorityClassSpec(in *kops.ClusterDefaultsPriorityClassSpec, out *ClusterDefaultsPriorityClassSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Value = in.Value
+	out.GlobalDefault = in.GlobalDefault
+	out.PreemptionPolicy = in.PreemptionPolicy
+	out.Description = in.Description
+	return nil
+}
+
+// Convert_kops_ClusterDefaultsPriorityClassSpec_To_v1alpha3_ClusterDefaultsPriorityClassSpec is an autogenerated conversion function.
+func Convert_kops_ClusterDefaultsPriorityClassSpec_To_v1alpha3_ClusterDefaultsPriorityClassSpec(in *kops.ClusterDefaultsPriorityClassSpec, out *ClusterDefaultsPriorityClassSpec, s conversion.Scope) error {
+	return autoConvert_kops_ClusterDefaultsPriorityClassSpec_To_v1alpha3_ClusterDefaultsPriorityClassSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_ClusterDefaultsSpec_To_kops_ClusterDefaultsSpec(in *ClusterDefaultsSpec, out *kops.ClusterDefaultsSpec, s conversion.Scope) error {
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]kops.ClusterDefaultsNamespaceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ClusterDefaultsNamespaceSpec_To_kops_ClusterDefaultsNamespaceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Namespaces = nil
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = make([]kops.ClusterDefaultsPriorityClassSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ClusterDefaultsPriorityClassSpec_To_kops_ClusterDefaultsPriorityClassSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PriorityClasses = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ClusterDefaultsSpec_To_kops_ClusterDefaultsSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ClusterDefaultsSpec_To_kops_ClusterDefaultsSpec(in *ClusterDefaultsSpec, out *kops.ClusterDefaultsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ClusterDefaultsSpec_To_kops_ClusterDefaultsSpec(in, out, s)
+}
+
+func autoConvert_kops_ClusterDefaultsSpec_To_v1alpha3_ClusterDefaultsSpec(in *kops.ClusterDefaultsSpec, out *ClusterDefaultsSpec, s conversion.Scope) error {
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]ClusterDefaultsNamespaceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ClusterDefaultsNamespaceSpec_To_v1alpha3_ClusterDefaultsNamespaceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Namespaces = nil
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = make([]ClusterDefaultsPriorityClassSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ClusterDefaultsPriorityClassSpec_To_v1alpha3_ClusterDefaultsPriorityClassSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PriorityClasses = nil
+	}
+	return nil
+}
+
+// Convert_kops_ClusterDefaultsSpec_To_v1alpha3_ClusterDefaultsSpec is an autogenerated conversion function.
+func Convert_kops_ClusterDefaultsSpec_To_v1alpha3_ClusterDefaultsSpec(in *kops.ClusterDefaultsSpec, out *ClusterDefaultsSpec, s conversion.Scope) error {
+	return autoConvert_kops_ClusterDefaultsSpec_To_v1alpha3_ClusterDefaultsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_ClusterList_To_kops_ClusterList(in *ClusterList, out *kops.ClusterList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -2841,6 +3638,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.NTP = nil
 	}
+	if in.LogRotation != nil {
+		in, out := &in.LogRotation, &out.LogRotation
+		*out = new(kops.LogRotationSpec)
+		if err := Convert_v1alpha3_LogRotationSpec_To_kops_LogRotationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LogRotation = nil
+	}
 	out.Packages = in.Packages
 	if in.NodeProblemDetector != nil {
 		in, out := &in.NodeProblemDetector, &out.NodeProblemDetector
@@ -2860,6 +3666,33 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.MetricsServer = nil
 	}
+	if in.EventExporter != nil {
+		in, out := &in.EventExporter, &out.EventExporter
+		*out = new(kops.EventExporterConfig)
+		if err := Convert_v1alpha3_EventExporterConfig_To_kops_EventExporterConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EventExporter = nil
+	}
+	if in.PrometheusAgent != nil {
+		in, out := &in.PrometheusAgent, &out.PrometheusAgent
+		*out = new(kops.PrometheusAgentConfig)
+		if err := Convert_v1alpha3_PrometheusAgentConfig_To_kops_PrometheusAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrometheusAgent = nil
+	}
+	if in.ClusterDefaults != nil {
+		in, out := &in.ClusterDefaults, &out.ClusterDefaults
+		*out = new(kops.ClusterDefaultsSpec)
+		if err := Convert_v1alpha3_ClusterDefaultsSpec_To_kops_ClusterDefaultsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClusterDefaults = nil
+	}
 	if in.CertManager != nil {
 		in, out := &in.CertManager, &out.CertManager
 		*out = new(kops.CertManagerConfig)
@@ -2934,14 +3767,38 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Target = nil
 	}
-	out.UseHostCertificates = in.UseHostCertificates
-	out.SysctlParameters = in.SysctlParameters
-	if in.RollingUpdate != nil {
-		in, out := &in.RollingUpdate, &out.RollingUpdate
-		*out = new(kops.RollingUpdate)
-		if err := Convert_v1alpha3_RollingUpdate_To_kops_RollingUpdate(*in, *out, s); err != nil {
-			return err
-		}
+	out.LifecycleOverrides = in.LifecycleOverrides
+	if in.ExternalTasks != nil {
+		in, out := &in.ExternalTasks, &out.ExternalTasks
+		*out = make([]kops.ExternalTaskSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ExternalTaskSpec_To_kops_ExternalTaskSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ExternalTasks = nil
+	}
+	out.DeletionProtection = in.DeletionProtection
+	out.TTL = in.TTL
+	if in.TTLNotification != nil {
+		in, out := &in.TTLNotification, &out.TTLNotification
+		*out = new(kops.TTLNotificationSpec)
+		if err := Convert_v1alpha3_TTLNotificationSpec_To_kops_TTLNotificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TTLNotification = nil
+	}
+	out.UseHostCertificates = in.UseHostCertificates
+	out.AdditionalTrustBundles = in.AdditionalTrustBundles
+	out.SysctlParameters = in.SysctlParameters
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(kops.RollingUpdate)
+		if err := Convert_v1alpha3_RollingUpdate_To_kops_RollingUpdate(*in, *out, s); err != nil {
+			return err
+		}
 	} else {
 		out.RollingUpdate = nil
 	}
@@ -3175,6 +4032,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.NTP = nil
 	}
+	if in.LogRotation != nil {
+		in, out := &in.LogRotation, &out.LogRotation
+		*out = new(LogRotationSpec)
+		if err := Convert_kops_LogRotationSpec_To_v1alpha3_LogRotationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LogRotation = nil
+	}
 	out.Packages = in.Packages
 	if in.NodeProblemDetector != nil {
 		in, out := &in.NodeProblemDetector, &out.NodeProblemDetector
@@ -3194,6 +4060,33 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.MetricsServer = nil
 	}
+	if in.EventExporter != nil {
+		in, out := &in.EventExporter, &out.EventExporter
+		*out = new(EventExporterConfig)
+		if err := Convert_kops_EventExporterConfig_To_v1alpha3_EventExporterConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EventExporter = nil
+	}
+	if in.PrometheusAgent != nil {
+		in, out := &in.PrometheusAgent, &out.PrometheusAgent
+		*out = new(PrometheusAgentConfig)
+		if err := Convert_kops_PrometheusAgentConfig_To_v1alpha3_PrometheusAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrometheusAgent = nil
+	}
+	if in.ClusterDefaults != nil {
+		in, out := &in.ClusterDefaults, &out.ClusterDefaults
+		*out = new(ClusterDefaultsSpec)
+		if err := Convert_kops_ClusterDefaultsSpec_To_v1alpha3_ClusterDefaultsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ClusterDefaults = nil
+	}
 	if in.CertManager != nil {
 		in, out := &in.CertManager, &out.CertManager
 		*out = new(CertManagerConfig)
@@ -3268,7 +4161,31 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Target = nil
 	}
+	out.LifecycleOverrides = in.LifecycleOverrides
+	if in.ExternalTasks != nil {
+		in, out := &in.ExternalTasks, &out.ExternalTasks
+		*out = make([]ExternalTaskSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ExternalTaskSpec_To_v1alpha3_ExternalTaskSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ExternalTasks = nil
+	}
+	out.DeletionProtection = in.DeletionProtection
+	out.TTL = in.TTL
+	if in.TTLNotification != nil {
+		in, out := &in.TTLNotification, &out.TTLNotification
+		*out = new(TTLNotificationSpec)
+		if err := Convert_kops_TTLNotificationSpec_To_v1alpha3_TTLNotificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TTLNotification = nil
+	}
 	out.UseHostCertificates = in.UseHostCertificates
+	out.AdditionalTrustBundles = in.AdditionalTrustBundles
 	out.SysctlParameters = in.SysctlParameters
 	if in.RollingUpdate != nil {
 		in, out := &in.RollingUpdate, &out.RollingUpdate
@@ -3333,6 +4250,7 @@ func autoConvert_v1alpha3_ClusterSubnetSpec_To_kops_ClusterSubnetSpec(in *Cluste
 	out.Egress = in.Egress
 	out.Type = kops.SubnetType(in.Type)
 	out.PublicIP = in.PublicIP
+	out.NATGatewayConnectivityType = in.NATGatewayConnectivityType
 	if in.AdditionalRoutes != nil {
 		in, out := &in.AdditionalRoutes, &out.AdditionalRoutes
 		*out = make([]kops.RouteSpec, len(*in))
@@ -3344,6 +4262,16 @@ func autoConvert_v1alpha3_ClusterSubnetSpec_To_kops_ClusterSubnetSpec(in *Cluste
 	} else {
 		out.AdditionalRoutes = nil
 	}
+	if in.PrivateDNSNameOptions != nil {
+		in, out := &in.PrivateDNSNameOptions, &out.PrivateDNSNameOptions
+		*out = new(kops.PrivateDNSNameOptionsSpec)
+		if err := Convert_v1alpha3_PrivateDNSNameOptionsSpec_To_kops_PrivateDNSNameOptionsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrivateDNSNameOptions = nil
+	}
+	out.Tags = in.Tags
 	return nil
 }
 
@@ -3362,6 +4290,7 @@ func autoConvert_kops_ClusterSubnetSpec_To_v1alpha3_ClusterSubnetSpec(in *kops.C
 	out.Egress = in.Egress
 	out.Type = SubnetType(in.Type)
 	out.PublicIP = in.PublicIP
+	out.NATGatewayConnectivityType = in.NATGatewayConnectivityType
 	if in.AdditionalRoutes != nil {
 		in, out := &in.AdditionalRoutes, &out.AdditionalRoutes
 		*out = make([]RouteSpec, len(*in))
@@ -3373,6 +4302,16 @@ func autoConvert_kops_ClusterSubnetSpec_To_v1alpha3_ClusterSubnetSpec(in *kops.C
 	} else {
 		out.AdditionalRoutes = nil
 	}
+	if in.PrivateDNSNameOptions != nil {
+		in, out := &in.PrivateDNSNameOptions, &out.PrivateDNSNameOptions
+		*out = new(PrivateDNSNameOptionsSpec)
+		if err := Convert_kops_PrivateDNSNameOptionsSpec_To_v1alpha3_PrivateDNSNameOptionsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PrivateDNSNameOptions = nil
+	}
+	out.Tags = in.Tags
 	return nil
 }
 
@@ -3381,6 +4320,44 @@ func Convert_kops_ClusterSubnetSpec_To_v1alpha3_ClusterSubnetSpec(in *kops.Clust
 	return autoConvert_kops_ClusterSubnetSpec_To_v1alpha3_ClusterSubnetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_ConfidentialComputeSpec_To_kops_ConfidentialComputeSpec(in *ConfidentialComputeSpec, out *kops.ConfidentialComputeSpec, s conversion.Scope) error {
+	if in.NitroEnclaves != nil {
+		in, out := &in.NitroEnclaves, &out.NitroEnclaves
+		*out = new(kops.NitroEnclavesSpec)
+		if err := Convert_v1alpha3_NitroEnclavesSpec_To_kops_NitroEnclavesSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NitroEnclaves = nil
+	}
+	out.AMDSevSnp = in.AMDSevSnp
+	return nil
+}
+
+// Convert_v1alpha3_ConfidentialComputeSpec_To_kops_ConfidentialComputeSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ConfidentialComputeSpec_To_kops_ConfidentialComputeSpec(in *ConfidentialComputeSpec, out *kops.ConfidentialComputeSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ConfidentialComputeSpec_To_kops_ConfidentialComputeSpec(in, out, s)
+}
+
+func autoConvert_kops_ConfidentialComputeSpec_To_v1alpha3_ConfidentialComputeSpec(in *kops.ConfidentialComputeSpec, out *ConfidentialComputeSpec, s conversion.Scope) error {
+	if in.NitroEnclaves != nil {
+		in, out := &in.NitroEnclaves, &out.NitroEnclaves
+		*out = new(NitroEnclavesSpec)
+		if err := Convert_kops_NitroEnclavesSpec_To_v1alpha3_NitroEnclavesSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NitroEnclaves = nil
+	}
+	out.AMDSevSnp = in.AMDSevSnp
+	return nil
+}
+
+// Convert_kops_ConfidentialComputeSpec_To_v1alpha3_ConfidentialComputeSpec is an autogenerated conversion function.
+func Convert_kops_ConfidentialComputeSpec_To_v1alpha3_ConfidentialComputeSpec(in *kops.ConfidentialComputeSpec, out *ConfidentialComputeSpec, s conversion.Scope) error {
+	return autoConvert_kops_ConfidentialComputeSpec_To_v1alpha3_ConfidentialComputeSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_ConfigStoreSpec_To_kops_ConfigStoreSpec(in *ConfigStoreSpec, out *kops.ConfigStoreSpec, s conversion.Scope) error {
 	out.Base = in.Base
 	out.Keypairs = in.Keypairs
@@ -3535,6 +4512,30 @@ func Convert_kops_DCGMExporterConfig_To_v1alpha3_DCGMExporterConfig(in *kops.DCG
 	return autoConvert_kops_DCGMExporterConfig_To_v1alpha3_DCGMExporterConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_DHCPOptionsSpec_To_kops_DHCPOptionsSpec(in *DHCPOptionsSpec, out *kops.DHCPOptionsSpec, s conversion.Scope) error {
+	out.DomainName = in.DomainName
+	out.DomainNameServers = in.DomainNameServers
+	out.NTPServers = in.NTPServers
+	return nil
+}
+
+// Convert_v1alpha3_DHCPOptionsSpec_To_kops_DHCPOptionsSpec is an autogenerated conversion function.
+func Convert_v1alpha3_DHCPOptionsSpec_To_kops_DHCPOptionsSpec(in *DHCPOptionsSpec, out *kops.DHCPOptionsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_DHCPOptionsSpec_To_kops_DHCPOptionsSpec(in, out, s)
+}
+
+func autoConvert_kops_DHCPOptionsSpec_To_v1alpha3_DHCPOptionsSpec(in *kops.DHCPOptionsSpec, out *DHCPOptionsSpec, s conversion.Scope) error {
+	out.DomainName = in.DomainName
+	out.DomainNameServers = in.DomainNameServers
+	out.NTPServers = in.NTPServers
+	return nil
+}
+
+// Convert_kops_DHCPOptionsSpec_To_v1alpha3_DHCPOptionsSpec is an autogenerated conversion function.
+func Convert_kops_DHCPOptionsSpec_To_v1alpha3_DHCPOptionsSpec(in *kops.DHCPOptionsSpec, out *DHCPOptionsSpec, s conversion.Scope) error {
+	return autoConvert_kops_DHCPOptionsSpec_To_v1alpha3_DHCPOptionsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_DNSAccessSpec_To_kops_DNSAccessSpec(in *DNSAccessSpec, out *kops.DNSAccessSpec, s conversion.Scope) error {
 	return nil
 }
@@ -3777,6 +4778,28 @@ func Convert_kops_EBSCSIDriverSpec_To_v1alpha3_EBSCSIDriverSpec(in *kops.EBSCSID
 	return autoConvert_kops_EBSCSIDriverSpec_To_v1alpha3_EBSCSIDriverSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_EFASpec_To_kops_EFASpec(in *EFASpec, out *kops.EFASpec, s conversion.Scope) error {
+	out.InterfaceCount = in.InterfaceCount
+	out.HugePages = in.HugePages
+	return nil
+}
+
+// Convert_v1alpha3_EFASpec_To_kops_EFASpec is an autogenerated conversion function.
+func Convert_v1alpha3_EFASpec_To_kops_EFASpec(in *EFASpec, out *kops.EFASpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EFASpec_To_kops_EFASpec(in, out, s)
+}
+
+func autoConvert_kops_EFASpec_To_v1alpha3_EFASpec(in *kops.EFASpec, out *EFASpec, s conversion.Scope) error {
+	out.InterfaceCount = in.InterfaceCount
+	out.HugePages = in.HugePages
+	return nil
+}
+
+// Convert_kops_EFASpec_To_v1alpha3_EFASpec is an autogenerated conversion function.
+func Convert_kops_EFASpec_To_v1alpha3_EFASpec(in *kops.EFASpec, out *EFASpec, s conversion.Scope) error {
+	return autoConvert_kops_EFASpec_To_v1alpha3_EFASpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_EgressProxySpec_To_kops_EgressProxySpec(in *EgressProxySpec, out *kops.EgressProxySpec, s conversion.Scope) error {
 	if err := Convert_v1alpha3_HTTPProxy_To_kops_HTTPProxy(&in.HTTPProxy, &out.HTTPProxy, s); err != nil {
 		return err
@@ -3827,6 +4850,7 @@ func Convert_kops_EnvVar_To_v1alpha3_EnvVar(in *kops.EnvVar, out *EnvVar, s conv
 
 func autoConvert_v1alpha3_EtcdBackupSpec_To_kops_EtcdBackupSpec(in *EtcdBackupSpec, out *kops.EtcdBackupSpec, s conversion.Scope) error {
 	out.BackupStore = in.BackupStore
+	out.SecondaryBackupStore = in.SecondaryBackupStore
 	out.Image = in.Image
 	return nil
 }
@@ -3838,6 +4862,7 @@ func Convert_v1alpha3_EtcdBackupSpec_To_kops_EtcdBackupSpec(in *EtcdBackupSpec,
 
 func autoConvert_kops_EtcdBackupSpec_To_v1alpha3_EtcdBackupSpec(in *kops.EtcdBackupSpec, out *EtcdBackupSpec, s conversion.Scope) error {
 	out.BackupStore = in.BackupStore
+	out.SecondaryBackupStore = in.SecondaryBackupStore
 	out.Image = in.Image
 	return nil
 }
@@ -3885,6 +4910,33 @@ func autoConvert_v1alpha3_EtcdClusterSpec_To_kops_EtcdClusterSpec(in *EtcdCluste
 	}
 	out.MemoryRequest = in.MemoryRequest
 	out.CPURequest = in.CPURequest
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(kops.EtcdTLSSpec)
+		if err := Convert_v1alpha3_EtcdTLSSpec_To_kops_EtcdTLSSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(kops.EtcdGRPCSpec)
+		if err := Convert_v1alpha3_EtcdGRPCSpec_To_kops_EtcdGRPCSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GRPC = nil
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(kops.EtcdExternalSpec)
+		if err := Convert_v1alpha3_EtcdExternalSpec_To_kops_EtcdExternalSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.External = nil
+	}
 	return nil
 }
 
@@ -3931,6 +4983,33 @@ func autoConvert_kops_EtcdClusterSpec_To_v1alpha3_EtcdClusterSpec(in *kops.EtcdC
 	}
 	out.MemoryRequest = in.MemoryRequest
 	out.CPURequest = in.CPURequest
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(EtcdTLSSpec)
+		if err := Convert_kops_EtcdTLSSpec_To_v1alpha3_EtcdTLSSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLS = nil
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(EtcdGRPCSpec)
+		if err := Convert_kops_EtcdGRPCSpec_To_v1alpha3_EtcdGRPCSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GRPC = nil
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(EtcdExternalSpec)
+		if err := Convert_kops_EtcdExternalSpec_To_v1alpha3_EtcdExternalSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.External = nil
+	}
 	return nil
 }
 
@@ -3939,6 +5018,56 @@ func Convert_kops_EtcdClusterSpec_To_v1alpha3_EtcdClusterSpec(in *kops.EtcdClust
 	return autoConvert_kops_EtcdClusterSpec_To_v1alpha3_EtcdClusterSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_EtcdExternalSpec_To_kops_EtcdExternalSpec(in *EtcdExternalSpec, out *kops.EtcdExternalSpec, s conversion.Scope) error {
+	out.Endpoints = in.Endpoints
+	out.ClientSecret = in.ClientSecret
+	return nil
+}
+
+// Convert_v1alpha3_EtcdExternalSpec_To_kops_EtcdExternalSpec is an autogenerated conversion function.
+func Convert_v1alpha3_EtcdExternalSpec_To_kops_EtcdExternalSpec(in *EtcdExternalSpec, out *kops.EtcdExternalSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EtcdExternalSpec_To_kops_EtcdExternalSpec(in, out, s)
+}
+
+func autoConvert_kops_EtcdExternalSpec_To_v1alpha3_EtcdExternalSpec(in *kops.EtcdExternalSpec, out *EtcdExternalSpec, s conversion.Scope) error {
+	out.Endpoints = in.Endpoints
+	out.ClientSecret = in.ClientSecret
+	return nil
+}
+
+// Convert_kops_EtcdExternalSpec_To_v1alpha3_EtcdExternalSpec is an autogenerated conversion function.
+func Convert_kops_EtcdExternalSpec_To_v1alpha3_EtcdExternalSpec(in *kops.EtcdExternalSpec, out *EtcdExternalSpec, s conversion.Scope) error {
+	return autoConvert_kops_EtcdExternalSpec_To_v1alpha3_EtcdExternalSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_EtcdGRPCSpec_To_kops_EtcdGRPCSpec(in *EtcdGRPCSpec, out *kops.EtcdGRPCSpec, s conversion.Scope) error {
+	out.MaxConcurrentStreams = in.MaxConcurrentStreams
+	out.MaxRequestBytes = in.MaxRequestBytes
+	out.KeepaliveMinTime = in.KeepaliveMinTime
+	out.KeepaliveInterval = in.KeepaliveInterval
+	out.KeepaliveTimeout = in.KeepaliveTimeout
+	return nil
+}
+
+// Convert_v1alpha3_EtcdGRPCSpec_To_kops_EtcdGRPCSpec is an autogenerated conversion function.
+func Convert_v1alpha3_EtcdGRPCSpec_To_kops_EtcdGRPCSpec(in *EtcdGRPCSpec, out *kops.EtcdGRPCSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EtcdGRPCSpec_To_kops_EtcdGRPCSpec(in, out, s)
+}
+
+func autoConvert_kops_EtcdGRPCSpec_To_v1alpha3_EtcdGRPCSpec(in *kops.EtcdGRPCSpec, out *EtcdGRPCSpec, s conversion.Scope) error {
+	out.MaxConcurrentStreams = in.MaxConcurrentStreams
+	out.MaxRequestBytes = in.MaxRequestBytes
+	out.KeepaliveMinTime = in.KeepaliveMinTime
+	out.KeepaliveInterval = in.KeepaliveInterval
+	out.KeepaliveTimeout = in.KeepaliveTimeout
+	return nil
+}
+
+// Convert_kops_EtcdGRPCSpec_To_v1alpha3_EtcdGRPCSpec is an autogenerated conversion function.
+func Convert_kops_EtcdGRPCSpec_To_v1alpha3_EtcdGRPCSpec(in *kops.EtcdGRPCSpec, out *EtcdGRPCSpec, s conversion.Scope) error {
+	return autoConvert_kops_EtcdGRPCSpec_To_v1alpha3_EtcdGRPCSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_EtcdManagerSpec_To_kops_EtcdManagerSpec(in *EtcdManagerSpec, out *kops.EtcdManagerSpec, s conversion.Scope) error {
 	out.Image = in.Image
 	if in.Env != nil {
@@ -4025,6 +5154,140 @@ func Convert_kops_EtcdMemberSpec_To_v1alpha3_EtcdMemberSpec(in *kops.EtcdMemberS
 	return autoConvert_kops_EtcdMemberSpec_To_v1alpha3_EtcdMemberSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_EtcdTLSSpec_To_kops_EtcdTLSSpec(in *EtcdTLSSpec, out *kops.EtcdTLSSpec, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.MaxVersion = in.MaxVersion
+	out.CipherSuites = in.CipherSuites
+	return nil
+}
+
+// Convert_v1alpha3_EtcdTLSSpec_To_kops_EtcdTLSSpec is an autogenerated conversion function.
+func Convert_v1alpha3_EtcdTLSSpec_To_kops_EtcdTLSSpec(in *EtcdTLSSpec, out *kops.EtcdTLSSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EtcdTLSSpec_To_kops_EtcdTLSSpec(in, out, s)
+}
+
+func autoConvert_kops_EtcdTLSSpec_To_v1alpha3_EtcdTLSSpec(in *kops.EtcdTLSSpec, out *EtcdTLSSpec, s conversion.Scope) error {
+	out.MinVersion = in.MinVersion
+	out.MaxVersion = in.MaxVersion
+	out.CipherSuites = in.CipherSuites
+	return nil
+}
+
+// Convert_kops_EtcdTLSSpec_To_v1alpha3_EtcdTLSSpec is an autogenerated conversion function.
+func Convert_kops_EtcdTLSSpec_To_v1alpha3_EtcdTLSSpec(in *kops.EtcdTLSSpec, out *EtcdTLSSpec, s conversion.Scope) error {
+	return autoConvert_kops_EtcdTLSSpec_To_v1alpha3_EtcdTLSSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_EventExporterCloudWatchSpec_To_kops_EventExporterCloudWatchSpec(in *EventExporterCloudWatchSpec, out *kops.EventExporterCloudWatchSpec, s conversion.Scope) error {
+	out.LogGroupName = in.LogGroupName
+	out.RetentionInDays = in.RetentionInDays
+	return nil
+}
+
+// Convert_v1alpha3_EventExporterCloudWatchSpec_To_kops_EventExporterCloudWatchSpec is an autogenerated conversion function.
+func Convert_v1alpha3_EventExporterCloudWatchSpec_To_kops_EventExporterCloudWatchSpec(in *EventExporterCloudWatchSpec, out *kops.EventExporterCloudWatchSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EventExporterCloudWatchSpec_To_kops_EventExporterCloudWatchSpec(in, out, s)
+}
+
+func autoConvert_kops_EventExporterCloudWatchSpec_To_v1alpha3_EventExporterCloudWatchSpec(in *kops.EventExporterCloudWatchSpec, out *EventExporterCloudWatchSpec, s conversion.Scope) error {
+	out.LogGroupName = in.LogGroupName
+	out.RetentionInDays = in.RetentionInDays
+	return nil
+}
+
+// Convert_kops_EventExporterCloudWatchSpec_To_v1alpha3_EventExporterCloudWatchSpec is an autogenerated conversion function.
+func Convert_kops_EventExporterCloudWatchSpec_To_v1alpha3_EventExporterCloudWatchSpec(in *kops.EventExporterCloudWatchSpec, out *EventExporterCloudWatchSpec, s conversion.Scope) error {
+	return autoConvert_kops_EventExporterCloudWatchSpec_To_v1alpha3_EventExporterCloudWatchSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_EventExporterConfig_To_kops_EventExporterConfig(in *EventExporterConfig, out *kops.EventExporterConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.ForwarderImage = in.ForwarderImage
+	out.Sink = kops.EventExporterSink(in.Sink)
+	if in.CloudWatch != nil {
+		in, out := &in.CloudWatch, &out.CloudWatch
+		*out = new(kops.EventExporterCloudWatchSpec)
+		if err := Convert_v1alpha3_EventExporterCloudWatchSpec_To_kops_EventExporterCloudWatchSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudWatch = nil
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(kops.EventExporterLokiSpec)
+		if err := Convert_v1alpha3_EventExporterLokiSpec_To_kops_EventExporterLokiSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Loki = nil
+	}
+	out.MemoryRequest = in.MemoryRequest
+	out.CPURequest = in.CPURequest
+	return nil
+}
+
+// Convert_v1alpha3_EventExporterConfig_To_kops_EventExporterConfig is an autogenerated conversion function.
+func Convert_v1alpha3_EventExporterConfig_To_kops_EventExporterConfig(in *EventExporterConfig, out *kops.EventExporterConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EventExporterConfig_To_kops_EventExporterConfig(in, out, s)
+}
+
+func autoConvert_kops_EventExporterConfig_To_v1alpha3_EventExporterConfig(in *kops.EventExporterConfig, out *EventExporterConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.ForwarderImage = in.ForwarderImage
+	out.Sink = EventExporterSink(in.Sink)
+	if in.CloudWatch != nil {
+		in, out := &in.CloudWatch, &out.CloudWatch
+		*out = new(EventExporterCloudWatchSpec)
+		if err := Convert_kops_EventExporterCloudWatchSpec_To_v1alpha3_EventExporterCloudWatchSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudWatch = nil
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(EventExporterLokiSpec)
+		if err := Convert_kops_EventExporterLokiSpec_To_v1alpha3_EventExporterLokiSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Loki = nil
+	}
+	out.MemoryRequest = in.MemoryRequest
+	out.CPURequest = in.CPURequest
+	return nil
+}
+
+// Convert_kops_EventExporterConfig_To_v1alpha3_EventExporterConfig is an autogenerated conversion function.
+func Convert_kops_EventExporterConfig_To_v1alpha3_EventExporterConfig(in *kops.EventExporterConfig, out *EventExporterConfig, s conversion.Scope) error {
+	return autoConvert_kops_EventExporterConfig_To_v1alpha3_EventExporterConfig(in, out, s)
+}
+
+func autoConvert_v1alpha3_EventExporterLokiSpec_To_kops_EventExporterLokiSpec(in *EventExporterLokiSpec, out *kops.EventExporterLokiSpec, s conversion.Scope) error {
+	out.URL = in.URL
+	out.TenantID = in.TenantID
+	return nil
+}
+
+// Convert_v1alpha3_EventExporterLokiSpec_To_kops_EventExporterLokiSpec is an autogenerated conversion function.
+func Convert_v1alpha3_EventExporterLokiSpec_To_kops_EventExporterLokiSpec(in *EventExporterLokiSpec, out *kops.EventExporterLokiSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EventExporterLokiSpec_To_kops_EventExporterLokiSpec(in, out, s)
+}
+
+func autoConvert_kops_EventExporterLokiSpec_To_v1alpha3_EventExporterLokiSpec(in *kops.EventExporterLokiSpec, out *EventExporterLokiSpec, s conversion.Scope) error {
+	out.URL = in.URL
+	out.TenantID = in.TenantID
+	return nil
+}
+
+// Convert_kops_EventExporterLokiSpec_To_v1alpha3_EventExporterLokiSpec is an autogenerated conversion function.
+func Convert_kops_EventExporterLokiSpec_To_v1alpha3_EventExporterLokiSpec(in *kops.EventExporterLokiSpec, out *EventExporterLokiSpec, s conversion.Scope) error {
+	return autoConvert_kops_EventExporterLokiSpec_To_v1alpha3_EventExporterLokiSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_ExecContainerAction_To_kops_ExecContainerAction(in *ExecContainerAction, out *kops.ExecContainerAction, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Command = in.Command
@@ -4091,6 +5354,34 @@ func Convert_kops_ExternalNetworkingSpec_To_v1alpha3_ExternalNetworkingSpec(in *
 	return autoConvert_kops_ExternalNetworkingSpec_To_v1alpha3_ExternalNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_ExternalTaskSpec_To_kops_ExternalTaskSpec(in *ExternalTaskSpec, out *kops.ExternalTaskSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Command = in.Command
+	out.URL = in.URL
+	out.DependsOn = in.DependsOn
+	out.Config = in.Config
+	return nil
+}
+
+// Convert_v1alpha3_ExternalTaskSpec_To_kops_ExternalTaskSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ExternalTaskSpec_To_kops_ExternalTaskSpec(in *ExternalTaskSpec, out *kops.ExternalTaskSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ExternalTaskSpec_To_kops_ExternalTaskSpec(in, out, s)
+}
+
+func autoConvert_kops_ExternalTaskSpec_To_v1alpha3_ExternalTaskSpec(in *kops.ExternalTaskSpec, out *ExternalTaskSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Command = in.Command
+	out.URL = in.URL
+	out.DependsOn = in.DependsOn
+	out.Config = in.Config
+	return nil
+}
+
+// Convert_kops_ExternalTaskSpec_To_v1alpha3_ExternalTaskSpec is an autogenerated conversion function.
+func Convert_kops_ExternalTaskSpec_To_v1alpha3_ExternalTaskSpec(in *kops.ExternalTaskSpec, out *ExternalTaskSpec, s conversion.Scope) error {
+	return autoConvert_kops_ExternalTaskSpec_To_v1alpha3_ExternalTaskSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_FileAssetSpec_To_kops_FileAssetSpec(in *FileAssetSpec, out *kops.FileAssetSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Path = in.Path
@@ -4313,6 +5604,26 @@ func Convert_kops_HTTPProxy_To_v1alpha3_HTTPProxy(in *kops.HTTPProxy, out *HTTPP
 	return autoConvert_kops_HTTPProxy_To_v1alpha3_HTTPProxy(in, out, s)
 }
 
+func autoConvert_v1alpha3_HetznerInstanceGroupSpec_To_kops_HetznerInstanceGroupSpec(in *HetznerInstanceGroupSpec, out *kops.HetznerInstanceGroupSpec, s conversion.Scope) error {
+	out.PlacementGroup = in.PlacementGroup
+	return nil
+}
+
+// Convert_v1alpha3_HetznerInstanceGroupSpec_To_kops_HetznerInstanceGroupSpec is an autogenerated conversion function.
+func Convert_v1alpha3_HetznerInstanceGroupSpec_To_kops_HetznerInstanceGroupSpec(in *HetznerInstanceGroupSpec, out *kops.HetznerInstanceGroupSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_HetznerInstanceGroupSpec_To_kops_HetznerInstanceGroupSpec(in, out, s)
+}
+
+func autoConvert_kops_HetznerInstanceGroupSpec_To_v1alpha3_HetznerInstanceGroupSpec(in *kops.HetznerInstanceGroupSpec, out *HetznerInstanceGroupSpec, s conversion.Scope) error {
+	out.PlacementGroup = in.PlacementGroup
+	return nil
+}
+
+// Convert_kops_HetznerInstanceGroupSpec_To_v1alpha3_HetznerInstanceGroupSpec is an autogenerated conversion function.
+func Convert_kops_HetznerInstanceGroupSpec_To_v1alpha3_HetznerInstanceGroupSpec(in *kops.HetznerInstanceGroupSpec, out *HetznerInstanceGroupSpec, s conversion.Scope) error {
+	return autoConvert_kops_HetznerInstanceGroupSpec_To_v1alpha3_HetznerInstanceGroupSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_HetznerSpec_To_kops_HetznerSpec(in *HetznerSpec, out *kops.HetznerSpec, s conversion.Scope) error {
 	return nil
 }
@@ -4513,6 +5824,28 @@ func Convert_kops_InstanceGroup_To_v1alpha3_InstanceGroup(in *kops.InstanceGroup
 	return autoConvert_kops_InstanceGroup_To_v1alpha3_InstanceGroup(in, out, s)
 }
 
+func autoConvert_v1alpha3_InstanceGroupHealthCheckSpec_To_kops_InstanceGroupHealthCheckSpec(in *InstanceGroupHealthCheckSpec, out *kops.InstanceGroupHealthCheckSpec, s conversion.Scope) error {
+	out.Type = in.Type
+	out.GracePeriod = in.GracePeriod
+	return nil
+}
+
+// Convert_v1alpha3_InstanceGroupHealthCheckSpec_To_kops_InstanceGroupHealthCheckSpec is an autogenerated conversion function.
+func Convert_v1alpha3_InstanceGroupHealthCheckSpec_To_kops_InstanceGroupHealthCheckSpec(in *InstanceGroupHealthCheckSpec, out *kops.InstanceGroupHealthCheckSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_InstanceGroupHealthCheckSpec_To_kops_InstanceGroupHealthCheckSpec(in, out, s)
+}
+
+func autoConvert_kops_InstanceGroupHealthCheckSpec_To_v1alpha3_InstanceGroupHealthCheckSpec(in *kops.InstanceGroupHealthCheckSpec, out *InstanceGroupHealthCheckSpec, s conversion.Scope) error {
+	out.Type = in.Type
+	out.GracePeriod = in.GracePeriod
+	return nil
+}
+
+// Convert_kops_InstanceGroupHealthCheckSpec_To_v1alpha3_InstanceGroupHealthCheckSpec is an autogenerated conversion function.
+func Convert_kops_InstanceGroupHealthCheckSpec_To_v1alpha3_InstanceGroupHealthCheckSpec(in *kops.InstanceGroupHealthCheckSpec, out *InstanceGroupHealthCheckSpec, s conversion.Scope) error {
+	return autoConvert_kops_InstanceGroupHealthCheckSpec_To_v1alpha3_InstanceGroupHealthCheckSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_InstanceGroupList_To_kops_InstanceGroupList(in *InstanceGroupList, out *kops.InstanceGroupList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -4627,6 +5960,7 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.FileAssets = nil
 	}
 	out.Tenancy = in.Tenancy
+	out.HostResourceGroupARN = in.HostResourceGroupARN
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(kops.KubeletConfigSpec)
@@ -4659,6 +5993,7 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.AdditionalUserData = nil
 	}
 	out.SuspendProcesses = in.SuspendProcesses
+	out.Suspended = in.Suspended
 	if in.ExternalLoadBalancers != nil {
 		in, out := &in.ExternalLoadBalancers, &out.ExternalLoadBalancers
 		*out = make([]kops.LoadBalancerSpec, len(*in))
@@ -4723,6 +6058,15 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.Containerd = nil
 	}
 	out.Packages = in.Packages
+	if in.LogRotation != nil {
+		in, out := &in.LogRotation, &out.LogRotation
+		*out = new(kops.LogRotationSpec)
+		if err := Convert_v1alpha3_LogRotationSpec_To_kops_LogRotationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LogRotation = nil
+	}
 	if in.GuestAccelerators != nil {
 		in, out := &in.GuestAccelerators, &out.GuestAccelerators
 		*out = make([]kops.AcceleratorConfig, len(*in))
@@ -4735,16 +6079,102 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.GuestAccelerators = nil
 	}
 	out.MaxInstanceLifetime = in.MaxInstanceLifetime
-	out.GCPProvisioningModel = in.GCPProvisioningModel
-	return nil
-}
-
-// Convert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec is an autogenerated conversion function.
-func Convert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *InstanceGroupSpec, out *kops.InstanceGroupSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in, out, s)
-}
-
-func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.InstanceGroupSpec, out *InstanceGroupSpec, s conversion.Scope) error {
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(kops.InstanceGroupHealthCheckSpec)
+		if err := Convert_v1alpha3_InstanceGroupHealthCheckSpec_To_kops_InstanceGroupHealthCheckSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthCheck = nil
+	}
+	if in.InstanceMaintenancePolicy != nil {
+		in, out := &in.InstanceMaintenancePolicy, &out.InstanceMaintenancePolicy
+		*out = new(kops.InstanceMaintenancePolicySpec)
+		if err := Convert_v1alpha3_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InstanceMaintenancePolicy = nil
+	}
+	out.DefaultInstanceWarmup = in.DefaultInstanceWarmup
+	if in.ScalingPolicies != nil {
+		in, out := &in.ScalingPolicies, &out.ScalingPolicies
+		*out = make([]kops.ScalingPolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ScalingPolicySpec_To_kops_ScalingPolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ScalingPolicies = nil
+	}
+	if in.StaticNetworkInterface != nil {
+		in, out := &in.StaticNetworkInterface, &out.StaticNetworkInterface
+		*out = new(kops.StaticNetworkInterfaceSpec)
+		if err := Convert_v1alpha3_StaticNetworkInterfaceSpec_To_kops_StaticNetworkInterfaceSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StaticNetworkInterface = nil
+	}
+	if in.ConfidentialCompute != nil {
+		in, out := &in.ConfidentialCompute, &out.ConfidentialCompute
+		*out = new(kops.ConfidentialComputeSpec)
+		if err := Convert_v1alpha3_ConfidentialComputeSpec_To_kops_ConfidentialComputeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfidentialCompute = nil
+	}
+	if in.EFA != nil {
+		in, out := &in.EFA, &out.EFA
+		*out = new(kops.EFASpec)
+		if err := Convert_v1alpha3_EFASpec_To_kops_EFASpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EFA = nil
+	}
+	if in.AdditionalNetworkInterfaces != nil {
+		in, out := &in.AdditionalNetworkInterfaces, &out.AdditionalNetworkInterfaces
+		*out = make([]kops.AdditionalNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AdditionalNetworkInterfaceSpec_To_kops_AdditionalNetworkInterfaceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalNetworkInterfaces = nil
+	}
+	out.GCPProvisioningModel = in.GCPProvisioningModel
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(kops.AzureInstanceGroupSpec)
+		if err := Convert_v1alpha3_AzureInstanceGroupSpec_To_kops_AzureInstanceGroupSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Azure = nil
+	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(kops.HetznerInstanceGroupSpec)
+		if err := Convert_v1alpha3_HetznerInstanceGroupSpec_To_kops_HetznerInstanceGroupSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hetzner = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec is an autogenerated conversion function.
+func Convert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *InstanceGroupSpec, out *kops.InstanceGroupSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in, out, s)
+}
+
+func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.InstanceGroupSpec, out *InstanceGroupSpec, s conversion.Scope) error {
 	out.Manager = InstanceManager(in.Manager)
 	out.Role = InstanceGroupRole(in.Role)
 	out.Image = in.Image
@@ -4816,6 +6246,7 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 		out.FileAssets = nil
 	}
 	out.Tenancy = in.Tenancy
+	out.HostResourceGroupARN = in.HostResourceGroupARN
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(KubeletConfigSpec)
@@ -4848,6 +6279,7 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 		out.AdditionalUserData = nil
 	}
 	out.SuspendProcesses = in.SuspendProcesses
+	out.Suspended = in.Suspended
 	if in.ExternalLoadBalancers != nil {
 		in, out := &in.ExternalLoadBalancers, &out.ExternalLoadBalancers
 		*out = make([]LoadBalancerSpec, len(*in))
@@ -4912,6 +6344,15 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 		out.Containerd = nil
 	}
 	out.Packages = in.Packages
+	if in.LogRotation != nil {
+		in, out := &in.LogRotation, &out.LogRotation
+		*out = new(LogRotationSpec)
+		if err := Convert_kops_LogRotationSpec_To_v1alpha3_LogRotationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LogRotation = nil
+	}
 	if in.GuestAccelerators != nil {
 		in, out := &in.GuestAccelerators, &out.GuestAccelerators
 		*out = make([]AcceleratorConfig, len(*in))
@@ -4924,7 +6365,93 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 		out.GuestAccelerators = nil
 	}
 	out.MaxInstanceLifetime = in.MaxInstanceLifetime
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(InstanceGroupHealthCheckSpec)
+		if err := Convert_kops_InstanceGroupHealthCheckSpec_To_v1alpha3_InstanceGroupHealthCheckSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthCheck = nil
+	}
+	if in.InstanceMaintenancePolicy != nil {
+		in, out := &in.InstanceMaintenancePolicy, &out.InstanceMaintenancePolicy
+		*out = new(InstanceMaintenancePolicySpec)
+		if err := Convert_kops_InstanceMaintenancePolicySpec_To_v1alpha3_InstanceMaintenancePolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InstanceMaintenancePolicy = nil
+	}
+	out.DefaultInstanceWarmup = in.DefaultInstanceWarmup
+	if in.ScalingPolicies != nil {
+		in, out := &in.ScalingPolicies, &out.ScalingPolicies
+		*out = make([]ScalingPolicySpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ScalingPolicySpec_To_v1alpha3_ScalingPolicySpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ScalingPolicies = nil
+	}
+	if in.StaticNetworkInterface != nil {
+		in, out := &in.StaticNetworkInterface, &out.StaticNetworkInterface
+		*out = new(StaticNetworkInterfaceSpec)
+		if err := Convert_kops_StaticNetworkInterfaceSpec_To_v1alpha3_StaticNetworkInterfaceSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StaticNetworkInterface = nil
+	}
+	if in.ConfidentialCompute != nil {
+		in, out := &in.ConfidentialCompute, &out.ConfidentialCompute
+		*out = new(ConfidentialComputeSpec)
+		if err := Convert_kops_ConfidentialComputeSpec_To_v1alpha3_ConfidentialComputeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfidentialCompute = nil
+	}
+	if in.EFA != nil {
+		in, out := &in.EFA, &out.EFA
+		*out = new(EFASpec)
+		if err := Convert_kops_EFASpec_To_v1alpha3_EFASpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EFA = nil
+	}
+	if in.AdditionalNetworkInterfaces != nil {
+		in, out := &in.AdditionalNetworkInterfaces, &out.AdditionalNetworkInterfaces
+		*out = make([]AdditionalNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AdditionalNetworkInterfaceSpec_To_v1alpha3_AdditionalNetworkInterfaceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalNetworkInterfaces = nil
+	}
 	out.GCPProvisioningModel = in.GCPProvisioningModel
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureInstanceGroupSpec)
+		if err := Convert_kops_AzureInstanceGroupSpec_To_v1alpha3_AzureInstanceGroupSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Azure = nil
+	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(HetznerInstanceGroupSpec)
+		if err := Convert_kops_HetznerInstanceGroupSpec_To_v1alpha3_HetznerInstanceGroupSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Hetzner = nil
+	}
 	return nil
 }
 
@@ -4933,6 +6460,28 @@ func Convert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.Insta
 	return autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(in *InstanceMaintenancePolicySpec, out *kops.InstanceMaintenancePolicySpec, s conversion.Scope) error {
+	out.MinHealthyPercentage = in.MinHealthyPercentage
+	out.MaxHealthyPercentage = in.MaxHealthyPercentage
+	return nil
+}
+
+// Convert_v1alpha3_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec is an autogenerated conversion function.
+func Convert_v1alpha3_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(in *InstanceMaintenancePolicySpec, out *kops.InstanceMaintenancePolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_InstanceMaintenancePolicySpec_To_kops_InstanceMaintenancePolicySpec(in, out, s)
+}
+
+func autoConvert_kops_InstanceMaintenancePolicySpec_To_v1alpha3_InstanceMaintenancePolicySpec(in *kops.InstanceMaintenancePolicySpec, out *InstanceMaintenancePolicySpec, s conversion.Scope) error {
+	out.MinHealthyPercentage = in.MinHealthyPercentage
+	out.MaxHealthyPercentage = in.MaxHealthyPercentage
+	return nil
+}
+
+// Convert_kops_InstanceMaintenancePolicySpec_To_v1alpha3_InstanceMaintenancePolicySpec is an autogenerated conversion function.
+func Convert_kops_InstanceMaintenancePolicySpec_To_v1alpha3_InstanceMaintenancePolicySpec(in *kops.InstanceMaintenancePolicySpec, out *InstanceMaintenancePolicySpec, s conversion.Scope) error {
+	return autoConvert_kops_InstanceMaintenancePolicySpec_To_v1alpha3_InstanceMaintenancePolicySpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_InstanceMetadataOptions_To_kops_InstanceMetadataOptions(in *InstanceMetadataOptions, out *kops.InstanceMetadataOptions, s conversion.Scope) error {
 	out.HTTPPutResponseHopLimit = in.HTTPPutResponseHopLimit
 	out.HTTPTokens = in.HTTPTokens
@@ -4974,6 +6523,8 @@ func autoConvert_v1alpha3_InstanceRequirementsSpec_To_kops_InstanceRequirementsS
 	} else {
 		out.Memory = nil
 	}
+	out.Architectures = in.Architectures
+	out.ExcludedInstanceTypes = in.ExcludedInstanceTypes
 	return nil
 }
 
@@ -5001,6 +6552,8 @@ func autoConvert_kops_InstanceRequirementsSpec_To_v1alpha3_InstanceRequirementsS
 	} else {
 		out.Memory = nil
 	}
+	out.Architectures = in.Architectures
+	out.ExcludedInstanceTypes = in.ExcludedInstanceTypes
 	return nil
 }
 
@@ -5041,6 +6594,52 @@ func Convert_kops_InstanceRootVolumeSpec_To_v1alpha3_InstanceRootVolumeSpec(in *
 	return autoConvert_kops_InstanceRootVolumeSpec_To_v1alpha3_InstanceRootVolumeSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_InternalLoadBalancerAccessSpec_To_kops_InternalLoadBalancerAccessSpec(in *InternalLoadBalancerAccessSpec, out *kops.InternalLoadBalancerAccessSpec, s conversion.Scope) error {
+	out.Access = in.Access
+	out.SSLCertificate = in.SSLCertificate
+	out.SSLPolicy = in.SSLPolicy
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]kops.LoadBalancerSubnetSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_LoadBalancerSubnetSpec_To_kops_LoadBalancerSubnetSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Subnets = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_InternalLoadBalancerAccessSpec_To_kops_InternalLoadBalancerAccessSpec is an autogenerated conversion function.
+func Convert_v1alpha3_InternalLoadBalancerAccessSpec_To_kops_InternalLoadBalancerAccessSpec(in *InternalLoadBalancerAccessSpec, out *kops.InternalLoadBalancerAccessSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_InternalLoadBalancerAccessSpec_To_kops_InternalLoadBalancerAccessSpec(in, out, s)
+}
+
+func autoConvert_kops_InternalLoadBalancerAccessSpec_To_v1alpha3_InternalLoadBalancerAccessSpec(in *kops.InternalLoadBalancerAccessSpec, out *InternalLoadBalancerAccessSpec, s conversion.Scope) error {
+	out.Access = in.Access
+	out.SSLCertificate = in.SSLCertificate
+	out.SSLPolicy = in.SSLPolicy
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]LoadBalancerSubnetSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_LoadBalancerSubnetSpec_To_v1alpha3_LoadBalancerSubnetSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Subnets = nil
+	}
+	return nil
+}
+
+// Convert_kops_InternalLoadBalancerAccessSpec_To_v1alpha3_InternalLoadBalancerAccessSpec is an autogenerated conversion function.
+func Convert_kops_InternalLoadBalancerAccessSpec_To_v1alpha3_InternalLoadBalancerAccessSpec(in *kops.InternalLoadBalancerAccessSpec, out *InternalLoadBalancerAccessSpec, s conversion.Scope) error {
+	return autoConvert_kops_InternalLoadBalancerAccessSpec_To_v1alpha3_InternalLoadBalancerAccessSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_KarpenterConfig_To_kops_KarpenterConfig(in *KarpenterConfig, out *kops.KarpenterConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.LogEncoding = in.LogEncoding
@@ -5945,6 +7544,7 @@ func autoConvert_v1alpha3_KubeletConfigSpec_To_kops_KubeletConfigSpec(in *Kubele
 	out.ShutdownGracePeriod = in.ShutdownGracePeriod
 	out.ShutdownGracePeriodCriticalPods = in.ShutdownGracePeriodCriticalPods
 	out.MemorySwapBehavior = in.MemorySwapBehavior
+	out.ServerTLSBootstrap = in.ServerTLSBootstrap
 	return nil
 }
 
@@ -6049,6 +7649,7 @@ func autoConvert_kops_KubeletConfigSpec_To_v1alpha3_KubeletConfigSpec(in *kops.K
 	out.ShutdownGracePeriod = in.ShutdownGracePeriod
 	out.ShutdownGracePeriodCriticalPods = in.ShutdownGracePeriodCriticalPods
 	out.MemorySwapBehavior = in.MemorySwapBehavior
+	out.ServerTLSBootstrap = in.ServerTLSBootstrap
 	return nil
 }
 
@@ -6134,6 +7735,17 @@ func autoConvert_v1alpha3_LoadBalancerAccessSpec_To_kops_LoadBalancerAccessSpec(
 	out.UseForInternalAPI = in.UseForInternalAPI
 	out.SSLCertificate = in.SSLCertificate
 	out.SSLPolicy = in.SSLPolicy
+	if in.ACMCertificate != nil {
+		in, out := &in.ACMCertificate, &out.ACMCertificate
+		*out = new(kops.ACMCertificateSpec)
+		if err := Convert_v1alpha3_ACMCertificateSpec_To_kops_ACMCertificateSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ACMCertificate = nil
+	}
+	out.TLSMode = kops.LoadBalancerTLSMode(in.TLSMode)
+	out.ALPNPolicy = in.ALPNPolicy
 	out.CrossZoneLoadBalancing = in.CrossZoneLoadBalancing
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
@@ -6155,6 +7767,15 @@ func autoConvert_v1alpha3_LoadBalancerAccessSpec_To_kops_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(kops.InternalLoadBalancerAccessSpec)
+		if err := Convert_v1alpha3_InternalLoadBalancerAccessSpec_To_kops_InternalLoadBalancerAccessSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Internal = nil
+	}
 	return nil
 }
 
@@ -6172,6 +7793,17 @@ func autoConvert_kops_LoadBalancerAccessSpec_To_v1alpha3_LoadBalancerAccessSpec(
 	out.UseForInternalAPI = in.UseForInternalAPI
 	out.SSLCertificate = in.SSLCertificate
 	out.SSLPolicy = in.SSLPolicy
+	if in.ACMCertificate != nil {
+		in, out := &in.ACMCertificate, &out.ACMCertificate
+		*out = new(ACMCertificateSpec)
+		if err := Convert_kops_ACMCertificateSpec_To_v1alpha3_ACMCertificateSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ACMCertificate = nil
+	}
+	out.TLSMode = LoadBalancerTLSMode(in.TLSMode)
+	out.ALPNPolicy = in.ALPNPolicy
 	out.CrossZoneLoadBalancing = in.CrossZoneLoadBalancing
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
@@ -6193,6 +7825,15 @@ func autoConvert_kops_LoadBalancerAccessSpec_To_v1alpha3_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(InternalLoadBalancerAccessSpec)
+		if err := Convert_kops_InternalLoadBalancerAccessSpec_To_v1alpha3_InternalLoadBalancerAccessSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Internal = nil
+	}
 	return nil
 }
 
@@ -6275,6 +7916,30 @@ func Convert_kops_LoadBalancerSubnetSpec_To_v1alpha3_LoadBalancerSubnetSpec(in *
 	return autoConvert_kops_LoadBalancerSubnetSpec_To_v1alpha3_LoadBalancerSubnetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_LogRotationSpec_To_kops_LogRotationSpec(in *LogRotationSpec, out *kops.LogRotationSpec, s conversion.Scope) error {
+	out.MaxSize = in.MaxSize
+	out.MaxFiles = in.MaxFiles
+	out.JournalMaxUse = in.JournalMaxUse
+	return nil
+}
+
+// Convert_v1alpha3_LogRotationSpec_To_kops_LogRotationSpec is an autogenerated conversion function.
+func Convert_v1alpha3_LogRotationSpec_To_kops_LogRotationSpec(in *LogRotationSpec, out *kops.LogRotationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_LogRotationSpec_To_kops_LogRotationSpec(in, out, s)
+}
+
+func autoConvert_kops_LogRotationSpec_To_v1alpha3_LogRotationSpec(in *kops.LogRotationSpec, out *LogRotationSpec, s conversion.Scope) error {
+	out.MaxSize = in.MaxSize
+	out.MaxFiles = in.MaxFiles
+	out.JournalMaxUse = in.JournalMaxUse
+	return nil
+}
+
+// Convert_kops_LogRotationSpec_To_v1alpha3_LogRotationSpec is an autogenerated conversion function.
+func Convert_kops_LogRotationSpec_To_v1alpha3_LogRotationSpec(in *kops.LogRotationSpec, out *LogRotationSpec, s conversion.Scope) error {
+	return autoConvert_kops_LogRotationSpec_To_v1alpha3_LogRotationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_MetricsServerConfig_To_kops_MetricsServerConfig(in *MetricsServerConfig, out *kops.MetricsServerConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Image = in.Image
@@ -6395,6 +8060,7 @@ func Convert_kops_NRIConfig_To_v1alpha3_NRIConfig(in *kops.NRIConfig, out *NRICo
 
 func autoConvert_v1alpha3_NTPConfig_To_kops_NTPConfig(in *NTPConfig, out *kops.NTPConfig, s conversion.Scope) error {
 	out.Managed = in.Managed
+	out.Servers = in.Servers
 	return nil
 }
 
@@ -6405,6 +8071,7 @@ func Convert_v1alpha3_NTPConfig_To_kops_NTPConfig(in *NTPConfig, out *kops.NTPCo
 
 func autoConvert_kops_NTPConfig_To_v1alpha3_NTPConfig(in *kops.NTPConfig, out *NTPConfig, s conversion.Scope) error {
 	out.Managed = in.Managed
+	out.Servers = in.Servers
 	return nil
 }
 
@@ -6451,6 +8118,35 @@ func autoConvert_v1alpha3_NetworkingSpec_To_kops_NetworkingSpec(in *NetworkingSp
 	out.PodCIDR = in.PodCIDR
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
 	out.IsolateControlPlane = in.IsolateControlPlane
+	if in.DHCPOptions != nil {
+		in, out := &in.DHCPOptions, &out.DHCPOptions
+		*out = new(kops.DHCPOptionsSpec)
+		if err := Convert_v1alpha3_DHCPOptionsSpec_To_kops_DHCPOptionsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DHCPOptions = nil
+	}
+	if in.TransitGateway != nil {
+		in, out := &in.TransitGateway, &out.TransitGateway
+		*out = new(kops.TransitGatewaySpec)
+		if err := Convert_v1alpha3_TransitGatewaySpec_To_kops_TransitGatewaySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TransitGateway = nil
+	}
+	if in.VPCEndpoints != nil {
+		in, out := &in.VPCEndpoints, &out.VPCEndpoints
+		*out = make([]kops.VPCEndpointSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VPCEndpointSpec_To_kops_VPCEndpointSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.VPCEndpoints = nil
+	}
 	out.Classic = in.Classic
 	if in.Kubenet != nil {
 		in, out := &in.Kubenet, &out.Kubenet
@@ -6608,6 +8304,35 @@ func autoConvert_kops_NetworkingSpec_To_v1alpha3_NetworkingSpec(in *kops.Network
 	out.PodCIDR = in.PodCIDR
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
 	out.IsolateControlPlane = in.IsolateControlPlane
+	if in.DHCPOptions != nil {
+		in, out := &in.DHCPOptions, &out.DHCPOptions
+		*out = new(DHCPOptionsSpec)
+		if err := Convert_kops_DHCPOptionsSpec_To_v1alpha3_DHCPOptionsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DHCPOptions = nil
+	}
+	if in.TransitGateway != nil {
+		in, out := &in.TransitGateway, &out.TransitGateway
+		*out = new(TransitGatewaySpec)
+		if err := Convert_kops_TransitGatewaySpec_To_v1alpha3_TransitGatewaySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TransitGateway = nil
+	}
+	if in.VPCEndpoints != nil {
+		in, out := &in.VPCEndpoints, &out.VPCEndpoints
+		*out = make([]VPCEndpointSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_VPCEndpointSpec_To_v1alpha3_VPCEndpointSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.VPCEndpoints = nil
+	}
 	out.Classic = in.Classic
 	if in.Kubenet != nil {
 		in, out := &in.Kubenet, &out.Kubenet
@@ -6727,6 +8452,28 @@ func Convert_kops_NetworkingSpec_To_v1alpha3_NetworkingSpec(in *kops.NetworkingS
 	return autoConvert_kops_NetworkingSpec_To_v1alpha3_NetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_NitroEnclavesSpec_To_kops_NitroEnclavesSpec(in *NitroEnclavesSpec, out *kops.NitroEnclavesSpec, s conversion.Scope) error {
+	out.CPUCount = in.CPUCount
+	out.MemoryMiB = in.MemoryMiB
+	return nil
+}
+
+// Convert_v1alpha3_NitroEnclavesSpec_To_kops_NitroEnclavesSpec is an autogenerated conversion function.
+func Convert_v1alpha3_NitroEnclavesSpec_To_kops_NitroEnclavesSpec(in *NitroEnclavesSpec, out *kops.NitroEnclavesSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NitroEnclavesSpec_To_kops_NitroEnclavesSpec(in, out, s)
+}
+
+func autoConvert_kops_NitroEnclavesSpec_To_v1alpha3_NitroEnclavesSpec(in *kops.NitroEnclavesSpec, out *NitroEnclavesSpec, s conversion.Scope) error {
+	out.CPUCount = in.CPUCount
+	out.MemoryMiB = in.MemoryMiB
+	return nil
+}
+
+// Convert_kops_NitroEnclavesSpec_To_v1alpha3_NitroEnclavesSpec is an autogenerated conversion function.
+func Convert_kops_NitroEnclavesSpec_To_v1alpha3_NitroEnclavesSpec(in *kops.NitroEnclavesSpec, out *NitroEnclavesSpec, s conversion.Scope) error {
+	return autoConvert_kops_NitroEnclavesSpec_To_v1alpha3_NitroEnclavesSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_NodeLocalDNSConfig_To_kops_NodeLocalDNSConfig(in *NodeLocalDNSConfig, out *kops.NodeLocalDNSConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.ExternalCoreFile = in.ExternalCoreFile
@@ -6955,40 +8702,142 @@ func Convert_kops_OpenstackBlockStorageConfig_To_v1alpha3_OpenstackBlockStorageC
 	return autoConvert_kops_OpenstackBlockStorageConfig_To_v1alpha3_OpenstackBlockStorageConfig(in, out, s)
 }
 
-func autoConvert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig(in *OpenstackLoadbalancerConfig, out *kops.OpenstackLoadbalancerConfig, s conversion.Scope) error {
-	out.Method = in.Method
-	out.Provider = in.Provider
-	out.UseOctavia = in.UseOctavia
-	out.FloatingNetwork = in.FloatingNetwork
-	out.FloatingNetworkID = in.FloatingNetworkID
-	out.FloatingSubnet = in.FloatingSubnet
-	out.SubnetID = in.SubnetID
-	out.ManageSecGroups = in.ManageSecGroups
-	out.EnableIngressHostname = in.EnableIngressHostname
-	out.IngressHostnameSuffix = in.IngressHostnameSuffix
-	out.FlavorID = in.FlavorID
+func autoConvert_v1alpha3_OpenstackL7Policy_To_kops_OpenstackL7Policy(in *OpenstackL7Policy, out *kops.OpenstackL7Policy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Action = in.Action
+	out.Position = in.Position
+	out.PoolPort = in.PoolPort
+	out.PoolTLS = in.PoolTLS
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]kops.OpenstackL7Rule, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_OpenstackL7Rule_To_kops_OpenstackL7Rule(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Rules = nil
+	}
 	return nil
 }
 
-// Convert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig is an autogenerated conversion function.
-func Convert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig(in *OpenstackLoadbalancerConfig, out *kops.OpenstackLoadbalancerConfig, s conversion.Scope) error {
-	return autoConvert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig(in, out, s)
+// Convert_v1alpha3_OpenstackL7Policy_To_kops_OpenstackL7Policy is an autogenerated conversion function.
+func Convert_v1alpha3_OpenstackL7Policy_To_kops_OpenstackL7Policy(in *OpenstackL7Policy, out *kops.OpenstackL7Policy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_OpenstackL7Policy_To_kops_OpenstackL7Policy(in, out, s)
 }
 
-func autoConvert_kops_OpenstackLoadbalancerConfig_To_v1alpha3_OpenstackLoadbalancerConfig(in *kops.OpenstackLoadbalancerConfig, out *OpenstackLoadbalancerConfig, s conversion.Scope) error {
-	out.Method = in.Method
-	out.Provider = in.Provider
-	out.UseOctavia = in.UseOctavia
-	out.FloatingNetwork = in.FloatingNetwork
-	out.FloatingNetworkID = in.FloatingNetworkID
-	out.FloatingSubnet = in.FloatingSubnet
-	out.SubnetID = in.SubnetID
-	out.ManageSecGroups = in.ManageSecGroups
-	out.EnableIngressHostname = in.EnableIngressHostname
-	out.IngressHostnameSuffix = in.IngressHostnameSuffix
-	out.FlavorID = in.FlavorID
-	return nil
-}
+func autoConvert_kops_OpenstackL7Policy_To_v1alpha3_OpenstackL7Policy(in *kops.OpenstackL7Policy, out *OpenstackL7Policy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Action = in.Action
+	out.Position = in.Position
+	out.PoolPort = in.PoolPort
+	out.PoolTLS = in.PoolTLS
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]OpenstackL7Rule, len(*in))
+		for i := range *in {
+			if err := Convert_kops_OpenstackL7Rule_To_v1alpha3_OpenstackL7Rule(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Rules = nil
+	}
+	return nil
+}
+
+// Convert_kops_OpenstackL7Policy_To_v1alpha3_OpenstackL7Policy is an autogenerated conversion function.
+func Convert_kops_OpenstackL7Policy_To_v1alpha3_OpenstackL7Policy(in *kops.OpenstackL7Policy, out *OpenstackL7Policy, s conversion.Scope) error {
+	return autoConvert_kops_OpenstackL7Policy_To_v1alpha3_OpenstackL7Policy(in, out, s)
+}
+
+func autoConvert_v1alpha3_OpenstackL7Rule_To_kops_OpenstackL7Rule(in *OpenstackL7Rule, out *kops.OpenstackL7Rule, s conversion.Scope) error {
+	out.Type = in.Type
+	out.CompareType = in.CompareType
+	out.Key = in.Key
+	out.Value = in.Value
+	out.Invert = in.Invert
+	return nil
+}
+
+// Convert_v1alpha3_OpenstackL7Rule_To_kops_OpenstackL7Rule is an autogenerated conversion function.
+func Convert_v1alpha3_OpenstackL7Rule_To_kops_OpenstackL7Rule(in *OpenstackL7Rule, out *kops.OpenstackL7Rule, s conversion.Scope) error {
+	return autoConvert_v1alpha3_OpenstackL7Rule_To_kops_OpenstackL7Rule(in, out, s)
+}
+
+func autoConvert_kops_OpenstackL7Rule_To_v1alpha3_OpenstackL7Rule(in *kops.OpenstackL7Rule, out *OpenstackL7Rule, s conversion.Scope) error {
+	out.Type = in.Type
+	out.CompareType = in.CompareType
+	out.Key = in.Key
+	out.Value = in.Value
+	out.Invert = in.Invert
+	return nil
+}
+
+// Convert_kops_OpenstackL7Rule_To_v1alpha3_OpenstackL7Rule is an autogenerated conversion function.
+func Convert_kops_OpenstackL7Rule_To_v1alpha3_OpenstackL7Rule(in *kops.OpenstackL7Rule, out *OpenstackL7Rule, s conversion.Scope) error {
+	return autoConvert_kops_OpenstackL7Rule_To_v1alpha3_OpenstackL7Rule(in, out, s)
+}
+
+func autoConvert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig(in *OpenstackLoadbalancerConfig, out *kops.OpenstackLoadbalancerConfig, s conversion.Scope) error {
+	out.Method = in.Method
+	out.Provider = in.Provider
+	out.UseOctavia = in.UseOctavia
+	out.FloatingNetwork = in.FloatingNetwork
+	out.FloatingNetworkID = in.FloatingNetworkID
+	out.FloatingSubnet = in.FloatingSubnet
+	out.SubnetID = in.SubnetID
+	out.ManageSecGroups = in.ManageSecGroups
+	out.EnableIngressHostname = in.EnableIngressHostname
+	out.IngressHostnameSuffix = in.IngressHostnameSuffix
+	out.FlavorID = in.FlavorID
+	out.TLSContainerRef = in.TLSContainerRef
+	if in.L7Policies != nil {
+		in, out := &in.L7Policies, &out.L7Policies
+		*out = make([]kops.OpenstackL7Policy, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_OpenstackL7Policy_To_kops_OpenstackL7Policy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.L7Policies = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig is an autogenerated conversion function.
+func Convert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig(in *OpenstackLoadbalancerConfig, out *kops.OpenstackLoadbalancerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_OpenstackLoadbalancerConfig_To_kops_OpenstackLoadbalancerConfig(in, out, s)
+}
+
+func autoConvert_kops_OpenstackLoadbalancerConfig_To_v1alpha3_OpenstackLoadbalancerConfig(in *kops.OpenstackLoadbalancerConfig, out *OpenstackLoadbalancerConfig, s conversion.Scope) error {
+	out.Method = in.Method
+	out.Provider = in.Provider
+	out.UseOctavia = in.UseOctavia
+	out.FloatingNetwork = in.FloatingNetwork
+	out.FloatingNetworkID = in.FloatingNetworkID
+	out.FloatingSubnet = in.FloatingSubnet
+	out.SubnetID = in.SubnetID
+	out.ManageSecGroups = in.ManageSecGroups
+	out.EnableIngressHostname = in.EnableIngressHostname
+	out.IngressHostnameSuffix = in.IngressHostnameSuffix
+	out.FlavorID = in.FlavorID
+	out.TLSContainerRef = in.TLSContainerRef
+	if in.L7Policies != nil {
+		in, out := &in.L7Policies, &out.L7Policies
+		*out = make([]OpenstackL7Policy, len(*in))
+		for i := range *in {
+			if err := Convert_kops_OpenstackL7Policy_To_v1alpha3_OpenstackL7Policy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.L7Policies = nil
+	}
+	return nil
+}
 
 // Convert_kops_OpenstackLoadbalancerConfig_To_v1alpha3_OpenstackLoadbalancerConfig is an autogenerated conversion function.
 func Convert_kops_OpenstackLoadbalancerConfig_To_v1alpha3_OpenstackLoadbalancerConfig(in *kops.OpenstackLoadbalancerConfig, out *OpenstackLoadbalancerConfig, s conversion.Scope) error {
@@ -7289,6 +9138,160 @@ func Convert_kops_PodIdentityWebhookSpec_To_v1alpha3_PodIdentityWebhookSpec(in *
 	return autoConvert_kops_PodIdentityWebhookSpec_To_v1alpha3_PodIdentityWebhookSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_PrivateDNSNameOptionsSpec_To_kops_PrivateDNSNameOptionsSpec(in *PrivateDNSNameOptionsSpec, out *kops.PrivateDNSNameOptionsSpec, s conversion.Scope) error {
+	out.HostnameType = in.HostnameType
+	out.EnableResourceNameDNSARecord = in.EnableResourceNameDNSARecord
+	out.EnableResourceNameDNSAAAARecord = in.EnableResourceNameDNSAAAARecord
+	return nil
+}
+
+// Convert_v1alpha3_PrivateDNSNameOptionsSpec_To_kops_PrivateDNSNameOptionsSpec is an autogenerated conversion function.
+func Convert_v1alpha3_PrivateDNSNameOptionsSpec_To_kops_PrivateDNSNameOptionsSpec(in *PrivateDNSNameOptionsSpec, out *kops.PrivateDNSNameOptionsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PrivateDNSNameOptionsSpec_To_kops_PrivateDNSNameOptionsSpec(in, out, s)
+}
+
+func autoConvert_kops_PrivateDNSNameOptionsSpec_To_v1alpha3_PrivateDNSNameOptionsSpec(in *kops.PrivateDNSNameOptionsSpec, out *PrivateDNSNameOptionsSpec, s conversion.Scope) error {
+	out.HostnameType = in.HostnameType
+	out.EnableResourceNameDNSARecord = in.EnableResourceNameDNSARecord
+	out.EnableResourceNameDNSAAAARecord = in.EnableResourceNameDNSAAAARecord
+	return nil
+}
+
+// Convert_kops_PrivateDNSNameOptionsSpec_To_v1alpha3_PrivateDNSNameOptionsSpec is an autogenerated conversion function.
+func Convert_kops_PrivateDNSNameOptionsSpec_To_v1alpha3_PrivateDNSNameOptionsSpec(in *kops.PrivateDNSNameOptionsSpec, out *PrivateDNSNameOptionsSpec, s conversion.Scope) error {
+	return autoConvert_kops_PrivateDNSNameOptionsSpec_To_v1alpha3_PrivateDNSNameOptionsSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_PrivateDNSZoneVPCSpec_To_kops_PrivateDNSZoneVPCSpec(in *PrivateDNSZoneVPCSpec, out *kops.PrivateDNSZoneVPCSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Region = in.Region
+	return nil
+}
+
+// Convert_v1alpha3_PrivateDNSZoneVPCSpec_To_kops_PrivateDNSZoneVPCSpec is an autogenerated conversion function.
+func Convert_v1alpha3_PrivateDNSZoneVPCSpec_To_kops_PrivateDNSZoneVPCSpec(in *PrivateDNSZoneVPCSpec, out *kops.PrivateDNSZoneVPCSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PrivateDNSZoneVPCSpec_To_kops_PrivateDNSZoneVPCSpec(in, out, s)
+}
+
+func autoConvert_kops_PrivateDNSZoneVPCSpec_To_v1alpha3_PrivateDNSZoneVPCSpec(in *kops.PrivateDNSZoneVPCSpec, out *PrivateDNSZoneVPCSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Region = in.Region
+	return nil
+}
+
+// Convert_kops_PrivateDNSZoneVPCSpec_To_v1alpha3_PrivateDNSZoneVPCSpec is an autogenerated conversion function.
+func Convert_kops_PrivateDNSZoneVPCSpec_To_v1alpha3_PrivateDNSZoneVPCSpec(in *kops.PrivateDNSZoneVPCSpec, out *PrivateDNSZoneVPCSpec, s conversion.Scope) error {
+	return autoConvert_kops_PrivateDNSZoneVPCSpec_To_v1alpha3_PrivateDNSZoneVPCSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_PrometheusAgentConfig_To_kops_PrometheusAgentConfig(in *PrometheusAgentConfig, out *kops.PrometheusAgentConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.ScrapeInterval = in.ScrapeInterval
+	out.ExternalLabels = in.ExternalLabels
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = new(kops.PrometheusRemoteWriteSpec)
+		if err := Convert_v1alpha3_PrometheusRemoteWriteSpec_To_kops_PrometheusRemoteWriteSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemoteWrite = nil
+	}
+	out.MemoryRequest = in.MemoryRequest
+	out.CPURequest = in.CPURequest
+	return nil
+}
+
+// Convert_v1alpha3_PrometheusAgentConfig_To_kops_PrometheusAgentConfig is an autogenerated conversion function.
+func Convert_v1alpha3_PrometheusAgentConfig_To_kops_PrometheusAgentConfig(in *PrometheusAgentConfig, out *kops.PrometheusAgentConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PrometheusAgentConfig_To_kops_PrometheusAgentConfig(in, out, s)
+}
+
+func autoConvert_kops_PrometheusAgentConfig_To_v1alpha3_PrometheusAgentConfig(in *kops.PrometheusAgentConfig, out *PrometheusAgentConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.ScrapeInterval = in.ScrapeInterval
+	out.ExternalLabels = in.ExternalLabels
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = new(PrometheusRemoteWriteSpec)
+		if err := Convert_kops_PrometheusRemoteWriteSpec_To_v1alpha3_PrometheusRemoteWriteSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemoteWrite = nil
+	}
+	out.MemoryRequest = in.MemoryRequest
+	out.CPURequest = in.CPURequest
+	return nil
+}
+
+// Convert_kops_PrometheusAgentConfig_To_v1alpha3_PrometheusAgentConfig is an autogenerated conversion function.
+func Convert_kops_PrometheusAgentConfig_To_v1alpha3_PrometheusAgentConfig(in *kops.PrometheusAgentConfig, out *PrometheusAgentConfig, s conversion.Scope) error {
+	return autoConvert_kops_PrometheusAgentConfig_To_v1alpha3_PrometheusAgentConfig(in, out, s)
+}
+
+func autoConvert_v1alpha3_PrometheusRemoteWriteSpec_To_kops_PrometheusRemoteWriteSpec(in *PrometheusRemoteWriteSpec, out *kops.PrometheusRemoteWriteSpec, s conversion.Scope) error {
+	out.URL = in.URL
+	if in.SigV4 != nil {
+		in, out := &in.SigV4, &out.SigV4
+		*out = new(kops.PrometheusSigV4Spec)
+		if err := Convert_v1alpha3_PrometheusSigV4Spec_To_kops_PrometheusSigV4Spec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SigV4 = nil
+	}
+	out.BasicAuthSecretName = in.BasicAuthSecretName
+	return nil
+}
+
+// Convert_v1alpha3_PrometheusRemoteWriteSpec_To_kops_PrometheusRemoteWriteSpec is an autogenerated conversion function.
+func Convert_v1alpha3_PrometheusRemoteWriteSpec_To_kops_PrometheusRemoteWriteSpec(in *PrometheusRemoteWriteSpec, out *kops.PrometheusRemoteWriteSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PrometheusRemoteWriteSpec_To_kops_PrometheusRemoteWriteSpec(in, out, s)
+}
+
+func autoConvert_kops_PrometheusRemoteWriteSpec_To_v1alpha3_PrometheusRemoteWriteSpec(in *kops.PrometheusRemoteWriteSpec, out *PrometheusRemoteWriteSpec, s conversion.Scope) error {
+	out.URL = in.URL
+	if in.SigV4 != nil {
+		in, out := &in.SigV4, &out.SigV4
+		*out = new(PrometheusSigV4Spec)
+		if err := Convert_kops_PrometheusSigV4Spec_To_v1alpha3_PrometheusSigV4Spec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SigV4 = nil
+	}
+	out.BasicAuthSecretName = in.BasicAuthSecretName
+	return nil
+}
+
+// Convert_kops_PrometheusRemoteWriteSpec_To_v1alpha3_PrometheusRemoteWriteSpec is an autogenerated conversion function.
+func Convert_kops_PrometheusRemoteWriteSpec_To_v1alpha3_PrometheusRemoteWriteSpec(in *kops.PrometheusRemoteWriteSpec, out *PrometheusRemoteWriteSpec, s conversion.Scope) error {
+	return autoConvert_kops_PrometheusRemoteWriteSpec_To_v1alpha3_PrometheusRemoteWriteSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_PrometheusSigV4Spec_To_kops_PrometheusSigV4Spec(in *PrometheusSigV4Spec, out *kops.PrometheusSigV4Spec, s conversion.Scope) error {
+	out.Region = in.Region
+	return nil
+}
+
+// Convert_v1alpha3_PrometheusSigV4Spec_To_kops_PrometheusSigV4Spec is an autogenerated conversion function.
+func Convert_v1alpha3_PrometheusSigV4Spec_To_kops_PrometheusSigV4Spec(in *PrometheusSigV4Spec, out *kops.PrometheusSigV4Spec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_PrometheusSigV4Spec_To_kops_PrometheusSigV4Spec(in, out, s)
+}
+
+func autoConvert_kops_PrometheusSigV4Spec_To_v1alpha3_PrometheusSigV4Spec(in *kops.PrometheusSigV4Spec, out *PrometheusSigV4Spec, s conversion.Scope) error {
+	out.Region = in.Region
+	return nil
+}
+
+// Convert_kops_PrometheusSigV4Spec_To_v1alpha3_PrometheusSigV4Spec is an autogenerated conversion function.
+func Convert_kops_PrometheusSigV4Spec_To_v1alpha3_PrometheusSigV4Spec(in *kops.PrometheusSigV4Spec, out *PrometheusSigV4Spec, s conversion.Scope) error {
+	return autoConvert_kops_PrometheusSigV4Spec_To_v1alpha3_PrometheusSigV4Spec(in, out, s)
+}
+
 func autoConvert_v1alpha3_RBACAuthorizationSpec_To_kops_RBACAuthorizationSpec(in *RBACAuthorizationSpec, out *kops.RBACAuthorizationSpec, s conversion.Scope) error {
 	return nil
 }
@@ -7311,6 +9314,27 @@ func autoConvert_v1alpha3_RollingUpdate_To_kops_RollingUpdate(in *RollingUpdate,
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(kops.RollingUpdateCanary)
+		if err := Convert_v1alpha3_RollingUpdateCanary_To_kops_RollingUpdateCanary(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Canary = nil
+	}
+	out.ZoneSerial = in.ZoneSerial
+	out.MaxUnavailablePerZone = in.MaxUnavailablePerZone
+	out.Strategy = kops.RollingUpdateStrategy(in.Strategy)
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(kops.RollingUpdateDrain)
+		if err := Convert_v1alpha3_RollingUpdateDrain_To_kops_RollingUpdateDrain(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Drain = nil
+	}
 	return nil
 }
 
@@ -7323,6 +9347,27 @@ func autoConvert_kops_RollingUpdate_To_v1alpha3_RollingUpdate(in *kops.RollingUp
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(RollingUpdateCanary)
+		if err := Convert_kops_RollingUpdateCanary_To_v1alpha3_RollingUpdateCanary(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Canary = nil
+	}
+	out.ZoneSerial = in.ZoneSerial
+	out.MaxUnavailablePerZone = in.MaxUnavailablePerZone
+	out.Strategy = RollingUpdateStrategy(in.Strategy)
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(RollingUpdateDrain)
+		if err := Convert_kops_RollingUpdateDrain_To_v1alpha3_RollingUpdateDrain(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Drain = nil
+	}
 	return nil
 }
 
@@ -7331,6 +9376,98 @@ func Convert_kops_RollingUpdate_To_v1alpha3_RollingUpdate(in *kops.RollingUpdate
 	return autoConvert_kops_RollingUpdate_To_v1alpha3_RollingUpdate(in, out, s)
 }
 
+func autoConvert_v1alpha3_RollingUpdateCanary_To_kops_RollingUpdateCanary(in *RollingUpdateCanary, out *kops.RollingUpdateCanary, s conversion.Scope) error {
+	out.NetworkReady = in.NetworkReady
+	out.PodScheduling = in.PodScheduling
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(kops.RollingUpdateCanaryJob)
+		if err := Convert_v1alpha3_RollingUpdateCanaryJob_To_kops_RollingUpdateCanaryJob(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Job = nil
+	}
+	out.Timeout = in.Timeout
+	return nil
+}
+
+// Convert_v1alpha3_RollingUpdateCanary_To_kops_RollingUpdateCanary is an autogenerated conversion function.
+func Convert_v1alpha3_RollingUpdateCanary_To_kops_RollingUpdateCanary(in *RollingUpdateCanary, out *kops.RollingUpdateCanary, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RollingUpdateCanary_To_kops_RollingUpdateCanary(in, out, s)
+}
+
+func autoConvert_kops_RollingUpdateCanary_To_v1alpha3_RollingUpdateCanary(in *kops.RollingUpdateCanary, out *RollingUpdateCanary, s conversion.Scope) error {
+	out.NetworkReady = in.NetworkReady
+	out.PodScheduling = in.PodScheduling
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(RollingUpdateCanaryJob)
+		if err := Convert_kops_RollingUpdateCanaryJob_To_v1alpha3_RollingUpdateCanaryJob(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Job = nil
+	}
+	out.Timeout = in.Timeout
+	return nil
+}
+
+// Convert_kops_RollingUpdateCanary_To_v1alpha3_RollingUpdateCanary is an autogenerated conversion function.
+func Convert_kops_RollingUpdateCanary_To_v1alpha3_RollingUpdateCanary(in *kops.RollingUpdateCanary, out *RollingUpdateCanary, s conversion.Scope) error {
+	return autoConvert_kops_RollingUpdateCanary_To_v1alpha3_RollingUpdateCanary(in, out, s)
+}
+
+func autoConvert_v1alpha3_RollingUpdateCanaryJob_To_kops_RollingUpdateCanaryJob(in *RollingUpdateCanaryJob, out *kops.RollingUpdateCanaryJob, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Image = in.Image
+	out.Command = in.Command
+	return nil
+}
+
+// Convert_v1alpha3_RollingUpdateCanaryJob_To_kops_RollingUpdateCanaryJob is an autogenerated conversion function.
+func Convert_v1alpha3_RollingUpdateCanaryJob_To_kops_RollingUpdateCanaryJob(in *RollingUpdateCanaryJob, out *kops.RollingUpdateCanaryJob, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RollingUpdateCanaryJob_To_kops_RollingUpdateCanaryJob(in, out, s)
+}
+
+func autoConvert_kops_RollingUpdateCanaryJob_To_v1alpha3_RollingUpdateCanaryJob(in *kops.RollingUpdateCanaryJob, out *RollingUpdateCanaryJob, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Image = in.Image
+	out.Command = in.Command
+	return nil
+}
+
+// Convert_kops_RollingUpdateCanaryJob_To_v1alpha3_RollingUpdateCanaryJob is an autogenerated conversion function.
+func Convert_kops_RollingUpdateCanaryJob_To_v1alpha3_RollingUpdateCanaryJob(in *kops.RollingUpdateCanaryJob, out *RollingUpdateCanaryJob, s conversion.Scope) error {
+	return autoConvert_kops_RollingUpdateCanaryJob_To_v1alpha3_RollingUpdateCanaryJob(in, out, s)
+}
+
+func autoConvert_v1alpha3_RollingUpdateDrain_To_kops_RollingUpdateDrain(in *RollingUpdateDrain, out *kops.RollingUpdateDrain, s conversion.Scope) error {
+	out.GracePeriod = in.GracePeriod
+	out.Timeout = in.Timeout
+	out.SkipPDBLabels = in.SkipPDBLabels
+	out.TimeoutPolicy = kops.RollingUpdateDrainTimeoutPolicy(in.TimeoutPolicy)
+	return nil
+}
+
+// Convert_v1alpha3_RollingUpdateDrain_To_kops_RollingUpdateDrain is an autogenerated conversion function.
+func Convert_v1alpha3_RollingUpdateDrain_To_kops_RollingUpdateDrain(in *RollingUpdateDrain, out *kops.RollingUpdateDrain, s conversion.Scope) error {
+	return autoConvert_v1alpha3_RollingUpdateDrain_To_kops_RollingUpdateDrain(in, out, s)
+}
+
+func autoConvert_kops_RollingUpdateDrain_To_v1alpha3_RollingUpdateDrain(in *kops.RollingUpdateDrain, out *RollingUpdateDrain, s conversion.Scope) error {
+	out.GracePeriod = in.GracePeriod
+	out.Timeout = in.Timeout
+	out.SkipPDBLabels = in.SkipPDBLabels
+	out.TimeoutPolicy = RollingUpdateDrainTimeoutPolicy(in.TimeoutPolicy)
+	return nil
+}
+
+// Convert_kops_RollingUpdateDrain_To_v1alpha3_RollingUpdateDrain is an autogenerated conversion function.
+func Convert_kops_RollingUpdateDrain_To_v1alpha3_RollingUpdateDrain(in *kops.RollingUpdateDrain, out *RollingUpdateDrain, s conversion.Scope) error {
+	return autoConvert_kops_RollingUpdateDrain_To_v1alpha3_RollingUpdateDrain(in, out, s)
+}
+
 func autoConvert_v1alpha3_RouteSpec_To_kops_RouteSpec(in *RouteSpec, out *kops.RouteSpec, s conversion.Scope) error {
 	out.CIDR = in.CIDR
 	out.Target = in.Target
@@ -7497,6 +9634,86 @@ func Convert_kops_ScalewaySpec_To_v1alpha3_ScalewaySpec(in *kops.ScalewaySpec, o
 	return autoConvert_kops_ScalewaySpec_To_v1alpha3_ScalewaySpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_ScalingPolicySpec_To_kops_ScalingPolicySpec(in *ScalingPolicySpec, out *kops.ScalingPolicySpec, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.TargetTracking != nil {
+		in, out := &in.TargetTracking, &out.TargetTracking
+		*out = new(kops.TargetTrackingScalingPolicySpec)
+		if err := Convert_v1alpha3_TargetTrackingScalingPolicySpec_To_kops_TargetTrackingScalingPolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetTracking = nil
+	}
+	if in.Step != nil {
+		in, out := &in.Step, &out.Step
+		*out = new(kops.StepScalingPolicySpec)
+		if err := Convert_v1alpha3_StepScalingPolicySpec_To_kops_StepScalingPolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Step = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ScalingPolicySpec_To_kops_ScalingPolicySpec is an autogenerated conversion function.
+func Convert_v1alpha3_ScalingPolicySpec_To_kops_ScalingPolicySpec(in *ScalingPolicySpec, out *kops.ScalingPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ScalingPolicySpec_To_kops_ScalingPolicySpec(in, out, s)
+}
+
+func autoConvert_kops_ScalingPolicySpec_To_v1alpha3_ScalingPolicySpec(in *kops.ScalingPolicySpec, out *ScalingPolicySpec, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.TargetTracking != nil {
+		in, out := &in.TargetTracking, &out.TargetTracking
+		*out = new(TargetTrackingScalingPolicySpec)
+		if err := Convert_kops_TargetTrackingScalingPolicySpec_To_v1alpha3_TargetTrackingScalingPolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetTracking = nil
+	}
+	if in.Step != nil {
+		in, out := &in.Step, &out.Step
+		*out = new(StepScalingPolicySpec)
+		if err := Convert_kops_StepScalingPolicySpec_To_v1alpha3_StepScalingPolicySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Step = nil
+	}
+	return nil
+}
+
+// Convert_kops_ScalingPolicySpec_To_v1alpha3_ScalingPolicySpec is an autogenerated conversion function.
+func Convert_kops_ScalingPolicySpec_To_v1alpha3_ScalingPolicySpec(in *kops.ScalingPolicySpec, out *ScalingPolicySpec, s conversion.Scope) error {
+	return autoConvert_kops_ScalingPolicySpec_To_v1alpha3_ScalingPolicySpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_ScalingStepSpec_To_kops_ScalingStepSpec(in *ScalingStepSpec, out *kops.ScalingStepSpec, s conversion.Scope) error {
+	out.LowerBound = in.LowerBound
+	out.UpperBound = in.UpperBound
+	out.Adjustment = in.Adjustment
+	return nil
+}
+
+// Convert_v1alpha3_ScalingStepSpec_To_kops_ScalingStepSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ScalingStepSpec_To_kops_ScalingStepSpec(in *ScalingStepSpec, out *kops.ScalingStepSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ScalingStepSpec_To_kops_ScalingStepSpec(in, out, s)
+}
+
+func autoConvert_kops_ScalingStepSpec_To_v1alpha3_ScalingStepSpec(in *kops.ScalingStepSpec, out *ScalingStepSpec, s conversion.Scope) error {
+	out.LowerBound = in.LowerBound
+	out.UpperBound = in.UpperBound
+	out.Adjustment = in.Adjustment
+	return nil
+}
+
+// Convert_kops_ScalingStepSpec_To_v1alpha3_ScalingStepSpec is an autogenerated conversion function.
+func Convert_kops_ScalingStepSpec_To_v1alpha3_ScalingStepSpec(in *kops.ScalingStepSpec, out *ScalingStepSpec, s conversion.Scope) error {
+	return autoConvert_kops_ScalingStepSpec_To_v1alpha3_ScalingStepSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_ServiceAccountExternalPermission_To_kops_ServiceAccountExternalPermission(in *ServiceAccountExternalPermission, out *kops.ServiceAccountExternalPermission, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Namespace = in.Namespace
@@ -7583,6 +9800,94 @@ func Convert_kops_SnapshotControllerConfig_To_v1alpha3_SnapshotControllerConfig(
 	return autoConvert_kops_SnapshotControllerConfig_To_v1alpha3_SnapshotControllerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_StaticNetworkInterfaceSpec_To_kops_StaticNetworkInterfaceSpec(in *StaticNetworkInterfaceSpec, out *kops.StaticNetworkInterfaceSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.PrivateIPAddress = in.PrivateIPAddress
+	return nil
+}
+
+// Convert_v1alpha3_StaticNetworkInterfaceSpec_To_kops_StaticNetworkInterfaceSpec is an autogenerated conversion function.
+func Convert_v1alpha3_StaticNetworkInterfaceSpec_To_kops_StaticNetworkInterfaceSpec(in *StaticNetworkInterfaceSpec, out *kops.StaticNetworkInterfaceSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_StaticNetworkInterfaceSpec_To_kops_StaticNetworkInterfaceSpec(in, out, s)
+}
+
+func autoConvert_kops_StaticNetworkInterfaceSpec_To_v1alpha3_StaticNetworkInterfaceSpec(in *kops.StaticNetworkInterfaceSpec, out *StaticNetworkInterfaceSpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.PrivateIPAddress = in.PrivateIPAddress
+	return nil
+}
+
+// Convert_kops_StaticNetworkInterfaceSpec_To_v1alpha3_StaticNetworkInterfaceSpec is an autogenerated conversion function.
+func Convert_kops_StaticNetworkInterfaceSpec_To_v1alpha3_StaticNetworkInterfaceSpec(in *kops.StaticNetworkInterfaceSpec, out *StaticNetworkInterfaceSpec, s conversion.Scope) error {
+	return autoConvert_kops_StaticNetworkInterfaceSpec_To_v1alpha3_StaticNetworkInterfaceSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_StepScalingPolicySpec_To_kops_StepScalingPolicySpec(in *StepScalingPolicySpec, out *kops.StepScalingPolicySpec, s conversion.Scope) error {
+	out.AdjustmentType = in.AdjustmentType
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]kops.ScalingStepSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ScalingStepSpec_To_kops_ScalingStepSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Steps = nil
+	}
+	out.EstimatedInstanceWarmup = in.EstimatedInstanceWarmup
+	return nil
+}
+
+// Convert_v1alpha3_StepScalingPolicySpec_To_kops_StepScalingPolicySpec is an autogenerated conversion function.
+func Convert_v1alpha3_StepScalingPolicySpec_To_kops_StepScalingPolicySpec(in *StepScalingPolicySpec, out *kops.StepScalingPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_StepScalingPolicySpec_To_kops_StepScalingPolicySpec(in, out, s)
+}
+
+func autoConvert_kops_StepScalingPolicySpec_To_v1alpha3_StepScalingPolicySpec(in *kops.StepScalingPolicySpec, out *StepScalingPolicySpec, s conversion.Scope) error {
+	out.AdjustmentType = in.AdjustmentType
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ScalingStepSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ScalingStepSpec_To_v1alpha3_ScalingStepSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Steps = nil
+	}
+	out.EstimatedInstanceWarmup = in.EstimatedInstanceWarmup
+	return nil
+}
+
+// Convert_kops_StepScalingPolicySpec_To_v1alpha3_StepScalingPolicySpec is an autogenerated conversion function.
+func Convert_kops_StepScalingPolicySpec_To_v1alpha3_StepScalingPolicySpec(in *kops.StepScalingPolicySpec, out *StepScalingPolicySpec, s conversion.Scope) error {
+	return autoConvert_kops_StepScalingPolicySpec_To_v1alpha3_StepScalingPolicySpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_TTLNotificationSpec_To_kops_TTLNotificationSpec(in *TTLNotificationSpec, out *kops.TTLNotificationSpec, s conversion.Scope) error {
+	out.WebhookURLs = in.WebhookURLs
+	out.Before = in.Before
+	return nil
+}
+
+// Convert_v1alpha3_TTLNotificationSpec_To_kops_TTLNotificationSpec is an autogenerated conversion function.
+func Convert_v1alpha3_TTLNotificationSpec_To_kops_TTLNotificationSpec(in *TTLNotificationSpec, out *kops.TTLNotificationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_TTLNotificationSpec_To_kops_TTLNotificationSpec(in, out, s)
+}
+
+func autoConvert_kops_TTLNotificationSpec_To_v1alpha3_TTLNotificationSpec(in *kops.TTLNotificationSpec, out *TTLNotificationSpec, s conversion.Scope) error {
+	out.WebhookURLs = in.WebhookURLs
+	out.Before = in.Before
+	return nil
+}
+
+// Convert_kops_TTLNotificationSpec_To_v1alpha3_TTLNotificationSpec is an autogenerated conversion function.
+func Convert_kops_TTLNotificationSpec_To_v1alpha3_TTLNotificationSpec(in *kops.TTLNotificationSpec, out *TTLNotificationSpec, s conversion.Scope) error {
+	return autoConvert_kops_TTLNotificationSpec_To_v1alpha3_TTLNotificationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_TargetSpec_To_kops_TargetSpec(in *TargetSpec, out *kops.TargetSpec, s conversion.Scope) error {
 	if in.Terraform != nil {
 		in, out := &in.Terraform, &out.Terraform
@@ -7619,9 +9924,69 @@ func Convert_kops_TargetSpec_To_v1alpha3_TargetSpec(in *kops.TargetSpec, out *Ta
 	return autoConvert_kops_TargetSpec_To_v1alpha3_TargetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_TargetTrackingScalingPolicySpec_To_kops_TargetTrackingScalingPolicySpec(in *TargetTrackingScalingPolicySpec, out *kops.TargetTrackingScalingPolicySpec, s conversion.Scope) error {
+	out.PredefinedMetric = in.PredefinedMetric
+	out.TargetValue = in.TargetValue
+	out.DisableScaleIn = in.DisableScaleIn
+	return nil
+}
+
+// Convert_v1alpha3_TargetTrackingScalingPolicySpec_To_kops_TargetTrackingScalingPolicySpec is an autogenerated conversion function.
+func Convert_v1alpha3_TargetTrackingScalingPolicySpec_To_kops_TargetTrackingScalingPolicySpec(in *TargetTrackingScalingPolicySpec, out *kops.TargetTrackingScalingPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_TargetTrackingScalingPolicySpec_To_kops_TargetTrackingScalingPolicySpec(in, out, s)
+}
+
+func autoConvert_kops_TargetTrackingScalingPolicySpec_To_v1alpha3_TargetTrackingScalingPolicySpec(in *kops.TargetTrackingScalingPolicySpec, out *TargetTrackingScalingPolicySpec, s conversion.Scope) error {
+	out.PredefinedMetric = in.PredefinedMetric
+	out.TargetValue = in.TargetValue
+	out.DisableScaleIn = in.DisableScaleIn
+	return nil
+}
+
+// Convert_kops_TargetTrackingScalingPolicySpec_To_v1alpha3_TargetTrackingScalingPolicySpec is an autogenerated conversion function.
+func Convert_kops_TargetTrackingScalingPolicySpec_To_v1alpha3_TargetTrackingScalingPolicySpec(in *kops.TargetTrackingScalingPolicySpec, out *TargetTrackingScalingPolicySpec, s conversion.Scope) error {
+	return autoConvert_kops_TargetTrackingScalingPolicySpec_To_v1alpha3_TargetTrackingScalingPolicySpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_TerraformMovedSpec_To_kops_TerraformMovedSpec(in *TerraformMovedSpec, out *kops.TerraformMovedSpec, s conversion.Scope) error {
+	out.From = in.From
+	out.To = in.To
+	return nil
+}
+
+// Convert_v1alpha3_TerraformMovedSpec_To_kops_TerraformMovedSpec is an autogenerated conversion function.
+func Convert_v1alpha3_TerraformMovedSpec_To_kops_TerraformMovedSpec(in *TerraformMovedSpec, out *kops.TerraformMovedSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_TerraformMovedSpec_To_kops_TerraformMovedSpec(in, out, s)
+}
+
+func autoConvert_kops_TerraformMovedSpec_To_v1alpha3_TerraformMovedSpec(in *kops.TerraformMovedSpec, out *TerraformMovedSpec, s conversion.Scope) error {
+	out.From = in.From
+	out.To = in.To
+	return nil
+}
+
+// Convert_kops_TerraformMovedSpec_To_v1alpha3_TerraformMovedSpec is an autogenerated conversion function.
+func Convert_kops_TerraformMovedSpec_To_v1alpha3_TerraformMovedSpec(in *kops.TerraformMovedSpec, out *TerraformMovedSpec, s conversion.Scope) error {
+	return autoConvert_kops_TerraformMovedSpec_To_v1alpha3_TerraformMovedSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out *kops.TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	out.ManageAddons = in.ManageAddons
+	out.SplitOutput = in.SplitOutput
+	out.DeduplicatePolicies = in.DeduplicatePolicies
+	if in.Moved != nil {
+		in, out := &in.Moved, &out.Moved
+		*out = make([]kops.TerraformMovedSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_TerraformMovedSpec_To_kops_TerraformMovedSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Moved = nil
+	}
 	return nil
 }
 
@@ -7633,6 +9998,20 @@ func Convert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out
 func autoConvert_kops_TerraformSpec_To_v1alpha3_TerraformSpec(in *kops.TerraformSpec, out *TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	out.ManageAddons = in.ManageAddons
+	out.SplitOutput = in.SplitOutput
+	out.DeduplicatePolicies = in.DeduplicatePolicies
+	if in.Moved != nil {
+		in, out := &in.Moved, &out.Moved
+		*out = make([]TerraformMovedSpec, len(*in))
+		for i := range *in {
+			if err := Convert_kops_TerraformMovedSpec_To_v1alpha3_TerraformMovedSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Moved = nil
+	}
 	return nil
 }
 
@@ -7679,6 +10058,30 @@ func Convert_kops_TopologySpec_To_v1alpha3_TopologySpec(in *kops.TopologySpec, o
 	return autoConvert_kops_TopologySpec_To_v1alpha3_TopologySpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_TransitGatewaySpec_To_kops_TransitGatewaySpec(in *TransitGatewaySpec, out *kops.TransitGatewaySpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Subnets = in.Subnets
+	out.Routes = in.Routes
+	return nil
+}
+
+// Convert_v1alpha3_TransitGatewaySpec_To_kops_TransitGatewaySpec is an autogenerated conversion function.
+func Convert_v1alpha3_TransitGatewaySpec_To_kops_TransitGatewaySpec(in *TransitGatewaySpec, out *kops.TransitGatewaySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_TransitGatewaySpec_To_kops_TransitGatewaySpec(in, out, s)
+}
+
+func autoConvert_kops_TransitGatewaySpec_To_v1alpha3_TransitGatewaySpec(in *kops.TransitGatewaySpec, out *TransitGatewaySpec, s conversion.Scope) error {
+	out.ID = in.ID
+	out.Subnets = in.Subnets
+	out.Routes = in.Routes
+	return nil
+}
+
+// Convert_kops_TransitGatewaySpec_To_v1alpha3_TransitGatewaySpec is an autogenerated conversion function.
+func Convert_kops_TransitGatewaySpec_To_v1alpha3_TransitGatewaySpec(in *kops.TransitGatewaySpec, out *TransitGatewaySpec, s conversion.Scope) error {
+	return autoConvert_kops_TransitGatewaySpec_To_v1alpha3_TransitGatewaySpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_UserData_To_kops_UserData(in *UserData, out *kops.UserData, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Type = in.Type
@@ -7703,6 +10106,28 @@ func Convert_kops_UserData_To_v1alpha3_UserData(in *kops.UserData, out *UserData
 	return autoConvert_kops_UserData_To_v1alpha3_UserData(in, out, s)
 }
 
+func autoConvert_v1alpha3_VPCEndpointSpec_To_kops_VPCEndpointSpec(in *VPCEndpointSpec, out *kops.VPCEndpointSpec, s conversion.Scope) error {
+	out.Service = in.Service
+	out.Type = kops.VPCEndpointType(in.Type)
+	return nil
+}
+
+// Convert_v1alpha3_VPCEndpointSpec_To_kops_VPCEndpointSpec is an autogenerated conversion function.
+func Convert_v1alpha3_VPCEndpointSpec_To_kops_VPCEndpointSpec(in *VPCEndpointSpec, out *kops.VPCEndpointSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VPCEndpointSpec_To_kops_VPCEndpointSpec(in, out, s)
+}
+
+func autoConvert_kops_VPCEndpointSpec_To_v1alpha3_VPCEndpointSpec(in *kops.VPCEndpointSpec, out *VPCEndpointSpec, s conversion.Scope) error {
+	out.Service = in.Service
+	out.Type = VPCEndpointType(in.Type)
+	return nil
+}
+
+// Convert_kops_VPCEndpointSpec_To_v1alpha3_VPCEndpointSpec is an autogenerated conversion function.
+func Convert_kops_VPCEndpointSpec_To_v1alpha3_VPCEndpointSpec(in *kops.VPCEndpointSpec, out *VPCEndpointSpec, s conversion.Scope) error {
+	return autoConvert_kops_VPCEndpointSpec_To_v1alpha3_VPCEndpointSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_VolumeMountSpec_To_kops_VolumeMountSpec(in *VolumeMountSpec, out *kops.VolumeMountSpec, s conversion.Scope) error {
 	out.Device = in.Device
 	out.Filesystem = in.Filesystem
@@ -29,6 +29,27 @@ import (
 	kops "k8s.io/kops/pkg/apis/kops"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMCertificateSpec) DeepCopyInto(out *ACMCertificateSpec) {
+	*out = *in
+	if in.SubjectAlternativeNames != nil {
+		in, out := &in.SubjectAlternativeNames, &out.SubjectAlternativeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMCertificateSpec.
+func (in *ACMCertificateSpec) DeepCopy() *ACMCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APISpec) DeepCopyInto(out *APISpec) {
 	*out = *in
@@ -208,6 +229,11 @@ func (in *AWSSpec) DeepCopyInto(out *AWSSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.PrivateDNSZoneVPCs != nil {
+		in, out := &in.PrivateDNSZoneVPCs, &out.PrivateDNSZoneVPCs
+		*out = make([]PrivateDNSZoneVPCSpec, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -263,6 +289,27 @@ func (in *AccessLogSpec) DeepCopy() *AccessLogSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalNetworkInterfaceSpec) DeepCopyInto(out *AdditionalNetworkInterfaceSpec) {
+	*out = *in
+	if in.SecurityGroups != nil {
+		in, out := &in.SecurityGroups, &out.SecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalNetworkInterfaceSpec.
+func (in *AdditionalNetworkInterfaceSpec) DeepCopy() *AdditionalNetworkInterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalNetworkInterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
 	*out = *in
@@ -365,6 +412,11 @@ func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
 		*out = new(OIDCAuthenticationSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CloudIdentity != nil {
+		in, out := &in.CloudIdentity, &out.CloudIdentity
+		*out = new(CloudIdentityAuthenticationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -404,6 +456,22 @@ func (in *AuthorizationSpec) DeepCopy() *AuthorizationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureInstanceGroupSpec) DeepCopyInto(out *AzureInstanceGroupSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureInstanceGroupSpec.
+func (in *AzureInstanceGroupSpec) DeepCopy() *AzureInstanceGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureInstanceGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureSpec) DeepCopyInto(out *AzureSpec) {
 	*out = *in
@@ -420,6 +488,22 @@ func (in *AzureSpec) DeepCopy() *AzureSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BastionAuditLoggingSpec) DeepCopyInto(out *BastionAuditLoggingSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionAuditLoggingSpec.
+func (in *BastionAuditLoggingSpec) DeepCopy() *BastionAuditLoggingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BastionAuditLoggingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BastionLoadBalancerSpec) DeepCopyInto(out *BastionLoadBalancerSpec) {
 	*out = *in
@@ -436,6 +520,27 @@ func (in *BastionLoadBalancerSpec) DeepCopy() *BastionLoadBalancerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BastionSSMSpec) DeepCopyInto(out *BastionSSMSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionSSMSpec.
+func (in *BastionSSMSpec) DeepCopy() *BastionSSMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BastionSSMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BastionSpec) DeepCopyInto(out *BastionSpec) {
 	*out = *in
@@ -444,6 +549,16 @@ func (in *BastionSpec) DeepCopyInto(out *BastionSpec) {
 		*out = new(BastionLoadBalancerSpec)
 		**out = **in
 	}
+	if in.SSM != nil {
+		in, out := &in.SSM, &out.SSM
+		*out = new(BastionSSMSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditLogging != nil {
+		in, out := &in.AuditLogging, &out.AuditLogging
+		*out = new(BastionAuditLoggingSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -806,6 +921,32 @@ func (in *CloudControllerManagerConfig) DeepCopy() *CloudControllerManagerConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudIdentityAuthenticationSpec) DeepCopyInto(out *CloudIdentityAuthenticationSpec) {
+	*out = *in
+	if in.AllowedIdentities != nil {
+		in, out := &in.AllowedIdentities, &out.AllowedIdentities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxLifetime != nil {
+		in, out := &in.MaxLifetime, &out.MaxLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudIdentityAuthenticationSpec.
+func (in *CloudIdentityAuthenticationSpec) DeepCopy() *CloudIdentityAuthenticationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudIdentityAuthenticationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudProviderSpec) DeepCopyInto(out *CloudProviderSpec) {
 	*out = *in
@@ -1007,6 +1148,94 @@ func (in *ClusterAutoscalerConfig) DeepCopy() *ClusterAutoscalerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDefaultsNamespaceSpec) DeepCopyInto(out *ClusterDefaultsNamespaceSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = make([]corev1.LimitRangeItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefaultsNamespaceSpec.
+func (in *ClusterDefaultsNamespaceSpec) DeepCopy() *ClusterDefaultsNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDefaultsNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDefaultsPriorityClassSpec) DeepCopyInto(out *ClusterDefaultsPriorityClassSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefaultsPriorityClassSpec.
+func (in *ClusterDefaultsPriorityClassSpec) DeepCopy() *ClusterDefaultsPriorityClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDefaultsPriorityClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDefaultsSpec) DeepCopyInto(out *ClusterDefaultsSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]ClusterDefaultsNamespaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PriorityClasses != nil {
+		in, out := &in.PriorityClasses, &out.PriorityClasses
+		*out = make([]ClusterDefaultsPriorityClassSpec, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDefaultsSpec.
+func (in *ClusterDefaultsSpec) DeepCopy() *ClusterDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterList) DeepCopyInto(out *ClusterList) {
 	*out = *in
@@ -1181,6 +1410,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(NTPConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LogRotation != nil {
+		in, out := &in.LogRotation, &out.LogRotation
+		*out = new(LogRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Packages != nil {
 		in, out := &in.Packages, &out.Packages
 		*out = make([]string, len(*in))
@@ -1196,6 +1430,21 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(MetricsServerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EventExporter != nil {
+		in, out := &in.EventExporter, &out.EventExporter
+		*out = new(EventExporterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusAgent != nil {
+		in, out := &in.PrometheusAgent, &out.PrometheusAgent
+		*out = new(PrometheusAgentConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterDefaults != nil {
+		in, out := &in.ClusterDefaults, &out.ClusterDefaults
+		*out = new(ClusterDefaultsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CertManager != nil {
 		in, out := &in.CertManager, &out.CertManager
 		*out = new(CertManagerConfig)
@@ -1252,11 +1501,45 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(TargetSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LifecycleOverrides != nil {
+		in, out := &in.LifecycleOverrides, &out.LifecycleOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExternalTasks != nil {
+		in, out := &in.ExternalTasks, &out.ExternalTasks
+		*out = make([]ExternalTaskSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TTLNotification != nil {
+		in, out := &in.TTLNotification, &out.TTLNotification
+		*out = new(TTLNotificationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UseHostCertificates != nil {
 		in, out := &in.UseHostCertificates, &out.UseHostCertificates
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AdditionalTrustBundles != nil {
+		in, out := &in.AdditionalTrustBundles, &out.AdditionalTrustBundles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SysctlParameters != nil {
 		in, out := &in.SysctlParameters, &out.SysctlParameters
 		*out = make([]string, len(*in))
@@ -1308,6 +1591,18 @@ func (in *ClusterSubnetSpec) DeepCopyInto(out *ClusterSubnetSpec) {
 		*out = make([]RouteSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateDNSNameOptions != nil {
+		in, out := &in.PrivateDNSNameOptions, &out.PrivateDNSNameOptions
+		*out = new(PrivateDNSNameOptionsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -1321,6 +1616,32 @@ func (in *ClusterSubnetSpec) DeepCopy() *ClusterSubnetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfidentialComputeSpec) DeepCopyInto(out *ConfidentialComputeSpec) {
+	*out = *in
+	if in.NitroEnclaves != nil {
+		in, out := &in.NitroEnclaves, &out.NitroEnclaves
+		*out = new(NitroEnclavesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AMDSevSnp != nil {
+		in, out := &in.AMDSevSnp, &out.AMDSevSnp
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfidentialComputeSpec.
+func (in *ConfidentialComputeSpec) DeepCopy() *ConfidentialComputeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfidentialComputeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigStoreSpec) DeepCopyInto(out *ConfigStoreSpec) {
 	*out = *in
@@ -1441,6 +1762,32 @@ func (in *DCGMExporterConfig) DeepCopy() *DCGMExporterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DHCPOptionsSpec) DeepCopyInto(out *DHCPOptionsSpec) {
+	*out = *in
+	if in.DomainNameServers != nil {
+		in, out := &in.DomainNameServers, &out.DomainNameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DHCPOptionsSpec.
+func (in *DHCPOptionsSpec) DeepCopy() *DHCPOptionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DHCPOptionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSAccessSpec) DeepCopyInto(out *DNSAccessSpec) {
 	*out = *in
@@ -1774,6 +2121,32 @@ func (in *EBSCSIDriverSpec) DeepCopy() *EBSCSIDriverSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EFASpec) DeepCopyInto(out *EFASpec) {
+	*out = *in
+	if in.InterfaceCount != nil {
+		in, out := &in.InterfaceCount, &out.InterfaceCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EFASpec.
+func (in *EFASpec) DeepCopy() *EFASpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EFASpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressProxySpec) DeepCopyInto(out *EgressProxySpec) {
 	*out = *in
@@ -1863,6 +2236,21 @@ func (in *EtcdClusterSpec) DeepCopyInto(out *EtcdClusterSpec) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(EtcdTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(EtcdGRPCSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(EtcdExternalSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1877,11 +2265,73 @@ func (in *EtcdClusterSpec) DeepCopy() *EtcdClusterSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EtcdManagerSpec) DeepCopyInto(out *EtcdManagerSpec) {
+func (in *EtcdExternalSpec) DeepCopyInto(out *EtcdExternalSpec) {
 	*out = *in
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]EnvVar, len(*in))
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdExternalSpec.
+func (in *EtcdExternalSpec) DeepCopy() *EtcdExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdGRPCSpec) DeepCopyInto(out *EtcdGRPCSpec) {
+	*out = *in
+	if in.MaxConcurrentStreams != nil {
+		in, out := &in.MaxConcurrentStreams, &out.MaxConcurrentStreams
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRequestBytes != nil {
+		in, out := &in.MaxRequestBytes, &out.MaxRequestBytes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepaliveMinTime != nil {
+		in, out := &in.KeepaliveMinTime, &out.KeepaliveMinTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepaliveInterval != nil {
+		in, out := &in.KeepaliveInterval, &out.KeepaliveInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepaliveTimeout != nil {
+		in, out := &in.KeepaliveTimeout, &out.KeepaliveTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdGRPCSpec.
+func (in *EtcdGRPCSpec) DeepCopy() *EtcdGRPCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdGRPCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdManagerSpec) DeepCopyInto(out *EtcdManagerSpec) {
+	*out = *in
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
 		copy(*out, *in)
 	}
 	if in.BackupInterval != nil {
@@ -1973,6 +2423,115 @@ func (in *EtcdMemberSpec) DeepCopy() *EtcdMemberSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdTLSSpec) DeepCopyInto(out *EtcdTLSSpec) {
+	*out = *in
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdTLSSpec.
+func (in *EtcdTLSSpec) DeepCopy() *EtcdTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventExporterCloudWatchSpec) DeepCopyInto(out *EventExporterCloudWatchSpec) {
+	*out = *in
+	if in.RetentionInDays != nil {
+		in, out := &in.RetentionInDays, &out.RetentionInDays
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventExporterCloudWatchSpec.
+func (in *EventExporterCloudWatchSpec) DeepCopy() *EventExporterCloudWatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventExporterCloudWatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventExporterConfig) DeepCopyInto(out *EventExporterConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.ForwarderImage != nil {
+		in, out := &in.ForwarderImage, &out.ForwarderImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.CloudWatch != nil {
+		in, out := &in.CloudWatch, &out.CloudWatch
+		*out = new(EventExporterCloudWatchSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Loki != nil {
+		in, out := &in.Loki, &out.Loki
+		*out = new(EventExporterLokiSpec)
+		**out = **in
+	}
+	if in.MemoryRequest != nil {
+		in, out := &in.MemoryRequest, &out.MemoryRequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPURequest != nil {
+		in, out := &in.CPURequest, &out.CPURequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventExporterConfig.
+func (in *EventExporterConfig) DeepCopy() *EventExporterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventExporterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventExporterLokiSpec) DeepCopyInto(out *EventExporterLokiSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventExporterLokiSpec.
+func (in *EventExporterLokiSpec) DeepCopy() *EventExporterLokiSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventExporterLokiSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecContainerAction) DeepCopyInto(out *ExecContainerAction) {
 	*out = *in
@@ -2038,6 +2597,39 @@ func (in *ExternalNetworkingSpec) DeepCopy() *ExternalNetworkingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalTaskSpec) DeepCopyInto(out *ExternalTaskSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalTaskSpec.
+func (in *ExternalTaskSpec) DeepCopy() *ExternalTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileAssetSpec) DeepCopyInto(out *FileAssetSpec) {
 	*out = *in
@@ -2220,6 +2812,22 @@ func (in *HTTPProxy) DeepCopy() *HTTPProxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HetznerInstanceGroupSpec) DeepCopyInto(out *HetznerInstanceGroupSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HetznerInstanceGroupSpec.
+func (in *HetznerInstanceGroupSpec) DeepCopy() *HetznerInstanceGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HetznerInstanceGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HetznerSpec) DeepCopyInto(out *HetznerSpec) {
 	*out = *in
@@ -2460,6 +3068,27 @@ func (in *InstanceGroup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceGroupHealthCheckSpec) DeepCopyInto(out *InstanceGroupHealthCheckSpec) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceGroupHealthCheckSpec.
+func (in *InstanceGroupHealthCheckSpec) DeepCopy() *InstanceGroupHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceGroupHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceGroupList) DeepCopyInto(out *InstanceGroupList) {
 	*out = *in
@@ -2623,6 +3252,11 @@ func (in *InstanceGroupSpec) DeepCopyInto(out *InstanceGroupSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Suspended != nil {
+		in, out := &in.Suspended, &out.Suspended
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ExternalLoadBalancers != nil {
 		in, out := &in.ExternalLoadBalancers, &out.ExternalLoadBalancers
 		*out = make([]LoadBalancerSpec, len(*in))
@@ -2695,6 +3329,11 @@ func (in *InstanceGroupSpec) DeepCopyInto(out *InstanceGroupSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LogRotation != nil {
+		in, out := &in.LogRotation, &out.LogRotation
+		*out = new(LogRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.GuestAccelerators != nil {
 		in, out := &in.GuestAccelerators, &out.GuestAccelerators
 		*out = make([]AcceleratorConfig, len(*in))
@@ -2705,11 +3344,65 @@ func (in *InstanceGroupSpec) DeepCopyInto(out *InstanceGroupSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(InstanceGroupHealthCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstanceMaintenancePolicy != nil {
+		in, out := &in.InstanceMaintenancePolicy, &out.InstanceMaintenancePolicy
+		*out = new(InstanceMaintenancePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultInstanceWarmup != nil {
+		in, out := &in.DefaultInstanceWarmup, &out.DefaultInstanceWarmup
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScalingPolicies != nil {
+		in, out := &in.ScalingPolicies, &out.ScalingPolicies
+		*out = make([]ScalingPolicySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StaticNetworkInterface != nil {
+		in, out := &in.StaticNetworkInterface, &out.StaticNetworkInterface
+		*out = new(StaticNetworkInterfaceSpec)
+		**out = **in
+	}
+	if in.ConfidentialCompute != nil {
+		in, out := &in.ConfidentialCompute, &out.ConfidentialCompute
+		*out = new(ConfidentialComputeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EFA != nil {
+		in, out := &in.EFA, &out.EFA
+		*out = new(EFASpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalNetworkInterfaces != nil {
+		in, out := &in.AdditionalNetworkInterfaces, &out.AdditionalNetworkInterfaces
+		*out = make([]AdditionalNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.GCPProvisioningModel != nil {
 		in, out := &in.GCPProvisioningModel, &out.GCPProvisioningModel
 		*out = new(string)
 		**out = **in
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureInstanceGroupSpec)
+		**out = **in
+	}
+	if in.Hetzner != nil {
+		in, out := &in.Hetzner, &out.Hetzner
+		*out = new(HetznerInstanceGroupSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -2723,6 +3416,32 @@ func (in *InstanceGroupSpec) DeepCopy() *InstanceGroupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceMaintenancePolicySpec) DeepCopyInto(out *InstanceMaintenancePolicySpec) {
+	*out = *in
+	if in.MinHealthyPercentage != nil {
+		in, out := &in.MinHealthyPercentage, &out.MinHealthyPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHealthyPercentage != nil {
+		in, out := &in.MaxHealthyPercentage, &out.MaxHealthyPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceMaintenancePolicySpec.
+func (in *InstanceMaintenancePolicySpec) DeepCopy() *InstanceMaintenancePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceMaintenancePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceMetadataOptions) DeepCopyInto(out *InstanceMetadataOptions) {
 	*out = *in
@@ -2762,6 +3481,16 @@ func (in *InstanceRequirementsSpec) DeepCopyInto(out *InstanceRequirementsSpec)
 		*out = new(MinMaxSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludedInstanceTypes != nil {
+		in, out := &in.ExcludedInstanceTypes, &out.ExcludedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2827,20 +3556,53 @@ func (in *InstanceRootVolumeSpec) DeepCopy() *InstanceRootVolumeSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KarpenterConfig) DeepCopyInto(out *KarpenterConfig) {
+func (in *InternalLoadBalancerAccessSpec) DeepCopyInto(out *InternalLoadBalancerAccessSpec) {
 	*out = *in
-	if in.MemoryLimit != nil {
-		in, out := &in.MemoryLimit, &out.MemoryLimit
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.MemoryRequest != nil {
-		in, out := &in.MemoryRequest, &out.MemoryRequest
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.SSLPolicy != nil {
+		in, out := &in.SSLPolicy, &out.SSLPolicy
+		*out = new(string)
+		**out = **in
 	}
-	if in.CPURequest != nil {
-		in, out := &in.CPURequest, &out.CPURequest
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]LoadBalancerSubnetSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalLoadBalancerAccessSpec.
+func (in *InternalLoadBalancerAccessSpec) DeepCopy() *InternalLoadBalancerAccessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalLoadBalancerAccessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KarpenterConfig) DeepCopyInto(out *KarpenterConfig) {
+	*out = *in
+	if in.MemoryLimit != nil {
+		in, out := &in.MemoryLimit, &out.MemoryLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemoryRequest != nil {
+		in, out := &in.MemoryRequest, &out.MemoryRequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPURequest != nil {
+		in, out := &in.CPURequest, &out.CPURequest
 		x := (*in).DeepCopy()
 		*out = &x
 	}
@@ -4205,6 +4967,11 @@ func (in *KubeletConfigSpec) DeepCopyInto(out *KubeletConfigSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.ServerTLSBootstrap != nil {
+		in, out := &in.ServerTLSBootstrap, &out.ServerTLSBootstrap
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -4324,6 +5091,16 @@ func (in *LoadBalancerAccessSpec) DeepCopyInto(out *LoadBalancerAccessSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ACMCertificate != nil {
+		in, out := &in.ACMCertificate, &out.ACMCertificate
+		*out = new(ACMCertificateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ALPNPolicy != nil {
+		in, out := &in.ALPNPolicy, &out.ALPNPolicy
+		*out = new(string)
+		**out = **in
+	}
 	if in.CrossZoneLoadBalancing != nil {
 		in, out := &in.CrossZoneLoadBalancing, &out.CrossZoneLoadBalancing
 		*out = new(bool)
@@ -4341,6 +5118,11 @@ func (in *LoadBalancerAccessSpec) DeepCopyInto(out *LoadBalancerAccessSpec) {
 		*out = new(AccessLogSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(InternalLoadBalancerAccessSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4432,6 +5214,37 @@ func (in *LoadBalancerSubnetSpec) DeepCopy() *LoadBalancerSubnetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRotationSpec) DeepCopyInto(out *LogRotationSpec) {
+	*out = *in
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxFiles != nil {
+		in, out := &in.MaxFiles, &out.MaxFiles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.JournalMaxUse != nil {
+		in, out := &in.JournalMaxUse, &out.JournalMaxUse
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRotationSpec.
+func (in *LogRotationSpec) DeepCopy() *LogRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsServerConfig) DeepCopyInto(out *MetricsServerConfig) {
 	*out = *in
@@ -4579,6 +5392,11 @@ func (in *NTPConfig) DeepCopyInto(out *NTPConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4627,6 +5445,21 @@ func (in *NetworkingSpec) DeepCopyInto(out *NetworkingSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DHCPOptions != nil {
+		in, out := &in.DHCPOptions, &out.DHCPOptions
+		*out = new(DHCPOptionsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TransitGateway != nil {
+		in, out := &in.TransitGateway, &out.TransitGateway
+		*out = new(TransitGatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VPCEndpoints != nil {
+		in, out := &in.VPCEndpoints, &out.VPCEndpoints
+		*out = make([]VPCEndpointSpec, len(*in))
+		copy(*out, *in)
+	}
 	if in.Classic != nil {
 		in, out := &in.Classic, &out.Classic
 		*out = new(kops.ClassicNetworkingSpec)
@@ -4715,6 +5548,32 @@ func (in *NetworkingSpec) DeepCopy() *NetworkingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NitroEnclavesSpec) DeepCopyInto(out *NitroEnclavesSpec) {
+	*out = *in
+	if in.CPUCount != nil {
+		in, out := &in.CPUCount, &out.CPUCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryMiB != nil {
+		in, out := &in.MemoryMiB, &out.MemoryMiB
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NitroEnclavesSpec.
+func (in *NitroEnclavesSpec) DeepCopy() *NitroEnclavesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NitroEnclavesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeLocalDNSConfig) DeepCopyInto(out *NodeLocalDNSConfig) {
 	*out = *in
@@ -5045,6 +5904,58 @@ func (in *OpenstackBlockStorageConfig) DeepCopy() *OpenstackBlockStorageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenstackL7Policy) DeepCopyInto(out *OpenstackL7Policy) {
+	*out = *in
+	if in.Position != nil {
+		in, out := &in.Position, &out.Position
+		*out = new(int)
+		**out = **in
+	}
+	if in.PoolPort != nil {
+		in, out := &in.PoolPort, &out.PoolPort
+		*out = new(int)
+		**out = **in
+	}
+	if in.PoolTLS != nil {
+		in, out := &in.PoolTLS, &out.PoolTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]OpenstackL7Rule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackL7Policy.
+func (in *OpenstackL7Policy) DeepCopy() *OpenstackL7Policy {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenstackL7Policy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenstackL7Rule) DeepCopyInto(out *OpenstackL7Rule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackL7Rule.
+func (in *OpenstackL7Rule) DeepCopy() *OpenstackL7Rule {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenstackL7Rule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenstackLoadbalancerConfig) DeepCopyInto(out *OpenstackLoadbalancerConfig) {
 	*out = *in
@@ -5103,6 +6014,18 @@ func (in *OpenstackLoadbalancerConfig) DeepCopyInto(out *OpenstackLoadbalancerCo
 		*out = new(string)
 		**out = **in
 	}
+	if in.TLSContainerRef != nil {
+		in, out := &in.TLSContainerRef, &out.TLSContainerRef
+		*out = new(string)
+		**out = **in
+	}
+	if in.L7Policies != nil {
+		in, out := &in.L7Policies, &out.L7Policies
+		*out = make([]OpenstackL7Policy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -5393,6 +6316,138 @@ func (in *PodIdentityWebhookSpec) DeepCopy() *PodIdentityWebhookSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateDNSNameOptionsSpec) DeepCopyInto(out *PrivateDNSNameOptionsSpec) {
+	*out = *in
+	if in.EnableResourceNameDNSARecord != nil {
+		in, out := &in.EnableResourceNameDNSARecord, &out.EnableResourceNameDNSARecord
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableResourceNameDNSAAAARecord != nil {
+		in, out := &in.EnableResourceNameDNSAAAARecord, &out.EnableResourceNameDNSAAAARecord
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateDNSNameOptionsSpec.
+func (in *PrivateDNSNameOptionsSpec) DeepCopy() *PrivateDNSNameOptionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateDNSNameOptionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateDNSZoneVPCSpec) DeepCopyInto(out *PrivateDNSZoneVPCSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateDNSZoneVPCSpec.
+func (in *PrivateDNSZoneVPCSpec) DeepCopy() *PrivateDNSZoneVPCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateDNSZoneVPCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAgentConfig) DeepCopyInto(out *PrometheusAgentConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScrapeInterval != nil {
+		in, out := &in.ScrapeInterval, &out.ScrapeInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = new(PrometheusRemoteWriteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryRequest != nil {
+		in, out := &in.MemoryRequest, &out.MemoryRequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPURequest != nil {
+		in, out := &in.CPURequest, &out.CPURequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAgentConfig.
+func (in *PrometheusAgentConfig) DeepCopy() *PrometheusAgentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAgentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRemoteWriteSpec) DeepCopyInto(out *PrometheusRemoteWriteSpec) {
+	*out = *in
+	if in.SigV4 != nil {
+		in, out := &in.SigV4, &out.SigV4
+		*out = new(PrometheusSigV4Spec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRemoteWriteSpec.
+func (in *PrometheusRemoteWriteSpec) DeepCopy() *PrometheusRemoteWriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRemoteWriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusSigV4Spec) DeepCopyInto(out *PrometheusSigV4Spec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusSigV4Spec.
+func (in *PrometheusSigV4Spec) DeepCopy() *PrometheusSigV4Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusSigV4Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RBACAuthorizationSpec) DeepCopyInto(out *RBACAuthorizationSpec) {
 	*out = *in
@@ -5427,6 +6482,26 @@ func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(RollingUpdateCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ZoneSerial != nil {
+		in, out := &in.ZoneSerial, &out.ZoneSerial
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxUnavailablePerZone != nil {
+		in, out := &in.MaxUnavailablePerZone, &out.MaxUnavailablePerZone
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(RollingUpdateDrain)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5440,6 +6515,96 @@ func (in *RollingUpdate) DeepCopy() *RollingUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateCanary) DeepCopyInto(out *RollingUpdateCanary) {
+	*out = *in
+	if in.NetworkReady != nil {
+		in, out := &in.NetworkReady, &out.NetworkReady
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodScheduling != nil {
+		in, out := &in.PodScheduling, &out.PodScheduling
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(RollingUpdateCanaryJob)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateCanary.
+func (in *RollingUpdateCanary) DeepCopy() *RollingUpdateCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateCanaryJob) DeepCopyInto(out *RollingUpdateCanaryJob) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateCanaryJob.
+func (in *RollingUpdateCanaryJob) DeepCopy() *RollingUpdateCanaryJob {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateCanaryJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateDrain) DeepCopyInto(out *RollingUpdateDrain) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SkipPDBLabels != nil {
+		in, out := &in.SkipPDBLabels, &out.SkipPDBLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateDrain.
+func (in *RollingUpdateDrain) DeepCopy() *RollingUpdateDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 	*out = *in
@@ -5574,6 +6739,58 @@ func (in *ScalewaySpec) DeepCopy() *ScalewaySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingPolicySpec) DeepCopyInto(out *ScalingPolicySpec) {
+	*out = *in
+	if in.TargetTracking != nil {
+		in, out := &in.TargetTracking, &out.TargetTracking
+		*out = new(TargetTrackingScalingPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Step != nil {
+		in, out := &in.Step, &out.Step
+		*out = new(StepScalingPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingPolicySpec.
+func (in *ScalingPolicySpec) DeepCopy() *ScalingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingStepSpec) DeepCopyInto(out *ScalingStepSpec) {
+	*out = *in
+	if in.LowerBound != nil {
+		in, out := &in.LowerBound, &out.LowerBound
+		*out = new(float64)
+		**out = **in
+	}
+	if in.UpperBound != nil {
+		in, out := &in.UpperBound, &out.UpperBound
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingStepSpec.
+func (in *ScalingStepSpec) DeepCopy() *ScalingStepSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingStepSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountExternalPermission) DeepCopyInto(out *ServiceAccountExternalPermission) {
 	*out = *in
@@ -5637,6 +6854,76 @@ func (in *SnapshotControllerConfig) DeepCopy() *SnapshotControllerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticNetworkInterfaceSpec) DeepCopyInto(out *StaticNetworkInterfaceSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticNetworkInterfaceSpec.
+func (in *StaticNetworkInterfaceSpec) DeepCopy() *StaticNetworkInterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticNetworkInterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepScalingPolicySpec) DeepCopyInto(out *StepScalingPolicySpec) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ScalingStepSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EstimatedInstanceWarmup != nil {
+		in, out := &in.EstimatedInstanceWarmup, &out.EstimatedInstanceWarmup
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepScalingPolicySpec.
+func (in *StepScalingPolicySpec) DeepCopy() *StepScalingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StepScalingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TTLNotificationSpec) DeepCopyInto(out *TTLNotificationSpec) {
+	*out = *in
+	if in.WebhookURLs != nil {
+		in, out := &in.WebhookURLs, &out.WebhookURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TTLNotificationSpec.
+func (in *TTLNotificationSpec) DeepCopy() *TTLNotificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TTLNotificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetSpec) DeepCopyInto(out *TargetSpec) {
 	*out = *in
@@ -5658,6 +6945,43 @@ func (in *TargetSpec) DeepCopy() *TargetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetTrackingScalingPolicySpec) DeepCopyInto(out *TargetTrackingScalingPolicySpec) {
+	*out = *in
+	if in.DisableScaleIn != nil {
+		in, out := &in.DisableScaleIn, &out.DisableScaleIn
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetTrackingScalingPolicySpec.
+func (in *TargetTrackingScalingPolicySpec) DeepCopy() *TargetTrackingScalingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetTrackingScalingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerraformMovedSpec) DeepCopyInto(out *TerraformMovedSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerraformMovedSpec.
+func (in *TerraformMovedSpec) DeepCopy() *TerraformMovedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TerraformMovedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 	*out = *in
@@ -5675,6 +6999,26 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ManageAddons != nil {
+		in, out := &in.ManageAddons, &out.ManageAddons
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SplitOutput != nil {
+		in, out := &in.SplitOutput, &out.SplitOutput
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeduplicatePolicies != nil {
+		in, out := &in.DeduplicatePolicies, &out.DeduplicatePolicies
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Moved != nil {
+		in, out := &in.Moved, &out.Moved
+		*out = make([]TerraformMovedSpec, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -5709,6 +7053,32 @@ func (in *TopologySpec) DeepCopy() *TopologySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransitGatewaySpec) DeepCopyInto(out *TransitGatewaySpec) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransitGatewaySpec.
+func (in *TransitGatewaySpec) DeepCopy() *TransitGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TransitGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserData) DeepCopyInto(out *UserData) {
 	*out = *in
@@ -5725,6 +7095,22 @@ func (in *UserData) DeepCopy() *UserData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCEndpointSpec) DeepCopyInto(out *VPCEndpointSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCEndpointSpec.
+func (in *VPCEndpointSpec) DeepCopy() *VPCEndpointSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCEndpointSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeMountSpec) DeepCopyInto(out *VolumeMountSpec) {
 	*out = *in
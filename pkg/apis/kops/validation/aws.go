@@ -17,20 +17,32 @@ limitations under the License.
 package validation
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/util/stringorset"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/architectures"
 )
 
 func awsValidateCluster(c *kops.Cluster, strict bool) field.ErrorList {
@@ -51,11 +63,20 @@ func awsValidateCluster(c *kops.Cluster, strict bool) field.ErrorList {
 		if lbSpec.SSLCertificate != "" && lbSpec.Class != kops.LoadBalancerClassNetwork {
 			allErrs = append(allErrs, field.Forbidden(lbPath.Child("sslCertificate"), "sslCertificate requires a network load balancer. See https://github.com/kubernetes/kops/blob/master/permalinks/acm_nlb.md"))
 		}
+		if lbSpec.ACMCertificate != nil {
+			allErrs = append(allErrs, awsValidateACMCertificate(lbPath.Child("acmCertificate"), c, lbSpec)...)
+		}
 		allErrs = append(allErrs, awsValidateSSLPolicy(lbPath.Child("sslPolicy"), lbSpec)...)
-		allErrs = append(allErrs, awsValidateLoadBalancerSubnets(lbPath.Child("subnets"), c.Spec)...)
+		allErrs = append(allErrs, awsValidateTLSMode(lbPath.Child("tlsMode"), lbSpec)...)
+		allErrs = append(allErrs, awsValidateALPNPolicy(lbPath.Child("alpnPolicy"), lbSpec)...)
+		allErrs = append(allErrs, awsValidateLoadBalancerSubnets(lbPath.Child("subnets"), c.Spec, lbSpec.Class, lbSpec.Type, lbSpec.Subnets)...)
+		if lbSpec.Internal != nil {
+			allErrs = append(allErrs, awsValidateInternalLoadBalancer(lbPath.Child("internal"), c, lbSpec)...)
+		}
 	}
 
 	allErrs = append(allErrs, awsValidateEBSCSIDriver(c)...)
+	allErrs = append(allErrs, awsValidatePrivateDNSZoneVPCs(field.NewPath("spec", "cloudProvider", "aws", "privateDNSZoneVPCs"), c)...)
 
 	if c.Spec.Authentication != nil && c.Spec.Authentication.AWS != nil {
 		allErrs = append(allErrs, awsValidateIAMAuthenticator(field.NewPath("spec", "authentication", "aws"), c.Spec.Authentication.AWS)...)
@@ -64,6 +85,35 @@ func awsValidateCluster(c *kops.Cluster, strict bool) field.ErrorList {
 	return allErrs
 }
 
+func awsValidatePrivateDNSZoneVPCs(fldPath *field.Path, c *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	vpcs := c.Spec.CloudProvider.AWS.PrivateDNSZoneVPCs
+	if len(vpcs) == 0 {
+		return allErrs
+	}
+	if c.Spec.Networking.Topology == nil || c.Spec.Networking.Topology.DNS != kops.DNSTypePrivate {
+		return append(allErrs, field.Forbidden(fldPath, "VPCs can only be associated with a private DNS zone"))
+	}
+
+	seen := sets.NewString()
+	for i, vpc := range vpcs {
+		fldPath := fldPath.Index(i)
+		if !strings.HasPrefix(vpc.ID, "vpc-") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), vpc.ID, "must be the ID of a VPC"))
+		} else if vpc.ID == c.Spec.Networking.NetworkID && vpc.Region == "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("id"), "the VPC of the cluster is always associated with the DNS zone"))
+		}
+		key := vpc.ID + "/" + vpc.Region
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(fldPath, vpc))
+		}
+		seen.Insert(key)
+	}
+
+	return allErrs
+}
+
 func awsValidateEBSCSIDriver(cluster *kops.Cluster) (allErrs field.ErrorList) {
 	c := cluster.Spec
 
@@ -79,6 +129,10 @@ func awsValidateInstanceGroup(ig *kops.InstanceGroup, cloud awsup.AWSCloud) fiel
 
 	allErrs = append(allErrs, awsValidateAdditionalSecurityGroups(field.NewPath("spec", "additionalSecurityGroups"), ig.Spec.AdditionalSecurityGroups)...)
 
+	for i, ni := range ig.Spec.AdditionalNetworkInterfaces {
+		allErrs = append(allErrs, awsValidateAdditionalSecurityGroups(field.NewPath("spec", "additionalNetworkInterfaces").Index(i).Child("securityGroups"), ni.SecurityGroups)...)
+	}
+
 	allErrs = append(allErrs, awsValidateInstanceTypeAndImage(field.NewPath(ig.GetName(), "spec", "machineType"), field.NewPath(ig.GetName(), "spec", "image"), ig.Spec.MachineType, ig.Spec.Image, cloud)...)
 
 	allErrs = append(allErrs, awsValidateSpotDurationInMinute(field.NewPath(ig.GetName(), "spec", "spotDurationInMinutes"), ig)...)
@@ -101,6 +155,18 @@ func awsValidateInstanceGroup(ig *kops.InstanceGroup, cloud awsup.AWSCloud) fiel
 		allErrs = append(allErrs, awsValidateMaximumInstanceLifetime(field.NewPath(ig.GetName(), "spec"), ig.Spec.MaxInstanceLifetime)...)
 	}
 
+	if ig.Spec.ConfidentialCompute != nil {
+		allErrs = append(allErrs, awsValidateConfidentialCompute(field.NewPath("spec", "confidentialCompute"), ig, cloud)...)
+	}
+
+	if ig.Spec.EFA != nil {
+		allErrs = append(allErrs, awsValidateEFA(field.NewPath("spec", "efa"), ig, cloud)...)
+	}
+
+	if ig.Spec.RootVolume != nil && ig.Spec.RootVolume.EncryptionKey != nil && (ig.Spec.RootVolume.Encryption == nil || *ig.Spec.RootVolume.Encryption) {
+		allErrs = append(allErrs, awsValidateRootVolumeEncryptionKey(field.NewPath("spec", "rootVolume", "encryptionKey"), *ig.Spec.RootVolume.EncryptionKey, cloud)...)
+	}
+
 	return allErrs
 }
 
@@ -220,6 +286,37 @@ func awsValidateInstanceTypeAndImage(instanceTypeFieldPath *field.Path, imageFie
 	return allErrs
 }
 
+// awsValidateTrunkingInstanceTypes checks that the instance types of an instance group support trunk network interfaces,
+// which security groups for pods need. These are the bare metal and the non-burstable Nitro instance types.
+func awsValidateTrunkingInstanceTypes(ig *kops.InstanceGroup, cloud awsup.AWSCloud) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fldPath := field.NewPath("spec", "machineType")
+	instanceTypes := strings.Split(ig.Spec.MachineType, ",")
+	if ig.Spec.MixedInstancesPolicy != nil && len(ig.Spec.MixedInstancesPolicy.Instances) > 0 {
+		fldPath = field.NewPath("spec", "mixedInstancesPolicy", "instances")
+		instanceTypes = ig.Spec.MixedInstancesPolicy.Instances
+	}
+
+	for _, instanceType := range instanceTypes {
+		if instanceType == "" {
+			continue
+		}
+		machineInfo, err := cloud.DescribeInstanceType(instanceType)
+		if err != nil {
+			// Reported by awsValidateInstanceTypeAndImage
+			continue
+		}
+		bareMetal := fi.ValueOf(machineInfo.BareMetal)
+		nitro := machineInfo.Hypervisor == ec2types.InstanceTypeHypervisorNitro && !fi.ValueOf(machineInfo.BurstablePerformanceSupported)
+		if !bareMetal && !nitro {
+			allErrs = append(allErrs, field.Invalid(fldPath, instanceType, "machine type does not support trunk network interfaces, which securityGroupsForPods requires"))
+		}
+	}
+
+	return allErrs
+}
+
 func awsValidateSpotDurationInMinute(fieldPath *field.Path, ig *kops.InstanceGroup) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if ig.Spec.SpotDurationInMinutes != nil {
@@ -287,11 +384,68 @@ func awsValidateMixedInstancesPolicy(path *field.Path, spec *kops.MixedInstances
 		}
 	}
 
+	if spec.InstanceRequirements != nil {
+		errs = append(errs, awsValidateInstanceRequirements(path.Child("instanceRequirements"), spec.InstanceRequirements, ig.Spec.Image, cloud)...)
+	}
+
 	errs = append(errs, IsValidValue(path.Child("spotAllocationStrategy"), spec.SpotAllocationStrategy, kops.SpotAllocationStrategies)...)
 
 	return errs
 }
 
+// awsValidateInstanceRequirements checks the requirements for the instance types that EC2 Auto Scaling selects.
+// As all instances are launched from the same image, the architectures must match the architecture of the image.
+func awsValidateInstanceRequirements(path *field.Path, spec *kops.InstanceRequirementsSpec, image string, cloud awsup.AWSCloud) field.ErrorList {
+	var errs field.ErrorList
+
+	for _, minMax := range []struct {
+		name string
+		spec *kops.MinMaxSpec
+	}{{name: "cpu", spec: spec.CPU}, {name: "memory", spec: spec.Memory}} {
+		if minMax.spec != nil && minMax.spec.Min != nil && minMax.spec.Max != nil && minMax.spec.Min.Cmp(*minMax.spec.Max) > 0 {
+			errs = append(errs, field.Invalid(path.Child(minMax.name, "min"), minMax.spec.Min.String(), "cannot be greater than max"))
+		}
+	}
+
+	validArchitectures := []string{string(architectures.ArchitectureAmd64), string(architectures.ArchitectureArm64)}
+	imageArch := ""
+	if cloud != nil && image != "" && len(spec.Architectures) > 0 {
+		imageInfo, err := cloud.ResolveImage(image)
+		if err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "image"), image, fmt.Sprintf("specified image %q is invalid: %s", image, err)))
+		} else {
+			switch imageInfo.Architecture {
+			case ec2types.ArchitectureValuesX8664:
+				imageArch = string(architectures.ArchitectureAmd64)
+			case ec2types.ArchitectureValuesArm64:
+				imageArch = string(architectures.ArchitectureArm64)
+			}
+		}
+	}
+	for i, arch := range spec.Architectures {
+		fld := path.Child("architectures").Index(i)
+		if archErrs := IsValidValue(fld, &arch, validArchitectures); len(archErrs) > 0 {
+			errs = append(errs, archErrs...)
+		} else if imageArch != "" && arch != imageArch {
+			errs = append(errs, field.Invalid(fld, arch, fmt.Sprintf("does not match image architecture %q", imageArch)))
+		}
+	}
+
+	// EC2 Auto Scaling accepts up to 400 excluded instance types
+	if len(spec.ExcludedInstanceTypes) > 400 {
+		errs = append(errs, field.TooMany(path.Child("excludedInstanceTypes"), len(spec.ExcludedInstanceTypes), 400))
+	}
+	for i, instanceType := range spec.ExcludedInstanceTypes {
+		if !excludedInstanceTypeRegex.MatchString(instanceType) {
+			errs = append(errs, field.Invalid(path.Child("excludedInstanceTypes").Index(i), instanceType, "must be an instance type, which may contain * wildcards"))
+		}
+	}
+
+	return errs
+}
+
+var excludedInstanceTypeRegex = regexp.MustCompile(`^[a-z0-9*][a-z0-9.*-]*$`)
+
 func awsValidateTopologyDNS(fieldPath *field.Path, c *kops.Cluster) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -302,6 +456,28 @@ func awsValidateTopologyDNS(fieldPath *field.Path, c *kops.Cluster) field.ErrorL
 	return allErrs
 }
 
+func awsValidateACMCertificate(fieldPath *field.Path, c *kops.Cluster, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.SSLCertificate != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "acmCertificate cannot be used with sslCertificate"))
+	}
+	if spec.Class != kops.LoadBalancerClassNetwork {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "acmCertificate requires a network load balancer"))
+	}
+	if !c.PublishesDNSRecords() || !c.UsesPublicDNS() {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "acmCertificate requires a public DNS zone, in which the certificate is validated"))
+	}
+	for i, name := range spec.ACMCertificate.SubjectAlternativeNames {
+		errs := utilvalidation.IsDNS1123Subdomain(strings.TrimPrefix(name, "*."))
+		if len(errs) != 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("subjectAlternativeNames").Index(i), name, strings.Join(errs, "; ")))
+		}
+	}
+
+	return allErrs
+}
+
 func awsValidateSSLPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -309,7 +485,7 @@ func awsValidateSSLPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSp
 		if spec.Class != kops.LoadBalancerClassNetwork {
 			allErrs = append(allErrs, field.Forbidden(fieldPath, "sslPolicy should be specified with Network Load Balancer"))
 		}
-		if spec.SSLCertificate == "" {
+		if !spec.UsesSSLCertificate() {
 			allErrs = append(allErrs, field.Forbidden(fieldPath, "sslPolicy should not be specified without SSLCertificate"))
 		}
 	}
@@ -317,12 +493,45 @@ func awsValidateSSLPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSp
 	return allErrs
 }
 
-func awsValidateLoadBalancerSubnets(fieldPath *field.Path, spec kops.ClusterSpec) field.ErrorList {
+func awsValidateTLSMode(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	lbSpec := spec.API.LoadBalancer
+	if spec.TLSMode != "" {
+		allErrs = append(allErrs, IsValidValue(fieldPath, &spec.TLSMode, kops.SupportedLoadBalancerTLSModes)...)
+		if spec.Class != kops.LoadBalancerClassNetwork {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "tlsMode should be specified with Network Load Balancer"))
+		}
+		if spec.TLSMode == kops.LoadBalancerTLSModeTerminate && !spec.UsesSSLCertificate() {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "terminating TLS requires sslCertificate or acmCertificate"))
+		}
+	}
 
-	for i, subnet := range lbSpec.Subnets {
+	return allErrs
+}
+
+// awsALPNPolicies are the ALPN policies supported by TLS listeners of Network Load Balancers.
+var awsALPNPolicies = []string{"HTTP1Only", "HTTP2Only", "HTTP2Optional", "HTTP2Preferred", "None"}
+
+func awsValidateALPNPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ALPNPolicy != nil {
+		allErrs = append(allErrs, IsValidValue(fieldPath, spec.ALPNPolicy, awsALPNPolicies)...)
+		if spec.Class != kops.LoadBalancerClassNetwork {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "alpnPolicy should be specified with Network Load Balancer"))
+		}
+		if !spec.UsesSSLCertificate() {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "alpnPolicy should not be specified without SSLCertificate"))
+		}
+	}
+
+	return allErrs
+}
+
+func awsValidateLoadBalancerSubnets(fieldPath *field.Path, spec kops.ClusterSpec, lbClass kops.LoadBalancerClass, lbType kops.LoadBalancerType, subnets []kops.LoadBalancerSubnetSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, subnet := range subnets {
 		var clusterSubnet *kops.ClusterSubnetSpec
 		if subnet.Name == "" {
 			allErrs = append(allErrs, field.Required(fieldPath.Index(i).Child("name"), "subnet name can't be empty"))
@@ -354,7 +563,7 @@ func awsValidateLoadBalancerSubnets(fieldPath *field.Path, spec kops.ClusterSpec
 				}
 
 			}
-			if lbSpec.Class != kops.LoadBalancerClassNetwork || lbSpec.Type != kops.LoadBalancerTypeInternal {
+			if lbClass != kops.LoadBalancerClassNetwork || lbType != kops.LoadBalancerTypeInternal {
 				allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i).Child("privateIPv4Address"), "privateIPv4Address only allowed for internal NLBs"))
 			}
 		}
@@ -364,7 +573,7 @@ func awsValidateLoadBalancerSubnets(fieldPath *field.Path, spec kops.ClusterSpec
 				allErrs = append(allErrs, field.Required(fieldPath.Index(i).Child("allocationID"), "allocationID can't be empty"))
 			}
 
-			if lbSpec.Class != kops.LoadBalancerClassNetwork || lbSpec.Type == kops.LoadBalancerTypeInternal {
+			if lbClass != kops.LoadBalancerClassNetwork || lbType == kops.LoadBalancerTypeInternal {
 				allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i).Child("allocationID"), "allocationID only allowed for Public NLBs"))
 			}
 		}
@@ -373,6 +582,35 @@ func awsValidateLoadBalancerSubnets(fieldPath *field.Path, spec kops.ClusterSpec
 	return allErrs
 }
 
+func awsValidateInternalLoadBalancer(fieldPath *field.Path, c *kops.Cluster, lbSpec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	internalSpec := lbSpec.Internal
+	if lbSpec.Class != kops.LoadBalancerClassNetwork {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "an internal load balancer requires a Network Load Balancer"))
+	}
+	if lbSpec.Type != kops.LoadBalancerTypePublic {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "an internal load balancer can only be added to a Public load balancer"))
+	}
+	if c.UsesNoneDNS() {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "an internal load balancer is not supported with topology.dns.type=none"))
+	}
+
+	for i, cidr := range internalSpec.Access {
+		if !strings.HasPrefix(cidr, "pl-") {
+			allErrs = append(allErrs, validateCIDR(fieldPath.Child("access").Index(i), cidr)...)
+		}
+	}
+
+	if internalSpec.SSLPolicy != nil && internalSpec.SSLCertificate == "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("sslPolicy"), "sslPolicy should not be specified without sslCertificate"))
+	}
+
+	allErrs = append(allErrs, awsValidateLoadBalancerSubnets(fieldPath.Child("subnets"), c.Spec, kops.LoadBalancerClassNetwork, kops.LoadBalancerTypeInternal, internalSpec.Subnets)...)
+
+	return allErrs
+}
+
 func awsValidateCPUCredits(fieldPath *field.Path, spec *kops.InstanceGroupSpec, cloud awsup.AWSCloud) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -383,14 +621,23 @@ func awsValidateCPUCredits(fieldPath *field.Path, spec *kops.InstanceGroupSpec,
 func awsValidateIAMAuthenticator(fieldPath *field.Path, spec *kops.AWSAuthenticationSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if !strings.Contains(spec.BackendMode, "CRD") && len(spec.IdentityMappings) > 0 {
-		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("backendMode"), "backendMode must be CRD if identityMappings is set"))
+	// The identity mappings are managed as IAMIdentityMapping objects in the CRD backend,
+	// or as the configuration of the MountedFile backend, which is the default.
+	if spec.BackendMode != "" && !strings.Contains(spec.BackendMode, "CRD") && !strings.Contains(spec.BackendMode, "MountedFile") && len(spec.IdentityMappings) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("backendMode"), "backendMode must include CRD or MountedFile if identityMappings is set"))
 	}
+	arns := sets.NewString()
 	for i, mapping := range spec.IdentityMappings {
 		parsedARN, err := arn.Parse(mapping.ARN)
 		if err != nil || (!strings.HasPrefix(parsedARN.Resource, "role/") && !strings.HasPrefix(parsedARN.Resource, "user/")) {
 			allErrs = append(allErrs, field.Invalid(fieldPath.Child("identityMappings").Index(i).Child("arn"), mapping.ARN,
 				"arn must be a valid IAM Role or User ARN such as arn:aws:iam::123456789012:role/KopsExampleRole"))
+		} else if arns.Has(mapping.ARN) {
+			allErrs = append(allErrs, field.Duplicate(fieldPath.Child("identityMappings").Index(i).Child("arn"), mapping.ARN))
+		}
+		arns.Insert(mapping.ARN)
+		if mapping.Username == "" {
+			allErrs = append(allErrs, field.Required(fieldPath.Child("identityMappings").Index(i).Child("username"), ""))
 		}
 	}
 	return allErrs
@@ -440,3 +687,226 @@ func awsValidateAdditionalRoutes(fieldPath *field.Path, routes []kops.RouteSpec,
 
 	return allErrs
 }
+
+// awsValidateConfidentialCompute checks that the machine types of the instance group support the confidential computing features.
+func awsValidateConfidentialCompute(fieldPath *field.Path, ig *kops.InstanceGroup, cloud awsup.AWSCloud) field.ErrorList {
+	allErrs := field.ErrorList{}
+	spec := ig.Spec.ConfidentialCompute
+
+	instanceTypes := strings.Split(ig.Spec.MachineType, ",")
+	if ig.Spec.MixedInstancesPolicy != nil {
+		instanceTypes = append(instanceTypes, ig.Spec.MixedInstancesPolicy.Instances...)
+	}
+
+	for _, instanceType := range sets.List(sets.New(instanceTypes...)) {
+		if instanceType == "" {
+			continue
+		}
+		// Invalid machine types are reported by the validation of the machine types
+		info, err := cloud.DescribeInstanceType(instanceType)
+		if err != nil || info == nil {
+			continue
+		}
+
+		if spec.NitroEnclaves != nil {
+			if info.NitroEnclavesSupport != ec2types.NitroEnclavesSupportSupported {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("nitroEnclaves"), fmt.Sprintf("machine type %q does not support Nitro Enclaves", instanceType)))
+			} else {
+				if spec.NitroEnclaves.CPUCount != nil && info.VCpuInfo != nil && *spec.NitroEnclaves.CPUCount >= aws.ToInt32(info.VCpuInfo.DefaultVCpus) {
+					allErrs = append(allErrs, field.Invalid(fieldPath.Child("nitroEnclaves", "cpuCount"), *spec.NitroEnclaves.CPUCount,
+						fmt.Sprintf("must leave vCPUs of machine type %q to the instance", instanceType)))
+				}
+				if spec.NitroEnclaves.MemoryMiB != nil && info.MemoryInfo != nil && int64(*spec.NitroEnclaves.MemoryMiB) >= aws.ToInt64(info.MemoryInfo.SizeInMiB) {
+					allErrs = append(allErrs, field.Invalid(fieldPath.Child("nitroEnclaves", "memoryMiB"), *spec.NitroEnclaves.MemoryMiB,
+						fmt.Sprintf("must leave memory of machine type %q to the instance", instanceType)))
+				}
+			}
+		}
+
+		if fi.ValueOf(spec.AMDSevSnp) {
+			supported := false
+			if info.ProcessorInfo != nil {
+				for _, feature := range info.ProcessorInfo.SupportedFeatures {
+					if feature == ec2types.SupportedAdditionalProcessorFeatureAmdSevSnp {
+						supported = true
+					}
+				}
+			}
+			if !supported {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("amdSevSnp"), fmt.Sprintf("machine type %q does not support AMD SEV-SNP", instanceType)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// rootVolumeKMSActions are the actions the autoscaling service-linked role needs on the key of encrypted root volumes.
+var rootVolumeKMSActions = []string{"kms:CreateGrant", "kms:Decrypt", "kms:GenerateDataKeyWithoutPlaintext"}
+
+type kmsKeyPolicy struct {
+	Statement []kmsKeyPolicyStatement
+}
+
+type kmsKeyPolicyStatement struct {
+	Effect    string
+	Principal kmsKeyPolicyPrincipal
+	Action    stringorset.StringOrSet
+	// Condition is not evaluated, the statement is only known to allow its actions when it has none.
+	Condition json.RawMessage
+}
+
+// kmsKeyPolicyPrincipal is either "*" or a map of principal types to principals.
+type kmsKeyPolicyPrincipal struct {
+	Any bool
+	AWS stringorset.StringOrSet
+}
+
+func (p *kmsKeyPolicyPrincipal) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		p.Any = s == "*"
+		return nil
+	}
+	var m struct {
+		AWS stringorset.StringOrSet
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	p.AWS = m.AWS
+	return nil
+}
+
+// awsValidateRootVolumeEncryptionKey checks that the autoscaling service-linked role is allowed to use the key,
+// either through the key policy or through grants, otherwise instances fail to launch.
+// The check is skipped with a warning when the key cannot be inspected, for example when it belongs to another account,
+// and only warns about the actions allowed by statements of the key policy with conditions, which are not evaluated.
+func awsValidateRootVolumeEncryptionKey(fieldPath *field.Path, keyID string, cloud awsup.AWSCloud) field.ErrorList {
+	ctx := context.TODO()
+	allErrs := field.ErrorList{}
+
+	key, err := cloud.KMS().DescribeKey(ctx, &kms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		var notFound *kmstypes.NotFoundException
+		if errors.As(err, &notFound) {
+			return append(allErrs, field.NotFound(fieldPath, keyID))
+		}
+		klog.Warningf("unable to describe KMS key %q, skipping validation of its key policy: %v", keyID, err)
+		return allErrs
+	}
+	if key.KeyMetadata == nil || key.KeyMetadata.KeyManager == kmstypes.KeyManagerTypeAws {
+		return allErrs
+	}
+	if key.KeyMetadata.KeyState == kmstypes.KeyStateDisabled || key.KeyMetadata.KeyState == kmstypes.KeyStatePendingDeletion {
+		return append(allErrs, field.Invalid(fieldPath, keyID, fmt.Sprintf("KMS key is in state %s", key.KeyMetadata.KeyState)))
+	}
+	keyARN := aws.ToString(key.KeyMetadata.Arn)
+
+	account, partition, err := cloud.AccountInfo(ctx)
+	if err != nil {
+		klog.Warningf("unable to determine the AWS account, skipping validation of the key policy of KMS key %q: %v", keyID, err)
+		return allErrs
+	}
+	serviceLinkedRole := fmt.Sprintf("arn:%s:iam::%s:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling", partition, account)
+
+	missing := sets.New(rootVolumeKMSActions...)
+	// conditional are the actions allowed by statements with conditions, which may or may not apply to the role
+	conditional := sets.New[string]()
+
+	policy, err := cloud.KMS().GetKeyPolicy(ctx, &kms.GetKeyPolicyInput{KeyId: aws.String(keyARN), PolicyName: aws.String("default")})
+	if err != nil {
+		klog.Warningf("unable to get the key policy of KMS key %q, skipping its validation: %v", keyID, err)
+		return allErrs
+	}
+	var keyPolicy kmsKeyPolicy
+	if err := json.Unmarshal([]byte(aws.ToString(policy.Policy)), &keyPolicy); err != nil {
+		klog.Warningf("unable to parse the key policy of KMS key %q, skipping its validation: %v", keyID, err)
+		return allErrs
+	}
+	for _, statement := range keyPolicy.Statement {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		if !statement.Principal.Any && !sets.New(statement.Principal.AWS.Value()...).Has(serviceLinkedRole) {
+			continue
+		}
+		hasCondition := len(statement.Condition) > 0 && string(statement.Condition) != "null" && string(statement.Condition) != "{}"
+		for _, pattern := range statement.Action.Value() {
+			for _, action := range sets.List(missing) {
+				if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(action)); matched {
+					if hasCondition {
+						conditional.Insert(action)
+					} else {
+						missing.Delete(action)
+					}
+				}
+			}
+		}
+	}
+
+	paginator := kms.NewListGrantsPaginator(cloud.KMS(), &kms.ListGrantsInput{KeyId: aws.String(keyARN)})
+	for missing.Len() > 0 && paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			klog.Warningf("unable to list the grants of KMS key %q, skipping their validation: %v", keyID, err)
+			return allErrs
+		}
+		for _, grant := range page.Grants {
+			if aws.ToString(grant.GranteePrincipal) != serviceLinkedRole {
+				continue
+			}
+			for _, operation := range grant.Operations {
+				missing.Delete("kms:" + string(operation))
+			}
+		}
+	}
+
+	if unverified := missing.Intersection(conditional); unverified.Len() > 0 {
+		klog.Warningf("the key policy of KMS key %q only allows %s to use %s under conditions that kOps does not evaluate; instances fail to launch if they do not apply",
+			keyID, serviceLinkedRole, strings.Join(sets.List(unverified), ", "))
+		missing = missing.Difference(conditional)
+	}
+
+	if missing.Len() > 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath, keyID,
+			fmt.Sprintf("KMS key does not allow %s to use %s", serviceLinkedRole, strings.Join(sets.List(missing), ", "))))
+	}
+
+	return allErrs
+}
+
+// awsValidateEFA checks that the machine types of the instance group support the Elastic Fabric Adapter interfaces.
+func awsValidateEFA(fieldPath *field.Path, ig *kops.InstanceGroup, cloud awsup.AWSCloud) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	interfaceCount := int32(1)
+	if ig.Spec.EFA.InterfaceCount != nil {
+		interfaceCount = *ig.Spec.EFA.InterfaceCount
+	}
+
+	instanceTypes := strings.Split(ig.Spec.MachineType, ",")
+	if ig.Spec.MixedInstancesPolicy != nil {
+		instanceTypes = append(instanceTypes, ig.Spec.MixedInstancesPolicy.Instances...)
+	}
+
+	for _, instanceType := range sets.List(sets.New(instanceTypes...)) {
+		if instanceType == "" {
+			continue
+		}
+		// Invalid machine types are reported by the validation of the machine types
+		info, err := cloud.DescribeInstanceType(instanceType)
+		if err != nil || info == nil || info.NetworkInfo == nil {
+			continue
+		}
+
+		if !aws.ToBool(info.NetworkInfo.EfaSupported) {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, fmt.Sprintf("machine type %q does not support EFA", instanceType)))
+		} else if maxNetworkCards := aws.ToInt32(info.NetworkInfo.MaximumNetworkCards); interfaceCount > maxNetworkCards {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("interfaceCount"), interfaceCount,
+				fmt.Sprintf("machine type %q has %d network cards", instanceType, maxNetworkCards)))
+		}
+	}
+
+	return allErrs
+}
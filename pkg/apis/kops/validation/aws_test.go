@@ -22,8 +22,11 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/cloudmock/aws/mockkms"
 
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
@@ -334,6 +337,56 @@ func TestMixedInstancePolicies(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Invalid value::spec.mixedInstancesPolicy.onDemandAboveBase"},
 		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						CPU: &kops.MinMaxSpec{
+							Min: resource.NewQuantity(2, resource.DecimalSI),
+							Max: resource.NewQuantity(8, resource.DecimalSI),
+						},
+						Architectures:         []string{"amd64"},
+						ExcludedInstanceTypes: []string{"t2.*", "*6g.*", "m5.24xlarge"},
+					},
+				},
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						Architectures: []string{"amd64", "arm64", "riscv64"},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.mixedInstancesPolicy.instanceRequirements.architectures[1]",
+				"Unsupported value::spec.mixedInstancesPolicy.instanceRequirements.architectures[2]",
+			},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						Memory: &kops.MinMaxSpec{
+							Min: resource.NewQuantity(16*1024*1024*1024, resource.BinarySI),
+							Max: resource.NewQuantity(8*1024*1024*1024, resource.BinarySI),
+						},
+						ExcludedInstanceTypes: []string{"t3 micro"},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.mixedInstancesPolicy.instanceRequirements.memory.min",
+				"Invalid value::spec.mixedInstancesPolicy.instanceRequirements.excludedInstanceTypes[0]",
+			},
+		},
 	}
 	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
 	mockEC2 := &mockec2.MockEC2{}
@@ -607,6 +660,223 @@ func TestLoadBalancerSubnets(t *testing.T) {
 	}
 }
 
+func TestInternalLoadBalancer(t *testing.T) {
+	tests := []struct {
+		class    kops.LoadBalancerClass
+		lbType   kops.LoadBalancerType
+		internal kops.InternalLoadBalancerAccessSpec
+		expected []string
+	}{
+		{
+			class:  kops.LoadBalancerClassNetwork,
+			lbType: kops.LoadBalancerTypePublic,
+			internal: kops.InternalLoadBalancerAccessSpec{
+				Access:         []string{"10.0.0.0/8", "pl-12345678"},
+				SSLCertificate: "arn:aws-test:acm:us-test-1:000000000000:certificate/123456789012-1234-1234-1234-12345678",
+				SSLPolicy:      fi.PtrTo("ELBSecurityPolicy-2016-08"),
+				Subnets:        []kops.LoadBalancerSubnetSpec{{Name: "a", PrivateIPv4Address: fi.PtrTo("10.0.0.10")}},
+			},
+		},
+		{
+			class:    kops.LoadBalancerClassClassic,
+			lbType:   kops.LoadBalancerTypePublic,
+			expected: []string{"Forbidden::spec.api.loadBalancer.internal"},
+		},
+		{
+			class:    kops.LoadBalancerClassNetwork,
+			lbType:   kops.LoadBalancerTypeInternal,
+			expected: []string{"Forbidden::spec.api.loadBalancer.internal"},
+		},
+		{
+			class:  kops.LoadBalancerClassNetwork,
+			lbType: kops.LoadBalancerTypePublic,
+			internal: kops.InternalLoadBalancerAccessSpec{
+				Access: []string{"10.0.0.0"},
+			},
+			expected: []string{"Invalid value::spec.api.loadBalancer.internal.access[0]"},
+		},
+		{
+			class:  kops.LoadBalancerClassNetwork,
+			lbType: kops.LoadBalancerTypePublic,
+			internal: kops.InternalLoadBalancerAccessSpec{
+				SSLPolicy: fi.PtrTo("ELBSecurityPolicy-2016-08"),
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.internal.sslPolicy"},
+		},
+		{
+			class:  kops.LoadBalancerClassNetwork,
+			lbType: kops.LoadBalancerTypePublic,
+			internal: kops.InternalLoadBalancerAccessSpec{
+				Subnets: []kops.LoadBalancerSubnetSpec{{Name: "a", AllocationID: fi.PtrTo("eipalloc-222ghi789")}},
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.internal.subnets[0].allocationID"},
+		},
+	}
+
+	for _, test := range tests {
+		internal := test.internal
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:    test.class,
+						Type:     test.lbType,
+						Internal: &internal,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+				Networking: kops.NetworkingSpec{
+					Subnets: []kops.ClusterSubnetSpec{{Name: "a", CIDR: "10.0.0.0/24"}},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, true)
+		testErrors(t, test, errs, test.expected)
+	}
+}
+
+func TestACMCertificate(t *testing.T) {
+	tests := []struct {
+		clusterName    string
+		class          kops.LoadBalancerClass
+		dns            kops.DNSType
+		sslCertificate string
+		sans           []string
+		expected       []string
+	}{
+		{
+			clusterName: "cluster.example.com",
+			class:       kops.LoadBalancerClassNetwork,
+			sans:        []string{"api.example.com", "*.api.example.com"},
+		},
+		{
+			clusterName: "cluster.example.com",
+			class:       kops.LoadBalancerClassClassic,
+			expected:    []string{"Forbidden::spec.api.loadBalancer.acmCertificate"},
+		},
+		{
+			clusterName:    "cluster.example.com",
+			class:          kops.LoadBalancerClassNetwork,
+			sslCertificate: "arn:aws-test:acm:us-test-1:000000000000:certificate/123456789012-1234-1234-1234-12345678",
+			expected:       []string{"Forbidden::spec.api.loadBalancer.acmCertificate"},
+		},
+		{
+			clusterName: "cluster.k8s.local",
+			class:       kops.LoadBalancerClassNetwork,
+			expected:    []string{"Forbidden::spec.api.loadBalancer.acmCertificate"},
+		},
+		{
+			clusterName: "cluster.example.com",
+			class:       kops.LoadBalancerClassNetwork,
+			dns:         kops.DNSTypePrivate,
+			expected:    []string{"Forbidden::spec.api.loadBalancer.acmCertificate"},
+		},
+		{
+			clusterName: "cluster.example.com",
+			class:       kops.LoadBalancerClassNetwork,
+			sans:        []string{"api_example.com"},
+			expected:    []string{"Invalid value::spec.api.loadBalancer.acmCertificate.subjectAlternativeNames[0]"},
+		},
+	}
+
+	for _, test := range tests {
+		cluster := kops.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: test.clusterName},
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:          test.class,
+						Type:           kops.LoadBalancerTypePublic,
+						SSLCertificate: test.sslCertificate,
+						ACMCertificate: &kops.ACMCertificateSpec{
+							SubjectAlternativeNames: test.sans,
+						},
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		if test.dns != "" {
+			cluster.Spec.Networking.Topology = &kops.TopologySpec{DNS: test.dns}
+		}
+		errs := awsValidateCluster(&cluster, true)
+		testErrors(t, test, errs, test.expected)
+	}
+}
+
+func TestLoadBalancerTLSMode(t *testing.T) {
+	tests := []struct {
+		class          kops.LoadBalancerClass
+		sslCertificate string
+		tlsMode        kops.LoadBalancerTLSMode
+		alpnPolicy     *string
+		expected       []string
+	}{
+		{
+			class:          kops.LoadBalancerClassNetwork,
+			sslCertificate: "arn:aws-test:acm:us-test-1:000000000000:certificate/123456789012-1234-1234-1234-12345678",
+			tlsMode:        kops.LoadBalancerTLSModePassthrough,
+			alpnPolicy:     fi.PtrTo("HTTP2Preferred"),
+		},
+		{
+			class:   kops.LoadBalancerClassNetwork,
+			tlsMode: kops.LoadBalancerTLSModePassthrough,
+		},
+		{
+			class:    kops.LoadBalancerClassNetwork,
+			tlsMode:  kops.LoadBalancerTLSModeTerminate,
+			expected: []string{"Forbidden::spec.api.loadBalancer.tlsMode"},
+		},
+		{
+			class:          kops.LoadBalancerClassNetwork,
+			sslCertificate: "arn:aws-test:acm:us-test-1:000000000000:certificate/123456789012-1234-1234-1234-12345678",
+			tlsMode:        "Reencrypt",
+			expected:       []string{"Unsupported value::spec.api.loadBalancer.tlsMode"},
+		},
+		{
+			class:    kops.LoadBalancerClassClassic,
+			tlsMode:  kops.LoadBalancerTLSModePassthrough,
+			expected: []string{"Forbidden::spec.api.loadBalancer.tlsMode"},
+		},
+		{
+			class:          kops.LoadBalancerClassNetwork,
+			sslCertificate: "arn:aws-test:acm:us-test-1:000000000000:certificate/123456789012-1234-1234-1234-12345678",
+			alpnPolicy:     fi.PtrTo("HTTP3"),
+			expected:       []string{"Unsupported value::spec.api.loadBalancer.alpnPolicy"},
+		},
+		{
+			class:      kops.LoadBalancerClassNetwork,
+			alpnPolicy: fi.PtrTo("HTTP2Only"),
+			expected:   []string{"Forbidden::spec.api.loadBalancer.alpnPolicy"},
+		},
+	}
+
+	for _, test := range tests {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: kops.APISpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:          test.class,
+						Type:           kops.LoadBalancerTypePublic,
+						SSLCertificate: test.sslCertificate,
+						TLSMode:        test.tlsMode,
+						ALPNPolicy:     test.alpnPolicy,
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster, true)
+		testErrors(t, test, errs, test.expected)
+	}
+}
+
 func TestAWSAuthentication(t *testing.T) {
 	tests := []struct {
 		backendMode      string
@@ -639,7 +909,15 @@ func TestAWSAuthentication(t *testing.T) {
 				},
 			},
 		},
-		{ // forbidden backendMode
+		{ // valid, default backendMode
+			identityMappings: []kops.AWSAuthenticationIdentityMappingSpec{
+				{
+					ARN:      "arn:aws:iam::123456789012:role/KopsExampleRole",
+					Username: "foo",
+				},
+			},
+		},
+		{ // valid, MountedFile backendMode
 			backendMode: "MountedFile",
 			identityMappings: []kops.AWSAuthenticationIdentityMappingSpec{
 				{
@@ -647,8 +925,33 @@ func TestAWSAuthentication(t *testing.T) {
 					Username: "foo",
 				},
 			},
+		},
+		{ // forbidden backendMode
+			backendMode: "EKSConfigMap",
+			identityMappings: []kops.AWSAuthenticationIdentityMappingSpec{
+				{
+					ARN:      "arn:aws:iam::123456789012:role/KopsExampleRole",
+					Username: "foo",
+				},
+			},
 			expected: []string{"Forbidden::spec.authentication.aws.backendMode"},
 		},
+		{ // duplicate identity ARN and missing username
+			backendMode: "CRD",
+			identityMappings: []kops.AWSAuthenticationIdentityMappingSpec{
+				{
+					ARN:      "arn:aws:iam::123456789012:role/KopsExampleRole",
+					Username: "foo",
+				},
+				{
+					ARN: "arn:aws:iam::123456789012:role/KopsExampleRole",
+				},
+			},
+			expected: []string{
+				"Duplicate value::spec.authentication.aws.identityMappings[1].arn",
+				"Required value::spec.authentication.aws.identityMappings[1].username",
+			},
+		},
 		{ // invalid identity ARN
 			backendMode: "CRD",
 			identityMappings: []kops.AWSAuthenticationIdentityMappingSpec{
@@ -886,3 +1189,286 @@ func TestAWSAdditionalRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestAWSValidateRootVolumeEncryptionKey(t *testing.T) {
+	const serviceLinkedRole = "arn:aws-test:iam::123456789012:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling"
+
+	grid := []struct {
+		name     string
+		policy   string
+		grants   []kmstypes.GrantListEntry
+		keyID    string
+		expected []string
+	}{
+		{
+			name: "service-linked role in key policy",
+			policy: `{"Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "arn:aws-test:iam::123456789012:root"}, "Action": "kms:*"},
+				{"Effect": "Allow", "Principal": {"AWS": ["` + serviceLinkedRole + `"]}, "Action": ["kms:Encrypt", "kms:Decrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*", "kms:DescribeKey"]},
+				{"Effect": "Allow", "Principal": {"AWS": "` + serviceLinkedRole + `"}, "Action": "kms:CreateGrant"}
+			]}`,
+		},
+		{
+			name:   "any principal in key policy",
+			policy: `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "kms:*"}]}`,
+		},
+		{
+			name:   "found by alias",
+			policy: `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "kms:*"}]}`,
+			keyID:  "alias/nodes",
+		},
+		{
+			name:     "only account root in key policy",
+			policy:   `{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "arn:aws-test:iam::123456789012:root"}, "Action": "kms:*"}]}`,
+			expected: []string{"Invalid value::spec.rootVolume.encryptionKey"},
+		},
+		{
+			name:     "create grant not allowed",
+			policy:   `{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "` + serviceLinkedRole + `"}, "Action": ["kms:Decrypt", "kms:GenerateDataKey*"]}]}`,
+			expected: []string{"Invalid value::spec.rootVolume.encryptionKey"},
+		},
+		{
+			name:     "denied to service-linked role",
+			policy:   `{"Statement": [{"Effect": "Deny", "Principal": {"AWS": "` + serviceLinkedRole + `"}, "Action": "kms:*"}]}`,
+			expected: []string{"Invalid value::spec.rootVolume.encryptionKey"},
+		},
+		{
+			name:   "service-linked role in grants",
+			policy: `{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "arn:aws-test:iam::123456789012:root"}, "Action": "kms:*"}]}`,
+			grants: []kmstypes.GrantListEntry{
+				{
+					GranteePrincipal: aws.String(serviceLinkedRole),
+					Operations: []kmstypes.GrantOperation{
+						kmstypes.GrantOperationDecrypt,
+						kmstypes.GrantOperationGenerateDataKeyWithoutPlaintext,
+						kmstypes.GrantOperationCreateGrant,
+					},
+				},
+			},
+		},
+		{
+			name:   "service-linked role in second page of grants",
+			policy: `{"Statement": [{"Effect": "Allow", "Principal": {"AWS": "arn:aws-test:iam::123456789012:root"}, "Action": "kms:*"}]}`,
+			grants: append(make([]kmstypes.GrantListEntry, 50), kmstypes.GrantListEntry{
+				GranteePrincipal: aws.String(serviceLinkedRole),
+				Operations: []kmstypes.GrantOperation{
+					kmstypes.GrantOperationDecrypt,
+					kmstypes.GrantOperationGenerateDataKeyWithoutPlaintext,
+					kmstypes.GrantOperationCreateGrant,
+				},
+			}),
+		},
+		{
+			name: "service-linked role in key policy with conditions",
+			policy: `{"Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "` + serviceLinkedRole + `"}, "Action": ["kms:Decrypt", "kms:GenerateDataKey*"]},
+				{"Effect": "Allow", "Principal": {"AWS": "` + serviceLinkedRole + `"}, "Action": "kms:CreateGrant", "Condition": {"Bool": {"kms:GrantIsForAWSResource": "true"}}}
+			]}`,
+		},
+		{
+			name: "conditions do not allow missing actions",
+			policy: `{"Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "` + serviceLinkedRole + `"}, "Action": "kms:CreateGrant", "Condition": {"Bool": {"kms:GrantIsForAWSResource": "true"}}}
+			]}`,
+			expected: []string{"Invalid value::spec.rootVolume.encryptionKey"},
+		},
+		{
+			name:     "key not found",
+			keyID:    "arn:aws-test:kms:us-test-1:123456789012:key/unknown",
+			expected: []string{"Not found::spec.rootVolume.encryptionKey"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cloud := awsup.BuildMockAWSCloud("us-test-1", "abc")
+			mockKMS := &mockkms.MockKMS{}
+			cloud.MockKMS = mockKMS
+			key := mockKMS.AddKey("arn:aws-test:kms:us-test-1:123456789012:key/1234abcd", g.policy, "alias/nodes")
+			key.Grants = g.grants
+
+			keyID := g.keyID
+			if keyID == "" {
+				keyID = "arn:aws-test:kms:us-test-1:123456789012:key/1234abcd"
+			}
+			errs := awsValidateRootVolumeEncryptionKey(field.NewPath("spec", "rootVolume", "encryptionKey"), keyID, cloud)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateConfidentialCompute(t *testing.T) {
+	grid := []struct {
+		name     string
+		input    kops.InstanceGroupSpec
+		expected []string
+	}{
+		{
+			name: "supported",
+			input: kops.InstanceGroupSpec{
+				MachineType: "m6a.xlarge",
+				ConfidentialCompute: &kops.ConfidentialComputeSpec{
+					NitroEnclaves: &kops.NitroEnclavesSpec{CPUCount: fi.PtrTo(int32(2)), MemoryMiB: fi.PtrTo(int32(1024))},
+					AMDSevSnp:     fi.PtrTo(true),
+				},
+			},
+		},
+		{
+			name: "unsupported machine type",
+			input: kops.InstanceGroupSpec{
+				MachineType: "t3.medium",
+				ConfidentialCompute: &kops.ConfidentialComputeSpec{
+					NitroEnclaves: &kops.NitroEnclavesSpec{},
+					AMDSevSnp:     fi.PtrTo(true),
+				},
+			},
+			expected: []string{
+				"Forbidden::spec.confidentialCompute.nitroEnclaves",
+				"Forbidden::spec.confidentialCompute.amdSevSnp",
+			},
+		},
+		{
+			name: "unsupported mixed instance type",
+			input: kops.InstanceGroupSpec{
+				MachineType: "m6a.xlarge",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances: []string{"m6a.xlarge", "m5.large"},
+				},
+				ConfidentialCompute: &kops.ConfidentialComputeSpec{
+					AMDSevSnp: fi.PtrTo(true),
+				},
+			},
+			expected: []string{"Forbidden::spec.confidentialCompute.amdSevSnp"},
+		},
+		{
+			name: "too many resources",
+			input: kops.InstanceGroupSpec{
+				MachineType: "m6a.xlarge",
+				ConfidentialCompute: &kops.ConfidentialComputeSpec{
+					NitroEnclaves: &kops.NitroEnclavesSpec{CPUCount: fi.PtrTo(int32(4)), MemoryMiB: fi.PtrTo(int32(16384))},
+				},
+			},
+			expected: []string{
+				"Invalid value::spec.confidentialCompute.nitroEnclaves.cpuCount",
+				"Invalid value::spec.confidentialCompute.nitroEnclaves.memoryMiB",
+			},
+		},
+	}
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-nodes",
+				},
+				Spec: g.input,
+			}
+			errs := awsValidateConfidentialCompute(field.NewPath("spec", "confidentialCompute"), ig, cloud)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidateEFA(t *testing.T) {
+	grid := []struct {
+		name     string
+		input    kops.InstanceGroupSpec
+		expected []string
+	}{
+		{
+			name: "supported",
+			input: kops.InstanceGroupSpec{
+				MachineType: "p4d.24xlarge",
+				EFA:         &kops.EFASpec{InterfaceCount: fi.PtrTo(int32(4))},
+			},
+		},
+		{
+			name: "unsupported machine type",
+			input: kops.InstanceGroupSpec{
+				MachineType: "m5.large",
+				EFA:         &kops.EFASpec{},
+			},
+			expected: []string{"Forbidden::spec.efa"},
+		},
+		{
+			name: "too many interfaces",
+			input: kops.InstanceGroupSpec{
+				MachineType: "p4d.24xlarge",
+				EFA:         &kops.EFASpec{InterfaceCount: fi.PtrTo(int32(5))},
+			},
+			expected: []string{"Invalid value::spec.efa.interfaceCount"},
+		},
+	}
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			ig := &kops.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-nodes",
+				},
+				Spec: g.input,
+			}
+			errs := awsValidateEFA(field.NewPath("spec", "efa"), ig, cloud)
+			testErrors(t, g.name, errs, g.expected)
+		})
+	}
+}
+
+func TestAWSValidatePrivateDNSZoneVPCs(t *testing.T) {
+	grid := []struct {
+		DNS            kops.DNSType
+		Input          []kops.PrivateDNSZoneVPCSpec
+		ExpectedErrors []string
+	}{
+		{
+			DNS: kops.DNSTypePrivate,
+			Input: []kops.PrivateDNSZoneVPCSpec{
+				{ID: "vpc-mgmt"},
+				{ID: "vpc-vpn", Region: "us-west-2"},
+				{ID: "vpc-cluster", Region: "us-west-2"},
+			},
+		},
+		{
+			DNS:            kops.DNSTypePublic,
+			Input:          []kops.PrivateDNSZoneVPCSpec{{ID: "vpc-mgmt"}},
+			ExpectedErrors: []string{"Forbidden::spec.cloudProvider.aws.privateDNSZoneVPCs"},
+		},
+		{
+			DNS:            kops.DNSTypePrivate,
+			Input:          []kops.PrivateDNSZoneVPCSpec{{ID: "mgmt"}},
+			ExpectedErrors: []string{"Invalid value::spec.cloudProvider.aws.privateDNSZoneVPCs[0].id"},
+		},
+		{
+			DNS:            kops.DNSTypePrivate,
+			Input:          []kops.PrivateDNSZoneVPCSpec{{ID: "vpc-cluster"}},
+			ExpectedErrors: []string{"Forbidden::spec.cloudProvider.aws.privateDNSZoneVPCs[0].id"},
+		},
+		{
+			DNS:            kops.DNSTypePrivate,
+			Input:          []kops.PrivateDNSZoneVPCSpec{{ID: "vpc-mgmt"}, {ID: "vpc-mgmt"}},
+			ExpectedErrors: []string{"Duplicate value::spec.cloudProvider.aws.privateDNSZoneVPCs[1]"},
+		},
+	}
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{
+						PrivateDNSZoneVPCs: g.Input,
+					},
+				},
+				Networking: kops.NetworkingSpec{
+					NetworkID: "vpc-cluster",
+					Topology: &kops.TopologySpec{
+						DNS: g.DNS,
+					},
+				},
+			},
+		}
+		errs := awsValidatePrivateDNSZoneVPCs(field.NewPath("spec", "cloudProvider", "aws", "privateDNSZoneVPCs"), cluster)
+
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
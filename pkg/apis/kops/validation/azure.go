@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func azureValidateInstanceGroup(ig *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fieldSpec := field.NewPath("spec")
+
+	spot := ig.Spec.MaxPrice != nil
+	if spec := ig.Spec.Azure; spec != nil {
+		fldPath := fieldSpec.Child("azure")
+		if spec.OrchestrationMode != "" {
+			allErrs = append(allErrs, IsValidValue(fldPath.Child("orchestrationMode"), &spec.OrchestrationMode, []string{kops.AzureOrchestrationModeUniform, kops.AzureOrchestrationModeFlexible})...)
+		}
+		if spec.Priority != "" {
+			allErrs = append(allErrs, IsValidValue(fldPath.Child("priority"), &spec.Priority, []string{kops.AzurePriorityRegular, kops.AzurePrioritySpot})...)
+			spot = spec.Priority == kops.AzurePrioritySpot
+			if !spot && ig.Spec.MaxPrice != nil {
+				allErrs = append(allErrs, field.Forbidden(fieldSpec.Child("maxPrice"), "maxPrice can only be set for Spot VMs"))
+			}
+		}
+		if spec.EvictionPolicy != "" {
+			allErrs = append(allErrs, IsValidValue(fldPath.Child("evictionPolicy"), &spec.EvictionPolicy, []string{kops.AzureEvictionPolicyDelete, kops.AzureEvictionPolicyDeallocate})...)
+			if !spot {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("evictionPolicy"), "evictionPolicy can only be set for Spot VMs"))
+			}
+		}
+	}
+
+	if ig.Spec.MaxPrice != nil {
+		// Azure accepts a price in USD with up to 5 decimals, or -1 to pay up to the price of regular VMs
+		price, err := strconv.ParseFloat(*ig.Spec.MaxPrice, 64)
+		if err != nil || (price <= 0 && price != -1) {
+			allErrs = append(allErrs, field.Invalid(fieldSpec.Child("maxPrice"), *ig.Spec.MaxPrice, "must be a price in USD greater than 0, or -1"))
+		}
+	}
+
+	return allErrs
+}
@@ -66,6 +66,9 @@ func validateEtcdClusterUpdate(fp *field.Path, obj kops.EtcdClusterSpec, status
 	if obj.Name != old.Name {
 		allErrs = append(allErrs, field.Forbidden(fp.Child("name"), "name cannot be changed"))
 	}
+	if (obj.External == nil) != (old.External == nil) {
+		allErrs = append(allErrs, field.Forbidden(fp.Child("external"), "an etcd cluster cannot be switched between external and managed by kOps"))
+	}
 
 	var etcdClusterStatus *kops.EtcdClusterStatus
 	if status != nil {
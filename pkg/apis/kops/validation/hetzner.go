@@ -0,0 +1,47 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// hetznerMaxPlacementGroupServers is the maximum number of servers in a spread placement group.
+const hetznerMaxPlacementGroupServers = 10
+
+func hetznerValidateInstanceGroup(ig *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	spec := ig.Spec.Hetzner
+	if spec == nil || spec.PlacementGroup == "" {
+		return allErrs
+	}
+	fldPath := field.NewPath("spec", "hetzner", "placementGroup")
+
+	for _, msg := range validation.IsDNS1123Label(spec.PlacementGroup) {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.PlacementGroup, msg))
+	}
+	if ig.Spec.MaxSize != nil && *ig.Spec.MaxSize > hetznerMaxPlacementGroupServers {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("a placement group can hold at most %d servers", hetznerMaxPlacementGroupServers)))
+	}
+
+	return allErrs
+}
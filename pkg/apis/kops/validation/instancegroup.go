@@ -18,6 +18,8 @@ package validation
 
 import (
 	"fmt"
+	"net"
+	"path/filepath"
 	"strings"
 
 	"k8s.io/kops/pkg/nodeidentity/aws"
@@ -29,6 +31,7 @@ import (
 
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/model/defaults"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
@@ -71,6 +74,24 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "tenancy"), &tenancy, ec2types.Tenancy("").Values())...)
 	}
 
+	if g.Spec.HostResourceGroupARN != "" {
+		fldPath := field.NewPath("spec", "hostResourceGroupARN")
+		if g.Spec.Tenancy != string(ec2types.TenancyHost) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "hostResourceGroupARN can only be used with host tenancy"))
+		} else if parsed, err := arn.Parse(g.Spec.HostResourceGroupARN); err != nil || parsed.Service != "resource-groups" || !strings.HasPrefix(parsed.Resource, "group/") {
+			allErrs = append(allErrs, field.Invalid(fldPath, g.Spec.HostResourceGroupARN, "must be the ARN of a resource group"))
+		}
+	}
+
+	if g.Spec.Tenancy == string(ec2types.TenancyHost) {
+		if g.Spec.HostResourceGroupARN == "" && g.Spec.Manager != kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "hostResourceGroupARN"), "autoscaling groups can only launch instances on dedicated hosts of a host resource group"))
+		}
+		if g.Spec.MaxPrice != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "maxPrice"), "spot instances cannot run on dedicated hosts"))
+		}
+	}
+
 	if strict && g.Spec.Manager == kops.InstanceManagerCloudGroup {
 		if g.Spec.MaxSize == nil {
 			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "maxSize"), "maxSize must be set"))
@@ -100,6 +121,56 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		}
 	}
 
+	if g.Spec.LogRotation != nil {
+		allErrs = append(allErrs, validateLogRotation(g.Spec.LogRotation, field.NewPath("spec", "logRotation"))...)
+	}
+
+	if g.Spec.ConfidentialCompute != nil && g.Spec.ConfidentialCompute.NitroEnclaves != nil {
+		fldPath := field.NewPath("spec", "confidentialCompute", "nitroEnclaves")
+		nitroEnclaves := g.Spec.ConfidentialCompute.NitroEnclaves
+		if nitroEnclaves.CPUCount != nil && *nitroEnclaves.CPUCount <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cpuCount"), *nitroEnclaves.CPUCount, "must be greater than 0"))
+		}
+		if nitroEnclaves.MemoryMiB != nil && *nitroEnclaves.MemoryMiB <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("memoryMiB"), *nitroEnclaves.MemoryMiB, "must be greater than 0"))
+		}
+	}
+
+	if efa := g.Spec.EFA; efa != nil {
+		fldPath := field.NewPath("spec", "efa")
+		if g.Spec.Role != kops.InstanceGroupRoleNode {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "efa is only supported on instance groups with role Node"))
+		}
+		if g.Spec.Manager == kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "efa is not supported on instance groups managed by Karpenter"))
+		}
+		if efa.InterfaceCount != nil && *efa.InterfaceCount <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("interfaceCount"), *efa.InterfaceCount, "must be greater than 0"))
+		}
+		if efa.HugePages != nil && *efa.HugePages < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hugePages"), *efa.HugePages, "must not be negative"))
+		}
+	}
+
+	if len(g.Spec.AdditionalNetworkInterfaces) > 0 {
+		fldPath := field.NewPath("spec", "additionalNetworkInterfaces")
+		if g.Spec.Manager == kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "additionalNetworkInterfaces are not supported on instance groups managed by Karpenter"))
+		}
+		deviceIndexes := make(map[int32]bool)
+		for i, ni := range g.Spec.AdditionalNetworkInterfaces {
+			if ni.DeviceIndex < 1 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("deviceIndex"), ni.DeviceIndex, "must be greater than 0"))
+			} else if deviceIndexes[ni.DeviceIndex] {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("deviceIndex"), ni.DeviceIndex))
+			}
+			deviceIndexes[ni.DeviceIndex] = true
+			if ni.Subnet == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("subnet"), ""))
+			}
+		}
+	}
+
 	// @check all the hooks are valid in this instancegroup
 	for i := range g.Spec.Hooks {
 		allErrs = append(allErrs, validateHookSpec(&g.Spec.Hooks[i], field.NewPath("spec", "hooks").Index(i))...)
@@ -115,8 +186,8 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 	}
 
 	// @step: iterate and check the volume specs
+	devices := make(map[string]bool)
 	for i, x := range g.Spec.Volumes {
-		devices := make(map[string]bool)
 		path := field.NewPath("spec", "volumes").Index(i)
 
 		allErrs = append(allErrs, validateVolumeSpec(path, x)...)
@@ -130,17 +201,20 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 	}
 
 	// @step: iterate and check the volume mount specs
+	usedDevices := make(map[string]bool)
+	usedPaths := make(map[string]bool)
 	for i, x := range g.Spec.VolumeMounts {
-		used := make(map[string]bool)
 		path := field.NewPath("spec", "volumeMounts").Index(i)
 
 		allErrs = append(allErrs, validateVolumeMountSpec(path, x)...)
-		if _, found := used[x.Device]; found {
+		if usedDevices[x.Device] {
 			allErrs = append(allErrs, field.Duplicate(path.Child("device"), x.Device))
 		}
-		if _, found := used[x.Path]; found {
+		if usedPaths[x.Path] {
 			allErrs = append(allErrs, field.Duplicate(path.Child("path"), x.Path))
 		}
+		usedDevices[x.Device] = true
+		usedPaths[x.Path] = true
 	}
 
 	allErrs = append(allErrs, validateInstanceProfile(g.Spec.IAM, field.NewPath("spec", "iam"))...)
@@ -155,6 +229,26 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, validateRollingUpdate(g.Spec.RollingUpdate, field.NewPath("spec", "rollingUpdate"), g.Spec.Role == kops.InstanceGroupRoleControlPlane)...)
 	}
 
+	if g.Spec.HealthCheck != nil {
+		allErrs = append(allErrs, validateHealthCheck(g.Spec.HealthCheck, field.NewPath("spec", "healthCheck"))...)
+	}
+
+	if g.Spec.InstanceMaintenancePolicy != nil {
+		allErrs = append(allErrs, validateInstanceMaintenancePolicy(g.Spec.InstanceMaintenancePolicy, field.NewPath("spec", "instanceMaintenancePolicy"))...)
+	}
+
+	if g.Spec.DefaultInstanceWarmup != nil && g.Spec.DefaultInstanceWarmup.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "defaultInstanceWarmup"), g.Spec.DefaultInstanceWarmup.Duration.String(), "must not be negative"))
+	}
+
+	if len(g.Spec.ScalingPolicies) > 0 {
+		allErrs = append(allErrs, validateScalingPolicies(g.Spec.ScalingPolicies, field.NewPath("spec", "scalingPolicies"))...)
+	}
+
+	if g.Spec.StaticNetworkInterface != nil {
+		allErrs = append(allErrs, validateStaticNetworkInterface(g, field.NewPath("spec", "staticNetworkInterface"))...)
+	}
+
 	if g.Spec.NodeLabels != nil {
 		allErrs = append(allErrs, validateNodeLabels(g.Spec.NodeLabels, field.NewPath("spec", "nodeLabels"))...)
 	}
@@ -223,6 +317,8 @@ func validateVolumeMountSpec(path *field.Path, spec kops.VolumeMountSpec) field.
 	}
 	if spec.Path == "" {
 		allErrs = append(allErrs, field.Required(path.Child("path"), "mount path required"))
+	} else if !filepath.IsAbs(spec.Path) || filepath.Clean(spec.Path) == "/" {
+		allErrs = append(allErrs, field.Invalid(path.Child("path"), spec.Path, "mount path must be an absolute path other than /"))
 	}
 	allErrs = append(allErrs, IsValidValue(path.Child("filesystem"), &spec.Filesystem, kops.SupportedFilesystems)...)
 
@@ -247,6 +343,36 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 		}
 	}
 
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAzure {
+		allErrs = append(allErrs, azureValidateInstanceGroup(g)...)
+	} else if g.Spec.Azure != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "azure"), "azure can only be set on Azure"))
+	}
+
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderHetzner {
+		allErrs = append(allErrs, hetznerValidateInstanceGroup(g)...)
+	} else if g.Spec.Hetzner != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "hetzner"), "hetzner can only be set on Hetzner"))
+	}
+
+	if g.IsSuspended() {
+		fldPath := field.NewPath("spec", "suspended")
+		if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "suspended instance groups are only supported on AWS"))
+		}
+		if g.IsControlPlane() {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "control plane instance groups cannot be suspended"))
+		}
+		if g.Spec.Manager == kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "instance groups managed by Karpenter cannot be suspended"))
+		}
+	}
+
+	// The kubelet serving certificates are only approved when the cluster enables the bootstrap
+	if g.Spec.Kubelet != nil && fi.ValueOf(g.Spec.Kubelet.ServerTLSBootstrap) && !cluster.Spec.UsesKubeletServerTLSBootstrap() {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "kubelet", "serverTLSBootstrap"), "serverTLSBootstrap must be enabled in spec.kubelet of the cluster"))
+	}
+
 	// Check that instance groups are defined in subnets that are defined in the cluster
 	{
 		clusterSubnets := make(map[string]*kops.ClusterSubnetSpec)
@@ -260,12 +386,33 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 				allErrs = append(allErrs, field.NotFound(field.NewPath("spec", "networking", "subnets").Index(i), z))
 			}
 		}
+
+		// Additional network interfaces must be in the availability zone of the instances
+		for i, ni := range g.Spec.AdditionalNetworkInterfaces {
+			fldPath := field.NewPath("spec", "additionalNetworkInterfaces").Index(i).Child("subnet")
+			subnet := clusterSubnets[ni.Subnet]
+			if subnet == nil {
+				if ni.Subnet != "" {
+					allErrs = append(allErrs, field.NotFound(fldPath, ni.Subnet))
+				}
+				continue
+			}
+			for _, z := range g.Spec.Subnets {
+				if clusterSubnets[z] != nil && clusterSubnets[z].Zone != subnet.Zone {
+					allErrs = append(allErrs, field.Invalid(fldPath, ni.Subnet, fmt.Sprintf("must be in the zone of the instance group subnet %q", z)))
+					break
+				}
+			}
+		}
 	}
 
 	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
 		if g.Spec.RootVolume != nil && g.Spec.RootVolume.Type != nil {
 			allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "rootVolume", "type"), g.Spec.RootVolume.Type, []string{"standard", "gp3", "gp2", "io1", "io2"})...)
 		}
+		if g.Spec.RootVolume != nil && g.Spec.RootVolume.EncryptionKey != nil && g.Spec.RootVolume.Encryption != nil && !*g.Spec.RootVolume.Encryption {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "rootVolume", "encryptionKey"), "encryptionKey cannot be used when encryption is disabled"))
+		}
 
 		warmPool := cluster.Spec.CloudProvider.AWS.WarmPool.ResolveDefaults(g)
 		if warmPool.MaxSize == nil || *warmPool.MaxSize != 0 {
@@ -291,6 +438,81 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 		}
 	}
 
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		if g.Spec.HealthCheck != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "healthCheck"), "healthCheck is only supported on AWS"))
+		}
+		if g.Spec.InstanceMaintenancePolicy != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "instanceMaintenancePolicy"), "instanceMaintenancePolicy is only supported on AWS"))
+		}
+		if g.Spec.DefaultInstanceWarmup != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "defaultInstanceWarmup"), "defaultInstanceWarmup is only supported on AWS"))
+		}
+		if len(g.Spec.ScalingPolicies) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "scalingPolicies"), "scalingPolicies are only supported on AWS"))
+		}
+		if g.Spec.ConfidentialCompute != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "confidentialCompute"), "confidentialCompute is only supported on AWS"))
+		}
+		if g.Spec.EFA != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "efa"), "efa is only supported on AWS"))
+		}
+		if len(g.Spec.AdditionalNetworkInterfaces) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "additionalNetworkInterfaces"), "additionalNetworkInterfaces are only supported on AWS"))
+		}
+	}
+
+	// Instances with more than one network interface cannot be assigned a public IP address
+	multipleNetworkInterfaces := len(g.Spec.AdditionalNetworkInterfaces) > 0 || (g.Spec.EFA != nil && fi.ValueOf(g.Spec.EFA.InterfaceCount) > 1)
+	if multipleNetworkInterfaces && len(g.Spec.Subnets) > 0 && (g.Spec.AssociatePublicIP == nil || *g.Spec.AssociatePublicIP) {
+		for _, subnet := range cluster.Spec.Networking.Subnets {
+			if subnet.Name == g.Spec.Subnets[0] && (subnet.Type == kops.SubnetTypePublic || subnet.Type == kops.SubnetTypeUtility) {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "associatePublicIP"), "instances with multiple network interfaces cannot have a public IP address; use private subnets or set associatePublicIP to false"))
+			}
+		}
+	}
+
+	if eni := g.Spec.StaticNetworkInterface; eni != nil {
+		fldPath := field.NewPath("spec", "staticNetworkInterface")
+		if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "staticNetworkInterface is only supported on AWS"))
+		}
+		if eni.PrivateIPAddress != "" && len(g.Spec.Subnets) == 1 {
+			ip := net.ParseIP(eni.PrivateIPAddress)
+			for _, subnet := range cluster.Spec.Networking.Subnets {
+				if subnet.Name != g.Spec.Subnets[0] || subnet.CIDR == "" {
+					continue
+				}
+				_, cidr, err := net.ParseCIDR(subnet.CIDR)
+				if err == nil && ip != nil && !cidr.Contains(ip) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("privateIPAddress"), eni.PrivateIPAddress, fmt.Sprintf("must be within the CIDR %s of subnet %q", subnet.CIDR, subnet.Name)))
+				}
+			}
+		}
+	}
+
+	if cluster.Spec.UsesSecurityGroupsForPods() {
+		if g.Spec.Manager == kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "manager"), "instance groups managed by Karpenter cannot be used with securityGroupsForPods"))
+		}
+		if awsCloud, ok := cloud.(awsup.AWSCloud); ok && g.Spec.Role == kops.InstanceGroupRoleNode {
+			allErrs = append(allErrs, awsValidateTrunkingInstanceTypes(g, awsCloud)...)
+		}
+	}
+
+	if len(g.Spec.ScalingPolicies) > 0 {
+		fldPath := field.NewPath("spec", "scalingPolicies")
+		if g.IsControlPlane() {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "control plane instance groups cannot have scaling policies"))
+		}
+		if g.Spec.Manager == kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "instance groups managed by Karpenter cannot have scaling policies"))
+		}
+		if cluster.Spec.ClusterAutoscaler != nil && fi.ValueOf(cluster.Spec.ClusterAutoscaler.Enabled) && (g.Spec.Autoscale == nil || *g.Spec.Autoscale) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "scaling policies conflict with cluster-autoscaler; set spec.autoscale to false"))
+		}
+	}
+
 	if g.Spec.Containerd != nil {
 		allErrs = append(allErrs, validateContainerdConfig(&cluster.Spec, g.Spec.Containerd, field.NewPath("spec", "containerd"), false)...)
 	}
@@ -301,6 +523,9 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 func ValidateControlPlaneInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for _, etcd := range cluster.Spec.EtcdClusters {
+		if etcd.External != nil {
+			continue
+		}
 		hasEtcd := false
 		for _, m := range etcd.Members {
 			if fi.ValueOf(m.InstanceGroup) == g.ObjectMeta.Name {
@@ -326,6 +551,119 @@ var validUserDataTypes = []string{
 	"text/cloud-boothook",
 }
 
+func validateHealthCheck(spec *kops.InstanceGroupHealthCheckSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Type != "" {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("type"), &spec.Type, []string{"EC2", "ELB"})...)
+	}
+	if spec.GracePeriod != nil && spec.GracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("gracePeriod"), spec.GracePeriod.Duration.String(), "must not be negative"))
+	}
+
+	return allErrs
+}
+
+func validateInstanceMaintenancePolicy(spec *kops.InstanceMaintenancePolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.MinHealthyPercentage == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("minHealthyPercentage"), "minHealthyPercentage must be set with maxHealthyPercentage"))
+	} else if *spec.MinHealthyPercentage < 0 || *spec.MinHealthyPercentage > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minHealthyPercentage"), *spec.MinHealthyPercentage, "must be between 0 and 100"))
+	}
+	if spec.MaxHealthyPercentage == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("maxHealthyPercentage"), "maxHealthyPercentage must be set with minHealthyPercentage"))
+	} else if *spec.MaxHealthyPercentage < 100 || *spec.MaxHealthyPercentage > 200 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxHealthyPercentage"), *spec.MaxHealthyPercentage, "must be between 100 and 200"))
+	}
+	if len(allErrs) == 0 && *spec.MaxHealthyPercentage-*spec.MinHealthyPercentage > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxHealthyPercentage"), *spec.MaxHealthyPercentage, "must be at most 100 more than minHealthyPercentage"))
+	}
+
+	return allErrs
+}
+
+var validScalingPolicyMetrics = []string{"ASGAverageCPUUtilization", "ASGAverageNetworkIn", "ASGAverageNetworkOut"}
+
+var validScalingPolicyAdjustmentTypes = []string{"ChangeInCapacity", "ExactCapacity", "PercentChangeInCapacity"}
+
+func validateScalingPolicies(policies []kops.ScalingPolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for i, policy := range policies {
+		policyPath := fldPath.Index(i)
+		if policy.Name == "" {
+			allErrs = append(allErrs, field.Required(policyPath.Child("name"), "scaling policy name must be set"))
+		} else if names.Has(policy.Name) {
+			allErrs = append(allErrs, field.Duplicate(policyPath.Child("name"), policy.Name))
+		}
+		names.Insert(policy.Name)
+
+		if (policy.TargetTracking == nil) == (policy.Step == nil) {
+			allErrs = append(allErrs, field.Invalid(policyPath, policy.Name, "exactly one of targetTracking and step must be set"))
+		}
+
+		if tt := policy.TargetTracking; tt != nil {
+			ttPath := policyPath.Child("targetTracking")
+			allErrs = append(allErrs, IsValidValue(ttPath.Child("predefinedMetric"), &tt.PredefinedMetric, validScalingPolicyMetrics)...)
+			if tt.TargetValue <= 0 {
+				allErrs = append(allErrs, field.Invalid(ttPath.Child("targetValue"), tt.TargetValue, "must be greater than 0"))
+			}
+		}
+
+		if step := policy.Step; step != nil {
+			stepPath := policyPath.Child("step")
+			allErrs = append(allErrs, IsValidValue(stepPath.Child("adjustmentType"), &step.AdjustmentType, validScalingPolicyAdjustmentTypes)...)
+			if len(step.Steps) == 0 {
+				allErrs = append(allErrs, field.Required(stepPath.Child("steps"), "step scaling policies must have at least one step"))
+			}
+			for j, s := range step.Steps {
+				if s.LowerBound != nil && s.UpperBound != nil && *s.LowerBound >= *s.UpperBound {
+					allErrs = append(allErrs, field.Invalid(stepPath.Child("steps").Index(j).Child("upperBound"), *s.UpperBound, "must be greater than lowerBound"))
+				}
+			}
+			if step.EstimatedInstanceWarmup != nil && step.EstimatedInstanceWarmup.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(stepPath.Child("estimatedInstanceWarmup"), step.EstimatedInstanceWarmup.Duration.String(), "must not be negative"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateStaticNetworkInterface(g *kops.InstanceGroup, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	eni := g.Spec.StaticNetworkInterface
+
+	if !g.IsControlPlane() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only control plane instance groups can have a static network interface"))
+	}
+	if len(g.Spec.Subnets) != 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "subnets"), g.Spec.Subnets, "instance groups with a static network interface must have exactly one subnet"))
+	}
+	if g.Spec.MaxSize != nil && *g.Spec.MaxSize > 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "maxSize"), *g.Spec.MaxSize, "instance groups with a static network interface can have at most one instance"))
+	}
+
+	if eni.ID != "" {
+		if !strings.HasPrefix(eni.ID, "eni-") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), eni.ID, "must be the ID of a network interface"))
+		}
+		if eni.PrivateIPAddress != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("privateIPAddress"), "privateIPAddress cannot be set together with id"))
+		}
+	}
+	if eni.PrivateIPAddress != "" {
+		if ip := net.ParseIP(eni.PrivateIPAddress); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("privateIPAddress"), eni.PrivateIPAddress, "must be an IPv4 address"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateExtraUserData(userData *kops.UserData) field.ErrorList {
 	allErrs := field.ErrorList{}
 	fieldPath := field.NewPath("additionalUserData")
@@ -437,3 +775,51 @@ func validateExternalLoadBalancer(lb *kops.LoadBalancerSpec, fldPath *field.Path
 
 	return allErrs
 }
+
+// LogDiskUsageWarnings warns when the logs of the instance group, at the limits of their rotation,
+// could use more than half of its root volume, leaving little space to the images and the pods.
+func LogDiskUsageWarnings(cluster *kops.Cluster, g *kops.InstanceGroup) []string {
+	rootVolumeSize, err := defaults.DefaultInstanceGroupVolumeSize(g.Spec.Role)
+	if err != nil {
+		return nil
+	}
+	if g.Spec.RootVolume != nil && g.Spec.RootVolume.Size != nil {
+		rootVolumeSize = *g.Spec.RootVolume.Size
+	}
+
+	logRotation := cluster.Spec.LogRotation.ResolveDefaults(g)
+
+	// The log files in /var/log written on the instances of each role
+	logFiles := int64(1)
+	switch g.Spec.Role {
+	case kops.InstanceGroupRoleControlPlane:
+		logFiles = 6
+	case kops.InstanceGroupRoleAPIServer:
+		logFiles = 2
+	}
+	logUsage := logFiles * logRotation.MaxSize.Value() * int64(fi.ValueOf(logRotation.MaxFiles)+1)
+
+	if logRotation.JournalMaxUse != nil {
+		logUsage += logRotation.JournalMaxUse.Value()
+	}
+
+	if (g.Spec.Role == kops.InstanceGroupRoleControlPlane || g.Spec.Role == kops.InstanceGroupRoleAPIServer) && cluster.Spec.KubeAPIServer != nil {
+		kubeAPIServer := cluster.Spec.KubeAPIServer
+		if auditLogPath := fi.ValueOf(kubeAPIServer.AuditLogPath); auditLogPath != "" && auditLogPath != "-" {
+			auditLogMaxSize := int64(fi.ValueOf(kubeAPIServer.AuditLogMaxSize)) * 1024 * 1024
+			if kubeAPIServer.AuditLogMaxSize == nil && (cluster.Spec.LogRotation != nil || g.Spec.LogRotation != nil) {
+				auditLogMaxSize = logRotation.MaxSize.Value()
+			}
+			auditLogMaxBackups := int64(fi.ValueOf(logRotation.MaxFiles))
+			if kubeAPIServer.AuditLogMaxBackups != nil {
+				auditLogMaxBackups = int64(*kubeAPIServer.AuditLogMaxBackups)
+			}
+			logUsage += auditLogMaxSize * (auditLogMaxBackups + 1)
+		}
+	}
+
+	if logUsage*2 > int64(rootVolumeSize)*1024*1024*1024 {
+		return []string{fmt.Sprintf("the logs of instance group %q can use up to %dMi, more than half of its %dGB root volume; increase spec.rootVolume.size or lower spec.logRotation", g.ObjectMeta.Name, logUsage/(1024*1024), rootVolumeSize)}
+	}
+	return nil
+}
@@ -17,14 +17,20 @@ limitations under the License.
 package validation
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/kops/pkg/nodeidentity/aws"
 
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/cloudmock/aws/mockec2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
 
 func s(v string) *string {
@@ -229,6 +235,145 @@ func TestValidBootDevice(t *testing.T) {
 	}
 }
 
+func TestValidRootVolumeEncryptionKey(t *testing.T) {
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			CloudProvider: kops.CloudProviderSpec{
+				AWS: &kops.AWSSpec{},
+			},
+		},
+	}
+	grid := []struct {
+		encryption *bool
+		expected   []string
+	}{
+		{
+			encryption: nil,
+		},
+		{
+			encryption: fi.PtrTo(true),
+		},
+		{
+			encryption: fi.PtrTo(false),
+			expected:   []string{"Forbidden::spec.rootVolume.encryptionKey"},
+		},
+	}
+
+	for _, g := range grid {
+		ig := createMinimalInstanceGroup()
+		ig.Spec.RootVolume = &kops.InstanceRootVolumeSpec{
+			Encryption:    g.encryption,
+			EncryptionKey: fi.PtrTo("arn:aws-test:kms:us-test-1:123456789012:key/1234abcd"),
+		}
+		errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+		testErrors(t, g.encryption, errs, g.expected)
+	}
+}
+
+func TestValidKubeletServerTLSBootstrap(t *testing.T) {
+	grid := []struct {
+		cluster  *bool
+		ig       *bool
+		expected []string
+	}{
+		{},
+		{
+			cluster: fi.PtrTo(true),
+			ig:      fi.PtrTo(true),
+		},
+		{
+			cluster: fi.PtrTo(true),
+			ig:      fi.PtrTo(false),
+		},
+		{
+			ig:       fi.PtrTo(true),
+			expected: []string{"Forbidden::spec.kubelet.serverTLSBootstrap"},
+		},
+	}
+
+	for _, g := range grid {
+		cluster := &kops.Cluster{
+			Spec: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+				Kubelet: &kops.KubeletConfigSpec{
+					ServerTLSBootstrap: g.cluster,
+				},
+			},
+		}
+		ig := createMinimalInstanceGroup()
+		ig.Spec.Kubelet = &kops.KubeletConfigSpec{
+			ServerTLSBootstrap: g.ig,
+		}
+		errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+		testErrors(t, g, errs, g.expected)
+	}
+}
+
+func TestValidSuspended(t *testing.T) {
+	grid := []struct {
+		description string
+		cloud       kops.CloudProviderSpec
+		role        kops.InstanceGroupRole
+		manager     kops.InstanceManager
+		expected    []string
+	}{
+		{
+			description: "node on AWS",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:        kops.InstanceGroupRoleNode,
+		},
+		{
+			description: "bastion on AWS",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:        kops.InstanceGroupRoleBastion,
+		},
+		{
+			description: "control plane on AWS",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:        kops.InstanceGroupRoleControlPlane,
+			expected:    []string{"Forbidden::spec.suspended"},
+		},
+		{
+			description: "Karpenter on AWS",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:        kops.InstanceGroupRoleNode,
+			manager:     kops.InstanceManagerKarpenter,
+			expected:    []string{"Forbidden::spec.suspended"},
+		},
+		{
+			description: "node on GCE",
+			cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			role:        kops.InstanceGroupRoleNode,
+			expected:    []string{"Forbidden::spec.suspended"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: g.cloud,
+				},
+			}
+			ig := createMinimalInstanceGroup()
+			ig.Spec.Role = g.role
+			ig.Spec.Manager = g.manager
+			ig.Spec.Suspended = fi.PtrTo(true)
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			// Only check the suspended field; other fields may be invalid for the role
+			var suspendedErrs field.ErrorList
+			for _, err := range errs {
+				if err.Field == "spec.suspended" {
+					suspendedErrs = append(suspendedErrs, err)
+				}
+			}
+			testErrors(t, g.description, suspendedErrs, g.expected)
+		})
+	}
+}
+
 func TestValidNodeLabels(t *testing.T) {
 	grid := []struct {
 		label    string
@@ -495,6 +640,514 @@ func TestValidInstanceGroup(t *testing.T) {
 	}
 }
 
+func TestValidateHealthCheckAndMaintenancePolicy(t *testing.T) {
+	grid := []struct {
+		description string
+		spec        kops.InstanceGroupSpec
+		expected    []string
+	}{
+		{
+			description: "valid",
+			spec: kops.InstanceGroupSpec{
+				HealthCheck: &kops.InstanceGroupHealthCheckSpec{
+					Type:        "ELB",
+					GracePeriod: &v1.Duration{Duration: 15 * time.Minute},
+				},
+				InstanceMaintenancePolicy: &kops.InstanceMaintenancePolicySpec{
+					MinHealthyPercentage: fi.PtrTo(int32(90)),
+					MaxHealthyPercentage: fi.PtrTo(int32(120)),
+				},
+				DefaultInstanceWarmup: &v1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+		{
+			description: "unknown health check type",
+			spec: kops.InstanceGroupSpec{
+				HealthCheck: &kops.InstanceGroupHealthCheckSpec{Type: "TCP"},
+			},
+			expected: []string{"Unsupported value::spec.healthCheck.type"},
+		},
+		{
+			description: "negative grace period",
+			spec: kops.InstanceGroupSpec{
+				HealthCheck: &kops.InstanceGroupHealthCheckSpec{GracePeriod: &v1.Duration{Duration: -time.Second}},
+			},
+			expected: []string{"Invalid value::spec.healthCheck.gracePeriod"},
+		},
+		{
+			description: "negative warmup",
+			spec: kops.InstanceGroupSpec{
+				DefaultInstanceWarmup: &v1.Duration{Duration: -time.Second},
+			},
+			expected: []string{"Invalid value::spec.defaultInstanceWarmup"},
+		},
+		{
+			description: "maintenance policy missing max",
+			spec: kops.InstanceGroupSpec{
+				InstanceMaintenancePolicy: &kops.InstanceMaintenancePolicySpec{
+					MinHealthyPercentage: fi.PtrTo(int32(90)),
+				},
+			},
+			expected: []string{"Required value::spec.instanceMaintenancePolicy.maxHealthyPercentage"},
+		},
+		{
+			description: "maintenance policy out of range",
+			spec: kops.InstanceGroupSpec{
+				InstanceMaintenancePolicy: &kops.InstanceMaintenancePolicySpec{
+					MinHealthyPercentage: fi.PtrTo(int32(101)),
+					MaxHealthyPercentage: fi.PtrTo(int32(99)),
+				},
+			},
+			expected: []string{
+				"Invalid value::spec.instanceMaintenancePolicy.minHealthyPercentage",
+				"Invalid value::spec.instanceMaintenancePolicy.maxHealthyPercentage",
+			},
+		},
+		{
+			description: "maintenance policy range too wide",
+			spec: kops.InstanceGroupSpec{
+				InstanceMaintenancePolicy: &kops.InstanceMaintenancePolicySpec{
+					MinHealthyPercentage: fi.PtrTo(int32(50)),
+					MaxHealthyPercentage: fi.PtrTo(int32(200)),
+				},
+			},
+			expected: []string{"Invalid value::spec.instanceMaintenancePolicy.maxHealthyPercentage"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			ig := createMinimalInstanceGroup()
+			ig.Spec.HealthCheck = g.spec.HealthCheck
+			ig.Spec.InstanceMaintenancePolicy = g.spec.InstanceMaintenancePolicy
+			ig.Spec.DefaultInstanceWarmup = g.spec.DefaultInstanceWarmup
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, g.description, errs, g.expected)
+		})
+	}
+}
+
+func TestValidateScalingPolicies(t *testing.T) {
+	grid := []struct {
+		description string
+		policies    []kops.ScalingPolicySpec
+		expected    []string
+	}{
+		{
+			description: "valid",
+			policies: []kops.ScalingPolicySpec{
+				{
+					Name: "cpu",
+					TargetTracking: &kops.TargetTrackingScalingPolicySpec{
+						PredefinedMetric: "ASGAverageCPUUtilization",
+						TargetValue:      60,
+					},
+				},
+				{
+					Name: "network",
+					TargetTracking: &kops.TargetTrackingScalingPolicySpec{
+						PredefinedMetric: "ASGAverageNetworkIn",
+						TargetValue:      2.5e6,
+						DisableScaleIn:   fi.PtrTo(true),
+					},
+				},
+				{
+					Name: "queue",
+					Step: &kops.StepScalingPolicySpec{
+						AdjustmentType: "ChangeInCapacity",
+						Steps: []kops.ScalingStepSpec{
+							{UpperBound: fi.PtrTo(10.5), Adjustment: 1},
+							{LowerBound: fi.PtrTo(10.5), Adjustment: 3},
+						},
+						EstimatedInstanceWarmup: &v1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+			},
+		},
+		{
+			description: "missing and duplicate names",
+			policies: []kops.ScalingPolicySpec{
+				{TargetTracking: &kops.TargetTrackingScalingPolicySpec{PredefinedMetric: "ASGAverageNetworkIn", TargetValue: 1000}},
+				{Name: "cpu", TargetTracking: &kops.TargetTrackingScalingPolicySpec{PredefinedMetric: "ASGAverageCPUUtilization", TargetValue: 60}},
+				{Name: "cpu", TargetTracking: &kops.TargetTrackingScalingPolicySpec{PredefinedMetric: "ASGAverageCPUUtilization", TargetValue: 80}},
+			},
+			expected: []string{
+				"Required value::spec.scalingPolicies[0].name",
+				"Duplicate value::spec.scalingPolicies[2].name",
+			},
+		},
+		{
+			description: "neither target tracking nor step",
+			policies:    []kops.ScalingPolicySpec{{Name: "empty"}},
+			expected:    []string{"Invalid value::spec.scalingPolicies[0]"},
+		},
+		{
+			description: "both target tracking and step",
+			policies: []kops.ScalingPolicySpec{
+				{
+					Name:           "both",
+					TargetTracking: &kops.TargetTrackingScalingPolicySpec{PredefinedMetric: "ASGAverageCPUUtilization", TargetValue: 60},
+					Step: &kops.StepScalingPolicySpec{
+						AdjustmentType: "ExactCapacity",
+						Steps:          []kops.ScalingStepSpec{{Adjustment: 3}},
+					},
+				},
+			},
+			expected: []string{"Invalid value::spec.scalingPolicies[0]"},
+		},
+		{
+			description: "invalid target tracking",
+			policies: []kops.ScalingPolicySpec{
+				{Name: "memory", TargetTracking: &kops.TargetTrackingScalingPolicySpec{PredefinedMetric: "ASGAverageMemoryUtilization"}},
+			},
+			expected: []string{
+				"Unsupported value::spec.scalingPolicies[0].targetTracking.predefinedMetric",
+				"Invalid value::spec.scalingPolicies[0].targetTracking.targetValue",
+			},
+		},
+		{
+			description: "invalid step",
+			policies: []kops.ScalingPolicySpec{
+				{
+					Name: "queue",
+					Step: &kops.StepScalingPolicySpec{
+						AdjustmentType:          "ChangeInSize",
+						EstimatedInstanceWarmup: &v1.Duration{Duration: -time.Second},
+					},
+				},
+			},
+			expected: []string{
+				"Unsupported value::spec.scalingPolicies[0].step.adjustmentType",
+				"Required value::spec.scalingPolicies[0].step.steps",
+				"Invalid value::spec.scalingPolicies[0].step.estimatedInstanceWarmup",
+			},
+		},
+		{
+			description: "step bounds reversed",
+			policies: []kops.ScalingPolicySpec{
+				{
+					Name: "queue",
+					Step: &kops.StepScalingPolicySpec{
+						AdjustmentType: "ChangeInCapacity",
+						Steps:          []kops.ScalingStepSpec{{LowerBound: fi.PtrTo(10.0), UpperBound: fi.PtrTo(5.0), Adjustment: 1}},
+					},
+				},
+			},
+			expected: []string{"Invalid value::spec.scalingPolicies[0].step.steps[0].upperBound"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			ig := createMinimalInstanceGroup()
+			ig.Spec.ScalingPolicies = g.policies
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, g.description, errs, g.expected)
+		})
+	}
+}
+
+func TestCrossValidateScalingPolicies(t *testing.T) {
+	grid := []struct {
+		description       string
+		cloud             kops.CloudProviderSpec
+		role              kops.InstanceGroupRole
+		manager           kops.InstanceManager
+		clusterAutoscaler *kops.ClusterAutoscalerConfig
+		autoscale         *bool
+		expected          []string
+	}{
+		{
+			description: "node on AWS",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:        kops.InstanceGroupRoleNode,
+		},
+		{
+			description: "control plane on AWS",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:        kops.InstanceGroupRoleControlPlane,
+			expected:    []string{"Forbidden::spec.scalingPolicies"},
+		},
+		{
+			description: "Karpenter on AWS",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:        kops.InstanceGroupRoleNode,
+			manager:     kops.InstanceManagerKarpenter,
+			expected:    []string{"Forbidden::spec.scalingPolicies"},
+		},
+		{
+			description:       "cluster-autoscaler enabled",
+			cloud:             kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:              kops.InstanceGroupRoleNode,
+			clusterAutoscaler: &kops.ClusterAutoscalerConfig{Enabled: fi.PtrTo(true)},
+			expected:          []string{"Forbidden::spec.scalingPolicies"},
+		},
+		{
+			description:       "cluster-autoscaler disabled for the instance group",
+			cloud:             kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:              kops.InstanceGroupRoleNode,
+			clusterAutoscaler: &kops.ClusterAutoscalerConfig{Enabled: fi.PtrTo(true)},
+			autoscale:         fi.PtrTo(false),
+		},
+		{
+			description: "node on GCE",
+			cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			role:        kops.InstanceGroupRoleNode,
+			expected:    []string{"Forbidden::spec.scalingPolicies"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider:     g.cloud,
+					ClusterAutoscaler: g.clusterAutoscaler,
+				},
+			}
+			ig := createMinimalInstanceGroup()
+			ig.Spec.Role = g.role
+			ig.Spec.Manager = g.manager
+			ig.Spec.Autoscale = g.autoscale
+			ig.Spec.ScalingPolicies = []kops.ScalingPolicySpec{
+				{
+					Name: "cpu",
+					TargetTracking: &kops.TargetTrackingScalingPolicySpec{
+						PredefinedMetric: "ASGAverageCPUUtilization",
+						TargetValue:      60,
+					},
+				},
+			}
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			// Only check the scalingPolicies field; other fields may be invalid for the role
+			var policyErrs field.ErrorList
+			for _, err := range errs {
+				if err.Field == "spec.scalingPolicies" {
+					policyErrs = append(policyErrs, err)
+				}
+			}
+			testErrors(t, g.description, policyErrs, g.expected)
+		})
+	}
+}
+
+func TestValidateStaticNetworkInterface(t *testing.T) {
+	grid := []struct {
+		description string
+		role        kops.InstanceGroupRole
+		subnets     []string
+		maxSize     int32
+		eni         kops.StaticNetworkInterfaceSpec
+		expected    []string
+	}{
+		{
+			description: "managed network interface",
+			eni:         kops.StaticNetworkInterfaceSpec{PrivateIPAddress: "172.20.32.10"},
+		},
+		{
+			description: "existing network interface",
+			eni:         kops.StaticNetworkInterfaceSpec{ID: "eni-0123456789abcdef0"},
+		},
+		{
+			description: "node instance group",
+			role:        kops.InstanceGroupRoleNode,
+			expected:    []string{"Forbidden::spec.staticNetworkInterface"},
+		},
+		{
+			description: "multiple subnets",
+			subnets:     []string{"us-test-1a", "us-test-1b"},
+			expected:    []string{"Invalid value::spec.subnets"},
+		},
+		{
+			description: "multiple instances",
+			maxSize:     2,
+			expected:    []string{"Invalid value::spec.maxSize"},
+		},
+		{
+			description: "invalid network interface ID",
+			eni:         kops.StaticNetworkInterfaceSpec{ID: "sg-0123456789abcdef0"},
+			expected:    []string{"Invalid value::spec.staticNetworkInterface.id"},
+		},
+		{
+			description: "ID and private IP address",
+			eni:         kops.StaticNetworkInterfaceSpec{ID: "eni-0123456789abcdef0", PrivateIPAddress: "172.20.32.10"},
+			expected:    []string{"Forbidden::spec.staticNetworkInterface.privateIPAddress"},
+		},
+		{
+			description: "IPv6 private IP address",
+			eni:         kops.StaticNetworkInterfaceSpec{PrivateIPAddress: "2001:db8::10"},
+			expected:    []string{"Invalid value::spec.staticNetworkInterface.privateIPAddress"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			ig := createMinimalInstanceGroup()
+			ig.Spec.Role = kops.InstanceGroupRoleControlPlane
+			if g.role != "" {
+				ig.Spec.Role = g.role
+			}
+			ig.Spec.Subnets = []string{"us-test-1a"}
+			if g.subnets != nil {
+				ig.Spec.Subnets = g.subnets
+			}
+			if g.maxSize != 0 {
+				ig.Spec.MaxSize = fi.PtrTo(g.maxSize)
+			}
+			eni := g.eni
+			ig.Spec.StaticNetworkInterface = &eni
+
+			errs := validateStaticNetworkInterface(ig, field.NewPath("spec", "staticNetworkInterface"))
+			testErrors(t, g.description, errs, g.expected)
+		})
+	}
+}
+
+func TestCrossValidateStaticNetworkInterface(t *testing.T) {
+	grid := []struct {
+		description string
+		cloud       kops.CloudProviderSpec
+		ip          string
+		expected    []string
+	}{
+		{
+			description: "address in subnet",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			ip:          "172.20.32.10",
+		},
+		{
+			description: "address outside of subnet",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			ip:          "172.20.64.10",
+			expected:    []string{"Invalid value::spec.staticNetworkInterface.privateIPAddress"},
+		},
+		{
+			description: "not AWS",
+			cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			ip:          "172.20.32.10",
+			expected:    []string{"Forbidden::spec.staticNetworkInterface"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: g.cloud,
+					Networking: kops.NetworkingSpec{
+						Subnets: []kops.ClusterSubnetSpec{
+							{Name: "us-test-1a", CIDR: "172.20.32.0/19"},
+						},
+					},
+				},
+			}
+			ig := createMinimalInstanceGroup()
+			ig.Spec.Role = kops.InstanceGroupRoleControlPlane
+			ig.Spec.Subnets = []string{"us-test-1a"}
+			ig.Spec.StaticNetworkInterface = &kops.StaticNetworkInterfaceSpec{PrivateIPAddress: g.ip}
+
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			// Only check the staticNetworkInterface fields; other fields may be invalid for the minimal cluster
+			var eniErrs field.ErrorList
+			for _, err := range errs {
+				if strings.HasPrefix(err.Field, "spec.staticNetworkInterface") {
+					eniErrs = append(eniErrs, err)
+				}
+			}
+			testErrors(t, g.description, eniErrs, g.expected)
+		})
+	}
+}
+
+func TestCrossValidateSecurityGroupsForPods(t *testing.T) {
+	grid := []struct {
+		description string
+		role        kops.InstanceGroupRole
+		manager     kops.InstanceManager
+		machineType string
+		mixed       []string
+		expected    []string
+	}{
+		{
+			description: "nitro node",
+			role:        kops.InstanceGroupRoleNode,
+			machineType: "m5.large",
+		},
+		{
+			description: "burstable node",
+			role:        kops.InstanceGroupRoleNode,
+			machineType: "t3.medium",
+			expected:    []string{"Invalid value::spec.machineType"},
+		},
+		{
+			description: "xen node",
+			role:        kops.InstanceGroupRoleNode,
+			machineType: "m4.large",
+			expected:    []string{"Invalid value::spec.machineType"},
+		},
+		{
+			description: "mixed instances node",
+			role:        kops.InstanceGroupRoleNode,
+			machineType: "m5.large",
+			mixed:       []string{"m5.xlarge", "t3.large"},
+			expected:    []string{"Invalid value::spec.mixedInstancesPolicy.instances"},
+		},
+		{
+			description: "burstable control plane",
+			role:        kops.InstanceGroupRoleControlPlane,
+			machineType: "t3.medium",
+		},
+		{
+			description: "Karpenter node",
+			role:        kops.InstanceGroupRoleNode,
+			manager:     kops.InstanceManagerKarpenter,
+			machineType: "m5.large",
+			expected:    []string{"Forbidden::spec.manager"},
+		},
+	}
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockEC2 = &mockec2.MockEC2{
+		Images: []*ec2types.Image{
+			{
+				ImageId:      fi.PtrTo("ami-073c8c0760395aab8"),
+				Name:         fi.PtrTo("focal"),
+				Architecture: ec2types.ArchitectureValuesX8664,
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+					Networking: kops.NetworkingSpec{
+						AmazonVPC: &kops.AmazonVPCNetworkingSpec{SecurityGroupsForPods: true},
+					},
+				},
+			}
+			ig := createMinimalInstanceGroup()
+			ig.Spec.Role = g.role
+			ig.Spec.Manager = g.manager
+			ig.Spec.MachineType = g.machineType
+			ig.Spec.Image = "ami-073c8c0760395aab8"
+			if g.mixed != nil {
+				ig.Spec.MixedInstancesPolicy = &kops.MixedInstancesPolicySpec{Instances: g.mixed}
+			}
+			errs := CrossValidateInstanceGroup(ig, cluster, cloud, true)
+			// Only check the fields validated for securityGroupsForPods; other fields may be invalid for the role
+			var podErrs field.ErrorList
+			for _, err := range errs {
+				switch err.Field {
+				case "spec.machineType", "spec.mixedInstancesPolicy.instances", "spec.manager":
+					podErrs = append(podErrs, err)
+				}
+			}
+			testErrors(t, g.description, podErrs, g.expected)
+		})
+	}
+}
+
 func createMinimalInstanceGroup() *kops.InstanceGroup {
 	ig := &kops.InstanceGroup{
 		ObjectMeta: v1.ObjectMeta{
@@ -510,3 +1163,480 @@ func createMinimalInstanceGroup() *kops.InstanceGroup {
 	}
 	return ig
 }
+
+func TestValidateNitroEnclaves(t *testing.T) {
+	grid := []struct {
+		Input          kops.NitroEnclavesSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.NitroEnclavesSpec{},
+		},
+		{
+			Input: kops.NitroEnclavesSpec{CPUCount: fi.PtrTo(int32(2)), MemoryMiB: fi.PtrTo(int32(512))},
+		},
+		{
+			Input: kops.NitroEnclavesSpec{CPUCount: fi.PtrTo(int32(0)), MemoryMiB: fi.PtrTo(int32(-1))},
+			ExpectedErrors: []string{
+				"Invalid value::spec.confidentialCompute.nitroEnclaves.cpuCount",
+				"Invalid value::spec.confidentialCompute.nitroEnclaves.memoryMiB",
+			},
+		},
+	}
+	for _, g := range grid {
+		ig := createMinimalInstanceGroup()
+		ig.Spec.ConfidentialCompute = &kops.ConfidentialComputeSpec{NitroEnclaves: &g.Input}
+		errs := ValidateInstanceGroup(ig, nil, true)
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func TestValidateEFA(t *testing.T) {
+	grid := []struct {
+		Input          kops.EFASpec
+		Role           kops.InstanceGroupRole
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.EFASpec{},
+		},
+		{
+			Input: kops.EFASpec{InterfaceCount: fi.PtrTo(int32(4)), HugePages: fi.PtrTo(int32(0))},
+		},
+		{
+			Input: kops.EFASpec{InterfaceCount: fi.PtrTo(int32(0)), HugePages: fi.PtrTo(int32(-1))},
+			ExpectedErrors: []string{
+				"Invalid value::spec.efa.interfaceCount",
+				"Invalid value::spec.efa.hugePages",
+			},
+		},
+		{
+			Input:          kops.EFASpec{},
+			Role:           kops.InstanceGroupRoleBastion,
+			ExpectedErrors: []string{"Forbidden::spec.efa"},
+		},
+	}
+	for _, g := range grid {
+		ig := createMinimalInstanceGroup()
+		if g.Role != "" {
+			ig.Spec.Role = g.Role
+		}
+		ig.Spec.EFA = &g.Input
+		errs := ValidateInstanceGroup(ig, nil, true)
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func TestValidateAdditionalNetworkInterfaces(t *testing.T) {
+	grid := []struct {
+		description string
+		interfaces  []kops.AdditionalNetworkInterfaceSpec
+		expected    []string
+	}{
+		{
+			description: "valid interfaces",
+			interfaces: []kops.AdditionalNetworkInterfaceSpec{
+				{DeviceIndex: 1, Subnet: "storage-us-test-1a"},
+				{DeviceIndex: 2, Subnet: "storage-us-test-1a", SecurityGroups: []string{"sg-0123456789abcdef0"}},
+			},
+		},
+		{
+			description: "primary device index",
+			interfaces: []kops.AdditionalNetworkInterfaceSpec{
+				{DeviceIndex: 0, Subnet: "storage-us-test-1a"},
+			},
+			expected: []string{"Invalid value::spec.additionalNetworkInterfaces[0].deviceIndex"},
+		},
+		{
+			description: "duplicate device index",
+			interfaces: []kops.AdditionalNetworkInterfaceSpec{
+				{DeviceIndex: 1, Subnet: "storage-us-test-1a"},
+				{DeviceIndex: 1, Subnet: "storage-us-test-1a"},
+			},
+			expected: []string{"Duplicate value::spec.additionalNetworkInterfaces[1].deviceIndex"},
+		},
+		{
+			description: "missing subnet",
+			interfaces: []kops.AdditionalNetworkInterfaceSpec{
+				{DeviceIndex: 1},
+			},
+			expected: []string{"Required value::spec.additionalNetworkInterfaces[0].subnet"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			ig := createMinimalInstanceGroup()
+			ig.Spec.AdditionalNetworkInterfaces = g.interfaces
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, g.description, errs, g.expected)
+		})
+	}
+}
+
+func TestCrossValidateAdditionalNetworkInterfaces(t *testing.T) {
+	grid := []struct {
+		description       string
+		cloud             kops.CloudProviderSpec
+		subnet            string
+		associatePublicIP *bool
+		expected          []string
+	}{
+		{
+			description: "subnet in the same zone",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			subnet:      "storage-us-test-1a",
+		},
+		{
+			description: "unknown subnet",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			subnet:      "storage-us-test-1c",
+			expected:    []string{"Not found::spec.additionalNetworkInterfaces[0].subnet"},
+		},
+		{
+			description: "subnet in another zone",
+			cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			subnet:      "storage-us-test-1b",
+			expected:    []string{"Invalid value::spec.additionalNetworkInterfaces[0].subnet"},
+		},
+		{
+			description:       "public subnet",
+			cloud:             kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			subnet:            "storage-us-test-1a",
+			associatePublicIP: fi.PtrTo(true),
+			expected:          []string{"Forbidden::spec.associatePublicIP"},
+		},
+		{
+			description: "not AWS",
+			cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			subnet:      "storage-us-test-1a",
+			expected:    []string{"Forbidden::spec.additionalNetworkInterfaces"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: g.cloud,
+					Networking: kops.NetworkingSpec{
+						Subnets: []kops.ClusterSubnetSpec{
+							{Name: "us-test-1a", Zone: "us-test-1a", Type: kops.SubnetTypePublic},
+							{Name: "storage-us-test-1a", Zone: "us-test-1a", Type: kops.SubnetTypePrivate},
+							{Name: "storage-us-test-1b", Zone: "us-test-1b", Type: kops.SubnetTypePrivate},
+						},
+					},
+				},
+			}
+			ig := createMinimalInstanceGroup()
+			ig.Spec.Subnets = []string{"us-test-1a"}
+			ig.Spec.AssociatePublicIP = fi.PtrTo(false)
+			if g.associatePublicIP != nil {
+				ig.Spec.AssociatePublicIP = g.associatePublicIP
+			}
+			ig.Spec.AdditionalNetworkInterfaces = []kops.AdditionalNetworkInterfaceSpec{
+				{DeviceIndex: 1, Subnet: g.subnet},
+			}
+
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			// Only check the network interface fields; other fields may be invalid for the minimal cluster
+			var niErrs field.ErrorList
+			for _, err := range errs {
+				if strings.HasPrefix(err.Field, "spec.additionalNetworkInterfaces") || err.Field == "spec.associatePublicIP" {
+					niErrs = append(niErrs, err)
+				}
+			}
+			testErrors(t, g.description, niErrs, g.expected)
+		})
+	}
+}
+
+func TestValidateTenancy(t *testing.T) {
+	grid := []struct {
+		tenancy              string
+		hostResourceGroupARN string
+		maxPrice             *string
+		expected             []string
+	}{
+		{
+			tenancy: "default",
+		},
+		{
+			tenancy: "dedicated",
+		},
+		{
+			tenancy:  "shared",
+			expected: []string{"Unsupported value::spec.tenancy"},
+		},
+		{
+			tenancy:              "host",
+			hostResourceGroupARN: "arn:aws:resource-groups:us-east-1:123456789012:group/byol-hosts",
+		},
+		{
+			tenancy:  "host",
+			expected: []string{"Required value::spec.hostResourceGroupARN"},
+		},
+		{
+			tenancy:              "host",
+			hostResourceGroupARN: "arn:aws:ec2:us-east-1:123456789012:dedicated-host/h-0123456789abcdef0",
+			expected:             []string{"Invalid value::spec.hostResourceGroupARN"},
+		},
+		{
+			tenancy:              "host",
+			hostResourceGroupARN: "arn:aws:resource-groups:us-east-1:123456789012:group/byol-hosts",
+			maxPrice:             fi.PtrTo("0.1"),
+			expected:             []string{"Forbidden::spec.maxPrice"},
+		},
+		{
+			tenancy:              "dedicated",
+			hostResourceGroupARN: "arn:aws:resource-groups:us-east-1:123456789012:group/byol-hosts",
+			expected:             []string{"Forbidden::spec.hostResourceGroupARN"},
+		},
+	}
+	for _, g := range grid {
+		ig := createMinimalInstanceGroup()
+		ig.Spec.Tenancy = g.tenancy
+		ig.Spec.HostResourceGroupARN = g.hostResourceGroupARN
+		ig.Spec.MaxPrice = g.maxPrice
+		errs := ValidateInstanceGroup(ig, nil, true)
+		testErrors(t, g, errs, g.expected)
+	}
+}
+
+func TestValidateLogRotation(t *testing.T) {
+	grid := []struct {
+		Input          kops.LogRotationSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.LogRotationSpec{
+				MaxSize:       resource.NewQuantity(50*1024*1024, resource.BinarySI),
+				MaxFiles:      fi.PtrTo(int32(3)),
+				JournalMaxUse: resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+			},
+		},
+		{
+			Input: kops.LogRotationSpec{
+				MaxSize: resource.NewQuantity(0, resource.BinarySI),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.logRotation.maxSize"},
+		},
+		{
+			Input: kops.LogRotationSpec{
+				MaxFiles: fi.PtrTo(int32(0)),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.logRotation.maxFiles"},
+		},
+		{
+			Input: kops.LogRotationSpec{
+				JournalMaxUse: resource.NewQuantity(-1, resource.BinarySI),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.logRotation.journalMaxUse"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateLogRotation(&g.Input, field.NewPath("spec", "logRotation"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func TestLogDiskUsageWarnings(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Cluster        kops.ClusterSpec
+		InstanceGroup  kops.InstanceGroupSpec
+		ExpectWarnings bool
+	}{
+		{
+			Description:   "defaults",
+			InstanceGroup: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode},
+		},
+		{
+			Description: "large journal on a small root volume",
+			Cluster: kops.ClusterSpec{
+				LogRotation: &kops.LogRotationSpec{JournalMaxUse: resource.NewQuantity(8*1024*1024*1024, resource.BinarySI)},
+			},
+			InstanceGroup: kops.InstanceGroupSpec{
+				Role:       kops.InstanceGroupRoleNode,
+				RootVolume: &kops.InstanceRootVolumeSpec{Size: fi.PtrTo(int32(12))},
+			},
+			ExpectWarnings: true,
+		},
+		{
+			Description: "instance group override",
+			Cluster: kops.ClusterSpec{
+				LogRotation: &kops.LogRotationSpec{JournalMaxUse: resource.NewQuantity(8*1024*1024*1024, resource.BinarySI)},
+			},
+			InstanceGroup: kops.InstanceGroupSpec{
+				Role:        kops.InstanceGroupRoleNode,
+				RootVolume:  &kops.InstanceRootVolumeSpec{Size: fi.PtrTo(int32(12))},
+				LogRotation: &kops.LogRotationSpec{JournalMaxUse: resource.NewQuantity(1024*1024*1024, resource.BinarySI)},
+			},
+		},
+		{
+			Description: "audit log on the control plane",
+			Cluster: kops.ClusterSpec{
+				KubeAPIServer: &kops.KubeAPIServerConfig{
+					AuditLogPath:       fi.PtrTo("/var/log/kube-apiserver-audit.log"),
+					AuditLogMaxSize:    fi.PtrTo(int32(2048)),
+					AuditLogMaxBackups: fi.PtrTo(int32(20)),
+				},
+			},
+			InstanceGroup:  kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleControlPlane},
+			ExpectWarnings: true,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{Spec: g.Cluster}
+			ig := &kops.InstanceGroup{Spec: g.InstanceGroup}
+			ig.ObjectMeta.Name = "nodes"
+
+			warnings := LogDiskUsageWarnings(cluster, ig)
+			if g.ExpectWarnings && len(warnings) == 0 {
+				t.Errorf("expected warnings, got none")
+			}
+			if !g.ExpectWarnings && len(warnings) != 0 {
+				t.Errorf("unexpected warnings: %v", warnings)
+			}
+		})
+	}
+}
+
+func TestValidateVolumeMounts(t *testing.T) {
+	grid := []struct {
+		description  string
+		volumeMounts []kops.VolumeMountSpec
+		expected     []string
+	}{
+		{
+			description: "containerd and log volumes",
+			volumeMounts: []kops.VolumeMountSpec{
+				{Device: "/dev/xvdd", Filesystem: "xfs", Path: "/var/lib/containerd"},
+				{Device: "/dev/xvde", Filesystem: "ext4", Path: "/var/log", MountOptions: []string{"noatime"}},
+			},
+		},
+		{
+			description: "duplicate device and path",
+			volumeMounts: []kops.VolumeMountSpec{
+				{Device: "/dev/xvdd", Filesystem: "xfs", Path: "/var/lib/containerd"},
+				{Device: "/dev/xvdd", Filesystem: "xfs", Path: "/var/lib/containerd"},
+			},
+			expected: []string{"Duplicate value::spec.volumeMounts[1].device", "Duplicate value::spec.volumeMounts[1].path"},
+		},
+		{
+			description: "relative path",
+			volumeMounts: []kops.VolumeMountSpec{
+				{Device: "/dev/xvdd", Filesystem: "xfs", Path: "var/lib/containerd"},
+			},
+			expected: []string{"Invalid value::spec.volumeMounts[0].path"},
+		},
+		{
+			description: "root path",
+			volumeMounts: []kops.VolumeMountSpec{
+				{Device: "/dev/xvdd", Filesystem: "xfs", Path: "/"},
+			},
+			expected: []string{"Invalid value::spec.volumeMounts[0].path"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			ig := createMinimalInstanceGroup()
+			ig.Spec.VolumeMounts = g.volumeMounts
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, g.description, errs, g.expected)
+		})
+	}
+}
+
+func TestAzureValidateInstanceGroup(t *testing.T) {
+	grid := []struct {
+		azure    *kops.AzureInstanceGroupSpec
+		maxPrice *string
+		expected []string
+	}{
+		{
+			azure: &kops.AzureInstanceGroupSpec{
+				OrchestrationMode: kops.AzureOrchestrationModeFlexible,
+				Priority:          kops.AzurePrioritySpot,
+				EvictionPolicy:    kops.AzureEvictionPolicyDeallocate,
+			},
+		},
+		{
+			maxPrice: fi.PtrTo("0.05"),
+		},
+		{
+			azure: &kops.AzureInstanceGroupSpec{
+				EvictionPolicy: kops.AzureEvictionPolicyDelete,
+			},
+			maxPrice: fi.PtrTo("-1"),
+		},
+		{
+			azure: &kops.AzureInstanceGroupSpec{
+				OrchestrationMode: "Mixed",
+				Priority:          "Low",
+			},
+			expected: []string{
+				"Unsupported value::spec.azure.orchestrationMode",
+				"Unsupported value::spec.azure.priority",
+			},
+		},
+		{
+			azure: &kops.AzureInstanceGroupSpec{
+				Priority: kops.AzurePriorityRegular,
+			},
+			maxPrice: fi.PtrTo("0.05"),
+			expected: []string{"Forbidden::spec.maxPrice"},
+		},
+		{
+			azure: &kops.AzureInstanceGroupSpec{
+				EvictionPolicy: kops.AzureEvictionPolicyDelete,
+			},
+			expected: []string{"Forbidden::spec.azure.evictionPolicy"},
+		},
+		{
+			maxPrice: fi.PtrTo("0"),
+			expected: []string{"Invalid value::spec.maxPrice"},
+		},
+	}
+	for _, g := range grid {
+		ig := createMinimalInstanceGroup()
+		ig.Spec.Azure = g.azure
+		ig.Spec.MaxPrice = g.maxPrice
+		errs := azureValidateInstanceGroup(ig)
+		testErrors(t, g, errs, g.expected)
+	}
+}
+
+func TestHetznerValidateInstanceGroup(t *testing.T) {
+	grid := []struct {
+		hetzner  *kops.HetznerInstanceGroupSpec
+		maxSize  int32
+		expected []string
+	}{
+		{
+			hetzner: &kops.HetznerInstanceGroupSpec{PlacementGroup: "control-plane"},
+			maxSize: 3,
+		},
+		{
+			maxSize: 20,
+		},
+		{
+			hetzner:  &kops.HetznerInstanceGroupSpec{PlacementGroup: "Control_Plane"},
+			maxSize:  3,
+			expected: []string{"Invalid value::spec.hetzner.placementGroup"},
+		},
+		{
+			hetzner:  &kops.HetznerInstanceGroupSpec{PlacementGroup: "nodes"},
+			maxSize:  20,
+			expected: []string{"Forbidden::spec.hetzner.placementGroup"},
+		},
+	}
+	for _, g := range grid {
+		ig := createMinimalInstanceGroup()
+		ig.Spec.Hetzner = g.hetzner
+		ig.Spec.MaxSize = fi.PtrTo(g.maxSize)
+		errs := hetznerValidateInstanceGroup(ig)
+		testErrors(t, g, errs, g.expected)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strings"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/util/subnet"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -271,6 +273,19 @@ func DeepValidate(c *kops.Cluster, groups []*kops.InstanceGroup, strict bool, vf
 		return fmt.Errorf("must configure at least one Node InstanceGroup")
 	}
 
+	if awsCloud, ok := cloud.(awsup.AWSCloud); ok {
+		// Look up the SSM parameters of the images of all the instance groups at once
+		var parameters []string
+		for _, g := range groups {
+			if parameter, found := strings.CutPrefix(g.Spec.Image, "ssm:"); found {
+				parameters = append(parameters, parameter)
+			}
+		}
+		if err := awsup.PrefetchSSMParameters(context.TODO(), awsCloud, parameters); err != nil {
+			return fmt.Errorf("error resolving the images of the instance groups: %w", err)
+		}
+	}
+
 	for _, g := range groups {
 		errs := CrossValidateInstanceGroup(g, c, cloud, strict)
 
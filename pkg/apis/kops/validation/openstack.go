@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/l7policies"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/wellknownports"
+)
+
+func openstackValidateCluster(c *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.Spec.CloudProvider.Openstack == nil || c.Spec.CloudProvider.Openstack.Loadbalancer == nil {
+		return allErrs
+	}
+	lbConfig := c.Spec.CloudProvider.Openstack.Loadbalancer
+	fieldSpec := field.NewPath("spec", "cloudProvider", "openstack", "loadbalancer")
+
+	if len(lbConfig.L7Policies) > 0 && lbConfig.TLSContainerRef == nil {
+		allErrs = append(allErrs, field.Required(fieldSpec.Child("tlsContainerRef"), "L7 policies require the API listener to terminate TLS"))
+	}
+
+	names := make(map[string]bool)
+	poolTLS := make(map[int]bool)
+	for i, policy := range lbConfig.L7Policies {
+		fldPath := fieldSpec.Child("l7Policies").Index(i)
+
+		if policy.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+		} else if names[policy.Name] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), policy.Name))
+		}
+		names[policy.Name] = true
+
+		if policy.Action != "" {
+			allErrs = append(allErrs, IsValidValue(fldPath.Child("action"), &policy.Action, []string{string(l7policies.ActionRedirectToPool), string(l7policies.ActionReject)})...)
+		}
+		if policy.Position != nil && *policy.Position < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("position"), *policy.Position, "must be at least 1"))
+		}
+		if policy.Action == string(l7policies.ActionReject) {
+			if policy.PoolPort != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("poolPort"), "poolPort can only be set with REDIRECT_TO_POOL"))
+			}
+			if policy.PoolTLS != nil {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("poolTLS"), "poolTLS can only be set with REDIRECT_TO_POOL"))
+			}
+		} else {
+			port := wellknownports.KubeAPIServerHealthCheck
+			if policy.PoolPort != nil {
+				port = *policy.PoolPort
+				if port < 1 || port > 65535 {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("poolPort"), port, "must be a valid port"))
+				}
+			}
+			// Policies redirecting to the same port share a pool
+			tls := policy.PoolTLS != nil && *policy.PoolTLS
+			if previous, found := poolTLS[port]; found && previous != tls {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("poolTLS"), tls, "must be the same for all policies with the same poolPort"))
+			}
+			poolTLS[port] = tls
+		}
+
+		if len(policy.Rules) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("rules"), ""))
+		}
+		for j, rule := range policy.Rules {
+			rulePath := fldPath.Child("rules").Index(j)
+			allErrs = append(allErrs, IsValidValue(rulePath.Child("type"), &rule.Type, []string{
+				string(l7policies.TypePath),
+				string(l7policies.TypeHostName),
+				string(l7policies.TypeHeader),
+				string(l7policies.TypeCookie),
+				string(l7policies.TypeFileType),
+			})...)
+			if rule.CompareType != "" {
+				allErrs = append(allErrs, IsValidValue(rulePath.Child("compareType"), &rule.CompareType, []string{
+					string(l7policies.CompareTypeEqual),
+					string(l7policies.CompareTypeStartWith),
+					string(l7policies.CompareTypeEndWith),
+					string(l7policies.CompareTypeContains),
+					string(l7policies.CompareTypeRegex),
+				})...)
+			}
+			needsKey := rule.Type == string(l7policies.TypeHeader) || rule.Type == string(l7policies.TypeCookie)
+			if needsKey && rule.Key == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("key"), "key is required for HEADER and COOKIE rules"))
+			} else if !needsKey && rule.Key != "" {
+				allErrs = append(allErrs, field.Forbidden(rulePath.Child("key"), "key can only be set for HEADER and COOKIE rules"))
+			}
+			if rule.Value == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("value"), ""))
+			}
+		}
+	}
+
+	return allErrs
+}
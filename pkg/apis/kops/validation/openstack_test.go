@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestOpenstackValidateL7Policies(t *testing.T) {
+	healthz := kops.OpenstackL7Rule{Type: "PATH", Value: "/healthz"}
+
+	grid := []struct {
+		Description     string
+		TLSContainerRef *string
+		Policies        []kops.OpenstackL7Policy
+		ExpectedErrors  []string
+	}{
+		{
+			Description:     "valid policies",
+			TLSContainerRef: fi.PtrTo("https://barbican.example.com/v1/containers/1234"),
+			Policies: []kops.OpenstackL7Policy{
+				{Name: "healthz", Rules: []kops.OpenstackL7Rule{healthz}},
+				{Name: "readyz", PoolPort: fi.PtrTo(3990), Rules: []kops.OpenstackL7Rule{{Type: "PATH", CompareType: "STARTS_WITH", Value: "/readyz"}}},
+				{Name: "other-cluster", Action: "REJECT", Rules: []kops.OpenstackL7Rule{{Type: "HOST_NAME", Value: "api.other.example.com"}}},
+			},
+		},
+		{
+			Description: "missing tls container",
+			Policies: []kops.OpenstackL7Policy{
+				{Name: "healthz", Rules: []kops.OpenstackL7Rule{healthz}},
+			},
+			ExpectedErrors: []string{"Required value::spec.cloudProvider.openstack.loadbalancer.tlsContainerRef"},
+		},
+		{
+			Description:     "invalid policies",
+			TLSContainerRef: fi.PtrTo("https://barbican.example.com/v1/containers/1234"),
+			Policies: []kops.OpenstackL7Policy{
+				{Name: "healthz", Action: "REDIRECT_TO_URL", Rules: []kops.OpenstackL7Rule{healthz}},
+				{Name: "healthz", Rules: []kops.OpenstackL7Rule{{Type: "HEADER", CompareType: "LIKE", Value: "x"}}},
+				{Name: "reject", Action: "REJECT", PoolPort: fi.PtrTo(3990)},
+				{Name: "tls", PoolTLS: fi.PtrTo(true), Rules: []kops.OpenstackL7Rule{{Type: "PATH", Key: "x", Value: "/livez"}}},
+			},
+			ExpectedErrors: []string{
+				"Unsupported value::spec.cloudProvider.openstack.loadbalancer.l7Policies[0].action",
+				"Duplicate value::spec.cloudProvider.openstack.loadbalancer.l7Policies[1].name",
+				"Unsupported value::spec.cloudProvider.openstack.loadbalancer.l7Policies[1].rules[0].compareType",
+				"Required value::spec.cloudProvider.openstack.loadbalancer.l7Policies[1].rules[0].key",
+				"Forbidden::spec.cloudProvider.openstack.loadbalancer.l7Policies[2].poolPort",
+				"Required value::spec.cloudProvider.openstack.loadbalancer.l7Policies[2].rules",
+				"Invalid value::spec.cloudProvider.openstack.loadbalancer.l7Policies[3].poolTLS",
+				"Forbidden::spec.cloudProvider.openstack.loadbalancer.l7Policies[3].rules[0].key",
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						Openstack: &kops.OpenstackSpec{
+							Loadbalancer: &kops.OpenstackLoadbalancerConfig{
+								TLSContainerRef: g.TLSContainerRef,
+								L7Policies:      g.Policies,
+							},
+						},
+					},
+				},
+			}
+			errs := openstackValidateCluster(cluster)
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func scalewayValidateCluster(c *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	networking := &c.Spec.Networking
+	fieldNetworking := field.NewPath("spec", "networking")
+
+	// Servers are attached to private networks only when the cluster has a VPC
+	usesPrivateNetworks := networking.NetworkCIDR != "" || networking.NetworkID != ""
+
+	for i, subnet := range networking.Subnets {
+		fieldSubnet := fieldNetworking.Child("subnets").Index(i)
+
+		if !usesPrivateNetworks {
+			if subnet.Type == kops.SubnetTypePrivate {
+				allErrs = append(allErrs, field.Forbidden(fieldSubnet.Child("type"), "private subnets require a networkCIDR or a networkID"))
+			}
+			continue
+		}
+
+		if subnet.ID != "" {
+			if networking.NetworkID == "" {
+				allErrs = append(allErrs, field.Forbidden(fieldSubnet.Child("id"), "existing private networks require the networkID of their VPC"))
+			}
+			continue
+		}
+		if subnet.CIDR == "" && (len(networking.Subnets) > 1 || networking.NetworkCIDR == "") {
+			allErrs = append(allErrs, field.Required(fieldSubnet.Child("cidr"), "the CIDR of the private network must be set"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestScalewayValidateCluster(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Networking     kops.NetworkingSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "public IPs",
+			Networking: kops.NetworkingSpec{
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "fr-par-1", Zone: "fr-par-1", Type: kops.SubnetTypePublic},
+				},
+			},
+		},
+		{
+			Description: "private subnet without VPC",
+			Networking: kops.NetworkingSpec{
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "fr-par-1", Zone: "fr-par-1", Type: kops.SubnetTypePrivate},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.networking.subnets[0].type"},
+		},
+		{
+			Description: "single private network",
+			Networking: kops.NetworkingSpec{
+				NetworkCIDR: "172.16.0.0/16",
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "fr-par-1", Zone: "fr-par-1", Type: kops.SubnetTypePrivate},
+				},
+			},
+		},
+		{
+			Description: "multiple private networks",
+			Networking: kops.NetworkingSpec{
+				NetworkCIDR: "172.16.0.0/16",
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "control-plane", Zone: "fr-par-1", Type: kops.SubnetTypePrivate, CIDR: "172.16.0.0/24"},
+					{Name: "nodes", Zone: "fr-par-1", Type: kops.SubnetTypePrivate},
+				},
+			},
+			ExpectedErrors: []string{"Required value::spec.networking.subnets[1].cidr"},
+		},
+		{
+			Description: "existing private networks",
+			Networking: kops.NetworkingSpec{
+				NetworkID: "11111111-1111-1111-1111-111111111111",
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "fr-par-1", Zone: "fr-par-1", Type: kops.SubnetTypePrivate, ID: "22222222-2222-2222-2222-222222222222"},
+				},
+			},
+		},
+		{
+			Description: "existing private network in new VPC",
+			Networking: kops.NetworkingSpec{
+				NetworkCIDR: "172.16.0.0/16",
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "fr-par-1", Zone: "fr-par-1", Type: kops.SubnetTypePrivate, ID: "22222222-2222-2222-2222-222222222222"},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.networking.subnets[0].id"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					Networking: g.Networking,
+				},
+			}
+			errs := scalewayValidateCluster(cluster)
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
@@ -17,19 +17,26 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"net"
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/blang/semver/v4"
 	"golang.org/x/net/ipv4"
 	"golang.org/x/net/ipv6"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -38,9 +45,11 @@ import (
 	"k8s.io/kops/pkg/util/subnet"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/etcdexternal"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/utils"
 )
 
@@ -71,6 +80,10 @@ func newValidateCluster(cluster *kops.Cluster, strict bool) field.ErrorList {
 		allErrs = append(allErrs, awsValidateCluster(cluster, strict)...)
 	case kops.CloudProviderGCE:
 		allErrs = append(allErrs, gceValidateCluster(cluster)...)
+	case kops.CloudProviderOpenstack:
+		allErrs = append(allErrs, openstackValidateCluster(cluster)...)
+	case kops.CloudProviderScaleway:
+		allErrs = append(allErrs, scalewayValidateCluster(cluster)...)
 	}
 
 	return allErrs
@@ -126,6 +139,26 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		}
 	}
 
+	if spec.NTP != nil {
+		allErrs = append(allErrs, validateNTP(spec.NTP, fieldPath.Child("ntp"))...)
+	}
+
+	if spec.LogRotation != nil {
+		allErrs = append(allErrs, validateLogRotation(spec.LogRotation, fieldPath.Child("logRotation"))...)
+	}
+
+	for i, bundle := range spec.AdditionalTrustBundles {
+		allErrs = append(allErrs, validateTrustBundle(bundle, fieldPath.Child("additionalTrustBundles").Index(i))...)
+	}
+
+	if spec.TTL != nil && spec.TTL.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("ttl"), spec.TTL.Duration.String(), "must be greater than 0"))
+	}
+
+	if spec.TTLNotification != nil {
+		allErrs = append(allErrs, validateTTLNotification(spec, fieldPath.Child("ttlNotification"))...)
+	}
+
 	if spec.KubeAPIServer != nil {
 		allErrs = append(allErrs, validateKubeAPIServer(spec.KubeAPIServer, c, fieldPath.Child("kubeAPIServer"), strict)...)
 	}
@@ -172,6 +205,10 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateSnapshotController(c, spec.SnapshotController, fieldPath.Child("snapshotController"))...)
 	}
 
+	if spec.Authentication != nil && spec.Authentication.CloudIdentity != nil {
+		allErrs = append(allErrs, validateCloudIdentityAuthentication(c, spec.Authentication.CloudIdentity, fieldPath.Child("authentication", "cloudIdentity"))...)
+	}
+
 	// IAM additional policies
 	for k, v := range spec.AdditionalPolicies {
 		allErrs = append(allErrs, validateAdditionalPolicy(k, v, fieldPath.Child("additionalPolicies"))...)
@@ -193,6 +230,7 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 			}
 			allErrs = append(allErrs, validateEtcdBackupStore(spec.EtcdClusters, fieldEtcdClusters)...)
 			allErrs = append(allErrs, validateEtcdStorage(spec.EtcdClusters, fieldEtcdClusters)...)
+			allErrs = append(allErrs, validateEtcdExternalClusters(spec.EtcdClusters, fieldEtcdClusters)...)
 		}
 	}
 
@@ -220,6 +258,12 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		}
 	}
 
+	for key, lifecycle := range spec.LifecycleOverrides {
+		allErrs = append(allErrs, validateLifecycleOverride(key, lifecycle, fieldPath.Child("lifecycleOverrides").Key(key))...)
+	}
+
+	allErrs = append(allErrs, validateExternalTasks(spec.ExternalTasks, fieldPath.Child("externalTasks"))...)
+
 	if spec.RollingUpdate != nil {
 		allErrs = append(allErrs, validateRollingUpdate(spec.RollingUpdate, fieldPath.Child("rollingUpdate"), false)...)
 	}
@@ -243,9 +287,18 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 			if lbSpec.SSLCertificate != "" {
 				allErrs = append(allErrs, field.Forbidden(lbPath.Child("sslCertificate"), "sslCertificate is only supported on AWS"))
 			}
+			if lbSpec.ACMCertificate != nil {
+				allErrs = append(allErrs, field.Forbidden(lbPath.Child("acmCertificate"), "acmCertificate is only supported on AWS"))
+			}
 			if lbSpec.SSLPolicy != nil {
 				allErrs = append(allErrs, field.Forbidden(lbPath.Child("sslPolicy"), "sslPolicy is only supported on AWS"))
 			}
+			if lbSpec.TLSMode != "" {
+				allErrs = append(allErrs, field.Forbidden(lbPath.Child("tlsMode"), "tlsMode is only supported on AWS"))
+			}
+			if lbSpec.ALPNPolicy != nil {
+				allErrs = append(allErrs, field.Forbidden(lbPath.Child("alpnPolicy"), "alpnPolicy is only supported on AWS"))
+			}
 			if lbSpec.CrossZoneLoadBalancing != nil {
 				allErrs = append(allErrs, field.Forbidden(lbPath.Child("crossZoneLoadBalancing"), "crossZoneLoadBalancing is only supported on AWS"))
 			}
@@ -293,6 +346,48 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateCertManager(c, spec.CertManager, fieldPath.Child("certManager"))...)
 	}
 
+	if spec.EventExporter != nil && fi.ValueOf(spec.EventExporter.Enabled) {
+		allErrs = append(allErrs, validateEventExporter(c, spec.EventExporter, fieldPath.Child("eventExporter"))...)
+	}
+
+	if spec.PrometheusAgent != nil && fi.ValueOf(spec.PrometheusAgent.Enabled) {
+		allErrs = append(allErrs, validatePrometheusAgent(c, spec.PrometheusAgent, fieldPath.Child("prometheusAgent"))...)
+	}
+
+	if spec.ClusterDefaults != nil {
+		allErrs = append(allErrs, validateClusterDefaults(spec.ClusterDefaults, fieldPath.Child("clusterDefaults"))...)
+	}
+
+	if spec.Target != nil && spec.Target.Terraform != nil {
+		allErrs = append(allErrs, validateTerraformMoved(spec.Target.Terraform.Moved, fieldPath.Child("target", "terraform", "moved"))...)
+	}
+
+	return allErrs
+}
+
+var terraformAddressRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*\.[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+func validateTerraformMoved(moved []kops.TerraformMovedSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	froms := sets.NewString()
+	for i, m := range moved {
+		fldPath := fldPath.Index(i)
+		if !terraformAddressRegex.MatchString(m.From) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("from"), m.From, "must be the address of a resource, such as aws_vpc.example-com"))
+		}
+		if !terraformAddressRegex.MatchString(m.To) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("to"), m.To, "must be the address of a resource, such as aws_vpc.example-com"))
+		}
+		if m.From == m.To {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("to"), m.To, "must differ from the previous address"))
+		}
+		if froms.Has(m.From) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("from"), m.From))
+		}
+		froms.Insert(m.From)
+	}
+
 	return allErrs
 }
 
@@ -518,6 +613,34 @@ func validateTopology(c *kops.Cluster, topology *kops.TopologySpec, fieldPath *f
 		allErrs = append(allErrs, IsValidValue(fieldPath.Child("dns", "type"), &topology.DNS, kops.SupportedDnsTypes)...)
 	}
 
+	if topology.Bastion != nil {
+		allErrs = append(allErrs, validateBastion(c, topology.Bastion, fieldPath.Child("bastion"))...)
+	}
+
+	return allErrs
+}
+
+func validateBastion(c *kops.Cluster, bastion *kops.BastionSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	ssmEnabled := bastion.SSM != nil && fi.ValueOf(bastion.SSM.Enabled)
+	if ssmEnabled && c.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("ssm", "enabled"), "SSM Session Manager access is only supported on AWS"))
+	}
+
+	if logging := bastion.AuditLogging; logging != nil {
+		loggingPath := fieldPath.Child("auditLogging")
+		if !ssmEnabled {
+			allErrs = append(allErrs, field.Forbidden(loggingPath, "audit logging requires that SSM Session Manager access is enabled"))
+		}
+		if logging.S3BucketName == "" && logging.CloudWatchLogGroupName == "" {
+			allErrs = append(allErrs, field.Required(loggingPath, "either s3BucketName or cloudWatchLogGroupName must be specified"))
+		}
+		if logging.S3KeyPrefix != "" && logging.S3BucketName == "" {
+			allErrs = append(allErrs, field.Required(loggingPath.Child("s3BucketName"), "s3BucketName must be specified when s3KeyPrefix is set"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -639,6 +762,24 @@ func validateSubnet(subnetSpec *kops.ClusterSubnetSpec, c *kops.ClusterSpec, fie
 		}
 	}
 
+	if subnetSpec.NATGatewayConnectivityType != "" {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("natGatewayConnectivityType"), &subnetSpec.NATGatewayConnectivityType, []string{
+			kops.NATGatewayConnectivityTypePublic,
+			kops.NATGatewayConnectivityTypePrivate,
+		})...)
+		if c.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("natGatewayConnectivityType"), "natGatewayConnectivityType is only supported on AWS"))
+		}
+		if subnetSpec.NATGatewayConnectivityType == kops.NATGatewayConnectivityTypePrivate {
+			if subnetSpec.Egress != "" {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("natGatewayConnectivityType"), "a private NAT gateway cannot be used with egress"))
+			}
+			if subnetSpec.PublicIP != "" {
+				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("natGatewayConnectivityType"), "a private NAT gateway cannot have a publicIP"))
+			}
+		}
+	}
+
 	allErrs = append(allErrs, IsValidValue(fieldPath.Child("type"), &subnetSpec.Type, []kops.SubnetType{
 		kops.SubnetTypePublic,
 		kops.SubnetTypePrivate,
@@ -667,9 +808,59 @@ func validateSubnet(subnetSpec *kops.ClusterSubnetSpec, c *kops.ClusterSpec, fie
 		allErrs = append(allErrs, awsValidateAdditionalRoutes(fieldPath.Child("additionalRoutes"), subnetSpec.AdditionalRoutes, networkCIDRs)...)
 	}
 
+	if subnetSpec.PrivateDNSNameOptions != nil {
+		allErrs = append(allErrs, validatePrivateDNSNameOptions(subnetSpec, c, fieldPath.Child("privateDNSNameOptions"))...)
+	}
+
+	if len(subnetSpec.Tags) != 0 {
+		allErrs = append(allErrs, validateSubnetTags(subnetSpec, c, fieldPath.Child("tags"))...)
+	}
+
 	return allErrs
 }
 
+func validatePrivateDNSNameOptions(subnetSpec *kops.ClusterSubnetSpec, c *kops.ClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	v := subnetSpec.PrivateDNSNameOptions
+
+	if c.GetCloudProvider() != kops.CloudProviderAWS {
+		return append(allErrs, field.Forbidden(fldPath, "privateDNSNameOptions are supported only in AWS"))
+	}
+	if subnetSpec.ID != "" {
+		// kOps does not modify the attributes of shared subnets
+		return append(allErrs, field.Forbidden(fldPath, "privateDNSNameOptions cannot be set on shared subnets"))
+	}
+
+	if v.HostnameType != "" {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("hostnameType"), &v.HostnameType, []string{kops.HostnameTypeIPName, kops.HostnameTypeResourceName})...)
+		if v.HostnameType == kops.HostnameTypeIPName && subnetSpec.CIDR == "" && subnetSpec.IPv6CIDR != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("hostnameType"), v.HostnameType, "IPv6-only subnets must use resource-name hostnames"))
+		}
+	}
+	if fi.ValueOf(v.EnableResourceNameDNSARecord) && subnetSpec.CIDR == "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableResourceNameDNSARecord"), "A records require an IPv4 CIDR on the subnet"))
+	}
+	if fi.ValueOf(v.EnableResourceNameDNSAAAARecord) && subnetSpec.IPv6CIDR == "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableResourceNameDNSAAAARecord"), "AAAA records require an IPv6 CIDR on the subnet"))
+	}
+
+	return allErrs
+}
+
+func validateSubnetTags(subnetSpec *kops.ClusterSubnetSpec, c *kops.ClusterSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if c.GetCloudProvider() != kops.CloudProviderAWS {
+		return append(allErrs, field.Forbidden(fldPath, "tags are supported only in AWS"))
+	}
+	if subnetSpec.ID != "" {
+		// kOps only manages the cluster ownership tag of shared subnets
+		return append(allErrs, field.Forbidden(fldPath, "tags cannot be set on shared subnets"))
+	}
+
+	return append(allErrs, validateCloudLabels(subnetSpec.Tags, fldPath)...)
+}
+
 // validateFileAssetSpec is responsible for checking a FileAssetSpec is ok
 func validateFileAssetSpec(v *kops.FileAssetSpec, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -1084,6 +1275,18 @@ func validateNetworking(cluster *kops.Cluster, v *kops.NetworkingSpec, fldPath *
 		allErrs = append(allErrs, validateTopology(cluster, v.Topology, fldPath.Child("topology"))...)
 	}
 
+	if v.DHCPOptions != nil {
+		allErrs = append(allErrs, validateDHCPOptions(cluster, v.DHCPOptions, fldPath.Child("dhcpOptions"))...)
+	}
+
+	if v.TransitGateway != nil {
+		allErrs = append(allErrs, validateTransitGateway(cluster, v.TransitGateway, fldPath.Child("transitGateway"), networkCIDRs)...)
+	}
+
+	if len(v.VPCEndpoints) != 0 {
+		allErrs = append(allErrs, validateVPCEndpoints(cluster, v.VPCEndpoints, fldPath.Child("vpcEndpoints"))...)
+	}
+
 	optionTaken := false
 
 	if v.Classic != nil {
@@ -1195,6 +1398,14 @@ func validateNetworking(cluster *kops.Cluster, v *kops.NetworkingSpec, fldPath *
 			allErrs = append(allErrs, field.Forbidden(fldPath.Child("amazonVPC"), "amazon-vpc-routed-eni networking does not support IPv6"))
 		}
 
+		if v.AmazonVPC.SecurityGroupsForPods {
+			for i, env := range v.AmazonVPC.Env {
+				switch env.Name {
+				case "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG", "ENI_CONFIG_LABEL_DEF", "ENI_CONFIG_ANNOTATION_DEF", "ENABLE_POD_ENI":
+					allErrs = append(allErrs, field.Forbidden(fldPath.Child("amazonVPC", "env").Index(i).Child("name"), fmt.Sprintf("%s is managed by kOps when securityGroupsForPods is enabled", env.Name)))
+				}
+			}
+		}
 	}
 
 	if v.Cilium != nil {
@@ -1221,6 +1432,142 @@ func validateNetworking(cluster *kops.Cluster, v *kops.NetworkingSpec, fldPath *
 	return allErrs
 }
 
+// maxDHCPOptionsServers is the maximum number of servers of each type in an AWS DHCP options set.
+const maxDHCPOptionsServers = 4
+
+func validateDHCPOptions(cluster *kops.Cluster, v *kops.DHCPOptionsSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return append(allErrs, field.Forbidden(fldPath, "dhcpOptions are supported only in AWS"))
+	}
+	if cluster.Spec.Networking.NetworkID != "" {
+		// The DHCP options of a shared VPC also apply to resources that kOps does not manage
+		return append(allErrs, field.Forbidden(fldPath, "dhcpOptions cannot be set when using a shared VPC"))
+	}
+
+	if v.DomainName != "" {
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(v.DomainName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("domainName"), v.DomainName, msg))
+		}
+	}
+
+	if len(v.DomainNameServers) > maxDHCPOptionsServers {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("domainNameServers"), len(v.DomainNameServers), maxDHCPOptionsServers))
+	}
+	for i, server := range v.DomainNameServers {
+		if server != "AmazonProvidedDNS" && net.ParseIP(server) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("domainNameServers").Index(i), server, "must be an IP address or AmazonProvidedDNS"))
+		}
+	}
+
+	if len(v.NTPServers) > maxDHCPOptionsServers {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("ntpServers"), len(v.NTPServers), maxDHCPOptionsServers))
+	}
+	for i, server := range v.NTPServers {
+		if net.ParseIP(server) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ntpServers").Index(i), server, "must be an IP address"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateTransitGateway(cluster *kops.Cluster, v *kops.TransitGatewaySpec, fldPath *field.Path, networkCIDRs []*net.IPNet) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return append(allErrs, field.Forbidden(fldPath, "transitGateway is supported only in AWS"))
+	}
+
+	if v.ID == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("id"), ""))
+	} else if !strings.HasPrefix(v.ID, "tgw-") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("id"), v.ID, "must be the ID of a transit gateway"))
+	}
+
+	zones := make(map[string]string)
+	for i, name := range v.Subnets {
+		var subnetSpec *kops.ClusterSubnetSpec
+		for j := range cluster.Spec.Networking.Subnets {
+			if cluster.Spec.Networking.Subnets[j].Name == name {
+				subnetSpec = &cluster.Spec.Networking.Subnets[j]
+			}
+		}
+		if subnetSpec == nil {
+			allErrs = append(allErrs, field.NotFound(fldPath.Child("subnets").Index(i), name))
+			continue
+		}
+		if other, found := zones[subnetSpec.Zone]; found {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subnets").Index(i), name, fmt.Sprintf("subnet %q is in the same zone %q; the attachment can use only one subnet per zone", other, subnetSpec.Zone)))
+		}
+		zones[subnetSpec.Zone] = name
+	}
+
+	routes := sets.NewString()
+	for i, route := range v.Routes {
+		routePath := fldPath.Child("routes").Index(i)
+		if routes.Has(route) {
+			allErrs = append(allErrs, field.Duplicate(routePath, route))
+			continue
+		}
+		routes.Insert(route)
+
+		cidr, errs := parseCIDR(routePath, route)
+		allErrs = append(allErrs, errs...)
+		if cidr == nil {
+			continue
+		}
+		if cidr.IP.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(routePath, route, "must be an IPv4 CIDR"))
+			continue
+		}
+		for _, networkCIDR := range networkCIDRs {
+			if subnet.Overlap(cidr, networkCIDR) {
+				allErrs = append(allErrs, field.Invalid(routePath, route, fmt.Sprintf("must not overlap the network CIDR %q", networkCIDR.String())))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+var vpcEndpointServiceRegex = regexp.MustCompile(`^[a-z0-9]+([.-][a-z0-9]+)*$`)
+
+func validateVPCEndpoints(cluster *kops.Cluster, endpoints []kops.VPCEndpointSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return append(allErrs, field.Forbidden(fldPath, "vpcEndpoints are supported only in AWS"))
+	}
+
+	services := sets.NewString()
+	for i, endpoint := range endpoints {
+		endpointPath := fldPath.Index(i)
+
+		if endpoint.Service == "" {
+			allErrs = append(allErrs, field.Required(endpointPath.Child("service"), ""))
+		} else if !vpcEndpointServiceRegex.MatchString(endpoint.Service) || strings.HasPrefix(endpoint.Service, "com.amazonaws.") {
+			allErrs = append(allErrs, field.Invalid(endpointPath.Child("service"), endpoint.Service, "must be the name of a service in the region of the cluster, for example s3 or ecr.api"))
+		} else if services.Has(endpoint.Service) {
+			allErrs = append(allErrs, field.Duplicate(endpointPath.Child("service"), endpoint.Service))
+		}
+		services.Insert(endpoint.Service)
+
+		switch endpoint.Type {
+		case "", kops.VPCEndpointTypeInterface:
+		case kops.VPCEndpointTypeGateway:
+			if !slices.Contains(kops.VPCEndpointGatewayServices, endpoint.Service) {
+				allErrs = append(allErrs, field.Invalid(endpointPath.Child("type"), endpoint.Type, fmt.Sprintf("gateway endpoints are supported only for %s", strings.Join(kops.VPCEndpointGatewayServices, " and "))))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(endpointPath.Child("type"), endpoint.Type, []kops.VPCEndpointType{kops.VPCEndpointTypeGateway, kops.VPCEndpointTypeInterface}))
+		}
+	}
+
+	return allErrs
+}
+
 func validateNetworkingFlannel(c *kops.Cluster, v *kops.FlannelNetworkingSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -1393,6 +1740,73 @@ func validateNetworkingGCP(c *kops.ClusterSpec, v *kops.GCPNetworkingSpec, fldPa
 	return allErrs
 }
 
+func validateLifecycleOverride(key string, lifecycle string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	typeName, _, _ := strings.Cut(key, ":")
+	if typeName == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, key, "must be a task type, optionally followed by \":\" and a task name pattern"))
+	}
+
+	allErrs = append(allErrs, IsValidValue(fldPath, &lifecycle, fi.Lifecycles.List())...)
+
+	return allErrs
+}
+
+func validateExternalTasks(tasks []kops.ExternalTaskSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.New[string]()
+	for i := range tasks {
+		task := &tasks[i]
+		if names.Has(task.Name) {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), task.Name))
+		}
+		names.Insert(task.Name)
+	}
+
+	for i := range tasks {
+		task := &tasks[i]
+		taskPath := fldPath.Index(i)
+
+		if task.Name == "" {
+			allErrs = append(allErrs, field.Required(taskPath.Child("name"), ""))
+		} else {
+			for _, msg := range utilvalidation.IsDNS1123Subdomain(task.Name) {
+				allErrs = append(allErrs, field.Invalid(taskPath.Child("name"), task.Name, msg))
+			}
+		}
+
+		switch {
+		case len(task.Command) == 0 && task.URL == "":
+			allErrs = append(allErrs, field.Required(taskPath, "one of command and url must be set"))
+		case len(task.Command) != 0 && task.URL != "":
+			allErrs = append(allErrs, field.Forbidden(taskPath.Child("url"), "url cannot be set with command"))
+		case task.URL != "":
+			if u, err := url.Parse(task.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(taskPath.Child("url"), task.URL, "must be an http or https URL"))
+			}
+		case task.Command[0] == "":
+			allErrs = append(allErrs, field.Required(taskPath.Child("command").Index(0), "the executable of the plugin must be set"))
+		}
+
+		for j, dependency := range task.DependsOn {
+			dependencyPath := taskPath.Child("dependsOn").Index(j)
+			if typeName, name, found := strings.Cut(dependency, "/"); found {
+				if typeName == "" || name == "" {
+					allErrs = append(allErrs, field.Invalid(dependencyPath, dependency, "must be the name of an external task, or a task of kOps as type/name"))
+				}
+			} else if dependency == task.Name {
+				allErrs = append(allErrs, field.Invalid(dependencyPath, dependency, "a task cannot depend on itself"))
+			} else if !names.Has(dependency) {
+				allErrs = append(allErrs, field.NotFound(dependencyPath, dependency))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func validateAdditionalPolicy(role string, policy string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -1448,6 +1862,9 @@ func validateEtcdClusterSpec(spec kops.EtcdClusterSpec, c *kops.Cluster, fieldPa
 	if spec.Provider != "" {
 		allErrs = append(allErrs, IsValidValue(fieldPath.Child("provider"), &spec.Provider, []kops.EtcdProviderType{kops.EtcdProviderTypeManager})...)
 	}
+	if spec.External != nil {
+		return append(allErrs, validateEtcdExternal(spec, fieldPath)...)
+	}
 	if len(spec.Members) == 0 {
 		allErrs = append(allErrs, field.Required(fieldPath.Child("etcdMembers"), "No members defined in etcd cluster"))
 	} else if (len(spec.Members) % 2) == 0 {
@@ -1458,6 +1875,215 @@ func validateEtcdClusterSpec(spec kops.EtcdClusterSpec, c *kops.Cluster, fieldPa
 	for i, m := range spec.Members {
 		allErrs = append(allErrs, validateEtcdMemberSpec(m, fieldPath.Child("etcdMembers").Index(i))...)
 	}
+	allErrs = append(allErrs, validateEtcdTimings(spec, fieldPath)...)
+	if spec.TLS != nil {
+		allErrs = append(allErrs, validateEtcdTLS(spec.TLS, fieldPath.Child("tls"))...)
+	}
+	if spec.GRPC != nil {
+		allErrs = append(allErrs, validateEtcdGRPC(spec.GRPC, fieldPath.Child("grpc"))...)
+	}
+	if spec.Backups != nil && spec.Backups.SecondaryBackupStore != "" {
+		allErrs = append(allErrs, validateEtcdSecondaryBackupStore(spec.Backups, fieldPath.Child("backups", "secondaryBackupStore"))...)
+	}
+
+	return allErrs
+}
+
+// validateEtcdSecondaryBackupStore checks that the secondary backup store is a VFS URL, distinct from the backup store
+func validateEtcdSecondaryBackupStore(spec *kops.EtcdBackupSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	store := spec.SecondaryBackupStore
+	if u, err := url.Parse(store); err != nil || u.Scheme == "" {
+		allErrs = append(allErrs, field.Invalid(fieldPath, store, "must be a VFS URL, such as s3://bucket/prefix"))
+	} else if strings.TrimSuffix(store, "/") == strings.TrimSuffix(spec.BackupStore, "/") {
+		allErrs = append(allErrs, field.Invalid(fieldPath, store, "must not be the backup store"))
+	}
+	return allErrs
+}
+
+// validateEtcdExternal is responsible for validating an etcd cluster that is managed outside of kOps
+func validateEtcdExternal(spec kops.EtcdClusterSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Name != "main" && spec.Name != "events" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("external"), "only the main and events etcd clusters can be external"))
+	}
+	if len(spec.Members) != 0 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("etcdMembers"), "an external etcd cluster cannot have members"))
+	}
+	if spec.Manager != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("manager"), "an external etcd cluster is not run by etcd-manager"))
+	}
+	if spec.Backups != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("backups"), "an external etcd cluster is not backed up by kOps"))
+	}
+	allErrs = append(allErrs, validateEtcdVersion(spec, fieldPath, nil)...)
+
+	fieldEndpoints := fieldPath.Child("external", "endpoints")
+	if len(spec.External.Endpoints) == 0 {
+		allErrs = append(allErrs, field.Required(fieldEndpoints, "an external etcd cluster must have endpoints"))
+	}
+	for i, endpoint := range spec.External.Endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fieldEndpoints.Index(i), endpoint, "must be an https URL"))
+		}
+	}
+	return allErrs
+}
+
+// validateEtcdExternalClusters checks that the API server can use the same client credentials for the main and events
+// etcd clusters, as it has a single client certificate.
+func validateEtcdExternalClusters(specs []kops.EtcdClusterSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	var main *kops.EtcdClusterSpec
+	for i := range specs {
+		if specs[i].Name == "main" {
+			main = &specs[i]
+		}
+	}
+	if main == nil {
+		return allErrs
+	}
+	for i, spec := range specs {
+		if spec.Name != "events" {
+			continue
+		}
+		if (spec.External == nil) != (main.External == nil) {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i).Child("external"), "the main and events etcd clusters must both be external, or both be managed by kOps"))
+		} else if spec.External != nil && etcdexternal.ClientSecret(&spec) != etcdexternal.ClientSecret(main) {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Index(i).Child("external", "clientSecret"), "the main and events etcd clusters must use the same client secret"))
+		}
+	}
+
+	return allErrs
+}
+
+const (
+	// etcdDefaultHeartbeatInterval and etcdDefaultElectionTimeout are the defaults of etcd
+	etcdDefaultHeartbeatInterval = 100 * time.Millisecond
+	etcdDefaultElectionTimeout   = 1000 * time.Millisecond
+	// etcdMaxElectionTimeout is the largest election timeout accepted by etcd
+	etcdMaxElectionTimeout = 50 * time.Second
+)
+
+// validateEtcdTimings is responsible for validating the raft timings of etcd, which etcd checks only when starting
+func validateEtcdTimings(spec kops.EtcdClusterSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	heartbeatInterval := etcdDefaultHeartbeatInterval
+	if spec.HeartbeatInterval != nil {
+		heartbeatInterval = spec.HeartbeatInterval.Duration
+		if heartbeatInterval < time.Millisecond {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("heartbeatInterval"), heartbeatInterval.String(), "must be at least 1ms"))
+		}
+	}
+	electionTimeout := etcdDefaultElectionTimeout
+	if spec.LeaderElectionTimeout != nil {
+		electionTimeout = spec.LeaderElectionTimeout.Duration
+		if electionTimeout > etcdMaxElectionTimeout {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("leaderElectionTimeout"), electionTimeout.String(), fmt.Sprintf("must not be greater than %v", etcdMaxElectionTimeout)))
+		}
+	}
+
+	if (spec.HeartbeatInterval != nil || spec.LeaderElectionTimeout != nil) && electionTimeout < 5*heartbeatInterval {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("leaderElectionTimeout"), electionTimeout.String(), fmt.Sprintf("must be at least 5 times the heartbeat interval %v", heartbeatInterval)))
+	}
+
+	return allErrs
+}
+
+// EtcdTimingWarnings warns about the tradeoffs of the raft timings of the etcd cluster, when they are tuned
+// for members far apart: a failed leader is replaced more slowly, and members further apart than their heartbeat
+// interval trigger elections. kops validate cluster warns about the latency measured between the members.
+func EtcdTimingWarnings(spec kops.EtcdClusterSpec) []string {
+	if spec.HeartbeatInterval == nil && spec.LeaderElectionTimeout == nil {
+		return nil
+	}
+
+	heartbeatInterval := etcdDefaultHeartbeatInterval
+	if spec.HeartbeatInterval != nil {
+		heartbeatInterval = spec.HeartbeatInterval.Duration
+	}
+	electionTimeout := etcdDefaultElectionTimeout
+	if spec.LeaderElectionTimeout != nil {
+		electionTimeout = spec.LeaderElectionTimeout.Duration
+	}
+
+	var warnings []string
+	if electionTimeout > etcdDefaultElectionTimeout {
+		warnings = append(warnings, fmt.Sprintf("a failed leader of etcd cluster %q is replaced after %v, instead of %v with the default leaderElectionTimeout; the API server is unavailable meanwhile",
+			spec.Name, electionTimeout, etcdDefaultElectionTimeout))
+	}
+	if electionTimeout < 10*heartbeatInterval {
+		warnings = append(warnings, fmt.Sprintf("the leaderElectionTimeout of etcd cluster %q is less than 10 times its heartbeatInterval of %v, so slow links can trigger elections",
+			spec.Name, heartbeatInterval))
+	}
+	return warnings
+}
+
+// etcdTLSVersions are the TLS versions supported by etcd, in increasing order.
+var etcdTLSVersions = []string{"TLS1.2", "TLS1.3"}
+
+// validateEtcdTLS is responsible for validating the TLS settings of etcd
+func validateEtcdTLS(spec *kops.EtcdTLSSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.MinVersion != "" {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("minVersion"), &spec.MinVersion, etcdTLSVersions)...)
+	}
+	if spec.MaxVersion != "" {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("maxVersion"), &spec.MaxVersion, etcdTLSVersions)...)
+	}
+	minIndex := slices.Index(etcdTLSVersions, spec.MinVersion)
+	maxIndex := slices.Index(etcdTLSVersions, spec.MaxVersion)
+	if minIndex >= 0 && maxIndex >= 0 && minIndex > maxIndex {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("minVersion"), spec.MinVersion, fmt.Sprintf("must not be greater than maxVersion %q", spec.MaxVersion)))
+	}
+
+	if len(spec.CipherSuites) != 0 {
+		if spec.MinVersion == "TLS1.3" {
+			// TLS 1.3 cipher suites are not configurable
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("cipherSuites"), "cipherSuites cannot be set when minVersion is TLS1.3"))
+		}
+		cipherSuites := sets.NewString()
+		for _, suite := range tls.CipherSuites() {
+			cipherSuites.Insert(suite.Name)
+		}
+		for _, suite := range tls.InsecureCipherSuites() {
+			cipherSuites.Insert(suite.Name)
+		}
+		for i, suite := range spec.CipherSuites {
+			if !cipherSuites.Has(suite) {
+				allErrs = append(allErrs, field.NotSupported(fieldPath.Child("cipherSuites").Index(i), suite, cipherSuites.List()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateEtcdGRPC is responsible for validating the gRPC settings of etcd
+func validateEtcdGRPC(spec *kops.EtcdGRPCSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.MaxConcurrentStreams != nil && *spec.MaxConcurrentStreams <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxConcurrentStreams"), *spec.MaxConcurrentStreams, "must be greater than zero"))
+	}
+	if spec.MaxRequestBytes != nil && *spec.MaxRequestBytes <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("maxRequestBytes"), *spec.MaxRequestBytes, "must be greater than zero"))
+	}
+	if spec.KeepaliveMinTime != nil && spec.KeepaliveMinTime.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("keepaliveMinTime"), spec.KeepaliveMinTime.Duration.String(), "must be greater than zero"))
+	}
+	if spec.KeepaliveInterval != nil && spec.KeepaliveInterval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("keepaliveInterval"), spec.KeepaliveInterval.Duration.String(), "must be greater than zero"))
+	}
+	if spec.KeepaliveTimeout != nil && spec.KeepaliveTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("keepaliveTimeout"), spec.KeepaliveTimeout.Duration.String(), "must be greater than zero"))
+	}
 
 	return allErrs
 }
@@ -1833,6 +2459,73 @@ func validateRollingUpdate(rollingUpdate *kops.RollingUpdate, fldpath *field.Pat
 			allErrs = append(allErrs, field.Forbidden(fldpath.Child("maxSurge"), "Cannot be zero if maxUnavailable is zero"))
 		}
 	}
+	if rollingUpdate.MaxUnavailablePerZone != nil {
+		unavailable, err := intstr.GetScaledValueFromIntOrPercent(rollingUpdate.MaxUnavailablePerZone, 1, false)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("maxUnavailablePerZone"), rollingUpdate.MaxUnavailablePerZone,
+				fmt.Sprintf("Unable to parse: %v", err)))
+		}
+		if unavailable < 0 {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("maxUnavailablePerZone"), rollingUpdate.MaxUnavailablePerZone, "Cannot be negative"))
+		}
+	}
+	if canary := rollingUpdate.Canary; canary != nil {
+		if onControlPlaneInstanceGroup {
+			allErrs = append(allErrs, field.Forbidden(fldpath.Child("canary"), "Cannot use a canary on instance groups with role \"ControlPlane\""))
+		}
+		if canary.Timeout != nil && canary.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("canary", "timeout"), canary.Timeout.Duration.String(), "Must be positive"))
+		}
+		if canary.Job != nil && canary.Job.Image == "" {
+			allErrs = append(allErrs, field.Required(fldpath.Child("canary", "job", "image"), "An image is required to run a canary job"))
+		}
+	}
+	if rollingUpdate.Strategy != "" {
+		if !slices.Contains(kops.SupportedRollingUpdateStrategies, rollingUpdate.Strategy) {
+			allErrs = append(allErrs, field.NotSupported(fldpath.Child("strategy"), rollingUpdate.Strategy, kops.SupportedRollingUpdateStrategies))
+		} else if onControlPlaneInstanceGroup && rollingUpdate.Strategy == kops.RollingUpdateStrategySurge {
+			allErrs = append(allErrs, field.Forbidden(fldpath.Child("strategy"), "Cannot surge instance groups with role \"ControlPlane\""))
+		}
+	}
+	if drain := rollingUpdate.Drain; drain != nil {
+		if drain.GracePeriod != nil && drain.GracePeriod.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("drain", "gracePeriod"), drain.GracePeriod.Duration.String(), "Cannot be negative"))
+		}
+		if drain.Timeout != nil && drain.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("drain", "timeout"), drain.Timeout.Duration.String(), "Must be positive"))
+		}
+		allErrs = append(allErrs, metav1validation.ValidateLabels(drain.SkipPDBLabels, fldpath.Child("drain", "skipPDBLabels"))...)
+		if drain.TimeoutPolicy != "" && !slices.Contains(kops.SupportedRollingUpdateDrainTimeoutPolicies, drain.TimeoutPolicy) {
+			allErrs = append(allErrs, field.NotSupported(fldpath.Child("drain", "timeoutPolicy"), drain.TimeoutPolicy, kops.SupportedRollingUpdateDrainTimeoutPolicies))
+		}
+	}
+	return allErrs
+}
+
+func validateCloudIdentityAuthentication(c *kops.Cluster, spec *kops.CloudIdentityAuthenticationSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	cloudProvider := c.Spec.GetCloudProvider()
+	if cloudProvider != kops.CloudProviderAWS && cloudProvider != kops.CloudProviderGCE {
+		return append(allErrs, field.Forbidden(fldPath, "cloudIdentity is only supported on AWS and GCE"))
+	}
+
+	if len(spec.AllowedIdentities) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("allowedIdentities"), "at least one identity must be allowed"))
+	}
+	for i, identity := range spec.AllowedIdentities {
+		fld := fldPath.Child("allowedIdentities").Index(i)
+		if cloudProvider == kops.CloudProviderAWS {
+			if _, err := awsup.CanonicalIAMIdentity(identity); err != nil || strings.Contains(identity, ":assumed-role/") {
+				allErrs = append(allErrs, field.Invalid(fld, identity, "must be the ARN of an IAM role or user"))
+			}
+		} else if !strings.Contains(identity, "@") {
+			allErrs = append(allErrs, field.Invalid(fld, identity, "must be the email of a service account"))
+		}
+	}
+
+	if spec.MaxLifetime != nil && (spec.MaxLifetime.Duration <= 0 || spec.MaxLifetime.Duration > 24*time.Hour) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxLifetime"), spec.MaxLifetime.Duration.String(), "must be greater than zero and at most 24h"))
+	}
+
 	return allErrs
 }
 
@@ -1980,3 +2673,218 @@ func validateCertManager(cluster *kops.Cluster, spec *kops.CertManagerConfig, fl
 	}
 	return allErrs
 }
+
+func validateEventExporter(cluster *kops.Cluster, spec *kops.EventExporterConfig, fldPath *field.Path) (allErrs field.ErrorList) {
+	cloudProvider := cluster.Spec.GetCloudProvider()
+
+	switch spec.Sink {
+	case "":
+		if cloudProvider != kops.CloudProviderAWS && cloudProvider != kops.CloudProviderGCE {
+			allErrs = append(allErrs, field.Required(fldPath.Child("sink"), "sink must be specified on this cloud provider"))
+		}
+	case kops.EventExporterSinkCloudWatch:
+		if cloudProvider != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("sink"), "the CloudWatch sink is only supported on AWS"))
+		}
+	case kops.EventExporterSinkStackdriver:
+		if cloudProvider != kops.CloudProviderGCE {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("sink"), "the Stackdriver sink is only supported on GCE"))
+		}
+	case kops.EventExporterSinkLoki:
+		if spec.Loki == nil || spec.Loki.URL == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("loki", "url"), "the Loki sink requires a push URL"))
+		} else if _, err := url.ParseRequestURI(spec.Loki.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("loki", "url"), spec.Loki.URL, "must be a valid URL"))
+		}
+	default:
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("sink"), &spec.Sink, kops.SupportedEventExporterSinks)...)
+	}
+
+	if spec.CloudWatch != nil && spec.CloudWatch.RetentionInDays != nil && *spec.CloudWatch.RetentionInDays <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cloudWatch", "retentionInDays"), *spec.CloudWatch.RetentionInDays, "must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+func validateTTLNotification(spec *kops.ClusterSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	if spec.TTL == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "ttlNotification requires ttl to be set"))
+	}
+	for i, webhookURL := range spec.TTLNotification.WebhookURLs {
+		if u, err := url.ParseRequestURI(webhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("webhookURLs").Index(i), webhookURL, "must be an http or https URL"))
+		}
+	}
+	if before := spec.TTLNotification.Before; before != nil && before.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("before"), before.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+func validatePrometheusAgent(cluster *kops.Cluster, spec *kops.PrometheusAgentConfig, fldPath *field.Path) (allErrs field.ErrorList) {
+	if spec.RemoteWrite == nil || spec.RemoteWrite.URL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("remoteWrite", "url"), "the Prometheus agent requires a remote-write URL"))
+	} else {
+		rw := spec.RemoteWrite
+		fldPath := fldPath.Child("remoteWrite")
+		if u, err := url.ParseRequestURI(rw.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), rw.URL, "must be an http or https URL"))
+		}
+		if rw.SigV4 != nil {
+			if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("sigV4"), "SigV4 signing is only supported on AWS"))
+			}
+			if rw.BasicAuthSecretName != "" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("basicAuthSecretName"), "basic authentication cannot be used with SigV4 signing"))
+			}
+		}
+		if rw.BasicAuthSecretName != "" {
+			for _, msg := range validation.NameIsDNSSubdomain(rw.BasicAuthSecretName, false) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("basicAuthSecretName"), rw.BasicAuthSecretName, msg))
+			}
+		}
+	}
+
+	if _, found := spec.ExternalLabels["cluster"]; found {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("externalLabels").Key("cluster"), "the cluster label is set to the cluster name"))
+	}
+
+	if spec.ScrapeInterval != nil && spec.ScrapeInterval.Duration < time.Second {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("scrapeInterval"), spec.ScrapeInterval.Duration.String(), "must be at least 1s"))
+	}
+
+	return allErrs
+}
+
+// highestUserDefinablePriority is the highest priority of PriorityClasses that are not reserved for the system.
+const highestUserDefinablePriority = 1000000000
+
+func validateClusterDefaults(spec *kops.ClusterDefaultsSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	namespaces := sets.NewString()
+	for i, namespace := range spec.Namespaces {
+		fldPath := fldPath.Child("namespaces").Index(i)
+		if namespace.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.ValidateNamespaceName(namespace.Name, false) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), namespace.Name, msg))
+			}
+			if namespaces.Has(namespace.Name) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), namespace.Name))
+			}
+			namespaces.Insert(namespace.Name)
+		}
+		allErrs = append(allErrs, metav1validation.ValidateLabels(namespace.Labels, fldPath.Child("labels"))...)
+		allErrs = append(allErrs, validation.ValidateAnnotations(namespace.Annotations, fldPath.Child("annotations"))...)
+		for j, limit := range namespace.LimitRange {
+			allErrs = append(allErrs, IsValidValue(fldPath.Child("limitRange").Index(j).Child("type"), &limit.Type, []corev1.LimitType{
+				corev1.LimitTypeContainer,
+				corev1.LimitTypePod,
+				corev1.LimitTypePersistentVolumeClaim,
+			})...)
+		}
+	}
+
+	priorityClasses := sets.NewString()
+	globalDefault := false
+	for i, priorityClass := range spec.PriorityClasses {
+		fldPath := fldPath.Child("priorityClasses").Index(i)
+		if priorityClass.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+		} else {
+			for _, msg := range validation.NameIsDNSSubdomain(priorityClass.Name, false) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), priorityClass.Name, msg))
+			}
+			if strings.HasPrefix(priorityClass.Name, "system-") {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("name"), "the system- prefix is reserved for the system PriorityClasses"))
+			}
+			if priorityClasses.Has(priorityClass.Name) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), priorityClass.Name))
+			}
+			priorityClasses.Insert(priorityClass.Name)
+		}
+		if priorityClass.Value > highestUserDefinablePriority {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("value"), priorityClass.Value, fmt.Sprintf("must be at most %d", highestUserDefinablePriority)))
+		}
+		if priorityClass.GlobalDefault {
+			if globalDefault {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("globalDefault"), "only one PriorityClass can be the global default"))
+			}
+			globalDefault = true
+		}
+		if priorityClass.PreemptionPolicy != "" {
+			allErrs = append(allErrs, IsValidValue(fldPath.Child("preemptionPolicy"), fi.PtrTo(corev1.PreemptionPolicy(priorityClass.PreemptionPolicy)), []corev1.PreemptionPolicy{
+				corev1.PreemptLowerPriority,
+				corev1.PreemptNever,
+			})...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateTrustBundle(bundle string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	rest := []byte(bundle)
+	certificates := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			allErrs = append(allErrs, field.Invalid(fldPath, block.Type, "trust bundles must only contain certificates"))
+			continue
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, "...", fmt.Sprintf("error parsing certificate: %v", err)))
+			continue
+		}
+		certificates++
+	}
+
+	if strings.TrimSpace(string(rest)) != "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, "...", "trust bundles must only contain PEM-encoded certificates"))
+	} else if certificates == 0 && len(allErrs) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "trust bundles must contain at least one PEM-encoded certificate"))
+	}
+
+	return allErrs
+}
+
+func validateNTP(spec *kops.NTPConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(spec.Servers) != 0 && spec.Managed != nil && !*spec.Managed {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("servers"), "servers cannot be set when NTP is not managed"))
+	}
+	for i, server := range spec.Servers {
+		if net.ParseIP(server) != nil {
+			continue
+		}
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(server) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("servers").Index(i), server, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validateLogRotation(spec *kops.LogRotationSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.MaxSize != nil && spec.MaxSize.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxSize"), spec.MaxSize.String(), "must be greater than 0"))
+	}
+	if spec.MaxFiles != nil && *spec.MaxFiles < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxFiles"), *spec.MaxFiles, "must be at least 1"))
+	}
+	if spec.JournalMaxUse != nil && spec.JournalMaxUse.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("journalMaxUse"), spec.JournalMaxUse.String(), "must be greater than 0"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+const AdminCredentialsAPIVersion = "admincredentials.kops.k8s.io/v1alpha1"
+
+// AdminCredentialsRequest is a request from an operator to kops-controller for a short-lived admin credential.
+type AdminCredentialsRequest struct {
+	// APIVersion defines the versioned schema of this representation of a request.
+	APIVersion string `json:"apiVersion"`
+	// ClusterName is the name of the cluster the credential is requested for.
+	ClusterName string `json:"clusterName"`
+	// PublicKey is the public key of the requested client certificate.
+	PublicKey string `json:"publicKey"`
+	// LifetimeSeconds is the requested lifetime of the credential.
+	// The credential is issued with the maximum lifetime if it is zero or exceeds the maximum.
+	LifetimeSeconds int64 `json:"lifetimeSeconds,omitempty"`
+}
+
+// AdminCredentialsResponse is a response to an AdminCredentialsRequest.
+type AdminCredentialsResponse struct {
+	// Certificate is the issued client certificate.
+	Certificate string `json:"certificate"`
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/model"
+	"k8s.io/kops/pkg/etcdexternal"
 	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/reflectutils"
 )
@@ -54,6 +55,8 @@ type Config struct {
 
 	// CAs are the CA certificates to trust.
 	CAs map[string]string
+	// AdditionalTrustBundles are PEM-encoded CA certificates to add to the trust store of the node.
+	AdditionalTrustBundles []string `json:"additionalTrustBundles,omitempty"`
 	// KeypairIDs are the IDs of keysets used to sign things.
 	KeypairIDs map[string]string
 	// DefaultMachineType is the first-listed instance machine type, used if querying instance metadata fails.
@@ -75,6 +78,16 @@ type Config struct {
 	UsesKubenet bool `json:",omitempty"`
 	// NTPUnmanaged is true when NTP is not managed by kOps.
 	NTPUnmanaged bool `json:",omitempty"`
+	// NTPServers are the NTP servers to synchronize with, instead of the NTP service of the cloud provider.
+	NTPServers []string `json:",omitempty"`
+	// LogRotation is the log rotation configuration of the instance group, when set in the cluster or the instance group.
+	LogRotation *kops.LogRotationSpec `json:",omitempty"`
+	// NitroEnclaves configures the Nitro Enclaves allocator, when enclaves are enabled for the instance group.
+	NitroEnclaves *kops.NitroEnclavesSpec `json:",omitempty"`
+	// EFA configures the Elastic Fabric Adapter driver, when EFA interfaces are attached to the instance group.
+	EFA *kops.EFASpec `json:",omitempty"`
+	// MultipleNetworkInterfaces is true when the instances have more than one network interface.
+	MultipleNetworkInterfaces bool `json:",omitempty"`
 	// ServiceNodePortRange is the service NodePort range.
 	ServiceNodePortRange string `json:",omitempty"`
 	// SysctlParameters will configure kernel parameters using sysctl(8).
@@ -114,6 +127,8 @@ type Config struct {
 	NodeIPFamilies []string `json:"nodeIPFamilies,omitempty"`
 	// WarmPoolImages are the container images to pre-pull during instance pre-initialization
 	WarmPoolImages []string `json:"warmPoolImages,omitempty"`
+	// StaticNetworkInterface is the network interface to attach to the instance, if any.
+	StaticNetworkInterface *kops.StaticNetworkInterfaceSpec `json:"staticNetworkInterface,omitempty"`
 
 	// Azure-specific
 	// AzureLocation is the location of the resource group that the cluster is deployed in.
@@ -202,6 +217,9 @@ type APIServerConfig struct {
 	EncryptionConfigSecretHash string `json:",omitempty"`
 	// ServiceAccountPublicKeys are the service-account public keys to trust.
 	ServiceAccountPublicKeys string
+	// EtcdClientSecret is the name of the secret holding the client credentials of the external etcd clusters.
+	// It is empty if the etcd clusters are managed by kOps.
+	EtcdClientSecret string `json:",omitempty"`
 }
 
 // ControlPlaneConfig is additional configuration for control-plane nodes.
@@ -234,6 +252,8 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		Hooks:                [][]kops.HookSpec{igHooks, clusterHooks},
 		UsesLegacyGossip:     cluster.UsesLegacyGossip(),
 		UsesNoneDNS:          cluster.UsesNoneDNS(),
+
+		AdditionalTrustBundles: cluster.Spec.AdditionalTrustBundles,
 	}
 
 	bootConfig := BootConfig{
@@ -253,8 +273,15 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 
 	config.KubeProxy = buildKubeProxy(cluster, instanceGroup)
 
-	if cluster.Spec.NTP != nil && cluster.Spec.NTP.Managed != nil && !*cluster.Spec.NTP.Managed {
-		config.NTPUnmanaged = true
+	if cluster.Spec.LogRotation != nil || instanceGroup.Spec.LogRotation != nil {
+		config.LogRotation = cluster.Spec.LogRotation.ResolveDefaults(instanceGroup)
+	}
+
+	if cluster.Spec.NTP != nil {
+		if cluster.Spec.NTP.Managed != nil && !*cluster.Spec.NTP.Managed {
+			config.NTPUnmanaged = true
+		}
+		config.NTPServers = cluster.Spec.NTP.Servers
 	}
 
 	if cluster.Spec.CloudProvider.AWS != nil {
@@ -269,6 +296,17 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 			config.ElbSecurityGroup = aws.ElbSecurityGroup
 			config.NodeIPFamilies = aws.NodeIPFamilies
 		}
+
+		config.StaticNetworkInterface = instanceGroup.Spec.StaticNetworkInterface
+
+		if instanceGroup.Spec.ConfidentialCompute != nil {
+			config.NitroEnclaves = instanceGroup.Spec.ConfidentialCompute.NitroEnclaves
+		}
+		config.EFA = instanceGroup.Spec.EFA
+		config.MultipleNetworkInterfaces = len(instanceGroup.Spec.AdditionalNetworkInterfaces) > 0
+		if instanceGroup.Spec.EFA != nil && instanceGroup.Spec.EFA.InterfaceCount != nil && *instanceGroup.Spec.EFA.InterfaceCount > 1 {
+			config.MultipleNetworkInterfaces = true
+		}
 	}
 
 	if cluster.Spec.CloudProvider.Azure != nil {
@@ -354,6 +392,12 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		if cluster.Spec.API.LoadBalancer != nil && cluster.Spec.API.LoadBalancer.UseForInternalAPI {
 			config.APIServerConfig.API.LoadBalancer = &kops.LoadBalancerAccessSpec{UseForInternalAPI: true}
 		}
+		for i := range cluster.Spec.EtcdClusters {
+			etcdCluster := &cluster.Spec.EtcdClusters[i]
+			if etcdCluster.Name == "main" && etcdCluster.External != nil {
+				config.APIServerConfig.EtcdClientSecret = etcdexternal.ClientSecret(etcdCluster)
+			}
+		}
 	}
 
 	if instanceGroup.HasAPIServer() || !model.UseKopsControllerForNodeConfig(cluster) {
@@ -0,0 +1,27 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+// The paths of the files that are built by cloudup and written by nodeup.
+// They are declared here, rather than with the builders of the files,
+// so that nodeup does not link the cloudup models.
+const (
+	// KubeSchedulerConfigPath is the path where we write the kube-scheduler config file (on the control-plane nodes)
+	KubeSchedulerConfigPath = "/var/lib/kube-scheduler/config.yaml"
+	// KubeSchedulerKubeConfigPath is the path where we write the kube-scheduler kubeconfig file (on the control-plane nodes)
+	KubeSchedulerKubeConfigPath = "/var/lib/kube-scheduler/kubeconfig"
+)
@@ -0,0 +1,46 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import "strings"
+
+// Locations of the additional trust bundles on the nodes.
+const (
+	// TrustBundlesDirectory holds the additional trust bundles for the components that read them from SSL_CERT_DIR.
+	TrustBundlesDirectory = "/etc/kubernetes/trust-bundles"
+	// TrustBundlesFile is the name of the file of the additional trust bundles.
+	TrustBundlesFile = "kops-additional-trust-bundles.crt"
+
+	// TrustBundlesDebianDirectory is the source of update-ca-certificates on Debian and Ubuntu.
+	TrustBundlesDebianDirectory = "/usr/local/share/ca-certificates"
+	// TrustBundlesRHELDirectory is the source of update-ca-trust on RHEL-family distributions.
+	TrustBundlesRHELDirectory = "/etc/pki/ca-trust/source/anchors"
+	// TrustBundlesFlatcarDirectory is the source of update-ca-certificates on Flatcar.
+	TrustBundlesFlatcarDirectory = "/etc/ssl/certs"
+	// TrustBundlesFlatcarFile is the name of the file of the additional trust bundles on Flatcar, which only reads .pem files.
+	TrustBundlesFlatcarFile = "kops-additional-trust-bundles.pem"
+)
+
+// JoinTrustBundles returns the contents of the file of the additional trust bundles.
+func JoinTrustBundles(bundles []string) string {
+	var b strings.Builder
+	for _, bundle := range bundles {
+		b.WriteString(strings.TrimSpace(bundle))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -56,5 +56,24 @@ type Verifier interface {
 	VerifyToken(ctx context.Context, rawRequest *http.Request, token string, body []byte) (*VerifyResult, error)
 }
 
+// IdentityResult is the result of a successfully verified request from an operator.
+type IdentityResult struct {
+	// Identity is the cloud identity of the caller, such as the ARN of an IAM role,
+	// in the form in which it is matched against the allowed identities.
+	Identity string
+
+	// Principal is the full identity of the caller, such as the ARN of an IAM role session.
+	// It identifies the caller in audit logs and in the credentials issued to it.
+	Principal string
+}
+
+// IdentityVerifier verifies authentication credentials of operators, rather than of nodes.
+type IdentityVerifier interface {
+	// VerifyIdentity performs full validation of the provided token, often making cloud API calls to verify the caller.
+	// It should return either an error or a validated IdentityResult.
+	// If the token looks like it is intended for a different verifier, we should return ErrNotThisVerifier
+	VerifyIdentity(ctx context.Context, rawRequest *http.Request, token string, body []byte) (*IdentityResult, error)
+}
+
 // ErrNotThisVerifier is returned when a verifier receives a token that is not intended for it.
 var ErrNotThisVerifier = errors.New("token not valid for this verifier")
@@ -41,6 +41,10 @@ type RESTClientset struct {
 	vfsContext *vfs.VFSContext
 	BaseURL    *url.URL
 	KopsClient kopsinternalversion.KopsInterface
+
+	// Namespace is the namespace holding the objects of the cluster.
+	// If empty, each cluster is stored in a namespace named after the cluster.
+	Namespace string
 }
 
 func NewRESTClientset(vfsContext *vfs.VFSContext, baseURL *url.URL, kopsClient kopsinternalversion.KopsInterface) *RESTClientset {
@@ -48,6 +52,7 @@ func NewRESTClientset(vfsContext *vfs.VFSContext, baseURL *url.URL, kopsClient k
 		vfsContext: vfsContext,
 		BaseURL:    baseURL,
 		KopsClient: kopsClient,
+		Namespace:  strings.Trim(baseURL.Path, "/"),
 	}
 }
 
@@ -57,7 +62,7 @@ func (c *RESTClientset) VFSContext() *vfs.VFSContext {
 
 // GetCluster implements the GetCluster method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) GetCluster(ctx context.Context, name string) (*kops.Cluster, error) {
-	namespace := restNamespaceForClusterName(name)
+	namespace := c.namespaceForClusterName(name)
 	return c.KopsClient.Clusters(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
@@ -70,7 +75,20 @@ func (c *RESTClientset) AddonsFor(cluster *kops.Cluster) simple.AddonsClient {
 
 // CreateCluster implements the CreateCluster method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) CreateCluster(ctx context.Context, cluster *kops.Cluster) (*kops.Cluster, error) {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
+	if c.Namespace != "" {
+		// The instance groups and keysets are named independently of the cluster,
+		// so a namespace can only hold a single cluster
+		clusters, err := c.KopsClient.Clusters(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error listing clusters in namespace %q: %w", namespace, err)
+		}
+		for _, existing := range clusters.Items {
+			if existing.Name != cluster.Name {
+				return nil, fmt.Errorf("namespace %q already holds cluster %q, and can only hold a single cluster", namespace, existing.Name)
+			}
+		}
+	}
 	return c.KopsClient.Clusters(namespace).Create(ctx, cluster, metav1.CreateOptions{})
 }
 
@@ -85,7 +103,7 @@ func (c *RESTClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster
 		return nil, err
 	}
 
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return c.KopsClient.Clusters(namespace).Update(ctx, cluster, metav1.UpdateOptions{})
 }
 
@@ -101,27 +119,31 @@ func (c *RESTClientset) ConfigBaseFor(cluster *kops.Cluster) (vfs.Path, error) {
 
 // ListClusters implements the ListClusters method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) ListClusters(ctx context.Context, options metav1.ListOptions) (*kops.ClusterList, error) {
-	return c.KopsClient.Clusters(metav1.NamespaceAll).List(ctx, options)
+	namespace := metav1.NamespaceAll
+	if c.Namespace != "" {
+		namespace = c.Namespace
+	}
+	return c.KopsClient.Clusters(namespace).List(ctx, options)
 }
 
 // InstanceGroupsFor implements the InstanceGroupsFor method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) InstanceGroupsFor(cluster *kops.Cluster) kopsinternalversion.InstanceGroupInterface {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return c.KopsClient.InstanceGroups(namespace)
 }
 
 func (c *RESTClientset) SecretStore(cluster *kops.Cluster) (fi.SecretStore, error) {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return secrets.NewClientsetSecretStore(cluster, c.KopsClient, namespace), nil
 }
 
 func (c *RESTClientset) KeyStore(cluster *kops.Cluster) (fi.CAStore, error) {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return fi.NewClientsetCAStore(cluster, c.KopsClient, namespace), nil
 }
 
 func (c *RESTClientset) SSHCredentialStore(cluster *kops.Cluster) (fi.SSHCredentialStore, error) {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return fi.NewClientsetSSHCredentialStore(cluster, c.KopsClient, namespace), nil
 }
 
@@ -137,7 +159,7 @@ func (c *RESTClientset) DeleteCluster(ctx context.Context, cluster *kops.Cluster
 	}
 
 	name := cluster.Name
-	namespace := restNamespaceForClusterName(name)
+	namespace := c.namespaceForClusterName(name)
 
 	{
 		keysets, err := c.KopsClient.Keysets(namespace).List(ctx, metav1.ListOptions{})
@@ -192,6 +214,13 @@ func (c *RESTClientset) DeleteCluster(ctx context.Context, cluster *kops.Cluster
 	return nil
 }
 
+func (c *RESTClientset) namespaceForClusterName(clusterName string) string {
+	if c.Namespace != "" {
+		return c.Namespace
+	}
+	return restNamespaceForClusterName(clusterName)
+}
+
 func restNamespaceForClusterName(clusterName string) string {
 	// We are not allowed dots, so we map them to dashes
 	// This can conflict, but this will simply be a limitation that we pass on to the user
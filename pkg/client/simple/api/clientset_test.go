@@ -0,0 +1,91 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/clientset_generated/clientset/fake"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func newTestRESTClientset(t *testing.T, stateStore string) *RESTClientset {
+	u, err := url.Parse(stateStore)
+	if err != nil {
+		t.Fatalf("error parsing %q: %v", stateStore, err)
+	}
+	return NewRESTClientset(vfs.Context, u, fake.NewSimpleClientset().Kops())
+}
+
+func TestRESTClientsetNamespace(t *testing.T) {
+	ctx := context.TODO()
+
+	grid := []struct {
+		stateStore string
+		expected   string
+	}{
+		{
+			stateStore: "k8s://",
+			expected:   "cluster-example-com",
+		},
+		{
+			stateStore: "k8s://management",
+			expected:   "cluster-example-com",
+		},
+		{
+			stateStore: "k8s://management/kops-clusters",
+			expected:   "kops-clusters",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.stateStore, func(t *testing.T) {
+			c := newTestRESTClientset(t, g.stateStore)
+
+			cluster := &kops.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster.example.com"}}
+			if _, err := c.CreateCluster(ctx, cluster); err != nil {
+				t.Fatalf("error creating cluster: %v", err)
+			}
+			if _, err := c.KopsClient.Clusters(g.expected).Get(ctx, cluster.Name, metav1.GetOptions{}); err != nil {
+				t.Errorf("expected cluster in namespace %q: %v", g.expected, err)
+			}
+
+			clusters, err := c.ListClusters(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("error listing clusters: %v", err)
+			}
+			if len(clusters.Items) != 1 {
+				t.Errorf("expected 1 cluster, got %d", len(clusters.Items))
+			}
+		})
+	}
+}
+
+func TestRESTClientsetSingleClusterPerNamespace(t *testing.T) {
+	ctx := context.TODO()
+
+	c := newTestRESTClientset(t, "k8s://management/kops-clusters")
+	if _, err := c.CreateCluster(ctx, &kops.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "a.example.com"}}); err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	if _, err := c.CreateCluster(ctx, &kops.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "b.example.com"}}); err == nil {
+		t.Errorf("expected error creating a second cluster in the namespace")
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kops/pkg/apis/kops"
+	kopsinternalversion "k8s.io/kops/pkg/client/clientset_generated/clientset/typed/kops/internalversion"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// ErrReadOnly is returned when writing to a state store opened in read-only mode.
+var ErrReadOnly = errors.New("the state store is read-only")
+
+// NewReadOnlyClientset returns a Clientset that reads from the provided Clientset, but refuses to write to it.
+func NewReadOnlyClientset(clientset Clientset) Clientset {
+	return &readOnlyClientset{Clientset: clientset}
+}
+
+type readOnlyClientset struct {
+	Clientset
+}
+
+var _ Clientset = &readOnlyClientset{}
+
+func (c *readOnlyClientset) CreateCluster(ctx context.Context, cluster *kops.Cluster) (*kops.Cluster, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *readOnlyClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus) (*kops.Cluster, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *readOnlyClientset) DeleteCluster(ctx context.Context, cluster *kops.Cluster) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyClientset) InstanceGroupsFor(cluster *kops.Cluster) kopsinternalversion.InstanceGroupInterface {
+	return &readOnlyInstanceGroups{InstanceGroupInterface: c.Clientset.InstanceGroupsFor(cluster)}
+}
+
+func (c *readOnlyClientset) AddonsFor(cluster *kops.Cluster) AddonsClient {
+	return &readOnlyAddons{AddonsClient: c.Clientset.AddonsFor(cluster)}
+}
+
+func (c *readOnlyClientset) SecretStore(cluster *kops.Cluster) (fi.SecretStore, error) {
+	secretStore, err := c.Clientset.SecretStore(cluster)
+	if err != nil {
+		return nil, err
+	}
+	readOnly := &readOnlySecretStore{SecretStore: secretStore}
+	// The path of VFS stores is used to build the cluster spec, so it must stay visible
+	if hasVFSPath, ok := secretStore.(fi.HasVFSPath); ok {
+		return &readOnlyVFSSecretStore{readOnlySecretStore: readOnly, HasVFSPath: hasVFSPath}, nil
+	}
+	return readOnly, nil
+}
+
+func (c *readOnlyClientset) KeyStore(cluster *kops.Cluster) (fi.CAStore, error) {
+	keyStore, err := c.Clientset.KeyStore(cluster)
+	if err != nil {
+		return nil, err
+	}
+	readOnly := &readOnlyKeyStore{CAStore: keyStore}
+	if hasVFSPath, ok := keyStore.(fi.HasVFSPath); ok {
+		return &readOnlyVFSKeyStore{readOnlyKeyStore: readOnly, HasVFSPath: hasVFSPath}, nil
+	}
+	return readOnly, nil
+}
+
+func (c *readOnlyClientset) SSHCredentialStore(cluster *kops.Cluster) (fi.SSHCredentialStore, error) {
+	sshCredentialStore, err := c.Clientset.SSHCredentialStore(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return &readOnlySSHCredentialStore{SSHCredentialStore: sshCredentialStore}, nil
+}
+
+type readOnlyInstanceGroups struct {
+	kopsinternalversion.InstanceGroupInterface
+}
+
+func (c *readOnlyInstanceGroups) Create(ctx context.Context, instanceGroup *kops.InstanceGroup, opts metav1.CreateOptions) (*kops.InstanceGroup, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *readOnlyInstanceGroups) Update(ctx context.Context, instanceGroup *kops.InstanceGroup, opts metav1.UpdateOptions) (*kops.InstanceGroup, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *readOnlyInstanceGroups) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyInstanceGroups) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	return ErrReadOnly
+}
+
+func (c *readOnlyInstanceGroups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*kops.InstanceGroup, error) {
+	return nil, ErrReadOnly
+}
+
+type readOnlyAddons struct {
+	AddonsClient
+}
+
+func (c *readOnlyAddons) Replace(objects kubemanifest.ObjectList) error {
+	return ErrReadOnly
+}
+
+type readOnlySecretStore struct {
+	fi.SecretStore
+}
+
+func (s *readOnlySecretStore) DeleteSecret(id string) error {
+	return ErrReadOnly
+}
+
+func (s *readOnlySecretStore) GetOrCreateSecret(ctx context.Context, id string, secret *fi.Secret) (*fi.Secret, bool, error) {
+	current, err := s.SecretStore.FindSecret(id)
+	if err != nil {
+		return nil, false, err
+	}
+	if current == nil {
+		return nil, false, ErrReadOnly
+	}
+	return current, false, nil
+}
+
+func (s *readOnlySecretStore) ReplaceSecret(id string, secret *fi.Secret) (*fi.Secret, error) {
+	return nil, ErrReadOnly
+}
+
+func (s *readOnlySecretStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
+	return ErrReadOnly
+}
+
+type readOnlyVFSSecretStore struct {
+	*readOnlySecretStore
+	fi.HasVFSPath
+}
+
+type readOnlyKeyStore struct {
+	fi.CAStore
+}
+
+func (s *readOnlyKeyStore) StoreKeyset(ctx context.Context, name string, keyset *fi.Keyset) error {
+	return ErrReadOnly
+}
+
+func (s *readOnlyKeyStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
+	return ErrReadOnly
+}
+
+type readOnlyVFSKeyStore struct {
+	*readOnlyKeyStore
+	fi.HasVFSPath
+}
+
+type readOnlySSHCredentialStore struct {
+	fi.SSHCredentialStore
+}
+
+func (s *readOnlySSHCredentialStore) DeleteSSHCredential() error {
+	return ErrReadOnly
+}
+
+func (s *readOnlySSHCredentialStore) AddSSHPublicKey(ctx context.Context, data []byte) error {
+	return ErrReadOnly
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simple_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestReadOnlyClientset(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://state")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := vfsclientset.NewVFSClientset(vfs.Context, basePath)
+
+	// Write the objects directly, so that they do not need to be valid clusters
+	for path, contents := range map[string]string{
+		"cluster.example.com/config":              "apiVersion: kops.k8s.io/v1alpha2\nkind: Cluster\nmetadata:\n  name: cluster.example.com\nspec:\n  configBase: memfs://state/cluster.example.com\n",
+		"cluster.example.com/instancegroup/nodes": "apiVersion: kops.k8s.io/v1alpha2\nkind: InstanceGroup\nmetadata:\n  name: nodes\n",
+	} {
+		if err := basePath.Join(path).WriteFile(ctx, strings.NewReader(contents), nil); err != nil {
+			t.Fatalf("error writing %s: %v", path, err)
+		}
+	}
+	cluster, err := clientset.GetCluster(ctx, "cluster.example.com")
+	if err != nil {
+		t.Fatalf("error reading cluster: %v", err)
+	}
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		t.Fatalf("error building secret store: %v", err)
+	}
+	if _, _, err := secretStore.GetOrCreateSecret(ctx, "existing", &fi.Secret{Data: []byte("secret")}); err != nil {
+		t.Fatalf("error creating secret: %v", err)
+	}
+
+	readOnly := simple.NewReadOnlyClientset(clientset)
+
+	cluster, err = readOnly.GetCluster(ctx, "cluster.example.com")
+	if err != nil || cluster == nil {
+		t.Fatalf("error reading cluster: %v", err)
+	}
+	igs, err := readOnly.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil || len(igs.Items) != 1 {
+		t.Fatalf("error listing instance groups: %v", err)
+	}
+
+	if _, err := readOnly.UpdateCluster(ctx, cluster, nil); !errors.Is(err, simple.ErrReadOnly) {
+		t.Errorf("UpdateCluster: expected ErrReadOnly, got %v", err)
+	}
+	if err := readOnly.DeleteCluster(ctx, cluster); !errors.Is(err, simple.ErrReadOnly) {
+		t.Errorf("DeleteCluster: expected ErrReadOnly, got %v", err)
+	}
+	ig := &kops.InstanceGroup{}
+	ig.ObjectMeta.Name = "bastions"
+	if _, err := readOnly.InstanceGroupsFor(cluster).Create(ctx, ig, metav1.CreateOptions{}); !errors.Is(err, simple.ErrReadOnly) {
+		t.Errorf("InstanceGroup Create: expected ErrReadOnly, got %v", err)
+	}
+	if err := readOnly.InstanceGroupsFor(cluster).Delete(ctx, "nodes", metav1.DeleteOptions{}); !errors.Is(err, simple.ErrReadOnly) {
+		t.Errorf("InstanceGroup Delete: expected ErrReadOnly, got %v", err)
+	}
+
+	keyStore, err := readOnly.KeyStore(cluster)
+	if err != nil {
+		t.Fatalf("error building keystore: %v", err)
+	}
+	if _, ok := keyStore.(fi.HasVFSPath); !ok {
+		t.Errorf("read-only keystore does not expose its VFS path")
+	}
+	if err := keyStore.StoreKeyset(ctx, "ca", &fi.Keyset{}); !errors.Is(err, simple.ErrReadOnly) {
+		t.Errorf("StoreKeyset: expected ErrReadOnly, got %v", err)
+	}
+
+	readOnlySecrets, err := readOnly.SecretStore(cluster)
+	if err != nil {
+		t.Fatalf("error building secret store: %v", err)
+	}
+	if secret, created, err := readOnlySecrets.GetOrCreateSecret(ctx, "existing", &fi.Secret{Data: []byte("other")}); err != nil || created || string(secret.Data) != "secret" {
+		t.Errorf("GetOrCreateSecret of an existing secret: got %v, %v, %v", secret, created, err)
+	}
+	if _, _, err := readOnlySecrets.GetOrCreateSecret(ctx, "missing", &fi.Secret{Data: []byte("secret")}); !errors.Is(err, simple.ErrReadOnly) {
+		t.Errorf("GetOrCreateSecret of a missing secret: expected ErrReadOnly, got %v", err)
+	}
+
+	sshCredentialStore, err := readOnly.SSHCredentialStore(cluster)
+	if err != nil {
+		t.Fatalf("error building SSH credential store: %v", err)
+	}
+	if err := sshCredentialStore.AddSSHPublicKey(ctx, []byte("ssh-rsa AAAA")); !errors.Is(err, simple.ErrReadOnly) {
+		t.Errorf("AddSSHPublicKey: expected ErrReadOnly, got %v", err)
+	}
+}
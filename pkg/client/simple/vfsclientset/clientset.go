@@ -154,7 +154,7 @@ func DeleteAllClusterState(ctx context.Context, basePath vfs.Path) error {
 		}
 
 		// "cluster.spec" was written by kOps 1.21 and earlier.
-		if relativePath == "config" || relativePath == "cluster.spec" || relativePath == "cluster-completed.spec" || relativePath == registry.PathKopsVersionUpdated {
+		if relativePath == "config" || relativePath == "cluster.spec" || relativePath == "cluster-completed.spec" || relativePath == registry.PathKopsVersionUpdated || relativePath == registry.PathIncrementalApply || relativePath == registry.PathFailedApply || relativePath == registry.PathRollingUpdateCheckpoint {
 			continue
 		}
 		if strings.HasPrefix(relativePath, "addons/") {
@@ -16,7 +16,11 @@ limitations under the License.
 
 package cloudinstances
 
-import v1 "k8s.io/api/core/v1"
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
 
 // CloudInstanceStatusDetached means the instance needs update and has been detached.
 const CloudInstanceStatusDetached = "Detached"
@@ -50,6 +54,20 @@ type CloudInstance struct {
 	PrivateIP string
 	// External IP is the public ip address of the instance.
 	ExternalIP string
+	// Zone is the zone the instance runs in, if known.
+	Zone string
 	// State indicates if the instance has joined the cluster and if it needs any updates.
 	State State
+	// Lifecycle is the purchasing option of the instance, such as "on-demand" or "spot".
+	Lifecycle string
+	// ImageID is the image the instance was launched from.
+	ImageID string
+	// ImageCreationTime is when the image the instance was launched from was created, if known.
+	ImageCreationTime *time.Time
+	// LaunchTime is when the instance was launched.
+	LaunchTime *time.Time
+	// LaunchConfiguration identifies the cloud-specific configuration the instance was launched from.
+	LaunchConfiguration string
+	// NeedUpdateReasons lists the fields of the launch configuration that changed since the instance was launched.
+	NeedUpdateReasons []string
 }
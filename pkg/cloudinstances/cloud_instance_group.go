@@ -35,6 +35,8 @@ type CloudInstanceGroup struct {
 	MinSize       int
 	TargetSize    int
 	MaxSize       int
+	// DesiredImageID is the image new instances of the group are launched from, if known.
+	DesiredImageID string
 
 	// Raw allows for the implementer to attach an object, for tracking additional state
 	Raw interface{}
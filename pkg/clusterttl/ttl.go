@@ -0,0 +1,175 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterttl decides when ephemeral clusters, which have a spec.ttl, expire,
+// and notifies webhooks before they are deleted.
+package clusterttl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+const (
+	// AnnotationNotified is set on a cluster to the time the notification about its expiration was sent, in RFC 3339 format.
+	AnnotationNotified = "kops.k8s.io/ttl-notified"
+
+	// DefaultNotifyBefore is how long before its deletion the notification about a cluster is sent, unless overridden.
+	DefaultNotifyBefore = time.Hour
+
+	// EventClusterExpiring is the event of the notification sent before an expired cluster is deleted.
+	EventClusterExpiring = "ClusterExpiring"
+)
+
+// Action is what needs to be done to a cluster with a TTL.
+type Action string
+
+const (
+	// ActionNone means that the cluster has not expired yet.
+	ActionNone Action = "None"
+	// ActionNotify means that the webhooks must be notified that the cluster is about to be deleted.
+	ActionNotify Action = "Notify"
+	// ActionDelete means that the cluster has expired and must be deleted.
+	ActionDelete Action = "Delete"
+)
+
+// Status is the expiration status of a cluster with a TTL.
+type Status struct {
+	// ExpirationTimestamp is the creation time of the cluster plus its TTL.
+	ExpirationTimestamp time.Time
+	// DeletionTimestamp is the time the cluster can be deleted. It is later than the expiration time
+	// when the notification was sent late, so that the webhooks always get the configured notice.
+	DeletionTimestamp time.Time
+	// Action is what needs to be done to the cluster now.
+	Action Action
+}
+
+// Evaluate returns the expiration status of the cluster at the given time, or nil if the cluster has no TTL.
+func Evaluate(cluster *kops.Cluster, now time.Time) (*Status, error) {
+	if cluster.Spec.TTL == nil {
+		return nil, nil
+	}
+	if cluster.CreationTimestamp.IsZero() {
+		return nil, fmt.Errorf("cluster %q has a ttl but no creation timestamp", cluster.Name)
+	}
+
+	status := &Status{
+		ExpirationTimestamp: cluster.CreationTimestamp.Add(cluster.Spec.TTL.Duration),
+		Action:              ActionNone,
+	}
+	status.DeletionTimestamp = status.ExpirationTimestamp
+
+	notification := cluster.Spec.TTLNotification
+	if notification == nil || len(notification.WebhookURLs) == 0 {
+		if !now.Before(status.DeletionTimestamp) {
+			status.Action = ActionDelete
+		}
+		return status, nil
+	}
+
+	before := DefaultNotifyBefore
+	if notification.Before != nil {
+		before = notification.Before.Duration
+	}
+
+	notifyAt := status.ExpirationTimestamp.Add(-before)
+
+	var notifiedAt time.Time
+	if notified, found := cluster.Annotations[AnnotationNotified]; found {
+		t, err := time.Parse(time.RFC3339, notified)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %q has an invalid %s annotation %q: %w", cluster.Name, AnnotationNotified, notified, err)
+		}
+		notifiedAt = t
+	}
+
+	// A notification sent before the notification time was about an earlier expiration,
+	// before the TTL was extended, so it is sent again.
+	if notifiedAt.IsZero() || notifiedAt.Before(notifyAt) {
+		if !now.Before(notifyAt) {
+			status.Action = ActionNotify
+		}
+		return status, nil
+	}
+
+	if deletion := notifiedAt.Add(before); deletion.After(status.DeletionTimestamp) {
+		status.DeletionTimestamp = deletion
+	}
+	if !now.Before(status.DeletionTimestamp) {
+		status.Action = ActionDelete
+	}
+	return status, nil
+}
+
+// Notification is the JSON body of the requests sent to the webhooks.
+type Notification struct {
+	// Event is the reason of the notification, which is always ClusterExpiring.
+	Event string `json:"event"`
+	// ClusterName is the name of the cluster.
+	ClusterName string `json:"clusterName"`
+	// ExpirationTimestamp is the creation time of the cluster plus its TTL.
+	ExpirationTimestamp time.Time `json:"expirationTimestamp"`
+	// DeletionTimestamp is the earliest time the cluster is deleted.
+	DeletionTimestamp time.Time `json:"deletionTimestamp"`
+}
+
+// Notify sends the notification about the expiration of the cluster to all of its webhooks,
+// returning the errors of the webhooks that failed.
+func Notify(ctx context.Context, httpClient *http.Client, cluster *kops.Cluster, status *Status) error {
+	body, err := json.Marshal(&Notification{
+		Event:               EventClusterExpiring,
+		ClusterName:         cluster.Name,
+		ExpirationTimestamp: status.ExpirationTimestamp.UTC(),
+		DeletionTimestamp:   status.DeletionTimestamp.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("error building notification: %w", err)
+	}
+
+	var errs []error
+	for _, webhookURL := range cluster.Spec.TTLNotification.WebhookURLs {
+		if err := post(ctx, httpClient, webhookURL, body); err != nil {
+			errs = append(errs, fmt.Errorf("error notifying %s: %w", webhookURL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func post(ctx context.Context, httpClient *http.Client, webhookURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
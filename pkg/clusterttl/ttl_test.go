@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterttl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestEvaluate(t *testing.T) {
+	created := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	expiration := created.Add(72 * time.Hour)
+
+	buildCluster := func(webhooks bool, notified string) *kops.Cluster {
+		cluster := &kops.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "ci.example.com",
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: kops.ClusterSpec{
+				TTL: &metav1.Duration{Duration: 72 * time.Hour},
+			},
+		}
+		if webhooks {
+			cluster.Spec.TTLNotification = &kops.TTLNotificationSpec{
+				WebhookURLs: []string{"https://hooks.example.com/ci"},
+				Before:      &metav1.Duration{Duration: 2 * time.Hour},
+			}
+		}
+		if notified != "" {
+			cluster.Annotations = map[string]string{AnnotationNotified: notified}
+		}
+		return cluster
+	}
+
+	grid := []struct {
+		name     string
+		cluster  *kops.Cluster
+		now      time.Time
+		action   Action
+		deletion time.Time
+	}{
+		{
+			name:     "not expired",
+			cluster:  buildCluster(false, ""),
+			now:      expiration.Add(-time.Minute),
+			action:   ActionNone,
+			deletion: expiration,
+		},
+		{
+			name:     "expired",
+			cluster:  buildCluster(false, ""),
+			now:      expiration,
+			action:   ActionDelete,
+			deletion: expiration,
+		},
+		{
+			name:     "before notification",
+			cluster:  buildCluster(true, ""),
+			now:      expiration.Add(-3 * time.Hour),
+			action:   ActionNone,
+			deletion: expiration,
+		},
+		{
+			name:     "notification due",
+			cluster:  buildCluster(true, ""),
+			now:      expiration.Add(-2 * time.Hour),
+			action:   ActionNotify,
+			deletion: expiration,
+		},
+		{
+			name:     "expired without notification",
+			cluster:  buildCluster(true, ""),
+			now:      expiration.Add(time.Hour),
+			action:   ActionNotify,
+			deletion: expiration,
+		},
+		{
+			name:     "notified on time",
+			cluster:  buildCluster(true, expiration.Add(-2*time.Hour).Format(time.RFC3339)),
+			now:      expiration,
+			action:   ActionDelete,
+			deletion: expiration,
+		},
+		{
+			name:     "notified late",
+			cluster:  buildCluster(true, expiration.Add(time.Hour).Format(time.RFC3339)),
+			now:      expiration.Add(2 * time.Hour),
+			action:   ActionNone,
+			deletion: expiration.Add(3 * time.Hour),
+		},
+		{
+			name:     "notified before the ttl was extended",
+			cluster:  buildCluster(true, expiration.Add(-24*time.Hour).Format(time.RFC3339)),
+			now:      expiration.Add(-time.Hour),
+			action:   ActionNotify,
+			deletion: expiration,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			status, err := Evaluate(g.cluster, g.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if status.Action != g.action {
+				t.Errorf("expected action %s, got %s", g.action, status.Action)
+			}
+			if !status.ExpirationTimestamp.Equal(expiration) {
+				t.Errorf("expected expiration %v, got %v", expiration, status.ExpirationTimestamp)
+			}
+			if !status.DeletionTimestamp.Equal(g.deletion) {
+				t.Errorf("expected deletion %v, got %v", g.deletion, status.DeletionTimestamp)
+			}
+		})
+	}
+
+	t.Run("no ttl", func(t *testing.T) {
+		status, err := Evaluate(&kops.Cluster{}, created)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status != nil {
+			t.Errorf("expected no status, got %v", status)
+		}
+	})
+
+	t.Run("invalid annotation", func(t *testing.T) {
+		if _, err := Evaluate(buildCluster(true, "yesterday"), created); err == nil {
+			t.Errorf("expected error")
+		}
+	})
+}
+
+func TestNotify(t *testing.T) {
+	var received []Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n Notification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			t.Errorf("error decoding notification: %v", err)
+		}
+		received = append(received, n)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci.example.com"},
+		Spec: kops.ClusterSpec{
+			TTLNotification: &kops.TTLNotificationSpec{
+				WebhookURLs: []string{server.URL + "/ok", server.URL + "/fail"},
+			},
+		},
+	}
+	expiration := time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC)
+	status := &Status{ExpirationTimestamp: expiration, DeletionTimestamp: expiration, Action: ActionNotify}
+
+	err := Notify(context.Background(), server.Client(), cluster, status)
+	if err == nil {
+		t.Errorf("expected the failing webhook to return an error")
+	}
+	if len(received) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(received))
+	}
+	expected := Notification{
+		Event:               EventClusterExpiring,
+		ClusterName:         "ci.example.com",
+		ExpirationTimestamp: expiration,
+		DeletionTimestamp:   expiration,
+	}
+	if received[0] != expected {
+		t.Errorf("expected notification %+v, got %+v", expected, received[0])
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commandutils
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// PermissionTier is the access to the state store and to the cloud provider that a command needs.
+type PermissionTier string
+
+const (
+	// PermissionTierView reads the state store.
+	PermissionTierView PermissionTier = "view"
+	// PermissionTierPlan also reads the cloud resources, to preview changes without making them.
+	PermissionTierPlan PermissionTier = "plan"
+	// PermissionTierApply writes the state store or changes the cloud resources.
+	PermissionTierApply PermissionTier = "apply"
+)
+
+// PermissionTiers lists the permission tiers, from the least to the most privileged.
+var PermissionTiers = []PermissionTier{PermissionTierView, PermissionTierPlan, PermissionTierApply}
+
+const (
+	permissionTierAnnotation = "kops.k8s.io/permission-tier"
+	applyFlagAnnotation      = "kops.k8s.io/apply-flag"
+)
+
+// ParsePermissionTier parses the name of a permission tier.
+func ParsePermissionTier(s string) (PermissionTier, error) {
+	for _, tier := range PermissionTiers {
+		if string(tier) == s {
+			return tier, nil
+		}
+	}
+	return "", fmt.Errorf("unknown permission tier %q; must be one of %v", s, PermissionTiers)
+}
+
+// Allows returns true if the tier includes the permissions of the other tier.
+func (t PermissionTier) Allows(other PermissionTier) bool {
+	return t.rank() >= other.rank()
+}
+
+func (t PermissionTier) rank() int {
+	for i, tier := range PermissionTiers {
+		if tier == t {
+			return i
+		}
+	}
+	return len(PermissionTiers)
+}
+
+// SetPermissionTier records the permission tier needed by a command and, unless they set their own, its subcommands.
+func SetPermissionTier(cmd *cobra.Command, tier PermissionTier) {
+	setAnnotation(cmd, permissionTierAnnotation, string(tier))
+}
+
+// SetApplyFlag records that a command of the apply tier only previews its changes, needing the plan tier,
+// unless the named boolean flag is set.
+func SetApplyFlag(cmd *cobra.Command, flag string) {
+	setAnnotation(cmd, applyFlagAnnotation, flag)
+}
+
+func setAnnotation(cmd *cobra.Command, key, value string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[key] = value
+}
+
+// RequiredPermissionTier returns the permission tier needed to run a command with its parsed flags.
+// Commands without a permission tier, on themselves or on a parent, need the apply tier.
+func RequiredPermissionTier(cmd *cobra.Command) PermissionTier {
+	tier := PermissionTierApply
+	for c := cmd; c != nil; c = c.Parent() {
+		if s, found := c.Annotations[permissionTierAnnotation]; found {
+			tier = PermissionTier(s)
+			break
+		}
+	}
+
+	if flag, found := cmd.Annotations[applyFlagAnnotation]; found && tier == PermissionTierApply {
+		if apply, err := cmd.Flags().GetBool(flag); err == nil && !apply {
+			tier = PermissionTierPlan
+		}
+	}
+
+	return tier
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/commands/helpers"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -44,6 +45,8 @@ func NewCmdHelpers(f *util.Factory, out io.Writer) *cobra.Command {
 		Hidden: true,
 	}
 
+	commandutils.SetPermissionTier(cmd, commandutils.PermissionTierView)
+
 	cmd.AddCommand(helpers.NewCmdHelperKubectlAuth(f, out))
 
 	return cmd
@@ -20,14 +20,19 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"math/big"
+	"net"
+	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -35,10 +40,17 @@ import (
 	"k8s.io/client-go/util/homedir"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/bootstrap"
 	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/kopscontrollerclient"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/pkg/rbac"
+	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce/gceidtoken"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
 
@@ -54,6 +66,9 @@ type HelperKubectlAuthOptions struct {
 
 	// APIVersion specifies the version of the client.authentication.k8s.io schema in use
 	APIVersion string
+
+	// CloudIdentity obtains the credential from kops-controller in exchange for the cloud identity of the caller
+	CloudIdentity bool
 }
 
 // InitDefaults populates the default values of options
@@ -83,6 +98,7 @@ func NewCmdHelperKubectlAuth(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.APIVersion, "api-version", options.APIVersion, "version of client.authentication.k8s.io schema in use")
 	cmd.Flags().StringVar(&options.ClusterName, "cluster", options.ClusterName, "cluster to target")
 	cmd.Flags().DurationVar(&options.Lifetime, "lifetime", options.Lifetime, "lifetime of the credential to issue")
+	cmd.Flags().BoolVar(&options.CloudIdentity, "cloud-identity", options.CloudIdentity, "obtain the credential from kops-controller in exchange for the cloud identity of the caller")
 
 	return cmd
 }
@@ -245,6 +261,10 @@ func buildCredentials(ctx context.Context, f *util.Factory, options *HelperKubec
 		return nil, fmt.Errorf("unable to get cluster keystore: %v", err)
 	}
 
+	if options.CloudIdentity {
+		return buildCloudIdentityCredentials(ctx, cluster, keyStore, options)
+	}
+
 	cn := "kubecfg"
 	user, err := user.Current()
 	if err != nil || user == nil {
@@ -283,3 +303,89 @@ func buildCredentials(ctx context.Context, f *util.Factory, options *HelperKubec
 
 	return status, nil
 }
+
+// buildCloudIdentityCredentials obtains a short-lived admin certificate from kops-controller in exchange
+// for the cloud identity of the caller, so that the caller doesn't need the private key of the CA.
+func buildCloudIdentityCredentials(ctx context.Context, cluster *kops.Cluster, keyStore fi.KeystoreReader, options *HelperKubectlAuthOptions) (*ExecCredentialStatus, error) {
+	clusterName := cluster.ObjectMeta.Name
+
+	var authenticator bootstrap.Authenticator
+	switch cluster.Spec.GetCloudProvider() {
+	case kops.CloudProviderAWS:
+		region, err := awsup.FindRegion(cluster)
+		if err != nil {
+			return nil, err
+		}
+		authenticator, err = awsup.NewAWSAuthenticator(ctx, region)
+		if err != nil {
+			return nil, err
+		}
+	case kops.CloudProviderGCE:
+		var err error
+		authenticator, err = gceidtoken.NewIDTokenAuthenticator(ctx, clusterName)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("cloud identity credentials are not supported for cloud provider %q", cluster.Spec.GetCloudProvider())
+	}
+
+	keySet, err := keyStore.FindKeyset(ctx, fi.CertificateIDCA)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching CA keypair: %v", err)
+	}
+	if keySet == nil {
+		return nil, fmt.Errorf("cannot find CA certificate")
+	}
+	caCerts, err := keySet.ToCertificateBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := pki.GeneratePrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("generating private key: %v", err)
+	}
+	pkData, err := x509.MarshalPKIXPublicKey(privateKey.Key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("marshalling public key: %v", err)
+	}
+
+	client := &kopscontrollerclient.Client{
+		Authenticator: authenticator,
+		CAs:           caCerts,
+		BaseURL: url.URL{
+			Scheme: "https",
+			Host:   net.JoinHostPort("kops-controller.internal."+clusterName, strconv.Itoa(wellknownports.KopsControllerPort)),
+			Path:   "/",
+		},
+	}
+	req := &nodeup.AdminCredentialsRequest{
+		APIVersion:      nodeup.AdminCredentialsAPIVersion,
+		ClusterName:     clusterName,
+		PublicKey:       string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: pkData})),
+		LifetimeSeconds: int64(options.Lifetime.Seconds()),
+	}
+	resp := &nodeup.AdminCredentialsResponse{}
+	if err := client.QueryAdminCredentials(ctx, req, resp); err != nil {
+		return nil, fmt.Errorf("requesting admin credentials from kops-controller: %w", err)
+	}
+
+	cert, err := pki.ParsePEMCertificate([]byte(resp.Certificate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing admin certificate: %w", err)
+	}
+
+	status := &ExecCredentialStatus{
+		ClientCertificateData: resp.Certificate,
+	}
+	status.ClientKeyData, err = privateKey.AsString()
+	if err != nil {
+		return nil, err
+	}
+
+	// Subtract a few minutes from the validity for clock skew
+	status.ExpirationTimestamp = cert.Certificate.NotAfter.Add(-5 * time.Minute)
+
+	return status, nil
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package controlplanerestart restarts the static pods of the control plane nodes in place,
+// so that file-based configuration and certificates are reloaded without replacing the instances.
+package controlplanerestart
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/nodelabels"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+const (
+	// ComponentEtcd restarts etcd-manager, and with it etcd, for every etcd cluster.
+	ComponentEtcd = "etcd"
+	// ComponentKubeAPIServer restarts kube-apiserver.
+	ComponentKubeAPIServer = "kube-apiserver"
+	// ComponentKubeControllerManager restarts kube-controller-manager.
+	ComponentKubeControllerManager = "kube-controller-manager"
+	// ComponentKubeScheduler restarts kube-scheduler.
+	ComponentKubeScheduler = "kube-scheduler"
+
+	// DefaultImage is the image of the pods that restart the static pods; it only needs a shell.
+	DefaultImage = "registry.k8s.io/e2e-test-images/busybox:1.36.1-1"
+
+	// configHashAnnotation is set by the kubelet on mirror pods to the UID of the static pod.
+	configHashAnnotation = "kubernetes.io/config.hash"
+
+	manifestsDir = "/etc/kubernetes/manifests"
+)
+
+// AllComponents are the components that can be restarted, in the order they are restarted on each node.
+var AllComponents = []string{
+	ComponentEtcd,
+	ComponentKubeAPIServer,
+	ComponentKubeControllerManager,
+	ComponentKubeScheduler,
+}
+
+// restartScript moves the manifest of a static pod out of the manifest directory, waits for the kubelet
+// to clean up the static pod, and moves the manifest back, so that the kubelet starts the static pod again.
+// The manifest is moved back when the script fails, and when a previous run was interrupted.
+const restartScript = `set -e
+manifest="` + manifestsDir + `/${MANIFEST}"
+stash="/etc/kubernetes/${MANIFEST}.kops-restart"
+if [ -f "${stash}" ] && [ ! -f "${manifest}" ]; then
+  echo "restoring ${manifest} from an interrupted restart"
+  mv "${stash}" "${manifest}"
+fi
+if [ ! -f "${manifest}" ]; then
+  echo "manifest ${manifest} not found"
+  exit 1
+fi
+mv "${manifest}" "${stash}"
+trap 'mv "${stash}" "${manifest}"' EXIT
+echo "waiting for the kubelet to stop pod ${POD_UID}"
+i=0
+while [ -d "/var/lib/kubelet/pods/${POD_UID}" ]; do
+  if [ "${i}" -ge "${STOP_TIMEOUT_SECONDS}" ]; then
+    echo "pod ${POD_UID} was not stopped within ${STOP_TIMEOUT_SECONDS} seconds"
+    exit 1
+  fi
+  sleep 1
+  i=$((i+1))
+done
+echo "pod ${POD_UID} stopped, restoring ${manifest}"
+`
+
+// StaticPodRestart describes a static pod of a control plane node that will be restarted.
+type StaticPodRestart struct {
+	// NodeName is the name of the node running the static pod.
+	NodeName string
+	// InstanceGroup is the name of the instance group of the node.
+	InstanceGroup string
+	// Component is the component that the static pod runs.
+	Component string
+	// PodName is the name of the mirror pod of the static pod.
+	PodName string
+	// Manifest is the file name of the manifest of the static pod on the node.
+	Manifest string
+
+	// uid is the UID of the static pod, which names its directory on the node
+	uid string
+	// mirrorPodUID is the UID of the mirror pod before the restart
+	mirrorPodUID string
+}
+
+// Restart restarts the static pods of the control plane nodes, one node at a time.
+// The static pods are restarted by a pod scheduled on the node, which moves the manifest
+// of each static pod out of the manifest directory until the kubelet has stopped it.
+type Restart struct {
+	K8sClient kubernetes.Interface
+	Cluster   *kops.Cluster
+	Out       io.Writer
+
+	// Components are the components to restart; all components are restarted if empty.
+	Components []string
+	// Image is the image of the pods that restart the static pods.
+	Image string
+	// Timeout is the maximum time to wait for a static pod to stop and to become ready again.
+	Timeout time.Duration
+	// PollInterval is the interval between checks of the state of the pods.
+	PollInterval time.Duration
+
+	// ValidateCluster waits for the cluster to become healthy once the static pods of a node
+	// of the given instance group have been restarted.
+	ValidateCluster func(instanceGroup string) error
+}
+
+// staticPod is a static pod of a component, as named in its manifest.
+type staticPod struct {
+	component string
+	name      string
+	// manifest returns the file name of the manifest on a node of the instance group
+	manifest func(instanceGroup string) string
+}
+
+func (r *Restart) staticPods() ([]staticPod, error) {
+	components := r.Components
+	if len(components) == 0 {
+		components = AllComponents
+	}
+	for _, component := range components {
+		if !slices.Contains(AllComponents, component) {
+			return nil, fmt.Errorf("unknown component %q, expected one of %v", component, AllComponents)
+		}
+	}
+
+	var pods []staticPod
+	for _, component := range AllComponents {
+		if !slices.Contains(components, component) {
+			continue
+		}
+		if component == ComponentEtcd {
+			for _, etcdCluster := range r.Cluster.Spec.EtcdClusters {
+				etcdClusterName := etcdCluster.Name
+				pods = append(pods, staticPod{
+					component: component,
+					name:      "etcd-manager-" + etcdClusterName,
+					manifest: func(instanceGroup string) string {
+						return "etcd-" + etcdClusterName + "-" + instanceGroup + ".manifest"
+					},
+				})
+			}
+			continue
+		}
+		manifest := component + ".manifest"
+		pods = append(pods, staticPod{
+			component: component,
+			name:      component,
+			manifest:  func(string) string { return manifest },
+		})
+	}
+	return pods, nil
+}
+
+// Plan finds the static pods to restart on each control plane node, without changing anything.
+func (r *Restart) Plan(ctx context.Context) ([]*StaticPodRestart, error) {
+	staticPods, err := r.staticPods()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := r.K8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: nodelabels.RoleLabelControlPlane20,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing control plane nodes: %w", err)
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool {
+		return nodes.Items[i].Name < nodes.Items[j].Name
+	})
+
+	var restarts []*StaticPodRestart
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		instanceGroup := node.Labels[kops.NodeLabelInstanceGroup]
+		if instanceGroup == "" {
+			return nil, fmt.Errorf("node %q does not have label %q", node.Name, kops.NodeLabelInstanceGroup)
+		}
+
+		for _, staticPod := range staticPods {
+			podName := staticPod.name + "-" + node.Name
+			pod, err := r.K8sClient.CoreV1().Pods(metav1.NamespaceSystem).Get(ctx, podName, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				klog.Warningf("static pod %q of %s was not found on node %q", podName, staticPod.component, node.Name)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error getting static pod %q: %w", podName, err)
+			}
+			uid := pod.Annotations[configHashAnnotation]
+			if uid == "" {
+				return nil, fmt.Errorf("pod %q is not a static pod, it does not have annotation %q", podName, configHashAnnotation)
+			}
+
+			restarts = append(restarts, &StaticPodRestart{
+				NodeName:      node.Name,
+				InstanceGroup: instanceGroup,
+				Component:     staticPod.component,
+				PodName:       podName,
+				Manifest:      staticPod.manifest(instanceGroup),
+				uid:           uid,
+				mirrorPodUID:  string(pod.UID),
+			})
+		}
+	}
+
+	return restarts, nil
+}
+
+// Run restarts the static pods of the plan, validating the cluster after the static pods of each node have been restarted.
+func (r *Restart) Run(ctx context.Context, plan []*StaticPodRestart) error {
+	for i, restart := range plan {
+		if err := r.restartStaticPod(ctx, restart); err != nil {
+			return err
+		}
+
+		lastOfNode := i == len(plan)-1 || plan[i+1].NodeName != restart.NodeName
+		if lastOfNode && r.ValidateCluster != nil {
+			if err := r.ValidateCluster(restart.InstanceGroup); err != nil {
+				return fmt.Errorf("error validating cluster after restarting the static pods of node %q: %w", restart.NodeName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Restart) restartStaticPod(ctx context.Context, restart *StaticPodRestart) error {
+	fmt.Fprintf(r.Out, "Restarting %s on node %q\n", restart.PodName, restart.NodeName)
+
+	pods := r.K8sClient.CoreV1().Pods(metav1.NamespaceSystem)
+	helper, err := pods.Create(ctx, r.buildHelperPod(restart), metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("error creating pod to restart %q: %w", restart.PodName, err)
+	}
+	defer func() {
+		if err := pods.Delete(context.WithoutCancel(ctx), helper.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			klog.Warningf("error deleting pod %q: %v", helper.Name, err)
+		}
+	}()
+
+	// The API may be unavailable while kube-apiserver restarts, so errors are retried until the timeout.
+	// The pod waits up to the timeout for the static pod to stop, so it is given twice as long to complete.
+	err = wait.PollUntilContextTimeout(ctx, r.PollInterval, 2*r.Timeout, false, func(ctx context.Context) (bool, error) {
+		pod, err := pods.Get(ctx, helper.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.V(2).Infof("error getting pod %q: %v", helper.Name, err)
+			return false, nil
+		}
+		switch pod.Status.Phase {
+		case corev1.PodSucceeded:
+			return true, nil
+		case corev1.PodFailed:
+			return false, fmt.Errorf("pod %q failed to restart %q: %s", helper.Name, restart.PodName, terminationMessage(pod))
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error restarting %q: %w", restart.PodName, err)
+	}
+
+	// The kubelet creates a new mirror pod once the static pod has started again
+	err = wait.PollUntilContextTimeout(ctx, r.PollInterval, r.Timeout, false, func(ctx context.Context) (bool, error) {
+		pod, err := pods.Get(ctx, restart.PodName, metav1.GetOptions{})
+		if err != nil {
+			klog.V(2).Infof("error getting pod %q: %v", restart.PodName, err)
+			return false, nil
+		}
+		return string(pod.UID) != restart.mirrorPodUID && isPodReady(pod), nil
+	})
+	if err != nil {
+		return fmt.Errorf("pod %q did not become ready after restarting: %w", restart.PodName, err)
+	}
+
+	fmt.Fprintf(r.Out, "Restarted %s on node %q\n", restart.PodName, restart.NodeName)
+	return nil
+}
+
+// buildHelperPod builds the pod that restarts the static pod on its node.
+func (r *Restart) buildHelperPod(restart *StaticPodRestart) *corev1.Pod {
+	hostPathDirectory := corev1.HostPathDirectory
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "kops-restart-",
+			Namespace:    metav1.NamespaceSystem,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "kops",
+				"app.kubernetes.io/name":       "kops-restart",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:                      restart.NodeName,
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			PriorityClassName:             "system-node-critical",
+			TerminationGracePeriodSeconds: fi.PtrTo(int64(0)),
+			Tolerations: []corev1.Toleration{
+				{Operator: corev1.TolerationOpExists},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:    "restart",
+					Image:   r.Image,
+					Command: []string{"/bin/sh", "-c", restartScript},
+					Env: []corev1.EnvVar{
+						{Name: "MANIFEST", Value: restart.Manifest},
+						{Name: "POD_UID", Value: restart.uid},
+						{Name: "STOP_TIMEOUT_SECONDS", Value: strconv.Itoa(int(r.Timeout.Seconds()))},
+					},
+					SecurityContext: &corev1.SecurityContext{
+						RunAsUser: fi.PtrTo(int64(0)),
+					},
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "kubernetes", MountPath: "/etc/kubernetes"},
+						{Name: "kubelet-pods", MountPath: "/var/lib/kubelet/pods", ReadOnly: true},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "kubernetes",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: "/etc/kubernetes", Type: &hostPathDirectory},
+					},
+				},
+				{
+					Name: "kubelet-pods",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/kubelet/pods", Type: &hostPathDirectory},
+					},
+				},
+			},
+		},
+	}
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func terminationMessage(pod *corev1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+			return status.State.Terminated.Message
+		}
+	}
+	return "no termination message"
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controlplanerestart
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func controlPlaneNode(name, instanceGroup string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"node-role.kubernetes.io/control-plane": "",
+				kops.NodeLabelInstanceGroup:             instanceGroup,
+			},
+		},
+	}
+}
+
+func mirrorPod(name, nodeName, uid string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-" + nodeName,
+			Namespace: metav1.NamespaceSystem,
+			UID:       types.UID(uid),
+			Annotations: map[string]string{
+				configHashAnnotation: "hash-" + name + "-" + nodeName,
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func testCluster() *kops.Cluster {
+	return &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			EtcdClusters: []kops.EtcdClusterSpec{
+				{Name: "main"},
+				{Name: "events"},
+			},
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	objects := []runtime.Object{
+		controlPlaneNode("cp-b", "control-plane-b"),
+		controlPlaneNode("cp-a", "control-plane-a"),
+		// Worker nodes are not restarted
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		mirrorPod("kube-apiserver", "node-a", "uid-kube-apiserver-node-a"),
+	}
+	for _, node := range []string{"cp-a", "cp-b"} {
+		for _, name := range []string{"etcd-manager-main", "etcd-manager-events", "kube-apiserver", "kube-controller-manager", "kube-scheduler"} {
+			// The scheduler is missing from the second node
+			if node == "cp-b" && name == "kube-scheduler" {
+				continue
+			}
+			objects = append(objects, mirrorPod(name, node, "uid-"+name+"-"+node))
+		}
+	}
+
+	grid := []struct {
+		name       string
+		components []string
+		expected   []string
+		error      string
+	}{
+		{
+			name: "all components",
+			expected: []string{
+				"cp-a control-plane-a etcd etcd-manager-main-cp-a etcd-main-control-plane-a.manifest",
+				"cp-a control-plane-a etcd etcd-manager-events-cp-a etcd-events-control-plane-a.manifest",
+				"cp-a control-plane-a kube-apiserver kube-apiserver-cp-a kube-apiserver.manifest",
+				"cp-a control-plane-a kube-controller-manager kube-controller-manager-cp-a kube-controller-manager.manifest",
+				"cp-a control-plane-a kube-scheduler kube-scheduler-cp-a kube-scheduler.manifest",
+				"cp-b control-plane-b etcd etcd-manager-main-cp-b etcd-main-control-plane-b.manifest",
+				"cp-b control-plane-b etcd etcd-manager-events-cp-b etcd-events-control-plane-b.manifest",
+				"cp-b control-plane-b kube-apiserver kube-apiserver-cp-b kube-apiserver.manifest",
+				"cp-b control-plane-b kube-controller-manager kube-controller-manager-cp-b kube-controller-manager.manifest",
+			},
+		},
+		{
+			name:       "selected components are restarted in order",
+			components: []string{"kube-scheduler", "kube-apiserver"},
+			expected: []string{
+				"cp-a control-plane-a kube-apiserver kube-apiserver-cp-a kube-apiserver.manifest",
+				"cp-a control-plane-a kube-scheduler kube-scheduler-cp-a kube-scheduler.manifest",
+				"cp-b control-plane-b kube-apiserver kube-apiserver-cp-b kube-apiserver.manifest",
+			},
+		},
+		{
+			name:       "unknown component",
+			components: []string{"kube-proxy"},
+			error:      `unknown component "kube-proxy"`,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			r := &Restart{
+				K8sClient:  fake.NewSimpleClientset(objects...),
+				Cluster:    testCluster(),
+				Components: g.components,
+			}
+			plan, err := r.Plan(context.Background())
+			if g.error != "" {
+				if err == nil || !strings.Contains(err.Error(), g.error) {
+					t.Fatalf("expected error containing %q, got %v", g.error, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var actual []string
+			for _, p := range plan {
+				actual = append(actual, strings.Join([]string{p.NodeName, p.InstanceGroup, p.Component, p.PodName, p.Manifest}, " "))
+			}
+			if !reflect.DeepEqual(actual, g.expected) {
+				t.Errorf("unexpected plan\nexpected: %q\n  actual: %q", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+
+	client := fake.NewSimpleClientset(
+		controlPlaneNode("cp-a", "control-plane-a"),
+		controlPlaneNode("cp-b", "control-plane-b"),
+		mirrorPod("kube-apiserver", "cp-a", "old-a"),
+		mirrorPod("kube-apiserver", "cp-b", "old-b"),
+	)
+
+	// The fake kubelet completes the pod that restarts a static pod, and replaces the mirror pod
+	var helpers []*corev1.Pod
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod).DeepCopy()
+		pod.Name = fmt.Sprintf("%s%d", pod.GenerateName, len(helpers))
+		pod.Status.Phase = corev1.PodSucceeded
+		helpers = append(helpers, pod)
+
+		restarted := mirrorPod("kube-apiserver", pod.Spec.NodeName, "new-"+pod.Spec.NodeName)
+		if err := client.Tracker().Update(corev1.SchemeGroupVersion.WithResource("pods"), restarted, metav1.NamespaceSystem); err != nil {
+			return true, nil, err
+		}
+		if err := client.Tracker().Add(pod); err != nil {
+			return true, nil, err
+		}
+		return true, pod, nil
+	})
+
+	var validated []string
+	r := &Restart{
+		K8sClient:    client,
+		Cluster:      testCluster(),
+		Out:          io.Discard,
+		Components:   []string{ComponentKubeAPIServer},
+		Image:        DefaultImage,
+		Timeout:      time.Second,
+		PollInterval: time.Millisecond,
+		ValidateCluster: func(instanceGroup string) error {
+			validated = append(validated, instanceGroup)
+			return nil
+		},
+	}
+
+	plan, err := r.Plan(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := r.Run(ctx, plan); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(helpers) != 2 {
+		t.Fatalf("expected 2 pods to restart static pods, got %d", len(helpers))
+	}
+	for i, node := range []string{"cp-a", "cp-b"} {
+		helper := helpers[i]
+		if helper.Spec.NodeName != node {
+			t.Errorf("expected pod %d on node %q, got %q", i, node, helper.Spec.NodeName)
+		}
+		env := make(map[string]string)
+		for _, e := range helper.Spec.Containers[0].Env {
+			env[e.Name] = e.Value
+		}
+		expectedEnv := map[string]string{
+			"MANIFEST":             "kube-apiserver.manifest",
+			"POD_UID":              "hash-kube-apiserver-" + node,
+			"STOP_TIMEOUT_SECONDS": "1",
+		}
+		if !reflect.DeepEqual(env, expectedEnv) {
+			t.Errorf("unexpected environment of pod %d\nexpected: %v\n  actual: %v", i, expectedEnv, env)
+		}
+
+		// The pods are deleted once the static pod has restarted
+		if _, err := client.CoreV1().Pods(metav1.NamespaceSystem).Get(ctx, helper.Name, metav1.GetOptions{}); err == nil {
+			t.Errorf("pod %q was not deleted", helper.Name)
+		}
+	}
+
+	if expected := []string{"control-plane-a", "control-plane-b"}; !reflect.DeepEqual(validated, expected) {
+		t.Errorf("expected cluster to be validated after %v, got %v", expected, validated)
+	}
+}
+
+func TestRunFailedRestart(t *testing.T) {
+	ctx := context.Background()
+
+	client := fake.NewSimpleClientset(
+		controlPlaneNode("cp-a", "control-plane-a"),
+		mirrorPod("kube-apiserver", "cp-a", "old-a"),
+	)
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod).DeepCopy()
+		pod.Name = pod.GenerateName + "0"
+		pod.Status.Phase = corev1.PodFailed
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{Message: "manifest not found"},
+				},
+			},
+		}
+		if err := client.Tracker().Add(pod); err != nil {
+			return true, nil, err
+		}
+		return true, pod, nil
+	})
+
+	r := &Restart{
+		K8sClient:    client,
+		Cluster:      testCluster(),
+		Out:          io.Discard,
+		Components:   []string{ComponentKubeAPIServer},
+		Image:        DefaultImage,
+		Timeout:      time.Second,
+		PollInterval: time.Millisecond,
+		ValidateCluster: func(instanceGroup string) error {
+			t.Errorf("cluster should not be validated after a failed restart")
+			return nil
+		},
+	}
+
+	plan, err := r.Plan(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = r.Run(ctx, plan)
+	if err == nil || !strings.Contains(err.Error(), "manifest not found") {
+		t.Fatalf("expected error containing the termination message, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+const (
+	// PlaceholderIP is the address of the DNS records created before the control plane is up, from TEST-NET-3.
+	// https://en.wikipedia.org/wiki/Reserved_IP_addresses
+	PlaceholderIP = "203.0.113.123"
+	// PlaceholderIPv6 is the address of the AAAA DNS records created before the control plane is up.
+	PlaceholderIPv6 = "fd00:dead:add::"
+)
+
+// IsPlaceholderIP returns true if the address is one of the placeholder addresses of the DNS records.
+func IsPlaceholderIP(ip string) bool {
+	return ip == PlaceholderIP || ip == PlaceholderIPv6
+}
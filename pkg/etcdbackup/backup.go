@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package etcdbackup lists the backups that etcd-manager takes of the etcd clusters, and copies them to other stores.
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// backupFile is the name of the snapshot of etcd in the directory of a backup.
+	backupFile = "etcd.backup.gz"
+	// metaFile is the name of the metadata of a backup, which etcd-manager writes once the snapshot is complete.
+	metaFile = "_etcd_backup.meta"
+)
+
+// BackupStore returns the path where etcd-manager writes the backups of the etcd cluster.
+func BackupStore(vfsContext *vfs.VFSContext, etcdCluster *kops.EtcdClusterSpec) (vfs.Path, error) {
+	if etcdCluster.Backups == nil || etcdCluster.Backups.BackupStore == "" {
+		return nil, fmt.Errorf("etcd cluster %q does not have a backup store", etcdCluster.Name)
+	}
+	p, err := vfsContext.BuildVfsPath(etcdCluster.Backups.BackupStore)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing backup store of etcd cluster %q: %w", etcdCluster.Name, err)
+	}
+	return p, nil
+}
+
+// ListBackups returns the names of the complete backups of the store, oldest first.
+// The names of the backups start with the time they were taken.
+func ListBackups(ctx context.Context, store vfs.Path) ([]string, error) {
+	files, err := store.ReadTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing backups in %s: %w", store, err)
+	}
+
+	var backups []string
+	for _, file := range files {
+		if file.Base() != metaFile {
+			continue
+		}
+		relativePath, err := vfs.RelativePath(store, file)
+		if err != nil {
+			return nil, err
+		}
+		// Backups are direct children of the store; the other directories hold the commands of etcd-manager
+		name := path.Dir(relativePath)
+		if name == "." || strings.Contains(name, "/") {
+			continue
+		}
+		backups = append(backups, name)
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// Copy copies the backup to the destination store, under the same name, so that etcd-manager can restore it
+// from there. The metadata is copied last, so that an interrupted copy is not listed as a backup.
+func Copy(ctx context.Context, store vfs.Path, backup string, destination vfs.Path) error {
+	for _, name := range []string{backupFile, metaFile} {
+		if err := copyFile(ctx, store.Join(backup, name), destination.Join(backup, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies a file through a temporary file, as the snapshots of etcd can be too big to be read in memory.
+func copyFile(ctx context.Context, source vfs.Path, destination vfs.Path) error {
+	tmp, err := os.CreateTemp("", "etcd-backup")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+	defer func() {
+		tmp.Close()
+		if err := os.Remove(tmp.Name()); err != nil {
+			klog.Warningf("error removing temporary file %q: %v", tmp.Name(), err)
+		}
+	}()
+
+	if _, err := source.WriteTo(tmp); err != nil {
+		return fmt.Errorf("error reading %s: %w", source, err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		return fmt.Errorf("error reading temporary file: %w", err)
+	}
+	if err := destination.WriteFile(ctx, tmp, nil); err != nil {
+		return fmt.Errorf("error writing %s: %w", destination, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func writeTestFile(t *testing.T, p vfs.Path, contents string) {
+	if err := p.WriteFile(context.TODO(), bytes.NewReader([]byte(contents)), nil); err != nil {
+		t.Fatalf("error writing %s: %v", p, err)
+	}
+}
+
+func TestListAndCopyBackups(t *testing.T) {
+	ctx := context.TODO()
+	vfsContext := vfs.NewTestingVFSContext()
+
+	store, err := vfsContext.BuildVfsPath("memfs://state/cluster.example.com/backups/etcd/main")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	for _, backup := range []string{"2024-01-02T10:00:00Z-000002", "2024-01-01T10:00:00Z-000001"} {
+		writeTestFile(t, store.Join(backup, backupFile), "snapshot of "+backup)
+		writeTestFile(t, store.Join(backup, metaFile), "{}")
+	}
+	// A backup being written, and the commands of etcd-manager, are not listed
+	writeTestFile(t, store.Join("2024-01-03T10:00:00Z-000003", backupFile), "incomplete")
+	writeTestFile(t, store.Join("control", "2024-01-01T09:00:00Z-000000", metaFile), "{}")
+
+	backups, err := ListBackups(ctx, store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"2024-01-01T10:00:00Z-000001", "2024-01-02T10:00:00Z-000002"}; !reflect.DeepEqual(backups, expected) {
+		t.Errorf("expected backups %v, got %v", expected, backups)
+	}
+
+	destination, err := vfsContext.BuildVfsPath("memfs://dr/cluster.example.com/main")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	if err := Copy(ctx, store, "2024-01-02T10:00:00Z-000002", destination); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	backups, err = ListBackups(ctx, destination)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"2024-01-02T10:00:00Z-000002"}; !reflect.DeepEqual(backups, expected) {
+		t.Errorf("expected copied backups %v, got %v", expected, backups)
+	}
+	data, err := destination.Join("2024-01-02T10:00:00Z-000002", backupFile).ReadFile(ctx)
+	if err != nil {
+		t.Fatalf("error reading copy: %v", err)
+	}
+	if string(data) != "snapshot of 2024-01-02T10:00:00Z-000002" {
+		t.Errorf("unexpected contents of the copy: %q", data)
+	}
+}
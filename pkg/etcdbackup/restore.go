@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// LatestBackup selects the most recent backup.
+	LatestBackup = "latest"
+
+	// controlDir is the directory of the backup store from which etcd-manager reads its commands.
+	controlDir = "control"
+	// clusterSpecFile is the expected spec of the etcd cluster, which kOps writes to the control directory.
+	clusterSpecFile = "etcd-cluster-spec"
+	// commandFile is the name of a command in its directory of the control directory.
+	commandFile = "_command.json"
+)
+
+// restoreCommand is the restore-backup command of etcd-manager, in the JSON encoding of its protobuf.
+type restoreCommand struct {
+	Timestamp     int64                 `json:"timestamp,string"`
+	RestoreBackup *restoreBackupCommand `json:"restoreBackup"`
+}
+
+type restoreBackupCommand struct {
+	ClusterSpec *clusterSpec `json:"clusterSpec"`
+	Backup      string       `json:"backup"`
+}
+
+// clusterSpec is the spec of the etcd cluster that etcd-manager creates from the backup.
+type clusterSpec struct {
+	MemberCount int32  `json:"memberCount,omitempty"`
+	EtcdVersion string `json:"etcdVersion,omitempty"`
+}
+
+// SelectBackup returns the backup with the name, or the latest backup taken at or before the time in RFC 3339 format,
+// or the latest backup. The backups must be ordered as returned by ListBackups.
+func SelectBackup(backups []string, selector string) (string, error) {
+	if len(backups) == 0 {
+		return "", fmt.Errorf("no backups found")
+	}
+	if selector == LatestBackup {
+		return backups[len(backups)-1], nil
+	}
+	for _, backup := range backups {
+		if backup == selector {
+			return backup, nil
+		}
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, selector)
+	if err != nil {
+		return "", fmt.Errorf("backup %q not found, and it is not a time in RFC 3339 format", selector)
+	}
+	for i := len(backups) - 1; i >= 0; i-- {
+		// The names of the backups are the time they were taken, followed by a sequence number
+		backup := backups[i]
+		taken, err := time.Parse(time.RFC3339, backup[:max(strings.LastIndex(backup, "-"), 0)])
+		if err != nil {
+			klog.V(2).Infof("ignoring backup %q with an unknown name format", backup)
+			continue
+		}
+		if !taken.After(timestamp) {
+			return backup, nil
+		}
+	}
+	return "", fmt.Errorf("no backup was taken at or before %s", selector)
+}
+
+// AddRestoreCommand adds the command that makes etcd-manager restore the backup when it starts, as
+// etcd-manager-ctl restore-backup does, and returns its path. etcd-manager removes the command once the
+// backup is restored, onto a new etcd cluster with the spec that kOps wrote to the backup store.
+func AddRestoreCommand(ctx context.Context, store vfs.Path, backup string, now time.Time) (vfs.Path, error) {
+	specPath := store.Join(controlDir, clusterSpecFile)
+	data, err := specPath.ReadFile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error reading etcd cluster spec %s: %w", specPath, err)
+	}
+	spec := &clusterSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("error parsing etcd cluster spec %s: %w", specPath, err)
+	}
+
+	command := &restoreCommand{
+		Timestamp: now.UnixNano(),
+		RestoreBackup: &restoreBackupCommand{
+			ClusterSpec: spec,
+			Backup:      backup,
+		},
+	}
+	data, err = json.Marshal(command)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding restore command: %w", err)
+	}
+
+	p := store.Join(controlDir, now.UTC().Format(time.RFC3339Nano), commandFile)
+	if err := p.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		return nil, fmt.Errorf("error writing restore command %s: %w", p, err)
+	}
+	return p, nil
+}
+
+// WaitForRestore waits until etcd-manager has removed the restore command, which it does once the backup is restored.
+func WaitForRestore(ctx context.Context, command vfs.Path, pollInterval, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		_, err := command.ReadFile(ctx)
+		if errors.Is(err, os.ErrNotExist) {
+			return true, nil
+		}
+		if err != nil {
+			klog.V(2).Infof("error reading restore command %s: %v", command, err)
+		}
+		return false, nil
+	})
+}
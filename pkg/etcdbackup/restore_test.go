@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestSelectBackup(t *testing.T) {
+	backups := []string{"2024-01-01T10:00:00Z-000001", "2024-01-02T10:00:00Z-000002", "2024-01-03T10:00:00Z-000003"}
+	grid := []struct {
+		Selector string
+		Expected string
+		Error    bool
+	}{
+		{Selector: "latest", Expected: "2024-01-03T10:00:00Z-000003"},
+		{Selector: "2024-01-02T10:00:00Z-000002", Expected: "2024-01-02T10:00:00Z-000002"},
+		{Selector: "2024-01-02T10:00:00Z", Expected: "2024-01-02T10:00:00Z-000002"},
+		{Selector: "2024-01-02T23:00:00+02:00", Expected: "2024-01-02T10:00:00Z-000002"},
+		{Selector: "2024-01-01T09:00:00Z", Error: true},
+		{Selector: "yesterday", Error: true},
+	}
+	for _, g := range grid {
+		actual, err := SelectBackup(backups, g.Selector)
+		if g.Error {
+			if err == nil {
+				t.Errorf("expected an error selecting %q, got %q", g.Selector, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error selecting %q: %v", g.Selector, err)
+		} else if actual != g.Expected {
+			t.Errorf("expected %q selecting %q, got %q", g.Expected, g.Selector, actual)
+		}
+	}
+
+	if _, err := SelectBackup(nil, "latest"); err == nil {
+		t.Errorf("expected an error selecting from no backups")
+	}
+}
+
+func TestAddRestoreCommand(t *testing.T) {
+	ctx := context.TODO()
+	vfsContext := vfs.NewTestingVFSContext()
+
+	store, err := vfsContext.BuildVfsPath("memfs://state/cluster.example.com/backups/etcd/main")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+
+	if _, err := AddRestoreCommand(ctx, store, "2024-01-02T10:00:00Z-000002", time.Now()); err == nil {
+		t.Errorf("expected an error without an etcd cluster spec")
+	}
+
+	writeTestFile(t, store.Join("control", "etcd-cluster-spec"), `{"memberCount": 3, "etcdVersion": "3.5.13"}`)
+	now := time.Date(2024, 1, 3, 10, 0, 0, 0, time.UTC)
+	command, err := AddRestoreCommand(ctx, store, "2024-01-02T10:00:00Z-000002", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "memfs://state/cluster.example.com/backups/etcd/main/control/2024-01-03T10:00:00Z/_command.json"; command.Path() != expected {
+		t.Errorf("expected command %s, got %s", expected, command.Path())
+	}
+	data, err := command.ReadFile(ctx)
+	if err != nil {
+		t.Fatalf("error reading command: %v", err)
+	}
+	expected := `{"timestamp":"1704276000000000000","restoreBackup":{"clusterSpec":{"memberCount":3,"etcdVersion":"3.5.13"},"backup":"2024-01-02T10:00:00Z-000002"}}`
+	if string(data) != expected {
+		t.Errorf("expected command %s, got %s", expected, data)
+	}
+
+	// The command is not a backup
+	backups, err := ListBackups(ctx, store)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(backups) != 0 {
+		t.Errorf("expected no backups, got %v", backups)
+	}
+
+	if err := command.Remove(ctx); err != nil {
+		t.Fatalf("error removing command: %v", err)
+	}
+	if err := WaitForRestore(ctx, command, time.Millisecond, time.Second); err != nil {
+		t.Errorf("unexpected error waiting for the restore: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdbackup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/controlplanerestart"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// Trigger makes etcd-manager take a backup of an etcd cluster on demand.
+// etcd-manager has no API to request a backup, but its leader takes one as soon as it is elected,
+// so the etcd-manager pods of the etcd cluster are restarted one at a time until a new backup appears.
+type Trigger struct {
+	// Restart restarts the static pods of etcd-manager; its components must include etcd.
+	Restart *controlplanerestart.Restart
+	// Timeout is the maximum time to wait for a backup after restarting each pod.
+	Timeout time.Duration
+	// PollInterval is the interval between the listings of the backup store.
+	PollInterval time.Duration
+}
+
+// Plan returns the etcd-manager pods of the etcd cluster, in the order they are restarted.
+func (t *Trigger) Plan(ctx context.Context, etcdClusterName string) ([]*controlplanerestart.StaticPodRestart, error) {
+	return PlanRestarts(ctx, t.Restart, etcdClusterName)
+}
+
+// PlanRestarts returns the restarts of the etcd-manager pods of the etcd cluster, from the plan of the restart.
+func PlanRestarts(ctx context.Context, restart *controlplanerestart.Restart, etcdClusterName string) ([]*controlplanerestart.StaticPodRestart, error) {
+	plan, err := restart.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var restarts []*controlplanerestart.StaticPodRestart
+	prefix := "etcd-manager-" + etcdClusterName + "-"
+	for _, r := range plan {
+		if r.Component == controlplanerestart.ComponentEtcd && strings.HasPrefix(r.PodName, prefix) {
+			restarts = append(restarts, r)
+		}
+	}
+	return restarts, nil
+}
+
+// Run restarts the pods of the plan until etcd-manager writes a new backup to the store, and returns its name.
+func (t *Trigger) Run(ctx context.Context, store vfs.Path, plan []*controlplanerestart.StaticPodRestart) (string, error) {
+	before, err := ListBackups(ctx, store)
+	if err != nil {
+		return "", err
+	}
+	existing := sets.New(before...)
+
+	for _, restart := range plan {
+		if err := t.Restart.Run(ctx, []*controlplanerestart.StaticPodRestart{restart}); err != nil {
+			return "", err
+		}
+
+		// Only the restart of the leader causes a backup, so the wait moves on to the next pod after the timeout
+		var backup string
+		err := wait.PollUntilContextTimeout(ctx, t.PollInterval, t.Timeout, true, func(ctx context.Context) (bool, error) {
+			backups, err := ListBackups(ctx, store)
+			if err != nil {
+				klog.V(2).Infof("error listing backups: %v", err)
+				return false, nil
+			}
+			for i := len(backups) - 1; i >= 0; i-- {
+				if !existing.Has(backups[i]) {
+					backup = backups[i]
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		if err == nil {
+			return backup, nil
+		}
+		if !wait.Interrupted(err) {
+			return "", err
+		}
+		klog.Infof("No backup was taken within %v of restarting %s", t.Timeout, restart.PodName)
+	}
+
+	return "", fmt.Errorf("no backup was taken after restarting %d etcd-manager pods", len(plan))
+}
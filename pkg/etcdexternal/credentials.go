@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package etcdexternal holds the client credentials of the etcd clusters that are managed outside of kOps.
+package etcdexternal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/pki"
+)
+
+// DefaultClientSecret is the name of the secret holding the client credentials, if the etcd cluster does not name one.
+const DefaultClientSecret = "etcd-client"
+
+// ClientSecret returns the name of the secret of the secret store holding the client credentials of the external etcd cluster.
+func ClientSecret(etcdCluster *kops.EtcdClusterSpec) string {
+	if etcdCluster.External == nil || etcdCluster.External.ClientSecret == "" {
+		return DefaultClientSecret
+	}
+	return etcdCluster.External.ClientSecret
+}
+
+// ClientCredentials are the PEM-encoded TLS credentials that the API server uses to connect to an external etcd cluster.
+type ClientCredentials struct {
+	// CA is the certificate of the CA that signed the certificates of the etcd members.
+	CA string `json:"ca"`
+	// Certificate is the client certificate.
+	Certificate string `json:"certificate"`
+	// Key is the private key of the client certificate.
+	Key string `json:"key"`
+}
+
+// Validate returns an error if the credentials are not valid PEM-encoded certificates and key.
+func (c *ClientCredentials) Validate() error {
+	if _, err := pki.ParsePEMCertificate([]byte(c.CA)); err != nil {
+		return fmt.Errorf("error parsing the CA certificate: %w", err)
+	}
+	if _, err := pki.ParsePEMCertificate([]byte(c.Certificate)); err != nil {
+		return fmt.Errorf("error parsing the client certificate: %w", err)
+	}
+	if _, err := pki.ParsePEMPrivateKey([]byte(c.Key)); err != nil {
+		return fmt.Errorf("error parsing the client key: %w", err)
+	}
+	return nil
+}
+
+// Encode returns the data of the secret holding the credentials.
+func (c *ClientCredentials) Encode() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// ParseClientCredentials parses and validates the data of the secret holding the credentials.
+func ParseClientCredentials(data []byte) (*ClientCredentials, error) {
+	credentials := &ClientCredentials{}
+	if err := json.Unmarshal(data, credentials); err != nil {
+		return nil, fmt.Errorf("error parsing the etcd client credentials: %w", err)
+	}
+	if err := credentials.Validate(); err != nil {
+		return nil, err
+	}
+	return credentials, nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdexternal
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/pki"
+)
+
+func TestClientSecret(t *testing.T) {
+	grid := []struct {
+		External *kops.EtcdExternalSpec
+		Expected string
+	}{
+		{External: nil, Expected: "etcd-client"},
+		{External: &kops.EtcdExternalSpec{}, Expected: "etcd-client"},
+		{External: &kops.EtcdExternalSpec{ClientSecret: "etcd-main"}, Expected: "etcd-main"},
+	}
+	for _, g := range grid {
+		if actual := ClientSecret(&kops.EtcdClusterSpec{Name: "main", External: g.External}); actual != g.Expected {
+			t.Errorf("expected %q, got %q", g.Expected, actual)
+		}
+	}
+}
+
+func TestParseClientCredentials(t *testing.T) {
+	certificate, key, _, err := pki.IssueCert(context.TODO(), &pki.IssueCertRequest{
+		Type:    "ca",
+		Subject: pkix.Name{CommonName: "etcd-ca"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error creating certificate: %v", err)
+	}
+	certificatePEM, err := certificate.AsString()
+	if err != nil {
+		t.Fatalf("error encoding certificate: %v", err)
+	}
+	keyPEM, err := key.AsString()
+	if err != nil {
+		t.Fatalf("error encoding key: %v", err)
+	}
+
+	credentials := &ClientCredentials{CA: certificatePEM, Certificate: certificatePEM, Key: keyPEM}
+	data, err := credentials.Encode()
+	if err != nil {
+		t.Fatalf("error encoding credentials: %v", err)
+	}
+	parsed, err := ParseClientCredentials(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *parsed != *credentials {
+		t.Errorf("expected %+v, got %+v", credentials, parsed)
+	}
+
+	credentials.Key = "not a key"
+	data, err = credentials.Encode()
+	if err != nil {
+		t.Fatalf("error encoding credentials: %v", err)
+	}
+	if _, err := ParseClientCredentials(data); err == nil || !strings.Contains(err.Error(), "error parsing the client key") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := ParseClientCredentials([]byte("{")); err == nil {
+		t.Errorf("expected an error parsing invalid JSON")
+	}
+}
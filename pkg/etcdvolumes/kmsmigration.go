@@ -0,0 +1,394 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdvolumes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+const (
+	// TagReplacedBy is set on an etcd volume that was replaced by a copy encrypted with another KMS key.
+	// Its value is the ID of the replacement volume, or "pending" while the copy is being made.
+	TagReplacedBy = "kops.k8s.io/etcd-volume-replaced-by"
+
+	replacedByPending = "pending"
+	replacedSuffix    = "-replaced"
+)
+
+// VolumeMigration describes an etcd volume that is not encrypted with the KMS key from the cluster spec.
+type VolumeMigration struct {
+	// VolumeID is the ID of the existing volume.
+	VolumeID string
+	// Name is the value of the Name tag of the volume.
+	Name string
+	// EtcdCluster is the name of the etcd cluster the volume belongs to.
+	EtcdCluster string
+	// Member is the name of the etcd member the volume belongs to.
+	Member string
+	// InstanceID is the ID of the instance the volume is attached to, if any.
+	InstanceID string
+	// CurrentKMSKeyID is the KMS key the volume is encrypted with; empty if the volume is not encrypted.
+	CurrentKMSKeyID string
+	// TargetKMSKeyID is the KMS key from the cluster spec.
+	TargetKMSKeyID string
+
+	volume ec2types.Volume
+}
+
+// KMSKeyMigration re-encrypts the etcd volumes of a cluster with the KMS keys from the cluster spec.
+// EBS volumes cannot be re-encrypted in place, so each volume is hidden from etcd-manager,
+// detached by replacing the control plane instance, snapshotted and copied to a new volume
+// encrypted with the new key, one instance at a time.
+type KMSKeyMigration struct {
+	Cloud   awsup.AWSCloud
+	Cluster *kops.Cluster
+	Out     io.Writer
+
+	// TerminateInstance drains and terminates the control plane instance with the given ID,
+	// without waiting for its replacement to become healthy.
+	TerminateInstance func(instanceID string) error
+	// ValidateCluster waits for the cluster to become healthy once the replacement
+	// of the instance with the given ID has mounted the new volumes.
+	ValidateCluster func(instanceID string) error
+
+	// DeleteOldVolumes deletes the replaced volumes once the cluster has validated;
+	// otherwise they are kept, renamed and tagged with TagReplacedBy.
+	DeleteOldVolumes bool
+	// PollInterval is the interval between checks of the state of volumes and snapshots.
+	PollInterval time.Duration
+}
+
+// Plan finds the etcd volumes whose KMS key does not match the cluster spec, without changing anything.
+func (m *KMSKeyMigration) Plan(ctx context.Context) ([]*VolumeMigration, error) {
+	clusterName := m.Cluster.ObjectMeta.Name
+
+	var migrations []*VolumeMigration
+	for _, etcdCluster := range m.Cluster.Spec.EtcdClusters {
+		members := make(map[string]kops.EtcdMemberSpec)
+		for _, member := range etcdCluster.Members {
+			members[member.Name] = member
+		}
+
+		etcdTag := awsup.TagNameEtcdClusterPrefix + etcdCluster.Name
+		request := &ec2.DescribeVolumesInput{
+			Filters: []ec2types.Filter{
+				awsup.NewEC2Filter("tag:kubernetes.io/cluster/"+clusterName, "owned"),
+				{Name: aws.String("tag-key"), Values: []string{etcdTag}},
+			},
+		}
+		paginator := ec2.NewDescribeVolumesPaginator(m.Cloud.EC2(), request)
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error listing volumes of etcd cluster %q: %w", etcdCluster.Name, err)
+			}
+			for _, volume := range page.Volumes {
+				tags := tagsToMap(volume.Tags)
+				memberName, _, _ := strings.Cut(tags[etcdTag], "/")
+				member, found := members[memberName]
+				if !found {
+					klog.Warningf("ignoring volume %q of unknown etcd member %q", aws.ToString(volume.VolumeId), memberName)
+					continue
+				}
+
+				target := fi.ValueOf(member.KmsKeyID)
+				if target == "" || !fi.ValueOf(member.EncryptedVolume) {
+					continue
+				}
+				current := ""
+				if aws.ToBool(volume.Encrypted) {
+					current = aws.ToString(volume.KmsKeyId)
+				}
+				matches, err := kmsKeyMatches(current, target)
+				if err != nil {
+					return nil, fmt.Errorf("etcd member %q of cluster %q: %w", member.Name, etcdCluster.Name, err)
+				}
+				if matches {
+					continue
+				}
+
+				migration := &VolumeMigration{
+					VolumeID:        aws.ToString(volume.VolumeId),
+					Name:            tags["Name"],
+					EtcdCluster:     etcdCluster.Name,
+					Member:          member.Name,
+					CurrentKMSKeyID: current,
+					TargetKMSKeyID:  target,
+					volume:          volume,
+				}
+				for _, attachment := range volume.Attachments {
+					migration.InstanceID = aws.ToString(attachment.InstanceId)
+				}
+				migrations = append(migrations, migration)
+			}
+		}
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		if migrations[i].InstanceID != migrations[j].InstanceID {
+			return migrations[i].InstanceID < migrations[j].InstanceID
+		}
+		return migrations[i].VolumeID < migrations[j].VolumeID
+	})
+
+	return migrations, nil
+}
+
+// Run migrates the volumes returned by Plan, replacing one control plane instance at a time.
+func (m *KMSKeyMigration) Run(ctx context.Context, migrations []*VolumeMigration) error {
+	var instanceIDs []string
+	byInstance := make(map[string][]*VolumeMigration)
+	for _, migration := range migrations {
+		if _, found := byInstance[migration.InstanceID]; !found {
+			instanceIDs = append(instanceIDs, migration.InstanceID)
+		}
+		byInstance[migration.InstanceID] = append(byInstance[migration.InstanceID], migration)
+	}
+
+	for _, instanceID := range instanceIDs {
+		volumes := byInstance[instanceID]
+
+		// Hide the volumes from etcd-manager first, so that the replacement instance cannot mount them.
+		for _, migration := range volumes {
+			if err := m.hideVolume(ctx, migration); err != nil {
+				return err
+			}
+		}
+
+		if instanceID != "" {
+			fmt.Fprintf(m.Out, "Replacing instance %s to detach its etcd volumes\n", instanceID)
+			if err := m.TerminateInstance(instanceID); err != nil {
+				return fmt.Errorf("error terminating instance %q: %w", instanceID, err)
+			}
+		}
+
+		for _, migration := range volumes {
+			if err := m.copyVolume(ctx, migration); err != nil {
+				return err
+			}
+		}
+
+		if instanceID != "" {
+			if err := m.ValidateCluster(instanceID); err != nil {
+				return err
+			}
+		}
+
+		if m.DeleteOldVolumes {
+			for _, migration := range volumes {
+				fmt.Fprintf(m.Out, "Deleting volume %s\n", migration.VolumeID)
+				if _, err := m.Cloud.EC2().DeleteVolume(ctx, &ec2.DeleteVolumeInput{VolumeId: aws.String(migration.VolumeID)}); err != nil {
+					return fmt.Errorf("error deleting volume %q: %w", migration.VolumeID, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// hideVolume removes the tags etcd-manager uses to find the volume, and renames it so kops no longer manages it.
+func (m *KMSKeyMigration) hideVolume(ctx context.Context, migration *VolumeMigration) error {
+	var remove []ec2types.Tag
+	for _, tag := range migration.volume.Tags {
+		key := aws.ToString(tag.Key)
+		if strings.HasPrefix(key, awsup.TagNameEtcdClusterPrefix) || strings.HasPrefix(key, awsup.TagNameRolePrefix) {
+			remove = append(remove, ec2types.Tag{Key: tag.Key})
+		}
+	}
+	if _, err := m.Cloud.EC2().DeleteTags(ctx, &ec2.DeleteTagsInput{
+		Resources: []string{migration.VolumeID},
+		Tags:      remove,
+	}); err != nil {
+		return fmt.Errorf("error removing etcd tags from volume %q: %w", migration.VolumeID, err)
+	}
+
+	if _, err := m.Cloud.EC2().CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: []string{migration.VolumeID},
+		Tags: []ec2types.Tag{
+			{Key: aws.String("Name"), Value: aws.String(migration.Name + replacedSuffix)},
+			{Key: aws.String(TagReplacedBy), Value: aws.String(replacedByPending)},
+		},
+	}); err != nil {
+		return fmt.Errorf("error tagging volume %q: %w", migration.VolumeID, err)
+	}
+
+	return nil
+}
+
+// copyVolume copies a detached volume to a new volume encrypted with the target key, carrying over its tags.
+func (m *KMSKeyMigration) copyVolume(ctx context.Context, migration *VolumeMigration) error {
+	ec2Client := m.Cloud.EC2()
+
+	fmt.Fprintf(m.Out, "Waiting for volume %s to be detached\n", migration.VolumeID)
+	if err := m.waitForVolume(ctx, migration.VolumeID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(m.Out, "Creating snapshot of volume %s\n", migration.VolumeID)
+	snapshot, err := ec2Client.CreateSnapshot(ctx, &ec2.CreateSnapshotInput{
+		VolumeId:    aws.String(migration.VolumeID),
+		Description: aws.String(fmt.Sprintf("Copy of etcd volume %s for KMS key migration", migration.Name)),
+		TagSpecifications: []ec2types.TagSpecification{
+			{
+				ResourceType: ec2types.ResourceTypeSnapshot,
+				Tags: []ec2types.Tag{
+					{Key: aws.String("kubernetes.io/cluster/" + m.Cluster.ObjectMeta.Name), Value: aws.String("owned")},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating snapshot of volume %q: %w", migration.VolumeID, err)
+	}
+	snapshotID := aws.ToString(snapshot.SnapshotId)
+	if err := m.waitForSnapshot(ctx, snapshotID); err != nil {
+		return err
+	}
+
+	var tags []ec2types.Tag
+	for _, tag := range migration.volume.Tags {
+		if strings.HasPrefix(aws.ToString(tag.Key), "aws:") {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	volume := migration.volume
+	request := &ec2.CreateVolumeInput{
+		AvailabilityZone: volume.AvailabilityZone,
+		SnapshotId:       aws.String(snapshotID),
+		Size:             volume.Size,
+		VolumeType:       volume.VolumeType,
+		Encrypted:        aws.Bool(true),
+		KmsKeyId:         aws.String(migration.TargetKMSKeyID),
+		TagSpecifications: []ec2types.TagSpecification{
+			{ResourceType: ec2types.ResourceTypeVolume, Tags: tags},
+		},
+	}
+	switch volume.VolumeType {
+	case ec2types.VolumeTypeGp3:
+		request.Throughput = volume.Throughput
+		fallthrough
+	case ec2types.VolumeTypeIo1, ec2types.VolumeTypeIo2:
+		request.Iops = volume.Iops
+	}
+	created, err := ec2Client.CreateVolume(ctx, request)
+	if err != nil {
+		return fmt.Errorf("error creating copy of volume %q: %w", migration.VolumeID, err)
+	}
+	newVolumeID := aws.ToString(created.VolumeId)
+	fmt.Fprintf(m.Out, "Created volume %s from volume %s, encrypted with %s\n", newVolumeID, migration.VolumeID, migration.TargetKMSKeyID)
+	if err := m.waitForVolume(ctx, newVolumeID); err != nil {
+		return err
+	}
+
+	if _, err := ec2Client.CreateTags(ctx, &ec2.CreateTagsInput{
+		Resources: []string{migration.VolumeID},
+		Tags:      []ec2types.Tag{{Key: aws.String(TagReplacedBy), Value: aws.String(newVolumeID)}},
+	}); err != nil {
+		return fmt.Errorf("error tagging volume %q: %w", migration.VolumeID, err)
+	}
+
+	if _, err := ec2Client.DeleteSnapshot(ctx, &ec2.DeleteSnapshotInput{SnapshotId: aws.String(snapshotID)}); err != nil {
+		klog.Warningf("error deleting snapshot %q: %v", snapshotID, err)
+	}
+
+	return nil
+}
+
+// waitForVolume waits for a volume to be available, which it is once created and detached.
+func (m *KMSKeyMigration) waitForVolume(ctx context.Context, volumeID string) error {
+	err := wait.PollUntilContextCancel(ctx, m.PollInterval, true, func(ctx context.Context) (bool, error) {
+		response, err := m.Cloud.EC2().DescribeVolumes(ctx, &ec2.DescribeVolumesInput{VolumeIds: []string{volumeID}})
+		if err != nil {
+			return false, err
+		}
+		for _, volume := range response.Volumes {
+			switch volume.State {
+			case ec2types.VolumeStateAvailable:
+				return true, nil
+			case ec2types.VolumeStateError, ec2types.VolumeStateDeleting, ec2types.VolumeStateDeleted:
+				return false, fmt.Errorf("volume is in state %q", volume.State)
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for volume %q to be available: %w", volumeID, err)
+	}
+	return nil
+}
+
+// waitForSnapshot waits for a snapshot to be completed.
+func (m *KMSKeyMigration) waitForSnapshot(ctx context.Context, snapshotID string) error {
+	err := wait.PollUntilContextCancel(ctx, m.PollInterval, true, func(ctx context.Context) (bool, error) {
+		response, err := m.Cloud.EC2().DescribeSnapshots(ctx, &ec2.DescribeSnapshotsInput{SnapshotIds: []string{snapshotID}})
+		if err != nil {
+			return false, err
+		}
+		for _, snapshot := range response.Snapshots {
+			switch snapshot.State {
+			case ec2types.SnapshotStateCompleted:
+				return true, nil
+			case ec2types.SnapshotStateError:
+				return false, fmt.Errorf("snapshot failed: %s", aws.ToString(snapshot.StateMessage))
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for snapshot %q to complete: %w", snapshotID, err)
+	}
+	return nil
+}
+
+// kmsKeyMatches returns true if the key ARN of a volume is the key from the cluster spec,
+// which may be a key ID or a key ARN.
+func kmsKeyMatches(current, target string) (bool, error) {
+	if strings.HasPrefix(target, "alias/") || strings.Contains(target, ":alias/") {
+		return false, fmt.Errorf("kmsKeyID %q is an alias, which cannot be compared with the key of existing volumes; use the key ID or ARN", target)
+	}
+	if current == "" {
+		return false, nil
+	}
+	if strings.HasPrefix(target, "arn:") {
+		return current == target, nil
+	}
+	return current == target || strings.HasSuffix(current, ":key/"+target), nil
+}
+
+func tagsToMap(tags []ec2types.Tag) map[string]string {
+	m := make(map[string]string)
+	for _, tag := range tags {
+		m[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	return m
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package etcdvolumes
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+const (
+	oldKey = "arn:aws-test:kms:us-test-1:123456789012:key/old"
+	newKey = "arn:aws-test:kms:us-test-1:123456789012:key/new"
+)
+
+func TestKMSKeyMatches(t *testing.T) {
+	grid := []struct {
+		current string
+		target  string
+		matches bool
+		err     bool
+	}{
+		{current: oldKey, target: oldKey, matches: true},
+		{current: oldKey, target: "old", matches: true},
+		{current: oldKey, target: newKey},
+		{current: oldKey, target: "new"},
+		{current: "", target: newKey},
+		{current: oldKey, target: "alias/etcd", err: true},
+		{current: oldKey, target: "arn:aws-test:kms:us-test-1:123456789012:alias/etcd", err: true},
+	}
+	for _, g := range grid {
+		matches, err := kmsKeyMatches(g.current, g.target)
+		if (err != nil) != g.err {
+			t.Errorf("kmsKeyMatches(%q, %q) returned error %v", g.current, g.target, err)
+		}
+		if matches != g.matches {
+			t.Errorf("kmsKeyMatches(%q, %q) = %v, expected %v", g.current, g.target, matches, g.matches)
+		}
+	}
+}
+
+func TestKMSKeyMigration(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-test-1", "abc")
+	ec2Client := &mockec2.MockEC2{}
+	cloud.MockEC2 = ec2Client
+
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kops.ClusterSpec{
+			EtcdClusters: []kops.EtcdClusterSpec{
+				{
+					Name: "main",
+					Members: []kops.EtcdMemberSpec{
+						{Name: "a", EncryptedVolume: fi.PtrTo(true), KmsKeyID: fi.PtrTo(newKey)},
+						{Name: "b", EncryptedVolume: fi.PtrTo(true), KmsKeyID: fi.PtrTo(newKey)},
+						{Name: "c", EncryptedVolume: fi.PtrTo(true), KmsKeyID: fi.PtrTo(newKey)},
+					},
+				},
+			},
+		},
+	}
+
+	volumes := map[string]string{}
+	for _, member := range []struct {
+		name     string
+		key      string
+		instance string
+	}{
+		{name: "a", key: oldKey, instance: "i-a"},
+		{name: "b", key: newKey, instance: "i-b"},
+		{name: "c", instance: "i-c"},
+	} {
+		response, err := ec2Client.CreateVolume(ctx, &ec2.CreateVolumeInput{
+			AvailabilityZone: aws.String("us-test-1" + member.name),
+			Size:             aws.Int32(20),
+			VolumeType:       ec2types.VolumeTypeGp3,
+			Iops:             aws.Int32(3000),
+			Throughput:       aws.Int32(125),
+			Encrypted:        aws.Bool(member.key != ""),
+			KmsKeyId:         aws.String(member.key),
+			TagSpecifications: []ec2types.TagSpecification{
+				{
+					ResourceType: ec2types.ResourceTypeVolume,
+					Tags: []ec2types.Tag{
+						{Key: aws.String("Name"), Value: aws.String(member.name + ".etcd-main.minimal.example.com")},
+						{Key: aws.String("k8s.io/etcd/main"), Value: aws.String(member.name + "/a,b,c")},
+						{Key: aws.String("k8s.io/role/control-plane"), Value: aws.String("1")},
+						{Key: aws.String("kubernetes.io/cluster/minimal.example.com"), Value: aws.String("owned")},
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("error creating volume: %v", err)
+		}
+		volumeID := aws.ToString(response.VolumeId)
+		volumes[member.name] = volumeID
+		ec2Client.Volumes[volumeID].State = ec2types.VolumeStateInUse
+		ec2Client.Volumes[volumeID].Attachments = []ec2types.VolumeAttachment{{InstanceId: aws.String(member.instance), VolumeId: aws.String(volumeID)}}
+	}
+
+	var terminated, validated []string
+	migration := &KMSKeyMigration{
+		Cloud:   cloud,
+		Cluster: cluster,
+		Out:     io.Discard,
+		TerminateInstance: func(instanceID string) error {
+			terminated = append(terminated, instanceID)
+			for _, volume := range ec2Client.Volumes {
+				for _, attachment := range volume.Attachments {
+					if aws.ToString(attachment.InstanceId) == instanceID {
+						volume.Attachments = nil
+						volume.State = ec2types.VolumeStateAvailable
+					}
+				}
+			}
+			return nil
+		},
+		ValidateCluster: func(instanceID string) error {
+			validated = append(validated, instanceID)
+			return nil
+		},
+		PollInterval: time.Millisecond,
+	}
+
+	plan, err := migration.Plan(ctx)
+	if err != nil {
+		t.Fatalf("error planning migration: %v", err)
+	}
+	var planned []string
+	for _, p := range plan {
+		planned = append(planned, p.Member+"@"+p.InstanceID)
+	}
+	if !reflect.DeepEqual(planned, []string{"a@i-a", "c@i-c"}) {
+		t.Fatalf("unexpected plan: %v", planned)
+	}
+
+	if err := migration.Run(ctx, plan); err != nil {
+		t.Fatalf("error running migration: %v", err)
+	}
+	if !reflect.DeepEqual(terminated, []string{"i-a", "i-c"}) {
+		t.Errorf("unexpected terminated instances: %v", terminated)
+	}
+	if !reflect.DeepEqual(validated, []string{"i-a", "i-c"}) {
+		t.Errorf("unexpected validated instances: %v", validated)
+	}
+	if len(ec2Client.Snapshots) != 0 {
+		t.Errorf("expected snapshots to be deleted, found %d", len(ec2Client.Snapshots))
+	}
+
+	response, err := ec2Client.DescribeVolumes(ctx, &ec2.DescribeVolumesInput{})
+	if err != nil {
+		t.Fatalf("error listing volumes: %v", err)
+	}
+	byName := make(map[string]ec2types.Volume)
+	for _, volume := range response.Volumes {
+		byName[tagsToMap(volume.Tags)["Name"]] = volume
+	}
+	if len(byName) != 5 {
+		t.Fatalf("expected 5 volumes, found %d", len(byName))
+	}
+	for _, member := range []string{"a", "c"} {
+		current := byName[member+".etcd-main.minimal.example.com"]
+		if aws.ToString(current.KmsKeyId) != newKey || !aws.ToBool(current.Encrypted) {
+			t.Errorf("volume of member %q is not encrypted with the new key", member)
+		}
+		if tags := tagsToMap(current.Tags); tags["k8s.io/etcd/main"] != member+"/a,b,c" || tags["k8s.io/role/control-plane"] != "1" {
+			t.Errorf("volume of member %q is missing its etcd tags: %v", member, tags)
+		}
+		if aws.ToInt32(current.Iops) != 3000 || aws.ToInt32(current.Throughput) != 125 {
+			t.Errorf("volume of member %q did not keep its performance settings", member)
+		}
+
+		old := byName[member+".etcd-main.minimal.example.com-replaced"]
+		tags := tagsToMap(old.Tags)
+		if tags[TagReplacedBy] != aws.ToString(current.VolumeId) {
+			t.Errorf("replaced volume of member %q has %s=%q, expected %q", member, TagReplacedBy, tags[TagReplacedBy], aws.ToString(current.VolumeId))
+		}
+		if _, found := tags["k8s.io/etcd/main"]; found {
+			t.Errorf("replaced volume of member %q still has its etcd tag", member)
+		}
+		if aws.ToString(old.VolumeId) != volumes[member] {
+			t.Errorf("unexpected replaced volume of member %q: %s", member, aws.ToString(old.VolumeId))
+		}
+	}
+}
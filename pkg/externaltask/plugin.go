@@ -0,0 +1,173 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package externaltask calls the plugins that run the external tasks of the cluster spec.
+//
+// A plugin receives a JSON Request, on its standard input if it is an executable or in the body
+// of a POST if it is a webhook, and replies with a JSON Response. The find operation returns
+// the actual state of the resource of the task, and the apply operation applies the desired state.
+package externaltask
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+const (
+	// APIVersion is the version of the requests and responses.
+	APIVersion = "externaltask.kops.k8s.io/v1alpha1"
+
+	// OperationFind asks for the actual state of the resource of the task.
+	OperationFind = "find"
+	// OperationApply asks to apply the desired state of the resource of the task.
+	OperationApply = "apply"
+
+	// timeout is the maximum duration of a call to a plugin.
+	timeout = 5 * time.Minute
+)
+
+// Request is sent to a plugin.
+type Request struct {
+	APIVersion string `json:"apiVersion"`
+	// Operation is find or apply.
+	Operation string `json:"operation"`
+	// ClusterName is the name of the cluster.
+	ClusterName string `json:"clusterName"`
+	// Task is the name of the task.
+	Task string `json:"task"`
+	// Config is the desired state of the resource of the task.
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// Response is the reply of a plugin.
+type Response struct {
+	// Exists is whether the resource exists, in reply to find.
+	Exists bool `json:"exists,omitempty"`
+	// Config is the actual state of the resource, in reply to find.
+	Config map[string]string `json:"config,omitempty"`
+	// Error is set if the operation failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Plugin runs external tasks.
+type Plugin interface {
+	Call(ctx context.Context, request *Request) (*Response, error)
+}
+
+// NewPlugin returns the plugin of the external task.
+func NewPlugin(spec *kops.ExternalTaskSpec) (Plugin, error) {
+	switch {
+	case len(spec.Command) != 0 && spec.URL == "":
+		return &commandPlugin{command: spec.Command}, nil
+	case len(spec.Command) == 0 && spec.URL != "":
+		return &webhookPlugin{url: spec.URL, client: &http.Client{Timeout: timeout}}, nil
+	default:
+		return nil, fmt.Errorf("external task %q must have exactly one of command and url", spec.Name)
+	}
+}
+
+// commandPlugin runs an executable for each request.
+type commandPlugin struct {
+	command []string
+}
+
+func (p *commandPlugin) Call(ctx context.Context, request *Request) (*Response, error) {
+	body, err := encodeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.command[0], p.command[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running %s of task %q with %q: %w: %s", request.Operation, request.Task, strings.Join(p.command, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return decodeResponse(request, stdout.Bytes())
+}
+
+// webhookPlugin posts each request to an endpoint.
+type webhookPlugin struct {
+	url    string
+	client *http.Client
+}
+
+func (p *webhookPlugin) Call(ctx context.Context, request *Request) (*Response, error) {
+	body, err := encodeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error building request to %s: %w", p.url, err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := p.client.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error calling %s for %s of task %q: %w", p.url, request.Operation, request.Task, err)
+	}
+	defer httpResponse.Body.Close()
+
+	data, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response of %s: %w", p.url, err)
+	}
+	if httpResponse.StatusCode/100 != 2 {
+		// Plugins may explain the failure in a response
+		response := &Response{}
+		if json.Unmarshal(data, response) == nil && response.Error != "" {
+			return nil, fmt.Errorf("plugin failed to %s task %q: %s", request.Operation, request.Task, response.Error)
+		}
+		return nil, fmt.Errorf("unexpected status %q calling %s for %s of task %q", httpResponse.Status, p.url, request.Operation, request.Task)
+	}
+	return decodeResponse(request, data)
+}
+
+func encodeRequest(request *Request) ([]byte, error) {
+	request.APIVersion = APIVersion
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request: %w", err)
+	}
+	return body, nil
+}
+
+func decodeResponse(request *Request, data []byte) (*Response, error) {
+	response := &Response{}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("error parsing response to %s of task %q: %w", request.Operation, request.Task, err)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("plugin failed to %s task %q: %s", request.Operation, request.Task, response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externaltask
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestWebhookPlugin(t *testing.T) {
+	var requests []*Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &Request{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			t.Errorf("error decoding request: %v", err)
+		}
+		requests = append(requests, request)
+
+		switch request.Operation {
+		case OperationFind:
+			json.NewEncoder(w).Encode(&Response{Exists: true, Config: map[string]string{"owner": "platform"}})
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(&Response{Error: "inventory is read-only"})
+		}
+	}))
+	defer server.Close()
+
+	plugin, err := NewPlugin(&kops.ExternalTaskSpec{Name: "inventory", URL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	response, err := plugin.Call(context.TODO(), &Request{Operation: OperationFind, ClusterName: "cluster.example.com", Task: "inventory", Config: map[string]string{"owner": "sre"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (&Response{Exists: true, Config: map[string]string{"owner": "platform"}}); !reflect.DeepEqual(response, expected) {
+		t.Errorf("expected response %+v, got %+v", expected, response)
+	}
+	expectedRequest := &Request{APIVersion: APIVersion, Operation: OperationFind, ClusterName: "cluster.example.com", Task: "inventory", Config: map[string]string{"owner": "sre"}}
+	if len(requests) != 1 || !reflect.DeepEqual(requests[0], expectedRequest) {
+		t.Errorf("expected request %+v, got %+v", expectedRequest, requests)
+	}
+
+	_, err = plugin.Call(context.TODO(), &Request{Operation: OperationApply, ClusterName: "cluster.example.com", Task: "inventory"})
+	if err == nil || !strings.Contains(err.Error(), "inventory is read-only") {
+		t.Errorf("expected the error of the plugin, got %v", err)
+	}
+}
+
+func TestCommandPlugin(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	// The plugin replies with the request it received, as the config of the resource
+	plugin, err := NewPlugin(&kops.ExternalTaskSpec{Name: "cmdb", Command: []string{"sh", "-c", `printf '{"exists":true,"config":{"request":"%s"}}' "$(cat | tr -d '"')"`}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	response, err := plugin.Call(context.TODO(), &Request{Operation: OperationFind, ClusterName: "cluster.example.com", Task: "cmdb"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "{apiVersion:externaltask.kops.k8s.io/v1alpha1,operation:find,clusterName:cluster.example.com,task:cmdb}"
+	if !response.Exists || response.Config["request"] != expected {
+		t.Errorf("expected the request %s, got %+v", expected, response)
+	}
+
+	plugin, err = NewPlugin(&kops.ExternalTaskSpec{Name: "cmdb", Command: []string{"sh", "-c", "echo cmdb is unavailable >&2; exit 1"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = plugin.Call(context.TODO(), &Request{Operation: OperationApply, ClusterName: "cluster.example.com", Task: "cmdb"})
+	if err == nil || !strings.Contains(err.Error(), "cmdb is unavailable") {
+		t.Errorf("expected the standard error of the plugin, got %v", err)
+	}
+
+	if _, err := NewPlugin(&kops.ExternalTaskSpec{Name: "cmdb"}); err == nil {
+		t.Errorf("expected an error without command and url")
+	}
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// defaultCanaryTimeout is the default time to wait for a canary node to join and pass its checks.
+	defaultCanaryTimeout = 10 * time.Minute
+	// defaultCanaryNamespace is the namespace the canary pods and jobs run in.
+	defaultCanaryNamespace = "kube-system"
+	// canaryPodImage is the image of the pod used to check that pods can be scheduled on the canary node,
+	// before it is remapped to the cluster's container registry.
+	canaryPodImage = "registry.k8s.io/pause:3.9"
+)
+
+// canaryApplies returns true if the first instance of the group should be replaced and checked before the rest.
+func (c *RollingUpdateCluster) canaryApplies(group *cloudinstances.CloudInstanceGroup, settings api.RollingUpdate) bool {
+	if settings.Canary == nil || c.CloudOnly || c.K8sClient == nil {
+		return false
+	}
+	if !fi.ValueOf(settings.DrainAndTerminate) {
+		return false
+	}
+	switch group.InstanceGroup.Spec.Role {
+	case api.InstanceGroupRoleControlPlane, api.InstanceGroupRoleBastion:
+		return false
+	}
+	return true
+}
+
+// rollingUpdateCanary replaces a single instance, then waits for its replacement node to join the cluster
+// and pass the configured checks. If surge is true, the instance is detached first so its replacement is
+// launched before it is terminated.
+func (c *RollingUpdateCluster) rollingUpdateCanary(group *cloudinstances.CloudInstanceGroup, u *cloudinstances.CloudInstance, canary *api.RollingUpdateCanary, surge bool, sleepAfterTerminate time.Duration) error {
+	igName := group.InstanceGroup.ObjectMeta.Name
+
+	existing := make(map[string]bool)
+	for _, members := range [][]*cloudinstances.CloudInstance{group.Ready, group.NeedUpdate} {
+		for _, member := range members {
+			if member.Node != nil {
+				existing[member.Node.Name] = true
+			}
+		}
+	}
+
+	klog.Infof("Replacing canary instance %q in instance group %q", u.ID, igName)
+	if surge && u.Status != cloudinstances.CloudInstanceStatusDetached {
+		if err := c.detachInstance(u); err != nil {
+			return fmt.Errorf("error detaching canary instance %q: %w", u.ID, err)
+		}
+		klog.Infof("waiting for %v after detaching canary instance", sleepAfterTerminate)
+		time.Sleep(sleepAfterTerminate)
+		if err := c.maybeValidate(" after detaching canary instance", c.ValidateCount, group); err != nil {
+			return err
+		}
+	}
+	if err := c.drainTerminateAndWait(u, sleepAfterTerminate); err != nil {
+		return err
+	}
+	if err := c.maybeValidate(" after terminating canary instance", c.ValidateCount, group); err != nil {
+		return err
+	}
+
+	timeout := defaultCanaryTimeout
+	if canary.Timeout != nil {
+		timeout = canary.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(c.Ctx, timeout)
+	defer cancel()
+
+	node, err := c.waitForCanaryNode(ctx, igName, existing)
+	if err != nil {
+		return fmt.Errorf("canary node for instance group %q did not join the cluster, not updating the remaining instances: %w", igName, err)
+	}
+
+	klog.Infof("Running canary checks on node %q", node.Name)
+	if err := c.runCanaryChecks(ctx, node, canary); err != nil {
+		return fmt.Errorf("canary node %q of instance group %q failed its checks, not updating the remaining instances: %w", node.Name, igName, err)
+	}
+	klog.Infof("Canary node %q passed its checks", node.Name)
+
+	return nil
+}
+
+// waitForCanaryNode waits for a node of the instance group that was not part of it before the canary was replaced.
+func (c *RollingUpdateCluster) waitForCanaryNode(ctx context.Context, igName string, existing map[string]bool) (*corev1.Node, error) {
+	var canary *corev1.Node
+	err := wait.PollUntilContextCancel(ctx, c.ValidateTickDuration, true, func(ctx context.Context) (bool, error) {
+		nodes, err := c.K8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+			LabelSelector: api.NodeLabelInstanceGroup + "=" + igName,
+		})
+		if err != nil {
+			klog.Warningf("(will retry): error listing nodes: %v", err)
+			return false, nil
+		}
+		for i := range nodes.Items {
+			node := &nodes.Items[i]
+			if existing[node.Name] {
+				continue
+			}
+			if canary == nil || node.CreationTimestamp.After(canary.CreationTimestamp.Time) {
+				canary = node
+			}
+		}
+		return canary != nil, nil
+	})
+	return canary, err
+}
+
+// runCanaryChecks runs the checks configured for the canary against its node.
+func (c *RollingUpdateCluster) runCanaryChecks(ctx context.Context, node *corev1.Node, canary *api.RollingUpdateCanary) error {
+	if canary.NetworkReady == nil || *canary.NetworkReady {
+		if err := c.checkCanaryNetworkReady(ctx, node.Name); err != nil {
+			return fmt.Errorf("network not ready: %w", err)
+		}
+	}
+	if canary.PodScheduling == nil || *canary.PodScheduling {
+		if err := c.checkCanaryPodScheduling(ctx, node); err != nil {
+			return fmt.Errorf("pod not started: %w", err)
+		}
+	}
+	if canary.Job != nil {
+		if err := c.checkCanaryJob(ctx, node, canary.Job); err != nil {
+			return fmt.Errorf("job not completed: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkCanaryNetworkReady waits for the node to be ready with its network available,
+// which requires the network plugin to be running.
+func (c *RollingUpdateCluster) checkCanaryNetworkReady(ctx context.Context, nodeName string) error {
+	return wait.PollUntilContextCancel(ctx, c.ValidateTickDuration, true, func(ctx context.Context) (bool, error) {
+		node, err := c.K8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			klog.Warningf("(will retry): error getting node %q: %v", nodeName, err)
+			return false, nil
+		}
+		ready := false
+		for _, condition := range node.Status.Conditions {
+			switch condition.Type {
+			case corev1.NodeReady:
+				ready = condition.Status == corev1.ConditionTrue
+			case corev1.NodeNetworkUnavailable:
+				if condition.Status == corev1.ConditionTrue {
+					return false, nil
+				}
+			}
+		}
+		return ready, nil
+	})
+}
+
+// checkCanaryPodScheduling starts a pod on the node and waits for it to run.
+func (c *RollingUpdateCluster) checkCanaryPodScheduling(ctx context.Context, node *corev1.Node) error {
+	pods := c.K8sClient.CoreV1().Pods(defaultCanaryNamespace)
+
+	image, err := c.canaryPodImage()
+	if err != nil {
+		return err
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      canaryObjectName(node.Name),
+			Namespace: defaultCanaryNamespace,
+		},
+		Spec: canaryPodSpec(node, corev1.Container{
+			Name:  "canary",
+			Image: image,
+		}),
+	}
+	// Remove a pod left behind by an interrupted run, which would make creating this one fail.
+	if err := pods.Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting previous pod: %w", err)
+	}
+	if _, err := pods.Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating pod: %w", err)
+	}
+	defer func() {
+		if err := pods.Delete(context.Background(), pod.Name, metav1.DeleteOptions{}); err != nil {
+			klog.Warningf("error deleting canary pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}()
+
+	return wait.PollUntilContextCancel(ctx, c.ValidateTickDuration, true, func(ctx context.Context) (bool, error) {
+		p, err := pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.Warningf("(will retry): error getting pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			return false, nil
+		}
+		switch p.Status.Phase {
+		case corev1.PodRunning, corev1.PodSucceeded:
+			return true, nil
+		case corev1.PodFailed:
+			return false, fmt.Errorf("pod %s/%s failed: %s", pod.Namespace, pod.Name, p.Status.Message)
+		}
+		return false, nil
+	})
+}
+
+// checkCanaryJob runs the configured job on the node and waits for it to complete.
+func (c *RollingUpdateCluster) checkCanaryJob(ctx context.Context, node *corev1.Node, spec *api.RollingUpdateCanaryJob) error {
+	namespace := spec.Namespace
+	if namespace == "" {
+		namespace = defaultCanaryNamespace
+	}
+	jobs := c.K8sClient.BatchV1().Jobs(namespace)
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      canaryObjectName(node.Name),
+			Namespace: namespace,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: fi.PtrTo(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				Spec: canaryPodSpec(node, corev1.Container{
+					Name:    "canary",
+					Image:   spec.Image,
+					Command: spec.Command,
+				}),
+			},
+		},
+	}
+	job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	propagation := metav1.DeletePropagationBackground
+	// Remove a job left behind by an interrupted run, which would make creating this one fail.
+	if err := jobs.Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting previous job: %w", err)
+	}
+	if _, err := jobs.Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating job: %w", err)
+	}
+	defer func() {
+		if err := jobs.Delete(context.Background(), job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+			klog.Warningf("error deleting canary job %s/%s: %v", job.Namespace, job.Name, err)
+		}
+	}()
+
+	return wait.PollUntilContextCancel(ctx, c.ValidateTickDuration, true, func(ctx context.Context) (bool, error) {
+		j, err := jobs.Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			klog.Warningf("(will retry): error getting job %s/%s: %v", job.Namespace, job.Name, err)
+			return false, nil
+		}
+		if j.Status.Failed > 0 {
+			return false, fmt.Errorf("job %s/%s failed", job.Namespace, job.Name)
+		}
+		return j.Status.Succeeded > 0, nil
+	})
+}
+
+// canaryPodImage returns the image of the canary pod, remapped to the cluster's container registry if one is set.
+func (c *RollingUpdateCluster) canaryPodImage() (string, error) {
+	vfsContext := vfs.Context
+	if c.Clientset != nil {
+		vfsContext = c.Clientset.VFSContext()
+	}
+	assetBuilder := assets.NewAssetBuilder(vfsContext, c.Cluster.Spec.Assets, c.Cluster.Spec.KubernetesVersion, false)
+	image, err := assetBuilder.RemapImage(canaryPodImage)
+	if err != nil {
+		return "", fmt.Errorf("error remapping canary image %q: %w", canaryPodImage, err)
+	}
+	return image, nil
+}
+
+// canaryPodSpec returns a pod spec that runs the container on the node, whatever its taints.
+func canaryPodSpec(node *corev1.Node, container corev1.Container) corev1.PodSpec {
+	hostname := node.Labels[corev1.LabelHostname]
+	if hostname == "" {
+		hostname = node.Name
+	}
+	return corev1.PodSpec{
+		NodeSelector: map[string]string{
+			corev1.LabelHostname: hostname,
+		},
+		Tolerations: []corev1.Toleration{
+			{Operator: corev1.TolerationOpExists},
+		},
+		Containers:    []corev1.Container{container},
+		RestartPolicy: corev1.RestartPolicyNever,
+	}
+}
+
+// canaryObjectName returns the name of the canary pod or job for a node, within the length allowed for a label value.
+func canaryObjectName(nodeName string) string {
+	name := "kops-canary-" + nodeName
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return strings.TrimRight(name, ".-")
+}
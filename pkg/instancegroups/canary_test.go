@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	testingclient "k8s.io/client-go/testing"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func canaryTestNode(name string, conditions ...v1.NodeCondition) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				kopsapi.NodeLabelInstanceGroup: "nodes",
+				v1.LabelHostname:               name,
+			},
+		},
+		Status: v1.NodeStatus{Conditions: conditions},
+	}
+}
+
+// completeCanaryWorkloads makes pods start and jobs finish as soon as they are created.
+func completeCanaryWorkloads(k8sClient *fake.Clientset, jobSucceeds bool) {
+	k8sClient.PrependReactor("create", "pods", func(action testingclient.Action) (bool, runtime.Object, error) {
+		pod := action.(testingclient.CreateAction).GetObject().(*v1.Pod)
+		pod.Status.Phase = v1.PodRunning
+		return false, nil, nil
+	})
+	k8sClient.PrependReactor("create", "jobs", func(action testingclient.Action) (bool, runtime.Object, error) {
+		job := action.(testingclient.CreateAction).GetObject().(*batchv1.Job)
+		if jobSucceeds {
+			job.Status.Succeeded = 1
+		} else {
+			job.Status.Failed = 1
+		}
+		return false, nil, nil
+	})
+}
+
+func TestCanaryChecks(t *testing.T) {
+	ready := v1.NodeCondition{Type: v1.NodeReady, Status: v1.ConditionTrue}
+	networkUnavailable := v1.NodeCondition{Type: v1.NodeNetworkUnavailable, Status: v1.ConditionTrue}
+
+	grid := []struct {
+		name          string
+		node          *v1.Node
+		canary        *kopsapi.RollingUpdateCanary
+		jobSucceeds   bool
+		expectedError string
+	}{
+		{
+			name:   "default checks",
+			node:   canaryTestNode("node-1", ready),
+			canary: &kopsapi.RollingUpdateCanary{},
+		},
+		{
+			name: "job succeeds",
+			node: canaryTestNode("node-1", ready),
+			canary: &kopsapi.RollingUpdateCanary{
+				Job: &kopsapi.RollingUpdateCanaryJob{Image: "busybox", Command: []string{"true"}},
+			},
+			jobSucceeds: true,
+		},
+		{
+			name: "job fails",
+			node: canaryTestNode("node-1", ready),
+			canary: &kopsapi.RollingUpdateCanary{
+				Job: &kopsapi.RollingUpdateCanaryJob{Image: "busybox", Command: []string{"false"}},
+			},
+			expectedError: "job not completed",
+		},
+		{
+			name:          "network unavailable",
+			node:          canaryTestNode("node-1", ready, networkUnavailable),
+			canary:        &kopsapi.RollingUpdateCanary{},
+			expectedError: "network not ready",
+		},
+		{
+			name:   "network check disabled",
+			node:   canaryTestNode("node-1", ready, networkUnavailable),
+			canary: &kopsapi.RollingUpdateCanary{NetworkReady: fi.PtrTo(false)},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			c, _ := getTestSetup()
+			k8sClient := fake.NewSimpleClientset(g.node)
+			completeCanaryWorkloads(k8sClient, g.jobSucceeds)
+			c.K8sClient = k8sClient
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			err := c.runCanaryChecks(ctx, g.node, g.canary)
+			if g.expectedError == "" {
+				assert.NoError(t, err)
+			} else if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+				t.Fatalf("expected error containing %q, got %v", g.expectedError, err)
+			}
+
+			pods, err := k8sClient.CoreV1().Pods(defaultCanaryNamespace).List(context.Background(), v1meta.ListOptions{})
+			assert.NoError(t, err)
+			assert.Empty(t, pods.Items, "canary pods should be cleaned up")
+			jobs, err := k8sClient.BatchV1().Jobs(defaultCanaryNamespace).List(context.Background(), v1meta.ListOptions{})
+			assert.NoError(t, err)
+			assert.Empty(t, jobs.Items, "canary jobs should be cleaned up")
+		})
+	}
+}
+
+func TestCanaryChecksReplaceLeftoverWorkloads(t *testing.T) {
+	ready := v1.NodeCondition{Type: v1.NodeReady, Status: v1.ConditionTrue}
+	node := canaryTestNode("node-1", ready)
+	leftoverPod := &v1.Pod{ObjectMeta: v1meta.ObjectMeta{Name: canaryObjectName(node.Name), Namespace: defaultCanaryNamespace}}
+	leftoverJob := &batchv1.Job{ObjectMeta: v1meta.ObjectMeta{Name: canaryObjectName(node.Name), Namespace: defaultCanaryNamespace}}
+
+	c, _ := getTestSetup()
+	k8sClient := fake.NewSimpleClientset(node, leftoverPod, leftoverJob)
+	completeCanaryWorkloads(k8sClient, true)
+	c.K8sClient = k8sClient
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := c.runCanaryChecks(ctx, node, &kopsapi.RollingUpdateCanary{
+		Job: &kopsapi.RollingUpdateCanaryJob{Image: "busybox", Command: []string{"true"}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestCanaryPodImage(t *testing.T) {
+	c, _ := getTestSetup()
+	image, err := c.canaryPodImage()
+	assert.NoError(t, err)
+	assert.Equal(t, canaryPodImage, image)
+
+	c.Cluster.Spec.Assets = &kopsapi.AssetsSpec{ContainerRegistry: fi.PtrTo("registry.example.com")}
+	image, err = c.canaryPodImage()
+	assert.NoError(t, err)
+	assert.Equal(t, "registry.example.com/pause:3.9", image)
+}
+
+func TestWaitForCanaryNode(t *testing.T) {
+	c, _ := getTestSetup()
+	other := canaryTestNode("other")
+	other.Labels[kopsapi.NodeLabelInstanceGroup] = "other"
+	c.K8sClient = fake.NewSimpleClientset(canaryTestNode("old"), canaryTestNode("new"), other)
+
+	node, err := c.waitForCanaryNode(context.Background(), "nodes", map[string]bool{"old": true})
+	assert.NoError(t, err)
+	assert.Equal(t, "new", node.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.waitForCanaryNode(ctx, "nodes", map[string]bool{"old": true, "new": true})
+	assert.Error(t, err, "expected no canary node to be found")
+}
+
+func TestCanaryApplies(t *testing.T) {
+	settings := kopsapi.RollingUpdate{
+		DrainAndTerminate: fi.PtrTo(true),
+		Canary:            &kopsapi.RollingUpdateCanary{},
+	}
+
+	for _, role := range kopsapi.AllInstanceGroupRoles {
+		c, _ := getTestSetup()
+		group := &cloudinstances.CloudInstanceGroup{
+			InstanceGroup: &kopsapi.InstanceGroup{Spec: kopsapi.InstanceGroupSpec{Role: role}},
+		}
+		expected := role == kopsapi.InstanceGroupRoleNode || role == kopsapi.InstanceGroupRoleAPIServer
+		assert.Equal(t, expected, c.canaryApplies(group, settings), "role %s", role)
+
+		c.CloudOnly = true
+		assert.False(t, c.canaryApplies(group, settings), "role %s with cloudonly", role)
+	}
+
+	c, _ := getTestSetup()
+	group := &cloudinstances.CloudInstanceGroup{
+		InstanceGroup: &kopsapi.InstanceGroup{Spec: kopsapi.InstanceGroupSpec{Role: kopsapi.InstanceGroupRoleNode}},
+	}
+	assert.False(t, c.canaryApplies(group, kopsapi.RollingUpdate{DrainAndTerminate: fi.PtrTo(true)}), "without canary")
+	assert.False(t, c.canaryApplies(group, kopsapi.RollingUpdate{DrainAndTerminate: fi.PtrTo(false), Canary: &kopsapi.RollingUpdateCanary{}}), "without drain and terminate")
+}
+
+func TestCanaryObjectName(t *testing.T) {
+	assert.Equal(t, "kops-canary-node-1", canaryObjectName("node-1"))
+	name := canaryObjectName("ip-172-20-32-10.us-west-2.compute.internal.example.very.long.name")
+	assert.LessOrEqual(t, len(name), 63)
+	assert.False(t, strings.HasSuffix(name, ".") || strings.HasSuffix(name, "-"))
+}
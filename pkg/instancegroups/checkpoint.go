@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// ErrRollingUpdatePaused is returned when a rolling update stops after reaching its maximum duration.
+// Its progress is recorded in the state store, so that the next rolling update resumes it.
+var ErrRollingUpdatePaused = errors.New("rolling update paused after reaching its maximum duration; run it again to resume")
+
+// rollingUpdateCheckpoint records the progress of a rolling update that paused before completing.
+type rollingUpdateCheckpoint struct {
+	// StartedAt is when the first of the rolling updates started; instances launched since then are replacements.
+	StartedAt time.Time `json:"startedAt"`
+	// Force is true if the rolling update replaced the instances that did not need updating.
+	Force bool `json:"force,omitempty"`
+	// Completed holds the names of the instance groups whose instances were all replaced.
+	Completed []string `json:"completed,omitempty"`
+}
+
+// isCompleted returns true if all the instances of the group were replaced by a previous rolling update.
+func (p *rollingUpdateCheckpoint) isCompleted(name string) bool {
+	for _, completed := range p.Completed {
+		if completed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkpointPath returns the path of the checkpoint in the state store, or nil if the rolling update has no state store.
+func (c *RollingUpdateCluster) checkpointPath() (vfs.Path, error) {
+	if c.Clientset == nil {
+		return nil, nil
+	}
+	configBase, err := c.Clientset.ConfigBaseFor(c.Cluster)
+	if err != nil {
+		return nil, fmt.Errorf("error building config base for cluster %q: %w", c.Cluster.Name, err)
+	}
+	return configBase.Join(registry.PathRollingUpdateCheckpoint), nil
+}
+
+// loadCheckpoint reads the progress of a previous rolling update that paused, so that it is resumed.
+// A forced rolling update only resumes a forced rolling update, and the other way around.
+func (c *RollingUpdateCluster) loadCheckpoint() error {
+	c.checkpoint = &rollingUpdateCheckpoint{StartedAt: time.Now(), Force: c.Force}
+
+	p, err := c.checkpointPath()
+	if err != nil || p == nil {
+		return err
+	}
+	data, err := p.ReadFile(c.Ctx)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("error reading %s: %w", p, err)
+	}
+
+	previous := &rollingUpdateCheckpoint{}
+	if err := json.Unmarshal(data, previous); err != nil {
+		klog.Warningf("ignoring invalid rolling update progress in %s: %v", p, err)
+		return nil
+	}
+	if previous.Force != c.Force {
+		klog.Infof("Ignoring the progress of a previous rolling update with force=%v", previous.Force)
+		c.keepCheckpoint = true
+		return nil
+	}
+	klog.Infof("Resuming the rolling update started at %v", previous.StartedAt.Format(time.RFC3339))
+	c.checkpoint = previous
+	return nil
+}
+
+// markCompleted records that all the instances of the group were replaced.
+func (c *RollingUpdateCluster) markCompleted(name string) {
+	c.checkpointMutex.Lock()
+	defer c.checkpointMutex.Unlock()
+
+	if !c.checkpoint.isCompleted(name) {
+		c.checkpoint.Completed = append(c.checkpoint.Completed, name)
+	}
+}
+
+// skipCompleted returns true if a previous forced rolling update replaced all the instances of the group,
+// and none of them need updating since.
+func (c *RollingUpdateCluster) skipCompleted(name string, group *cloudinstances.CloudInstanceGroup) bool {
+	c.checkpointMutex.Lock()
+	defer c.checkpointMutex.Unlock()
+
+	if !c.Force || !c.checkpoint.isCompleted(name) || len(group.NeedUpdate) != 0 {
+		return false
+	}
+	klog.Infof("Skipping instance group %q, its instances were replaced by the paused rolling update", name)
+	return true
+}
+
+// forcedUpdates returns the ready instances that a forced rolling update replaces, leaving out the replacements
+// launched since a previous rolling update that paused.
+func (c *RollingUpdateCluster) forcedUpdates(group *cloudinstances.CloudInstanceGroup) []*cloudinstances.CloudInstance {
+	var update []*cloudinstances.CloudInstance
+	for _, u := range group.Ready {
+		if u.LaunchTime != nil && c.checkpoint != nil && u.LaunchTime.After(c.checkpoint.StartedAt) {
+			klog.V(2).Infof("Not replacing instance %q, it was launched by the paused rolling update", u.ID)
+			continue
+		}
+		update = append(update, u)
+	}
+	return update
+}
+
+// deadlineReached returns true if the rolling update has run for its maximum duration.
+func (c *RollingUpdateCluster) deadlineReached() bool {
+	return !c.deadline.IsZero() && time.Now().After(c.deadline)
+}
+
+// pause records the progress of the rolling update in the state store and returns ErrRollingUpdatePaused.
+func (c *RollingUpdateCluster) pause() error {
+	c.checkpointMutex.Lock()
+	defer c.checkpointMutex.Unlock()
+
+	sort.Strings(c.checkpoint.Completed)
+	data, err := json.Marshal(c.checkpoint)
+	if err != nil {
+		return fmt.Errorf("error serializing rolling update progress: %w", err)
+	}
+
+	p, err := c.checkpointPath()
+	if err != nil {
+		return err
+	}
+	if p == nil {
+		klog.Warningf("No state store to record the progress of the rolling update")
+		return ErrRollingUpdatePaused
+	}
+	acl, err := acls.GetACL(c.Ctx, p, c.Cluster)
+	if err != nil {
+		return err
+	}
+	if err := p.WriteFile(c.Ctx, bytes.NewReader(data), acl); err != nil {
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	klog.Infof("Rolling update reached its maximum duration of %v, pausing", c.MaxDuration)
+	return ErrRollingUpdatePaused
+}
+
+// removeCheckpoint removes the progress of a paused rolling update, once the rolling update completes.
+// The progress of a paused rolling update that this one did not resume is kept.
+func (c *RollingUpdateCluster) removeCheckpoint() {
+	if c.keepCheckpoint {
+		return
+	}
+	p, err := c.checkpointPath()
+	if err != nil || p == nil {
+		return
+	}
+	if err := p.Remove(c.Ctx); err != nil && !errors.Is(err, os.ErrNotExist) {
+		klog.Warningf("unable to remove %s: %v", p, err)
+	}
+}
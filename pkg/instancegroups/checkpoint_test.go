@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/stretchr/testify/assert"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// pauseAfterTerminationValidator reaches the deadline of the rolling update once an instance of the group was terminated.
+type pauseAfterTerminationValidator struct {
+	c     *RollingUpdateCluster
+	cloud awsup.AWSCloud
+	group string
+	size  int
+}
+
+func (v *pauseAfterTerminationValidator) Validate() (*validation.ValidationCluster, error) {
+	asgGroups, err := v.cloud.Autoscaling().DescribeAutoScalingGroups(context.Background(), &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []string{v.group},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(asgGroups.AutoScalingGroups) == 1 && len(asgGroups.AutoScalingGroups[0].Instances) < v.size {
+		v.c.deadline = time.Now().Add(-time.Second)
+	}
+	return &validation.ValidationCluster{}, nil
+}
+
+func getTestSetupCheckpoint(t *testing.T) (*RollingUpdateCluster, *awsup.MockAWSCloud) {
+	c, cloud := getTestSetup()
+	c.Cluster.Spec.ConfigStore.Base = "memfs://tests/test.k8s.local"
+
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building state store path: %v", err)
+	}
+	c.Clientset = vfsclientset.NewVFSClientset(vfs.Context, basePath)
+	return c, cloud
+}
+
+func readCheckpoint(t *testing.T, c *RollingUpdateCluster) *rollingUpdateCheckpoint {
+	p, err := vfs.Context.BuildVfsPath("memfs://tests/test.k8s.local/" + registry.PathRollingUpdateCheckpoint)
+	if err != nil {
+		t.Fatalf("error building checkpoint path: %v", err)
+	}
+	data, err := p.ReadFile(c.Ctx)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("error reading checkpoint: %v", err)
+	}
+	checkpoint := &rollingUpdateCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		t.Fatalf("error parsing checkpoint: %v", err)
+	}
+	return checkpoint
+}
+
+func TestRollingUpdateMaxDurationPausesBeforeGroups(t *testing.T) {
+	vfs.Context.ResetMemfsContext(true)
+	c, cloud := getTestSetupCheckpoint(t)
+	c.MaxDuration = time.Nanosecond
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.ErrorIs(t, err, ErrRollingUpdatePaused)
+
+	assertGroupInstanceCount(t, cloud, "node-1", 3)
+	assertGroupInstanceCount(t, cloud, "node-2", 3)
+	assertGroupInstanceCount(t, cloud, "master-1", 2)
+	assertGroupInstanceCount(t, cloud, "bastion-1", 1)
+
+	checkpoint := readCheckpoint(t, c)
+	if assert.NotNil(t, checkpoint, "checkpoint") {
+		assert.Empty(t, checkpoint.Completed)
+	}
+}
+
+func TestRollingUpdateMaxDurationResumesWithForce(t *testing.T) {
+	vfs.Context.ResetMemfsContext(true)
+	c, cloud := getTestSetupCheckpoint(t)
+	c.Force = true
+	c.MaxDuration = time.Hour
+	c.ClusterValidator = &pauseAfterTerminationValidator{c: c, cloud: cloud, group: "node-1", size: 3}
+
+	groups := getGroups(c.K8sClient, cloud)
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.ErrorIs(t, err, ErrRollingUpdatePaused)
+
+	assertGroupInstanceCount(t, cloud, "bastion-1", 0)
+	assertGroupInstanceCount(t, cloud, "master-1", 0)
+	assertGroupInstanceCount(t, cloud, "node-1", 2)
+	assertGroupInstanceCount(t, cloud, "node-2", 3)
+
+	checkpoint := readCheckpoint(t, c)
+	if assert.NotNil(t, checkpoint, "checkpoint") {
+		assert.True(t, checkpoint.Force, "checkpoint force")
+		assert.Equal(t, []string{"bastion-1", "master-1"}, checkpoint.Completed)
+	}
+
+	// The groups now hold the replacements, which were launched after the paused rolling update started
+	resumed, cloud := getTestSetupCheckpoint(t)
+	resumed.Force = true
+	groups = getGroups(resumed.K8sClient, cloud)
+	launchTime := time.Now()
+	groups["node-1"].Ready[0].LaunchTime = &launchTime
+
+	err = resumed.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "bastion-1", 1)
+	assertGroupInstanceCount(t, cloud, "master-1", 2)
+	assertGroupInstanceCount(t, cloud, "node-1", 1)
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+
+	assert.Nil(t, readCheckpoint(t, resumed), "checkpoint after completion")
+}
+
+func TestRollingUpdateIgnoresCheckpointOfOtherForce(t *testing.T) {
+	vfs.Context.ResetMemfsContext(true)
+	c, cloud := getTestSetupCheckpoint(t)
+	c.Force = true
+	c.MaxDuration = time.Nanosecond
+
+	err := c.RollingUpdate(getGroups(c.K8sClient, cloud), &kopsapi.InstanceGroupList{})
+	assert.ErrorIs(t, err, ErrRollingUpdatePaused)
+
+	unforced, cloud := getTestSetupCheckpoint(t)
+	groups := getGroupsAllNeedUpdate(unforced.K8sClient, cloud)
+	err = unforced.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	assert.NotNil(t, readCheckpoint(t, unforced), "checkpoint of the forced rolling update")
+}
@@ -0,0 +1,374 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+const (
+	// RollingUpdateLeasePrefix is the prefix of the name of the Lease held in the kube-system namespace while a rolling
+	// update is replacing the instances of an instance group, followed by the name of the instance group.
+	// Concurrent rolling updates of the same instance group fail, while other instance groups can be updated in parallel.
+	RollingUpdateLeasePrefix = "kops-rolling-update-"
+	// rollingUpdateLeaseNamespace is the namespace of the rolling update Lease.
+	rollingUpdateLeaseNamespace = "kube-system"
+	// rollingUpdateLeaseDuration is how long the Lease is held for without being renewed.
+	rollingUpdateLeaseDuration = 60 * time.Second
+	// rollingUpdateLeaseRenewInterval is how often the Lease is renewed while the rolling update runs.
+	rollingUpdateLeaseRenewInterval = 20 * time.Second
+
+	// ScaleDownDisabledAnnotation is the node annotation that stops cluster-autoscaler from removing a node.
+	// Rolling updates set it on the nodes of the instance group being updated, so that cluster-autoscaler
+	// doesn't remove the capacity the rolling update relies on, such as freshly launched replacement nodes.
+	ScaleDownDisabledAnnotation = "cluster-autoscaler.kubernetes.io/scale-down-disabled"
+	// ScaleDownDisabledByRollingUpdateAnnotation marks the nodes on which a rolling update set ScaleDownDisabledAnnotation,
+	// so that the annotations left by an interrupted rolling update are removed by the next one, while the annotations
+	// set by users are kept.
+	ScaleDownDisabledByRollingUpdateAnnotation = "kops.k8s.io/rolling-update-scale-down-disabled"
+)
+
+// externalDrainTaints are the taint keys, or key prefixes ending in "/", that other components put on
+// nodes they are draining or removing: cluster-autoscaler scale-downs and aws-node-termination-handler drains.
+var externalDrainTaints = []string{
+	validation.ClusterAutoscalerScaleDownTaint,
+	"aws-node-termination-handler/",
+}
+
+// rollingUpdateLease is the Lease held by a rolling update.
+type rollingUpdateLease struct {
+	name   string
+	holder string
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// acquireLease acquires the rolling update Lease of the instance group and keeps renewing it until it is released.
+// It fails if the Lease is held by another rolling update that has renewed it recently.
+func (c *RollingUpdateCluster) acquireLease(group *cloudinstances.CloudInstanceGroup) (*rollingUpdateLease, error) {
+	name := RollingUpdateLeasePrefix + group.InstanceGroup.Name
+	hostname, _ := os.Hostname()
+	holder := fmt.Sprintf("%s_%d", hostname, os.Getpid())
+
+	leases := c.K8sClient.CoordinationV1().Leases(rollingUpdateLeaseNamespace)
+	now := metav1.NewMicroTime(time.Now())
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       fi.PtrTo(holder),
+		LeaseDurationSeconds: fi.PtrTo(int32(rollingUpdateLeaseDuration.Seconds())),
+		AcquireTime:          &now,
+		RenewTime:            &now,
+	}
+
+	existing, err := leases.Get(c.Ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease := &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: rollingUpdateLeaseNamespace,
+			},
+			Spec: spec,
+		}
+		if _, err := leases.Create(c.Ctx, lease, metav1.CreateOptions{}); err != nil {
+			return nil, fmt.Errorf("error acquiring rolling update lease: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("error getting rolling update lease: %w", err)
+	} else {
+		if leaseHeldByOther(existing, holder, now.Time) {
+			return nil, fmt.Errorf("another rolling update of instance group %q is in progress (lease %s/%s held by %q); wait for it to finish or delete the lease if it is stale",
+				group.InstanceGroup.Name, rollingUpdateLeaseNamespace, name, fi.ValueOf(existing.Spec.HolderIdentity))
+		}
+		existing.Spec = spec
+		if _, err := leases.Update(c.Ctx, existing, metav1.UpdateOptions{}); err != nil {
+			return nil, fmt.Errorf("error acquiring rolling update lease: %w", err)
+		}
+	}
+	klog.Infof("Acquired rolling update lease %s/%s as %q", rollingUpdateLeaseNamespace, name, holder)
+
+	ctx, cancel := context.WithCancel(c.Ctx)
+	lease := &rollingUpdateLease{
+		name:   name,
+		holder: holder,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go c.renewLease(ctx, lease)
+
+	return lease, nil
+}
+
+// renewLease keeps the Lease from expiring until the context is cancelled.
+func (c *RollingUpdateCluster) renewLease(ctx context.Context, lease *rollingUpdateLease) {
+	defer close(lease.done)
+
+	ticker := time.NewTicker(rollingUpdateLeaseRenewInterval)
+	defer ticker.Stop()
+
+	leases := c.K8sClient.CoordinationV1().Leases(rollingUpdateLeaseNamespace)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		existing, err := leases.Get(ctx, lease.name, metav1.GetOptions{})
+		if err != nil {
+			klog.Warningf("error getting rolling update lease: %v", err)
+			continue
+		}
+		if fi.ValueOf(existing.Spec.HolderIdentity) != lease.holder {
+			klog.Warningf("rolling update lease is now held by %q", fi.ValueOf(existing.Spec.HolderIdentity))
+			continue
+		}
+		now := metav1.NewMicroTime(time.Now())
+		existing.Spec.RenewTime = &now
+		if _, err := leases.Update(ctx, existing, metav1.UpdateOptions{}); err != nil {
+			klog.Warningf("error renewing rolling update lease: %v", err)
+		}
+	}
+}
+
+// releaseLease stops renewing the Lease and releases it, unless it has since been taken over.
+func (c *RollingUpdateCluster) releaseLease(lease *rollingUpdateLease) {
+	lease.cancel()
+	<-lease.done
+
+	leases := c.K8sClient.CoordinationV1().Leases(rollingUpdateLeaseNamespace)
+	existing, err := leases.Get(context.Background(), lease.name, metav1.GetOptions{})
+	if err != nil {
+		klog.Warningf("error getting rolling update lease: %v", err)
+	} else if fi.ValueOf(existing.Spec.HolderIdentity) == lease.holder {
+		existing.Spec.HolderIdentity = nil
+		existing.Spec.AcquireTime = nil
+		existing.Spec.RenewTime = nil
+		if _, err := leases.Update(context.Background(), existing, metav1.UpdateOptions{}); err != nil {
+			klog.Warningf("error releasing rolling update lease: %v", err)
+		}
+	}
+}
+
+// leaseHeldByOther returns true if the Lease is held by another holder and has not expired.
+func leaseHeldByOther(lease *coordinationv1.Lease, holder string, now time.Time) bool {
+	other := fi.ValueOf(lease.Spec.HolderIdentity)
+	if other == "" || other == holder {
+		return false
+	}
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.Before(expiry)
+}
+
+// waitForExternalDrains waits until no node is being drained by cluster-autoscaler or
+// aws-node-termination-handler, so that the rolling update doesn't reduce capacity at the same time.
+// Nodes scheduled for update by a rolling update are not waited for: they are being replaced anyway, and
+// aws-node-termination-handler drains them itself when the rolling update terminates their instances.
+func (c *RollingUpdateCluster) waitForExternalDrains() error {
+	var draining []string
+	err := wait.PollUntilContextTimeout(c.Ctx, c.ValidateTickDuration, c.ValidationTimeout, true, func(ctx context.Context) (bool, error) {
+		nodes, err := c.K8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			klog.Warningf("(will retry): error listing nodes: %v", err)
+			return false, nil
+		}
+		draining = nil
+		for i := range nodes.Items {
+			node := &nodes.Items[i]
+			if isExternallyDrained(node) && !hasTaint(node, rollingUpdateTaintKey) {
+				draining = append(draining, node.Name)
+			}
+		}
+		if len(draining) != 0 {
+			klog.Infof("Waiting for nodes being drained by other components: %s", strings.Join(draining, ", "))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("nodes still being drained by other components after %v: %s", c.ValidationTimeout, strings.Join(draining, ", "))
+	}
+	return nil
+}
+
+// isExternallyDrained returns true if the node has a taint put on it by cluster-autoscaler or aws-node-termination-handler.
+func isExternallyDrained(node *corev1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		for _, key := range externalDrainTaints {
+			if taint.Key == key || (strings.HasSuffix(key, "/") && strings.HasPrefix(taint.Key, key)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasTaint returns true if the node has a taint with the key.
+func hasTaint(node *corev1.Node, key string) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// scaleDownGuard keeps cluster-autoscaler from removing the nodes of an instance group during its rolling update.
+type scaleDownGuard struct {
+	c     *RollingUpdateCluster
+	group string
+	// annotated are the nodes annotated by the guard, or by an earlier rolling update of the group, which are the only
+	// ones it removes the annotation from: nodes annotated by someone else keep it after the rolling update.
+	annotated map[string]bool
+}
+
+// newScaleDownGuard returns a guard for the nodes of the instance group, and annotates its current nodes.
+func (c *RollingUpdateCluster) newScaleDownGuard(group *cloudinstances.CloudInstanceGroup) *scaleDownGuard {
+	g := &scaleDownGuard{
+		c:         c,
+		group:     group.InstanceGroup.Name,
+		annotated: make(map[string]bool),
+	}
+	g.refresh()
+	return g
+}
+
+// refresh annotates the nodes of the instance group that joined since the last refresh, such as replacement nodes.
+// Errors are logged rather than returned, as the guard only makes interference from cluster-autoscaler less likely.
+func (g *scaleDownGuard) refresh() {
+	nodes, err := g.c.K8sClient.CoreV1().Nodes().List(g.c.Ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{api.NodeLabelInstanceGroup: g.group}).String(),
+	})
+	if err != nil {
+		klog.Warningf("error listing nodes of instance group %q: %v", g.group, err)
+		return
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if _, found := node.Annotations[ScaleDownDisabledByRollingUpdateAnnotation]; found {
+			g.annotated[node.Name] = true
+			continue
+		}
+		if _, found := node.Annotations[ScaleDownDisabledAnnotation]; found {
+			continue
+		}
+		if err := patchScaleDownDisabled(g.c, node.Name, true); err != nil {
+			klog.Warningf("error disabling cluster-autoscaler scale down of node %q: %v", node.Name, err)
+			continue
+		}
+		g.annotated[node.Name] = true
+	}
+}
+
+// release removes the annotation from the nodes annotated by the guard that still exist.
+// If the rolling update is interrupted first, the next rolling update removes them.
+func (g *scaleDownGuard) release() {
+	for name := range g.annotated {
+		if err := patchScaleDownDisabled(g.c, name, false); err != nil && !apierrors.IsNotFound(err) {
+			klog.Warningf("error enabling cluster-autoscaler scale down of node %q: %v", name, err)
+		}
+	}
+}
+
+// releaseStaleScaleDownGuards removes the annotations left by earlier rolling updates that were interrupted,
+// except on the nodes of the instance groups that another rolling update is replacing.
+func (c *RollingUpdateCluster) releaseStaleScaleDownGuards() {
+	nodes, err := c.K8sClient.CoreV1().Nodes().List(c.Ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Warningf("error listing nodes: %v", err)
+		return
+	}
+
+	now := time.Now()
+	inProgress := make(map[string]bool)
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if _, found := node.Annotations[ScaleDownDisabledByRollingUpdateAnnotation]; !found {
+			continue
+		}
+		group := node.Labels[api.NodeLabelInstanceGroup]
+		held, checked := inProgress[group]
+		if !checked {
+			lease, err := c.K8sClient.CoordinationV1().Leases(rollingUpdateLeaseNamespace).Get(c.Ctx, RollingUpdateLeasePrefix+group, metav1.GetOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				klog.Warningf("error getting rolling update lease of instance group %q: %v", group, err)
+				held = true
+			} else if err == nil {
+				held = leaseHeldByOther(lease, "", now)
+			}
+			inProgress[group] = held
+		}
+		if held {
+			continue
+		}
+		klog.Infof("Enabling cluster-autoscaler scale down of node %q, disabled by an interrupted rolling update", node.Name)
+		if err := patchScaleDownDisabled(c, node.Name, false); err != nil && !apierrors.IsNotFound(err) {
+			klog.Warningf("error enabling cluster-autoscaler scale down of node %q: %v", node.Name, err)
+		}
+	}
+}
+
+// anyNeedUpdate returns true if instances of the groups need updating, in which case the rolling update changes the cluster.
+func anyNeedUpdate(groups map[string]*cloudinstances.CloudInstanceGroup) bool {
+	for _, group := range groups {
+		if len(group.NeedUpdate) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// patchScaleDownDisabled sets the annotations disabling the scale down of the node by cluster-autoscaler, or removes them.
+func patchScaleDownDisabled(c *RollingUpdateCluster, nodeName string, disabled bool) error {
+	var value *string
+	if disabled {
+		value = fi.PtrTo("true")
+	}
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				ScaleDownDisabledAnnotation:                value,
+				ScaleDownDisabledByRollingUpdateAnnotation: value,
+			},
+		},
+	}
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = c.K8sClient.CoreV1().Nodes().Patch(c.Ctx, nodeName, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
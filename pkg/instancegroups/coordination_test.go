@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	v1 "k8s.io/api/core/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func createTestLease(t *testing.T, c *RollingUpdateCluster, group string, holder string, renewed time.Time) {
+	renewTime := v1meta.NewMicroTime(renewed)
+	lease := &coordinationv1.Lease{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name:      RollingUpdateLeasePrefix + group,
+			Namespace: rollingUpdateLeaseNamespace,
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       fi.PtrTo(holder),
+			LeaseDurationSeconds: fi.PtrTo(int32(60)),
+			RenewTime:            &renewTime,
+		},
+	}
+	_, err := c.K8sClient.CoordinationV1().Leases(rollingUpdateLeaseNamespace).Create(context.TODO(), lease, v1meta.CreateOptions{})
+	require.NoError(t, err)
+}
+
+func getTestLeaseHolder(t *testing.T, c *RollingUpdateCluster, group string) string {
+	lease, err := c.K8sClient.CoordinationV1().Leases(rollingUpdateLeaseNamespace).Get(context.TODO(), RollingUpdateLeasePrefix+group, v1meta.GetOptions{})
+	require.NoError(t, err)
+	return fi.ValueOf(lease.Spec.HolderIdentity)
+}
+
+func TestAcquireAndReleaseLease(t *testing.T) {
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+
+	lease, err := c.acquireLease(groups["node-1"])
+	require.NoError(t, err)
+	assert.Equal(t, lease.holder, getTestLeaseHolder(t, c, "node-1"))
+
+	c.releaseLease(lease)
+	assert.Empty(t, getTestLeaseHolder(t, c, "node-1"))
+}
+
+func TestAcquireLeaseHeldByOther(t *testing.T) {
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	createTestLease(t, c, "node-1", "other", time.Now())
+
+	_, err := c.acquireLease(groups["node-1"])
+	assert.ErrorContains(t, err, `another rolling update of instance group "node-1" is in progress`)
+	assert.Equal(t, "other", getTestLeaseHolder(t, c, "node-1"))
+
+	// The leases of other instance groups are independent
+	lease, err := c.acquireLease(groups["node-2"])
+	require.NoError(t, err)
+	c.releaseLease(lease)
+}
+
+func TestAcquireLeaseExpired(t *testing.T) {
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	createTestLease(t, c, "node-1", "other", time.Now().Add(-2*time.Minute))
+
+	lease, err := c.acquireLease(groups["node-1"])
+	require.NoError(t, err)
+	assert.Equal(t, lease.holder, getTestLeaseHolder(t, c, "node-1"))
+	c.releaseLease(lease)
+}
+
+func TestRollingUpdateLeaseHeldByOther(t *testing.T) {
+	c, cloud := getTestSetup()
+	createTestLease(t, c, "node-1", "other", time.Now())
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.ErrorContains(t, err, `another rolling update of instance group "node-1" is in progress`)
+	assertGroupInstanceCount(t, cloud, "node-1", 3)
+	// Instance groups that are not being updated by the other rolling update are still updated
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+}
+
+func TestIsExternallyDrained(t *testing.T) {
+	grid := []struct {
+		taint    string
+		expected bool
+	}{
+		{taint: "ToBeDeletedByClusterAutoscaler", expected: true},
+		{taint: "aws-node-termination-handler/spot-itn", expected: true},
+		{taint: "aws-node-termination-handler/rebalance-recommendation", expected: true},
+		{taint: "node.kubernetes.io/unschedulable", expected: false},
+		{taint: "DeletionCandidateOfClusterAutoscaler", expected: false},
+	}
+	for _, g := range grid {
+		t.Run(g.taint, func(t *testing.T) {
+			node := &v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{{Key: g.taint, Effect: v1.TaintEffectNoSchedule}}}}
+			assert.Equal(t, g.expected, isExternallyDrained(node))
+		})
+	}
+}
+
+func TestWaitForExternalDrainsTimesOut(t *testing.T) {
+	c, _ := getTestSetup()
+	c.ValidationTimeout = 10 * time.Millisecond
+
+	node := &v1.Node{
+		ObjectMeta: v1meta.ObjectMeta{Name: "node-1a.local"},
+		Spec:       v1.NodeSpec{Taints: []v1.Taint{{Key: "ToBeDeletedByClusterAutoscaler", Effect: v1.TaintEffectNoSchedule}}},
+	}
+	_, err := c.K8sClient.CoreV1().Nodes().Create(context.TODO(), node, v1meta.CreateOptions{})
+	require.NoError(t, err)
+
+	err = c.waitForExternalDrains()
+	assert.ErrorContains(t, err, "node-1a.local")
+}
+
+func TestWaitForExternalDrainsIgnoresNodesScheduledForUpdate(t *testing.T) {
+	c, _ := getTestSetup()
+	c.ValidationTimeout = 10 * time.Millisecond
+
+	// aws-node-termination-handler drains the nodes of instances terminated by the rolling update itself
+	node := &v1.Node{
+		ObjectMeta: v1meta.ObjectMeta{Name: "node-1a.local"},
+		Spec: v1.NodeSpec{Taints: []v1.Taint{
+			{Key: rollingUpdateTaintKey, Effect: v1.TaintEffectPreferNoSchedule},
+			{Key: "aws-node-termination-handler/asg-lifecycle-termination", Effect: v1.TaintEffectNoSchedule},
+		}},
+	}
+	_, err := c.K8sClient.CoreV1().Nodes().Create(context.TODO(), node, v1meta.CreateOptions{})
+	require.NoError(t, err)
+
+	assert.NoError(t, c.waitForExternalDrains())
+}
+
+func TestScaleDownGuard(t *testing.T) {
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+
+	createNode := func(name string, group string, annotations map[string]string) {
+		node := &v1.Node{
+			ObjectMeta: v1meta.ObjectMeta{
+				Name:        name,
+				Labels:      map[string]string{kopsapi.NodeLabelInstanceGroup: group},
+				Annotations: annotations,
+			},
+		}
+		_, err := c.K8sClient.CoreV1().Nodes().Create(context.TODO(), node, v1meta.CreateOptions{})
+		require.NoError(t, err)
+	}
+	getAnnotation := func(name string) (string, bool) {
+		node, err := c.K8sClient.CoreV1().Nodes().Get(context.TODO(), name, v1meta.GetOptions{})
+		require.NoError(t, err)
+		value, found := node.Annotations[ScaleDownDisabledAnnotation]
+		return value, found
+	}
+
+	createNode("guarded.local", "node-1", nil)
+	createNode("preset.local", "node-1", map[string]string{ScaleDownDisabledAnnotation: "true"})
+	createNode("other.local", "node-2", nil)
+
+	guard := c.newScaleDownGuard(groups["node-1"])
+	value, found := getAnnotation("guarded.local")
+	assert.True(t, found, "guarded node annotated")
+	assert.Equal(t, "true", value)
+	node, err := c.K8sClient.CoreV1().Nodes().Get(context.TODO(), "guarded.local", v1meta.GetOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, node.Annotations, ScaleDownDisabledByRollingUpdateAnnotation, "guarded node marked as annotated by the rolling update")
+	_, found = getAnnotation("other.local")
+	assert.False(t, found, "node of other instance group annotated")
+
+	// Replacement nodes are annotated when they have joined
+	createNode("replacement.local", "node-1", nil)
+	guard.refresh()
+	_, found = getAnnotation("replacement.local")
+	assert.True(t, found, "replacement node annotated")
+
+	// Nodes deleted during the rolling update are skipped
+	require.NoError(t, c.K8sClient.CoreV1().Nodes().Delete(context.TODO(), "guarded.local", v1meta.DeleteOptions{}))
+
+	guard.release()
+	_, found = getAnnotation("replacement.local")
+	assert.False(t, found, "annotation removed from replacement node")
+	_, found = getAnnotation("preset.local")
+	assert.True(t, found, "annotation not set by the guard kept")
+}
+
+func TestStaleScaleDownGuards(t *testing.T) {
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+
+	stale := map[string]string{ScaleDownDisabledAnnotation: "true", ScaleDownDisabledByRollingUpdateAnnotation: "true"}
+	createNode := func(name string, group string, annotations map[string]string) {
+		node := &v1.Node{
+			ObjectMeta: v1meta.ObjectMeta{
+				Name:        name,
+				Labels:      map[string]string{kopsapi.NodeLabelInstanceGroup: group},
+				Annotations: annotations,
+			},
+		}
+		_, err := c.K8sClient.CoreV1().Nodes().Create(context.TODO(), node, v1meta.CreateOptions{})
+		require.NoError(t, err)
+	}
+	hasAnnotation := func(name string) bool {
+		node, err := c.K8sClient.CoreV1().Nodes().Get(context.TODO(), name, v1meta.GetOptions{})
+		require.NoError(t, err)
+		_, found := node.Annotations[ScaleDownDisabledAnnotation]
+		return found
+	}
+
+	// Nodes left annotated by a rolling update that was interrupted
+	createNode("stale.local", "node-2", stale)
+	createNode("expired.local", "node-3", stale)
+	createTestLease(t, c, "node-3", "interrupted", time.Now().Add(-time.Hour))
+	createNode("in-progress.local", "node-4", stale)
+	createTestLease(t, c, "node-4", "other", time.Now())
+	createNode("preset.local", "node-2", map[string]string{ScaleDownDisabledAnnotation: "true"})
+
+	c.releaseStaleScaleDownGuards()
+	assert.False(t, hasAnnotation("stale.local"), "stale annotation removed")
+	assert.False(t, hasAnnotation("expired.local"), "stale annotation removed once the lease expired")
+	assert.True(t, hasAnnotation("in-progress.local"), "annotation of a rolling update in progress kept")
+	assert.True(t, hasAnnotation("preset.local"), "annotation not set by a rolling update kept")
+
+	// The guard of an instance group also takes over the annotations of an earlier rolling update of the group
+	createNode("previous.local", "node-1", stale)
+	guard := c.newScaleDownGuard(groups["node-1"])
+	guard.release()
+	assert.False(t, hasAnnotation("previous.local"), "annotation of the earlier rolling update removed")
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -30,7 +31,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/klog/v2"
@@ -109,13 +112,28 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 	numInstances := len(group.Ready) + len(group.NeedUpdate)
 	update := group.NeedUpdate
 	if c.Force {
-		update = append(update, group.Ready...)
+		update = append(update, c.forcedUpdates(group)...)
 	}
 
 	if len(update) == 0 {
 		return nil
 	}
 
+	var scaleDown *scaleDownGuard
+	if !c.CloudOnly {
+		// Hold the lease while replacing instances, so that concurrent rolling updates of the group fail rather than compete
+		lease, err := c.acquireLease(group)
+		if err != nil {
+			return err
+		}
+		defer c.releaseLease(lease)
+
+		if !isBastion {
+			scaleDown = c.newScaleDownGuard(group)
+			defer scaleDown.release()
+		}
+	}
+
 	if isBastion {
 		klog.V(3).Info("Not validating the cluster as instance is a bastion.")
 	} else if err = c.maybeValidate("", 1, group); err != nil {
@@ -146,7 +164,6 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 
 	settings := resolveSettings(c.Cluster, group.InstanceGroup, numInstances)
 
-	runningDrains := 0
 	maxSurge := settings.MaxSurge.IntValue()
 
 	if maxSurge > len(update) {
@@ -178,8 +195,76 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 		maxConcurrency = 1
 	}
 
+	if len(update) > 0 && c.canSurge(group, settings) {
+		restore, err := c.prewarmSurge(group, len(update))
+		if err != nil {
+			return err
+		}
+		defer restore()
+
+		// Detach all the instances at once, so that the warm instances replace them
+		maxSurge = len(update)
+		maxConcurrency = maxSurge + settings.MaxUnavailable.IntValue()
+	}
+
 	update = prioritizeUpdate(update)
 
+	if len(update) > 0 && c.canaryApplies(group, settings) {
+		if err := c.rollingUpdateCanary(group, update[0], settings.Canary, maxSurge > 0, sleepAfterTerminate); err != nil {
+			return err
+		}
+		update = update[1:]
+		if len(update) == 0 {
+			return nil
+		}
+		if maxSurge > len(update) {
+			maxSurge = len(update)
+		}
+		noneReady = false
+	}
+
+	if !fi.ValueOf(settings.ZoneSerial) {
+		return c.rollingUpdateInstances(group, update, settings, maxSurge, maxConcurrency, noneReady, sleepAfterTerminate, scaleDown)
+	}
+
+	// Replace the instances one zone at a time, so that nodes in two zones are never unavailable at the same time
+	zones, zoneUpdates := groupInstancesByZone(update)
+	zoneSizes := make(map[string]int)
+	for _, u := range group.Ready {
+		zoneSizes[instanceZone(u)]++
+	}
+	for _, u := range group.NeedUpdate {
+		zoneSizes[instanceZone(u)]++
+	}
+	for _, zone := range zones {
+		zoneUpdate := zoneUpdates[zone]
+		zoneSurge := maxSurge
+		if zoneSurge > len(zoneUpdate) {
+			zoneSurge = len(zoneUpdate)
+		}
+		zoneConcurrency := maxConcurrency
+		if settings.MaxUnavailablePerZone != nil && !c.Interactive {
+			unavailable, _ := intstr.GetScaledValueFromIntOrPercent(settings.MaxUnavailablePerZone, zoneSizes[zone], false)
+			if unavailable <= 0 {
+				// While we round down, percentages should resolve to a minimum of 1
+				unavailable = 1
+			}
+			zoneConcurrency = zoneSurge + unavailable
+		}
+
+		klog.Infof("Rolling update of InstanceGroup %q in zone %q: %d instances", group.InstanceGroup.Name, zone, len(zoneUpdate))
+		if err := c.rollingUpdateInstances(group, zoneUpdate, settings, zoneSurge, zoneConcurrency, noneReady, sleepAfterTerminate, scaleDown); err != nil {
+			return err
+		}
+		noneReady = false
+	}
+
+	return nil
+}
+
+// rollingUpdateInstances detaches up to maxSurge instances of the update, then drains and terminates
+// the instances of the update, maxConcurrency at a time.
+func (c *RollingUpdateCluster) rollingUpdateInstances(group *cloudinstances.CloudInstanceGroup, update []*cloudinstances.CloudInstance, settings api.RollingUpdate, maxSurge, maxConcurrency int, noneReady bool, sleepAfterTerminate time.Duration, scaleDown *scaleDownGuard) (err error) {
 	if maxSurge > 0 && !c.CloudOnly {
 		skippedNodes := 0
 		for numSurge := 1; numSurge <= maxSurge; numSurge++ {
@@ -217,9 +302,21 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 		return nil
 	}
 
+	runningDrains := 0
 	terminateChan := make(chan error, maxConcurrency)
 
 	for uIdx, u := range update {
+		// Pause between instances once the rolling update reached its maximum duration
+		if uIdx > 0 && c.deadlineReached() {
+			if err := c.finishDrains(group, runningDrains, terminateChan); err != nil {
+				return err
+			}
+			return ErrRollingUpdatePaused
+		}
+
+		if scaleDown != nil {
+			scaleDown.refresh()
+		}
 		go func(m *cloudinstances.CloudInstance) {
 			terminateChan <- c.drainTerminateAndWait(m, sleepAfterTerminate)
 		}(u)
@@ -274,22 +371,49 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 		}
 	}
 
-	if runningDrains > 0 {
-		for runningDrains > 0 {
-			err = <-terminateChan
-			runningDrains--
-			if err != nil {
-				return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
-			}
-		}
+	return c.finishDrains(group, runningDrains, terminateChan)
+}
 
-		err = c.maybeValidate(" after terminating instance", c.ValidateCount, group)
+// finishDrains waits for the running drains to complete, then validates the cluster if there were any.
+func (c *RollingUpdateCluster) finishDrains(group *cloudinstances.CloudInstanceGroup, runningDrains int, terminateChan chan error) error {
+	if runningDrains == 0 {
+		return nil
+	}
+	for runningDrains > 0 {
+		err := <-terminateChan
+		runningDrains--
 		if err != nil {
-			return err
+			return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
 		}
 	}
 
-	return nil
+	return c.maybeValidate(" after terminating instance", c.ValidateCount, group)
+}
+
+// groupInstancesByZone returns the zones of the instances, in order, and the instances in each zone.
+func groupInstancesByZone(update []*cloudinstances.CloudInstance) ([]string, map[string][]*cloudinstances.CloudInstance) {
+	var zones []string
+	byZone := make(map[string][]*cloudinstances.CloudInstance)
+	for _, u := range update {
+		zone := instanceZone(u)
+		if _, found := byZone[zone]; !found {
+			zones = append(zones, zone)
+		}
+		byZone[zone] = append(byZone[zone], u)
+	}
+	sort.Strings(zones)
+	return zones, byZone
+}
+
+// instanceZone returns the zone of the instance, from the cloud or from the labels of its node.
+func instanceZone(u *cloudinstances.CloudInstance) string {
+	if u.Zone != "" {
+		return u.Zone
+	}
+	if u.Node != nil {
+		return u.Node.Labels[corev1.LabelTopologyZone]
+	}
+	return ""
 }
 
 func prioritizeUpdate(update []*cloudinstances.CloudInstance) []*cloudinstances.CloudInstance {
@@ -427,6 +551,10 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInst
 	} else if c.CloudOnly {
 		klog.Warning("Not draining cluster nodes as 'cloudonly' flag is set.")
 	} else {
+		if err := c.waitForExternalDrains(); err != nil {
+			return err
+		}
+
 		if u.Node != nil {
 			klog.Infof("Draining the node: %q.", nodeName)
 
@@ -657,20 +785,15 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 		return fmt.Errorf("node name not set")
 	}
 
-	helper := &drain.Helper{
-		Ctx:                 c.Ctx,
-		Client:              c.K8sClient,
-		Force:               true,
-		GracePeriodSeconds:  -1,
-		IgnoreAllDaemonSets: true,
-		Out:                 os.Stdout,
-		ErrOut:              os.Stderr,
-		Timeout:             c.DrainTimeout,
-
-		// We want to proceed even when pods are using emptyDir volumes
-		DeleteEmptyDirData: true,
+	settings := &api.RollingUpdateDrain{}
+	if u.CloudInstanceGroup != nil && u.CloudInstanceGroup.InstanceGroup != nil {
+		if drainSettings := resolveSettings(c.Cluster, u.CloudInstanceGroup.InstanceGroup, 0).Drain; drainSettings != nil {
+			settings = drainSettings
+		}
 	}
 
+	helper := c.newDrainHelper(settings)
+
 	if err := drain.RunCordonOrUncordon(helper, u.Node, true); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
@@ -703,11 +826,36 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 		}
 	}
 
+	if len(settings.SkipPDBLabels) > 0 {
+		// Delete the pods that do not wait for their PodDisruptionBudgets first, so that they do not hold up the drain
+		skipPDB := c.newDrainHelper(settings)
+		skipPDB.DisableEviction = true
+		skipPDB.PodSelector = labels.SelectorFromSet(settings.SkipPDBLabels).String()
+		if err := drain.RunNodeDrain(skipPDB, u.Node.Name); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("error deleting pods of node: %v", err)
+		}
+	}
+
 	if err := drain.RunNodeDrain(helper, u.Node.Name); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
-		return fmt.Errorf("error draining node: %v", err)
+		if settings.TimeoutPolicy != api.RollingUpdateDrainTimeoutPolicyForce {
+			return fmt.Errorf("error draining node: %v", err)
+		}
+
+		klog.Warningf("Failed to drain node %q, deleting its pods without waiting for their PodDisruptionBudgets: %v", u.Node.Name, err)
+		forced := c.newDrainHelper(settings)
+		forced.DisableEviction = true
+		if err := drain.RunNodeDrain(forced, u.Node.Name); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("error force draining node: %v", err)
+		}
 	}
 
 	if c.PostDrainDelay > 0 {
@@ -718,6 +866,30 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 	return nil
 }
 
+// newDrainHelper builds the helper that drains a node, with the drain settings of its instance group.
+func (c *RollingUpdateCluster) newDrainHelper(settings *api.RollingUpdateDrain) *drain.Helper {
+	helper := &drain.Helper{
+		Ctx:                 c.Ctx,
+		Client:              c.K8sClient,
+		Force:               true,
+		GracePeriodSeconds:  -1,
+		IgnoreAllDaemonSets: true,
+		Out:                 os.Stdout,
+		ErrOut:              os.Stderr,
+		Timeout:             c.DrainTimeout,
+
+		// We want to proceed even when pods are using emptyDir volumes
+		DeleteEmptyDirData: true,
+	}
+	if settings.GracePeriod != nil {
+		helper.GracePeriodSeconds = int(settings.GracePeriod.Duration.Seconds())
+	}
+	if settings.Timeout != nil {
+		helper.Timeout = settings.Timeout.Duration
+	}
+	return helper
+}
+
 // deleteNode deletes a node from the k8s API.  It does not delete the underlying instance.
 func (c *RollingUpdateCluster) deleteNode(node *corev1.Node) error {
 	var options metav1.DeleteOptions
@@ -751,3 +923,14 @@ func (c *RollingUpdateCluster) UpdateSingleInstance(cloudMember *cloudinstances.
 
 	return c.drainTerminateAndWait(cloudMember, 0)
 }
+
+// ValidateAfterUpdate validates the cluster after an instance of the group has been replaced,
+// honouring the CloudOnly, FailOnValidate and ValidateCount settings.
+func (c *RollingUpdateCluster) ValidateAfterUpdate(group *cloudinstances.CloudInstanceGroup) error {
+	return c.maybeValidate(" after replacing instance", c.ValidateCount, group)
+}
+
+// ValidateAfterRestart validates the cluster after the static pods of an instance of the group were restarted in place.
+func (c *RollingUpdateCluster) ValidateAfterRestart(group *cloudinstances.CloudInstanceGroup) error {
+	return c.maybeValidate(" after restarting static pods", c.ValidateCount, group)
+}
@@ -18,11 +18,19 @@ package instancegroups
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	testingclient "k8s.io/client-go/testing"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/cloudinstances"
 )
@@ -66,3 +74,86 @@ func TestWarmPoolOnlyRoll(t *testing.T) {
 		}
 	}
 }
+
+// setupDrainTest returns an instance of a group with the drain settings, whose node runs the pods, and whose pods
+// fail to be evicted.
+func setupDrainTest(t *testing.T, settings *kopsapi.RollingUpdateDrain, pods ...*corev1.Pod) (*RollingUpdateCluster, *cloudinstances.CloudInstance) {
+	c, cloud := getTestSetup()
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 1, 1)
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{Drain: settings}
+	u := groups["node-1"].NeedUpdate[0]
+
+	fakeClient := c.K8sClient.(*fake.Clientset)
+	fakeClient.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{{Name: "pods/eviction", Kind: "Eviction", Group: "policy", Version: "v1"}},
+		},
+	}
+	fakeClient.PrependReactor("create", "pods", func(action testingclient.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" {
+			return true, nil, apierrors.NewInternalError(errors.New("eviction failed"))
+		}
+		return false, nil, nil
+	})
+	for _, pod := range pods {
+		pod.Spec.NodeName = u.Node.Name
+		if err := fakeClient.Tracker().Add(pod); err != nil {
+			t.Fatalf("error adding pod: %v", err)
+		}
+	}
+	return c, u
+}
+
+func deletedPods(c *RollingUpdateCluster) []string {
+	var deleted []string
+	for _, action := range c.K8sClient.(*fake.Clientset).Actions() {
+		if a, ok := action.(testingclient.DeleteAction); ok && a.GetResource().Resource == "pods" {
+			deleted = append(deleted, a.GetName())
+		}
+	}
+	return deleted
+}
+
+func TestDrainNodeSkipPDBLabels(t *testing.T) {
+	batch := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "default", Labels: map[string]string{"app": "batch"}}}
+	c, u := setupDrainTest(t, &kopsapi.RollingUpdateDrain{SkipPDBLabels: map[string]string{"app": "batch"}}, batch)
+
+	err := c.drainNode(u)
+	assert.NoError(t, err, "drain")
+	assert.Equal(t, []string{"batch"}, deletedPods(c))
+}
+
+func TestDrainNodeTimeoutPolicy(t *testing.T) {
+	web := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	c, u := setupDrainTest(t, nil, web)
+
+	err := c.drainNode(u)
+	assert.Error(t, err, "drain")
+	assert.Empty(t, deletedPods(c))
+
+	web = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	c, u = setupDrainTest(t, &kopsapi.RollingUpdateDrain{TimeoutPolicy: kopsapi.RollingUpdateDrainTimeoutPolicyForce}, web)
+
+	err = c.drainNode(u)
+	assert.NoError(t, err, "drain")
+	assert.Equal(t, []string{"web"}, deletedPods(c))
+}
+
+func TestNewDrainHelper(t *testing.T) {
+	c, _ := getTestSetup()
+	c.DrainTimeout = 15 * time.Minute
+
+	helper := c.newDrainHelper(&kopsapi.RollingUpdateDrain{})
+	assert.Equal(t, -1, helper.GracePeriodSeconds)
+	assert.Equal(t, 15*time.Minute, helper.Timeout)
+
+	helper = c.newDrainHelper(&kopsapi.RollingUpdateDrain{
+		GracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+		Timeout:     &metav1.Duration{Duration: 5 * time.Minute},
+	})
+	assert.Equal(t, 30, helper.GracePeriodSeconds)
+	assert.Equal(t, 5*time.Minute, helper.Timeout)
+}
@@ -83,8 +83,20 @@ type RollingUpdateCluster struct {
 	// DrainTimeout is the maximum amount of time to wait while draining a node.
 	DrainTimeout time.Duration
 
+	// MaxDuration is the maximum amount of time to spend replacing instances before pausing the rolling update.
+	// The progress is recorded in the state store, so that the next rolling update resumes it. Zero means no limit.
+	MaxDuration time.Duration
+
 	// Options holds user-specified options
 	Options RollingUpdateOptions
+
+	// deadline is when the rolling update pauses, or zero if it runs to completion
+	deadline time.Time
+	// checkpoint is the progress of the rolling update, including that of the paused rolling update it resumes
+	checkpoint      *rollingUpdateCheckpoint
+	checkpointMutex sync.Mutex
+	// keepCheckpoint is true if the state store holds the progress of a paused rolling update that this one does not resume
+	keepCheckpoint bool
 }
 
 type RollingUpdateOptions struct {
@@ -107,11 +119,22 @@ func (*RollingUpdateCluster) AdjustNeedUpdate(groups map[string]*cloudinstances.
 
 // RollingUpdate performs a rolling update on a K8s Cluster.
 func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.CloudInstanceGroup, instanceGroups *api.InstanceGroupList) error {
+	groups = skipSuspendedGroups(groups)
 	if len(groups) == 0 {
 		klog.Info("Cloud Instance Group length is zero. Not doing a rolling-update.")
 		return nil
 	}
 
+	if c.MaxDuration > 0 {
+		c.deadline = time.Now().Add(c.MaxDuration)
+	}
+	if err := c.loadCheckpoint(); err != nil {
+		return err
+	}
+	if c.K8sClient != nil && !c.CloudOnly && (c.Force || anyNeedUpdate(groups)) {
+		c.releaseStaleScaleDownGuards()
+	}
+
 	var resultsMutex sync.Mutex
 	results := make(map[string]error)
 
@@ -147,7 +170,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 
 				defer wg.Done()
 
-				err := c.rollingUpdateInstanceGroup(bastionGroups[k], c.BastionInterval)
+				err := c.rollingUpdateGroup(k, bastionGroups[k], c.BastionInterval)
 
 				resultsMutex.Lock()
 				results[k] = err
@@ -159,11 +182,17 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 	}
 
 	// Do not continue update if bastion(s) failed
+	paused := false
 	for _, err := range results {
-		if err != nil {
+		if stderrors.Is(err, ErrRollingUpdatePaused) {
+			paused = true
+		} else if err != nil {
 			return fmt.Errorf("bastion not healthy after update, stopping rolling-update: %q", err)
 		}
 	}
+	if paused {
+		return c.pause()
+	}
 
 	// Upgrade control plane next.
 	{
@@ -172,7 +201,10 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		// and we don't want to roll all the control-plane nodes at the same time.  See issue #284
 
 		for _, k := range sortGroups(masterGroups) {
-			err := c.rollingUpdateInstanceGroup(masterGroups[k], c.MasterInterval)
+			err := c.rollingUpdateGroup(k, masterGroups[k], c.MasterInterval)
+			if stderrors.Is(err, ErrRollingUpdatePaused) {
+				return c.pause()
+			}
 			// Do not continue update if control-plane node(s) failed; cluster is potentially in an unhealthy state.
 			if err != nil {
 				return fmt.Errorf("control-plane node not healthy after update, stopping rolling-update: %q", err)
@@ -187,7 +219,10 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		}
 
 		for _, k := range sortGroups(apiServerGroups) {
-			err := c.rollingUpdateInstanceGroup(apiServerGroups[k], c.NodeInterval)
+			err := c.rollingUpdateGroup(k, apiServerGroups[k], c.NodeInterval)
+			if stderrors.Is(err, ErrRollingUpdatePaused) {
+				return c.pause()
+			}
 			results[k] = err
 			if err != nil {
 				klog.Errorf("failed to roll InstanceGroup %q: %v", k, err)
@@ -212,7 +247,10 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		}
 
 		for _, k := range sortGroups(nodeGroups) {
-			err := c.rollingUpdateInstanceGroup(nodeGroups[k], c.NodeInterval)
+			err := c.rollingUpdateGroup(k, nodeGroups[k], c.NodeInterval)
+			if stderrors.Is(err, ErrRollingUpdatePaused) {
+				return c.pause()
+			}
 			results[k] = err
 			if err != nil {
 				klog.Errorf("failed to roll InstanceGroup %q: %v", k, err)
@@ -231,10 +269,30 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		}
 	}
 
+	if len(errs) == 0 {
+		c.removeCheckpoint()
+	}
+
 	klog.Infof("Rolling update completed for cluster %q!", c.ClusterName)
 	return errors.NewAggregate(errs)
 }
 
+// rollingUpdateGroup updates the instance group, unless a paused rolling update already replaced its instances,
+// and records its completion. It returns ErrRollingUpdatePaused if the rolling update reached its maximum duration.
+func (c *RollingUpdateCluster) rollingUpdateGroup(name string, group *cloudinstances.CloudInstanceGroup, sleepAfterTerminate time.Duration) error {
+	if c.skipCompleted(name, group) {
+		return nil
+	}
+	if c.deadlineReached() {
+		return ErrRollingUpdatePaused
+	}
+	if err := c.rollingUpdateInstanceGroup(group, sleepAfterTerminate); err != nil {
+		return err
+	}
+	c.markCompleted(name)
+	return nil
+}
+
 func sortGroups(groupMap map[string]*cloudinstances.CloudInstanceGroup) []string {
 	groups := make([]string, 0, len(groupMap))
 	for group := range groupMap {
@@ -253,3 +311,17 @@ func sortGroups(groupMap map[string]*cloudinstances.CloudInstanceGroup) []string
 func isExitableError(err error) bool {
 	return stderrors.Is(err, &ValidationTimeoutError{})
 }
+
+// skipSuspendedGroups returns the groups whose instance group is not suspended; a suspended instance group is scaled to zero
+// and any instance still terminating is not replaced.
+func skipSuspendedGroups(groups map[string]*cloudinstances.CloudInstanceGroup) map[string]*cloudinstances.CloudInstanceGroup {
+	active := make(map[string]*cloudinstances.CloudInstanceGroup, len(groups))
+	for name, group := range groups {
+		if group.InstanceGroup != nil && group.InstanceGroup.IsSuspended() {
+			klog.Infof("Skipping suspended instance group %q", name)
+			continue
+		}
+		active[name] = group
+	}
+	return active
+}
@@ -63,6 +63,7 @@ func getTestSetup() (*RollingUpdateCluster, *awsup.MockAWSCloud) {
 
 	cluster := &kopsapi.Cluster{}
 	cluster.Name = "test.k8s.local"
+	cluster.Spec.KubernetesVersion = "1.30.0"
 
 	c := &RollingUpdateCluster{
 		Ctx:                     context.Background(),
@@ -83,6 +84,18 @@ func getTestSetup() (*RollingUpdateCluster, *awsup.MockAWSCloud) {
 	return c, mockcloud
 }
 
+// nodeActions returns the actions on the fake k8s client, other than those on the rolling update lease.
+func nodeActions(c *RollingUpdateCluster) []testingclient.Action {
+	var actions []testingclient.Action
+	for _, action := range c.K8sClient.(*fake.Clientset).Actions() {
+		if action.GetResource().Resource == "leases" {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
 type successfulClusterValidator struct{}
 
 func (*successfulClusterValidator) Validate() (*validation.ValidationCluster, error) {
@@ -220,7 +233,7 @@ func TestRollingUpdateAllNeedUpdate(t *testing.T) {
 	excluded := ""
 	tainted := map[string]bool{}
 	deleted := map[string]bool{}
-	for _, action := range c.K8sClient.(*fake.Clientset).Actions() {
+	for _, action := range nodeActions(c) {
 		switch a := action.(type) {
 		case testingclient.PatchAction:
 			if string(a.GetPatch()) == cordonPatch {
@@ -280,6 +293,18 @@ func TestRollingUpdateAllNeedUpdateCloudonly(t *testing.T) {
 	}
 }
 
+func TestRollingUpdateSkipsSuspendedGroups(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	groups["node-1"].InstanceGroup.Spec.Suspended = fi.PtrTo(true)
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 3)
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+}
+
 func TestRollingUpdateAllNeedUpdateNoFailOnValidate(t *testing.T) {
 	ctx := context.TODO()
 	c, cloud := getTestSetup()
@@ -836,7 +861,7 @@ func TestRollingUpdateTaintAllButOneNeedUpdate(t *testing.T) {
 	excluded := ""
 	tainted := map[string]bool{}
 	deleted := map[string]bool{}
-	for _, action := range c.K8sClient.(*fake.Clientset).Actions() {
+	for _, action := range nodeActions(c) {
 		switch a := action.(type) {
 		case testingclient.PatchAction:
 			if string(a.GetPatch()) == cordonPatch {
@@ -886,7 +911,7 @@ func TestRollingUpdateMaxSurgeIgnoredForMaster(t *testing.T) {
 	excluded := ""
 	tainted := map[string]bool{}
 	deleted := map[string]bool{}
-	for _, action := range c.K8sClient.(*fake.Clientset).Actions() {
+	for _, action := range nodeActions(c) {
 		switch a := action.(type) {
 		case testingclient.PatchAction:
 			if string(a.GetPatch()) == cordonPatch {
@@ -1349,6 +1374,118 @@ func TestRollingUpdateMaxSurgeGreaterThanNeedUpdate(t *testing.T) {
 	assert.Equal(t, 2, countDetach.Count)
 }
 
+// zoneSerialTest records the zones of the terminated instances, and the most instances and zones terminating at the same time.
+type zoneSerialTest struct {
+	awsinterfaces.EC2API
+	zones map[string]string
+
+	mutex          sync.Mutex
+	terminating    map[string]int
+	concurrent     int
+	maxConcurrent  int
+	maxZones       int
+	terminatedZone []string
+}
+
+func (z *zoneSerialTest) TerminateInstances(ctx context.Context, input *ec2.TerminateInstancesInput, optFns ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error) {
+	z.mutex.Lock()
+	for _, id := range input.InstanceIds {
+		zone := z.zones[id]
+		z.terminating[zone]++
+		z.terminatedZone = append(z.terminatedZone, zone)
+		z.concurrent++
+	}
+	if z.concurrent > z.maxConcurrent {
+		z.maxConcurrent = z.concurrent
+	}
+	if len(z.terminating) > z.maxZones {
+		z.maxZones = len(z.terminating)
+	}
+	z.mutex.Unlock()
+
+	// Give concurrent drains a chance to overlap
+	time.Sleep(5 * time.Millisecond)
+
+	z.mutex.Lock()
+	for _, id := range input.InstanceIds {
+		zone := z.zones[id]
+		z.terminating[zone]--
+		z.concurrent--
+		if z.terminating[zone] == 0 {
+			delete(z.terminating, zone)
+		}
+	}
+	z.mutex.Unlock()
+
+	return z.EC2API.TerminateInstances(ctx, input, optFns...)
+}
+
+func TestRollingUpdateZoneSerial(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	four := intstr.FromInt(4)
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		MaxUnavailable: &four,
+		ZoneSerial:     fi.PtrTo(true),
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 6, 6)
+
+	zoneSerial := &zoneSerialTest{
+		EC2API:      cloud.MockEC2,
+		zones:       make(map[string]string),
+		terminating: make(map[string]int),
+	}
+	cloud.MockEC2 = zoneSerial
+	for i, u := range groups["node-1"].NeedUpdate {
+		u.Zone = []string{"us-east-1b", "us-east-1a", "us-east-1c"}[i%3]
+		zoneSerial.zones[u.ID] = u.Zone
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	assert.Equal(t, 1, zoneSerial.maxZones, "zones terminating at the same time")
+	assert.Equal(t, []string{"us-east-1a", "us-east-1a", "us-east-1b", "us-east-1b", "us-east-1c", "us-east-1c"}, zoneSerial.terminatedZone)
+}
+
+func TestRollingUpdateMaxUnavailablePerZone(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	four := intstr.FromInt(4)
+	one := intstr.FromInt(1)
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		MaxUnavailable:        &four,
+		ZoneSerial:            fi.PtrTo(true),
+		MaxUnavailablePerZone: &one,
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 4, 4)
+
+	zoneSerial := &zoneSerialTest{
+		EC2API:      cloud.MockEC2,
+		zones:       make(map[string]string),
+		terminating: make(map[string]int),
+	}
+	cloud.MockEC2 = zoneSerial
+	for i, u := range groups["node-1"].NeedUpdate {
+		// The zone comes from the node when the cloud does not report it
+		zone := []string{"us-east-1a", "us-east-1b"}[i%2]
+		u.Node.Labels = map[string]string{v1.LabelTopologyZone: zone}
+		zoneSerial.zones[u.ID] = zone
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	assert.Equal(t, 1, zoneSerial.maxConcurrent, "instances terminating at the same time")
+	assert.Equal(t, []string{"us-east-1a", "us-east-1a", "us-east-1b", "us-east-1b"}, zoneSerial.terminatedZone)
+}
+
 type failDetachAutoscaling struct {
 	awsinterfaces.AutoScalingAPI
 }
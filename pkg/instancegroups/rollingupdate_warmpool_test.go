@@ -20,6 +20,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/stretchr/testify/assert"
@@ -113,3 +115,61 @@ func (t *warmPoolBeforeJoinedNodesTest) TerminateInstances(ctx context.Context,
 
 	return t.EC2API.TerminateInstances(ctx, input, optFns...)
 }
+
+func TestRollingUpdateSurgeStrategy(t *testing.T) {
+	c, cloud := getTestSetup()
+	c.Cluster.Spec.CloudProvider.AWS = &kops.AWSSpec{}
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kops.InstanceGroupRoleNode, 3, 3)
+	groups["node-1"].InstanceGroup.Spec.WarmPool = &kops.WarmPoolSpec{}
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kops.RollingUpdate{
+		Strategy: kops.RollingUpdateStrategySurge,
+	}
+
+	mockASG := cloud.MockAutoscaling.(*mockautoscaling.MockAutoscaling)
+	mockASG.WarmPools = map[string]*autoscalingtypes.WarmPoolConfiguration{
+		"node-1": {MinSize: aws.Int32(1)},
+	}
+	surge := &surgeTest{MockAutoscaling: mockASG}
+	cloud.MockAutoscaling = surge
+	cloud.MockEC2 = &ec2IgnoreTags{EC2API: &surgeTerminationsTest{EC2API: cloud.MockEC2, surge: surge}}
+
+	err := c.RollingUpdate(groups, &kops.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Equal(t, []int32{3, 1}, surge.warmPoolMinSizes, "minimum sizes of the warm pool")
+	assert.Equal(t, 3, surge.detachedBeforeTermination, "instances detached before the first termination")
+	assert.Equal(t, 3, surge.terminations, "number of terminations")
+}
+
+// surgeTest records the changes to the warm pool, and the instances detached before the first termination.
+type surgeTest struct {
+	*mockautoscaling.MockAutoscaling
+	warmPoolMinSizes          []int32
+	detached                  int
+	detachedBeforeTermination int
+	terminations              int
+}
+
+func (m *surgeTest) PutWarmPool(ctx context.Context, input *autoscaling.PutWarmPoolInput, optFns ...func(*autoscaling.Options)) (*autoscaling.PutWarmPoolOutput, error) {
+	m.warmPoolMinSizes = append(m.warmPoolMinSizes, aws.ToInt32(input.MinSize))
+	return m.MockAutoscaling.PutWarmPool(ctx, input, optFns...)
+}
+
+func (m *surgeTest) DetachInstances(ctx context.Context, input *autoscaling.DetachInstancesInput, optFns ...func(*autoscaling.Options)) (*autoscaling.DetachInstancesOutput, error) {
+	m.detached += len(input.InstanceIds)
+	return &autoscaling.DetachInstancesOutput{}, nil
+}
+
+type surgeTerminationsTest struct {
+	awsinterfaces.EC2API
+	surge *surgeTest
+}
+
+func (t *surgeTerminationsTest) TerminateInstances(ctx context.Context, input *ec2.TerminateInstancesInput, optFns ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error) {
+	if t.surge.terminations == 0 {
+		t.surge.detachedBeforeTermination = t.surge.detached
+	}
+	t.surge.terminations++
+	return t.EC2API.TerminateInstances(ctx, input, optFns...)
+}
@@ -40,12 +40,35 @@ func resolveSettings(cluster *kops.Cluster, group *kops.InstanceGroup, numInstan
 		if rollingUpdate.MaxSurge == nil {
 			rollingUpdate.MaxSurge = def.MaxSurge
 		}
+		if rollingUpdate.Canary == nil {
+			rollingUpdate.Canary = def.Canary
+		}
+		if rollingUpdate.ZoneSerial == nil {
+			rollingUpdate.ZoneSerial = def.ZoneSerial
+		}
+		if rollingUpdate.MaxUnavailablePerZone == nil {
+			rollingUpdate.MaxUnavailablePerZone = def.MaxUnavailablePerZone
+		}
+		if rollingUpdate.Strategy == "" {
+			rollingUpdate.Strategy = def.Strategy
+		}
+		if rollingUpdate.Drain == nil {
+			rollingUpdate.Drain = def.Drain
+		}
 	}
 
 	if rollingUpdate.DrainAndTerminate == nil {
 		rollingUpdate.DrainAndTerminate = fi.PtrTo(true)
 	}
 
+	if rollingUpdate.ZoneSerial == nil {
+		rollingUpdate.ZoneSerial = fi.PtrTo(false)
+	}
+
+	if rollingUpdate.Strategy == "" {
+		rollingUpdate.Strategy = kops.RollingUpdateStrategyReplace
+	}
+
 	if rollingUpdate.MaxSurge == nil {
 		val := intstr.FromInt(0)
 		if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS && !featureflag.Spotinst.Enabled() && group.Spec.Manager != kops.InstanceManagerKarpenter {
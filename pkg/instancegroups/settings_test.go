@@ -47,6 +47,11 @@ func TestSettings(t *testing.T) {
 			defaultValue:    intstr.FromInt(0),
 			nonDefaultValue: intstr.FromInt(2),
 		},
+		{
+			name:            "ZoneSerial",
+			defaultValue:    false,
+			nonDefaultValue: true,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			defaultCluster := &kops.RollingUpdate{}
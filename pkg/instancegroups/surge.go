@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// surgeWarmPoolTimeout is the maximum time to wait for the warm pool to hold the replacements of the instances being updated.
+const surgeWarmPoolTimeout = 15 * time.Minute
+
+// canSurge returns true if the instances of the group can be replaced with the surge strategy.
+func (c *RollingUpdateCluster) canSurge(group *cloudinstances.CloudInstanceGroup, settings api.RollingUpdate) bool {
+	if settings.Strategy != api.RollingUpdateStrategySurge || c.CloudOnly || c.Interactive {
+		return false
+	}
+
+	ig := group.InstanceGroup
+	if ig.Spec.Role == api.InstanceGroupRoleControlPlane || ig.Spec.Manager == api.InstanceManagerKarpenter {
+		klog.Warningf("Instance group %q cannot surge, replacing its instances as usual", ig.Name)
+		return false
+	}
+	if _, ok := c.Cloud.(awsup.AWSCloud); !ok {
+		klog.Warningf("Instance group %q cannot surge without a warm pool, replacing its instances as usual", ig.Name)
+		return false
+	}
+	if aws := c.Cluster.Spec.CloudProvider.AWS; aws == nil || !aws.WarmPool.ResolveDefaults(ig).IsEnabled() {
+		klog.Warningf("Instance group %q cannot surge without a warm pool, replacing its instances as usual", ig.Name)
+		return false
+	}
+	return true
+}
+
+// prewarmSurge provisions the replacements of count instances in the warm pool of the group, so that detaching
+// the instances moves warm instances into service instead of launching new ones.
+// The returned function restores the size of the warm pool.
+func (c *RollingUpdateCluster) prewarmSurge(group *cloudinstances.CloudInstanceGroup, count int) (func(), error) {
+	klog.Infof("Provisioning %d instances in the warm pool of instance group %q", count, group.InstanceGroup.Name)
+
+	restore, err := awsup.PrewarmInstances(c.Ctx, c.Cloud.(awsup.AWSCloud), group, count, surgeWarmPoolTimeout)
+	release := func() {
+		if restore == nil {
+			return
+		}
+		if err := restore(); err != nil {
+			klog.Warningf("failed to restore the warm pool of instance group %q: %v", group.InstanceGroup.Name, err)
+		}
+	}
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("error provisioning the warm pool of instance group %q: %w", group.InstanceGroup.Name, err)
+	}
+	return release, nil
+}
@@ -32,9 +32,10 @@ import (
 	"time"
 
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/dns"
 	"k8s.io/kops/upup/pkg/fi"
-	"k8s.io/kops/upup/pkg/fi/cloudup"
 )
 
 type Client struct {
@@ -49,7 +50,17 @@ type Client struct {
 	httpClient *http.Client
 }
 
+// Query bootstraps a node, requesting the certificates and configuration it needs.
 func (b *Client) Query(ctx context.Context, req any, resp any) error {
+	return b.query(ctx, "/bootstrap", req, resp)
+}
+
+// QueryAdminCredentials requests short-lived admin credentials for the cloud identity of an operator.
+func (b *Client) QueryAdminCredentials(ctx context.Context, req *nodeup.AdminCredentialsRequest, resp *nodeup.AdminCredentialsResponse) error {
+	return b.query(ctx, "/admin-credentials", req, resp)
+}
+
+func (b *Client) query(ctx context.Context, requestPath string, req any, resp any) error {
 	if b.httpClient == nil {
 		certPool := x509.NewCertPool()
 		certPool.AppendCertsFromPEM(b.CAs)
@@ -75,7 +86,7 @@ func (b *Client) Query(ctx context.Context, req any, resp any) error {
 			return fi.NewTryAgainLaterError(fmt.Sprintf("kops-controller DNS not setup yet (not found: %v)", dnsErr))
 		}
 		return err
-	} else if len(ips) == 1 && (ips[0].String() == cloudup.PlaceholderIP || ips[0].String() == cloudup.PlaceholderIPv6) {
+	} else if len(ips) == 1 && dns.IsPlaceholderIP(ips[0].String()) {
 		return fi.NewTryAgainLaterError(fmt.Sprintf("kops-controller DNS not setup yet (placeholder IP found: %v)", ips))
 	}
 
@@ -84,9 +95,9 @@ func (b *Client) Query(ctx context.Context, req any, resp any) error {
 		return err
 	}
 
-	bootstrapURL := b.BaseURL
-	bootstrapURL.Path = path.Join(bootstrapURL.Path, "/bootstrap")
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", bootstrapURL.String(), bytes.NewReader(reqBytes))
+	requestURL := b.BaseURL
+	requestURL.Path = path.Join(requestURL.Path, requestPath)
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), bytes.NewReader(reqBytes))
 	if err != nil {
 		return err
 	}
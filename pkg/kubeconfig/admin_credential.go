@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/kops/pkg/pki"
+)
+
+const (
+	authenticationPluginLifetimeFlag      = "--lifetime="
+	authenticationPluginCloudIdentityFlag = "--cloud-identity"
+)
+
+// AdminCredential describes the admin credential that a kubeconfig holds for a cluster.
+type AdminCredential struct {
+	// Lifetime is the lifetime the credential was issued with, or zero if it is not known.
+	Lifetime time.Duration
+	// NotAfter is the expiry of a static client certificate; it is zero for the authentication plugin.
+	NotAfter time.Time
+	// AutoRefresh is true if the credential is issued on demand by the kOps authentication plugin.
+	AutoRefresh bool
+	// CloudIdentity is true if the authentication plugin obtains the credential from kops-controller
+	// in exchange for the cloud identity of the operator.
+	CloudIdentity bool
+}
+
+// FindAdminCredential returns the admin credential of the context of a cluster, or nil if the
+// context doesn't exist or uses another user, such as one configured with --user.
+func FindAdminCredential(config *clientcmdapi.Config, clusterName string) (*AdminCredential, error) {
+	context := config.Contexts[clusterName]
+	if context == nil || context.AuthInfo != clusterName {
+		return nil, nil
+	}
+	authInfo := config.AuthInfos[clusterName]
+	if authInfo == nil {
+		return nil, nil
+	}
+
+	if authInfo.Exec != nil {
+		credential := &AdminCredential{AutoRefresh: true}
+		for _, arg := range authInfo.Exec.Args {
+			if strings.HasPrefix(arg, authenticationPluginLifetimeFlag) {
+				lifetime, err := time.ParseDuration(strings.TrimPrefix(arg, authenticationPluginLifetimeFlag))
+				if err != nil {
+					return nil, fmt.Errorf("parsing lifetime of authentication plugin for %q: %w", clusterName, err)
+				}
+				credential.Lifetime = lifetime
+			}
+			if arg == authenticationPluginCloudIdentityFlag {
+				credential.CloudIdentity = true
+			}
+		}
+		return credential, nil
+	}
+
+	if len(authInfo.ClientCertificateData) == 0 {
+		return nil, nil
+	}
+	cert, err := pki.ParsePEMCertificate(authInfo.ClientCertificateData)
+	if err != nil {
+		return nil, fmt.Errorf("parsing client certificate for %q: %w", clusterName, err)
+	}
+	// The certificate was issued with the lifetime, but is valid from some time before its issuance
+	lifetime := cert.Certificate.NotAfter.Sub(cert.Certificate.NotBefore) - pki.CertificateBackdate
+	return &AdminCredential{
+		Lifetime: lifetime.Round(time.Minute),
+		NotAfter: cert.Certificate.NotAfter,
+	}, nil
+}
+
+// NeedsRenewal returns true if a static credential has expired or has less than a quarter of its lifetime left.
+func (c *AdminCredential) NeedsRenewal(now time.Time) bool {
+	if c.AutoRefresh {
+		return false
+	}
+	return now.After(c.NotAfter.Add(-c.Lifetime / 4))
+}
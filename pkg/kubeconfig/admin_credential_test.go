@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/kops/pkg/pki"
+)
+
+func TestFindAdminCredential(t *testing.T) {
+	notBefore := time.Date(2017, 12, 27, 23, 52, 40, 0, time.UTC)
+	notAfter := time.Date(2027, 12, 27, 23, 52, 40, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		context  *clientcmdapi.Context
+		authInfo *clientcmdapi.AuthInfo
+		want     *AdminCredential
+	}{
+		{
+			name: "no context",
+		},
+		{
+			name:     "other user",
+			context:  &clientcmdapi.Context{Cluster: "testcluster", AuthInfo: "oidc"},
+			authInfo: &clientcmdapi.AuthInfo{ClientCertificateData: []byte(certData)},
+		},
+		{
+			name:     "no credential",
+			context:  &clientcmdapi.Context{Cluster: "testcluster"},
+			authInfo: &clientcmdapi.AuthInfo{},
+		},
+		{
+			name:     "client certificate",
+			context:  &clientcmdapi.Context{Cluster: "testcluster", AuthInfo: "testcluster"},
+			authInfo: &clientcmdapi.AuthInfo{ClientCertificateData: []byte(certData)},
+			want: &AdminCredential{
+				Lifetime: notAfter.Sub(notBefore) - pki.CertificateBackdate,
+				NotAfter: notAfter,
+			},
+		},
+		{
+			name:    "authentication plugin",
+			context: &clientcmdapi.Context{Cluster: "testcluster", AuthInfo: "testcluster"},
+			authInfo: &clientcmdapi.AuthInfo{Exec: &clientcmdapi.ExecConfig{
+				Command: "kops",
+				Args:    []string{"helpers", "kubectl-auth", "--cluster=testcluster", "--state=memfs://example-state-store", "--lifetime=2h0m0s"},
+			}},
+			want: &AdminCredential{
+				Lifetime:    2 * time.Hour,
+				AutoRefresh: true,
+			},
+		},
+		{
+			name:    "authentication plugin with cloud identity",
+			context: &clientcmdapi.Context{Cluster: "testcluster", AuthInfo: "testcluster"},
+			authInfo: &clientcmdapi.AuthInfo{Exec: &clientcmdapi.ExecConfig{
+				Command: "kops",
+				Args:    []string{"helpers", "kubectl-auth", "--cluster=testcluster", "--state=memfs://example-state-store", "--lifetime=1h0m0s", "--cloud-identity"},
+			}},
+			want: &AdminCredential{
+				Lifetime:      time.Hour,
+				AutoRefresh:   true,
+				CloudIdentity: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := clientcmdapi.NewConfig()
+			if tt.context != nil {
+				config.Contexts["testcluster"] = tt.context
+			}
+			if tt.authInfo != nil {
+				config.AuthInfos["testcluster"] = tt.authInfo
+				config.AuthInfos["oidc"] = tt.authInfo
+			}
+
+			got, err := FindAdminCredential(config, "testcluster")
+			if err != nil {
+				t.Fatalf("FindAdminCredential() error = %v", err)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("FindAdminCredential() diff (+got, -want): %s", diff)
+			}
+		})
+	}
+}
+
+func TestAdminCredentialNeedsRenewal(t *testing.T) {
+	notAfter := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	credential := &AdminCredential{Lifetime: 8 * time.Hour, NotAfter: notAfter}
+
+	if credential.NeedsRenewal(notAfter.Add(-3 * time.Hour)) {
+		t.Errorf("credential with 3h of its 8h lifetime left should not need renewal")
+	}
+	if !credential.NeedsRenewal(notAfter.Add(-time.Hour)) {
+		t.Errorf("credential with 1h of its 8h lifetime left should need renewal")
+	}
+	if !credential.NeedsRenewal(notAfter.Add(time.Hour)) {
+		t.Errorf("expired credential should need renewal")
+	}
+
+	plugin := &AdminCredential{Lifetime: 8 * time.Hour, AutoRefresh: true}
+	if plugin.NeedsRenewal(notAfter.Add(time.Hour)) {
+		t.Errorf("credential of the authentication plugin should not need renewal")
+	}
+}
@@ -33,9 +33,16 @@ import (
 
 const DefaultKubecfgAdminLifetime = 18 * time.Hour
 
-func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.KeystoreReader, secretStore fi.SecretStore, cloud fi.Cloud, admin time.Duration, configUser string, internal bool, kopsStateStore string, useKopsAuthenticationPlugin bool) (*KubeconfigBuilder, error) {
+// AuthenticationPluginCommand is the command kubeconfigs run to use the kOps authentication plugin.
+// It is kubectl-kops when kOps runs as a kubectl plugin.
+var AuthenticationPluginCommand = "kops"
+
+func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.KeystoreReader, secretStore fi.SecretStore, cloud fi.Cloud, admin time.Duration, configUser string, internal bool, kopsStateStore string, useKopsAuthenticationPlugin bool, useCloudIdentity bool) (*KubeconfigBuilder, error) {
 	clusterName := cluster.ObjectMeta.Name
 
+	// Credentials obtained in exchange for the cloud identity are always issued by the kOps authentication plugin
+	useKopsAuthenticationPlugin = useKopsAuthenticationPlugin || useCloudIdentity
+
 	var server string
 	if internal {
 		server = "https://" + cluster.APIInternalName()
@@ -46,9 +53,9 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 			server = "https://api." + clusterName
 		}
 
-		// If a load balancer exists we use it, except for when an SSL certificate is set.
+		// If a load balancer exists we use it, except for when it terminates TLS with an SSL certificate.
 		// This should avoid a lot of pain with DNS pre-creation.
-		if cluster.Spec.API.LoadBalancer != nil && (cluster.Spec.API.LoadBalancer.SSLCertificate == "" || admin != 0) {
+		if cluster.Spec.API.LoadBalancer != nil && (!cluster.Spec.API.LoadBalancer.TerminatesTLS() || admin != 0) {
 			ingresses, err := cloud.GetApiIngressStatus(cluster)
 			if err != nil {
 				return nil, fmt.Errorf("error getting ingress status: %v", err)
@@ -89,8 +96,8 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 
 	b := NewKubeconfigBuilder()
 
-	// Use the secondary load balancer port if a certificate is on the primary listener
-	if admin != 0 && cluster.Spec.API.LoadBalancer != nil && cluster.Spec.API.LoadBalancer.SSLCertificate != "" && cluster.Spec.API.LoadBalancer.Class == kops.LoadBalancerClassNetwork {
+	// Use the secondary load balancer port if the primary listener terminates TLS with a certificate
+	if admin != 0 && cluster.Spec.API.LoadBalancer != nil && cluster.Spec.API.LoadBalancer.TerminatesTLS() && cluster.Spec.API.LoadBalancer.Class == kops.LoadBalancerClassNetwork {
 		server = server + ":8443"
 	}
 
@@ -100,7 +107,7 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 
 	// add the CA Cert to the kubeconfig only if we didn't specify a certificate for the LB
 	//  or if we're using admin credentials and the secondary port
-	if cluster.Spec.API.LoadBalancer == nil || cluster.Spec.API.LoadBalancer.SSLCertificate == "" || cluster.Spec.API.LoadBalancer.Class == kops.LoadBalancerClassNetwork || internal {
+	if cluster.Spec.API.LoadBalancer == nil || !cluster.Spec.API.LoadBalancer.TerminatesTLS() || cluster.Spec.API.LoadBalancer.Class == kops.LoadBalancerClassNetwork || internal {
 		keySet, err := keyStore.FindKeyset(ctx, fi.CertificateIDCA)
 		if err != nil {
 			return nil, fmt.Errorf("error fetching CA keypair: %v", err)
@@ -115,7 +122,7 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 		}
 	}
 
-	if admin != 0 {
+	if admin != 0 && !useKopsAuthenticationPlugin {
 		cn := "kubecfg"
 		user, err := user.Current()
 		if err != nil || user == nil {
@@ -124,24 +131,7 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 			cn += "-" + user.Name
 		}
 
-		req := pki.IssueCertRequest{
-			Signer: fi.CertificateIDCA,
-			Type:   "client",
-			Subject: pkix.Name{
-				CommonName:   cn,
-				Organization: []string{rbac.SystemPrivilegedGroup},
-			},
-			Validity: admin,
-		}
-		cert, privateKey, _, err := pki.IssueCert(ctx, &req, fi.NewPKIKeystoreAdapter(keyStore))
-		if err != nil {
-			return nil, err
-		}
-		b.ClientCert, err = cert.AsBytes()
-		if err != nil {
-			return nil, err
-		}
-		b.ClientKey, err = privateKey.AsBytes()
+		b.ClientCert, b.ClientKey, err = IssueAdminCredential(ctx, keyStore, cn, admin)
 		if err != nil {
 			return nil, err
 		}
@@ -149,16 +139,18 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 
 	if useKopsAuthenticationPlugin {
 		b.AuthenticationExec = []string{
-			"kops",
+			AuthenticationPluginCommand,
 			"helpers",
 			"kubectl-auth",
 			"--cluster=" + clusterName,
 			"--state=" + kopsStateStore,
 		}
-
-		// If there's an existing client-cert / client-key, we need to clear it so it won't be used
-		b.ClientCert = nil
-		b.ClientKey = nil
+		if admin != 0 {
+			b.AuthenticationExec = append(b.AuthenticationExec, authenticationPluginLifetimeFlag+admin.String())
+		}
+		if useCloudIdentity {
+			b.AuthenticationExec = append(b.AuthenticationExec, authenticationPluginCloudIdentityFlag)
+		}
 	}
 
 	b.Server = server
@@ -171,3 +163,30 @@ func BuildKubecfg(ctx context.Context, cluster *kops.Cluster, keyStore fi.Keysto
 
 	return b, nil
 }
+
+// IssueAdminCredential issues a cluster admin client certificate with the common name and lifetime,
+// and returns the PEM encoded certificate and private key.
+func IssueAdminCredential(ctx context.Context, keyStore fi.KeystoreReader, commonName string, validity time.Duration) ([]byte, []byte, error) {
+	req := pki.IssueCertRequest{
+		Signer: fi.CertificateIDCA,
+		Type:   "client",
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{rbac.SystemPrivilegedGroup},
+		},
+		Validity: validity,
+	}
+	cert, privateKey, _, err := pki.IssueCert(ctx, &req, fi.NewPKIKeystoreAdapter(keyStore))
+	if err != nil {
+		return nil, nil, err
+	}
+	certBytes, err := cert.AsBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	keyBytes, err := privateKey.AsBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	return certBytes, keyBytes, nil
+}
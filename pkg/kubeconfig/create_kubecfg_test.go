@@ -156,6 +156,7 @@ func TestBuildKubecfg(t *testing.T) {
 		user                        string
 		internal                    bool
 		useKopsAuthenticationPlugin bool
+		useCloudIdentity            bool
 	}
 
 	publicCluster := buildMinimalCluster("testcluster", "testcluster.test.com", false, false)
@@ -164,6 +165,8 @@ func TestBuildKubecfg(t *testing.T) {
 	certCluster := buildMinimalCluster("testcluster", "testcluster.test.com", true, false)
 	certNLBCluster := buildMinimalCluster("testcluster", "testcluster.test.com", true, true)
 	certGossipNLBCluster := buildMinimalCluster("testgossipcluster.k8s.local", "", true, true)
+	passthroughNLBCluster := buildMinimalCluster("testcluster", "testcluster.test.com", true, true)
+	passthroughNLBCluster.Spec.API.LoadBalancer.TLSMode = kops.LoadBalancerTLSModePassthrough
 
 	fakeStatus := fakeStatusCloud{
 		GetApiIngressStatusFn: func(cluster *kops.Cluster) ([]fi.ApiIngressStatus, error) {
@@ -215,6 +218,22 @@ func TestBuildKubecfg(t *testing.T) {
 			},
 			wantClientCert: true,
 		},
+		{
+			name: "Test Kube Config Data For Public DNS with admin and TLS passthrough NLB",
+			args: args{
+				cluster: passthroughNLBCluster,
+				status:  fakeStatus,
+				admin:   DefaultKubecfgAdminLifetime,
+			},
+			want: &KubeconfigBuilder{
+				Context:       "testcluster",
+				Server:        "https://elbHostName",
+				TLSServerName: "api.internal.testcluster",
+				CACerts:       []byte(nextCertificate + certData),
+				User:          "testcluster",
+			},
+			wantClientCert: true,
+		},
 		{
 			name: "Test Kube Config Data For Public DNS with admin and CLB ACM Certificate",
 			args: args{
@@ -320,6 +339,57 @@ func TestBuildKubecfg(t *testing.T) {
 			},
 			wantClientCert: false,
 		},
+		{
+			name: "Public DNS with kops auth plugin and admin lifetime",
+			args: args{
+				cluster:                     publicCluster,
+				status:                      fakeStatus,
+				admin:                       2 * time.Hour,
+				useKopsAuthenticationPlugin: true,
+			},
+			want: &KubeconfigBuilder{
+				Context:       "testcluster",
+				Server:        "https://testcluster.test.com",
+				TLSServerName: "api.internal.testcluster",
+				CACerts:       []byte(nextCertificate + certData),
+				User:          "testcluster",
+				AuthenticationExec: []string{
+					"kops",
+					"helpers",
+					"kubectl-auth",
+					"--cluster=testcluster",
+					"--state=memfs://example-state-store",
+					"--lifetime=2h0m0s",
+				},
+			},
+			wantClientCert: false,
+		},
+		{
+			name: "Public DNS with cloud identity and admin lifetime",
+			args: args{
+				cluster:          publicCluster,
+				status:           fakeStatus,
+				admin:            time.Hour,
+				useCloudIdentity: true,
+			},
+			want: &KubeconfigBuilder{
+				Context:       "testcluster",
+				Server:        "https://testcluster.test.com",
+				TLSServerName: "api.internal.testcluster",
+				CACerts:       []byte(nextCertificate + certData),
+				User:          "testcluster",
+				AuthenticationExec: []string{
+					"kops",
+					"helpers",
+					"kubectl-auth",
+					"--cluster=testcluster",
+					"--state=memfs://example-state-store",
+					"--lifetime=1h0m0s",
+					"--cloud-identity",
+				},
+			},
+			wantClientCert: false,
+		},
 		{
 			name: "Test Kube Config Data For internal DNS name with admin",
 			args: args{
@@ -401,7 +471,7 @@ func TestBuildKubecfg(t *testing.T) {
 				},
 			}
 
-			got, err := BuildKubecfg(ctx, tt.args.cluster, keyStore, tt.args.secretStore, tt.args.status, tt.args.admin, tt.args.user, tt.args.internal, kopsStateStore, tt.args.useKopsAuthenticationPlugin)
+			got, err := BuildKubecfg(ctx, tt.args.cluster, keyStore, tt.args.secretStore, tt.args.status, tt.args.admin, tt.args.user, tt.args.internal, kopsStateStore, tt.args.useKopsAuthenticationPlugin, tt.args.useCloudIdentity)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("BuildKubecfg() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -178,6 +178,25 @@ func (m *Object) GetName() string {
 	return getStringValue(metadata, "name")
 }
 
+// GetLabels returns the labels of the object, ignoring any that are invalid
+func (m *Object) GetLabels() map[string]string {
+	v, found := m.metadata()["labels"]
+	if !found {
+		return nil
+	}
+	labels, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	result := make(map[string]string)
+	for k, v := range labels {
+		if s, ok := v.(string); ok {
+			result[k] = s
+		}
+	}
+	return result
+}
+
 // getStringValue returns the specified field of the object, or "" if it cannot be found or is invalid
 func getStringValue(m map[string]interface{}, key string) string {
 	v, found := m[key]
@@ -65,61 +65,9 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		return fmt.Errorf("unhandled LoadBalancer type %q", lbSpec.Type)
 	}
 
-	var elbSubnets []*awstasks.Subnet
-	var nlbSubnetMappings []*awstasks.SubnetMapping
-	if len(lbSpec.Subnets) != 0 {
-		// Subnets have been explicitly set
-		for _, subnet := range lbSpec.Subnets {
-			for _, clusterSubnet := range b.Cluster.Spec.Networking.Subnets {
-				if subnet.Name == clusterSubnet.Name {
-					elbSubnet := b.LinkToSubnet(&clusterSubnet)
-					elbSubnets = append(elbSubnets, elbSubnet)
-
-					nlbSubnetMapping := &awstasks.SubnetMapping{
-						Subnet: elbSubnet,
-					}
-					if subnet.PrivateIPv4Address != nil {
-						nlbSubnetMapping.PrivateIPv4Address = subnet.PrivateIPv4Address
-					}
-					if subnet.AllocationID != nil {
-						nlbSubnetMapping.AllocationID = subnet.AllocationID
-					}
-					nlbSubnetMappings = append(nlbSubnetMappings, nlbSubnetMapping)
-					break
-				}
-			}
-		}
-	} else {
-		// Compute the subnets - only one per zone, and then break ties based on chooseBestSubnetForELB
-		subnetsByZone := make(map[string][]*kops.ClusterSubnetSpec)
-		for i := range b.Cluster.Spec.Networking.Subnets {
-			subnet := &b.Cluster.Spec.Networking.Subnets[i]
-
-			switch subnet.Type {
-			case kops.SubnetTypePublic, kops.SubnetTypeUtility:
-				if lbSpec.Type != kops.LoadBalancerTypePublic {
-					continue
-				}
-
-			case kops.SubnetTypeDualStack, kops.SubnetTypePrivate:
-				if lbSpec.Type != kops.LoadBalancerTypeInternal {
-					continue
-				}
-
-			default:
-				return fmt.Errorf("subnet %q had unknown type %q", subnet.Name, subnet.Type)
-			}
-
-			subnetsByZone[subnet.Zone] = append(subnetsByZone[subnet.Zone], subnet)
-		}
-
-		for zone, subnets := range subnetsByZone {
-			subnet := b.chooseBestSubnetForELB(zone, subnets)
-
-			elbSubnet := b.LinkToSubnet(subnet)
-			elbSubnets = append(elbSubnets, elbSubnet)
-			nlbSubnetMappings = append(nlbSubnetMappings, &awstasks.SubnetMapping{Subnet: elbSubnet})
-		}
+	elbSubnets, nlbSubnetMappings, err := b.loadBalancerSubnets(lbSpec.Type, lbSpec.Subnets)
+	if err != nil {
+		return err
 	}
 
 	var clb *awstasks.ClassicLoadBalancer
@@ -135,7 +83,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		}
 		var nlbListeners []*awstasks.NetworkLoadBalancerListener
 
-		if lbSpec.SSLCertificate == "" {
+		if !lbSpec.UsesSSLCertificate() {
 			listener443 := &awstasks.NetworkLoadBalancerListener{
 				Name:                fi.PtrTo(b.NLBListenerName("api", 443)),
 				Lifecycle:           b.Lifecycle,
@@ -147,31 +95,49 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		} else {
 			// When using a custom certificate, we create a secondary listener on 8443, which does _not_ use the custom certificate.
 			// This is because client certificates cannot be used in conjunction with custom certificates on NLBs.
-			listener8443 := &awstasks.NetworkLoadBalancerListener{
-				Name:                fi.PtrTo(b.NLBListenerName("api", 8443)),
+			// With TLS passthrough, the listeners are swapped: the primary listener passes TLS through to the API server.
+			tlsPort, tcpPort := 443, 8443
+			if !lbSpec.TerminatesTLS() {
+				tlsPort, tcpPort = 8443, 443
+			}
+			tcpListener := &awstasks.NetworkLoadBalancerListener{
+				Name:                fi.PtrTo(b.NLBListenerName("api", tcpPort)),
 				Lifecycle:           b.Lifecycle,
 				NetworkLoadBalancer: b.LinkToNLB("api"),
-				Port:                8443,
+				Port:                tcpPort,
 				TargetGroup:         b.LinkToTargetGroup("tcp"),
 			}
-			nlbListeners = append(nlbListeners, listener8443)
+			nlbListeners = append(nlbListeners, tcpListener)
 
-			// The primary listener _does_ use the custom certificate.
+			// The TLS listener _does_ use the custom certificate.
 			listeners["443"].SSLCertificateID = lbSpec.SSLCertificate
-			listener443 := &awstasks.NetworkLoadBalancerListener{
-				Name:                fi.PtrTo(b.NLBListenerName("api", 443)),
+			tlsListener := &awstasks.NetworkLoadBalancerListener{
+				Name:                fi.PtrTo(b.NLBListenerName("api", tlsPort)),
 				Lifecycle:           b.Lifecycle,
 				NetworkLoadBalancer: b.LinkToNLB("api"),
-				Port:                443,
+				Port:                tlsPort,
 				TargetGroup:         b.LinkToTargetGroup("tls"),
 				SSLCertificateID:    lbSpec.SSLCertificate,
+				ALPNPolicy:          fi.ValueOf(lbSpec.ALPNPolicy),
+			}
+			if lbSpec.ACMCertificate != nil {
+				certificate := &awstasks.ACMCertificate{
+					Name:                    fi.PtrTo("api." + b.ClusterName()),
+					Lifecycle:               b.Lifecycle,
+					DomainName:              fi.PtrTo(b.Cluster.Spec.API.PublicName),
+					SubjectAlternativeNames: lbSpec.ACMCertificate.SubjectAlternativeNames,
+					DNSZone:                 b.LinkToDNSZone(),
+					Tags:                    b.CloudTags("api."+b.ClusterName(), false),
+				}
+				c.AddTask(certificate)
+				tlsListener.Certificate = certificate
 			}
 			if lbSpec.SSLPolicy != nil {
-				listener443.SSLPolicy = *lbSpec.SSLPolicy
+				tlsListener.SSLPolicy = *lbSpec.SSLPolicy
 			} else {
-				listener443.SSLPolicy = "ELBSecurityPolicy-2016-08" // The AWS default
+				tlsListener.SSLPolicy = "ELBSecurityPolicy-2016-08" // The AWS default
 			}
-			nlbListeners = append(nlbListeners, listener443)
+			nlbListeners = append(nlbListeners, tlsListener)
 		}
 
 		if b.Cluster.UsesNoneDNS() {
@@ -352,7 +318,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				c.AddTask(tg)
 			}
 
-			if lbSpec.SSLCertificate != "" {
+			if lbSpec.UsesSSLCertificate() {
 				tlsGroupName := b.NLBTargetGroupName("tls")
 				tlsGroupTags := b.CloudTags(tlsGroupName, false)
 
@@ -442,7 +408,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			}
 
 			// If we have opened a secondary listener on 8443, allow it also
-			if b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork && b.Cluster.Spec.API.LoadBalancer != nil && b.Cluster.Spec.API.LoadBalancer.SSLCertificate != "" {
+			if b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork && b.Cluster.Spec.API.LoadBalancer != nil && b.Cluster.Spec.API.LoadBalancer.UsesSSLCertificate() {
 				t := &awstasks.SecurityGroupRule{
 					Name:          fi.PtrTo("https-api-elb-8443-" + cidr),
 					Lifecycle:     b.SecurityLifecycle,
@@ -512,7 +478,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		return err
 	}
 
-	if b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork && b.Cluster.Spec.API.LoadBalancer != nil && b.Cluster.Spec.API.LoadBalancer.SSLCertificate != "" {
+	if b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork && b.Cluster.Spec.API.LoadBalancer != nil && b.Cluster.Spec.API.LoadBalancer.UsesSSLCertificate() {
 		for _, masterGroup := range masterGroups {
 			suffix := masterGroup.Suffix
 			// Allow access to control plane on secondary port through NLB
@@ -592,9 +558,296 @@ func (b *APILoadBalancerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		}
 	}
 
+	if lbSpec.Internal != nil && b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork {
+		if err := b.buildInternalNLB(c, lbSpec, masterGroups); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// buildInternalNLB builds the internal network load balancer created alongside a public one,
+// with its own listeners, target groups and security group.
+func (b *APILoadBalancerBuilder) buildInternalNLB(c *fi.CloudupModelBuilderContext, lbSpec *kops.LoadBalancerAccessSpec, masterGroups []SecurityGroupInfo) error {
+	internalSpec := lbSpec.Internal
+
+	_, nlbSubnetMappings, err := b.loadBalancerSubnets(kops.LoadBalancerTypeInternal, internalSpec.Subnets)
+	if err != nil {
+		return err
+	}
+
+	lbSG := &awstasks.SecurityGroup{
+		Name:             fi.PtrTo(b.ELBSecurityGroupName("api-internal")),
+		Lifecycle:        b.SecurityLifecycle,
+		Description:      fi.PtrTo("Security group for internal api NLB"),
+		RemoveExtraRules: []string{"port=443"},
+		VPC:              b.LinkToVPC(),
+	}
+	lbSG.Tags = b.CloudTags(*lbSG.Name, false)
+	c.AddTask(lbSG)
+
+	// Client IP preservation is disabled so that control plane nodes can reach the API through the load balancer;
+	// NLBs drop connections from a target to itself when the client IP is preserved.
+	groupAttrs := map[string]string{
+		awstasks.TargetGroupAttributeDeregistrationDelayConnectionTerminationEnabled: "true",
+		awstasks.TargetGroupAttributeDeregistrationDelayTimeoutSeconds:               "30",
+		awstasks.TargetGroupAttributePreserveClientIPEnabled:                         "false",
+	}
+
+	nlb := &awstasks.NetworkLoadBalancer{
+		Name:      fi.PtrTo(b.NLBName("api-internal")),
+		Lifecycle: b.Lifecycle,
+
+		LoadBalancerBaseName:   fi.PtrTo(b.LBName32("api-internal")),
+		SecurityGroups:         []*awstasks.SecurityGroup{lbSG},
+		SubnetMappings:         nlbSubnetMappings,
+		WellKnownServices:      []wellknownservices.WellKnownService{wellknownservices.KubeAPIServer},
+		VPC:                    b.LinkToVPC(),
+		Type:                   elbv2types.LoadBalancerTypeEnumNetwork,
+		Scheme:                 elbv2types.LoadBalancerSchemeEnumInternal,
+		CrossZoneLoadBalancing: lbSpec.CrossZoneLoadBalancing,
+	}
+
+	nlb.Tags = b.CloudTags("", false)
+	for k, v := range b.Cluster.Spec.CloudLabels {
+		nlb.Tags[k] = v
+	}
+	// Override the returned name to be the expected NLB name
+	nlb.Tags["Name"] = b.NLBName("api-internal")
+
+	if lbSpec.AccessLog != nil {
+		nlb.AccessLog = &awstasks.NetworkLoadBalancerAccessLog{
+			Enabled:        fi.PtrTo(true),
+			S3BucketName:   lbSpec.AccessLog.Bucket,
+			S3BucketPrefix: lbSpec.AccessLog.BucketPrefix,
+		}
+	} else {
+		nlb.AccessLog = &awstasks.NetworkLoadBalancerAccessLog{
+			Enabled: fi.PtrTo(false),
+		}
+	}
+
+	targetGroups := map[string]elbv2types.ProtocolEnum{
+		"internal-tcp": elbv2types.ProtocolEnumTcp,
+	}
+	listeners := map[int]string{}
+	if internalSpec.SSLCertificate == "" {
+		listeners[443] = "internal-tcp"
+	} else {
+		// As for the public load balancer, client certificates cannot be used in conjunction with
+		// custom certificates, so a secondary listener on 8443 does not use the custom certificate.
+		targetGroups["internal-tls"] = elbv2types.ProtocolEnumTls
+		listeners[443] = "internal-tls"
+		listeners[8443] = "internal-tcp"
+	}
+
+	for groupName, protocol := range targetGroups {
+		name := b.NLBTargetGroupName(groupName)
+		tags := b.CloudTags(name, false)
+
+		// Override the returned name to be the expected NLB TG name
+		tags["Name"] = name
+
+		tg := &awstasks.TargetGroup{
+			Name:               fi.PtrTo(name),
+			Lifecycle:          b.Lifecycle,
+			VPC:                b.LinkToVPC(),
+			Tags:               tags,
+			Protocol:           protocol,
+			Port:               fi.PtrTo(int32(443)),
+			Attributes:         groupAttrs,
+			Interval:           fi.PtrTo(int32(10)),
+			HealthyThreshold:   fi.PtrTo(int32(2)),
+			UnhealthyThreshold: fi.PtrTo(int32(2)),
+			Shared:             fi.PtrTo(false),
+		}
+		tg.CreateNewRevisionsWith(nlb)
+		c.AddTask(tg)
+	}
+
+	for port, groupName := range listeners {
+		listener := &awstasks.NetworkLoadBalancerListener{
+			Name:                fi.PtrTo(b.NLBListenerName("api-internal", port)),
+			Lifecycle:           b.Lifecycle,
+			NetworkLoadBalancer: b.LinkToNLB("api-internal"),
+			Port:                port,
+			TargetGroup:         b.LinkToTargetGroup(groupName),
+		}
+		if groupName == "internal-tls" {
+			listener.SSLCertificateID = internalSpec.SSLCertificate
+			listener.SSLPolicy = fi.ValueOf(internalSpec.SSLPolicy)
+			if listener.SSLPolicy == "" {
+				listener.SSLPolicy = "ELBSecurityPolicy-2016-08" // The AWS default
+			}
+		}
+		c.AddTask(listener)
+	}
+
+	c.AddTask(nlb)
+
+	// Allow traffic from the load balancer to egress freely
+	{
+		AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+			Name:          fi.PtrTo("ipv4-api-internal-elb-egress"),
+			Lifecycle:     b.SecurityLifecycle,
+			CIDR:          fi.PtrTo("0.0.0.0/0"),
+			Egress:        fi.PtrTo(true),
+			SecurityGroup: lbSG,
+		})
+		AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+			Name:          fi.PtrTo("ipv6-api-internal-elb-egress"),
+			Lifecycle:     b.SecurityLifecycle,
+			IPv6CIDR:      fi.PtrTo("::/0"),
+			Egress:        fi.PtrTo(true),
+			SecurityGroup: lbSG,
+		})
+	}
+
+	access := internalSpec.Access
+	if len(access) == 0 {
+		access = append(access, b.Cluster.Spec.Networking.NetworkCIDR)
+		access = append(access, b.Cluster.Spec.Networking.AdditionalNetworkCIDRs...)
+	}
+
+	ports := []int{443}
+	if internalSpec.SSLCertificate != "" {
+		ports = append(ports, 8443)
+		lbSG.RemoveExtraRules = append(lbSG.RemoveExtraRules, "port=8443")
+	}
+
+	// Allow traffic into the load balancer from the access CIDRs
+	for _, cidr := range access {
+		for _, port := range ports {
+			name := "https-api-internal-elb-" + cidr
+			if port != 443 {
+				name = fmt.Sprintf("https-api-internal-elb-%d-%s", port, cidr)
+			}
+			t := &awstasks.SecurityGroupRule{
+				Name:          fi.PtrTo(name),
+				Lifecycle:     b.SecurityLifecycle,
+				FromPort:      fi.PtrTo(int32(port)),
+				Protocol:      fi.PtrTo("tcp"),
+				SecurityGroup: lbSG,
+				ToPort:        fi.PtrTo(int32(port)),
+			}
+			t.SetCidrOrPrefix(cidr)
+			AddDirectionalGroupRule(c, t)
+		}
+
+		// Allow ICMP traffic required for PMTU discovery
+		t := &awstasks.SecurityGroupRule{
+			Name:          fi.PtrTo("icmp-pmtu-api-internal-elb-" + cidr),
+			Lifecycle:     b.SecurityLifecycle,
+			FromPort:      fi.PtrTo(int32(3)),
+			Protocol:      fi.PtrTo("icmp"),
+			SecurityGroup: lbSG,
+			ToPort:        fi.PtrTo(int32(4)),
+		}
+		t.SetCidrOrPrefix(cidr)
+		if t.IPv6CIDR == nil {
+			c.AddTask(t)
+		}
+	}
+
+	// Allow HTTPS to the control-plane instances from the load balancer
+	for _, masterGroup := range masterGroups {
+		suffix := masterGroup.Suffix
+		c.AddTask(&awstasks.SecurityGroupRule{
+			Name:          fi.PtrTo(fmt.Sprintf("https-internal-elb-to-cp%s", suffix)),
+			Lifecycle:     b.SecurityLifecycle,
+			FromPort:      fi.PtrTo(int32(443)),
+			Protocol:      fi.PtrTo("tcp"),
+			SecurityGroup: masterGroup.Task,
+			SourceGroup:   lbSG,
+			ToPort:        fi.PtrTo(int32(443)),
+		})
+		c.AddTask(&awstasks.SecurityGroupRule{
+			Name:          fi.PtrTo(fmt.Sprintf("icmp-pmtu-internal-elb-to-cp%s", suffix)),
+			Lifecycle:     b.SecurityLifecycle,
+			FromPort:      fi.PtrTo(int32(3)),
+			Protocol:      fi.PtrTo("icmp"),
+			SecurityGroup: masterGroup.Task,
+			SourceGroup:   lbSG,
+			ToPort:        fi.PtrTo(int32(4)),
+		})
+		c.AddTask(&awstasks.SecurityGroupRule{
+			Name:          fi.PtrTo(fmt.Sprintf("icmp-pmtu-cp%s-to-internal-elb", suffix)),
+			Lifecycle:     b.SecurityLifecycle,
+			FromPort:      fi.PtrTo(int32(3)),
+			Protocol:      fi.PtrTo("icmp"),
+			SecurityGroup: lbSG,
+			SourceGroup:   masterGroup.Task,
+			ToPort:        fi.PtrTo(int32(4)),
+		})
+	}
+
+	return nil
+}
+
+// loadBalancerSubnets returns the subnets of a load balancer of the given type, either those explicitly
+// set or the best subnet of the matching type in each zone.
+func (b *APILoadBalancerBuilder) loadBalancerSubnets(lbType kops.LoadBalancerType, subnetSpecs []kops.LoadBalancerSubnetSpec) ([]*awstasks.Subnet, []*awstasks.SubnetMapping, error) {
+	var elbSubnets []*awstasks.Subnet
+	var nlbSubnetMappings []*awstasks.SubnetMapping
+	if len(subnetSpecs) != 0 {
+		// Subnets have been explicitly set
+		for _, subnet := range subnetSpecs {
+			for _, clusterSubnet := range b.Cluster.Spec.Networking.Subnets {
+				if subnet.Name == clusterSubnet.Name {
+					elbSubnet := b.LinkToSubnet(&clusterSubnet)
+					elbSubnets = append(elbSubnets, elbSubnet)
+
+					nlbSubnetMapping := &awstasks.SubnetMapping{
+						Subnet: elbSubnet,
+					}
+					if subnet.PrivateIPv4Address != nil {
+						nlbSubnetMapping.PrivateIPv4Address = subnet.PrivateIPv4Address
+					}
+					if subnet.AllocationID != nil {
+						nlbSubnetMapping.AllocationID = subnet.AllocationID
+					}
+					nlbSubnetMappings = append(nlbSubnetMappings, nlbSubnetMapping)
+					break
+				}
+			}
+		}
+	} else {
+		// Compute the subnets - only one per zone, and then break ties based on chooseBestSubnetForELB
+		subnetsByZone := make(map[string][]*kops.ClusterSubnetSpec)
+		for i := range b.Cluster.Spec.Networking.Subnets {
+			subnet := &b.Cluster.Spec.Networking.Subnets[i]
+
+			switch subnet.Type {
+			case kops.SubnetTypePublic, kops.SubnetTypeUtility:
+				if lbType != kops.LoadBalancerTypePublic {
+					continue
+				}
+
+			case kops.SubnetTypeDualStack, kops.SubnetTypePrivate:
+				if lbType != kops.LoadBalancerTypeInternal {
+					continue
+				}
+
+			default:
+				return nil, nil, fmt.Errorf("subnet %q had unknown type %q", subnet.Name, subnet.Type)
+			}
+
+			subnetsByZone[subnet.Zone] = append(subnetsByZone[subnet.Zone], subnet)
+		}
+
+		for zone, subnets := range subnetsByZone {
+			subnet := b.chooseBestSubnetForELB(zone, subnets)
+
+			elbSubnet := b.LinkToSubnet(subnet)
+			elbSubnets = append(elbSubnets, elbSubnet)
+			nlbSubnetMappings = append(nlbSubnetMappings, &awstasks.SubnetMapping{Subnet: elbSubnet})
+		}
+	}
+
+	return elbSubnets, nlbSubnetMappings, nil
+}
+
 type scoredSubnet struct {
 	score  int
 	subnet *kops.ClusterSubnetSpec
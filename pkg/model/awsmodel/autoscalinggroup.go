@@ -18,10 +18,12 @@ package awsmodel
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
@@ -33,6 +35,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/architectures"
 )
 
 const (
@@ -50,6 +53,18 @@ const (
 	DefaultVolumeEncryption = true
 )
 
+// suspendedInstanceGroupProcesses are the autoscaling processes suspended for a suspended instance group,
+// so that no instance is launched while the group is scaled to zero.
+var suspendedInstanceGroupProcesses = []string{
+	"Launch",
+	"HealthCheck",
+	"ReplaceUnhealthy",
+	"AZRebalance",
+	"AlarmNotification",
+	"ScheduledActions",
+	"InstanceRefresh",
+}
+
 // AutoscalingGroupModelBuilder configures AutoscalingGroup objects
 type AutoscalingGroupModelBuilder struct {
 	*AWSModelContext
@@ -91,14 +106,15 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) e
 
 			warmPool := b.Cluster.Spec.CloudProvider.AWS.WarmPool.ResolveDefaults(ig)
 
-			enabled := fi.PtrTo(warmPool.IsEnabled())
+			// A suspended instance group doesn't keep any warm instances either
+			enabled := warmPool.IsEnabled() && !ig.IsSuspended()
 			warmPoolTask := &awstasks.WarmPool{
 				Name:             &name,
 				Lifecycle:        b.Lifecycle,
-				Enabled:          enabled,
+				Enabled:          fi.PtrTo(enabled),
 				AutoscalingGroup: b.LinkToAutoscalingGroup(ig),
 			}
-			if warmPool.IsEnabled() {
+			if enabled {
 				warmPoolTask.MinSize = int32(warmPool.MinSize)
 				if warmPool.MaxSize != nil {
 					warmPoolTask.MaxSize = fi.PtrTo(int32(aws.ToInt64(warmPool.MaxSize)))
@@ -129,12 +145,131 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) e
 
 			c.AddTask(lifecyleTask)
 
+			for _, policy := range ig.Spec.ScalingPolicies {
+				c.AddTask(b.buildScalingPolicyTask(ig, policy))
+			}
+		}
+
+		if ig.Spec.StaticNetworkInterface != nil && ig.Spec.StaticNetworkInterface.ID == "" {
+			eni, err := b.buildNetworkInterfaceTask(c, ig)
+			if err != nil {
+				return err
+			}
+			c.AddTask(eni)
 		}
 	}
 
 	return nil
 }
 
+// buildScalingPolicyTask is responsible for building a scaling policy of the autoscaling group
+func (b *AutoscalingGroupModelBuilder) buildScalingPolicyTask(ig *kops.InstanceGroup, policy kops.ScalingPolicySpec) *awstasks.AutoscalingPolicy {
+	name := fmt.Sprintf("%s-%s", ig.GetName(), policy.Name)
+	t := &awstasks.AutoscalingPolicy{
+		ID:               aws.String(name),
+		Name:             aws.String(name),
+		Lifecycle:        b.Lifecycle,
+		PolicyName:       aws.String(awstasks.AutoscalingPolicyNamePrefix + policy.Name),
+		AutoscalingGroup: b.LinkToAutoscalingGroup(ig),
+	}
+
+	if tt := policy.TargetTracking; tt != nil {
+		t.PolicyType = aws.String(awstasks.AutoscalingPolicyTypeTargetTracking)
+		t.PredefinedMetric = aws.String(tt.PredefinedMetric)
+		t.TargetValue = aws.Float64(tt.TargetValue)
+		t.DisableScaleIn = aws.Bool(aws.ToBool(tt.DisableScaleIn))
+	}
+	if step := policy.Step; step != nil {
+		t.PolicyType = aws.String(awstasks.AutoscalingPolicyTypeStep)
+		t.AdjustmentType = aws.String(step.AdjustmentType)
+		if step.EstimatedInstanceWarmup != nil {
+			t.EstimatedInstanceWarmup = aws.Int32(int32(step.EstimatedInstanceWarmup.Seconds()))
+		}
+		for _, s := range step.Steps {
+			t.StepAdjustments = append(t.StepAdjustments, &awstasks.AutoscalingPolicyStepAdjustment{
+				MetricIntervalLowerBound: s.LowerBound,
+				MetricIntervalUpperBound: s.UpperBound,
+				ScalingAdjustment:        aws.Int32(s.Adjustment),
+			})
+		}
+	}
+
+	return t
+}
+
+// buildNetworkInterfaceTask is responsible for building the network interface that nodeup
+// attaches to the instance of the instance group
+func (b *AutoscalingGroupModelBuilder) buildNetworkInterfaceTask(c *fi.CloudupModelBuilderContext, ig *kops.InstanceGroup) (*awstasks.NetworkInterface, error) {
+	subnets, err := b.GatherSubnets(ig)
+	if err != nil {
+		return nil, err
+	}
+	if len(subnets) != 1 {
+		return nil, fmt.Errorf("InstanceGroup %q with a static network interface must have exactly one subnet", ig.ObjectMeta.Name)
+	}
+
+	securityGroups, err := b.buildSecurityGroups(c, ig)
+	if err != nil {
+		return nil, err
+	}
+
+	name := b.StaticNetworkInterfaceName(ig)
+	t := &awstasks.NetworkInterface{
+		Name:           fi.PtrTo(name),
+		Lifecycle:      b.Lifecycle,
+		Subnet:         b.LinkToSubnet(subnets[0]),
+		SecurityGroups: securityGroups,
+	}
+	if ip := ig.Spec.StaticNetworkInterface.PrivateIPAddress; ip != "" {
+		t.PrivateIPAddress = fi.PtrTo(ip)
+	}
+	t.Tags = b.CloudTags(name, false)
+	t.Tags[awsup.TagNameStaticNetworkInterface] = ig.ObjectMeta.Name
+	// The Amazon VPC CNI must not use the network interface for pods
+	t.Tags["node.k8s.amazonaws.com/no_manage"] = "true"
+
+	return t, nil
+}
+
+// buildAdditionalNetworkInterfaces is responsible for building the network interfaces of the
+// instance group in addition to the primary interface
+func (b *AutoscalingGroupModelBuilder) buildAdditionalNetworkInterfaces(c *fi.CloudupModelBuilderContext, ig *kops.InstanceGroup, securityGroups []*awstasks.SecurityGroup) ([]*awstasks.LaunchTemplateNetworkInterface, error) {
+	var interfaces []*awstasks.LaunchTemplateNetworkInterface
+	for _, spec := range ig.Spec.AdditionalNetworkInterfaces {
+		var subnet *kops.ClusterSubnetSpec
+		for i := range b.Cluster.Spec.Networking.Subnets {
+			if b.Cluster.Spec.Networking.Subnets[i].Name == spec.Subnet {
+				subnet = &b.Cluster.Spec.Networking.Subnets[i]
+			}
+		}
+		if subnet == nil {
+			return nil, fmt.Errorf("InstanceGroup %q network interface %d uses unknown subnet %q", ig.ObjectMeta.Name, spec.DeviceIndex, spec.Subnet)
+		}
+
+		t := &awstasks.LaunchTemplateNetworkInterface{
+			DeviceIndex:    fi.PtrTo(spec.DeviceIndex),
+			Subnet:         b.LinkToSubnet(subnet),
+			SecurityGroups: securityGroups,
+		}
+		if len(spec.SecurityGroups) > 0 {
+			t.SecurityGroups = nil
+			for _, id := range spec.SecurityGroups {
+				sgTask := &awstasks.SecurityGroup{
+					ID:        fi.PtrTo(id),
+					Lifecycle: b.SecurityLifecycle,
+					Name:      fi.PtrTo(id),
+					Shared:    fi.PtrTo(true),
+				}
+				c.EnsureTask(sgTask)
+				t.SecurityGroups = append(t.SecurityGroups, sgTask)
+			}
+		}
+		interfaces = append(interfaces, t)
+	}
+
+	return interfaces, nil
+}
+
 // buildLaunchTemplateTask is responsible for creating the template task into the aws model
 func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.CloudupModelBuilderContext, name string, ig *kops.InstanceGroup) (*awstasks.LaunchTemplate, error) {
 	// @step: add the iam instance profile
@@ -162,7 +297,7 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.CloudupMode
 			rootVolumeEncryption = fi.ValueOf(ig.Spec.RootVolume.Encryption)
 		}
 
-		if fi.ValueOf(ig.Spec.RootVolume.Encryption) && ig.Spec.RootVolume.EncryptionKey != nil {
+		if rootVolumeEncryption && ig.Spec.RootVolume.EncryptionKey != nil {
 			rootVolumeKmsKey = *ig.Spec.RootVolume.EncryptionKey
 		}
 	}
@@ -189,6 +324,7 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.CloudupMode
 		Name:                    fi.PtrTo(name),
 		Lifecycle:               b.Lifecycle,
 		CPUCredits:              fi.PtrTo(fi.ValueOf(ig.Spec.CPUCredits)),
+		DisableAPITermination:   fi.PtrTo(ig.IsControlPlane() && fi.ValueOf(b.Cluster.Spec.DeletionProtection)),
 		HTTPPutResponseHopLimit: fi.PtrTo(int32(1)),
 		HTTPTokens:              fi.PtrTo(ec2types.LaunchTemplateHttpTokensStateRequired),
 		HTTPProtocolIPv6:        fi.PtrTo(ec2types.LaunchTemplateInstanceMetadataProtocolIpv6Disabled),
@@ -214,6 +350,25 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.CloudupMode
 		}
 		lt.RootVolumeOptimization = ig.Spec.RootVolume.Optimization
 	}
+	lt.AMDSevSnp = fi.PtrTo(false)
+	lt.NitroEnclaves = fi.PtrTo(false)
+	if ig.Spec.ConfidentialCompute != nil {
+		lt.AMDSevSnp = fi.PtrTo(fi.ValueOf(ig.Spec.ConfidentialCompute.AMDSevSnp))
+		lt.NitroEnclaves = fi.PtrTo(ig.Spec.ConfidentialCompute.NitroEnclaves != nil)
+	}
+	lt.EFAInterfaceCount = fi.PtrTo(int32(0))
+	if ig.Spec.EFA != nil {
+		lt.EFAInterfaceCount = fi.PtrTo(int32(1))
+		if ig.Spec.EFA.InterfaceCount != nil {
+			lt.EFAInterfaceCount = ig.Spec.EFA.InterfaceCount
+		}
+	}
+	if len(ig.Spec.AdditionalNetworkInterfaces) > 0 {
+		lt.AdditionalNetworkInterfaces, err = b.buildAdditionalNetworkInterfaces(c, ig, securityGroups)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if ig.Spec.Manager == kops.InstanceManagerCloudGroup {
 		lt.InstanceType = fi.PtrTo(ec2types.InstanceType(strings.Split(ig.Spec.MachineType, ",")[0]))
@@ -349,6 +504,7 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.CloudupMode
 
 	if ig.Spec.Tenancy != "" {
 		lt.Tenancy = fi.PtrTo(ec2types.Tenancy(ig.Spec.Tenancy))
+		lt.HostResourceGroupARN = fi.PtrTo(ig.Spec.HostResourceGroupARN)
 	}
 
 	return lt, nil
@@ -432,6 +588,11 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 		maxSize = fi.PtrTo(int32(2))
 	}
 
+	if ig.IsSuspended() {
+		minSize = fi.PtrTo(int32(0))
+		maxSize = fi.PtrTo(int32(0))
+	}
+
 	t.MinSize = minSize
 	t.MaxSize = maxSize
 
@@ -454,6 +615,13 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 
 	processes := []string{}
 	processes = append(processes, ig.Spec.SuspendProcesses...)
+	if ig.IsSuspended() {
+		for _, process := range suspendedInstanceGroupProcesses {
+			if !slices.Contains(processes, process) {
+				processes = append(processes, process)
+			}
+		}
+	}
 	t.SuspendProcesses = &processes
 
 	if ig.Spec.InstanceProtection != nil {
@@ -478,9 +646,15 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 				if b.Cluster.UsesNoneDNS() && ig.IsControlPlane() {
 					t.TargetGroups = append(t.TargetGroups, b.LinkToTargetGroup("kops-controller"))
 				}
-				if b.Cluster.Spec.API.LoadBalancer.SSLCertificate != "" {
+				if b.Cluster.Spec.API.LoadBalancer.UsesSSLCertificate() {
 					t.TargetGroups = append(t.TargetGroups, b.LinkToTargetGroup("tls"))
 				}
+				if internal := b.Cluster.Spec.API.LoadBalancer.Internal; internal != nil {
+					t.TargetGroups = append(t.TargetGroups, b.LinkToTargetGroup("internal-tcp"))
+					if internal.SSLCertificate != "" {
+						t.TargetGroups = append(t.TargetGroups, b.LinkToTargetGroup("internal-tls"))
+					}
+				}
 			} else {
 				t.LoadBalancers = append(t.LoadBalancers, b.LinkToCLB("api"))
 			}
@@ -539,7 +713,8 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 					cpuMin, _ := spec.InstanceRequirements.CPU.Min.AsInt64()
 					ir.CPUMin = fi.PtrTo(int32(cpuMin))
 				}
-			} else {
+			}
+			if ir.CPUMin == nil {
 				ir.CPUMin = fi.PtrTo(int32(0))
 			}
 
@@ -553,9 +728,26 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 					memoryMin := spec.InstanceRequirements.Memory.Min.ScaledValue(resource.Mega)
 					ir.MemoryMin = fi.PtrTo(int32(memoryMin))
 				}
-			} else {
+			}
+			if ir.MemoryMin == nil {
 				ir.MemoryMin = fi.PtrTo(int32(0))
 			}
+
+			// EC2 Auto Scaling selects the architecture of the instance types by the manufacturer of their CPUs
+			for _, arch := range spec.InstanceRequirements.Architectures {
+				switch architectures.Architecture(arch) {
+				case architectures.ArchitectureAmd64:
+					ir.CPUManufacturers = append(ir.CPUManufacturers, string(autoscalingtypes.CpuManufacturerAmd), string(autoscalingtypes.CpuManufacturerIntel))
+				case architectures.ArchitectureArm64:
+					ir.CPUManufacturers = append(ir.CPUManufacturers, string(autoscalingtypes.CpuManufacturerAmazonWebServices))
+				default:
+					return nil, fmt.Errorf("unsupported architecture %q in instance requirements of instance group %q", arch, ig.Name)
+				}
+			}
+			sort.Strings(ir.CPUManufacturers)
+			ir.ExcludedInstanceTypes = append(ir.ExcludedInstanceTypes, spec.InstanceRequirements.ExcludedInstanceTypes...)
+			sort.Strings(ir.ExcludedInstanceTypes)
+
 			t.InstanceRequirements = ir
 		}
 
@@ -585,5 +777,22 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.CloudupMo
 	} else {
 		t.MaxInstanceLifetime = fi.PtrTo(int32(0))
 	}
+
+	if hc := ig.Spec.HealthCheck; hc != nil {
+		if hc.Type != "" {
+			t.HealthCheckType = fi.PtrTo(hc.Type)
+		}
+		if hc.GracePeriod != nil {
+			t.HealthCheckGracePeriod = fi.PtrTo(int32(hc.GracePeriod.Seconds()))
+		}
+	}
+	if ig.Spec.DefaultInstanceWarmup != nil {
+		t.DefaultInstanceWarmup = fi.PtrTo(int32(ig.Spec.DefaultInstanceWarmup.Seconds()))
+	}
+	if policy := ig.Spec.InstanceMaintenancePolicy; policy != nil {
+		t.MinHealthyPercentage = policy.MinHealthyPercentage
+		t.MaxHealthyPercentage = policy.MaxHealthyPercentage
+	}
+
 	return t, nil
 }
@@ -18,7 +18,10 @@ package awsmodel
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/apis/kops"
@@ -27,6 +30,7 @@ import (
 	"k8s.io/kops/pkg/testutils"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/fitasks"
 )
 
@@ -122,6 +126,172 @@ func TestRootVolumeOptimizationFlag(t *testing.T) {
 	}
 }
 
+// buildNodeAutoscalingGroup builds the model for a node instance group and returns its autoscaling group task
+func buildNodeAutoscalingGroup(t *testing.T, ig *kops.InstanceGroup) *awstasks.AutoscalingGroup {
+	return buildNodeTasks(t, ig)["AutoscalingGroup/nodes.testcluster.test.com"].(*awstasks.AutoscalingGroup)
+}
+
+func buildNodeTasks(t *testing.T, ig *kops.InstanceGroup) map[string]fi.CloudupTask {
+	cluster := buildMinimalCluster()
+
+	b := AutoscalingGroupModelBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+				SSHPublicKeys:   [][]byte{[]byte(sshPublicKeyEntry)},
+				InstanceGroups:  []*kops.InstanceGroup{ig},
+			},
+		},
+		BootstrapScriptBuilder: &model.BootstrapScriptBuilder{
+			Lifecycle: fi.LifecycleSync,
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{
+					Cluster: &kops.Cluster{
+						Spec: kops.ClusterSpec{
+							CloudProvider: kops.CloudProviderSpec{
+								AWS: &kops.AWSSpec{},
+							},
+							KubernetesVersion: "1.20.0",
+						},
+					},
+				},
+			},
+		},
+		Cluster: cluster,
+	}
+
+	c := &fi.CloudupModelBuilderContext{
+		Tasks: make(map[string]fi.CloudupTask),
+	}
+	for _, keypair := range []string{fi.CertificateIDCA, "etcd-clients-ca"} {
+		c.AddTask(&fitasks.Keypair{
+			Name:    fi.PtrTo(keypair),
+			Subject: "cn=" + keypair,
+			Type:    "ca",
+		})
+	}
+
+	if err := b.Build(c); err != nil {
+		t.Fatalf("error from Build: %v", err)
+	}
+
+	return c.Tasks
+}
+
+// Tests that a suspended instance group is scaled to zero with its launches suspended
+func TestSuspendedInstanceGroup(t *testing.T) {
+	ig := buildNodeInstanceGroup("subnet-us-test-1a")
+	ig.Spec.MinSize = fi.PtrTo(int32(3))
+	ig.Spec.MaxSize = fi.PtrTo(int32(5))
+	ig.Spec.SuspendProcesses = []string{"AZRebalance"}
+	ig.Spec.Suspended = fi.PtrTo(true)
+
+	asg := buildNodeAutoscalingGroup(t, ig)
+
+	if fi.ValueOf(asg.MinSize) != 0 || fi.ValueOf(asg.MaxSize) != 0 {
+		t.Errorf("expected size 0-0, got %d-%d", fi.ValueOf(asg.MinSize), fi.ValueOf(asg.MaxSize))
+	}
+	expectedProcesses := []string{"AZRebalance", "Launch", "HealthCheck", "ReplaceUnhealthy", "AlarmNotification", "ScheduledActions", "InstanceRefresh"}
+	if !reflect.DeepEqual(*asg.SuspendProcesses, expectedProcesses) {
+		t.Errorf("expected suspended processes %v, got %v", expectedProcesses, *asg.SuspendProcesses)
+	}
+}
+
+// Tests that the health check settings are only set on the autoscaling group when configured
+func TestHealthCheckSettings(t *testing.T) {
+	ig := buildNodeInstanceGroup("subnet-us-test-1a")
+	asg := buildNodeAutoscalingGroup(t, ig)
+	if asg.HealthCheckType != nil || asg.HealthCheckGracePeriod != nil || asg.DefaultInstanceWarmup != nil || asg.MinHealthyPercentage != nil || asg.MaxHealthyPercentage != nil {
+		t.Errorf("expected no health check settings, got %+v", asg)
+	}
+
+	ig.Spec.HealthCheck = &kops.InstanceGroupHealthCheckSpec{
+		Type:        "ELB",
+		GracePeriod: &v1.Duration{Duration: 15 * time.Minute},
+	}
+	ig.Spec.DefaultInstanceWarmup = &v1.Duration{Duration: 10 * time.Minute}
+	ig.Spec.InstanceMaintenancePolicy = &kops.InstanceMaintenancePolicySpec{
+		MinHealthyPercentage: fi.PtrTo(int32(90)),
+		MaxHealthyPercentage: fi.PtrTo(int32(120)),
+	}
+	asg = buildNodeAutoscalingGroup(t, ig)
+	if fi.ValueOf(asg.HealthCheckType) != "ELB" {
+		t.Errorf("expected health check type ELB, got %q", fi.ValueOf(asg.HealthCheckType))
+	}
+	if fi.ValueOf(asg.HealthCheckGracePeriod) != 900 {
+		t.Errorf("expected health check grace period 900, got %d", fi.ValueOf(asg.HealthCheckGracePeriod))
+	}
+	if fi.ValueOf(asg.DefaultInstanceWarmup) != 600 {
+		t.Errorf("expected default instance warmup 600, got %d", fi.ValueOf(asg.DefaultInstanceWarmup))
+	}
+	if fi.ValueOf(asg.MinHealthyPercentage) != 90 || fi.ValueOf(asg.MaxHealthyPercentage) != 120 {
+		t.Errorf("expected healthy percentage 90-120, got %d-%d", fi.ValueOf(asg.MinHealthyPercentage), fi.ValueOf(asg.MaxHealthyPercentage))
+	}
+}
+
+func TestScalingPolicies(t *testing.T) {
+	ig := buildNodeInstanceGroup("subnet-us-test-1a")
+	ig.Spec.ScalingPolicies = []kops.ScalingPolicySpec{
+		{
+			Name: "cpu",
+			TargetTracking: &kops.TargetTrackingScalingPolicySpec{
+				PredefinedMetric: "ASGAverageCPUUtilization",
+				TargetValue:      60,
+			},
+		},
+		{
+			Name: "network",
+			TargetTracking: &kops.TargetTrackingScalingPolicySpec{
+				PredefinedMetric: "ASGAverageNetworkIn",
+				TargetValue:      2.5e6,
+				DisableScaleIn:   fi.PtrTo(true),
+			},
+		},
+		{
+			Name: "queue",
+			Step: &kops.StepScalingPolicySpec{
+				AdjustmentType:          "ChangeInCapacity",
+				Steps:                   []kops.ScalingStepSpec{{LowerBound: fi.PtrTo(0.5), Adjustment: 2}},
+				EstimatedInstanceWarmup: &v1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+	}
+	tasks := buildNodeTasks(t, ig)
+
+	cpu := tasks["AutoscalingPolicy/nodes-cpu"].(*awstasks.AutoscalingPolicy)
+	if fi.ValueOf(cpu.PolicyType) != awstasks.AutoscalingPolicyTypeTargetTracking {
+		t.Errorf("expected policy type %q, got %q", awstasks.AutoscalingPolicyTypeTargetTracking, fi.ValueOf(cpu.PolicyType))
+	}
+	if fi.ValueOf(cpu.PolicyName) != "kops-cpu" {
+		t.Errorf("expected policy name %q, got %q", "kops-cpu", fi.ValueOf(cpu.PolicyName))
+	}
+	if fi.ValueOf(cpu.PredefinedMetric) != "ASGAverageCPUUtilization" || fi.ValueOf(cpu.TargetValue) != 60 {
+		t.Errorf("expected ASGAverageCPUUtilization at 60, got %s at %v", fi.ValueOf(cpu.PredefinedMetric), fi.ValueOf(cpu.TargetValue))
+	}
+	if fi.ValueOf(cpu.DisableScaleIn) {
+		t.Errorf("expected scale in to be enabled")
+	}
+
+	network := tasks["AutoscalingPolicy/nodes-network"].(*awstasks.AutoscalingPolicy)
+	if fi.ValueOf(network.TargetValue) != 2.5e6 {
+		t.Errorf("expected target value 2.5e6, got %v", fi.ValueOf(network.TargetValue))
+	}
+	if !fi.ValueOf(network.DisableScaleIn) {
+		t.Errorf("expected scale in to be disabled")
+	}
+
+	queue := tasks["AutoscalingPolicy/nodes-queue"].(*awstasks.AutoscalingPolicy)
+	if fi.ValueOf(queue.PolicyType) != awstasks.AutoscalingPolicyTypeStep {
+		t.Errorf("expected policy type %q, got %q", awstasks.AutoscalingPolicyTypeStep, fi.ValueOf(queue.PolicyType))
+	}
+	if fi.ValueOf(queue.EstimatedInstanceWarmup) != 300 {
+		t.Errorf("expected estimated instance warmup 300, got %d", fi.ValueOf(queue.EstimatedInstanceWarmup))
+	}
+	if len(queue.StepAdjustments) != 1 || fi.ValueOf(queue.StepAdjustments[0].MetricIntervalLowerBound) != 0.5 || fi.ValueOf(queue.StepAdjustments[0].ScalingAdjustment) != 2 {
+		t.Errorf("expected a single step adjustment of 2 from 0.5, got %+v", queue.StepAdjustments)
+	}
+}
+
 func TestAPIServerAdditionalSecurityGroupsWithNLB(t *testing.T) {
 	const sgIDAPIServer = "sg-01234567890abcdef"
 
@@ -283,3 +453,36 @@ func TestAPIServerAdditionalSecurityGroupsWithNLB(t *testing.T) {
 		})
 	}
 }
+
+// Tests that a static network interface is created in the subnet of the instance group, unless an existing one is used
+func TestStaticNetworkInterface(t *testing.T) {
+	ig := buildNodeInstanceGroup("subnet-us-test-1a")
+	ig.Spec.StaticNetworkInterface = &kops.StaticNetworkInterfaceSpec{
+		PrivateIPAddress: "172.20.32.10",
+	}
+	tasks := buildNodeTasks(t, ig)
+
+	eni, ok := tasks["NetworkInterface/nodes.testcluster.test.com"].(*awstasks.NetworkInterface)
+	if !ok {
+		t.Fatalf("expected a network interface task")
+	}
+	if fi.ValueOf(eni.PrivateIPAddress) != "172.20.32.10" {
+		t.Errorf("expected private IP address 172.20.32.10, got %q", fi.ValueOf(eni.PrivateIPAddress))
+	}
+	if fi.ValueOf(eni.Subnet.Name) != "subnet-us-test-1a.testcluster.test.com" {
+		t.Errorf("expected subnet subnet-us-test-1a.testcluster.test.com, got %q", fi.ValueOf(eni.Subnet.Name))
+	}
+	if eni.Tags[awsup.TagNameStaticNetworkInterface] != "nodes" {
+		t.Errorf("expected tag %s=nodes, got tags %v", awsup.TagNameStaticNetworkInterface, eni.Tags)
+	}
+
+	ig.Spec.StaticNetworkInterface = &kops.StaticNetworkInterfaceSpec{
+		ID: "eni-1234",
+	}
+	tasks = buildNodeTasks(t, ig)
+	for key := range tasks {
+		if strings.HasPrefix(key, "NetworkInterface/") {
+			t.Errorf("expected no network interface task for an existing network interface, got %q", key)
+		}
+	}
+}
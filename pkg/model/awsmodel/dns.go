@@ -68,6 +68,23 @@ func (b *DNSModelBuilder) ensureDNSZone(c *fi.CloudupModelBuilderContext) error
 	}
 
 	c.EnsureTask(dnsZone)
+
+	if fi.ValueOf(dnsZone.Private) {
+		for _, vpc := range b.Cluster.Spec.CloudProvider.AWS.PrivateDNSZoneVPCs {
+			region := vpc.Region
+			if region == "" {
+				region = b.Region
+			}
+			c.AddTask(&awstasks.DNSZoneVPCAssociation{
+				Name:      fi.PtrTo(b.NameForDNSZone() + "-" + vpc.ID),
+				Lifecycle: b.Lifecycle,
+				DNSZone:   dnsZone,
+				VPCID:     fi.PtrTo(vpc.ID),
+				VPCRegion: fi.PtrTo(region),
+			})
+		}
+	}
+
 	return nil
 }
 
@@ -123,6 +140,11 @@ func (b *DNSModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		// This will point the internal API DNS record to the load balancer.
 		// This means kubelet connections go via the load balancer and are more HA.
 
+		if b.Cluster.Spec.API.LoadBalancer.Internal != nil && b.UseNetworkLoadBalancer() {
+			// Clients inside the VPC use the internal load balancer created alongside the public one
+			targetLoadBalancer = awstasks.DNSTarget(b.LinkToNLB("api-internal"))
+		}
+
 		if b.Cluster.PublishesDNSRecords() {
 			if err := b.ensureDNSZone(c); err != nil {
 				return err
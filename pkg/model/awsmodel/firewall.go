@@ -18,11 +18,13 @@ package awsmodel
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 
 	"k8s.io/klog/v2"
 )
@@ -59,9 +61,75 @@ func (b *FirewallModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	// b.applyNodeToMasterAllowSpecificPorts(c)
 	b.applyNodeToMasterBlockSpecificPorts(c, nodeGroups, masterGroups)
 
+	if b.Cluster.Spec.UsesSecurityGroupsForPods() {
+		b.buildPodRules(c, nodeGroups, masterGroups)
+	}
+
 	return nil
 }
 
+// buildPodRules creates a security group for the pods of each instance group, for the Amazon VPC CNI
+// to attach to the network interfaces of the pods. Pods can talk to each other, to nodes and to masters.
+func (b *FirewallModelBuilder) buildPodRules(c *fi.CloudupModelBuilderContext, nodeGroups []SecurityGroupInfo, masterGroups []SecurityGroupInfo) {
+	var podGroups []*awstasks.SecurityGroup
+	for _, ig := range b.InstanceGroups {
+		// aws-node runs on every instance group apart from bastions, and needs an ENIConfig for it
+		if ig.Spec.Role == kops.InstanceGroupRoleBastion || ig.Spec.Manager == kops.InstanceManagerKarpenter {
+			continue
+		}
+
+		name := b.PodSecurityGroupName(ig)
+		t := &awstasks.SecurityGroup{
+			Name:        fi.PtrTo(name),
+			Lifecycle:   b.Lifecycle,
+			VPC:         b.LinkToVPC(),
+			Description: fi.PtrTo("Security group for pods of " + ig.ObjectMeta.Name),
+		}
+		t.Tags = b.CloudTags(name, false)
+		t.Tags[awsup.TagNamePodSecurityGroup] = ig.ObjectMeta.Name
+		c.AddTask(t)
+		podGroups = append(podGroups, t)
+	}
+
+	for _, src := range podGroups {
+		AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+			Lifecycle:     b.Lifecycle,
+			SecurityGroup: src,
+			Egress:        fi.PtrTo(true),
+			CIDR:          fi.PtrTo("0.0.0.0/0"),
+		})
+		AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+			Lifecycle:     b.Lifecycle,
+			SecurityGroup: src,
+			Egress:        fi.PtrTo(true),
+			IPv6CIDR:      fi.PtrTo("::/0"),
+		})
+
+		// Pods can talk to pods
+		for _, dest := range podGroups {
+			AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+				Lifecycle:     b.Lifecycle,
+				SecurityGroup: dest,
+				SourceGroup:   src,
+			})
+		}
+
+		// Pods can talk to nodes and masters, and the other way around
+		for _, group := range slices.Concat(nodeGroups, masterGroups) {
+			AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+				Lifecycle:     b.Lifecycle,
+				SecurityGroup: group.Task,
+				SourceGroup:   src,
+			})
+			AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+				Lifecycle:     b.Lifecycle,
+				SecurityGroup: src,
+				SourceGroup:   group.Task,
+			})
+		}
+	}
+}
+
 func (b *FirewallModelBuilder) buildNodeRules(c *fi.CloudupModelBuilderContext) ([]SecurityGroupInfo, error) {
 	nodeGroups, err := b.GetSecurityGroups(kops.InstanceGroupRoleNode)
 	if err != nil {
@@ -109,11 +177,38 @@ func (b *FirewallModelBuilder) buildNodeRules(c *fi.CloudupModelBuilderContext)
 			AddDirectionalGroupRule(c, t)
 		}
 
+		// EFA requires the security group to allow all outbound traffic to itself
+		if b.groupUsesEFA(src) {
+			t := &awstasks.SecurityGroupRule{
+				Lifecycle:     b.Lifecycle,
+				SecurityGroup: src.Task,
+				SourceGroup:   src.Task,
+				Egress:        fi.PtrTo(true),
+			}
+			AddDirectionalGroupRule(c, t)
+		}
 	}
 
 	return nodeGroups, nil
 }
 
+// groupUsesEFA returns true if the security group is used by a node instance group with EFA interfaces.
+func (b *FirewallModelBuilder) groupUsesEFA(group SecurityGroupInfo) bool {
+	for _, ig := range b.InstanceGroups {
+		if ig.Spec.Role != kops.InstanceGroupRoleNode || ig.Spec.EFA == nil {
+			continue
+		}
+		if ig.Spec.SecurityGroupOverride == nil {
+			if group.Suffix == "" {
+				return true
+			}
+		} else if fi.ValueOf(ig.Spec.SecurityGroupOverride) == group.Name {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *FirewallModelBuilder) applyNodeToMasterBlockSpecificPorts(c *fi.CloudupModelBuilderContext, nodeGroups []SecurityGroupInfo, masterGroups []SecurityGroupInfo) {
 	type portRange struct {
 		From int
@@ -18,6 +18,10 @@ package awsmodel
 
 import (
 	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/upup/pkg/fi"
 )
 
 func TestJoinSuffixes(t *testing.T) {
@@ -50,3 +54,46 @@ func TestJoinSuffixes(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupUsesEFA(t *testing.T) {
+	igs := []*kops.InstanceGroup{
+		{Spec: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode}},
+		{Spec: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode, SecurityGroupOverride: fi.PtrTo("sg-efa"), EFA: &kops.EFASpec{}}},
+		{Spec: kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode, SecurityGroupOverride: fi.PtrTo("sg-other")}},
+	}
+	b := &FirewallModelBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				InstanceGroups: igs,
+			},
+		},
+	}
+
+	grid := []struct {
+		group    SecurityGroupInfo
+		expected bool
+	}{
+		{
+			group:    SecurityGroupInfo{Name: "nodes.example.com"},
+			expected: false,
+		},
+		{
+			group:    SecurityGroupInfo{Name: "sg-efa", Suffix: "-sg-efa"},
+			expected: true,
+		},
+		{
+			group:    SecurityGroupInfo{Name: "sg-other", Suffix: "-sg-other"},
+			expected: false,
+		},
+	}
+	for _, g := range grid {
+		if actual := b.groupUsesEFA(g.group); actual != g.expected {
+			t.Errorf("unexpected result for %q: expected %v, got %v", g.group.Name, g.expected, actual)
+		}
+	}
+
+	igs[0].Spec.EFA = &kops.EFASpec{}
+	if !b.groupUsesEFA(grid[0].group) {
+		t.Errorf("expected the default group to be used by EFA")
+	}
+}
@@ -93,7 +93,7 @@ func (b *IAMModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 
 			}
 		}
-		role, err := iam.BuildNodeRoleSubject(igRole, lchPermissions)
+		role, err := iam.BuildNodeRoleSubject(igRole, lchPermissions, b.hasStaticNetworkInterfaces(igRole))
 		if err != nil {
 			return err
 		}
@@ -120,7 +120,7 @@ func (b *IAMModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 
 			}
 		}
-		role, err := iam.BuildNodeRoleSubject(igRole, haveWarmPool)
+		role, err := iam.BuildNodeRoleSubject(igRole, haveWarmPool, b.hasStaticNetworkInterfaces(igRole))
 		if err != nil {
 			return err
 		}
@@ -171,6 +171,16 @@ func (b *IAMModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	return nil
 }
 
+// hasStaticNetworkInterfaces returns true if an instance group of the role attaches a static network interface
+func (b *IAMModelBuilder) hasStaticNetworkInterfaces(igRole kops.InstanceGroupRole) bool {
+	for _, ig := range b.InstanceGroups {
+		if ig.Spec.Role == igRole && ig.Spec.StaticNetworkInterface != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // BuildServiceAccountRoleTasks build tasks specifically for the ServiceAccount role.
 func (b *IAMModelBuilder) BuildServiceAccountRoleTasks(role iam.Subject, c *fi.CloudupModelBuilderContext) (*awstasks.IAMRole, error) {
 	iamName, err := b.IAMNameForServiceAccountRole(role)
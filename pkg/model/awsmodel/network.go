@@ -19,6 +19,7 @@ package awsmodel
 import (
 	"fmt"
 	"net"
+	"slices"
 	"strings"
 
 	aws "k8s.io/cloud-provider-aws/pkg/providers/v1"
@@ -130,6 +131,17 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		} else {
 			dhcp.DomainName = fi.PtrTo(b.Region + ".compute.internal")
 		}
+		if o := b.Cluster.Spec.Networking.DHCPOptions; o != nil {
+			if o.DomainName != "" {
+				dhcp.DomainName = fi.PtrTo(o.DomainName)
+			}
+			if len(o.DomainNameServers) != 0 {
+				dhcp.DomainNameServers = fi.PtrTo(strings.Join(o.DomainNameServers, ","))
+			}
+			if len(o.NTPServers) != 0 {
+				dhcp.NTPServers = fi.PtrTo(strings.Join(o.NTPServers, ","))
+			}
+		}
 		c.AddTask(dhcp)
 
 		c.AddTask(&awstasks.VPCDHCPOptionsAssociation{
@@ -140,6 +152,21 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		})
 	}
 
+	var tgwAttachment *awstasks.TransitGatewayAttachment
+	if tgw := b.Cluster.Spec.Networking.TransitGateway; tgw != nil {
+		tgwAttachment = &awstasks.TransitGatewayAttachment{
+			Name:             fi.PtrTo(b.ClusterName()),
+			Lifecycle:        b.Lifecycle,
+			TransitGatewayID: fi.PtrTo(tgw.ID),
+			VPC:              b.LinkToVPC(),
+			Tags:             b.CloudTags(b.ClusterName(), false),
+		}
+		for _, subnetSpec := range b.transitGatewaySubnets() {
+			tgwAttachment.Subnets = append(tgwAttachment.Subnets, b.LinkToSubnet(subnetSpec))
+		}
+		c.AddTask(tgwAttachment)
+	}
+
 	allSubnetsUnmanaged := true
 	allPrivateSubnetsUnmanaged := true
 	allSubnetsShared := true
@@ -165,6 +192,9 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		}
 	}
 
+	// routeTables are the route tables of the cluster, which route to the gateway endpoints
+	var routeTables []*awstasks.RouteTable
+
 	// We always have a public route table, though for private networks it is only used for NGWs and ELBs
 	var publicRouteTable *awstasks.RouteTable
 	var igw *awstasks.InternetGateway
@@ -196,6 +226,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				Shared: fi.PtrTo(sharedRouteTable),
 			}
 			c.AddTask(publicRouteTable)
+			routeTables = append(routeTables, publicRouteTable)
 
 			// TODO: Validate when allSubnetsShared
 			c.AddTask(&awstasks.Route{
@@ -212,6 +243,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				RouteTable:      publicRouteTable,
 				InternetGateway: igw,
 			})
+			b.addTransitGatewayRoutes(c, "", publicRouteTable, tgwAttachment)
 		}
 	}
 
@@ -279,6 +311,10 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			klog.V(2).Infof("skipping subnet tags. Ensure these are maintained externally.")
 		}
 
+		for k, v := range subnetSpec.Tags {
+			tags[k] = v
+		}
+
 		subnet := &awstasks.Subnet{
 			Name:             fi.PtrTo(subnetName),
 			ShortName:        fi.PtrTo(subnetSpec.Name),
@@ -292,6 +328,27 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		if b.Cluster.Spec.ExternalCloudControllerManager != nil {
 			subnet.ResourceBasedNaming = fi.PtrTo(true)
 		}
+		options := subnetSpec.PrivateDNSNameOptions
+		if options != nil && options.HostnameType != "" {
+			subnet.ResourceBasedNaming = fi.PtrTo(options.HostnameType == kops.HostnameTypeResourceName)
+		}
+		// By default, the resource-name hostnames resolve to the addresses of the instances when they are used
+		if subnet.ResourceBasedNaming != nil {
+			if subnetSpec.CIDR != "" {
+				subnet.ResourceNameDNSARecord = fi.PtrTo(*subnet.ResourceBasedNaming)
+			}
+			if subnetSpec.IPv6CIDR != "" {
+				subnet.ResourceNameDNSAAAARecord = fi.PtrTo(*subnet.ResourceBasedNaming)
+			}
+		}
+		if options != nil {
+			if options.EnableResourceNameDNSARecord != nil {
+				subnet.ResourceNameDNSARecord = fi.PtrTo(*options.EnableResourceNameDNSARecord)
+			}
+			if options.EnableResourceNameDNSAAAARecord != nil {
+				subnet.ResourceNameDNSAAAARecord = fi.PtrTo(*options.EnableResourceNameDNSAAAARecord)
+			}
+		}
 
 		if subnetSpec.CIDR != "" {
 			subnet.CIDR = fi.PtrTo(subnetSpec.CIDR)
@@ -413,6 +470,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 
 		egress := info.NATSubnets[0].Egress
 		publicIP := info.NATSubnets[0].PublicIP
+		natGatewayConnectivityType := info.NATSubnets[0].NATGatewayConnectivityType
 
 		allUnmanaged := true
 		for _, subnetSpec := range info.NATSubnets {
@@ -433,6 +491,9 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			if subnet.PublicIP != publicIP {
 				return fmt.Errorf("cannot mix publicIP values in private or IPv6-capable subnets")
 			}
+			if subnet.NATGatewayConnectivityType != natGatewayConnectivityType {
+				return fmt.Errorf("cannot mix natGatewayConnectivityType values in private or IPv6-capable subnets")
+			}
 		}
 
 		var ngw *awstasks.NatGateway
@@ -472,7 +533,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 					Subnet:               egressSubnet,
 					ElasticIP:            eip,
 					AssociatedRouteTable: egressRouteTable,
-					Tags:                 b.CloudTags(zone+"."+b.ClusterName(), false),
+					Tags:                 b.addSubnetTags(b.CloudTags(zone+"."+b.ClusterName(), false), egressSubnet),
 				}
 				c.AddTask(ngw)
 
@@ -494,6 +555,18 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			} else {
 				return fmt.Errorf("kops currently only supports re-use of either NAT EC2 Instances or NAT Gateways. We will support more eventually! Please see https://github.com/kubernetes/kops/issues/1530")
 			}
+		} else if natGatewayConnectivityType == kops.NATGatewayConnectivityTypePrivate {
+			// Private NGWs have no public IP address; the traffic is routed
+			// to other VPCs or networks through a transit gateway
+			ngw = &awstasks.NatGateway{
+				Name:                 fi.PtrTo(zone + "." + b.ClusterName()),
+				Lifecycle:            b.Lifecycle,
+				Subnet:               egressSubnet,
+				ConnectivityType:     fi.PtrTo(natGatewayConnectivityType),
+				AssociatedRouteTable: egressRouteTable,
+				Tags:                 b.addSubnetTags(b.CloudTags(zone+"."+b.ClusterName(), false), egressSubnet),
+			}
+			c.AddTask(ngw)
 		} else {
 
 			// Every NGW needs a public (Elastic) IP address, every private
@@ -509,7 +582,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				eip.PublicIP = fi.PtrTo(publicIP)
 				eip.Tags = b.CloudTags(*eip.Name, true)
 			} else {
-				eip.Tags = b.CloudTags(*eip.Name, false)
+				eip.Tags = b.addSubnetTags(b.CloudTags(*eip.Name, false), egressSubnet)
 			}
 
 			c.AddTask(eip)
@@ -528,7 +601,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				Subnet:               egressSubnet,
 				ElasticIP:            eip,
 				AssociatedRouteTable: egressRouteTable,
-				Tags:                 b.CloudTags(zone+"."+b.ClusterName(), false),
+				Tags:                 b.addSubnetTags(b.CloudTags(zone+"."+b.ClusterName(), false), egressSubnet),
 			}
 			c.AddTask(ngw)
 		}
@@ -550,6 +623,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				Tags:   routeTableTags,
 			}
 			c.AddTask(rt)
+			routeTables = append(routeTables, rt)
 
 			// Private Routes
 			//
@@ -599,6 +673,8 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				})
 			}
 
+			b.addTransitGatewayRoutes(c, "private-"+zone+"-", rt, tgwAttachment)
+
 			subnets, err := b.LinkToPrivateSubnetsInZone(zone)
 			if err != nil {
 				return err
@@ -633,6 +709,7 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				Tags:   routeTableTags,
 			}
 			c.AddTask(rt)
+			routeTables = append(routeTables, rt)
 
 			// Routes for the public route table.
 			c.AddTask(&awstasks.Route{
@@ -660,12 +737,161 @@ func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				NatGateway:       ngw,
 				TransitGatewayID: tgwID,
 			})
+
+			b.addTransitGatewayRoutes(c, "public-"+zone+"-", rt, tgwAttachment)
 		}
 	}
 
+	b.addVPCEndpoints(c, routeTables)
+
 	return nil
 }
 
+// transitGatewaySubnets returns the subnets in which the transit gateway attachment is placed.
+// Unless configured, it picks one private subnet per zone, falling back to a utility or public subnet.
+func (b *NetworkModelBuilder) transitGatewaySubnets() []*kops.ClusterSubnetSpec {
+	tgw := b.Cluster.Spec.Networking.TransitGateway
+
+	var subnets []*kops.ClusterSubnetSpec
+	if len(tgw.Subnets) != 0 {
+		for _, name := range tgw.Subnets {
+			for i := range b.Cluster.Spec.Networking.Subnets {
+				subnetSpec := &b.Cluster.Spec.Networking.Subnets[i]
+				if subnetSpec.Name == name {
+					subnets = append(subnets, subnetSpec)
+				}
+			}
+		}
+		return subnets
+	}
+
+	return b.subnetsPerZone()
+}
+
+// subnetsPerZone returns one subnet per zone, preferring private subnets over utility and public subnets.
+func (b *NetworkModelBuilder) subnetsPerZone() []*kops.ClusterSubnetSpec {
+	preference := func(subnetType kops.SubnetType) int {
+		switch subnetType {
+		case kops.SubnetTypePrivate, kops.SubnetTypeDualStack:
+			return 0
+		case kops.SubnetTypeUtility:
+			return 1
+		default:
+			return 2
+		}
+	}
+	byZone := make(map[string]*kops.ClusterSubnetSpec)
+	var zones []string
+	var subnets []*kops.ClusterSubnetSpec
+	for i := range b.Cluster.Spec.Networking.Subnets {
+		subnetSpec := &b.Cluster.Spec.Networking.Subnets[i]
+		existing := byZone[subnetSpec.Zone]
+		if existing == nil {
+			zones = append(zones, subnetSpec.Zone)
+		}
+		if existing == nil || preference(subnetSpec.Type) < preference(existing.Type) {
+			byZone[subnetSpec.Zone] = subnetSpec
+		}
+	}
+	for _, zone := range zones {
+		subnets = append(subnets, byZone[zone])
+	}
+	return subnets
+}
+
+// addTransitGatewayRoutes routes the configured CIDRs of the transit gateway from the route table.
+func (b *NetworkModelBuilder) addTransitGatewayRoutes(c *fi.CloudupModelBuilderContext, prefix string, rt *awstasks.RouteTable, tgwAttachment *awstasks.TransitGatewayAttachment) {
+	if tgwAttachment == nil {
+		return
+	}
+	for _, cidr := range b.Cluster.Spec.Networking.TransitGateway.Routes {
+		c.AddTask(&awstasks.Route{
+			Name:                     fi.PtrTo(prefix + "transitgateway-" + cidr),
+			Lifecycle:                b.Lifecycle,
+			CIDR:                     fi.PtrTo(cidr),
+			RouteTable:               rt,
+			TransitGatewayID:         tgwAttachment.TransitGatewayID,
+			TransitGatewayAttachment: tgwAttachment,
+		})
+	}
+}
+
+// addVPCEndpoints creates the configured endpoints to AWS services.
+// Gateway endpoints are routed to from the route tables of the cluster,
+// interface endpoints are placed in one subnet per zone.
+func (b *NetworkModelBuilder) addVPCEndpoints(c *fi.CloudupModelBuilderContext, routeTables []*awstasks.RouteTable) {
+	endpoints := b.Cluster.Spec.Networking.VPCEndpoints
+	if len(endpoints) == 0 {
+		return
+	}
+
+	haveInterfaceEndpoints := slices.ContainsFunc(endpoints, func(endpoint kops.VPCEndpointSpec) bool {
+		return endpoint.EndpointType() == kops.VPCEndpointTypeInterface
+	})
+
+	// The interface endpoints accept HTTPS from the network
+	var endpointSG *awstasks.SecurityGroup
+	if haveInterfaceEndpoints {
+		endpointSG = &awstasks.SecurityGroup{
+			Name:        fi.PtrTo("vpc-endpoints." + b.ClusterName()),
+			Lifecycle:   b.Lifecycle,
+			VPC:         b.LinkToVPC(),
+			Description: fi.PtrTo("Security group for VPC endpoints"),
+		}
+		endpointSG.Tags = b.CloudTags(*endpointSG.Name, false)
+		c.AddTask(endpointSG)
+
+		AddDirectionalGroupRule(c, &awstasks.SecurityGroupRule{
+			Name:          fi.PtrTo("ipv4-vpc-endpoints-egress"),
+			Lifecycle:     b.Lifecycle,
+			CIDR:          fi.PtrTo("0.0.0.0/0"),
+			Egress:        fi.PtrTo(true),
+			SecurityGroup: endpointSG,
+		})
+
+		var cidrs []string
+		if b.Cluster.Spec.Networking.NetworkCIDR != "" {
+			cidrs = append(cidrs, b.Cluster.Spec.Networking.NetworkCIDR)
+		}
+		cidrs = append(cidrs, b.Cluster.Spec.Networking.AdditionalNetworkCIDRs...)
+		for _, cidr := range cidrs {
+			t := &awstasks.SecurityGroupRule{
+				Name:          fi.PtrTo("https-vpc-endpoints-" + cidr),
+				Lifecycle:     b.Lifecycle,
+				FromPort:      fi.PtrTo(int32(443)),
+				Protocol:      fi.PtrTo("tcp"),
+				SecurityGroup: endpointSG,
+				ToPort:        fi.PtrTo(int32(443)),
+			}
+			t.SetCidrOrPrefix(cidr)
+			AddDirectionalGroupRule(c, t)
+		}
+	}
+
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		name := endpoint.Service + "." + b.ClusterName()
+		t := &awstasks.VPCEndpoint{
+			Name:        fi.PtrTo(name),
+			Lifecycle:   b.Lifecycle,
+			VPC:         b.LinkToVPC(),
+			ServiceName: fi.PtrTo("com.amazonaws." + b.Region + "." + endpoint.Service),
+			Type:        fi.PtrTo(string(endpoint.EndpointType())),
+			Tags:        b.CloudTags(name, false),
+		}
+		if endpoint.EndpointType() == kops.VPCEndpointTypeGateway {
+			t.RouteTables = routeTables
+		} else {
+			for _, subnetSpec := range b.subnetsPerZone() {
+				t.Subnets = append(t.Subnets, b.LinkToSubnet(subnetSpec))
+			}
+			t.SecurityGroups = []*awstasks.SecurityGroup{endpointSG}
+			t.PrivateDNSEnabled = fi.PtrTo(true)
+		}
+		c.AddTask(t)
+	}
+}
+
 func addAdditionalRoutes(routes []kops.RouteSpec, sbName string, rt *awstasks.RouteTable, lf fi.Lifecycle, c *fi.CloudupModelBuilderContext) error {
 	for _, r := range routes {
 		t := &awstasks.Route{
@@ -724,3 +950,17 @@ func addAdditionalRoutes(routes []kops.RouteSpec, sbName string, rt *awstasks.Ro
 	}
 	return nil
 }
+
+// addSubnetTags adds the tags of the subnet to the tags of a resource created for the subnet.
+func (b *NetworkModelBuilder) addSubnetTags(tags map[string]string, subnet *awstasks.Subnet) map[string]string {
+	for i := range b.Cluster.Spec.Networking.Subnets {
+		subnetSpec := &b.Cluster.Spec.Networking.Subnets[i]
+		if fi.ValueOf(b.LinkToSubnet(subnetSpec).Name) != fi.ValueOf(subnet.Name) {
+			continue
+		}
+		for k, v := range subnetSpec.Tags {
+			tags[k] = v
+		}
+	}
+	return tags
+}
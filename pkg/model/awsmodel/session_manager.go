@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsmodel
+
+import (
+	"encoding/json"
+	"fmt"
+
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+// SessionManagerPreferencesDocument is the name of the document holding the Session Manager
+// preferences of the account and region, which applies to all sessions started without a document.
+const SessionManagerPreferencesDocument = "SSM-SessionManagerRunShell"
+
+// SessionManagerModelBuilder configures the Session Manager preferences, so that session
+// activity is logged to the destinations set in the bastion audit logging spec.
+type SessionManagerModelBuilder struct {
+	*AWSModelContext
+	Lifecycle fi.Lifecycle
+}
+
+var _ fi.CloudupModelBuilder = &SessionManagerModelBuilder{}
+
+type sessionManagerPreferences struct {
+	SchemaVersion string                          `json:"schemaVersion"`
+	Description   string                          `json:"description"`
+	SessionType   string                          `json:"sessionType"`
+	Inputs        sessionManagerPreferencesInputs `json:"inputs"`
+}
+
+type sessionManagerPreferencesInputs struct {
+	S3BucketName                string `json:"s3BucketName"`
+	S3KeyPrefix                 string `json:"s3KeyPrefix"`
+	S3EncryptionEnabled         bool   `json:"s3EncryptionEnabled"`
+	CloudWatchLogGroupName      string `json:"cloudWatchLogGroupName"`
+	CloudWatchEncryptionEnabled bool   `json:"cloudWatchEncryptionEnabled"`
+	CloudWatchStreamingEnabled  bool   `json:"cloudWatchStreamingEnabled"`
+}
+
+func (b *SessionManagerModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
+	logging := sessionManagerAuditLogging(b.Cluster)
+	if logging == nil {
+		return nil
+	}
+
+	content, err := buildSessionManagerPreferences(logging)
+	if err != nil {
+		return err
+	}
+
+	c.AddTask(&awstasks.SSMDocument{
+		Name:         fi.PtrTo(SessionManagerPreferencesDocument),
+		Lifecycle:    b.Lifecycle,
+		DocumentType: fi.PtrTo(string(ssmtypes.DocumentTypeSession)),
+		Content:      fi.NewStringResource(content),
+	})
+
+	return nil
+}
+
+// sessionManagerAuditLogging returns the audit logging spec if Session Manager access is enabled.
+func sessionManagerAuditLogging(cluster *kops.Cluster) *kops.BastionAuditLoggingSpec {
+	topology := cluster.Spec.Networking.Topology
+	if topology == nil || topology.Bastion == nil || topology.Bastion.SSM == nil || !fi.ValueOf(topology.Bastion.SSM.Enabled) {
+		return nil
+	}
+	return topology.Bastion.AuditLogging
+}
+
+// buildSessionManagerPreferences renders the Session Manager preferences document for the audit logging spec.
+func buildSessionManagerPreferences(logging *kops.BastionAuditLoggingSpec) (string, error) {
+	preferences := &sessionManagerPreferences{
+		SchemaVersion: "1.0",
+		Description:   "Session Manager preferences managed by kOps",
+		SessionType:   "Standard_Stream",
+		Inputs: sessionManagerPreferencesInputs{
+			S3BucketName:           logging.S3BucketName,
+			S3KeyPrefix:            logging.S3KeyPrefix,
+			S3EncryptionEnabled:    logging.S3BucketName != "",
+			CloudWatchLogGroupName: logging.CloudWatchLogGroupName,
+			// Log events are streamed as they happen, rather than uploaded when the session ends
+			CloudWatchStreamingEnabled: logging.CloudWatchLogGroupName != "",
+		},
+	}
+
+	data, err := json.MarshalIndent(preferences, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error building session manager preferences: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsmodel
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+func TestSessionManagerModelBuilder(t *testing.T) {
+	grid := []struct {
+		name     string
+		bastion  *kops.BastionSpec
+		expected string
+	}{
+		{
+			name: "without bastion",
+		},
+		{
+			name: "ssm without audit logging",
+			bastion: &kops.BastionSpec{
+				SSM: &kops.BastionSSMSpec{Enabled: fi.PtrTo(true)},
+			},
+		},
+		{
+			name: "audit logging without ssm",
+			bastion: &kops.BastionSpec{
+				AuditLogging: &kops.BastionAuditLoggingSpec{S3BucketName: "session-logs"},
+			},
+		},
+		{
+			name: "audit logging",
+			bastion: &kops.BastionSpec{
+				SSM: &kops.BastionSSMSpec{Enabled: fi.PtrTo(true)},
+				AuditLogging: &kops.BastionAuditLoggingSpec{
+					S3BucketName:           "session-logs",
+					S3KeyPrefix:            "minimal.example.com/",
+					CloudWatchLogGroupName: "session-logs",
+				},
+			},
+			expected: `{
+  "schemaVersion": "1.0",
+  "description": "Session Manager preferences managed by kOps",
+  "sessionType": "Standard_Stream",
+  "inputs": {
+    "s3BucketName": "session-logs",
+    "s3KeyPrefix": "minimal.example.com/",
+    "s3EncryptionEnabled": true,
+    "cloudWatchLogGroupName": "session-logs",
+    "cloudWatchEncryptionEnabled": false,
+    "cloudWatchStreamingEnabled": true
+  }
+}`,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := &kops.Cluster{}
+			if g.bastion != nil {
+				cluster.Spec.Networking.Topology = &kops.TopologySpec{Bastion: g.bastion}
+			}
+			b := &SessionManagerModelBuilder{
+				AWSModelContext: &AWSModelContext{
+					KopsModelContext: &model.KopsModelContext{
+						IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+					},
+				},
+			}
+
+			c := &fi.CloudupModelBuilderContext{
+				Tasks: make(map[string]fi.CloudupTask),
+			}
+			if err := b.Build(c); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if g.expected == "" {
+				if len(c.Tasks) != 0 {
+					t.Errorf("expected no tasks, got %v", c.Tasks)
+				}
+				return
+			}
+
+			task, ok := c.Tasks["SSMDocument/"+SessionManagerPreferencesDocument].(*awstasks.SSMDocument)
+			if !ok {
+				t.Fatalf("expected SSM document task, got %v", c.Tasks)
+			}
+			content, err := fi.ResourceAsString(task.Content)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.TrimSpace(content) != g.expected {
+				t.Errorf("unexpected document content:\n%s", content)
+			}
+		})
+	}
+}
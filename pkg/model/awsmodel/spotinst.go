@@ -828,9 +828,15 @@ func (b *SpotInstanceGroupModelBuilder) buildLoadBalancers(c *fi.CloudupModelBui
 	if b.UseLoadBalancerForAPI() && ig.HasAPIServer() {
 		if b.UseNetworkLoadBalancer() {
 			targetGroups = append(targetGroups, b.LinkToTargetGroup("tcp"))
-			if b.Cluster.Spec.API.LoadBalancer.SSLCertificate != "" {
+			if b.Cluster.Spec.API.LoadBalancer.UsesSSLCertificate() {
 				targetGroups = append(targetGroups, b.LinkToTargetGroup("tls"))
 			}
+			if internal := b.Cluster.Spec.API.LoadBalancer.Internal; internal != nil {
+				targetGroups = append(targetGroups, b.LinkToTargetGroup("internal-tcp"))
+				if internal.SSLCertificate != "" {
+					targetGroups = append(targetGroups, b.LinkToTargetGroup("internal-tls"))
+				}
+			}
 		} else {
 			loadBalancers = append(loadBalancers, b.LinkToCLB("api"))
 		}
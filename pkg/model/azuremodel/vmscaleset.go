@@ -18,6 +18,7 @@ package azuremodel
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
@@ -129,6 +130,10 @@ func (b *VMScaleSetModelBuilder) buildVMScaleSetTask(
 		return nil, err
 	}
 
+	if err = setPriority(t, &ig.Spec); err != nil {
+		return nil, err
+	}
+
 	sp, err := getStorageProfile(&ig.Spec)
 	if err != nil {
 		return nil, err
@@ -201,6 +206,47 @@ func getCapacity(spec *kops.InstanceGroupSpec) (*int64, error) {
 	return fi.PtrTo(int64(minSize)), nil
 }
 
+// setPriority sets the orchestration mode and the priority of the VM Scale Set, along with the
+// eviction policy and the max price of Spot VMs.
+func setPriority(t *azuretasks.VMScaleSet, spec *kops.InstanceGroupSpec) error {
+	azureSpec := spec.Azure
+	if azureSpec == nil {
+		azureSpec = &kops.AzureInstanceGroupSpec{}
+	}
+
+	t.OrchestrationMode = fi.PtrTo(compute.OrchestrationModeUniform)
+	if azureSpec.OrchestrationMode != "" {
+		t.OrchestrationMode = fi.PtrTo(compute.OrchestrationMode(azureSpec.OrchestrationMode))
+	}
+
+	t.Priority = fi.PtrTo(compute.VirtualMachinePriorityTypesRegular)
+	if azureSpec.Priority != "" {
+		t.Priority = fi.PtrTo(compute.VirtualMachinePriorityTypes(azureSpec.Priority))
+	} else if spec.MaxPrice != nil {
+		t.Priority = fi.PtrTo(compute.VirtualMachinePriorityTypesSpot)
+	}
+	if *t.Priority != compute.VirtualMachinePriorityTypesSpot {
+		return nil
+	}
+
+	t.EvictionPolicy = fi.PtrTo(compute.VirtualMachineEvictionPolicyTypesDelete)
+	if azureSpec.EvictionPolicy != "" {
+		t.EvictionPolicy = fi.PtrTo(compute.VirtualMachineEvictionPolicyTypes(azureSpec.EvictionPolicy))
+	}
+
+	// A max price of -1 means that Spot VMs are never evicted for price reasons
+	t.MaxPrice = fi.PtrTo(float64(-1))
+	if spec.MaxPrice != nil {
+		maxPrice, err := strconv.ParseFloat(*spec.MaxPrice, 64)
+		if err != nil {
+			return fmt.Errorf("parsing max price %q: %w", *spec.MaxPrice, err)
+		}
+		t.MaxPrice = fi.PtrTo(maxPrice)
+	}
+
+	return nil
+}
+
 func getStorageProfile(spec *kops.InstanceGroupSpec) (*compute.VirtualMachineScaleSetStorageProfile, error) {
 	var volumeSize int32
 	if spec.RootVolume != nil && spec.RootVolume.Size != nil {
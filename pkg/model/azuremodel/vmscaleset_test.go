@@ -28,6 +28,7 @@ import (
 	"k8s.io/kops/pkg/model/defaults"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azuretasks"
 	"k8s.io/kops/upup/pkg/fi/fitasks"
 )
 
@@ -139,6 +140,82 @@ func TestGetCapacity(t *testing.T) {
 	}
 }
 
+func TestSetPriority(t *testing.T) {
+	testCases := []struct {
+		spec              kops.InstanceGroupSpec
+		success           bool
+		orchestrationMode compute.OrchestrationMode
+		priority          compute.VirtualMachinePriorityTypes
+		evictionPolicy    *compute.VirtualMachineEvictionPolicyTypes
+		maxPrice          *float64
+	}{
+		{
+			spec:              kops.InstanceGroupSpec{},
+			success:           true,
+			orchestrationMode: compute.OrchestrationModeUniform,
+			priority:          compute.VirtualMachinePriorityTypesRegular,
+		},
+		{
+			spec: kops.InstanceGroupSpec{
+				MaxPrice: fi.PtrTo("0.05"),
+			},
+			success:           true,
+			orchestrationMode: compute.OrchestrationModeUniform,
+			priority:          compute.VirtualMachinePriorityTypesSpot,
+			evictionPolicy:    to.Ptr(compute.VirtualMachineEvictionPolicyTypesDelete),
+			maxPrice:          to.Ptr(0.05),
+		},
+		{
+			spec: kops.InstanceGroupSpec{
+				Azure: &kops.AzureInstanceGroupSpec{
+					OrchestrationMode: kops.AzureOrchestrationModeFlexible,
+					Priority:          kops.AzurePrioritySpot,
+					EvictionPolicy:    kops.AzureEvictionPolicyDeallocate,
+				},
+			},
+			success:           true,
+			orchestrationMode: compute.OrchestrationModeFlexible,
+			priority:          compute.VirtualMachinePriorityTypesSpot,
+			evictionPolicy:    to.Ptr(compute.VirtualMachineEvictionPolicyTypesDeallocate),
+			maxPrice:          to.Ptr(float64(-1)),
+		},
+		{
+			spec: kops.InstanceGroupSpec{
+				MaxPrice: fi.PtrTo("cheap"),
+			},
+			success: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
+			vmss := &azuretasks.VMScaleSet{}
+			err := setPriority(vmss, &tc.spec)
+			if !tc.success {
+				if err == nil {
+					t.Fatalf("unexpected success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if *vmss.OrchestrationMode != tc.orchestrationMode {
+				t.Errorf("expected orchestration mode %s, but got %s", tc.orchestrationMode, *vmss.OrchestrationMode)
+			}
+			if *vmss.Priority != tc.priority {
+				t.Errorf("expected priority %s, but got %s", tc.priority, *vmss.Priority)
+			}
+			if !reflect.DeepEqual(vmss.EvictionPolicy, tc.evictionPolicy) {
+				t.Errorf("expected eviction policy %v, but got %v", tc.evictionPolicy, vmss.EvictionPolicy)
+			}
+			if !reflect.DeepEqual(vmss.MaxPrice, tc.maxPrice) {
+				t.Errorf("expected max price %v, but got %v", tc.maxPrice, vmss.MaxPrice)
+			}
+		})
+	}
+}
+
 func TestGetStorageProfile(t *testing.T) {
 	testCases := []struct {
 		spec    kops.InstanceGroupSpec
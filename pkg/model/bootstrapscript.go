@@ -22,6 +22,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -239,6 +240,9 @@ func (b *BootstrapScript) buildEnvironmentVariables() (map[string]string, error)
 func (b *BootstrapScriptBuilder) ResourceNodeUp(c *fi.CloudupModelBuilderContext, ig *kops.InstanceGroup) (fi.Resource, error) {
 	keypairs := []string{"kubernetes-ca", "etcd-clients-ca"}
 	for _, etcdCluster := range b.Cluster.Spec.EtcdClusters {
+		if etcdCluster.External != nil {
+			continue
+		}
 		k := etcdCluster.Name
 		keypairs = append(keypairs, "etcd-manager-ca-"+k, "etcd-peers-ca-"+k)
 		if k != "events" && k != "main" {
@@ -348,6 +352,10 @@ func (b *BootstrapScript) Run(c *fi.CloudupContext) error {
 		nodeupScript.ProxyEnv = func() (string, error) {
 			return b.createProxyEnv(c.T.Cluster.Spec.Networking.EgressProxy)
 		}
+
+		nodeupScript.TrustBundles = func() (string, error) {
+			return createTrustBundles(c.T.Cluster.Spec.AdditionalTrustBundles), nil
+		}
 	}
 
 	nodeupScript.CompressUserData = fi.ValueOf(b.ig.Spec.CompressUserData)
@@ -429,6 +437,37 @@ func (b *BootstrapScript) createProxyEnv(ps *kops.EgressProxySpec) (string, erro
 	return buffer.String(), nil
 }
 
+// createTrustBundles returns the script installing the additional trust bundles in the system trust store,
+// so that nodeup and its configuration can be downloaded through TLS intercepting proxies.
+func createTrustBundles(bundles []string) string {
+	if len(bundles) == 0 {
+		return ""
+	}
+
+	var buffer bytes.Buffer
+
+	buffer.WriteString("if [[ -d " + nodeup.TrustBundlesDebianDirectory + " ]] && command -v update-ca-certificates >/dev/null; then\n")
+	buffer.WriteString("  TRUST_BUNDLES_FILE=" + path.Join(nodeup.TrustBundlesDebianDirectory, nodeup.TrustBundlesFile) + "\n")
+	buffer.WriteString("  TRUST_BUNDLES_UPDATE=\"update-ca-certificates\"\n")
+	buffer.WriteString("elif command -v update-ca-trust >/dev/null; then\n")
+	buffer.WriteString("  TRUST_BUNDLES_FILE=" + path.Join(nodeup.TrustBundlesRHELDirectory, nodeup.TrustBundlesFile) + "\n")
+	buffer.WriteString("  TRUST_BUNDLES_UPDATE=\"update-ca-trust extract\"\n")
+	buffer.WriteString("elif command -v update-ca-certificates >/dev/null; then\n")
+	buffer.WriteString("  TRUST_BUNDLES_FILE=" + path.Join(nodeup.TrustBundlesFlatcarDirectory, nodeup.TrustBundlesFlatcarFile) + "\n")
+	buffer.WriteString("  TRUST_BUNDLES_UPDATE=\"update-ca-certificates\"\n")
+	buffer.WriteString("fi\n")
+	buffer.WriteString("if [[ -n \"${TRUST_BUNDLES_FILE:-}\" ]]; then\n")
+	buffer.WriteString("cat > \"${TRUST_BUNDLES_FILE}\" << '__EOF_TRUST_BUNDLES'\n")
+	buffer.WriteString(nodeup.JoinTrustBundles(bundles))
+	buffer.WriteString("__EOF_TRUST_BUNDLES\n")
+	buffer.WriteString("  ${TRUST_BUNDLES_UPDATE}\n")
+	buffer.WriteString("else\n")
+	buffer.WriteString("  echo \"== Unable to install the additional trust bundles in the system trust store ==\"\n")
+	buffer.WriteString("fi\n")
+
+	return buffer.String()
+}
+
 func setSysctls() string {
 	var b bytes.Buffer
 
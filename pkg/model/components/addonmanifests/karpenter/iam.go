@@ -48,12 +48,10 @@ func (r *ServiceAccount) ServiceAccount() (types.NamespacedName, bool) {
 
 func addKarpenterPermissions(p *iam.Policy) {
 	p.AddUnconditionalActions(
-		// Not included because we require Karpenter
-		// use existing kOps instance group launch templates
-		// "ec2:CreateLaunchTemplate",
-		// "ec2:DeleteLaunchTemplate",
 		"ec2:CreateFleet",
+		"ec2:CreateLaunchTemplate",
 		"ec2:CreateTags",
+		"ec2:DeleteLaunchTemplate",
 		"ec2:DescribeAvailabilityZones",
 		"ec2:DescribeImages",
 		"ec2:DescribeInstanceTypeOfferings",
@@ -65,6 +63,7 @@ func addKarpenterPermissions(p *iam.Policy) {
 		"ec2:DescribeSubnets",
 		"ec2:RunInstances",
 		"ec2:TerminateInstances",
+		"iam:GetInstanceProfile",
 		"iam:PassRole",
 		"pricing:GetProducts",
 		"ssm:GetParameter",
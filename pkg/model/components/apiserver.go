@@ -137,9 +137,17 @@ func (b *KubeAPIServerOptionsBuilder) BuildOptions(o interface{}) error {
 	for _, etcdCluster := range clusterSpec.EtcdClusters {
 		switch etcdCluster.Name {
 		case "main":
-			c.EtcdServers = append(c.EtcdServers, "https://127.0.0.1:4001")
+			if etcdCluster.External != nil {
+				c.EtcdServers = append(c.EtcdServers, etcdCluster.External.Endpoints...)
+			} else {
+				c.EtcdServers = append(c.EtcdServers, "https://127.0.0.1:4001")
+			}
 		case "events":
-			c.EtcdServersOverrides = append(c.EtcdServersOverrides, "/events#https://127.0.0.1:4002")
+			if etcdCluster.External != nil {
+				c.EtcdServersOverrides = append(c.EtcdServersOverrides, "/events#"+strings.Join(etcdCluster.External.Endpoints, ";"))
+			} else {
+				c.EtcdServersOverrides = append(c.EtcdServersOverrides, "/events#https://127.0.0.1:4002")
+			}
 		}
 	}
 
@@ -222,6 +230,11 @@ func (b *KubeAPIServerOptionsBuilder) buildAPIServerCount(clusterSpec *kops.Clus
 
 	counts := make(map[string]int)
 	for _, etcdCluster := range clusterSpec.EtcdClusters {
+		if etcdCluster.External != nil {
+			// An external etcd cluster tells nothing about the control plane; set apiServerCount if it matters
+			counts[etcdCluster.Name] = 1
+			continue
+		}
 		counts[etcdCluster.Name] = len(etcdCluster.Members)
 	}
 
@@ -58,7 +58,21 @@ var _ fi.CloudupModelBuilder = &EtcdManagerBuilder{}
 
 // Build creates the tasks
 func (b *EtcdManagerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
+	// Because API server can only have a single client-cert, we need to share a client CA.
+	// The control plane nodes load it even if the API server connects to external etcd clusters.
+	c.EnsureTask(&fitasks.Keypair{
+		Name:      fi.PtrTo("etcd-clients-ca"),
+		Lifecycle: b.Lifecycle,
+		Subject:   "cn=etcd-clients-ca",
+		Type:      "ca",
+	})
+
 	for _, etcdCluster := range b.Cluster.Spec.EtcdClusters {
+		if etcdCluster.External != nil {
+			// External etcd clusters are not provisioned by kOps
+			continue
+		}
+
 		backupStore := ""
 		if etcdCluster.Backups != nil {
 			backupStore = etcdCluster.Backups.BackupStore
@@ -130,14 +144,6 @@ func (b *EtcdManagerBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			Type:      "ca",
 		})
 
-		// Because API server can only have a single client-cert, we need to share a client CA
-		c.EnsureTask(&fitasks.Keypair{
-			Name:      fi.PtrTo("etcd-clients-ca"),
-			Lifecycle: b.Lifecycle,
-			Subject:   "cn=etcd-clients-ca",
-			Type:      "ca",
-		})
-
 		if etcdCluster.Name == "cilium" {
 			clientsCaCilium := &fitasks.Keypair{
 				Name:      fi.PtrTo("etcd-clients-ca-cilium"),
@@ -436,17 +442,6 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec, instance
 		config.PeerUrls = fmt.Sprintf("%s://__name__:%d", scheme, ports.PeerPort)
 		config.ClientUrls = fmt.Sprintf("%s://%s:%d", scheme, clientHost, ports.ClientPort)
 		config.QuarantineClientUrls = fmt.Sprintf("%s://__name__:%d", scheme, ports.QuarantinedGRPCPort)
-
-		// TODO: We need to wire these into the etcd-manager spec
-		// // add timeout/heartbeat settings
-		if etcdCluster.LeaderElectionTimeout != nil {
-			//      envs = append(envs, v1.EnvVar{Name: "ETCD_ELECTION_TIMEOUT", Value: convEtcdSettingsToMs(etcdClusterSpec.LeaderElectionTimeout)})
-			return nil, fmt.Errorf("LeaderElectionTimeout not supported by etcd-manager")
-		}
-		if etcdCluster.HeartbeatInterval != nil {
-			//      envs = append(envs, v1.EnvVar{Name: "ETCD_HEARTBEAT_INTERVAL", Value: convEtcdSettingsToMs(etcdClusterSpec.HeartbeatInterval)})
-			return nil, fmt.Errorf("HeartbeatInterval not supported by etcd-manager")
-		}
 	}
 
 	{
@@ -568,6 +563,9 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec, instance
 
 	container.Env = envMap.ToEnvVars()
 
+	// Settings starting with ETCD_ are passed down to the etcd process by etcd-manager
+	container.Env = append(container.Env, buildEtcdTuningEnvVars(etcdCluster)...)
+
 	if etcdCluster.Manager != nil {
 		if etcdCluster.Manager.BackupRetentionDays != nil {
 			envVar := v1.EnvVar{
@@ -695,3 +693,48 @@ func PortsForCluster(etcdCluster kops.EtcdClusterSpec) (Ports, error) {
 		return Ports{}, fmt.Errorf("unknown etcd cluster key %q", etcdCluster.Name)
 	}
 }
+
+// buildEtcdTuningEnvVars returns the etcd settings for the timing, TLS and gRPC configuration of the etcd cluster.
+func buildEtcdTuningEnvVars(etcdCluster kops.EtcdClusterSpec) []v1.EnvVar {
+	var envVars []v1.EnvVar
+
+	// etcd expects the raft timings in milliseconds
+	if etcdCluster.HeartbeatInterval != nil {
+		envVars = append(envVars, v1.EnvVar{Name: "ETCD_HEARTBEAT_INTERVAL", Value: strconv.FormatInt(etcdCluster.HeartbeatInterval.Milliseconds(), 10)})
+	}
+	if etcdCluster.LeaderElectionTimeout != nil {
+		envVars = append(envVars, v1.EnvVar{Name: "ETCD_ELECTION_TIMEOUT", Value: strconv.FormatInt(etcdCluster.LeaderElectionTimeout.Milliseconds(), 10)})
+	}
+
+	if tls := etcdCluster.TLS; tls != nil {
+		if tls.MinVersion != "" {
+			envVars = append(envVars, v1.EnvVar{Name: "ETCD_TLS_MIN_VERSION", Value: tls.MinVersion})
+		}
+		if tls.MaxVersion != "" {
+			envVars = append(envVars, v1.EnvVar{Name: "ETCD_TLS_MAX_VERSION", Value: tls.MaxVersion})
+		}
+		if len(tls.CipherSuites) != 0 {
+			envVars = append(envVars, v1.EnvVar{Name: "ETCD_CIPHER_SUITES", Value: strings.Join(tls.CipherSuites, ",")})
+		}
+	}
+
+	if grpc := etcdCluster.GRPC; grpc != nil {
+		if grpc.MaxConcurrentStreams != nil {
+			envVars = append(envVars, v1.EnvVar{Name: "ETCD_MAX_CONCURRENT_STREAMS", Value: strconv.Itoa(int(*grpc.MaxConcurrentStreams))})
+		}
+		if grpc.MaxRequestBytes != nil {
+			envVars = append(envVars, v1.EnvVar{Name: "ETCD_MAX_REQUEST_BYTES", Value: strconv.Itoa(int(*grpc.MaxRequestBytes))})
+		}
+		if grpc.KeepaliveMinTime != nil {
+			envVars = append(envVars, v1.EnvVar{Name: "ETCD_GRPC_KEEPALIVE_MIN_TIME", Value: grpc.KeepaliveMinTime.Duration.String()})
+		}
+		if grpc.KeepaliveInterval != nil {
+			envVars = append(envVars, v1.EnvVar{Name: "ETCD_GRPC_KEEPALIVE_INTERVAL", Value: grpc.KeepaliveInterval.Duration.String()})
+		}
+		if grpc.KeepaliveTimeout != nil {
+			envVars = append(envVars, v1.EnvVar{Name: "ETCD_GRPC_KEEPALIVE_TIMEOUT", Value: grpc.KeepaliveTimeout.Duration.String()})
+		}
+	}
+
+	return envVars
+}
@@ -42,6 +42,9 @@ func (b *EtcdManagerOptionsBuilder) BuildOptions(o interface{}) error {
 
 	for i := range clusterSpec.EtcdClusters {
 		etcdCluster := &clusterSpec.EtcdClusters[i]
+		if etcdCluster.External != nil {
+			continue
+		}
 		if etcdCluster.Backups == nil {
 			etcdCluster.Backups = &kops.EtcdBackupSpec{}
 		}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/loader"
+)
+
+// EventExporterOptionsBuilder adds options for the event exporter to the model.
+type EventExporterOptionsBuilder struct {
+	*OptionsContext
+}
+
+var _ loader.OptionsBuilder = &EventExporterOptionsBuilder{}
+
+func (b *EventExporterOptionsBuilder) BuildOptions(o interface{}) error {
+	clusterSpec := o.(*kops.ClusterSpec)
+	if clusterSpec.EventExporter == nil {
+		return nil
+	}
+	ee := clusterSpec.EventExporter
+
+	if ee.Enabled == nil {
+		ee.Enabled = fi.PtrTo(false)
+	}
+
+	if ee.Image == nil {
+		ee.Image = fi.PtrTo("ghcr.io/resmoio/kubernetes-event-exporter:v1.7")
+	}
+
+	if ee.ForwarderImage == nil {
+		ee.ForwarderImage = fi.PtrTo("cr.fluentbit.io/fluent/fluent-bit:3.1.9")
+	}
+
+	if ee.Sink == "" {
+		switch clusterSpec.GetCloudProvider() {
+		case kops.CloudProviderAWS:
+			ee.Sink = kops.EventExporterSinkCloudWatch
+		case kops.CloudProviderGCE:
+			ee.Sink = kops.EventExporterSinkStackdriver
+		}
+	}
+
+	if ee.Sink == kops.EventExporterSinkCloudWatch {
+		if ee.CloudWatch == nil {
+			ee.CloudWatch = &kops.EventExporterCloudWatchSpec{}
+		}
+		if ee.CloudWatch.LogGroupName == "" {
+			ee.CloudWatch.LogGroupName = "/kops/" + b.ClusterName + "/events"
+		}
+		if ee.CloudWatch.RetentionInDays == nil {
+			ee.CloudWatch.RetentionInDays = fi.PtrTo(int32(30))
+		}
+	}
+
+	if ee.CPURequest == nil {
+		defaultCPURequest := resource.MustParse("10m")
+		ee.CPURequest = &defaultCPURequest
+	}
+
+	if ee.MemoryRequest == nil {
+		defaultMemoryRequest := resource.MustParse("64Mi")
+		ee.MemoryRequest = &defaultMemoryRequest
+	}
+
+	return nil
+}
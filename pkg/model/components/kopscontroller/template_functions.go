@@ -84,6 +84,9 @@ func (t *templateFunctions) GossipServices() ([]*corev1.Service, error) {
 	// etcd services
 	if featureflag.APIServerNodes.Enabled() {
 		for _, etcdCluster := range t.Cluster.Spec.EtcdClusters {
+			if etcdCluster.External != nil {
+				continue
+			}
 			name := "etcd-" + etcdCluster.Name + "-internal"
 			service := buildHeadlessService(types.NamespacedName{Name: name, Namespace: "kube-system"})
 			ports, err := etcdmanager.PortsForCluster(etcdCluster)
@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model"
@@ -35,10 +36,10 @@ import (
 )
 
 // KubeSchedulerConfigPath is the path where we write the kube-scheduler config file (on the control-plane nodes)
-const KubeSchedulerConfigPath = "/var/lib/kube-scheduler/config.yaml"
+const KubeSchedulerConfigPath = nodeup.KubeSchedulerConfigPath
 
 // Kubeconfig is the path where we write the kube-scheduler kubeconfig file (on the control-plane nodes)
-const KubeConfigPath = "/var/lib/kube-scheduler/kubeconfig"
+const KubeConfigPath = nodeup.KubeSchedulerKubeConfigPath
 
 // KubeSchedulerBuilder builds the configuration file for kube-scheduler
 type KubeSchedulerBuilder struct {
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/loader"
+)
+
+// PrometheusAgentOptionsBuilder adds options for the Prometheus agent to the model.
+type PrometheusAgentOptionsBuilder struct {
+	*OptionsContext
+}
+
+var _ loader.OptionsBuilder = &PrometheusAgentOptionsBuilder{}
+
+func (b *PrometheusAgentOptionsBuilder) BuildOptions(o interface{}) error {
+	clusterSpec := o.(*kops.ClusterSpec)
+	if clusterSpec.PrometheusAgent == nil {
+		return nil
+	}
+	pa := clusterSpec.PrometheusAgent
+
+	if pa.Enabled == nil {
+		pa.Enabled = fi.PtrTo(false)
+	}
+
+	if pa.Image == nil {
+		pa.Image = fi.PtrTo("quay.io/prometheus/prometheus:v2.54.1")
+	}
+
+	if pa.ScrapeInterval == nil {
+		pa.ScrapeInterval = &metav1.Duration{Duration: 60 * time.Second}
+	}
+
+	if pa.CPURequest == nil {
+		defaultCPURequest := resource.MustParse("50m")
+		pa.CPURequest = &defaultCPURequest
+	}
+
+	if pa.MemoryRequest == nil {
+		defaultMemoryRequest := resource.MustParse("200Mi")
+		pa.MemoryRequest = &defaultMemoryRequest
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+)
+
+// ExternalTaskModelBuilder adds the external tasks of the cluster spec, which are run by plugins.
+type ExternalTaskModelBuilder struct {
+	*KopsModelContext
+
+	Lifecycle fi.Lifecycle
+}
+
+var _ fi.CloudupModelBuilder = &ExternalTaskModelBuilder{}
+
+func (b *ExternalTaskModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
+	for _, spec := range b.Cluster.Spec.ExternalTasks {
+		c.AddTask(&fitasks.ExternalTask{
+			Name:      fi.PtrTo(spec.Name),
+			Lifecycle: b.Lifecycle,
+			Command:   spec.Command,
+			URL:       spec.URL,
+			DependsOn: spec.DependsOn,
+			Config:    spec.Config,
+		})
+	}
+	return nil
+}
@@ -130,14 +130,13 @@ func (b *AutoscalingGroupModelBuilder) buildInstanceTemplate(c *fi.CloudupModelB
 				// However, pods will still be IPv6 only.
 				stackType = "IPV4_IPV6"
 
-				// // Ipv6AccessType must be set when enabling IPv6.
-				// // EXTERNAL is currently the only supported value
-				// ipv6AccessType := "EXTERNAL"
-				// t.Ipv6AccessType = &ipv6AccessType
+				// The subnets have an EXTERNAL Ipv6AccessType, so the IPv6 range of the VM must be requested
+				// with an IPv6 access config. kops-controller assigns the pod CIDR of the node from this range.
+				t.HasExternalIPv6 = fi.PtrTo(true)
 			}
 			t.StackType = &stackType
 
-			nodeRole, err := iam.BuildNodeRoleSubject(ig.Spec.Role, false)
+			nodeRole, err := iam.BuildNodeRoleSubject(ig.Spec.Role, false, false)
 			if err != nil {
 				return nil, err
 			}
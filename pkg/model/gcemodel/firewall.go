@@ -46,6 +46,11 @@ func (b *FirewallModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		allProtocols = append(allProtocols, "ipip")
 	}
 
+	if b.IsIPv6Only() {
+		// The rules matching by tag also apply to IPv6 traffic, but icmp only matches ICMPv4
+		allProtocols = append(allProtocols, "58") // 58 == the IANA protocol number for ICMPv6
+	}
+
 	// Allow all TCP traffic from load balancer health checks
 	if b.Cluster.Spec.API.LoadBalancer != nil {
 		network, err := b.LinkToNetwork()
@@ -76,7 +76,7 @@ func (b *StorageAclBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		}
 
 		klog.Warningf("we need to split control-plane / worker node roles")
-		nodeRole, err := iam.BuildNodeRoleSubject(kops.InstanceGroupRoleControlPlane, false)
+		nodeRole, err := iam.BuildNodeRoleSubject(kops.InstanceGroupRoleControlPlane, false, false)
 		if err != nil {
 			return err
 		}
@@ -136,7 +136,7 @@ func (b *StorageAclBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	for serviceAccountRole := range serviceAccountRoles {
 		role := serviceAccountRole.Role
 
-		nodeRole, err := iam.BuildNodeRoleSubject(role, false)
+		nodeRole, err := iam.BuildNodeRoleSubject(role, false, false)
 		if err != nil {
 			return err
 		}
@@ -17,6 +17,7 @@ limitations under the License.
 package hetznermodel
 
 import (
+	"github.com/hetznercloud/hcloud-go/hcloud"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/upup/pkg/fi"
@@ -52,6 +53,7 @@ func (b *ServerGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 		sshkeyTasks = append(sshkeyTasks, t)
 	}
 
+	placementGroupTasks := make(map[string]*hetznertasks.PlacementGroup)
 	for _, ig := range b.InstanceGroups {
 		igSize := fi.ValueOf(ig.Spec.MinSize)
 
@@ -80,6 +82,23 @@ func (b *ServerGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 			Labels:     labels,
 		}
 
+		if ig.Spec.Hetzner != nil && ig.Spec.Hetzner.PlacementGroup != "" {
+			// Instance groups with the same placement group share it
+			name := ig.Spec.Hetzner.PlacementGroup
+			if placementGroupTasks[name] == nil {
+				placementGroupTasks[name] = &hetznertasks.PlacementGroup{
+					Name:      fi.PtrTo(name + "." + b.ClusterName()),
+					Lifecycle: b.Lifecycle,
+					Type:      string(hcloud.PlacementGroupTypeSpread),
+					Labels: map[string]string{
+						hetzner.TagKubernetesClusterName: b.ClusterName(),
+					},
+				}
+				c.AddTask(placementGroupTasks[name])
+			}
+			serverGroup.PlacementGroup = placementGroupTasks[name]
+		}
+
 		c.AddTask(&serverGroup)
 	}
 
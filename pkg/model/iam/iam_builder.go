@@ -351,6 +351,7 @@ func (r *NodeRoleAPIServer) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	p := NewPolicy(b.Cluster.GetName(), b.Partition)
 
 	b.addNodeupPermissions(p, r.warmPool)
+	b.addSessionManagerPermissions(p)
 
 	var err error
 	if p, err = b.AddS3Permissions(p); err != nil {
@@ -386,11 +387,20 @@ func (r *NodeRoleMaster) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 
 	addEtcdManagerPermissions(p)
 	b.addNodeupPermissions(p, false)
+	b.addSessionManagerPermissions(p)
 
 	if b.Cluster.Spec.IsKopsControllerIPAM() {
 		addKopsControllerIPAMPermissions(p)
 	}
 
+	if b.Cluster.Spec.UsesSecurityGroupsForPods() {
+		addKopsControllerENIConfigPermissions(p)
+	}
+
+	if r.staticNetworkInterfaces {
+		addStaticNetworkInterfacePermissions(p)
+	}
+
 	var err error
 	if p, err = b.AddS3Permissions(p); err != nil {
 		return nil, fmt.Errorf("failed to generate AWS IAM S3 access statements: %v", err)
@@ -422,6 +432,14 @@ func (r *NodeRoleMaster) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 		if nth.IsQueueMode() {
 			AddNodeTerminationHandlerSQSPermissions(p)
 		}
+
+		if ee := b.Cluster.Spec.EventExporter; ee != nil && fi.ValueOf(ee.Enabled) && ee.Sink == kops.EventExporterSinkCloudWatch {
+			AddEventExporterPermissions(p, ee.CloudWatch.LogGroupName)
+		}
+
+		if pa := b.Cluster.Spec.PrometheusAgent; pa != nil && fi.ValueOf(pa.Enabled) && pa.RemoteWrite != nil && pa.RemoteWrite.SigV4 != nil {
+			AddPrometheusAgentPermissions(p)
+		}
 	}
 
 	if b.Cluster.Spec.IAM != nil && b.Cluster.Spec.IAM.AllowContainerRegistry {
@@ -448,6 +466,7 @@ func (r *NodeRoleNode) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	p := NewPolicy(b.Cluster.GetName(), b.Partition)
 
 	b.addNodeupPermissions(p, r.enableLifecycleHookPermissions)
+	b.addSessionManagerPermissions(p)
 
 	if !b.Cluster.UsesNoneDNS() {
 		var err error
@@ -483,6 +502,8 @@ func (r *NodeRoleBastion) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	// A trivial permission is granted, because empty policies are not allowed.
 	p.unconditionalAction.Insert("ec2:DescribeRegions")
 
+	b.addSessionManagerPermissions(p)
+
 	return p, nil
 }
 
@@ -773,6 +794,15 @@ func addCalicoSrcDstCheckPermissions(p *Policy) {
 	)
 }
 
+// addStaticNetworkInterfacePermissions grants the permissions nodeup needs to attach
+// the network interfaces of control-plane instance groups
+func addStaticNetworkInterfacePermissions(p *Policy) {
+	p.unconditionalAction.Insert(
+		"ec2:AttachNetworkInterface",
+		"ec2:DescribeNetworkInterfaces",
+	)
+}
+
 func addKubeRouterSrcDstCheckPermissions(p *Policy) {
 	p.unconditionalAction.Insert(
 		"ec2:ModifyInstanceAttribute",
@@ -796,12 +826,66 @@ func (b *PolicyBuilder) addNodeupPermissions(p *Policy, enableHookSupport bool)
 	}
 }
 
+// addSessionManagerPermissions grants the permissions needed for instances to be
+// reachable through SSM Session Manager, and to write session logs if configured.
+func (b *PolicyBuilder) addSessionManagerPermissions(p *Policy) {
+	topology := b.Cluster.Spec.Networking.Topology
+	if topology == nil || topology.Bastion == nil || topology.Bastion.SSM == nil || !fi.ValueOf(topology.Bastion.SSM.Enabled) {
+		return
+	}
+
+	p.unconditionalAction.Insert(
+		"ssm:UpdateInstanceInformation",
+		"ssmmessages:CreateControlChannel",
+		"ssmmessages:CreateDataChannel",
+		"ssmmessages:OpenControlChannel",
+		"ssmmessages:OpenDataChannel",
+	)
+
+	logging := topology.Bastion.AuditLogging
+	if logging == nil {
+		return
+	}
+
+	if logging.S3BucketName != "" {
+		p.unconditionalAction.Insert("s3:GetEncryptionConfiguration")
+		p.Statement = append(p.Statement, &Statement{
+			Effect: StatementEffectAllow,
+			Action: stringorset.Of("s3:PutObject"),
+			Resource: stringorset.Of(
+				fmt.Sprintf("arn:%s:s3:::%s/%s*", p.partition, logging.S3BucketName, logging.S3KeyPrefix),
+			),
+		})
+	}
+
+	if logging.CloudWatchLogGroupName != "" {
+		p.unconditionalAction.Insert("logs:DescribeLogGroups")
+		p.Statement = append(p.Statement, &Statement{
+			Effect: StatementEffectAllow,
+			Action: stringorset.Of(
+				"logs:CreateLogStream",
+				"logs:DescribeLogStreams",
+				"logs:PutLogEvents",
+			),
+			Resource: stringorset.Of(
+				fmt.Sprintf("arn:%s:logs:*:*:log-group:%s:*", p.partition, logging.CloudWatchLogGroupName),
+			),
+		})
+	}
+}
+
 func addKopsControllerIPAMPermissions(p *Policy) {
 	p.unconditionalAction.Insert(
 		"ec2:DescribeNetworkInterfaces",
 	)
 }
 
+func addKopsControllerENIConfigPermissions(p *Policy) {
+	p.unconditionalAction.Insert(
+		"ec2:DescribeSecurityGroups",
+	)
+}
+
 func addEtcdManagerPermissions(p *Policy) {
 	p.unconditionalAction.Insert(
 		"ec2:DescribeVolumes", // aws.go
@@ -1202,3 +1286,29 @@ func AddNodeTerminationHandlerSQSPermissions(p *Policy) {
 		"autoscaling:CompleteLifecycleAction",
 	)
 }
+
+// AddEventExporterPermissions appends policy statements that the event exporter needs
+// to write cluster events to its CloudWatch Logs log group.
+func AddEventExporterPermissions(p *Policy, logGroupName string) {
+	p.unconditionalAction.Insert("logs:DescribeLogGroups")
+	p.Statement = append(p.Statement, &Statement{
+		Effect: StatementEffectAllow,
+		Action: stringorset.Of(
+			"logs:CreateLogGroup",
+			"logs:CreateLogStream",
+			"logs:DescribeLogStreams",
+			"logs:PutLogEvents",
+			"logs:PutRetentionPolicy",
+		),
+		Resource: stringorset.Of(
+			fmt.Sprintf("arn:%s:logs:*:*:log-group:%s", p.partition, logGroupName),
+			fmt.Sprintf("arn:%s:logs:*:*:log-group:%s:*", p.partition, logGroupName),
+		),
+	})
+}
+
+// AddPrometheusAgentPermissions appends policy statements that the Prometheus agent needs
+// to remote-write metrics to Amazon Managed Service for Prometheus.
+func AddPrometheusAgentPermissions(p *Policy) {
+	p.unconditionalAction.Insert("aps:RemoteWrite")
+}
@@ -100,6 +100,7 @@ func TestPolicyGeneration(t *testing.T) {
 		Gossip                 bool
 		Role                   Subject
 		AllowContainerRegistry bool
+		Bastion                *kops.BastionSpec
 		Policy                 string
 	}{
 		{
@@ -168,6 +169,25 @@ func TestPolicyGeneration(t *testing.T) {
 			AllowContainerRegistry: true,
 			Policy:                 "tests/iam_builder_bastion.json",
 		},
+		{
+			Role: &NodeRoleNode{},
+			Bastion: &kops.BastionSpec{
+				SSM: &kops.BastionSSMSpec{Enabled: fi.PtrTo(true)},
+			},
+			Policy: "tests/iam_builder_node_ssm.json",
+		},
+		{
+			Role: &NodeRoleBastion{},
+			Bastion: &kops.BastionSpec{
+				SSM: &kops.BastionSSMSpec{Enabled: fi.PtrTo(true)},
+				AuditLogging: &kops.BastionAuditLoggingSpec{
+					S3BucketName:           "session-logs",
+					S3KeyPrefix:            "bastion/",
+					CloudWatchLogGroupName: "session-logs",
+				},
+			},
+			Policy: "tests/iam_builder_bastion_ssm_audit_logging.json",
+		},
 	}
 
 	for i, x := range grid {
@@ -218,6 +238,11 @@ func TestPolicyGeneration(t *testing.T) {
 			Role:      x.Role,
 			Partition: "aws-test",
 		}
+		if x.Bastion != nil {
+			b.Cluster.Spec.Networking.Topology = &kops.TopologySpec{
+				Bastion: x.Bastion,
+			}
+		}
 		if x.Gossip {
 			b.Cluster.SetName("iam-builder-test.k8s.local")
 		} else {
@@ -37,7 +37,9 @@ type Subject interface {
 }
 
 // NodeRoleMaster represents the role of control-plane nodes, and implements Subject.
-type NodeRoleMaster struct{}
+type NodeRoleMaster struct {
+	staticNetworkInterfaces bool
+}
 
 // ServiceAccount implements Subject.
 func (_ *NodeRoleMaster) ServiceAccount() (types.NamespacedName, bool) {
@@ -86,10 +88,12 @@ func (g *GenericServiceAccount) BuildAWSPolicy(*PolicyBuilder) (*Policy, error)
 }
 
 // BuildNodeRoleSubject returns a Subject implementation for the specified InstanceGroupRole.
-func BuildNodeRoleSubject(igRole kops.InstanceGroupRole, enableLifecycleHookPermissions bool) (Subject, error) {
+func BuildNodeRoleSubject(igRole kops.InstanceGroupRole, enableLifecycleHookPermissions bool, enableStaticNetworkInterfacePermissions bool) (Subject, error) {
 	switch igRole {
 	case kops.InstanceGroupRoleControlPlane:
-		return &NodeRoleMaster{}, nil
+		return &NodeRoleMaster{
+			staticNetworkInterfaces: enableStaticNetworkInterfacePermissions,
+		}, nil
 	case kops.InstanceGroupRoleAPIServer:
 		return &NodeRoleAPIServer{
 			warmPool: enableLifecycleHookPermissions,
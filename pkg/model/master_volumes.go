@@ -186,6 +186,7 @@ func (b *MasterVolumeBuilder) addAWSVolume(c *fi.CloudupModelBuilderContext, nam
 		KmsKeyId:         m.KmsKeyID,
 		Encrypted:        fi.PtrTo(encrypted),
 		Tags:             tags,
+		PreventDestroy:   b.Cluster.Spec.DeletionProtection,
 	}
 	switch ec2types.VolumeType(volumeType) {
 	case ec2types.VolumeTypeGp3:
@@ -47,6 +47,16 @@ func (b *KopsModelContext) SecurityGroupName(role kops.InstanceGroupRole) string
 	}
 }
 
+// PodSecurityGroupName returns the name of the security group for the pods of an instance group
+func (b *KopsModelContext) PodSecurityGroupName(ig *kops.InstanceGroup) string {
+	return ig.ObjectMeta.Name + ".pods." + b.ClusterName()
+}
+
+// StaticNetworkInterfaceName returns the name of the network interface that is attached to the instance of an instance group
+func (b *KopsModelContext) StaticNetworkInterfaceName(ig *kops.InstanceGroup) string {
+	return ig.ObjectMeta.Name + "." + b.ClusterName()
+}
+
 // LinkToSecurityGroup creates a task link the security group to the instncegroup
 func (b *KopsModelContext) LinkToSecurityGroup(role kops.InstanceGroupRole) *awstasks.SecurityGroup {
 	name := b.SecurityGroupName(role)
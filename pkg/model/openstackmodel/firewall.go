@@ -23,10 +23,12 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstacktasks"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/utils/net"
 
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/l7policies"
 	sg "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
 )
@@ -351,6 +353,45 @@ func (b *FirewallModelBuilder) addHTTPSRules(c *fi.CloudupModelBuilderContext, s
 	return nil
 }
 
+// addL7PoolRules - Add rules to the ports of the control plane nodes that L7 policies of the API loadbalancer redirect to
+func (b *FirewallModelBuilder) addL7PoolRules(c *fi.CloudupModelBuilderContext, sgMap map[string]*openstacktasks.SecurityGroup, useVIPACL bool) {
+	lbConfig := b.Cluster.Spec.CloudProvider.Openstack.Loadbalancer
+	if !b.UseLoadBalancerForAPI() || lbConfig == nil {
+		return
+	}
+	masterSG := sgMap[b.SecurityGroupName(kops.InstanceGroupRoleControlPlane)]
+	lbSG := sgMap[b.APIResourceName()]
+
+	ports := sets.New[int]()
+	for _, policy := range lbConfig.L7Policies {
+		if policy.Action != "" && policy.Action != string(l7policies.ActionRedirectToPool) {
+			continue
+		}
+		port := wellknownports.KubeAPIServerHealthCheck
+		if policy.PoolPort != nil {
+			port = fi.ValueOf(policy.PoolPort)
+		}
+		ports.Insert(port)
+	}
+	for _, port := range sets.List(ports) {
+		rule := &openstacktasks.SecurityGroupRule{
+			Lifecycle:    b.Lifecycle,
+			Direction:    s(string(rules.DirIngress)),
+			Protocol:     s(IPProtocolTCP),
+			EtherType:    s(IPV4),
+			PortRangeMin: i(port),
+			PortRangeMax: i(port),
+		}
+		if useVIPACL {
+			// The loadbalancer has no security group, its requests come from the network
+			rule.RemoteIPPrefix = s(b.Cluster.Spec.Networking.NetworkCIDR)
+			b.addDirectionalGroupRule(c, masterSG, nil, rule)
+		} else {
+			b.addDirectionalGroupRule(c, masterSG, lbSG, rule)
+		}
+	}
+}
+
 // addKubeletRules - Add rules to 10250 port
 func (b *FirewallModelBuilder) addKubeletRules(c *fi.CloudupModelBuilderContext, sgMap map[string]*openstacktasks.SecurityGroup) error {
 	// TODO: This is the default port for kubelet and may be overridden
@@ -752,6 +793,7 @@ func (b *FirewallModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 
 	// Add API Server Rules
 	b.addHTTPSRules(c, sgMap, useVIPACL)
+	b.addL7PoolRules(c, sgMap, useVIPACL)
 
 	// Add SSH
 	b.addSSHRules(c, sgMap)
@@ -22,6 +22,9 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/l7policies"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/listeners"
+	v2pools "github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/pools"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
@@ -339,11 +342,18 @@ func (b *ServerGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 
 		lbfipTask.WellKnownServices = append(lbfipTask.WellKnownServices, wellknownservices.KubeAPIServer)
 
+		lbConfig := b.Cluster.Spec.CloudProvider.Openstack.Loadbalancer
+
 		poolTask := &openstacktasks.LBPool{
 			Name:         fi.PtrTo(fmt.Sprintf("%s-https", fi.ValueOf(lbTask.Name))),
 			Loadbalancer: lbTask,
 			Lifecycle:    b.Lifecycle,
 		}
+		if lbConfig.TLSContainerRef != nil {
+			// The listener terminates TLS, so the requests are encrypted again to kube-apiserver
+			poolTask.Protocol = fi.PtrTo(string(v2pools.ProtocolHTTP))
+			poolTask.TLSEnabled = fi.PtrTo(true)
+		}
 		c.AddTask(poolTask)
 
 		nameForResource := fi.ValueOf(lbTask.Name)
@@ -353,6 +363,10 @@ func (b *ServerGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 			Lifecycle: b.Lifecycle,
 			Pool:      poolTask,
 		}
+		if lbConfig.TLSContainerRef != nil {
+			listenerTask.Protocol = fi.PtrTo(string(listeners.ProtocolTerminatedHTTPS))
+			listenerTask.TLSContainerRef = lbConfig.TLSContainerRef
+		}
 		if useVIPACL {
 			var AllowedCIDRs []string
 			// currently kOps openstack supports only ipv4 addresses
@@ -394,6 +408,72 @@ func (b *ServerGroupModelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 			}
 		}
 
+		// Policies redirecting to the same port of the control plane nodes share a pool
+		l7Pools := make(map[int]*openstacktasks.LBPool)
+		for i, policy := range lbConfig.L7Policies {
+			policyTask := &openstacktasks.LBL7Policy{
+				Name:      fi.PtrTo(fmt.Sprintf("%s-%s", nameForResource, policy.Name)),
+				Listener:  listenerTask,
+				Action:    fi.PtrTo(string(l7policies.ActionRedirectToPool)),
+				Position:  fi.PtrTo(i + 1),
+				Lifecycle: b.Lifecycle,
+			}
+			if policy.Action != "" {
+				policyTask.Action = fi.PtrTo(policy.Action)
+			}
+			if policy.Position != nil {
+				policyTask.Position = policy.Position
+			}
+			for _, rule := range policy.Rules {
+				compareType := rule.CompareType
+				if compareType == "" {
+					compareType = string(l7policies.CompareTypeEqual)
+				}
+				policyTask.Rules = append(policyTask.Rules, openstacktasks.LBL7Rule{
+					Type:        rule.Type,
+					CompareType: compareType,
+					Key:         rule.Key,
+					Value:       rule.Value,
+					Invert:      rule.Invert,
+				})
+			}
+
+			if fi.ValueOf(policyTask.Action) == string(l7policies.ActionRedirectToPool) {
+				port := wellknownports.KubeAPIServerHealthCheck
+				if policy.PoolPort != nil {
+					port = fi.ValueOf(policy.PoolPort)
+				}
+				l7Pool := l7Pools[port]
+				if l7Pool == nil {
+					l7Pool = &openstacktasks.LBPool{
+						Name:         fi.PtrTo(fmt.Sprintf("%s-%d", nameForResource, port)),
+						Loadbalancer: lbTask,
+						Protocol:     fi.PtrTo(string(v2pools.ProtocolHTTP)),
+						TLSEnabled:   fi.PtrTo(fi.ValueOf(policy.PoolTLS)),
+						Lifecycle:    b.Lifecycle,
+					}
+					c.AddTask(l7Pool)
+					l7Pools[port] = l7Pool
+
+					for _, ig := range b.InstanceGroups {
+						if ig.Spec.Role == kops.InstanceGroupRoleControlPlane {
+							c.AddTask(&openstacktasks.PoolAssociation{
+								Name:          fi.PtrTo(fmt.Sprintf("%s-%s-%d", clusterName, ig.Name, port)),
+								ServerPrefix:  fi.PtrTo(ig.Name),
+								ClusterName:   s(clusterName),
+								Pool:          l7Pool,
+								InterfaceName: fi.PtrTo(ifName),
+								ProtocolPort:  fi.PtrTo(port),
+								Lifecycle:     b.Lifecycle,
+								Weight:        fi.PtrTo(1),
+							})
+						}
+					}
+				}
+				policyTask.RedirectPool = l7Pool
+			}
+			c.AddTask(policyTask)
+		}
 	}
 
 	return nil
@@ -48,6 +48,10 @@ NODEUP_HASH_ARM64={{ NodeUpSourceHashArm64 }}
 {{ EnvironmentVariables }}
 
 {{ ProxyEnv }}
+{{- with TrustBundles }}
+
+{{ . }}
+{{- end }}
 
 {{ SetSysctls }}
 
@@ -183,6 +187,8 @@ type NodeUpScript struct {
 	CloudProvider        string
 	ProxyEnv             func() (string, error)
 	EnvironmentVariables func() (string, error)
+	// TrustBundles installs the additional trust bundles before downloading nodeup.
+	TrustBundles func() (string, error)
 }
 
 func funcEmptyString() (string, error) {
@@ -196,6 +202,9 @@ func (b *NodeUpScript) Build() (fi.Resource, error) {
 	if b.EnvironmentVariables == nil {
 		b.EnvironmentVariables = funcEmptyString
 	}
+	if b.TrustBundles == nil {
+		b.TrustBundles = funcEmptyString
+	}
 
 	functions := template.FuncMap{
 		"NodeUpSourceAmd64": func() string {
@@ -250,6 +259,7 @@ func (b *NodeUpScript) Build() (fi.Resource, error) {
 
 		"ProxyEnv":             b.ProxyEnv,
 		"EnvironmentVariables": b.EnvironmentVariables,
+		"TrustBundles":         b.TrustBundles,
 	}
 
 	return newTemplateResource("nodeup", nodeUpTemplate, functions, nil)
@@ -51,7 +51,7 @@ func (b *APILoadBalancerModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 	case kops.LoadBalancerTypePublic:
 		klog.V(8).Infof("Using public load-balancer")
 	case kops.LoadBalancerTypeInternal:
-		return fmt.Errorf("Scaleway clusters don't have a VPC yet, so internal load-balancers are not supported at the time")
+		return fmt.Errorf("internal load-balancers are not supported for Scaleway clusters at the time")
 	default:
 		return fmt.Errorf("unhandled load-balancer type %q", lbSpec.Type)
 	}
@@ -80,6 +80,21 @@ func (b *APILoadBalancerModelBuilder) Build(c *fi.CloudupModelBuilderContext) er
 		SslCompatibilityLevel: string(lb.SSLCompatibilityLevelSslCompatibilityLevelUnknown),
 	}
 
+	// The load-balancer reaches the control-plane servers through their private network
+	if b.UsesPrivateNetworks() {
+		for _, ig := range b.InstanceGroups {
+			if !ig.IsControlPlane() {
+				continue
+			}
+			subnet := b.FindSubnet(ig.Spec.Subnets[0])
+			if subnet == nil {
+				return fmt.Errorf("building load-balancer task: subnet %q not found", ig.Spec.Subnets[0])
+			}
+			loadBalancer.PrivateNetwork = b.LinkToPrivateNetwork(subnet)
+			break
+		}
+	}
+
 	c.AddTask(loadBalancer)
 
 	loadBalancer.WellKnownServices = append(loadBalancer.WellKnownServices, wellknownservices.KubeAPIServer)
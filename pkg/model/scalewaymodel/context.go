@@ -17,9 +17,28 @@ limitations under the License.
 package scalewaymodel
 
 import (
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scalewaytasks"
 )
 
 type ScwModelContext struct {
 	*model.KopsModelContext
 }
+
+// UsesPrivateNetworks returns true if the servers of the cluster are attached to private networks, which is the case
+// when the cluster has a network CIDR or uses an existing VPC. Otherwise, they are only reachable through their public IPs.
+func (b *ScwModelContext) UsesPrivateNetworks() bool {
+	return b.Cluster.Spec.Networking.NetworkCIDR != "" || b.Cluster.Spec.Networking.NetworkID != ""
+}
+
+// LinkToVPC returns the VPC of the cluster.
+func (b *ScwModelContext) LinkToVPC() *scalewaytasks.VPC {
+	return &scalewaytasks.VPC{Name: fi.PtrTo(b.ClusterName())}
+}
+
+// LinkToPrivateNetwork returns the private network of the subnet.
+func (b *ScwModelContext) LinkToPrivateNetwork(subnet *kops.ClusterSubnetSpec) *scalewaytasks.PrivateNetwork {
+	return &scalewaytasks.PrivateNetwork{Name: fi.PtrTo(subnet.Name + "." + b.ClusterName())}
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
@@ -45,7 +46,13 @@ var _ fi.CloudupModelBuilder = &InstanceModelBuilder{}
 func (b *InstanceModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 	for _, ig := range b.InstanceGroups {
 		name := ig.Name
-		zone, err := scw.ParseZone(ig.Spec.Subnets[0])
+		// Subnets are named after their zone, unless the cluster uses private networks
+		subnet := b.FindSubnet(ig.Spec.Subnets[0])
+		zoneName := ig.Spec.Subnets[0]
+		if subnet != nil && subnet.Zone != "" {
+			zoneName = subnet.Zone
+		}
+		zone, err := scw.ParseZone(zoneName)
 		if err != nil {
 			return fmt.Errorf("error building instance task for %q: %w", name, err)
 		}
@@ -74,6 +81,14 @@ func (b *InstanceModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 			Tags:           instanceTags,
 		}
 
+		if b.UsesPrivateNetworks() {
+			if subnet == nil {
+				return fmt.Errorf("error building instance task for %q: subnet %q not found", name, ig.Spec.Subnets[0])
+			}
+			instance.PrivateNetwork = b.LinkToPrivateNetwork(subnet)
+			instance.AssociatePublicIP = fi.PtrTo(subnet.Type != kops.SubnetTypePrivate)
+		}
+
 		if ig.IsControlPlane() {
 			instance.Tags = append(instance.Tags, scaleway.TagNameRolePrefix+"="+scaleway.TagRoleControlPlane)
 			instance.Role = fi.PtrTo(scaleway.TagRoleControlPlane)
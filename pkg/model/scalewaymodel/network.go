@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaymodel
+
+import (
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scalewaytasks"
+)
+
+// NetworkModelBuilder configures the VPC, the private networks and the public gateways of the cluster
+type NetworkModelBuilder struct {
+	*ScwModelContext
+	Lifecycle fi.Lifecycle
+}
+
+var _ fi.CloudupModelBuilder = &NetworkModelBuilder{}
+
+func (b *NetworkModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
+	if !b.UsesPrivateNetworks() {
+		return nil
+	}
+
+	tags := []string{
+		scaleway.TagClusterName + "=" + b.ClusterName(),
+	}
+	for k, v := range b.CloudTags(b.ClusterName(), false) {
+		tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	vpc := b.LinkToVPC()
+	vpc.Lifecycle = b.Lifecycle
+	vpc.Region = fi.PtrTo(b.Region)
+	if b.Cluster.Spec.Networking.NetworkID != "" {
+		vpc.ID = fi.PtrTo(b.Cluster.Spec.Networking.NetworkID)
+		vpc.Shared = fi.PtrTo(true)
+	} else {
+		vpc.Tags = tags
+	}
+	c.AddTask(vpc)
+
+	subnets := b.Cluster.Spec.Networking.Subnets
+	for i := range subnets {
+		subnet := &subnets[i]
+
+		privateNetwork := b.LinkToPrivateNetwork(subnet)
+		privateNetwork.Lifecycle = b.Lifecycle
+		privateNetwork.Region = fi.PtrTo(b.Region)
+		privateNetwork.VPC = vpc
+		if subnet.ID != "" {
+			privateNetwork.ID = fi.PtrTo(subnet.ID)
+			privateNetwork.Shared = fi.PtrTo(true)
+		} else {
+			privateNetwork.Tags = tags
+			if subnet.CIDR != "" {
+				privateNetwork.IPRange = fi.PtrTo(subnet.CIDR)
+			} else if len(subnets) == 1 && b.Cluster.Spec.Networking.NetworkCIDR != "" {
+				privateNetwork.IPRange = fi.PtrTo(b.Cluster.Spec.Networking.NetworkCIDR)
+			}
+		}
+		c.AddTask(privateNetwork)
+
+		// The servers of private subnets have no public IP, so they reach the internet through a public gateway
+		if subnet.Type == kops.SubnetTypePrivate {
+			gateway := &scalewaytasks.Gateway{
+				Name:           fi.PtrTo(subnet.Name + "." + b.ClusterName()),
+				Lifecycle:      b.Lifecycle,
+				Zone:           fi.PtrTo(subnet.Zone),
+				Type:           scalewaytasks.GatewayDefaultType,
+				Tags:           tags,
+				PrivateNetwork: privateNetwork,
+			}
+			c.AddTask(gateway)
+		}
+	}
+
+	return nil
+}
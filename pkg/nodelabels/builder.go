@@ -101,7 +101,7 @@ func BuildNodeLabels(cluster *api.Cluster, instanceGroup *api.InstanceGroup) (ma
 	}
 
 	if instanceGroup.Spec.Manager == api.InstanceManagerKarpenter {
-		nodeLabels["karpenter.sh/provisioner-name"] = instanceGroup.ObjectMeta.Name
+		nodeLabels["karpenter.sh/nodepool"] = instanceGroup.ObjectMeta.Name
 	}
 
 	return nodeLabels, nil
@@ -249,6 +249,9 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 				return nil, nil, err
 			}
 			for _, etcdCluster := range cluster.Spec.EtcdClusters {
+				if etcdCluster.External != nil {
+					continue
+				}
 				k := etcdCluster.Name
 				if err := loadCertificates(keysets, "etcd-manager-ca-"+k, config, true); err != nil {
 					return nil, nil, err
@@ -429,6 +432,9 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddre
 
 	if isMaster {
 		for _, etcdCluster := range cluster.Spec.EtcdClusters {
+			if etcdCluster.External != nil {
+				continue
+			}
 			config.EtcdClusterNames = append(config.EtcdClusterNames, etcdCluster.Name)
 		}
 		config.EtcdManifests = n.etcdManifests[ig.Name]
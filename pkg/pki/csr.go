@@ -44,6 +44,9 @@ func BuildPKISerial(timestamp int64) *big.Int {
 	return serial
 }
 
+// CertificateBackdate is how long before their issuance certificates become valid, to tolerate clock skew.
+const CertificateBackdate = 48 * time.Hour
+
 func signNewCertificate(privateKey *PrivateKey, template *x509.Certificate, signer *x509.Certificate, signerPrivateKey *PrivateKey) (*Certificate, error) {
 	if template.PublicKey == nil {
 		template.PublicKey = privateKey.Key.Public()
@@ -55,7 +58,7 @@ func signNewCertificate(privateKey *PrivateKey, template *x509.Certificate, sign
 
 	now := time.Now()
 	if template.NotBefore.IsZero() {
-		template.NotBefore = now.Add(time.Hour * -48)
+		template.NotBefore = now.Add(-CertificateBackdate)
 	}
 
 	if template.NotAfter.IsZero() {
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+const TypeACMCertificate = "acm-certificate"
+
+func DumpACMCertificate(op *resources.DumpOperation, r *resources.Resource) error {
+	data := make(map[string]interface{})
+	data["id"] = r.ID
+	data["name"] = r.Name
+	data["type"] = r.Type
+	data["raw"] = r.Obj
+	op.Dump.Resources = append(op.Dump.Resources, data)
+
+	return nil
+}
+
+func DeleteACMCertificate(cloud fi.Cloud, r *resources.Resource) error {
+	ctx := context.TODO()
+	c := cloud.(awsup.AWSCloud)
+
+	arn := r.ID
+
+	klog.V(2).Infof("Deleting ACM certificate %q", arn)
+	request := &acm.DeleteCertificateInput{
+		CertificateArn: aws.String(arn),
+	}
+	if _, err := c.ACM().DeleteCertificate(ctx, request); err != nil {
+		var notFound *acmtypes.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			// Concurrently deleted
+			return nil
+		}
+		return fmt.Errorf("error deleting ACM certificate %q: %w", arn, err)
+	}
+	return nil
+}
+
+func ListACMCertificates(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Resource, error) {
+	ctx := context.TODO()
+	c := cloud.(awsup.AWSCloud)
+
+	klog.V(2).Infof("Listing ACM certificates")
+
+	var resourceTrackers []*resources.Resource
+
+	paginator := acm.NewListCertificatesPaginator(c.ACM(), &acm.ListCertificatesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing ACM certificates: %w", err)
+		}
+		for _, summary := range page.CertificateSummaryList {
+			arn := aws.ToString(summary.CertificateArn)
+
+			tagsResponse, err := c.ACM().ListTagsForCertificate(ctx, &acm.ListTagsForCertificateInput{
+				CertificateArn: summary.CertificateArn,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error listing tags of ACM certificate %q: %w", arn, err)
+			}
+			var name string
+			owned := false
+			for _, tag := range tagsResponse.Tags {
+				switch aws.ToString(tag.Key) {
+				case "Name":
+					name = aws.ToString(tag.Value)
+				case awsup.TagClusterName:
+					owned = aws.ToString(tag.Value) == clusterName
+				}
+			}
+			if !owned {
+				continue
+			}
+
+			response, err := c.ACM().DescribeCertificate(ctx, &acm.DescribeCertificateInput{
+				CertificateArn: summary.CertificateArn,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error describing ACM certificate %q: %w", arn, err)
+			}
+			certificate := response.Certificate
+
+			resourceTracker := &resources.Resource{
+				Name:    name,
+				ID:      arn,
+				Type:    TypeACMCertificate,
+				Deleter: DeleteACMCertificate,
+				Dumper:  DumpACMCertificate,
+				Obj:     certificate,
+			}
+
+			// A certificate cannot be deleted while a load balancer listener uses it
+			var blocked []string
+			for _, inUseBy := range certificate.InUseBy {
+				blocked = append(blocked, TypeLoadBalancer+":"+inUseBy)
+			}
+			resourceTracker.Blocked = blocked
+
+			resourceTrackers = append(resourceTrackers, resourceTracker)
+		}
+	}
+
+	return resourceTrackers, nil
+}
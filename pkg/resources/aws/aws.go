@@ -76,6 +76,8 @@ func ListResourcesAWS(cloud awsup.AWSCloud, clusterInfo resources.ClusterInfo) (
 		ListDhcpOptions,
 		ListInternetGateways,
 		ListEgressOnlyInternetGateways,
+		ListTransitGatewayAttachments,
+		ListVPCEndpoints,
 		ListRouteTables,
 		ListSubnets,
 		ListENIs,
@@ -91,6 +93,8 @@ func ListResourcesAWS(cloud awsup.AWSCloud, clusterInfo resources.ClusterInfo) (
 		ListSQSQueues,
 		// EventBridge
 		ListEventBridgeRules,
+		// ACM
+		ListACMCertificates,
 	}
 
 	if !dns.IsGossipClusterName(clusterName) && !clusterUsesNoneDNS {
@@ -338,8 +342,16 @@ func DeleteInstances(cloud fi.Cloud, t []*resources.Resource) error {
 		request := &ec2.TerminateInstancesInput{
 			InstanceIds: ids,
 		}
-		ids = []string{}
 		_, err := c.EC2().TerminateInstances(ctx, request)
+		if awsup.AWSErrorCode(err) == "OperationNotPermitted" {
+			// Control-plane instances have termination protection when the cluster had deletion protection enabled
+			klog.Infof("Disabling termination protection on %d EC2 instances", len(ids))
+			if err := awsup.DisableTerminationProtection(ctx, c, ids...); err != nil {
+				return err
+			}
+			_, err = c.EC2().TerminateInstances(ctx, request)
+		}
+		ids = []string{}
 		if err != nil {
 			if awsup.AWSErrorCode(err) == "InvalidInstanceID.NotFound" {
 				klog.V(2).Infof("Got InvalidInstanceID.NotFound error terminating instances; will treat as already terminated")
@@ -398,6 +410,12 @@ func ListInstances(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Reso
 				for _, sg := range instance.SecurityGroups {
 					blocks = append(blocks, "security-group:"+aws.ToString(sg.GroupId))
 				}
+				for _, eni := range instance.NetworkInterfaces {
+					if eni.Attachment == nil || fi.ValueOf(eni.Attachment.DeleteOnTermination) {
+						continue
+					}
+					blocks = append(blocks, string(ec2types.ResourceTypeNetworkInterface)+":"+aws.ToString(eni.NetworkInterfaceId))
+				}
 
 				resourceTracker.Blocks = blocks
 
@@ -593,6 +611,7 @@ func ListVolumes(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Resour
 			Type:    "volume",
 			Deleter: DeleteVolume,
 			Shared:  HasSharedTag(string(ec2types.ResourceTypeVolume)+":"+id, volume.Tags, clusterName),
+			Obj:     volume,
 		}
 
 		var blocks []string
@@ -1217,6 +1236,179 @@ func DescribeEgressOnlyInternetGateways(cloud fi.Cloud) ([]ec2types.EgressOnlyIn
 	return gateways, nil
 }
 
+func DumpTransitGatewayAttachment(op *resources.DumpOperation, r *resources.Resource) error {
+	data := make(map[string]interface{})
+	data["id"] = r.ID
+	data["type"] = r.Type
+	data["raw"] = r.Obj
+	op.Dump.Resources = append(op.Dump.Resources, data)
+	return nil
+}
+
+func DeleteTransitGatewayAttachment(cloud fi.Cloud, r *resources.Resource) error {
+	ctx := context.TODO()
+	c := cloud.(awsup.AWSCloud)
+
+	id := r.ID
+
+	klog.V(2).Infof("Deleting EC2 TransitGatewayAttachment %q", id)
+	request := &ec2.DeleteTransitGatewayVpcAttachmentInput{
+		TransitGatewayAttachmentId: &id,
+	}
+	_, err := c.EC2().DeleteTransitGatewayVpcAttachment(ctx, request)
+	if err != nil {
+		if IsDependencyViolation(err) {
+			return err
+		}
+		if awsup.AWSErrorCode(err) == "InvalidTransitGatewayAttachmentID.NotFound" {
+			klog.Infof("Transit gateway attachment %q not found; assuming already deleted", id)
+			return nil
+		}
+		return fmt.Errorf("error deleting TransitGatewayAttachment %q: %v", id, err)
+	}
+
+	return nil
+}
+
+func ListTransitGatewayAttachments(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Resource, error) {
+	ctx := context.TODO()
+	c := cloud.(awsup.AWSCloud)
+
+	klog.V(2).Infof("Listing EC2 TransitGatewayAttachments")
+	request := &ec2.DescribeTransitGatewayVpcAttachmentsInput{
+		Filters: BuildEC2Filters(cloud),
+	}
+	response, err := c.EC2().DescribeTransitGatewayVpcAttachments(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing TransitGatewayAttachments: %v", err)
+	}
+
+	var resourceTrackers []*resources.Resource
+
+	for _, o := range response.TransitGatewayVpcAttachments {
+		if o.State == ec2types.TransitGatewayAttachmentStateDeleting || o.State == ec2types.TransitGatewayAttachmentStateDeleted {
+			continue
+		}
+
+		resourceTracker := &resources.Resource{
+			Name:    FindName(o.Tags),
+			ID:      aws.ToString(o.TransitGatewayAttachmentId),
+			Type:    "transit-gateway-attachment",
+			Obj:     o,
+			Dumper:  DumpTransitGatewayAttachment,
+			Deleter: DeleteTransitGatewayAttachment,
+			Shared:  HasSharedTag(string(ec2types.ResourceTypeTransitGatewayAttachment)+":"+aws.ToString(o.TransitGatewayAttachmentId), o.Tags, clusterName),
+		}
+
+		// The network interfaces of the attachment block the deletion of its subnets
+		var blocks []string
+		if aws.ToString(o.VpcId) != "" {
+			blocks = append(blocks, "vpc:"+aws.ToString(o.VpcId))
+		}
+		for _, subnetID := range o.SubnetIds {
+			blocks = append(blocks, "subnet:"+subnetID)
+		}
+		resourceTracker.Blocks = blocks
+
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
+func DumpVPCEndpoint(op *resources.DumpOperation, r *resources.Resource) error {
+	data := make(map[string]interface{})
+	data["id"] = r.ID
+	data["type"] = r.Type
+	data["raw"] = r.Obj
+	op.Dump.Resources = append(op.Dump.Resources, data)
+	return nil
+}
+
+func DeleteVPCEndpoint(cloud fi.Cloud, r *resources.Resource) error {
+	ctx := context.TODO()
+	c := cloud.(awsup.AWSCloud)
+
+	id := r.ID
+
+	klog.V(2).Infof("Deleting EC2 VPCEndpoint %q", id)
+	request := &ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: []string{id},
+	}
+	response, err := c.EC2().DeleteVpcEndpoints(ctx, request)
+	if err != nil {
+		if IsDependencyViolation(err) {
+			return err
+		}
+		return fmt.Errorf("error deleting VPCEndpoint %q: %v", id, err)
+	}
+	for _, item := range response.Unsuccessful {
+		if item.Error == nil {
+			continue
+		}
+		if aws.ToString(item.Error.Code) == "InvalidVpcEndpoint.NotFound" || aws.ToString(item.Error.Code) == "InvalidVpcEndpointId.NotFound" {
+			klog.Infof("VPC endpoint %q not found; assuming already deleted", id)
+			continue
+		}
+		return fmt.Errorf("error deleting VPCEndpoint %q: %s", id, aws.ToString(item.Error.Message))
+	}
+
+	return nil
+}
+
+func ListVPCEndpoints(cloud fi.Cloud, vpcID, clusterName string) ([]*resources.Resource, error) {
+	ctx := context.TODO()
+	c := cloud.(awsup.AWSCloud)
+
+	klog.V(2).Infof("Listing EC2 VPCEndpoints")
+	request := &ec2.DescribeVpcEndpointsInput{
+		Filters: BuildEC2Filters(cloud),
+	}
+	response, err := c.EC2().DescribeVpcEndpoints(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing VPCEndpoints: %v", err)
+	}
+
+	var resourceTrackers []*resources.Resource
+
+	for _, o := range response.VpcEndpoints {
+		if o.State == ec2types.StateDeleting || o.State == ec2types.StateDeleted {
+			continue
+		}
+
+		resourceTracker := &resources.Resource{
+			Name:    FindName(o.Tags),
+			ID:      aws.ToString(o.VpcEndpointId),
+			Type:    "vpc-endpoint",
+			Obj:     o,
+			Dumper:  DumpVPCEndpoint,
+			Deleter: DeleteVPCEndpoint,
+			Shared:  HasSharedTag(string(ec2types.ResourceTypeVpcEndpoint)+":"+aws.ToString(o.VpcEndpointId), o.Tags, clusterName),
+		}
+
+		// The endpoint holds on to its route tables, and its network interfaces block
+		// the deletion of their subnets and security groups
+		var blocks []string
+		if aws.ToString(o.VpcId) != "" {
+			blocks = append(blocks, "vpc:"+aws.ToString(o.VpcId))
+		}
+		for _, routeTableID := range o.RouteTableIds {
+			blocks = append(blocks, "route-table:"+routeTableID)
+		}
+		for _, subnetID := range o.SubnetIds {
+			blocks = append(blocks, "subnet:"+subnetID)
+		}
+		for _, group := range o.Groups {
+			blocks = append(blocks, "security-group:"+aws.ToString(group.GroupId))
+		}
+		resourceTracker.Blocks = blocks
+
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func DeleteAutoScalingGroup(cloud fi.Cloud, r *resources.Resource) error {
 	ctx := context.TODO()
 
@@ -1257,6 +1449,7 @@ func ListAutoScalingGroups(cloud fi.Cloud, vpcID, clusterName string) ([]*resour
 			ID:      aws.ToString(asg.AutoScalingGroupName),
 			Type:    "autoscaling-group",
 			Deleter: DeleteAutoScalingGroup,
+			Obj:     asg,
 		}
 
 		var blocks []string
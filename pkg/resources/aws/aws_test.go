@@ -27,6 +27,7 @@ import (
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/smithy-go"
 	"k8s.io/kops/cloudmock/aws/mockec2"
 	"k8s.io/kops/cloudmock/aws/mockiam"
 	"k8s.io/kops/pkg/resources"
@@ -402,3 +403,53 @@ func TestMatchesElbTags(t *testing.T) {
 		}
 	}
 }
+
+// terminationProtectedEC2 rejects terminating instances that have termination protection enabled, like EC2 does.
+type terminationProtectedEC2 struct {
+	*mockec2.MockEC2
+
+	protected  map[string]bool
+	terminated []string
+}
+
+func (m *terminationProtectedEC2) TerminateInstances(ctx context.Context, request *ec2.TerminateInstancesInput, optFns ...func(*ec2.Options)) (*ec2.TerminateInstancesOutput, error) {
+	for _, id := range request.InstanceIds {
+		if m.protected[id] {
+			return nil, &smithy.GenericAPIError{Code: "OperationNotPermitted", Message: "instance " + id + " may not be terminated"}
+		}
+	}
+	m.terminated = append(m.terminated, request.InstanceIds...)
+	return &ec2.TerminateInstancesOutput{}, nil
+}
+
+func (m *terminationProtectedEC2) ModifyInstanceAttribute(ctx context.Context, request *ec2.ModifyInstanceAttributeInput, optFns ...func(*ec2.Options)) (*ec2.ModifyInstanceAttributeOutput, error) {
+	if request.DisableApiTermination != nil {
+		m.protected[aws.ToString(request.InstanceId)] = aws.ToBool(request.DisableApiTermination.Value)
+	}
+	return &ec2.ModifyInstanceAttributeOutput{}, nil
+}
+
+func TestDeleteInstancesTerminationProtection(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &terminationProtectedEC2{
+		MockEC2:   &mockec2.MockEC2{},
+		protected: map[string]bool{"i-control-plane": true},
+	}
+	cloud.MockEC2 = c
+
+	err := DeleteInstances(cloud, []*resources.Resource{
+		{ID: "i-control-plane", Type: string(ec2types.ResourceTypeInstance)},
+		{ID: "i-node", Type: string(ec2types.ResourceTypeInstance)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if c.protected["i-control-plane"] {
+		t.Errorf("expected termination protection to be disabled")
+	}
+	expected := []string{"i-control-plane", "i-node"}
+	if !reflect.DeepEqual(c.terminated, expected) {
+		t.Errorf("expected terminated instances %v, got %v", expected, c.terminated)
+	}
+}
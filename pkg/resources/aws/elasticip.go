@@ -38,6 +38,7 @@ func buildElasticIPResource(address ec2types.Address, forceShared bool, clusterN
 		Type:    TypeElasticIp,
 		Deleter: DeleteElasticIP,
 		Shared:  forceShared,
+		Obj:     address,
 	}
 
 	if HasSharedTag(r.Type+":"+r.Name, address.Tags, clusterName) {
@@ -78,9 +78,18 @@ func DescribeENIs(cloud fi.Cloud, vpcID, clusterName string) (map[string]ec2type
 	statusFilter := awsup.NewEC2Filter("status", string(ec2types.NetworkInterfaceStatusAvailable))
 	enis := make(map[string]ec2types.NetworkInterface)
 	klog.V(2).Info("Listing ENIs")
-	for _, filters := range buildEC2FiltersForCluster(clusterName) {
+	filterSets := buildEC2FiltersForCluster(clusterName)
+	for i := range filterSets {
+		filterSets[i] = append(filterSets[i], statusFilter)
+	}
+	// Static network interfaces of control-plane instances are deleted once the instances are gone
+	filterSets = append(filterSets, []ec2types.Filter{
+		awsup.NewEC2Filter("tag:"+awsup.TagClusterName, clusterName),
+		{Name: aws.String("tag-key"), Values: []string{awsup.TagNameStaticNetworkInterface}},
+	})
+	for _, filters := range filterSets {
 		request := &ec2.DescribeNetworkInterfacesInput{
-			Filters: append(filters, vpcFilter, statusFilter),
+			Filters: append(filters, vpcFilter),
 		}
 		paginator := ec2.NewDescribeNetworkInterfacesPaginator(c.EC2(), request)
 		for paginator.HasMorePages() {
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/ec2pricing"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbtypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// hoursPerMonth is the number of hours AWS uses to convert hourly prices to monthly prices.
+const hoursPerMonth = 730
+
+// Hourly list prices in us-east-1, in USD.
+const (
+	natGatewayHourlyPrice               = 0.045
+	classicLoadBalancerHourlyPrice      = 0.025
+	loadBalancerV2HourlyPrice           = 0.0225
+	elasticIPHourlyPrice                = 0.005
+	vpcInterfaceEndpointHourlyPrice     = 0.01
+	transitGatewayAttachmentHourlyPrice = 0.05
+)
+
+// volumeGBMonthPrices are the monthly list prices in us-east-1 of a GB of each EBS volume type, in USD.
+var volumeGBMonthPrices = map[ec2types.VolumeType]float64{
+	ec2types.VolumeTypeGp2:      0.10,
+	ec2types.VolumeTypeGp3:      0.08,
+	ec2types.VolumeTypeIo1:      0.125,
+	ec2types.VolumeTypeIo2:      0.125,
+	ec2types.VolumeTypeSt1:      0.045,
+	ec2types.VolumeTypeSc1:      0.015,
+	ec2types.VolumeTypeStandard: 0.05,
+}
+
+// Monthly list prices in us-east-1 of provisioned EBS performance, in USD.
+const (
+	gp3IOPSMonthPrice       = 0.005
+	gp3ThroughputMonthPrice = 0.04
+	ioIOPSMonthPrice        = 0.065
+
+	gp3BaselineIOPS       = 3000
+	gp3BaselineThroughput = 125
+)
+
+// ResourceCreationTimestamp returns the time the resource was created, or nil if it is not known.
+func ResourceCreationTimestamp(r *resources.Resource) *time.Time {
+	switch obj := r.Obj.(type) {
+	case ec2types.Instance:
+		return obj.LaunchTime
+	case ec2types.Volume:
+		return obj.CreateTime
+	case *autoscalingtypes.AutoScalingGroup:
+		return obj.CreatedTime
+	case ec2types.NatGateway:
+		return obj.CreateTime
+	case ec2types.VpcEndpoint:
+		return obj.CreationTimestamp
+	case ec2types.TransitGatewayVpcAttachment:
+		return obj.CreationTime
+	case elbtypes.LoadBalancerDescription:
+		return obj.CreatedTime
+	case elbv2types.LoadBalancer:
+		return obj.CreatedTime
+	case iamtypes.InstanceProfile:
+		return obj.CreateDate
+	case *acmtypes.CertificateDetail:
+		return obj.CreatedAt
+	default:
+		return nil
+	}
+}
+
+// InstancePricing returns the hourly on-demand price of EC2 instance types.
+type InstancePricing interface {
+	GetOnDemandInstanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType) (float64, error)
+}
+
+// CostEstimator estimates the monthly cost of AWS resources.
+// Instances are priced at the on-demand price of their instance type in the region of the cluster,
+// which is an upper bound for spot instances. Other resources are priced at their list price in us-east-1,
+// without usage-based charges such as data transfer.
+type CostEstimator struct {
+	pricing InstancePricing
+
+	// instanceTypeCosts caches the hourly price of each instance type.
+	instanceTypeCosts map[ec2types.InstanceType]float64
+}
+
+// NewCostEstimator builds a CostEstimator that queries the AWS Pricing API for the price of instance types.
+func NewCostEstimator(ctx context.Context, cloud awsup.AWSCloud) (*CostEstimator, error) {
+	pricing, err := ec2pricing.New(ctx, cloud.Config())
+	if err != nil {
+		return nil, fmt.Errorf("error building pricing client: %w", err)
+	}
+	return NewCostEstimatorWithPricing(pricing), nil
+}
+
+// NewCostEstimatorWithPricing builds a CostEstimator that uses the given instance type prices.
+func NewCostEstimatorWithPricing(pricing InstancePricing) *CostEstimator {
+	return &CostEstimator{
+		pricing:           pricing,
+		instanceTypeCosts: make(map[ec2types.InstanceType]float64),
+	}
+}
+
+// EstimateMonthlyCost returns the estimated monthly cost of the resource in USD.
+// It returns false if the resource has no fixed cost, or if its cost is not known.
+func (e *CostEstimator) EstimateMonthlyCost(ctx context.Context, r *resources.Resource) (float64, bool, error) {
+	switch obj := r.Obj.(type) {
+	case ec2types.Instance:
+		if obj.State != nil && obj.State.Name != ec2types.InstanceStateNamePending && obj.State.Name != ec2types.InstanceStateNameRunning {
+			return 0, true, nil
+		}
+		hourly, err := e.instanceTypeCost(ctx, obj.InstanceType)
+		if err != nil {
+			return 0, false, err
+		}
+		return hourly * hoursPerMonth, true, nil
+	case ec2types.Volume:
+		cost, known := volumeMonthlyCost(obj)
+		return cost, known, nil
+	case ec2types.NatGateway:
+		return natGatewayHourlyPrice * hoursPerMonth, true, nil
+	case elbtypes.LoadBalancerDescription:
+		return classicLoadBalancerHourlyPrice * hoursPerMonth, true, nil
+	case elbv2types.LoadBalancer:
+		return loadBalancerV2HourlyPrice * hoursPerMonth, true, nil
+	case ec2types.Address:
+		return elasticIPHourlyPrice * hoursPerMonth, true, nil
+	case ec2types.VpcEndpoint:
+		// Gateway endpoints are free; interface endpoints are charged for each availability zone
+		if obj.VpcEndpointType != ec2types.VpcEndpointTypeInterface {
+			return 0, false, nil
+		}
+		return vpcInterfaceEndpointHourlyPrice * hoursPerMonth * float64(len(obj.SubnetIds)), true, nil
+	case ec2types.TransitGatewayVpcAttachment:
+		return transitGatewayAttachmentHourlyPrice * hoursPerMonth, true, nil
+	default:
+		return 0, false, nil
+	}
+}
+
+func (e *CostEstimator) instanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType) (float64, error) {
+	if cost, found := e.instanceTypeCosts[instanceType]; found {
+		return cost, nil
+	}
+	cost, err := e.pricing.GetOnDemandInstanceTypeCost(ctx, instanceType)
+	if err != nil {
+		return 0, fmt.Errorf("error getting the price of instance type %q: %w", instanceType, err)
+	}
+	e.instanceTypeCosts[instanceType] = cost
+	return cost, nil
+}
+
+func volumeMonthlyCost(volume ec2types.Volume) (float64, bool) {
+	gbMonthPrice, found := volumeGBMonthPrices[volume.VolumeType]
+	if !found || volume.Size == nil {
+		return 0, false
+	}
+	cost := gbMonthPrice * float64(*volume.Size)
+
+	iops := int32(0)
+	if volume.Iops != nil {
+		iops = *volume.Iops
+	}
+	switch volume.VolumeType {
+	case ec2types.VolumeTypeGp3:
+		if iops > gp3BaselineIOPS {
+			cost += gp3IOPSMonthPrice * float64(iops-gp3BaselineIOPS)
+		}
+		if volume.Throughput != nil && *volume.Throughput > gp3BaselineThroughput {
+			cost += gp3ThroughputMonthPrice * float64(*volume.Throughput-gp3BaselineThroughput)
+		}
+	case ec2types.VolumeTypeIo1, ec2types.VolumeTypeIo2:
+		cost += ioIOPSMonthPrice * float64(iops)
+	}
+	return cost, true
+}
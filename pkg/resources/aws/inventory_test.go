@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"k8s.io/kops/pkg/resources"
+)
+
+type fakeInstancePricing struct {
+	prices map[ec2types.InstanceType]float64
+	calls  int
+}
+
+func (p *fakeInstancePricing) GetOnDemandInstanceTypeCost(ctx context.Context, instanceType ec2types.InstanceType) (float64, error) {
+	p.calls++
+	price, found := p.prices[instanceType]
+	if !found {
+		return 0, fmt.Errorf("no price for %s", instanceType)
+	}
+	return price, nil
+}
+
+func TestEstimateMonthlyCost(t *testing.T) {
+	pricing := &fakeInstancePricing{
+		prices: map[ec2types.InstanceType]float64{
+			ec2types.InstanceTypeT3Medium: 0.0416,
+		},
+	}
+	estimator := NewCostEstimatorWithPricing(pricing)
+
+	grid := []struct {
+		name  string
+		obj   interface{}
+		cost  float64
+		known bool
+		err   bool
+	}{
+		{
+			name:  "running instance",
+			obj:   ec2types.Instance{InstanceType: ec2types.InstanceTypeT3Medium, State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameRunning}},
+			cost:  30.368,
+			known: true,
+		},
+		{
+			name:  "stopped instance",
+			obj:   ec2types.Instance{InstanceType: ec2types.InstanceTypeT3Medium, State: &ec2types.InstanceState{Name: ec2types.InstanceStateNameStopped}},
+			cost:  0,
+			known: true,
+		},
+		{
+			name: "instance without price",
+			obj:  ec2types.Instance{InstanceType: ec2types.InstanceTypeM5Large},
+			err:  true,
+		},
+		{
+			name:  "gp3 volume with provisioned performance",
+			obj:   ec2types.Volume{VolumeType: ec2types.VolumeTypeGp3, Size: aws.Int32(100), Iops: aws.Int32(4000), Throughput: aws.Int32(225)},
+			cost:  8 + 5 + 4,
+			known: true,
+		},
+		{
+			name:  "io1 volume",
+			obj:   ec2types.Volume{VolumeType: ec2types.VolumeTypeIo1, Size: aws.Int32(20), Iops: aws.Int32(1000)},
+			cost:  2.5 + 65,
+			known: true,
+		},
+		{
+			name:  "network load balancer",
+			obj:   elbv2types.LoadBalancer{Type: elbv2types.LoadBalancerTypeEnumNetwork},
+			cost:  16.425,
+			known: true,
+		},
+		{
+			name:  "interface endpoint in two zones",
+			obj:   ec2types.VpcEndpoint{VpcEndpointType: ec2types.VpcEndpointTypeInterface, SubnetIds: []string{"subnet-a", "subnet-b"}},
+			cost:  14.6,
+			known: true,
+		},
+		{
+			name: "gateway endpoint",
+			obj:  ec2types.VpcEndpoint{VpcEndpointType: ec2types.VpcEndpointTypeGateway},
+		},
+		{
+			name: "security group",
+			obj:  ec2types.SecurityGroup{},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cost, known, err := estimator.EstimateMonthlyCost(context.Background(), &resources.Resource{Obj: g.obj})
+			if g.err {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if known != g.known {
+				t.Errorf("expected known=%v, got %v", g.known, known)
+			}
+			if math.Abs(cost-g.cost) > 0.0001 {
+				t.Errorf("expected cost %v, got %v", g.cost, cost)
+			}
+		})
+	}
+
+	// The price of an instance type is only queried once
+	calls := pricing.calls
+	if _, _, err := estimator.EstimateMonthlyCost(context.Background(), &resources.Resource{Obj: ec2types.Instance{InstanceType: ec2types.InstanceTypeT3Medium}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pricing.calls != calls {
+		t.Errorf("expected the price of t3.medium to be cached")
+	}
+}
+
+func TestResourceCreationTimestamp(t *testing.T) {
+	launchTime := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	r := &resources.Resource{Obj: ec2types.Instance{LaunchTime: &launchTime}}
+	if actual := ResourceCreationTimestamp(r); actual == nil || !actual.Equal(launchTime) {
+		t.Errorf("expected %v, got %v", launchTime, actual)
+	}
+
+	r = &resources.Resource{Obj: ec2types.SecurityGroup{}}
+	if actual := ResourceCreationTimestamp(r); actual != nil {
+		t.Errorf("expected no creation time, got %v", actual)
+	}
+}
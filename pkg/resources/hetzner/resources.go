@@ -30,12 +30,13 @@ import (
 )
 
 const (
-	resourceTypeSSHKey       = "ssh-key"
-	resourceTypeNetwork      = "network"
-	resourceTypeFirewall     = "firewall"
-	resourceTypeLoadBalancer = "load-balancer"
-	resourceTypeServer       = "server"
-	resourceTypeVolume       = "volume"
+	resourceTypeSSHKey         = "ssh-key"
+	resourceTypeNetwork        = "network"
+	resourceTypeFirewall       = "firewall"
+	resourceTypeLoadBalancer   = "load-balancer"
+	resourceTypeServer         = "server"
+	resourceTypeVolume         = "volume"
+	resourceTypePlacementGroup = "placement-group"
 )
 
 type listFn func(fi.Cloud, string) ([]*resources.Resource, error)
@@ -52,6 +53,7 @@ func ListResources(cloud hetzner.HetznerCloud, clusterInfo resources.ClusterInfo
 		listLoadBalancers,
 		listServers,
 		listVolumes,
+		listPlacementGroups,
 	}
 
 	for _, fn := range listFunctions {
@@ -220,6 +222,34 @@ func listVolumes(cloud fi.Cloud, clusterName string) ([]*resources.Resource, err
 	return resourceTrackers, nil
 }
 
+func listPlacementGroups(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(hetzner.HetznerCloud)
+	var resourceTrackers []*resources.Resource
+
+	placementGroups, err := c.GetPlacementGroups(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list placement groups: %w", err)
+	}
+
+	for _, placementGroup := range placementGroups {
+		resourceTracker := &resources.Resource{
+			Name:    placementGroup.Name,
+			ID:      strconv.Itoa(placementGroup.ID),
+			Type:    resourceTypePlacementGroup,
+			Deleter: deletePlacementGroup,
+			Obj:     placementGroup,
+		}
+
+		for _, serverID := range placementGroup.Servers {
+			resourceTracker.Blocked = append(resourceTracker.Blocked, fmt.Sprintf("%s:%d", resourceTypeServer, serverID))
+		}
+
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func deleteSSHKey(cloud fi.Cloud, r *resources.Resource) error {
 	klog.Infof("Deleting SSH Key: %s(%s)", r.Name, r.ID)
 
@@ -313,6 +343,20 @@ func deleteVolume(cloud fi.Cloud, r *resources.Resource) error {
 	return nil
 }
 
+func deletePlacementGroup(cloud fi.Cloud, r *resources.Resource) error {
+	klog.Infof("Deleting Placement Group: %s(%s)", r.Name, r.ID)
+
+	c := cloud.(hetzner.HetznerCloud)
+	client := c.PlacementGroupClient()
+	placementGroup := r.Obj.(*hcloud.PlacementGroup)
+	_, err := client.Delete(context.TODO(), placementGroup)
+	if err != nil {
+		return fmt.Errorf("failed to delete placement group %s(%s): %w", r.Name, r.ID, err)
+	}
+
+	return nil
+}
+
 func dumpServer(op *resources.DumpOperation, r *resources.Resource) error {
 	server := r.Obj.(*hcloud.Server)
 
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ops
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/pkg/resources/aws"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// InventoryItem is a cloud resource owned by a cluster.
+type InventoryItem struct {
+	Resource *resources.Resource
+	// CreationTimestamp is the time the resource was created, if known.
+	CreationTimestamp *time.Time
+	// MonthlyCost is the estimated monthly cost of the resource in USD, if known.
+	MonthlyCost *float64
+}
+
+// InventoryOptions controls the details collected by Inventory.
+type InventoryOptions struct {
+	// EstimateCost enables the estimation of the monthly cost of resources, which can query pricing APIs.
+	EstimateCost bool
+}
+
+// Inventory lists the cloud resources owned by the cluster, which are the resources that deleting the cluster deletes.
+// The creation time and cost of resources are only known for some resources of some cloud providers.
+func Inventory(ctx context.Context, cloud fi.Cloud, cluster *kops.Cluster, options InventoryOptions) ([]*InventoryItem, error) {
+	allResources, err := ListResources(cloud, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*InventoryItem
+	for _, r := range allResources {
+		if r.Shared {
+			continue
+		}
+		items = append(items, &InventoryItem{Resource: r})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Resource.Type != items[j].Resource.Type {
+			return items[i].Resource.Type < items[j].Resource.Type
+		}
+		return items[i].Resource.ID < items[j].Resource.ID
+	})
+
+	if cloud.ProviderID() == kops.CloudProviderAWS {
+		var estimator *aws.CostEstimator
+		if options.EstimateCost {
+			estimator, err = aws.NewCostEstimator(ctx, cloud.(awsup.AWSCloud))
+			if err != nil {
+				klog.Warningf("not estimating costs: %v", err)
+			}
+		}
+		describeAWSResources(ctx, estimator, items)
+	}
+
+	return items, nil
+}
+
+// describeAWSResources fills in the creation time and, if estimator is not nil, the cost of AWS resources.
+// Failures to get prices are logged once per resource type, so that a missing permission does not hide the inventory.
+func describeAWSResources(ctx context.Context, estimator *aws.CostEstimator, items []*InventoryItem) {
+	warned := make(map[string]bool)
+	for _, item := range items {
+		item.CreationTimestamp = aws.ResourceCreationTimestamp(item.Resource)
+
+		if estimator == nil {
+			continue
+		}
+		cost, known, err := estimator.EstimateMonthlyCost(ctx, item.Resource)
+		if err != nil {
+			if !warned[item.Resource.Type] {
+				klog.Warningf("cannot estimate the cost of %s %s: %v", item.Resource.Type, item.Resource.ID, err)
+				warned[item.Resource.Type] = true
+			}
+			continue
+		}
+		if known {
+			item.MonthlyCost = &cost
+		}
+	}
+}
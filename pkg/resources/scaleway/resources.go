@@ -29,15 +29,20 @@ import (
 	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
 )
 
 const (
-	resourceTypeDNSRecord    = "dns-record"
-	resourceTypeLoadBalancer = "load-balancer"
-	resourceTypeServer       = "server"
-	resourceTypeServerIP     = "server-IP"
-	resourceTypeSSHKey       = "ssh-key"
-	resourceTypeVolume       = "volume"
+	resourceTypeDNSRecord      = "dns-record"
+	resourceTypeGateway        = "gateway"
+	resourceTypeLoadBalancer   = "load-balancer"
+	resourceTypePrivateNetwork = "private-network"
+	resourceTypeServer         = "server"
+	resourceTypeServerIP       = "server-IP"
+	resourceTypeSSHKey         = "ssh-key"
+	resourceTypeVolume         = "volume"
+	resourceTypeVPC            = "vpc"
 )
 
 type listFn func(fi.Cloud, string) ([]*resources.Resource, error)
@@ -47,11 +52,14 @@ func ListResources(cloud scaleway.ScwCloud, clusterInfo resources.ClusterInfo) (
 	clusterName := clusterInfo.Name
 
 	listFunctions := []listFn{
+		listGateways,
 		listLoadBalancers,
+		listPrivateNetworks,
 		listServers,
 		listServerIPs,
 		listSSHKeys,
 		listVolumes,
+		listVPCs,
 	}
 	if !strings.HasSuffix(clusterName, ".k8s.local") && !clusterInfo.UsesNoneDNS {
 		listFunctions = append(listFunctions, listDNSRecords)
@@ -94,6 +102,33 @@ func listDNSRecords(cloud fi.Cloud, clusterName string) ([]*resources.Resource,
 	return resourceTrackers, nil
 }
 
+func listGateways(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(scaleway.ScwCloud)
+	gateways, err := c.GetClusterGateways(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTrackers := []*resources.Resource(nil)
+	for _, gateway := range gateways {
+		resourceTracker := &resources.Resource{
+			Name: gateway.Name,
+			ID:   gateway.ID,
+			Type: resourceTypeGateway,
+			Deleter: func(cloud fi.Cloud, tracker *resources.Resource) error {
+				return deleteGateway(cloud, tracker)
+			},
+			Obj: gateway,
+		}
+		for _, gatewayNetwork := range gateway.GatewayNetworks {
+			resourceTracker.Blocks = append(resourceTracker.Blocks, resourceTypePrivateNetwork+":"+gatewayNetwork.PrivateNetworkID)
+		}
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func listLoadBalancers(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
 	c := cloud.(scaleway.ScwCloud)
 	lbs, err := c.GetClusterLoadBalancers(clusterName)
@@ -112,6 +147,41 @@ func listLoadBalancers(cloud fi.Cloud, clusterName string) ([]*resources.Resourc
 			},
 			Obj: loadBalancer,
 		}
+		privateNetworks, err := c.LBService().ListLBPrivateNetworks(&lb.ZonedAPIListLBPrivateNetworksRequest{
+			Zone: loadBalancer.Zone,
+			LBID: loadBalancer.ID,
+		}, scw.WithAllPages())
+		if err != nil {
+			return nil, fmt.Errorf("listing private networks of load-balancer %s: %w", loadBalancer.ID, err)
+		}
+		for _, privateNetwork := range privateNetworks.PrivateNetwork {
+			resourceTracker.Blocks = append(resourceTracker.Blocks, resourceTypePrivateNetwork+":"+privateNetwork.PrivateNetworkID)
+		}
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
+func listPrivateNetworks(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(scaleway.ScwCloud)
+	privateNetworks, err := c.GetClusterPrivateNetworks(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTrackers := []*resources.Resource(nil)
+	for _, privateNetwork := range privateNetworks {
+		resourceTracker := &resources.Resource{
+			Name: privateNetwork.Name,
+			ID:   privateNetwork.ID,
+			Type: resourceTypePrivateNetwork,
+			Deleter: func(cloud fi.Cloud, tracker *resources.Resource) error {
+				return deletePrivateNetwork(cloud, tracker)
+			},
+			Blocks: []string{resourceTypeVPC + ":" + privateNetwork.VpcID},
+			Obj:    privateNetwork,
+		}
 		resourceTrackers = append(resourceTrackers, resourceTracker)
 	}
 
@@ -136,6 +206,9 @@ func listServers(cloud fi.Cloud, clusterName string) ([]*resources.Resource, err
 			},
 			Obj: server,
 		}
+		for _, nic := range server.PrivateNics {
+			resourceTracker.Blocks = append(resourceTracker.Blocks, resourceTypePrivateNetwork+":"+nic.PrivateNetworkID)
+		}
 		resourceTrackers = append(resourceTrackers, resourceTracker)
 	}
 
@@ -221,6 +294,30 @@ func listVolumes(cloud fi.Cloud, clusterName string) ([]*resources.Resource, err
 	return resourceTrackers, nil
 }
 
+func listVPCs(cloud fi.Cloud, clusterName string) ([]*resources.Resource, error) {
+	c := cloud.(scaleway.ScwCloud)
+	vpcs, err := c.GetClusterVPCs(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceTrackers := []*resources.Resource(nil)
+	for _, v := range vpcs {
+		resourceTracker := &resources.Resource{
+			Name: v.Name,
+			ID:   v.ID,
+			Type: resourceTypeVPC,
+			Deleter: func(cloud fi.Cloud, tracker *resources.Resource) error {
+				return deleteVPC(cloud, tracker)
+			},
+			Obj: v,
+		}
+		resourceTrackers = append(resourceTrackers, resourceTracker)
+	}
+
+	return resourceTrackers, nil
+}
+
 func deleteDNSRecord(cloud fi.Cloud, tracker *resources.Resource, domainName string) error {
 	c := cloud.(scaleway.ScwCloud)
 	record := tracker.Obj.(*domain.Record)
@@ -228,6 +325,13 @@ func deleteDNSRecord(cloud fi.Cloud, tracker *resources.Resource, domainName str
 	return c.DeleteDNSRecord(record, domainName)
 }
 
+func deleteGateway(cloud fi.Cloud, tracker *resources.Resource) error {
+	c := cloud.(scaleway.ScwCloud)
+	gateway := tracker.Obj.(*vpcgw.Gateway)
+
+	return c.DeleteGateway(gateway)
+}
+
 func deleteLoadBalancer(cloud fi.Cloud, tracker *resources.Resource) error {
 	c := cloud.(scaleway.ScwCloud)
 	loadBalancer := tracker.Obj.(*lb.LB)
@@ -235,6 +339,13 @@ func deleteLoadBalancer(cloud fi.Cloud, tracker *resources.Resource) error {
 	return c.DeleteLoadBalancer(loadBalancer)
 }
 
+func deletePrivateNetwork(cloud fi.Cloud, tracker *resources.Resource) error {
+	c := cloud.(scaleway.ScwCloud)
+	privateNetwork := tracker.Obj.(*vpc.PrivateNetwork)
+
+	return c.DeletePrivateNetwork(privateNetwork)
+}
+
 func deleteServer(cloud fi.Cloud, tracker *resources.Resource) error {
 	c := cloud.(scaleway.ScwCloud)
 	server := tracker.Obj.(*instance.Server)
@@ -270,3 +381,10 @@ func deleteVolume(cloud fi.Cloud, tracker *resources.Resource) error {
 
 	return c.DeleteVolume(volume)
 }
+
+func deleteVPC(cloud fi.Cloud, tracker *resources.Resource) error {
+	c := cloud.(scaleway.ScwCloud)
+	v := tracker.Obj.(*vpc.VPC)
+
+	return c.DeleteVPC(v)
+}
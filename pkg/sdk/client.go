@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdk exposes the cluster operations behind the kops command line
+// (create, update, rolling-update and validate) as a Go API, so that other
+// tools can embed kops instead of running the binary and parsing its output.
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+)
+
+// Operation identifies the operation a ProgressEvent belongs to.
+type Operation string
+
+const (
+	OperationCreate        Operation = "create"
+	OperationUpdate        Operation = "update"
+	OperationRollingUpdate Operation = "rolling-update"
+	OperationValidate      Operation = "validate"
+)
+
+// ProgressEvent describes a step reached by a long-running operation.
+type ProgressEvent struct {
+	// Operation is the operation reporting progress.
+	Operation Operation
+	// ClusterName is the name of the cluster being operated on.
+	ClusterName string
+	// Message is a human-readable description of the step.
+	Message string
+}
+
+// ProgressFunc receives progress events; it is called synchronously from the operation.
+type ProgressFunc func(event ProgressEvent)
+
+// Client runs kops operations against the clusters in a state store.
+type Client struct {
+	// Clientset is the state store holding the cluster configuration.
+	Clientset simple.Clientset
+	// Progress, if set, is notified as operations make progress.
+	Progress ProgressFunc
+}
+
+// NewClient returns a Client for the clusters in the given state store.
+func NewClient(clientset simple.Clientset) *Client {
+	return &Client{Clientset: clientset}
+}
+
+func (c *Client) progress(operation Operation, clusterName string, format string, args ...interface{}) {
+	if c.Progress == nil {
+		return
+	}
+	c.Progress(ProgressEvent{
+		Operation:   operation,
+		ClusterName: clusterName,
+		Message:     fmt.Sprintf(format, args...),
+	})
+}
+
+// getCluster reads the named cluster from the state store.
+func (c *Client) getCluster(ctx context.Context, clusterName string) (*kops.Cluster, error) {
+	if clusterName == "" {
+		return nil, fmt.Errorf("cluster name is required")
+	}
+
+	cluster, err := c.Clientset.GetCluster(ctx, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster %q: %w", clusterName, err)
+	}
+	if cluster == nil {
+		return nil, fmt.Errorf("cluster %q not found", clusterName)
+	}
+	if clusterName != cluster.ObjectMeta.Name {
+		return nil, fmt.Errorf("cluster name did not match expected name: %v vs %v", clusterName, cluster.ObjectMeta.Name)
+	}
+
+	return cluster, nil
+}
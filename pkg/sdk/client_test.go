@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestCreateAndPlanRollingUpdate(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building vfspath: %v", err)
+	}
+
+	cloud := awsup.InstallMockAWSCloud("us-test-1", "abc")
+	cloud.MockEC2 = &mockec2.MockEC2{}
+	cloud.MockAutoscaling = &mockautoscaling.MockAutoscaling{}
+
+	var events []string
+	client := NewClient(vfsclientset.NewVFSClientset(vfs.Context, basePath))
+	client.Progress = func(event ProgressEvent) {
+		if event.ClusterName != "minimal.example.com" {
+			t.Errorf("unexpected cluster name in progress event: %q", event.ClusterName)
+		}
+		events = append(events, string(event.Operation)+": "+event.Message)
+	}
+
+	cluster := testutils.BuildMinimalCluster("minimal.example.com")
+	master := testutils.BuildMinimalMasterInstanceGroup("subnet-us-test-1a")
+	nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+	if err := client.CreateCluster(ctx, cluster, []*kops.InstanceGroup{&master, &nodes}, &CreateClusterOptions{
+		SSHPublicKey: []byte("ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAAAgQCtWu40XQo8dczLsCq0OWV+hxm9uV3WxeH9Kgh4sMzQxNtoU1pvW0XdjpkBesRKGoolfWeCLXWxpyQb1IaiMkKoz7MdhQ/6UKjMjP66aFWWp3pwD0uj0HuJ7tq4gKHKRYGTaZIRWpzUiANBrjugVgA+Sd7E/mYwc/DMXkIyRZbvhQ== test@example.com"),
+	}); err != nil {
+		t.Fatalf("unexpected error creating cluster: %v", err)
+	}
+
+	igs, err := client.Clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error listing instance groups: %v", err)
+	}
+	if len(igs.Items) != 2 {
+		t.Fatalf("expected 2 instance groups, got %d", len(igs.Items))
+	}
+
+	if err := client.CreateCluster(ctx, cluster, nil, nil); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected already exists error creating cluster twice, got %v", err)
+	}
+
+	plan, err := client.PlanRollingUpdate(ctx, "minimal.example.com", &RollingUpdateClusterOptions{CloudOnly: true})
+	if err != nil {
+		t.Fatalf("unexpected error planning rolling update: %v", err)
+	}
+	if !plan.SingleControlPlane {
+		t.Errorf("expected single control plane to be detected")
+	}
+	if plan.NeedsUpdate() {
+		t.Errorf("expected no instances to need updating")
+	}
+	if err := client.RollingUpdate(ctx, plan); err != nil {
+		t.Fatalf("unexpected error running rolling update: %v", err)
+	}
+
+	expected := []string{
+		"create: created cluster minimal.example.com",
+		"create: created instancegroup master-subnet-us-test-1a",
+		"create: created instancegroup nodes",
+		"create: added ssh credential",
+		"rolling-update: Detected single-control-plane cluster; won't detach before draining",
+		"rolling-update: No rolling-update required.",
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("unexpected progress events\nactual:   %q\nexpected: %q", events, expected)
+	}
+}
+
+func TestPlanRollingUpdateClusterNotFound(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building vfspath: %v", err)
+	}
+
+	client := NewClient(vfsclientset.NewVFSClientset(vfs.Context, basePath))
+	if _, err := client.PlanRollingUpdate(ctx, "missing.example.com", &RollingUpdateClusterOptions{}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+type failingValidator struct{}
+
+func (failingValidator) Validate() (*validation.ValidationCluster, error) {
+	return &validation.ValidationCluster{
+		Failures: []*validation.ValidationError{{Kind: "Node", Name: "node-1", Message: "not ready"}},
+	}, nil
+}
+
+func TestValidateClusterNilOptions(t *testing.T) {
+	client := NewClient(nil)
+	if _, err := client.ValidateCluster(context.TODO(), "minimal.example.com", nil); err == nil || !strings.Contains(err.Error(), "RESTConfig is required") {
+		t.Errorf("expected RESTConfig is required error, got %v", err)
+	}
+}
+
+func TestWaitForValidationHonorsContext(t *testing.T) {
+	client := NewClient(nil)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err := client.waitForValidation(ctx, "minimal.example.com", failingValidator{}, &ValidateClusterOptions{Wait: time.Hour, Interval: time.Hour, Count: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error, got %v", err)
+	}
+	if time.Since(start) > time.Minute {
+		t.Errorf("validation did not stop when the context was cancelled")
+	}
+
+	_, err = client.waitForValidation(context.TODO(), "minimal.example.com", failingValidator{}, &ValidateClusterOptions{Wait: 10 * time.Millisecond, Interval: time.Millisecond, Count: 1})
+	if err == nil || !strings.Contains(err.Error(), "wait time exceeded") {
+		t.Errorf("expected wait time exceeded error, got %v", err)
+	}
+}
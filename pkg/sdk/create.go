@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// CreateClusterOptions holds the optional inputs to CreateCluster.
+type CreateClusterOptions struct {
+	// SSHPublicKey is an SSH public key to register for the cluster, in authorized_keys format.
+	SSHPublicKey []byte
+}
+
+// CreateCluster stores a new cluster and its instance groups in the state store.
+// Like `kops create -f`, it does not create any cloud resources; call UpdateCluster for that.
+func (c *Client) CreateCluster(ctx context.Context, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, options *CreateClusterOptions) error {
+	if options == nil {
+		options = &CreateClusterOptions{}
+	}
+	clusterName := cluster.ObjectMeta.Name
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	if err := cloudup.PerformAssignments(cluster, vfs.Context, cloud); err != nil {
+		return fmt.Errorf("error populating configuration: %w", err)
+	}
+
+	if _, err := c.Clientset.CreateCluster(ctx, cluster); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("cluster %q already exists", clusterName)
+		}
+		return fmt.Errorf("error creating cluster: %w", err)
+	}
+	c.progress(OperationCreate, clusterName, "created cluster %s", clusterName)
+
+	for _, ig := range instanceGroups {
+		if _, err := c.Clientset.InstanceGroupsFor(cluster).Create(ctx, ig, metav1.CreateOptions{}); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("instanceGroup %q already exists", ig.ObjectMeta.Name)
+			}
+			return fmt.Errorf("error creating instanceGroup %q: %w", ig.ObjectMeta.Name, err)
+		}
+		c.progress(OperationCreate, clusterName, "created instancegroup %s", ig.ObjectMeta.Name)
+	}
+
+	if len(options.SSHPublicKey) != 0 {
+		sshCredentialStore, err := c.Clientset.SSHCredentialStore(cluster)
+		if err != nil {
+			return err
+		}
+		if err := sshCredentialStore.AddSSHPublicKey(ctx, options.SSHPublicKey); err != nil {
+			return fmt.Errorf("error adding SSH public key: %w", err)
+		}
+		c.progress(OperationCreate, clusterName, "added ssh credential")
+	}
+
+	return nil
+}
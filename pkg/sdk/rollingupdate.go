@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/instancegroups"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+)
+
+// ErrKubernetesAPIUnreachable is returned when the kubernetes API cannot be reached to confirm
+// the progress of a rolling update; setting CloudOnly skips those checks.
+var ErrKubernetesAPIUnreachable = errors.New("unable to reach the kubernetes API")
+
+// RollingUpdateClusterOptions holds the inputs to PlanRollingUpdate.
+type RollingUpdateClusterOptions struct {
+	// RESTConfig is used to reach the kubernetes API of the cluster; it is not needed when CloudOnly is set.
+	RESTConfig *rest.Config
+	// CloudOnly performs the rolling update without confirming progress with the kubernetes API.
+	CloudOnly bool
+	// Force replaces all instances, even those that do not need updating.
+	Force bool
+	// Interactive pauses after each instance is replaced.
+	Interactive bool
+	// FailOnDrainError stops the rolling update if draining a node fails.
+	FailOnDrainError bool
+	// FailOnValidate stops the rolling update if the cluster fails validation.
+	FailOnValidate bool
+	// DrainTimeout is the maximum time to wait for a node to drain.
+	DrainTimeout time.Duration
+	// PostDrainDelay is the time to wait after draining a node.
+	PostDrainDelay time.Duration
+	// ValidationTimeout is the maximum time to wait for the cluster to validate.
+	ValidationTimeout time.Duration
+	// ValidateCount is the number of consecutive successful validations required.
+	ValidateCount int
+	// ControlPlaneInterval is the time to wait between restarting control plane nodes.
+	ControlPlaneInterval time.Duration
+	// NodeInterval is the time to wait between restarting nodes.
+	NodeInterval time.Duration
+	// BastionInterval is the time to wait between restarting bastions.
+	BastionInterval time.Duration
+	// MaxDuration pauses the rolling update after replacing instances for this long; the next rolling update resumes it.
+	// Zero means no limit.
+	MaxDuration time.Duration
+	// InstanceGroups restricts the rolling update to the named instance groups.
+	InstanceGroups []string
+	// InstanceGroupRoles restricts the rolling update to instance groups with the given roles.
+	InstanceGroupRoles []string
+
+	instancegroups.RollingUpdateOptions
+}
+
+// RollingUpdatePlan describes the instances a rolling update would replace.
+type RollingUpdatePlan struct {
+	// Cluster is the cluster being updated.
+	Cluster *kops.Cluster
+	// Groups holds the cloud state of each selected instance group, keyed by name.
+	Groups map[string]*cloudinstances.CloudInstanceGroup
+	// SingleControlPlane is true if the cluster has a single control plane node,
+	// in which case control plane nodes are not detached before draining.
+	SingleControlPlane bool
+
+	force          bool
+	cloudOnly      bool
+	restConfig     *rest.Config
+	cloud          fi.Cloud
+	instanceGroups *kops.InstanceGroupList
+	rollingUpdate  *instancegroups.RollingUpdateCluster
+}
+
+// NeedsUpdate returns true if any instance needs replacing, or if the update is forced.
+func (p *RollingUpdatePlan) NeedsUpdate() bool {
+	if p.force {
+		return true
+	}
+	for _, group := range p.Groups {
+		if len(group.NeedUpdate) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PlanRollingUpdate finds the instances of a cluster that need to be replaced, without changing anything.
+func (c *Client) PlanRollingUpdate(ctx context.Context, clusterName string, options *RollingUpdateClusterOptions) (*RollingUpdatePlan, error) {
+	if options == nil {
+		options = &RollingUpdateClusterOptions{}
+	}
+	cluster, err := c.getCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []v1.Node
+	var k8sClient kubernetes.Interface
+	if !options.CloudOnly {
+		if options.RESTConfig == nil {
+			return nil, fmt.Errorf("RESTConfig is required unless CloudOnly is set")
+		}
+		k8sClient, err = kubernetes.NewForConfig(options.RESTConfig)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build kube client for %q: %w", clusterName, err)
+		}
+
+		nodeList, err := k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("%w: error listing nodes in cluster: %w", ErrKubernetesAPIUnreachable, err)
+		}
+		if nodeList != nil {
+			nodes = nodeList.Items
+		}
+	}
+
+	list, err := c.Clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	rollingUpdateOptions := options.RollingUpdateOptions
+
+	singleControlPlane := false
+	countByRole := make(map[kops.InstanceGroupRole]int32)
+	var instanceGroups []*kops.InstanceGroup
+	for i := range list.Items {
+		instanceGroup := &list.Items[i]
+		instanceGroups = append(instanceGroups, instanceGroup)
+
+		minSize := int32(1)
+		if instanceGroup.Spec.MinSize != nil {
+			minSize = *instanceGroup.Spec.MinSize
+		}
+		countByRole[instanceGroup.Spec.Role] = countByRole[instanceGroup.Spec.Role] + minSize
+	}
+	if countByRole[kops.InstanceGroupRoleAPIServer]+countByRole[kops.InstanceGroupRoleControlPlane] <= 1 {
+		c.progress(OperationRollingUpdate, clusterName, "Detected single-control-plane cluster; won't detach before draining")
+		rollingUpdateOptions.DeregisterControlPlaneNodes = false
+		singleControlPlane = true
+	}
+
+	warnUnmatched := true
+
+	if len(options.InstanceGroups) != 0 {
+		var filtered []*kops.InstanceGroup
+
+		for _, instanceGroupName := range options.InstanceGroups {
+			var found *kops.InstanceGroup
+			for _, ig := range instanceGroups {
+				if ig.ObjectMeta.Name == instanceGroupName {
+					found = ig
+					break
+				}
+			}
+			if found == nil {
+				return nil, fmt.Errorf("InstanceGroup %q not found", instanceGroupName)
+			}
+
+			filtered = append(filtered, found)
+		}
+
+		instanceGroups = filtered
+
+		// Don't warn if we find more ASGs than IGs
+		warnUnmatched = false
+	}
+
+	if len(options.InstanceGroupRoles) != 0 {
+		var filtered []*kops.InstanceGroup
+
+		for _, role := range options.InstanceGroupRoles {
+			s, f := kops.ParseInstanceGroupRole(role, true)
+			if !f {
+				return nil, fmt.Errorf("invalid instance group role %q", role)
+			}
+			for _, ig := range instanceGroups {
+				if ig.Spec.Role == s {
+					filtered = append(filtered, ig)
+				}
+			}
+		}
+
+		instanceGroups = filtered
+
+		// Don't warn if we find more ASGs than IGs
+		warnUnmatched = false
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := cloud.GetCloudGroups(cluster, instanceGroups, warnUnmatched, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &instancegroups.RollingUpdateCluster{
+		Clientset:         c.Clientset,
+		Ctx:               ctx,
+		Cluster:           cluster,
+		MasterInterval:    options.ControlPlaneInterval,
+		NodeInterval:      options.NodeInterval,
+		BastionInterval:   options.BastionInterval,
+		Interactive:       options.Interactive,
+		Force:             options.Force,
+		Cloud:             cloud,
+		K8sClient:         k8sClient,
+		FailOnDrainError:  options.FailOnDrainError,
+		FailOnValidate:    options.FailOnValidate,
+		CloudOnly:         options.CloudOnly,
+		ClusterName:       clusterName,
+		PostDrainDelay:    options.PostDrainDelay,
+		ValidationTimeout: options.ValidationTimeout,
+		ValidateCount:     options.ValidateCount,
+		DrainTimeout:      options.DrainTimeout,
+		MaxDuration:       options.MaxDuration,
+		// TODO should we expose this to the UI?
+		ValidateTickDuration:    30 * time.Second,
+		ValidateSuccessDuration: 10 * time.Second,
+
+		// TODO: Move more of the passthrough options here, instead of duplicating them.
+		Options: rollingUpdateOptions,
+	}
+
+	if err := d.AdjustNeedUpdate(groups); err != nil {
+		return nil, err
+	}
+
+	return &RollingUpdatePlan{
+		Cluster:            cluster,
+		Groups:             groups,
+		SingleControlPlane: singleControlPlane,
+		force:              options.Force,
+		cloudOnly:          options.CloudOnly,
+		restConfig:         options.RESTConfig,
+		cloud:              cloud,
+		instanceGroups:     list,
+		rollingUpdate:      d,
+	}, nil
+}
+
+// RollingUpdate replaces the instances selected by a plan, validating the cluster as it goes
+// unless the plan was made with CloudOnly.
+func (c *Client) RollingUpdate(ctx context.Context, plan *RollingUpdatePlan) error {
+	clusterName := plan.Cluster.ObjectMeta.Name
+
+	if !plan.NeedsUpdate() {
+		c.progress(OperationRollingUpdate, clusterName, "No rolling-update required.")
+		return nil
+	}
+
+	var clusterValidator validation.ClusterValidator
+	if !plan.cloudOnly {
+		var err error
+		clusterValidator, err = validation.NewClusterValidator(plan.Cluster, plan.cloud, plan.instanceGroups, plan.restConfig.Host, plan.rollingUpdate.K8sClient)
+		if err != nil {
+			return fmt.Errorf("cannot create cluster validator: %w", err)
+		}
+	}
+	plan.rollingUpdate.ClusterValidator = clusterValidator
+	plan.rollingUpdate.Ctx = ctx
+
+	c.progress(OperationRollingUpdate, clusterName, "starting rolling update")
+	if err := plan.rollingUpdate.RollingUpdate(plan.Groups, plan.instanceGroups); err != nil {
+		if errors.Is(err, instancegroups.ErrRollingUpdatePaused) {
+			c.progress(OperationRollingUpdate, clusterName, "rolling update paused")
+		}
+		return err
+	}
+	c.progress(OperationRollingUpdate, clusterName, "rolling update complete")
+
+	return nil
+}
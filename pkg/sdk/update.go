@@ -0,0 +1,156 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"io"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// UpdateClusterOptions holds the inputs to UpdateCluster.
+type UpdateClusterOptions struct {
+	// Target is the target to render to: cloudup.TargetDirect, cloudup.TargetDryRun or cloudup.TargetTerraform.
+	// It defaults to cloudup.TargetDryRun.
+	Target string
+	// OutDir is the directory for rendered output, such as terraform files.
+	OutDir string
+	// Phase restricts the update to a subset of tasks.
+	Phase cloudup.Phase
+	// LifecycleOverrides overrides the lifecycle of matching tasks, keyed by task type and optional name pattern.
+	LifecycleOverrides map[string]fi.Lifecycle
+	// AllowKopsDowngrade permits applying with a kops version older than the one last used on the cluster.
+	AllowKopsDowngrade bool
+	// Prune also deletes resources whose deletion is normally deferred.
+	Prune bool
+	// GetAssets only computes the assets used by the cluster.
+	GetAssets bool
+	// RunTasksOptions controls task execution, such as retries; defaults are used when nil.
+	RunTasksOptions *fi.RunTasksOptions
+	// Incremental skips the tasks whose desired state has not changed since the last apply, trusting that
+	// their cloud resources were not changed outside of kOps.
+	Incremental bool
+	// RetryFailed runs only the tasks that did not complete in the last apply, which failed part-way.
+	RetryFailed bool
+	// AWSRateLimit overrides the client-side limit of the requests to the AWS APIs while the tasks run.
+	AWSRateLimit *awsup.RateLimit
+	// AWSResourceWaits overrides the timeouts of the waits for the AWS resources created by the tasks.
+	AWSResourceWaits *awsup.ResourceWaitTimeouts
+	// DryRunReport receives the report of planned changes for dry-run updates; defaults to os.Stdout.
+	DryRunReport io.Writer
+}
+
+// UpdateClusterResult holds the outcome of UpdateCluster.
+type UpdateClusterResult struct {
+	// Cluster is the cluster that was updated.
+	Cluster *kops.Cluster
+	// InstanceGroups are the instance groups of the cluster.
+	InstanceGroups []*kops.InstanceGroup
+	// Cloud is the cloud the cluster runs in.
+	Cloud fi.Cloud
+	// Target is the target that was rendered to; for dry-runs it is a *fi.CloudupDryRunTarget.
+	Target fi.CloudupTarget
+	// TaskMap holds the tasks that were built.
+	TaskMap map[string]fi.CloudupTask
+	// ImageAssets are the container images used by the cluster.
+	ImageAssets []*assets.ImageAsset
+	// FileAssets are the files used by the cluster.
+	FileAssets []*assets.FileAsset
+}
+
+// HasChanges returns true if a dry-run update found changes to apply.
+func (r *UpdateClusterResult) HasChanges() bool {
+	if target, ok := r.Target.(*fi.CloudupDryRunTarget); ok {
+		return target.HasChanges()
+	}
+	return false
+}
+
+// UpdateCluster computes the cloud resources for a cluster and renders them to the requested target,
+// as `kops update cluster` does.
+func (c *Client) UpdateCluster(ctx context.Context, clusterName string, options *UpdateClusterOptions) (*UpdateClusterResult, error) {
+	if options == nil {
+		options = &UpdateClusterOptions{}
+	}
+
+	cluster, err := c.getCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	targetName := options.Target
+	if targetName == "" {
+		targetName = cloudup.TargetDryRun
+	}
+
+	runTasksOptions := options.RunTasksOptions
+	if runTasksOptions == nil {
+		runTasksOptions = &fi.RunTasksOptions{}
+		runTasksOptions.InitDefaults()
+	}
+
+	deletionProcessing := fi.DeletionProcessingModeDeleteIfNotDeferrred
+	if options.Prune {
+		deletionProcessing = fi.DeletionProcessingModeDeleteIncludingDeferred
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	applyCmd := &cloudup.ApplyClusterCmd{
+		Cloud:              cloud,
+		Clientset:          c.Clientset,
+		Cluster:            cluster,
+		DryRun:             targetName == cloudup.TargetDryRun,
+		AllowKopsDowngrade: options.AllowKopsDowngrade,
+		RunTasksOptions:    runTasksOptions,
+		Incremental:        options.Incremental,
+		RetryFailed:        options.RetryFailed,
+		AWSRateLimit:       options.AWSRateLimit,
+		AWSResourceWaits:   options.AWSResourceWaits,
+		OutDir:             options.OutDir,
+		Phase:              options.Phase,
+		TargetName:         targetName,
+		LifecycleOverrides: options.LifecycleOverrides,
+		GetAssets:          options.GetAssets,
+		DeletionProcessing: deletionProcessing,
+		Out:                options.DryRunReport,
+	}
+
+	c.progress(OperationUpdate, clusterName, "rendering cluster to target %s", targetName)
+	if err := applyCmd.Run(ctx); err != nil {
+		return nil, err
+	}
+	c.progress(OperationUpdate, clusterName, "rendered cluster to target %s", targetName)
+
+	return &UpdateClusterResult{
+		Cluster:        cluster,
+		InstanceGroups: applyCmd.InstanceGroups,
+		Cloud:          cloud,
+		Target:         applyCmd.Target,
+		TaskMap:        applyCmd.TaskMap,
+		ImageAssets:    applyCmd.ImageAssets,
+		FileAssets:     applyCmd.FileAssets,
+	}, nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+)
+
+// ValidateClusterOptions holds the inputs to ValidateCluster.
+type ValidateClusterOptions struct {
+	// RESTConfig is used to reach the kubernetes API of the cluster; ValidateInfra does not use it.
+	RESTConfig *rest.Config
+	// Wait is how long to keep retrying until the cluster is healthy; zero validates once.
+	Wait time.Duration
+	// Interval is the time between validation attempts.
+	Interval time.Duration
+	// Count is the number of consecutive successful validations required.
+	Count int
+	// OnResult, if set, is called with the result of every validation attempt.
+	OnResult func(result *validation.ValidationCluster) error
+	// Checks, if set, are the names of the only checks that ValidateCluster runs; ValidateInfra does not use it.
+	Checks []string
+	// SkipChecks are the names of the checks that ValidateCluster does not run; ValidateInfra does not use it.
+	SkipChecks []string
+}
+
+// ValidateCluster checks that the cluster's nodes and system pods are healthy, as `kops validate cluster` does.
+// It returns the last validation result once the cluster is healthy.
+func (c *Client) ValidateCluster(ctx context.Context, clusterName string, options *ValidateClusterOptions) (*validation.ValidationCluster, error) {
+	if options == nil {
+		options = &ValidateClusterOptions{}
+	}
+	if options.RESTConfig == nil {
+		return nil, fmt.Errorf("RESTConfig is required")
+	}
+
+	cluster, err := c.getCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.Clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get InstanceGroups for %q: %w", clusterName, err)
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no InstanceGroup objects found")
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(options.RESTConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build kubernetes api client for %q: %w", clusterName, err)
+	}
+
+	validator, err := validation.NewClusterValidator(cluster, cloud, list, options.RESTConfig.Host, k8sClient,
+		validation.WithChecks(options.Checks), validation.WithSkippedChecks(options.SkipChecks))
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error creating validatior: %w", err)
+	}
+
+	return c.waitForValidation(ctx, clusterName, validator, options)
+}
+
+// ValidateInfra checks the cloud resources of the cluster without using the kubernetes API, as `kops validate infra` does.
+// It returns the last validation result once the infrastructure is healthy.
+func (c *Client) ValidateInfra(ctx context.Context, clusterName string, options *ValidateClusterOptions) (*validation.ValidationCluster, error) {
+	if options == nil {
+		options = &ValidateClusterOptions{}
+	}
+
+	cluster, err := c.getCluster(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.Clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get InstanceGroups for %q: %w", clusterName, err)
+	}
+
+	validator, err := validation.NewInfraValidator(cluster, cloud, list)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error creating validator: %w", err)
+	}
+
+	return c.waitForValidation(ctx, clusterName, validator, options)
+}
+
+// waitForValidation runs the validator until the cluster has passed validation options.Count consecutive times,
+// options.Wait has elapsed, or ctx is done.
+func (c *Client) waitForValidation(ctx context.Context, clusterName string, validator validation.ClusterValidator, options *ValidateClusterOptions) (*validation.ValidationCluster, error) {
+	if options.Wait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Wait)
+		defer cancel()
+	}
+
+	// retry waits for the next attempt, or returns an error if validation should stop.
+	retry := func(lastErr error) error {
+		if options.Wait <= 0 {
+			return lastErr
+		}
+		timer := time.NewTimer(options.Interval)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return fmt.Errorf("wait time exceeded during validation: %w", lastErr)
+			}
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+
+	consecutive := 0
+	for {
+		c.progress(OperationValidate, clusterName, "validating cluster")
+		result, err := validator.Validate()
+		if err != nil {
+			consecutive = 0
+			klog.Warningf("(will retry): unexpected error during validation: %v", err)
+			if err := retry(fmt.Errorf("unexpected error during validation: %w", err)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if options.OnResult != nil {
+			if err := options.OnResult(result); err != nil {
+				return nil, err
+			}
+		}
+
+		if len(result.Failures) == 0 {
+			consecutive++
+			if consecutive >= options.Count {
+				return result, nil
+			}
+			c.progress(OperationValidate, clusterName, "cluster passed validation %d consecutive times", consecutive)
+			klog.Infof("(will retry): cluster passed validation %d consecutive times", consecutive)
+			if err := retry(fmt.Errorf("cluster passed validation %d consecutive times", consecutive)); err != nil {
+				return nil, err
+			}
+		} else {
+			c.progress(OperationValidate, clusterName, "cluster not yet healthy: %d failures", len(result.Failures))
+			klog.Warningf("(will retry): cluster not yet healthy")
+			consecutive = 0
+			if err := retry(fmt.Errorf("cluster not yet healthy")); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
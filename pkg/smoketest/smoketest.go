@@ -0,0 +1,442 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package smoketest deploys a canary workload to a newly provisioned cluster,
+// checks that pods, services, DNS, load balancers and volumes work end-to-end, and cleans up.
+package smoketest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+const (
+	// DefaultImage serves HTTP for the canary deployment, and connects to it for the DNS check.
+	DefaultImage = "registry.k8s.io/e2e-test-images/agnhost:2.52"
+	// DefaultNamespace is the namespace of the canary workload, deleted after the checks.
+	DefaultNamespace = "kops-smoke-test"
+
+	// appName names the objects of the canary workload.
+	appName = "smoke-test"
+	// httpPort is the port that the canary deployment serves HTTP on.
+	httpPort = 8080
+)
+
+const (
+	// CheckNamespace creates the namespace of the canary workload.
+	CheckNamespace = "namespace"
+	// CheckDeployment waits for the pods of the canary deployment to be ready.
+	CheckDeployment = "deployment"
+	// CheckService waits for the service of the canary deployment to have ready endpoints.
+	CheckService = "service"
+	// CheckDNS connects to the service by its DNS name from a job.
+	CheckDNS = "dns"
+	// CheckLoadBalancer waits for a service of type LoadBalancer to get an address.
+	CheckLoadBalancer = "load-balancer"
+	// CheckVolume waits for a pod mounting a persistent volume claim to run.
+	CheckVolume = "volume"
+	// CheckCleanup deletes the namespace of the canary workload and waits for it to be gone.
+	CheckCleanup = "cleanup"
+)
+
+// SmokeTest checks a cluster with a canary workload.
+type SmokeTest struct {
+	K8sClient kubernetes.Interface
+
+	// Namespace is the namespace of the canary workload. It must not exist.
+	Namespace string
+	// Image is the image of the canary pods, which must provide the agnhost commands.
+	Image string
+	// LoadBalancer enables the check of services of type LoadBalancer.
+	LoadBalancer bool
+	// Volume enables the check of persistent volumes, with the default storage class.
+	Volume bool
+	// Keep leaves the canary workload in place after the checks, for troubleshooting.
+	Keep bool
+
+	// Timeout is how long each check waits for its objects.
+	Timeout time.Duration
+	// PollInterval is how often the objects are polled while waiting.
+	PollInterval time.Duration
+}
+
+// Case is the result of a check.
+type Case struct {
+	// Name is the name of the check.
+	Name string
+	// Duration is how long the check took.
+	Duration time.Duration
+	// Err is the reason the check failed, nil if it passed.
+	Err error
+	// Skipped is the reason the check was not run, empty if it was run.
+	Skipped string
+}
+
+// Result is the result of a smoke test.
+type Result struct {
+	Cases []*Case
+}
+
+// Failed returns true if any check failed.
+func (r *Result) Failed() bool {
+	for _, c := range r.Cases {
+		if c.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Run runs the checks in order, skipping the checks that depend on a failed check.
+// The namespace of the canary workload is deleted at the end, unless Keep is set.
+func (s *SmokeTest) Run(ctx context.Context) *Result {
+	result := &Result{}
+
+	failed := make(map[string]bool)
+	run := func(name string, dependsOn []string, check func(ctx context.Context) error) {
+		c := &Case{Name: name}
+		result.Cases = append(result.Cases, c)
+		for _, dep := range dependsOn {
+			if failed[dep] {
+				failed[name] = true
+				c.Skipped = fmt.Sprintf("check %q failed", dep)
+				return
+			}
+		}
+
+		klog.Infof("running check %q", name)
+		start := time.Now()
+		c.Err = check(ctx)
+		c.Duration = time.Since(start)
+		if c.Err != nil {
+			failed[name] = true
+			klog.Warningf("check %q failed: %v", name, c.Err)
+		}
+	}
+	skip := func(name, reason string) {
+		result.Cases = append(result.Cases, &Case{Name: name, Skipped: reason})
+	}
+
+	run(CheckNamespace, nil, s.createNamespace)
+	run(CheckDeployment, []string{CheckNamespace}, s.checkDeployment)
+	run(CheckService, []string{CheckDeployment}, s.checkService)
+	run(CheckDNS, []string{CheckService}, s.checkDNS)
+	if s.LoadBalancer {
+		run(CheckLoadBalancer, []string{CheckDeployment}, s.checkLoadBalancer)
+	} else {
+		skip(CheckLoadBalancer, "load balancer check disabled")
+	}
+	if s.Volume {
+		run(CheckVolume, []string{CheckNamespace}, s.checkVolume)
+	} else {
+		skip(CheckVolume, "volume check disabled")
+	}
+	if s.Keep {
+		skip(CheckCleanup, fmt.Sprintf("namespace %q kept", s.Namespace))
+	} else {
+		run(CheckCleanup, []string{CheckNamespace}, s.cleanup)
+	}
+
+	return result
+}
+
+func (s *SmokeTest) labels() map[string]string {
+	return map[string]string{"app": appName}
+}
+
+func (s *SmokeTest) createNamespace(ctx context.Context) error {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   s.Namespace,
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "kops-smoke-test"},
+		},
+	}
+	if _, err := s.K8sClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating namespace %q: %w", s.Namespace, err)
+	}
+	return nil
+}
+
+func (s *SmokeTest) checkDeployment(ctx context.Context) error {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   appName,
+			Labels: s.labels(),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: fi.PtrTo(int32(2)),
+			Selector: &metav1.LabelSelector{MatchLabels: s.labels()},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: s.labels()},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "netexec",
+							Image: s.Image,
+							Args:  []string{"netexec", fmt.Sprintf("--http-port=%d", httpPort)},
+							Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: httpPort}},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if _, err := s.K8sClient.AppsV1().Deployments(s.Namespace).Create(ctx, deployment, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating deployment: %w", err)
+	}
+
+	var readyReplicas int32
+	err := s.poll(ctx, func(ctx context.Context) (bool, error) {
+		d, err := s.K8sClient.AppsV1().Deployments(s.Namespace).Get(ctx, appName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		readyReplicas = d.Status.ReadyReplicas
+		return readyReplicas == fi.ValueOf(d.Spec.Replicas), nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for the pods of the deployment to be ready (%d ready): %w", readyReplicas, err)
+	}
+	return nil
+}
+
+func (s *SmokeTest) checkService(ctx context.Context) error {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   appName,
+			Labels: s.labels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: s.labels(),
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	if _, err := s.K8sClient.CoreV1().Services(s.Namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating service: %w", err)
+	}
+
+	err := s.poll(ctx, func(ctx context.Context) (bool, error) {
+		slices, err := s.K8sClient.DiscoveryV1().EndpointSlices(s.Namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: discoveryv1.LabelServiceName + "=" + appName,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, slice := range slices.Items {
+			for _, endpoint := range slice.Endpoints {
+				if fi.ValueOf(endpoint.Conditions.Ready) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for the service to have ready endpoints: %w", err)
+	}
+	return nil
+}
+
+func (s *SmokeTest) checkDNS(ctx context.Context) error {
+	host := fmt.Sprintf("%s.%s.svc:80", appName, s.Namespace)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   appName + "-dns",
+			Labels: s.labels(),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: fi.PtrTo(int32(3)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:  "connect",
+							Image: s.Image,
+							Args:  []string{"connect", host, "--timeout=10s"},
+						},
+					},
+				},
+			},
+		},
+	}
+	if _, err := s.K8sClient.BatchV1().Jobs(s.Namespace).Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating job: %w", err)
+	}
+
+	err := s.poll(ctx, func(ctx context.Context) (bool, error) {
+		j, err := s.K8sClient.BatchV1().Jobs(s.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, condition := range j.Status.Conditions {
+			if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+				return false, fmt.Errorf("job failed to connect to %s: %s", host, condition.Message)
+			}
+		}
+		return j.Status.Succeeded > 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for a pod to connect to %s: %w", host, err)
+	}
+	return nil
+}
+
+func (s *SmokeTest) checkLoadBalancer(ctx context.Context) error {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   appName + "-lb",
+			Labels: s.labels(),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: s.labels(),
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 80, TargetPort: intstr.FromString("http")}},
+		},
+	}
+	if _, err := s.K8sClient.CoreV1().Services(s.Namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating service: %w", err)
+	}
+
+	err := s.poll(ctx, func(ctx context.Context) (bool, error) {
+		svc, err := s.K8sClient.CoreV1().Services(s.Namespace).Get(ctx, service.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" || ingress.Hostname != "" {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for the load balancer to get an address: %w", err)
+	}
+	return nil
+}
+
+func (s *SmokeTest) checkVolume(ctx context.Context) error {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   appName,
+			Labels: s.labels(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+	}
+	if _, err := s.K8sClient.CoreV1().PersistentVolumeClaims(s.Namespace).Create(ctx, pvc, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating persistent volume claim: %w", err)
+	}
+
+	// Storage classes commonly bind volumes when the first pod is scheduled, so a pod mounts the claim
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   appName + "-volume",
+			Labels: s.labels(),
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:         "pause",
+					Image:        s.Image,
+					Args:         []string{"pause"},
+					VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name},
+					},
+				},
+			},
+		},
+	}
+	if _, err := s.K8sClient.CoreV1().Pods(s.Namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("creating pod: %w", err)
+	}
+
+	var phase corev1.PersistentVolumeClaimPhase
+	err := s.poll(ctx, func(ctx context.Context) (bool, error) {
+		c, err := s.K8sClient.CoreV1().PersistentVolumeClaims(s.Namespace).Get(ctx, pvc.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		phase = c.Status.Phase
+		if phase != corev1.ClaimBound {
+			return false, nil
+		}
+		p, err := s.K8sClient.CoreV1().Pods(s.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return p.Status.Phase == corev1.PodRunning, nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for a pod to mount the persistent volume claim (claim %s): %w", phase, err)
+	}
+	return nil
+}
+
+func (s *SmokeTest) cleanup(ctx context.Context) error {
+	// Deleting the namespace also deletes the load balancer and the volume of the cloud provider
+	propagation := metav1.DeletePropagationForeground
+	err := s.K8sClient.CoreV1().Namespaces().Delete(ctx, s.Namespace, metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting namespace %q: %w", s.Namespace, err)
+	}
+
+	err = s.poll(ctx, func(ctx context.Context) (bool, error) {
+		_, err := s.K8sClient.CoreV1().Namespaces().Get(ctx, s.Namespace, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for namespace %q to be deleted: %w", s.Namespace, err)
+	}
+	return nil
+}
+
+func (s *SmokeTest) poll(ctx context.Context, condition wait.ConditionWithContextFunc) error {
+	return wait.PollUntilContextTimeout(ctx, s.PollInterval, s.Timeout, true, condition)
+}
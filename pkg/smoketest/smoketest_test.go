@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smoketest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// newFakeClient returns a client whose objects become ready as soon as they are created.
+// The load balancer never gets an address unless withLoadBalancer is set.
+func newFakeClient(withLoadBalancer bool) *fake.Clientset {
+	client := fake.NewSimpleClientset(&discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      appName + "-abcde",
+			Namespace: DefaultNamespace,
+			Labels:    map[string]string{discoveryv1.LabelServiceName: appName},
+		},
+		Endpoints: []discoveryv1.Endpoint{
+			{Conditions: discoveryv1.EndpointConditions{Ready: fi.PtrTo(true)}},
+		},
+	})
+
+	ready := func(action k8stesting.Action) (bool, runtime.Object, error) {
+		switch obj := action.(k8stesting.CreateAction).GetObject().(type) {
+		case *appsv1.Deployment:
+			obj.Status.ReadyReplicas = fi.ValueOf(obj.Spec.Replicas)
+		case *batchv1.Job:
+			obj.Status.Succeeded = 1
+		case *corev1.Service:
+			if obj.Spec.Type == corev1.ServiceTypeLoadBalancer && withLoadBalancer {
+				obj.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "lb.example.com"}}
+			}
+		case *corev1.PersistentVolumeClaim:
+			obj.Status.Phase = corev1.ClaimBound
+		case *corev1.Pod:
+			obj.Status.Phase = corev1.PodRunning
+		}
+		return false, nil, nil
+	}
+	client.PrependReactor("create", "*", ready)
+	return client
+}
+
+func TestRun(t *testing.T) {
+	grid := []struct {
+		name         string
+		loadBalancer bool
+		volume       bool
+		keep         bool
+		lbReady      bool
+		failed       []string
+		skipped      []string
+	}{
+		{
+			name:         "all checks pass",
+			loadBalancer: true,
+			volume:       true,
+			lbReady:      true,
+		},
+		{
+			name:         "load balancer without address",
+			loadBalancer: true,
+			volume:       true,
+			failed:       []string{CheckLoadBalancer},
+		},
+		{
+			name:    "optional checks disabled and namespace kept",
+			keep:    true,
+			skipped: []string{CheckLoadBalancer, CheckVolume, CheckCleanup},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			client := newFakeClient(g.lbReady)
+			s := &SmokeTest{
+				K8sClient:    client,
+				Namespace:    DefaultNamespace,
+				Image:        DefaultImage,
+				LoadBalancer: g.loadBalancer,
+				Volume:       g.volume,
+				Keep:         g.keep,
+				Timeout:      50 * time.Millisecond,
+				PollInterval: 10 * time.Millisecond,
+			}
+			result := s.Run(context.Background())
+
+			var failed, skipped []string
+			for _, c := range result.Cases {
+				if c.Err != nil {
+					failed = append(failed, c.Name)
+				}
+				if c.Skipped != "" {
+					skipped = append(skipped, c.Name)
+				}
+			}
+			if strings.Join(failed, ",") != strings.Join(g.failed, ",") {
+				t.Errorf("expected failed checks %v, got %v", g.failed, failed)
+			}
+			if strings.Join(skipped, ",") != strings.Join(g.skipped, ",") {
+				t.Errorf("expected skipped checks %v, got %v", g.skipped, skipped)
+			}
+			if result.Failed() != (len(g.failed) > 0) {
+				t.Errorf("unexpected Failed() %v", result.Failed())
+			}
+
+			_, err := client.CoreV1().Namespaces().Get(context.Background(), DefaultNamespace, metav1.GetOptions{})
+			if exists := err == nil; exists != g.keep {
+				t.Errorf("expected namespace to exist: %v, got %v", g.keep, exists)
+			}
+		})
+	}
+}
+
+func TestRunSkipsDependentChecks(t *testing.T) {
+	client := newFakeClient(true)
+	client.PrependReactor("create", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+	s := &SmokeTest{
+		K8sClient:    client,
+		Namespace:    DefaultNamespace,
+		Image:        DefaultImage,
+		LoadBalancer: true,
+		Volume:       true,
+		Timeout:      50 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+	}
+	result := s.Run(context.Background())
+
+	for _, c := range result.Cases {
+		if c.Name == CheckNamespace {
+			if c.Err == nil {
+				t.Errorf("expected check %q to fail", c.Name)
+			}
+			continue
+		}
+		if c.Skipped == "" {
+			t.Errorf("expected check %q to be skipped", c.Name)
+		}
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	result := &Result{
+		Cases: []*Case{
+			{Name: CheckNamespace, Duration: 1500 * time.Millisecond},
+			{Name: CheckDeployment, Duration: 2 * time.Second, Err: errors.New("pods not ready")},
+			{Name: CheckVolume, Skipped: "volume check disabled"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := result.WriteJUnit(&buf, "kops-smoke-test"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="kops-smoke-test" tests="3" failures="1" skipped="1" time="3.500">
+  <testcase name="namespace" classname="kops-smoke-test" time="1.500"></testcase>
+  <testcase name="deployment" classname="kops-smoke-test" time="2.000">
+    <failure message="pods not ready">pods not ready</failure>
+  </testcase>
+  <testcase name="volume" classname="kops-smoke-test" time="0.000">
+    <skipped message="volume check disabled"></skipped>
+  </testcase>
+</testsuite>
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected junit report, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// manifestName is the name of the archive entry holding the Manifest.
+// It is always the first entry of the archive.
+const manifestName = "manifest.yaml"
+
+// Store names, which are also the top-level directories of the archive.
+const (
+	StoreConfig   = "config"
+	StoreKeypairs = "keypairs"
+	StoreSecrets  = "secrets"
+)
+
+// Manifest describes the contents of a backup of the state of a cluster.
+type Manifest struct {
+	// ClusterName is the name of the cluster the state belongs to.
+	ClusterName string `json:"clusterName"`
+	// KopsVersion is the version of kOps that created the backup.
+	KopsVersion string `json:"kopsVersion"`
+	// CreationTimestamp is the time the backup was created.
+	CreationTimestamp time.Time `json:"creationTimestamp"`
+	// Stores are the state store locations that were backed up.
+	Stores []Store `json:"stores"`
+}
+
+// Store is a state store location, such as the config base of a cluster.
+type Store struct {
+	// Name identifies the store and is the directory of its files in the archive.
+	Name string `json:"name"`
+	// Path is the VFS path of the store when the backup was created.
+	Path string `json:"path"`
+	// Files are the files of the store, relative to Path.
+	Files []File `json:"files"`
+}
+
+// File is a file in a Store.
+type File struct {
+	// Path is the path of the file, relative to the store.
+	Path string `json:"path"`
+	// SHA256 is the hex-encoded SHA-256 hash of the file contents.
+	SHA256 string `json:"sha256"`
+}
+
+// Backup is a snapshot of the state store objects of a cluster.
+type Backup struct {
+	Manifest Manifest
+
+	// contents holds the file contents, keyed by store name and then by file path.
+	contents map[string]map[string][]byte
+}
+
+// StorePath is a state store location to include in a backup.
+type StorePath struct {
+	Name string
+	Path vfs.Path
+}
+
+// skipFile returns true for files that are not part of the state of the cluster.
+// The backups directory holds the previous versions of the cluster spec, which kOps never reads.
+func skipFile(store string, relativePath string) bool {
+	return store == StoreConfig && strings.HasPrefix(relativePath, "backups/")
+}
+
+// Create reads all the files of the given stores.
+func Create(ctx context.Context, clusterName string, kopsVersion string, stores []StorePath) (*Backup, error) {
+	b := &Backup{
+		Manifest: Manifest{
+			ClusterName:       clusterName,
+			KopsVersion:       kopsVersion,
+			CreationTimestamp: time.Now().UTC().Truncate(time.Second),
+		},
+		contents: make(map[string]map[string][]byte),
+	}
+
+	for _, store := range stores {
+		files, err := readStore(ctx, store.Name, store.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		s := Store{
+			Name: store.Name,
+			Path: store.Path.Path(),
+		}
+		for _, p := range sortedKeys(files) {
+			s.Files = append(s.Files, File{
+				Path:   p,
+				SHA256: hashBytes(files[p]),
+			})
+		}
+		b.Manifest.Stores = append(b.Manifest.Stores, s)
+		b.contents[store.Name] = files
+	}
+
+	return b, nil
+}
+
+// readStore reads all the files under basePath, keyed by their path relative to basePath.
+func readStore(ctx context.Context, name string, basePath vfs.Path) (map[string][]byte, error) {
+	paths, err := basePath.ReadTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing files in %s: %w", basePath, err)
+	}
+
+	files := make(map[string][]byte)
+	for _, p := range paths {
+		relativePath, err := vfs.RelativePath(basePath, p)
+		if err != nil {
+			return nil, err
+		}
+		if relativePath == "" || skipFile(name, relativePath) {
+			continue
+		}
+		data, err := p.ReadFile(ctx)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading %s: %w", p, err)
+		}
+		files[relativePath] = data
+	}
+	return files, nil
+}
+
+// WriteTo writes the backup as a gzipped tar archive.
+func (b *Backup) WriteTo(w io.Writer) (int64, error) {
+	manifest, err := yaml.Marshal(&b.Manifest)
+	if err != nil {
+		return 0, fmt.Errorf("error serializing backup manifest: %w", err)
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+
+	writeEntry := func(name string, data []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: b.Manifest.CreationTimestamp,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := writeEntry(manifestName, manifest); err != nil {
+		return 0, fmt.Errorf("error writing backup manifest: %w", err)
+	}
+	for _, store := range b.Manifest.Stores {
+		for _, file := range store.Files {
+			if err := writeEntry(path.Join(store.Name, file.Path), b.contents[store.Name][file.Path]); err != nil {
+				return 0, fmt.Errorf("error writing %s to backup: %w", file.Path, err)
+			}
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return 0, err
+	}
+	if err := gw.Close(); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
+
+// Read reads a backup written by WriteTo.
+// It verifies that the archive holds exactly the files listed in its manifest, with the recorded hashes.
+func Read(r io.Reader) (*Backup, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup: %w", err)
+	}
+	tr := tar.NewReader(gr)
+
+	b := &Backup{
+		contents: make(map[string]map[string][]byte),
+	}
+	entries := make(map[string][]byte)
+	first := true
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading backup: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s from backup: %w", header.Name, err)
+		}
+
+		if first {
+			if header.Name != manifestName {
+				return nil, fmt.Errorf("backup does not start with %s", manifestName)
+			}
+			if err := yaml.UnmarshalStrict(data, &b.Manifest); err != nil {
+				return nil, fmt.Errorf("error parsing backup manifest: %w", err)
+			}
+			first = false
+			continue
+		}
+		if _, found := entries[header.Name]; found {
+			return nil, fmt.Errorf("backup contains %s more than once", header.Name)
+		}
+		entries[header.Name] = data
+	}
+	if first {
+		return nil, fmt.Errorf("backup is empty")
+	}
+	if b.Manifest.ClusterName == "" {
+		return nil, fmt.Errorf("backup manifest does not name a cluster")
+	}
+
+	for _, store := range b.Manifest.Stores {
+		if _, found := b.contents[store.Name]; found {
+			return nil, fmt.Errorf("backup contains store %q more than once", store.Name)
+		}
+		files := make(map[string][]byte)
+		for _, file := range store.Files {
+			name := path.Join(store.Name, file.Path)
+			data, found := entries[name]
+			if !found {
+				return nil, fmt.Errorf("backup is missing %s", name)
+			}
+			if actual := hashBytes(data); actual != file.SHA256 {
+				return nil, fmt.Errorf("backup is corrupted: hash of %s is %s, expected %s", name, actual, file.SHA256)
+			}
+			files[file.Path] = data
+			delete(entries, name)
+		}
+		b.contents[store.Name] = files
+	}
+	for name := range entries {
+		return nil, fmt.Errorf("backup contains %s, which is not in its manifest", name)
+	}
+
+	return b, nil
+}
+
+// File returns the contents of a file of the backup, or nil if the file is not in the backup.
+func (b *Backup) File(store string, relativePath string) []byte {
+	return b.contents[store][relativePath]
+}
+
+func hashBytes(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
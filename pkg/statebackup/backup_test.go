@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+var testFiles = map[string]string{
+	"config":                           "cluster",
+	"instancegroup/nodes":              "nodes",
+	"pki/private/kubernetes-ca/keyset": "ca",
+	"secrets/admin":                    "admin",
+	"backups/2024-01-01/config":        "old cluster",
+}
+
+func newTestStore(t *testing.T) (*vfs.VFSContext, vfs.Path) {
+	ctx := context.Background()
+	vfsContext := vfs.NewVFSContext()
+	vfsContext.ResetMemfsContext(true)
+	basePath, err := vfsContext.BuildVfsPath("memfs://state/minimal.example.com")
+	if err != nil {
+		t.Fatalf("building path: %v", err)
+	}
+	for name, contents := range testFiles {
+		if err := basePath.Join(name).WriteFile(ctx, strings.NewReader(contents), nil); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	return vfsContext, basePath
+}
+
+func readFile(t *testing.T, p vfs.Path) string {
+	data, err := p.ReadFile(context.Background())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "<missing>"
+		}
+		t.Fatalf("reading %s: %v", p, err)
+	}
+	return string(data)
+}
+
+func createBackup(t *testing.T, basePath vfs.Path) []byte {
+	b, err := Create(context.Background(), "minimal.example.com", "1.31.0", []StorePath{{Name: StoreConfig, Path: basePath}})
+	if err != nil {
+		t.Fatalf("creating backup: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := b.WriteTo(&buf); err != nil {
+		t.Fatalf("writing backup: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	_, basePath := newTestStore(t)
+
+	b, err := Read(bytes.NewReader(createBackup(t, basePath)))
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+
+	if b.Manifest.ClusterName != "minimal.example.com" {
+		t.Errorf("unexpected cluster name %q", b.Manifest.ClusterName)
+	}
+	if len(b.Manifest.Stores) != 1 || len(b.Manifest.Stores[0].Files) != 4 {
+		t.Fatalf("unexpected stores %+v", b.Manifest.Stores)
+	}
+	for name, contents := range testFiles {
+		expected := contents
+		if strings.HasPrefix(name, "backups/") {
+			expected = ""
+		}
+		if actual := string(b.File(StoreConfig, name)); actual != expected {
+			t.Errorf("file %s: expected %q, got %q", name, expected, actual)
+		}
+	}
+}
+
+// rewriteArchive calls mutate for each entry of the archive, which can change its contents
+// or drop it by returning nil.
+func rewriteArchive(t *testing.T, archive []byte, mutate func(name string, data []byte) []byte) []byte {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("reading archive: %v", err)
+	}
+	tr := tar.NewReader(gr)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading archive: %v", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("reading archive: %v", err)
+		}
+		data = mutate(header.Name, data)
+		if data == nil {
+			continue
+		}
+		header.Size = int64(len(data))
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("writing archive: %v", err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatalf("writing archive: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("writing archive: %v", err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatalf("writing archive: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadVerifiesIntegrity(t *testing.T) {
+	_, basePath := newTestStore(t)
+	archive := createBackup(t, basePath)
+
+	grid := []struct {
+		name   string
+		mutate func(name string, data []byte) []byte
+		err    string
+	}{
+		{
+			name: "corrupted file",
+			mutate: func(name string, data []byte) []byte {
+				if name == "config/secrets/admin" {
+					return []byte("tampered")
+				}
+				return data
+			},
+			err: "backup is corrupted: hash of config/secrets/admin",
+		},
+		{
+			name: "missing file",
+			mutate: func(name string, data []byte) []byte {
+				if name == "config/instancegroup/nodes" {
+					return nil
+				}
+				return data
+			},
+			err: "backup is missing config/instancegroup/nodes",
+		},
+		{
+			name: "missing manifest",
+			mutate: func(name string, data []byte) []byte {
+				if name == manifestName {
+					return nil
+				}
+				return data
+			},
+			err: "backup does not start with manifest.yaml",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(rewriteArchive(t, archive, g.mutate)))
+			if err == nil {
+				t.Fatalf("expected error %q", g.err)
+			}
+			if !strings.Contains(err.Error(), g.err) {
+				t.Errorf("expected error %q, got %q", g.err, err)
+			}
+		})
+	}
+
+	if _, err := Read(bytes.NewReader(archive[:len(archive)/2])); err == nil {
+		t.Errorf("expected error reading a truncated backup")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	vfsContext, basePath := newTestStore(t)
+	b, err := Read(bytes.NewReader(createBackup(t, basePath)))
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+
+	// Corrupt the state store after the backup
+	if err := basePath.Join("config").WriteFile(ctx, strings.NewReader("corrupted"), nil); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	if err := basePath.Join("secrets/admin").Remove(ctx); err != nil {
+		t.Fatalf("removing secret: %v", err)
+	}
+	if err := basePath.Join("instancegroup/extra").WriteFile(ctx, strings.NewReader("extra"), nil); err != nil {
+		t.Fatalf("writing instance group: %v", err)
+	}
+
+	plan, err := b.PlanRestore(ctx, vfsContext)
+	if err != nil {
+		t.Fatalf("planning restore: %v", err)
+	}
+	var changes []string
+	for _, change := range plan.Changes {
+		relativePath, err := vfs.RelativePath(basePath, change.Path)
+		if err != nil {
+			t.Fatalf("relative path: %v", err)
+		}
+		changes = append(changes, fmt.Sprintf("%s %s", change.Type, relativePath))
+	}
+	expected := "create secrets/admin, delete instancegroup/extra, update config"
+	if actual := strings.Join(changes, ", "); actual != expected {
+		t.Errorf("expected changes %q, got %q", expected, actual)
+	}
+
+	if err := plan.Apply(ctx, func(vfs.Path) (vfs.ACL, error) { return nil, nil }); err != nil {
+		t.Fatalf("applying restore: %v", err)
+	}
+	for name, contents := range testFiles {
+		if actual := readFile(t, basePath.Join(name)); actual != contents {
+			t.Errorf("file %s: expected %q, got %q", name, contents, actual)
+		}
+	}
+	if actual := readFile(t, basePath.Join("instancegroup/extra")); actual != "<missing>" {
+		t.Errorf("expected instancegroup/extra to be deleted, got %q", actual)
+	}
+}
+
+func TestRestoreRollback(t *testing.T) {
+	ctx := context.Background()
+	vfsContext, basePath := newTestStore(t)
+	b, err := Read(bytes.NewReader(createBackup(t, basePath)))
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+
+	if err := basePath.Join("secrets/admin").Remove(ctx); err != nil {
+		t.Fatalf("removing secret: %v", err)
+	}
+	if err := basePath.Join("instancegroup/nodes").WriteFile(ctx, strings.NewReader("changed"), nil); err != nil {
+		t.Fatalf("writing instance group: %v", err)
+	}
+	if err := basePath.Join("config").WriteFile(ctx, strings.NewReader("changed"), nil); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	plan, err := b.PlanRestore(ctx, vfsContext)
+	if err != nil {
+		t.Fatalf("planning restore: %v", err)
+	}
+
+	// Fail the last write, the cluster spec, so that the previous changes must be reverted
+	aclOracle := func(p vfs.Path) (vfs.ACL, error) {
+		if p.Base() == "config" {
+			return nil, fmt.Errorf("access denied")
+		}
+		return nil, nil
+	}
+	err = plan.Apply(ctx, aclOracle)
+	if err == nil || !strings.Contains(err.Error(), "access denied") {
+		t.Fatalf("expected access denied error, got %v", err)
+	}
+
+	expected := map[string]string{
+		"config":              "changed",
+		"instancegroup/nodes": "changed",
+		"secrets/admin":       "<missing>",
+	}
+	for name, contents := range expected {
+		if actual := readFile(t, basePath.Join(name)); actual != contents {
+			t.Errorf("file %s: expected %q, got %q", name, contents, actual)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statebackup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// ChangeType is the kind of change that restoring a backup makes to a file.
+type ChangeType string
+
+const (
+	ChangeTypeCreate ChangeType = "create"
+	ChangeTypeUpdate ChangeType = "update"
+	ChangeTypeDelete ChangeType = "delete"
+)
+
+// Change is a change that restoring a backup makes to a file of the state store.
+type Change struct {
+	Type ChangeType
+	Path vfs.Path
+
+	// data is the contents of the file in the backup, for creates and updates.
+	data []byte
+	// previous is the contents of the file in the state store, for updates and deletes.
+	previous []byte
+}
+
+// RestorePlan is the set of changes that restore the state store to the contents of a backup.
+type RestorePlan struct {
+	Changes []*Change
+}
+
+// PlanRestore compares the stores of the backup to their current contents.
+// Files that are not in the backup are deleted, so that the stores match the backup exactly.
+func (b *Backup) PlanRestore(ctx context.Context, vfsContext *vfs.VFSContext) (*RestorePlan, error) {
+	plan := &RestorePlan{}
+
+	// The cluster spec is written last and deleted first, so that an interrupted restore
+	// never leaves a cluster spec without the rest of its state.
+	var clusterSpec *Change
+
+	for _, store := range b.Manifest.Stores {
+		basePath, err := vfsContext.BuildVfsPath(store.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error building path for %q: %w", store.Path, err)
+		}
+		current, err := readStore(ctx, store.Name, basePath)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range store.Files {
+			data := b.contents[store.Name][file.Path]
+			change := &Change{
+				Path: basePath.Join(file.Path),
+				data: data,
+			}
+			previous, found := current[file.Path]
+			switch {
+			case !found:
+				change.Type = ChangeTypeCreate
+			case !bytes.Equal(previous, data):
+				change.Type = ChangeTypeUpdate
+				change.previous = previous
+			default:
+				continue
+			}
+			if store.Name == StoreConfig && file.Path == "config" {
+				clusterSpec = change
+				continue
+			}
+			plan.Changes = append(plan.Changes, change)
+		}
+
+		for _, p := range sortedKeys(current) {
+			if _, found := b.contents[store.Name][p]; found {
+				continue
+			}
+			change := &Change{
+				Type:     ChangeTypeDelete,
+				Path:     basePath.Join(p),
+				previous: current[p],
+			}
+			if store.Name == StoreConfig && p == "config" {
+				plan.Changes = append([]*Change{change}, plan.Changes...)
+				continue
+			}
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+
+	if clusterSpec != nil {
+		plan.Changes = append(plan.Changes, clusterSpec)
+	}
+
+	return plan, nil
+}
+
+// Apply makes the changes of the plan.
+// If a change fails, the changes that were already made are reverted,
+// so that the state store is left as it was before the restore.
+func (p *RestorePlan) Apply(ctx context.Context, aclOracle vfs.ACLOracle) error {
+	for i, change := range p.Changes {
+		var err error
+		switch change.Type {
+		case ChangeTypeCreate, ChangeTypeUpdate:
+			err = writeFile(ctx, change.Path, change.data, aclOracle)
+		case ChangeTypeDelete:
+			err = change.Path.Remove(ctx)
+		default:
+			err = fmt.Errorf("unknown change type %q", change.Type)
+		}
+		if err == nil {
+			continue
+		}
+
+		err = fmt.Errorf("error restoring %s: %w", change.Path, err)
+		if rollbackErr := rollback(ctx, p.Changes[:i], aclOracle); rollbackErr != nil {
+			return errors.Join(err, fmt.Errorf("error reverting the restore, the state store is incomplete: %w", rollbackErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// rollback reverts the given changes, in reverse order.
+func rollback(ctx context.Context, changes []*Change, aclOracle vfs.ACLOracle) error {
+	var errs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		klog.Infof("reverting %s of %s", change.Type, change.Path)
+		var err error
+		switch change.Type {
+		case ChangeTypeCreate:
+			err = change.Path.Remove(ctx)
+		case ChangeTypeUpdate, ChangeTypeDelete:
+			err = writeFile(ctx, change.Path, change.previous, aclOracle)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", change.Path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func writeFile(ctx context.Context, p vfs.Path, data []byte, aclOracle vfs.ACLOracle) error {
+	acl, err := aclOracle(p)
+	if err != nil {
+		return err
+	}
+	return p.WriteFile(ctx, bytes.NewReader(data), acl)
+}
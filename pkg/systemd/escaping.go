@@ -75,3 +75,34 @@ func escapeArg(s string) string {
 
 	return b.String()
 }
+
+// EscapePath escapes a path like "systemd-escape --path", for example to name the mount unit of the path
+func EscapePath(path string) string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	path = strings.Join(parts, "/")
+
+	var b bytes.Buffer
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '/':
+			b.WriteByte('-')
+		case c == '.' && i == 0:
+			b.WriteString("\\x2e")
+		case ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c == ':' || c == '_' || c == '.':
+			b.WriteByte(c)
+		default:
+			b.WriteString("\\x")
+			b.WriteString(hex.EncodeToString([]byte{c}))
+		}
+	}
+	return b.String()
+}
@@ -41,3 +41,37 @@ func TestEscapeCommand(t *testing.T) {
 		}
 	}
 }
+
+func TestEscapePath(t *testing.T) {
+	tests := []struct {
+		path        string
+		expectedStr string
+	}{
+		{
+			path:        "/",
+			expectedStr: "-",
+		},
+		{
+			path:        "/var/lib/containerd",
+			expectedStr: "var-lib-containerd",
+		},
+		{
+			path:        "//var/log/",
+			expectedStr: "var-log",
+		},
+		{
+			path:        "/mnt/local-ssd/.data",
+			expectedStr: `mnt-local\x2dssd-.data`,
+		},
+		{
+			path:        "/.hidden",
+			expectedStr: `\x2ehidden`,
+		},
+	}
+	for _, test := range tests {
+		result := EscapePath(test.path)
+		if test.expectedStr != result {
+			t.Errorf("Expected %s, got %s", test.expectedStr, result)
+		}
+	}
+}
@@ -41,11 +41,13 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
 	"k8s.io/klog/v2"
 	kopsroot "k8s.io/kops"
+	"k8s.io/kops/cloudmock/aws/mockacm"
 	"k8s.io/kops/cloudmock/aws/mockautoscaling"
 	"k8s.io/kops/cloudmock/aws/mockec2"
 	"k8s.io/kops/cloudmock/aws/mockelb"
 	"k8s.io/kops/cloudmock/aws/mockelbv2"
 	"k8s.io/kops/cloudmock/aws/mockiam"
+	"k8s.io/kops/cloudmock/aws/mockkms"
 	"k8s.io/kops/cloudmock/aws/mockroute53"
 	gcemock "k8s.io/kops/cloudmock/gce"
 	"k8s.io/kops/cloudmock/openstack/mockblockstorage"
@@ -152,6 +154,13 @@ func (h *IntegrationTestHarness) SetupMockAWS() *awsup.MockAWSCloud {
 	cloud.MockSQS = mockSQS
 	mockEventBridge := &mockeventbridge.MockEventBridge{}
 	cloud.MockEventBridge = mockEventBridge
+	mockACM := &mockacm.MockACM{}
+	cloud.MockACM = mockACM
+	mockKMS := &mockkms.MockKMS{}
+	cloud.MockKMS = mockKMS
+
+	mockKMS.AddKey("arn:aws-test:kms:us-test-1:000000000000:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		`{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws-test:iam::123456789012:role/aws-service-role/autoscaling.amazonaws.com/AWSServiceRoleForAutoScaling"},"Action":["kms:CreateGrant","kms:Decrypt","kms:GenerateDataKey*"]}]}`)
 
 	mockRoute53.MockCreateZone(&route53types.HostedZone{
 		Id:   aws.String("/hostedzone/Z1AFAKE1ZON3YO"),
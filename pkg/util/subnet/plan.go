@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnet
+
+import (
+	"fmt"
+	"net"
+	"sort"
+)
+
+// PlanRequest is a subnet for which Plan allocates a CIDR
+type PlanRequest struct {
+	// Name identifies the subnet in the result of Plan
+	Name string
+	// Weight is the size of the subnet relative to the other subnets
+	Weight int
+	// CIDR is the existing CIDR of the subnet, if it is kept; it still takes its share of the total weight
+	CIDR *net.IPNet
+}
+
+// Plan allocates non-overlapping CIDRs within parent to the requests without a CIDR, skipping the reserved CIDRs.
+// Each subnet gets the largest power-of-two share of parent that does not exceed its share of the total weight,
+// so that the subnets fit in parent whatever their order.
+func Plan(parent *net.IPNet, requests []PlanRequest, reserved []*net.IPNet) (map[string]*net.IPNet, error) {
+	if parent.IP.To4() == nil {
+		return nil, fmt.Errorf("unexpected IP address type: %s", parent)
+	}
+	parentLength, bits := parent.Mask.Size()
+
+	totalWeight := 0
+	for _, request := range requests {
+		if request.Weight <= 0 {
+			return nil, fmt.Errorf("weight of subnet %q must be positive, got %d", request.Name, request.Weight)
+		}
+		totalWeight += request.Weight
+	}
+
+	type allocation struct {
+		request        PlanRequest
+		additionalBits uint
+	}
+	var allocations []*allocation
+	inUse := append([]*net.IPNet{}, reserved...)
+	planned := make(map[string]*net.IPNet)
+	for _, request := range requests {
+		if request.CIDR != nil {
+			planned[request.Name] = request.CIDR
+			inUse = append(inUse, request.CIDR)
+			continue
+		}
+
+		// The smallest number of additional bits such that weight / totalWeight >= 1 / 2^additionalBits
+		additionalBits := uint(0)
+		for request.Weight<<additionalBits < totalWeight {
+			additionalBits++
+		}
+		if parentLength+int(additionalBits) > bits {
+			return nil, fmt.Errorf("network %s is too small for subnet %q", parent, request.Name)
+		}
+		allocations = append(allocations, &allocation{request: request, additionalBits: additionalBits})
+	}
+
+	// Allocating the largest subnets first keeps the free ranges aligned
+	sort.SliceStable(allocations, func(i, j int) bool {
+		return allocations[i].additionalBits < allocations[j].additionalBits
+	})
+
+	for _, a := range allocations {
+		candidates, err := SplitInto(a.additionalBits, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		var found *net.IPNet
+		for _, candidate := range candidates {
+			overlapped := false
+			for _, r := range inUse {
+				if Overlap(r, candidate) {
+					overlapped = true
+					break
+				}
+			}
+			if !overlapped {
+				found = candidate
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("insufficient free addresses in network %s for subnet %q", parent, a.request.Name)
+		}
+
+		planned[a.request.Name] = found
+		inUse = append(inUse, found)
+	}
+
+	return planned, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subnet
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func Test_Plan(t *testing.T) {
+	tests := []struct {
+		parent   string
+		requests []PlanRequest
+		reserved []string
+		expected map[string]string
+		err      bool
+	}{
+		{
+			parent: "10.0.0.0/16",
+			requests: []PlanRequest{
+				{Name: "utility-a", Weight: 1},
+				{Name: "utility-b", Weight: 1},
+				{Name: "a", Weight: 4},
+				{Name: "b", Weight: 4},
+			},
+			expected: map[string]string{
+				"a":         "10.0.0.0/18",
+				"b":         "10.0.64.0/18",
+				"utility-a": "10.0.128.0/20",
+				"utility-b": "10.0.144.0/20",
+			},
+		},
+		{
+			parent: "10.0.0.0/16",
+			requests: []PlanRequest{
+				{Name: "utility-a", Weight: 1},
+				{Name: "utility-b", Weight: 1},
+				{Name: "utility-c", Weight: 1},
+				{Name: "a", Weight: 4},
+				{Name: "b", Weight: 4},
+				{Name: "c", Weight: 4},
+			},
+			expected: map[string]string{
+				"a":         "10.0.0.0/18",
+				"b":         "10.0.64.0/18",
+				"c":         "10.0.128.0/18",
+				"utility-a": "10.0.192.0/20",
+				"utility-b": "10.0.208.0/20",
+				"utility-c": "10.0.224.0/20",
+			},
+		},
+		{
+			parent: "10.0.0.0/16",
+			requests: []PlanRequest{
+				{Name: "a", Weight: 1},
+				{Name: "b", Weight: 1},
+			},
+			reserved: []string{"10.0.0.0/24"},
+			err:      true,
+		},
+		{
+			parent: "10.0.0.0/16",
+			requests: []PlanRequest{
+				{Name: "a", Weight: 1},
+				{Name: "b", Weight: 1},
+				{Name: "c", Weight: 1},
+			},
+			reserved: []string{"10.0.0.0/24"},
+			expected: map[string]string{
+				"a": "10.0.64.0/18",
+				"b": "10.0.128.0/18",
+				"c": "10.0.192.0/18",
+			},
+		},
+		{
+			parent: "10.0.0.0/16",
+			requests: []PlanRequest{
+				{Name: "a", Weight: 4},
+				{Name: "b", Weight: 4, CIDR: mustParseCIDR(t, "10.0.0.0/18")},
+				{Name: "utility-a", Weight: 1},
+				{Name: "utility-b", Weight: 1},
+			},
+			expected: map[string]string{
+				"a":         "10.0.64.0/18",
+				"b":         "10.0.0.0/18",
+				"utility-a": "10.0.128.0/20",
+				"utility-b": "10.0.144.0/20",
+			},
+		},
+		{
+			parent: "10.0.0.0/30",
+			requests: []PlanRequest{
+				{Name: "a", Weight: 1},
+				{Name: "b", Weight: 100},
+			},
+			err: true,
+		},
+		{
+			parent: "10.0.0.0/16",
+			requests: []PlanRequest{
+				{Name: "a", Weight: 0},
+			},
+			err: true,
+		},
+	}
+	for _, test := range tests {
+		_, parent, err := net.ParseCIDR(test.parent)
+		if err != nil {
+			t.Fatalf("error parsing parent cidr %q: %v", test.parent, err)
+		}
+		var reserved []*net.IPNet
+		for _, s := range test.reserved {
+			reserved = append(reserved, mustParseCIDR(t, s))
+		}
+
+		planned, err := Plan(parent, test.requests, reserved)
+		if test.err {
+			if err == nil {
+				t.Errorf("expected error planning %v in %s", test.requests, test.parent)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("error planning %v in %s: %v", test.requests, test.parent, err)
+		}
+
+		actual := make(map[string]string)
+		for name, cidr := range planned {
+			actual[name] = cidr.String()
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("unexpected plan: actual=%v, expected=%v", actual, test.expected)
+		}
+	}
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, cidr, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatalf("error parsing cidr %q: %v", s, err)
+	}
+	return cidr
+}
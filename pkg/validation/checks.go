@@ -0,0 +1,293 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/pager"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// The names of the built-in checks, in the order they run.
+const (
+	CheckDNS              = "dns"
+	CheckNodes            = "nodes"
+	CheckCriticalPods     = "critical-pods"
+	CheckControlPlanePods = "control-plane-pods"
+	CheckEtcd             = "etcd"
+	CheckEtcdHealth       = "etcd-health"
+	CheckCNI              = "cni"
+)
+
+// Check is a named validation of the cluster, which can be selected or skipped by name.
+type Check interface {
+	// Name identifies the check in the selections and in the results.
+	Name() string
+	// Description is a short description of what the check validates.
+	Description() string
+	// Run adds the failures it finds to the result. An error means the check could not run.
+	Run(ctx context.Context, cluster *CheckCluster, result *ValidationCluster) error
+}
+
+// CheckCluster is the cluster being validated, as seen by the checks.
+// It caches the state that several checks need, so that it is fetched once per validation.
+type CheckCluster struct {
+	Cluster        *kops.Cluster
+	Cloud          fi.Cloud
+	InstanceGroups []*kops.InstanceGroup
+	// Host is the URL of the Kubernetes API server.
+	Host      string
+	K8sClient kubernetes.Interface
+
+	nodes *nodeState
+	pods  []v1.Pod
+}
+
+// nodeState is the result of the validation of the nodes, that the checks of the pods depend on.
+type nodeState struct {
+	// result holds the failures of the nodes and their status.
+	result                   *ValidationCluster
+	readyNodes               []v1.Node
+	scalingDownNodes         []v1.Node
+	nodeInstanceGroupMapping map[string]*kops.InstanceGroup
+}
+
+// nodeState matches the nodes with the instances of the cloud groups, and validates them.
+func (c *CheckCluster) nodeState(ctx context.Context) (*nodeState, error) {
+	if c.nodes != nil {
+		return c.nodes, nil
+	}
+
+	nodeList, err := c.K8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %v", err)
+	}
+
+	warnUnmatched := false
+	cloudGroups, err := c.Cloud.GetCloudGroups(c.Cluster, c.InstanceGroups, warnUnmatched, nodeList.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &nodeState{result: &ValidationCluster{}}
+	state.readyNodes, state.scalingDownNodes, state.nodeInstanceGroupMapping = state.result.validateNodes(cloudGroups, c.InstanceGroups)
+	c.nodes = state
+	return state, nil
+}
+
+// listPods returns all the pods of the cluster.
+func (c *CheckCluster) listPods(ctx context.Context) ([]v1.Pod, error) {
+	if c.pods != nil {
+		return c.pods, nil
+	}
+
+	pods := []v1.Pod{}
+	err := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		return c.K8sClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
+	})).EachListItem(ctx, metav1.ListOptions{}, func(obj runtime.Object) error {
+		pods = append(pods, *obj.(*v1.Pod))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get pod health for %q: error listing Pods: %v", c.Cluster.Name, err)
+	}
+	c.pods = pods
+	return pods, nil
+}
+
+// errStopValidation is returned by a check whose failures make the following checks meaningless.
+var errStopValidation = errors.New("validation stopped")
+
+// CheckResult is the outcome of a check.
+type CheckResult struct {
+	Name string `json:"name"`
+	// Skipped is true if the check was not selected, or a previous check stopped the validation.
+	Skipped bool `json:"skipped,omitempty"`
+	// Failures is the number of failures the check found.
+	Failures int `json:"failures,omitempty"`
+	// Warnings is the number of warnings the check found.
+	Warnings int `json:"warnings,omitempty"`
+}
+
+var (
+	checks      []Check
+	checksMutex sync.Mutex
+)
+
+// RegisterCheck adds the check to the checks of the cluster validation, after the checks already registered.
+// A check with the name of a registered check replaces it.
+func RegisterCheck(check Check) {
+	checksMutex.Lock()
+	defer checksMutex.Unlock()
+
+	for i, existing := range checks {
+		if existing.Name() == check.Name() {
+			checks[i] = check
+			return
+		}
+	}
+	checks = append(checks, check)
+}
+
+// Checks returns the registered checks, in the order they run.
+func Checks() []Check {
+	checksMutex.Lock()
+	defer checksMutex.Unlock()
+
+	return slices.Clone(checks)
+}
+
+// CheckNames returns the names of the registered checks, in the order they run.
+func CheckNames() []string {
+	var names []string
+	for _, check := range Checks() {
+		names = append(names, check.Name())
+	}
+	return names
+}
+
+// checkSelection selects the checks that a validation runs.
+type checkSelection struct {
+	// checks, if not empty, are the only checks that run.
+	checks []string
+	// skipChecks are the checks that do not run.
+	skipChecks []string
+}
+
+// validate returns an error if the selection names checks that are not registered.
+func (s *checkSelection) validate() error {
+	known := CheckNames()
+	for _, name := range append(slices.Clone(s.checks), s.skipChecks...) {
+		if !slices.Contains(known, name) {
+			return fmt.Errorf("unknown validation check %q, expected one of %s", name, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+func (s *checkSelection) selected(name string) bool {
+	if len(s.checks) != 0 && !slices.Contains(s.checks, name) {
+		return false
+	}
+	return !slices.Contains(s.skipChecks, name)
+}
+
+// runChecks runs the selected checks in order, recording the check of each failure.
+// A check returning errStopValidation skips the checks that follow it.
+func (s *checkSelection) runChecks(ctx context.Context, cluster *CheckCluster) (*ValidationCluster, error) {
+	validation := &ValidationCluster{}
+	stopped := false
+	for _, check := range Checks() {
+		name := check.Name()
+		if stopped || !s.selected(name) {
+			validation.Checks = append(validation.Checks, &CheckResult{Name: name, Skipped: true})
+			continue
+		}
+
+		failures, ignored, warnings := len(validation.Failures), len(validation.Ignored), len(validation.Warnings)
+		err := check.Run(ctx, cluster, validation)
+		if err != nil && !errors.Is(err, errStopValidation) {
+			return nil, err
+		}
+		for _, failure := range validation.Failures[failures:] {
+			failure.Check = name
+		}
+		for _, failure := range validation.Ignored[ignored:] {
+			failure.Check = name
+		}
+		for _, warning := range validation.Warnings[warnings:] {
+			warning.Check = name
+		}
+		validation.Checks = append(validation.Checks, &CheckResult{
+			Name:     name,
+			Failures: len(validation.Failures) - failures,
+			Warnings: len(validation.Warnings) - warnings,
+		})
+		stopped = err != nil
+	}
+	return validation, nil
+}
+
+// checkFunc is a built-in check.
+type checkFunc struct {
+	name        string
+	description string
+	run         func(ctx context.Context, cluster *CheckCluster, result *ValidationCluster) error
+}
+
+var _ Check = &checkFunc{}
+
+func (c *checkFunc) Name() string {
+	return c.name
+}
+
+func (c *checkFunc) Description() string {
+	return c.description
+}
+
+func (c *checkFunc) Run(ctx context.Context, cluster *CheckCluster, result *ValidationCluster) error {
+	return c.run(ctx, cluster, result)
+}
+
+func init() {
+	RegisterCheck(&checkFunc{
+		name:        CheckDNS,
+		description: "The API DNS name points at the control plane instead of the placeholder address.",
+		run:         checkDNS,
+	})
+	RegisterCheck(&checkFunc{
+		name:        CheckNodes,
+		description: "The instance groups have their instances, and their nodes joined the cluster and are ready.",
+		run:         checkNodes,
+	})
+	RegisterCheck(&checkFunc{
+		name:        CheckCriticalPods,
+		description: "The pods with a critical priority are running and ready.",
+		run:         checkCriticalPods,
+	})
+	RegisterCheck(&checkFunc{
+		name:        CheckControlPlanePods,
+		description: "The control plane nodes run the API server, controller manager and scheduler.",
+		run:         checkControlPlanePods,
+	})
+	RegisterCheck(&checkFunc{
+		name:        CheckEtcd,
+		description: "The control plane nodes run a ready etcd-manager pod for each etcd cluster, with a quorum of members.",
+		run:         checkEtcd,
+	})
+	RegisterCheck(&checkFunc{
+		name:        CheckEtcdHealth,
+		description: "The etcd members are healthy, have a leader, have no alarms, and their databases are within their quota.",
+		run:         checkEtcdHealth,
+	})
+	RegisterCheck(&checkFunc{
+		name:        CheckCNI,
+		description: "The daemonsets of the networking plugin have all their pods ready.",
+		run:         checkCNI,
+	})
+}
@@ -0,0 +1,320 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+// testValidateChecks validates a cluster with a ready control plane node at 1.2.3.4, running its static pods.
+func testValidateChecks(t *testing.T, spec kopsapi.ClusterSpec, objects []runtime.Object, opts ...ClusterValidatorOption) (*ValidationCluster, error) {
+	cluster := &kopsapi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "testcluster.k8s.local"},
+		Spec:       spec,
+	}
+
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "master-1a",
+			Labels: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+		},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{{Address: "1.2.3.4"}},
+			Conditions: []v1.NodeCondition{
+				{Type: "Ready", Status: v1.ConditionTrue},
+			},
+		},
+	}
+	groups := map[string]*cloudinstances.CloudInstanceGroup{
+		"master-1": {
+			InstanceGroup: &kopsapi.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "master-1"},
+				Spec:       kopsapi.InstanceGroupSpec{Role: kopsapi.InstanceGroupRoleControlPlane},
+			},
+			MinSize:    1,
+			TargetSize: 1,
+			Ready:      []*cloudinstances.CloudInstance{{ID: "i-00001", Node: node}},
+		},
+	}
+	instanceGroups := []kopsapi.InstanceGroup{*groups["master-1"].InstanceGroup}
+
+	var pods []map[string]string
+	for _, app := range masterStaticPods {
+		pods = append(pods, map[string]string{
+			"name":    app,
+			"ready":   "true",
+			"k8s-app": app,
+			"phase":   string(v1.PodRunning),
+			"hostip":  "1.2.3.4",
+		})
+	}
+	objects = append(append(makePodList(pods), node), objects...)
+
+	mockcloud := BuildMockCloud(t, groups, cluster, instanceGroups)
+	validator, err := NewClusterValidator(cluster, mockcloud, &kopsapi.InstanceGroupList{Items: instanceGroups}, "https://api.testcluster.k8s.local", fake.NewSimpleClientset(objects...), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return validator.Validate()
+}
+
+// failureMessages returns the checks and messages of the failures.
+func failureMessages(failures []*ValidationError) []string {
+	var messages []string
+	for _, failure := range failures {
+		messages = append(messages, failure.Check+": "+failure.Message)
+	}
+	return messages
+}
+
+func TestValidateSelectedChecks(t *testing.T) {
+	v, err := testValidateChecks(t, kopsapi.ClusterSpec{}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, v.Failures)
+	assert.Equal(t, []*CheckResult{
+		{Name: CheckDNS},
+		{Name: CheckNodes},
+		{Name: CheckCriticalPods},
+		{Name: CheckControlPlanePods},
+		{Name: CheckEtcd},
+		{Name: CheckEtcdHealth},
+		{Name: CheckCNI},
+	}, v.Checks)
+
+	spec := kopsapi.ClusterSpec{
+		Networking: kopsapi.NetworkingSpec{Calico: &kopsapi.CalicoNetworkingSpec{}},
+	}
+	v, err = testValidateChecks(t, spec, nil, WithChecks([]string{CheckNodes, CheckCNI}), WithSkippedChecks([]string{CheckCNI}))
+	require.NoError(t, err)
+	assert.Empty(t, v.Failures)
+	assert.Equal(t, []*CheckResult{
+		{Name: CheckDNS, Skipped: true},
+		{Name: CheckNodes},
+		{Name: CheckCriticalPods, Skipped: true},
+		{Name: CheckControlPlanePods, Skipped: true},
+		{Name: CheckEtcd, Skipped: true},
+		{Name: CheckEtcdHealth, Skipped: true},
+		{Name: CheckCNI, Skipped: true},
+	}, v.Checks)
+	assert.Len(t, v.Nodes, 1)
+
+	_, err = testValidateChecks(t, kopsapi.ClusterSpec{}, nil, WithSkippedChecks([]string{"nodes", "pods"}))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `unknown validation check "pods"`)
+	}
+}
+
+func TestValidateEtcd(t *testing.T) {
+	spec := kopsapi.ClusterSpec{
+		EtcdClusters: []kopsapi.EtcdClusterSpec{
+			{Name: "main", Members: []kopsapi.EtcdMemberSpec{{Name: "a"}}},
+			{Name: "events", Members: []kopsapi.EtcdMemberSpec{{Name: "a"}}},
+		},
+	}
+	pods := makePodList([]map[string]string{
+		{
+			"name":    "etcd-manager-main-master-1a",
+			"ready":   "true",
+			"k8s-app": "etcd-manager-main",
+			"phase":   string(v1.PodRunning),
+			"hostip":  "1.2.3.4",
+		},
+		{
+			"name":    "etcd-manager-events-master-1a",
+			"ready":   "false",
+			"k8s-app": "etcd-manager-events",
+			"phase":   string(v1.PodRunning),
+			"hostip":  "1.2.3.4",
+		},
+	})
+
+	v, err := testValidateChecks(t, spec, pods, WithChecks([]string{CheckEtcd}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`etcd: control-plane node "master-1a" is missing a ready etcd-manager-events pod`,
+		`etcd: etcd cluster "events" has 0 of 1 members ready, fewer than its quorum of 1`,
+	}, failureMessages(v.Failures))
+	assert.Equal(t, &CheckResult{Name: CheckEtcd, Failures: 2}, v.Checks[4])
+}
+
+func TestValidateCNI(t *testing.T) {
+	spec := kopsapi.ClusterSpec{
+		Networking: kopsapi.NetworkingSpec{
+			AmazonVPC: &kopsapi.AmazonVPCNetworkingSpec{},
+			Cilium:    &kopsapi.CiliumNetworkingSpec{},
+		},
+	}
+	daemonSet := func(name string, desired, ready int32) runtime.Object {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "kube-system"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, NumberReady: ready},
+		}
+	}
+
+	v, err := testValidateChecks(t, spec, []runtime.Object{daemonSet("aws-node", 3, 2)}, WithChecks([]string{CheckCNI}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`cni: networking daemonset "aws-node" has 2 of 3 pods ready`,
+		`cni: networking daemonset "cilium" is missing`,
+	}, failureMessages(v.Failures))
+
+	v, err = testValidateChecks(t, spec, []runtime.Object{daemonSet("aws-node", 3, 3), daemonSet("cilium", 3, 3)}, WithChecks([]string{CheckCNI}))
+	require.NoError(t, err)
+	assert.Empty(t, v.Failures)
+}
+
+func TestValidationJUnit(t *testing.T) {
+	v := &ValidationCluster{
+		Failures: []*ValidationError{
+			{Kind: "Node", Name: "node-1a", Message: `node "node-1a" of role "node" is not ready`, Check: CheckNodes},
+		},
+		Ignored: []*ValidationError{
+			{Kind: "Pod", Name: "kube-system/pod1", Message: `system-cluster-critical pod "pod1" is pending`, Check: CheckCriticalPods},
+		},
+		Checks: []*CheckResult{
+			{Name: CheckDNS, Skipped: true},
+			{Name: CheckNodes, Failures: 1},
+			{Name: CheckCriticalPods},
+		},
+	}
+
+	var b bytes.Buffer
+	require.NoError(t, v.WriteJUnit(&b, "kops-validate-cluster"))
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="kops-validate-cluster" tests="3" failures="1" skipped="1">
+  <testcase name="dns" classname="kops-validate-cluster">
+    <skipped message="check not run"></skipped>
+  </testcase>
+  <testcase name="nodes" classname="kops-validate-cluster">
+    <failure message="1 validation failures">Node node-1a: node &#34;node-1a&#34; of role &#34;node&#34; is not ready&#xA;</failure>
+  </testcase>
+  <testcase name="critical-pods" classname="kops-validate-cluster">
+    <system-out>Pod kube-system/pod1: system-cluster-critical pod &#34;pod1&#34; is pending&#xA;</system-out>
+  </testcase>
+</testsuite>
+`
+	assert.Equal(t, expected, b.String())
+}
+
+// fakeResponse is the response of a proxied request to a pod.
+type fakeResponse struct {
+	body string
+	err  error
+}
+
+func (r *fakeResponse) DoRaw(ctx context.Context) ([]byte, error) {
+	return []byte(r.body), r.err
+}
+
+func (r *fakeResponse) Stream(ctx context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(r.body)), r.err
+}
+
+func TestValidateEtcdHealth(t *testing.T) {
+	spec := kopsapi.ClusterSpec{
+		EtcdClusters: []kopsapi.EtcdClusterSpec{
+			{
+				Name:    "main",
+				Members: []kopsapi.EtcdMemberSpec{{Name: "a"}},
+				Manager: &kopsapi.EtcdManagerSpec{ListenMetricsURLs: []string{"http://0.0.0.0:8081"}},
+			},
+			{
+				Name:    "events",
+				Members: []kopsapi.EtcdMemberSpec{{Name: "a"}},
+				Manager: &kopsapi.EtcdManagerSpec{ListenMetricsURLs: []string{"http://0.0.0.0:8082"}},
+			},
+			{
+				Name:    "cilium",
+				Members: []kopsapi.EtcdMemberSpec{{Name: "a"}},
+			},
+		},
+	}
+	var objects []runtime.Object
+	for _, name := range []string{"main", "events", "cilium"} {
+		objects = append(objects, makePodList([]map[string]string{
+			{
+				"name":    "etcd-manager-" + name + "-master-1a",
+				"ready":   "true",
+				"k8s-app": "etcd-manager-" + name,
+				"phase":   string(v1.PodRunning),
+				"hostip":  "1.2.3.4",
+			},
+		})...)
+	}
+
+	responses := map[string]*fakeResponse{
+		"etcd-manager-main-master-1a:8081/health": {body: `{"health":"true","reason":""}`},
+		"etcd-manager-main-master-1a:8081/metrics": {body: `# TYPE etcd_server_has_leader gauge
+etcd_server_has_leader 1
+# TYPE etcd_server_leader_changes_seen_total counter
+etcd_server_leader_changes_seen_total 7
+# TYPE etcd_mvcc_db_total_size_in_bytes gauge
+etcd_mvcc_db_total_size_in_bytes 1.8253611008e+09
+# TYPE etcd_mvcc_db_total_size_in_use_in_bytes gauge
+etcd_mvcc_db_total_size_in_use_in_bytes 2.68435456e+08
+# TYPE etcd_server_quota_backend_bytes gauge
+etcd_server_quota_backend_bytes 2.147483648e+09
+# TYPE etcd_network_peer_round_trip_time_seconds histogram
+etcd_network_peer_round_trip_time_seconds_bucket{To="2b4ff6d2a3c1e9f0",le="+Inf"} 4
+etcd_network_peer_round_trip_time_seconds_sum{To="2b4ff6d2a3c1e9f0"} 0.04
+etcd_network_peer_round_trip_time_seconds_count{To="2b4ff6d2a3c1e9f0"} 4
+etcd_network_peer_round_trip_time_seconds_bucket{To="8e9e05c52164694d",le="+Inf"} 4
+etcd_network_peer_round_trip_time_seconds_sum{To="8e9e05c52164694d"} 1
+etcd_network_peer_round_trip_time_seconds_count{To="8e9e05c52164694d"} 4
+`},
+		"etcd-manager-events-master-1a:8082/health": {body: `{"health":"false","reason":"ALARM NOSPACE"}`, err: errors.New("the server is currently unable to handle the request")},
+	}
+	v, err := testValidateChecks(t, spec, objects, WithChecks([]string{CheckEtcdHealth}), func(v *clusterValidatorImpl) {
+		v.k8sClient.(*fake.Clientset).AddProxyReactor("pods", func(action k8stesting.Action) (bool, rest.ResponseWrapper, error) {
+			proxy := action.(k8stesting.ProxyGetAction)
+			response := responses[proxy.GetName()+":"+proxy.GetPort()+proxy.GetPath()]
+			if response == nil {
+				response = &fakeResponse{err: errors.New("not found")}
+			}
+			return true, response, nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`etcd-health: member of etcd cluster "events" is unhealthy: ALARM NOSPACE`,
+		`etcd-health: etcd cluster "events" has 0 of 1 members healthy, fewer than its quorum of 1`,
+	}, failureMessages(v.Failures))
+	assert.Equal(t, []string{
+		`etcd-health: database of the member of etcd cluster "main" uses 85% of its quota of 2048MiB`,
+		`etcd-health: database of the member of etcd cluster "main" is 1741MiB with 256MiB in use, and would shrink if defragmented`,
+		`etcd-health: member of etcd cluster "main" has seen 7 leader changes since it started`,
+		`etcd-health: member of etcd cluster "main" has an average round-trip time of 250ms to peer 8e9e05c52164694d, more than its heartbeat interval of 100ms; raise heartbeatInterval and leaderElectionTimeout`,
+	}, failureMessages(v.Warnings))
+	assert.Equal(t, &CheckResult{Name: CheckEtcdHealth, Failures: 2, Warnings: 4}, v.Checks[5])
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// junitTestSuite is the root element of a JUnit XML report, as read by CI systems.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes the result as a JUnit XML report, with a test case for each check that fails if the check
+// found failures. The warnings, and the failures ignored during a cluster-autoscaler scale-down, are in the output of their check.
+func (v *ValidationCluster) WriteJUnit(w io.Writer, suiteName string) error {
+	suite := &junitTestSuite{
+		Name:  suiteName,
+		Tests: len(v.Checks),
+	}
+
+	for _, check := range v.Checks {
+		tc := junitTestCase{
+			Name:      check.Name,
+			ClassName: suiteName,
+			SystemOut: formatFailures(v.Warnings, check.Name) + formatFailures(v.Ignored, check.Name),
+		}
+		switch {
+		case check.Skipped:
+			suite.Skipped++
+			tc.Skipped = &junitSkipped{Message: "check not run"}
+		case check.Failures != 0:
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: fmt.Sprintf("%d validation failures", check.Failures),
+				Text:    formatFailures(v.Failures, check.Name),
+			}
+		}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suite); err != nil {
+		return fmt.Errorf("error encoding junit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// formatFailures returns the failures found by the check, one per line.
+func formatFailures(failures []*ValidationError, check string) string {
+	var b strings.Builder
+	for _, failure := range failures {
+		if failure.Check == check {
+			fmt.Fprintf(&b, "%s %s: %s\n", failure.Kind, failure.Name, failure.Message)
+		}
+	}
+	return b.String()
+}
@@ -21,6 +21,19 @@ import (
 	"k8s.io/klog/v2"
 )
 
+// ClusterAutoscalerScaleDownTaint is the taint cluster-autoscaler puts on a node it is removing.
+const ClusterAutoscalerScaleDownTaint = "ToBeDeletedByClusterAutoscaler"
+
+// isScalingDown returns true if cluster-autoscaler is removing the node.
+func isScalingDown(node *v1.Node) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == ClusterAutoscalerScaleDownTaint {
+			return true
+		}
+	}
+	return false
+}
+
 func getNodeReadyStatus(node *v1.Node) v1.ConditionStatus {
 	cond := findNodeCondition(node, v1.NodeReady)
 	if cond != nil {
@@ -24,13 +24,13 @@ import (
 	"sort"
 	"strings"
 
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/tools/pager"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
@@ -41,7 +41,16 @@ import (
 type ValidationCluster struct {
 	Failures []*ValidationError `json:"failures,omitempty"`
 
+	// Ignored holds the failures caused by cluster-autoscaler removing nodes; they don't fail validation.
+	Ignored []*ValidationError `json:"ignored,omitempty"`
+
+	// Warnings holds the problems that do not fail validation, but need attention.
+	Warnings []*ValidationError `json:"warnings,omitempty"`
+
 	Nodes []*ValidationNode `json:"nodes,omitempty"`
+
+	// Checks holds the outcome of each check, in the order they ran.
+	Checks []*CheckResult `json:"checks,omitempty"`
 }
 
 // ValidationError holds a validation failure
@@ -51,6 +60,8 @@ type ValidationError struct {
 	Message string `json:"message,omitempty"`
 	// The InstanceGroup field is used to indicate which instance group this validation error is coming from
 	InstanceGroup *kops.InstanceGroup `json:"instanceGroup,omitempty"`
+	// Check is the name of the check that found the failure.
+	Check string `json:"check,omitempty"`
 }
 
 type ClusterValidator interface {
@@ -64,12 +75,39 @@ type clusterValidatorImpl struct {
 	instanceGroups []*kops.InstanceGroup
 	host           string
 	k8sClient      kubernetes.Interface
+
+	selection checkSelection
+}
+
+// ClusterValidatorOption configures the cluster validator built by NewClusterValidator.
+type ClusterValidatorOption func(v *clusterValidatorImpl)
+
+// WithChecks runs only the named checks; all the checks run if it is empty.
+func WithChecks(names []string) ClusterValidatorOption {
+	return func(v *clusterValidatorImpl) {
+		v.selection.checks = names
+	}
+}
+
+// WithSkippedChecks does not run the named checks.
+func WithSkippedChecks(names []string) ClusterValidatorOption {
+	return func(v *clusterValidatorImpl) {
+		v.selection.skipChecks = names
+	}
 }
 
 func (v *ValidationCluster) addError(failure *ValidationError) {
 	v.Failures = append(v.Failures, failure)
 }
 
+func (v *ValidationCluster) addIgnored(failure *ValidationError) {
+	v.Ignored = append(v.Ignored, failure)
+}
+
+func (v *ValidationCluster) addWarning(warning *ValidationError) {
+	v.Warnings = append(v.Warnings, warning)
+}
+
 // ValidationNode represents the validation status for a node
 type ValidationNode struct {
 	Name     string             `json:"name,omitempty"`
@@ -77,15 +115,20 @@ type ValidationNode struct {
 	Role     string             `json:"role,omitempty"`
 	Hostname string             `json:"hostname,omitempty"`
 	Status   v1.ConditionStatus `json:"status,omitempty"`
+	// ScalingDown is true if cluster-autoscaler is removing the node.
+	ScalingDown bool `json:"scalingDown,omitempty"`
 }
 
+// lookupHost resolves DNS names; tests replace it.
+var lookupHost = net.LookupHost
+
 // hasPlaceHolderIP checks if the API DNS has been updated.
 func hasPlaceHolderIP(host string) (string, error) {
 	apiAddr, err := url.Parse(host)
 	if err != nil {
 		return "", fmt.Errorf("unable to parse Kubernetes cluster API URL: %v", err)
 	}
-	hostAddrs, err := net.LookupHost(apiAddr.Hostname())
+	hostAddrs, err := lookupHost(apiAddr.Hostname())
 	if err != nil {
 		return "", fmt.Errorf("unable to resolve Kubernetes cluster API URL dns: %v", err)
 	}
@@ -100,7 +143,7 @@ func hasPlaceHolderIP(host string) (string, error) {
 	return "", nil
 }
 
-func NewClusterValidator(cluster *kops.Cluster, cloud fi.Cloud, instanceGroupList *kops.InstanceGroupList, host string, k8sClient kubernetes.Interface) (ClusterValidator, error) {
+func NewClusterValidator(cluster *kops.Cluster, cloud fi.Cloud, instanceGroupList *kops.InstanceGroupList, host string, k8sClient kubernetes.Interface, opts ...ClusterValidatorOption) (ClusterValidator, error) {
 	var instanceGroups []*kops.InstanceGroup
 
 	for i := range instanceGroupList.Items {
@@ -112,65 +155,84 @@ func NewClusterValidator(cluster *kops.Cluster, cloud fi.Cloud, instanceGroupLis
 		return nil, fmt.Errorf("no InstanceGroup objects found")
 	}
 
-	return &clusterValidatorImpl{
+	v := &clusterValidatorImpl{
 		cluster:        cluster,
 		cloud:          cloud,
 		instanceGroups: instanceGroups,
 		host:           host,
 		k8sClient:      k8sClient,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if err := v.selection.validate(); err != nil {
+		return nil, err
+	}
+	return v, nil
 }
 
 func (v *clusterValidatorImpl) Validate() (*ValidationCluster, error) {
-	ctx := context.TODO()
-
-	validation := &ValidationCluster{}
+	return v.selection.runChecks(context.TODO(), &CheckCluster{
+		Cluster:        v.cluster,
+		Cloud:          v.cloud,
+		InstanceGroups: v.instanceGroups,
+		Host:           v.host,
+		K8sClient:      v.k8sClient,
+	})
+}
 
+// checkDNS reports a failure if the API DNS name still points at the placeholder address, and then stops the
+// validation, as the Kubernetes API is not reachable.
+func checkDNS(ctx context.Context, c *CheckCluster, validation *ValidationCluster) error {
 	// Do not use if we are running gossip or without dns
-	if !v.cluster.UsesLegacyGossip() && !v.cluster.UsesNoneDNS() {
-		dnsProvider := kops.ExternalDNSProviderDNSController
-		if v.cluster.Spec.ExternalDNS != nil && v.cluster.Spec.ExternalDNS.Provider == kops.ExternalDNSProviderExternalDNS {
-			dnsProvider = kops.ExternalDNSProviderExternalDNS
-		}
-
-		hasPlaceHolderIPAddress, err := hasPlaceHolderIP(v.host)
-		if err != nil {
-			return nil, err
-		}
-
-		if hasPlaceHolderIPAddress != "" {
-			message := fmt.Sprintf("Validation Failed\n\n"+
-				"The %[1]v Kubernetes deployment has not updated the Kubernetes cluster's API DNS entry to the correct IP address."+
-				"  The API DNS IP address is the placeholder address that kops creates: %[2]v."+
-				"  Please wait about 5-10 minutes for a control plane node to start, %[1]v to launch, and DNS to propagate."+
-				"  The protokube container and %[1]v deployment logs may contain more diagnostic information."+
-				"  Etcd and the API DNS entries must be updated for a kops Kubernetes cluster to start.", dnsProvider, hasPlaceHolderIPAddress)
-			validation.addError(&ValidationError{
-				Kind:    "dns",
-				Name:    "apiserver",
-				Message: message,
-			})
-			return validation, nil
-		}
+	if c.Cluster.UsesLegacyGossip() || c.Cluster.UsesNoneDNS() {
+		return nil
 	}
 
-	nodeList, err := v.k8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error listing nodes: %v", err)
+	dnsProvider := kops.ExternalDNSProviderDNSController
+	if c.Cluster.Spec.ExternalDNS != nil && c.Cluster.Spec.ExternalDNS.Provider == kops.ExternalDNSProviderExternalDNS {
+		dnsProvider = kops.ExternalDNSProviderExternalDNS
 	}
 
-	warnUnmatched := false
-	cloudGroups, err := v.cloud.GetCloudGroups(v.cluster, v.instanceGroups, warnUnmatched, nodeList.Items)
+	hasPlaceHolderIPAddress, err := hasPlaceHolderIP(c.Host)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	readyNodes, nodeInstanceGroupMapping := validation.validateNodes(cloudGroups, v.instanceGroups)
 
-	if err := validation.collectPodFailures(ctx, v.k8sClient, readyNodes, nodeInstanceGroupMapping); err != nil {
-		return nil, fmt.Errorf("cannot get pod health for %q: %v", v.cluster.Name, err)
+	if hasPlaceHolderIPAddress != "" {
+		message := fmt.Sprintf("Validation Failed\n\n"+
+			"The %[1]v Kubernetes deployment has not updated the Kubernetes cluster's API DNS entry to the correct IP address."+
+			"  The API DNS IP address is the placeholder address that kops creates: %[2]v."+
+			"  Please wait about 5-10 minutes for a control plane node to start, %[1]v to launch, and DNS to propagate."+
+			"  The protokube container and %[1]v deployment logs may contain more diagnostic information."+
+			"  Etcd and the API DNS entries must be updated for a kops Kubernetes cluster to start.", dnsProvider, hasPlaceHolderIPAddress)
+		validation.addError(&ValidationError{
+			Kind:    "dns",
+			Name:    "apiserver",
+			Message: message,
+		})
+		return errStopValidation
 	}
+	return nil
+}
 
-	return validation, nil
+// checkNodes reports the instances that have not joined the cluster, the nodes that are not ready,
+// and the instance groups that do not have their instances.
+func checkNodes(ctx context.Context, c *CheckCluster, validation *ValidationCluster) error {
+	state, err := c.nodeState(ctx)
+	if err != nil {
+		return err
+	}
+	for _, failure := range state.result.Failures {
+		copied := *failure
+		validation.addError(&copied)
+	}
+	for _, failure := range state.result.Ignored {
+		copied := *failure
+		validation.addIgnored(&copied)
+	}
+	validation.Nodes = append(validation.Nodes, state.result.Nodes...)
+	return nil
 }
 
 var masterStaticPods = []string{
@@ -179,42 +241,128 @@ var masterStaticPods = []string{
 	"kube-scheduler",
 }
 
-func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kubernetes.Interface, nodes []v1.Node,
-	nodeInstanceGroupMapping map[string]*kops.InstanceGroup,
-) error {
-	masterWithoutPod := map[string]map[string]bool{}
+// checkControlPlanePods reports the ready control plane nodes that do not run one of masterStaticPods.
+func checkControlPlanePods(ctx context.Context, c *CheckCluster, validation *ValidationCluster) error {
+	state, err := c.nodeState(ctx)
+	if err != nil {
+		return err
+	}
+	pods, err := c.listPods(ctx)
+	if err != nil {
+		return err
+	}
+	validation.collectControlPlanePodFailures(pods, state.readyNodes, state.nodeInstanceGroupMapping)
+	return nil
+}
+
+// checkCriticalPods reports the pods with a critical priority that are not running and ready.
+func checkCriticalPods(ctx context.Context, c *CheckCluster, validation *ValidationCluster) error {
+	state, err := c.nodeState(ctx)
+	if err != nil {
+		return err
+	}
+	pods, err := c.listPods(ctx)
+	if err != nil {
+		return err
+	}
+	if err := validation.collectPodFailures(ctx, c.K8sClient, pods, state.readyNodes, state.scalingDownNodes, state.nodeInstanceGroupMapping); err != nil {
+		return fmt.Errorf("cannot get pod health for %q: %v", c.Cluster.Name, err)
+	}
+	return nil
+}
+
+// nodesByAddress maps the addresses of the nodes to their names.
+func nodesByAddress(nodes []v1.Node) map[string]string {
 	nodeByAddress := map[string]string{}
+	for _, node := range nodes {
+		for _, nodeAddress := range node.Status.Addresses {
+			nodeByAddress[nodeAddress.Address] = node.Name
+		}
+	}
+	return nodeByAddress
+}
+
+// isControlPlaneNode returns true if the node has the control plane role.
+func isControlPlaneNode(node *v1.Node) bool {
+	_, found := node.GetLabels()["node-role.kubernetes.io/control-plane"]
+	return found
+}
+
+func (v *ValidationCluster) collectControlPlanePodFailures(pods []v1.Pod, nodes []v1.Node, nodeInstanceGroupMapping map[string]*kops.InstanceGroup) {
+	masterWithoutPod := map[string]map[string]bool{}
+	nodeByAddress := nodesByAddress(nodes)
 
 	for _, node := range nodes {
-		labels := node.GetLabels()
-		if _, found := labels["node-role.kubernetes.io/control-plane"]; found {
+		if isControlPlaneNode(&node) {
 			masterWithoutPod[node.Name] = map[string]bool{}
 			for _, pod := range masterStaticPods {
 				masterWithoutPod[node.Name][pod] = true
 			}
 		}
-
-		for _, nodeAddress := range node.Status.Addresses {
-			nodeByAddress[nodeAddress.Address] = node.Name
-		}
 	}
 
-	err := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
-		return client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, opts)
-	})).EachListItem(context.TODO(), metav1.ListOptions{}, func(obj runtime.Object) error {
-		pod := obj.(*v1.Pod)
-
+	for i := range pods {
+		pod := &pods[i]
 		app := pod.GetLabels()["k8s-app"]
 		if pod.Namespace == "kube-system" && masterWithoutPod[nodeByAddress[pod.Status.HostIP]][app] {
 			delete(masterWithoutPod[nodeByAddress[pod.Status.HostIP]], app)
 		}
+	}
+
+	for node, nodeMap := range masterWithoutPod {
+		for app := range nodeMap {
+			v.addError(&ValidationError{
+				Kind:          "Node",
+				Name:          node,
+				Message:       fmt.Sprintf("control-plane node %q is missing %s pod", node, app),
+				InstanceGroup: nodeInstanceGroupMapping[node],
+			})
+		}
+	}
+}
+
+func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kubernetes.Interface, pods []v1.Pod, nodes []v1.Node, scalingDownNodes []v1.Node,
+	nodeInstanceGroupMapping map[string]*kops.InstanceGroup,
+) error {
+	nodeByAddress := nodesByAddress(nodes)
+
+	// While cluster-autoscaler is removing nodes, pods on those nodes may be waiting for their
+	// PodDisruptionBudget to allow eviction, and pods it has evicted wait to be scheduled elsewhere.
+	// Those failures are part of a normal scale-down, so they don't fail validation.
+	scalingDownByAddress := map[string]bool{}
+	for _, node := range scalingDownNodes {
+		for _, nodeAddress := range node.Status.Addresses {
+			scalingDownByAddress[nodeAddress.Address] = true
+		}
+	}
+	var pdbs []policyv1.PodDisruptionBudget
+	if len(scalingDownNodes) != 0 {
+		pdbList, err := client.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("error listing PodDisruptionBudgets: %v", err)
+		}
+		pdbs = pdbList.Items
+	}
+	addPodError := func(failure *ValidationError, pod *v1.Pod) {
+		switch {
+		case scalingDownByAddress[pod.Status.HostIP] && isBlockedByPodDisruptionBudget(pod, pdbs):
+			v.addIgnored(failure)
+		case len(scalingDownNodes) != 0 && pod.Status.Phase == v1.PodPending && pod.Spec.NodeName == "":
+			v.addIgnored(failure)
+		default:
+			v.addError(failure)
+		}
+	}
+
+	for i := range pods {
+		pod := &pods[i]
 
 		priority := pod.Spec.PriorityClassName
 		if priority != "system-cluster-critical" && priority != "system-node-critical" {
-			return nil
+			continue
 		}
 		if pod.Status.Phase == v1.PodSucceeded {
-			return nil
+			continue
 		}
 
 		var podNode *kops.InstanceGroup
@@ -223,22 +371,22 @@ func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kuber
 		}
 
 		if pod.Status.Phase == v1.PodPending {
-			v.addError(&ValidationError{
+			addPodError(&ValidationError{
 				Kind:          "Pod",
 				Name:          pod.Namespace + "/" + pod.Name,
 				Message:       fmt.Sprintf("%s pod %q is pending", priority, pod.Name),
 				InstanceGroup: podNode,
-			})
-			return nil
+			}, pod)
+			continue
 		}
 		if pod.Status.Phase == v1.PodUnknown {
-			v.addError(&ValidationError{
+			addPodError(&ValidationError{
 				Kind:          "Pod",
 				Name:          pod.Namespace + "/" + pod.Name,
 				Message:       fmt.Sprintf("%s pod %q is unknown phase", priority, pod.Name),
 				InstanceGroup: podNode,
-			})
-			return nil
+			}, pod)
+			continue
 		}
 		var notready []string
 		for _, container := range pod.Status.ContainerStatuses {
@@ -247,35 +395,43 @@ func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kuber
 			}
 		}
 		if len(notready) != 0 {
-			v.addError(&ValidationError{
+			addPodError(&ValidationError{
 				Kind:          "Pod",
 				Name:          pod.Namespace + "/" + pod.Name,
 				Message:       fmt.Sprintf("%s pod %q is not ready (%s)", priority, pod.Name, strings.Join(notready, ",")),
 				InstanceGroup: podNode,
-			})
+			}, pod)
 		}
-		return nil
-	})
-	if err != nil {
-		return fmt.Errorf("error listing Pods: %v", err)
 	}
 
-	for node, nodeMap := range masterWithoutPod {
-		for app := range nodeMap {
-			v.addError(&ValidationError{
-				Kind:          "Node",
-				Name:          node,
-				Message:       fmt.Sprintf("control-plane node %q is missing %s pod", node, app),
-				InstanceGroup: nodeInstanceGroupMapping[node],
-			})
+	return nil
+}
+
+// isBlockedByPodDisruptionBudget returns true if a PodDisruptionBudget selecting the pod currently allows no disruptions.
+func isBlockedByPodDisruptionBudget(pod *v1.Pod, pdbs []policyv1.PodDisruptionBudget) bool {
+	for i := range pdbs {
+		pdb := &pdbs[i]
+		if pdb.Namespace != pod.Namespace || pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			klog.Warningf("ignoring PodDisruptionBudget %s/%s with invalid selector: %v", pdb.Namespace, pdb.Name, err)
+			continue
+		}
+		if selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		if pdb.Status.DisruptionsAllowed <= 0 {
+			return true
 		}
 	}
-
-	return nil
+	return false
 }
 
-func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances.CloudInstanceGroup, groups []*kops.InstanceGroup) ([]v1.Node, map[string]*kops.InstanceGroup) {
+func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances.CloudInstanceGroup, groups []*kops.InstanceGroup) ([]v1.Node, []v1.Node, map[string]*kops.InstanceGroup) {
 	var readyNodes []v1.Node
+	var scalingDownNodes []v1.Node
 	groupsSeen := map[string]bool{}
 	nodeInstanceGroupMapping := map[string]*kops.InstanceGroup{}
 
@@ -285,24 +441,13 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 		allMembers = append(allMembers, cloudGroup.NeedUpdate...)
 
 		groupsSeen[cloudGroup.InstanceGroup.Name] = true
-		numNodes := 0
-		for _, m := range allMembers {
-			if m.Status != cloudinstances.CloudInstanceStatusDetached {
-				numNodes++
-			}
-		}
-		if numNodes < cloudGroup.TargetSize {
-			v.addError(&ValidationError{
-				Kind: "InstanceGroup",
-				Name: cloudGroup.InstanceGroup.Name,
-				Message: fmt.Sprintf("InstanceGroup %q did not have enough nodes %d vs %d",
-					cloudGroup.InstanceGroup.Name,
-					numNodes,
-					cloudGroup.TargetSize),
-				InstanceGroup: cloudGroup.InstanceGroup,
-			})
+		if cloudGroup.InstanceGroup.IsSuspended() {
+			klog.V(2).Infof("skipping validation of suspended InstanceGroup %q", cloudGroup.InstanceGroup.Name)
+			continue
 		}
 
+		v.validateGroupSize(cloudGroup, allMembers)
+
 		for _, member := range allMembers {
 			node := member.Node
 
@@ -350,16 +495,25 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 			if ready {
 				readyNodes = append(readyNodes, *node)
 			}
+			if isScalingDown(node) {
+				n.ScalingDown = true
+				scalingDownNodes = append(scalingDownNodes, *node)
+			}
 
 			switch n.Role {
 			case "control-plane", "apiserver", "node":
 				if !ready {
-					v.addError(&ValidationError{
+					failure := &ValidationError{
 						Kind:          "Node",
 						Name:          node.Name,
 						Message:       fmt.Sprintf("node %q of role %q is not ready", node.Name, n.Role),
 						InstanceGroup: cloudGroup.InstanceGroup,
-					})
+					}
+					if n.ScalingDown {
+						v.addIgnored(failure)
+					} else {
+						v.addError(failure)
+					}
 				}
 
 				v.Nodes = append(v.Nodes, n)
@@ -370,8 +524,36 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 		}
 	}
 
+	v.validateGroupsExist(groupsSeen, groups)
+
+	return readyNodes, scalingDownNodes, nodeInstanceGroupMapping
+}
+
+// validateGroupSize reports a failure if the cloud group has fewer attached instances than its target size.
+func (v *ValidationCluster) validateGroupSize(cloudGroup *cloudinstances.CloudInstanceGroup, allMembers []*cloudinstances.CloudInstance) {
+	numNodes := 0
+	for _, m := range allMembers {
+		if m.Status != cloudinstances.CloudInstanceStatusDetached {
+			numNodes++
+		}
+	}
+	if numNodes < cloudGroup.TargetSize {
+		v.addError(&ValidationError{
+			Kind: "InstanceGroup",
+			Name: cloudGroup.InstanceGroup.Name,
+			Message: fmt.Sprintf("InstanceGroup %q did not have enough nodes %d vs %d",
+				cloudGroup.InstanceGroup.Name,
+				numNodes,
+				cloudGroup.TargetSize),
+			InstanceGroup: cloudGroup.InstanceGroup,
+		})
+	}
+}
+
+// validateGroupsExist reports a failure for every instance group that is not suspended and has no cloud group.
+func (v *ValidationCluster) validateGroupsExist(groupsSeen map[string]bool, groups []*kops.InstanceGroup) {
 	for _, ig := range groups {
-		if !groupsSeen[ig.Name] {
+		if !groupsSeen[ig.Name] && !ig.IsSuspended() {
 			v.addError(&ValidationError{
 				Kind:          "InstanceGroup",
 				Name:          ig.Name,
@@ -380,6 +562,4 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 			})
 		}
 	}
-
-	return readyNodes, nodeInstanceGroupMapping
 }
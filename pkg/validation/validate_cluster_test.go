@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
@@ -166,6 +167,7 @@ func Test_ValidateCloudGroupMissing(t *testing.T) {
 			Name:          "node-1",
 			Message:       "InstanceGroup \"node-1\" is missing from the cloud provider",
 			InstanceGroup: &instanceGroups[0],
+			Check:         CheckNodes,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
@@ -220,6 +222,7 @@ func Test_ValidateNodesNotEnough(t *testing.T) {
 			Name:          "node-1",
 			Message:       "InstanceGroup \"node-1\" did not have enough nodes 2 vs 3",
 			InstanceGroup: groups["node-1"].InstanceGroup,
+			Check:         CheckNodes,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
@@ -275,6 +278,7 @@ func Test_ValidateDetachedNodesDontCount(t *testing.T) {
 			Name:          "node-1",
 			Message:       "InstanceGroup \"node-1\" did not have enough nodes 1 vs 2",
 			InstanceGroup: groups["node-1"].InstanceGroup,
+			Check:         CheckNodes,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
@@ -329,6 +333,7 @@ func Test_ValidateNodeNotReady(t *testing.T) {
 			Name:          "node-1b",
 			Message:       "node \"node-1b\" of role \"node\" is not ready",
 			InstanceGroup: groups["node-1"].InstanceGroup,
+			Check:         CheckNodes,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
@@ -383,6 +388,7 @@ func Test_ValidateMastersNotEnough(t *testing.T) {
 			Name:          "master-1",
 			Message:       "InstanceGroup \"master-1\" did not have enough nodes 2 vs 3",
 			InstanceGroup: groups["node-1"].InstanceGroup,
+			Check:         CheckNodes,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
@@ -437,6 +443,7 @@ func Test_ValidateMasterNotReady(t *testing.T) {
 			Name:          "master-1b",
 			Message:       "node \"master-1b\" of role \"control-plane\" is not ready",
 			InstanceGroup: groups["node-1"].InstanceGroup,
+			Check:         CheckNodes,
 		}, v.Failures[0]) {
 		printDebug(t, v)
 	}
@@ -525,6 +532,7 @@ func Test_ValidateMasterStaticPods(t *testing.T) {
 			Name:          "master-1c",
 			Message:       "node \"master-1c\" of role \"control-plane\" is not ready",
 			InstanceGroup: groups["node-1"].InstanceGroup,
+			Check:         CheckNodes,
 		},
 	}
 
@@ -557,6 +565,7 @@ func Test_ValidateMasterStaticPods(t *testing.T) {
 			Name:          "master-1b",
 			Message:       "control-plane node \"master-1b\" is missing " + pod + " pod",
 			InstanceGroup: groups["node-1"].InstanceGroup,
+			Check:         CheckControlPlanePods,
 		})
 	}
 
@@ -745,6 +754,7 @@ func Test_ValidatePodFailure(t *testing.T) {
 							Name:          fmt.Sprintf("%s/pod1", namespace),
 							Message:       fmt.Sprintf("system-%s-critical pod \"pod1\" is %s", priority, tc.expected),
 							InstanceGroup: podInstanceGroup,
+							Check:         CheckCriticalPods,
 						}
 
 						require.NoError(t, err)
@@ -759,6 +769,118 @@ func Test_ValidatePodFailure(t *testing.T) {
 	}
 }
 
+func Test_ValidateScalingDownNode(t *testing.T) {
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	groups["node-1"] = &cloudinstances.CloudInstanceGroup{
+		InstanceGroup: &kopsapi.InstanceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+			},
+			Spec: kopsapi.InstanceGroupSpec{
+				Role: kopsapi.InstanceGroupRoleNode,
+			},
+		},
+		MinSize:    1,
+		TargetSize: 2,
+		Ready: []*cloudinstances.CloudInstance{
+			{
+				ID: "i-00001",
+				Node: &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-1a"},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{
+							{
+								Address: "1.2.3.4",
+							},
+						},
+						Conditions: []v1.NodeCondition{
+							{Type: "Ready", Status: v1.ConditionTrue},
+						},
+					},
+				},
+			},
+			{
+				ID: "i-00002",
+				Node: &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-1b"},
+					Spec: v1.NodeSpec{
+						Taints: []v1.Taint{
+							{Key: "ToBeDeletedByClusterAutoscaler", Value: "1700000000", Effect: v1.TaintEffectNoSchedule},
+						},
+					},
+					Status: v1.NodeStatus{
+						Addresses: []v1.NodeAddress{
+							{
+								Address: "5.6.7.8",
+							},
+						},
+						Conditions: []v1.NodeCondition{
+							{Type: "Ready", Status: v1.ConditionFalse},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objects := makePodList(
+		[]map[string]string{
+			{
+				"name":              "pod1",
+				"k8s-app":           "guarded",
+				"priorityClassName": "system-node-critical",
+				"ready":             "false",
+				"phase":             string(v1.PodRunning),
+				"hostip":            "5.6.7.8",
+			},
+			{
+				"name":              "pod2",
+				"priorityClassName": "system-cluster-critical",
+				"ready":             "false",
+				"phase":             string(v1.PodRunning),
+				"hostip":            "1.2.3.4",
+			},
+			{
+				"name":              "pod3",
+				"priorityClassName": "system-cluster-critical",
+				"ready":             "false",
+				"phase":             string(v1.PodRunning),
+				"hostip":            "5.6.7.8",
+			},
+			{
+				"name":              "pod4",
+				"priorityClassName": "system-cluster-critical",
+				"ready":             "false",
+				"phase":             string(v1.PodPending),
+			},
+		},
+	)
+	objects = append(objects, &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "guarded", Namespace: "kube-system"},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"k8s-app": "guarded"}},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 0},
+	})
+
+	v, err := testValidate(t, groups, objects)
+	require.NoError(t, err)
+
+	if !assert.Len(t, v.Failures, 2) ||
+		!assert.Equal(t, "kube-system/pod2", v.Failures[0].Name) ||
+		!assert.Equal(t, "kube-system/pod3", v.Failures[1].Name) {
+		printDebug(t, v)
+	}
+	if assert.Len(t, v.Ignored, 3) {
+		assert.Equal(t, "node-1b", v.Ignored[0].Name)
+		assert.Equal(t, "kube-system/pod1", v.Ignored[1].Name)
+		assert.Equal(t, "kube-system/pod4", v.Ignored[2].Name)
+	}
+	for _, node := range v.Nodes {
+		assert.Equal(t, node.Name == "node-1b", node.ScalingDown, "scalingDown of node %q", node.Name)
+	}
+}
+
 func printDebug(t *testing.T, v *ValidationCluster) {
 	t.Logf("cluster - %d failures", len(v.Failures))
 	for _, fail := range v.Failures {
@@ -850,6 +972,41 @@ func Test_ValidateBastionNodes(t *testing.T) {
 	})
 }
 
+func Test_ValidateSuspendedInstanceGroup(t *testing.T) {
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	groups["node-1"] = &cloudinstances.CloudInstanceGroup{
+		InstanceGroup: &kopsapi.InstanceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+			},
+			Spec: kopsapi.InstanceGroupSpec{
+				Role:      kopsapi.InstanceGroupRoleNode,
+				Suspended: fi.PtrTo(true),
+			},
+		},
+		TargetSize: 2,
+		Ready: []*cloudinstances.CloudInstance{
+			{
+				ID: "i-00001",
+				Node: &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node-1a"},
+					Status: v1.NodeStatus{
+						Conditions: []v1.NodeCondition{
+							{Type: "Ready", Status: v1.ConditionFalse},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	v, err := testValidate(t, groups, nil)
+	require.NoError(t, err)
+	if !assert.Empty(t, v.Failures, "suspended instance groups are not validated") {
+		printDebug(t, v)
+	}
+}
+
 func Test_ValidateDetachedNodesNotValidated(t *testing.T) {
 	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
 	groups["node-1"] = &cloudinstances.CloudInstanceGroup{
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// checkEtcd reports the ready control plane nodes without a ready etcd-manager pod for each etcd cluster,
+// and the etcd clusters with fewer ready members than their quorum.
+func checkEtcd(ctx context.Context, c *CheckCluster, validation *ValidationCluster) error {
+	if len(c.Cluster.Spec.EtcdClusters) == 0 {
+		return nil
+	}
+	state, err := c.nodeState(ctx)
+	if err != nil {
+		return err
+	}
+	pods, err := c.listPods(ctx)
+	if err != nil {
+		return err
+	}
+	validation.collectEtcdFailures(c.Cluster.Spec.EtcdClusters, pods, state.readyNodes, state.nodeInstanceGroupMapping)
+	return nil
+}
+
+func (v *ValidationCluster) collectEtcdFailures(etcdClusters []kops.EtcdClusterSpec, pods []v1.Pod, nodes []v1.Node, nodeInstanceGroupMapping map[string]*kops.InstanceGroup) {
+	nodeByAddress := nodesByAddress(nodes)
+
+	for _, etcdCluster := range etcdClusters {
+		// External etcd clusters do not run in the cluster
+		if etcdCluster.External != nil {
+			continue
+		}
+		app := "etcd-manager-" + etcdCluster.Name
+
+		readyMembers := map[string]bool{}
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Namespace != "kube-system" || pod.GetLabels()["k8s-app"] != app || !isPodReady(pod) {
+				continue
+			}
+			if node := nodeByAddress[pod.Status.HostIP]; node != "" {
+				readyMembers[node] = true
+			}
+		}
+
+		for i := range nodes {
+			node := &nodes[i]
+			if isControlPlaneNode(node) && !readyMembers[node.Name] {
+				v.addError(&ValidationError{
+					Kind:          "Node",
+					Name:          node.Name,
+					Message:       fmt.Sprintf("control-plane node %q is missing a ready %s pod", node.Name, app),
+					InstanceGroup: nodeInstanceGroupMapping[node.Name],
+				})
+			}
+		}
+
+		quorum := len(etcdCluster.Members)/2 + 1
+		if len(readyMembers) < quorum {
+			v.addError(&ValidationError{
+				Kind: "EtcdCluster",
+				Name: etcdCluster.Name,
+				Message: fmt.Sprintf("etcd cluster %q has %d of %d members ready, fewer than its quorum of %d",
+					etcdCluster.Name, len(readyMembers), len(etcdCluster.Members), quorum),
+			})
+		}
+	}
+}
+
+// isPodReady returns true if the pod is running and all its containers are ready.
+func isPodReady(pod *v1.Pod) bool {
+	if pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+	for _, container := range pod.Status.ContainerStatuses {
+		if !container.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// checkCNI reports the daemonsets of the networking plugin that are missing, or do not have all their pods ready.
+func checkCNI(ctx context.Context, c *CheckCluster, validation *ValidationCluster) error {
+	for _, name := range networkingDaemonSets(&c.Cluster.Spec.Networking) {
+		daemonSet, err := c.K8sClient.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			validation.addError(&ValidationError{
+				Kind:    "DaemonSet",
+				Name:    metav1.NamespaceSystem + "/" + name,
+				Message: fmt.Sprintf("networking daemonset %q is missing", name),
+			})
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error getting daemonset %q: %w", name, err)
+		}
+
+		desired, ready := daemonSet.Status.DesiredNumberScheduled, daemonSet.Status.NumberReady
+		if ready < desired {
+			validation.addError(&ValidationError{
+				Kind:    "DaemonSet",
+				Name:    metav1.NamespaceSystem + "/" + name,
+				Message: fmt.Sprintf("networking daemonset %q has %d of %d pods ready", name, ready, desired),
+			})
+		}
+	}
+	return nil
+}
+
+// networkingDaemonSets returns the names of the daemonsets that kOps deploys in kube-system for the networking plugin.
+// The networking plugins that are not deployed by kOps have none.
+func networkingDaemonSets(networking *kops.NetworkingSpec) []string {
+	var names []string
+	if networking.AmazonVPC != nil {
+		names = append(names, "aws-node")
+	}
+	if networking.Calico != nil {
+		names = append(names, "calico-node")
+	}
+	if networking.Canal != nil {
+		names = append(names, "canal")
+	}
+	if networking.Cilium != nil {
+		names = append(names, "cilium")
+	}
+	if networking.Flannel != nil {
+		names = append(names, "kube-flannel-ds")
+	}
+	if networking.Kopeio != nil {
+		names = append(names, "kopeio-networking-agent")
+	}
+	if networking.KubeRouter != nil {
+		names = append(names, "kube-router")
+	}
+	return names
+}
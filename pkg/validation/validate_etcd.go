@@ -0,0 +1,262 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+const (
+	// etcdQuotaWarning and etcdQuotaFailure are the fractions of its quota filled by a database
+	// from which the etcd health check warns and fails.
+	etcdQuotaWarning = 0.8
+	etcdQuotaFailure = 0.95
+	// etcdDefragmentationMinSize is the size of a database from which the check warns if it is mostly free space,
+	// that a defragmentation would reclaim.
+	etcdDefragmentationMinSize = 100 * 1024 * 1024
+	// etcdLeaderChangesWarning is the number of leader changes seen by a member since it started, from which the check warns.
+	etcdLeaderChangesWarning = 5
+	// etcdDefaultHeartbeatInterval is the heartbeat interval of etcd when the cluster does not set one.
+	etcdDefaultHeartbeatInterval = 100 * time.Millisecond
+)
+
+// checkEtcdHealth queries the /health and /metrics endpoints of the etcd members, through the API server proxy
+// to the etcd-manager pods. Only the etcd clusters with metrics URLs serve these endpoints.
+func checkEtcdHealth(ctx context.Context, c *CheckCluster, validation *ValidationCluster) error {
+	var pods []v1.Pod
+	for _, etcdCluster := range c.Cluster.Spec.EtcdClusters {
+		port := etcdMetricsPort(&etcdCluster)
+		if port == "" {
+			klog.V(2).Infof("Not checking the health of etcd cluster %q, which has no metrics URLs", etcdCluster.Name)
+			continue
+		}
+
+		if pods == nil {
+			var err error
+			pods, err = c.listPods(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		app := "etcd-manager-" + etcdCluster.Name
+		healthy, unknown := 0, 0
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Namespace != "kube-system" || pod.GetLabels()["k8s-app"] != app || !isPodReady(pod) {
+				continue
+			}
+			ok, err := validation.checkEtcdMember(ctx, c.K8sClient, &etcdCluster, pod, port)
+			if err != nil {
+				unknown++
+				validation.addWarning(&ValidationError{
+					Kind:    "Pod",
+					Name:    pod.Namespace + "/" + pod.Name,
+					Message: fmt.Sprintf("cannot query the health of the member of etcd cluster %q: %v", etcdCluster.Name, err),
+				})
+				continue
+			}
+			if ok {
+				healthy++
+			}
+		}
+
+		// Members that could not be queried may be healthy
+		quorum := len(etcdCluster.Members)/2 + 1
+		if healthy+unknown < quorum {
+			validation.addError(&ValidationError{
+				Kind: "EtcdCluster",
+				Name: etcdCluster.Name,
+				Message: fmt.Sprintf("etcd cluster %q has %d of %d members healthy, fewer than its quorum of %d",
+					etcdCluster.Name, healthy, len(etcdCluster.Members), quorum),
+			})
+		}
+	}
+	return nil
+}
+
+// etcdMetricsPort returns the port of the first metrics URL of the etcd cluster, or "" if it has none.
+func etcdMetricsPort(etcdCluster *kops.EtcdClusterSpec) string {
+	if etcdCluster.Manager == nil || len(etcdCluster.Manager.ListenMetricsURLs) == 0 {
+		return ""
+	}
+	u, err := url.Parse(etcdCluster.Manager.ListenMetricsURLs[0])
+	if err != nil {
+		klog.Warningf("cannot parse the metrics URL of etcd cluster %q: %v", etcdCluster.Name, err)
+		return ""
+	}
+	return u.Port()
+}
+
+// etcdHealth is the response of the /health endpoint of etcd.
+type etcdHealth struct {
+	Health string `json:"health"`
+	Reason string `json:"reason"`
+}
+
+// checkEtcdMember reports the problems of the etcd member run by the pod, and returns true if it is healthy.
+func (v *ValidationCluster) checkEtcdMember(ctx context.Context, client kubernetes.Interface, etcdCluster *kops.EtcdClusterSpec, pod *v1.Pod, port string) (bool, error) {
+	clusterName := etcdCluster.Name
+	pods := client.CoreV1().Pods(pod.Namespace)
+	name := pod.Namespace + "/" + pod.Name
+
+	// etcd answers with an error status when it is unhealthy, but still describes the reason
+	body, err := pods.ProxyGet("http", pod.Name, port, "/health", nil).DoRaw(ctx)
+	health := &etcdHealth{}
+	if jsonErr := json.Unmarshal(body, health); jsonErr != nil {
+		if err != nil {
+			return false, err
+		}
+		return false, fmt.Errorf("error parsing health: %w", jsonErr)
+	}
+
+	// The reason of an unhealthy member, such as an alarm, explains the other problems
+	if health.Health != "true" {
+		v.addError(&ValidationError{
+			Kind:    "Pod",
+			Name:    name,
+			Message: fmt.Sprintf("member of etcd cluster %q is unhealthy: %s", clusterName, health.Reason),
+		})
+		return false, nil
+	}
+
+	body, err = pods.ProxyGet("http", pod.Name, port, "/metrics", nil).DoRaw(ctx)
+	if err != nil {
+		return false, err
+	}
+	var parser expfmt.TextParser
+	metrics, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("error parsing metrics: %w", err)
+	}
+
+	healthy := true
+	addError := func(message string) {
+		healthy = false
+		v.addError(&ValidationError{Kind: "Pod", Name: name, Message: message})
+	}
+	addWarning := func(message string) {
+		v.addWarning(&ValidationError{Kind: "Pod", Name: name, Message: message})
+	}
+
+	if hasLeader, found := metricValue(metrics, "etcd_server_has_leader"); found && hasLeader == 0 {
+		addError(fmt.Sprintf("member of etcd cluster %q has no leader", clusterName))
+	}
+
+	size, foundSize := metricValue(metrics, "etcd_mvcc_db_total_size_in_bytes")
+	quota, foundQuota := metricValue(metrics, "etcd_server_quota_backend_bytes")
+	if foundSize && foundQuota && quota > 0 {
+		used := size / quota
+		message := fmt.Sprintf("database of the member of etcd cluster %q uses %.0f%% of its quota of %s", clusterName, used*100, formatBytes(quota))
+		switch {
+		case used >= etcdQuotaFailure:
+			addError(message)
+		case used >= etcdQuotaWarning:
+			addWarning(message)
+		}
+	}
+	if inUse, found := metricValue(metrics, "etcd_mvcc_db_total_size_in_use_in_bytes"); foundSize && found {
+		if size >= etcdDefragmentationMinSize && inUse < size/2 {
+			addWarning(fmt.Sprintf("database of the member of etcd cluster %q is %s with %s in use, and would shrink if defragmented",
+				clusterName, formatBytes(size), formatBytes(inUse)))
+		}
+	}
+	if changes, found := metricValue(metrics, "etcd_server_leader_changes_seen_total"); found && changes >= etcdLeaderChangesWarning {
+		addWarning(fmt.Sprintf("member of etcd cluster %q has seen %.0f leader changes since it started", clusterName, changes))
+	}
+
+	// Peers further away than a heartbeat interval miss heartbeats, and start elections when the links slow down
+	heartbeatInterval := etcdDefaultHeartbeatInterval
+	if etcdCluster.HeartbeatInterval != nil {
+		heartbeatInterval = etcdCluster.HeartbeatInterval.Duration
+	}
+	for _, peer := range peerRoundTripTimes(metrics) {
+		if peer.rtt > heartbeatInterval {
+			addWarning(fmt.Sprintf("member of etcd cluster %q has an average round-trip time of %v to peer %s, more than its heartbeat interval of %v; raise heartbeatInterval and leaderElectionTimeout",
+				clusterName, peer.rtt.Round(time.Millisecond), peer.id, heartbeatInterval))
+		}
+	}
+
+	return healthy, nil
+}
+
+// peerRoundTripTime is the average round-trip time from an etcd member to one of its peers.
+type peerRoundTripTime struct {
+	id  string
+	rtt time.Duration
+}
+
+// peerRoundTripTimes returns the average round-trip times to the peers reported by the member,
+// from the etcd_network_peer_round_trip_time_seconds histogram.
+func peerRoundTripTimes(metrics map[string]*dto.MetricFamily) []peerRoundTripTime {
+	family := metrics["etcd_network_peer_round_trip_time_seconds"]
+	if family == nil {
+		return nil
+	}
+	var peers []peerRoundTripTime
+	for _, metric := range family.GetMetric() {
+		histogram := metric.GetHistogram()
+		if histogram == nil || histogram.GetSampleCount() == 0 {
+			continue
+		}
+		peer := peerRoundTripTime{
+			rtt: time.Duration(histogram.GetSampleSum() / float64(histogram.GetSampleCount()) * float64(time.Second)),
+		}
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "To" {
+				peer.id = label.GetValue()
+			}
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// metricValue returns the value of the first sample of the metric, if it was reported.
+func metricValue(metrics map[string]*dto.MetricFamily, name string) (float64, bool) {
+	family := metrics[name]
+	if family == nil || len(family.GetMetric()) == 0 {
+		return 0, false
+	}
+	metric := family.GetMetric()[0]
+	switch {
+	case metric.GetGauge() != nil:
+		return metric.GetGauge().GetValue(), true
+	case metric.GetCounter() != nil:
+		return metric.GetCounter().GetValue(), true
+	case metric.GetUntyped() != nil:
+		return metric.GetUntyped().GetValue(), true
+	}
+	return 0, false
+}
+
+// formatBytes formats a size in MiB.
+func formatBytes(size float64) string {
+	return fmt.Sprintf("%.0fMiB", size/(1024*1024))
+}
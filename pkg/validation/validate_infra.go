@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elb "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// classicLoadBalancerInService is the state of an instance that a classic load balancer sends traffic to.
+const classicLoadBalancerInService = "InService"
+
+// infraValidatorImpl validates the cloud resources of a cluster without using the Kubernetes API,
+// so that it can tell whether the infrastructure is at fault when the API is unreachable.
+type infraValidatorImpl struct {
+	cluster        *kops.Cluster
+	cloud          fi.Cloud
+	instanceGroups []*kops.InstanceGroup
+}
+
+// NewInfraValidator returns a validator that checks the instance groups are at their target size,
+// the load balancer targets are healthy, the etcd volumes are attached and the API DNS name resolves.
+func NewInfraValidator(cluster *kops.Cluster, cloud fi.Cloud, instanceGroupList *kops.InstanceGroupList) (ClusterValidator, error) {
+	var instanceGroups []*kops.InstanceGroup
+
+	for i := range instanceGroupList.Items {
+		ig := &instanceGroupList.Items[i]
+		instanceGroups = append(instanceGroups, ig)
+	}
+
+	if len(instanceGroups) == 0 {
+		return nil, fmt.Errorf("no InstanceGroup objects found")
+	}
+
+	return &infraValidatorImpl{
+		cluster:        cluster,
+		cloud:          cloud,
+		instanceGroups: instanceGroups,
+	}, nil
+}
+
+func (v *infraValidatorImpl) Validate() (*ValidationCluster, error) {
+	ctx := context.TODO()
+
+	validation := &ValidationCluster{}
+
+	validation.validateAPIDNS(v.cluster)
+
+	warnUnmatched := false
+	cloudGroups, err := v.cloud.GetCloudGroups(v.cluster, v.instanceGroups, warnUnmatched, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	groupsSeen := map[string]bool{}
+	for _, cloudGroup := range cloudGroups {
+		groupsSeen[cloudGroup.InstanceGroup.Name] = true
+		if cloudGroup.InstanceGroup.IsSuspended() {
+			klog.V(2).Infof("skipping validation of suspended InstanceGroup %q", cloudGroup.InstanceGroup.Name)
+			continue
+		}
+		var allMembers []*cloudinstances.CloudInstance
+		allMembers = append(allMembers, cloudGroup.Ready...)
+		allMembers = append(allMembers, cloudGroup.NeedUpdate...)
+		validation.validateGroupSize(cloudGroup, allMembers)
+	}
+	validation.validateGroupsExist(groupsSeen, v.instanceGroups)
+
+	awsCloud, ok := v.cloud.(awsup.AWSCloud)
+	if !ok {
+		klog.V(2).Infof("load balancer and etcd volume validation is only supported on AWS")
+		return validation, nil
+	}
+	if err := validation.validateAWSLoadBalancerTargets(ctx, awsCloud, cloudGroups); err != nil {
+		return nil, err
+	}
+	if err := validation.validateAWSEtcdVolumes(ctx, awsCloud, v.cluster); err != nil {
+		return nil, err
+	}
+
+	return validation, nil
+}
+
+// validateAPIDNS reports a failure if the API DNS name does not resolve, or still points at the placeholder address.
+func (v *ValidationCluster) validateAPIDNS(cluster *kops.Cluster) {
+	// Gossip and none DNS clusters have no records to check, and private records don't resolve outside the VPC
+	if cluster.UsesLegacyGossip() || cluster.UsesNoneDNS() || cluster.UsesPrivateDNS() {
+		return
+	}
+
+	name := "api." + cluster.ObjectMeta.Name
+	if cluster.Spec.API.PublicName != "" {
+		name = cluster.Spec.API.PublicName
+	}
+	placeholder, err := hasPlaceHolderIP("https://" + name)
+	if err != nil {
+		v.addError(&ValidationError{
+			Kind:    "dns",
+			Name:    name,
+			Message: err.Error(),
+		})
+		return
+	}
+	if placeholder != "" {
+		v.addError(&ValidationError{
+			Kind:    "dns",
+			Name:    name,
+			Message: fmt.Sprintf("DNS name %q still resolves to the placeholder address %s", name, placeholder),
+		})
+	}
+}
+
+// validateAWSLoadBalancerTargets reports a failure for every instance that a load balancer attached to its
+// autoscaling group does not consider healthy.
+func (v *ValidationCluster) validateAWSLoadBalancerTargets(ctx context.Context, cloud awsup.AWSCloud, cloudGroups map[string]*cloudinstances.CloudInstanceGroup) error {
+	for _, cloudGroup := range cloudGroups {
+		asg, ok := cloudGroup.Raw.(*autoscalingtypes.AutoScalingGroup)
+		if !ok || cloudGroup.InstanceGroup.IsSuspended() {
+			continue
+		}
+
+		// Warm pool and detached instances don't receive traffic
+		members := map[string]bool{}
+		for _, group := range [][]*cloudinstances.CloudInstance{cloudGroup.Ready, cloudGroup.NeedUpdate} {
+			for _, member := range group {
+				if member.State != cloudinstances.WarmPool && member.Status != cloudinstances.CloudInstanceStatusDetached {
+					members[member.ID] = true
+				}
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+
+		for _, targetGroupARN := range asg.TargetGroupARNs {
+			response, err := cloud.ELBV2().DescribeTargetHealth(ctx, &elbv2.DescribeTargetHealthInput{
+				TargetGroupArn: aws.String(targetGroupARN),
+			})
+			if err != nil {
+				return fmt.Errorf("error describing health of target group %q: %w", targetGroupARN, err)
+			}
+			for _, target := range response.TargetHealthDescriptions {
+				id := aws.ToString(target.Target.Id)
+				if !members[id] || target.TargetHealth == nil || target.TargetHealth.State == elbv2types.TargetHealthStateEnumHealthy {
+					continue
+				}
+				message := fmt.Sprintf("instance %q is %s in target group %q", id, target.TargetHealth.State, targetGroupARN)
+				if description := aws.ToString(target.TargetHealth.Description); description != "" {
+					message += ": " + description
+				}
+				v.addError(&ValidationError{
+					Kind:          "LoadBalancerTarget",
+					Name:          id,
+					Message:       message,
+					InstanceGroup: cloudGroup.InstanceGroup,
+				})
+			}
+		}
+
+		for _, loadBalancerName := range asg.LoadBalancerNames {
+			response, err := cloud.ELB().DescribeInstanceHealth(ctx, &elb.DescribeInstanceHealthInput{
+				LoadBalancerName: aws.String(loadBalancerName),
+			})
+			if err != nil {
+				return fmt.Errorf("error describing instance health of load balancer %q: %w", loadBalancerName, err)
+			}
+			for _, state := range response.InstanceStates {
+				id := aws.ToString(state.InstanceId)
+				if !members[id] || aws.ToString(state.State) == classicLoadBalancerInService {
+					continue
+				}
+				message := fmt.Sprintf("instance %q is %s in load balancer %q", id, aws.ToString(state.State), loadBalancerName)
+				if description := aws.ToString(state.Description); description != "" {
+					message += ": " + description
+				}
+				v.addError(&ValidationError{
+					Kind:          "LoadBalancerTarget",
+					Name:          id,
+					Message:       message,
+					InstanceGroup: cloudGroup.InstanceGroup,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// validateAWSEtcdVolumes reports a failure for every etcd member without a volume,
+// and for every etcd volume that is not attached to an instance.
+func (v *ValidationCluster) validateAWSEtcdVolumes(ctx context.Context, cloud awsup.AWSCloud, cluster *kops.Cluster) error {
+	for _, etcdCluster := range cluster.Spec.EtcdClusters {
+		if etcdCluster.External != nil {
+			continue
+		}
+		var volumes []ec2types.Volume
+		paginator := ec2.NewDescribeVolumesPaginator(cloud.EC2(), &ec2.DescribeVolumesInput{
+			Filters: []ec2types.Filter{
+				awsup.NewEC2Filter("tag:kubernetes.io/cluster/"+cluster.ObjectMeta.Name, "owned"),
+				awsup.NewEC2Filter("tag-key", awsup.TagNameEtcdClusterPrefix+etcdCluster.Name),
+			},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return fmt.Errorf("error describing volumes of etcd cluster %q: %w", etcdCluster.Name, err)
+			}
+			volumes = append(volumes, page.Volumes...)
+		}
+		sort.Slice(volumes, func(i, j int) bool {
+			return aws.ToString(volumes[i].VolumeId) < aws.ToString(volumes[j].VolumeId)
+		})
+
+		if len(volumes) < len(etcdCluster.Members) {
+			v.addError(&ValidationError{
+				Kind:    "Volume",
+				Name:    etcdCluster.Name,
+				Message: fmt.Sprintf("etcd cluster %q has %d volumes for %d members", etcdCluster.Name, len(volumes), len(etcdCluster.Members)),
+			})
+		}
+		for _, volume := range volumes {
+			if volume.State == ec2types.VolumeStateInUse {
+				continue
+			}
+			id := aws.ToString(volume.VolumeId)
+			v.addError(&ValidationError{
+				Kind:    "Volume",
+				Name:    id,
+				Message: fmt.Sprintf("volume %q of etcd cluster %q is %s, not attached to a control plane instance", id, etcdCluster.Name, volume.State),
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+// mockTargetHealthELBV2 returns the health of the targets of a single target group.
+type mockTargetHealthELBV2 struct {
+	awsinterfaces.ELBV2API
+
+	targetGroupARN string
+	health         map[string]elbv2types.TargetHealthStateEnum
+}
+
+func (m *mockTargetHealthELBV2) DescribeTargetHealth(ctx context.Context, input *elbv2.DescribeTargetHealthInput, optFns ...func(*elbv2.Options)) (*elbv2.DescribeTargetHealthOutput, error) {
+	if aws.ToString(input.TargetGroupArn) != m.targetGroupARN {
+		return nil, fmt.Errorf("unexpected target group %q", aws.ToString(input.TargetGroupArn))
+	}
+	output := &elbv2.DescribeTargetHealthOutput{}
+	for id, state := range m.health {
+		output.TargetHealthDescriptions = append(output.TargetHealthDescriptions, elbv2types.TargetHealthDescription{
+			Target:       &elbv2types.TargetDescription{Id: aws.String(id)},
+			TargetHealth: &elbv2types.TargetHealth{State: state},
+		})
+	}
+	return output, nil
+}
+
+func testValidateInfra(t *testing.T, addresses []string, targetHealth map[string]elbv2types.TargetHealthStateEnum, volumeStates []ec2types.VolumeState) *ValidationCluster {
+	ctx := context.TODO()
+
+	cluster := &kopsapi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "testcluster.example.com"},
+		Spec: kopsapi.ClusterSpec{
+			API: kopsapi.APISpec{PublicName: "api.testcluster.example.com"},
+			EtcdClusters: []kopsapi.EtcdClusterSpec{
+				{
+					Name: "main",
+					Members: []kopsapi.EtcdMemberSpec{
+						{Name: "a", InstanceGroup: aws.String("master-1")},
+					},
+				},
+			},
+		},
+	}
+
+	groups := map[string]*cloudinstances.CloudInstanceGroup{
+		"master-1": {
+			InstanceGroup: &kopsapi.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "master-1"},
+				Spec:       kopsapi.InstanceGroupSpec{Role: kopsapi.InstanceGroupRoleControlPlane},
+			},
+			TargetSize: 1,
+			Ready:      []*cloudinstances.CloudInstance{{ID: "i-00001"}},
+			Raw:        &autoscalingtypes.AutoScalingGroup{TargetGroupARNs: []string{"arn:tg-api"}},
+		},
+		"nodes": {
+			InstanceGroup: &kopsapi.InstanceGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+				Spec:       kopsapi.InstanceGroupSpec{Role: kopsapi.InstanceGroupRoleNode},
+			},
+			TargetSize: 2,
+			Ready:      []*cloudinstances.CloudInstance{{ID: "i-00002"}},
+			Raw:        &autoscalingtypes.AutoScalingGroup{},
+		},
+	}
+	var instanceGroups []kopsapi.InstanceGroup
+	for _, g := range groups {
+		instanceGroups = append(instanceGroups, *g.InstanceGroup)
+	}
+
+	mockcloud := BuildMockCloud(t, groups, cluster, instanceGroups)
+	mockcloud.MockELBV2 = &mockTargetHealthELBV2{targetGroupARN: "arn:tg-api", health: targetHealth}
+	mockEC2 := &mockec2.MockEC2{}
+	mockcloud.MockEC2 = mockEC2
+	for _, state := range volumeStates {
+		volume, err := mockEC2.CreateVolume(ctx, &ec2.CreateVolumeInput{
+			TagSpecifications: []ec2types.TagSpecification{{
+				ResourceType: ec2types.ResourceTypeVolume,
+				Tags: []ec2types.Tag{
+					{Key: aws.String("k8s.io/etcd/main"), Value: aws.String("a/a")},
+					{Key: aws.String("kubernetes.io/cluster/testcluster.example.com"), Value: aws.String("owned")},
+				},
+			}},
+		})
+		require.NoError(t, err)
+		mockEC2.Volumes[aws.ToString(volume.VolumeId)].State = state
+	}
+
+	defer func(original func(string) ([]string, error)) { lookupHost = original }(lookupHost)
+	lookupHost = func(host string) ([]string, error) {
+		assert.Equal(t, "api.testcluster.example.com", host)
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("no such host")
+		}
+		return addresses, nil
+	}
+
+	validator, err := NewInfraValidator(cluster, mockcloud, &kopsapi.InstanceGroupList{Items: instanceGroups})
+	require.NoError(t, err)
+	result, err := validator.Validate()
+	require.NoError(t, err)
+	return result
+}
+
+func Test_ValidateInfraHealthy(t *testing.T) {
+	result := testValidateInfra(t,
+		[]string{"192.0.2.1"},
+		map[string]elbv2types.TargetHealthStateEnum{"i-00001": elbv2types.TargetHealthStateEnumHealthy},
+		[]ec2types.VolumeState{ec2types.VolumeStateInUse},
+	)
+
+	// The node group is short of an instance, which is the only failure
+	if assert.Len(t, result.Failures, 1) {
+		assert.Equal(t, "InstanceGroup", result.Failures[0].Kind)
+		assert.Equal(t, "nodes", result.Failures[0].Name)
+	}
+}
+
+func Test_ValidateInfraFailures(t *testing.T) {
+	result := testValidateInfra(t,
+		[]string{cloudup.PlaceholderIP},
+		map[string]elbv2types.TargetHealthStateEnum{
+			"i-00001":       elbv2types.TargetHealthStateEnumUnhealthy,
+			"i-not-a-match": elbv2types.TargetHealthStateEnumUnhealthy,
+		},
+		[]ec2types.VolumeState{ec2types.VolumeStateAvailable},
+	)
+
+	failures := map[string]string{}
+	for _, failure := range result.Failures {
+		failures[failure.Kind+"/"+failure.Name] = failure.Message
+	}
+	assert.Equal(t, map[string]string{
+		"InstanceGroup/nodes":             `InstanceGroup "nodes" did not have enough nodes 1 vs 2`,
+		"dns/api.testcluster.example.com": `DNS name "api.testcluster.example.com" still resolves to the placeholder address 203.0.113.123`,
+		"LoadBalancerTarget/i-00001":      `instance "i-00001" is unhealthy in target group "arn:tg-api"`,
+		"Volume/vol-1":                    `volume "vol-1" of etcd cluster "main" is available, not attached to a control plane instance`,
+	}, failures)
+}
+
+func Test_ValidateInfraMissingResources(t *testing.T) {
+	result := testValidateInfra(t, nil, nil, nil)
+
+	failures := map[string]string{}
+	for _, failure := range result.Failures {
+		failures[failure.Kind+"/"+failure.Name] = failure.Message
+	}
+	assert.Equal(t, map[string]string{
+		"InstanceGroup/nodes":             `InstanceGroup "nodes" did not have enough nodes 1 vs 2`,
+		"dns/api.testcluster.example.com": `unable to resolve Kubernetes cluster API URL dns: no such host`,
+		"Volume/main":                     `etcd cluster "main" has 0 volumes for 1 members`,
+	}, failures)
+}
@@ -64,6 +64,9 @@ const (
 	// EtcdCiliumClientPort is the port were the Cilium etcd cluster listens
 	EtcdCiliumClientPort = 4003
 
+	// KopsControllerMetricsPort is the port where kops-controller exposes metrics, when enabled
+	KopsControllerMetricsPort = 4004
+
 	// CiliumOperatorPrometheusPort is the port the Cilium Operator exposes metrics
 	CiliumPrometheusOperatorPort = 6942
 
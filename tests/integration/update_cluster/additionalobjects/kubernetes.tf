@@ -474,6 +474,22 @@ resource "aws_launch_template" "master-us-test-1a-masters-additionalobjects-exam
       "kubernetes.io/cluster/additionalobjects.example.com"                                                   = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                                                     = "additionalobjects.example.com"
+      "Name"                                                                                                  = "master-us-test-1a.masters.additionalobjects.example.com"
+      "aws-node-termination-handler/managed"                                                                  = ""
+      "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/instancegroup"                               = "master-us-test-1a"
+      "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/kops-controller-pki"                         = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/control-plane"                   = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node.kubernetes.io/exclude-from-external-load-balancers" = ""
+      "k8s.io/role/control-plane"                                                                             = "1"
+      "k8s.io/role/master"                                                                                    = "1"
+      "kops.k8s.io/instancegroup"                                                                             = "master-us-test-1a"
+      "kubernetes.io/cluster/additionalobjects.example.com"                                                   = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                                                     = "additionalobjects.example.com"
     "Name"                                                                                                  = "master-us-test-1a.masters.additionalobjects.example.com"
@@ -553,6 +569,19 @@ resource "aws_launch_template" "nodes-additionalobjects-example-com" {
       "kubernetes.io/cluster/additionalobjects.example.com"                        = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                          = "additionalobjects.example.com"
+      "Name"                                                                       = "nodes.additionalobjects.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/instancegroup"    = "nodes-us-test-1a"
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "nodes"
+      "kubernetes.io/cluster/additionalobjects.example.com"                        = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                          = "additionalobjects.example.com"
     "Name"                                                                       = "nodes.additionalobjects.example.com"
@@ -591,38 +591,51 @@ resource "aws_launch_template" "karpenter-nodes-default-minimal-example-com" {
   tag_specifications {
     resource_type = "instance"
     tags = {
-      "KubernetesCluster"                                                           = "minimal.example.com"
-      "Name"                                                                        = "karpenter-nodes-default.minimal.example.com"
-      "aws-node-termination-handler/managed"                                        = ""
-      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/provisioner-name" = "karpenter-nodes-default"
-      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node"  = ""
-      "k8s.io/role/node"                                                            = "1"
-      "kops.k8s.io/instancegroup"                                                   = "karpenter-nodes-default"
-      "kubernetes.io/cluster/minimal.example.com"                                   = "owned"
+      "KubernetesCluster"                                                          = "minimal.example.com"
+      "Name"                                                                       = "karpenter-nodes-default.minimal.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/nodepool"        = "karpenter-nodes-default"
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "karpenter-nodes-default"
+      "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
     }
   }
   tag_specifications {
     resource_type = "volume"
     tags = {
-      "KubernetesCluster"                                                           = "minimal.example.com"
-      "Name"                                                                        = "karpenter-nodes-default.minimal.example.com"
-      "aws-node-termination-handler/managed"                                        = ""
-      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/provisioner-name" = "karpenter-nodes-default"
-      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node"  = ""
-      "k8s.io/role/node"                                                            = "1"
-      "kops.k8s.io/instancegroup"                                                   = "karpenter-nodes-default"
-      "kubernetes.io/cluster/minimal.example.com"                                   = "owned"
+      "KubernetesCluster"                                                          = "minimal.example.com"
+      "Name"                                                                       = "karpenter-nodes-default.minimal.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/nodepool"        = "karpenter-nodes-default"
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "karpenter-nodes-default"
+      "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
+    }
+  }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                          = "minimal.example.com"
+      "Name"                                                                       = "karpenter-nodes-default.minimal.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/nodepool"        = "karpenter-nodes-default"
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "karpenter-nodes-default"
+      "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
     }
   }
   tags = {
-    "KubernetesCluster"                                                           = "minimal.example.com"
-    "Name"                                                                        = "karpenter-nodes-default.minimal.example.com"
-    "aws-node-termination-handler/managed"                                        = ""
-    "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/provisioner-name" = "karpenter-nodes-default"
-    "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node"  = ""
-    "k8s.io/role/node"                                                            = "1"
-    "kops.k8s.io/instancegroup"                                                   = "karpenter-nodes-default"
-    "kubernetes.io/cluster/minimal.example.com"                                   = "owned"
+    "KubernetesCluster"                                                          = "minimal.example.com"
+    "Name"                                                                       = "karpenter-nodes-default.minimal.example.com"
+    "aws-node-termination-handler/managed"                                       = ""
+    "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/nodepool"        = "karpenter-nodes-default"
+    "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/role/node"                                                           = "1"
+    "kops.k8s.io/instancegroup"                                                  = "karpenter-nodes-default"
+    "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
   }
   user_data = filebase64("${path.module}/data/aws_launch_template_karpenter-nodes-default.minimal.example.com_user_data")
 }
@@ -666,38 +679,51 @@ resource "aws_launch_template" "karpenter-nodes-single-machinetype-minimal-examp
   tag_specifications {
     resource_type = "instance"
     tags = {
-      "KubernetesCluster"                                                           = "minimal.example.com"
-      "Name"                                                                        = "karpenter-nodes-single-machinetype.minimal.example.com"
-      "aws-node-termination-handler/managed"                                        = ""
-      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/provisioner-name" = "karpenter-nodes-single-machinetype"
-      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node"  = ""
-      "k8s.io/role/node"                                                            = "1"
-      "kops.k8s.io/instancegroup"                                                   = "karpenter-nodes-single-machinetype"
-      "kubernetes.io/cluster/minimal.example.com"                                   = "owned"
+      "KubernetesCluster"                                                          = "minimal.example.com"
+      "Name"                                                                       = "karpenter-nodes-single-machinetype.minimal.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/nodepool"        = "karpenter-nodes-single-machinetype"
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "karpenter-nodes-single-machinetype"
+      "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
     }
   }
   tag_specifications {
     resource_type = "volume"
     tags = {
-      "KubernetesCluster"                                                           = "minimal.example.com"
-      "Name"                                                                        = "karpenter-nodes-single-machinetype.minimal.example.com"
-      "aws-node-termination-handler/managed"                                        = ""
-      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/provisioner-name" = "karpenter-nodes-single-machinetype"
-      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node"  = ""
-      "k8s.io/role/node"                                                            = "1"
-      "kops.k8s.io/instancegroup"                                                   = "karpenter-nodes-single-machinetype"
-      "kubernetes.io/cluster/minimal.example.com"                                   = "owned"
+      "KubernetesCluster"                                                          = "minimal.example.com"
+      "Name"                                                                       = "karpenter-nodes-single-machinetype.minimal.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/nodepool"        = "karpenter-nodes-single-machinetype"
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "karpenter-nodes-single-machinetype"
+      "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
+    }
+  }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                          = "minimal.example.com"
+      "Name"                                                                       = "karpenter-nodes-single-machinetype.minimal.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/nodepool"        = "karpenter-nodes-single-machinetype"
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "karpenter-nodes-single-machinetype"
+      "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
     }
   }
   tags = {
-    "KubernetesCluster"                                                           = "minimal.example.com"
-    "Name"                                                                        = "karpenter-nodes-single-machinetype.minimal.example.com"
-    "aws-node-termination-handler/managed"                                        = ""
-    "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/provisioner-name" = "karpenter-nodes-single-machinetype"
-    "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node"  = ""
-    "k8s.io/role/node"                                                            = "1"
-    "kops.k8s.io/instancegroup"                                                   = "karpenter-nodes-single-machinetype"
-    "kubernetes.io/cluster/minimal.example.com"                                   = "owned"
+    "KubernetesCluster"                                                          = "minimal.example.com"
+    "Name"                                                                       = "karpenter-nodes-single-machinetype.minimal.example.com"
+    "aws-node-termination-handler/managed"                                       = ""
+    "k8s.io/cluster-autoscaler/node-template/label/karpenter.sh/nodepool"        = "karpenter-nodes-single-machinetype"
+    "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+    "k8s.io/role/node"                                                           = "1"
+    "kops.k8s.io/instancegroup"                                                  = "karpenter-nodes-single-machinetype"
+    "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
   }
   user_data = filebase64("${path.module}/data/aws_launch_template_karpenter-nodes-single-machinetype.minimal.example.com_user_data")
 }
@@ -773,6 +799,21 @@ resource "aws_launch_template" "master-us-test-1a-masters-minimal-example-com" {
       "kubernetes.io/cluster/minimal.example.com"                                                             = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                                                     = "minimal.example.com"
+      "Name"                                                                                                  = "master-us-test-1a.masters.minimal.example.com"
+      "aws-node-termination-handler/managed"                                                                  = ""
+      "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/kops-controller-pki"                         = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/control-plane"                   = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node.kubernetes.io/exclude-from-external-load-balancers" = ""
+      "k8s.io/role/control-plane"                                                                             = "1"
+      "k8s.io/role/master"                                                                                    = "1"
+      "kops.k8s.io/instancegroup"                                                                             = "master-us-test-1a"
+      "kubernetes.io/cluster/minimal.example.com"                                                             = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                                                     = "minimal.example.com"
     "Name"                                                                                                  = "master-us-test-1a.masters.minimal.example.com"
@@ -849,6 +890,18 @@ resource "aws_launch_template" "nodes-minimal-example-com" {
       "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                          = "minimal.example.com"
+      "Name"                                                                       = "nodes.minimal.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "nodes"
+      "kubernetes.io/cluster/minimal.example.com"                                  = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                          = "minimal.example.com"
     "Name"                                                                       = "nodes.minimal.example.com"
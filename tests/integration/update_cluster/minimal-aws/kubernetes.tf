@@ -462,6 +462,21 @@ resource "aws_launch_template" "master-us-test-1a-masters-minimal-aws-example-co
       "kubernetes.io/cluster/minimal-aws.example.com"                                                         = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                                                     = "minimal-aws.example.com"
+      "Name"                                                                                                  = "master-us-test-1a.masters.minimal-aws.example.com"
+      "aws-node-termination-handler/managed"                                                                  = ""
+      "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/kops-controller-pki"                         = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/control-plane"                   = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node.kubernetes.io/exclude-from-external-load-balancers" = ""
+      "k8s.io/role/control-plane"                                                                             = "1"
+      "k8s.io/role/master"                                                                                    = "1"
+      "kops.k8s.io/instancegroup"                                                                             = "master-us-test-1a"
+      "kubernetes.io/cluster/minimal-aws.example.com"                                                         = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                                                     = "minimal-aws.example.com"
     "Name"                                                                                                  = "master-us-test-1a.masters.minimal-aws.example.com"
@@ -538,6 +553,18 @@ resource "aws_launch_template" "nodes-minimal-aws-example-com" {
       "kubernetes.io/cluster/minimal-aws.example.com"                              = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                          = "minimal-aws.example.com"
+      "Name"                                                                       = "nodes.minimal-aws.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "nodes"
+      "kubernetes.io/cluster/minimal-aws.example.com"                              = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                          = "minimal-aws.example.com"
     "Name"                                                                       = "nodes.minimal-aws.example.com"
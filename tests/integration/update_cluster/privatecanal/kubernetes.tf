@@ -598,6 +598,17 @@ resource "aws_launch_template" "bastion-privatecanal-example-com" {
       "kubernetes.io/cluster/privatecanal.example.com" = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                              = "privatecanal.example.com"
+      "Name"                                           = "bastion.privatecanal.example.com"
+      "aws-node-termination-handler/managed"           = ""
+      "k8s.io/role/bastion"                            = "1"
+      "kops.k8s.io/instancegroup"                      = "bastion"
+      "kubernetes.io/cluster/privatecanal.example.com" = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                              = "privatecanal.example.com"
     "Name"                                           = "bastion.privatecanal.example.com"
@@ -679,6 +690,21 @@ resource "aws_launch_template" "master-us-test-1a-masters-privatecanal-example-c
       "kubernetes.io/cluster/privatecanal.example.com"                                                        = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                                                     = "privatecanal.example.com"
+      "Name"                                                                                                  = "master-us-test-1a.masters.privatecanal.example.com"
+      "aws-node-termination-handler/managed"                                                                  = ""
+      "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/kops-controller-pki"                         = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/control-plane"                   = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node.kubernetes.io/exclude-from-external-load-balancers" = ""
+      "k8s.io/role/control-plane"                                                                             = "1"
+      "k8s.io/role/master"                                                                                    = "1"
+      "kops.k8s.io/instancegroup"                                                                             = "master-us-test-1a"
+      "kubernetes.io/cluster/privatecanal.example.com"                                                        = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                                                     = "privatecanal.example.com"
     "Name"                                                                                                  = "master-us-test-1a.masters.privatecanal.example.com"
@@ -755,6 +781,18 @@ resource "aws_launch_template" "nodes-privatecanal-example-com" {
       "kubernetes.io/cluster/privatecanal.example.com"                             = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                          = "privatecanal.example.com"
+      "Name"                                                                       = "nodes.privatecanal.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "nodes"
+      "kubernetes.io/cluster/privatecanal.example.com"                             = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                          = "privatecanal.example.com"
     "Name"                                                                       = "nodes.privatecanal.example.com"
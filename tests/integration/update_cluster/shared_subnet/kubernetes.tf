@@ -453,6 +453,21 @@ resource "aws_launch_template" "master-us-test-1a-masters-sharedsubnet-example-c
       "kubernetes.io/cluster/sharedsubnet.example.com"                                                        = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                                                     = "sharedsubnet.example.com"
+      "Name"                                                                                                  = "master-us-test-1a.masters.sharedsubnet.example.com"
+      "aws-node-termination-handler/managed"                                                                  = ""
+      "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/kops-controller-pki"                         = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/control-plane"                   = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node.kubernetes.io/exclude-from-external-load-balancers" = ""
+      "k8s.io/role/control-plane"                                                                             = "1"
+      "k8s.io/role/master"                                                                                    = "1"
+      "kops.k8s.io/instancegroup"                                                                             = "master-us-test-1a"
+      "kubernetes.io/cluster/sharedsubnet.example.com"                                                        = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                                                     = "sharedsubnet.example.com"
     "Name"                                                                                                  = "master-us-test-1a.masters.sharedsubnet.example.com"
@@ -529,6 +544,18 @@ resource "aws_launch_template" "nodes-sharedsubnet-example-com" {
       "kubernetes.io/cluster/sharedsubnet.example.com"                             = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                          = "sharedsubnet.example.com"
+      "Name"                                                                       = "nodes.sharedsubnet.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "nodes"
+      "kubernetes.io/cluster/sharedsubnet.example.com"                             = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                          = "sharedsubnet.example.com"
     "Name"                                                                       = "nodes.sharedsubnet.example.com"
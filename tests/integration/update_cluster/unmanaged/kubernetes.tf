@@ -580,6 +580,17 @@ resource "aws_launch_template" "bastion-unmanaged-example-com" {
       "kubernetes.io/cluster/unmanaged.example.com" = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                           = "unmanaged.example.com"
+      "Name"                                        = "bastion.unmanaged.example.com"
+      "aws-node-termination-handler/managed"        = ""
+      "k8s.io/role/bastion"                         = "1"
+      "kops.k8s.io/instancegroup"                   = "bastion"
+      "kubernetes.io/cluster/unmanaged.example.com" = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                           = "unmanaged.example.com"
     "Name"                                        = "bastion.unmanaged.example.com"
@@ -661,6 +672,21 @@ resource "aws_launch_template" "master-us-test-1a-masters-unmanaged-example-com"
       "kubernetes.io/cluster/unmanaged.example.com"                                                           = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                                                     = "unmanaged.example.com"
+      "Name"                                                                                                  = "master-us-test-1a.masters.unmanaged.example.com"
+      "aws-node-termination-handler/managed"                                                                  = ""
+      "k8s.io/cluster-autoscaler/node-template/label/kops.k8s.io/kops-controller-pki"                         = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/control-plane"                   = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node.kubernetes.io/exclude-from-external-load-balancers" = ""
+      "k8s.io/role/control-plane"                                                                             = "1"
+      "k8s.io/role/master"                                                                                    = "1"
+      "kops.k8s.io/instancegroup"                                                                             = "master-us-test-1a"
+      "kubernetes.io/cluster/unmanaged.example.com"                                                           = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                                                     = "unmanaged.example.com"
     "Name"                                                                                                  = "master-us-test-1a.masters.unmanaged.example.com"
@@ -737,6 +763,18 @@ resource "aws_launch_template" "nodes-unmanaged-example-com" {
       "kubernetes.io/cluster/unmanaged.example.com"                                = "owned"
     }
   }
+  tag_specifications {
+    resource_type = "network-interface"
+    tags = {
+      "KubernetesCluster"                                                          = "unmanaged.example.com"
+      "Name"                                                                       = "nodes.unmanaged.example.com"
+      "aws-node-termination-handler/managed"                                       = ""
+      "k8s.io/cluster-autoscaler/node-template/label/node-role.kubernetes.io/node" = ""
+      "k8s.io/role/node"                                                           = "1"
+      "kops.k8s.io/instancegroup"                                                  = "nodes"
+      "kubernetes.io/cluster/unmanaged.example.com"                                = "owned"
+    }
+  }
   tags = {
     "KubernetesCluster"                                                          = "unmanaged.example.com"
     "Name"                                                                       = "nodes.unmanaged.example.com"
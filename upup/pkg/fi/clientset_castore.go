@@ -67,7 +67,8 @@ func NewClientsetSSHCredentialStore(cluster *kops.Cluster, clientset kopsinterna
 	return c
 }
 
-func parseKeyset(o *kops.Keyset) (*Keyset, error) {
+// ParseKeyset converts a Keyset API object, as read from a state store or a manifest.
+func ParseKeyset(o *kops.Keyset) (*Keyset, error) {
 	name := o.Name
 
 	keyset := &Keyset{
@@ -117,7 +118,7 @@ func (c *ClientsetCAStore) loadKeyset(ctx context.Context, name string) (*Keyset
 		return nil, fmt.Errorf("error reading keyset %q: %v", name, err)
 	}
 
-	keyset, err := parseKeyset(o)
+	keyset, err := ParseKeyset(o)
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +176,7 @@ func (c *ClientsetCAStore) ListKeysets() (map[string]*Keyset, error) {
 			keyset := &list.Items[i]
 			switch keyset.Spec.Type {
 			case kops.SecretTypeKeypair:
-				item, err := parseKeyset(keyset)
+				item, err := ParseKeyset(keyset)
 				if err != nil {
 					return nil, fmt.Errorf("parsing keyset %q: %w", keyset.Name, err)
 				}
@@ -36,6 +36,7 @@ import (
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/featureflag"
+	"k8s.io/kops/pkg/kubeconfig"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/pkg/model/awsmodel"
@@ -110,6 +111,18 @@ type ApplyClusterCmd struct {
 	// RunTasksOptions defines parameters for task execution, e.g. retry interval
 	RunTasksOptions *fi.RunTasksOptions
 
+	// Incremental skips the tasks whose desired state has not changed since the last apply,
+	// instead of comparing them with the cloud resources.
+	Incremental bool
+
+	// RetryFailed runs only the tasks that did not complete in the last apply, which failed part-way.
+	RetryFailed bool
+
+	// AWSResourceWaits overrides the timeouts of the waits for the AWS resources created by the apply.
+	AWSResourceWaits *awsup.ResourceWaitTimeouts
+	// AWSRateLimit overrides the client-side limit of the requests to the AWS APIs while the tasks run.
+	AWSRateLimit *awsup.RateLimit
+
 	// The channel we are using
 	channel *kops.Channel
 
@@ -117,7 +130,8 @@ type ApplyClusterCmd struct {
 	Phase Phase
 
 	// LifecycleOverrides is passed in to override the lifecycle for one of more tasks.
-	// The key value is the task name such as InternetGateway and the value is the fi.Lifecycle
+	// The key value is the task name such as InternetGateway, optionally followed by a pattern
+	// matching task names such as SecurityGroupRule:api-elb*, and the value is the fi.Lifecycle
 	// that is re-mapped.
 	LifecycleOverrides map[string]fi.Lifecycle
 
@@ -137,6 +151,9 @@ type ApplyClusterCmd struct {
 
 	// DeletionProcessing controls whether we process deletions.
 	DeletionProcessing fi.DeletionProcessingMode
+
+	// Out is where the dry-run report is written; defaults to os.Stdout.
+	Out io.Writer
 }
 
 func (c *ApplyClusterCmd) Run(ctx context.Context) error {
@@ -559,6 +576,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 			},
 			&model.MasterVolumeBuilder{KopsModelContext: modelContext, Lifecycle: clusterLifecycle},
 			&model.ConfigBuilder{KopsModelContext: modelContext, Lifecycle: clusterLifecycle},
+			&model.ExternalTaskModelBuilder{KopsModelContext: modelContext, Lifecycle: clusterLifecycle},
 		)
 
 		switch cluster.Spec.GetCloudProvider() {
@@ -577,6 +595,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 				&awsmodel.NetworkModelBuilder{AWSModelContext: awsModelContext, Lifecycle: networkLifecycle},
 				&awsmodel.IAMModelBuilder{AWSModelContext: awsModelContext, Lifecycle: securityLifecycle, Cluster: cluster},
 				&awsmodel.OIDCProviderBuilder{AWSModelContext: awsModelContext, Lifecycle: securityLifecycle, KeyStore: keyStore},
+				&awsmodel.SessionManagerModelBuilder{AWSModelContext: awsModelContext, Lifecycle: securityLifecycle},
 			)
 
 			awsModelBuilder := &awsmodel.AutoscalingGroupModelBuilder{
@@ -682,6 +701,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 				&scalewaymodel.APILoadBalancerModelBuilder{ScwModelContext: scwModelContext, Lifecycle: networkLifecycle},
 				&scalewaymodel.DNSModelBuilder{ScwModelContext: scwModelContext, Lifecycle: networkLifecycle},
 				&scalewaymodel.InstanceModelBuilder{ScwModelContext: scwModelContext, BootstrapScriptBuilder: bootstrapScriptBuilder, Lifecycle: clusterLifecycle},
+				&scalewaymodel.NetworkModelBuilder{ScwModelContext: scwModelContext, Lifecycle: networkLifecycle},
 				&scalewaymodel.SSHKeyModelBuilder{ScwModelContext: scwModelContext, Lifecycle: securityLifecycle},
 			)
 
@@ -689,7 +709,16 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 			return fmt.Errorf("unknown cloudprovider %q", cluster.Spec.GetCloudProvider())
 		}
 	}
-	c.TaskMap, err = l.BuildTasks(ctx, c.LifecycleOverrides)
+	// Lifecycle overrides from the command line take precedence over those persisted in the cluster spec
+	lifecycleOverrides := make(map[string]fi.Lifecycle)
+	for key, lifecycle := range cluster.Spec.LifecycleOverrides {
+		lifecycleOverrides[key] = fi.Lifecycle(lifecycle)
+	}
+	for key, lifecycle := range c.LifecycleOverrides {
+		lifecycleOverrides[key] = lifecycle
+	}
+
+	c.TaskMap, err = l.BuildTasks(ctx, lifecycleOverrides)
 	if err != nil {
 		return fmt.Errorf("error building tasks: %v", err)
 	}
@@ -704,7 +733,12 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		case kops.CloudProviderGCE:
 			target = gce.NewGCEAPITarget(cloud.(gce.GCECloud))
 		case kops.CloudProviderAWS:
-			target = awsup.NewAWSAPITarget(cloud.(awsup.AWSCloud))
+			// The breaker of the mutations is scoped to this apply, as the cloud is shared by the whole process
+			awsTarget := awsup.NewAWSAPITarget(cloud.(awsup.AWSCloud), awsup.NewMutationRetrier())
+			if c.AWSResourceWaits != nil {
+				awsTarget.ResourceWaits = *c.AWSResourceWaits
+			}
+			target = awsTarget
 		case kops.CloudProviderDO:
 			target = do.NewDOAPITarget(cloud.(do.DOCloud))
 		case kops.CloudProviderHetzner:
@@ -722,6 +756,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	case TargetTerraform:
 		outDir := c.OutDir
 		tf := terraform.NewTerraformTarget(cloud, project, outDir, cluster.Spec.Target)
+		tf.StreamFilesTo(outDir)
 
 		// We include a few "util" variables in the TF output
 		if err := tf.AddOutputVariable("region", terraformWriter.LiteralFromStringValue(cloud.Region())); err != nil {
@@ -753,6 +788,9 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 
 	case TargetDryRun:
 		var out io.Writer = os.Stdout
+		if c.Out != nil {
+			out = c.Out
+		}
 		if c.GetAssets {
 			out = io.Discard
 		}
@@ -766,8 +804,13 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	}
 	c.Target = target
 
+	if awsCloud, ok := cloud.(awsup.AWSCloud); ok {
+		// Tasks describe the same subnets, security groups and instances many times
+		defer awsup.EnableDescribeCache(awsCloud)()
+	}
+
 	if target.DefaultCheckExisting() {
-		c.TaskMap, err = l.FindDeletions(cloud, c.LifecycleOverrides)
+		c.TaskMap, err = l.FindDeletions(cloud, lifecycleOverrides)
 		if err != nil {
 			return fmt.Errorf("error finding deletions: %w", err)
 		}
@@ -785,8 +828,54 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		options.InitDefaults()
 	}
 
+	// The fingerprints of the tasks are recorded by full applies, so that the next incremental apply can skip them
+	var incremental *fi.IncrementalOptions
+	if c.TargetName == TargetDirect && c.Phase == "" {
+		incremental = &fi.IncrementalOptions{
+			Current: fi.NewIncrementalState(kopsbase.Version),
+		}
+		if c.RetryFailed {
+			incremental.Previous, err = readFailedApplyState(ctx, configBase)
+			if err != nil {
+				return err
+			}
+		} else if c.Incremental {
+			incremental.Previous, err = readIncrementalState(ctx, configBase)
+			if err != nil {
+				return err
+			}
+		}
+	} else if c.Incremental || c.RetryFailed {
+		klog.Warningf("incremental apply and retries are only supported by full applies with the %s target, running all tasks", TargetDirect)
+	}
+	options.Incremental = incremental
+
+	// The tasks running in parallel share a client-side limit, so that they are not throttled
+	disableRateLimit := func() {}
+	if awsCloud, ok := cloud.(awsup.AWSCloud); ok {
+		rateLimit := awsup.DefaultRateLimit()
+		if c.AWSRateLimit != nil {
+			rateLimit = *c.AWSRateLimit
+		}
+		disableRateLimit = awsup.EnableRateLimit(awsCloud, rateLimit)
+	}
 	err = context.RunTasks(options)
+	disableRateLimit()
 	if err != nil {
+		tagsErr := reconcileTagsOfFailedApply(ctx, target)
+		if tagsErr != nil {
+			klog.Warningf("unable to tag the resources of the tasks that completed: %v", tagsErr)
+		}
+		if incremental != nil {
+			// Some changes may have been applied, so the fingerprints of the last apply no longer match the cloud resources
+			removeIncrementalState(ctx, configBase)
+			if tagsErr == nil {
+				c.recordFailedApply(ctx, configBase, incremental.Current)
+			} else {
+				// The tasks that completed must run again to add their tags
+				removeFailedApplyState(ctx, configBase)
+			}
+		}
 		return fmt.Errorf("error running tasks: %v", err)
 	}
 
@@ -800,17 +889,60 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		}
 	}
 
+	if tf, ok := target.(*terraform.TerraformTarget); ok && tf.ManageAddons() {
+		// The CA may only have been issued while running the tasks, so the credentials are built afterwards
+		kubecfg, err := kubeconfig.BuildKubecfg(ctx, cluster, keyStore, secretStore, cloud, kubeconfig.DefaultKubecfgAdminLifetime, "", false, "", false, false)
+		if err != nil {
+			return fmt.Errorf("error building credentials of the kubectl provider: %w", err)
+		}
+		tf.KubernetesProvider = &terraform.KubernetesProvider{
+			Server:            kubecfg.Server,
+			CACertificates:    kubecfg.CACerts,
+			ClientCertificate: kubecfg.ClientCert,
+			ClientKey:         kubecfg.ClientKey,
+		}
+	}
+
 	err = target.Finish(c.TaskMap) // This will finish the apply, and print the changes
 	if err != nil {
+		if incremental != nil {
+			removeIncrementalState(ctx, configBase)
+		}
 		return fmt.Errorf("error closing target: %v", err)
 	}
 
+	if incremental != nil {
+		if err := writeIncrementalState(ctx, configBase, cluster, incremental.Current); err != nil {
+			klog.Warningf("unable to record the fingerprints of the tasks, the next incremental apply will run all tasks: %v", err)
+		}
+		removeFailedApplyState(ctx, configBase)
+	}
+
 	c.ImageAssets = assetBuilder.ImageAssets
 	c.FileAssets = assetBuilder.FileAssets
 
 	return nil
 }
 
+// reconcileTagsOfFailedApply adds the tags batched by the tasks that completed before the apply failed,
+// such as the tags that associate elastic IPs and NAT gateways with their subnets, by which their resources are found again.
+func reconcileTagsOfFailedApply(ctx context.Context, target fi.CloudupTarget) error {
+	awsTarget, ok := target.(*awsup.AWSAPITarget)
+	if !ok {
+		return nil
+	}
+	return awsTarget.ReconcileTags(ctx)
+}
+
+// recordFailedApply records the tasks that completed before the apply failed, so that a retry can skip them.
+func (c *ApplyClusterCmd) recordFailedApply(ctx context.Context, configBase vfs.Path, state *fi.IncrementalState) {
+	if err := writeFailedApplyState(ctx, configBase, c.Cluster, state); err != nil {
+		klog.Warningf("unable to record the progress of the apply, a retry will run all tasks: %v", err)
+		return
+	}
+	klog.Infof("%d tasks failed and %d were blocked by them; to retry only these tasks, run kops update cluster --yes --retry-failed", len(state.Failed), len(state.Blocked))
+}
+
 // upgradeSpecs ensures that fields are fully populated / defaulted
 func (c *ApplyClusterCmd) upgradeSpecs(ctx context.Context, assetBuilder *assets.AssetBuilder) error {
 	fullCluster, err := PopulateClusterSpec(ctx, c.Clientset, c.Cluster, c.InstanceGroups, c.Cloud, assetBuilder)
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// failingTask is a task that always fails.
+type failingTask struct{}
+
+func (t *failingTask) Run(*fi.CloudupContext) error {
+	return fmt.Errorf("task failed")
+}
+
+func TestReconcileTagsOfFailedApply(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-test-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	buildTasks := func() map[string]fi.CloudupTask {
+		vpc := &awstasks.VPC{
+			Name:      fi.PtrTo("vpc"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      fi.PtrTo("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc"},
+		}
+		subnet := &awstasks.Subnet{
+			Name:      fi.PtrTo("subnet"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc,
+			CIDR:      fi.PtrTo("172.20.1.0/24"),
+			Tags:      map[string]string{"Name": "subnet"},
+		}
+		eip := &awstasks.ElasticIP{
+			Name:        fi.PtrTo("eip"),
+			Lifecycle:   fi.LifecycleSync,
+			TagOnSubnet: subnet,
+			Tags:        map[string]string{"Name": "eip"},
+		}
+		return map[string]fi.CloudupTask{
+			"vpc":    vpc,
+			"subnet": subnet,
+			"eip":    eip,
+			"failed": &failingTask{},
+		}
+	}
+
+	options := fi.RunTasksOptions{
+		MaxTaskDuration:         time.Second,
+		WaitAfterAllTasksFailed: 100 * time.Millisecond,
+	}
+	apply := func() map[string]fi.CloudupTask {
+		tasks := buildTasks()
+		target := awsup.NewAWSAPITarget(cloud, nil)
+		context, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, tasks)
+		if err != nil {
+			t.Fatalf("error building context: %v", err)
+		}
+		if err := context.RunTasks(options); err == nil {
+			t.Fatalf("expected the apply to fail")
+		}
+		if err := reconcileTagsOfFailedApply(ctx, target); err != nil {
+			t.Fatalf("error reconciling tags: %v", err)
+		}
+		return tasks
+	}
+
+	tasks := apply()
+	subnetID := fi.ValueOf(tasks["subnet"].(*awstasks.Subnet).ID)
+	tags, err := cloud.GetTags(subnetID)
+	if err != nil {
+		t.Fatalf("error getting tags of subnet: %v", err)
+	}
+	if tags["AssociatedElasticIp"] == "" {
+		t.Errorf("subnet was not tagged with its elastic IP after the failed apply: %v", tags)
+	}
+
+	// The next apply finds the elastic IP by the tag of its subnet, instead of allocating another one
+	apply()
+	if len(c.Addresses) != 1 {
+		t.Errorf("expected 1 elastic IP after the second apply, got %d", len(c.Addresses))
+	}
+}
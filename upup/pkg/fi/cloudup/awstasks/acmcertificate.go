@@ -0,0 +1,457 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// ACMCertificate is an ACM certificate requested by kOps and validated with DNS records in the cluster's hosted zone.
+// ACM renews the certificate in place; when the names change, a new certificate is requested and the listeners
+// switch to it. Superseded certificates are deleted when pruning, once nothing uses them anymore.
+// +kops:fitask
+type ACMCertificate struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	// DomainName is the main name the certificate is valid for.
+	DomainName *string
+	// SubjectAlternativeNames are the additional names the certificate is valid for.
+	SubjectAlternativeNames []string
+	// DNSZone is the hosted zone in which the DNS validation records are created.
+	DNSZone *DNSZone
+
+	Tags map[string]string
+
+	// ARN is the ARN of the certificate, set once it is found or requested.
+	ARN *string
+
+	deletions []fi.CloudupDeletion
+}
+
+var _ fi.CompareWithID = &ACMCertificate{}
+var _ fi.CloudupProducesDeletions = &ACMCertificate{}
+
+func (e *ACMCertificate) CompareWithID() *string {
+	return e.Name
+}
+
+func (e *ACMCertificate) Find(c *fi.CloudupContext) (*ACMCertificate, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	certificates, err := findACMCertificates(ctx, cloud, aws.ToString(e.DomainName), e.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the issued certificate for the expected names, falling back to the one in use.
+	// Every other certificate was superseded, or left pending by an interrupted update, and is deleted.
+	var found *acmCertificateInfo
+	for _, certificate := range certificates {
+		if certificate.detail.Status != acmtypes.CertificateStatusIssued {
+			continue
+		}
+		if found == nil || (!e.namesMatch(found) && (e.namesMatch(certificate) || len(certificate.detail.InUseBy) != 0)) {
+			found = certificate
+		}
+	}
+	e.deletions = nil
+	for _, certificate := range certificates {
+		if certificate != found || !e.namesMatch(certificate) {
+			e.deletions = append(e.deletions, &deleteACMCertificate{arn: aws.ToString(certificate.detail.CertificateArn)})
+		}
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+
+	actual := &ACMCertificate{
+		Name:       e.Name,
+		Lifecycle:  e.Lifecycle,
+		DomainName: found.detail.DomainName,
+		DNSZone:    e.DNSZone,
+		Tags:       found.tags,
+		ARN:        found.detail.CertificateArn,
+	}
+	for _, name := range found.detail.SubjectAlternativeNames {
+		if name != aws.ToString(found.detail.DomainName) {
+			actual.SubjectAlternativeNames = append(actual.SubjectAlternativeNames, name)
+		}
+	}
+
+	if e.namesMatch(found) {
+		// Avoid spurious changes from the order of the names
+		actual.SubjectAlternativeNames = e.SubjectAlternativeNames
+		e.ARN = actual.ARN
+	}
+
+	return actual, nil
+}
+
+type acmCertificateInfo struct {
+	detail *acmtypes.CertificateDetail
+	tags   map[string]string
+}
+
+// findACMCertificates returns the certificates for the domain name with the Name and cluster tags of the expected tags.
+// Only the tags and details of the certificates for the domain name are fetched, as the account may hold many certificates.
+// Certificates left for a previous domain name are not found, they are deleted with the cluster.
+func findACMCertificates(ctx context.Context, cloud awsup.AWSCloud, domainName string, tags map[string]string) ([]*acmCertificateInfo, error) {
+	var certificates []*acmCertificateInfo
+
+	paginator := acm.NewListCertificatesPaginator(cloud.ACM(), &acm.ListCertificatesInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing ACM certificates: %w", err)
+		}
+		for _, summary := range page.CertificateSummaryList {
+			if aws.ToString(summary.DomainName) != domainName {
+				continue
+			}
+			tagsResponse, err := cloud.ACM().ListTagsForCertificate(ctx, &acm.ListTagsForCertificateInput{
+				CertificateArn: summary.CertificateArn,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error listing tags of ACM certificate %q: %w", aws.ToString(summary.CertificateArn), err)
+			}
+			certificateTags := make(map[string]string)
+			for _, tag := range tagsResponse.Tags {
+				certificateTags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+			}
+			if certificateTags["Name"] != tags["Name"] || certificateTags[awsup.TagClusterName] != tags[awsup.TagClusterName] {
+				continue
+			}
+
+			response, err := cloud.ACM().DescribeCertificate(ctx, &acm.DescribeCertificateInput{
+				CertificateArn: summary.CertificateArn,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error describing ACM certificate %q: %w", aws.ToString(summary.CertificateArn), err)
+			}
+			certificates = append(certificates, &acmCertificateInfo{detail: response.Certificate, tags: certificateTags})
+		}
+	}
+
+	return certificates, nil
+}
+
+// namesMatch returns true if the certificate is valid for the expected names, and only those.
+func (e *ACMCertificate) namesMatch(certificate *acmCertificateInfo) bool {
+	if aws.ToString(certificate.detail.DomainName) != aws.ToString(e.DomainName) {
+		return false
+	}
+	expected := sets.New(e.SubjectAlternativeNames...).Insert(aws.ToString(e.DomainName))
+	return expected.Equal(sets.New(certificate.detail.SubjectAlternativeNames...))
+}
+
+func (e *ACMCertificate) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (_ *ACMCertificate) CheckChanges(a, e, changes *ACMCertificate) error {
+	if a == nil {
+		if e.Name == nil {
+			return field.Required(field.NewPath("Name"), "")
+		}
+		if e.DomainName == nil {
+			return field.Required(field.NewPath("DomainName"), "")
+		}
+		if e.DNSZone == nil {
+			return field.Required(field.NewPath("DNSZone"), "")
+		}
+	}
+	return nil
+}
+
+func (_ *ACMCertificate) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *ACMCertificate) error {
+	ctx := context.TODO()
+
+	if a != nil && changes.DomainName == nil && changes.SubjectAlternativeNames == nil {
+		if changes.Tags != nil {
+			klog.V(2).Infof("Updating tags of ACM certificate %q", aws.ToString(a.ARN))
+			_, err := t.Cloud.ACM().AddTagsToCertificate(ctx, &acm.AddTagsToCertificateInput{
+				CertificateArn: a.ARN,
+				Tags:           acmTags(e.Tags),
+			})
+			if err != nil {
+				return fmt.Errorf("error tagging ACM certificate %q: %w", aws.ToString(a.ARN), err)
+			}
+		}
+		return nil
+	}
+
+	// The names of a certificate cannot be changed, so a new certificate replaces the existing one.
+	klog.V(2).Infof("Requesting ACM certificate for %q", aws.ToString(e.DomainName))
+	request := &acm.RequestCertificateInput{
+		DomainName:       e.DomainName,
+		ValidationMethod: acmtypes.ValidationMethodDns,
+		Tags:             acmTags(e.Tags),
+	}
+	if len(e.SubjectAlternativeNames) != 0 {
+		request.SubjectAlternativeNames = append([]string{aws.ToString(e.DomainName)}, e.SubjectAlternativeNames...)
+	}
+	response, err := t.Cloud.ACM().RequestCertificate(ctx, request)
+	if err != nil {
+		return fmt.Errorf("error requesting ACM certificate for %q: %w", aws.ToString(e.DomainName), err)
+	}
+	e.ARN = response.CertificateArn
+
+	return e.validate(ctx, t.Cloud)
+}
+
+// validate creates the DNS records validating the certificate, then waits for the certificate to be issued.
+func (e *ACMCertificate) validate(ctx context.Context, cloud awsup.AWSCloud) error {
+	arn := aws.ToString(e.ARN)
+
+	if e.DNSZone == nil || e.DNSZone.ZoneID == nil {
+		return fmt.Errorf("DNS zone for ACM certificate %q is not known", arn)
+	}
+
+	// ACM fills in the validation records shortly after the certificate is requested
+	var records []*acmtypes.ResourceRecord
+	for attempt := 0; ; attempt++ {
+		response, err := cloud.ACM().DescribeCertificate(ctx, &acm.DescribeCertificateInput{
+			CertificateArn: e.ARN,
+		})
+		if err != nil {
+			return fmt.Errorf("error describing ACM certificate %q: %w", arn, err)
+		}
+		records = nil
+		for _, validation := range response.Certificate.DomainValidationOptions {
+			if validation.ResourceRecord == nil {
+				records = nil
+				break
+			}
+			records = append(records, validation.ResourceRecord)
+		}
+		if len(records) != 0 {
+			break
+		}
+		if attempt >= 60 {
+			return fmt.Errorf("timed out waiting for the validation records of ACM certificate %q", arn)
+		}
+		time.Sleep(5 * time.Second)
+	}
+
+	changes := make(map[string]route53types.Change)
+	for _, record := range records {
+		name := aws.ToString(record.Name)
+		changes[name] = route53types.Change{
+			Action: route53types.ChangeActionUpsert,
+			ResourceRecordSet: &route53types.ResourceRecordSet{
+				Name:            record.Name,
+				Type:            route53types.RRType(record.Type),
+				TTL:             aws.Int64(300),
+				ResourceRecords: []route53types.ResourceRecord{{Value: record.Value}},
+			},
+		}
+	}
+	// Wildcard names share the validation record of their base name
+	var names []string
+	for name := range changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	changeBatch := &route53types.ChangeBatch{
+		Comment: aws.String("Validation of ACM certificate " + arn),
+	}
+	for _, name := range names {
+		changeBatch.Changes = append(changeBatch.Changes, changes[name])
+	}
+
+	klog.V(2).Infof("Creating DNS records validating ACM certificate %q", arn)
+	if _, err := cloud.Route53().ChangeResourceRecordSets(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: e.DNSZone.ZoneID,
+		ChangeBatch:  changeBatch,
+	}); err != nil {
+		return fmt.Errorf("error creating DNS records validating ACM certificate %q: %w", arn, err)
+	}
+
+	klog.Infof("Waiting for ACM certificate %q to be validated", arn)
+	waiter := acm.NewCertificateValidatedWaiter(cloud.ACM(), func(o *acm.CertificateValidatedWaiterOptions) {
+		o.MinDelay = 10 * time.Second
+	})
+	if err := waiter.Wait(ctx, &acm.DescribeCertificateInput{CertificateArn: e.ARN}, 45*time.Minute); err != nil {
+		return fmt.Errorf("error waiting for ACM certificate %q to be validated: %w", arn, err)
+	}
+
+	return nil
+}
+
+func acmTags(tags map[string]string) []acmtypes.Tag {
+	var keys []string
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var acmTags []acmtypes.Tag
+	for _, k := range keys {
+		acmTags = append(acmTags, acmtypes.Tag{Key: aws.String(k), Value: aws.String(tags[k])})
+	}
+	return acmTags
+}
+
+// FindDeletions returns the superseded certificates and those left pending by an interrupted update.
+func (e *ACMCertificate) FindDeletions(c *fi.CloudupContext) ([]fi.CloudupDeletion, error) {
+	return e.deletions, nil
+}
+
+// deleteACMCertificate tracks an ACMCertificate that we're going to delete
+// It implements fi.CloudupDeletion
+type deleteACMCertificate struct {
+	arn string
+}
+
+var _ fi.CloudupDeletion = &deleteACMCertificate{}
+
+func (d *deleteACMCertificate) Delete(t fi.CloudupTarget) error {
+	ctx := context.TODO()
+
+	awsTarget, ok := t.(*awsup.AWSAPITarget)
+	if !ok {
+		return fmt.Errorf("unexpected target type for deletion: %T", t)
+	}
+
+	klog.V(2).Infof("deleting ACM certificate %q", d.arn)
+	if _, err := awsTarget.Cloud.ACM().DeleteCertificate(ctx, &acm.DeleteCertificateInput{
+		CertificateArn: aws.String(d.arn),
+	}); err != nil {
+		var inUse *acmtypes.ResourceInUseException
+		if errors.As(err, &inUse) {
+			// The listeners switch to the new certificate later in the update; it is deleted on the next one
+			klog.Warningf("not deleting ACM certificate %q, which is still in use", d.arn)
+			return nil
+		}
+		return fmt.Errorf("error deleting ACM certificate %q: %w", d.arn, err)
+	}
+
+	return nil
+}
+
+// String returns a string representation of the task
+func (d *deleteACMCertificate) String() string {
+	return d.TaskName() + "-" + d.Item()
+}
+
+func (d *deleteACMCertificate) TaskName() string {
+	return "acm-certificate"
+}
+
+func (d *deleteACMCertificate) Item() string {
+	return d.arn
+}
+
+func (d *deleteACMCertificate) DeferDeletion() bool {
+	return true
+}
+
+type terraformACMCertificate struct {
+	DomainName              *string              `cty:"domain_name"`
+	SubjectAlternativeNames []string             `cty:"subject_alternative_names"`
+	ValidationMethod        *string              `cty:"validation_method"`
+	Tags                    map[string]string    `cty:"tags"`
+	Lifecycle               *terraform.Lifecycle `cty:"lifecycle"`
+}
+
+type terraformACMValidationRecord struct {
+	AllowOverwrite *bool                      `cty:"allow_overwrite"`
+	Name           *terraformWriter.Literal   `cty:"name"`
+	Records        []*terraformWriter.Literal `cty:"records"`
+	TTL            *int64                     `cty:"ttl"`
+	Type           *terraformWriter.Literal   `cty:"type"`
+	ZoneID         *terraformWriter.Literal   `cty:"zone_id"`
+}
+
+type terraformACMCertificateValidation struct {
+	CertificateARN        *terraformWriter.Literal   `cty:"certificate_arn"`
+	ValidationRecordFQDNs []*terraformWriter.Literal `cty:"validation_record_fqdns"`
+}
+
+func (_ *ACMCertificate) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *ACMCertificate) error {
+	tfName := e.TerraformName()
+
+	tf := &terraformACMCertificate{
+		DomainName:              e.DomainName,
+		SubjectAlternativeNames: e.SubjectAlternativeNames,
+		ValidationMethod:        aws.String(string(acmtypes.ValidationMethodDns)),
+		Tags:                    e.Tags,
+		// The listeners switch to the replacement certificate before the existing one is destroyed
+		Lifecycle: &terraform.Lifecycle{CreateBeforeDestroy: fi.PtrTo(true)},
+	}
+	if err := t.RenderResource("aws_acm_certificate", tfName, tf); err != nil {
+		return err
+	}
+
+	validation := &terraformACMCertificateValidation{
+		CertificateARN: terraformWriter.LiteralProperty("aws_acm_certificate", tfName, "arn"),
+	}
+	names := append([]string{aws.ToString(e.DomainName)}, e.SubjectAlternativeNames...)
+	for i, name := range names {
+		// domain_validation_options is a set, so the option of each name is selected by its domain name
+		option := func(property string) *terraformWriter.Literal {
+			return &terraformWriter.Literal{
+				String: fmt.Sprintf("one([for dvo in aws_acm_certificate.%s.domain_validation_options : dvo.%s if dvo.domain_name == %q])", tfName, property, name),
+			}
+		}
+		recordName := fmt.Sprintf("%s-validation-%d", tfName, i)
+		record := &terraformACMValidationRecord{
+			// Wildcard names share the validation record of their base name
+			AllowOverwrite: fi.PtrTo(true),
+			Name:           option("resource_record_name"),
+			Records:        []*terraformWriter.Literal{option("resource_record_value")},
+			TTL:            fi.PtrTo(int64(300)),
+			Type:           option("resource_record_type"),
+			ZoneID:         e.DNSZone.TerraformLink(),
+		}
+		if err := t.RenderResource("aws_route53_record", recordName, record); err != nil {
+			return err
+		}
+		validation.ValidationRecordFQDNs = append(validation.ValidationRecordFQDNs, terraformWriter.LiteralProperty("aws_route53_record", recordName, "fqdn"))
+	}
+
+	return t.RenderResource("aws_acm_certificate_validation", tfName, validation)
+}
+
+func (e *ACMCertificate) TerraformName() string {
+	return fi.ValueOf(e.Name)
+}
+
+// TerraformLink returns the ARN of the certificate once it is validated.
+func (e *ACMCertificate) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("aws_acm_certificate_validation", e.TerraformName(), "certificate_arn")
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
+	acmtypes "github.com/aws/aws-sdk-go-v2/service/acm/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"k8s.io/kops/cloudmock/aws/mockacm"
+	"k8s.io/kops/cloudmock/aws/mockroute53"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestACMCertificateRotation(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	acmClient := &mockacm.MockACM{}
+	cloud.MockACM = acmClient
+	route53Client := &mockroute53.MockRoute53{}
+	cloud.MockRoute53 = route53Client
+
+	route53Client.MockCreateZone(&route53types.HostedZone{
+		Id:     aws.String("/hostedzone/Z1AFAKE1ZON3YO"),
+		Name:   aws.String("example.com."),
+		Config: &route53types.HostedZoneConfig{PrivateZone: false},
+	}, nil)
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(subjectAlternativeNames []string) map[string]fi.CloudupTask {
+		zone := &DNSZone{
+			Name:      s("example.com"),
+			Lifecycle: fi.LifecycleSync,
+			DNSName:   s("example.com"),
+			ZoneID:    s("Z1AFAKE1ZON3YO"),
+			Private:   fi.PtrTo(false),
+		}
+		certificate := &ACMCertificate{
+			Name:                    s("api.cluster.example.com"),
+			Lifecycle:               fi.LifecycleSync,
+			DomainName:              s("api.cluster.example.com"),
+			SubjectAlternativeNames: subjectAlternativeNames,
+			DNSZone:                 zone,
+			Tags: map[string]string{
+				"Name":              "api.cluster.example.com",
+				"KubernetesCluster": "cluster.example.com",
+			},
+		}
+		return map[string]fi.CloudupTask{
+			"zone":        zone,
+			"certificate": certificate,
+		}
+	}
+
+	issued := func(t *testing.T) []*acmtypes.CertificateDetail {
+		t.Helper()
+		var certificates []*acmtypes.CertificateDetail
+		for arn := range acmClient.Certificates {
+			response, err := acmClient.DescribeCertificate(ctx, &acm.DescribeCertificateInput{CertificateArn: aws.String(arn)})
+			if err != nil {
+				t.Fatalf("error describing certificate: %v", err)
+			}
+			if response.Certificate.Status != acmtypes.CertificateStatusIssued {
+				t.Errorf("certificate %q has status %q, expected %q", arn, response.Certificate.Status, acmtypes.CertificateStatusIssued)
+			}
+			certificates = append(certificates, response.Certificate)
+		}
+		return certificates
+	}
+
+	var firstARN string
+	{
+		allTasks := buildTasks(nil)
+		certificate := allTasks["certificate"].(*ACMCertificate)
+
+		runTasks(t, cloud, allTasks)
+
+		firstARN = aws.ToString(certificate.ARN)
+		if firstARN == "" {
+			t.Fatalf("ARN not set after create")
+		}
+		if certificates := issued(t); len(certificates) != 1 {
+			t.Fatalf("expected one certificate, found %d", len(certificates))
+		}
+
+		records, err := route53Client.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{HostedZoneId: aws.String("/hostedzone/Z1AFAKE1ZON3YO")})
+		if err != nil {
+			t.Fatalf("error listing records: %v", err)
+		}
+		found := false
+		for _, record := range records.ResourceRecordSets {
+			if aws.ToString(record.Name) == "_validation.api.cluster.example.com." && record.Type == route53types.RRTypeCname {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("validation record not created, found %v", records.ResourceRecordSets)
+		}
+
+		// Check it doesn't try to request the certificate again
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	// The superseded certificate is kept while a listener uses it
+	acmClient.MockSetInUseBy(firstARN, []string{"arn:aws-test:elasticloadbalancing:us-east-1:000000000000:loadbalancer/net/api/1"})
+	var secondARN string
+	{
+		allTasks := buildTasks([]string{"api.example.com"})
+		certificate := allTasks["certificate"].(*ACMCertificate)
+
+		runTasks(t, cloud, allTasks)
+
+		secondARN = aws.ToString(certificate.ARN)
+		if secondARN == "" || secondARN == firstARN {
+			t.Fatalf("expected a new certificate, got %q", secondARN)
+		}
+		if certificates := issued(t); len(certificates) != 2 {
+			t.Fatalf("expected two certificates, found %d", len(certificates))
+		}
+	}
+
+	// Once the listener uses the new certificate, the old one is deleted
+	acmClient.MockSetInUseBy(firstARN, nil)
+	{
+		allTasks := buildTasks([]string{"api.example.com"})
+		certificate := allTasks["certificate"].(*ACMCertificate)
+
+		runTasks(t, cloud, allTasks)
+
+		if aws.ToString(certificate.ARN) != secondARN {
+			t.Fatalf("expected certificate %q to be reused, got %q", secondARN, aws.ToString(certificate.ARN))
+		}
+		certificates := issued(t)
+		if len(certificates) != 1 || aws.ToString(certificates[0].CertificateArn) != secondARN {
+			t.Fatalf("expected only certificate %q to remain", secondARN)
+		}
+		if len(certificates[0].SubjectAlternativeNames) != 2 {
+			t.Errorf("unexpected subject alternative names %v", certificates[0].SubjectAlternativeNames)
+		}
+	}
+}
+
+// countingACM counts the requests fetching the tags and details of certificates.
+type countingACM struct {
+	*mockacm.MockACM
+	requests int
+}
+
+func (m *countingACM) ListTagsForCertificate(ctx context.Context, request *acm.ListTagsForCertificateInput, optFns ...func(*acm.Options)) (*acm.ListTagsForCertificateOutput, error) {
+	m.requests++
+	return m.MockACM.ListTagsForCertificate(ctx, request, optFns...)
+}
+
+func (m *countingACM) DescribeCertificate(ctx context.Context, request *acm.DescribeCertificateInput, optFns ...func(*acm.Options)) (*acm.DescribeCertificateOutput, error) {
+	m.requests++
+	return m.MockACM.DescribeCertificate(ctx, request, optFns...)
+}
+
+func TestFindACMCertificatesByDomainName(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	acmClient := &countingACM{MockACM: &mockacm.MockACM{}}
+	cloud.MockACM = acmClient
+
+	tags := map[string]string{
+		"Name":              "api.cluster.example.com",
+		"KubernetesCluster": "cluster.example.com",
+	}
+	request := func(domainName string) {
+		input := &acm.RequestCertificateInput{
+			DomainName:       aws.String(domainName),
+			ValidationMethod: acmtypes.ValidationMethodDns,
+		}
+		for k, v := range tags {
+			input.Tags = append(input.Tags, acmtypes.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		if _, err := acmClient.RequestCertificate(ctx, input); err != nil {
+			t.Fatalf("error requesting certificate: %v", err)
+		}
+	}
+	request("api.cluster.example.com")
+	for i := 0; i < 10; i++ {
+		request(fmt.Sprintf("service-%d.example.com", i))
+	}
+
+	certificates, err := findACMCertificates(ctx, cloud, "api.cluster.example.com", tags)
+	if err != nil {
+		t.Fatalf("error finding certificates: %v", err)
+	}
+	if len(certificates) != 1 || aws.ToString(certificates[0].detail.DomainName) != "api.cluster.example.com" {
+		t.Errorf("expected the certificate of api.cluster.example.com, got %v", certificates)
+	}
+	// The tags and the details of the certificate of the domain name only
+	if acmClient.requests != 2 {
+		t.Errorf("expected 2 requests for the certificate of the domain name, got %d", acmClient.requests)
+	}
+}
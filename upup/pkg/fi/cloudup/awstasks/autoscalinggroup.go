@@ -43,6 +43,11 @@ const (
 	// https://docs.aws.amazon.com/autoscaling/ec2/userguide/ec2-auto-scaling-quotas.html
 	attachLoadBalancerTargetGroupsMaxItems = 10
 	detachLoadBalancerTargetGroupsMaxItems = 10
+
+	// defaultHealthCheckType is the health check type of an ASG that doesn't configure one
+	defaultHealthCheckType = "EC2"
+	// unsetAutoscalingValue clears the default instance warmup and the healthy percentages of an ASG
+	unsetAutoscalingValue = int32(-1)
 )
 
 // AutoscalingGroup provides the definition for a autoscaling group in aws
@@ -53,20 +58,30 @@ type AutoscalingGroup struct {
 	// Lifecycle is the resource lifecycle
 	Lifecycle fi.Lifecycle
 
+	// DefaultInstanceWarmup is the time, in seconds, until a new instance contributes metrics and counts as healthy capacity
+	DefaultInstanceWarmup *int32
 	// Granularity specifys the granularity of the metrics
 	Granularity *string
+	// HealthCheckGracePeriod is the time, in seconds, after an instance enters service before its health is checked
+	HealthCheckGracePeriod *int32
+	// HealthCheckType is the type of health check, EC2 or ELB
+	HealthCheckType *string
 	// InstanceProtection makes new instances in an autoscaling group protected from scale in
 	InstanceProtection *bool
 	// LaunchTemplate is the launch template for the asg
 	LaunchTemplate *LaunchTemplate
 	// LoadBalancers is a list of elastic load balancer names to add to the autoscaling group
 	LoadBalancers []*ClassicLoadBalancer
+	// MaxHealthyPercentage is the percentage of the desired capacity that can be running while instances are replaced
+	MaxHealthyPercentage *int32
 	// MaxInstanceLifetime is the maximum amount of time, in seconds, that an instance can be in service.
 	MaxInstanceLifetime *int32
 	// MaxSize is the max number of nodes in asg
 	MaxSize *int32
 	// Metrics is a collection of metrics to monitor
 	Metrics []string
+	// MinHealthyPercentage is the percentage of the desired capacity that must stay healthy while instances are replaced
+	MinHealthyPercentage *int32
 	// MinSize is the smallest number of nodes in the asg
 	MinSize *int32
 	// MixedInstanceOverrides is a collection of instance types to use with fleet policy
@@ -155,10 +170,47 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 	}
 
 	actual := &AutoscalingGroup{
-		Name:                g.AutoScalingGroupName,
-		MaxSize:             g.MaxSize,
-		MinSize:             g.MinSize,
-		MaxInstanceLifetime: g.MaxInstanceLifetime,
+		Name:                   g.AutoScalingGroupName,
+		MaxSize:                g.MaxSize,
+		MinSize:                g.MinSize,
+		MaxInstanceLifetime:    g.MaxInstanceLifetime,
+		HealthCheckType:        g.HealthCheckType,
+		HealthCheckGracePeriod: g.HealthCheckGracePeriod,
+		DefaultInstanceWarmup:  g.DefaultInstanceWarmup,
+	}
+
+	if g.InstanceMaintenancePolicy != nil {
+		actual.MinHealthyPercentage = g.InstanceMaintenancePolicy.MinHealthyPercentage
+		actual.MaxHealthyPercentage = g.InstanceMaintenancePolicy.MaxHealthyPercentage
+	}
+
+	// Settings that are not set are reset to the AWS defaults, so that removing them from the instance group takes effect.
+	// The defaults are only filled in here, so that new groups and terraform output don't mention them.
+	if actual.HealthCheckType == nil {
+		actual.HealthCheckType = fi.PtrTo(defaultHealthCheckType)
+	}
+	if e.HealthCheckType == nil {
+		e.HealthCheckType = fi.PtrTo(defaultHealthCheckType)
+	}
+	if actual.HealthCheckGracePeriod == nil {
+		actual.HealthCheckGracePeriod = fi.PtrTo(int32(0))
+	}
+	if e.HealthCheckGracePeriod == nil {
+		e.HealthCheckGracePeriod = fi.PtrTo(int32(0))
+	}
+	if actual.DefaultInstanceWarmup == nil {
+		actual.DefaultInstanceWarmup = fi.PtrTo(unsetAutoscalingValue)
+	}
+	if e.DefaultInstanceWarmup == nil {
+		e.DefaultInstanceWarmup = fi.PtrTo(unsetAutoscalingValue)
+	}
+	if actual.MinHealthyPercentage == nil && actual.MaxHealthyPercentage == nil {
+		actual.MinHealthyPercentage = fi.PtrTo(unsetAutoscalingValue)
+		actual.MaxHealthyPercentage = fi.PtrTo(unsetAutoscalingValue)
+	}
+	if e.MinHealthyPercentage == nil && e.MaxHealthyPercentage == nil {
+		e.MinHealthyPercentage = fi.PtrTo(unsetAutoscalingValue)
+		e.MaxHealthyPercentage = fi.PtrTo(unsetAutoscalingValue)
 	}
 
 	// Use 0 as default value when api returns nil (same as model)
@@ -235,6 +287,12 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 	}
 	sort.Stable(OrderTargetGroupsByName(actual.TargetGroups))
 
+	policyDeletions, err := findRemovedAutoscalingPolicies(c, aws.ToString(g.AutoScalingGroupName))
+	if err != nil {
+		return nil, err
+	}
+	e.deletions = append(e.deletions, policyDeletions...)
+
 	if g.VPCZoneIdentifier != nil {
 		subnets := strings.Split(*g.VPCZoneIdentifier, ",")
 		for _, subnet := range subnets {
@@ -290,7 +348,10 @@ func (e *AutoscalingGroup) Find(c *fi.CloudupContext) (*AutoscalingGroup, error)
 			}
 
 			for _, n := range g.MixedInstancesPolicy.LaunchTemplate.Overrides {
-				actual.MixedInstanceOverrides = append(actual.MixedInstanceOverrides, fi.ValueOf(n.InstanceType))
+				// Overrides with instance requirements are found separately
+				if n.InstanceType != nil {
+					actual.MixedInstanceOverrides = append(actual.MixedInstanceOverrides, fi.ValueOf(n.InstanceType))
+				}
 			}
 		}
 	}
@@ -397,6 +458,10 @@ func (v *AutoscalingGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Autos
 			Tags:                             v.AutoscalingGroupTags(),
 			VPCZoneIdentifier:                fi.PtrTo(strings.Join(e.AutoscalingGroupSubnets(), ",")),
 			CapacityRebalance:                e.CapacityRebalance,
+			HealthCheckType:                  e.HealthCheckType,
+			HealthCheckGracePeriod:           e.HealthCheckGracePeriod,
+			DefaultInstanceWarmup:            e.DefaultInstanceWarmup,
+			InstanceMaintenancePolicy:        e.instanceMaintenancePolicy(),
 		}
 
 		//On ASG creation 0 value is forbidden
@@ -462,13 +527,20 @@ func (v *AutoscalingGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Autos
 		}
 
 		// @step: attempt to create the autoscaling group for us
-		if _, err := t.Cloud.Autoscaling().CreateAutoScalingGroup(ctx, request); err != nil {
+		err := t.RetryInvalidIAMInstanceProfile(ctx, "creating AutoScalingGroup", func() error {
+			_, err := t.Cloud.Autoscaling().CreateAutoScalingGroup(ctx, request)
+			return err
+		})
+		if err != nil {
 			code := awsup.AWSErrorCode(err)
 			message := awsup.AWSErrorMessage(err)
 			if code == "ValidationError" && strings.Contains(message, "Invalid IAM Instance Profile name") {
 				klog.V(4).Infof("error creating AutoscalingGroup: %s", message)
 				return fi.NewTryAgainLaterError("waiting for the IAM Instance Profile to be propagated")
 			}
+			if message == "" {
+				return fmt.Errorf("error creating AutoScalingGroup: %w", err)
+			}
 			return fmt.Errorf("error creating AutoScalingGroup: %s", message)
 		}
 
@@ -551,20 +623,16 @@ func (v *AutoscalingGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Autos
 				}
 			}
 
-			if changes.MixedInstanceOverrides != nil {
-				p := request.MixedInstancesPolicy.LaunchTemplate
-				for _, x := range changes.MixedInstanceOverrides {
-					p.Overrides = append(p.Overrides, autoscalingtypes.LaunchTemplateOverrides{InstanceType: fi.PtrTo(x)})
-				}
-				changes.MixedInstanceOverrides = nil
+			// The overrides replace the existing ones, so they are all set from the expected state
+			p := request.MixedInstancesPolicy.LaunchTemplate
+			for _, x := range e.MixedInstanceOverrides {
+				p.Overrides = append(p.Overrides, autoscalingtypes.LaunchTemplateOverrides{InstanceType: fi.PtrTo(x)})
 			}
-
-			if changes.InstanceRequirements != nil {
-				p := request.MixedInstancesPolicy.LaunchTemplate
-
-				p.Overrides = append(p.Overrides, overridesFromInstanceRequirements(changes.InstanceRequirements))
-				changes.InstanceRequirements = nil
+			if e.InstanceRequirements != nil {
+				p.Overrides = append(p.Overrides, overridesFromInstanceRequirements(e.InstanceRequirements))
 			}
+			changes.MixedInstanceOverrides = nil
+			changes.InstanceRequirements = nil
 		}
 
 		if changes.MinSize != nil {
@@ -687,6 +755,24 @@ func (v *AutoscalingGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Autos
 			changes.CapacityRebalance = nil
 		}
 
+		if changes.HealthCheckType != nil {
+			request.HealthCheckType = e.HealthCheckType
+			changes.HealthCheckType = nil
+		}
+		if changes.HealthCheckGracePeriod != nil {
+			request.HealthCheckGracePeriod = e.HealthCheckGracePeriod
+			changes.HealthCheckGracePeriod = nil
+		}
+		if changes.DefaultInstanceWarmup != nil {
+			request.DefaultInstanceWarmup = e.DefaultInstanceWarmup
+			changes.DefaultInstanceWarmup = nil
+		}
+		if changes.MinHealthyPercentage != nil || changes.MaxHealthyPercentage != nil {
+			request.InstanceMaintenancePolicy = e.instanceMaintenancePolicy()
+			changes.MinHealthyPercentage = nil
+			changes.MaxHealthyPercentage = nil
+		}
+
 		empty := &AutoscalingGroup{}
 		if !reflect.DeepEqual(empty, changes) {
 			klog.Warningf("cannot apply changes to AutoScalingGroup: %v", changes)
@@ -762,6 +848,17 @@ func (e *AutoscalingGroup) UseMixedInstancesPolicy() bool {
 	return false
 }
 
+// instanceMaintenancePolicy returns the instance maintenance policy of the asg, or nil if it is not set
+func (e *AutoscalingGroup) instanceMaintenancePolicy() *autoscalingtypes.InstanceMaintenancePolicy {
+	if e.MinHealthyPercentage == nil && e.MaxHealthyPercentage == nil {
+		return nil
+	}
+	return &autoscalingtypes.InstanceMaintenancePolicy{
+		MinHealthyPercentage: e.MinHealthyPercentage,
+		MaxHealthyPercentage: e.MaxHealthyPercentage,
+	}
+}
+
 // AutoscalingGroupTags is responsible for generating the tagging for the asg
 func (e *AutoscalingGroup) AutoscalingGroupTags() []autoscalingtypes.Tag {
 	var list []autoscalingtypes.Tag
@@ -923,6 +1020,8 @@ type terraformAutoscalingMixedInstancesPolicyLaunchTemplateSpecification struct
 type terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride struct {
 	// InstanceType is the instance to use
 	InstanceType *string `cty:"instance_type"`
+	// InstanceRequirements are the requirements of the instance types to use
+	InstanceRequirements []*terraformAutoscalingInstanceRequirements `cty:"instance_requirements"`
 }
 
 type terraformAutoscalingMixedInstancesPolicyLaunchTemplate struct {
@@ -954,29 +1053,38 @@ type terraformMixedInstancesPolicy struct {
 	InstanceDistribution []*terraformAutoscalingInstanceDistribution `cty:"instances_distribution"`
 }
 
+type terraformAutoscalingInstanceMaintenancePolicy struct {
+	MinHealthyPercentage *int32 `cty:"min_healthy_percentage"`
+	MaxHealthyPercentage *int32 `cty:"max_healthy_percentage"`
+}
+
 type terraformWarmPool struct {
 	MinSize *int32 `cty:"min_size"`
 	MaxSize *int32 `cty:"max_group_prepared_capacity"`
 }
 
 type terraformAutoscalingGroup struct {
-	Name                    *string                                          `cty:"name"`
-	LaunchConfigurationName *terraformWriter.Literal                         `cty:"launch_configuration"`
-	LaunchTemplate          *terraformAutoscalingLaunchTemplateSpecification `cty:"launch_template"`
-	MaxSize                 *int32                                           `cty:"max_size"`
-	MinSize                 *int32                                           `cty:"min_size"`
-	MixedInstancesPolicy    []*terraformMixedInstancesPolicy                 `cty:"mixed_instances_policy"`
-	VPCZoneIdentifier       []*terraformWriter.Literal                       `cty:"vpc_zone_identifier"`
-	Tags                    []*terraformASGTag                               `cty:"tag"`
-	MetricsGranularity      *string                                          `cty:"metrics_granularity"`
-	EnabledMetrics          []*string                                        `cty:"enabled_metrics"`
-	SuspendedProcesses      []*string                                        `cty:"suspended_processes"`
-	InstanceProtection      *bool                                            `cty:"protect_from_scale_in"`
-	LoadBalancers           []*terraformWriter.Literal                       `cty:"load_balancers"`
-	TargetGroupARNs         []*terraformWriter.Literal                       `cty:"target_group_arns"`
-	MaxInstanceLifetime     *int32                                           `cty:"max_instance_lifetime"`
-	CapacityRebalance       *bool                                            `cty:"capacity_rebalance"`
-	WarmPool                *terraformWarmPool                               `cty:"warm_pool"`
+	Name                      *string                                          `cty:"name"`
+	LaunchConfigurationName   *terraformWriter.Literal                         `cty:"launch_configuration"`
+	LaunchTemplate            *terraformAutoscalingLaunchTemplateSpecification `cty:"launch_template"`
+	MaxSize                   *int32                                           `cty:"max_size"`
+	MinSize                   *int32                                           `cty:"min_size"`
+	MixedInstancesPolicy      []*terraformMixedInstancesPolicy                 `cty:"mixed_instances_policy"`
+	VPCZoneIdentifier         []*terraformWriter.Literal                       `cty:"vpc_zone_identifier"`
+	Tags                      []*terraformASGTag                               `cty:"tag"`
+	MetricsGranularity        *string                                          `cty:"metrics_granularity"`
+	EnabledMetrics            []*string                                        `cty:"enabled_metrics"`
+	SuspendedProcesses        []*string                                        `cty:"suspended_processes"`
+	InstanceProtection        *bool                                            `cty:"protect_from_scale_in"`
+	LoadBalancers             []*terraformWriter.Literal                       `cty:"load_balancers"`
+	TargetGroupARNs           []*terraformWriter.Literal                       `cty:"target_group_arns"`
+	MaxInstanceLifetime       *int32                                           `cty:"max_instance_lifetime"`
+	CapacityRebalance         *bool                                            `cty:"capacity_rebalance"`
+	WarmPool                  *terraformWarmPool                               `cty:"warm_pool"`
+	HealthCheckType           *string                                          `cty:"health_check_type"`
+	HealthCheckGracePeriod    *int32                                           `cty:"health_check_grace_period"`
+	DefaultInstanceWarmup     *int32                                           `cty:"default_instance_warmup"`
+	InstanceMaintenancePolicy *terraformAutoscalingInstanceMaintenancePolicy   `cty:"instance_maintenance_policy"`
 }
 
 // RenderTerraform is responsible for rendering the terraform codebase
@@ -990,6 +1098,17 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 		InstanceProtection:  e.InstanceProtection,
 		MaxInstanceLifetime: e.MaxInstanceLifetime,
 		CapacityRebalance:   e.CapacityRebalance,
+
+		HealthCheckType:        e.HealthCheckType,
+		HealthCheckGracePeriod: e.HealthCheckGracePeriod,
+		DefaultInstanceWarmup:  e.DefaultInstanceWarmup,
+	}
+
+	if e.MinHealthyPercentage != nil || e.MaxHealthyPercentage != nil {
+		tf.InstanceMaintenancePolicy = &terraformAutoscalingInstanceMaintenancePolicy{
+			MinHealthyPercentage: e.MinHealthyPercentage,
+			MaxHealthyPercentage: e.MaxHealthyPercentage,
+		}
 	}
 
 	for _, s := range e.Subnets {
@@ -1049,6 +1168,11 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 		for _, x := range e.MixedInstanceOverrides {
 			tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override = append(tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override, &terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride{InstanceType: fi.PtrTo(x)})
 		}
+		if e.InstanceRequirements != nil {
+			tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override = append(tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override, &terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride{
+				InstanceRequirements: []*terraformAutoscalingInstanceRequirements{e.InstanceRequirements.terraform()},
+			})
+		}
 	} else if e.LaunchTemplate != nil {
 		tf.LaunchTemplate = &terraformAutoscalingLaunchTemplateSpecification{
 			LaunchTemplateID: e.LaunchTemplate.TerraformLink(),
@@ -17,14 +17,19 @@ limitations under the License.
 package awstasks
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"testing"
 
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/cloudmock/aws/mockec2"
 	"k8s.io/kops/pkg/diff"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 	"sigs.k8s.io/yaml"
 )
@@ -403,6 +408,125 @@ resource "aws_autoscaling_group" "test1" {
   }
 }
 
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &AutoscalingGroup{
+				Name:                   fi.PtrTo("test2"),
+				LaunchTemplate:         &LaunchTemplate{Name: fi.PtrTo("test_lt")},
+				MaxSize:                fi.PtrTo(int32(10)),
+				MinSize:                fi.PtrTo(int32(5)),
+				HealthCheckType:        fi.PtrTo("ELB"),
+				HealthCheckGracePeriod: fi.PtrTo(int32(900)),
+				DefaultInstanceWarmup:  fi.PtrTo(int32(600)),
+				MinHealthyPercentage:   fi.PtrTo(int32(90)),
+				MaxHealthyPercentage:   fi.PtrTo(int32(120)),
+				Subnets: []*Subnet{
+					{
+						Name: fi.PtrTo("test-sg"),
+						ID:   fi.PtrTo("sg-1111"),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_group" "test2" {
+  default_instance_warmup   = 600
+  health_check_grace_period = 900
+  health_check_type         = "ELB"
+  instance_maintenance_policy {
+    max_healthy_percentage = 120
+    min_healthy_percentage = 90
+  }
+  launch_template {
+    id      = aws_launch_template.test_lt.id
+    version = aws_launch_template.test_lt.latest_version
+  }
+  max_size            = 10
+  min_size            = 5
+  name                = "test2"
+  vpc_zone_identifier = [aws_subnet.test-sg.id]
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &AutoscalingGroup{
+				Name:           fi.PtrTo("test3"),
+				LaunchTemplate: &LaunchTemplate{Name: fi.PtrTo("test_lt")},
+				MaxSize:        fi.PtrTo(int32(10)),
+				MinSize:        fi.PtrTo(int32(5)),
+				InstanceRequirements: &InstanceRequirements{
+					CPUMin:                fi.PtrTo(int32(2)),
+					CPUMax:                fi.PtrTo(int32(8)),
+					MemoryMin:             fi.PtrTo(int32(4096)),
+					CPUManufacturers:      []string{"amazon-web-services"},
+					ExcludedInstanceTypes: []string{"a1.*", "t4g.*"},
+				},
+				MixedSpotAllocationStrategy: fi.PtrTo("price-capacity-optimized"),
+				Subnets: []*Subnet{
+					{
+						Name: fi.PtrTo("test-sg"),
+						ID:   fi.PtrTo("sg-1111"),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_group" "test3" {
+  max_size = 10
+  min_size = 5
+  mixed_instances_policy {
+    instances_distribution {
+      spot_allocation_strategy = "price-capacity-optimized"
+    }
+    launch_template {
+      launch_template_specification {
+        launch_template_id = aws_launch_template.test_lt.id
+        version            = aws_launch_template.test_lt.latest_version
+      }
+      override {
+        instance_requirements {
+          burstable_performance   = "included"
+          cpu_manufacturers       = ["amazon-web-services"]
+          excluded_instance_types = ["a1.*", "t4g.*"]
+          memory_mib {
+            min = 4096
+          }
+          vcpu_count {
+            max = 8
+            min = 2
+          }
+        }
+      }
+    }
+  }
+  name                = "test3"
+  vpc_zone_identifier = [aws_subnet.test-sg.id]
+}
+
 terraform {
   required_version = ">= 0.15.0"
   required_providers {
@@ -499,3 +623,73 @@ func TestTGsARNsChunks(t *testing.T) {
 		}
 	}
 }
+
+func TestAutoscalingGroupResetsHealthSettings(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockEC2 = &mockec2.MockEC2{}
+	c := &mockautoscaling.MockAutoscaling{}
+	cloud.MockAutoscaling = c
+
+	// The settings of an instance group that no longer configures them
+	if _, err := c.CreateAutoScalingGroup(ctx, &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName:   aws.String("nodes"),
+		MinSize:                aws.Int32(1),
+		MaxSize:                aws.Int32(1),
+		HealthCheckType:        aws.String("ELB"),
+		HealthCheckGracePeriod: aws.Int32(900),
+		DefaultInstanceWarmup:  aws.Int32(600),
+		InstanceMaintenancePolicy: &autoscalingtypes.InstanceMaintenancePolicy{
+			MinHealthyPercentage: aws.Int32(90),
+			MaxHealthyPercentage: aws.Int32(120),
+		},
+	}); err != nil {
+		t.Fatalf("error creating autoscaling group: %v", err)
+	}
+
+	e := &AutoscalingGroup{Name: aws.String("nodes")}
+	target := &awsup.AWSAPITarget{
+		Cloud: cloud,
+	}
+	context, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, map[string]fi.CloudupTask{"nodes": e})
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	a, err := e.Find(context)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	changes := &AutoscalingGroup{}
+	fi.BuildChanges(a, e, changes)
+	if err := e.RenderAWS(target, a, e, changes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	g := c.Groups["nodes"]
+	if aws.ToString(g.HealthCheckType) != "EC2" {
+		t.Errorf("expected health check type EC2, got %q", aws.ToString(g.HealthCheckType))
+	}
+	if aws.ToInt32(g.HealthCheckGracePeriod) != 0 {
+		t.Errorf("expected health check grace period 0, got %d", aws.ToInt32(g.HealthCheckGracePeriod))
+	}
+	if aws.ToInt32(g.DefaultInstanceWarmup) != -1 {
+		t.Errorf("expected default instance warmup to be cleared, got %d", aws.ToInt32(g.DefaultInstanceWarmup))
+	}
+	if g.InstanceMaintenancePolicy == nil || aws.ToInt32(g.InstanceMaintenancePolicy.MinHealthyPercentage) != -1 || aws.ToInt32(g.InstanceMaintenancePolicy.MaxHealthyPercentage) != -1 {
+		t.Errorf("expected instance maintenance policy to be cleared, got %+v", g.InstanceMaintenancePolicy)
+	}
+
+	// Once reset, there are no further changes
+	e = &AutoscalingGroup{Name: aws.String("nodes")}
+	a, err = e.Find(context)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	changes = &AutoscalingGroup{}
+	fi.BuildChanges(a, e, changes)
+	if changes.HealthCheckType != nil || changes.HealthCheckGracePeriod != nil || changes.DefaultInstanceWarmup != nil || changes.MinHealthyPercentage != nil || changes.MaxHealthyPercentage != nil {
+		t.Errorf("unexpected changes after reset: %+v", changes)
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+const (
+	// AutoscalingPolicyTypeTargetTracking is the policy type of target tracking scaling policies
+	AutoscalingPolicyTypeTargetTracking = "TargetTrackingScaling"
+	// AutoscalingPolicyTypeStep is the policy type of step scaling policies
+	AutoscalingPolicyTypeStep = "StepScaling"
+
+	// AutoscalingPolicyNamePrefix is prepended to the names of the scaling policies managed by kops,
+	// so that policies removed from the spec can be told apart from policies created by other tools.
+	AutoscalingPolicyNamePrefix = "kops-"
+)
+
+// +kops:fitask
+type AutoscalingPolicy struct {
+	ID        *string
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	// PolicyName is the name of the scaling policy.
+	// It needs to be unique within the autoscaling group.
+	PolicyName *string
+
+	AutoscalingGroup *AutoscalingGroup
+	PolicyType       *string
+
+	// PredefinedMetric, TargetValue and DisableScaleIn configure target tracking policies.
+	PredefinedMetric *string
+	TargetValue      *float64
+	DisableScaleIn   *bool
+
+	// AdjustmentType, EstimatedInstanceWarmup and StepAdjustments configure step policies.
+	AdjustmentType          *string
+	EstimatedInstanceWarmup *int32
+	StepAdjustments         []*AutoscalingPolicyStepAdjustment
+}
+
+// AutoscalingPolicyStepAdjustment is a step of a step scaling policy.
+type AutoscalingPolicyStepAdjustment struct {
+	MetricIntervalLowerBound *float64
+	MetricIntervalUpperBound *float64
+	ScalingAdjustment        *int32
+}
+
+var _ fi.CompareWithID = &AutoscalingPolicy{}
+
+func (p *AutoscalingPolicy) CompareWithID() *string {
+	return p.Name
+}
+
+func (p *AutoscalingPolicy) Find(c *fi.CloudupContext) (*AutoscalingPolicy, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	request := &autoscaling.DescribePoliciesInput{
+		AutoScalingGroupName: p.AutoscalingGroup.Name,
+		PolicyNames:          []string{aws.ToString(p.PolicyName)},
+	}
+
+	response, err := cloud.Autoscaling().DescribePolicies(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing ASG scaling policies: %w", err)
+	}
+	if response == nil || len(response.ScalingPolicies) == 0 {
+		return nil, nil
+	}
+	if len(response.ScalingPolicies) > 1 {
+		return nil, fmt.Errorf("found multiple ASG scaling policies with the same name")
+	}
+
+	policy := response.ScalingPolicies[0]
+	actual := &AutoscalingPolicy{
+		ID:                      p.Name,
+		Name:                    p.Name,
+		Lifecycle:               p.Lifecycle,
+		PolicyName:              policy.PolicyName,
+		AutoscalingGroup:        p.AutoscalingGroup,
+		PolicyType:              policy.PolicyType,
+		AdjustmentType:          policy.AdjustmentType,
+		EstimatedInstanceWarmup: policy.EstimatedInstanceWarmup,
+	}
+	if tt := policy.TargetTrackingConfiguration; tt != nil {
+		if tt.PredefinedMetricSpecification != nil {
+			actual.PredefinedMetric = fi.PtrTo(string(tt.PredefinedMetricSpecification.PredefinedMetricType))
+		}
+		actual.TargetValue = tt.TargetValue
+		actual.DisableScaleIn = tt.DisableScaleIn
+	}
+	for _, step := range policy.StepAdjustments {
+		actual.StepAdjustments = append(actual.StepAdjustments, &AutoscalingPolicyStepAdjustment{
+			MetricIntervalLowerBound: step.MetricIntervalLowerBound,
+			MetricIntervalUpperBound: step.MetricIntervalUpperBound,
+			ScalingAdjustment:        step.ScalingAdjustment,
+		})
+	}
+
+	return actual, nil
+}
+
+func (p *AutoscalingPolicy) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(p, c)
+}
+
+func (_ *AutoscalingPolicy) CheckChanges(a, e, changes *AutoscalingPolicy) error {
+	if a == nil {
+		if e.PolicyName == nil {
+			return field.Required(field.NewPath("PolicyName"), "")
+		}
+		if e.AutoscalingGroup == nil {
+			return field.Required(field.NewPath("AutoScalingGroupName"), "")
+		}
+	} else {
+		if changes.PolicyType != nil {
+			return fi.CannotChangeField("PolicyType")
+		}
+	}
+
+	return nil
+}
+
+func (*AutoscalingPolicy) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *AutoscalingPolicy) error {
+	ctx := context.TODO()
+
+	request := &autoscaling.PutScalingPolicyInput{
+		AutoScalingGroupName: e.AutoscalingGroup.Name,
+		PolicyName:           e.PolicyName,
+		PolicyType:           e.PolicyType,
+	}
+	switch fi.ValueOf(e.PolicyType) {
+	case AutoscalingPolicyTypeTargetTracking:
+		request.TargetTrackingConfiguration = &autoscalingtypes.TargetTrackingConfiguration{
+			PredefinedMetricSpecification: &autoscalingtypes.PredefinedMetricSpecification{
+				PredefinedMetricType: autoscalingtypes.MetricType(fi.ValueOf(e.PredefinedMetric)),
+			},
+			TargetValue:    e.TargetValue,
+			DisableScaleIn: e.DisableScaleIn,
+		}
+	case AutoscalingPolicyTypeStep:
+		request.AdjustmentType = e.AdjustmentType
+		request.EstimatedInstanceWarmup = e.EstimatedInstanceWarmup
+		for _, step := range e.StepAdjustments {
+			request.StepAdjustments = append(request.StepAdjustments, autoscalingtypes.StepAdjustment{
+				MetricIntervalLowerBound: step.MetricIntervalLowerBound,
+				MetricIntervalUpperBound: step.MetricIntervalUpperBound,
+				ScalingAdjustment:        step.ScalingAdjustment,
+			})
+		}
+	default:
+		return fmt.Errorf("unknown scaling policy type %q", fi.ValueOf(e.PolicyType))
+	}
+
+	if _, err := t.Cloud.Autoscaling().PutScalingPolicy(ctx, request); err != nil {
+		return fmt.Errorf("error creating ASG scaling policy: %w", err)
+	}
+
+	return nil
+}
+
+type terraformAutoscalingPolicy struct {
+	Name                        *string                                         `cty:"name"`
+	AutoScalingGroupName        *terraformWriter.Literal                        `cty:"autoscaling_group_name"`
+	PolicyType                  *string                                         `cty:"policy_type"`
+	AdjustmentType              *string                                         `cty:"adjustment_type"`
+	EstimatedInstanceWarmup     *int32                                          `cty:"estimated_instance_warmup"`
+	StepAdjustments             []*terraformAutoscalingPolicyStepAdjustment     `cty:"step_adjustment"`
+	TargetTrackingConfiguration *terraformAutoscalingPolicyTargetTrackingConfig `cty:"target_tracking_configuration"`
+}
+
+type terraformAutoscalingPolicyStepAdjustment struct {
+	MetricIntervalLowerBound *float64 `cty:"metric_interval_lower_bound"`
+	MetricIntervalUpperBound *float64 `cty:"metric_interval_upper_bound"`
+	ScalingAdjustment        *int32   `cty:"scaling_adjustment"`
+}
+
+type terraformAutoscalingPolicyTargetTrackingConfig struct {
+	PredefinedMetricSpecification *terraformAutoscalingPolicyPredefinedMetric `cty:"predefined_metric_specification"`
+	TargetValue                   *float64                                    `cty:"target_value"`
+	DisableScaleIn                *bool                                       `cty:"disable_scale_in"`
+}
+
+type terraformAutoscalingPolicyPredefinedMetric struct {
+	PredefinedMetricType *string `cty:"predefined_metric_type"`
+}
+
+func (_ *AutoscalingPolicy) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *AutoscalingPolicy) error {
+	tf := &terraformAutoscalingPolicy{
+		Name:                 e.PolicyName,
+		AutoScalingGroupName: e.AutoscalingGroup.TerraformLink(),
+		PolicyType:           e.PolicyType,
+	}
+	switch fi.ValueOf(e.PolicyType) {
+	case AutoscalingPolicyTypeTargetTracking:
+		tf.TargetTrackingConfiguration = &terraformAutoscalingPolicyTargetTrackingConfig{
+			PredefinedMetricSpecification: &terraformAutoscalingPolicyPredefinedMetric{
+				PredefinedMetricType: e.PredefinedMetric,
+			},
+			TargetValue:    e.TargetValue,
+			DisableScaleIn: e.DisableScaleIn,
+		}
+	case AutoscalingPolicyTypeStep:
+		tf.AdjustmentType = e.AdjustmentType
+		tf.EstimatedInstanceWarmup = e.EstimatedInstanceWarmup
+		for _, step := range e.StepAdjustments {
+			tf.StepAdjustments = append(tf.StepAdjustments, &terraformAutoscalingPolicyStepAdjustment{
+				MetricIntervalLowerBound: step.MetricIntervalLowerBound,
+				MetricIntervalUpperBound: step.MetricIntervalUpperBound,
+				ScalingAdjustment:        step.ScalingAdjustment,
+			})
+		}
+	default:
+		return fmt.Errorf("unknown scaling policy type %q", fi.ValueOf(e.PolicyType))
+	}
+
+	return t.RenderResource("aws_autoscaling_policy", *e.Name, tf)
+}
+
+// findRemovedAutoscalingPolicies returns deletions for the scaling policies of the autoscaling group
+// that kops created, but that are no longer in the model.
+func findRemovedAutoscalingPolicies(c *fi.CloudupContext, autoScalingGroupName string) ([]fi.CloudupDeletion, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	expected := make(map[string]bool)
+	for _, task := range c.AllTasks() {
+		p, ok := task.(*AutoscalingPolicy)
+		if !ok || p.AutoscalingGroup == nil {
+			continue
+		}
+		if aws.ToString(p.AutoscalingGroup.Name) == autoScalingGroupName {
+			expected[aws.ToString(p.PolicyName)] = true
+		}
+	}
+
+	var deletions []fi.CloudupDeletion
+	request := &autoscaling.DescribePoliciesInput{
+		AutoScalingGroupName: aws.String(autoScalingGroupName),
+	}
+	paginator := autoscaling.NewDescribePoliciesPaginator(cloud.Autoscaling(), request)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing ASG scaling policies: %w", err)
+		}
+		for _, policy := range page.ScalingPolicies {
+			name := aws.ToString(policy.PolicyName)
+			if !strings.HasPrefix(name, AutoscalingPolicyNamePrefix) || expected[name] {
+				continue
+			}
+			deletions = append(deletions, &deleteAutoscalingPolicy{
+				autoScalingGroupName: autoScalingGroupName,
+				policyName:           name,
+			})
+		}
+	}
+
+	return deletions, nil
+}
+
+type deleteAutoscalingPolicy struct {
+	autoScalingGroupName string
+	policyName           string
+}
+
+var _ fi.CloudupDeletion = &deleteAutoscalingPolicy{}
+
+func (d *deleteAutoscalingPolicy) Delete(t fi.CloudupTarget) error {
+	ctx := context.TODO()
+
+	awsTarget, ok := t.(*awsup.AWSAPITarget)
+	if !ok {
+		return fmt.Errorf("unexpected target type for deletion: %T", t)
+	}
+
+	request := &autoscaling.DeletePolicyInput{
+		AutoScalingGroupName: aws.String(d.autoScalingGroupName),
+		PolicyName:           aws.String(d.policyName),
+	}
+	if _, err := awsTarget.Cloud.Autoscaling().DeletePolicy(ctx, request); err != nil {
+		return fmt.Errorf("error deleting ASG scaling policy: %w", err)
+	}
+
+	return nil
+}
+
+func (d *deleteAutoscalingPolicy) TaskName() string {
+	return "AutoscalingPolicy"
+}
+
+func (d *deleteAutoscalingPolicy) Item() string {
+	return d.autoScalingGroupName + ":" + d.policyName
+}
+
+func (d *deleteAutoscalingPolicy) DeferDeletion() bool {
+	return false
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestAutoscalingPolicyTerraformRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &AutoscalingPolicy{
+				Name:             fi.PtrTo("nodes-cpu"),
+				PolicyName:       fi.PtrTo("kops-cpu"),
+				AutoscalingGroup: &AutoscalingGroup{Name: fi.PtrTo("nodes")},
+				PolicyType:       fi.PtrTo(AutoscalingPolicyTypeTargetTracking),
+				PredefinedMetric: fi.PtrTo("ASGAverageCPUUtilization"),
+				TargetValue:      fi.PtrTo(float64(60)),
+				DisableScaleIn:   fi.PtrTo(false),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_policy" "nodes-cpu" {
+  autoscaling_group_name = aws_autoscaling_group.nodes.id
+  name                   = "kops-cpu"
+  policy_type            = "TargetTrackingScaling"
+  target_tracking_configuration {
+    disable_scale_in = false
+    predefined_metric_specification {
+      predefined_metric_type = "ASGAverageCPUUtilization"
+    }
+    target_value = 60
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &AutoscalingPolicy{
+				Name:             fi.PtrTo("nodes-network"),
+				PolicyName:       fi.PtrTo("kops-network"),
+				AutoscalingGroup: &AutoscalingGroup{Name: fi.PtrTo("nodes")},
+				PolicyType:       fi.PtrTo(AutoscalingPolicyTypeTargetTracking),
+				PredefinedMetric: fi.PtrTo("ASGAverageNetworkIn"),
+				TargetValue:      fi.PtrTo(2.5e6),
+				DisableScaleIn:   fi.PtrTo(true),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_policy" "nodes-network" {
+  autoscaling_group_name = aws_autoscaling_group.nodes.id
+  name                   = "kops-network"
+  policy_type            = "TargetTrackingScaling"
+  target_tracking_configuration {
+    disable_scale_in = true
+    predefined_metric_specification {
+      predefined_metric_type = "ASGAverageNetworkIn"
+    }
+    target_value = 2500000
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &AutoscalingPolicy{
+				Name:                    fi.PtrTo("nodes-queue"),
+				PolicyName:              fi.PtrTo("kops-queue"),
+				AutoscalingGroup:        &AutoscalingGroup{Name: fi.PtrTo("nodes")},
+				PolicyType:              fi.PtrTo(AutoscalingPolicyTypeStep),
+				AdjustmentType:          fi.PtrTo("ChangeInCapacity"),
+				EstimatedInstanceWarmup: fi.PtrTo(int32(300)),
+				StepAdjustments: []*AutoscalingPolicyStepAdjustment{
+					{
+						MetricIntervalUpperBound: fi.PtrTo(10.5),
+						ScalingAdjustment:        fi.PtrTo(int32(1)),
+					},
+					{
+						MetricIntervalLowerBound: fi.PtrTo(10.5),
+						ScalingAdjustment:        fi.PtrTo(int32(3)),
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_autoscaling_policy" "nodes-queue" {
+  adjustment_type           = "ChangeInCapacity"
+  autoscaling_group_name    = aws_autoscaling_group.nodes.id
+  estimated_instance_warmup = 300
+  name                      = "kops-queue"
+  policy_type               = "StepScaling"
+  step_adjustment {
+    metric_interval_upper_bound = 10.5
+    scaling_adjustment          = 1
+  }
+  step_adjustment {
+    metric_interval_lower_bound = 10.5
+    scaling_adjustment          = 3
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	}
+	doRenderTests(t, "RenderTerraform", cases)
+}
+
+func TestAutoscalingPolicyStepRoundTrip(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockAutoscaling = &mockautoscaling.MockAutoscaling{}
+
+	asg := &AutoscalingGroup{Name: aws.String("nodes")}
+	expected := &AutoscalingPolicy{
+		Name:                    aws.String("nodes-queue"),
+		Lifecycle:               fi.LifecycleSync,
+		PolicyName:              aws.String("kops-queue"),
+		AutoscalingGroup:        asg,
+		PolicyType:              aws.String(AutoscalingPolicyTypeStep),
+		AdjustmentType:          aws.String("ChangeInCapacity"),
+		EstimatedInstanceWarmup: aws.Int32(300),
+		StepAdjustments: []*AutoscalingPolicyStepAdjustment{
+			{MetricIntervalUpperBound: aws.Float64(10.5), ScalingAdjustment: aws.Int32(1)},
+			{MetricIntervalLowerBound: aws.Float64(10.5), ScalingAdjustment: aws.Int32(3)},
+		},
+	}
+
+	target := &awsup.AWSAPITarget{
+		Cloud: cloud,
+	}
+	if err := expected.RenderAWS(target, nil, expected, expected); err != nil {
+		t.Fatalf("error creating scaling policy: %v", err)
+	}
+
+	allTasks := map[string]fi.CloudupTask{"nodes": asg, "nodes-queue": expected}
+	context, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, allTasks)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	actual, err := expected.Find(context)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual == nil {
+		t.Fatalf("expected the scaling policy to be found")
+	}
+	actual.ID = nil
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %+v, got %+v", expected, actual)
+	}
+}
+
+func TestFindRemovedAutoscalingPolicies(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockautoscaling.MockAutoscaling{}
+	cloud.MockAutoscaling = c
+
+	for _, policy := range []struct{ group, name string }{
+		{"nodes", "kops-cpu"},
+		{"nodes", "kops-network"},
+		{"nodes", "manual"},
+		{"other", "kops-network"},
+	} {
+		if _, err := c.PutScalingPolicy(ctx, &autoscaling.PutScalingPolicyInput{
+			AutoScalingGroupName: aws.String(policy.group),
+			PolicyName:           aws.String(policy.name),
+			PolicyType:           aws.String(AutoscalingPolicyTypeTargetTracking),
+		}); err != nil {
+			t.Fatalf("error creating scaling policy: %v", err)
+		}
+	}
+
+	asg := &AutoscalingGroup{Name: aws.String("nodes")}
+	allTasks := map[string]fi.CloudupTask{
+		"nodes": asg,
+		"nodes-cpu": &AutoscalingPolicy{
+			Name:             aws.String("nodes-cpu"),
+			PolicyName:       aws.String("kops-cpu"),
+			AutoscalingGroup: asg,
+		},
+	}
+
+	target := &awsup.AWSAPITarget{
+		Cloud: cloud,
+	}
+	context, err := fi.NewCloudupContext(ctx, fi.DeletionProcessingModeDeleteIncludingDeferred, target, nil, cloud, nil, nil, nil, allTasks)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+
+	deletions, err := findRemovedAutoscalingPolicies(context, "nodes")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deletions) != 1 || deletions[0].Item() != "nodes:kops-network" {
+		t.Fatalf("expected only nodes:kops-network to be deleted, got %v", deletions)
+	}
+
+	for _, deletion := range deletions {
+		if err := deletion.Delete(target); err != nil {
+			t.Fatalf("error deleting scaling policy: %v", err)
+		}
+	}
+
+	var remaining []string
+	for name := range c.ScalingPolicies {
+		remaining = append(remaining, name)
+	}
+	sort.Strings(remaining)
+	expected := []string{"nodes::kops-cpu", "nodes::manual", "other::kops-network"}
+	if !reflect.DeepEqual(remaining, expected) {
+		t.Errorf("expected remaining policies %v, got %v", expected, remaining)
+	}
+}
@@ -39,6 +39,7 @@ type DHCPOptions struct {
 	ID                *string
 	DomainName        *string
 	DomainNameServers *string
+	NTPServers        *string
 
 	// Shared is set if this is a shared DHCPOptions
 	Shared *bool
@@ -72,35 +73,49 @@ func (e *DHCPOptions) Find(c *fi.CloudupContext) (*DHCPOptions, error) {
 		return nil, nil
 	}
 
-	if len(response.DhcpOptions) != 1 {
-		return nil, fmt.Errorf("found multiple DhcpOptions with name: %s", *e.Name)
-	}
-	klog.V(2).Info("found existing DhcpOptions")
-	o := response.DhcpOptions[0]
-	actual := &DHCPOptions{
-		ID:   o.DhcpOptionsId,
-		Name: findNameTag(o.Tags),
-		Tags: intersectTags(o.Tags, e.Tags),
-	}
-
-	for _, s := range o.DhcpConfigurations {
-		k := aws.ToString(s.Key)
-		v := ""
-		for _, av := range s.Values {
-			if v != "" {
-				v = v + ","
+	var actual *DHCPOptions
+	for _, o := range response.DhcpOptions {
+		options := &DHCPOptions{
+			ID:   o.DhcpOptionsId,
+			Name: findNameTag(o.Tags),
+			Tags: intersectTags(o.Tags, e.Tags),
+		}
+		for _, s := range o.DhcpConfigurations {
+			k := aws.ToString(s.Key)
+			v := ""
+			for _, av := range s.Values {
+				if v != "" {
+					v = v + ","
+				}
+				v = v + *av.Value
 			}
-			v = v + *av.Value
+			switch k {
+			case "domain-name":
+				options.DomainName = &v
+			case "domain-name-servers":
+				options.DomainNameServers = &v
+			case "ntp-servers":
+				options.NTPServers = &v
+			default:
+				klog.Infof("Skipping over DHCPOption with key=%q value=%q", k, v)
+			}
+		}
+
+		if len(response.DhcpOptions) == 1 {
+			actual = options
+			break
 		}
-		switch k {
-		case "domain-name":
-			actual.DomainName = &v
-		case "domain-name-servers":
-			actual.DomainNameServers = &v
-		default:
-			klog.Infof("Skipping over DHCPOption with key=%q value=%q", k, v)
+		// A replacement set may have been created without the previous set being deleted;
+		// prefer the set that already has the expected options.
+		if options.matches(e) {
+			actual = options
+			break
 		}
 	}
+	if actual == nil {
+		return nil, fmt.Errorf("found multiple DhcpOptions with name: %s", *e.Name)
+	}
+	klog.V(2).Info("found existing DhcpOptions")
 
 	e.ID = actual.ID
 
@@ -111,6 +126,13 @@ func (e *DHCPOptions) Find(c *fi.CloudupContext) (*DHCPOptions, error) {
 	return actual, nil
 }
 
+// matches returns true if the options of o are the same as those of e.
+func (o *DHCPOptions) matches(e *DHCPOptions) bool {
+	return fi.ValueOf(o.DomainName) == fi.ValueOf(e.DomainName) &&
+		fi.ValueOf(o.DomainNameServers) == fi.ValueOf(e.DomainNameServers) &&
+		fi.ValueOf(o.NTPServers) == fi.ValueOf(e.NTPServers)
+}
+
 func (e *DHCPOptions) Run(c *fi.CloudupContext) error {
 	return fi.CloudupDefaultDeltaRunMethod(e, c)
 }
@@ -125,14 +147,10 @@ func (s *DHCPOptions) CheckChanges(a, e, changes *DHCPOptions) error {
 		if changes.ID != nil {
 			return fi.CannotChangeField("ID")
 		}
-
-		// TODO: Delete & create new DHCPOptions
-		// We can't delete the DHCPOptions while it is attached, but we can change the tag (add a timestamp suffix?)
-		if changes.DomainName != nil {
-			return fi.CannotChangeField("DomainName")
-		}
-		if changes.DomainNameServers != nil {
-			return fi.CannotChangeField("DomainNameServers")
+		// Changes to the options are applied by creating a replacement set of DHCPOptions,
+		// which VPCDHCPOptionsAssociation associates in place of the existing one.
+		if fi.ValueOf(a.Shared) && !a.matches(e) {
+			return fmt.Errorf("cannot change the options of shared DHCPOptions %q", fi.ValueOf(a.ID))
 		}
 	}
 	return nil
@@ -140,8 +158,13 @@ func (s *DHCPOptions) CheckChanges(a, e, changes *DHCPOptions) error {
 
 func (_ *DHCPOptions) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DHCPOptions) error {
 	ctx := context.TODO()
-	if a == nil {
-		klog.V(2).Infof("Creating DHCPOptions with Name:%q", *e.Name)
+	// DHCPOptions are immutable, so changed options are applied by creating a replacement set
+	if a == nil || !a.matches(e) {
+		if a == nil {
+			klog.V(2).Infof("Creating DHCPOptions with Name:%q", *e.Name)
+		} else {
+			klog.V(2).Infof("Creating DHCPOptions with Name:%q to replace %q", *e.Name, aws.ToString(a.ID))
+		}
 
 		request := &ec2.CreateDhcpOptionsInput{
 			TagSpecifications: awsup.EC2TagSpecification(ec2types.ResourceTypeDhcpOptions, e.Tags),
@@ -149,7 +172,7 @@ func (_ *DHCPOptions) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DHCPOption
 		if e.DomainNameServers != nil {
 			o := ec2types.NewDhcpConfiguration{
 				Key:    aws.String("domain-name-servers"),
-				Values: []string{aws.ToString(e.DomainNameServers)},
+				Values: strings.Split(aws.ToString(e.DomainNameServers), ","),
 			}
 			request.DhcpConfigurations = append(request.DhcpConfigurations, o)
 		}
@@ -160,6 +183,13 @@ func (_ *DHCPOptions) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DHCPOption
 			}
 			request.DhcpConfigurations = append(request.DhcpConfigurations, o)
 		}
+		if e.NTPServers != nil {
+			o := ec2types.NewDhcpConfiguration{
+				Key:    aws.String("ntp-servers"),
+				Values: strings.Split(aws.ToString(e.NTPServers), ","),
+			}
+			request.DhcpConfigurations = append(request.DhcpConfigurations, o)
+		}
 
 		response, err := t.Cloud.EC2().CreateDhcpOptions(ctx, request)
 		if err != nil {
@@ -175,6 +205,7 @@ func (_ *DHCPOptions) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DHCPOption
 type terraformDHCPOptions struct {
 	DomainName        *string           `cty:"domain_name"`
 	DomainNameServers []string          `cty:"domain_name_servers"`
+	NTPServers        []string          `cty:"ntp_servers"`
 	Tags              map[string]string `cty:"tags"`
 }
 
@@ -187,6 +218,9 @@ func (_ *DHCPOptions) RenderTerraform(t *terraform.TerraformTarget, a, e, change
 	if e.DomainNameServers != nil {
 		tf.DomainNameServers = strings.Split(*e.DomainNameServers, ",")
 	}
+	if e.NTPServers != nil {
+		tf.NTPServers = strings.Split(*e.NTPServers, ",")
+	}
 
 	return t.RenderResource("aws_vpc_dhcp_options", *e.Name, tf)
 }
@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestDHCPOptionsReplacedWhenChanged(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(ntpServers *string) map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.21.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		dhcp1 := &DHCPOptions{
+			Name:              s("dhcp1"),
+			Lifecycle:         fi.LifecycleSync,
+			DomainName:        s("ec2.internal"),
+			DomainNameServers: s("10.0.0.2,10.0.0.3"),
+			NTPServers:        ntpServers,
+			Shared:            fi.PtrTo(false),
+			Tags:              map[string]string{"Name": "dhcp1"},
+		}
+		association1 := &VPCDHCPOptionsAssociation{
+			Name:        s("association1"),
+			Lifecycle:   fi.LifecycleSync,
+			VPC:         vpc1,
+			DHCPOptions: dhcp1,
+		}
+
+		return map[string]fi.CloudupTask{
+			"vpc1":         vpc1,
+			"dhcp1":        dhcp1,
+			"association1": association1,
+		}
+	}
+
+	var originalID string
+	{
+		allTasks := buildTasks(nil)
+		dhcp1 := allTasks["dhcp1"].(*DHCPOptions)
+
+		runTasks(t, cloud, allTasks)
+
+		originalID = fi.ValueOf(dhcp1.ID)
+		if originalID == "" {
+			t.Fatalf("ID not set after create")
+		}
+		if len(c.DhcpOptions) != 1 {
+			t.Fatalf("Expected exactly one DhcpOptions; found %d", len(c.DhcpOptions))
+		}
+		servers := c.DhcpOptions[originalID].DhcpConfigurations[0]
+		expected := ec2types.DhcpConfiguration{
+			Key: aws.String("domain-name-servers"),
+			Values: []ec2types.AttributeValue{
+				{Value: aws.String("10.0.0.2")},
+				{Value: aws.String("10.0.0.3")},
+			},
+		}
+		if !reflect.DeepEqual(servers, expected) {
+			t.Fatalf("Unexpected domain name servers: expected=%v actual=%v", expected, servers)
+		}
+	}
+
+	{
+		allTasks := buildTasks(nil)
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks(s("169.254.169.123"))
+		vpc1 := allTasks["vpc1"].(*VPC)
+		dhcp1 := allTasks["dhcp1"].(*DHCPOptions)
+
+		runTasks(t, cloud, allTasks)
+
+		id := fi.ValueOf(dhcp1.ID)
+		if id == "" || id == originalID {
+			t.Fatalf("Expected replacement DhcpOptions; found %q", id)
+		}
+		if len(c.DhcpOptions) != 1 || c.DhcpOptions[id] == nil {
+			t.Fatalf("Expected replaced DhcpOptions to be deleted; found %v", c.DhcpOptions)
+		}
+		if associated := aws.ToString(c.FindVpc(*vpc1.ID).DhcpOptionsId); associated != id {
+			t.Fatalf("Expected DhcpOptions %q to be associated; found %q", id, associated)
+		}
+	}
+
+	{
+		allTasks := buildTasks(s("169.254.169.123"))
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// DNSZoneVPCAssociation associates an additional VPC with a private hosted zone
+// +kops:fitask
+type DNSZoneVPCAssociation struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	DNSZone   *DNSZone
+	VPCID     *string
+	VPCRegion *string
+}
+
+var _ fi.CompareWithID = &DNSZoneVPCAssociation{}
+
+func (e *DNSZoneVPCAssociation) CompareWithID() *string {
+	return e.Name
+}
+
+func (e *DNSZoneVPCAssociation) Find(c *fi.CloudupContext) (*DNSZoneVPCAssociation, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	zoneID := aws.ToString(e.DNSZone.ZoneID)
+	if zoneID == "" {
+		// The zone has not been created yet
+		return nil, nil
+	}
+
+	response, err := cloud.Route53().GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String(zoneID)})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching DNS HostedZone %q: %w", zoneID, err)
+	}
+
+	// The association may have been removed outside of kOps, in which case it is created again
+	for _, vpc := range response.VPCs {
+		if aws.ToString(vpc.VPCId) == aws.ToString(e.VPCID) && string(vpc.VPCRegion) == aws.ToString(e.VPCRegion) {
+			actual := &DNSZoneVPCAssociation{
+				Name:      e.Name,
+				Lifecycle: e.Lifecycle,
+				DNSZone:   e.DNSZone,
+				VPCID:     vpc.VPCId,
+				VPCRegion: aws.String(string(vpc.VPCRegion)),
+			}
+			return actual, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (e *DNSZoneVPCAssociation) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (s *DNSZoneVPCAssociation) CheckChanges(a, e, changes *DNSZoneVPCAssociation) error {
+	if fi.ValueOf(e.VPCID) == "" {
+		return fi.RequiredField("VPCID")
+	}
+	if fi.ValueOf(e.VPCRegion) == "" {
+		return fi.RequiredField("VPCRegion")
+	}
+	return nil
+}
+
+func (_ *DNSZoneVPCAssociation) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DNSZoneVPCAssociation) error {
+	if a != nil {
+		return nil
+	}
+
+	ctx := context.TODO()
+	request := &route53.AssociateVPCWithHostedZoneInput{
+		HostedZoneId: e.DNSZone.ZoneID,
+		VPC: &route53types.VPC{
+			VPCId:     e.VPCID,
+			VPCRegion: route53types.VPCRegion(aws.ToString(e.VPCRegion)),
+		},
+	}
+
+	klog.V(2).Infof("Associating VPC %q with DNS HostedZone %q", aws.ToString(e.VPCID), aws.ToString(e.DNSZone.ZoneID))
+	if _, err := t.Cloud.Route53().AssociateVPCWithHostedZone(ctx, request); err != nil {
+		return fmt.Errorf("error associating VPC %q with hosted zone %q: %w", aws.ToString(e.VPCID), aws.ToString(e.DNSZone.ZoneID), err)
+	}
+
+	return nil
+}
+
+type terraformRoute53ZoneVPCAssociation struct {
+	ZoneID    *terraformWriter.Literal `cty:"zone_id"`
+	VPCID     *string                  `cty:"vpc_id"`
+	VPCRegion *string                  `cty:"vpc_region"`
+}
+
+func (_ *DNSZoneVPCAssociation) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *DNSZoneVPCAssociation) error {
+	tf := &terraformRoute53ZoneVPCAssociation{
+		ZoneID:    e.DNSZone.TerraformLink(),
+		VPCID:     e.VPCID,
+		VPCRegion: e.VPCRegion,
+	}
+	return t.RenderResource("aws_route53_zone_association", *e.Name, tf)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"k8s.io/kops/cloudmock/aws/mockroute53"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestDNSZoneVPCAssociation(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	route53Client := &mockroute53.MockRoute53{}
+	cloud.MockRoute53 = route53Client
+
+	createZone := func() {
+		route53Client.Zones = nil
+		route53Client.MockCreateZone(&route53types.HostedZone{
+			Id:     aws.String("/hostedzone/Z1AFAKE1ZON3YO"),
+			Name:   aws.String("example.com."),
+			Config: &route53types.HostedZoneConfig{PrivateZone: true},
+		}, []*route53types.VPC{
+			{VPCId: aws.String("vpc-cluster"), VPCRegion: route53types.VPCRegionUsEast1},
+		})
+	}
+	createZone()
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		zone := &DNSZone{
+			Name:      s("example.com"),
+			Lifecycle: fi.LifecycleSync,
+			ZoneID:    s("Z1AFAKE1ZON3YO"),
+			Private:   fi.PtrTo(true),
+		}
+		association := &DNSZoneVPCAssociation{
+			Name:      s("example.com-vpc-mgmt"),
+			Lifecycle: fi.LifecycleSync,
+			DNSZone:   zone,
+			VPCID:     s("vpc-mgmt"),
+			VPCRegion: s("us-west-2"),
+		}
+		return map[string]fi.CloudupTask{
+			"DNSZone/example.com":                        zone,
+			"DNSZoneVPCAssociation/example.com-vpc-mgmt": association,
+		}
+	}
+
+	expectedVPCs := []route53types.VPC{
+		{VPCId: aws.String("vpc-cluster"), VPCRegion: route53types.VPCRegionUsEast1},
+		{VPCId: aws.String("vpc-mgmt"), VPCRegion: route53types.VPCRegionUsWest2},
+	}
+	checkVPCs := func() {
+		t.Helper()
+		response, err := route53Client.GetHostedZone(ctx, &route53.GetHostedZoneInput{Id: aws.String("Z1AFAKE1ZON3YO")})
+		if err != nil {
+			t.Fatalf("error getting hosted zone: %v", err)
+		}
+		if !reflect.DeepEqual(response.VPCs, expectedVPCs) {
+			t.Fatalf("unexpected VPCs of the hosted zone: %v", response.VPCs)
+		}
+	}
+
+	runTasks(t, cloud, buildTasks())
+	checkVPCs()
+	checkNoChanges(t, ctx, cloud, buildTasks())
+
+	// The association is created again when it is removed outside of kOps
+	createZone()
+	runTasks(t, cloud, buildTasks())
+	checkVPCs()
+	checkNoChanges(t, ctx, cloud, buildTasks())
+}
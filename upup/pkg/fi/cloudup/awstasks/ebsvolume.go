@@ -45,6 +45,9 @@ type EBSVolume struct {
 	VolumeIops       *int32
 	VolumeThroughput *int32
 	VolumeType       ec2types.VolumeType
+
+	// PreventDestroy protects the volume from being destroyed by terraform
+	PreventDestroy *bool
 }
 
 var _ fi.CompareWithID = &EBSVolume{}
@@ -93,6 +96,7 @@ func (e *EBSVolume) Find(c *fi.CloudupContext) (*EBSVolume, error) {
 
 	// Avoid spurious changes
 	actual.Lifecycle = e.Lifecycle
+	actual.PreventDestroy = e.PreventDestroy
 	e.ID = actual.ID
 	if fi.ValueOf(e.Encrypted) && e.KmsKeyId == nil {
 		e.KmsKeyId = actual.KmsKeyId
@@ -205,14 +209,15 @@ func (e *EBSVolume) getEBSVolumeTagsToDelete(currentTags map[string]string) map[
 }
 
 type terraformVolume struct {
-	AvailabilityZone *string             `cty:"availability_zone"`
-	Size             *int32              `cty:"size"`
-	Type             ec2types.VolumeType `cty:"type"`
-	Iops             *int32              `cty:"iops"`
-	Throughput       *int32              `cty:"throughput"`
-	KmsKeyId         *string             `cty:"kms_key_id"`
-	Encrypted        *bool               `cty:"encrypted"`
-	Tags             map[string]string   `cty:"tags"`
+	AvailabilityZone *string              `cty:"availability_zone"`
+	Size             *int32               `cty:"size"`
+	Type             ec2types.VolumeType  `cty:"type"`
+	Iops             *int32               `cty:"iops"`
+	Throughput       *int32               `cty:"throughput"`
+	KmsKeyId         *string              `cty:"kms_key_id"`
+	Encrypted        *bool                `cty:"encrypted"`
+	Tags             map[string]string    `cty:"tags"`
+	Lifecycle        *terraform.Lifecycle `cty:"lifecycle"`
 }
 
 func (_ *EBSVolume) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *EBSVolume) error {
@@ -226,6 +231,9 @@ func (_ *EBSVolume) RenderTerraform(t *terraform.TerraformTarget, a, e, changes
 		Encrypted:        e.Encrypted,
 		Tags:             e.Tags,
 	}
+	if fi.ValueOf(e.PreventDestroy) {
+		tf.Lifecycle = &terraform.Lifecycle{PreventDestroy: fi.PtrTo(true)}
+	}
 
 	tfName, _ := e.TerraformName()
 	return t.RenderResource("aws_ebs_volume", tfName, tf)
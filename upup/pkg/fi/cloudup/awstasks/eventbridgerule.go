@@ -35,6 +35,7 @@ import (
 type EventBridgeRule struct {
 	ID        *string
 	Name      *string
+	ARN       *string
 	Lifecycle fi.Lifecycle
 
 	EventPattern *string
@@ -80,11 +81,15 @@ func (eb *EventBridgeRule) Find(c *fi.CloudupContext) (*EventBridgeRule, error)
 	actual := &EventBridgeRule{
 		ID:           eb.ID,
 		Name:         eb.Name,
+		ARN:          rule.Arn,
 		Lifecycle:    eb.Lifecycle,
 		EventPattern: rule.EventPattern,
 		SQSQueue:     eb.SQSQueue,
 		Tags:         mapEventBridgeTagsToMap(tagResponse.Tags),
 	}
+	// Avoid flapping
+	eb.ARN = actual.ARN
+
 	return actual, nil
 }
 
@@ -122,10 +127,27 @@ func (eb *EventBridgeRule) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Event
 			Tags:         tags,
 		}
 
-		_, err := t.Cloud.EventBridge().PutRule(context.TODO(), request)
+		response, err := t.Cloud.EventBridge().PutRule(context.TODO(), request)
 		if err != nil {
 			return fmt.Errorf("error creating EventBridge rule: %v", err)
 		}
+		e.ARN = response.RuleArn
+	} else if changes.Tags != nil {
+		var tags []eventbridgetypes.Tag
+		for k, v := range e.Tags {
+			tags = append(tags, eventbridgetypes.Tag{
+				Key:   aws.String(k),
+				Value: aws.String(v),
+			})
+		}
+
+		request := &eventbridge.TagResourceInput{
+			ResourceARN: a.ARN,
+			Tags:        tags,
+		}
+		if _, err := t.Cloud.EventBridge().TagResource(context.TODO(), request); err != nil {
+			return fmt.Errorf("error tagging EventBridge rule: %v", err)
+		}
 	}
 
 	return nil
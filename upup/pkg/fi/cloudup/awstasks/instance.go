@@ -289,9 +289,14 @@ func (_ *Instance) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Instance) err
 			}
 		}
 
-		response, err := t.Cloud.EC2().RunInstances(ctx, request)
+		var response *ec2.RunInstancesOutput
+		err = t.RetryInvalidIAMInstanceProfile(ctx, "creating Instance", func() error {
+			var err error
+			response, err = t.Cloud.EC2().RunInstances(ctx, request)
+			return err
+		})
 		if err != nil {
-			return fmt.Errorf("error creating Instance: %v", err)
+			return fmt.Errorf("error creating Instance: %w", err)
 		}
 
 		e.ID = response.Instances[0].InstanceId
@@ -17,17 +17,22 @@ limitations under the License.
 package awstasks
 
 import (
+	"sort"
+
 	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
 
 	"k8s.io/kops/upup/pkg/fi"
 )
 
 type InstanceRequirements struct {
-	Architecture *string
-	CPUMin       *int32
-	CPUMax       *int32
-	MemoryMin    *int32
-	MemoryMax    *int32
+	CPUMin    *int32
+	CPUMax    *int32
+	MemoryMin *int32
+	MemoryMax *int32
+	// CPUManufacturers are the manufacturers of the CPUs of the instance types, which select their architecture.
+	CPUManufacturers []string
+	// ExcludedInstanceTypes are the instance types not to use, which may contain wildcards.
+	ExcludedInstanceTypes []string
 }
 
 var _ fi.CloudupHasDependencies = &InstanceRequirements{}
@@ -38,7 +43,7 @@ func (e *InstanceRequirements) GetDependencies(tasks map[string]fi.CloudupTask)
 
 func findInstanceRequirements(asg *autoscalingtypes.AutoScalingGroup) (*InstanceRequirements, error) {
 	actual := &InstanceRequirements{}
-	if asg.MixedInstancesPolicy != nil {
+	if asg.MixedInstancesPolicy != nil && asg.MixedInstancesPolicy.LaunchTemplate != nil {
 		for _, override := range asg.MixedInstancesPolicy.LaunchTemplate.Overrides {
 			if override.InstanceRequirements != nil {
 				if override.InstanceRequirements.VCpuCount != nil {
@@ -47,8 +52,14 @@ func findInstanceRequirements(asg *autoscalingtypes.AutoScalingGroup) (*Instance
 				}
 				if override.InstanceRequirements.MemoryMiB != nil {
 					actual.MemoryMax = override.InstanceRequirements.MemoryMiB.Max
-					actual.MemoryMax = override.InstanceRequirements.MemoryMiB.Min
+					actual.MemoryMin = override.InstanceRequirements.MemoryMiB.Min
+				}
+				for _, manufacturer := range override.InstanceRequirements.CpuManufacturers {
+					actual.CPUManufacturers = append(actual.CPUManufacturers, string(manufacturer))
 				}
+				sort.Strings(actual.CPUManufacturers)
+				actual.ExcludedInstanceTypes = append(actual.ExcludedInstanceTypes, override.InstanceRequirements.ExcludedInstanceTypes...)
+				sort.Strings(actual.ExcludedInstanceTypes)
 				return actual, nil
 			}
 		}
@@ -57,6 +68,11 @@ func findInstanceRequirements(asg *autoscalingtypes.AutoScalingGroup) (*Instance
 }
 
 func overridesFromInstanceRequirements(ir *InstanceRequirements) autoscalingtypes.LaunchTemplateOverrides {
+	var cpuManufacturers []autoscalingtypes.CpuManufacturer
+	for _, manufacturer := range ir.CPUManufacturers {
+		cpuManufacturers = append(cpuManufacturers, autoscalingtypes.CpuManufacturer(manufacturer))
+	}
+
 	return autoscalingtypes.LaunchTemplateOverrides{
 		InstanceRequirements: &autoscalingtypes.InstanceRequirements{
 			VCpuCount: &autoscalingtypes.VCpuCountRequest{
@@ -67,7 +83,32 @@ func overridesFromInstanceRequirements(ir *InstanceRequirements) autoscalingtype
 				Max: ir.MemoryMax,
 				Min: ir.MemoryMin,
 			},
-			BurstablePerformance: autoscalingtypes.BurstablePerformanceIncluded,
+			CpuManufacturers:      cpuManufacturers,
+			ExcludedInstanceTypes: ir.ExcludedInstanceTypes,
+			BurstablePerformance:  autoscalingtypes.BurstablePerformanceIncluded,
 		},
 	}
 }
+
+type terraformAutoscalingInstanceRequirements struct {
+	VCPUCount             []*terraformAutoscalingMinMax `cty:"vcpu_count"`
+	MemoryMiB             []*terraformAutoscalingMinMax `cty:"memory_mib"`
+	CPUManufacturers      []string                      `cty:"cpu_manufacturers"`
+	ExcludedInstanceTypes []string                      `cty:"excluded_instance_types"`
+	BurstablePerformance  *string                       `cty:"burstable_performance"`
+}
+
+type terraformAutoscalingMinMax struct {
+	Min *int32 `cty:"min"`
+	Max *int32 `cty:"max"`
+}
+
+func (e *InstanceRequirements) terraform() *terraformAutoscalingInstanceRequirements {
+	return &terraformAutoscalingInstanceRequirements{
+		VCPUCount:             []*terraformAutoscalingMinMax{{Min: e.CPUMin, Max: e.CPUMax}},
+		MemoryMiB:             []*terraformAutoscalingMinMax{{Min: e.MemoryMin, Max: e.MemoryMax}},
+		CPUManufacturers:      e.CPUManufacturers,
+		ExcludedInstanceTypes: e.ExcludedInstanceTypes,
+		BurstablePerformance:  fi.PtrTo(string(autoscalingtypes.BurstablePerformanceIncluded)),
+	}
+}
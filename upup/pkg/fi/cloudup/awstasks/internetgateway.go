@@ -129,8 +129,8 @@ func (e *InternetGateway) Run(c *fi.CloudupContext) error {
 
 func (s *InternetGateway) CheckChanges(a, e, changes *InternetGateway) error {
 	if a != nil {
-		// TODO: I think we can change it; we just detach & attach
-		if changes.VPC != nil {
+		// We only detach and reattach InternetGateways that we own
+		if changes.VPC != nil && fi.ValueOf(e.Shared) {
 			return fi.CannotChangeField("VPC")
 		}
 	}
@@ -165,6 +165,22 @@ func (_ *InternetGateway) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Intern
 		e.ID = response.InternetGateway.InternetGatewayId
 	}
 
+	if a != nil && changes != nil && changes.VPC != nil && a.VPC != nil {
+		// An InternetGateway can only be attached to one VPC, so it is detached from the previous VPC first.
+		// This fails while resources in the previous VPC still have public addresses.
+		klog.V(2).Infof("Detaching InternetGateway %q from VPC %q", fi.ValueOf(e.ID), fi.ValueOf(a.VPC.ID))
+
+		detachRequest := &ec2.DetachInternetGatewayInput{
+			VpcId:             a.VPC.ID,
+			InternetGatewayId: e.ID,
+		}
+
+		_, err := t.Cloud.EC2().DetachInternetGateway(ctx, detachRequest)
+		if err != nil {
+			return fmt.Errorf("error detaching InternetGateway %q from VPC %q: %w", fi.ValueOf(e.ID), fi.ValueOf(a.VPC.ID), err)
+		}
+	}
+
 	if a == nil || (changes != nil && changes.VPC != nil) {
 		klog.V(2).Infof("Creating InternetGatewayAttachment")
 
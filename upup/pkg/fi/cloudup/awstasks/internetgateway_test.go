@@ -150,3 +150,65 @@ func TestSharedInternetGatewayDoesNotRename(t *testing.T) {
 		checkNoChanges(t, ctx, cloud, allTasks)
 	}
 }
+
+func TestInternetGatewayMovesToAnotherVPC(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	var vpcIDs []*string
+	for _, cidr := range []string{"172.20.0.0/16", "172.21.0.0/16"} {
+		vpc, err := c.CreateVpc(ctx, &ec2.CreateVpcInput{
+			CidrBlock: aws.String(cidr),
+		})
+		if err != nil {
+			t.Fatalf("error creating test VPC: %v", err)
+		}
+		vpcIDs = append(vpcIDs, vpc.Vpc.VpcId)
+	}
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(vpcID *string) map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			Tags:      map[string]string{"kubernetes.io/cluster/cluster.example.com": "shared"},
+			Shared:    fi.PtrTo(true),
+			ID:        vpcID,
+		}
+		igw1 := &InternetGateway{
+			Name:      s("igw1"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			Tags:      map[string]string{"Name": "igw1"},
+		}
+
+		return map[string]fi.CloudupTask{
+			"igw1": igw1,
+			"vpc1": vpc1,
+		}
+	}
+
+	for _, vpcID := range vpcIDs {
+		allTasks := buildTasks(vpcID)
+		runTasks(t, cloud, allTasks)
+
+		if len(c.InternetGatewayIds()) != 1 {
+			t.Fatalf("Expected exactly one InternetGateway; found %v", c.InternetGatewayIds())
+		}
+
+		actual := c.FindInternetGateway(c.InternetGatewayIds()[0])
+		expected := []ec2types.InternetGatewayAttachment{
+			{
+				VpcId: vpcID,
+			},
+		}
+		if !reflect.DeepEqual(actual.Attachments, expected) {
+			t.Fatalf("Unexpected InternetGateway attachments: expected=%v actual=%v", expected, actual.Attachments)
+		}
+
+		checkNoChanges(t, ctx, cloud, buildTasks(vpcID))
+	}
+}
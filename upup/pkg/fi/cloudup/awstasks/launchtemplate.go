@@ -18,6 +18,7 @@ package awstasks
 
 import (
 	"fmt"
+	"reflect"
 	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -36,12 +37,22 @@ type LaunchTemplate struct {
 	// Lifecycle is the resource lifecycle
 	Lifecycle fi.Lifecycle
 
+	// AdditionalNetworkInterfaces are the network interfaces of the instances in addition to the primary interface
+	AdditionalNetworkInterfaces []*LaunchTemplateNetworkInterface
+	// AMDSevSnp enables AMD SEV-SNP on the instances
+	AMDSevSnp *bool
 	// AssociatePublicIP indicates if a public ip address is assigned to instances
 	AssociatePublicIP *bool
 	// BlockDeviceMappings is a block device mappings
 	BlockDeviceMappings []*BlockDeviceMapping
 	// CPUCredits is the credit option for CPU Usage on some instance types
 	CPUCredits *string
+	// DisableAPITermination enables termination protection on the instances
+	DisableAPITermination *bool
+	// EFAInterfaceCount is the number of Elastic Fabric Adapter interfaces, one on each network card of the instances
+	EFAInterfaceCount *int32
+	// HostResourceGroupARN is the ARN of the host resource group to launch the instances into
+	HostResourceGroupARN *string
 	// HTTPPutResponseHopLimit is the desired HTTP PUT response hop limit for instance metadata requests.
 	HTTPPutResponseHopLimit *int32
 	// HTTPTokens is the state of token usage for your instance metadata requests.
@@ -61,6 +72,8 @@ type LaunchTemplate struct {
 	InstanceType *ec2types.InstanceType
 	// Ipv6AddressCount is the number of IPv6 addresses to assign with the primary network interface.
 	IPv6AddressCount *int32
+	// NitroEnclaves enables AWS Nitro Enclaves on the instances
+	NitroEnclaves *bool
 	// RootVolumeIops is the provisioned IOPS when the volume type is io1, io2 or gp3
 	RootVolumeIops *int32
 	// RootVolumeOptimization enables EBS optimization for an instance
@@ -83,9 +96,9 @@ type LaunchTemplate struct {
 	SpotPrice *string
 	// SpotDurationInMinutes is set for requesting spot blocks
 	SpotDurationInMinutes *int32
-	// Tags are the keypairs to apply to the instance and volume on launch as well as the launch template itself.
+	// Tags are the keypairs to apply to the instance, volume and network interface on launch as well as the launch template itself.
 	Tags map[string]string
-	// Tenancy. Can be either default or dedicated.
+	// Tenancy. Can be default, dedicated or host.
 	Tenancy *ec2types.Tenancy
 	// UserData is the user data configuration
 	UserData fi.Resource
@@ -139,6 +152,9 @@ func (t *LaunchTemplate) buildRootDevice(cloud awsup.AWSCloud) (map[string]*Bloc
 
 func (t *LaunchTemplate) Normalize(c *fi.CloudupContext) error {
 	sort.Stable(OrderSecurityGroupsById(t.SecurityGroups))
+	for _, x := range t.AdditionalNetworkInterfaces {
+		sort.Stable(OrderSecurityGroupsById(x.SecurityGroups))
+	}
 	return nil
 }
 
@@ -161,6 +177,30 @@ func (t *LaunchTemplate) CheckChanges(a, e, changes *LaunchTemplate) error {
 	return nil
 }
 
+// launchTemplateInPlaceFields are the fields that only apply to instances launched after a change,
+// so changing them does not require existing instances to be replaced.
+var launchTemplateInPlaceFields = map[string]bool{
+	"ID":        true,
+	"Name":      true,
+	"Lifecycle": true,
+	"Tags":      true,
+}
+
+// RequiresReplacement is called on the changes to a launch template. It returns true if existing instances
+// must be replaced to pick up the changes, as with a new image, instance type or user data.
+func (t *LaunchTemplate) RequiresReplacement() bool {
+	v := reflect.ValueOf(t).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if launchTemplateInPlaceFields[v.Type().Field(i).Name] {
+			continue
+		}
+		if !v.Field(i).IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
 // FindDeletions is responsible for finding launch templates which can be deleted
 func (t *LaunchTemplate) FindDeletions(c *fi.CloudupContext) ([]fi.CloudupDeletion, error) {
 	var removals []fi.CloudupDeletion
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// LaunchTemplateNetworkInterface is a network interface of a launch template in addition to the primary interface
+type LaunchTemplateNetworkInterface struct {
+	// DeviceIndex is the device index of the interface
+	DeviceIndex *int32
+	// Subnet is the subnet of the interface
+	Subnet *Subnet
+	// SecurityGroups are the security groups of the interface
+	SecurityGroups []*SecurityGroup
+}
+
+var (
+	_ fi.CloudupHasDependencies = &LaunchTemplateNetworkInterface{}
+	_ fi.CompareWithID          = &LaunchTemplateNetworkInterface{}
+)
+
+// GetDependencies returns the subnet and security groups of the interface
+func (i *LaunchTemplateNetworkInterface) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
+	var deps []fi.CloudupTask
+	if i.Subnet != nil {
+		deps = append(deps, i.Subnet)
+	}
+	for _, sg := range i.SecurityGroups {
+		deps = append(deps, sg)
+	}
+	return deps
+}
+
+// CompareWithID identifies the interface by its device index, subnet and security groups,
+// because the subnet and security groups only have IDs once they are found or created
+func (i *LaunchTemplateNetworkInterface) CompareWithID() *string {
+	if i.Subnet == nil || i.Subnet.ID == nil {
+		return nil
+	}
+	var groups []string
+	for _, sg := range i.SecurityGroups {
+		if sg.ID == nil {
+			return nil
+		}
+		groups = append(groups, *sg.ID)
+	}
+	return fi.PtrTo(fmt.Sprintf("%d/%s/%s", fi.ValueOf(i.DeviceIndex), *i.Subnet.ID, strings.Join(groups, ",")))
+}
+
+// toRequest builds the launch template network interface request of the interface
+func (i *LaunchTemplateNetworkInterface) toRequest() ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest {
+	request := ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+		DeleteOnTermination: aws.Bool(true),
+		DeviceIndex:         i.DeviceIndex,
+		SubnetId:            i.Subnet.ID,
+	}
+	for _, sg := range i.SecurityGroups {
+		request.Groups = append(request.Groups, fi.ValueOf(sg.ID))
+	}
+	return request
+}
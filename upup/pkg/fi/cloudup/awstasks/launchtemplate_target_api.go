@@ -43,7 +43,7 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 
 	// @step: lets build the launch template data
 	data := &ec2types.RequestLaunchTemplateData{
-		DisableApiTermination: fi.PtrTo(false),
+		DisableApiTermination: fi.PtrTo(fi.ValueOf(t.DisableAPITermination)),
 		EbsOptimized:          t.RootVolumeOptimization,
 		ImageId:               image.ImageId,
 		InstanceType:          fi.ValueOf(t.InstanceType),
@@ -89,9 +89,30 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 	for _, sg := range t.SecurityGroups {
 		data.NetworkInterfaces[0].Groups = append(data.NetworkInterfaces[0].Groups, fi.ValueOf(sg.ID))
 	}
+	// @step: add the additional network interfaces
+	for _, x := range t.AdditionalNetworkInterfaces {
+		data.NetworkInterfaces = append(data.NetworkInterfaces, x.toRequest())
+	}
+	// @step: add the Elastic Fabric Adapter interfaces, the primary one on the first network card
+	if efaInterfaceCount := fi.ValueOf(t.EFAInterfaceCount); efaInterfaceCount > 0 {
+		data.NetworkInterfaces[0].InterfaceType = aws.String("efa")
+		data.NetworkInterfaces[0].NetworkCardIndex = aws.Int32(0)
+		for i := int32(1); i < efaInterfaceCount; i++ {
+			data.NetworkInterfaces = append(data.NetworkInterfaces, ec2types.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+				DeleteOnTermination: aws.Bool(true),
+				DeviceIndex:         aws.Int32(1),
+				Groups:              data.NetworkInterfaces[0].Groups,
+				InterfaceType:       aws.String("efa"),
+				NetworkCardIndex:    aws.Int32(i),
+			})
+		}
+	}
 	// @step: add any tenancy details
 	if t.Tenancy != nil {
 		data.Placement = &ec2types.LaunchTemplatePlacementRequest{Tenancy: fi.ValueOf(t.Tenancy)}
+		if fi.ValueOf(t.HostResourceGroupARN) != "" {
+			data.Placement.HostResourceGroupArn = t.HostResourceGroupARN
+		}
 	}
 	// @step: set the instance monitoring
 	data.Monitoring = &ec2types.LaunchTemplatesMonitoringRequest{Enabled: fi.PtrTo(false)}
@@ -121,6 +142,10 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 			ResourceType: ec2types.ResourceTypeVolume,
 			Tags:         tags,
 		})
+		data.TagSpecifications = append(data.TagSpecifications, ec2types.LaunchTemplateTagSpecificationRequest{
+			ResourceType: ec2types.ResourceTypeNetworkInterface,
+			Tags:         tags,
+		})
 	}
 	// @step: add the userdata
 	if t.UserData != nil {
@@ -149,6 +174,17 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 			CpuCredits: t.CPUCredits,
 		}
 	}
+	// @step: add the confidential computing options
+	if fi.ValueOf(t.AMDSevSnp) {
+		data.CpuOptions = &ec2types.LaunchTemplateCpuOptionsRequest{
+			AmdSevSnp: ec2types.AmdSevSnpSpecificationEnabled,
+		}
+	}
+	if fi.ValueOf(t.NitroEnclaves) {
+		data.EnclaveOptions = &ec2types.LaunchTemplateEnclaveOptionsRequest{
+			Enabled: t.NitroEnclaves,
+		}
+	}
 	// @step: attempt to create the launch template
 	if a == nil {
 		input := &ec2.CreateLaunchTemplateInput{
@@ -171,6 +207,10 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 			LaunchTemplateName: t.Name,
 			LaunchTemplateData: data,
 		}
+		// Let rolling updates know that instances using the previous version are still up to date
+		if !changes.RequiresReplacement() {
+			input.VersionDescription = aws.String(awsup.LaunchTemplateVersionDescriptionInPlace)
+		}
 		if version, err := c.Cloud.EC2().CreateLaunchTemplateVersion(ctx, input); err != nil {
 			return fmt.Errorf("error creating LaunchTemplateVersion: %v", err)
 		} else {
@@ -228,10 +268,29 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 	}
 
 	// @step: check if any of the interfaces are public facing
+	actual.EFAInterfaceCount = fi.PtrTo(int32(0))
 	for _, x := range lt.LaunchTemplateData.NetworkInterfaces {
 		if aws.ToBool(x.AssociatePublicIpAddress) {
 			actual.AssociatePublicIP = fi.PtrTo(true)
 		}
+		if aws.ToString(x.InterfaceType) == "efa" {
+			actual.EFAInterfaceCount = fi.PtrTo(*actual.EFAInterfaceCount + 1)
+		}
+		if aws.ToInt32(x.DeviceIndex) != 0 {
+			// Additional EFA interfaces share the settings of the primary interface
+			if aws.ToString(x.InterfaceType) != "efa" {
+				ni := &LaunchTemplateNetworkInterface{
+					DeviceIndex: x.DeviceIndex,
+					Subnet:      &Subnet{ID: x.SubnetId},
+				}
+				for _, id := range x.Groups {
+					ni.SecurityGroups = append(ni.SecurityGroups, &SecurityGroup{ID: fi.PtrTo(id)})
+				}
+				sort.Sort(OrderSecurityGroupsById(ni.SecurityGroups))
+				actual.AdditionalNetworkInterfaces = append(actual.AdditionalNetworkInterfaces, ni)
+			}
+			continue
+		}
 		for _, id := range x.Groups {
 			actual.SecurityGroups = append(actual.SecurityGroups, &SecurityGroup{ID: fi.PtrTo(id)})
 		}
@@ -248,6 +307,9 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 	} else {
 		actual.CPUCredits = aws.String("")
 	}
+	// @step: add the confidential computing options
+	actual.AMDSevSnp = fi.PtrTo(lt.LaunchTemplateData.CpuOptions != nil && lt.LaunchTemplateData.CpuOptions.AmdSevSnp == ec2types.AmdSevSnpSpecificationEnabled)
+	actual.NitroEnclaves = fi.PtrTo(lt.LaunchTemplateData.EnclaveOptions != nil && aws.ToBool(lt.LaunchTemplateData.EnclaveOptions.Enabled))
 	// @step: check if monitoring it enabled
 	if lt.LaunchTemplateData.Monitoring != nil {
 		actual.InstanceMonitoring = lt.LaunchTemplateData.Monitoring.Enabled
@@ -255,6 +317,7 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 	// @step: add the tenancy
 	if lt.LaunchTemplateData.Placement != nil && len(lt.LaunchTemplateData.Placement.Tenancy) > 0 {
 		actual.Tenancy = fi.PtrTo(lt.LaunchTemplateData.Placement.Tenancy)
+		actual.HostResourceGroupARN = fi.PtrTo(aws.ToString(lt.LaunchTemplateData.Placement.HostResourceGroupArn))
 	}
 	// @step: add the ssh if there is one
 	if lt.LaunchTemplateData.KeyName != nil {
@@ -322,6 +385,9 @@ func (t *LaunchTemplate) Find(c *fi.CloudupContext) (*LaunchTemplate, error) {
 		}
 	}
 
+	// @step: add termination protection
+	actual.DisableAPITermination = fi.PtrTo(fi.ValueOf(lt.LaunchTemplateData.DisableApiTermination))
+
 	// @step: add instance metadata options
 	if options := lt.LaunchTemplateData.MetadataOptions; options != nil {
 		actual.HTTPPutResponseHopLimit = options.HttpPutResponseHopLimit
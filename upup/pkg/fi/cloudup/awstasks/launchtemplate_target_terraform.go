@@ -29,10 +29,18 @@ type terraformLaunchTemplateNetworkInterface struct {
 	AssociatePublicIPAddress *bool `cty:"associate_public_ip_address"`
 	// DeleteOnTermination indicates whether the network interface should be destroyed on instance termination.
 	DeleteOnTermination *bool `cty:"delete_on_termination"`
+	// DeviceIndex is the device index of the network interface, set for the additional interfaces.
+	DeviceIndex *int32 `cty:"device_index"`
+	// InterfaceType is the type of the network interface, efa for Elastic Fabric Adapter interfaces.
+	InterfaceType *string `cty:"interface_type"`
+	// NetworkCardIndex is the index of the network card of the network interface.
+	NetworkCardIndex *int32 `cty:"network_card_index"`
 	// Ipv6AddressCount is the number of IPv6 addresses to assign with the primary network interface.
 	Ipv6AddressCount *int32 `cty:"ipv6_address_count"`
 	// SecurityGroups is a list of security group ids.
 	SecurityGroups []*terraformWriter.Literal `cty:"security_groups"`
+	// SubnetID is the subnet of the network interface, set for the additional interfaces.
+	SubnetID *terraformWriter.Literal `cty:"subnet_id"`
 }
 
 type terraformLaunchTemplateMonitoring struct {
@@ -49,6 +57,8 @@ type terraformLaunchTemplatePlacement struct {
 	GroupName *string `cty:"group_name"`
 	// HostID is the ID of the Dedicated Host for the instance.
 	HostID *string `cty:"host_id"`
+	// HostResourceGroupARN is the ARN of the host resource group in which to launch the instance.
+	HostResourceGroupARN *string `cty:"host_resource_group_arn"`
 	// SpreadDomain are reserved for future use.
 	SpreadDomain *string `cty:"spread_domain"`
 	// Tenancy ist he tenancy of the instance. Can be default, dedicated, or host.
@@ -110,6 +120,16 @@ type terraformLaunchTemplateCreditSpecification struct {
 	CPUCredits *string `cty:"cpu_credits"`
 }
 
+type terraformLaunchTemplateCPUOptions struct {
+	// AMDSevSnp indicates whether AMD SEV-SNP is enabled.
+	AMDSevSnp *string `cty:"amd_sev_snp"`
+}
+
+type terraformLaunchTemplateEnclaveOptions struct {
+	// Enabled indicates whether AWS Nitro Enclaves are enabled.
+	Enabled *bool `cty:"enabled"`
+}
+
 type terraformLaunchTemplateTagSpecification struct {
 	// ResourceType is the type of resource to tag.
 	ResourceType *string `cty:"resource_type"`
@@ -136,10 +156,16 @@ type terraformLaunchTemplate struct {
 
 	// BlockDeviceMappings is the device mappings
 	BlockDeviceMappings []*terraformLaunchTemplateBlockDevice `cty:"block_device_mappings"`
+	// CPUOptions are the processor options of the instances
+	CPUOptions *terraformLaunchTemplateCPUOptions `cty:"cpu_options"`
 	// CreditSpecification is the credit option for CPU Usage on some instance types
 	CreditSpecification *terraformLaunchTemplateCreditSpecification `cty:"credit_specification"`
+	// DisableAPITermination enables termination protection on the instances
+	DisableAPITermination *bool `cty:"disable_api_termination"`
 	// EBSOptimized indicates if the root device is ebs optimized
 	EBSOptimized *bool `cty:"ebs_optimized"`
+	// EnclaveOptions are the AWS Nitro Enclaves options
+	EnclaveOptions *terraformLaunchTemplateEnclaveOptions `cty:"enclave_options"`
 	// IAMInstanceProfile is the IAM profile to assign to the nodes
 	IAMInstanceProfile []*terraformLaunchTemplateIAMProfile `cty:"iam_instance_profile"`
 	// ImageID is the ami to use for the instances
@@ -191,6 +217,9 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 		image = im.ImageId
 	}
 
+	// The version description is deliberately not rendered: without the existing template, the changes are unknown,
+	// so versions created by terraform are never marked with awsup.LaunchTemplateVersionDescriptionInPlace
+	// and every change still marks the instances as needing a rolling update.
 	tf := terraformLaunchTemplate{
 		Name:         e.Name,
 		EBSOptimized: e.RootVolumeOptimization,
@@ -213,6 +242,10 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 		},
 	}
 
+	if fi.ValueOf(e.DisableAPITermination) {
+		tf.DisableAPITermination = e.DisableAPITermination
+	}
+
 	if fi.ValueOf(e.SpotPrice) != "" {
 		marketSpotOptions := terraformLaunchTemplateMarketOptionsSpotOptions{
 			BlockDurationMinutes:         e.SpotDurationInMinutes,
@@ -231,14 +264,51 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 			CPUCredits: e.CPUCredits,
 		}
 	}
+	if fi.ValueOf(e.AMDSevSnp) {
+		tf.CPUOptions = &terraformLaunchTemplateCPUOptions{
+			AMDSevSnp: fi.PtrTo(string(ec2types.AmdSevSnpSpecificationEnabled)),
+		}
+	}
+	if fi.ValueOf(e.NitroEnclaves) {
+		tf.EnclaveOptions = &terraformLaunchTemplateEnclaveOptions{
+			Enabled: e.NitroEnclaves,
+		}
+	}
 	for _, x := range e.SecurityGroups {
 		tf.NetworkInterfaces[0].SecurityGroups = append(tf.NetworkInterfaces[0].SecurityGroups, x.TerraformLink())
 	}
+	for _, x := range e.AdditionalNetworkInterfaces {
+		ni := &terraformLaunchTemplateNetworkInterface{
+			DeleteOnTermination: fi.PtrTo(true),
+			DeviceIndex:         x.DeviceIndex,
+			SubnetID:            x.Subnet.TerraformLink(),
+		}
+		for _, sg := range x.SecurityGroups {
+			ni.SecurityGroups = append(ni.SecurityGroups, sg.TerraformLink())
+		}
+		tf.NetworkInterfaces = append(tf.NetworkInterfaces, ni)
+	}
+	if efaInterfaceCount := fi.ValueOf(e.EFAInterfaceCount); efaInterfaceCount > 0 {
+		tf.NetworkInterfaces[0].InterfaceType = fi.PtrTo("efa")
+		tf.NetworkInterfaces[0].NetworkCardIndex = fi.PtrTo(int32(0))
+		for i := int32(1); i < efaInterfaceCount; i++ {
+			tf.NetworkInterfaces = append(tf.NetworkInterfaces, &terraformLaunchTemplateNetworkInterface{
+				DeleteOnTermination: fi.PtrTo(true),
+				DeviceIndex:         fi.PtrTo(int32(1)),
+				InterfaceType:       fi.PtrTo("efa"),
+				NetworkCardIndex:    fi.PtrTo(i),
+				SecurityGroups:      tf.NetworkInterfaces[0].SecurityGroups,
+			})
+		}
+	}
 	if e.SSHKey != nil {
 		tf.KeyName = e.SSHKey.TerraformLink()
 	}
 	if e.Tenancy != nil {
 		tf.Placement = []*terraformLaunchTemplatePlacement{{Tenancy: e.Tenancy}}
+		if fi.ValueOf(e.HostResourceGroupARN) != "" {
+			tf.Placement[0].HostResourceGroupARN = e.HostResourceGroupARN
+		}
 	}
 	if e.InstanceMonitoring != nil {
 		tf.Monitoring = []*terraformLaunchTemplateMonitoring{
@@ -323,6 +393,10 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 			ResourceType: fi.PtrTo("volume"),
 			Tags:         e.Tags,
 		})
+		tf.TagSpecifications = append(tf.TagSpecifications, &terraformLaunchTemplateTagSpecification{
+			ResourceType: fi.PtrTo("network-interface"),
+			Tags:         e.Tags,
+		})
 		tf.Tags = e.Tags
 	}
 
@@ -181,6 +181,245 @@ resource "aws_launch_template" "test" {
   }
 }
 
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name: fi.PtrTo("test"),
+				IAMInstanceProfile: &IAMInstanceProfile{
+					Name: fi.PtrTo("control-plane"),
+				},
+				DisableAPITermination: fi.PtrTo(true),
+				ID:                    fi.PtrTo("test-11"),
+				InstanceType:          fi.PtrTo(ec2types.InstanceTypeT2Medium),
+				SSHKey: &SSHKey{
+					Name: fi.PtrTo("mykey"),
+				},
+				SecurityGroups: []*SecurityGroup{
+					{Name: fi.PtrTo("control-plane"), ID: fi.PtrTo("1111")},
+				},
+				HTTPTokens:              fi.PtrTo(ec2types.LaunchTemplateHttpTokensStateRequired),
+				HTTPPutResponseHopLimit: fi.PtrTo(int32(1)),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  disable_api_termination = true
+  iam_instance_profile {
+    name = aws_iam_instance_profile.control-plane.id
+  }
+  instance_type = "t2.medium"
+  key_name      = "mykey"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint               = "enabled"
+    http_put_response_hop_limit = 1
+    http_tokens                 = "required"
+  }
+  name = "test"
+  network_interfaces {
+    delete_on_termination = true
+    security_groups       = [aws_security_group.control-plane.id]
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name: fi.PtrTo("test"),
+				IAMInstanceProfile: &IAMInstanceProfile{
+					Name: fi.PtrTo("nodes"),
+				},
+				ID:           fi.PtrTo("test-11"),
+				InstanceType: fi.PtrTo(ec2types.InstanceTypeM6aXlarge),
+				SecurityGroups: []*SecurityGroup{
+					{Name: fi.PtrTo("nodes"), ID: fi.PtrTo("1111")},
+				},
+				HTTPTokens:              fi.PtrTo(ec2types.LaunchTemplateHttpTokensStateRequired),
+				HTTPPutResponseHopLimit: fi.PtrTo(int32(1)),
+				AMDSevSnp:               fi.PtrTo(true),
+				NitroEnclaves:           fi.PtrTo(true),
+				Tenancy:                 fi.PtrTo(ec2types.TenancyHost),
+				HostResourceGroupARN:    fi.PtrTo("arn:aws:resource-groups:eu-west-2:123456789012:group/byol-hosts"),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  cpu_options {
+    amd_sev_snp = "enabled"
+  }
+  enclave_options {
+    enabled = true
+  }
+  iam_instance_profile {
+    name = aws_iam_instance_profile.nodes.id
+  }
+  instance_type = "m6a.xlarge"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint               = "enabled"
+    http_put_response_hop_limit = 1
+    http_tokens                 = "required"
+  }
+  name = "test"
+  network_interfaces {
+    delete_on_termination = true
+    security_groups       = [aws_security_group.nodes.id]
+  }
+  placement {
+    host_resource_group_arn = "arn:aws:resource-groups:eu-west-2:123456789012:group/byol-hosts"
+    tenancy                 = "host"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name: fi.PtrTo("test"),
+				IAMInstanceProfile: &IAMInstanceProfile{
+					Name: fi.PtrTo("nodes"),
+				},
+				ID:           fi.PtrTo("test-11"),
+				InstanceType: fi.PtrTo(ec2types.InstanceTypeP4d24xlarge),
+				SecurityGroups: []*SecurityGroup{
+					{Name: fi.PtrTo("nodes"), ID: fi.PtrTo("1111")},
+				},
+				HTTPTokens:              fi.PtrTo(ec2types.LaunchTemplateHttpTokensStateRequired),
+				HTTPPutResponseHopLimit: fi.PtrTo(int32(1)),
+				EFAInterfaceCount:       fi.PtrTo(int32(2)),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  iam_instance_profile {
+    name = aws_iam_instance_profile.nodes.id
+  }
+  instance_type = "p4d.24xlarge"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint               = "enabled"
+    http_put_response_hop_limit = 1
+    http_tokens                 = "required"
+  }
+  name = "test"
+  network_interfaces {
+    delete_on_termination = true
+    interface_type        = "efa"
+    network_card_index    = 0
+    security_groups       = [aws_security_group.nodes.id]
+  }
+  network_interfaces {
+    delete_on_termination = true
+    device_index          = 1
+    interface_type        = "efa"
+    network_card_index    = 1
+    security_groups       = [aws_security_group.nodes.id]
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name: fi.PtrTo("test"),
+				IAMInstanceProfile: &IAMInstanceProfile{
+					Name: fi.PtrTo("nodes"),
+				},
+				ID:           fi.PtrTo("test-12"),
+				InstanceType: fi.PtrTo(ec2types.InstanceTypeM5Large),
+				SecurityGroups: []*SecurityGroup{
+					{Name: fi.PtrTo("nodes"), ID: fi.PtrTo("1111")},
+				},
+				HTTPTokens:              fi.PtrTo(ec2types.LaunchTemplateHttpTokensStateRequired),
+				HTTPPutResponseHopLimit: fi.PtrTo(int32(1)),
+				AdditionalNetworkInterfaces: []*LaunchTemplateNetworkInterface{
+					{
+						DeviceIndex:    fi.PtrTo(int32(1)),
+						Subnet:         &Subnet{Name: fi.PtrTo("storage-eu-west-2a")},
+						SecurityGroups: []*SecurityGroup{{Name: fi.PtrTo("storage"), ID: fi.PtrTo("2222")}},
+					},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  iam_instance_profile {
+    name = aws_iam_instance_profile.nodes.id
+  }
+  instance_type = "m5.large"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint               = "enabled"
+    http_put_response_hop_limit = 1
+    http_tokens                 = "required"
+  }
+  name = "test"
+  network_interfaces {
+    delete_on_termination = true
+    security_groups       = [aws_security_group.nodes.id]
+  }
+  network_interfaces {
+    delete_on_termination = true
+    device_index          = 1
+    security_groups       = [aws_security_group.storage.id]
+    subnet_id             = aws_subnet.storage-eu-west-2a.id
+  }
+}
+
 terraform {
   required_version = ">= 0.15.0"
   required_providers {
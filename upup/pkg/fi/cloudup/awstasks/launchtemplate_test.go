@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"testing"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestLaunchTemplateRequiresReplacement(t *testing.T) {
+	grid := []struct {
+		name     string
+		changes  *LaunchTemplate
+		expected bool
+	}{
+		{
+			name:     "no changes",
+			changes:  &LaunchTemplate{},
+			expected: false,
+		},
+		{
+			name:     "tags",
+			changes:  &LaunchTemplate{Tags: map[string]string{"team": "infra"}},
+			expected: false,
+		},
+		{
+			name:     "image",
+			changes:  &LaunchTemplate{ImageID: fi.PtrTo("ami-12345678")},
+			expected: true,
+		},
+		{
+			name:     "instance type and tags",
+			changes:  &LaunchTemplate{InstanceType: fi.PtrTo(ec2types.InstanceTypeT3Medium), Tags: map[string]string{"team": "infra"}},
+			expected: true,
+		},
+		{
+			name:     "user data",
+			changes:  &LaunchTemplate{UserData: fi.NewStringResource("#!/bin/bash")},
+			expected: true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			if actual := g.changes.RequiresReplacement(); actual != g.expected {
+				t.Errorf("expected RequiresReplacement() to be %v, got %v", g.expected, actual)
+			}
+		})
+	}
+}
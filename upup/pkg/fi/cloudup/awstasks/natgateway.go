@@ -43,6 +43,10 @@ type NatGateway struct {
 
 	EgressId *string
 
+	// ConnectivityType is either public, the default, or private.
+	// Private NAT gateways have no elastic IP, and route to other VPCs or networks through a transit gateway.
+	ConnectivityType *string
+
 	// Shared is set if this is a shared NatGateway
 	Shared *bool
 
@@ -84,7 +88,7 @@ func (e *NatGateway) Find(c *fi.CloudupContext) (*NatGateway, error) {
 		}
 		ngw = &response.NatGateways[0]
 
-		if len(ngw.NatGatewayAddresses) != 1 {
+		if len(ngw.NatGatewayAddresses) != 1 && ngw.ConnectivityType != ec2types.ConnectivityTypePrivate {
 			return nil, fmt.Errorf("found %d EIP Addresses for 1 NATGateway, expected 1", len(ngw.NatGatewayAddresses))
 		}
 	} else {
@@ -102,7 +106,11 @@ func (e *NatGateway) Find(c *fi.CloudupContext) (*NatGateway, error) {
 	actual.ID = ngw.NatGatewayId
 
 	actual.Subnet = e.Subnet
-	if len(ngw.NatGatewayAddresses) == 0 {
+	if ngw.ConnectivityType == ec2types.ConnectivityTypePrivate {
+		// Private NAT gateways have a private IP address, but no elastic IP
+		actual.ConnectivityType = fi.PtrTo(string(ngw.ConnectivityType))
+		actual.ElasticIP = nil
+	} else if len(ngw.NatGatewayAddresses) == 0 {
 		// Not sure if this ever happens
 		actual.ElasticIP = nil
 	} else if len(ngw.NatGatewayAddresses) == 1 {
@@ -120,6 +128,9 @@ func (e *NatGateway) Find(c *fi.CloudupContext) (*NatGateway, error) {
 	actual.Tags = intersectTags(ngw.Tags, e.Tags)
 
 	// Avoid spurious changes
+	if actual.ConnectivityType == nil && !e.isPrivate() {
+		actual.ConnectivityType = e.ConnectivityType
+	}
 	actual.Lifecycle = e.Lifecycle
 	actual.Shared = e.Shared
 	actual.AssociatedRouteTable = e.AssociatedRouteTable
@@ -259,7 +270,11 @@ func (s *NatGateway) CheckChanges(a, e, changes *NatGateway) error {
 	// New
 	if a == nil {
 		if !fi.ValueOf(e.Shared) {
-			if e.ElasticIP == nil {
+			if e.isPrivate() {
+				if e.ElasticIP != nil {
+					return fmt.Errorf("a private NatGateway cannot have an ElasticIP")
+				}
+			} else if e.ElasticIP == nil {
 				return fi.RequiredField("ElasticIP")
 			}
 			if e.Subnet == nil {
@@ -287,6 +302,9 @@ func (s *NatGateway) CheckChanges(a, e, changes *NatGateway) error {
 		if changes.Subnet != nil {
 			return fi.CannotChangeField("Subnet")
 		}
+		if changes.ConnectivityType != nil {
+			return fi.FieldIsImmutable(fi.ValueOf(e.ConnectivityType), fi.ValueOf(a.ConnectivityType), field.NewPath("ConnectivityType"))
+		}
 		if changes.ID != nil {
 			return fi.CannotChangeField("ID")
 		}
@@ -294,6 +312,11 @@ func (s *NatGateway) CheckChanges(a, e, changes *NatGateway) error {
 	return nil
 }
 
+// isPrivate returns true if the NatGateway has no public connectivity.
+func (e *NatGateway) isPrivate() bool {
+	return fi.ValueOf(e.ConnectivityType) == string(ec2types.ConnectivityTypePrivate)
+}
+
 func (e *NatGateway) Run(c *fi.CloudupContext) error {
 	return fi.CloudupDefaultDeltaRunMethod(e, c)
 }
@@ -314,7 +337,11 @@ func (_ *NatGateway) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *NatGateway)
 		request := &ec2.CreateNatGatewayInput{
 			TagSpecifications: awsup.EC2TagSpecification(ec2types.ResourceTypeNatgateway, e.Tags),
 		}
-		request.AllocationId = e.ElasticIP.ID
+		if e.isPrivate() {
+			request.ConnectivityType = ec2types.ConnectivityTypePrivate
+		} else {
+			request.AllocationId = e.ElasticIP.ID
+		}
 		request.SubnetId = e.Subnet.ID
 		response, err := t.Cloud.EC2().CreateNatGateway(ctx, request)
 		if err != nil {
@@ -366,9 +393,10 @@ func (_ *NatGateway) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *NatGateway)
 }
 
 type terraformNATGateway struct {
-	AllocationID *terraformWriter.Literal `cty:"allocation_id"`
-	SubnetID     *terraformWriter.Literal `cty:"subnet_id"`
-	Tag          map[string]string        `cty:"tags"`
+	AllocationID     *terraformWriter.Literal `cty:"allocation_id"`
+	ConnectivityType *string                  `cty:"connectivity_type"`
+	SubnetID         *terraformWriter.Literal `cty:"subnet_id"`
+	Tag              map[string]string        `cty:"tags"`
 }
 
 func (_ *NatGateway) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *NatGateway) error {
@@ -382,9 +410,13 @@ func (_ *NatGateway) RenderTerraform(t *terraform.TerraformTarget, a, e, changes
 	}
 
 	tf := &terraformNATGateway{
-		AllocationID: e.ElasticIP.TerraformLink(),
-		SubnetID:     e.Subnet.TerraformLink(),
-		Tag:          e.Tags,
+		SubnetID: e.Subnet.TerraformLink(),
+		Tag:      e.Tags,
+	}
+	if e.isPrivate() {
+		tf.ConnectivityType = e.ConnectivityType
+	} else {
+		tf.AllocationID = e.ElasticIP.TerraformLink()
 	}
 
 	return t.RenderResource("aws_nat_gateway", *e.Name, tf)
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestPrivateNatGatewayCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		subnet1 := &Subnet{
+			Name:      s("subnet1"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			CIDR:      s("172.20.1.0/24"),
+			Tags:      map[string]string{"Name": "subnet1"},
+		}
+		rt1 := &RouteTable{
+			Name:      s("rt1"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			Tags:      map[string]string{"Name": "rt1"},
+		}
+		ngw1 := &NatGateway{
+			Name:                 s("ngw1"),
+			Lifecycle:            fi.LifecycleSync,
+			Subnet:               subnet1,
+			ConnectivityType:     s("private"),
+			AssociatedRouteTable: rt1,
+			Tags:                 map[string]string{"Name": "ngw1"},
+		}
+
+		return map[string]fi.CloudupTask{
+			"ngw1":    ngw1,
+			"rt1":     rt1,
+			"subnet1": subnet1,
+			"vpc1":    vpc1,
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		ngw1 := allTasks["ngw1"].(*NatGateway)
+
+		runTasks(t, cloud, allTasks)
+
+		if fi.ValueOf(ngw1.ID) == "" {
+			t.Fatalf("ID not set after create")
+		}
+
+		if len(c.NatGateways) != 1 {
+			t.Fatalf("Expected exactly one NatGateway; found %v", c.NatGateways)
+		}
+
+		actual := c.NatGateways[*ngw1.ID]
+		if actual.ConnectivityType != ec2types.ConnectivityTypePrivate {
+			t.Fatalf("Unexpected ConnectivityType: expected=%v actual=%v", ec2types.ConnectivityTypePrivate, actual.ConnectivityType)
+		}
+		if len(actual.NatGatewayAddresses) != 0 {
+			t.Fatalf("Unexpected NatGatewayAddresses: %v", actual.NatGatewayAddresses)
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestPrivateNatGatewayTerraformRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &NatGateway{
+				Name:             fi.PtrTo("us-test-1a.example.com"),
+				Subnet:           &Subnet{Name: fi.PtrTo("utility-us-test-1a.example.com")},
+				ConnectivityType: fi.PtrTo("private"),
+				Tags: map[string]string{
+					"Name": "us-test-1a.example.com",
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_nat_gateway" "us-test-1a-example-com" {
+  connectivity_type = "private"
+  subnet_id         = aws_subnet.utility-us-test-1a-example-com.id
+  tags = {
+    "Name" = "us-test-1a.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	}
+	doRenderTests(t, "RenderTerraform", cases)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// NetworkInterface is a network interface that outlives the instances it is attached to.
+// It is attached to instances by nodeup, not by the launch template.
+// +kops:fitask
+type NetworkInterface struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID     *string
+	Subnet *Subnet
+	// PrivateIPAddress is the primary private IPv4 address of the network interface.
+	// If not set, an address of the subnet is picked when the network interface is created.
+	PrivateIPAddress *string
+	SecurityGroups   []*SecurityGroup
+
+	// Tags is a map of aws tags that are added to the NetworkInterface
+	Tags map[string]string
+}
+
+var _ fi.CompareWithID = &NetworkInterface{}
+
+func (e *NetworkInterface) CompareWithID() *string {
+	return e.ID
+}
+
+func (e *NetworkInterface) Find(c *fi.CloudupContext) (*NetworkInterface, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	request := &ec2.DescribeNetworkInterfacesInput{}
+	if e.ID != nil {
+		request.NetworkInterfaceIds = []string{fi.ValueOf(e.ID)}
+	} else {
+		request.Filters = cloud.BuildFilters(e.Name)
+	}
+
+	response, err := cloud.EC2().DescribeNetworkInterfaces(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing NetworkInterfaces: %w", err)
+	}
+	if response == nil || len(response.NetworkInterfaces) == 0 {
+		return nil, nil
+	}
+	if len(response.NetworkInterfaces) != 1 {
+		return nil, fmt.Errorf("found multiple NetworkInterfaces matching tags")
+	}
+	eni := response.NetworkInterfaces[0]
+
+	actual := &NetworkInterface{
+		Name:             e.Name,
+		Lifecycle:        e.Lifecycle,
+		ID:               eni.NetworkInterfaceId,
+		Subnet:           &Subnet{ID: eni.SubnetId},
+		PrivateIPAddress: eni.PrivateIpAddress,
+		Tags:             intersectTags(eni.TagSet, e.Tags),
+	}
+	for _, sg := range eni.Groups {
+		actual.SecurityGroups = append(actual.SecurityGroups, &SecurityGroup{ID: sg.GroupId})
+	}
+	sort.Sort(OrderSecurityGroupsById(actual.SecurityGroups))
+
+	klog.V(2).Infof("found matching NetworkInterface %q", aws.ToString(actual.ID))
+
+	// Prevent spurious changes
+	if e.Subnet != nil && aws.ToString(e.Subnet.ID) == aws.ToString(actual.Subnet.ID) {
+		actual.Subnet = e.Subnet
+	}
+	if e.ID == nil {
+		e.ID = actual.ID
+	}
+
+	return actual, nil
+}
+
+func (e *NetworkInterface) Run(c *fi.CloudupContext) error {
+	sort.Stable(OrderSecurityGroupsById(e.SecurityGroups))
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (_ *NetworkInterface) CheckChanges(a, e, changes *NetworkInterface) error {
+	if a == nil {
+		if e.Subnet == nil {
+			return fi.RequiredField("Subnet")
+		}
+	} else {
+		if changes.Subnet != nil {
+			return fi.CannotChangeField("Subnet")
+		}
+		if changes.PrivateIPAddress != nil {
+			return fi.CannotChangeField("PrivateIPAddress")
+		}
+	}
+	return nil
+}
+
+func (_ *NetworkInterface) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *NetworkInterface) error {
+	ctx := context.TODO()
+
+	if a == nil {
+		klog.V(2).Infof("Creating NetworkInterface %q", fi.ValueOf(e.Name))
+
+		request := &ec2.CreateNetworkInterfaceInput{
+			SubnetId:          e.Subnet.ID,
+			PrivateIpAddress:  e.PrivateIPAddress,
+			TagSpecifications: awsup.EC2TagSpecification(ec2types.ResourceTypeNetworkInterface, e.Tags),
+		}
+		for _, sg := range e.SecurityGroups {
+			request.Groups = append(request.Groups, aws.ToString(sg.ID))
+		}
+
+		response, err := t.Cloud.EC2().CreateNetworkInterface(ctx, request)
+		if err != nil {
+			return fmt.Errorf("error creating NetworkInterface: %w", err)
+		}
+
+		e.ID = response.NetworkInterface.NetworkInterfaceId
+		return nil
+	}
+
+	if changes.SecurityGroups != nil {
+		request := &ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: a.ID,
+		}
+		for _, sg := range e.SecurityGroups {
+			request.Groups = append(request.Groups, aws.ToString(sg.ID))
+		}
+
+		if _, err := t.Cloud.EC2().ModifyNetworkInterfaceAttribute(ctx, request); err != nil {
+			return fmt.Errorf("error updating security groups of NetworkInterface %q: %w", aws.ToString(a.ID), err)
+		}
+	}
+
+	return t.UpdateTags(*a.ID, e.Tags)
+}
+
+type terraformNetworkInterface struct {
+	SubnetID       *terraformWriter.Literal   `cty:"subnet_id"`
+	PrivateIPs     []string                   `cty:"private_ips"`
+	SecurityGroups []*terraformWriter.Literal `cty:"security_groups"`
+	Tags           map[string]string          `cty:"tags"`
+}
+
+func (_ *NetworkInterface) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *NetworkInterface) error {
+	tf := &terraformNetworkInterface{
+		SubnetID: e.Subnet.TerraformLink(),
+		Tags:     e.Tags,
+	}
+	if e.PrivateIPAddress != nil {
+		tf.PrivateIPs = []string{aws.ToString(e.PrivateIPAddress)}
+	}
+	for _, sg := range e.SecurityGroups {
+		tf.SecurityGroups = append(tf.SecurityGroups, sg.TerraformLink())
+	}
+
+	return t.RenderResource("aws_network_interface", *e.Name, tf)
+}
+
+func (e *NetworkInterface) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("aws_network_interface", *e.Name, "id")
+}
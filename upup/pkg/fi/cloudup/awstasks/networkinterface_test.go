@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestNetworkInterfaceCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		subnet1 := &Subnet{
+			Name:      s("subnet1"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			CIDR:      s("172.20.1.0/24"),
+			Tags:      map[string]string{"Name": "subnet1"},
+		}
+		sg1 := &SecurityGroup{
+			Name:        s("sg1"),
+			Lifecycle:   fi.LifecycleSync,
+			Description: s("Description"),
+			VPC:         vpc1,
+			Tags:        map[string]string{"Name": "sg1"},
+		}
+		eni1 := &NetworkInterface{
+			Name:             s("eni1"),
+			Lifecycle:        fi.LifecycleSync,
+			Subnet:           subnet1,
+			PrivateIPAddress: s("172.20.1.10"),
+			SecurityGroups:   []*SecurityGroup{sg1},
+			Tags:             map[string]string{"Name": "eni1"},
+		}
+
+		return map[string]fi.CloudupTask{
+			"eni1":    eni1,
+			"sg1":     sg1,
+			"subnet1": subnet1,
+			"vpc1":    vpc1,
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		eni1 := allTasks["eni1"].(*NetworkInterface)
+
+		runTasks(t, cloud, allTasks)
+
+		if fi.ValueOf(eni1.ID) == "" {
+			t.Fatalf("ID not set after create")
+		}
+
+		if len(c.NetworkInterfaces) != 1 {
+			t.Fatalf("Expected exactly one NetworkInterface; found %v", c.NetworkInterfaces)
+		}
+
+		actual := c.NetworkInterfaces[*eni1.ID]
+		if actual == nil {
+			t.Fatalf("NetworkInterface created but then not found")
+		}
+		if aws.ToString(actual.PrivateIpAddress) != "172.20.1.10" {
+			t.Errorf("Unexpected private IP address: %q", aws.ToString(actual.PrivateIpAddress))
+		}
+		if aws.ToString(actual.SubnetId) != "subnet-1" {
+			t.Errorf("Unexpected subnet: %q", aws.ToString(actual.SubnetId))
+		}
+		if len(actual.Groups) != 1 || aws.ToString(actual.Groups[0].GroupId) != "sg-1" {
+			t.Errorf("Unexpected security groups: %v", actual.Groups)
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestNetworkInterfaceTerraformRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &NetworkInterface{
+				Name:             fi.PtrTo("control-plane-us-test-1a.example.com"),
+				Subnet:           &Subnet{Name: fi.PtrTo("us-test-1a.example.com")},
+				PrivateIPAddress: fi.PtrTo("172.20.32.10"),
+				SecurityGroups: []*SecurityGroup{
+					{Name: fi.PtrTo("masters.example.com")},
+				},
+				Tags: map[string]string{
+					"Name": "control-plane-us-test-1a.example.com",
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_network_interface" "control-plane-us-test-1a-example-com" {
+  private_ips     = ["172.20.32.10"]
+  security_groups = [aws_security_group.masters-example-com.id]
+  subnet_id       = aws_subnet.us-test-1a-example-com.id
+  tags = {
+    "Name" = "control-plane-us-test-1a.example.com"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	}
+	doRenderTests(t, "RenderTerraform", cases)
+}
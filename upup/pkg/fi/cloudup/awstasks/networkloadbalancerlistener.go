@@ -43,6 +43,10 @@ type NetworkLoadBalancerListener struct {
 	TargetGroup      *TargetGroup
 	SSLCertificateID string
 	SSLPolicy        string
+	// ALPNPolicy is the ALPN policy of a TLS listener, such as HTTP2Preferred.
+	ALPNPolicy string
+	// Certificate is an ACM certificate managed by kOps, used instead of SSLCertificateID.
+	Certificate *ACMCertificate
 
 	listenerArn string
 }
@@ -108,6 +112,10 @@ func (e *NetworkLoadBalancerListener) Find(c *fi.CloudupContext) (*NetworkLoadBa
 		if l.SslPolicy != nil {
 			actual.SSLPolicy = aws.ToString(l.SslPolicy)
 		}
+		// TLS listeners report a "None" policy when no ALPN policy was set
+		if len(l.AlpnPolicy) != 0 && (l.AlpnPolicy[0] != "None" || e.ALPNPolicy == "None") {
+			actual.ALPNPolicy = l.AlpnPolicy[0]
+		}
 	}
 
 	// This will need to be rearranged when we recognized multiple listeners and target groups per NLB
@@ -120,6 +128,11 @@ func (e *NetworkLoadBalancerListener) Find(c *fi.CloudupContext) (*NetworkLoadBa
 		}
 	}
 
+	if e.Certificate != nil && e.Certificate.ARN != nil && actual.SSLCertificateID == aws.ToString(e.Certificate.ARN) {
+		actual.SSLCertificateID = ""
+		actual.Certificate = e.Certificate
+	}
+
 	_ = actual.Normalize(c)
 	actual.Lifecycle = e.Lifecycle
 
@@ -155,6 +168,36 @@ func (*NetworkLoadBalancerListener) RenderAWS(t *awsup.AWSAPITarget, a, e, chang
 		return fmt.Errorf("load balancer not yet created (arn not set)")
 	}
 
+	certificateARN := e.SSLCertificateID
+	if e.Certificate != nil {
+		certificateARN = aws.ToString(e.Certificate.ARN)
+		if certificateARN == "" {
+			return fmt.Errorf("ACM certificate not yet requested (arn not set)")
+		}
+	}
+
+	tlsListener := a != nil && (a.SSLCertificateID != "" || a.Certificate != nil)
+	if tlsListener && certificateARN != "" && changes.Port == 0 && changes.TargetGroup == nil {
+		// Replace the certificate in place, so that the API remains reachable
+		klog.V(2).Infof("Modifying certificate of NLB listener %q", a.listenerArn)
+		request := &elbv2.ModifyListenerInput{
+			ListenerArn: aws.String(a.listenerArn),
+			Certificates: []elbv2types.Certificate{
+				{CertificateArn: aws.String(certificateARN)},
+			},
+		}
+		if e.SSLPolicy != "" {
+			request.SslPolicy = aws.String(e.SSLPolicy)
+		}
+		if e.ALPNPolicy != "" {
+			request.AlpnPolicy = []string{e.ALPNPolicy}
+		}
+		if _, err := t.Cloud.ELBV2().ModifyListener(ctx, request); err != nil {
+			return fmt.Errorf("error modifying NLB listener %q: %w", a.listenerArn, err)
+		}
+		return nil
+	}
+
 	if a != nil {
 		// TODO: Can we do better here?
 		klog.Warningf("deleting ELB listener %q for required changes (%+v)", a.listenerArn, changes)
@@ -188,15 +231,18 @@ func (*NetworkLoadBalancerListener) RenderAWS(t *awsup.AWSAPITarget, a, e, chang
 			Port:            aws.Int32(int32(e.Port)),
 		}
 
-		if e.SSLCertificateID != "" {
+		if certificateARN != "" {
 			request.Certificates = []elbv2types.Certificate{}
 			request.Certificates = append(request.Certificates, elbv2types.Certificate{
-				CertificateArn: aws.String(e.SSLCertificateID),
+				CertificateArn: aws.String(certificateARN),
 			})
 			request.Protocol = elbv2types.ProtocolEnumTls
 			if e.SSLPolicy != "" {
 				request.SslPolicy = aws.String(e.SSLPolicy)
 			}
+			if e.ALPNPolicy != "" {
+				request.AlpnPolicy = []string{e.ALPNPolicy}
+			}
 		} else {
 			request.Protocol = elbv2types.ProtocolEnumTcp
 		}
@@ -215,8 +261,9 @@ type terraformNetworkLoadBalancerListener struct {
 	LoadBalancer   *terraformWriter.Literal                     `cty:"load_balancer_arn"`
 	Port           int64                                        `cty:"port"`
 	Protocol       elbv2types.ProtocolEnum                      `cty:"protocol"`
-	CertificateARN *string                                      `cty:"certificate_arn"`
+	CertificateARN *terraformWriter.Literal                     `cty:"certificate_arn"`
 	SSLPolicy      *string                                      `cty:"ssl_policy"`
+	ALPNPolicy     *string                                      `cty:"alpn_policy"`
 	DefaultAction  []terraformNetworkLoadBalancerListenerAction `cty:"default_action"`
 }
 
@@ -239,12 +286,19 @@ func (_ *NetworkLoadBalancerListener) RenderTerraform(t *terraform.TerraformTarg
 			},
 		},
 	}
-	if e.SSLCertificateID != "" {
-		listenerTF.CertificateARN = &e.SSLCertificateID
+	if e.Certificate != nil {
+		listenerTF.CertificateARN = e.Certificate.TerraformLink()
+	} else if e.SSLCertificateID != "" {
+		listenerTF.CertificateARN = terraformWriter.LiteralFromStringValue(e.SSLCertificateID)
+	}
+	if listenerTF.CertificateARN != nil {
 		listenerTF.Protocol = elbv2types.ProtocolEnumTls
 		if e.SSLPolicy != "" {
 			listenerTF.SSLPolicy = &e.SSLPolicy
 		}
+		if e.ALPNPolicy != "" {
+			listenerTF.ALPNPolicy = &e.ALPNPolicy
+		}
 	} else {
 		listenerTF.Protocol = elbv2types.ProtocolEnumTcp
 	}
@@ -47,6 +47,9 @@ type Route struct {
 	NatGateway                *NatGateway
 	TransitGatewayID          *string
 	VPCPeeringConnectionID    *string
+
+	// TransitGatewayAttachment is the attachment through which TransitGatewayID is reached, if managed by kOps.
+	TransitGatewayAttachment *TransitGatewayAttachment
 }
 
 func (e *Route) Find(c *fi.CloudupContext) (*Route, error) {
@@ -102,6 +105,8 @@ func (e *Route) Find(c *fi.CloudupContext) (*Route, error) {
 			}
 			if r.TransitGatewayId != nil {
 				actual.TransitGatewayID = r.TransitGatewayId
+				// The attachment is only used for ordering
+				actual.TransitGatewayAttachment = e.TransitGatewayAttachment
 			}
 			if r.VpcPeeringConnectionId != nil {
 				actual.VPCPeeringConnectionID = r.VpcPeeringConnectionId
@@ -291,7 +296,7 @@ type terraformRoute struct {
 	EgressOnlyInternetGatewayID *terraformWriter.Literal `cty:"egress_only_gateway_id"`
 	InternetGatewayID           *terraformWriter.Literal `cty:"gateway_id"`
 	NATGatewayID                *terraformWriter.Literal `cty:"nat_gateway_id"`
-	TransitGatewayID            *string                  `cty:"transit_gateway_id"`
+	TransitGatewayID            *terraformWriter.Literal `cty:"transit_gateway_id"`
 	InstanceID                  *terraformWriter.Literal `cty:"instance_id"`
 	VPCPeeringConnectionID      *string                  `cty:"vpc_peering_connection_id"`
 }
@@ -311,8 +316,10 @@ func (_ *Route) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Rou
 		tf.InternetGatewayID = e.InternetGateway.TerraformLink()
 	} else if e.NatGateway != nil {
 		tf.NATGatewayID = e.NatGateway.TerraformLink()
+	} else if e.TransitGatewayAttachment != nil {
+		tf.TransitGatewayID = e.TransitGatewayAttachment.TerraformLinkTransitGatewayID()
 	} else if e.TransitGatewayID != nil {
-		tf.TransitGatewayID = e.TransitGatewayID
+		tf.TransitGatewayID = terraformWriter.LiteralFromStringValue(*e.TransitGatewayID)
 	} else if e.VPCPeeringConnectionID != nil {
 		tf.VPCPeeringConnectionID = e.VPCPeeringConnectionID
 	}
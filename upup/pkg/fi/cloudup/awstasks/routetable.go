@@ -50,6 +50,15 @@ func (e *RouteTable) CompareWithID() *string {
 	return e.ID
 }
 
+// OrderRouteTablesById implements sort.Interface for []RouteTable, based on ID
+type OrderRouteTablesById []*RouteTable
+
+func (a OrderRouteTablesById) Len() int      { return len(a) }
+func (a OrderRouteTablesById) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a OrderRouteTablesById) Less(i, j int) bool {
+	return fi.ValueOf(a[i].ID) < fi.ValueOf(a[j].ID)
+}
+
 func (e *RouteTable) Find(c *fi.CloudupContext) (*RouteTable, error) {
 	ctx := c.Context()
 	cloud := c.T.Cloud.(awsup.AWSCloud)
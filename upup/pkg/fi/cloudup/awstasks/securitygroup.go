@@ -183,6 +183,10 @@ func (_ *SecurityGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Security
 		}
 
 		e.ID = response.GroupId
+
+		if err := t.WaitForSecurityGroup(ctx, *e.ID); err != nil {
+			return err
+		}
 	}
 
 	return t.AddAWSTags(*e.ID, e.Tags)
@@ -153,6 +153,10 @@ func (e *SecurityGroupRule) SetCidrOrPrefix(cidr string) {
 }
 
 func (e *SecurityGroupRule) matches(rule *ec2types.SecurityGroupRule) bool {
+	if fi.ValueOf(e.Egress) != aws.ToBool(rule.IsEgress) {
+		return false
+	}
+
 	matchFromPort := int32(-1)
 	if e.FromPort != nil {
 		matchFromPort = *e.FromPort
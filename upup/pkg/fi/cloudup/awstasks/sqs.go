@@ -187,6 +187,14 @@ func (q *SQS) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *SQS) error {
 		}
 
 		e.ARN = aws.String(attributes.Attributes["QueueArn"])
+	} else if changes.Tags != nil {
+		_, err := t.Cloud.SQS().TagQueue(ctx, &sqs.TagQueueInput{
+			QueueUrl: a.URL,
+			Tags:     e.Tags,
+		})
+		if err != nil {
+			return fmt.Errorf("error tagging SQS queue: %v", err)
+		}
 	}
 
 	return nil
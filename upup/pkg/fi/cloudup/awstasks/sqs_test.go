@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"k8s.io/kops/cloudmock/aws/mocksqs"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestSQSUpdatesTags(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mocksqs.MockSQS{}
+	cloud.MockSQS = c
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(tags map[string]string) map[string]fi.CloudupTask {
+		queue := &SQS{
+			Name:                   s("queue1"),
+			Lifecycle:              fi.LifecycleSync,
+			Policy:                 fi.NewStringResource(`{"Version":"2012-10-17"}`),
+			MessageRetentionPeriod: 300,
+			Tags:                   tags,
+		}
+		return map[string]fi.CloudupTask{
+			"queue1": queue,
+		}
+	}
+
+	runTasks(t, cloud, buildTasks(map[string]string{"owner": "team-a"}))
+
+	// Changing the tags updates them in place
+	tags := map[string]string{"owner": "team-b", "cost-center": "1234"}
+	runTasks(t, cloud, buildTasks(tags))
+
+	if len(c.Queues) != 1 {
+		t.Fatalf("expected exactly one queue, found %d", len(c.Queues))
+	}
+	actual, err := c.ListQueueTags(ctx, &sqs.ListQueueTagsInput{
+		QueueUrl: aws.String("https://sqs.us-east-1.amazonaws.com/123456789123/queue1"),
+	})
+	if err != nil {
+		t.Fatalf("error listing queue tags: %v", err)
+	}
+	if !reflect.DeepEqual(actual.Tags, tags) {
+		t.Errorf("unexpected queue tags: expected=%v actual=%v", tags, actual.Tags)
+	}
+
+	checkNoChanges(t, ctx, cloud, buildTasks(tags))
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// SSMDocument is an SSM document, such as the Session Manager preferences document.
+// +kops:fitask
+type SSMDocument struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	// DocumentType is the type of the document, for example Session.
+	DocumentType *string
+	// Content is the JSON content of the document.
+	Content fi.Resource
+}
+
+var _ fi.CompareWithID = &SSMDocument{}
+
+func (e *SSMDocument) CompareWithID() *string {
+	return e.Name
+}
+
+func (e *SSMDocument) Find(c *fi.CloudupContext) (*SSMDocument, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	response, err := cloud.SSM().GetDocument(ctx, &ssm.GetDocumentInput{
+		Name: e.Name,
+	})
+	if err != nil {
+		var invalidDocument *ssmtypes.InvalidDocument
+		if errors.As(err, &invalidDocument) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting SSM document %q: %w", aws.ToString(e.Name), err)
+	}
+
+	actualContent := aws.ToString(response.Content)
+
+	// We parse both as JSON; if the json forms are equal we pretend the actual value is the expected value
+	if e.Content != nil {
+		expectedContent, err := fi.ResourceAsString(e.Content)
+		if err != nil {
+			return nil, fmt.Errorf("error reading expected content for SSM document %q: %w", aws.ToString(e.Name), err)
+		}
+		equal, err := jsonEqual(expectedContent, actualContent)
+		if err != nil {
+			return nil, fmt.Errorf("error comparing content for SSM document %q: %w", aws.ToString(e.Name), err)
+		}
+		if equal {
+			klog.V(2).Infof("actual content was json-equal to expected; returning expected value")
+			actualContent = expectedContent
+		}
+	}
+
+	actual := &SSMDocument{
+		Name:         e.Name,
+		Lifecycle:    e.Lifecycle,
+		DocumentType: aws.String(string(response.DocumentType)),
+		Content:      fi.NewStringResource(actualContent),
+	}
+
+	return actual, nil
+}
+
+// jsonEqual returns true if both documents are equal once parsed as JSON.
+func jsonEqual(expected, actual string) (bool, error) {
+	var expectedJSON, actualJSON interface{}
+	if err := json.Unmarshal([]byte(expected), &expectedJSON); err != nil {
+		return false, fmt.Errorf("error parsing expected json: %w", err)
+	}
+	if err := json.Unmarshal([]byte(actual), &actualJSON); err != nil {
+		return false, nil
+	}
+	return reflect.DeepEqual(expectedJSON, actualJSON), nil
+}
+
+func (e *SSMDocument) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (_ *SSMDocument) CheckChanges(a, e, changes *SSMDocument) error {
+	if a == nil {
+		if e.Name == nil {
+			return field.Required(field.NewPath("Name"), "")
+		}
+		if e.DocumentType == nil {
+			return field.Required(field.NewPath("DocumentType"), "")
+		}
+	}
+	if a != nil {
+		if changes.DocumentType != nil {
+			return fi.CannotChangeField("DocumentType")
+		}
+	}
+	return nil
+}
+
+func (_ *SSMDocument) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *SSMDocument) error {
+	ctx := context.TODO()
+
+	content, err := fi.ResourceAsString(e.Content)
+	if err != nil {
+		return fmt.Errorf("error rendering SSM document content: %w", err)
+	}
+
+	if a == nil {
+		klog.V(2).Infof("Creating SSM document %q", aws.ToString(e.Name))
+		_, err := t.Cloud.SSM().CreateDocument(ctx, &ssm.CreateDocumentInput{
+			Name:           e.Name,
+			Content:        aws.String(content),
+			DocumentType:   ssmtypes.DocumentType(aws.ToString(e.DocumentType)),
+			DocumentFormat: ssmtypes.DocumentFormatJson,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating SSM document %q: %w", aws.ToString(e.Name), err)
+		}
+		return nil
+	}
+
+	if changes.Content != nil {
+		klog.V(2).Infof("Updating SSM document %q", aws.ToString(e.Name))
+		response, err := t.Cloud.SSM().UpdateDocument(ctx, &ssm.UpdateDocumentInput{
+			Name:            e.Name,
+			Content:         aws.String(content),
+			DocumentFormat:  ssmtypes.DocumentFormatJson,
+			DocumentVersion: aws.String("$LATEST"),
+		})
+		if err != nil {
+			return fmt.Errorf("error updating SSM document %q: %w", aws.ToString(e.Name), err)
+		}
+
+		// Sessions use the default version, which is not changed by the update
+		_, err = t.Cloud.SSM().UpdateDocumentDefaultVersion(ctx, &ssm.UpdateDocumentDefaultVersionInput{
+			Name:            e.Name,
+			DocumentVersion: response.DocumentDescription.DocumentVersion,
+		})
+		if err != nil {
+			return fmt.Errorf("error setting default version of SSM document %q: %w", aws.ToString(e.Name), err)
+		}
+	}
+
+	return nil
+}
+
+type terraformSSMDocument struct {
+	Name           *string                  `cty:"name"`
+	DocumentType   *string                  `cty:"document_type"`
+	DocumentFormat *string                  `cty:"document_format"`
+	Content        *terraformWriter.Literal `cty:"content"`
+}
+
+func (_ *SSMDocument) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *SSMDocument) error {
+	content, err := t.AddFileResource("aws_ssm_document", *e.Name, "content", e.Content, false)
+	if err != nil {
+		return err
+	}
+
+	tf := &terraformSSMDocument{
+		Name:           e.Name,
+		DocumentType:   e.DocumentType,
+		DocumentFormat: aws.String(string(ssmtypes.DocumentFormatJson)),
+		Content:        content,
+	}
+
+	return t.RenderResource("aws_ssm_document", *e.Name, tf)
+}
@@ -52,6 +52,8 @@ type Subnet struct {
 	CIDR                        *string
 	IPv6CIDR                    *string
 	ResourceBasedNaming         *bool
+	ResourceNameDNSARecord      *bool
+	ResourceNameDNSAAAARecord   *bool
 	AssignIPv6AddressOnCreation *bool
 	Shared                      *bool
 
@@ -111,14 +113,10 @@ func (e *Subnet) Find(c *fi.CloudupContext) (*Subnet, error) {
 
 	actual.AssignIPv6AddressOnCreation = subnet.AssignIpv6AddressOnCreation
 
-	actual.ResourceBasedNaming = fi.PtrTo(subnet.PrivateDnsNameOptionsOnLaunch.HostnameType == ec2types.HostnameTypeResourceName)
-	if *actual.ResourceBasedNaming {
-		if fi.ValueOf(actual.CIDR) != "" && !aws.ToBool(subnet.PrivateDnsNameOptionsOnLaunch.EnableResourceNameDnsARecord) {
-			actual.ResourceBasedNaming = nil
-		}
-		if fi.ValueOf(actual.IPv6CIDR) != "" && !aws.ToBool(subnet.PrivateDnsNameOptionsOnLaunch.EnableResourceNameDnsAAAARecord) {
-			actual.ResourceBasedNaming = nil
-		}
+	if options := subnet.PrivateDnsNameOptionsOnLaunch; options != nil {
+		actual.ResourceBasedNaming = fi.PtrTo(options.HostnameType == ec2types.HostnameTypeResourceName)
+		actual.ResourceNameDNSARecord = fi.PtrTo(aws.ToBool(options.EnableResourceNameDnsARecord))
+		actual.ResourceNameDNSAAAARecord = fi.PtrTo(aws.ToBool(options.EnableResourceNameDnsAAAARecord))
 	}
 
 	klog.V(2).Infof("found matching subnet %q", *actual.ID)
@@ -228,6 +226,8 @@ func (s *Subnet) CheckChanges(a, e, changes *Subnet) error {
 func (_ *Subnet) ShouldCreate(a, e, changes *Subnet) (bool, error) {
 	if fi.ValueOf(e.Shared) {
 		changes.ResourceBasedNaming = nil
+		changes.ResourceNameDNSARecord = nil
+		changes.ResourceNameDNSAAAARecord = nil
 		return changes.Tags != nil, nil
 	}
 	return true, nil
@@ -332,26 +332,28 @@ func (_ *Subnet) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Subnet) error {
 			if err != nil {
 				return fmt.Errorf("error enabling DNS64: %w", err)
 			}
-		} else {
-			request = &ec2.ModifySubnetAttributeInput{
-				SubnetId:                             e.ID,
-				EnableResourceNameDnsARecordOnLaunch: &ec2types.AttributeBooleanValue{Value: changes.ResourceBasedNaming},
-			}
-			_, err = t.Cloud.EC2().ModifySubnetAttribute(ctx, request)
-			if err != nil {
-				return fmt.Errorf("error modifying A records: %w", err)
-			}
 		}
+	}
 
-		if fi.ValueOf(e.IPv6CIDR) != "" {
-			request = &ec2.ModifySubnetAttributeInput{
-				SubnetId:                                e.ID,
-				EnableResourceNameDnsAAAARecordOnLaunch: &ec2types.AttributeBooleanValue{Value: changes.ResourceBasedNaming},
-			}
-			_, err = t.Cloud.EC2().ModifySubnetAttribute(ctx, request)
-			if err != nil {
-				return fmt.Errorf("error modifying AAAA records: %w", err)
-			}
+	if changes.ResourceNameDNSARecord != nil {
+		request := &ec2.ModifySubnetAttributeInput{
+			SubnetId:                             e.ID,
+			EnableResourceNameDnsARecordOnLaunch: &ec2types.AttributeBooleanValue{Value: changes.ResourceNameDNSARecord},
+		}
+		_, err := t.Cloud.EC2().ModifySubnetAttribute(ctx, request)
+		if err != nil {
+			return fmt.Errorf("error modifying A records: %w", err)
+		}
+	}
+
+	if changes.ResourceNameDNSAAAARecord != nil {
+		request := &ec2.ModifySubnetAttributeInput{
+			SubnetId:                                e.ID,
+			EnableResourceNameDnsAAAARecordOnLaunch: &ec2types.AttributeBooleanValue{Value: changes.ResourceNameDNSAAAARecord},
+		}
+		_, err := t.Cloud.EC2().ModifySubnetAttribute(ctx, request)
+		if err != nil {
+			return fmt.Errorf("error modifying AAAA records: %w", err)
 		}
 	}
 
@@ -448,13 +450,9 @@ func (_ *Subnet) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Su
 			hostnameType = ec2types.HostnameTypeResourceName
 		}
 		tf.PrivateDNSHostnameTypeOnLaunch = fi.PtrTo(string(hostnameType))
-		if fi.ValueOf(e.CIDR) != "" {
-			tf.EnableResourceNameDNSARecordOnLaunch = e.ResourceBasedNaming
-		}
-		if fi.ValueOf(e.IPv6CIDR) != "" {
-			tf.EnableResourceNameDNSAAAARecordOnLaunch = e.ResourceBasedNaming
-		}
 	}
+	tf.EnableResourceNameDNSARecordOnLaunch = e.ResourceNameDNSARecord
+	tf.EnableResourceNameDNSAAAARecordOnLaunch = e.ResourceNameDNSAAAARecord
 
 	return t.RenderResource("aws_subnet", *e.Name, tf)
 }
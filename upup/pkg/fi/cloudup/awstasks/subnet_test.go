@@ -81,12 +81,13 @@ func TestSubnetCreate(t *testing.T) {
 			Tags:      map[string]string{"Name": "vpc1"},
 		}
 		subnet1 := &Subnet{
-			Name:                s("subnet1"),
-			Lifecycle:           fi.LifecycleSync,
-			VPC:                 vpc1,
-			CIDR:                s("172.20.1.0/24"),
-			ResourceBasedNaming: fi.PtrTo(true),
-			Tags:                map[string]string{"Name": "subnet1"},
+			Name:                   s("subnet1"),
+			Lifecycle:              fi.LifecycleSync,
+			VPC:                    vpc1,
+			CIDR:                   s("172.20.1.0/24"),
+			ResourceBasedNaming:    fi.PtrTo(true),
+			ResourceNameDNSARecord: fi.PtrTo(true),
+			Tags:                   map[string]string{"Name": "subnet1"},
 		}
 
 		return map[string]fi.CloudupTask{
@@ -160,13 +161,15 @@ func TestSubnetCreateIPv6(t *testing.T) {
 			VPC:       vpc1,
 		}
 		subnet1 := &Subnet{
-			Name:                s("subnet1"),
-			Lifecycle:           fi.LifecycleSync,
-			VPC:                 vpc1,
-			CIDR:                s("172.20.1.0/24"),
-			IPv6CIDR:            s("2001:db8:0:1::/64"),
-			ResourceBasedNaming: fi.PtrTo(true),
-			Tags:                map[string]string{"Name": "subnet1"},
+			Name:                      s("subnet1"),
+			Lifecycle:                 fi.LifecycleSync,
+			VPC:                       vpc1,
+			CIDR:                      s("172.20.1.0/24"),
+			IPv6CIDR:                  s("2001:db8:0:1::/64"),
+			ResourceBasedNaming:       fi.PtrTo(true),
+			ResourceNameDNSARecord:    fi.PtrTo(true),
+			ResourceNameDNSAAAARecord: fi.PtrTo(true),
+			Tags:                      map[string]string{"Name": "subnet1"},
 		}
 
 		return map[string]fi.CloudupTask{
@@ -42,6 +42,9 @@ const (
 	// to wait before changing the state of a deregistering target from draining to unused.
 	// https://docs.aws.amazon.com/elasticloadbalancing/latest/network/load-balancer-target-groups.html#deregistration-delay
 	TargetGroupAttributeDeregistrationDelayTimeoutSeconds = "deregistration_delay.timeout_seconds"
+	// TargetGroupAttributePreserveClientIPEnabled indicates whether client IP preservation is enabled.
+	// https://docs.aws.amazon.com/elasticloadbalancing/latest/network/load-balancer-target-groups.html#client-ip-preservation
+	TargetGroupAttributePreserveClientIPEnabled = "preserve_client_ip.enabled"
 )
 
 // +kops:fitask
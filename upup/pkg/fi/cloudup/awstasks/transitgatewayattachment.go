@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// TransitGatewayAttachment attaches the VPC to an existing transit gateway.
+// +kops:fitask
+type TransitGatewayAttachment struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID *string
+	// TransitGatewayID is the ID of the existing transit gateway.
+	TransitGatewayID *string
+	VPC              *VPC
+	// Subnets are the subnets in which the attachment places its network interfaces, at most one per zone.
+	Subnets []*Subnet
+
+	// Tags is a map of aws tags that are added to the TransitGatewayAttachment
+	Tags map[string]string
+}
+
+var _ fi.CompareWithID = &TransitGatewayAttachment{}
+
+func (e *TransitGatewayAttachment) CompareWithID() *string {
+	return e.ID
+}
+
+func (e *TransitGatewayAttachment) Find(c *fi.CloudupContext) (*TransitGatewayAttachment, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	request := &ec2.DescribeTransitGatewayVpcAttachmentsInput{}
+	if e.ID != nil {
+		request.TransitGatewayAttachmentIds = []string{fi.ValueOf(e.ID)}
+	} else {
+		if e.VPC == nil || e.VPC.ID == nil {
+			return nil, nil
+		}
+		request.Filters = cloud.BuildFilters(e.Name)
+		request.Filters = append(request.Filters,
+			awsup.NewEC2Filter("transit-gateway-id", fi.ValueOf(e.TransitGatewayID)),
+			awsup.NewEC2Filter("vpc-id", fi.ValueOf(e.VPC.ID)))
+	}
+
+	attachment, err := findTransitGatewayAttachment(ctx, cloud, request)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		return nil, nil
+	}
+
+	actual := &TransitGatewayAttachment{
+		ID:               attachment.TransitGatewayAttachmentId,
+		Name:             findNameTag(attachment.Tags),
+		TransitGatewayID: attachment.TransitGatewayId,
+		VPC:              &VPC{ID: attachment.VpcId},
+		Tags:             intersectTags(attachment.Tags, e.Tags),
+	}
+	for _, subnetID := range attachment.SubnetIds {
+		actual.Subnets = append(actual.Subnets, &Subnet{ID: aws.String(subnetID)})
+	}
+	sort.Sort(OrderSubnetsById(actual.Subnets))
+	sort.Stable(OrderSubnetsById(e.Subnets))
+
+	klog.V(2).Infof("found matching TransitGatewayAttachment %q", aws.ToString(actual.ID))
+
+	// Prevent spurious comparison failures
+	actual.Lifecycle = e.Lifecycle
+	if e.ID == nil {
+		e.ID = actual.ID
+	}
+
+	return actual, nil
+}
+
+func findTransitGatewayAttachment(ctx context.Context, cloud awsup.AWSCloud, request *ec2.DescribeTransitGatewayVpcAttachmentsInput) (*ec2types.TransitGatewayVpcAttachment, error) {
+	response, err := cloud.EC2().DescribeTransitGatewayVpcAttachments(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing TransitGatewayAttachments: %v", err)
+	}
+
+	var found []ec2types.TransitGatewayVpcAttachment
+	for _, attachment := range response.TransitGatewayVpcAttachments {
+		switch attachment.State {
+		case ec2types.TransitGatewayAttachmentStateDeleting, ec2types.TransitGatewayAttachmentStateDeleted,
+			ec2types.TransitGatewayAttachmentStateFailed, ec2types.TransitGatewayAttachmentStateRejected:
+			// Ignore the attachments that are going away
+			continue
+		}
+		found = append(found, attachment)
+	}
+	if len(found) == 0 {
+		return nil, nil
+	}
+	if len(found) != 1 {
+		return nil, fmt.Errorf("found multiple TransitGatewayAttachments matching tags")
+	}
+	return &found[0], nil
+}
+
+func (e *TransitGatewayAttachment) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (s *TransitGatewayAttachment) CheckChanges(a, e, changes *TransitGatewayAttachment) error {
+	if a == nil {
+		if e.TransitGatewayID == nil {
+			return fi.RequiredField("TransitGatewayID")
+		}
+		if e.VPC == nil {
+			return fi.RequiredField("VPC")
+		}
+		if len(e.Subnets) == 0 {
+			return fi.RequiredField("Subnets")
+		}
+	}
+
+	if a != nil {
+		if changes.TransitGatewayID != nil {
+			return fi.CannotChangeField("TransitGatewayID")
+		}
+		if changes.VPC != nil {
+			return fi.CannotChangeField("VPC")
+		}
+	}
+
+	return nil
+}
+
+func (_ *TransitGatewayAttachment) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *TransitGatewayAttachment) error {
+	ctx := context.TODO()
+
+	if a == nil {
+		klog.V(2).Infof("Creating TransitGatewayAttachment to %q", fi.ValueOf(e.TransitGatewayID))
+
+		request := &ec2.CreateTransitGatewayVpcAttachmentInput{
+			TransitGatewayId:  e.TransitGatewayID,
+			VpcId:             e.VPC.ID,
+			TagSpecifications: awsup.EC2TagSpecification(ec2types.ResourceTypeTransitGatewayAttachment, e.Tags),
+		}
+		for _, subnet := range e.Subnets {
+			request.SubnetIds = append(request.SubnetIds, fi.ValueOf(subnet.ID))
+		}
+
+		response, err := t.Cloud.EC2().CreateTransitGatewayVpcAttachment(ctx, request)
+		if err != nil {
+			return fmt.Errorf("error creating TransitGatewayAttachment: %v", err)
+		}
+
+		e.ID = response.TransitGatewayVpcAttachment.TransitGatewayAttachmentId
+		return nil
+	}
+
+	if changes.Subnets != nil {
+		request := &ec2.ModifyTransitGatewayVpcAttachmentInput{
+			TransitGatewayAttachmentId: a.ID,
+		}
+		actualSubnets := make(map[string]bool)
+		for _, subnet := range a.Subnets {
+			actualSubnets[fi.ValueOf(subnet.ID)] = true
+		}
+		expectedSubnets := make(map[string]bool)
+		for _, subnet := range e.Subnets {
+			id := fi.ValueOf(subnet.ID)
+			expectedSubnets[id] = true
+			if !actualSubnets[id] {
+				request.AddSubnetIds = append(request.AddSubnetIds, id)
+			}
+		}
+		for _, subnet := range a.Subnets {
+			id := fi.ValueOf(subnet.ID)
+			if !expectedSubnets[id] {
+				request.RemoveSubnetIds = append(request.RemoveSubnetIds, id)
+			}
+		}
+
+		klog.V(2).Infof("Modifying subnets of TransitGatewayAttachment %q", fi.ValueOf(a.ID))
+		if _, err := t.Cloud.EC2().ModifyTransitGatewayVpcAttachment(ctx, request); err != nil {
+			return fmt.Errorf("error modifying TransitGatewayAttachment %q: %v", fi.ValueOf(a.ID), err)
+		}
+	}
+
+	return t.UpdateTags(fi.ValueOf(a.ID), e.Tags)
+}
+
+type terraformTransitGatewayAttachment struct {
+	SubnetIDs        []*terraformWriter.Literal `cty:"subnet_ids"`
+	TransitGatewayID *string                    `cty:"transit_gateway_id"`
+	VPCID            *terraformWriter.Literal   `cty:"vpc_id"`
+	Tags             map[string]string          `cty:"tags"`
+}
+
+func (_ *TransitGatewayAttachment) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *TransitGatewayAttachment) error {
+	tf := &terraformTransitGatewayAttachment{
+		TransitGatewayID: e.TransitGatewayID,
+		VPCID:            e.VPC.TerraformLink(),
+		Tags:             e.Tags,
+	}
+	for _, subnet := range e.Subnets {
+		tf.SubnetIDs = append(tf.SubnetIDs, subnet.TerraformLink())
+	}
+	terraformWriter.SortLiterals(tf.SubnetIDs)
+
+	return t.RenderResource("aws_ec2_transit_gateway_vpc_attachment", *e.Name, tf)
+}
+
+func (e *TransitGatewayAttachment) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("aws_ec2_transit_gateway_vpc_attachment", *e.Name, "id")
+}
+
+// TerraformLinkTransitGatewayID returns the ID of the transit gateway through the attachment,
+// so that the resources routing to the transit gateway are created after the attachment.
+func (e *TransitGatewayAttachment) TerraformLinkTransitGatewayID() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("aws_ec2_transit_gateway_vpc_attachment", *e.Name, "transit_gateway_id")
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestTransitGatewayAttachmentCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(subnetNames ...string) map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		subnet1 := &Subnet{
+			Name:      s("subnet1"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			CIDR:      s("172.20.1.0/24"),
+			Tags:      map[string]string{"Name": "subnet1"},
+		}
+		subnet2 := &Subnet{
+			Name:      s("subnet2"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			CIDR:      s("172.20.2.0/24"),
+			Tags:      map[string]string{"Name": "subnet2"},
+		}
+		subnets := map[string]*Subnet{"subnet1": subnet1, "subnet2": subnet2}
+		tgwa1 := &TransitGatewayAttachment{
+			Name:             s("tgwa1"),
+			Lifecycle:        fi.LifecycleSync,
+			TransitGatewayID: s("tgw-123"),
+			VPC:              vpc1,
+			Tags:             map[string]string{"Name": "tgwa1"},
+		}
+		for _, name := range subnetNames {
+			tgwa1.Subnets = append(tgwa1.Subnets, subnets[name])
+		}
+
+		return map[string]fi.CloudupTask{
+			"tgwa1":   tgwa1,
+			"subnet1": subnet1,
+			"subnet2": subnet2,
+			"vpc1":    vpc1,
+		}
+	}
+
+	{
+		allTasks := buildTasks("subnet1")
+		tgwa1 := allTasks["tgwa1"].(*TransitGatewayAttachment)
+		subnet1 := allTasks["subnet1"].(*Subnet)
+
+		runTasks(t, cloud, allTasks)
+
+		if fi.ValueOf(tgwa1.ID) == "" {
+			t.Fatalf("ID not set after create")
+		}
+
+		if len(c.TransitGatewayVpcAttachments) != 1 {
+			t.Fatalf("Expected exactly one TransitGatewayVpcAttachment; found %v", c.TransitGatewayVpcAttachments)
+		}
+
+		actual := c.TransitGatewayVpcAttachments[*tgwa1.ID]
+		if fi.ValueOf(actual.TransitGatewayId) != "tgw-123" {
+			t.Fatalf("Unexpected TransitGatewayId: %v", fi.ValueOf(actual.TransitGatewayId))
+		}
+		if !reflect.DeepEqual(actual.SubnetIds, []string{*subnet1.ID}) {
+			t.Fatalf("Unexpected SubnetIds: %v", actual.SubnetIds)
+		}
+	}
+
+	{
+		allTasks := buildTasks("subnet1")
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks("subnet2")
+		tgwa1 := allTasks["tgwa1"].(*TransitGatewayAttachment)
+		subnet2 := allTasks["subnet2"].(*Subnet)
+
+		runTasks(t, cloud, allTasks)
+
+		if len(c.TransitGatewayVpcAttachments) != 1 {
+			t.Fatalf("Expected exactly one TransitGatewayVpcAttachment; found %v", c.TransitGatewayVpcAttachments)
+		}
+
+		actual := c.TransitGatewayVpcAttachments[*tgwa1.ID]
+		if !reflect.DeepEqual(actual.SubnetIds, []string{*subnet2.ID}) {
+			t.Fatalf("Unexpected SubnetIds after modify: %v", actual.SubnetIds)
+		}
+	}
+
+	{
+		allTasks := buildTasks("subnet2")
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestTransitGatewayAttachmentTerraformRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &TransitGatewayAttachment{
+				Name:             fi.PtrTo("example.com"),
+				TransitGatewayID: fi.PtrTo("tgw-123"),
+				VPC:              &VPC{Name: fi.PtrTo("example.com")},
+				Subnets: []*Subnet{
+					{Name: fi.PtrTo("us-test-1b.example.com")},
+					{Name: fi.PtrTo("us-test-1a.example.com")},
+				},
+				Tags: map[string]string{
+					"Name": "example.com",
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_ec2_transit_gateway_vpc_attachment" "example-com" {
+  subnet_ids = [aws_subnet.us-test-1a-example-com.id, aws_subnet.us-test-1b-example-com.id]
+  tags = {
+    "Name" = "example.com"
+  }
+  transit_gateway_id = "tgw-123"
+  vpc_id             = aws_vpc.example-com.id
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &Route{
+				Name:                     fi.PtrTo("transitgateway-10.0.0.0/8"),
+				CIDR:                     fi.PtrTo("10.0.0.0/8"),
+				RouteTable:               &RouteTable{Name: fi.PtrTo("example.com")},
+				TransitGatewayID:         fi.PtrTo("tgw-123"),
+				TransitGatewayAttachment: &TransitGatewayAttachment{Name: fi.PtrTo("example.com")},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_route" "route-transitgateway-10-0-0-0--8" {
+  destination_cidr_block = "10.0.0.0/8"
+  route_table_id         = aws_route_table.example-com.id
+  transit_gateway_id     = aws_ec2_transit_gateway_vpc_attachment.example-com.transit_gateway_id
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	}
+	doRenderTests(t, "RenderTerraform", cases)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/upup/pkg/fi"
@@ -98,11 +99,44 @@ func (_ *VPCDHCPOptionsAssociation) RenderAWS(t *awsup.AWSAPITarget, a, e, chang
 		if err != nil {
 			return fmt.Errorf("error creating VPCDHCPOptionsAssociation: %v", err)
 		}
+
+		if a != nil && a.DHCPOptions != nil && a.DHCPOptions.ID != nil {
+			if err := deleteReplacedDHCPOptions(ctx, t.Cloud, e.DHCPOptions, aws.ToString(a.DHCPOptions.ID)); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil // no tags
 }
 
+// deleteReplacedDHCPOptions deletes the DHCPOptions that were associated with the VPC before being replaced by e.
+// Only DHCPOptions that were created by kOps for the cluster are deleted.
+func deleteReplacedDHCPOptions(ctx context.Context, cloud awsup.AWSCloud, e *DHCPOptions, id string) error {
+	if id == aws.ToString(e.ID) {
+		return nil
+	}
+
+	request := &ec2.DescribeDhcpOptionsInput{
+		Filters: cloud.BuildFilters(e.Name),
+	}
+	response, err := cloud.EC2().DescribeDhcpOptions(ctx, request)
+	if err != nil {
+		return fmt.Errorf("error listing DHCPOptions: %v", err)
+	}
+	for _, o := range response.DhcpOptions {
+		if aws.ToString(o.DhcpOptionsId) != id {
+			continue
+		}
+		klog.V(2).Infof("Deleting replaced DHCPOptions %q", id)
+		if _, err := cloud.EC2().DeleteDhcpOptions(ctx, &ec2.DeleteDhcpOptionsInput{DhcpOptionsId: aws.String(id)}); err != nil {
+			// The options may still be associated with another VPC, which is not fatal
+			klog.Warningf("error deleting replaced DHCPOptions %q: %v", id, err)
+		}
+	}
+	return nil
+}
+
 type terraformVPCDHCPOptionsAssociation struct {
 	VPCID         *terraformWriter.Literal `cty:"vpc_id"`
 	DHCPOptionsID *terraformWriter.Literal `cty:"dhcp_options_id"`
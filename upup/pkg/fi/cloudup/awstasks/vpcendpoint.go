@@ -0,0 +1,351 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// VPCEndpoint is a gateway or interface endpoint through which the VPC reaches an AWS service.
+// +kops:fitask
+type VPCEndpoint struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID  *string
+	VPC *VPC
+	// ServiceName is the full name of the service, for example com.amazonaws.us-east-1.s3.
+	ServiceName *string
+	// Type is the type of the endpoint, Gateway or Interface.
+	Type *string
+
+	// RouteTables are the route tables that route to a Gateway endpoint.
+	RouteTables []*RouteTable
+	// Subnets are the subnets of the network interfaces of an Interface endpoint, at most one per zone.
+	Subnets []*Subnet
+	// SecurityGroups are the security groups of the network interfaces of an Interface endpoint.
+	SecurityGroups []*SecurityGroup
+	// PrivateDNSEnabled makes the default DNS name of the service resolve to an Interface endpoint.
+	PrivateDNSEnabled *bool
+
+	// Shared is set if the endpoint was found in the VPC without being created by kOps.
+	// Shared endpoints are used as they are.
+	Shared *bool
+
+	// Tags is a map of aws tags that are added to the VPCEndpoint
+	Tags map[string]string
+}
+
+var _ fi.CompareWithID = &VPCEndpoint{}
+
+func (e *VPCEndpoint) CompareWithID() *string {
+	return e.ID
+}
+
+func (e *VPCEndpoint) Find(c *fi.CloudupContext) (*VPCEndpoint, error) {
+	ctx := c.Context()
+	cloud := c.T.Cloud.(awsup.AWSCloud)
+
+	request := &ec2.DescribeVpcEndpointsInput{}
+	if e.ID != nil {
+		request.VpcEndpointIds = []string{fi.ValueOf(e.ID)}
+	} else {
+		if e.VPC == nil || e.VPC.ID == nil {
+			return nil, nil
+		}
+		request.Filters = []ec2types.Filter{
+			awsup.NewEC2Filter("vpc-id", fi.ValueOf(e.VPC.ID)),
+			awsup.NewEC2Filter("service-name", fi.ValueOf(e.ServiceName)),
+		}
+	}
+
+	response, err := cloud.EC2().DescribeVpcEndpoints(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error listing VPCEndpoints: %v", err)
+	}
+
+	var endpoints []ec2types.VpcEndpoint
+	for _, endpoint := range response.VpcEndpoints {
+		switch endpoint.State {
+		case ec2types.StateDeleting, ec2types.StateDeleted, ec2types.StateFailed, ec2types.StateRejected, ec2types.StateExpired:
+			// Ignore the endpoints that are going away
+			continue
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+	if len(endpoints) != 1 {
+		return nil, fmt.Errorf("found multiple VPCEndpoints for service %q", fi.ValueOf(e.ServiceName))
+	}
+	endpoint := endpoints[0]
+
+	actual := &VPCEndpoint{
+		ID:          endpoint.VpcEndpointId,
+		Name:        findNameTag(endpoint.Tags),
+		VPC:         &VPC{ID: endpoint.VpcId},
+		ServiceName: endpoint.ServiceName,
+		Type:        aws.String(string(endpoint.VpcEndpointType)),
+		Tags:        intersectTags(endpoint.Tags, e.Tags),
+	}
+	if endpoint.VpcEndpointType == ec2types.VpcEndpointTypeInterface {
+		actual.PrivateDNSEnabled = endpoint.PrivateDnsEnabled
+	}
+	for _, id := range endpoint.RouteTableIds {
+		actual.RouteTables = append(actual.RouteTables, &RouteTable{ID: aws.String(id)})
+	}
+	for _, id := range endpoint.SubnetIds {
+		actual.Subnets = append(actual.Subnets, &Subnet{ID: aws.String(id)})
+	}
+	for _, group := range endpoint.Groups {
+		actual.SecurityGroups = append(actual.SecurityGroups, &SecurityGroup{ID: group.GroupId})
+	}
+	sort.Sort(OrderRouteTablesById(actual.RouteTables))
+	sort.Sort(OrderSubnetsById(actual.Subnets))
+	sort.Sort(OrderSecurityGroupsById(actual.SecurityGroups))
+	sort.Stable(OrderRouteTablesById(e.RouteTables))
+	sort.Stable(OrderSubnetsById(e.Subnets))
+	sort.Stable(OrderSecurityGroupsById(e.SecurityGroups))
+
+	// An endpoint of a BYO VPC that is not tagged for the cluster belongs to someone else;
+	// only one endpoint per service can resolve the service name, so we use it as is.
+	clusterName := e.Tags[awsup.TagClusterName]
+	if !fi.ValueOf(e.Shared) && clusterName != "" && mapEC2TagsToMap(endpoint.Tags)[awsup.TagClusterName] != clusterName {
+		klog.Infof("using existing VPCEndpoint %q for service %q", aws.ToString(endpoint.VpcEndpointId), fi.ValueOf(e.ServiceName))
+		e.Shared = fi.PtrTo(true)
+	}
+
+	klog.V(2).Infof("found matching VPCEndpoint %q", aws.ToString(actual.ID))
+
+	// Prevent spurious comparison failures
+	actual.Shared = e.Shared
+	actual.Lifecycle = e.Lifecycle
+	if e.ID == nil {
+		e.ID = actual.ID
+	}
+	if fi.ValueOf(e.Shared) {
+		actual.Name = e.Name
+		actual.RouteTables = e.RouteTables
+		actual.Subnets = e.Subnets
+		actual.SecurityGroups = e.SecurityGroups
+		actual.PrivateDNSEnabled = e.PrivateDNSEnabled
+		actual.Tags = e.Tags
+	}
+
+	return actual, nil
+}
+
+func (e *VPCEndpoint) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (s *VPCEndpoint) CheckChanges(a, e, changes *VPCEndpoint) error {
+	if a == nil {
+		if e.VPC == nil {
+			return fi.RequiredField("VPC")
+		}
+		if e.ServiceName == nil {
+			return fi.RequiredField("ServiceName")
+		}
+		if e.Type == nil {
+			return fi.RequiredField("Type")
+		}
+	}
+
+	switch ec2types.VpcEndpointType(fi.ValueOf(e.Type)) {
+	case ec2types.VpcEndpointTypeGateway:
+		if len(e.Subnets) != 0 || len(e.SecurityGroups) != 0 || e.PrivateDNSEnabled != nil {
+			return fmt.Errorf("subnets, security groups and private DNS can only be set for Interface endpoints")
+		}
+	case ec2types.VpcEndpointTypeInterface:
+		if len(e.RouteTables) != 0 {
+			return fmt.Errorf("route tables can only be set for Gateway endpoints")
+		}
+	default:
+		return fmt.Errorf("unknown VPCEndpoint type %q", fi.ValueOf(e.Type))
+	}
+
+	if a != nil {
+		if changes.VPC != nil {
+			return fi.CannotChangeField("VPC")
+		}
+		if changes.ServiceName != nil {
+			return fi.CannotChangeField("ServiceName")
+		}
+		if changes.Type != nil {
+			return fi.CannotChangeField("Type")
+		}
+	}
+
+	return nil
+}
+
+func (_ *VPCEndpoint) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *VPCEndpoint) error {
+	ctx := context.TODO()
+
+	if fi.ValueOf(e.Shared) {
+		// We do not change endpoints that we do not own
+		return nil
+	}
+
+	if a == nil {
+		klog.V(2).Infof("Creating VPCEndpoint for %q", fi.ValueOf(e.ServiceName))
+
+		request := &ec2.CreateVpcEndpointInput{
+			VpcId:             e.VPC.ID,
+			ServiceName:       e.ServiceName,
+			VpcEndpointType:   ec2types.VpcEndpointType(fi.ValueOf(e.Type)),
+			RouteTableIds:     routeTableIDs(e.RouteTables),
+			SubnetIds:         subnetIDs(e.Subnets),
+			SecurityGroupIds:  securityGroupIDs(e.SecurityGroups),
+			PrivateDnsEnabled: e.PrivateDNSEnabled,
+			TagSpecifications: awsup.EC2TagSpecification(ec2types.ResourceTypeVpcEndpoint, e.Tags),
+		}
+
+		response, err := t.Cloud.EC2().CreateVpcEndpoint(ctx, request)
+		if err != nil {
+			return fmt.Errorf("error creating VPCEndpoint: %v", err)
+		}
+
+		e.ID = response.VpcEndpoint.VpcEndpointId
+		return nil
+	}
+
+	if changes.RouteTables != nil || changes.Subnets != nil || changes.SecurityGroups != nil || changes.PrivateDNSEnabled != nil {
+		request := &ec2.ModifyVpcEndpointInput{
+			VpcEndpointId:     a.ID,
+			PrivateDnsEnabled: changes.PrivateDNSEnabled,
+		}
+		request.AddRouteTableIds, request.RemoveRouteTableIds = diffIDs(routeTableIDs(a.RouteTables), routeTableIDs(e.RouteTables))
+		request.AddSubnetIds, request.RemoveSubnetIds = diffIDs(subnetIDs(a.Subnets), subnetIDs(e.Subnets))
+		request.AddSecurityGroupIds, request.RemoveSecurityGroupIds = diffIDs(securityGroupIDs(a.SecurityGroups), securityGroupIDs(e.SecurityGroups))
+
+		klog.V(2).Infof("Modifying VPCEndpoint %q", fi.ValueOf(a.ID))
+		if _, err := t.Cloud.EC2().ModifyVpcEndpoint(ctx, request); err != nil {
+			return fmt.Errorf("error modifying VPCEndpoint %q: %v", fi.ValueOf(a.ID), err)
+		}
+	}
+
+	return t.UpdateTags(fi.ValueOf(a.ID), e.Tags)
+}
+
+func routeTableIDs(routeTables []*RouteTable) []string {
+	var ids []string
+	for _, rt := range routeTables {
+		ids = append(ids, fi.ValueOf(rt.ID))
+	}
+	return ids
+}
+
+func subnetIDs(subnets []*Subnet) []string {
+	var ids []string
+	for _, subnet := range subnets {
+		ids = append(ids, fi.ValueOf(subnet.ID))
+	}
+	return ids
+}
+
+func securityGroupIDs(securityGroups []*SecurityGroup) []string {
+	var ids []string
+	for _, sg := range securityGroups {
+		ids = append(ids, fi.ValueOf(sg.ID))
+	}
+	return ids
+}
+
+// diffIDs returns the IDs that must be added to and removed from actual to get expected.
+func diffIDs(actual, expected []string) (add, remove []string) {
+	actualIDs := make(map[string]bool)
+	for _, id := range actual {
+		actualIDs[id] = true
+	}
+	expectedIDs := make(map[string]bool)
+	for _, id := range expected {
+		expectedIDs[id] = true
+		if !actualIDs[id] {
+			add = append(add, id)
+		}
+	}
+	for _, id := range actual {
+		if !expectedIDs[id] {
+			remove = append(remove, id)
+		}
+	}
+	return add, remove
+}
+
+type terraformVPCEndpoint struct {
+	VPCID             *terraformWriter.Literal   `cty:"vpc_id"`
+	ServiceName       *string                    `cty:"service_name"`
+	Type              *string                    `cty:"vpc_endpoint_type"`
+	RouteTableIDs     []*terraformWriter.Literal `cty:"route_table_ids"`
+	SubnetIDs         []*terraformWriter.Literal `cty:"subnet_ids"`
+	SecurityGroupIDs  []*terraformWriter.Literal `cty:"security_group_ids"`
+	PrivateDNSEnabled *bool                      `cty:"private_dns_enabled"`
+	Tags              map[string]string          `cty:"tags"`
+}
+
+func (_ *VPCEndpoint) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *VPCEndpoint) error {
+	if fi.ValueOf(e.Shared) {
+		// We do not manage endpoints that we do not own
+		return nil
+	}
+
+	tf := &terraformVPCEndpoint{
+		VPCID:             e.VPC.TerraformLink(),
+		ServiceName:       e.ServiceName,
+		Type:              e.Type,
+		PrivateDNSEnabled: e.PrivateDNSEnabled,
+		Tags:              e.Tags,
+	}
+	for _, rt := range e.RouteTables {
+		tf.RouteTableIDs = append(tf.RouteTableIDs, rt.TerraformLink())
+	}
+	for _, subnet := range e.Subnets {
+		tf.SubnetIDs = append(tf.SubnetIDs, subnet.TerraformLink())
+	}
+	for _, sg := range e.SecurityGroups {
+		tf.SecurityGroupIDs = append(tf.SecurityGroupIDs, sg.TerraformLink())
+	}
+	terraformWriter.SortLiterals(tf.RouteTableIDs)
+	terraformWriter.SortLiterals(tf.SubnetIDs)
+	terraformWriter.SortLiterals(tf.SecurityGroupIDs)
+
+	return t.RenderResource("aws_vpc_endpoint", *e.Name, tf)
+}
+
+func (e *VPCEndpoint) TerraformLink() *terraformWriter.Literal {
+	if fi.ValueOf(e.Shared) {
+		if e.ID == nil {
+			klog.Fatalf("ID must be set, if VPCEndpoint is shared: %s", e)
+		}
+		return terraformWriter.LiteralFromStringValue(*e.ID)
+	}
+	return terraformWriter.LiteralProperty("aws_vpc_endpoint", *e.Name, "id")
+}
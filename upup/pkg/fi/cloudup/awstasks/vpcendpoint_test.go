@@ -0,0 +1,291 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestVPCEndpointCreate(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func(routeTableNames ...string) map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			CIDR:      s("172.20.0.0/16"),
+			Tags:      map[string]string{"Name": "vpc1"},
+		}
+		rt1 := &RouteTable{
+			Name:      s("rt1"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			Tags:      map[string]string{"Name": "rt1"},
+		}
+		rt2 := &RouteTable{
+			Name:      s("rt2"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			Tags:      map[string]string{"Name": "rt2"},
+		}
+		routeTables := map[string]*RouteTable{"rt1": rt1, "rt2": rt2}
+		vpce1 := &VPCEndpoint{
+			Name:        s("vpce1"),
+			Lifecycle:   fi.LifecycleSync,
+			VPC:         vpc1,
+			ServiceName: s("com.amazonaws.us-east-1.s3"),
+			Type:        s("Gateway"),
+			Tags:        map[string]string{"Name": "vpce1", awsup.TagClusterName: "cluster.example.com"},
+		}
+		for _, name := range routeTableNames {
+			vpce1.RouteTables = append(vpce1.RouteTables, routeTables[name])
+		}
+
+		return map[string]fi.CloudupTask{
+			"vpce1": vpce1,
+			"rt1":   rt1,
+			"rt2":   rt2,
+			"vpc1":  vpc1,
+		}
+	}
+
+	{
+		allTasks := buildTasks("rt1")
+		vpce1 := allTasks["vpce1"].(*VPCEndpoint)
+		rt1 := allTasks["rt1"].(*RouteTable)
+
+		runTasks(t, cloud, allTasks)
+
+		if fi.ValueOf(vpce1.ID) == "" {
+			t.Fatalf("ID not set after create")
+		}
+
+		if len(c.VpcEndpoints) != 1 {
+			t.Fatalf("Expected exactly one VpcEndpoint; found %v", c.VpcEndpoints)
+		}
+
+		actual := c.VpcEndpoints[*vpce1.ID]
+		if fi.ValueOf(actual.ServiceName) != "com.amazonaws.us-east-1.s3" {
+			t.Fatalf("Unexpected ServiceName: %v", fi.ValueOf(actual.ServiceName))
+		}
+		if !reflect.DeepEqual(actual.RouteTableIds, []string{*rt1.ID}) {
+			t.Fatalf("Unexpected RouteTableIds: %v", actual.RouteTableIds)
+		}
+	}
+
+	{
+		allTasks := buildTasks("rt1")
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks("rt2")
+		vpce1 := allTasks["vpce1"].(*VPCEndpoint)
+		rt2 := allTasks["rt2"].(*RouteTable)
+
+		runTasks(t, cloud, allTasks)
+
+		if len(c.VpcEndpoints) != 1 {
+			t.Fatalf("Expected exactly one VpcEndpoint; found %v", c.VpcEndpoints)
+		}
+
+		actual := c.VpcEndpoints[*vpce1.ID]
+		if !reflect.DeepEqual(actual.RouteTableIds, []string{*rt2.ID}) {
+			t.Fatalf("Unexpected RouteTableIds after modify: %v", actual.RouteTableIds)
+		}
+	}
+
+	{
+		allTasks := buildTasks("rt2")
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestVPCEndpointShared(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	vpc, err := c.CreateVpc(ctx, &ec2.CreateVpcInput{CidrBlock: s("172.20.0.0/16")})
+	if err != nil {
+		t.Fatalf("error creating VPC: %v", err)
+	}
+	existing, err := c.CreateVpcEndpoint(ctx, &ec2.CreateVpcEndpointInput{
+		VpcId:           vpc.Vpc.VpcId,
+		ServiceName:     s("com.amazonaws.us-east-1.s3"),
+		VpcEndpointType: ec2types.VpcEndpointTypeGateway,
+		RouteTableIds:   []string{"rtb-existing"},
+	})
+	if err != nil {
+		t.Fatalf("error creating VpcEndpoint: %v", err)
+	}
+
+	buildTasks := func() map[string]fi.CloudupTask {
+		vpc1 := &VPC{
+			Name:      s("vpc1"),
+			Lifecycle: fi.LifecycleSync,
+			ID:        vpc.Vpc.VpcId,
+			Shared:    fi.PtrTo(true),
+		}
+		rt1 := &RouteTable{
+			Name:      s("rt1"),
+			Lifecycle: fi.LifecycleSync,
+			VPC:       vpc1,
+			Tags:      map[string]string{"Name": "rt1"},
+		}
+		vpce1 := &VPCEndpoint{
+			Name:        s("vpce1"),
+			Lifecycle:   fi.LifecycleSync,
+			VPC:         vpc1,
+			ServiceName: s("com.amazonaws.us-east-1.s3"),
+			Type:        s("Gateway"),
+			RouteTables: []*RouteTable{rt1},
+			Tags:        map[string]string{"Name": "vpce1", awsup.TagClusterName: "cluster.example.com"},
+		}
+
+		return map[string]fi.CloudupTask{
+			"vpce1": vpce1,
+			"rt1":   rt1,
+			"vpc1":  vpc1,
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		vpce1 := allTasks["vpce1"].(*VPCEndpoint)
+
+		runTasks(t, cloud, allTasks)
+
+		if fi.ValueOf(vpce1.ID) != fi.ValueOf(existing.VpcEndpoint.VpcEndpointId) {
+			t.Fatalf("Expected the existing VpcEndpoint to be used; got %q", fi.ValueOf(vpce1.ID))
+		}
+		if !fi.ValueOf(vpce1.Shared) {
+			t.Fatalf("Expected the existing VpcEndpoint to be shared")
+		}
+		if len(c.VpcEndpoints) != 1 {
+			t.Fatalf("Expected exactly one VpcEndpoint; found %v", c.VpcEndpoints)
+		}
+		actual := c.VpcEndpoints[*vpce1.ID]
+		if !reflect.DeepEqual(actual.RouteTableIds, []string{"rtb-existing"}) {
+			t.Fatalf("Expected the shared VpcEndpoint to be unchanged; got RouteTableIds %v", actual.RouteTableIds)
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+}
+
+func TestVPCEndpointTerraformRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &VPCEndpoint{
+				Name:        fi.PtrTo("s3.example.com"),
+				VPC:         &VPC{Name: fi.PtrTo("example.com")},
+				ServiceName: fi.PtrTo("com.amazonaws.us-test-1.s3"),
+				Type:        fi.PtrTo("Gateway"),
+				RouteTables: []*RouteTable{
+					{Name: fi.PtrTo("private-us-test-1a.example.com")},
+					{Name: fi.PtrTo("example.com")},
+				},
+				Tags: map[string]string{
+					"Name": "s3.example.com",
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_vpc_endpoint" "s3-example-com" {
+  route_table_ids = [aws_route_table.example-com.id, aws_route_table.private-us-test-1a-example-com.id]
+  service_name    = "com.amazonaws.us-test-1.s3"
+  tags = {
+    "Name" = "s3.example.com"
+  }
+  vpc_endpoint_type = "Gateway"
+  vpc_id            = aws_vpc.example-com.id
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &VPCEndpoint{
+				Name:              fi.PtrTo("ec2.example.com"),
+				VPC:               &VPC{Name: fi.PtrTo("example.com")},
+				ServiceName:       fi.PtrTo("com.amazonaws.us-test-1.ec2"),
+				Type:              fi.PtrTo("Interface"),
+				Subnets:           []*Subnet{{Name: fi.PtrTo("us-test-1a.example.com")}},
+				SecurityGroups:    []*SecurityGroup{{Name: fi.PtrTo("vpc-endpoints.example.com")}},
+				PrivateDNSEnabled: fi.PtrTo(true),
+				Tags: map[string]string{
+					"Name": "ec2.example.com",
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_vpc_endpoint" "ec2-example-com" {
+  private_dns_enabled = true
+  security_group_ids  = [aws_security_group.vpc-endpoints-example-com.id]
+  service_name        = "com.amazonaws.us-test-1.ec2"
+  subnet_ids          = [aws_subnet.us-test-1a-example-com.id]
+  tags = {
+    "Name" = "ec2.example.com"
+  }
+  vpc_endpoint_type = "Interface"
+  vpc_id            = aws_vpc.example-com.id
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "source"  = "hashicorp/aws"
+      "version" = ">= 5.0.0"
+    }
+  }
+}
+`,
+		},
+	}
+	doRenderTests(t, "RenderTerraform", cases)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package awsup
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -27,14 +28,37 @@ import (
 
 type AWSAPITarget struct {
 	Cloud AWSCloud
+
+	// TagReconciler batches the tags added with AddAWSTags, which are then applied by Finish.
+	// When nil, the tags are added immediately.
+	TagReconciler *TagReconciler
+
+	// MutationRetrier retries the mutations of the tags during the apply, and aborts it after too many consecutive failures.
+	// When nil, the mutations are retried without a breaker.
+	MutationRetrier *fi.MutationRetrier
+
+	// ResourceWaits are the timeouts of the waits for the resources created during the apply.
+	ResourceWaits ResourceWaitTimeouts
 }
 
 var _ fi.CloudupTarget = &AWSAPITarget{}
 
-func NewAWSAPITarget(cloud AWSCloud) *AWSAPITarget {
+// NewAWSAPITarget builds the target of an apply, whose mutations of the tags are retried by the retrier.
+func NewAWSAPITarget(cloud AWSCloud, retrier *fi.MutationRetrier) *AWSAPITarget {
 	return &AWSAPITarget{
-		Cloud: cloud,
+		Cloud:           cloud,
+		TagReconciler:   NewTagReconciler(cloud, retrier),
+		MutationRetrier: retrier,
+		ResourceWaits:   DefaultResourceWaitTimeouts(),
+	}
+}
+
+// mutationRetrier returns the retrier of the mutations of the tags.
+func (t *AWSAPITarget) mutationRetrier() *fi.MutationRetrier {
+	if t.MutationRetrier != nil {
+		return t.MutationRetrier
 	}
+	return tagsRetrier
 }
 
 func (t *AWSAPITarget) DefaultCheckExisting() bool {
@@ -42,11 +66,30 @@ func (t *AWSAPITarget) DefaultCheckExisting() bool {
 }
 
 func (t *AWSAPITarget) Finish(taskMap map[string]fi.CloudupTask) error {
+	return t.ReconcileTags(context.TODO())
+}
+
+// ReconcileTags adds the tags batched by AddAWSTags. It must also be called when the tasks fail,
+// as the resources created by the tasks that completed are found again by their tags.
+func (t *AWSAPITarget) ReconcileTags(ctx context.Context) error {
+	if t.TagReconciler == nil {
+		return nil
+	}
+	if pending := t.TagReconciler.Pending(); pending != 0 {
+		klog.V(2).Infof("reconciling tags of %d resources", pending)
+	}
+	if err := t.TagReconciler.Reconcile(ctx); err != nil {
+		return fmt.Errorf("error reconciling tags: %w", err)
+	}
 	return nil
 }
 
 func (t *AWSAPITarget) AddAWSTags(id string, expected map[string]string) error {
-	return t.Cloud.AddAWSTags(id, expected)
+	if t.TagReconciler != nil {
+		t.TagReconciler.AddTags(id, expected)
+		return nil
+	}
+	return addAWSTags(t.Cloud, t.mutationRetrier(), id, expected)
 }
 
 func (t *AWSAPITarget) GetTags(id string) (map[string]string, error) {
@@ -54,15 +97,15 @@ func (t *AWSAPITarget) GetTags(id string) (map[string]string, error) {
 }
 
 func (t *AWSAPITarget) CreateTags(id string, tags map[string]string) error {
-	return t.Cloud.CreateTags(id, tags)
+	return createTags(t.Cloud, t.mutationRetrier(), id, tags)
 }
 
 func (t *AWSAPITarget) DeleteTags(id string, tags map[string]string) error {
-	return t.Cloud.DeleteTags(id, tags)
+	return deleteTags(t.Cloud, t.mutationRetrier(), id, tags)
 }
 
 func (t *AWSAPITarget) UpdateTags(id string, tags map[string]string) error {
-	return t.Cloud.UpdateTags(id, tags)
+	return updateTags(t.Cloud, t.mutationRetrier(), id, tags)
 }
 
 func (t *AWSAPITarget) AddELBV2Tags(ResourceArn string, expected map[string]string) error {
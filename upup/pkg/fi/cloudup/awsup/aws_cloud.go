@@ -20,12 +20,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/acm"
 	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
@@ -44,6 +46,7 @@ import (
 	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"k8s.io/klog/v2"
@@ -69,24 +72,6 @@ import (
 // backoff along the way.
 const ClientMaxRetries = 13
 
-const (
-	DescribeTagsMaxAttempts   = 120
-	DescribeTagsRetryInterval = 2 * time.Second
-	DescribeTagsLogInterval   = 10 // this is in "retry intervals"
-)
-
-const (
-	CreateTagsMaxAttempts   = 120
-	CreateTagsRetryInterval = 2 * time.Second
-	CreateTagsLogInterval   = 10 // this is in "retry intervals"
-)
-
-const (
-	DeleteTagsMaxAttempts   = 120
-	DeleteTagsRetryInterval = 2 * time.Second
-	DeleteTagsLogInterval   = 10 // this is in "retry intervals"
-)
-
 const (
 	TagClusterName           = "KubernetesCluster"
 	TagNameRolePrefix        = "k8s.io/role/"
@@ -104,6 +89,17 @@ const TagNameClusterOwnershipPrefix = "kubernetes.io/cluster/"
 
 const tagNameDetachedInstance = "kops.k8s.io/detached-from-asg"
 
+// TagNamePodSecurityGroup is the AWS tag on the security group for the pods of an instance group, set to the name of the instance group
+const TagNamePodSecurityGroup = "kops.k8s.io/pod-security-group"
+
+// TagNameStaticNetworkInterface is the AWS tag on the network interface that is attached to the instance
+// of a control-plane group, set to the name of the instance group
+const TagNameStaticNetworkInterface = "kops.k8s.io/static-network-interface"
+
+// LaunchTemplateVersionDescriptionInPlace is the description of a launch template version whose changes
+// from the previous version, such as tags, do not require existing instances to be replaced.
+const LaunchTemplateVersionDescriptionInPlace = "kops.k8s.io/in-place"
+
 const (
 	WellKnownAccountAmazonLinux2 = "137112412989"
 	WellKnownAccountDebian       = "136693071363"
@@ -130,6 +126,8 @@ type AWSCloud interface {
 	SQS() awsinterfaces.SQSAPI
 	EventBridge() awsinterfaces.EventBridgeAPI
 	SSM() awsinterfaces.SSMAPI
+	ACM() awsinterfaces.ACMAPI
+	KMS() awsinterfaces.KMSAPI
 
 	// TODO: Document and rationalize these tags/filters methods
 	AddTags(name *string, tags map[string]string)
@@ -137,11 +135,11 @@ type AWSCloud interface {
 	BuildTags(name *string) map[string]string
 	Tags() map[string]string
 
-	// GetTags will fetch the tags for the specified resource, retrying (up to MaxDescribeTagsAttempts) if it hits an eventual-consistency type error
+	// GetTags will fetch the tags for the specified resource, retrying with MutationRetryPolicies if it hits a throttling or eventual-consistency type error
 	GetTags(resourceId string) (map[string]string, error)
-	// CreateTags will add/modify tags to the specified resource, retrying up to MaxCreateTagsAttempts times if it hits an eventual-consistency type error
+	// CreateTags will add/modify tags to the specified resource, retrying with MutationRetryPolicies if it hits a throttling or eventual-consistency type error
 	CreateTags(resourceId string, tags map[string]string) error
-	// DeleteTags will remove tags from the specified resource, retrying up to MaxCreateTagsAttempts times if it hits an eventual-consistency type error
+	// DeleteTags will remove tags from the specified resource, retrying with MutationRetryPolicies if it hits a throttling or eventual-consistency type error
 	DeleteTags(resourceId string, tags map[string]string) error
 	// UpdateTags will update tags of the specified resource to match tags, using getTags(), createTags() and deleteTags()
 	UpdateTags(resourceId string, tags map[string]string) error
@@ -189,17 +187,21 @@ type AWSCloud interface {
 }
 
 type awsCloudImplementation struct {
-	ec2         *ec2.Client
-	iam         *iam.Client
-	elb         *elb.Client
-	elbv2       *elbv2.Client
-	autoscaling *autoscaling.Client
-	route53     *route53.Client
+	// The clients of the AWS services are built on first use. They are held by their interfaces,
+	// so that the clients are only linked into the binaries that build them.
+	ec2         *lazyClient[awsinterfaces.EC2API]
+	iam         *lazyClient[awsinterfaces.IAMAPI]
+	elb         *lazyClient[awsinterfaces.ELBAPI]
+	elbv2       *lazyClient[awsinterfaces.ELBV2API]
+	autoscaling *lazyClient[awsinterfaces.AutoScalingAPI]
+	route53     *lazyClient[awsinterfaces.Route53API]
 	spotinst    spotinst.Cloud
-	sts         *sts.Client
-	sqs         *sqs.Client
-	eventbridge *eventbridge.Client
-	ssm         *ssm.Client
+	sts         *lazyClient[*sts.Client]
+	sqs         *lazyClient[awsinterfaces.SQSAPI]
+	eventbridge *lazyClient[awsinterfaces.EventBridgeAPI]
+	ssm         *lazyClient[awsinterfaces.SSMAPI]
+	acm         *lazyClient[awsinterfaces.ACMAPI]
+	kms         *lazyClient[awsinterfaces.KMSAPI]
 
 	region string
 
@@ -207,6 +209,13 @@ type awsCloudImplementation struct {
 
 	instanceTypes *instanceTypes
 
+	// describeCache caches the results of some calls of the EC2 API, while enabled by EnableDescribeCache.
+	describeCache *describeCache
+	// ssmParameters caches the values of the SSM parameters that were looked up.
+	ssmParameters *ssmParameters
+	// rateLimiter limits the requests of all the clients, while enabled by EnableRateLimit.
+	rateLimiter *rateLimiter
+
 	config aws.Config
 }
 
@@ -288,32 +297,29 @@ func loadAWSConfig(ctx context.Context, region string) (aws.Config, error) {
 	return awsconfig.LoadDefaultConfig(ctx, loadOptions...)
 }
 
+// NewAWSCloud returns the cloud of the region, with the clients of all the AWS services used by kOps.
 func NewAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
-	ctx := context.TODO()
 	raw := getCloudInstancesFromRegion(region)
 
 	if raw == nil {
-		c := &awsCloudImplementation{
-			region: region,
-			instanceTypes: &instanceTypes{
-				typeMap: make(map[string]*ec2types.InstanceTypeInfo),
-			},
-		}
-
-		cfg, err := loadAWSConfig(ctx, region)
+		c, err := newAWSCloudImplementation(region)
 		if err != nil {
-			return c, fmt.Errorf("failed to load default aws config: %w", err)
+			return c, err
 		}
+		cfg := c.config
 
-		c.config = cfg
-
-		c.ec2 = ec2.NewFromConfig(cfg)
-		c.iam = iam.NewFromConfig(cfg)
-		c.elb = elb.NewFromConfig(cfg)
-		c.elbv2 = elbv2.NewFromConfig(cfg)
-		c.sts = sts.NewFromConfig(cfg)
-		c.autoscaling = autoscaling.NewFromConfig(cfg)
-		c.route53 = route53.NewFromConfig(cfg)
+		c.iam = newLazyClient(func() awsinterfaces.IAMAPI { return iam.NewFromConfig(cfg) })
+		c.elb = newLazyClient(func() awsinterfaces.ELBAPI {
+			return elb.NewFromConfig(cfg, func(o *elb.Options) {
+				o.APIOptions = append(o.APIOptions, c.describeCache.addInvalidateMiddleware)
+			})
+		})
+		c.elbv2 = newLazyClient(func() awsinterfaces.ELBV2API {
+			return elbv2.NewFromConfig(cfg, func(o *elbv2.Options) {
+				o.APIOptions = append(o.APIOptions, c.describeCache.addInvalidateMiddleware)
+			})
+		})
+		c.route53 = newLazyClient(func() awsinterfaces.Route53API { return route53.NewFromConfig(cfg) })
 
 		if featureflag.Spotinst.Enabled() {
 			c.spotinst, err = spotinst.NewCloud(kops.CloudProviderAWS)
@@ -322,9 +328,11 @@ func NewAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
 			}
 		}
 
-		c.sqs = sqs.NewFromConfig(cfg)
-		c.eventbridge = eventbridge.NewFromConfig(cfg)
-		c.ssm = ssm.NewFromConfig(cfg)
+		c.sqs = newLazyClient(func() awsinterfaces.SQSAPI { return sqs.NewFromConfig(cfg) })
+		c.eventbridge = newLazyClient(func() awsinterfaces.EventBridgeAPI { return eventbridge.NewFromConfig(cfg) })
+		c.ssm = newLazyClient(func() awsinterfaces.SSMAPI { return ssm.NewFromConfig(cfg) })
+		c.acm = newLazyClient(func() awsinterfaces.ACMAPI { return acm.NewFromConfig(cfg) })
+		c.kms = newLazyClient(func() awsinterfaces.KMSAPI { return kms.NewFromConfig(cfg) })
 
 		updateAwsCloudInstances(region, c)
 
@@ -336,6 +344,66 @@ func NewAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
 	return i, nil
 }
 
+// NewNodeAWSCloud returns a cloud of the region with only the clients of the AWS services used by nodeup:
+// EC2, Auto Scaling and STS. The clients of the other services are not linked into the binaries that do not
+// call NewAWSCloud, which keeps nodeup smaller; using them aborts the process.
+// The cloud is not shared with NewAWSCloud.
+func NewNodeAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
+	c, err := newAWSCloudImplementation(region)
+	if err != nil {
+		return c, err
+	}
+
+	c.iam = newUnavailableClient[awsinterfaces.IAMAPI]("IAM")
+	c.elb = newUnavailableClient[awsinterfaces.ELBAPI]("ELB")
+	c.elbv2 = newUnavailableClient[awsinterfaces.ELBV2API]("ELBV2")
+	c.route53 = newUnavailableClient[awsinterfaces.Route53API]("Route53")
+	c.sqs = newUnavailableClient[awsinterfaces.SQSAPI]("SQS")
+	c.eventbridge = newUnavailableClient[awsinterfaces.EventBridgeAPI]("EventBridge")
+	c.ssm = newUnavailableClient[awsinterfaces.SSMAPI]("SSM")
+	c.acm = newUnavailableClient[awsinterfaces.ACMAPI]("ACM")
+	c.kms = newUnavailableClient[awsinterfaces.KMSAPI]("KMS")
+
+	return c.WithTags(tags), nil
+}
+
+// newAWSCloudImplementation builds a cloud of the region with the clients of EC2, Auto Scaling and STS.
+func newAWSCloudImplementation(region string) (*awsCloudImplementation, error) {
+	ctx := context.TODO()
+
+	c := &awsCloudImplementation{
+		region: region,
+		instanceTypes: &instanceTypes{
+			typeMap: make(map[string]*ec2types.InstanceTypeInfo),
+		},
+	}
+
+	cfg, err := loadAWSConfig(ctx, region)
+	if err != nil {
+		return c, fmt.Errorf("failed to load default aws config: %w", err)
+	}
+
+	c.rateLimiter = &rateLimiter{}
+	cfg.APIOptions = append(cfg.APIOptions, c.rateLimiter.addMiddleware)
+	c.config = cfg
+
+	c.describeCache = newDescribeCache()
+	c.ssmParameters = newSSMParameters()
+	c.ec2 = newLazyClient(func() awsinterfaces.EC2API {
+		return ec2.NewFromConfig(cfg, func(o *ec2.Options) {
+			o.APIOptions = append(o.APIOptions, c.describeCache.addMiddleware)
+		})
+	})
+	c.sts = newLazyClient(func() *sts.Client { return sts.NewFromConfig(cfg) })
+	c.autoscaling = newLazyClient(func() awsinterfaces.AutoScalingAPI {
+		return autoscaling.NewFromConfig(cfg, func(o *autoscaling.Options) {
+			o.APIOptions = append(o.APIOptions, c.describeCache.addInvalidateMiddleware)
+		})
+	})
+
+	return c, nil
+}
+
 func (c *awsCloudImplementation) Config() aws.Config {
 	return c.config
 }
@@ -483,7 +551,16 @@ func deleteInstance(ctx context.Context, c AWSCloud, i *cloudinstances.CloudInst
 		InstanceIds: []string{id},
 	}
 
-	if _, err := c.EC2().TerminateInstances(ctx, request); err != nil {
+	_, err := c.EC2().TerminateInstances(ctx, request)
+	if AWSErrorCode(err) == "OperationNotPermitted" {
+		// Control-plane instances have termination protection when the cluster has deletion protection enabled
+		klog.Infof("Disabling termination protection on instance %q", id)
+		if err := DisableTerminationProtection(ctx, c, id); err != nil {
+			return err
+		}
+		_, err = c.EC2().TerminateInstances(ctx, request)
+	}
+	if err != nil {
 		if AWSErrorCode(err) == "InvalidInstanceID.NotFound" {
 			klog.V(2).Infof("Got InvalidInstanceID.NotFound error deleting instance %q; will treat as already-deleted", id)
 		} else {
@@ -496,6 +573,45 @@ func deleteInstance(ctx context.Context, c AWSCloud, i *cloudinstances.CloudInst
 	return nil
 }
 
+// FindTerminationProtectedInstances returns the instances that have termination protection enabled.
+func FindTerminationProtectedInstances(ctx context.Context, c AWSCloud, ids ...string) ([]string, error) {
+	var protected []string
+	for _, id := range ids {
+		request := &ec2.DescribeInstanceAttributeInput{
+			InstanceId: aws.String(id),
+			Attribute:  ec2types.InstanceAttributeNameDisableApiTermination,
+		}
+		response, err := c.EC2().DescribeInstanceAttribute(ctx, request)
+		if err != nil {
+			if AWSErrorCode(err) == "InvalidInstanceID.NotFound" {
+				continue
+			}
+			return nil, fmt.Errorf("error describing termination protection of instance %q: %w", id, err)
+		}
+		if response.DisableApiTermination != nil && aws.ToBool(response.DisableApiTermination.Value) {
+			protected = append(protected, id)
+		}
+	}
+	return protected, nil
+}
+
+// DisableTerminationProtection disables termination protection on the specified instances, so they can be terminated.
+func DisableTerminationProtection(ctx context.Context, c AWSCloud, ids ...string) error {
+	for _, id := range ids {
+		request := &ec2.ModifyInstanceAttributeInput{
+			InstanceId:            aws.String(id),
+			DisableApiTermination: &ec2types.AttributeBooleanValue{Value: aws.Bool(false)},
+		}
+		if _, err := c.EC2().ModifyInstanceAttribute(ctx, request); err != nil {
+			if AWSErrorCode(err) == "InvalidInstanceID.NotFound" {
+				continue
+			}
+			return fmt.Errorf("error disabling termination protection on instance %q: %w", id, err)
+		}
+	}
+	return nil
+}
+
 // deregisterInstance ensures that the instance is fully drained/removed from all associated loadBalancers and targetGroups before termination.
 func deregisterInstance(ctx context.Context, c AWSCloud, i *cloudinstances.CloudInstance) error {
 	asg := i.CloudInstanceGroup.Raw.(*autoscalingtypes.AutoScalingGroup)
@@ -1015,6 +1131,45 @@ func findAutoscalingGroupLaunchConfiguration(ctx context.Context, c AWSCloud, g
 	return fmt.Sprintf("%s:%s", id, version), nil
 }
 
+// findInPlaceLaunchConfigurations returns the launch configuration of an autoscaling group, along with any earlier
+// launch template versions it only differs from by changes that do not require instances to be replaced.
+func findInPlaceLaunchConfigurations(ctx context.Context, c AWSCloud, configName string) (map[string]bool, error) {
+	configNames := map[string]bool{configName: true}
+
+	id, v, found := strings.Cut(configName, ":")
+	if !found {
+		return configNames, nil
+	}
+	version, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return configNames, nil
+	}
+
+	// The versions are listed in one (paginated) request, rather than one request per version
+	descriptions := make(map[int64]string)
+	paginator := ec2.NewDescribeLaunchTemplateVersionsPaginator(c.EC2(), &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: aws.String(id),
+		MaxVersion:       aws.String(strconv.FormatInt(version, 10)),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error describing launch template versions: %w", err)
+		}
+		for _, ltVersion := range page.LaunchTemplateVersions {
+			descriptions[aws.ToInt64(ltVersion.VersionNumber)] = aws.ToString(ltVersion.VersionDescription)
+		}
+	}
+
+	for version > 1 && descriptions[version] == LaunchTemplateVersionDescriptionInPlace {
+		version--
+		configNames[id+":"+strconv.FormatInt(version, 10)] = true
+	}
+	klog.V(4).Infof("Launch configurations considered up to date: %v", configNames)
+
+	return configNames, nil
+}
+
 // findInstanceLaunchConfiguration is responsible for discoverying the launch configuration for an instance
 func findInstanceLaunchConfiguration(i autoscalingtypes.Instance) string {
 	name := aws.ToString(i.LaunchConfigurationName)
@@ -1040,6 +1195,10 @@ func awsBuildCloudInstanceGroup(ctx context.Context, c AWSCloud, cluster *kops.C
 	if err != nil {
 		return nil, err
 	}
+	upToDateConfigNames, err := findInPlaceLaunchConfigurations(ctx, c, newConfigName)
+	if err != nil {
+		return nil, err
+	}
 
 	instanceSeen := map[string]bool{}
 	instances, err := findInstances(ctx, c, ig)
@@ -1057,7 +1216,7 @@ func awsBuildCloudInstanceGroup(ctx context.Context, c AWSCloud, cluster *kops.C
 	}
 
 	for _, i := range g.Instances {
-		err := buildCloudInstance(i, instances, instanceSeen, nodeMap, cg, newConfigName)
+		err := buildCloudInstance(i, instances, instanceSeen, nodeMap, cg, upToDateConfigNames)
 		if err != nil {
 			return nil, err
 		}
@@ -1070,7 +1229,7 @@ func awsBuildCloudInstanceGroup(ctx context.Context, c AWSCloud, cluster *kops.C
 		return nil, err
 	}
 	for _, i := range result.Instances {
-		err := buildCloudInstance(i, instances, instanceSeen, nodeMap, cg, newConfigName)
+		err := buildCloudInstance(i, instances, instanceSeen, nodeMap, cg, upToDateConfigNames)
 		if err != nil {
 			return nil, err
 		}
@@ -1100,7 +1259,7 @@ func awsBuildCloudInstanceGroup(ctx context.Context, c AWSCloud, cluster *kops.C
 	return cg, nil
 }
 
-func buildCloudInstance(i autoscalingtypes.Instance, instances map[string]*ec2types.Instance, instanceSeen map[string]bool, nodeMap map[string]*v1.Node, cg *cloudinstances.CloudInstanceGroup, newConfigName string) error {
+func buildCloudInstance(i autoscalingtypes.Instance, instances map[string]*ec2types.Instance, instanceSeen map[string]bool, nodeMap map[string]*v1.Node, cg *cloudinstances.CloudInstanceGroup, upToDateConfigNames map[string]bool) error {
 	id := aws.ToString(i.InstanceId)
 	if id == "" {
 		klog.Warningf("ignoring instance with no instance id: %s in autoscaling group: %s", id, cg.HumanName)
@@ -1117,7 +1276,7 @@ func buildCloudInstance(i autoscalingtypes.Instance, instances map[string]*ec2ty
 	}
 	currentConfigName := findInstanceLaunchConfiguration(i)
 	status := cloudinstances.CloudInstanceStatusUpToDate
-	if newConfigName != currentConfigName {
+	if !upToDateConfigNames[currentConfigName] {
 		status = cloudinstances.CloudInstanceStatusNeedsUpdate
 	}
 	cm, err := cg.NewCloudInstance(id, status, nodeMap[id])
@@ -1127,6 +1286,7 @@ func buildCloudInstance(i autoscalingtypes.Instance, instances map[string]*ec2ty
 	if strings.HasPrefix(string(i.LifecycleState), "Warmed") {
 		cm.State = cloudinstances.WarmPool
 	}
+	cm.LaunchConfiguration = currentConfigName
 
 	addCloudInstanceData(cm, instances[id])
 	return nil
@@ -1134,6 +1294,15 @@ func buildCloudInstance(i autoscalingtypes.Instance, instances map[string]*ec2ty
 
 func addCloudInstanceData(cm *cloudinstances.CloudInstance, instance *ec2types.Instance) {
 	cm.MachineType = string(instance.InstanceType)
+	cm.ImageID = aws.ToString(instance.ImageId)
+	cm.LaunchTime = instance.LaunchTime
+	if instance.Placement != nil {
+		cm.Zone = aws.ToString(instance.Placement.AvailabilityZone)
+	}
+	cm.Lifecycle = string(instance.InstanceLifecycle)
+	if cm.Lifecycle == "" {
+		cm.Lifecycle = "on-demand"
+	}
 	for _, tag := range instance.Tags {
 		key := aws.ToString(tag.Key)
 		if !strings.HasPrefix(key, TagNameRolePrefix) {
@@ -1149,6 +1318,141 @@ func addCloudInstanceData(cm *cloudinstances.CloudInstance, instance *ec2types.I
 	}
 }
 
+// DescribeInstanceUpdates adds the details that are too expensive to collect on every listing of the cloud groups:
+// the image each group launches new instances from, the age of the images instances were launched from,
+// and the launch template fields that changed for each instance that needs updating.
+func DescribeInstanceUpdates(ctx context.Context, c AWSCloud, groups map[string]*cloudinstances.CloudInstanceGroup) error {
+	launchTemplateData := make(map[string]*ec2types.ResponseLaunchTemplateData)
+	getLaunchTemplateData := func(configName string) (*ec2types.ResponseLaunchTemplateData, error) {
+		if data, found := launchTemplateData[configName]; found {
+			return data, nil
+		}
+		id, version, found := strings.Cut(configName, ":")
+		if !found {
+			return nil, nil
+		}
+		output, err := c.EC2().DescribeLaunchTemplateVersions(ctx, &ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateId: aws.String(id),
+			Versions:         []string{version},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error describing launch template versions: %w", err)
+		}
+		var data *ec2types.ResponseLaunchTemplateData
+		if len(output.LaunchTemplateVersions) != 0 {
+			data = output.LaunchTemplateVersions[0].LaunchTemplateData
+		}
+		launchTemplateData[configName] = data
+		return data, nil
+	}
+
+	imageIDs := sets.NewString()
+	for _, cg := range groups {
+		for _, cm := range groupInstances(cg) {
+			if cm.ImageID != "" {
+				imageIDs.Insert(cm.ImageID)
+			}
+		}
+
+		asg, ok := cg.Raw.(*autoscalingtypes.AutoScalingGroup)
+		if !ok {
+			continue
+		}
+		newConfigName, err := findAutoscalingGroupLaunchConfiguration(ctx, c, asg)
+		if err != nil {
+			return err
+		}
+		newData, err := getLaunchTemplateData(newConfigName)
+		if err != nil {
+			return err
+		}
+		if newData != nil {
+			cg.DesiredImageID = aws.ToString(newData.ImageId)
+		}
+
+		for _, cm := range cg.NeedUpdate {
+			if cm.Status != cloudinstances.CloudInstanceStatusNeedsUpdate {
+				continue
+			}
+			currentID, _, currentIsTemplate := strings.Cut(cm.LaunchConfiguration, ":")
+			newID, _, newIsTemplate := strings.Cut(newConfigName, ":")
+			if !currentIsTemplate || !newIsTemplate {
+				cm.NeedUpdateReasons = []string{"LaunchConfiguration"}
+				continue
+			}
+			if currentID != newID {
+				cm.NeedUpdateReasons = []string{"LaunchTemplateId"}
+				continue
+			}
+			currentData, err := getLaunchTemplateData(cm.LaunchConfiguration)
+			if err != nil {
+				return err
+			}
+			cm.NeedUpdateReasons = launchTemplateDataChanges(currentData, newData)
+			if len(cm.NeedUpdateReasons) == 0 {
+				cm.NeedUpdateReasons = []string{"LaunchTemplateVersion"}
+			}
+		}
+	}
+
+	if imageIDs.Len() == 0 {
+		return nil
+	}
+	// Filtering rather than listing the IDs means deregistered images are skipped instead of failing the request
+	output, err := c.EC2().DescribeImages(ctx, &ec2.DescribeImagesInput{
+		Filters: []ec2types.Filter{NewEC2Filter("image-id", imageIDs.List()...)},
+	})
+	if err != nil {
+		return fmt.Errorf("error describing images: %w", err)
+	}
+	creationTimes := make(map[string]time.Time)
+	for _, image := range output.Images {
+		t, err := time.Parse(time.RFC3339, aws.ToString(image.CreationDate))
+		if err != nil {
+			klog.Warningf("ignoring invalid creation date %q of image %q", aws.ToString(image.CreationDate), aws.ToString(image.ImageId))
+			continue
+		}
+		creationTimes[aws.ToString(image.ImageId)] = t
+	}
+	for _, cg := range groups {
+		for _, cm := range groupInstances(cg) {
+			if t, found := creationTimes[cm.ImageID]; found {
+				cm.ImageCreationTime = &t
+			}
+		}
+	}
+
+	return nil
+}
+
+// groupInstances returns the ready and outdated instances of the group in a new slice,
+// so that appending does not write into the backing array of the group's own slices.
+func groupInstances(cg *cloudinstances.CloudInstanceGroup) []*cloudinstances.CloudInstance {
+	instances := make([]*cloudinstances.CloudInstance, 0, len(cg.Ready)+len(cg.NeedUpdate))
+	instances = append(instances, cg.Ready...)
+	return append(instances, cg.NeedUpdate...)
+}
+
+// launchTemplateDataChanges returns the names of the launch template fields that differ between two versions.
+func launchTemplateDataChanges(current, desired *ec2types.ResponseLaunchTemplateData) []string {
+	if current == nil || desired == nil {
+		return nil
+	}
+	var changes []string
+	a := reflect.ValueOf(*current)
+	b := reflect.ValueOf(*desired)
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			changes = append(changes, field.Name)
+		}
+	}
+	return changes
+}
+
 func findInstances(ctx context.Context, c AWSCloud, ig *kops.InstanceGroup) (map[string]*ec2types.Instance, error) {
 	clusterName := c.Tags()[TagClusterName]
 	req := &ec2.DescribeInstancesInput{
@@ -1237,7 +1541,7 @@ func isTagsEventualConsistencyError(err error) bool {
 }
 
 // GetTags will fetch the tags for the specified resource,
-// retrying (up to MaxDescribeTagsAttempts) if it hits an eventual-consistency type error
+// retrying with MutationRetryPolicies if it hits a throttling or eventual-consistency type error
 func (c *awsCloudImplementation) GetTags(resourceID string) (map[string]string, error) {
 	return getTags(c, resourceID)
 }
@@ -1256,43 +1560,30 @@ func getTags(c AWSCloud, resourceID string) (map[string]string, error) {
 		},
 	}
 
-	attempt := 0
-	for {
-		attempt++
-
-		response, err := c.EC2().DescribeTags(ctx, request)
-		if err != nil {
-			if isTagsEventualConsistencyError(err) {
-				if attempt > DescribeTagsMaxAttempts {
-					return nil, fmt.Errorf("got retryable error while getting tags on %q, but retried too many times without success: %v", resourceID, err)
-				}
-
-				if (attempt % DescribeTagsLogInterval) == 0 {
-					klog.Infof("waiting for eventual consistency while describing tags on %q", resourceID)
-				}
-
-				klog.V(2).Infof("will retry after encountering error getting tags on %q: %v", resourceID, err)
-				time.Sleep(DescribeTagsRetryInterval)
-				continue
-			}
-
-			return nil, fmt.Errorf("error listing tags on %v: %v", resourceID, err)
-		}
-
-		for _, tag := range response.Tags {
-			tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
-		}
+	var response *ec2.DescribeTagsOutput
+	err := tagsRetrier.Retry(ctx, fmt.Sprintf("getting tags on %q", resourceID), func() error {
+		var err error
+		response, err = c.EC2().DescribeTags(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags on %v: %w", resourceID, err)
+	}
 
-		return tags, nil
+	for _, tag := range response.Tags {
+		tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
 	}
+
+	return tags, nil
 }
 
-// CreateTags will add tags to the specified resource, retrying up to MaxCreateTagsAttempts times if it hits an eventual-consistency type error
+// CreateTags will add tags to the specified resource,
+// retrying with MutationRetryPolicies if it hits a throttling or eventual-consistency type error
 func (c *awsCloudImplementation) CreateTags(resourceID string, tags map[string]string) error {
-	return createTags(c, resourceID, tags)
+	return createTags(c, tagsRetrier, resourceID, tags)
 }
 
-func createTags(c AWSCloud, resourceID string, tags map[string]string) error {
+func createTags(c AWSCloud, retrier *fi.MutationRetrier, resourceID string, tags map[string]string) error {
 	if len(tags) == 0 {
 		return nil
 	}
@@ -1303,45 +1594,28 @@ func createTags(c AWSCloud, resourceID string, tags map[string]string) error {
 		ec2Tags = append(ec2Tags, ec2types.Tag{Key: aws.String(k), Value: aws.String(v)})
 	}
 
-	attempt := 0
-	for {
-		attempt++
-
-		request := &ec2.CreateTagsInput{
-			Tags:      ec2Tags,
-			Resources: []string{resourceID},
-		}
-
+	request := &ec2.CreateTagsInput{
+		Tags:      ec2Tags,
+		Resources: []string{resourceID},
+	}
+	err := retrier.Do(ctx, fmt.Sprintf("creating tags on %q", resourceID), func() error {
 		_, err := c.EC2().CreateTags(ctx, request)
-		if err != nil {
-			if isTagsEventualConsistencyError(err) {
-				if attempt > CreateTagsMaxAttempts {
-					return fmt.Errorf("got retryable error while creating tags on %q, but retried too many times without success: %v", resourceID, err)
-				}
-
-				if (attempt % CreateTagsLogInterval) == 0 {
-					klog.Infof("waiting for eventual consistency while creating tags on %q", resourceID)
-				}
-
-				klog.V(2).Infof("will retry after encountering error creating tags on %q: %v", resourceID, err)
-				time.Sleep(CreateTagsRetryInterval)
-				continue
-			}
-
-			return fmt.Errorf("error creating tags on %v: %v", resourceID, err)
-		}
-
-		return nil
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error creating tags on %v: %w", resourceID, err)
 	}
+
+	return nil
 }
 
 // DeleteTags will remove tags from the specified resource,
-// retrying up to MaxCreateTagsAttempts times if it hits an eventual-consistency type error
+// retrying with MutationRetryPolicies if it hits a throttling or eventual-consistency type error
 func (c *awsCloudImplementation) DeleteTags(resourceID string, tags map[string]string) error {
-	return deleteTags(c, resourceID, tags)
+	return deleteTags(c, tagsRetrier, resourceID, tags)
 }
 
-func deleteTags(c AWSCloud, resourceID string, tags map[string]string) error {
+func deleteTags(c AWSCloud, retrier *fi.MutationRetrier, resourceID string, tags map[string]string) error {
 	if len(tags) == 0 {
 		return nil
 	}
@@ -1352,45 +1626,28 @@ func deleteTags(c AWSCloud, resourceID string, tags map[string]string) error {
 		ec2Tags = append(ec2Tags, ec2types.Tag{Key: aws.String(k), Value: aws.String(v)})
 	}
 
-	attempt := 0
-	for {
-		attempt++
-
-		request := &ec2.DeleteTagsInput{
-			Tags:      ec2Tags,
-			Resources: []string{resourceID},
-		}
-
+	request := &ec2.DeleteTagsInput{
+		Tags:      ec2Tags,
+		Resources: []string{resourceID},
+	}
+	err := retrier.Do(ctx, fmt.Sprintf("deleting tags on %q", resourceID), func() error {
 		_, err := c.EC2().DeleteTags(ctx, request)
-		if err != nil {
-			if isTagsEventualConsistencyError(err) {
-				if attempt > DeleteTagsMaxAttempts {
-					return fmt.Errorf("got retryable error while deleting tags on %q, but retried too many times without success: %v", resourceID, err)
-				}
-
-				if (attempt % DeleteTagsLogInterval) == 0 {
-					klog.Infof("waiting for eventual consistency while deleting tags on %q", resourceID)
-				}
-
-				klog.V(2).Infof("will retry after encountering error deleting tags on %q: %v", resourceID, err)
-				time.Sleep(DeleteTagsRetryInterval)
-				continue
-			}
-
-			return fmt.Errorf("error deleting tags on %v: %v", resourceID, err)
-		}
-
-		return nil
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting tags on %v: %w", resourceID, err)
 	}
+
+	return nil
 }
 
 // UpdateTags will update tags of the specified resource to match tags,
 // using getTags(), createTags() and deleteTags()
 func (c *awsCloudImplementation) UpdateTags(resourceID string, tags map[string]string) error {
-	return updateTags(c, resourceID, tags)
+	return updateTags(c, tagsRetrier, resourceID, tags)
 }
 
-func updateTags(c AWSCloud, resourceID string, expectedTags map[string]string) error {
+func updateTags(c AWSCloud, retrier *fi.MutationRetrier, resourceID string, expectedTags map[string]string) error {
 	actual, err := getTags(c, resourceID)
 	if err != nil {
 		return err
@@ -1404,7 +1661,7 @@ func updateTags(c AWSCloud, resourceID string, expectedTags map[string]string) e
 	}
 	if len(missing) > 0 {
 		klog.V(4).Infof("Adding tags to %q: %v", resourceID, missing)
-		err = createTags(c, resourceID, missing)
+		err = createTags(c, retrier, resourceID, missing)
 		if err != nil {
 			return err
 		}
@@ -1418,7 +1675,7 @@ func updateTags(c AWSCloud, resourceID string, expectedTags map[string]string) e
 	}
 	if len(extra) > 0 {
 		klog.V(4).Infof("Removing tags from %q: %v", resourceID, missing)
-		err := deleteTags(c, resourceID, extra)
+		err := deleteTags(c, retrier, resourceID, extra)
 		if err != nil {
 			return err
 		}
@@ -1428,11 +1685,11 @@ func updateTags(c AWSCloud, resourceID string, expectedTags map[string]string) e
 }
 
 func (c *awsCloudImplementation) AddAWSTags(id string, expected map[string]string) error {
-	return addAWSTags(c, id, expected)
+	return addAWSTags(c, tagsRetrier, id, expected)
 }
 
-func addAWSTags(c AWSCloud, id string, expected map[string]string) error {
-	actual, err := c.GetTags(id)
+func addAWSTags(c AWSCloud, retrier *fi.MutationRetrier, id string, expected map[string]string) error {
+	actual, err := getTags(c, id)
 	if err != nil {
 		return fmt.Errorf("unexpected error fetching tags for resource: %v", err)
 	}
@@ -1449,7 +1706,7 @@ func addAWSTags(c AWSCloud, id string, expected map[string]string) error {
 	if len(missing) != 0 {
 		klog.V(4).Infof("adding tags to %q: %v", id, missing)
 
-		err := c.CreateTags(id, missing)
+		err := createTags(c, retrier, id, missing)
 		if err != nil {
 			return fmt.Errorf("error adding tags to resource %q: %v", id, err)
 		}
@@ -1907,24 +2164,10 @@ func describeVPC(c AWSCloud, vpcID string) (*ec2types.Vpc, error) {
 // owner/name in which case we find the image with the specified name, owned by owner
 // name in which case we find the image with the specified name, with the current owner
 func (c *awsCloudImplementation) ResolveImage(name string) (*ec2types.Image, error) {
-	return resolveImage(context.TODO(), c.ssm, c.ec2, name)
-}
-
-func resolveSSMParameter(ctx context.Context, ssmClient awsinterfaces.SSMAPI, name string) (string, error) {
-	klog.V(2).Infof("Resolving SSM parameter %q", name)
-	request := &ssm.GetParameterInput{
-		Name: aws.String(name),
-	}
-
-	response, err := ssmClient.GetParameter(ctx, request)
-	if err != nil {
-		return "", fmt.Errorf("failed to get value for SSM parameter: %w", err)
-	}
-
-	return aws.ToString(response.Parameter.Value), nil
+	return resolveImage(context.TODO(), c.ssmParameters, c.SSM(), c.EC2(), name)
 }
 
-func resolveImage(ctx context.Context, ssmClient awsinterfaces.SSMAPI, ec2Client awsinterfaces.EC2API, name string) (*ec2types.Image, error) {
+func resolveImage(ctx context.Context, parameters *ssmParameters, ssmClient awsinterfaces.SSMAPI, ec2Client awsinterfaces.EC2API, name string) (*ec2types.Image, error) {
 	// TODO: Cache this result during a single execution (we get called multiple times)
 	klog.V(2).Infof("Calling DescribeImages to resolve name %q", name)
 	request := &ec2.DescribeImagesInput{}
@@ -1935,12 +2178,12 @@ func resolveImage(ctx context.Context, ssmClient awsinterfaces.SSMAPI, ec2Client
 	} else if strings.HasPrefix(name, "ssm:") {
 		parameter := strings.TrimPrefix(name, "ssm:")
 
-		image, err := resolveSSMParameter(ctx, ssmClient, parameter)
+		values, err := parameters.resolve(ctx, ssmClient, []string{parameter})
 		if err != nil {
 			return nil, err
 		}
 
-		request.ImageIds = []string{image}
+		request.ImageIds = []string{values[parameter]}
 	} else {
 		// Either <imagename> or <owner>/<imagename>
 		tokens := strings.SplitN(name, "/", 2)
@@ -2061,27 +2304,27 @@ func (c *awsCloudImplementation) DNS() (dnsprovider.Interface, error) {
 }
 
 func (c *awsCloudImplementation) EC2() awsinterfaces.EC2API {
-	return c.ec2
+	return c.ec2.get()
 }
 
 func (c *awsCloudImplementation) IAM() awsinterfaces.IAMAPI {
-	return c.iam
+	return c.iam.get()
 }
 
 func (c *awsCloudImplementation) ELB() awsinterfaces.ELBAPI {
-	return c.elb
+	return c.elb.get()
 }
 
 func (c *awsCloudImplementation) ELBV2() awsinterfaces.ELBV2API {
-	return c.elbv2
+	return c.elbv2.get()
 }
 
 func (c *awsCloudImplementation) Autoscaling() awsinterfaces.AutoScalingAPI {
-	return c.autoscaling
+	return c.autoscaling.get()
 }
 
 func (c *awsCloudImplementation) Route53() awsinterfaces.Route53API {
-	return c.route53
+	return c.route53.get()
 }
 
 func (c *awsCloudImplementation) Spotinst() spotinst.Cloud {
@@ -2089,15 +2332,23 @@ func (c *awsCloudImplementation) Spotinst() spotinst.Cloud {
 }
 
 func (c *awsCloudImplementation) SQS() awsinterfaces.SQSAPI {
-	return c.sqs
+	return c.sqs.get()
 }
 
 func (c *awsCloudImplementation) EventBridge() awsinterfaces.EventBridgeAPI {
-	return c.eventbridge
+	return c.eventbridge.get()
 }
 
 func (c *awsCloudImplementation) SSM() awsinterfaces.SSMAPI {
-	return c.ssm
+	return c.ssm.get()
+}
+
+func (c *awsCloudImplementation) ACM() awsinterfaces.ACMAPI {
+	return c.acm.get()
+}
+
+func (c *awsCloudImplementation) KMS() awsinterfaces.KMSAPI {
+	return c.kms.get()
 }
 
 func (c *awsCloudImplementation) FindVPCInfo(vpcID string) (*fi.VPCInfo, error) {
@@ -2274,7 +2525,7 @@ func (c *awsCloudImplementation) zonesWithInstanceType(instanceType ec2types.Ins
 
 	zones := sets.NewString()
 
-	response, err := c.ec2.DescribeReservedInstancesOfferings(ctx, request)
+	response, err := c.EC2().DescribeReservedInstancesOfferings(ctx, request)
 	if err != nil {
 		return zones, fmt.Errorf("error checking if instance type %q is supported in region %q: %v", instanceType, c.region, err)
 	}
@@ -2325,7 +2576,7 @@ func describeInstanceType(c AWSCloud, instanceType string) (*ec2types.InstanceTy
 func (c *awsCloudImplementation) AccountInfo(ctx context.Context) (string, string, error) {
 	request := &sts.GetCallerIdentityInput{}
 
-	response, err := c.sts.GetCallerIdentity(ctx, request)
+	response, err := c.sts.get().GetCallerIdentity(ctx, request)
 	if err != nil {
 		return "", "", fmt.Errorf("error getting AWS account ID: %v", err)
 	}
@@ -2376,6 +2627,16 @@ func GetInstanceCertificateNames(instances *ec2.DescribeInstancesOutput) (addrs
 
 	if instance.PrivateDnsName != nil {
 		addrs = append(addrs, *instance.PrivateDnsName)
+
+		// Instances with resource-name hostnames are named after their instance ID in the same domain
+		if instance.PrivateDnsNameOptions != nil && instance.PrivateDnsNameOptions.HostnameType == ec2types.HostnameTypeResourceName {
+			if _, domain, found := strings.Cut(*instance.PrivateDnsName, "."); found {
+				resourceName := *instance.InstanceId + "." + domain
+				if resourceName != *instance.PrivateDnsName {
+					addrs = append(addrs, resourceName)
+				}
+			}
+		}
 	}
 
 	// We only use data for the first interface, and only the first IP
@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+// countingEC2 counts the DescribeLaunchTemplateVersions requests
+type countingEC2 struct {
+	awsinterfaces.EC2API
+	describeLaunchTemplateVersions int
+}
+
+func (c *countingEC2) DescribeLaunchTemplateVersions(ctx context.Context, request *ec2.DescribeLaunchTemplateVersionsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
+	c.describeLaunchTemplateVersions++
+	return c.EC2API.DescribeLaunchTemplateVersions(ctx, request, optFns...)
+}
+
+func TestFindInPlaceLaunchConfigurations(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := InstallMockAWSCloud("us-test-1", "a")
+	counting := &countingEC2{EC2API: &mockec2.MockEC2{}}
+	cloud.MockEC2 = counting
+
+	lt, err := cloud.EC2().CreateLaunchTemplate(ctx, &ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String("nodes"),
+		LaunchTemplateData: &ec2types.RequestLaunchTemplateData{},
+	})
+	if err != nil {
+		t.Fatalf("error creating launch template: %v", err)
+	}
+	id := aws.ToString(lt.LaunchTemplate.LaunchTemplateId)
+
+	// Version 2 replaces instances, versions 3 and 4 only change tags
+	for _, description := range []string{"", LaunchTemplateVersionDescriptionInPlace, LaunchTemplateVersionDescriptionInPlace} {
+		input := &ec2.CreateLaunchTemplateVersionInput{
+			LaunchTemplateName: aws.String("nodes"),
+			LaunchTemplateData: &ec2types.RequestLaunchTemplateData{},
+		}
+		if description != "" {
+			input.VersionDescription = aws.String(description)
+		}
+		if _, err := cloud.EC2().CreateLaunchTemplateVersion(ctx, input); err != nil {
+			t.Fatalf("error creating launch template version: %v", err)
+		}
+	}
+
+	grid := []struct {
+		configName string
+		expected   map[string]bool
+		requests   int
+	}{
+		{
+			configName: id + ":4",
+			expected:   map[string]bool{id + ":4": true, id + ":3": true, id + ":2": true},
+			requests:   1,
+		},
+		{
+			configName: id + ":3",
+			expected:   map[string]bool{id + ":3": true, id + ":2": true},
+			requests:   1,
+		},
+		{
+			configName: id + ":2",
+			expected:   map[string]bool{id + ":2": true},
+			requests:   1,
+		},
+		{
+			configName: "nodes.minimal.example.com-20240101",
+			expected:   map[string]bool{"nodes.minimal.example.com-20240101": true},
+			requests:   0,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.configName, func(t *testing.T) {
+			counting.describeLaunchTemplateVersions = 0
+			actual, err := findInPlaceLaunchConfigurations(ctx, cloud, g.configName)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, g.expected) {
+				t.Errorf("expected %v, got %v", g.expected, actual)
+			}
+			if counting.describeLaunchTemplateVersions != g.requests {
+				t.Errorf("expected %d DescribeLaunchTemplateVersions requests, got %d", g.requests, counting.describeLaunchTemplateVersions)
+			}
+		})
+	}
+}
+
+func TestLaunchTemplateDataChanges(t *testing.T) {
+	grid := []struct {
+		name     string
+		current  *ec2types.ResponseLaunchTemplateData
+		desired  *ec2types.ResponseLaunchTemplateData
+		expected []string
+	}{
+		{
+			name:     "unchanged",
+			current:  &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-1"), UserData: aws.String("a")},
+			desired:  &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-1"), UserData: aws.String("a")},
+			expected: nil,
+		},
+		{
+			name:     "image and user data",
+			current:  &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-1"), InstanceType: ec2types.InstanceTypeT3Medium, UserData: aws.String("a")},
+			desired:  &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-2"), InstanceType: ec2types.InstanceTypeT3Medium, UserData: aws.String("b")},
+			expected: []string{"ImageId", "UserData"},
+		},
+		{
+			name:     "security groups",
+			current:  &ec2types.ResponseLaunchTemplateData{SecurityGroupIds: []string{"sg-1"}},
+			desired:  &ec2types.ResponseLaunchTemplateData{SecurityGroupIds: []string{"sg-1", "sg-2"}},
+			expected: []string{"SecurityGroupIds"},
+		},
+		{
+			name:     "unknown version data",
+			current:  nil,
+			desired:  &ec2types.ResponseLaunchTemplateData{ImageId: aws.String("ami-2")},
+			expected: nil,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			actual := launchTemplateDataChanges(g.current, g.desired)
+			if !reflect.DeepEqual(actual, g.expected) {
+				t.Errorf("expected %v, got %v", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGroupInstancesDoesNotModifyGroup(t *testing.T) {
+	ready := make([]*cloudinstances.CloudInstance, 1, 2)
+	ready[0] = &cloudinstances.CloudInstance{ID: "i-ready"}
+	cg := &cloudinstances.CloudInstanceGroup{
+		Ready:      ready,
+		NeedUpdate: []*cloudinstances.CloudInstance{{ID: "i-outdated"}},
+	}
+
+	instances := groupInstances(cg)
+	if len(instances) != 2 || instances[0].ID != "i-ready" || instances[1].ID != "i-outdated" {
+		t.Fatalf("unexpected instances %v", instances)
+	}
+	if spare := ready[:cap(ready)][1]; spare != nil {
+		t.Errorf("the spare capacity of the ready instances was overwritten with %q", spare.ID)
+	}
+}
+
+func TestGetInstanceCertificateNames(t *testing.T) {
+	grid := []struct {
+		description  string
+		hostnameType ec2types.HostnameType
+		dnsName      string
+		expected     []string
+	}{
+		{
+			description:  "ip-name hostname",
+			hostnameType: ec2types.HostnameTypeIpName,
+			dnsName:      "ip-172-20-32-10.ec2.internal",
+			expected:     []string{"i-0123456789abcdef0", "ip-172-20-32-10.ec2.internal", "172.20.32.10"},
+		},
+		{
+			description:  "resource-name hostname",
+			hostnameType: ec2types.HostnameTypeResourceName,
+			dnsName:      "ip-172-20-32-10.ec2.internal",
+			expected:     []string{"i-0123456789abcdef0", "ip-172-20-32-10.ec2.internal", "i-0123456789abcdef0.ec2.internal", "172.20.32.10"},
+		},
+		{
+			description:  "resource-name private DNS name",
+			hostnameType: ec2types.HostnameTypeResourceName,
+			dnsName:      "i-0123456789abcdef0.ec2.internal",
+			expected:     []string{"i-0123456789abcdef0", "i-0123456789abcdef0.ec2.internal", "172.20.32.10"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.description, func(t *testing.T) {
+			instances := &ec2.DescribeInstancesOutput{
+				Reservations: []ec2types.Reservation{
+					{
+						Instances: []ec2types.Instance{
+							{
+								InstanceId:     aws.String("i-0123456789abcdef0"),
+								PrivateDnsName: aws.String(g.dnsName),
+								PrivateDnsNameOptions: &ec2types.PrivateDnsNameOptionsResponse{
+									HostnameType: g.hostnameType,
+								},
+								NetworkInterfaces: []ec2types.InstanceNetworkInterface{
+									{
+										Attachment:       &ec2types.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int32(0)},
+										PrivateIpAddress: aws.String("172.20.32.10"),
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			addrs, err := GetInstanceCertificateNames(instances)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(addrs, g.expected) {
+				t.Errorf("expected %v, got %v", g.expected, addrs)
+			}
+		})
+	}
+}
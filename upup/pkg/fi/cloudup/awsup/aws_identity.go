@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"k8s.io/kops/pkg/bootstrap"
+)
+
+var _ bootstrap.IdentityVerifier = &awsVerifier{}
+
+// NewAWSIdentityVerifier returns a verifier of the IAM identities of operators, which sign their requests like nodes do.
+func NewAWSIdentityVerifier(ctx context.Context, opt *AWSVerifierOptions) (bootstrap.IdentityVerifier, error) {
+	return newAWSVerifier(ctx, opt)
+}
+
+// VerifyIdentity verifies the signed STS request of an operator, returning the IAM role or user of the caller.
+func (a awsVerifier) VerifyIdentity(ctx context.Context, rawRequest *http.Request, token string, body []byte) (*bootstrap.IdentityResult, error) {
+	var result *bootstrap.IdentityResult
+	verifyCallerIdentity := func(ctx context.Context, callerIdentity *GetCallerIdentityResponse) (*bootstrap.VerifyResult, error) {
+		if len(callerIdentity.GetCallerIdentityResult) == 0 {
+			return nil, fmt.Errorf("STS response contains no caller identity")
+		}
+		arn := callerIdentity.GetCallerIdentityResult[0].Arn
+		identity, err := CanonicalIAMIdentity(arn)
+		if err != nil {
+			return nil, err
+		}
+		result = &bootstrap.IdentityResult{
+			Identity:  identity,
+			Principal: arn,
+		}
+		return &bootstrap.VerifyResult{}, nil
+	}
+
+	var err error
+	switch {
+	case strings.HasPrefix(token, AWSAuthenticationTokenPrefixV1):
+		_, err = a.verifyTokenV1(ctx, token, body, verifyCallerIdentity)
+	case strings.HasPrefix(token, AWSAuthenticationTokenPrefixV2):
+		_, err = a.verifyTokenV2(ctx, token, body, verifyCallerIdentity)
+	default:
+		return nil, bootstrap.ErrNotThisVerifier
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CanonicalIAMIdentity returns the ARN of the IAM role or user of an ARN, without its path.
+// The ARN of an assumed role session, as returned by STS, maps to the ARN of its role.
+func CanonicalIAMIdentity(arn string) (string, error) {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 || parts[0] != "arn" {
+		return "", fmt.Errorf("%q is not a valid ARN", arn)
+	}
+	partition, service, account := parts[1], parts[2], parts[4]
+
+	resource := strings.Split(parts[5], "/")
+	if len(resource) < 2 {
+		return "", fmt.Errorf("ARN %q is not an IAM role or user", arn)
+	}
+	resourceType, name := resource[0], resource[len(resource)-1]
+	switch {
+	case service == "sts" && resourceType == "assumed-role":
+		if len(resource) != 3 {
+			return "", fmt.Errorf("ARN %q is not a valid assumed role", arn)
+		}
+		// The path of the role is not part of the ARN of its sessions, so we use the role name
+		resourceType, name = "role", resource[1]
+	case service == "iam" && (resourceType == "role" || resourceType == "user"):
+	default:
+		return "", fmt.Errorf("ARN %q is not an IAM role or user", arn)
+	}
+	if name == "" {
+		return "", fmt.Errorf("ARN %q has no name", arn)
+	}
+
+	return fmt.Sprintf("arn:%s:iam::%s:%s/%s", partition, account, resourceType, name), nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"testing"
+)
+
+func TestCanonicalIAMIdentity(t *testing.T) {
+	grid := []struct {
+		ARN      string
+		Expected string
+	}{
+		{
+			ARN:      "arn:aws:sts::123456789012:assumed-role/Admin/alice@example.com",
+			Expected: "arn:aws:iam::123456789012:role/Admin",
+		},
+		{
+			ARN:      "arn:aws:iam::123456789012:role/Admin",
+			Expected: "arn:aws:iam::123456789012:role/Admin",
+		},
+		{
+			ARN:      "arn:aws:iam::123456789012:role/platform/Admin",
+			Expected: "arn:aws:iam::123456789012:role/Admin",
+		},
+		{
+			ARN:      "arn:aws-cn:iam::123456789012:user/operators/alice",
+			Expected: "arn:aws-cn:iam::123456789012:user/alice",
+		},
+		{
+			ARN: "arn:aws:sts::123456789012:federated-user/alice",
+		},
+		{
+			ARN: "arn:aws:iam::123456789012:group/Admins",
+		},
+		{
+			ARN: "arn:aws:s3:::bucket/role/Admin",
+		},
+		{
+			ARN: "Admin",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.ARN, func(t *testing.T) {
+			identity, err := CanonicalIAMIdentity(g.ARN)
+			if g.Expected == "" {
+				if err == nil {
+					t.Errorf("expected error, got %q", identity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if identity != g.Expected {
+				t.Errorf("unexpected identity; got %q, want %q", identity, g.Expected)
+			}
+		})
+	}
+}
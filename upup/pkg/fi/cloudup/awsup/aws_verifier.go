@@ -63,6 +63,10 @@ type awsVerifier struct {
 var _ bootstrap.Verifier = &awsVerifier{}
 
 func NewAWSVerifier(ctx context.Context, opt *AWSVerifierOptions) (bootstrap.Verifier, error) {
+	return newAWSVerifier(ctx, opt)
+}
+
+func newAWSVerifier(ctx context.Context, opt *AWSVerifierOptions) (*awsVerifier, error) {
 	config, err := awsconfig.LoadDefaultConfig(
 		ctx,
 		awsconfig.WithRegion(opt.Region),
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+// WarmPoolPollInterval is the interval between checks of the instances of a warm pool.
+var WarmPoolPollInterval = 10 * time.Second
+
+// isWarmedInstance returns true for the instances of a warm pool that can be moved into service.
+func isWarmedInstance(i autoscalingtypes.Instance) bool {
+	switch i.LifecycleState {
+	case autoscalingtypes.LifecycleStateWarmedStopped, autoscalingtypes.LifecycleStateWarmedRunning, autoscalingtypes.LifecycleStateWarmedHibernated:
+		return true
+	default:
+		return false
+	}
+}
+
+// PrewarmInstances raises the minimum size of the warm pool of the autoscaling group to count, and waits
+// until the warm pool holds count instances that can be moved into service. The returned function restores
+// the previous minimum size of the warm pool.
+func PrewarmInstances(ctx context.Context, c AWSCloud, group *cloudinstances.CloudInstanceGroup, count int, timeout time.Duration) (func() error, error) {
+	name := group.HumanName
+
+	response, err := c.Autoscaling().DescribeWarmPool(ctx, &autoscaling.DescribeWarmPoolInput{
+		AutoScalingGroupName: aws.String(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error describing warm pool of autoscaling group %q: %w", name, err)
+	}
+	config := response.WarmPoolConfiguration
+	if config == nil {
+		return nil, fmt.Errorf("autoscaling group %q has no warm pool", name)
+	}
+
+	restore := func() error { return nil }
+	if previous := aws.ToInt32(config.MinSize); int(previous) < count {
+		klog.Infof("Raising the minimum size of the warm pool of %q from %d to %d", name, previous, count)
+		if err := putWarmPoolMinSize(ctx, c, name, config, int32(count)); err != nil {
+			return nil, err
+		}
+		restore = func() error {
+			klog.Infof("Restoring the minimum size of the warm pool of %q to %d", name, previous)
+			return putWarmPoolMinSize(ctx, c, name, config, previous)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		response, err := c.Autoscaling().DescribeWarmPool(ctx, &autoscaling.DescribeWarmPoolInput{
+			AutoScalingGroupName: aws.String(name),
+		})
+		if err != nil {
+			return restore, fmt.Errorf("error describing warm pool of autoscaling group %q: %w", name, err)
+		}
+
+		warmed := 0
+		for _, i := range response.Instances {
+			if isWarmedInstance(i) {
+				warmed++
+			}
+		}
+		if warmed >= count {
+			return restore, nil
+		}
+		if time.Now().After(deadline) {
+			return restore, fmt.Errorf("timed out waiting for the warm pool of autoscaling group %q to hold %d instances, it holds %d", name, count, warmed)
+		}
+
+		klog.Infof("Waiting for the warm pool of %q to hold %d instances, it holds %d", name, count, warmed)
+		time.Sleep(WarmPoolPollInterval)
+	}
+}
+
+func putWarmPoolMinSize(ctx context.Context, c AWSCloud, name string, config *autoscalingtypes.WarmPoolConfiguration, minSize int32) error {
+	request := &autoscaling.PutWarmPoolInput{
+		AutoScalingGroupName:     aws.String(name),
+		MaxGroupPreparedCapacity: config.MaxGroupPreparedCapacity,
+		MinSize:                  aws.Int32(minSize),
+		PoolState:                config.PoolState,
+		InstanceReusePolicy:      config.InstanceReusePolicy,
+	}
+	if _, err := c.Autoscaling().PutWarmPool(ctx, request); err != nil {
+		return fmt.Errorf("error updating warm pool of autoscaling group %q: %w", name, err)
+	}
+	return nil
+}
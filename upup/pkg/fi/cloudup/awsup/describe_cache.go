@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go/middleware"
+	"k8s.io/klog/v2"
+)
+
+// describeCacheTTL bounds how long a cached result is used, so that the loops waiting for a change
+// that kOps did not make itself, like an instance becoming ready, still see it.
+const describeCacheTTL = 30 * time.Second
+
+// describeCacheOperations are the operations of the EC2 API whose results are cached, with the constructors
+// of their results.
+var describeCacheOperations = map[string]func() interface{}{
+	"DescribeSubnets":        func() interface{} { return &ec2.DescribeSubnetsOutput{} },
+	"DescribeSecurityGroups": func() interface{} { return &ec2.DescribeSecurityGroupsOutput{} },
+	"DescribeInstances":      func() interface{} { return &ec2.DescribeInstancesOutput{} },
+}
+
+// describeCache memoizes the results of the Describe calls of the EC2 API that tasks repeat during a run,
+// like looking up the same subnets for each instance group. Any call of the EC2, Auto Scaling or ELB APIs
+// that changes resources invalidates it.
+//
+// The results are stored serialized, so that each caller gets its own copy that it can modify.
+type describeCache struct {
+	mutex   sync.Mutex
+	enabled bool
+	entries map[string]describeCacheEntry
+	hits    int
+	misses  int
+
+	// generation is incremented by each invalidation, so that the results of requests
+	// that were sent before it are not cached.
+	generation int
+}
+
+type describeCacheEntry struct {
+	result  []byte
+	expires time.Time
+}
+
+func newDescribeCache() *describeCache {
+	return &describeCache{
+		entries: make(map[string]describeCacheEntry),
+	}
+}
+
+// EnableDescribeCache caches the results of the EC2 calls that describe subnets, security groups and instances,
+// until the returned function is called. It does nothing for other implementations of AWSCloud.
+func EnableDescribeCache(cloud AWSCloud) func() {
+	c, ok := cloud.(*awsCloudImplementation)
+	if !ok || c.describeCache == nil {
+		return func() {}
+	}
+	c.describeCache.setEnabled(true)
+	return func() {
+		c.describeCache.setEnabled(false)
+	}
+}
+
+func (c *describeCache) setEnabled(enabled bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !enabled && c.enabled {
+		klog.V(2).Infof("EC2 describe cache: %d hits, %d misses", c.hits, c.misses)
+	}
+	c.enabled = enabled
+	c.entries = make(map[string]describeCacheEntry)
+	c.hits = 0
+	c.misses = 0
+}
+
+// get returns the cached result of the operation if any, and the current generation.
+func (c *describeCache) get(key string) ([]byte, bool, int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.enabled {
+		return nil, false, c.generation
+	}
+	entry, found := c.entries[key]
+	if !found || time.Now().After(entry.expires) {
+		c.misses++
+		return nil, false, c.generation
+	}
+	c.hits++
+	return entry.result, true, c.generation
+}
+
+func (c *describeCache) put(key string, result []byte, generation int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.enabled || generation != c.generation {
+		return
+	}
+	c.entries[key] = describeCacheEntry{
+		result:  result,
+		expires: time.Now().Add(describeCacheTTL),
+	}
+}
+
+func (c *describeCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.generation++
+	if len(c.entries) != 0 {
+		c.entries = make(map[string]describeCacheEntry)
+	}
+}
+
+// isReadOnlyOperation returns true for the operations that do not change resources.
+func isReadOnlyOperation(operation string) bool {
+	return strings.HasPrefix(operation, "Describe") || strings.HasPrefix(operation, "Get") || strings.HasPrefix(operation, "List")
+}
+
+// addMiddleware returns the cached results of the cached operations of the EC2 API, before the requests are sent,
+// and invalidates the cache after the other operations that change resources.
+func (c *describeCache) addMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("KopsDescribeCache",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			operation := awsmiddleware.GetOperationName(ctx)
+
+			newResult := describeCacheOperations[operation]
+			if newResult == nil {
+				return c.invalidateAfter(ctx, operation, in, next)
+			}
+
+			params, err := json.Marshal(in.Parameters)
+			if err != nil {
+				return next.HandleInitialize(ctx, in)
+			}
+			key := operation + string(params)
+
+			cached, found, generation := c.get(key)
+			if found {
+				result := newResult()
+				if err := json.Unmarshal(cached, result); err == nil {
+					return middleware.InitializeOutput{Result: result}, middleware.Metadata{}, nil
+				}
+			}
+
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			if err == nil {
+				if result, err := json.Marshal(out.Result); err == nil {
+					c.put(key, result, generation)
+				}
+			}
+			return out, metadata, err
+		}), middleware.After)
+}
+
+// addInvalidateMiddleware invalidates the cache after the operations that change resources, for the clients
+// of the APIs whose changes are seen by the cached operations, like the instances launched by Auto Scaling
+// or the network interfaces of load balancers.
+func (c *describeCache) addInvalidateMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("KopsDescribeCacheInvalidate",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			return c.invalidateAfter(ctx, awsmiddleware.GetOperationName(ctx), in, next)
+		}), middleware.After)
+}
+
+func (c *describeCache) invalidateAfter(ctx context.Context, operation string, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	if !isReadOnlyOperation(operation) {
+		// Invalidate even after errors, as the request may have been applied
+		c.invalidate()
+	}
+	return out, metadata, err
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	autoscalingtypes "github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// fakeEC2Transport answers the requests of the EC2 and Auto Scaling APIs with empty responses, and counts them by action.
+type fakeEC2Transport struct {
+	requests map[string]int
+}
+
+func (f *fakeEC2Transport) Do(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, err
+	}
+	action := values.Get("Action")
+	f.requests[action]++
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body:       io.NopCloser(strings.NewReader("<" + action + "Response><" + action + "Result></" + action + "Result></" + action + "Response>")),
+		Request:    req,
+	}, nil
+}
+
+func TestDescribeCache(t *testing.T) {
+	ctx := context.TODO()
+
+	transport := &fakeEC2Transport{requests: make(map[string]int)}
+	cache := newDescribeCache()
+	cfg := aws.Config{
+		Region:      "us-test-1",
+		Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+		HTTPClient:  transport,
+	}
+	client := ec2.NewFromConfig(cfg, func(o *ec2.Options) {
+		o.APIOptions = append(o.APIOptions, cache.addMiddleware)
+	})
+	autoscalingClient := autoscaling.NewFromConfig(cfg, func(o *autoscaling.Options) {
+		o.APIOptions = append(o.APIOptions, cache.addInvalidateMiddleware)
+	})
+
+	describe := func(subnetID string) *ec2.DescribeSubnetsOutput {
+		out, err := client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: []string{subnetID}})
+		if err != nil {
+			t.Fatalf("error describing subnets: %v", err)
+		}
+		return out
+	}
+
+	// Disabled
+	describe("subnet-1")
+	describe("subnet-1")
+	if transport.requests["DescribeSubnets"] != 2 {
+		t.Errorf("expected 2 requests while disabled, got %d", transport.requests["DescribeSubnets"])
+	}
+
+	cache.setEnabled(true)
+	describe("subnet-1")
+	describe("subnet-1")
+	describe("subnet-2")
+	if transport.requests["DescribeSubnets"] != 4 {
+		t.Errorf("expected 4 requests with cached results, got %d", transport.requests["DescribeSubnets"])
+	}
+
+	// Other read-only calls do not invalidate the cache
+	if _, err := client.DescribeVpcs(ctx, &ec2.DescribeVpcsInput{}); err != nil {
+		t.Fatalf("error describing VPCs: %v", err)
+	}
+	describe("subnet-1")
+	if transport.requests["DescribeSubnets"] != 4 {
+		t.Errorf("expected 4 requests after read-only call, got %d", transport.requests["DescribeSubnets"])
+	}
+
+	// Writes do
+	if _, err := client.CreateTags(ctx, &ec2.CreateTagsInput{Resources: []string{"subnet-1"}, Tags: []ec2types.Tag{{Key: aws.String("Name"), Value: aws.String("subnet-1")}}}); err != nil {
+		t.Fatalf("error creating tags: %v", err)
+	}
+	describe("subnet-1")
+	if transport.requests["DescribeSubnets"] != 5 {
+		t.Errorf("expected 5 requests after write, got %d", transport.requests["DescribeSubnets"])
+	}
+
+	// Each caller gets its own copy of the cached result
+	describe("subnet-1").NextToken = aws.String("modified")
+	if out := describe("subnet-1"); out.NextToken != nil {
+		t.Errorf("modification of a cached result was seen by another caller: %q", aws.ToString(out.NextToken))
+	}
+	if transport.requests["DescribeSubnets"] != 5 {
+		t.Errorf("expected 5 requests with cached copies, got %d", transport.requests["DescribeSubnets"])
+	}
+
+	// Reads of the Auto Scaling API do not invalidate the cache, its writes do
+	if _, err := autoscalingClient.DescribeAutoScalingGroups(ctx, &autoscaling.DescribeAutoScalingGroupsInput{}); err != nil {
+		t.Fatalf("error describing autoscaling groups: %v", err)
+	}
+	describe("subnet-1")
+	if transport.requests["DescribeSubnets"] != 5 {
+		t.Errorf("expected 5 requests after autoscaling read, got %d", transport.requests["DescribeSubnets"])
+	}
+	if _, err := autoscalingClient.CreateOrUpdateTags(ctx, &autoscaling.CreateOrUpdateTagsInput{Tags: []autoscalingtypes.Tag{{Key: aws.String("Name"), ResourceId: aws.String("nodes")}}}); err != nil {
+		t.Fatalf("error creating autoscaling tags: %v", err)
+	}
+	describe("subnet-1")
+	if transport.requests["DescribeSubnets"] != 6 {
+		t.Errorf("expected 6 requests after autoscaling write, got %d", transport.requests["DescribeSubnets"])
+	}
+
+	cache.setEnabled(false)
+	describe("subnet-1")
+	if transport.requests["DescribeSubnets"] != 7 {
+		t.Errorf("expected 7 requests after disabling, got %d", transport.requests["DescribeSubnets"])
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// lazyClient builds the client of an AWS service on first use. Building the clients of all the services
+// up front allocates their middleware stacks and endpoint resolvers, which is a noticeable share of the memory
+// of nodeup on small instances, although nodeup only uses a few of them, if any.
+type lazyClient[T any] struct {
+	once   sync.Once
+	build  func() T
+	client T
+}
+
+func newLazyClient[T any](build func() T) *lazyClient[T] {
+	return &lazyClient[T]{build: build}
+}
+
+// get returns the client, building it if needed.
+func (l *lazyClient[T]) get() T {
+	l.once.Do(func() {
+		l.client = l.build()
+		l.build = nil
+	})
+	return l.client
+}
+
+// newUnavailableClient returns a client of a service that the cloud was built without, which aborts the process when used.
+func newUnavailableClient[T any](service string) *lazyClient[T] {
+	return newLazyClient(func() T {
+		klog.Fatalf("the %s client is not available to this cloud", service)
+		var client T
+		return client
+	})
+}
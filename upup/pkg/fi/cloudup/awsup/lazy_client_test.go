@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+func TestLazyClient(t *testing.T) {
+	builds := 0
+	l := newLazyClient(func() *string {
+		builds++
+		client := "client"
+		return &client
+	})
+	if builds != 0 {
+		t.Fatalf("expected the client not to be built before use, got %d builds", builds)
+	}
+
+	var wg sync.WaitGroup
+	clients := make([]*string, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i] = l.get()
+		}(i)
+	}
+	wg.Wait()
+
+	if builds != 1 {
+		t.Errorf("expected the client to be built once, got %d builds", builds)
+	}
+	for _, client := range clients {
+		if client != clients[0] {
+			t.Errorf("expected the same client to be returned")
+		}
+	}
+}
+
+func TestWithTagsSharesClients(t *testing.T) {
+	c := &awsCloudImplementation{
+		ec2: newLazyClient(func() awsinterfaces.EC2API { return ec2.NewFromConfig(aws.Config{Region: "us-test-1"}) }),
+	}
+	tagged := c.WithTags(map[string]string{"KubernetesCluster": "example.com"}).(*awsCloudImplementation)
+	if tagged.ec2.get() != c.ec2.get() {
+		t.Errorf("expected the clients to be shared by the clouds with different tags")
+	}
+}
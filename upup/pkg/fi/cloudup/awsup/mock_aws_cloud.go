@@ -19,6 +19,7 @@ package awsup
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
@@ -79,6 +80,8 @@ type MockCloud struct {
 	MockSQS         awsinterfaces.SQSAPI
 	MockEventBridge awsinterfaces.EventBridgeAPI
 	MockSSM         awsinterfaces.SSMAPI
+	MockACM         awsinterfaces.ACMAPI
+	MockKMS         awsinterfaces.KMSAPI
 }
 
 func (c *MockAWSCloud) DeleteGroup(g *cloudinstances.CloudInstanceGroup) error {
@@ -139,11 +142,11 @@ func (c *MockAWSCloud) BuildFilters(name *string) []ec2types.Filter {
 }
 
 func (c *MockAWSCloud) AddAWSTags(id string, expected map[string]string) error {
-	return addAWSTags(c, id, expected)
+	return addAWSTags(c, tagsRetrier, id, expected)
 }
 
 func (c *MockAWSCloud) DeleteTags(id string, tags map[string]string) error {
-	return deleteTags(c, id, tags)
+	return deleteTags(c, tagsRetrier, id, tags)
 }
 
 func (c *MockAWSCloud) BuildTags(name *string) map[string]string {
@@ -159,7 +162,7 @@ func (c *MockAWSCloud) Tags() map[string]string {
 }
 
 func (c *MockAWSCloud) CreateTags(resourceId string, tags map[string]string) error {
-	return createTags(c, resourceId, tags)
+	return createTags(c, tagsRetrier, resourceId, tags)
 }
 
 func (c *MockAWSCloud) GetTags(resourceID string) (map[string]string, error) {
@@ -167,7 +170,7 @@ func (c *MockAWSCloud) GetTags(resourceID string) (map[string]string, error) {
 }
 
 func (c *MockAWSCloud) UpdateTags(id string, tags map[string]string) error {
-	return updateTags(c, id, tags)
+	return updateTags(c, tagsRetrier, id, tags)
 }
 
 func (c *MockAWSCloud) GetELBTags(loadBalancerName string) (map[string]string, error) {
@@ -219,7 +222,7 @@ func (c *MockAWSCloud) DescribeVPC(vpcID string) (*ec2types.Vpc, error) {
 }
 
 func (c *MockAWSCloud) ResolveImage(name string) (*ec2types.Image, error) {
-	return resolveImage(context.TODO(), c.MockSSM, c.MockEC2, name)
+	return resolveImage(context.TODO(), nil, c.MockSSM, c.MockEC2, name)
 }
 
 func (c *MockAWSCloud) WithTags(tags map[string]string) AWSCloud {
@@ -299,6 +302,20 @@ func (c *MockAWSCloud) SSM() awsinterfaces.SSMAPI {
 	return c.MockSSM
 }
 
+func (c *MockAWSCloud) ACM() awsinterfaces.ACMAPI {
+	if c.MockACM == nil {
+		klog.Fatalf("MockACM not set")
+	}
+	return c.MockACM
+}
+
+func (c *MockAWSCloud) KMS() awsinterfaces.KMSAPI {
+	if c.MockKMS == nil {
+		klog.Fatalf("MockKMS not set")
+	}
+	return c.MockKMS
+}
+
 func (c *MockAWSCloud) FindVPCInfo(id string) (*fi.VPCInfo, error) {
 	return findVPCInfo(c, id)
 }
@@ -350,7 +367,7 @@ func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2types.Inst
 	}
 
 	switch instanceType {
-	case "c5.large", "m3.medium", "m4.large", "m5.large", "m5.xlarge", "t3.micro", "t3.medium", "t3.large", "c4.large":
+	case "c5.large", "m3.medium", "m4.large", "m5.large", "m5.xlarge", "m6a.xlarge", "t3.micro", "t3.medium", "t3.large", "c4.large":
 		info.ProcessorInfo = &ec2types.ProcessorInfo{
 			SupportedArchitectures: []ec2types.ArchitectureType{
 				ec2types.ArchitectureTypeX8664,
@@ -369,7 +386,7 @@ func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2types.Inst
 				ec2types.ArchitectureTypeX8664,
 			},
 		}
-	case "g4dn.xlarge", "g4ad.16xlarge":
+	case "g4dn.xlarge", "g4ad.16xlarge", "p4d.24xlarge":
 		info.ProcessorInfo = &ec2types.ProcessorInfo{
 			SupportedArchitectures: []ec2types.ArchitectureType{
 				ec2types.ArchitectureTypeX8664,
@@ -378,6 +395,28 @@ func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2types.Inst
 		info.GpuInfo = &ec2types.GpuInfo{}
 	}
 
+	switch instanceType {
+	case "m3.medium", "m4.large", "c4.large", "t2.micro", "t2.medium":
+		info.Hypervisor = ec2types.InstanceTypeHypervisorXen
+	default:
+		info.Hypervisor = ec2types.InstanceTypeHypervisorNitro
+	}
+	if strings.HasPrefix(instanceType, "t2.") || strings.HasPrefix(instanceType, "t3.") {
+		info.BurstablePerformanceSupported = aws.Bool(true)
+	}
+	if instanceType == "m6a.xlarge" {
+		info.VCpuInfo.DefaultVCpus = aws.Int32(4)
+		info.MemoryInfo.SizeInMiB = aws.Int64(16384)
+		info.NitroEnclavesSupport = ec2types.NitroEnclavesSupportSupported
+		info.ProcessorInfo.SupportedFeatures = []ec2types.SupportedAdditionalProcessorFeature{
+			ec2types.SupportedAdditionalProcessorFeatureAmdSevSnp,
+		}
+	}
+	if instanceType == "p4d.24xlarge" {
+		info.NetworkInfo.EfaSupported = aws.Bool(true)
+		info.NetworkInfo.MaximumNetworkCards = aws.Int32(4)
+	}
+
 	return info, nil
 }
 
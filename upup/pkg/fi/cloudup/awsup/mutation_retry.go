@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"time"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// MutationCircuitBreakerThreshold is the number of consecutive failures of the mutations of the tags,
+// after their retries, that aborts the run.
+const MutationCircuitBreakerThreshold = 10
+
+// throttlingErrors are the error codes of requests rejected by the rate limits of the EC2 API.
+var throttlingErrors = map[string]bool{
+	"RequestLimitExceeded": true,
+	"Throttling":           true,
+	"ThrottlingException":  true,
+}
+
+// MutationRetryPolicies are the default retry policies of the mutations of the tags.
+// Throttled requests back off exponentially, in addition to the retries of the SDK, while requests for resources
+// that have not propagated yet are retried at a steady pace. The other errors are not retried.
+var MutationRetryPolicies = map[fi.MutationErrorClass]fi.MutationRetryPolicy{
+	fi.MutationErrorThrottled: {
+		MaxAttempts: 8,
+		Interval:    2 * time.Second,
+		Factor:      2,
+		MaxInterval: 2 * time.Minute,
+		Jitter:      0.2,
+	},
+	fi.MutationErrorEventualConsistency: {
+		MaxAttempts: 120,
+		Interval:    2 * time.Second,
+		Jitter:      0.1,
+	},
+}
+
+// classifyMutationError returns the class of an error of the EC2 API.
+func classifyMutationError(err error) fi.MutationErrorClass {
+	switch {
+	case throttlingErrors[AWSErrorCode(err)]:
+		return fi.MutationErrorThrottled
+	case isTagsEventualConsistencyError(err):
+		return fi.MutationErrorEventualConsistency
+	default:
+		return fi.MutationErrorInvalid
+	}
+}
+
+// NewMutationRetrier builds a retrier of the mutations of the tags with MutationRetryPolicies,
+// whose breaker opens after MutationCircuitBreakerThreshold consecutive failures.
+// It is built for each apply, so that an open breaker does not outlive the apply.
+func NewMutationRetrier() *fi.MutationRetrier {
+	return &fi.MutationRetrier{
+		Classify: classifyMutationError,
+		Policies: MutationRetryPolicies,
+		Breaker:  fi.NewCircuitBreaker(MutationCircuitBreakerThreshold),
+	}
+}
+
+// tagsRetrier retries the requests for the tags made outside of an apply, and the reads of the tags, without a breaker.
+var tagsRetrier = &fi.MutationRetrier{
+	Classify: classifyMutationError,
+	Policies: MutationRetryPolicies,
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// newTestMutationRetrier builds a retrier with the default policies, waiting a millisecond between the attempts.
+func newTestMutationRetrier() *fi.MutationRetrier {
+	r := NewMutationRetrier()
+	r.Policies = make(map[fi.MutationErrorClass]fi.MutationRetryPolicy)
+	for class, policy := range MutationRetryPolicies {
+		policy.Interval = time.Millisecond
+		policy.MaxInterval = 0
+		r.Policies[class] = policy
+	}
+	return r
+}
+
+func TestClassifyMutationError(t *testing.T) {
+	grid := []struct {
+		err      error
+		expected fi.MutationErrorClass
+	}{
+		{
+			err:      &smithy.GenericAPIError{Code: "RequestLimitExceeded"},
+			expected: fi.MutationErrorThrottled,
+		},
+		{
+			err:      fmt.Errorf("error creating tags: %w", &smithy.GenericAPIError{Code: "Throttling"}),
+			expected: fi.MutationErrorThrottled,
+		},
+		{
+			err:      &smithy.GenericAPIError{Code: "InvalidVpcID.NotFound"},
+			expected: fi.MutationErrorEventualConsistency,
+		},
+		{
+			err:      &smithy.GenericAPIError{Code: "UnauthorizedOperation"},
+			expected: fi.MutationErrorInvalid,
+		},
+		{
+			err:      errors.New("connection reset"),
+			expected: fi.MutationErrorInvalid,
+		},
+	}
+	for _, g := range grid {
+		if actual := classifyMutationError(g.err); actual != g.expected {
+			t.Errorf("expected %v to be %q, got %q", g.err, g.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// RateLimit is the client-side limit of the requests to the AWS APIs while the tasks run in parallel.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests. The requests are not limited when it is zero.
+	RequestsPerSecond float64
+	// Burst is the number of requests that can be made at once, before the rate applies.
+	Burst int
+}
+
+// DefaultRateLimit returns a limit below the refill rate of the request token buckets of the EC2 API.
+func DefaultRateLimit() RateLimit {
+	return RateLimit{
+		RequestsPerSecond: 20,
+		Burst:             50,
+	}
+}
+
+// rateLimiter holds the token bucket of the clients of one cloud, while enabled by EnableRateLimit.
+type rateLimiter struct {
+	mutex   sync.Mutex
+	limiter *rate.Limiter
+}
+
+// EnableRateLimit limits the requests made by the clients of the cloud, including their retries, until the returned
+// function is called. It does nothing for other implementations of AWSCloud.
+func EnableRateLimit(cloud AWSCloud, limit RateLimit) func() {
+	c, ok := cloud.(*awsCloudImplementation)
+	if !ok || c.rateLimiter == nil || limit.RequestsPerSecond <= 0 {
+		return func() {}
+	}
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+	previous := c.rateLimiter.swap(rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst))
+	return func() {
+		c.rateLimiter.swap(previous)
+	}
+}
+
+func (r *rateLimiter) swap(limiter *rate.Limiter) *rate.Limiter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	previous := r.limiter
+	r.limiter = limiter
+	return previous
+}
+
+func (r *rateLimiter) get() *rate.Limiter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.limiter
+}
+
+// addMiddleware waits for the token bucket, if any, before each attempt of a request.
+func (r *rateLimiter) addMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("KopsRateLimit",
+		func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			if limiter := r.get(); limiter != nil {
+				if err := limiter.Wait(ctx); err != nil {
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+				}
+			}
+			return next.HandleFinalize(ctx, in)
+		}), middleware.After)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+func TestEnableRateLimit(t *testing.T) {
+	newCloud := func() (*awsCloudImplementation, *ec2.Client) {
+		c := &awsCloudImplementation{rateLimiter: &rateLimiter{}}
+		client := ec2.NewFromConfig(aws.Config{
+			Region:      "us-test-1",
+			Credentials: credentials.NewStaticCredentialsProvider("key", "secret", ""),
+			HTTPClient:  &fakeEC2Transport{requests: make(map[string]int)},
+		}, func(o *ec2.Options) {
+			o.APIOptions = append(o.APIOptions, c.rateLimiter.addMiddleware)
+		})
+		return c, client
+	}
+	// describe fails if the request has to wait for the token bucket
+	describe := func(client *ec2.Client) error {
+		ctx, cancel := context.WithTimeout(context.TODO(), 100*time.Millisecond)
+		defer cancel()
+		_, err := client.DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+		return err
+	}
+
+	cloud, client := newCloud()
+	otherCloud, otherClient := newCloud()
+
+	// Not limited until enabled
+	for i := 0; i < 3; i++ {
+		if err := describe(client); err != nil {
+			t.Fatalf("unexpected error before enabling the limit: %v", err)
+		}
+	}
+
+	disable := EnableRateLimit(cloud, RateLimit{RequestsPerSecond: 0.1, Burst: 1})
+	if err := describe(client); err != nil {
+		t.Fatalf("unexpected error within the burst: %v", err)
+	}
+	if err := describe(client); err == nil {
+		t.Fatalf("expected the request after the burst to wait")
+	}
+	for i := 0; i < 3; i++ {
+		if err := describe(otherClient); err != nil {
+			t.Fatalf("the limit of a cloud applied to another cloud: %v", err)
+		}
+	}
+
+	disable()
+	if err := describe(client); err != nil {
+		t.Fatalf("unexpected error after disabling the limit: %v", err)
+	}
+
+	// A zero rate does not limit the requests
+	defer EnableRateLimit(otherCloud, RateLimit{})()
+	for i := 0; i < 3; i++ {
+		if err := describe(otherClient); err != nil {
+			t.Fatalf("unexpected error with a zero rate: %v", err)
+		}
+	}
+}
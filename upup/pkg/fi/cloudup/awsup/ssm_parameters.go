@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+// ssmGetParametersBatchSize is the maximum number of parameters of a GetParameters call.
+const ssmGetParametersBatchSize = 10
+
+// ssmParameters caches the values of the SSM parameters, which do not change during an execution.
+type ssmParameters struct {
+	mutex  sync.Mutex
+	values map[string]string
+}
+
+func newSSMParameters() *ssmParameters {
+	return &ssmParameters{
+		values: make(map[string]string),
+	}
+}
+
+func (p *ssmParameters) get(name string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	value, found := p.values[name]
+	return value, found
+}
+
+func (p *ssmParameters) set(name string, value string) {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.values[name] = value
+}
+
+// resolve returns the values of the named parameters. The parameters that are not cached are looked up
+// with GetParameters, ssmGetParametersBatchSize at a time, and the names of all the invalid parameters are
+// reported in one error. A nil cache looks up all the parameters.
+func (p *ssmParameters) resolve(ctx context.Context, ssmClient awsinterfaces.SSMAPI, names []string) (map[string]string, error) {
+	values := make(map[string]string, len(names))
+	var missing []string
+	for _, name := range names {
+		if value, found := p.get(name); found {
+			values[name] = value
+		} else if !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+
+	var invalid []string
+	for start := 0; start < len(missing); start += ssmGetParametersBatchSize {
+		batch := missing[start:min(start+ssmGetParametersBatchSize, len(missing))]
+		klog.V(2).Infof("Resolving SSM parameters %v", batch)
+
+		response, err := ssmClient.GetParameters(ctx, &ssm.GetParametersInput{
+			Names: batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get values for SSM parameters: %w", err)
+		}
+		for _, parameter := range response.Parameters {
+			name := ssmParameterName(parameter)
+			value := aws.ToString(parameter.Value)
+			values[name] = value
+			p.set(name, value)
+		}
+		invalid = append(invalid, response.InvalidParameters...)
+	}
+
+	for _, name := range missing {
+		if _, found := values[name]; !found && !slices.Contains(invalid, name) {
+			invalid = append(invalid, name)
+		}
+	}
+	if len(invalid) != 0 {
+		sort.Strings(invalid)
+		return nil, fmt.Errorf("invalid SSM parameters: %s", strings.Join(invalid, ", "))
+	}
+	return values, nil
+}
+
+// ssmParameterName returns the name a parameter was requested by, including its version or label.
+func ssmParameterName(parameter ssmtypes.Parameter) string {
+	name := aws.ToString(parameter.Name)
+	if selector := aws.ToString(parameter.Selector); selector != "" {
+		if !strings.HasPrefix(selector, ":") {
+			name += ":"
+		}
+		name += selector
+	}
+	return name
+}
+
+// PrefetchSSMParameters looks up the SSM parameters in batches, so that the lookups of the same parameters that
+// follow, such as those of images with an "ssm:" name, are answered from the cache. The names of all the invalid
+// parameters are reported in one error.
+func PrefetchSSMParameters(ctx context.Context, cloud AWSCloud, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	var parameters *ssmParameters
+	if c, ok := cloud.(*awsCloudImplementation); ok {
+		parameters = c.ssmParameters
+	}
+	_, err := parameters.resolve(ctx, cloud.SSM(), names)
+	return err
+}
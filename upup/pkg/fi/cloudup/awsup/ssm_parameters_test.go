@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+// fakeSSM answers GetParameters with the values of the parameters it holds, and records the names of each call.
+type fakeSSM struct {
+	awsinterfaces.SSMAPI
+	values map[string]string
+	calls  [][]string
+}
+
+func (f *fakeSSM) GetParameters(ctx context.Context, input *ssm.GetParametersInput, optFns ...func(*ssm.Options)) (*ssm.GetParametersOutput, error) {
+	f.calls = append(f.calls, input.Names)
+
+	output := &ssm.GetParametersOutput{}
+	for _, name := range input.Names {
+		if value, found := f.values[name]; found {
+			output.Parameters = append(output.Parameters, ssmtypes.Parameter{Name: aws.String(name), Value: aws.String(value)})
+		} else {
+			output.InvalidParameters = append(output.InvalidParameters, name)
+		}
+	}
+	return output, nil
+}
+
+func TestSSMParametersBatching(t *testing.T) {
+	ctx := context.TODO()
+
+	client := &fakeSSM{values: make(map[string]string)}
+	var names []string
+	for i := 0; i < 23; i++ {
+		name := fmt.Sprintf("/images/image-%02d", i)
+		client.values[name] = fmt.Sprintf("ami-%02d", i)
+		names = append(names, name)
+	}
+
+	parameters := newSSMParameters()
+	values, err := parameters.resolve(ctx, client, append(names, names[0]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(values) != 23 || values["/images/image-07"] != "ami-07" {
+		t.Errorf("unexpected values %v", values)
+	}
+	var sizes []int
+	for _, call := range client.calls {
+		sizes = append(sizes, len(call))
+	}
+	if expected := []int{10, 10, 3}; !reflect.DeepEqual(sizes, expected) {
+		t.Errorf("expected calls of %v parameters, got %v", expected, sizes)
+	}
+
+	// Cached values are not looked up again
+	client.calls = nil
+	if _, err := parameters.resolve(ctx, client, names[:5]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.calls) != 0 {
+		t.Errorf("expected no calls for cached parameters, got %v", client.calls)
+	}
+}
+
+func TestSSMParametersInvalid(t *testing.T) {
+	ctx := context.TODO()
+
+	client := &fakeSSM{values: make(map[string]string)}
+	var names []string
+	for i := 0; i < 12; i++ {
+		name := fmt.Sprintf("/images/image-%02d", i)
+		if i != 3 && i != 11 {
+			client.values[name] = fmt.Sprintf("ami-%02d", i)
+		}
+		names = append(names, name)
+	}
+
+	_, err := newSSMParameters().resolve(ctx, client, names)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if expected := "invalid SSM parameters: /images/image-03, /images/image-11"; err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"golang.org/x/time/rate"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+const (
+	// tagReconcilerDescribeBatchSize is the number of resources whose tags are described in one request,
+	// which is the maximum number of values of an EC2 filter.
+	tagReconcilerDescribeBatchSize = 200
+	// tagReconcilerCreateBatchSize is the number of resources tagged in one CreateTags request.
+	tagReconcilerCreateBatchSize = 500
+	// tagReconcilerRequestsPerSecond is the sustained rate of tagging requests, below the refill rate
+	// of the CreateTags token bucket of the EC2 API.
+	tagReconcilerRequestsPerSecond = 5
+	tagReconcilerRequestsBurst     = 10
+)
+
+// TagReconciler collects the tags that tasks expect on EC2 resources, and adds the missing ones
+// at the end of the apply, with a few CreateTags requests for all the resources that need the same tags.
+// Clusters with thousands of resources would otherwise get throttled by a request per resource.
+//
+// Reconcile can be called again after an error: the resources that were tagged are forgotten,
+// so it resumes with the remaining ones.
+type TagReconciler struct {
+	cloud   AWSCloud
+	limiter *rate.Limiter
+
+	// retrier retries the throttled requests, and those for resources that have not propagated yet.
+	retrier *fi.MutationRetrier
+
+	mutex    sync.Mutex
+	expected map[string]map[string]string
+}
+
+// NewTagReconciler builds a TagReconciler for the EC2 resources of the cloud, whose requests are retried by the retrier.
+func NewTagReconciler(cloud AWSCloud, retrier *fi.MutationRetrier) *TagReconciler {
+	if retrier == nil {
+		retrier = tagsRetrier
+	}
+	return &TagReconciler{
+		cloud:    cloud,
+		limiter:  rate.NewLimiter(tagReconcilerRequestsPerSecond, tagReconcilerRequestsBurst),
+		retrier:  retrier,
+		expected: make(map[string]map[string]string),
+	}
+}
+
+// AddTags records that the resource is expected to have the tags; existing tags that are not listed are kept.
+func (r *TagReconciler) AddTags(id string, tags map[string]string) {
+	if len(tags) == 0 {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	expected := r.expected[id]
+	if expected == nil {
+		expected = make(map[string]string)
+		r.expected[id] = expected
+	}
+	for k, v := range tags {
+		expected[k] = v
+	}
+}
+
+// Pending returns the number of resources whose tags have not been reconciled yet.
+func (r *TagReconciler) Pending() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.expected)
+}
+
+// Reconcile adds the missing tags to the resources.
+func (r *TagReconciler) Reconcile(ctx context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.expected) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(r.expected))
+	for id := range r.expected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// Group the resources by the tags they miss, so that each group is tagged with the same request
+	type tagGroup struct {
+		tags map[string]string
+		ids  []string
+	}
+	groups := make(map[string]*tagGroup)
+	for start := 0; start < len(ids); start += tagReconcilerDescribeBatchSize {
+		batch := ids[start:min(start+tagReconcilerDescribeBatchSize, len(ids))]
+		actual, err := r.describeTags(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range batch {
+			missing := make(map[string]string)
+			for k, v := range r.expected[id] {
+				if actualValue, found := actual[id][k]; !found || actualValue != v {
+					missing[k] = v
+				}
+			}
+			if len(missing) == 0 {
+				delete(r.expected, id)
+				continue
+			}
+
+			key := tagGroupKey(missing)
+			group := groups[key]
+			if group == nil {
+				group = &tagGroup{tags: missing}
+				groups[key] = group
+			}
+			group.ids = append(group.ids, id)
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		group := groups[key]
+		for start := 0; start < len(group.ids); start += tagReconcilerCreateBatchSize {
+			batch := group.ids[start:min(start+tagReconcilerCreateBatchSize, len(group.ids))]
+			klog.V(4).Infof("adding tags to %d resources: %v", len(batch), group.tags)
+			if err := r.createTags(ctx, batch, group.tags); err != nil {
+				return fmt.Errorf("error adding tags to resources %v: %w", batch, err)
+			}
+			for _, id := range batch {
+				delete(r.expected, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// describeTags returns the tags of the resources, by resource ID.
+func (r *TagReconciler) describeTags(ctx context.Context, ids []string) (map[string]map[string]string, error) {
+	tags := make(map[string]map[string]string)
+
+	request := &ec2.DescribeTagsInput{
+		Filters: []ec2types.Filter{
+			{Name: aws.String("resource-id"), Values: ids},
+		},
+	}
+	for {
+		var response *ec2.DescribeTagsOutput
+		err := r.read(ctx, "describing tags", func() error {
+			var err error
+			response, err = r.cloud.EC2().DescribeTags(ctx, request)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing tags: %w", err)
+		}
+
+		for _, tag := range response.Tags {
+			id := aws.ToString(tag.ResourceId)
+			if tags[id] == nil {
+				tags[id] = make(map[string]string)
+			}
+			tags[id][aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+		}
+
+		if aws.ToString(response.NextToken) == "" {
+			return tags, nil
+		}
+		request.NextToken = response.NextToken
+	}
+}
+
+func (r *TagReconciler) createTags(ctx context.Context, ids []string, tags map[string]string) error {
+	ec2Tags := make([]ec2types.Tag, 0, len(tags))
+	for k, v := range tags {
+		ec2Tags = append(ec2Tags, ec2types.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+
+	request := &ec2.CreateTagsInput{
+		Resources: ids,
+		Tags:      ec2Tags,
+	}
+	return r.mutate(ctx, "creating tags", func() error {
+		_, err := r.cloud.EC2().CreateTags(ctx, request)
+		return err
+	})
+}
+
+// read waits for the rate limiter before each attempt of the request, and retries it with the policies of the retrier,
+// without counting its failures in the breaker of the mutations.
+func (r *TagReconciler) read(ctx context.Context, description string, request func() error) error {
+	return r.retrier.Retry(ctx, description, r.limited(ctx, request))
+}
+
+// mutate waits for the rate limiter before each attempt of the mutation, and retries it with the retrier.
+func (r *TagReconciler) mutate(ctx context.Context, description string, mutation func() error) error {
+	return r.retrier.Do(ctx, description, r.limited(ctx, mutation))
+}
+
+// limited returns the request, waiting for the rate limiter first.
+func (r *TagReconciler) limited(ctx context.Context, request func() error) func() error {
+	return func() error {
+		if err := r.limiter.Wait(ctx); err != nil {
+			return err
+		}
+		return request()
+	}
+}
+
+// tagGroupKey returns a key identifying the set of tags.
+func tagGroupKey(tags map[string]string) string {
+	var b strings.Builder
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%q=%q,", k, tags[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+)
+
+// failingTagsEC2 fails the CreateTags requests with the queued errors, before passing them to MockEC2.
+type failingTagsEC2 struct {
+	*mockec2.MockEC2
+	errors []error
+	calls  int
+}
+
+func (m *failingTagsEC2) CreateTags(ctx context.Context, request *ec2.CreateTagsInput, optFns ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	m.calls++
+	if len(m.errors) != 0 {
+		err := m.errors[0]
+		m.errors = m.errors[1:]
+		return nil, err
+	}
+	return m.MockEC2.CreateTags(ctx, request, optFns...)
+}
+
+func TestTagReconciler(t *testing.T) {
+	ctx := context.TODO()
+	clusterTags := map[string]string{"KubernetesCluster": "example.com", "Name": "example"}
+
+	grid := []struct {
+		name          string
+		errors        []error
+		expectedCalls []int
+	}{
+		{
+			name:          "batched",
+			expectedCalls: []int{1},
+		},
+		{
+			name:          "throttled",
+			errors:        []error{&smithy.GenericAPIError{Code: "RequestLimitExceeded"}},
+			expectedCalls: []int{2},
+		},
+		{
+			name:          "resumed after error",
+			errors:        []error{&smithy.GenericAPIError{Code: "UnauthorizedOperation"}},
+			expectedCalls: []int{1, 2},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cloud := BuildMockAWSCloud("us-test-1", "a")
+			c := &failingTagsEC2{MockEC2: &mockec2.MockEC2{}, errors: g.errors}
+			cloud.MockEC2 = c.MockEC2
+			if err := cloud.CreateTags("vpc-3", clusterTags); err != nil {
+				t.Fatalf("error creating tags: %v", err)
+			}
+			cloud.MockEC2 = c
+
+			r := NewTagReconciler(cloud, nil)
+			r.retrier = newTestMutationRetrier()
+			for _, id := range []string{"vpc-1", "vpc-2", "vpc-3"} {
+				r.AddTags(id, clusterTags)
+			}
+
+			for i, expectedCalls := range g.expectedCalls {
+				err := r.Reconcile(ctx)
+				if i < len(g.expectedCalls)-1 {
+					if err == nil {
+						t.Fatalf("expected error reconciling tags")
+					}
+					if r.Pending() != 2 {
+						t.Errorf("expected 2 pending resources after error, got %d", r.Pending())
+					}
+				} else if err != nil {
+					t.Fatalf("error reconciling tags: %v", err)
+				}
+				if c.calls != expectedCalls {
+					t.Errorf("expected %d CreateTags calls, got %d", expectedCalls, c.calls)
+				}
+			}
+
+			if r.Pending() != 0 {
+				t.Errorf("expected no pending resources, got %d", r.Pending())
+			}
+			for _, id := range []string{"vpc-1", "vpc-2", "vpc-3"} {
+				actual, err := cloud.GetTags(id)
+				if err != nil {
+					t.Fatalf("error getting tags: %v", err)
+				}
+				if !reflect.DeepEqual(actual, clusterTags) {
+					t.Errorf("unexpected tags of %s: %v", id, actual)
+				}
+			}
+		})
+	}
+}
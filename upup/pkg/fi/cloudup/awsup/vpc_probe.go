@@ -0,0 +1,324 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	awsprovider "k8s.io/cloud-provider-aws/pkg/providers/v1"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// VPCProbe describes the topologies of kOps clusters that an existing VPC can host
+type VPCProbe struct {
+	VPCID              string `json:"vpcID"`
+	CIDR               string `json:"cidr"`
+	EnableDNSHostnames bool   `json:"enableDNSHostnames"`
+	EnableDNSSupport   bool   `json:"enableDNSSupport"`
+	// InternetGatewayID is the internet gateway attached to the VPC, if any
+	InternetGatewayID string `json:"internetGatewayID,omitempty"`
+
+	Subnets    []*SubnetProbe   `json:"subnets"`
+	Topologies []*TopologyProbe `json:"topologies"`
+
+	// Problems are the changes to make to the VPC before it can host any cluster
+	Problems []string `json:"problems,omitempty"`
+}
+
+// SubnetProbe describes a subnet of an existing VPC
+type SubnetProbe struct {
+	ID           string `json:"id"`
+	Zone         string `json:"zone"`
+	CIDR         string `json:"cidr"`
+	AvailableIPs int32  `json:"availableIPs"`
+	RouteTableID string `json:"routeTableID,omitempty"`
+	// DefaultRoute is the target of the default route of the subnet, if any
+	DefaultRoute string `json:"defaultRoute,omitempty"`
+	// Public is true if the default route of the subnet is an internet gateway
+	Public bool `json:"public"`
+	// MissingTags are the tags needed to place the load balancers of services in the subnet.
+	// kOps adds them, unless the subnet tags are disabled.
+	MissingTags map[string]string `json:"missingTags,omitempty"`
+}
+
+// TopologyProbe describes whether an existing VPC can host clusters of a topology
+type TopologyProbe struct {
+	Topology  string `json:"topology"`
+	Supported bool   `json:"supported"`
+	// Zones are the zones where the cluster can run
+	Zones []string `json:"zones,omitempty"`
+	// Subnets are the subnets of the cluster spec that use the existing subnets
+	Subnets []kops.ClusterSubnetSpec `json:"subnets,omitempty"`
+	// Problems are the reasons the topology is not supported
+	Problems []string `json:"problems,omitempty"`
+}
+
+// ProbeVPC inspects an existing VPC, and reports the topologies that it supports
+// and the subnets of the cluster spec for each of them.
+func ProbeVPC(ctx context.Context, cloud AWSCloud, vpcID string) (*VPCProbe, error) {
+	vpc, err := cloud.DescribeVPC(vpcID)
+	if err != nil {
+		return nil, err
+	}
+	if vpc == nil {
+		return nil, fmt.Errorf("VPC %q not found", vpcID)
+	}
+
+	probe := &VPCProbe{
+		VPCID: vpcID,
+		CIDR:  aws.ToString(vpc.CidrBlock),
+	}
+
+	{
+		response, err := cloud.EC2().DescribeVpcAttribute(ctx, &ec2.DescribeVpcAttributeInput{
+			VpcId:     aws.String(vpcID),
+			Attribute: ec2types.VpcAttributeNameEnableDnsHostnames,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error describing attributes of VPC %q: %w", vpcID, err)
+		}
+		probe.EnableDNSHostnames = response.EnableDnsHostnames != nil && aws.ToBool(response.EnableDnsHostnames.Value)
+	}
+	{
+		response, err := cloud.EC2().DescribeVpcAttribute(ctx, &ec2.DescribeVpcAttributeInput{
+			VpcId:     aws.String(vpcID),
+			Attribute: ec2types.VpcAttributeNameEnableDnsSupport,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error describing attributes of VPC %q: %w", vpcID, err)
+		}
+		probe.EnableDNSSupport = response.EnableDnsSupport != nil && aws.ToBool(response.EnableDnsSupport.Value)
+	}
+	if !probe.EnableDNSHostnames {
+		probe.Problems = append(probe.Problems, fmt.Sprintf("enableDnsHostnames must be enabled on VPC %q", vpcID))
+	}
+	if !probe.EnableDNSSupport {
+		probe.Problems = append(probe.Problems, fmt.Sprintf("enableDnsSupport must be enabled on VPC %q", vpcID))
+	}
+
+	{
+		response, err := cloud.EC2().DescribeInternetGateways(ctx, &ec2.DescribeInternetGatewaysInput{
+			Filters: []ec2types.Filter{NewEC2Filter("attachment.vpc-id", vpcID)},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error listing internet gateways of VPC %q: %w", vpcID, err)
+		}
+		for _, igw := range response.InternetGateways {
+			probe.InternetGatewayID = aws.ToString(igw.InternetGatewayId)
+		}
+	}
+
+	var routeTables []ec2types.RouteTable
+	{
+		paginator := ec2.NewDescribeRouteTablesPaginator(cloud.EC2(), &ec2.DescribeRouteTablesInput{
+			Filters: []ec2types.Filter{NewEC2Filter("vpc-id", vpcID)},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error listing route tables of VPC %q: %w", vpcID, err)
+			}
+			routeTables = append(routeTables, page.RouteTables...)
+		}
+	}
+
+	{
+		paginator := ec2.NewDescribeSubnetsPaginator(cloud.EC2(), &ec2.DescribeSubnetsInput{
+			Filters: []ec2types.Filter{NewEC2Filter("vpc-id", vpcID)},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error listing subnets of VPC %q: %w", vpcID, err)
+			}
+			for _, subnet := range page.Subnets {
+				probe.Subnets = append(probe.Subnets, probeSubnet(subnet, routeTables))
+			}
+		}
+	}
+	sort.Slice(probe.Subnets, func(i, j int) bool {
+		if probe.Subnets[i].Zone != probe.Subnets[j].Zone {
+			return probe.Subnets[i].Zone < probe.Subnets[j].Zone
+		}
+		return probe.Subnets[i].ID < probe.Subnets[j].ID
+	})
+
+	probe.Topologies = []*TopologyProbe{
+		probePublicTopology(probe),
+		probePrivateTopology(probe),
+	}
+
+	return probe, nil
+}
+
+func probeSubnet(subnet ec2types.Subnet, routeTables []ec2types.RouteTable) *SubnetProbe {
+	p := &SubnetProbe{
+		ID:           aws.ToString(subnet.SubnetId),
+		Zone:         aws.ToString(subnet.AvailabilityZone),
+		CIDR:         aws.ToString(subnet.CidrBlock),
+		AvailableIPs: aws.ToInt32(subnet.AvailableIpAddressCount),
+	}
+
+	// Subnets without an explicit association use the main route table of the VPC
+	var routeTable *ec2types.RouteTable
+	for i := range routeTables {
+		for _, association := range routeTables[i].Associations {
+			if aws.ToString(association.SubnetId) == p.ID {
+				routeTable = &routeTables[i]
+			} else if routeTable == nil && aws.ToBool(association.Main) {
+				routeTable = &routeTables[i]
+			}
+		}
+	}
+	if routeTable != nil {
+		p.RouteTableID = aws.ToString(routeTable.RouteTableId)
+		for _, route := range routeTable.Routes {
+			if aws.ToString(route.DestinationCidrBlock) != "0.0.0.0/0" {
+				continue
+			}
+			switch {
+			case strings.HasPrefix(aws.ToString(route.GatewayId), "igw-"):
+				p.DefaultRoute = aws.ToString(route.GatewayId)
+				p.Public = true
+			case route.NatGatewayId != nil:
+				p.DefaultRoute = aws.ToString(route.NatGatewayId)
+			case route.InstanceId != nil:
+				p.DefaultRoute = aws.ToString(route.InstanceId)
+			case route.TransitGatewayId != nil:
+				p.DefaultRoute = aws.ToString(route.TransitGatewayId)
+			default:
+				p.DefaultRoute = aws.ToString(route.GatewayId)
+			}
+		}
+	}
+
+	tagName := awsprovider.TagNameSubnetInternalELB
+	if p.Public {
+		tagName = awsprovider.TagNameSubnetPublicELB
+	}
+	hasTag := false
+	for _, tag := range subnet.Tags {
+		if aws.ToString(tag.Key) == tagName {
+			hasTag = true
+		}
+	}
+	if !hasTag {
+		p.MissingTags = map[string]string{tagName: "1"}
+	}
+
+	return p
+}
+
+// subnetsByZone returns the subnet with the most available addresses in each zone, among the public or private subnets.
+func subnetsByZone(probe *VPCProbe, public bool) map[string]*SubnetProbe {
+	byZone := make(map[string]*SubnetProbe)
+	for _, subnet := range probe.Subnets {
+		if subnet.Public != public {
+			continue
+		}
+		if existing := byZone[subnet.Zone]; existing == nil || subnet.AvailableIPs > existing.AvailableIPs {
+			byZone[subnet.Zone] = subnet
+		}
+	}
+	return byZone
+}
+
+func probePublicTopology(probe *VPCProbe) *TopologyProbe {
+	t := &TopologyProbe{
+		Topology: kops.TopologyPublic,
+		Problems: append([]string{}, probe.Problems...),
+	}
+
+	public := subnetsByZone(probe, true)
+	for zone := range public {
+		t.Zones = append(t.Zones, zone)
+	}
+	sort.Strings(t.Zones)
+
+	for _, zone := range t.Zones {
+		subnet := public[zone]
+		t.Subnets = append(t.Subnets, kops.ClusterSubnetSpec{
+			Name: zone,
+			Zone: zone,
+			CIDR: subnet.CIDR,
+			ID:   subnet.ID,
+			Type: kops.SubnetTypePublic,
+		})
+	}
+
+	if len(t.Zones) == 0 {
+		t.Problems = append(t.Problems, "no subnet has a default route to an internet gateway")
+	}
+	t.Supported = len(t.Problems) == 0
+	return t
+}
+
+func probePrivateTopology(probe *VPCProbe) *TopologyProbe {
+	t := &TopologyProbe{
+		Topology: kops.TopologyPrivate,
+		Problems: append([]string{}, probe.Problems...),
+	}
+
+	public := subnetsByZone(probe, true)
+	private := subnetsByZone(probe, false)
+	for zone := range private {
+		if public[zone] != nil {
+			t.Zones = append(t.Zones, zone)
+		}
+	}
+	sort.Strings(t.Zones)
+
+	for _, zone := range t.Zones {
+		subnet := private[zone]
+		egress := subnet.DefaultRoute
+		if !strings.HasPrefix(egress, kops.EgressNatGateway+"-") &&
+			!strings.HasPrefix(egress, kops.EgressNatInstance+"-") &&
+			!strings.HasPrefix(egress, kops.EgressTransitGateway+"-") {
+			egress = kops.EgressExternal
+		}
+		t.Subnets = append(t.Subnets, kops.ClusterSubnetSpec{
+			Name:   zone,
+			Zone:   zone,
+			CIDR:   subnet.CIDR,
+			ID:     subnet.ID,
+			Egress: egress,
+			Type:   kops.SubnetTypePrivate,
+		})
+	}
+	for _, zone := range t.Zones {
+		subnet := public[zone]
+		t.Subnets = append(t.Subnets, kops.ClusterSubnetSpec{
+			Name: "utility-" + zone,
+			Zone: zone,
+			CIDR: subnet.CIDR,
+			ID:   subnet.ID,
+			Type: kops.SubnetTypeUtility,
+		})
+	}
+
+	if len(t.Zones) == 0 {
+		t.Problems = append(t.Problems, "no zone has both a private subnet and a subnet with a default route to an internet gateway")
+	}
+	t.Supported = len(t.Problems) == 0
+	return t
+}
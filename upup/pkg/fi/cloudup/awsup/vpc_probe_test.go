@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestProbeVPC(t *testing.T) {
+	ctx := context.TODO()
+
+	cloud := BuildMockAWSCloud("us-test-1", "ab")
+	c := &mockec2.MockEC2{}
+	cloud.MockEC2 = c
+
+	vpc, err := c.CreateVpcWithId(&ec2.CreateVpcInput{CidrBlock: aws.String("10.0.0.0/16")}, "vpc-1")
+	if err != nil {
+		t.Fatalf("error creating VPC: %v", err)
+	}
+	vpcID := aws.ToString(vpc.Vpc.VpcId)
+	if _, err := c.ModifyVpcAttribute(ctx, &ec2.ModifyVpcAttributeInput{VpcId: vpc.Vpc.VpcId, EnableDnsHostnames: &ec2types.AttributeBooleanValue{Value: aws.Bool(false)}}); err != nil {
+		t.Fatalf("error modifying VPC: %v", err)
+	}
+
+	igw, err := c.CreateInternetGateway(ctx, &ec2.CreateInternetGatewayInput{})
+	if err != nil {
+		t.Fatalf("error creating internet gateway: %v", err)
+	}
+	if _, err := c.AttachInternetGateway(ctx, &ec2.AttachInternetGatewayInput{InternetGatewayId: igw.InternetGateway.InternetGatewayId, VpcId: vpc.Vpc.VpcId}); err != nil {
+		t.Fatalf("error attaching internet gateway: %v", err)
+	}
+	igwID := aws.ToString(igw.InternetGateway.InternetGatewayId)
+
+	for id, zone := range map[string]string{
+		"subnet-public-a":  "us-test-1a",
+		"subnet-private-a": "us-test-1a",
+		"subnet-private-b": "us-test-1b",
+	} {
+		if _, err := c.CreateSubnetWithId(&ec2.CreateSubnetInput{VpcId: vpc.Vpc.VpcId, AvailabilityZone: aws.String(zone), CidrBlock: aws.String("10.0.0.0/24")}, id); err != nil {
+			t.Fatalf("error creating subnet: %v", err)
+		}
+	}
+	if _, err := c.CreateTags(ctx, &ec2.CreateTagsInput{Resources: []string{"subnet-public-a"}, Tags: []ec2types.Tag{{Key: aws.String("kubernetes.io/role/elb"), Value: aws.String("1")}}}); err != nil {
+		t.Fatalf("error tagging subnet: %v", err)
+	}
+
+	// The private subnets use the main route table
+	c.AddRouteTable(&ec2types.RouteTable{
+		RouteTableId: aws.String("rtb-main"),
+		VpcId:        vpc.Vpc.VpcId,
+		Associations: []ec2types.RouteTableAssociation{{Main: aws.Bool(true)}},
+		Routes:       []ec2types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")}},
+	})
+	c.AddRouteTable(&ec2types.RouteTable{
+		RouteTableId: aws.String("rtb-public"),
+		VpcId:        vpc.Vpc.VpcId,
+		Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String("subnet-public-a")}},
+		Routes:       []ec2types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String(igwID)}},
+	})
+
+	probe, err := ProbeVPC(ctx, cloud, vpcID)
+	if err != nil {
+		t.Fatalf("error probing VPC: %v", err)
+	}
+
+	if probe.InternetGatewayID != igwID {
+		t.Errorf("expected internet gateway %q, got %q", igwID, probe.InternetGatewayID)
+	}
+	expectedProblems := []string{`enableDnsHostnames must be enabled on VPC "vpc-1"`}
+	if !reflect.DeepEqual(probe.Problems, expectedProblems) {
+		t.Errorf("unexpected problems: actual=%v, expected=%v", probe.Problems, expectedProblems)
+	}
+
+	expectedSubnets := []*SubnetProbe{
+		{ID: "subnet-private-a", Zone: "us-test-1a", CIDR: "10.0.0.0/24", RouteTableID: "rtb-main", DefaultRoute: "nat-1", MissingTags: map[string]string{"kubernetes.io/role/internal-elb": "1"}},
+		{ID: "subnet-public-a", Zone: "us-test-1a", CIDR: "10.0.0.0/24", RouteTableID: "rtb-public", DefaultRoute: igwID, Public: true},
+		{ID: "subnet-private-b", Zone: "us-test-1b", CIDR: "10.0.0.0/24", RouteTableID: "rtb-main", DefaultRoute: "nat-1", MissingTags: map[string]string{"kubernetes.io/role/internal-elb": "1"}},
+	}
+	if !reflect.DeepEqual(probe.Subnets, expectedSubnets) {
+		t.Errorf("unexpected subnets: actual=%+v, expected=%+v", probe.Subnets, expectedSubnets)
+	}
+
+	expectedTopologies := []*TopologyProbe{
+		{
+			Topology: kops.TopologyPublic,
+			Zones:    []string{"us-test-1a"},
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "us-test-1a", Zone: "us-test-1a", CIDR: "10.0.0.0/24", ID: "subnet-public-a", Type: kops.SubnetTypePublic},
+			},
+			Problems: expectedProblems,
+		},
+		{
+			Topology: kops.TopologyPrivate,
+			Zones:    []string{"us-test-1a"},
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "us-test-1a", Zone: "us-test-1a", CIDR: "10.0.0.0/24", ID: "subnet-private-a", Egress: "nat-1", Type: kops.SubnetTypePrivate},
+				{Name: "utility-us-test-1a", Zone: "us-test-1a", CIDR: "10.0.0.0/24", ID: "subnet-public-a", Type: kops.SubnetTypeUtility},
+			},
+			Problems: expectedProblems,
+		},
+	}
+	if !reflect.DeepEqual(probe.Topologies, expectedTopologies) {
+		for i := range probe.Topologies {
+			t.Errorf("unexpected topology: actual=%+v, expected=%+v", probe.Topologies[i], expectedTopologies[i])
+		}
+	}
+}
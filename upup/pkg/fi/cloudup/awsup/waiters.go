@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// ResourceWaitTimeouts are the maximum durations that the tasks wait for the resources they created
+// to be usable by the API. Zero disables the wait.
+type ResourceWaitTimeouts struct {
+	// IAMInstanceProfile is how long the requests rejected because their IAM instance profile
+	// has not propagated yet are retried.
+	IAMInstanceProfile time.Duration
+	// SecurityGroup is how long the tasks wait for the security groups they created to be visible.
+	SecurityGroup time.Duration
+	// Interval is the interval between the checks, or between the retries of the rejected requests.
+	Interval time.Duration
+}
+
+// DefaultResourceWaitTimeouts returns the default timeouts of the waits for the created resources.
+// The IAM API is eventually consistent, so a fresh instance profile can be rejected by EC2 for a while.
+func DefaultResourceWaitTimeouts() ResourceWaitTimeouts {
+	return ResourceWaitTimeouts{
+		IAMInstanceProfile: 2 * time.Minute,
+		SecurityGroup:      time.Minute,
+		Interval:           2 * time.Second,
+	}
+}
+
+// isInvalidIAMInstanceProfileError returns true if EC2 or Auto Scaling rejected the request
+// because its IAM instance profile has not propagated yet.
+func isInvalidIAMInstanceProfileError(err error) bool {
+	switch AWSErrorCode(err) {
+	case "InvalidParameterValue", "ValidationError":
+		return strings.Contains(AWSErrorMessage(err), "Invalid IAM Instance Profile")
+	default:
+		return false
+	}
+}
+
+// RetryInvalidIAMInstanceProfile runs the request that uses an IAM instance profile, retrying it while it is rejected
+// because the instance profile has not propagated yet, up to the IAMInstanceProfile timeout of the target.
+// Throttled requests are retried as well, and the failures are counted by the breaker of the apply.
+func (t *AWSAPITarget) RetryInvalidIAMInstanceProfile(ctx context.Context, description string, request func() error) error {
+	policies := map[fi.MutationErrorClass]fi.MutationRetryPolicy{
+		fi.MutationErrorThrottled: MutationRetryPolicies[fi.MutationErrorThrottled],
+	}
+	if t.ResourceWaits.IAMInstanceProfile > 0 && t.ResourceWaits.Interval > 0 {
+		policies[fi.MutationErrorEventualConsistency] = fi.MutationRetryPolicy{
+			MaxAttempts: int(t.ResourceWaits.IAMInstanceProfile/t.ResourceWaits.Interval) + 1,
+			Interval:    t.ResourceWaits.Interval,
+		}
+	}
+	retrier := &fi.MutationRetrier{
+		Classify: func(err error) fi.MutationErrorClass {
+			switch {
+			case throttlingErrors[AWSErrorCode(err)]:
+				return fi.MutationErrorThrottled
+			case isInvalidIAMInstanceProfileError(err):
+				return fi.MutationErrorEventualConsistency
+			default:
+				return fi.MutationErrorInvalid
+			}
+		},
+		Policies: policies,
+		Breaker:  t.mutationRetrier().Breaker,
+	}
+	return retrier.Do(ctx, description, request)
+}
+
+// WaitForSecurityGroup waits until the security group is visible, up to the SecurityGroup timeout of the target.
+func (t *AWSAPITarget) WaitForSecurityGroup(ctx context.Context, id string) error {
+	timeout := t.ResourceWaits.SecurityGroup
+	if timeout <= 0 || t.ResourceWaits.Interval <= 0 {
+		return nil
+	}
+
+	description := fmt.Sprintf("security group %q", id)
+	klog.V(2).Infof("Waiting for %s to be visible", description)
+	err := wait.PollUntilContextTimeout(ctx, t.ResourceWaits.Interval, timeout, true, func(ctx context.Context) (bool, error) {
+		response, err := t.Cloud.EC2().DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{GroupIds: []string{id}})
+		if AWSErrorCode(err) == "InvalidGroup.NotFound" {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return len(response.SecurityGroups) != 0, nil
+	})
+	if wait.Interrupted(err) {
+		return fmt.Errorf("timed out after %v waiting for %s to be visible", timeout, description)
+	}
+	if err != nil {
+		return fmt.Errorf("error waiting for %s: %w", description, err)
+	}
+	return nil
+}
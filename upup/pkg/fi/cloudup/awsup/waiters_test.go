@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"k8s.io/kops/util/pkg/awsinterfaces"
+)
+
+// fakeSecurityGroupsEC2 answers DescribeSecurityGroups with InvalidGroup.NotFound for its first calls.
+type fakeSecurityGroupsEC2 struct {
+	awsinterfaces.EC2API
+	missingCalls int
+	calls        int
+}
+
+func (f *fakeSecurityGroupsEC2) DescribeSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput, optFns ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	f.calls++
+	if f.calls <= f.missingCalls {
+		return nil, &smithy.GenericAPIError{Code: "InvalidGroup.NotFound"}
+	}
+	return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []ec2types.SecurityGroup{{GroupId: &input.GroupIds[0]}}}, nil
+}
+
+func newTestWaitTarget(ec2Client awsinterfaces.EC2API, waits ResourceWaitTimeouts) *AWSAPITarget {
+	cloud := BuildMockAWSCloud("us-east-1", "abc")
+	cloud.MockEC2 = ec2Client
+	target := NewAWSAPITarget(cloud, NewMutationRetrier())
+	target.ResourceWaits = waits
+	return target
+}
+
+func TestRetryInvalidIAMInstanceProfile(t *testing.T) {
+	ctx := context.TODO()
+	target := newTestWaitTarget(nil, ResourceWaitTimeouts{IAMInstanceProfile: time.Minute, Interval: time.Millisecond})
+
+	invalidProfile := &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: "Value (nodes) for parameter iamInstanceProfile.name is invalid. Invalid IAM Instance Profile name"}
+	calls := 0
+	err := target.RetryInvalidIAMInstanceProfile(ctx, "creating Instance", func() error {
+		calls++
+		if calls <= 3 {
+			return invalidProfile
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected the request to succeed after 4 calls, got %d", calls)
+	}
+
+	// Other errors are not retried
+	calls = 0
+	invalid := &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: "Invalid value for instance type"}
+	err = target.RetryInvalidIAMInstanceProfile(ctx, "creating Instance", func() error {
+		calls++
+		return invalid
+	})
+	if !errors.Is(err, invalid) {
+		t.Errorf("expected the error of the request, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single call, got %d", calls)
+	}
+
+	// A zero timeout does not retry
+	target = newTestWaitTarget(nil, ResourceWaitTimeouts{Interval: time.Millisecond})
+	calls = 0
+	err = target.RetryInvalidIAMInstanceProfile(ctx, "creating Instance", func() error {
+		calls++
+		return invalidProfile
+	})
+	if !errors.Is(err, invalidProfile) {
+		t.Errorf("expected the error of the request, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single call, got %d", calls)
+	}
+}
+
+func TestWaitForSecurityGroup(t *testing.T) {
+	ctx := context.TODO()
+
+	client := &fakeSecurityGroupsEC2{missingCalls: 2}
+	target := newTestWaitTarget(client, ResourceWaitTimeouts{SecurityGroup: time.Minute, Interval: time.Millisecond})
+	if err := target.WaitForSecurityGroup(ctx, "sg-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.calls != 3 {
+		t.Errorf("expected the wait to end when the security group is visible, after 3 calls, got %d", client.calls)
+	}
+
+	client = &fakeSecurityGroupsEC2{missingCalls: 1000000}
+	target = newTestWaitTarget(client, ResourceWaitTimeouts{SecurityGroup: 20 * time.Millisecond, Interval: time.Millisecond})
+	err := target.WaitForSecurityGroup(ctx, "sg-1")
+	if err == nil || !strings.Contains(err.Error(), `timed out after 20ms waiting for security group "sg-1" to be visible`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// A zero timeout does not wait
+	client = &fakeSecurityGroupsEC2{missingCalls: 1000000}
+	target = newTestWaitTarget(client, ResourceWaitTimeouts{Interval: time.Millisecond})
+	if err := target.WaitForSecurityGroup(ctx, "sg-1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if client.calls != 0 {
+		t.Errorf("expected no calls, got %d", client.calls)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	compute "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
@@ -56,6 +57,7 @@ type AzureCloud interface {
 	ApplicationSecurityGroup() ApplicationSecurityGroupsClient
 	VMScaleSet() VMScaleSetsClient
 	VMScaleSetVM() VMScaleSetVMsClient
+	VirtualMachine() VirtualMachinesClient
 	Disk() DisksClient
 	RoleAssignment() RoleAssignmentsClient
 	NetworkInterface() NetworkInterfacesClient
@@ -77,6 +79,7 @@ type azureCloudImplementation struct {
 	routeTablesClient               RouteTablesClient
 	vmscaleSetsClient               VMScaleSetsClient
 	vmscaleSetVMsClient             VMScaleSetVMsClient
+	virtualMachinesClient           VirtualMachinesClient
 	disksClient                     DisksClient
 	roleAssignmentsClient           RoleAssignmentsClient
 	networkInterfacesClient         NetworkInterfacesClient
@@ -126,6 +129,9 @@ func NewAzureCloud(subscriptionID, resourceGroupName, location string, tags map[
 	if azureCloudImpl.vmscaleSetVMsClient, err = newVMScaleSetVMsClientImpl(subscriptionID, cred); err != nil {
 		return nil, err
 	}
+	if azureCloudImpl.virtualMachinesClient, err = newVirtualMachinesClientImpl(subscriptionID, cred); err != nil {
+		return nil, err
+	}
 	if azureCloudImpl.disksClient, err = newDisksClientImpl(subscriptionID, cred); err != nil {
 		return nil, err
 	}
@@ -214,6 +220,9 @@ func (c *azureCloudImplementation) FindStorageAccountInfo(name string) (*armstor
 }
 
 func (c *azureCloudImplementation) DeleteInstance(i *cloudinstances.CloudInstance) error {
+	if vmss, ok := i.CloudInstanceGroup.Raw.(*compute.VirtualMachineScaleSet); ok && IsFlexible(vmss) {
+		return c.virtualMachinesClient.Delete(context.TODO(), c.resourceGroupName, i.ID)
+	}
 	vmssName := i.CloudInstanceGroup.HumanName
 	instanceID := strings.TrimPrefix(i.ID, vmssName+"_")
 	return c.vmscaleSetVMsClient.Delete(context.TODO(), c.resourceGroupName, vmssName, instanceID)
@@ -375,6 +384,10 @@ func (c *azureCloudImplementation) VMScaleSetVM() VMScaleSetVMsClient {
 	return c.vmscaleSetVMsClient
 }
 
+func (c *azureCloudImplementation) VirtualMachine() VirtualMachinesClient {
+	return c.virtualMachinesClient
+}
+
 func (c *azureCloudImplementation) Disk() DisksClient {
 	return c.disksClient
 }
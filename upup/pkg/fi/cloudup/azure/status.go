@@ -173,17 +173,31 @@ func (c *azureCloudImplementation) buildCloudInstanceGroup(
 	}
 
 	// Add members (VMs) to the Cloud Instance Group.
-	vms, err := c.vmscaleSetVMsClient.List(ctx, cluster.AzureResourceGroupName(), *vmss.Name)
-	if err != nil {
-		return nil, fmt.Errorf("error querying VM ScaleSet VMs: %s", err)
+	var vmNames []string
+	if IsFlexible(vmss) {
+		vms, err := c.virtualMachinesClient.List(ctx, cluster.AzureResourceGroupName(), *vmss.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error querying VM ScaleSet VMs: %s", err)
+		}
+		for _, vm := range vms {
+			vmNames = append(vmNames, *vm.Name)
+		}
+	} else {
+		vms, err := c.vmscaleSetVMsClient.List(ctx, cluster.AzureResourceGroupName(), *vmss.Name)
+		if err != nil {
+			return nil, fmt.Errorf("error querying VM ScaleSet VMs: %s", err)
+		}
+		for _, vm := range vms {
+			vmNames = append(vmNames, *vm.Name)
+		}
 	}
-	for _, vm := range vms {
+	for _, vmName := range vmNames {
 		// TODO(kenji): Ignore an instance that is being terminated.
 
 		// TODO(kenji): Set the status properly so that kops can
 		// tell whether a VM is up-to-date or not.
 		status := cloudinstances.CloudInstanceStatusUpToDate
-		_, err := cg.NewCloudInstance(*vm.Name, status, nodeMap[*vm.Name])
+		_, err := cg.NewCloudInstance(vmName, status, nodeMap[vmName])
 		if err != nil {
 			return nil, fmt.Errorf("error creating cloud instance group member: %s", err)
 		}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	compute "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
+)
+
+// VirtualMachinesClient is a client for managing the VMs of VM Scale Sets in Flexible orchestration mode.
+// The VMs of VM Scale Sets in Uniform orchestration mode are managed with VMScaleSetVMsClient.
+type VirtualMachinesClient interface {
+	// List returns the VMs of the VM Scale Set with the given ID.
+	List(ctx context.Context, resourceGroupName, vmssID string) ([]*compute.VirtualMachine, error)
+	Delete(ctx context.Context, resourceGroupName, vmName string) error
+}
+
+type virtualMachinesClientImpl struct {
+	c *compute.VirtualMachinesClient
+}
+
+var _ VirtualMachinesClient = &virtualMachinesClientImpl{}
+
+func (c *virtualMachinesClientImpl) List(ctx context.Context, resourceGroupName, vmssID string) ([]*compute.VirtualMachine, error) {
+	var l []*compute.VirtualMachine
+	opts := &compute.VirtualMachinesClientListOptions{
+		Filter: to.Ptr(fmt.Sprintf("'virtualMachineScaleSet/id' eq '%s'", vmssID)),
+	}
+	pager := c.c.NewListPager(resourceGroupName, opts)
+	for pager.More() {
+		resp, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing VMs: %w", err)
+		}
+		l = append(l, resp.Value...)
+	}
+	return l, nil
+}
+
+func (c *virtualMachinesClientImpl) Delete(ctx context.Context, resourceGroupName, vmName string) error {
+	future, err := c.c.BeginDelete(ctx, resourceGroupName, vmName, nil)
+	if err != nil {
+		return fmt.Errorf("deleting VM: %w", err)
+	}
+	if _, err = future.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("waiting for VM deletion completion: %w", err)
+	}
+	return nil
+}
+
+func newVirtualMachinesClientImpl(subscriptionID string, cred *azidentity.DefaultAzureCredential) (*virtualMachinesClientImpl, error) {
+	c, err := compute.NewVirtualMachinesClient(subscriptionID, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating VMs client: %w", err)
+	}
+	return &virtualMachinesClientImpl{
+		c: c,
+	}, nil
+}
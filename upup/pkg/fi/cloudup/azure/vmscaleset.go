@@ -35,6 +35,12 @@ type VMScaleSetsClient interface {
 	Delete(ctx context.Context, resourceGroupName, vmssName string) error
 }
 
+// IsFlexible returns true if the VM Scale Set uses the Flexible orchestration mode,
+// in which its VMs are standalone VMs named <VMSS name>_<suffix>.
+func IsFlexible(vmss *compute.VirtualMachineScaleSet) bool {
+	return vmss.Properties != nil && vmss.Properties.OrchestrationMode != nil && *vmss.Properties.OrchestrationMode == compute.OrchestrationModeFlexible
+}
+
 type vmScaleSetsClientImpl struct {
 	c *compute.VirtualMachineScaleSetsClient
 }
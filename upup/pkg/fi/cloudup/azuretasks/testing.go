@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	authz "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/authorization/armauthorization/v3"
@@ -51,6 +52,7 @@ type MockAzureCloud struct {
 	ApplicationSecurityGroupsClient *MockApplicationSecurityGroupsClient
 	VMScaleSetsClient               *MockVMScaleSetsClient
 	VMScaleSetVMsClient             *MockVMScaleSetVMsClient
+	VirtualMachinesClient           *MockVirtualMachinesClient
 	DisksClient                     *MockDisksClient
 	RoleAssignmentsClient           *MockRoleAssignmentsClient
 	NetworkInterfacesClient         *MockNetworkInterfacesClient
@@ -90,6 +92,9 @@ func NewMockAzureCloud(location string) *MockAzureCloud {
 		VMScaleSetVMsClient: &MockVMScaleSetVMsClient{
 			VMs: map[string]*compute.VirtualMachineScaleSetVM{},
 		},
+		VirtualMachinesClient: &MockVirtualMachinesClient{
+			VMs: map[string]*compute.VirtualMachine{},
+		},
 		DisksClient: &MockDisksClient{
 			Disks: map[string]*compute.Disk{},
 		},
@@ -233,6 +238,11 @@ func (c *MockAzureCloud) VMScaleSetVM() azure.VMScaleSetVMsClient {
 	return c.VMScaleSetVMsClient
 }
 
+// VirtualMachine returns the VM client.
+func (c *MockAzureCloud) VirtualMachine() azure.VirtualMachinesClient {
+	return c.VirtualMachinesClient
+}
+
 // Disk returns the disk client.
 func (c *MockAzureCloud) Disk() azure.DisksClient {
 	return c.DisksClient
@@ -481,6 +491,32 @@ func (c *MockVMScaleSetVMsClient) Delete(ctx context.Context, resourceGroupName,
 	return nil
 }
 
+// MockVirtualMachinesClient is a mock implementation of VM client.
+type MockVirtualMachinesClient struct {
+	VMs map[string]*compute.VirtualMachine
+}
+
+var _ azure.VirtualMachinesClient = &MockVirtualMachinesClient{}
+
+// List returns a slice of the VMs of a VM Scale Set.
+func (c *MockVirtualMachinesClient) List(ctx context.Context, resourceGroupName, vmssID string) ([]*compute.VirtualMachine, error) {
+	// Ignore resourceGroupName for simplicity.
+	var l []*compute.VirtualMachine
+	for _, vm := range c.VMs {
+		if vm.Properties != nil && vm.Properties.VirtualMachineScaleSet != nil && strings.EqualFold(fi.ValueOf(vm.Properties.VirtualMachineScaleSet.ID), vmssID) {
+			l = append(l, vm)
+		}
+	}
+	return l, nil
+}
+
+// Delete deletes a VM.
+func (c *MockVirtualMachinesClient) Delete(ctx context.Context, resourceGroupName, vmName string) error {
+	// Ignore resourceGroupName for simplicity.
+	delete(c.VMs, vmName)
+	return nil
+}
+
 // MockDisksClient is a mock implementation of disk client.
 type MockDisksClient struct {
 	Disks map[string]*compute.Disk
@@ -48,6 +48,14 @@ type VMScaleSet struct {
 	SKUName *string
 	// Capacity specifies the number of virtual machines the VM Scale Set.
 	Capacity *int64
+	// OrchestrationMode is the orchestration mode of the VM Scale Set, which cannot be changed.
+	OrchestrationMode *compute.OrchestrationMode
+	// Priority is the priority of the VMs, which cannot be changed.
+	Priority *compute.VirtualMachinePriorityTypes
+	// EvictionPolicy is what happens to evicted Spot VMs.
+	EvictionPolicy *compute.VirtualMachineEvictionPolicyTypes
+	// MaxPrice is the maximum hourly price of Spot VMs in USD, or -1 to pay up to the price of regular VMs.
+	MaxPrice *float64
 	// ComputerNamePrefix is the prefix of each VM name of the form <prefix><base-36-instance-id>.
 	// See https://docs.microsoft.com/en-us/azure/virtual-machine-scale-sets/virtual-machine-scale-sets-instance-ids.
 	ComputerNamePrefix *string
@@ -180,6 +188,16 @@ func (s *VMScaleSet) Find(c *fi.CloudupContext) (*VMScaleSet, error) {
 		return nil, fmt.Errorf("failed to decode user data: %w", err)
 	}
 
+	// The orchestration mode and the priority are not always returned when they have their default value
+	orchestrationMode := compute.OrchestrationModeUniform
+	if found.Properties.OrchestrationMode != nil {
+		orchestrationMode = *found.Properties.OrchestrationMode
+	}
+	priority := compute.VirtualMachinePriorityTypesRegular
+	if profile.Priority != nil {
+		priority = *profile.Priority
+	}
+
 	vmss := &VMScaleSet{
 		Name:      s.Name,
 		Lifecycle: s.Lifecycle,
@@ -198,6 +216,9 @@ func (s *VMScaleSet) Find(c *fi.CloudupContext) (*VMScaleSet, error) {
 		RequirePublicIP:    to.Ptr(ipConfig.Properties.PublicIPAddressConfiguration != nil),
 		SKUName:            found.SKU.Name,
 		Capacity:           found.SKU.Capacity,
+		OrchestrationMode:  to.Ptr(orchestrationMode),
+		Priority:           to.Ptr(priority),
+		EvictionPolicy:     profile.EvictionPolicy,
 		ComputerNamePrefix: osProfile.ComputerNamePrefix,
 		AdminUser:          osProfile.AdminUsername,
 		SSHPublicKey:       sshKeys[0].KeyData,
@@ -217,6 +238,9 @@ func (s *VMScaleSet) Find(c *fi.CloudupContext) (*VMScaleSet, error) {
 			Name: to.Ptr(loadBalancerID.LoadBalancerName),
 		}
 	}
+	if profile.BillingProfile != nil {
+		vmss.MaxPrice = profile.BillingProfile.MaxPrice
+	}
 	if found.Zones != nil {
 		vmss.Zones = found.Zones
 	}
@@ -248,6 +272,12 @@ func (s *VMScaleSet) CheckChanges(a, e, changes *VMScaleSet) error {
 	if changes.Name != nil {
 		return fi.CannotChangeField("Name")
 	}
+	if changes.OrchestrationMode != nil {
+		return fi.CannotChangeField("OrchestrationMode")
+	}
+	if changes.Priority != nil {
+		return fi.CannotChangeField("Priority")
+	}
 	return nil
 }
 
@@ -327,6 +357,8 @@ func (s *VMScaleSet) RenderAzure(t *azure.AzureAPITarget, a, e, changes *VMScale
 		}
 	}
 
+	flexible := fi.ValueOf(e.OrchestrationMode) == compute.OrchestrationModeFlexible
+
 	networkConfig := &compute.VirtualMachineScaleSetNetworkConfiguration{
 		Name: to.Ptr(name + "-netconfig"),
 		Properties: &compute.VirtualMachineScaleSetNetworkConfigurationProperties{
@@ -341,27 +373,51 @@ func (s *VMScaleSet) RenderAzure(t *azure.AzureAPITarget, a, e, changes *VMScale
 		},
 	}
 
+	networkProfile := &compute.VirtualMachineScaleSetNetworkProfile{
+		NetworkInterfaceConfigurations: []*compute.VirtualMachineScaleSetNetworkConfiguration{
+			networkConfig,
+		},
+	}
+	if flexible {
+		// The VMs of Flexible VM Scale Sets have standalone network interfaces, deleted with the VMs
+		networkProfile.NetworkAPIVersion = to.Ptr(compute.NetworkAPIVersionTwoThousandTwenty1101)
+		networkConfig.Properties.DeleteOption = to.Ptr(compute.DeleteOptionsDelete)
+	}
+
+	vmProfile := &compute.VirtualMachineScaleSetVMProfile{
+		OSProfile:      osProfile,
+		StorageProfile: e.StorageProfile.VirtualMachineScaleSetStorageProfile,
+		UserData:       customData,
+		NetworkProfile: networkProfile,
+		Priority:       e.Priority,
+	}
+	if fi.ValueOf(e.Priority) == compute.VirtualMachinePriorityTypesSpot {
+		vmProfile.EvictionPolicy = e.EvictionPolicy
+		vmProfile.BillingProfile = &compute.BillingProfile{
+			MaxPrice: e.MaxPrice,
+		}
+	}
+
+	properties := &compute.VirtualMachineScaleSetProperties{
+		OrchestrationMode:     e.OrchestrationMode,
+		VirtualMachineProfile: vmProfile,
+	}
+	if flexible {
+		properties.PlatformFaultDomainCount = to.Ptr[int32](1)
+		properties.SinglePlacementGroup = to.Ptr(false)
+	} else {
+		properties.UpgradePolicy = &compute.UpgradePolicy{
+			Mode: to.Ptr(compute.UpgradeModeManual),
+		}
+	}
+
 	vmss := compute.VirtualMachineScaleSet{
 		Location: to.Ptr(t.Cloud.Region()),
 		SKU: &compute.SKU{
 			Name:     e.SKUName,
 			Capacity: e.Capacity,
 		},
-		Properties: &compute.VirtualMachineScaleSetProperties{
-			UpgradePolicy: &compute.UpgradePolicy{
-				Mode: to.Ptr(compute.UpgradeModeManual),
-			},
-			VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
-				OSProfile:      osProfile,
-				StorageProfile: e.StorageProfile.VirtualMachineScaleSetStorageProfile,
-				UserData:       customData,
-				NetworkProfile: &compute.VirtualMachineScaleSetNetworkProfile{
-					NetworkInterfaceConfigurations: []*compute.VirtualMachineScaleSetNetworkConfiguration{
-						networkConfig,
-					},
-				},
-			},
-		},
+		Properties: properties,
 		// Assign a system-assigned managed identity so that
 		// Azure creates an identity for VMs and provision
 		// its credentials on the VMs.
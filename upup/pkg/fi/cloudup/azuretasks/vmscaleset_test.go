@@ -299,6 +299,74 @@ func TestVMScaleSetFind(t *testing.T) {
 	if a, e := actual.Zones, vmssParameters.Zones; !reflect.DeepEqual(a, e) {
 		t.Errorf("unexpected Zone: expected %v, but got %v", e, a)
 	}
+	// The orchestration mode and the priority default to Uniform and Regular.
+	if a, e := *actual.OrchestrationMode, compute.OrchestrationModeUniform; a != e {
+		t.Errorf("unexpected orchestration mode: expected %s, but got %s", e, a)
+	}
+	if a, e := *actual.Priority, compute.VirtualMachinePriorityTypesRegular; a != e {
+		t.Errorf("unexpected priority: expected %s, but got %s", e, a)
+	}
+}
+
+func TestVMScaleSetRenderAzureFlexibleSpot(t *testing.T) {
+	cloud := NewMockAzureCloud("eastus")
+	apiTarget := azure.NewAzureAPITarget(cloud)
+	ctx := &fi.CloudupContext{
+		T: fi.CloudupSubContext{
+			Cloud: cloud,
+		},
+	}
+
+	expected := newTestVMScaleSet()
+	expected.SSHPublicKey = to.Ptr("ssh")
+	expected.OrchestrationMode = to.Ptr(compute.OrchestrationModeFlexible)
+	expected.Priority = to.Ptr(compute.VirtualMachinePriorityTypesSpot)
+	expected.EvictionPolicy = to.Ptr(compute.VirtualMachineEvictionPolicyTypesDeallocate)
+	expected.MaxPrice = to.Ptr(-1.0)
+	if err := (&VMScaleSet{}).RenderAzure(apiTarget, nil, expected, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	actual := cloud.VMScaleSetsClient.VMSSes[*expected.Name]
+	if a, e := *actual.Properties.OrchestrationMode, compute.OrchestrationModeFlexible; a != e {
+		t.Errorf("unexpected orchestration mode: expected %s, but got %s", e, a)
+	}
+	if actual.Properties.UpgradePolicy != nil {
+		t.Errorf("unexpected upgrade policy for a Flexible VM Scale Set: %+v", actual.Properties.UpgradePolicy)
+	}
+	if actual.Properties.PlatformFaultDomainCount == nil {
+		t.Errorf("expected a platform fault domain count for a Flexible VM Scale Set")
+	}
+	profile := actual.Properties.VirtualMachineProfile
+	if profile.NetworkProfile.NetworkAPIVersion == nil {
+		t.Errorf("expected a network API version for a Flexible VM Scale Set")
+	}
+	if a, e := *profile.Priority, compute.VirtualMachinePriorityTypesSpot; a != e {
+		t.Errorf("unexpected priority: expected %s, but got %s", e, a)
+	}
+	if a, e := *profile.EvictionPolicy, compute.VirtualMachineEvictionPolicyTypesDeallocate; a != e {
+		t.Errorf("unexpected eviction policy: expected %s, but got %s", e, a)
+	}
+	if a, e := *profile.BillingProfile.MaxPrice, -1.0; a != e {
+		t.Errorf("unexpected max price: expected %v, but got %v", e, a)
+	}
+
+	found, err := (&VMScaleSet{Name: expected.Name, ResourceGroup: expected.ResourceGroup}).Find(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if a, e := *found.OrchestrationMode, *expected.OrchestrationMode; a != e {
+		t.Errorf("unexpected orchestration mode: expected %s, but got %s", e, a)
+	}
+	if a, e := *found.Priority, *expected.Priority; a != e {
+		t.Errorf("unexpected priority: expected %s, but got %s", e, a)
+	}
+	if a, e := *found.EvictionPolicy, *expected.EvictionPolicy; a != e {
+		t.Errorf("unexpected eviction policy: expected %s, but got %s", e, a)
+	}
+	if a, e := *found.MaxPrice, *expected.MaxPrice; a != e {
+		t.Errorf("unexpected max price: expected %v, but got %v", e, a)
+	}
 }
 
 func TestVMScaleSetRun(t *testing.T) {
@@ -354,6 +422,21 @@ func TestVMScaleSetCheckChanges(t *testing.T) {
 			changes: &VMScaleSet{Name: to.Ptr("newName")},
 			success: false,
 		},
+		{
+			a:       &VMScaleSet{Name: to.Ptr("name")},
+			changes: &VMScaleSet{OrchestrationMode: to.Ptr(compute.OrchestrationModeFlexible)},
+			success: false,
+		},
+		{
+			a:       &VMScaleSet{Name: to.Ptr("name")},
+			changes: &VMScaleSet{Priority: to.Ptr(compute.VirtualMachinePriorityTypesSpot)},
+			success: false,
+		},
+		{
+			a:       &VMScaleSet{Name: to.Ptr("name")},
+			changes: &VMScaleSet{MaxPrice: to.Ptr(0.05)},
+			success: true,
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("test case %d", i), func(t *testing.T) {
@@ -17,7 +17,9 @@ limitations under the License.
 package bootstrapchannelbuilder
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -36,10 +38,12 @@ import (
 	"k8s.io/kops/pkg/model/components/addonmanifests/certmanager"
 	"k8s.io/kops/pkg/model/components/addonmanifests/clusterautoscaler"
 	"k8s.io/kops/pkg/model/components/addonmanifests/dnscontroller"
+	"k8s.io/kops/pkg/model/components/addonmanifests/eventexporter"
 	"k8s.io/kops/pkg/model/components/addonmanifests/externaldns"
 	"k8s.io/kops/pkg/model/components/addonmanifests/karpenter"
 	"k8s.io/kops/pkg/model/components/addonmanifests/kuberouter"
 	"k8s.io/kops/pkg/model/components/addonmanifests/nodeterminationhandler"
+	"k8s.io/kops/pkg/model/components/addonmanifests/prometheusagent"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/pkg/templates"
 	"k8s.io/kops/pkg/wellknownoperators"
@@ -105,6 +109,7 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 		return err
 	}
 
+	deferredManifests := make(map[*channelsapi.AddonSpec]fi.Resource)
 	for _, a := range addons.Items {
 		// Older versions of channels that may be running on the upgrading cluster requires Version to be set
 		// We hardcode version to a high version to ensure an update is triggered on first run, and from then on
@@ -145,18 +150,27 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 		rawManifest := string(manifestBytes)
 		klog.V(4).Infof("Manifest %v", rawManifest)
 
-		manifestHash, err := utils.HashString(rawManifest)
-		klog.V(4).Infof("hash %s", manifestHash)
-		if err != nil {
-			return fmt.Errorf("error hashing manifest: %v", err)
+		var contents fi.Resource
+		if a.BuildContents != nil {
+			// The hash is only known once the tasks the contents depend on have run
+			contents = a.BuildContents(manifestBytes)
+			deferredManifests[a.Spec] = contents
+		} else {
+			manifestHash, err := utils.HashString(rawManifest)
+			klog.V(4).Infof("hash %s", manifestHash)
+			if err != nil {
+				return fmt.Errorf("error hashing manifest: %v", err)
+			}
+			a.Spec.ManifestHash = manifestHash
+			contents = fi.NewBytesResource(manifestBytes)
 		}
-		a.Spec.ManifestHash = manifestHash
 
 		c.AddTask(&fitasks.ManagedFile{
-			Contents:  fi.NewBytesResource(manifestBytes),
-			Lifecycle: b.Lifecycle,
-			Location:  fi.PtrTo(manifestPath),
-			Name:      fi.PtrTo(name),
+			Contents:           contents,
+			Lifecycle:          b.Lifecycle,
+			Location:           fi.PtrTo(manifestPath),
+			Name:               fi.PtrTo(name),
+			KubernetesManifest: fi.PtrTo(true),
 		})
 	}
 
@@ -201,10 +215,11 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 			a.Spec.ManifestHash = manifestHash
 
 			c.AddTask(&fitasks.ManagedFile{
-				Contents:  fi.NewBytesResource(manifestBytes),
-				Lifecycle: b.Lifecycle,
-				Location:  fi.PtrTo(manifestPath),
-				Name:      fi.PtrTo(name),
+				Contents:           fi.NewBytesResource(manifestBytes),
+				Lifecycle:          b.Lifecycle,
+				Location:           fi.PtrTo(manifestPath),
+				Name:               fi.PtrTo(name),
+				KubernetesManifest: fi.PtrTo(true),
 			})
 
 			addon := addons.Add(&a.Spec)
@@ -261,10 +276,11 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 		a.ManifestHash = manifestHash
 
 		c.AddTask(&fitasks.ManagedFile{
-			Contents:  fi.NewBytesResource(manifestBytes),
-			Lifecycle: b.Lifecycle,
-			Location:  fi.PtrTo(manifestPath),
-			Name:      fi.PtrTo(name),
+			Contents:           fi.NewBytesResource(manifestBytes),
+			Lifecycle:          b.Lifecycle,
+			Location:           fi.PtrTo(manifestPath),
+			Name:               fi.PtrTo(name),
+			KubernetesManifest: fi.PtrTo(true),
 		})
 
 		addons.Add(a)
@@ -285,15 +301,24 @@ func (b *BootstrapChannelBuilder) Build(c *fi.CloudupModelBuilderContext) error
 		return err
 	}
 
-	addonsYAML, err := utils.YamlMarshal(addonsObject)
-	if err != nil {
-		return fmt.Errorf("error serializing addons yaml: %v", err)
+	var channel fi.Resource
+	if len(deferredManifests) != 0 {
+		channel = &bootstrapChannel{
+			addons:    addonsObject,
+			manifests: deferredManifests,
+		}
+	} else {
+		addonsYAML, err := utils.YamlMarshal(addonsObject)
+		if err != nil {
+			return fmt.Errorf("error serializing addons yaml: %v", err)
+		}
+		channel = fi.NewBytesResource(addonsYAML)
 	}
 
 	name := b.Cluster.ObjectMeta.Name + "-addons-bootstrap"
 
 	c.AddTask(&fitasks.ManagedFile{
-		Contents:  fi.NewBytesResource(addonsYAML),
+		Contents:  channel,
 		Lifecycle: b.Lifecycle,
 		Location:  fi.PtrTo("addons/bootstrap-channel.yaml"),
 		Name:      fi.PtrTo(name),
@@ -323,6 +348,53 @@ type Addon struct {
 
 	// BuildPrune is set if we should automatically build prune specifiers, based on the manifest.
 	BuildPrune bool
+
+	// PruneGroupKinds are the group kinds that are pruned in addition to the well-known ones, when BuildPrune is set.
+	PruneGroupKinds []schema.GroupKind
+
+	// BuildContents is set for manifests that can only be completed once other tasks have run.
+	// It builds the resource for the manifest from the rendered template.
+	BuildContents func(manifest []byte) fi.Resource
+}
+
+// bootstrapChannel is the bootstrap channel for addons with manifests that depend on other tasks.
+// The hashes of those manifests are only known once the tasks have run.
+type bootstrapChannel struct {
+	addons    *channelsapi.Addons
+	manifests map[*channelsapi.AddonSpec]fi.Resource
+}
+
+var (
+	_ fi.Resource               = &bootstrapChannel{}
+	_ fi.CloudupHasDependencies = &bootstrapChannel{}
+)
+
+func (r *bootstrapChannel) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
+	var deps []fi.CloudupTask
+	for _, manifest := range r.manifests {
+		deps = append(deps, fi.FindDependencies(tasks, manifest)...)
+	}
+	return deps
+}
+
+func (r *bootstrapChannel) Open() (io.Reader, error) {
+	for spec, manifest := range r.manifests {
+		data, err := fi.ResourceAsString(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("error reading manifest %s: %w", fi.ValueOf(spec.Manifest), err)
+		}
+		manifestHash, err := utils.HashString(data)
+		if err != nil {
+			return nil, fmt.Errorf("error hashing manifest: %w", err)
+		}
+		spec.ManifestHash = manifestHash
+	}
+
+	addonsYAML, err := utils.YamlMarshal(r.addons)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing addons yaml: %w", err)
+	}
+	return bytes.NewReader(addonsYAML), nil
 }
 
 func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext) (*AddonList, map[string]iam.Subject, error) {
@@ -630,6 +702,70 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext)
 		}
 	}
 
+	if ee := b.Cluster.Spec.EventExporter; ee != nil && fi.ValueOf(ee.Enabled) {
+		key := "event-exporter.addons.k8s.io"
+
+		{
+			location := key + "/k8s-1.25.yaml"
+			id := "k8s-1.25"
+
+			addon := addons.Add(&channelsapi.AddonSpec{
+				Name:     fi.PtrTo(key),
+				Selector: map[string]string{"k8s-addon": key},
+				Manifest: fi.PtrTo(location),
+				Id:       id,
+			})
+			addon.BuildPrune = true
+		}
+
+		if ee.Sink == kops.EventExporterSinkCloudWatch && b.UseServiceAccountExternalPermissions() {
+			serviceAccountRoles = append(serviceAccountRoles, &eventexporter.ServiceAccount{})
+		}
+	}
+
+	if pa := b.Cluster.Spec.PrometheusAgent; pa != nil && fi.ValueOf(pa.Enabled) {
+		key := "prometheus-agent.addons.k8s.io"
+
+		{
+			location := key + "/k8s-1.25.yaml"
+			id := "k8s-1.25"
+
+			addon := addons.Add(&channelsapi.AddonSpec{
+				Name:     fi.PtrTo(key),
+				Selector: map[string]string{"k8s-addon": key},
+				Manifest: fi.PtrTo(location),
+				Id:       id,
+			})
+			addon.BuildPrune = true
+		}
+
+		if pa.RemoteWrite != nil && pa.RemoteWrite.SigV4 != nil && b.UseServiceAccountExternalPermissions() {
+			serviceAccountRoles = append(serviceAccountRoles, &prometheusagent.ServiceAccount{})
+		}
+	}
+
+	if b.Cluster.Spec.ClusterDefaults != nil {
+		key := "cluster-defaults.addons.k8s.io"
+
+		{
+			location := key + "/k8s-1.25.yaml"
+			id := "k8s-1.25"
+
+			addon := addons.Add(&channelsapi.AddonSpec{
+				Name:     fi.PtrTo(key),
+				Selector: map[string]string{"k8s-addon": key},
+				Manifest: fi.PtrTo(location),
+				Id:       id,
+			})
+			addon.BuildPrune = true
+			addon.PruneGroupKinds = []schema.GroupKind{
+				{Group: "", Kind: "LimitRange"},
+				{Group: "", Kind: "ResourceQuota"},
+				{Group: "scheduling.k8s.io", Kind: "PriorityClass"},
+			}
+		}
+	}
+
 	nvidia := b.Cluster.Spec.Containerd.NvidiaGPU
 	igNvidia := false
 	for _, ig := range b.KopsModelContext.InstanceGroups {
@@ -1098,12 +1234,17 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext)
 				location := key + "/k8s-1.12.yaml"
 				id := "k8s-1.12"
 
-				addons.Add(&channelsapi.AddonSpec{
+				addon := addons.Add(&channelsapi.AddonSpec{
 					Name:     fi.PtrTo(key),
 					Selector: authenticationSelector,
 					Manifest: fi.PtrTo(location),
 					Id:       id,
 				})
+				addon.BuildPrune = true
+				// Identity mappings that are removed from the cluster spec are deleted
+				addon.PruneGroupKinds = []schema.GroupKind{
+					{Group: "iamauthenticator.k8s.aws", Kind: "IAMIdentityMapping"},
+				}
 			}
 		}
 	}
@@ -1210,6 +1351,7 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext)
 				serviceAccountRoles = append(serviceAccountRoles, &karpenter.ServiceAccount{})
 			}
 			addon.BuildPrune = true
+			addon.BuildContents = b.buildKarpenterManifest
 		}
 	}
 
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapchannelbuilder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+// karpenterManifest is the manifest of the Karpenter addon.
+// The user data of each EC2NodeClass is the bootstrap script of the launch template of its instance group,
+// so that Karpenter nodes join the cluster with the same nodeup flow as the other nodes.
+type karpenterManifest struct {
+	manifest []byte
+
+	// launchTemplates maps the name of each EC2NodeClass to the name of the launch template of its instance group.
+	launchTemplates map[string]string
+
+	// userData holds the user data of each EC2NodeClass, once the dependencies are known.
+	userData map[string]fi.Resource
+}
+
+var (
+	_ fi.Resource               = &karpenterManifest{}
+	_ fi.CloudupHasDependencies = &karpenterManifest{}
+)
+
+func (b *BootstrapChannelBuilder) buildKarpenterManifest(manifest []byte) fi.Resource {
+	m := &karpenterManifest{
+		manifest:        manifest,
+		launchTemplates: make(map[string]string),
+		userData:        make(map[string]fi.Resource),
+	}
+	for _, ig := range b.InstanceGroups {
+		if ig.Spec.Manager == kops.InstanceManagerKarpenter {
+			m.launchTemplates[ig.ObjectMeta.Name] = b.AutoscalingGroupName(ig)
+		}
+	}
+	return m
+}
+
+func (m *karpenterManifest) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
+	var deps []fi.CloudupTask
+	for nodeClass, name := range m.launchTemplates {
+		task, found := tasks["LaunchTemplate/"+name]
+		if !found {
+			continue
+		}
+		userData := task.(*awstasks.LaunchTemplate).UserData
+		m.userData[nodeClass] = userData
+		deps = append(deps, fi.FindDependencies(tasks, userData)...)
+	}
+	return deps
+}
+
+func (m *karpenterManifest) Open() (io.Reader, error) {
+	objects, err := kubemanifest.LoadObjectsFrom(m.manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, object := range objects {
+		if object.Kind() != "EC2NodeClass" {
+			continue
+		}
+		userData := m.userData[object.GetName()]
+		if userData == nil {
+			return nil, fmt.Errorf("launch template for EC2NodeClass %q not found", object.GetName())
+		}
+		s, err := fi.ResourceAsString(userData)
+		if err != nil {
+			return nil, fmt.Errorf("error reading user data for EC2NodeClass %q: %w", object.GetName(), err)
+		}
+		if err := object.Set(s, "spec", "userData"); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := objects.ToYAML()
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader([]byte(strings.TrimSpace(string(b)))), nil
+}
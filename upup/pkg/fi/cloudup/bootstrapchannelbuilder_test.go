@@ -52,9 +52,14 @@ func TestBootstrapChannelBuilder_BuildTasks(t *testing.T) {
 	runChannelBuilderTest(t, "amazonvpc-containerd", []string{"networking.amazon-vpc-routed-eni-k8s-1.16"})
 	runChannelBuilderTest(t, "awsiamauthenticator/crd", []string{"authentication.aws-k8s-1.12"})
 	runChannelBuilderTest(t, "awsiamauthenticator/mappings", []string{"authentication.aws-k8s-1.12"})
+	runChannelBuilderTest(t, "awsiamauthenticator/mountedfile", []string{"authentication.aws-k8s-1.12"})
 	runChannelBuilderTest(t, "metrics-server/insecure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "metrics-server/secure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
+	runChannelBuilderTest(t, "metrics-server/server-tls-bootstrap", []string{"metrics-server.addons.k8s.io-k8s-1.11", "kops-controller.addons.k8s.io-k8s-1.16"})
 	runChannelBuilderTest(t, "coredns", []string{"coredns.addons.k8s.io-k8s-1.12"})
+	runChannelBuilderTest(t, "event-exporter", []string{"event-exporter.addons.k8s.io-k8s-1.25"})
+	runChannelBuilderTest(t, "prometheus-agent", []string{"prometheus-agent.addons.k8s.io-k8s-1.25", "kops-controller.addons.k8s.io-k8s-1.16"})
+	runChannelBuilderTest(t, "cluster-defaults", []string{"cluster-defaults.addons.k8s.io-k8s-1.25"})
 }
 
 func TestBootstrapChannelBuilder_ServiceAccountIAM(t *testing.T) {
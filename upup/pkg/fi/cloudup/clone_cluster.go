@@ -0,0 +1,323 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+)
+
+// CloneClusterOptions configures the copy of an existing cluster to a new cluster.
+type CloneClusterOptions struct {
+	// ClusterName is the name of the new cluster.
+	ClusterName string
+	// ConfigBase is the location where we will store the configuration of the new cluster. It defaults to the state store.
+	ConfigBase string
+	// Zones are the zones of the new cluster. They replace the zones of the source cluster, in sorted order.
+	// It defaults to the zones of the source cluster.
+	Zones []string
+	// DNSZone is the DNS hosted zone of the new cluster.
+	// It defaults to the hosted zone of the source cluster, if it is a parent domain of the new cluster name.
+	DNSZone string
+	// ResolveImage returns a name of the image that is valid in every region, for an image ID of the source region.
+	// It is only called for AWS image IDs when the new cluster is in another region.
+	ResolveImage func(image string) (string, error)
+}
+
+// CloneCluster copies the cluster and instance groups specifications of an existing cluster
+// to a new cluster, remapping the names, zones, images and hosted zone.
+// It is the responsibility of the caller to call cloudup.PerformAssignments() on
+// the returned cluster spec.
+func CloneCluster(opt *CloneClusterOptions, source *api.Cluster, sourceGroups []*api.InstanceGroup, clientset simple.Clientset) (*NewClusterResult, error) {
+	cluster, instanceGroups, err := cloneClusterSpec(opt, source, sourceGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.Spec.Channel == "" {
+		cluster.Spec.Channel = api.DefaultChannel
+	}
+	channel, err := api.LoadChannel(clientset.VFSContext(), cluster.Spec.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster.Spec.ConfigStore = api.ConfigStoreSpec{
+		Base: opt.ConfigBase,
+	}
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("error building ConfigBase for cluster: %v", err)
+	}
+	cluster.Spec.ConfigStore.Base = configBase.Path()
+
+	// Backups under the configuration of the source cluster move with the configuration
+	for i := range cluster.Spec.EtcdClusters {
+		etcdCluster := &cluster.Spec.EtcdClusters[i]
+		if etcdCluster.Backups == nil || source.Spec.ConfigStore.Base == "" {
+			continue
+		}
+		if rest, found := strings.CutPrefix(etcdCluster.Backups.BackupStore, source.Spec.ConfigStore.Base); found {
+			etcdCluster.Backups.BackupStore = cluster.Spec.ConfigStore.Base + rest
+		}
+	}
+
+	return &NewClusterResult{
+		Cluster:        cluster,
+		InstanceGroups: instanceGroups,
+		Channel:        channel,
+	}, nil
+}
+
+func cloneClusterSpec(opt *CloneClusterOptions, source *api.Cluster, sourceGroups []*api.InstanceGroup) (*api.Cluster, []*api.InstanceGroup, error) {
+	if opt.ClusterName == "" {
+		return nil, nil, fmt.Errorf("name is required")
+	}
+
+	cluster := &api.Cluster{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        opt.ClusterName,
+			Labels:      source.Labels,
+			Annotations: source.Annotations,
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	renameCluster := func(s string) string {
+		return strings.ReplaceAll(s, source.Name, cluster.Name)
+	}
+
+	zoneMap, err := buildZoneMap(source, opt.Zones)
+	if err != nil {
+		return nil, nil, err
+	}
+	var zoneReplacements []string
+	for _, from := range sortedByLength(zoneMap) {
+		zoneReplacements = append(zoneReplacements, from, zoneMap[from])
+	}
+	renameZone := strings.NewReplacer(zoneReplacements...).Replace
+
+	sourceRegion := regionOfZones(source)
+	for i := range cluster.Spec.Networking.Subnets {
+		subnet := &cluster.Spec.Networking.Subnets[i]
+		subnet.Name = renameZone(subnet.Name)
+		subnet.Zone = renameZone(subnet.Zone)
+		if subnet.Region != "" {
+			if region, err := gce.ZoneToRegion(subnet.Zone); err == nil {
+				subnet.Region = region
+			}
+		}
+	}
+	regionChanged := regionOfZones(cluster) != sourceRegion
+
+	cluster.Spec.API.PublicName = renameCluster(cluster.Spec.API.PublicName)
+	for i := range cluster.Spec.API.AdditionalSANs {
+		cluster.Spec.API.AdditionalSANs[i] = renameCluster(cluster.Spec.API.AdditionalSANs[i])
+	}
+	if lb := cluster.Spec.API.LoadBalancer; lb != nil {
+		for i := range lb.Subnets {
+			lb.Subnets[i].Name = renameZone(lb.Subnets[i].Name)
+		}
+	}
+	if tgw := cluster.Spec.Networking.TransitGateway; tgw != nil {
+		for i := range tgw.Subnets {
+			tgw.Subnets[i] = renameZone(tgw.Subnets[i])
+		}
+	}
+	for i := range cluster.Spec.EtcdClusters {
+		for j := range cluster.Spec.EtcdClusters[i].Members {
+			member := &cluster.Spec.EtcdClusters[i].Members[j]
+			if member.InstanceGroup != nil {
+				member.InstanceGroup = fi.PtrTo(renameZone(*member.InstanceGroup))
+			}
+		}
+	}
+	if discovery := cluster.Spec.ServiceAccountIssuerDiscovery; discovery != nil {
+		if base, found := strings.CutSuffix(discovery.DiscoveryStore, "/"+source.Name); found {
+			discovery.DiscoveryStore = base + "/" + cluster.Name
+		}
+	}
+
+	cluster.Spec.DNSZone = opt.DNSZone
+	if cluster.Spec.DNSZone == "" && source.Spec.DNSZone != "" {
+		zone := strings.TrimSuffix(source.Spec.DNSZone, ".")
+		if cluster.Name == zone || strings.HasSuffix(cluster.Name, "."+zone) {
+			cluster.Spec.DNSZone = source.Spec.DNSZone
+		} else {
+			klog.Infof("hosted zone %q of cluster %q does not match cluster %q; the longest matching hosted zone will be used", source.Spec.DNSZone, source.Name, cluster.Name)
+		}
+	}
+
+	// The IDs of regional resources of the source cluster are not valid in another region
+	if regionChanged {
+		clearField := func(field string, value *string) {
+			if *value != "" {
+				klog.Warningf("not copying %s %q of cluster %q to region %q", field, *value, source.Name, regionOfZones(cluster))
+				*value = ""
+			}
+		}
+		clearField("networking.networkID", &cluster.Spec.Networking.NetworkID)
+		for i := range cluster.Spec.Networking.Subnets {
+			subnet := &cluster.Spec.Networking.Subnets[i]
+			clearField("networking.subnets["+subnet.Name+"].id", &subnet.ID)
+			if subnet.Egress != api.EgressExternal {
+				clearField("networking.subnets["+subnet.Name+"].egress", &subnet.Egress)
+			}
+		}
+		if lb := cluster.Spec.API.LoadBalancer; lb != nil {
+			clearField("api.loadBalancer.sslCertificate", &lb.SSLCertificate)
+			if lb.SecurityGroupOverride != nil {
+				clearField("api.loadBalancer.securityGroupOverride", lb.SecurityGroupOverride)
+				lb.SecurityGroupOverride = nil
+			}
+			for i := range lb.Subnets {
+				lb.Subnets[i].AllocationID = nil
+			}
+		}
+		for i := range cluster.Spec.EtcdClusters {
+			etcdCluster := &cluster.Spec.EtcdClusters[i]
+			for j := range etcdCluster.Members {
+				member := &etcdCluster.Members[j]
+				if member.KmsKeyID != nil {
+					clearField("etcdClusters["+etcdCluster.Name+"].etcdMembers["+member.Name+"].kmsKeyID", member.KmsKeyID)
+					member.KmsKeyID = nil
+				}
+			}
+		}
+	}
+
+	var instanceGroups []*api.InstanceGroup
+	for _, sourceGroup := range sourceGroups {
+		ig := &api.InstanceGroup{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        renameZone(sourceGroup.Name),
+				Labels:      make(map[string]string),
+				Annotations: sourceGroup.Annotations,
+			},
+			Spec: *sourceGroup.Spec.DeepCopy(),
+		}
+		for k, v := range sourceGroup.Labels {
+			ig.Labels[k] = v
+		}
+		ig.Labels[api.LabelClusterName] = cluster.Name
+
+		for i := range ig.Spec.Zones {
+			ig.Spec.Zones[i] = renameZone(ig.Spec.Zones[i])
+		}
+		for i := range ig.Spec.Subnets {
+			ig.Spec.Subnets[i] = renameZone(ig.Spec.Subnets[i])
+		}
+		for k, v := range ig.Spec.NodeLabels {
+			if v == sourceGroup.Name {
+				ig.Spec.NodeLabels[k] = ig.Name
+			}
+		}
+
+		if regionChanged {
+			if cluster.Spec.GetCloudProvider() == api.CloudProviderAWS && strings.HasPrefix(ig.Spec.Image, "ami-") {
+				if opt.ResolveImage == nil {
+					return nil, nil, fmt.Errorf("image %q of instance group %q is specific to region %q", ig.Spec.Image, sourceGroup.Name, sourceRegion)
+				}
+				image, err := opt.ResolveImage(ig.Spec.Image)
+				if err != nil {
+					return nil, nil, fmt.Errorf("error resolving image %q of instance group %q: %w", ig.Spec.Image, sourceGroup.Name, err)
+				}
+				ig.Spec.Image = image
+			}
+
+			var securityGroups []string
+			for _, sg := range ig.Spec.AdditionalSecurityGroups {
+				if strings.HasPrefix(sg, "sg-") {
+					klog.Warningf("not copying security group %q of instance group %q to region %q", sg, sourceGroup.Name, regionOfZones(cluster))
+					continue
+				}
+				securityGroups = append(securityGroups, sg)
+			}
+			ig.Spec.AdditionalSecurityGroups = securityGroups
+		}
+
+		instanceGroups = append(instanceGroups, ig)
+	}
+
+	return cluster, instanceGroups, nil
+}
+
+// buildZoneMap maps the sorted zones of the source cluster to the given zones.
+func buildZoneMap(source *api.Cluster, zones []string) (map[string]string, error) {
+	var sourceZones []string
+	for _, subnet := range source.Spec.Networking.Subnets {
+		if subnet.Zone != "" && !slices.Contains(sourceZones, subnet.Zone) {
+			sourceZones = append(sourceZones, subnet.Zone)
+		}
+	}
+	sort.Strings(sourceZones)
+
+	zoneMap := make(map[string]string)
+	if len(zones) == 0 {
+		return zoneMap, nil
+	}
+	if len(zones) != len(sourceZones) {
+		return nil, fmt.Errorf("cluster %q uses %d zones (%s), but %d zones were specified", source.Name, len(sourceZones), strings.Join(sourceZones, ", "), len(zones))
+	}
+	for i, zone := range sourceZones {
+		if zone != zones[i] {
+			zoneMap[zone] = zones[i]
+		}
+	}
+	return zoneMap, nil
+}
+
+// regionOfZones returns the region of the zones of the cluster, or the zones if the region is not known.
+func regionOfZones(cluster *api.Cluster) string {
+	var regions []string
+	for _, subnet := range cluster.Spec.Networking.Subnets {
+		region := subnet.Region
+		if region == "" && len(subnet.Zone) > 1 {
+			region = subnet.Zone[:len(subnet.Zone)-1]
+		}
+		if !slices.Contains(regions, region) {
+			regions = append(regions, region)
+		}
+	}
+	sort.Strings(regions)
+	return strings.Join(regions, ",")
+}
+
+// sortedByLength returns the keys of the map, longest first, so that no key is replaced by one of its substrings.
+func sortedByLength(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
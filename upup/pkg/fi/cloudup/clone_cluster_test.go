@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func buildCloneSourceCluster() (*api.Cluster, []*api.InstanceGroup) {
+	cluster := &api.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "prod.example.com",
+		},
+		Spec: api.ClusterSpec{
+			CloudProvider: api.CloudProviderSpec{AWS: &api.AWSSpec{}},
+			DNSZone:       "example.com",
+			API: api.APISpec{
+				PublicName: "api.prod.example.com",
+				LoadBalancer: &api.LoadBalancerAccessSpec{
+					SSLCertificate: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+					Subnets:        []api.LoadBalancerSubnetSpec{{Name: "utility-us-east-1a"}},
+				},
+			},
+			Networking: api.NetworkingSpec{
+				NetworkID: "vpc-123",
+				Subnets: []api.ClusterSubnetSpec{
+					{Name: "us-east-1b", Zone: "us-east-1b", Type: api.SubnetTypePrivate, ID: "subnet-b", Egress: "nat-123"},
+					{Name: "us-east-1a", Zone: "us-east-1a", Type: api.SubnetTypePrivate, ID: "subnet-a", Egress: api.EgressExternal},
+					{Name: "utility-us-east-1a", Zone: "us-east-1a", Type: api.SubnetTypeUtility, ID: "subnet-ua"},
+				},
+			},
+			EtcdClusters: []api.EtcdClusterSpec{
+				{
+					Name: "main",
+					Members: []api.EtcdMemberSpec{
+						{Name: "a", InstanceGroup: fi.PtrTo("control-plane-us-east-1a"), KmsKeyID: fi.PtrTo("key-123")},
+					},
+				},
+			},
+		},
+	}
+	instanceGroups := []*api.InstanceGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "control-plane-us-east-1a",
+				Labels: map[string]string{api.LabelClusterName: "prod.example.com"},
+			},
+			Spec: api.InstanceGroupSpec{
+				Role:       api.InstanceGroupRoleControlPlane,
+				Image:      "ami-123",
+				Subnets:    []string{"us-east-1a"},
+				NodeLabels: map[string]string{"kops.k8s.io/instancegroup": "control-plane-us-east-1a"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "nodes",
+				Labels: map[string]string{api.LabelClusterName: "prod.example.com"},
+			},
+			Spec: api.InstanceGroupSpec{
+				Role:                     api.InstanceGroupRoleNode,
+				Image:                    "099720109477/ubuntu/images/hvm-ssd/ubuntu-jammy-22.04-amd64-server-20240607",
+				Subnets:                  []string{"us-east-1a", "us-east-1b"},
+				AdditionalSecurityGroups: []string{"sg-123"},
+			},
+		},
+	}
+	return cluster, instanceGroups
+}
+
+func TestCloneClusterSameRegion(t *testing.T) {
+	source, sourceGroups := buildCloneSourceCluster()
+
+	cluster, instanceGroups, err := cloneClusterSpec(&CloneClusterOptions{ClusterName: "staging.example.com"}, source, sourceGroups)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cluster.Name != "staging.example.com" {
+		t.Errorf("unexpected name %q", cluster.Name)
+	}
+	if cluster.Spec.API.PublicName != "api.staging.example.com" {
+		t.Errorf("unexpected public name %q", cluster.Spec.API.PublicName)
+	}
+	if cluster.Spec.DNSZone != "example.com" {
+		t.Errorf("unexpected DNS zone %q", cluster.Spec.DNSZone)
+	}
+	if cluster.Spec.Networking.NetworkID != "vpc-123" || cluster.Spec.Networking.Subnets[0].ID != "subnet-b" {
+		t.Errorf("expected the network to be kept in the same region, got %+v", cluster.Spec.Networking)
+	}
+	if instanceGroups[0].Spec.Image != "ami-123" {
+		t.Errorf("expected the image to be kept in the same region, got %q", instanceGroups[0].Spec.Image)
+	}
+	if instanceGroups[0].Labels[api.LabelClusterName] != "staging.example.com" {
+		t.Errorf("unexpected instance group labels %v", instanceGroups[0].Labels)
+	}
+	if source.Spec.API.PublicName != "api.prod.example.com" || sourceGroups[0].Labels[api.LabelClusterName] != "prod.example.com" {
+		t.Errorf("source cluster was modified")
+	}
+}
+
+func TestCloneClusterOtherRegion(t *testing.T) {
+	source, sourceGroups := buildCloneSourceCluster()
+
+	opt := &CloneClusterOptions{
+		ClusterName: "dr.example.org",
+		Zones:       []string{"us-west-2b", "us-west-2c"},
+		ResolveImage: func(image string) (string, error) {
+			return "123456789012/image-of-" + image, nil
+		},
+	}
+	cluster, instanceGroups, err := cloneClusterSpec(opt, source, sourceGroups)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var subnets []api.ClusterSubnetSpec
+	subnets = append(subnets,
+		api.ClusterSubnetSpec{Name: "us-west-2c", Zone: "us-west-2c", Type: api.SubnetTypePrivate},
+		api.ClusterSubnetSpec{Name: "us-west-2b", Zone: "us-west-2b", Type: api.SubnetTypePrivate, Egress: api.EgressExternal},
+		api.ClusterSubnetSpec{Name: "utility-us-west-2b", Zone: "us-west-2b", Type: api.SubnetTypeUtility},
+	)
+	if !reflect.DeepEqual(cluster.Spec.Networking.Subnets, subnets) {
+		t.Errorf("unexpected subnets %+v", cluster.Spec.Networking.Subnets)
+	}
+	if cluster.Spec.Networking.NetworkID != "" {
+		t.Errorf("expected the network ID to be cleared, got %q", cluster.Spec.Networking.NetworkID)
+	}
+	if cluster.Spec.DNSZone != "" {
+		t.Errorf("expected the DNS zone of another domain to be cleared, got %q", cluster.Spec.DNSZone)
+	}
+	if cluster.Spec.API.LoadBalancer.SSLCertificate != "" || cluster.Spec.API.LoadBalancer.Subnets[0].Name != "utility-us-west-2b" {
+		t.Errorf("unexpected load balancer %+v", cluster.Spec.API.LoadBalancer)
+	}
+	member := cluster.Spec.EtcdClusters[0].Members[0]
+	if fi.ValueOf(member.InstanceGroup) != "control-plane-us-west-2b" || member.KmsKeyID != nil {
+		t.Errorf("unexpected etcd member %+v", member)
+	}
+
+	controlPlane := instanceGroups[0]
+	if controlPlane.Name != "control-plane-us-west-2b" {
+		t.Errorf("unexpected instance group name %q", controlPlane.Name)
+	}
+	if controlPlane.Spec.Image != "123456789012/image-of-ami-123" {
+		t.Errorf("unexpected image %q", controlPlane.Spec.Image)
+	}
+	if !reflect.DeepEqual(controlPlane.Spec.Subnets, []string{"us-west-2b"}) {
+		t.Errorf("unexpected subnets %v", controlPlane.Spec.Subnets)
+	}
+	if controlPlane.Spec.NodeLabels["kops.k8s.io/instancegroup"] != "control-plane-us-west-2b" {
+		t.Errorf("unexpected node labels %v", controlPlane.Spec.NodeLabels)
+	}
+
+	nodes := instanceGroups[1]
+	if nodes.Spec.Image != sourceGroups[1].Spec.Image {
+		t.Errorf("expected the image name to be kept, got %q", nodes.Spec.Image)
+	}
+	if !reflect.DeepEqual(nodes.Spec.Subnets, []string{"us-west-2b", "us-west-2c"}) {
+		t.Errorf("unexpected subnets %v", nodes.Spec.Subnets)
+	}
+	if len(nodes.Spec.AdditionalSecurityGroups) != 0 {
+		t.Errorf("expected the security groups to be cleared, got %v", nodes.Spec.AdditionalSecurityGroups)
+	}
+}
+
+func TestCloneClusterZoneCount(t *testing.T) {
+	source, sourceGroups := buildCloneSourceCluster()
+
+	_, _, err := cloneClusterSpec(&CloneClusterOptions{ClusterName: "dr.example.com", Zones: []string{"us-west-2a"}}, source, sourceGroups)
+	if err == nil {
+		t.Fatalf("expected an error for a different number of zones")
+	}
+}
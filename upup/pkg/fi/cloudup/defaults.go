@@ -46,6 +46,9 @@ func PerformAssignments(c *kops.Cluster, vfsContext *vfs.VFSContext, cloud fi.Cl
 
 	for i := range c.Spec.EtcdClusters {
 		etcdCluster := &c.Spec.EtcdClusters[i]
+		if etcdCluster.External != nil {
+			continue
+		}
 		if etcdCluster.Manager == nil {
 			etcdCluster.Manager = &kops.EtcdManagerSpec{}
 		}
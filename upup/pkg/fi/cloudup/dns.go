@@ -28,14 +28,15 @@ import (
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider"
 	"k8s.io/kops/dnsprovider/pkg/dnsprovider/rrstype"
 	"k8s.io/kops/pkg/apis/kops"
+	kopsdns "k8s.io/kops/pkg/dns"
 	"k8s.io/kops/upup/pkg/fi"
 )
 
 const (
 	// PlaceholderIP is from TEST-NET-3
 	// https://en.wikipedia.org/wiki/Reserved_IP_addresses
-	PlaceholderIP   = "203.0.113.123"
-	PlaceholderIPv6 = "fd00:dead:add::"
+	PlaceholderIP   = kopsdns.PlaceholderIP
+	PlaceholderIPv6 = kopsdns.PlaceholderIPv6
 	PlaceholderTTL  = 10
 	// DigitalOcean's DNS servers require a certain minimum TTL (it's 30), keeping 60 here.
 	PlaceholderTTLDigitialOcean = 60
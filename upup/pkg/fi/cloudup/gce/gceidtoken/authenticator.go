@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gceidtoken
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/idtoken"
+	"k8s.io/kops/pkg/bootstrap"
+)
+
+// GCEAuthenticationTokenPrefix is the prefix of tokens holding a Google ID token.
+const GCEAuthenticationTokenPrefix = "x-gce-id-token "
+
+// Audience returns the audience of the ID tokens that kops-controller of a cluster accepts.
+func Audience(clusterName string) string {
+	return "https://kops-controller.internal." + clusterName
+}
+
+type idTokenAuthenticator struct {
+	tokenSource oauth2.TokenSource
+}
+
+var _ bootstrap.Authenticator = &idTokenAuthenticator{}
+
+// NewIDTokenAuthenticator builds an authenticator that presents Google ID tokens for the application default credentials,
+// which must be those of a service account, an impersonated service account or a GCE instance.
+func NewIDTokenAuthenticator(ctx context.Context, clusterName string) (bootstrap.Authenticator, error) {
+	tokenSource, err := idtoken.NewTokenSource(ctx, Audience(clusterName))
+	if err != nil {
+		return nil, fmt.Errorf("building ID token source: %w", err)
+	}
+	return &idTokenAuthenticator{tokenSource: tokenSource}, nil
+}
+
+// CreateToken returns an ID token of the caller.
+// ID tokens are bearer tokens, so unlike node tokens they don't sign the body of the request.
+func (a *idTokenAuthenticator) CreateToken(body []byte) (string, error) {
+	token, err := a.tokenSource.Token()
+	if err != nil {
+		return "", fmt.Errorf("getting ID token: %w", err)
+	}
+	return GCEAuthenticationTokenPrefix + token.AccessToken, nil
+}
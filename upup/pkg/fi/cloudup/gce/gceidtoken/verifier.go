@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gceidtoken
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/idtoken"
+	"k8s.io/kops/pkg/bootstrap"
+)
+
+type idTokenVerifier struct {
+	audience  string
+	validator *idtoken.Validator
+}
+
+var _ bootstrap.IdentityVerifier = &idTokenVerifier{}
+
+// NewIDTokenVerifier builds a verifier of the Google ID tokens of operators.
+func NewIDTokenVerifier(ctx context.Context, clusterName string) (bootstrap.IdentityVerifier, error) {
+	validator, err := idtoken.NewValidator(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("building ID token validator: %w", err)
+	}
+	return &idTokenVerifier{
+		audience:  Audience(clusterName),
+		validator: validator,
+	}, nil
+}
+
+// VerifyIdentity validates the ID token of an operator, returning the verified email of the caller.
+func (v *idTokenVerifier) VerifyIdentity(ctx context.Context, rawRequest *http.Request, token string, body []byte) (*bootstrap.IdentityResult, error) {
+	if !strings.HasPrefix(token, GCEAuthenticationTokenPrefix) {
+		return nil, bootstrap.ErrNotThisVerifier
+	}
+
+	payload, err := v.validator.Validate(ctx, strings.TrimPrefix(token, GCEAuthenticationTokenPrefix), v.audience)
+	if err != nil {
+		return nil, fmt.Errorf("validating ID token: %w", err)
+	}
+
+	email, _ := payload.Claims["email"].(string)
+	if email == "" {
+		return nil, fmt.Errorf("ID token of %q has no email", payload.Subject)
+	}
+	if verified, _ := payload.Claims["email_verified"].(bool); !verified {
+		return nil, fmt.Errorf("ID token of %q has unverified email %q", payload.Subject, email)
+	}
+
+	return &bootstrap.IdentityResult{
+		Identity:  email,
+		Principal: email,
+	}, nil
+}
@@ -39,6 +39,7 @@ const (
 	InstanceTemplateNamePrefixMaxLength = 32
 
 	accessConfigOneToOneNAT = "ONE_TO_ONE_NAT"
+	accessConfigDirectIPv6  = "DIRECT_IPV6"
 )
 
 // InstanceTemplate represents a GCE InstanceTemplate
@@ -74,6 +75,10 @@ type InstanceTemplate struct {
 	// HasExternalIP is set to true when an external IP is allocated to an instance.
 	HasExternalIP *bool
 
+	// HasExternalIPv6 is set to true when an external IPv6 range is allocated to an instance.
+	// The subnet must be dual-stack, with an EXTERNAL Ipv6AccessType.
+	HasExternalIPv6 *bool
+
 	// StackType indicates the address families supported (IPV4_IPV6 or IPV4_ONLY)
 	StackType *string
 
@@ -168,6 +173,19 @@ func (e *InstanceTemplate) Find(c *fi.CloudupContext) (*InstanceTemplate, error)
 			} else {
 				actual.HasExternalIP = fi.PtrTo(false)
 			}
+
+			ipv6acs := ni.Ipv6AccessConfigs
+			if len(ipv6acs) > 0 {
+				if len(ipv6acs) != 1 {
+					return nil, fmt.Errorf("unexpected number of IPv6 access configs in template %q: %d", *actual.Name, len(ipv6acs))
+				}
+				if ipv6acs[0].Type != accessConfigDirectIPv6 {
+					return nil, fmt.Errorf("unexpected IPv6 access type in template %q: %s", *actual.Name, ipv6acs[0].Type)
+				}
+				actual.HasExternalIPv6 = fi.PtrTo(true)
+			} else {
+				actual.HasExternalIPv6 = fi.PtrTo(false)
+			}
 		}
 
 		for _, serviceAccount := range p.ServiceAccounts {
@@ -318,6 +336,16 @@ func (e *InstanceTemplate) mapToGCE(project string, region string) (*compute.Ins
 			},
 		}
 	}
+	if fi.ValueOf(e.HasExternalIPv6) {
+		ni.Ipv6AccessConfigs = []*compute.AccessConfig{
+			{
+				Kind:        "compute#accessConfig",
+				Name:        "external-ipv6",
+				Type:        accessConfigDirectIPv6,
+				NetworkTier: "PREMIUM",
+			},
+		}
+	}
 	if e.StackType != nil {
 		ni.StackType = fi.ValueOf(e.StackType)
 	}
@@ -533,16 +561,21 @@ type terraformInstanceTemplateAttachedDisk struct {
 }
 
 type terraformNetworkInterface struct {
-	Network      *terraformWriter.Literal `cty:"network"`
-	Subnetwork   *terraformWriter.Literal `cty:"subnetwork"`
-	AccessConfig []*terraformAccessConfig `cty:"access_config"`
-	StackType    *string                  `cty:"stack_type"`
+	Network          *terraformWriter.Literal     `cty:"network"`
+	Subnetwork       *terraformWriter.Literal     `cty:"subnetwork"`
+	AccessConfig     []*terraformAccessConfig     `cty:"access_config"`
+	IPv6AccessConfig []*terraformIPv6AccessConfig `cty:"ipv6_access_config"`
+	StackType        *string                      `cty:"stack_type"`
 }
 
 type terraformAccessConfig struct {
 	NatIP *terraformWriter.Literal `cty:"nat_ip"`
 }
 
+type terraformIPv6AccessConfig struct {
+	NetworkTier string `cty:"network_tier"`
+}
+
 type terraformGuestAccelerator struct {
 	Type  string `cty:"type"`
 	Count int64  `cty:"count"`
@@ -568,6 +601,11 @@ func addNetworks(stackType *string, network *Network, subnet *Subnet, networkInt
 
 			tf.AccessConfig = append(tf.AccessConfig, tac)
 		}
+		for _, gac := range g.Ipv6AccessConfigs {
+			tf.IPv6AccessConfig = append(tf.IPv6AccessConfig, &terraformIPv6AccessConfig{
+				NetworkTier: gac.NetworkTier,
+			})
+		}
 
 		ni = append(ni, tf)
 	}
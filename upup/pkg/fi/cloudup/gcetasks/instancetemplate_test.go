@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcetasks
+
+import (
+	"context"
+	"testing"
+
+	gcemock "k8s.io/kops/cloudmock/gce"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestInstanceTemplateIPv6(t *testing.T) {
+	ctx := context.TODO()
+
+	project := "testproject"
+	region := "us-test1"
+
+	cloud := gcemock.InstallMockGCECloud(region, project)
+
+	// We define a function so we can rebuild the tasks, because we modify in-place when running
+	buildTasks := func() map[string]fi.CloudupTask {
+		// The network and subnet are only referenced by name
+		network := &Network{Name: fi.PtrTo("test"), Lifecycle: fi.LifecycleIgnore}
+		subnet := &Subnet{Name: fi.PtrTo("test"), Lifecycle: fi.LifecycleIgnore}
+		instanceTemplate := &InstanceTemplate{
+			Name:           fi.PtrTo("nodes"),
+			NamePrefix:     fi.PtrTo("nodes"),
+			Lifecycle:      fi.LifecycleSync,
+			Network:        network,
+			Subnet:         subnet,
+			MachineType:    fi.PtrTo("e2-medium"),
+			BootDiskImage:  fi.PtrTo("ubuntu-os-cloud/ubuntu-2204-jammy-v20240607"),
+			BootDiskSizeGB: fi.PtrTo(int64(64)),
+			BootDiskType:   fi.PtrTo("pd-standard"),
+			CanIPForward:   fi.PtrTo(true),
+			Tags:           []string{"nodes"},
+			Metadata:       map[string]fi.Resource{},
+
+			Preemptible:          fi.PtrTo(false),
+			GCPProvisioningModel: fi.PtrTo("STANDARD"),
+			GuestAccelerators:    []AcceleratorConfig{},
+
+			HasExternalIP:   fi.PtrTo(false),
+			HasExternalIPv6: fi.PtrTo(true),
+			StackType:       fi.PtrTo("IPV4_IPV6"),
+		}
+
+		return map[string]fi.CloudupTask{
+			"network":          network,
+			"subnet":           subnet,
+			"instanceTemplate": instanceTemplate,
+		}
+	}
+
+	{
+		allTasks := buildTasks()
+		checkHasChanges(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks()
+		runTasks(t, ctx, cloud, allTasks)
+	}
+
+	{
+		allTasks := buildTasks()
+		checkNoChanges(t, ctx, cloud, allTasks)
+	}
+
+	templates, err := cloud.Compute().InstanceTemplates().List(ctx, project)
+	if err != nil {
+		t.Fatalf("listing instance templates: %v", err)
+	}
+	if len(templates) != 1 {
+		t.Fatalf("expected 1 instance template, got %d", len(templates))
+	}
+	ni := templates[0].Properties.NetworkInterfaces[0]
+	if len(ni.AccessConfigs) != 0 {
+		t.Errorf("expected no IPv4 access configs, got %v", ni.AccessConfigs)
+	}
+	if len(ni.Ipv6AccessConfigs) != 1 || ni.Ipv6AccessConfigs[0].Type != "DIRECT_IPV6" {
+		t.Errorf("expected a DIRECT_IPV6 access config, got %v", ni.Ipv6AccessConfigs)
+	}
+}
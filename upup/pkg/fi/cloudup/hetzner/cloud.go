@@ -53,12 +53,14 @@ type HetznerCloud interface {
 	FirewallClient() hcloud.FirewallClient
 	ServerClient() hcloud.ServerClient
 	VolumeClient() hcloud.VolumeClient
+	PlacementGroupClient() hcloud.PlacementGroupClient
 	GetSSHKeys(clusterName string) ([]*hcloud.SSHKey, error)
 	GetNetworks(clusterName string) ([]*hcloud.Network, error)
 	GetFirewalls(clusterName string) ([]*hcloud.Firewall, error)
 	GetLoadBalancers(clusterName string) ([]*hcloud.LoadBalancer, error)
 	GetServers(clusterName string) ([]*hcloud.Server, error)
 	GetVolumes(clusterName string) ([]*hcloud.Volume, error)
+	GetPlacementGroups(clusterName string) ([]*hcloud.PlacementGroup, error)
 }
 
 // static compile time check to validate HetznerCloud's fi.Cloud Interface.
@@ -127,6 +129,11 @@ func (c *hetznerCloudImplementation) VolumeClient() hcloud.VolumeClient {
 	return c.Client.Volume
 }
 
+// PlacementGroupClient returns an implementation of hetzner.PlacementGroupClient
+func (c *hetznerCloudImplementation) PlacementGroupClient() hcloud.PlacementGroupClient {
+	return c.Client.PlacementGroup
+}
+
 func (c *hetznerCloudImplementation) GetSSHKeys(clusterName string) ([]*hcloud.SSHKey, error) {
 	client := c.SSHKeyClient()
 
@@ -238,6 +245,24 @@ func (c *hetznerCloudImplementation) GetVolumes(clusterName string) ([]*hcloud.V
 	return matches, nil
 }
 
+func (c *hetznerCloudImplementation) GetPlacementGroups(clusterName string) ([]*hcloud.PlacementGroup, error) {
+	client := c.PlacementGroupClient()
+
+	labelSelector := TagKubernetesClusterName + "=" + clusterName
+	listOptions := hcloud.ListOpts{
+		PerPage:       50,
+		LabelSelector: labelSelector,
+	}
+	placementGroupListOptions := hcloud.PlacementGroupListOpts{ListOpts: listOptions}
+
+	matches, err := client.AllWithOpts(context.TODO(), placementGroupListOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get placement groups matching label selector %q: %w", labelSelector, err)
+	}
+
+	return matches, nil
+}
+
 func (c *hetznerCloudImplementation) DNS() (dnsprovider.Interface, error) {
 	// Hetzner LB has a stable internal IP and can use that instead of creating a record for api.internal.
 	return nil, nil
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetznertasks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/hetzner"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// +kops:fitask
+type PlacementGroup struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID   *int
+	Type string
+
+	Labels map[string]string
+}
+
+var _ fi.CompareWithID = &PlacementGroup{}
+
+func (v *PlacementGroup) CompareWithID() *string {
+	return fi.PtrTo(strconv.Itoa(fi.ValueOf(v.ID)))
+}
+
+func (v *PlacementGroup) Find(c *fi.CloudupContext) (*PlacementGroup, error) {
+	cloud := c.T.Cloud.(hetzner.HetznerCloud)
+	client := cloud.PlacementGroupClient()
+
+	placementGroup, _, err := client.GetByName(context.TODO(), fi.ValueOf(v.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find placement group %q: %w", fi.ValueOf(v.Name), err)
+	}
+	if placementGroup == nil {
+		return nil, nil
+	}
+
+	matches := &PlacementGroup{
+		Name:      v.Name,
+		Lifecycle: v.Lifecycle,
+		ID:        fi.PtrTo(placementGroup.ID),
+		Type:      string(placementGroup.Type),
+		Labels:    placementGroup.Labels,
+	}
+	// Make sure the ID is set (used by other tasks)
+	v.ID = matches.ID
+
+	return matches, nil
+}
+
+func (v *PlacementGroup) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(v, c)
+}
+
+func (_ *PlacementGroup) CheckChanges(a, e, changes *PlacementGroup) error {
+	if a != nil {
+		if changes.Name != nil {
+			return fi.CannotChangeField("Name")
+		}
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Type != "" {
+			return fi.CannotChangeField("Type")
+		}
+	} else {
+		if e.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if e.Type == "" {
+			return fi.RequiredField("Type")
+		}
+	}
+	return nil
+}
+
+func (_ *PlacementGroup) RenderHetzner(t *hetzner.HetznerAPITarget, a, e, changes *PlacementGroup) error {
+	client := t.Cloud.PlacementGroupClient()
+
+	if a == nil {
+		opts := hcloud.PlacementGroupCreateOpts{
+			Name:   fi.ValueOf(e.Name),
+			Type:   hcloud.PlacementGroupType(e.Type),
+			Labels: e.Labels,
+		}
+		result, _, err := client.Create(context.TODO(), opts)
+		if err != nil {
+			return err
+		}
+		e.ID = fi.PtrTo(result.PlacementGroup.ID)
+
+	} else if len(changes.Labels) != 0 {
+		// Update the labels
+		_, _, err := client.Update(context.TODO(), &hcloud.PlacementGroup{ID: fi.ValueOf(a.ID)}, hcloud.PlacementGroupUpdateOpts{
+			Name:   fi.ValueOf(e.Name),
+			Labels: e.Labels,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type terraformPlacementGroup struct {
+	Name   *string           `cty:"name"`
+	Type   *string           `cty:"type"`
+	Labels map[string]string `cty:"labels"`
+}
+
+func (_ *PlacementGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *PlacementGroup) error {
+	tf := &terraformPlacementGroup{
+		Name:   e.Name,
+		Type:   fi.PtrTo(e.Type),
+		Labels: e.Labels,
+	}
+
+	return t.RenderResource("hcloud_placement_group", fi.ValueOf(e.Name), tf)
+}
+
+func (e *PlacementGroup) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("hcloud_placement_group", fi.ValueOf(e.Name), "id")
+}
@@ -38,6 +38,9 @@ type ServerGroup struct {
 	Lifecycle fi.Lifecycle
 	SSHKeys   []*SSHKey
 	Network   *Network
+	// PlacementGroup is the placement group of the servers, if any.
+	// Servers outside of it need to be replaced, as they cannot join it while running.
+	PlacementGroup *PlacementGroup
 
 	Count      int
 	NeedUpdate []string
@@ -126,6 +129,10 @@ func (v *ServerGroup) Find(c *fi.CloudupContext) (*ServerGroup, error) {
 			actual.NeedUpdate = append(actual.NeedUpdate, server.Name)
 			continue
 		}
+		if v.PlacementGroup != nil && (server.PlacementGroup == nil || server.PlacementGroup.Name != fi.ValueOf(v.PlacementGroup.Name)) {
+			actual.NeedUpdate = append(actual.NeedUpdate, server.Name)
+			continue
+		}
 	}
 
 	return &actual, nil
@@ -245,6 +252,10 @@ func (_ *ServerGroup) RenderHetzner(t *hetzner.HetznerAPITarget, a, e, changes *
 			opts.SSHKeys = append(opts.SSHKeys, &hcloud.SSHKey{ID: fi.ValueOf(sshkey.ID)})
 		}
 
+		if e.PlacementGroup != nil {
+			opts.PlacementGroup = &hcloud.PlacementGroup{ID: fi.ValueOf(e.PlacementGroup.ID)}
+		}
+
 		// Add the user-data hash label
 		opts.Labels[hetzner.TagKubernetesInstanceUserData] = userDataHash
 
@@ -273,16 +284,17 @@ func safeBytesHash(data []byte) string {
 }
 
 type terraformServer struct {
-	Count      *int                       `cty:"count"`
-	Name       *terraformWriter.Literal   `cty:"name"`
-	Location   *string                    `cty:"location"`
-	ServerType *string                    `cty:"server_type"`
-	Image      *string                    `cty:"image"`
-	SSHKeys    []*terraformWriter.Literal `cty:"ssh_keys"`
-	Network    []*terraformServerNetwork  `cty:"network"`
-	PublicNet  *terraformServerPublicNet  `cty:"public_net"`
-	UserData   *terraformWriter.Literal   `cty:"user_data"`
-	Labels     map[string]string          `cty:"labels"`
+	Count            *int                       `cty:"count"`
+	Name             *terraformWriter.Literal   `cty:"name"`
+	Location         *string                    `cty:"location"`
+	ServerType       *string                    `cty:"server_type"`
+	Image            *string                    `cty:"image"`
+	SSHKeys          []*terraformWriter.Literal `cty:"ssh_keys"`
+	Network          []*terraformServerNetwork  `cty:"network"`
+	PublicNet        *terraformServerPublicNet  `cty:"public_net"`
+	PlacementGroupID *terraformWriter.Literal   `cty:"placement_group_id"`
+	UserData         *terraformWriter.Literal   `cty:"user_data"`
+	Labels           map[string]string          `cty:"labels"`
 }
 
 type terraformServerNetwork struct {
@@ -321,6 +333,10 @@ func (_ *ServerGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, change
 		tf.SSHKeys = append(tf.SSHKeys, sshkey.TerraformLink())
 	}
 
+	if e.PlacementGroup != nil {
+		tf.PlacementGroupID = e.PlacementGroup.TerraformLink()
+	}
+
 	if e.UserData != nil {
 		data, err := fi.ResourceAsBytes(e.UserData)
 		if err != nil {
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"k8s.io/klog/v2"
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// readIncrementalState reads the fingerprints of the tasks of the last apply from the state store.
+// It returns nil if there are none, or if they were recorded by another version of kOps.
+func readIncrementalState(ctx context.Context, configBase vfs.Path) (*fi.IncrementalState, error) {
+	state, err := readTaskState(ctx, configBase.Join(registry.PathIncrementalApply))
+	if errors.Is(err, os.ErrNotExist) {
+		klog.Infof("No fingerprints of a previous apply found, running all tasks")
+		return nil, nil
+	}
+	return state, err
+}
+
+// readFailedApplyState reads the progress of the last apply, which failed part-way, from the state store.
+// The tasks that completed are skipped by the retry, unless their desired state changed since.
+func readFailedApplyState(ctx context.Context, configBase vfs.Path) (*fi.IncrementalState, error) {
+	state, err := readTaskState(ctx, configBase.Join(registry.PathFailedApply))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no failed apply to retry, the last apply succeeded")
+	}
+	if err != nil || state == nil {
+		return nil, err
+	}
+
+	klog.Infof("Retrying the failed apply: skipping %d completed tasks, retrying %d failed and %d blocked tasks", len(state.Tasks), len(state.Failed), len(state.Blocked))
+	keys := make([]string, 0, len(state.Failed))
+	for k := range state.Failed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		klog.Infof("Task %q failed: %s", k, state.Failed[k])
+	}
+	// The outcome of the previous attempt is not carried over to the next one
+	state.Failed = nil
+	state.Blocked = nil
+	return state, nil
+}
+
+// readTaskState reads the state of the tasks of an apply from the state store.
+// It returns nil if the state is invalid or was recorded by another version of kOps,
+// and an os.ErrNotExist error if there is none.
+func readTaskState(ctx context.Context, p vfs.Path) (*fi.IncrementalState, error) {
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("error reading %s: %w", p, err)
+	}
+
+	state := &fi.IncrementalState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		klog.Warningf("ignoring invalid fingerprints in %s: %v", p, err)
+		return nil, nil
+	}
+	if state.KopsVersion != kopsbase.Version {
+		klog.Infof("Fingerprints were recorded by kOps %s, running all tasks", state.KopsVersion)
+		return nil, nil
+	}
+	return state, nil
+}
+
+// writeIncrementalState records the fingerprints of the tasks of a successful apply in the state store.
+func writeIncrementalState(ctx context.Context, configBase vfs.Path, cluster *kops.Cluster, state *fi.IncrementalState) error {
+	return writeTaskState(ctx, configBase.Join(registry.PathIncrementalApply), cluster, state)
+}
+
+// writeFailedApplyState records the progress of an apply that failed part-way in the state store,
+// so that it can be retried with --retry-failed.
+func writeFailedApplyState(ctx context.Context, configBase vfs.Path, cluster *kops.Cluster, state *fi.IncrementalState) error {
+	return writeTaskState(ctx, configBase.Join(registry.PathFailedApply), cluster, state)
+}
+
+func writeTaskState(ctx context.Context, p vfs.Path, cluster *kops.Cluster, state *fi.IncrementalState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("error serializing task fingerprints: %w", err)
+	}
+
+	acl, err := acls.GetACL(ctx, p, cluster)
+	if err != nil {
+		return err
+	}
+	if err := p.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	return nil
+}
+
+// removeIncrementalState removes the fingerprints of the last apply, after an apply that failed part-way.
+func removeIncrementalState(ctx context.Context, configBase vfs.Path) {
+	removeTaskState(ctx, configBase.Join(registry.PathIncrementalApply))
+}
+
+// removeFailedApplyState removes the progress of the last failed apply, after an apply that succeeded.
+func removeFailedApplyState(ctx context.Context, configBase vfs.Path) {
+	removeTaskState(ctx, configBase.Join(registry.PathFailedApply))
+}
+
+func removeTaskState(ctx context.Context, p vfs.Path) {
+	if err := p.Remove(ctx); err != nil && !errors.Is(err, os.ErrNotExist) {
+		klog.Warningf("unable to remove %s: %v", p, err)
+	}
+}
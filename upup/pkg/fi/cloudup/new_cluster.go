@@ -914,7 +914,7 @@ func setupControlPlane(opt *NewClusterOptions, cluster *api.Cluster, zoneToSubne
 			default:
 				// Use only the main subnet for control-plane nodes
 				subnet := subnets[0]
-				if opt.IPv6 && opt.Topology == api.TopologyPrivate {
+				if opt.IPv6 && opt.Topology == api.TopologyPrivate && cloudProvider != api.CloudProviderGCE {
 					g.Spec.Subnets = append(g.Spec.Subnets, "dualstack-"+subnet.Name)
 				} else {
 					g.Spec.Subnets = append(g.Spec.Subnets, subnet.Name)
@@ -1319,7 +1319,8 @@ func setupTopology(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.S
 			}
 		}
 
-		if opt.IPv6 {
+		// All the subnets of IPv6 clusters on GCE are dual-stack
+		if opt.IPv6 && cluster.Spec.GetCloudProvider() != api.CloudProviderGCE {
 			var dualStackSubnets []api.ClusterSubnetSpec
 
 			for _, s := range cluster.Spec.Networking.Subnets {
@@ -1411,12 +1412,15 @@ func setupTopology(opt *NewClusterOptions, cluster *api.Cluster, allZones sets.S
 	if opt.IPv6 {
 		cluster.Spec.Networking.NonMasqueradeCIDR = "::/0"
 		cluster.Spec.ExternalCloudControllerManager = &api.CloudControllerManagerConfig{}
-		if cluster.Spec.GetCloudProvider() == api.CloudProviderAWS {
+		switch cluster.Spec.GetCloudProvider() {
+		case api.CloudProviderAWS:
 			for i := range cluster.Spec.Networking.Subnets {
 				cluster.Spec.Networking.Subnets[i].IPv6CIDR = fmt.Sprintf("/64#%x", i)
 			}
-		} else {
-			klog.Errorf("IPv6 support is available only on AWS")
+		case api.CloudProviderGCE:
+			// GCE allocates the IPv6 range of each subnet
+		default:
+			klog.Errorf("IPv6 support is available only on AWS and GCE")
 		}
 	}
 
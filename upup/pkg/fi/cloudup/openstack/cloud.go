@@ -38,6 +38,7 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/zones"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/apiversions"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/l7policies"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/listeners"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/monitors"
@@ -270,7 +271,7 @@ type OpenstackCloud interface {
 	// Returns the availability zones for the service client passed (compute, volume, network)
 	ListAvailabilityZones(serviceClient *gophercloud.ServiceClient) ([]az.AvailabilityZone, error)
 	AssociateToPool(server *servers.Server, poolID string, opts v2pools.CreateMemberOpts) (*v2pools.Member, error)
-	CreatePool(opts v2pools.CreateOpts) (*v2pools.Pool, error)
+	CreatePool(opts v2pools.CreateOptsBuilder) (*v2pools.Pool, error)
 	CreatePoolMonitor(opts monitors.CreateOpts) (*monitors.Monitor, error)
 	GetPool(poolID string) (*v2pools.Pool, error)
 	GetPoolMember(poolID string, memberID string) (*v2pools.Member, error)
@@ -289,6 +290,17 @@ type OpenstackCloud interface {
 
 	// DeleteListener will delete loadbalancer listener
 	DeleteListener(listenerID string) error
+
+	// ListL7Policies will list the L7 policies matching the provided options
+	ListL7Policies(opts l7policies.ListOpts) ([]l7policies.L7Policy, error)
+	CreateL7Policy(opts l7policies.CreateOpts) (*l7policies.L7Policy, error)
+	UpdateL7Policy(policyID string, opts l7policies.UpdateOpts) (*l7policies.L7Policy, error)
+
+	// DeleteL7Policy will delete an L7 policy and its rules
+	DeleteL7Policy(policyID string) error
+	ListL7Rules(policyID string, opts l7policies.ListRulesOpts) ([]l7policies.Rule, error)
+	CreateL7Rule(policyID string, opts l7policies.CreateRuleOpts) (*l7policies.Rule, error)
+	DeleteL7Rule(policyID string, ruleID string) error
 	GetStorageAZFromCompute(azName string) (*az.AvailabilityZone, error)
 	GetL3FloatingIP(id string) (fip *l3floatingip.FloatingIP, err error)
 	GetImage(name string) (i *images.Image, err error)
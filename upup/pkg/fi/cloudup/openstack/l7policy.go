@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstack
+
+import (
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/l7policies"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func (c *openstackCloud) ListL7Policies(opts l7policies.ListOpts) ([]l7policies.L7Policy, error) {
+	return listL7Policies(c, opts)
+}
+
+func listL7Policies(c OpenstackCloud, opts l7policies.ListOpts) (policyList []l7policies.L7Policy, err error) {
+	if c.LoadBalancerClient() == nil {
+		return nil, fmt.Errorf("loadbalancer support not available in this deployment")
+	}
+
+	done, err := vfs.RetryWithBackoff(readBackoff, func() (bool, error) {
+		page, err := l7policies.List(c.LoadBalancerClient(), opts).AllPages()
+		if err != nil {
+			return false, fmt.Errorf("failed to list L7 policies: %v", err)
+		}
+		policyList, err = l7policies.ExtractL7Policies(page)
+		if err != nil {
+			return false, fmt.Errorf("failed to extract L7 policies: %v", err)
+		}
+		return true, nil
+	})
+	if !done {
+		if err == nil {
+			err = wait.ErrWaitTimeout
+		}
+		return policyList, err
+	}
+	return policyList, nil
+}
+
+func (c *openstackCloud) CreateL7Policy(opts l7policies.CreateOpts) (*l7policies.L7Policy, error) {
+	return createL7Policy(c, opts)
+}
+
+func createL7Policy(c OpenstackCloud, opts l7policies.CreateOpts) (policy *l7policies.L7Policy, err error) {
+	if c.LoadBalancerClient() == nil {
+		return nil, fmt.Errorf("loadbalancer support not available in this deployment")
+	}
+
+	done, err := vfs.RetryWithBackoff(writeBackoff, func() (bool, error) {
+		policy, err = l7policies.Create(c.LoadBalancerClient(), opts).Extract()
+		if err != nil {
+			return false, fmt.Errorf("failed to create L7 policy: %v", err)
+		}
+		return true, nil
+	})
+	if !done {
+		if err == nil {
+			err = wait.ErrWaitTimeout
+		}
+		return policy, err
+	}
+	return policy, nil
+}
+
+func (c *openstackCloud) UpdateL7Policy(policyID string, opts l7policies.UpdateOpts) (*l7policies.L7Policy, error) {
+	return updateL7Policy(c, policyID, opts)
+}
+
+func updateL7Policy(c OpenstackCloud, policyID string, opts l7policies.UpdateOpts) (policy *l7policies.L7Policy, err error) {
+	if c.LoadBalancerClient() == nil {
+		return nil, fmt.Errorf("loadbalancer support not available in this deployment")
+	}
+
+	done, err := vfs.RetryWithBackoff(writeBackoff, func() (bool, error) {
+		policy, err = l7policies.Update(c.LoadBalancerClient(), policyID, opts).Extract()
+		if err != nil {
+			return false, fmt.Errorf("failed to update L7 policy: %v", err)
+		}
+		return true, nil
+	})
+	if !done {
+		if err == nil {
+			err = wait.ErrWaitTimeout
+		}
+		return policy, err
+	}
+	return policy, nil
+}
+
+func (c *openstackCloud) DeleteL7Policy(policyID string) error {
+	return deleteL7Policy(c, policyID)
+}
+
+func deleteL7Policy(c OpenstackCloud, policyID string) error {
+	if c.LoadBalancerClient() == nil {
+		return fmt.Errorf("loadbalancer support not available in this deployment")
+	}
+
+	done, err := vfs.RetryWithBackoff(deleteBackoff, func() (bool, error) {
+		err := l7policies.Delete(c.LoadBalancerClient(), policyID).ExtractErr()
+		if err != nil && !isNotFound(err) {
+			return false, fmt.Errorf("error deleting L7 policy: %v", err)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	} else if done {
+		return nil
+	} else {
+		return wait.ErrWaitTimeout
+	}
+}
+
+func (c *openstackCloud) ListL7Rules(policyID string, opts l7policies.ListRulesOpts) ([]l7policies.Rule, error) {
+	return listL7Rules(c, policyID, opts)
+}
+
+func listL7Rules(c OpenstackCloud, policyID string, opts l7policies.ListRulesOpts) (ruleList []l7policies.Rule, err error) {
+	if c.LoadBalancerClient() == nil {
+		return nil, fmt.Errorf("loadbalancer support not available in this deployment")
+	}
+
+	done, err := vfs.RetryWithBackoff(readBackoff, func() (bool, error) {
+		page, err := l7policies.ListRules(c.LoadBalancerClient(), policyID, opts).AllPages()
+		if err != nil {
+			return false, fmt.Errorf("failed to list rules of L7 policy %s: %v", policyID, err)
+		}
+		ruleList, err = l7policies.ExtractRules(page)
+		if err != nil {
+			return false, fmt.Errorf("failed to extract rules of L7 policy %s: %v", policyID, err)
+		}
+		return true, nil
+	})
+	if !done {
+		if err == nil {
+			err = wait.ErrWaitTimeout
+		}
+		return ruleList, err
+	}
+	return ruleList, nil
+}
+
+func (c *openstackCloud) CreateL7Rule(policyID string, opts l7policies.CreateRuleOpts) (*l7policies.Rule, error) {
+	return createL7Rule(c, policyID, opts)
+}
+
+func createL7Rule(c OpenstackCloud, policyID string, opts l7policies.CreateRuleOpts) (rule *l7policies.Rule, err error) {
+	if c.LoadBalancerClient() == nil {
+		return nil, fmt.Errorf("loadbalancer support not available in this deployment")
+	}
+
+	done, err := vfs.RetryWithBackoff(writeBackoff, func() (bool, error) {
+		rule, err = l7policies.CreateRule(c.LoadBalancerClient(), policyID, opts).Extract()
+		if err != nil {
+			return false, fmt.Errorf("failed to create rule of L7 policy %s: %v", policyID, err)
+		}
+		return true, nil
+	})
+	if !done {
+		if err == nil {
+			err = wait.ErrWaitTimeout
+		}
+		return rule, err
+	}
+	return rule, nil
+}
+
+func (c *openstackCloud) DeleteL7Rule(policyID string, ruleID string) error {
+	return deleteL7Rule(c, policyID, ruleID)
+}
+
+func deleteL7Rule(c OpenstackCloud, policyID string, ruleID string) error {
+	if c.LoadBalancerClient() == nil {
+		return fmt.Errorf("loadbalancer support not available in this deployment")
+	}
+
+	done, err := vfs.RetryWithBackoff(deleteBackoff, func() (bool, error) {
+		err := l7policies.DeleteRule(c.LoadBalancerClient(), policyID, ruleID).ExtractErr()
+		if err != nil && !isNotFound(err) {
+			return false, fmt.Errorf("error deleting rule of L7 policy %s: %v", policyID, err)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	} else if done {
+		return nil
+	} else {
+		return wait.ErrWaitTimeout
+	}
+}
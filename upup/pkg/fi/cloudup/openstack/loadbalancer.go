@@ -427,11 +427,11 @@ func associateToPool(c OpenstackCloud, server *servers.Server, poolID string, op
 	return association, nil
 }
 
-func (c *openstackCloud) CreatePool(opts v2pools.CreateOpts) (pool *v2pools.Pool, err error) {
+func (c *openstackCloud) CreatePool(opts v2pools.CreateOptsBuilder) (pool *v2pools.Pool, err error) {
 	return createPool(c, opts)
 }
 
-func createPool(c OpenstackCloud, opts v2pools.CreateOpts) (pool *v2pools.Pool, err error) {
+func createPool(c OpenstackCloud, opts v2pools.CreateOptsBuilder) (pool *v2pools.Pool, err error) {
 	if c.LoadBalancerClient() == nil {
 		return nil, fmt.Errorf("loadbalancer support not available in this deployment")
 	}
@@ -31,6 +31,7 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/recordsets"
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/zones"
 	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/l7policies"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/listeners"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
 	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/monitors"
@@ -193,11 +194,19 @@ func (c *MockCloud) CreateListener(opts listeners.CreateOpts) (listener *listene
 	return createListener(c, opts)
 }
 
+func (c *MockCloud) CreateL7Policy(opts l7policies.CreateOpts) (*l7policies.L7Policy, error) {
+	return createL7Policy(c, opts)
+}
+
+func (c *MockCloud) CreateL7Rule(policyID string, opts l7policies.CreateRuleOpts) (*l7policies.Rule, error) {
+	return createL7Rule(c, policyID, opts)
+}
+
 func (c *MockCloud) CreateNetwork(opt networks.CreateOptsBuilder) (*networks.Network, error) {
 	return createNetwork(c, opt)
 }
 
-func (c *MockCloud) CreatePool(opts v2pools.CreateOpts) (pool *v2pools.Pool, err error) {
+func (c *MockCloud) CreatePool(opts v2pools.CreateOptsBuilder) (pool *v2pools.Pool, err error) {
 	return createPool(c, opts)
 }
 
@@ -265,6 +274,14 @@ func (c *MockCloud) DeleteListener(listenerID string) error {
 	return deleteListener(c, listenerID)
 }
 
+func (c *MockCloud) DeleteL7Policy(policyID string) error {
+	return deleteL7Policy(c, policyID)
+}
+
+func (c *MockCloud) DeleteL7Rule(policyID string, ruleID string) error {
+	return deleteL7Rule(c, policyID, ruleID)
+}
+
 func (c *MockCloud) DeleteMonitor(monitorID string) error {
 	return deleteMonitor(c, monitorID)
 }
@@ -421,6 +438,10 @@ func (c *MockCloud) ListL3FloatingIPs(opts l3floatingip.ListOpts) (fips []l3floa
 	return listL3FloatingIPs(c, opts)
 }
 
+func (c *MockCloud) UpdateL7Policy(policyID string, opts l7policies.UpdateOpts) (*l7policies.L7Policy, error) {
+	return updateL7Policy(c, policyID, opts)
+}
+
 func (c *MockCloud) UpdateMemberInPool(poolID string, memberID string, opts v2pools.UpdateMemberOptsBuilder) (*v2pools.Member, error) {
 	return updateMemberInPool(c, poolID, memberID, opts)
 }
@@ -441,6 +462,14 @@ func (c *MockCloud) ListListeners(opts listeners.ListOpts) (listenerList []liste
 	return listListeners(c, opts)
 }
 
+func (c *MockCloud) ListL7Policies(opts l7policies.ListOpts) ([]l7policies.L7Policy, error) {
+	return listL7Policies(c, opts)
+}
+
+func (c *MockCloud) ListL7Rules(policyID string, opts l7policies.ListRulesOpts) ([]l7policies.Rule, error) {
+	return listL7Rules(c, policyID, opts)
+}
+
 func (c *MockCloud) ListMonitors(opts monitors.ListOpts) (monitorList []monitors.Monitor, err error) {
 	return listMonitors(c, opts)
 }
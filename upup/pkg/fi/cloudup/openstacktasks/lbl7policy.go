@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openstacktasks
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/l7policies"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+)
+
+// LBL7Policy is an L7 policy of a listener, which routes the requests matching all its rules.
+// +kops:fitask
+type LBL7Policy struct {
+	ID        *string
+	Name      *string
+	Lifecycle fi.Lifecycle
+	Listener  *LBListener
+	// Action is either REDIRECT_TO_POOL or REJECT.
+	Action *string
+	// Position is the position of the policy in the list of policies of the listener.
+	Position *int
+	// RedirectPool is the pool that requests are sent to with REDIRECT_TO_POOL.
+	RedirectPool *LBPool
+	Rules        []LBL7Rule
+}
+
+// LBL7Rule is a condition of an L7 policy.
+type LBL7Rule struct {
+	Type        string
+	CompareType string
+	Key         string
+	Value       string
+	Invert      bool
+}
+
+// GetDependencies returns the dependencies of the LBL7Policy task
+func (p *LBL7Policy) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
+	var deps []fi.CloudupTask
+	for _, task := range tasks {
+		if _, ok := task.(*LBListener); ok {
+			deps = append(deps, task)
+		}
+		if _, ok := task.(*LBPool); ok {
+			deps = append(deps, task)
+		}
+	}
+	return deps
+}
+
+var _ fi.CompareWithID = &LBL7Policy{}
+
+func (p *LBL7Policy) CompareWithID() *string {
+	return p.ID
+}
+
+// sortL7Rules sorts rules for consistent comparison, as their order does not matter.
+func sortL7Rules(rules []LBL7Rule) {
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Type != rules[j].Type {
+			return rules[i].Type < rules[j].Type
+		}
+		if rules[i].Key != rules[j].Key {
+			return rules[i].Key < rules[j].Key
+		}
+		if rules[i].CompareType != rules[j].CompareType {
+			return rules[i].CompareType < rules[j].CompareType
+		}
+		return rules[i].Value < rules[j].Value
+	})
+}
+
+func (p *LBL7Policy) Find(context *fi.CloudupContext) (*LBL7Policy, error) {
+	if p.Listener == nil || p.Listener.ID == nil {
+		return nil, nil
+	}
+
+	cloud := context.T.Cloud.(openstack.OpenstackCloud)
+	rs, err := cloud.ListL7Policies(l7policies.ListOpts{
+		Name:       fi.ValueOf(p.Name),
+		ListenerID: fi.ValueOf(p.Listener.ID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) == 0 {
+		return nil, nil
+	} else if len(rs) != 1 {
+		return nil, fmt.Errorf("found multiple L7 policies with name: %s", fi.ValueOf(p.Name))
+	}
+	found := rs[0]
+
+	rules, err := cloud.ListL7Rules(found.ID, l7policies.ListRulesOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	actual := &LBL7Policy{
+		ID:        fi.PtrTo(found.ID),
+		Name:      fi.PtrTo(found.Name),
+		Lifecycle: p.Lifecycle,
+		Listener:  p.Listener,
+		Action:    fi.PtrTo(found.Action),
+		Position:  fi.PtrTo(int(found.Position)),
+	}
+	if found.RedirectPoolID != "" {
+		actual.RedirectPool = &LBPool{ID: fi.PtrTo(found.RedirectPoolID)}
+	}
+	for _, rule := range rules {
+		actual.Rules = append(actual.Rules, LBL7Rule{
+			Type:        rule.RuleType,
+			CompareType: rule.CompareType,
+			Key:         rule.Key,
+			Value:       rule.Value,
+			Invert:      rule.Invert,
+		})
+	}
+	sortL7Rules(actual.Rules)
+	sortL7Rules(p.Rules)
+
+	p.ID = actual.ID
+	return actual, nil
+}
+
+func (p *LBL7Policy) Run(context *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(p, context)
+}
+
+func (_ *LBL7Policy) CheckChanges(a, e, changes *LBL7Policy) error {
+	if a == nil {
+		if e.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if len(e.Rules) == 0 {
+			return fi.RequiredField("Rules")
+		}
+	} else {
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Name != nil {
+			return fi.CannotChangeField("Name")
+		}
+	}
+	return nil
+}
+
+func (_ *LBL7Policy) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes *LBL7Policy) error {
+	// Octavia rejects changes to a load balancer until the previous change is applied
+	waitActive := func() error {
+		lbID := fi.ValueOf(e.Listener.Pool.Loadbalancer.ID)
+		provisioningStatus, err := waitLoadbalancerActiveProvisioningStatus(t.Cloud.LoadBalancerClient(), lbID)
+		if err != nil {
+			return fmt.Errorf("failed to loadbalancer ACTIVE provisioning status %v: %v", provisioningStatus, err)
+		}
+		return nil
+	}
+
+	var redirectPoolID string
+	if e.RedirectPool != nil {
+		redirectPoolID = fi.ValueOf(e.RedirectPool.ID)
+	}
+
+	if a == nil {
+		klog.V(2).Infof("Creating L7 policy with Name: %q", fi.ValueOf(e.Name))
+		if err := waitActive(); err != nil {
+			return err
+		}
+		policy, err := t.Cloud.CreateL7Policy(l7policies.CreateOpts{
+			Name:           fi.ValueOf(e.Name),
+			ListenerID:     fi.ValueOf(e.Listener.ID),
+			Action:         l7policies.Action(fi.ValueOf(e.Action)),
+			Position:       int32(fi.ValueOf(e.Position)),
+			RedirectPoolID: redirectPoolID,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating L7 policy: %v", err)
+		}
+		e.ID = fi.PtrTo(policy.ID)
+		return createL7Rules(t, e, waitActive)
+	}
+
+	if changes.Action != nil || changes.Position != nil || changes.RedirectPool != nil {
+		klog.V(2).Infof("Updating L7 policy with Name: %q", fi.ValueOf(e.Name))
+		if err := waitActive(); err != nil {
+			return err
+		}
+		_, err := t.Cloud.UpdateL7Policy(fi.ValueOf(a.ID), l7policies.UpdateOpts{
+			Action:         l7policies.Action(fi.ValueOf(e.Action)),
+			Position:       int32(fi.ValueOf(e.Position)),
+			RedirectPoolID: &redirectPoolID,
+		})
+		if err != nil {
+			return fmt.Errorf("error updating L7 policy: %v", err)
+		}
+	}
+
+	if changes.Rules != nil {
+		klog.V(2).Infof("Replacing rules of L7 policy with Name: %q", fi.ValueOf(e.Name))
+		rules, err := t.Cloud.ListL7Rules(fi.ValueOf(a.ID), l7policies.ListRulesOpts{})
+		if err != nil {
+			return err
+		}
+		for _, rule := range rules {
+			if err := waitActive(); err != nil {
+				return err
+			}
+			if err := t.Cloud.DeleteL7Rule(fi.ValueOf(a.ID), rule.ID); err != nil {
+				return fmt.Errorf("error deleting rule of L7 policy: %v", err)
+			}
+		}
+		e.ID = a.ID
+		return createL7Rules(t, e, waitActive)
+	}
+
+	return nil
+}
+
+func createL7Rules(t *openstack.OpenstackAPITarget, e *LBL7Policy, waitActive func() error) error {
+	for _, rule := range e.Rules {
+		if err := waitActive(); err != nil {
+			return err
+		}
+		_, err := t.Cloud.CreateL7Rule(fi.ValueOf(e.ID), l7policies.CreateRuleOpts{
+			RuleType:    l7policies.RuleType(rule.Type),
+			CompareType: l7policies.CompareType(rule.CompareType),
+			Key:         rule.Key,
+			Value:       rule.Value,
+			Invert:      rule.Invert,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating rule of L7 policy: %v", err)
+		}
+	}
+	return nil
+}
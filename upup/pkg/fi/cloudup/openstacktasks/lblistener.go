@@ -34,6 +34,10 @@ type LBListener struct {
 	Pool         *LBPool
 	Lifecycle    fi.Lifecycle
 	AllowedCIDRs []string
+	// Protocol is the protocol of the listener, TCP by default.
+	Protocol *string
+	// TLSContainerRef is the Barbican secret container of the certificate of TERMINATED_HTTPS listeners.
+	TLSContainerRef *string
 }
 
 // GetDependencies returns the dependencies of the Instance task
@@ -64,8 +68,12 @@ func NewLBListenerTaskFromCloud(cloud openstack.OpenstackCloud, lifecycle fi.Lif
 		Name:         fi.PtrTo(listener.Name),
 		Port:         fi.PtrTo(listener.ProtocolPort),
 		AllowedCIDRs: listener.AllowedCIDRs,
+		Protocol:     fi.PtrTo(listener.Protocol),
 		Lifecycle:    lifecycle,
 	}
+	if listener.DefaultTlsContainerRef != "" {
+		listenerTask.TLSContainerRef = fi.PtrTo(listener.DefaultTlsContainerRef)
+	}
 
 	if len(listener.Pools) > 0 {
 		for _, pool := range listener.Pools {
@@ -137,6 +145,9 @@ func (_ *LBListener) CheckChanges(a, e, changes *LBListener) error {
 		if changes.Name != nil {
 			return fi.CannotChangeField("Name")
 		}
+		if changes.Protocol != nil {
+			return fi.CannotChangeField("Protocol")
+		}
 	}
 	return nil
 }
@@ -149,12 +160,17 @@ func (_ *LBListener) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, chan
 
 	if a == nil {
 		klog.V(2).Infof("Creating LB with Name: %q", fi.ValueOf(e.Name))
+		protocol := listeners.ProtocolTCP
+		if e.Protocol != nil {
+			protocol = listeners.Protocol(fi.ValueOf(e.Protocol))
+		}
 		listeneropts := listeners.CreateOpts{
-			Name:           fi.ValueOf(e.Name),
-			DefaultPoolID:  fi.ValueOf(e.Pool.ID),
-			LoadbalancerID: fi.ValueOf(e.Pool.Loadbalancer.ID),
-			Protocol:       listeners.ProtocolTCP,
-			ProtocolPort:   fi.ValueOf(e.Port),
+			Name:                   fi.ValueOf(e.Name),
+			DefaultPoolID:          fi.ValueOf(e.Pool.ID),
+			LoadbalancerID:         fi.ValueOf(e.Pool.Loadbalancer.ID),
+			Protocol:               protocol,
+			ProtocolPort:           fi.ValueOf(e.Port),
+			DefaultTlsContainerRef: fi.ValueOf(e.TLSContainerRef),
 		}
 
 		if useVIPACL && (fi.ValueOf(e.Pool.Loadbalancer.Provider) != "ovn") {
@@ -167,7 +183,20 @@ func (_ *LBListener) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, chan
 		}
 		e.ID = fi.PtrTo(listener.ID)
 		return nil
-	} else if len(changes.AllowedCIDRs) > 0 {
+	}
+
+	if changes.TLSContainerRef != nil {
+		klog.V(2).Infof("Updating certificate of LB listener %q", fi.ValueOf(a.Name))
+		opts := listeners.UpdateOpts{
+			DefaultTlsContainerRef: changes.TLSContainerRef,
+		}
+		_, err := listeners.Update(t.Cloud.LoadBalancerClient(), fi.ValueOf(a.ID), opts).Extract()
+		if err != nil {
+			return fmt.Errorf("error updating LB listener: %v", err)
+		}
+	}
+
+	if len(changes.AllowedCIDRs) > 0 {
 		if useVIPACL && (fi.ValueOf(a.Pool.Loadbalancer.Provider) != "ovn") {
 			opts := listeners.UpdateOpts{
 				AllowedCIDRs: &changes.AllowedCIDRs,
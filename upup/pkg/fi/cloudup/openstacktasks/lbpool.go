@@ -31,6 +31,10 @@ type LBPool struct {
 	Name         *string
 	Lifecycle    fi.Lifecycle
 	Loadbalancer *LB
+	// Protocol is the protocol of the members of the pool, TCP by default.
+	Protocol *string
+	// TLSEnabled encrypts the requests to the members of HTTP pools.
+	TLSEnabled *bool
 }
 
 // GetDependencies returns the dependencies of the Instance task
@@ -58,8 +62,13 @@ func NewLBPoolTaskFromCloud(cloud openstack.OpenstackCloud, lifecycle fi.Lifecyc
 	a := &LBPool{
 		ID:        fi.PtrTo(pool.ID),
 		Name:      fi.PtrTo(pool.Name),
+		Protocol:  fi.PtrTo(pool.Protocol),
 		Lifecycle: lifecycle,
 	}
+	if find != nil {
+		// The version of the pools API that we use does not return tls_enabled
+		a.TLSEnabled = find.TLSEnabled
+	}
 	if len(pool.Loadbalancers) == 1 {
 		lbID := pool.Loadbalancers[0]
 		lb, err := cloud.GetLB(lbID.ID)
@@ -119,6 +128,9 @@ func (_ *LBPool) CheckChanges(a, e, changes *LBPool) error {
 		if changes.Name != nil {
 			return fi.CannotChangeField("Name")
 		}
+		if changes.Protocol != nil {
+			return fi.CannotChangeField("Protocol")
+		}
 	}
 	return nil
 }
@@ -136,11 +148,18 @@ func (_ *LBPool) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes
 		if fi.ValueOf(e.Loadbalancer.Provider) == "ovn" {
 			LbMethod = v2pools.LBMethodSourceIpPort
 		}
-		poolopts := v2pools.CreateOpts{
-			Name:           fi.ValueOf(e.Name),
-			LBMethod:       LbMethod,
-			Protocol:       v2pools.ProtocolTCP,
-			LoadbalancerID: fi.ValueOf(e.Loadbalancer.ID),
+		protocol := v2pools.ProtocolTCP
+		if e.Protocol != nil {
+			protocol = v2pools.Protocol(fi.ValueOf(e.Protocol))
+		}
+		poolopts := poolCreateOpts{
+			CreateOpts: v2pools.CreateOpts{
+				Name:           fi.ValueOf(e.Name),
+				LBMethod:       LbMethod,
+				Protocol:       protocol,
+				LoadbalancerID: fi.ValueOf(e.Loadbalancer.ID),
+			},
+			TLSEnabled: fi.ValueOf(e.TLSEnabled),
 		}
 		pool, err := t.Cloud.CreatePool(poolopts)
 		if err != nil {
@@ -154,3 +173,20 @@ func (_ *LBPool) RenderOpenstack(t *openstack.OpenstackAPITarget, a, e, changes
 	klog.V(2).Infof("Openstack task LB::RenderOpenstack did nothing")
 	return nil
 }
+
+// poolCreateOpts adds tls_enabled, which the version of the pools API that we use does not support.
+type poolCreateOpts struct {
+	v2pools.CreateOpts
+	TLSEnabled bool
+}
+
+func (opts poolCreateOpts) ToPoolCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOpts.ToPoolCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if opts.TLSEnabled {
+		b["pool"].(map[string]interface{})["tls_enabled"] = true
+	}
+	return b, nil
+}
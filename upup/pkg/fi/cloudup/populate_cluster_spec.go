@@ -155,6 +155,10 @@ func (c *populateClusterSpec) run(ctx context.Context, clientset simple.Clientse
 					etcdInstanceGroups[instanceGroupName] = m
 				}
 
+				for _, warning := range validation.EtcdTimingWarnings(etcd) {
+					klog.Warning(warning)
+				}
+
 				if (len(etcdNames) % 2) == 0 {
 					// Not technically a requirement, but doesn't really make sense to allow
 					return fmt.Errorf("there should be an odd number of control-plane-zones, for etcd's quorum.  Hint: Use --zones and --control-plane-zones to declare worker and control plane node zones separately")
@@ -260,6 +264,11 @@ func (c *populateClusterSpec) run(ctx context.Context, clientset simple.Clientse
 		cluster.Spec.API.LoadBalancer.Class = kopsapi.LoadBalancerClassClassic
 	}
 
+	// The internal API DNS name points to the internal load balancer created alongside a public one.
+	if cluster.Spec.API.LoadBalancer != nil && cluster.Spec.API.LoadBalancer.Internal != nil {
+		cluster.Spec.API.LoadBalancer.UseForInternalAPI = true
+	}
+
 	if cluster.Spec.DNSZone == "" && cluster.PublishesDNSRecords() {
 		dns, err := cloud.DNS()
 		if err != nil {
@@ -329,6 +338,8 @@ func (c *populateClusterSpec) run(ctx context.Context, clientset simple.Clientse
 			codeModels = append(codeModels, &components.ClusterAutoscalerOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.NodeTerminationHandlerOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.NodeProblemDetectorOptionsBuilder{OptionsContext: optionsContext})
+			codeModels = append(codeModels, &components.EventExporterOptionsBuilder{OptionsContext: optionsContext})
+			codeModels = append(codeModels, &components.PrometheusAgentOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.AWSOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.AWSEBSCSIDriverOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.AWSCloudControllerManagerOptionsBuilder{OptionsContext: optionsContext})
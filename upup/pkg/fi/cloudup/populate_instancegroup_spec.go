@@ -207,6 +207,15 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 			}
 			hasGPU = mt.GPU
 		}
+
+		// Instances of any architecture could be selected by the instance requirements, but they all run the image of the machine type
+		if ig.Spec.MixedInstancesPolicy != nil && ig.Spec.MixedInstancesPolicy.InstanceRequirements != nil && len(ig.Spec.MixedInstancesPolicy.InstanceRequirements.Architectures) == 0 {
+			architecture, err := MachineArchitecture(cloud, ig.Spec.MachineType)
+			if err != nil {
+				return nil, fmt.Errorf("unable to determine machine architecture for InstanceGroup %q: %v", ig.ObjectMeta.Name, err)
+			}
+			ig.Spec.MixedInstancesPolicy.InstanceRequirements.Architectures = []string{string(architecture)}
+		}
 	case kops.CloudProviderOpenstack:
 		if igNvidia {
 			hasGPU = true
@@ -299,6 +308,10 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 
 	ig.Spec.Kubelet = igKubeletConfig
 
+	for _, warning := range validation.LogDiskUsageWarnings(cluster, ig) {
+		klog.Warning(warning)
+	}
+
 	return ig, nil
 }
 
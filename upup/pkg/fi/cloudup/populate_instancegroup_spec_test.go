@@ -235,6 +235,32 @@ func TestPopulateInstanceGroup_AddTaints(t *testing.T) {
 	}
 }
 
+func TestPopulateInstanceGroup_InstanceRequirementsArchitectures(t *testing.T) {
+	_, cluster := buildMinimalCluster()
+	input := buildMinimalNodeInstanceGroup()
+	input.Spec.MachineType = "a1.large"
+	input.Spec.MixedInstancesPolicy = &kopsapi.MixedInstancesPolicySpec{
+		InstanceRequirements: &kopsapi.InstanceRequirementsSpec{},
+	}
+
+	channel := &kopsapi.Channel{}
+
+	cloud, err := BuildCloud(cluster)
+	if err != nil {
+		t.Fatalf("error from BuildCloud: %v", err)
+	}
+	output, err := PopulateInstanceGroupSpec(cluster, input, cloud, channel)
+	if err != nil {
+		t.Fatalf("error from PopulateInstanceGroupSpec: %v", err)
+	}
+	if actual := output.Spec.MixedInstancesPolicy.InstanceRequirements.Architectures; len(actual) != 1 || actual[0] != "arm64" {
+		t.Errorf("Expected the architecture of the machine type, got %v", actual)
+	}
+	if len(input.Spec.MixedInstancesPolicy.InstanceRequirements.Architectures) != 0 {
+		t.Errorf("Expected the input to be unchanged")
+	}
+}
+
 func expectErrorFromPopulateInstanceGroup(t *testing.T, cluster *kopsapi.Cluster, g *kopsapi.InstanceGroup, channel *kopsapi.Channel, message string) {
 	cloud, err := BuildCloud(cluster)
 	if err != nil {
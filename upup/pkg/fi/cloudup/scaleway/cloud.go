@@ -27,6 +27,8 @@ import (
 	ipam "github.com/scaleway/scaleway-sdk-go/api/ipam/v1alpha1"
 	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
 	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -65,6 +67,8 @@ type ScwCloud interface {
 	IPAMService() *ipam.API
 	LBService() *lb.ZonedAPI
 	MarketplaceService() *marketplace.API
+	VPCService() *vpc.API
+	GatewayService() *vpcgw.API
 
 	DeleteGroup(group *cloudinstances.CloudInstanceGroup) error
 	DeleteInstance(i *cloudinstances.CloudInstance) error
@@ -76,17 +80,23 @@ type ScwCloud interface {
 	GetCloudGroups(cluster *kops.Cluster, instancegroups []*kops.InstanceGroup, warnUnmatched bool, nodes []v1.Node) (map[string]*cloudinstances.CloudInstanceGroup, error)
 
 	GetClusterDNSRecords(clusterName string) ([]*domain.Record, error)
+	GetClusterGateways(clusterName string) ([]*vpcgw.Gateway, error)
 	GetClusterLoadBalancers(clusterName string) ([]*lb.LB, error)
+	GetClusterPrivateNetworks(clusterName string) ([]*vpc.PrivateNetwork, error)
 	GetClusterServers(clusterName string, instanceGroupName *string) ([]*instance.Server, error)
 	GetClusterSSHKeys(clusterName string) ([]*iam.SSHKey, error)
 	GetClusterVolumes(clusterName string) ([]*instance.Volume, error)
+	GetClusterVPCs(clusterName string) ([]*vpc.VPC, error)
 	GetServerIP(serverID string, zone scw.Zone) (string, error)
 
 	DeleteDNSRecord(record *domain.Record, clusterName string) error
+	DeleteGateway(gateway *vpcgw.Gateway) error
 	DeleteLoadBalancer(loadBalancer *lb.LB) error
+	DeletePrivateNetwork(privateNetwork *vpc.PrivateNetwork) error
 	DeleteServer(server *instance.Server) error
 	DeleteSSHKey(sshkey *iam.SSHKey) error
 	DeleteVolume(volume *instance.Volume) error
+	DeleteVPC(v *vpc.VPC) error
 }
 
 // static compile time check to validate ScwCloud's fi.Cloud Interface.
@@ -106,6 +116,8 @@ type scwCloudImplementation struct {
 	ipamAPI        *ipam.API
 	lbAPI          *lb.ZonedAPI
 	marketplaceAPI *marketplace.API
+	vpcAPI         *vpc.API
+	gatewayAPI     *vpcgw.API
 }
 
 // NewScwCloud returns a Cloud with a Scaleway Client using the env vars SCW_PROFILE or
@@ -161,6 +173,8 @@ func NewScwCloud(tags map[string]string) (ScwCloud, error) {
 		ipamAPI:        ipam.NewAPI(scwClient),
 		lbAPI:          lb.NewZonedAPI(scwClient),
 		marketplaceAPI: marketplace.NewAPI(scwClient),
+		vpcAPI:         vpc.NewAPI(scwClient),
+		gatewayAPI:     vpcgw.NewAPI(scwClient),
 	}, nil
 }
 
@@ -218,6 +232,14 @@ func (s *scwCloudImplementation) MarketplaceService() *marketplace.API {
 	return s.marketplaceAPI
 }
 
+func (s *scwCloudImplementation) VPCService() *vpc.API {
+	return s.vpcAPI
+}
+
+func (s *scwCloudImplementation) GatewayService() *vpcgw.API {
+	return s.gatewayAPI
+}
+
 func (s *scwCloudImplementation) DeleteGroup(group *cloudinstances.CloudInstanceGroup) error {
 	toDelete := append(group.NeedUpdate, group.Ready...)
 	for _, cloudInstance := range toDelete {
@@ -438,6 +460,17 @@ func (s *scwCloudImplementation) GetClusterDNSRecords(clusterName string) ([]*do
 	return clusterDNSRecords, nil
 }
 
+func (s *scwCloudImplementation) GetClusterGateways(clusterName string) ([]*vpcgw.Gateway, error) {
+	gateways, err := s.gatewayAPI.ListGateways(&vpcgw.ListGatewaysRequest{
+		Zone: s.zone,
+		Tags: []string{TagClusterName + "=" + clusterName},
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster public gateways: %w", err)
+	}
+	return gateways.Gateways, nil
+}
+
 func (s *scwCloudImplementation) GetClusterLoadBalancers(clusterName string) ([]*lb.LB, error) {
 	loadBalancerName := "api." + clusterName
 	lbs, err := s.lbAPI.ListLBs(&lb.ZonedAPIListLBsRequest{
@@ -450,6 +483,17 @@ func (s *scwCloudImplementation) GetClusterLoadBalancers(clusterName string) ([]
 	return lbs.LBs, nil
 }
 
+func (s *scwCloudImplementation) GetClusterPrivateNetworks(clusterName string) ([]*vpc.PrivateNetwork, error) {
+	privateNetworks, err := s.vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Region: s.region,
+		Tags:   []string{TagClusterName + "=" + clusterName},
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster private networks: %w", err)
+	}
+	return privateNetworks.PrivateNetworks, nil
+}
+
 func (s *scwCloudImplementation) GetClusterServers(clusterName string, instanceGroupName *string) ([]*instance.Server, error) {
 	tags := []string{TagClusterName + "=" + clusterName}
 	if instanceGroupName != nil {
@@ -495,12 +539,47 @@ func (s *scwCloudImplementation) GetClusterVolumes(clusterName string) ([]*insta
 	return volumes.Volumes, nil
 }
 
+func (s *scwCloudImplementation) GetClusterVPCs(clusterName string) ([]*vpc.VPC, error) {
+	vpcs, err := s.vpcAPI.ListVPCs(&vpc.ListVPCsRequest{
+		Region: s.region,
+		Tags:   []string{TagClusterName + "=" + clusterName},
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing cluster VPCs: %w", err)
+	}
+	return vpcs.Vpcs, nil
+}
+
+// GetServerIP returns the IPv4 address of the server in its private network if it is attached to one,
+// or its public IPv4 address otherwise.
 func (s *scwCloudImplementation) GetServerIP(serverID string, zone scw.Zone) (string, error) {
 	region, err := zone.Region()
 	if err != nil {
 		return "", fmt.Errorf("converting zone %s to region: %w", zone, err)
 	}
 
+	server, err := s.instanceAPI.GetServer(&instance.GetServerRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("getting server %s: %w", serverID, err)
+	}
+	for _, nic := range server.Server.PrivateNics {
+		ips, err := s.ipamAPI.ListIPs(&ipam.ListIPsRequest{
+			Region:           region,
+			IsIPv6:           fi.PtrTo(false),
+			ResourceID:       &nic.ID,
+			PrivateNetworkID: &nic.PrivateNetworkID,
+		}, scw.WithAllPages())
+		if err != nil {
+			return "", fmt.Errorf("listing private IPs for server %s: %w", serverID, err)
+		}
+		if len(ips.IPs) > 0 {
+			return ips.IPs[0].Address.IP.String(), nil
+		}
+	}
+
 	ips, err := s.ipamAPI.ListIPs(&ipam.ListIPsRequest{
 		Region:     region,
 		IsIPv6:     fi.PtrTo(false),
@@ -544,6 +623,64 @@ func (s *scwCloudImplementation) DeleteDNSRecord(record *domain.Record, clusterN
 	return nil
 }
 
+func (s *scwCloudImplementation) DeleteGateway(gateway *vpcgw.Gateway) error {
+	// The gateway networks are detached first, as a gateway attached to private networks cannot be deleted
+	for _, gatewayNetwork := range gateway.GatewayNetworks {
+		err := s.gatewayAPI.DeleteGatewayNetwork(&vpcgw.DeleteGatewayNetworkRequest{
+			Zone:             gateway.Zone,
+			GatewayNetworkID: gatewayNetwork.ID,
+		})
+		if err != nil && !is404Error(err) {
+			return fmt.Errorf("detaching public gateway %s from private network %s: %w", gateway.ID, gatewayNetwork.PrivateNetworkID, err)
+		}
+		_, err = s.gatewayAPI.WaitForGatewayNetwork(&vpcgw.WaitForGatewayNetworkRequest{
+			GatewayNetworkID: gatewayNetwork.ID,
+			Zone:             gateway.Zone,
+		})
+		if !is404Error(err) {
+			return fmt.Errorf("waiting for gateway network %s after deletion: %w", gatewayNetwork.ID, err)
+		}
+	}
+
+	_, err := s.gatewayAPI.WaitForGateway(&vpcgw.WaitForGatewayRequest{
+		GatewayID: gateway.ID,
+		Zone:      gateway.Zone,
+	})
+	if err != nil {
+		if is404Error(err) {
+			klog.V(8).Infof("Public gateway %q (%s) was already deleted", gateway.Name, gateway.ID)
+			return nil
+		}
+		return fmt.Errorf("waiting for public gateway: %w", err)
+	}
+	err = s.gatewayAPI.DeleteGateway(&vpcgw.DeleteGatewayRequest{
+		Zone:      gateway.Zone,
+		GatewayID: gateway.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting public gateway %s: %w", gateway.ID, err)
+	}
+
+	// We wait for the gateway to be deleted, then we release its IP
+	_, err = s.gatewayAPI.WaitForGateway(&vpcgw.WaitForGatewayRequest{
+		GatewayID: gateway.ID,
+		Zone:      gateway.Zone,
+	})
+	if !is404Error(err) {
+		return fmt.Errorf("waiting for public gateway %s after deletion: %w", gateway.ID, err)
+	}
+	if gateway.IP != nil {
+		err = s.gatewayAPI.DeleteIP(&vpcgw.DeleteIPRequest{
+			Zone: gateway.Zone,
+			IPID: gateway.IP.ID,
+		})
+		if err != nil && !is404Error(err) {
+			return fmt.Errorf("deleting public gateway IP: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *scwCloudImplementation) DeleteLoadBalancer(loadBalancer *lb.LB) error {
 	ipsToRelease := loadBalancer.IP
 
@@ -587,6 +724,21 @@ func (s *scwCloudImplementation) DeleteLoadBalancer(loadBalancer *lb.LB) error {
 	return nil
 }
 
+func (s *scwCloudImplementation) DeletePrivateNetwork(privateNetwork *vpc.PrivateNetwork) error {
+	err := s.vpcAPI.DeletePrivateNetwork(&vpc.DeletePrivateNetworkRequest{
+		Region:           privateNetwork.Region,
+		PrivateNetworkID: privateNetwork.ID,
+	})
+	if err != nil {
+		if is404Error(err) {
+			klog.V(8).Infof("Private network %q (%s) was already deleted", privateNetwork.Name, privateNetwork.ID)
+			return nil
+		}
+		return fmt.Errorf("deleting private network %s: %w", privateNetwork.ID, err)
+	}
+	return nil
+}
+
 func (s *scwCloudImplementation) DeleteServer(server *instance.Server) error {
 	srv, err := s.instanceAPI.GetServer(&instance.GetServerRequest{
 		Zone:     s.zone,
@@ -680,3 +832,18 @@ func (s *scwCloudImplementation) DeleteVolume(volume *instance.Volume) error {
 
 	return nil
 }
+
+func (s *scwCloudImplementation) DeleteVPC(v *vpc.VPC) error {
+	err := s.vpcAPI.DeleteVPC(&vpc.DeleteVPCRequest{
+		Region: v.Region,
+		VpcID:  v.ID,
+	})
+	if err != nil {
+		if is404Error(err) {
+			klog.V(8).Infof("VPC %q (%s) was already deleted", v.Name, v.ID)
+			return nil
+		}
+		return fmt.Errorf("deleting VPC %s: %w", v.ID, err)
+	}
+	return nil
+}
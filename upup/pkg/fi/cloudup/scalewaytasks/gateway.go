@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"fmt"
+
+	vpcgw "github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+const GatewayDefaultType = "VPC-GW-S"
+
+// Gateway is a public gateway, which gives the servers of a private network access to the internet.
+// +kops:fitask
+type Gateway struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID             *string
+	Zone           *string
+	Type           string
+	Tags           []string
+	PrivateNetwork *PrivateNetwork
+}
+
+var _ fi.CompareWithID = &Gateway{}
+
+func (g *Gateway) CompareWithID() *string {
+	return g.ID
+}
+
+func (g *Gateway) Find(c *fi.CloudupContext) (*Gateway, error) {
+	cloud := c.T.Cloud.(scaleway.ScwCloud)
+	gatewayService := cloud.GatewayService()
+
+	gateways, err := gatewayService.ListGateways(&vpcgw.ListGatewaysRequest{
+		Zone: scw.Zone(fi.ValueOf(g.Zone)),
+		Name: g.Name,
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, fmt.Errorf("listing public gateways: %w", err)
+	}
+
+	var found *vpcgw.Gateway
+	for _, gateway := range gateways.Gateways {
+		// The API matches names partially
+		if gateway.Name == fi.ValueOf(g.Name) {
+			if found != nil {
+				return nil, fmt.Errorf("found multiple public gateways named %q", fi.ValueOf(g.Name))
+			}
+			found = gateway
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+
+	actual := &Gateway{
+		Name:      g.Name,
+		Lifecycle: g.Lifecycle,
+		ID:        fi.PtrTo(found.ID),
+		Zone:      fi.PtrTo(found.Zone.String()),
+		Tags:      found.Tags,
+	}
+	if found.Type != nil {
+		actual.Type = found.Type.Name
+	}
+	for _, gatewayNetwork := range found.GatewayNetworks {
+		if g.PrivateNetwork != nil && gatewayNetwork.PrivateNetworkID == fi.ValueOf(g.PrivateNetwork.ID) {
+			actual.PrivateNetwork = g.PrivateNetwork
+		}
+	}
+
+	g.ID = actual.ID
+
+	return actual, nil
+}
+
+func (g *Gateway) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(g, c)
+}
+
+func (_ *Gateway) CheckChanges(actual, expected, changes *Gateway) error {
+	if actual != nil {
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Zone != nil {
+			return fi.CannotChangeField("Zone")
+		}
+		if changes.Type != "" {
+			return fi.CannotChangeField("Type")
+		}
+		if changes.PrivateNetwork != nil && actual.PrivateNetwork != nil {
+			return fi.CannotChangeField("PrivateNetwork")
+		}
+	} else {
+		if expected.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if expected.Zone == nil {
+			return fi.RequiredField("Zone")
+		}
+		if expected.PrivateNetwork == nil {
+			return fi.RequiredField("PrivateNetwork")
+		}
+	}
+	return nil
+}
+
+func (_ *Gateway) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes *Gateway) error {
+	gatewayService := t.Cloud.GatewayService()
+	zone := scw.Zone(fi.ValueOf(expected.Zone))
+
+	gatewayID := fi.ValueOf(expected.ID)
+	if actual != nil {
+		gatewayID = fi.ValueOf(actual.ID)
+
+		if changes.Name != nil || changes.Tags != nil {
+			klog.Infof("Updating existing public gateway with name %q", fi.ValueOf(expected.Name))
+			_, err := gatewayService.UpdateGateway(&vpcgw.UpdateGatewayRequest{
+				Zone:      zone,
+				GatewayID: gatewayID,
+				Name:      expected.Name,
+				Tags:      &expected.Tags,
+			})
+			if err != nil {
+				return fmt.Errorf("updating public gateway %q: %w", fi.ValueOf(expected.Name), err)
+			}
+		}
+
+	} else {
+
+		klog.Infof("Creating new public gateway with name %q", fi.ValueOf(expected.Name))
+
+		ip, err := gatewayService.CreateIP(&vpcgw.CreateIPRequest{
+			Zone: zone,
+			Tags: expected.Tags,
+		})
+		if err != nil {
+			return fmt.Errorf("creating IP for public gateway %q: %w", fi.ValueOf(expected.Name), err)
+		}
+
+		gatewayCreated, err := gatewayService.CreateGateway(&vpcgw.CreateGatewayRequest{
+			Zone: zone,
+			Name: fi.ValueOf(expected.Name),
+			Tags: expected.Tags,
+			Type: expected.Type,
+			IPID: &ip.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("creating public gateway %q: %w", fi.ValueOf(expected.Name), err)
+		}
+		gatewayID = gatewayCreated.ID
+	}
+	expected.ID = &gatewayID
+
+	if actual == nil || actual.PrivateNetwork == nil {
+		_, err := gatewayService.WaitForGateway(&vpcgw.WaitForGatewayRequest{
+			GatewayID: gatewayID,
+			Zone:      zone,
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for public gateway %s: %w", gatewayID, err)
+		}
+
+		// The gateway masquerades the traffic of the servers and is advertised as their default route
+		gatewayNetwork, err := gatewayService.CreateGatewayNetwork(&vpcgw.CreateGatewayNetworkRequest{
+			Zone:             zone,
+			GatewayID:        gatewayID,
+			PrivateNetworkID: fi.ValueOf(expected.PrivateNetwork.ID),
+			EnableMasquerade: true,
+			IpamConfig: &vpcgw.CreateGatewayNetworkRequestIpamConfig{
+				PushDefaultRoute: true,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("attaching public gateway %s to private network %s: %w", gatewayID, fi.ValueOf(expected.PrivateNetwork.ID), err)
+		}
+
+		_, err = gatewayService.WaitForGatewayNetwork(&vpcgw.WaitForGatewayNetworkRequest{
+			GatewayNetworkID: gatewayNetwork.ID,
+			Zone:             zone,
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for gateway network %s: %w", gatewayNetwork.ID, err)
+		}
+	}
+
+	return nil
+}
+
+type terraformGatewayIP struct {
+	Zone *string  `cty:"zone"`
+	Tags []string `cty:"tags"`
+}
+
+type terraformGateway struct {
+	Name *string                  `cty:"name"`
+	Zone *string                  `cty:"zone"`
+	Type *string                  `cty:"type"`
+	Tags []string                 `cty:"tags"`
+	IPID *terraformWriter.Literal `cty:"ip_id"`
+}
+
+type terraformGatewayNetworkIpamConfig struct {
+	PushDefaultRoute *bool `cty:"push_default_route"`
+}
+
+type terraformGatewayNetwork struct {
+	GatewayID        *terraformWriter.Literal            `cty:"gateway_id"`
+	PrivateNetworkID *terraformWriter.Literal            `cty:"private_network_id"`
+	EnableMasquerade *bool                               `cty:"enable_masquerade"`
+	IpamConfig       []terraformGatewayNetworkIpamConfig `cty:"ipam_config"`
+}
+
+func (_ *Gateway) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *Gateway) error {
+	name := fi.ValueOf(expected.Name)
+
+	tfGatewayIP := terraformGatewayIP{
+		Zone: expected.Zone,
+		Tags: expected.Tags,
+	}
+	err := t.RenderResource("scaleway_vpc_public_gateway_ip", name, tfGatewayIP)
+	if err != nil {
+		return err
+	}
+
+	tfGateway := terraformGateway{
+		Name: expected.Name,
+		Zone: expected.Zone,
+		Type: fi.PtrTo(expected.Type),
+		Tags: expected.Tags,
+		IPID: terraformWriter.LiteralProperty("scaleway_vpc_public_gateway_ip", name, "id"),
+	}
+	err = t.RenderResource("scaleway_vpc_public_gateway", name, tfGateway)
+	if err != nil {
+		return err
+	}
+
+	tfGatewayNetwork := terraformGatewayNetwork{
+		GatewayID:        expected.TerraformLink(),
+		PrivateNetworkID: expected.PrivateNetwork.TerraformLink(),
+		EnableMasquerade: fi.PtrTo(true),
+		IpamConfig: []terraformGatewayNetworkIpamConfig{
+			{
+				PushDefaultRoute: fi.PtrTo(true),
+			},
+		},
+	}
+	return t.RenderResource("scaleway_vpc_gateway_network", name, tfGatewayNetwork)
+}
+
+func (g *Gateway) TerraformLink() *terraformWriter.Literal {
+	return terraformWriter.LiteralProperty("scaleway_vpc_public_gateway", fi.ValueOf(g.Name), "id")
+}
@@ -49,6 +49,11 @@ type Instance struct {
 
 	UserData     *fi.Resource
 	LoadBalancer *LoadBalancer
+
+	// PrivateNetwork is the private network the servers are attached to, if any.
+	PrivateNetwork *PrivateNetwork
+	// AssociatePublicIP is false for servers that are only reachable through their private network.
+	AssociatePublicIP *bool
 }
 
 var _ fi.CloudupTask = &Instance{}
@@ -66,6 +71,13 @@ func (s *Instance) GetDependencies(tasks map[string]fi.CloudupTask) []fi.Cloudup
 		if _, ok := task.(*Volume); ok {
 			deps = append(deps, task)
 		}
+		// Servers without a public IP reach the internet through the public gateways
+		if _, ok := task.(*Gateway); ok {
+			deps = append(deps, task)
+		}
+	}
+	if s.PrivateNetwork != nil {
+		deps = append(deps, s.PrivateNetwork)
 	}
 	return deps
 }
@@ -119,6 +131,12 @@ func (s *Instance) Find(c *fi.CloudupContext) (*Instance, error) {
 		}
 		if diff == true {
 			needsUpdate = append(needsUpdate, server.ID)
+			continue
+		}
+
+		// Check that the server is attached to the private network
+		if s.PrivateNetwork != nil && !isAttachedToPrivateNetwork(server, fi.ValueOf(s.PrivateNetwork.ID)) {
+			needsUpdate = append(needsUpdate, server.ID)
 		}
 	}
 
@@ -142,6 +160,9 @@ func (s *Instance) Find(c *fi.CloudupContext) (*Instance, error) {
 		Count:          len(servers),
 		NeedsUpdate:    needsUpdate,
 		UserData:       s.UserData,
+		// Servers that are not attached to the private network are replaced by a rolling update
+		PrivateNetwork:    s.PrivateNetwork,
+		AssociatePublicIP: s.AssociatePublicIP,
 	}, nil
 }
 
@@ -229,6 +250,9 @@ func (_ *Instance) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes
 			Tags:            expected.Tags,
 			RoutedIPEnabled: fi.PtrTo(true),
 		}
+		if !expected.hasPublicIP() {
+			createServerRequest.DynamicIPRequired = fi.PtrTo(false)
+		}
 
 		// We resize the root volume if needed (for instance types with no local storage)
 		if expected.VolumeSize != nil {
@@ -254,6 +278,27 @@ func (_ *Instance) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes
 			return fmt.Errorf("error waiting for instance %s of group %q: %w", srv.Server.ID, fi.ValueOf(expected.Name), err)
 		}
 
+		// We attach the instance to the private network before it boots, so that it gets its private IP from the start
+		if expected.PrivateNetwork != nil {
+			nic, err := instanceService.CreatePrivateNIC(&instance.CreatePrivateNICRequest{
+				Zone:             zone,
+				ServerID:         srv.Server.ID,
+				PrivateNetworkID: fi.ValueOf(expected.PrivateNetwork.ID),
+				Tags:             expected.Tags,
+			})
+			if err != nil {
+				return fmt.Errorf("error attaching instance %s of group %q to private network: %w", srv.Server.ID, fi.ValueOf(expected.Name), err)
+			}
+			_, err = instanceService.WaitForPrivateNIC(&instance.WaitForPrivateNICRequest{
+				ServerID:     srv.Server.ID,
+				PrivateNicID: nic.PrivateNic.ID,
+				Zone:         zone,
+			})
+			if err != nil {
+				return fmt.Errorf("error waiting for private NIC of instance %s of group %q: %w", srv.Server.ID, fi.ValueOf(expected.Name), err)
+			}
+		}
+
 		// We load the cloud-init script in the instance user data
 		err = instanceService.SetServerUserData(&instance.SetServerUserDataRequest{
 			ServerID: srv.Server.ID,
@@ -309,6 +354,10 @@ type terraformInstanceIP struct {
 	Tags []string `cty:"tags"`
 }
 
+type terraformInstancePrivateNetwork struct {
+	PNID *terraformWriter.Literal `cty:"pn_id"`
+}
+
 type terraformInstance struct {
 	Name                *string                             `cty:"name"`
 	IPID                *terraformWriter.Literal            `cty:"ip_id"`
@@ -317,6 +366,7 @@ type terraformInstance struct {
 	Image               *string                             `cty:"image"`
 	UserData            map[string]*terraformWriter.Literal `cty:"user_data"`
 	RootVolume          []terraformVolume                   `cty:"root_volume"`
+	PrivateNetwork      []terraformInstancePrivateNetwork   `cty:"private_network"`
 	EnableDynamicIP     *bool                               `cty:"enable_dynamic_ip"`
 	ReplaceOnTypeChange *bool                               `cty:"replace_on_type_change"`
 	Lifecycle           *terraform.Lifecycle                `cty:"lifecycle"`
@@ -330,14 +380,23 @@ func (_ *Instance) RenderTerraform(t *terraform.TerraformTarget, actual, expecte
 
 		tfInstance := terraformInstance{
 			Name:                &uniqueName,
-			IPID:                terraformWriter.LiteralProperty("scaleway_instance_ip", tfName, "id"),
 			Type:                expected.CommercialType,
 			Tags:                expected.Tags,
 			Image:               expected.Image,
-			EnableDynamicIP:     fi.PtrTo(true),
+			EnableDynamicIP:     fi.PtrTo(expected.hasPublicIP()),
 			ReplaceOnTypeChange: fi.PtrTo(false),
 			Lifecycle:           nil,
 		}
+		if expected.hasPublicIP() {
+			tfInstance.IPID = terraformWriter.LiteralProperty("scaleway_instance_ip", tfName, "id")
+		}
+		if expected.PrivateNetwork != nil {
+			tfInstance.PrivateNetwork = []terraformInstancePrivateNetwork{
+				{
+					PNID: expected.PrivateNetwork.TerraformLink(),
+				},
+			}
+		}
 
 		// We load the cloud-init script in the instance user data
 		if expected.UserData != nil {
@@ -375,19 +434,21 @@ func (_ *Instance) RenderTerraform(t *terraform.TerraformTarget, actual, expecte
 		}
 
 		// We create an IP for the server (we only render it now to avoid duplicates if Instance task fails)
-		tfInstanceIP := terraformInstanceIP{}
-		for _, tag := range expected.Tags {
-			if strings.HasPrefix(tag, scaleway.TagClusterName) {
-				tfInstanceIP.Tags = []string{tag}
-				break
+		if expected.hasPublicIP() {
+			tfInstanceIP := terraformInstanceIP{}
+			for _, tag := range expected.Tags {
+				if strings.HasPrefix(tag, scaleway.TagClusterName) {
+					tfInstanceIP.Tags = []string{tag}
+					break
+				}
+			}
+			err := t.RenderResource("scaleway_instance_ip", tfName, tfInstanceIP)
+			if err != nil {
+				return err
 			}
-		}
-		err := t.RenderResource("scaleway_instance_ip", tfName, tfInstanceIP)
-		if err != nil {
-			return err
 		}
 
-		err = t.RenderResource("scaleway_instance_server", tfName, tfInstance)
+		err := t.RenderResource("scaleway_instance_server", tfName, tfInstance)
 		if err != nil {
 			return err
 		}
@@ -395,6 +456,20 @@ func (_ *Instance) RenderTerraform(t *terraform.TerraformTarget, actual, expecte
 	return nil
 }
 
+// hasPublicIP returns true if the servers get a public IP, which is the default.
+func (s *Instance) hasPublicIP() bool {
+	return s.AssociatePublicIP == nil || *s.AssociatePublicIP
+}
+
+func isAttachedToPrivateNetwork(server *instance.Server, privateNetworkID string) bool {
+	for _, nic := range server.PrivateNics {
+		if nic.PrivateNetworkID == privateNetworkID {
+			return true
+		}
+	}
+	return false
+}
+
 func checkImageDifferences(c *fi.CloudupContext, cloud scaleway.ScwCloud, actualServer *instance.Server, expectedImage string) (bool, error) {
 	localImage, err := cloud.MarketplaceService().GetLocalImageByLabel(&marketplace.GetLocalImageByLabelRequest{
 		ImageLabel:     expectedImage,
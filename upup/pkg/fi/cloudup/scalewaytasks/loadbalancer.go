@@ -47,6 +47,9 @@ type LoadBalancer struct {
 	Description           string
 	SslCompatibilityLevel string
 
+	// PrivateNetwork is the private network the load-balancer reaches its backends through, if any.
+	PrivateNetwork *PrivateNetwork
+
 	// WellKnownServices indicates which services are supported by this resource.
 	// This field is internal and is not rendered to the cloud.
 	WellKnownServices []wellknownservices.WellKnownService
@@ -86,7 +89,7 @@ func (l *LoadBalancer) Find(context *fi.CloudupContext) (*LoadBalancer, error) {
 		lbIPs = append(lbIPs, IP.IPAddress)
 	}
 
-	return &LoadBalancer{
+	actual := &LoadBalancer{
 		Name:              fi.PtrTo(loadBalancer.Name),
 		LBID:              fi.PtrTo(loadBalancer.ID),
 		Zone:              fi.PtrTo(string(loadBalancer.Zone)),
@@ -94,7 +97,24 @@ func (l *LoadBalancer) Find(context *fi.CloudupContext) (*LoadBalancer, error) {
 		Tags:              loadBalancer.Tags,
 		Lifecycle:         l.Lifecycle,
 		WellKnownServices: l.WellKnownServices,
-	}, nil
+	}
+
+	if l.PrivateNetwork != nil {
+		privateNetworks, err := lbService.ListLBPrivateNetworks(&lb.ZonedAPIListLBPrivateNetworksRequest{
+			Zone: loadBalancer.Zone,
+			LBID: loadBalancer.ID,
+		}, scw.WithAllPages())
+		if err != nil {
+			return nil, fmt.Errorf("listing private networks of load-balancer %s: %w", loadBalancer.ID, err)
+		}
+		for _, privateNetwork := range privateNetworks.PrivateNetwork {
+			if privateNetwork.PrivateNetworkID == fi.ValueOf(l.PrivateNetwork.ID) {
+				actual.PrivateNetwork = l.PrivateNetwork
+			}
+		}
+	}
+
+	return actual, nil
 }
 
 func (l *LoadBalancer) FindAddresses(context *fi.CloudupContext) ([]string, error) {
@@ -139,6 +159,9 @@ func (_ *LoadBalancer) CheckChanges(actual, expected, changes *LoadBalancer) err
 		if changes.Zone != nil {
 			return fi.CannotChangeField("Zone")
 		}
+		if changes.PrivateNetwork != nil && actual.PrivateNetwork != nil {
+			return fi.CannotChangeField("PrivateNetwork")
+		}
 	} else {
 		if expected.Name == nil {
 			return fi.RequiredField("Name")
@@ -206,17 +229,43 @@ func (l *LoadBalancer) RenderScw(t *scaleway.ScwAPITarget, actual, expected, cha
 
 	}
 
+	if expected.PrivateNetwork != nil && (actual == nil || actual.PrivateNetwork == nil) {
+		zone := scw.Zone(fi.ValueOf(expected.Zone))
+		_, err := lbService.AttachPrivateNetwork(&lb.ZonedAPIAttachPrivateNetworkRequest{
+			Zone:             zone,
+			LBID:             fi.ValueOf(expected.LBID),
+			PrivateNetworkID: fi.ValueOf(expected.PrivateNetwork.ID),
+			IpamConfig:       &lb.PrivateNetworkIpamConfig{},
+		})
+		if err != nil {
+			return fmt.Errorf("attaching load-balancer %s to private network: %w", fi.ValueOf(expected.LBID), err)
+		}
+
+		_, err = lbService.WaitForLb(&lb.ZonedAPIWaitForLBRequest{
+			LBID: fi.ValueOf(expected.LBID),
+			Zone: zone,
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for load-balancer %s: %w", fi.ValueOf(expected.LBID), err)
+		}
+	}
+
 	return nil
 }
 
 type terraformLBIP struct{}
 
+type terraformLBPrivateNetwork struct {
+	PrivateNetworkID *terraformWriter.Literal `cty:"private_network_id"`
+}
+
 type terraformLoadBalancer struct {
-	Type        string                   `cty:"type"`
-	Name        *string                  `cty:"name"`
-	Description string                   `cty:"description"`
-	Tags        []string                 `cty:"tags"`
-	IPID        *terraformWriter.Literal `cty:"ip_id"`
+	Type           string                      `cty:"type"`
+	Name           *string                     `cty:"name"`
+	Description    string                      `cty:"description"`
+	Tags           []string                    `cty:"tags"`
+	IPID           *terraformWriter.Literal    `cty:"ip_id"`
+	PrivateNetwork []terraformLBPrivateNetwork `cty:"private_network"`
 }
 
 func (_ *LoadBalancer) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *LoadBalancer) error {
@@ -235,6 +284,13 @@ func (_ *LoadBalancer) RenderTerraform(t *terraform.TerraformTarget, actual, exp
 		Tags:        expected.Tags,
 		IPID:        terraformWriter.LiteralProperty("scaleway_lb_ip", tfName, "id"),
 	}
+	if expected.PrivateNetwork != nil {
+		tfLB.PrivateNetwork = []terraformLBPrivateNetwork{
+			{
+				PrivateNetworkID: expected.PrivateNetwork.TerraformLink(),
+			},
+		}
+	}
 	return t.RenderResource("scaleway_lb", tfName, tfLB)
 }
 
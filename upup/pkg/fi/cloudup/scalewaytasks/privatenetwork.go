@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"fmt"
+	"net"
+
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// +kops:fitask
+type PrivateNetwork struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID      *string
+	Region  *string
+	IPRange *string
+	Tags    []string
+	VPC     *VPC
+
+	// Shared is set if this is a private network that is not managed by kOps.
+	Shared *bool
+}
+
+var _ fi.CompareWithID = &PrivateNetwork{}
+
+func (p *PrivateNetwork) CompareWithID() *string {
+	return p.ID
+}
+
+func (p *PrivateNetwork) Find(c *fi.CloudupContext) (*PrivateNetwork, error) {
+	cloud := c.T.Cloud.(scaleway.ScwCloud)
+	vpcService := cloud.VPCService()
+	region := scw.Region(fi.ValueOf(p.Region))
+
+	var found *vpc.PrivateNetwork
+	if p.ID != nil {
+		existing, err := vpcService.GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{
+			Region:           region,
+			PrivateNetworkID: fi.ValueOf(p.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting private network %s: %w", fi.ValueOf(p.ID), err)
+		}
+		found = existing
+	} else {
+		privateNetworks, err := vpcService.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+			Region: region,
+			Name:   p.Name,
+		}, scw.WithAllPages())
+		if err != nil {
+			return nil, fmt.Errorf("listing private networks: %w", err)
+		}
+		for _, existing := range privateNetworks.PrivateNetworks {
+			// The API matches names partially
+			if existing.Name == fi.ValueOf(p.Name) {
+				if found != nil {
+					return nil, fmt.Errorf("found multiple private networks named %q", fi.ValueOf(p.Name))
+				}
+				found = existing
+			}
+		}
+		if found == nil {
+			return nil, nil
+		}
+	}
+
+	actual := &PrivateNetwork{
+		Name:      p.Name,
+		Lifecycle: p.Lifecycle,
+		ID:        fi.PtrTo(found.ID),
+		Region:    fi.PtrTo(found.Region.String()),
+		Tags:      p.Tags,
+		VPC:       p.VPC,
+		Shared:    p.Shared,
+	}
+	if !fi.ValueOf(p.Shared) {
+		actual.Tags = found.Tags
+		for _, subnet := range found.Subnets {
+			// The API adds an IPv6 subnet to each private network
+			if subnet.Subnet.IP.To4() != nil {
+				actual.IPRange = fi.PtrTo(subnet.Subnet.String())
+				break
+			}
+		}
+		if p.VPC != nil && fi.ValueOf(p.VPC.ID) != found.VpcID {
+			actual.VPC = &VPC{ID: fi.PtrTo(found.VpcID)}
+		}
+	}
+
+	// Make sure the ID is set (used by other tasks)
+	p.ID = actual.ID
+
+	return actual, nil
+}
+
+func (p *PrivateNetwork) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(p, c)
+}
+
+func (_ *PrivateNetwork) CheckChanges(actual, expected, changes *PrivateNetwork) error {
+	if actual != nil {
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Region != nil {
+			return fi.CannotChangeField("Region")
+		}
+		if changes.IPRange != nil && actual.IPRange != nil {
+			return fi.CannotChangeField("IPRange")
+		}
+		if changes.VPC != nil {
+			return fi.CannotChangeField("VPC")
+		}
+	} else {
+		if expected.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if expected.Region == nil {
+			return fi.RequiredField("Region")
+		}
+		if expected.VPC == nil {
+			return fi.RequiredField("VPC")
+		}
+	}
+	return nil
+}
+
+func (_ *PrivateNetwork) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes *PrivateNetwork) error {
+	vpcService := t.Cloud.VPCService()
+	region := scw.Region(fi.ValueOf(expected.Region))
+
+	if fi.ValueOf(expected.Shared) {
+		if actual == nil {
+			return fmt.Errorf("private network %s was not found", fi.ValueOf(expected.ID))
+		}
+		return nil
+	}
+
+	if actual != nil {
+		if changes.Name != nil || changes.Tags != nil {
+			klog.Infof("Updating existing private network with name %q", fi.ValueOf(expected.Name))
+			_, err := vpcService.UpdatePrivateNetwork(&vpc.UpdatePrivateNetworkRequest{
+				Region:           region,
+				PrivateNetworkID: fi.ValueOf(actual.ID),
+				Name:             expected.Name,
+				Tags:             &expected.Tags,
+			})
+			if err != nil {
+				return fmt.Errorf("updating private network %q: %w", fi.ValueOf(expected.Name), err)
+			}
+		}
+		expected.ID = actual.ID
+		return nil
+	}
+
+	klog.Infof("Creating new private network with name %q", fi.ValueOf(expected.Name))
+
+	request := &vpc.CreatePrivateNetworkRequest{
+		Region: region,
+		Name:   fi.ValueOf(expected.Name),
+		Tags:   expected.Tags,
+		VpcID:  expected.VPC.ID,
+	}
+	if expected.IPRange != nil {
+		_, ipRange, err := net.ParseCIDR(fi.ValueOf(expected.IPRange))
+		if err != nil {
+			return fmt.Errorf("parsing IP range of private network %q: %w", fi.ValueOf(expected.Name), err)
+		}
+		request.Subnets = []scw.IPNet{{IPNet: *ipRange}}
+	}
+
+	privateNetworkCreated, err := vpcService.CreatePrivateNetwork(request)
+	if err != nil {
+		return fmt.Errorf("creating private network %q: %w", fi.ValueOf(expected.Name), err)
+	}
+	expected.ID = &privateNetworkCreated.ID
+
+	return nil
+}
+
+type terraformPrivateNetworkSubnet struct {
+	Subnet *string `cty:"subnet"`
+}
+
+type terraformPrivateNetwork struct {
+	Name       *string                         `cty:"name"`
+	VPCID      *terraformWriter.Literal        `cty:"vpc_id"`
+	Tags       []string                        `cty:"tags"`
+	IPv4Subnet []terraformPrivateNetworkSubnet `cty:"ipv4_subnet"`
+}
+
+func (_ *PrivateNetwork) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *PrivateNetwork) error {
+	if fi.ValueOf(expected.Shared) {
+		return nil
+	}
+
+	tfPrivateNetwork := terraformPrivateNetwork{
+		Name:  expected.Name,
+		VPCID: expected.VPC.TerraformLink(),
+		Tags:  expected.Tags,
+	}
+	if expected.IPRange != nil {
+		tfPrivateNetwork.IPv4Subnet = []terraformPrivateNetworkSubnet{
+			{
+				Subnet: expected.IPRange,
+			},
+		}
+	}
+	return t.RenderResource("scaleway_vpc_private_network", fi.ValueOf(expected.Name), tfPrivateNetwork)
+}
+
+func (p *PrivateNetwork) TerraformLink() *terraformWriter.Literal {
+	if fi.ValueOf(p.Shared) {
+		if p.ID == nil {
+			klog.Fatalf("ID must be set, if private network is shared: %v", p)
+		}
+		return terraformWriter.LiteralFromStringValue(fi.ValueOf(p.ID))
+	}
+	return terraformWriter.LiteralProperty("scaleway_vpc_private_network", fi.ValueOf(p.Name), "id")
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalewaytasks
+
+import (
+	"fmt"
+
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// +kops:fitask
+type VPC struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ID     *string
+	Region *string
+	Tags   []string
+
+	// Shared is set if this is a VPC that is not managed by kOps.
+	Shared *bool
+}
+
+var _ fi.CompareWithID = &VPC{}
+
+func (v *VPC) CompareWithID() *string {
+	return v.ID
+}
+
+func (v *VPC) Find(c *fi.CloudupContext) (*VPC, error) {
+	cloud := c.T.Cloud.(scaleway.ScwCloud)
+	vpcService := cloud.VPCService()
+	region := scw.Region(fi.ValueOf(v.Region))
+
+	var found *vpc.VPC
+	if v.ID != nil {
+		existing, err := vpcService.GetVPC(&vpc.GetVPCRequest{
+			Region: region,
+			VpcID:  fi.ValueOf(v.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting VPC %s: %w", fi.ValueOf(v.ID), err)
+		}
+		found = existing
+	} else {
+		vpcs, err := vpcService.ListVPCs(&vpc.ListVPCsRequest{
+			Region: region,
+			Name:   v.Name,
+		}, scw.WithAllPages())
+		if err != nil {
+			return nil, fmt.Errorf("listing VPCs: %w", err)
+		}
+		for _, existing := range vpcs.Vpcs {
+			// The API matches names partially
+			if existing.Name == fi.ValueOf(v.Name) {
+				if found != nil {
+					return nil, fmt.Errorf("found multiple VPCs named %q", fi.ValueOf(v.Name))
+				}
+				found = existing
+			}
+		}
+		if found == nil {
+			return nil, nil
+		}
+	}
+
+	actual := &VPC{
+		Name:      v.Name,
+		Lifecycle: v.Lifecycle,
+		ID:        fi.PtrTo(found.ID),
+		Region:    fi.PtrTo(found.Region.String()),
+		Tags:      v.Tags,
+		Shared:    v.Shared,
+	}
+	if !fi.ValueOf(v.Shared) {
+		actual.Tags = found.Tags
+	}
+
+	// Make sure the ID is set (used by other tasks)
+	v.ID = actual.ID
+
+	return actual, nil
+}
+
+func (v *VPC) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(v, c)
+}
+
+func (_ *VPC) CheckChanges(actual, expected, changes *VPC) error {
+	if actual != nil {
+		if changes.ID != nil {
+			return fi.CannotChangeField("ID")
+		}
+		if changes.Region != nil {
+			return fi.CannotChangeField("Region")
+		}
+	} else {
+		if expected.Name == nil {
+			return fi.RequiredField("Name")
+		}
+		if expected.Region == nil {
+			return fi.RequiredField("Region")
+		}
+	}
+	return nil
+}
+
+func (_ *VPC) RenderScw(t *scaleway.ScwAPITarget, actual, expected, changes *VPC) error {
+	vpcService := t.Cloud.VPCService()
+	region := scw.Region(fi.ValueOf(expected.Region))
+
+	if fi.ValueOf(expected.Shared) {
+		if actual == nil {
+			return fmt.Errorf("VPC %s was not found", fi.ValueOf(expected.ID))
+		}
+		return nil
+	}
+
+	if actual != nil {
+		if changes.Name != nil || changes.Tags != nil {
+			klog.Infof("Updating existing VPC with name %q", fi.ValueOf(expected.Name))
+			_, err := vpcService.UpdateVPC(&vpc.UpdateVPCRequest{
+				Region: region,
+				VpcID:  fi.ValueOf(actual.ID),
+				Name:   expected.Name,
+				Tags:   &expected.Tags,
+			})
+			if err != nil {
+				return fmt.Errorf("updating VPC %q: %w", fi.ValueOf(expected.Name), err)
+			}
+		}
+		expected.ID = actual.ID
+		return nil
+	}
+
+	klog.Infof("Creating new VPC with name %q", fi.ValueOf(expected.Name))
+
+	vpcCreated, err := vpcService.CreateVPC(&vpc.CreateVPCRequest{
+		Region: region,
+		Name:   fi.ValueOf(expected.Name),
+		Tags:   expected.Tags,
+	})
+	if err != nil {
+		return fmt.Errorf("creating VPC %q: %w", fi.ValueOf(expected.Name), err)
+	}
+	expected.ID = &vpcCreated.ID
+
+	return nil
+}
+
+type terraformVPC struct {
+	Name *string  `cty:"name"`
+	Tags []string `cty:"tags"`
+}
+
+func (_ *VPC) RenderTerraform(t *terraform.TerraformTarget, actual, expected, changes *VPC) error {
+	if fi.ValueOf(expected.Shared) {
+		return nil
+	}
+
+	tfVPC := terraformVPC{
+		Name: expected.Name,
+		Tags: expected.Tags,
+	}
+	return t.RenderResource("scaleway_vpc", fi.ValueOf(expected.Name), tfVPC)
+}
+
+func (v *VPC) TerraformLink() *terraformWriter.Literal {
+	if fi.ValueOf(v.Shared) {
+		if v.ID == nil {
+			klog.Fatalf("ID must be set, if VPC is shared: %v", v)
+		}
+		return terraformWriter.LiteralFromStringValue(fi.ValueOf(v.ID))
+	}
+	return terraformWriter.LiteralProperty("scaleway_vpc", fi.ValueOf(v.Name), "id")
+}
@@ -28,23 +28,25 @@ When defining a new function:
 package cloudup
 
 import (
-	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"path"
 	"sort"
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
-	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	kopsroot "k8s.io/kops"
@@ -57,7 +59,9 @@ import (
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/awsmodel"
 	"k8s.io/kops/pkg/model/components/kopscontroller"
+	"k8s.io/kops/pkg/model/defaults"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/pkg/resources/spotinst"
 	"k8s.io/kops/pkg/wellknownports"
@@ -107,6 +111,7 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["semverCompare"] = sprigTxtFuncMap["semverCompare"]
 	dest["ternary"] = sprigTxtFuncMap["ternary"]
 	dest["join"] = sprigTxtFuncMap["join"]
+	dest["sha256sum"] = sprigTxtFuncMap["sha256sum"]
 
 	dest["ClusterName"] = tf.ClusterName
 	dest["WithDefaultBool"] = func(v *bool, defaultValue bool) bool {
@@ -268,6 +273,12 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 				envVars["ENABLE_PREFIX_DELEGATION"] = "true"
 				envVars["WARM_PREFIX_TARGET"] = "1"
 			}
+			if cluster.Spec.UsesSecurityGroupsForPods() {
+				// Pods use ENIConfigs named after their instance group, maintained by kops-controller
+				envVars["AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG"] = "true"
+				envVars["ENI_CONFIG_LABEL_DEF"] = kops.NodeLabelInstanceGroup
+				envVars["ENABLE_POD_ENI"] = "true"
+			}
 			envVars["ADDITIONAL_ENI_TAGS"] = fmt.Sprintf(
 				"{\\\"KubernetesCluster\\\":\\\"%s\\\",\\\"kubernetes.io/cluster/%s\\\":\\\"owned\\\"}",
 				tf.ClusterName(),
@@ -339,7 +350,10 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	}
 
 	dest["IsIPv6Only"] = tf.IsIPv6Only
+	dest["UsesKubeletServerTLSBootstrap"] = cluster.Spec.UsesKubeletServerTLSBootstrap
 	dest["UseServiceAccountExternalPermissions"] = tf.UseServiceAccountExternalPermissions
+	dest["KopsControllerMetricsPort"] = func() int { return wellknownports.KopsControllerMetricsPort }
+	dest["EtcdMetricsPorts"] = tf.EtcdMetricsPorts
 
 	if cluster.Spec.ClusterAutoscaler != nil {
 		dest["ClusterAutoscalerPriorities"] = func() string {
@@ -389,11 +403,14 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["KarpenterEnabled"] = func() bool {
 		return cluster.Spec.Karpenter != nil && cluster.Spec.Karpenter.Enabled
 	}
-	dest["KarpenterInstanceTypes"] = func(ig kops.InstanceGroupSpec) ([]string, error) {
-		return karpenterInstanceTypes(tf.cloud.(awsup.AWSCloud), ig)
+	dest["KarpenterInstanceRequirements"] = karpenterInstanceRequirements
+	dest["KarpenterImage"] = func(image string) (*ec2types.Image, error) {
+		return tf.cloud.(awsup.AWSCloud).ResolveImage(image)
 	}
+	dest["KarpenterNodeClass"] = tf.karpenterNodeClass
 
 	dest["PodIdentityWebhookConfigMapData"] = tf.podIdentityWebhookConfigMapData
+	dest["AWSIAMAuthenticatorConfig"] = tf.awsIAMAuthenticatorConfig
 
 	dest["HasSnapshotController"] = func() bool {
 		sc := cluster.Spec.SnapshotController
@@ -450,6 +467,33 @@ func (tf *TemplateFunctions) GetInstanceGroup(name string) (*kops.InstanceGroup,
 	return ig, nil
 }
 
+// EtcdMetricsPorts returns the ports on which the etcd clusters serve metrics, keyed by etcd cluster name.
+// Only the etcd clusters that serve plain HTTP metrics on a non-loopback address are returned.
+func (tf *TemplateFunctions) EtcdMetricsPorts() (map[string]int, error) {
+	ports := make(map[string]int)
+	for _, etcdCluster := range tf.Cluster.Spec.EtcdClusters {
+		if etcdCluster.Manager == nil {
+			continue
+		}
+		for _, s := range etcdCluster.Manager.ListenMetricsURLs {
+			u, err := url.Parse(s)
+			if err != nil {
+				return nil, fmt.Errorf("parsing listenMetricsURLs of etcd cluster %q: %w", etcdCluster.Name, err)
+			}
+			if u.Scheme != "http" || u.Port() == "" || u.Hostname() == "localhost" || net.ParseIP(u.Hostname()).IsLoopback() {
+				continue
+			}
+			port, err := strconv.Atoi(u.Port())
+			if err != nil {
+				return nil, fmt.Errorf("parsing listenMetricsURLs of etcd cluster %q: %w", etcdCluster.Name, err)
+			}
+			ports[etcdCluster.Name] = port
+			break
+		}
+	}
+	return ports, nil
+}
+
 // ControlPlaneControllerReplicas returns the amount of replicas for a controllers that should run in the cluster.
 // deployOnWorkersIfExternalPermissons indicates if a controller can run on worker nodes when external IAM permissions is enabled for the cluster.
 func (tf *TemplateFunctions) ControlPlaneControllerReplicas(deployOnWorkersIfExternalPermissions bool) int {
@@ -763,12 +807,46 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 		default:
 			return "", fmt.Errorf("unsupported cloud provider %s", cluster.Spec.GetCloudProvider())
 		}
+
+		if cluster.Spec.Authentication != nil && cluster.Spec.Authentication.CloudIdentity != nil {
+			cloudIdentity := cluster.Spec.Authentication.CloudIdentity
+			adminCredentials := &kopscontrollerconfig.AdminCredentialsOptions{
+				MaxLifetime: metav1.Duration{Duration: time.Hour},
+			}
+			if cloudIdentity.MaxLifetime != nil {
+				adminCredentials.MaxLifetime = *cloudIdentity.MaxLifetime
+			}
+			for _, identity := range cloudIdentity.AllowedIdentities {
+				if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+					// kops-controller matches callers by the ARN of their role or user, without its path
+					canonical, err := awsup.CanonicalIAMIdentity(identity)
+					if err != nil {
+						return "", err
+					}
+					identity = canonical
+				}
+				adminCredentials.AllowedIdentities = append(adminCredentials.AllowedIdentities, identity)
+			}
+			config.Server.AdminCredentials = adminCredentials
+		}
 	}
 
 	if cluster.Spec.IsKopsControllerIPAM() {
 		config.EnableCloudIPAM = true
 	}
 
+	if cluster.Spec.UsesSecurityGroupsForPods() {
+		config.EnableENIConfigs = true
+	}
+
+	if cluster.Spec.UsesKubeletServerTLSBootstrap() {
+		config.EnableKubeletServingCertificateApprover = true
+	}
+
+	if pa := cluster.Spec.PrometheusAgent; pa != nil && fi.ValueOf(pa.Enabled) {
+		config.MetricsAddress = fmt.Sprintf(":%d", wellknownports.KopsControllerMetricsPort)
+	}
+
 	if cluster.UsesLegacyGossip() {
 		config.Discovery = &kopscontrollerconfig.DiscoveryOptions{
 			Enabled: true,
@@ -1000,114 +1078,256 @@ func (tf *TemplateFunctions) podIdentityWebhookConfigMapData() (string, error) {
 	return fmt.Sprintf("%q", jsonBytes), err
 }
 
-func karpenterInstanceTypes(cloud awsup.AWSCloud, ig kops.InstanceGroupSpec) ([]string, error) {
-	ctx := context.TODO()
-	var mixedInstancesPolicy *kops.MixedInstancesPolicySpec
+// awsIAMAuthenticatorConfig is the configuration of the MountedFile backend of the AWS IAM Authenticator.
+type awsIAMAuthenticatorConfig struct {
+	ClusterID string                          `json:"clusterID"`
+	Server    awsIAMAuthenticatorServerConfig `json:"server"`
+}
 
-	if ig.MachineType == "" && ig.MixedInstancesPolicy == nil {
-		// Karpenter thinks all clusters run VPC CNI and schedules thinking Node Capacity is constrainted by number of ENIs.
+type awsIAMAuthenticatorServerConfig struct {
+	MapRoles []awsIAMAuthenticatorRoleMapping `json:"mapRoles,omitempty"`
+	MapUsers []awsIAMAuthenticatorUserMapping `json:"mapUsers,omitempty"`
+}
 
-		// cpuMin is the reasonable lower limit for a Kubernetes Node
-		// Generally, it also avoids instances Karpenter thinks it can only schedule 4 Pods on.
-		cpuMin := resource.MustParse("2")
-		memoryMin := resource.MustParse(("2G"))
+type awsIAMAuthenticatorRoleMapping struct {
+	RoleARN  string   `json:"roleARN"`
+	Username string   `json:"username"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+type awsIAMAuthenticatorUserMapping struct {
+	UserARN  string   `json:"userARN"`
+	Username string   `json:"username"`
+	Groups   []string `json:"groups,omitempty"`
+}
+
+// awsIAMAuthenticatorConfig returns the configuration of the MountedFile backend of the AWS IAM Authenticator,
+// with the identity mappings of the cluster spec.
+func (tf *TemplateFunctions) awsIAMAuthenticatorConfig() (string, error) {
+	spec := tf.Cluster.Spec.Authentication.AWS
+
+	config := awsIAMAuthenticatorConfig{
+		ClusterID: spec.ClusterID,
+	}
+	if config.ClusterID == "" {
+		config.ClusterID = tf.ClusterName()
+	}
+	for _, mapping := range spec.IdentityMappings {
+		parsedARN, err := arn.Parse(mapping.ARN)
+		if err != nil {
+			return "", fmt.Errorf("parsing identity mapping ARN %q: %w", mapping.ARN, err)
+		}
+		if strings.HasPrefix(parsedARN.Resource, "user/") {
+			config.Server.MapUsers = append(config.Server.MapUsers, awsIAMAuthenticatorUserMapping{
+				UserARN:  mapping.ARN,
+				Username: mapping.Username,
+				Groups:   mapping.Groups,
+			})
+		} else {
+			config.Server.MapRoles = append(config.Server.MapRoles, awsIAMAuthenticatorRoleMapping{
+				RoleARN:  mapping.ARN,
+				Username: mapping.Username,
+				Groups:   mapping.Groups,
+			})
+		}
+	}
 
-		mixedInstancesPolicy = &kops.MixedInstancesPolicySpec{
-			InstanceRequirements: &kops.InstanceRequirementsSpec{
+	b, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("building AWS IAM Authenticator config: %w", err)
+	}
+	return string(b), nil
+}
+
+// karpenterRequirement is a node selector requirement of a Karpenter NodePool.
+type karpenterRequirement struct {
+	Key      string
+	Operator string
+	Values   []string
+}
+
+// karpenterInstanceRequirements returns the NodePool requirements that select the instance types of an instance group.
+// Listed instance types are selected by name, while instance requirements are mapped to the well-known Karpenter labels.
+func karpenterInstanceRequirements(ig kops.InstanceGroupSpec) []karpenterRequirement {
+	var instanceTypes []string
+	var instanceRequirements *kops.InstanceRequirementsSpec
+	if ig.MixedInstancesPolicy != nil {
+		instanceTypes = ig.MixedInstancesPolicy.Instances
+		instanceRequirements = ig.MixedInstancesPolicy.InstanceRequirements
+	}
+	if len(instanceTypes) == 0 && instanceRequirements == nil {
+		if ig.MachineType != "" {
+			instanceTypes = []string{ig.MachineType}
+		} else {
+			// Karpenter thinks all clusters run VPC CNI and schedules thinking Node Capacity is constrainted by number of ENIs.
+
+			// cpuMin is the reasonable lower limit for a Kubernetes Node
+			// Generally, it also avoids instances Karpenter thinks it can only schedule 4 Pods on.
+			cpuMin := resource.MustParse("2")
+			memoryMin := resource.MustParse("2G")
+
+			instanceRequirements = &kops.InstanceRequirementsSpec{
 				CPU: &kops.MinMaxSpec{
 					Min: &cpuMin,
 				},
 				Memory: &kops.MinMaxSpec{
 					Min: &memoryMin,
 				},
-			},
+			}
 		}
 	}
-	if ig.MixedInstancesPolicy != nil {
-		mixedInstancesPolicy = ig.MixedInstancesPolicy
+
+	if len(instanceTypes) > 0 {
+		return []karpenterRequirement{
+			{Key: corev1.LabelInstanceTypeStable, Operator: "In", Values: instanceTypes},
+		}
 	}
 
-	if mixedInstancesPolicy != nil {
-		if len(mixedInstancesPolicy.Instances) > 0 {
-			return mixedInstancesPolicy.Instances, nil
+	var requirements []karpenterRequirement
+	if cpu := instanceRequirements.CPU; cpu != nil {
+		if cpu.Min != nil {
+			cpuMin, _ := cpu.Min.AsInt64()
+			requirements = append(requirements, karpenterRequirement{Key: "karpenter.k8s.aws/instance-cpu", Operator: "Gt", Values: []string{strconv.FormatInt(cpuMin-1, 10)}})
 		}
-		if mixedInstancesPolicy.InstanceRequirements != nil {
-			instanceRequirements := mixedInstancesPolicy.InstanceRequirements
-			ami, err := cloud.ResolveImage(ig.Image)
-			if err != nil {
-				return nil, err
-			}
-			arch := ami.Architecture
-			hv := ami.VirtualizationType
-
-			ir := &ec2types.InstanceRequirementsRequest{
-				VCpuCount:            &ec2types.VCpuCountRangeRequest{},
-				MemoryMiB:            &ec2types.MemoryMiBRequest{},
-				BurstablePerformance: ec2types.BurstablePerformanceIncluded,
-				InstanceGenerations:  []ec2types.InstanceGeneration{ec2types.InstanceGenerationCurrent},
-			}
-			cpu := instanceRequirements.CPU
-			if cpu != nil {
-				if cpu.Max != nil {
-					cpuMax, _ := instanceRequirements.CPU.Max.AsInt64()
-					ir.VCpuCount.Max = fi.PtrTo(int32(cpuMax))
-				}
-				cpu := instanceRequirements.CPU
-				if cpu != nil {
-					if cpu.Max != nil {
-						cpuMax, _ := instanceRequirements.CPU.Max.AsInt64()
-						ir.VCpuCount.Max = fi.PtrTo(int32(cpuMax))
-					}
-					if cpu.Min != nil {
-						cpuMin, _ := instanceRequirements.CPU.Min.AsInt64()
-						ir.VCpuCount.Min = fi.PtrTo(int32(cpuMin))
-					}
-				} else {
-					ir.VCpuCount.Min = fi.PtrTo(int32(0))
-				}
+		if cpu.Max != nil {
+			cpuMax, _ := cpu.Max.AsInt64()
+			requirements = append(requirements, karpenterRequirement{Key: "karpenter.k8s.aws/instance-cpu", Operator: "Lt", Values: []string{strconv.FormatInt(cpuMax+1, 10)}})
+		}
+	}
+	if memory := instanceRequirements.Memory; memory != nil {
+		if memory.Min != nil {
+			memoryMin := memory.Min.ScaledValue(resource.Mega)
+			requirements = append(requirements, karpenterRequirement{Key: "karpenter.k8s.aws/instance-memory", Operator: "Gt", Values: []string{strconv.FormatInt(memoryMin-1, 10)}})
+		}
+		if memory.Max != nil {
+			memoryMax := memory.Max.ScaledValue(resource.Mega)
+			requirements = append(requirements, karpenterRequirement{Key: "karpenter.k8s.aws/instance-memory", Operator: "Lt", Values: []string{strconv.FormatInt(memoryMax+1, 10)}})
+		}
+	}
 
-				memory := instanceRequirements.Memory
-				if memory != nil {
-					if memory.Max != nil {
-						memoryMax := instanceRequirements.Memory.Max.ScaledValue(resource.Mega)
-						ir.MemoryMiB.Max = fi.PtrTo(int32(memoryMax))
-					}
-					if memory.Min != nil {
-						memoryMin := instanceRequirements.Memory.Min.ScaledValue(resource.Mega)
-						ir.MemoryMiB.Min = fi.PtrTo(int32(memoryMin))
-					}
-				} else {
-					ir.MemoryMiB.Min = fi.PtrTo(int32(0))
-				}
+	if len(instanceRequirements.Architectures) > 0 {
+		requirements = append(requirements, karpenterRequirement{Key: corev1.LabelArchStable, Operator: "In", Values: instanceRequirements.Architectures})
+	}
 
-				ir.AcceleratorCount = &ec2types.AcceleratorCountRequest{
-					Min: fi.PtrTo(int32(0)),
-					Max: fi.PtrTo(int32(0)),
-				}
+	// Like the instance requirements of autoscaling groups, exclude instances with accelerators
+	requirements = append(requirements,
+		karpenterRequirement{Key: "karpenter.k8s.aws/instance-gpu-count", Operator: "DoesNotExist"},
+		karpenterRequirement{Key: "karpenter.k8s.aws/instance-accelerator-count", Operator: "DoesNotExist"},
+	)
+	return requirements
+}
 
-				response, err := cloud.EC2().GetInstanceTypesFromInstanceRequirements(ctx,
-					&ec2.GetInstanceTypesFromInstanceRequirementsInput{
-						ArchitectureTypes:    []ec2types.ArchitectureType{ec2types.ArchitectureType(arch)},
-						VirtualizationTypes:  []ec2types.VirtualizationType{hv},
-						InstanceRequirements: ir,
-					},
-				)
-				if err != nil {
-					return nil, err
-				}
-				types := []string{}
-				for _, it := range response.InstanceTypes {
-					types = append(types, *it.InstanceType)
-				}
-				if len(types) == 0 {
-					return nil, fmt.Errorf("no instances matched requirements")
-				}
-				return types, nil
+// karpenterNodeClass holds the settings of the EC2NodeClass of an instance group that mirror its launch template.
+type karpenterNodeClass struct {
+	InstanceProfile         string
+	Tags                    map[string]string
+	RootVolume              karpenterVolume
+	AssociatePublicIP       bool
+	HTTPTokens              string
+	HTTPPutResponseHopLimit int64
+	HTTPProtocolIPv6        string
+	DetailedMonitoring      bool
+}
+
+// karpenterVolume is the root volume of the nodes of an instance group.
+type karpenterVolume struct {
+	Size       int32
+	Type       string
+	IOPS       int32
+	Throughput int32
+	Encrypted  bool
+	KMSKeyID   string
+}
+
+func (tf *TemplateFunctions) karpenterNodeClass(name string, spec kops.InstanceGroupSpec) (*karpenterNodeClass, error) {
+	ig := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+
+	profile, err := tf.LinkToIAMInstanceProfile(ig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find IAM profile for instance group %q: %w", name, err)
+	}
+
+	cloudTags, err := tf.CloudTagsForInstanceGroup(ig)
+	if err != nil {
+		return nil, err
+	}
+	// Karpenter sets the cluster tag itself and rejects node classes that set it
+	tags := make(map[string]string)
+	for k, v := range cloudTags {
+		if !strings.HasPrefix(k, "kubernetes.io/cluster/") {
+			tags[k] = v
+		}
+	}
+
+	nodeClass := &karpenterNodeClass{
+		InstanceProfile:         fi.ValueOf(profile.Name),
+		Tags:                    tags,
+		HTTPTokens:              string(ec2types.LaunchTemplateHttpTokensStateRequired),
+		HTTPPutResponseHopLimit: 1,
+		HTTPProtocolIPv6:        string(ec2types.LaunchTemplateInstanceMetadataProtocolIpv6Disabled),
+		DetailedMonitoring:      fi.ValueOf(spec.DetailedInstanceMonitoring),
+	}
+	for i, subnetName := range spec.Subnets {
+		for _, subnet := range tf.Cluster.Spec.Networking.Subnets {
+			if subnet.Name != subnetName {
+				continue
+			}
+			if i == 0 && (subnet.Type == kops.SubnetTypePublic || subnet.Type == kops.SubnetTypeUtility) {
+				nodeClass.AssociatePublicIP = fi.ValueOf(spec.AssociatePublicIP) || spec.AssociatePublicIP == nil
+			}
+			if subnet.IPv6CIDR != "" {
+				nodeClass.HTTPProtocolIPv6 = string(ec2types.LaunchTemplateInstanceMetadataProtocolIpv6Enabled)
 			}
 		}
 	}
+	if spec.InstanceMetadata != nil && spec.InstanceMetadata.HTTPPutResponseHopLimit != nil {
+		nodeClass.HTTPPutResponseHopLimit = fi.ValueOf(spec.InstanceMetadata.HTTPPutResponseHopLimit)
+	}
+	if spec.InstanceMetadata != nil && spec.InstanceMetadata.HTTPTokens != nil {
+		nodeClass.HTTPTokens = fi.ValueOf(spec.InstanceMetadata.HTTPTokens)
+	} else if tf.IsKubernetesLT("1.27") {
+		nodeClass.HTTPTokens = string(ec2types.LaunchTemplateHttpTokensStateOptional)
+	}
+
+	volume := &nodeClass.RootVolume
+	volume.Size, err = defaults.DefaultInstanceGroupVolumeSize(spec.Role)
+	if err != nil {
+		return nil, err
+	}
+	volume.Type = string(awsmodel.DefaultVolumeType)
+	volume.Encrypted = awsmodel.DefaultVolumeEncryption
+	if rootVolume := spec.RootVolume; rootVolume != nil {
+		if fi.ValueOf(rootVolume.Size) > 0 {
+			volume.Size = fi.ValueOf(rootVolume.Size)
+		}
+		if fi.ValueOf(rootVolume.Type) != "" {
+			volume.Type = fi.ValueOf(rootVolume.Type)
+		}
+		if rootVolume.Encryption != nil {
+			volume.Encrypted = fi.ValueOf(rootVolume.Encryption)
+		}
+		if volume.Encrypted {
+			volume.KMSKeyID = fi.ValueOf(rootVolume.EncryptionKey)
+		}
+		volume.IOPS = fi.ValueOf(rootVolume.IOPS)
+		volume.Throughput = fi.ValueOf(rootVolume.Throughput)
+	}
+	switch ec2types.VolumeType(volume.Type) {
+	case ec2types.VolumeTypeIo1, ec2types.VolumeTypeIo2:
+		volume.IOPS = max(volume.IOPS, awsmodel.DefaultVolumeIonIops)
+		volume.Throughput = 0
+	case ec2types.VolumeTypeGp3:
+		volume.IOPS = max(volume.IOPS, awsmodel.DefaultVolumeGp3Iops)
+		volume.Throughput = max(volume.Throughput, awsmodel.DefaultVolumeGp3Throughput)
+	default:
+		volume.IOPS = 0
+		volume.Throughput = 0
+	}
 
-	return []string{ig.MachineType}, nil
+	return nodeClass, nil
 }
 
 func (tf *TemplateFunctions) kopsFeatureEnabled(featureName string) (bool, error) {
@@ -21,13 +21,10 @@ import (
 	"reflect"
 	"testing"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
-	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/upup/pkg/fi"
-	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
 
 func Test_TemplateFunctions_CloudControllerConfigArgv(t *testing.T) {
@@ -297,26 +294,83 @@ func Test_TemplateFunctions_CloudControllerConfigArgv(t *testing.T) {
 	}
 }
 
-func Test_KarpenterInstanceTypes(t *testing.T) {
-	amiId := "ami-073c8c0760395aab8"
-	ec2Client := &mockec2.MockEC2{}
-	ec2Client.Images = append(ec2Client.Images, &ec2types.Image{
-		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
-		ImageId:        &amiId,
-		Name:           aws.String("focal"),
-		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
-		RootDeviceName: aws.String("/dev/xvda"),
-		Architecture:   ec2types.ArchitectureValuesX8664,
-	})
-	ig := kops.InstanceGroupSpec{
-		Image: amiId,
+func Test_KarpenterInstanceRequirements(t *testing.T) {
+	cpuMax := resource.MustParse("8")
+	memoryMin := resource.MustParse("4G")
+	tests := []struct {
+		name     string
+		ig       kops.InstanceGroupSpec
+		expected []karpenterRequirement
+	}{
+		{
+			name: "machine type",
+			ig:   kops.InstanceGroupSpec{MachineType: "t3.medium"},
+			expected: []karpenterRequirement{
+				{Key: "node.kubernetes.io/instance-type", Operator: "In", Values: []string{"t3.medium"}},
+			},
+		},
+		{
+			name: "mixed instances",
+			ig: kops.InstanceGroupSpec{
+				MachineType:          "t3.medium",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{Instances: []string{"m5.large", "m6i.large"}},
+			},
+			expected: []karpenterRequirement{
+				{Key: "node.kubernetes.io/instance-type", Operator: "In", Values: []string{"m5.large", "m6i.large"}},
+			},
+		},
+		{
+			name: "default",
+			ig:   kops.InstanceGroupSpec{},
+			expected: []karpenterRequirement{
+				{Key: "karpenter.k8s.aws/instance-cpu", Operator: "Gt", Values: []string{"1"}},
+				{Key: "karpenter.k8s.aws/instance-memory", Operator: "Gt", Values: []string{"1999"}},
+				{Key: "karpenter.k8s.aws/instance-gpu-count", Operator: "DoesNotExist"},
+				{Key: "karpenter.k8s.aws/instance-accelerator-count", Operator: "DoesNotExist"},
+			},
+		},
+		{
+			name: "instance requirements",
+			ig: kops.InstanceGroupSpec{
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						CPU:    &kops.MinMaxSpec{Max: &cpuMax},
+						Memory: &kops.MinMaxSpec{Min: &memoryMin},
+					},
+				},
+			},
+			expected: []karpenterRequirement{
+				{Key: "karpenter.k8s.aws/instance-cpu", Operator: "Lt", Values: []string{"9"}},
+				{Key: "karpenter.k8s.aws/instance-memory", Operator: "Gt", Values: []string{"3999"}},
+				{Key: "karpenter.k8s.aws/instance-gpu-count", Operator: "DoesNotExist"},
+				{Key: "karpenter.k8s.aws/instance-accelerator-count", Operator: "DoesNotExist"},
+			},
+		},
+		{
+			name: "instance requirements with architectures",
+			ig: kops.InstanceGroupSpec{
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					InstanceRequirements: &kops.InstanceRequirementsSpec{
+						Memory:        &kops.MinMaxSpec{Min: &memoryMin},
+						Architectures: []string{"arm64"},
+					},
+				},
+			},
+			expected: []karpenterRequirement{
+				{Key: "karpenter.k8s.aws/instance-memory", Operator: "Gt", Values: []string{"3999"}},
+				{Key: "kubernetes.io/arch", Operator: "In", Values: []string{"arm64"}},
+				{Key: "karpenter.k8s.aws/instance-gpu-count", Operator: "DoesNotExist"},
+				{Key: "karpenter.k8s.aws/instance-accelerator-count", Operator: "DoesNotExist"},
+			},
+		},
 	}
-	cloud := &awsup.MockAWSCloud{MockCloud: awsup.MockCloud{
-		MockEC2: ec2Client,
-	}}
-	_, err := karpenterInstanceTypes(cloud, ig)
-	if err != nil {
-		t.Errorf("failed to fetch instance types: %v", err)
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := karpenterInstanceRequirements(tc.ig)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("unexpected requirements %+v, expected %+v", actual, tc.expected)
+			}
+		})
 	}
 }
 
@@ -98,6 +98,8 @@ func toElement(item interface{}) element {
 		return terraformWriter.LiteralTokens(strconv.FormatBool(v.Bool()))
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return terraformWriter.LiteralTokens(strconv.FormatInt(v.Int(), 10))
+	case reflect.Float32, reflect.Float64:
+		return terraformWriter.LiteralTokens(strconv.FormatFloat(v.Float(), 'f', -1, 64))
 	case reflect.Map:
 		return mapToElement(v.Interface())
 	case reflect.String:
@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+const (
+	// kubernetesFieldManager is the field manager used for server-side apply.
+	// It matches the one used by channels, so that both can own the same fields.
+	kubernetesFieldManager = "kops"
+	// kubectlProviderApplyRetryCount is how many times the kubectl provider retries applying a manifest,
+	// which covers the time the API server of a new cluster takes to come up.
+	kubectlProviderApplyRetryCount = 30
+)
+
+// KubernetesProvider holds the connection details of the provider that applies the addon manifests.
+type KubernetesProvider struct {
+	// Server is the URL of the API server.
+	Server string
+	// CACertificates are the PEM encoded certificates of the cluster CA.
+	CACertificates []byte
+	// ClientCertificate is the PEM encoded client certificate used to authenticate.
+	ClientCertificate []byte
+	// ClientKey is the PEM encoded key of the client certificate.
+	ClientKey []byte
+}
+
+type terraformKubectlManifest struct {
+	YAMLBody        *terraformWriter.Literal `cty:"yaml_body"`
+	ServerSideApply *bool                    `cty:"server_side_apply"`
+	FieldManager    *string                  `cty:"field_manager"`
+	ForceConflicts  *bool                    `cty:"force_conflicts"`
+}
+
+// ManageAddons returns true if addon manifests should be rendered as kubectl provider resources.
+func (t *TerraformTarget) ManageAddons() bool {
+	return t.clusterSpecTarget != nil &&
+		t.clusterSpecTarget.Terraform != nil &&
+		fi.ValueOf(t.clusterSpecTarget.Terraform.ManageAddons)
+}
+
+// RenderKubernetesManifest renders each object in the manifest as a kubectl_manifest resource.
+func (t *TerraformTarget) RenderKubernetesManifest(name string, manifest []byte) error {
+	objects, err := kubemanifest.LoadObjectsFrom(manifest)
+	if err != nil {
+		return fmt.Errorf("error parsing manifest %q: %w", name, err)
+	}
+
+	for _, object := range objects {
+		resourceName := name + "-" + strings.ToLower(object.Kind())
+		if namespace := object.GetNamespace(); namespace != "" {
+			resourceName += "-" + namespace
+		}
+		resourceName += "-" + object.GetName()
+		// Object names such as system:coredns are not valid in file names on all platforms
+		resourceName = strings.ReplaceAll(resourceName, ":", "_")
+
+		data, err := object.ToYAML()
+		if err != nil {
+			return fmt.Errorf("error serializing object %q in manifest %q: %w", object.GetName(), name, err)
+		}
+		body, err := t.AddFileBytes("kubectl_manifest", resourceName, "yaml_body", data, false)
+		if err != nil {
+			return err
+		}
+
+		tf := &terraformKubectlManifest{
+			YAMLBody:        body,
+			ServerSideApply: fi.PtrTo(true),
+			FieldManager:    fi.PtrTo(kubernetesFieldManager),
+			ForceConflicts:  fi.PtrTo(true),
+		}
+		if err := t.RenderResource("kubectl_manifest", resourceName, tf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeKubectlProvider writes the provider block of the kubectl provider, which connects to the cluster
+// with the credentials issued by kops, so that no kubeconfig has to exist before the first apply.
+func (t *TerraformTarget) writeKubectlProvider(buf *bytes.Buffer) error {
+	if t.KubernetesProvider == nil {
+		return fmt.Errorf("connection details of the kubectl provider are required to manage addons")
+	}
+
+	providerBody := map[string]*terraformWriter.Literal{
+		"host":              terraformWriter.LiteralFromStringValue(t.KubernetesProvider.Server),
+		"load_config_file":  terraformWriter.LiteralTokens("false"),
+		"apply_retry_count": terraformWriter.LiteralFromIntValue(kubectlProviderApplyRetryCount),
+	}
+	for key, data := range map[string][]byte{
+		"cluster_ca_certificate": t.KubernetesProvider.CACertificates,
+		"client_certificate":     t.KubernetesProvider.ClientCertificate,
+		"client_key":             t.KubernetesProvider.ClientKey,
+	} {
+		if len(data) == 0 {
+			continue
+		}
+		literal, err := t.AddFileBytes("kubectl_provider", "cluster", key, data, false)
+		if err != nil {
+			return err
+		}
+		providerBody[key] = literal
+	}
+
+	mapToElement(providerBody).
+		ToObject().
+		Write(buf, 0, fmt.Sprintf("provider %q", "kubectl"))
+	buf.WriteString("\n")
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// unorderedPolicyKeys are the keys of a policy statement whose lists of values are unordered.
+var unorderedPolicyKeys = map[string]bool{
+	"Action":      true,
+	"NotAction":   true,
+	"Resource":    true,
+	"NotResource": true,
+}
+
+// AddPolicyFileResource adds a JSON policy document as a file, with its unordered lists sorted,
+// so that policies built from unordered sources don't produce spurious diffs.
+// Identical policies share a single file if DeduplicatePolicies is set.
+func (t *TerraformTarget) AddPolicyFileResource(resourceType string, resourceName string, key string, r fi.Resource) (*terraformWriter.Literal, error) {
+	d, err := fi.ResourceAsBytes(r)
+	if err != nil {
+		id := resourceType + "_" + resourceName + "_" + key
+		return nil, fmt.Errorf("error rending resource %s %v", id, err)
+	}
+
+	normalized, err := normalizePolicyDocument(d)
+	if err != nil {
+		return nil, fmt.Errorf("error normalizing policy of %s %s: %w", resourceType, resourceName, err)
+	}
+
+	if t.DeduplicatePolicies() {
+		return t.AddSharedFileBytes(resourceType, key, normalized)
+	}
+	return t.AddFileBytes(resourceType, resourceName, key, normalized, false)
+}
+
+// normalizePolicyDocument sorts the unordered lists of the statements of the policy.
+// The document is returned unchanged if it is already sorted or is not JSON, so that its formatting is kept.
+func normalizePolicyDocument(data []byte) ([]byte, error) {
+	var policy map[string]interface{}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return data, nil
+	}
+
+	var statements []interface{}
+	switch s := policy["Statement"].(type) {
+	case []interface{}:
+		statements = s
+	case map[string]interface{}:
+		statements = []interface{}{s}
+	}
+
+	changed := false
+	for _, statement := range statements {
+		fields, ok := statement.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key, value := range fields {
+			values, ok := value.([]interface{})
+			if !ok || !unorderedPolicyKeys[key] {
+				continue
+			}
+			sorted, ok := sortedStrings(values)
+			if !ok || reflect.DeepEqual(sorted, values) {
+				continue
+			}
+			fields[key] = sorted
+			changed = true
+		}
+	}
+
+	if !changed {
+		return data, nil
+	}
+	return json.MarshalIndent(policy, "", "  ")
+}
+
+// sortedStrings returns a sorted copy of values, or false if not all values are strings.
+func sortedStrings(values []interface{}) ([]interface{}, bool) {
+	s := make([]string, 0, len(values))
+	for _, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			return nil, false
+		}
+		s = append(s, str)
+	}
+	sort.Strings(s)
+
+	sorted := make([]interface{}, 0, len(s))
+	for _, str := range s {
+		sorted = append(sorted, str)
+	}
+	return sorted, true
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestNormalizePolicyDocument(t *testing.T) {
+	grid := []struct {
+		name     string
+		policy   string
+		expected string
+	}{
+		{
+			name:     "already sorted",
+			policy:   `{"Statement": [{"Action": ["ec2:A", "ec2:B"], "Effect": "Allow", "Resource": "*"}], "Version": "2012-10-17"}`,
+			expected: `{"Statement": [{"Action": ["ec2:A", "ec2:B"], "Effect": "Allow", "Resource": "*"}], "Version": "2012-10-17"}`,
+		},
+		{
+			name:   "unsorted actions and resources",
+			policy: `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Action": ["s3:Get*", "ec2:B", "ec2:A"], "Resource": ["arn:b", "arn:a"]}]}`,
+			expected: `{
+  "Statement": [
+    {
+      "Action": [
+        "ec2:A",
+        "ec2:B",
+        "s3:Get*"
+      ],
+      "Effect": "Allow",
+      "Resource": [
+        "arn:a",
+        "arn:b"
+      ]
+    }
+  ],
+  "Version": "2012-10-17"
+}`,
+		},
+		{
+			name:     "not json",
+			policy:   `not a policy`,
+			expected: `not a policy`,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			actual, err := normalizePolicyDocument([]byte(g.policy))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(actual) != g.expected {
+				t.Errorf("expected %s, got %s", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAddPolicyFileResourceDeduplicated(t *testing.T) {
+	policy := `{"Statement": [{"Action": ["ec2:A"], "Effect": "Allow", "Resource": "*"}], "Version": "2012-10-17"}`
+
+	target := NewTerraformTarget(&fakeCloud{}, "", "", &kops.TargetSpec{
+		Terraform: &kops.TerraformSpec{
+			DeduplicatePolicies: fi.PtrTo(true),
+		},
+	})
+	var paths []string
+	for _, name := range []string{"nodes-a", "nodes-b"} {
+		l, err := target.AddPolicyFileResource("aws_iam_role_policy", name, "policy", fi.NewStringResource(policy))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		paths = append(paths, l.String)
+	}
+
+	if paths[0] != paths[1] {
+		t.Errorf("expected identical policies to share a file, got %s and %s", paths[0], paths[1])
+	}
+	if len(target.Files) != 1 {
+		t.Errorf("expected 1 file, got %d", len(target.Files))
+	}
+
+	l, err := target.AddPolicyFileResource("aws_iam_role_policy", "masters", "policy", fi.NewStringResource(`{"Version": "2012-10-17"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if l.String == paths[0] {
+		t.Errorf("expected different policies to use different files, got %s", l.String)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package terraform
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path"
@@ -32,7 +33,8 @@ type TerraformTarget struct {
 	Cloud   fi.Cloud
 	Project string
 
-	ClusterName string
+	// KubernetesProvider is used to connect to the cluster when addons are managed by terraform.
+	KubernetesProvider *KubernetesProvider
 
 	outDir string
 	// extra config to add to the provider block
@@ -85,11 +87,29 @@ func tfGetFilesProviderExtraConfig(c *kops.TargetSpec) map[string]string {
 	return nil
 }
 
+// DeduplicatePolicies returns true if identical policy documents should be written to a single shared file.
+func (t *TerraformTarget) DeduplicatePolicies() bool {
+	return t.clusterSpecTarget != nil &&
+		t.clusterSpecTarget.Terraform != nil &&
+		fi.ValueOf(t.clusterSpecTarget.Terraform.DeduplicatePolicies)
+}
+
+// SplitOutput returns true if each resource type should be written to its own file.
+func (t *TerraformTarget) SplitOutput() bool {
+	return t.clusterSpecTarget != nil &&
+		t.clusterSpecTarget.Terraform != nil &&
+		fi.ValueOf(t.clusterSpecTarget.Terraform.SplitOutput)
+}
+
 func (t *TerraformTarget) Finish(taskMap map[string]fi.CloudupTask) error {
 	if err := t.finishHCL2(); err != nil {
 		return err
 	}
 
+	if err := t.removeStaleSplitFiles(); err != nil {
+		return err
+	}
+
 	for relativePath, contents := range t.Files {
 		p := path.Join(t.outDir, relativePath)
 
@@ -107,3 +127,37 @@ func (t *TerraformTarget) Finish(taskMap map[string]fi.CloudupTask) error {
 
 	return nil
 }
+
+// removeStaleSplitFiles removes the files kOps wrote when splitting the output by resource type that are no longer
+// generated, either because the output is no longer split or because no resources of that type remain.
+func (t *TerraformTarget) removeStaleSplitFiles() error {
+	entries, err := os.ReadDir(t.outDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error reading terraform output directory %q: %v", t.outDir, err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || path.Ext(name) != ".tf" {
+			continue
+		}
+		if t.HasFile(name) {
+			continue
+		}
+		p := path.Join(t.outDir, name)
+		contents, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("error reading terraform file %q: %v", p, err)
+		}
+		if !bytes.HasPrefix(contents, []byte(splitOutputHeader)) {
+			continue
+		}
+		klog.Infof("Removing terraform file %q that is no longer generated", p)
+		if err := os.Remove(p); err != nil {
+			return fmt.Errorf("error removing terraform file %q: %v", p, err)
+		}
+	}
+	return nil
+}
@@ -19,7 +19,9 @@ package terraform
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"sort"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
@@ -28,6 +30,10 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
+// splitOutputHeader starts the files written when the output is split by resource type,
+// so that they can be told apart from files added by the user when removing stale ones.
+const splitOutputHeader = "# This file is generated by kOps from the cluster spec.\n\n"
+
 func (t *TerraformTarget) finishHCL2() error {
 	buf := &bytes.Buffer{}
 
@@ -37,27 +43,66 @@ func (t *TerraformTarget) finishHCL2() error {
 	}
 	writeLocalsOutputs(buf, outputs)
 
-	t.writeProviders(buf)
+	if err := t.writeProviders(buf); err != nil {
+		return err
+	}
 
 	resourcesByType, err := t.GetResourcesByType()
 	if err != nil {
 		return err
 	}
 
-	t.writeResources(buf, resourcesByType)
-
 	dataSourcesByType, err := t.GetDataSourcesByType()
 	if err != nil {
 		return err
 	}
 
-	t.writeDataSources(buf, dataSourcesByType)
-
-	t.writeTerraform(buf)
+	splitOutput := t.SplitOutput()
+	if splitOutput {
+		// Write each resource and data source type to its own file, to keep diffs of large clusters reviewable
+		for _, resourceType := range sortedKeysForMap(resourcesByType) {
+			err := t.WriteFile(resourceType+".tf", func(w io.Writer) error {
+				if _, err := io.WriteString(w, splitOutputHeader); err != nil {
+					return err
+				}
+				return t.writeResources(w, map[string]map[string]interface{}{resourceType: resourcesByType[resourceType]})
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, dataSourceType := range sortedKeysForMap(dataSourcesByType) {
+			err := t.WriteFile("data_"+dataSourceType+".tf", func(w io.Writer) error {
+				if _, err := io.WriteString(w, splitOutputHeader); err != nil {
+					return err
+				}
+				return t.writeDataSources(w, map[string]map[string]interface{}{dataSourceType: dataSourcesByType[dataSourceType]})
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
 
-	t.Files["kubernetes.tf"] = buf.Bytes()
+	footer := &bytes.Buffer{}
+	t.writeMoved(footer, resourcesByType)
+	t.writeTerraform(footer)
 
-	return nil
+	return t.WriteFile("kubernetes.tf", func(w io.Writer) error {
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+		if !splitOutput {
+			if err := t.writeResources(w, resourcesByType); err != nil {
+				return err
+			}
+			if err := t.writeDataSources(w, dataSourcesByType); err != nil {
+				return err
+			}
+		}
+		_, err := w.Write(footer.Bytes())
+		return err
+	})
 }
 
 type output struct {
@@ -111,7 +156,7 @@ func writeLocalsOutputs(buf *bytes.Buffer, outputs map[string]terraformWriter.Ou
 	return
 }
 
-func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
+func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) error {
 	providerName := string(t.Cloud.ProviderID())
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
 		providerName = "google"
@@ -137,6 +182,13 @@ func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
 		Write(buf, 0, fmt.Sprintf("provider %q", providerName))
 	buf.WriteString("\n")
 
+	// Add the kubectl provider used for addons
+	if t.ManageAddons() {
+		if err := t.writeKubectlProvider(buf); err != nil {
+			return err
+		}
+	}
+
 	// Add any additional provider definition for managed files
 	keys := sortedKeysForMap(t.TerraformWriter.Providers)
 	for _, key := range keys {
@@ -154,6 +206,7 @@ func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
 			Write(buf, 0, fmt.Sprintf("provider %q", provider.Name))
 		buf.WriteString("\n")
 	}
+	return nil
 }
 
 func sortedKeysForMap[K ~string, V any](m map[K]V) []K {
@@ -165,7 +218,10 @@ func sortedKeysForMap[K ~string, V any](m map[K]V) []K {
 	return keys
 }
 
-func (t *TerraformTarget) writeResources(buf *bytes.Buffer, resourcesByType map[string]map[string]interface{}) {
+// writeResources writes the resources sorted by type and name. Each resource is rendered to a buffer
+// before being written, so that the output can be streamed to a file.
+func (t *TerraformTarget) writeResources(w io.Writer, resourcesByType map[string]map[string]interface{}) error {
+	buf := &bytes.Buffer{}
 	resourceTypes := make([]string, 0, len(resourcesByType))
 	for resourceType := range resourcesByType {
 		resourceTypes = append(resourceTypes, resourceType)
@@ -179,14 +235,20 @@ func (t *TerraformTarget) writeResources(buf *bytes.Buffer, resourcesByType map[
 		}
 		sort.Strings(resourceNames)
 		for _, resourceName := range resourceNames {
+			buf.Reset()
 			toElement(resources[resourceName]).
 				Write(buf, 0, fmt.Sprintf("resource %q %q", resourceType, resourceName))
 			buf.WriteString("\n")
+			if _, err := w.Write(buf.Bytes()); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
 }
 
-func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, dataSourcesByType map[string]map[string]interface{}) {
+func (t *TerraformTarget) writeDataSources(w io.Writer, dataSourcesByType map[string]map[string]interface{}) error {
+	buf := &bytes.Buffer{}
 	dataSourceTypes := make([]string, 0, len(dataSourcesByType))
 	for dataSourceType := range dataSourcesByType {
 		dataSourceTypes = append(dataSourceTypes, dataSourceType)
@@ -200,10 +262,40 @@ func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, dataSourcesByType
 		}
 		sort.Strings(dataSourceNames)
 		for _, dataSourceName := range dataSourceNames {
+			buf.Reset()
 			toElement(dataSources[dataSourceName]).
 				Write(buf, 0, fmt.Sprintf("data %q %q", dataSourceType, dataSourceName))
 			buf.WriteString("\n")
+			if _, err := w.Write(buf.Bytes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeMoved writes a moved block for each renamed resource of the cluster spec, skipping the renames
+// that terraform would reject because the resource is not rendered or its previous address is still in use.
+func (t *TerraformTarget) writeMoved(buf *bytes.Buffer, resourcesByType map[string]map[string]interface{}) {
+	if t.clusterSpecTarget == nil || t.clusterSpecTarget.Terraform == nil {
+		return
+	}
+	for _, moved := range t.clusterSpecTarget.Terraform.Moved {
+		fromType, fromName, _ := strings.Cut(moved.From, ".")
+		toType, toName, _ := strings.Cut(moved.To, ".")
+		if _, found := resourcesByType[toType][toName]; !found {
+			klog.Warningf("not moving %s to %s, which is not rendered", moved.From, moved.To)
+			continue
+		}
+		if _, found := resourcesByType[fromType][fromName]; found {
+			klog.Warningf("not moving %s to %s, because %s is still rendered", moved.From, moved.To, moved.From)
+			continue
 		}
+		mapToElement(map[string]*terraformWriter.Literal{
+			"from": terraformWriter.LiteralTokens(fromType, fromName),
+			"to":   terraformWriter.LiteralTokens(toType, toName),
+		}).ToObject().Write(buf, 0, "moved")
+		buf.WriteString("\n")
 	}
 }
 
@@ -229,6 +321,10 @@ func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 		providers["digitalocean"] = true
 	}
 
+	if t.ManageAddons() {
+		providers["kubectl"] = true
+	}
+
 	for _, tfProvider := range t.TerraformWriter.Providers {
 		providers[tfProvider.Name] = true
 		providerAliases[tfProvider.Name] = append(providerAliases[tfProvider.Name], "files")
@@ -263,6 +359,10 @@ func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 				"source":  "digitalocean/digitalocean",
 				"version": "~>2.0",
 			},
+			"kubectl": {
+				"source":  "alekc/kubectl",
+				"version": ">= 2.0.0",
+			},
 		}
 
 		providerVersion := providerVersions[provider]
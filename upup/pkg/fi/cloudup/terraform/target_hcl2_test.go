@@ -18,10 +18,16 @@ package terraform
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
@@ -85,3 +91,340 @@ output "key1" {
 		})
 	}
 }
+
+func TestRenderKubernetesManifest(t *testing.T) {
+	target := NewTerraformTarget(nil, "", "", &kops.TargetSpec{
+		Terraform: &kops.TerraformSpec{
+			ManageAddons: fi.PtrTo(true),
+		},
+	})
+
+	manifest := `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: coredns
+  namespace: kube-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: system:coredns
+`
+	if err := target.RenderKubernetesManifest("coredns.addons.k8s.io", []byte(manifest)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resourcesByType, err := target.GetResourcesByType()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := target.writeResources(buf, resourcesByType); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := strings.TrimSpace(buf.String())
+	expected := strings.TrimSpace(`
+resource "kubectl_manifest" "coredns-addons-k8s-io-clusterrole-system_coredns" {
+  field_manager     = "kops"
+  force_conflicts   = true
+  server_side_apply = true
+  yaml_body         = file("${path.module}/data/kubectl_manifest_coredns.addons.k8s.io-clusterrole-system_coredns_yaml_body")
+}
+
+resource "kubectl_manifest" "coredns-addons-k8s-io-serviceaccount-kube-system-coredns" {
+  field_manager     = "kops"
+  force_conflicts   = true
+  server_side_apply = true
+  yaml_body         = file("${path.module}/data/kubectl_manifest_coredns.addons.k8s.io-serviceaccount-kube-system-coredns_yaml_body")
+}`)
+	if actual != expected {
+		diffString := diff.FormatDiff(expected, actual)
+		t.Logf("diff:\n%s\n", diffString)
+		t.Errorf("expected: '%s', got: '%s'\n", expected, actual)
+	}
+	for name := range target.Files {
+		if strings.Contains(name, ":") {
+			t.Errorf("file name %q contains ':'", name)
+		}
+	}
+	if len(target.Files) != 2 {
+		t.Errorf("expected 2 manifest files, got %d", len(target.Files))
+	}
+}
+
+type fakeCloud struct {
+	fi.Cloud
+}
+
+func (c *fakeCloud) ProviderID() kops.CloudProviderID {
+	return kops.CloudProviderAWS
+}
+
+func (c *fakeCloud) Region() string {
+	return "us-test-1"
+}
+
+func TestFinishHCL2SplitOutput(t *testing.T) {
+	type testResource struct {
+		Name *string `cty:"name"`
+	}
+
+	resources := []struct {
+		resourceType string
+		resourceName string
+	}{
+		{"aws_security_group", "nodes"},
+		{"aws_security_group", "masters"},
+		{"aws_iam_role", "nodes"},
+	}
+
+	render := func(reverse bool) map[string][]byte {
+		target := NewTerraformTarget(&fakeCloud{}, "", "", &kops.TargetSpec{
+			Terraform: &kops.TerraformSpec{
+				SplitOutput: fi.PtrTo(true),
+			},
+		})
+		for i := range resources {
+			r := resources[i]
+			if reverse {
+				r = resources[len(resources)-1-i]
+			}
+			if err := target.RenderResource(r.resourceType, r.resourceName, &testResource{Name: fi.PtrTo(r.resourceName)}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if err := target.RenderDataSource("aws_ami", "ubuntu", &testResource{Name: fi.PtrTo("ubuntu")}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := target.finishHCL2(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return target.Files
+	}
+
+	files := render(false)
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	expectedNames := []string{"aws_iam_role.tf", "aws_security_group.tf", "data_aws_ami.tf", "kubernetes.tf"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("expected files %v, got %v", expectedNames, names)
+	}
+
+	actual := strings.TrimSpace(string(files["aws_security_group.tf"]))
+	expected := strings.TrimSpace(`
+# This file is generated by kOps from the cluster spec.
+
+resource "aws_security_group" "masters" {
+  name = "masters"
+}
+
+resource "aws_security_group" "nodes" {
+  name = "nodes"
+}`)
+	if actual != expected {
+		diffString := diff.FormatDiff(expected, actual)
+		t.Logf("diff:\n%s\n", diffString)
+		t.Errorf("expected: '%s', got: '%s'\n", expected, actual)
+	}
+	if strings.Contains(string(files["kubernetes.tf"]), "resource ") {
+		t.Errorf("expected no resources in kubernetes.tf, got:\n%s", files["kubernetes.tf"])
+	}
+
+	if reversed := render(true); !reflect.DeepEqual(files, reversed) {
+		t.Errorf("expected output to be independent of render order")
+	}
+}
+
+func TestWriteMoved(t *testing.T) {
+	type testResource struct {
+		Name *string `cty:"name"`
+	}
+
+	target := NewTerraformTarget(&fakeCloud{}, "", "", &kops.TargetSpec{
+		Terraform: &kops.TerraformSpec{
+			Moved: []kops.TerraformMovedSpec{
+				{From: "aws_internet_gateway.old-example-com", To: "aws_internet_gateway.new-example-com"},
+				// Not rendered
+				{From: "aws_vpc.old-example-com", To: "aws_vpc.new-example-com"},
+				// Still rendered
+				{From: "aws_iam_role.nodes", To: "aws_iam_role.nodes-example-com"},
+			},
+		},
+	})
+	for _, r := range []struct {
+		resourceType string
+		resourceName string
+	}{
+		{"aws_internet_gateway", "new.example.com"},
+		{"aws_iam_role", "nodes"},
+		{"aws_iam_role", "nodes.example.com"},
+	} {
+		if err := target.RenderResource(r.resourceType, r.resourceName, &testResource{Name: fi.PtrTo(r.resourceName)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	resourcesByType, err := target.GetResourcesByType()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	target.writeMoved(buf, resourcesByType)
+
+	actual := strings.TrimSpace(buf.String())
+	expected := strings.TrimSpace(`
+moved {
+  from = aws_internet_gateway.old-example-com
+  to   = aws_internet_gateway.new-example-com
+}`)
+	if actual != expected {
+		diffString := diff.FormatDiff(expected, actual)
+		t.Logf("diff:\n%s\n", diffString)
+		t.Errorf("expected: '%s', got: '%s'\n", expected, actual)
+	}
+}
+
+func TestWriteKubectlProvider(t *testing.T) {
+	target := NewTerraformTarget(nil, "", "", &kops.TargetSpec{
+		Terraform: &kops.TerraformSpec{
+			ManageAddons: fi.PtrTo(true),
+		},
+	})
+
+	buf := &bytes.Buffer{}
+	if err := target.writeKubectlProvider(buf); err == nil {
+		t.Errorf("expected error writing provider without connection details")
+	}
+
+	target.KubernetesProvider = &KubernetesProvider{
+		Server:            "https://api.minimal.example.com",
+		CACertificates:    []byte("ca"),
+		ClientCertificate: []byte("cert"),
+		ClientKey:         []byte("key"),
+	}
+	buf.Reset()
+	if err := target.writeKubectlProvider(buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := strings.TrimSpace(buf.String())
+	expected := strings.TrimSpace(`
+provider "kubectl" {
+  apply_retry_count      = 30
+  client_certificate     = file("${path.module}/data/kubectl_provider_cluster_client_certificate")
+  client_key             = file("${path.module}/data/kubectl_provider_cluster_client_key")
+  cluster_ca_certificate = file("${path.module}/data/kubectl_provider_cluster_cluster_ca_certificate")
+  host                   = "https://api.minimal.example.com"
+  load_config_file       = false
+}`)
+	if actual != expected {
+		diffString := diff.FormatDiff(expected, actual)
+		t.Logf("diff:\n%s\n", diffString)
+		t.Errorf("expected: '%s', got: '%s'\n", expected, actual)
+	}
+	if string(target.Files["data/kubectl_provider_cluster_client_key"]) != "key" {
+		t.Errorf("expected client key to be written to a data file")
+	}
+}
+
+func TestRemoveStaleSplitFiles(t *testing.T) {
+	outDir := t.TempDir()
+	for name, contents := range map[string]string{
+		"aws_iam_role.tf":       splitOutputHeader + "resource \"aws_iam_role\" \"nodes\" {}\n",
+		"aws_security_group.tf": splitOutputHeader + "resource \"aws_security_group\" \"nodes\" {}\n",
+		"custom.tf":             "resource \"aws_s3_bucket\" \"custom\" {}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(outDir, name), []byte(contents), 0o644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	target := NewTerraformTarget(&fakeCloud{}, "", outDir, nil)
+	target.Files["aws_security_group.tf"] = []byte(splitOutputHeader)
+	if err := target.removeStaleSplitFiles(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	expected := []string{"aws_security_group.tf", "custom.tf"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected files %v, got %v", expected, names)
+	}
+}
+
+func TestFinishStreamed(t *testing.T) {
+	type testResource struct {
+		Name *string `cty:"name"`
+	}
+
+	render := func(stream bool, splitOutput bool) map[string]string {
+		outDir := t.TempDir()
+		target := NewTerraformTarget(&fakeCloud{}, "", outDir, &kops.TargetSpec{
+			Terraform: &kops.TerraformSpec{
+				SplitOutput: fi.PtrTo(splitOutput),
+			},
+		})
+		if stream {
+			target.StreamFilesTo(outDir)
+		}
+		for _, name := range []string{"nodes", "masters"} {
+			if err := target.RenderResource("aws_security_group", name, &testResource{Name: fi.PtrTo(name)}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := target.AddFileBytes("aws_launch_template", name, "user_data", []byte("#!/bin/bash\n"+name), true); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if err := target.RenderDataSource("aws_ami", "ubuntu", &testResource{Name: fi.PtrTo("ubuntu")}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := target.Finish(nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stream && len(target.Files) != 0 {
+			t.Errorf("expected no files to be kept in memory when streamed, got %d", len(target.Files))
+		}
+
+		files := make(map[string]string)
+		err := filepath.WalkDir(outDir, func(p string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			contents, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			relativePath, err := filepath.Rel(outDir, p)
+			if err != nil {
+				return err
+			}
+			files[relativePath] = string(contents)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return files
+	}
+
+	for _, splitOutput := range []bool{false, true} {
+		expected := render(false, splitOutput)
+		actual := render(true, splitOutput)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("splitOutput=%v: expected streamed files %v, got %v", splitOutput, expected, actual)
+		}
+	}
+}
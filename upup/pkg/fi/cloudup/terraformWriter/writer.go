@@ -17,7 +17,13 @@ limitations under the License.
 package terraformWriter
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
 	"path"
 	"reflect"
 	"strconv"
@@ -42,6 +48,13 @@ type TerraformWriter struct {
 
 	// Files is a map of TF resource Files that should be created
 	Files map[string][]byte
+
+	// outDir is the directory the files are written to as they are added, if they are streamed
+	outDir string
+	// streamedFiles holds the paths of the files that were written to outDir
+	streamedFiles map[string]bool
+	// sharedFiles holds the paths of the files shared by resources
+	sharedFiles map[string]bool
 }
 
 type OutputValue struct {
@@ -79,6 +92,72 @@ func sanitizeName(name string) string {
 func (t *TerraformWriter) InitTerraformWriter() {
 	t.Files = make(map[string][]byte)
 	t.outputs = make(map[string]*terraformOutputVariable)
+	t.streamedFiles = make(map[string]bool)
+	t.sharedFiles = make(map[string]bool)
+}
+
+// StreamFilesTo writes the files that are added from now on directly to the directory, instead of keeping
+// their contents in Files until the end of the run. This bounds the memory used to render very large clusters.
+func (t *TerraformWriter) StreamFilesTo(outDir string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.outDir = outDir
+}
+
+// WriteFile adds the file with the output of render, at the path relative to the output directory.
+// When the files are streamed, the output is written to disk as it is rendered.
+func (t *TerraformWriter) WriteFile(relativePath string, render func(w io.Writer) error) error {
+	t.mutex.Lock()
+	outDir := t.outDir
+	t.mutex.Unlock()
+
+	if outDir == "" {
+		buf := &bytes.Buffer{}
+		if err := render(buf); err != nil {
+			return err
+		}
+
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		t.Files[relativePath] = buf.Bytes()
+		return nil
+	}
+
+	p := path.Join(outDir, relativePath)
+	if err := os.MkdirAll(path.Dir(p), os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("error creating output directory %q: %v", path.Dir(p), err)
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(0o644))
+	if err != nil {
+		return fmt.Errorf("error creating terraform output file %q: %v", p, err)
+	}
+	w := bufio.NewWriter(f)
+	if err := render(w); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing terraform output file %q: %v", p, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error writing terraform output file %q: %v", p, err)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.streamedFiles[relativePath] = true
+	return nil
+}
+
+// HasFile returns true if the file at the path relative to the output directory was added, whether it was streamed or not.
+func (t *TerraformWriter) HasFile(relativePath string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	_, found := t.Files[relativePath]
+	return found || t.streamedFiles[relativePath]
 }
 
 func (t *TerraformWriter) AddFileBytes(resourceType string, resourceName string, key string, data []byte, base64 bool) (*Literal, error) {
@@ -124,17 +203,53 @@ func (t *TerraformWriter) EnsureTerraformProvider(name string, arguments map[str
 func (t *TerraformWriter) AddFilePath(resourceType string, resourceName string, key string, data []byte, base64 bool) (*Literal, error) {
 	id := resourceType + "_" + resourceName + "_" + key
 
-	t.mutex.Lock()
-	defer t.mutex.Unlock()
-
 	p := path.Join("data", id)
-	t.Files[p] = data
+	if err := t.writeData(p, data); err != nil {
+		return nil, err
+	}
 
 	modulePath := fmt.Sprintf("%q", path.Join("${path.module}", p))
 
 	return LiteralTokens(modulePath), nil
 }
 
+// AddSharedFileBytes adds a file like AddFileBytes, except that the resources adding the same contents share a single file,
+// named after the hash of its contents.
+func (t *TerraformWriter) AddSharedFileBytes(resourceType string, key string, data []byte) (*Literal, error) {
+	hash := sha256.Sum256(data)
+	p := path.Join("data", resourceType+"_"+key+"_"+hex.EncodeToString(hash[:8]))
+
+	t.mutex.Lock()
+	shared := t.sharedFiles[p]
+	t.sharedFiles[p] = true
+	t.mutex.Unlock()
+
+	if !shared {
+		if err := t.writeData(p, data); err != nil {
+			return nil, err
+		}
+	}
+
+	modulePath := fmt.Sprintf("%q", path.Join("${path.module}", p))
+
+	return LiteralFunctionExpression("file", LiteralTokens(modulePath)), nil
+}
+
+func (t *TerraformWriter) writeData(p string, data []byte) error {
+	t.mutex.Lock()
+	if t.outDir == "" {
+		t.Files[p] = data
+		t.mutex.Unlock()
+		return nil
+	}
+	t.mutex.Unlock()
+
+	return t.WriteFile(p, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
 func (t *TerraformWriter) RenderDataSource(dataType string, dataName string, e interface{}) error {
 	data := &terraformDataSource{
 		DataType: dataType,
@@ -306,6 +306,81 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 type change struct {
 	FieldName   string
 	Description string
+
+	// Actual and Expected are the values of the field, unless Description is a diff
+	Actual   string
+	Expected string
+}
+
+// TaskChange is a structured description of a change that the tasks would make.
+type TaskChange struct {
+	// Task is the type of the task, such as SecurityGroup.
+	Task string `json:"task"`
+	// Name is the name of the task, or the deleted item.
+	Name string `json:"name"`
+	// Action is one of create, modify or delete.
+	Action string `json:"action"`
+	// Fields are the fields that would be modified.
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// FieldChange is a field of a task that would be modified.
+type FieldChange struct {
+	Field    string `json:"field"`
+	Actual   string `json:"actual,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	// Diff is set instead of Actual and Expected for fields holding resources, such as user data.
+	Diff string `json:"diff,omitempty"`
+}
+
+// TaskChanges returns the changes that the tasks would make, ordered by task type and name.
+func (t *DryRunTarget[T]) TaskChanges(taskMap map[string]Task[T]) ([]*TaskChange, error) {
+	var taskChanges []*TaskChange
+
+	for _, r := range t.changes {
+		taskChange := &TaskChange{
+			Task: getTaskName(r.changes),
+			Name: idForTask(taskMap, r.e),
+		}
+		if r.aIsNil {
+			taskChange.Action = "create"
+		} else {
+			taskChange.Action = "modify"
+			changeList, err := buildChangeList(r.a, r.e, r.changes)
+			if err != nil {
+				return nil, err
+			}
+			for _, change := range changeList {
+				fieldChange := FieldChange{
+					Field:    change.FieldName,
+					Actual:   change.Actual,
+					Expected: change.Expected,
+				}
+				if change.Actual == "" && change.Expected == "" {
+					fieldChange.Diff = change.Description
+				}
+				taskChange.Fields = append(taskChange.Fields, fieldChange)
+			}
+		}
+		taskChanges = append(taskChanges, taskChange)
+	}
+
+	for _, d := range t.deletions {
+		taskChanges = append(taskChanges, &TaskChange{
+			Task:   d.TaskName(),
+			Name:   d.Item(),
+			Action: "delete",
+		})
+	}
+
+	sort.SliceStable(taskChanges, func(i, j int) bool {
+		if taskChanges[i].Task != taskChanges[j].Task {
+			return taskChanges[i].Task < taskChanges[j].Task
+		}
+		return taskChanges[i].Name < taskChanges[j].Name
+	})
+
+	return taskChanges, nil
 }
 
 func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
@@ -353,6 +428,7 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 			}
 
 			description := ""
+			actual, expected := "", ""
 			ignored := false
 			if fieldValE.CanInterface() {
 
@@ -368,13 +444,15 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 				}
 
 				if !ignored && description == "" {
-					description = fmt.Sprintf(" %v -> %v", reflectutils.ValueAsString(fieldValA), reflectutils.ValueAsString(fieldValE))
+					actual = reflectutils.ValueAsString(fieldValA)
+					expected = reflectutils.ValueAsString(fieldValE)
+					description = fmt.Sprintf(" %v -> %v", actual, expected)
 				}
 			}
 			if ignored {
 				continue
 			}
-			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description})
+			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description, Actual: actual, Expected: expected})
 		}
 	} else {
 		return nil, fmt.Errorf("unhandled change type: %v", valC.Type())
@@ -91,3 +91,53 @@ func Test_DryrunTarget_PrintReport(t *testing.T) {
 	err = target.PrintReport(tasks, &out)
 	assert.NoError(t, err, "target.PrintReport()")
 }
+
+func Test_DryrunTarget_TaskChanges(t *testing.T) {
+	builder := assets.NewAssetBuilder(vfs.Context, nil, "1.17.3", false)
+	target := newDryRunTarget[CloudupSubContext](builder, &bytes.Buffer{})
+
+	a := &testTask{
+		Name:      PtrTo("modified"),
+		Lifecycle: LifecycleSync,
+		Tags:      map[string]string{"key": "removed"},
+	}
+	e := &testTask{
+		Name:      PtrTo("modified"),
+		Lifecycle: LifecycleSync,
+		Tags:      map[string]string{"key": "value"},
+	}
+	changes := &testTask{}
+	_ = BuildChanges(a, e, changes)
+	assert.NoError(t, target.Render(a, e, changes), "target.Render()")
+
+	created := &testTask{
+		Name:      PtrTo("created"),
+		Lifecycle: LifecycleSync,
+	}
+	var missing *testTask
+	assert.NoError(t, target.Render(missing, created, created), "target.Render()")
+
+	tasks := map[string]CloudupTask{
+		"testTask/modified": e,
+		"testTask/created":  created,
+	}
+	actual, err := target.TaskChanges(tasks)
+	assert.NoError(t, err, "target.TaskChanges()")
+
+	expected := []*TaskChange{
+		{
+			Task:   "testTask",
+			Name:   "created",
+			Action: "create",
+		},
+		{
+			Task:   "testTask",
+			Name:   "modified",
+			Action: "modify",
+			Fields: []FieldChange{
+				{Field: "Tags", Actual: "{key: removed}", Expected: "{key: value}"},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -20,8 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -31,6 +32,11 @@ type executor[T SubContext] struct {
 	context *Context[T]
 
 	options RunTasksOptions
+
+	// fingerprinter hashes the tasks of incremental runs
+	fingerprinter *taskFingerprinter[T]
+	// skipped counts the tasks skipped by incremental runs
+	skipped atomic.Int32
 }
 
 type taskState[T SubContext] struct {
@@ -40,19 +46,36 @@ type taskState[T SubContext] struct {
 	deadline     time.Time
 	lastError    error
 	dependencies []*taskState[T]
+
+	// running is true while the task is executing
+	running bool
+	// failed is true if the task failed and no other task made progress since
+	failed bool
+}
+
+type taskResult[T SubContext] struct {
+	ts  *taskState[T]
+	err error
 }
 
 type RunTasksOptions struct {
 	MaxTaskDuration         time.Duration
 	WaitAfterAllTasksFailed time.Duration
+	// MaxConcurrentTasks is the maximum number of tasks executing at the same time; zero means no limit.
+	MaxConcurrentTasks int
+	// Incremental, if set, skips the tasks that have not changed since a previous run,
+	// and records the fingerprints of the tasks of this run.
+	Incremental *IncrementalOptions
 }
 
 func (o *RunTasksOptions) InitDefaults() {
 	o.MaxTaskDuration = 10 * time.Minute
 	o.WaitAfterAllTasksFailed = 10 * time.Second
+	o.MaxConcurrentTasks = 32
 }
 
 // RunTasks executes all the tasks, considering their dependencies
+// Each task is started as soon as its dependencies are done, so that independent tasks are executed in parallel.
 // It will perform some re-execution on error, retrying as long as progress is still being made
 func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T]) error {
 	dependencies := FindTaskDependencies(taskMap)
@@ -65,6 +88,10 @@ func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T])
 		}
 	}
 
+	if e.options.Incremental != nil {
+		e.fingerprinter = newTaskFingerprinter(taskMap)
+	}
+
 	taskStates := make(map[string]*taskState[T])
 
 	for k, task := range taskMap {
@@ -85,6 +112,30 @@ func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T])
 		}
 	}
 
+	// fail records the tasks that did not complete, so that a retry runs only them
+	fail := func(err error) error {
+		if e.options.Incremental != nil {
+			e.recordIncomplete(taskStates)
+		}
+		return err
+	}
+
+	results := make(chan taskResult[T], len(taskStates))
+	running := 0
+
+	// waitForRunning waits for the executing tasks, so that none is left running when we return
+	waitForRunning := func() {
+		for ; running > 0; running-- {
+			result := <-results
+			result.ts.running = false
+			if result.err != nil {
+				result.ts.lastError = result.err
+			} else {
+				result.ts.done = true
+			}
+		}
+	}
+
 	for {
 		var canRun []*taskState[T]
 		doneCount := 0
@@ -93,6 +144,9 @@ func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T])
 				doneCount++
 				continue
 			}
+			if ts.running || ts.failed {
+				continue
+			}
 			ready := true
 			for _, dep := range ts.dependencies {
 				if !dep.done {
@@ -104,65 +158,47 @@ func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T])
 				if ts.deadline.IsZero() {
 					ts.deadline = time.Now().Add(e.options.MaxTaskDuration)
 				} else if time.Now().After(ts.deadline) {
-					return fmt.Errorf("deadline exceeded executing task %v. Example error: %v", ts.key, ts.lastError)
+					waitForRunning()
+					return fail(fmt.Errorf("deadline exceeded executing task %v. Example error: %v", ts.key, ts.lastError))
 				}
 				canRun = append(canRun, ts)
 			}
 		}
 
-		klog.Infof("Tasks: %d done / %d total; %d can run", doneCount, len(taskStates), len(canRun))
-		if len(canRun) == 0 {
-			break
+		if e.options.MaxConcurrentTasks > 0 && running+len(canRun) > e.options.MaxConcurrentTasks {
+			canRun = canRun[:e.options.MaxConcurrentTasks-running]
+		}
+		if len(canRun) != 0 {
+			klog.Infof("Tasks: %d done / %d total; %d running, %d starting", doneCount, len(taskStates), running, len(canRun))
+		}
+		for _, ts := range canRun {
+			ts.running = true
+			running++
+			go func(ts *taskState[T]) {
+				results <- taskResult[T]{ts: ts, err: e.runTask(ctx, ts)}
+			}(ts)
 		}
 
-		progress := false
-
-		var tasks []*taskState[T]
-		tasks = append(tasks, canRun...)
-
-		taskErrors := e.forkJoin(ctx, tasks)
-		var errs []error
-		for i, err := range taskErrors {
-			ts := tasks[i]
-			if err != nil {
-				//  print warning message and continue like the task succeeded
-				if _, ok := err.(*ExistsAndWarnIfChangesError); ok {
-					klog.Warningf(err.Error())
-					ts.done = true
-					ts.lastError = nil
-					progress = true
-					continue
-				}
-
-				remaining := time.Second * time.Duration(int(time.Until(ts.deadline).Seconds()))
-				if _, ok := err.(*TryAgainLaterError); ok {
-					klog.V(2).Infof("Task %q not ready: %v", ts.key, err)
-				} else {
-					klog.Warningf("error running task %q (%v remaining to succeed): %v", ts.key, remaining, err)
+		if running == 0 {
+			var failed []*taskState[T]
+			for _, ts := range taskStates {
+				if ts.failed {
+					failed = append(failed, ts)
 				}
-				errs = append(errs, err)
-				ts.lastError = err
-			} else {
-				ts.done = true
-				ts.lastError = nil
-				progress = true
 			}
-		}
-
-		if !progress {
-			n := len(errs)
-
+			n := len(failed)
 			if n == 0 {
-				// Logic error!
-				panic("did not make progress executing tasks; but no errors reported")
+				break
 			}
 
+			// No task made progress since these tasks failed; retry them after a while
 			tryAgainLaterCount := 0
-			for _, err := range errs {
+			for _, ts := range failed {
 				var tryAgainLaterError TryAgainLaterError
-				if !errors.Is(err, &tryAgainLaterError) {
+				if !errors.Is(ts.lastError, &tryAgainLaterError) {
 					tryAgainLaterCount++
 				}
+				ts.failed = false
 			}
 			formatTaskCount := func(n int) string {
 				return fmt.Sprintf("%d task(s)", n)
@@ -173,6 +209,45 @@ func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T])
 				klog.Infof("No progress made, sleeping before retrying %s", formatTaskCount(n))
 			}
 			time.Sleep(e.options.WaitAfterAllTasksFailed)
+			continue
+		}
+
+		result := <-results
+		running--
+		ts := result.ts
+		ts.running = false
+
+		err := result.err
+		if err != nil {
+			//  print warning message and continue like the task succeeded
+			if _, ok := err.(*ExistsAndWarnIfChangesError); ok {
+				klog.Warningf(err.Error())
+				err = nil
+			}
+		}
+		if errors.Is(err, ErrCircuitOpen) {
+			// Retrying the tasks would fail the same way, so the run stops once the running tasks are done
+			waitForRunning()
+			ts.lastError = err
+			return fail(fmt.Errorf("aborted after task %v failed: %w", ts.key, err))
+		}
+		if err != nil {
+			remaining := time.Second * time.Duration(int(time.Until(ts.deadline).Seconds()))
+			if _, ok := err.(*TryAgainLaterError); ok {
+				klog.V(2).Infof("Task %q not ready: %v", ts.key, err)
+			} else {
+				klog.Warningf("error running task %q (%v remaining to succeed): %v", ts.key, remaining, err)
+			}
+			ts.lastError = err
+			ts.failed = true
+		} else {
+			ts.done = true
+			ts.lastError = nil
+
+			// Progress was made, so the failed tasks are retried
+			for _, other := range taskStates {
+				other.failed = false
+			}
 		}
 	}
 
@@ -184,51 +259,89 @@ func (e *executor[T]) RunTasks(ctx context.Context, taskMap map[string]Task[T])
 		}
 	}
 	if len(notDone) != 0 {
-		return fmt.Errorf("Unable to execute tasks (circular dependency): %s", strings.Join(notDone, ", "))
+		return fail(fmt.Errorf("Unable to execute tasks (circular dependency): %s", strings.Join(notDone, ", ")))
+	}
+
+	if e.options.Incremental != nil {
+		klog.Infof("Skipped %d of %d tasks, unchanged since the last apply", e.skipped.Load(), len(taskStates))
 	}
 
 	return nil
 }
 
-func (e *executor[T]) forkJoin(ctx context.Context, tasks []*taskState[T]) []error {
-	if len(tasks) == 0 {
-		return nil
+// recordIncomplete records the tasks that failed, and the tasks that did not run, in the incremental state of the run.
+func (e *executor[T]) recordIncomplete(taskStates map[string]*taskState[T]) {
+	failed := make(map[string]string)
+	var blocked []string
+	for k, ts := range taskStates {
+		switch {
+		case ts.done:
+		case ts.lastError != nil:
+			failed[k] = ts.lastError.Error()
+		default:
+			blocked = append(blocked, k)
+		}
 	}
+	e.options.Incremental.Current.recordIncomplete(failed, blocked)
+}
 
-	results := make([]error, len(tasks))
-	var resultsMutex sync.Mutex
-
-	var wg sync.WaitGroup
-	for i := 0; i < len(tasks); i++ {
-		wg.Add(1)
-		go func(ts *taskState[T], index int) {
-			defer wg.Done()
-
-			_, span := tracer.Start(ctx, "task-"+ts.key)
-			defer span.End()
+// runTask executes a single task.
+func (e *executor[T]) runTask(ctx context.Context, ts *taskState[T]) error {
+	_, span := tracer.Start(ctx, "task-"+ts.key)
+	defer span.End()
 
-			resultsMutex.Lock()
-			results[index] = fmt.Errorf("function panic")
-			resultsMutex.Unlock()
+	klog.V(2).Infof("Executing task %q: %v\n", ts.key, ts.task)
 
-			klog.V(2).Infof("Executing task %q: %v\n", ts.key, ts.task)
+	if taskNormalize, ok := ts.task.(TaskNormalize[T]); ok {
+		if err := taskNormalize.Normalize(e.context); err != nil {
+			return err
+		}
+	}
 
-			if taskNormalize, ok := ts.task.(TaskNormalize[T]); ok {
-				if err := taskNormalize.Normalize(e.context); err != nil {
-					results[index] = err
-					return
-				}
-			}
+	if e.options.Incremental != nil && isIncrementalTask(ts.task) {
+		return e.runIncrementalTask(ts)
+	}
+	return ts.task.Run(e.context)
+}
 
-			result := ts.task.Run(e.context)
+// runIncrementalTask skips the task if its fingerprint matches the one of the previous run, restoring the values
+// that the previous run set. Otherwise it runs the task, and records its fingerprint.
+func (e *executor[T]) runIncrementalTask(ts *taskState[T]) error {
+	incremental := e.options.Incremental
 
-			resultsMutex.Lock()
-			results[index] = result
-			resultsMutex.Unlock()
-		}(tasks[i], i)
+	hash, err := e.fingerprinter.hash(ts.task)
+	if err != nil {
+		klog.V(2).Infof("Task %q cannot be fingerprinted, so it always runs: %v", ts.key, err)
+		return ts.task.Run(e.context)
 	}
 
-	wg.Wait()
+	if incremental.Previous != nil {
+		if previous := incremental.Previous.get(ts.key); previous != nil && previous.Hash == hash {
+			if err := restoreFields(ts.task, previous.Outputs); err != nil {
+				klog.Warningf("running task %q: %v", ts.key, err)
+			} else {
+				klog.V(2).Infof("Skipping task %q, unchanged since the last apply", ts.key)
+				e.skipped.Add(1)
+				incremental.Current.set(ts.key, previous)
+				return nil
+			}
+		}
+	}
 
-	return results
+	before, err := simpleFields(reflect.ValueOf(ts.task).Elem())
+	if err != nil {
+		return err
+	}
+	if err := ts.task.Run(e.context); err != nil {
+		return err
+	}
+	after, err := simpleFields(reflect.ValueOf(ts.task).Elem())
+	if err != nil {
+		return err
+	}
+	incremental.Current.set(ts.key, &TaskFingerprint{
+		Hash:    hash,
+		Outputs: changedFields(before, after),
+	})
+	return nil
 }
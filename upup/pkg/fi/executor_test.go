@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type executorTestTask struct {
+	dependencies []Task[CloudupSubContext]
+	run          func() error
+}
+
+var _ CloudupHasDependencies = &executorTestTask{}
+
+func (t *executorTestTask) GetDependencies(map[string]CloudupTask) []CloudupTask {
+	return t.dependencies
+}
+
+func (t *executorTestTask) Run(*CloudupContext) error {
+	return t.run()
+}
+
+func runExecutorTest(options RunTasksOptions, tasks map[string]CloudupTask) error {
+	e := &executor[CloudupSubContext]{
+		context: &CloudupContext{},
+		options: options,
+	}
+	return e.RunTasks(context.Background(), tasks)
+}
+
+func TestExecutorStartsTasksWhenDependenciesAreDone(t *testing.T) {
+	// The slow task only completes once the task that depends on a fast task has run,
+	// so it must not wait for all the tasks that were started together
+	release := make(chan struct{})
+	fast := &executorTestTask{run: func() error { return nil }}
+	dependent := &executorTestTask{
+		dependencies: []CloudupTask{fast},
+		run: func() error {
+			close(release)
+			return nil
+		},
+	}
+	slow := &executorTestTask{run: func() error {
+		select {
+		case <-release:
+			return nil
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("dependent task did not run while the slow task was running")
+		}
+	}}
+
+	options := RunTasksOptions{MaxTaskDuration: time.Second, WaitAfterAllTasksFailed: time.Millisecond, MaxConcurrentTasks: 2}
+	err := runExecutorTest(options, map[string]CloudupTask{
+		"fast":      fast,
+		"dependent": dependent,
+		"slow":      slow,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExecutorLimitsConcurrentTasks(t *testing.T) {
+	var mutex sync.Mutex
+	running, maxRunning := 0, 0
+
+	tasks := make(map[string]CloudupTask)
+	for i := 0; i < 10; i++ {
+		tasks[fmt.Sprintf("task-%d", i)] = &executorTestTask{run: func() error {
+			mutex.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mutex.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mutex.Lock()
+			running--
+			mutex.Unlock()
+			return nil
+		}}
+	}
+
+	options := RunTasksOptions{MaxTaskDuration: time.Second, WaitAfterAllTasksFailed: time.Millisecond, MaxConcurrentTasks: 3}
+	if err := runExecutorTest(options, tasks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxRunning > 3 {
+		t.Errorf("expected at most 3 tasks to run at the same time, got %d", maxRunning)
+	}
+}
+
+func TestExecutorRetriesFailedTasks(t *testing.T) {
+	attempts := 0
+	flaky := &executorTestTask{run: func() error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("attempt %d failed", attempts)
+		}
+		return nil
+	}}
+	dependent := &executorTestTask{
+		dependencies: []CloudupTask{flaky},
+		run:          func() error { return nil },
+	}
+
+	options := RunTasksOptions{MaxTaskDuration: time.Second, WaitAfterAllTasksFailed: time.Millisecond}
+	err := runExecutorTest(options, map[string]CloudupTask{
+		"flaky":     flaky,
+		"dependent": dependent,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+
+	// A task that keeps failing fails the run once its deadline is exceeded
+	failing := &executorTestTask{run: func() error { return fmt.Errorf("always failing") }}
+	options = RunTasksOptions{MaxTaskDuration: 10 * time.Millisecond, WaitAfterAllTasksFailed: time.Millisecond}
+	err = runExecutorTest(options, map[string]CloudupTask{"failing": failing})
+	if err == nil {
+		t.Fatalf("expected deadline error")
+	}
+}
+
+func TestExecutorAbortsWhenCircuitOpens(t *testing.T) {
+	attempts := 0
+	failing := &executorTestTask{run: func() error {
+		attempts++
+		return fmt.Errorf("error creating tags: %w", ErrCircuitOpen)
+	}}
+	dependent := &executorTestTask{
+		dependencies: []CloudupTask{failing},
+		run:          func() error { return nil },
+	}
+
+	options := RunTasksOptions{MaxTaskDuration: time.Minute, WaitAfterAllTasksFailed: time.Millisecond}
+	err := runExecutorTest(options, map[string]CloudupTask{
+		"failing":   failing,
+		"dependent": dependent,
+	})
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fitasks
+
+import (
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/externaltask"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// +kops:fitask
+type ExternalTask struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	// Command is the executable of the plugin and its arguments.
+	Command []string
+	// URL is the endpoint of the webhook of the plugin.
+	URL string
+	// DependsOn are the names of other external tasks, or the keys of tasks of kOps, that must run before this task.
+	DependsOn []string
+	// Config is the desired state of the resource of the task.
+	Config map[string]string
+}
+
+var _ fi.CloudupHasDependencies = &ExternalTask{}
+
+func (e *ExternalTask) GetDependencies(tasks map[string]fi.CloudupTask) []fi.CloudupTask {
+	var deps []fi.CloudupTask
+	for _, dependency := range e.DependsOn {
+		key := dependency
+		if !strings.Contains(key, "/") {
+			key = "ExternalTask/" + dependency
+		}
+		task, found := tasks[key]
+		if !found {
+			klog.Warningf("task %q, a dependency of external task %q, was not found", key, fi.ValueOf(e.Name))
+			continue
+		}
+		deps = append(deps, task)
+	}
+	return deps
+}
+
+var _ fi.CloudupHasCheckExisting = &ExternalTask{}
+
+// CheckExisting is true so that the plugin is asked for the actual state on every target, including terraform.
+func (e *ExternalTask) CheckExisting(c *fi.CloudupContext) bool {
+	return true
+}
+
+func (e *ExternalTask) plugin() (externaltask.Plugin, error) {
+	return externaltask.NewPlugin(&kops.ExternalTaskSpec{
+		Name:    fi.ValueOf(e.Name),
+		Command: e.Command,
+		URL:     e.URL,
+	})
+}
+
+func (e *ExternalTask) Find(c *fi.CloudupContext) (*ExternalTask, error) {
+	plugin, err := e.plugin()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := plugin.Call(c.Context(), &externaltask.Request{
+		Operation:   externaltask.OperationFind,
+		ClusterName: c.T.Cluster.ObjectMeta.Name,
+		Task:        fi.ValueOf(e.Name),
+		Config:      e.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !response.Exists {
+		return nil, nil
+	}
+
+	actual := &ExternalTask{
+		Name:   e.Name,
+		Config: response.Config,
+	}
+
+	// Avoid spurious changes
+	actual.Lifecycle = e.Lifecycle
+	actual.Command = e.Command
+	actual.URL = e.URL
+	actual.DependsOn = e.DependsOn
+
+	return actual, nil
+}
+
+func (e *ExternalTask) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (s *ExternalTask) CheckChanges(a, e, changes *ExternalTask) error {
+	if fi.ValueOf(e.Name) == "" {
+		return fi.RequiredField("Name")
+	}
+	return nil
+}
+
+// Render asks the plugin to apply the desired state. External tasks are applied by kOps on every target,
+// as their resources cannot be rendered for terraform.
+func (_ *ExternalTask) Render(c *fi.CloudupContext, a, e, changes *ExternalTask) error {
+	plugin, err := e.plugin()
+	if err != nil {
+		return err
+	}
+
+	_, err = plugin.Call(c.Context(), &externaltask.Request{
+		Operation:   externaltask.OperationApply,
+		ClusterName: c.T.Cluster.ObjectMeta.Name,
+		Task:        fi.ValueOf(e.Name),
+		Config:      e.Config,
+	})
+	return err
+}
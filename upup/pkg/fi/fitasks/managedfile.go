@@ -47,6 +47,9 @@ type ManagedFile struct {
 	// PublicACL controls whether the _object_ has an ACL which grants world-readable status.
 	// Note that the _bucket_ may itself have a grant for world-readable; that is separate.
 	PublicACL *bool
+
+	// KubernetesManifest marks the contents as a manifest of objects to be applied to the cluster.
+	KubernetesManifest *bool `json:"-"`
 }
 
 func (e *ManagedFile) Find(c *fi.CloudupContext) (*ManagedFile, error) {
@@ -105,6 +108,7 @@ func (e *ManagedFile) Find(c *fi.CloudupContext) (*ManagedFile, error) {
 
 	// Avoid spurious changes
 	actual.Lifecycle = e.Lifecycle
+	actual.KubernetesManifest = e.KubernetesManifest
 
 	return actual, nil
 }
@@ -199,6 +203,16 @@ func getBasePath(c *fi.CloudupContext, e *ManagedFile) (vfs.Path, error) {
 
 // RenderTerraform is responsible for rendering the terraform json.
 func (f *ManagedFile) RenderTerraform(c *fi.CloudupContext, t *terraform.TerraformTarget, a, e, changes *ManagedFile) error {
+	if fi.ValueOf(e.KubernetesManifest) && t.ManageAddons() {
+		data, err := fi.ResourceAsBytes(e.Contents)
+		if err != nil {
+			return fmt.Errorf("error reading contents of ManagedFile: %v", err)
+		}
+		if err := t.RenderKubernetesManifest(fi.ValueOf(e.Name), data); err != nil {
+			return err
+		}
+	}
+
 	if !featureflag.TerraformManagedFiles.Enabled() {
 		return f.Render(c, a, e, changes)
 	}
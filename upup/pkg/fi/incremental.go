@@ -0,0 +1,348 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// maxFingerprintDepth bounds the nesting of the values of a task, so that cyclic values are rejected.
+const maxFingerprintDepth = 64
+
+// IncrementalState holds the fingerprints of the tasks of an apply, so that the next apply can skip
+// the tasks whose desired state has not changed since.
+type IncrementalState struct {
+	// KopsVersion is the version of kOps that ran the tasks; fingerprints of other versions are not used,
+	// as the tasks may behave differently.
+	KopsVersion string `json:"kopsVersion"`
+	// Tasks holds the fingerprint of each task that ran successfully, by task key.
+	Tasks map[string]*TaskFingerprint `json:"tasks"`
+	// Failed holds the last error of each task that failed, by task key, if the apply failed part-way.
+	Failed map[string]string `json:"failed,omitempty"`
+	// Blocked lists the tasks that did not run because they depend on tasks that failed.
+	Blocked []string `json:"blocked,omitempty"`
+
+	mutex sync.Mutex
+}
+
+// TaskFingerprint identifies the desired state of a task, and records the values that running it added to the task.
+type TaskFingerprint struct {
+	// Hash is the hash of the fields of the task, before it ran.
+	Hash string `json:"hash"`
+	// Outputs are the fields that were set by running the task, such as the ID of the cloud resource.
+	// They are restored when the task is skipped, for the tasks that depend on it.
+	Outputs map[string]json.RawMessage `json:"outputs,omitempty"`
+}
+
+// NewIncrementalState builds an empty IncrementalState for the version of kOps.
+func NewIncrementalState(kopsVersion string) *IncrementalState {
+	return &IncrementalState{
+		KopsVersion: kopsVersion,
+		Tasks:       make(map[string]*TaskFingerprint),
+	}
+}
+
+// Len returns the number of tasks with a fingerprint.
+func (s *IncrementalState) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.Tasks)
+}
+
+func (s *IncrementalState) get(key string) *TaskFingerprint {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.Tasks[key]
+}
+
+func (s *IncrementalState) set(key string, fingerprint *TaskFingerprint) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.Tasks[key] = fingerprint
+}
+
+// recordIncomplete records the tasks that failed or did not run, when the apply fails part-way.
+func (s *IncrementalState) recordIncomplete(failed map[string]string, blocked []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sort.Strings(blocked)
+	s.Failed = failed
+	s.Blocked = blocked
+}
+
+// IncrementalOptions configures the tasks that are skipped by an incremental run.
+type IncrementalOptions struct {
+	// Previous holds the fingerprints of the previous run; tasks with the same fingerprint are not run.
+	// When nil, all the tasks are run.
+	Previous *IncrementalState
+	// Current receives the fingerprints of the tasks that ran successfully or were skipped.
+	Current *IncrementalState
+}
+
+// isIncrementalTask returns true for the tasks whose state can be fingerprinted and restored, so that they can be skipped.
+// Tasks that keep unexported state, or that find resources to delete, always run.
+func isIncrementalTask[T SubContext](task Task[T]) bool {
+	if hl, ok := task.(HasLifecycle); ok && hl.GetLifecycle() != LifecycleSync {
+		return false
+	}
+	if _, ok := task.(ProducesDeletions[T]); ok {
+		return false
+	}
+
+	v := reflect.ValueOf(task)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	t := v.Elem().Type()
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			return false
+		}
+	}
+	return true
+}
+
+// taskFingerprinter hashes the desired state of tasks.
+type taskFingerprinter[T SubContext] struct {
+	// taskKeys maps each task to its key, to identify the tasks referenced by other tasks.
+	taskKeys map[interface{}]string
+}
+
+func newTaskFingerprinter[T SubContext](tasks map[string]Task[T]) *taskFingerprinter[T] {
+	taskKeys := make(map[interface{}]string)
+	for k, t := range tasks {
+		taskKeys[t] = k
+	}
+	return &taskFingerprinter[T]{taskKeys: taskKeys}
+}
+
+// hash returns the hash of the exported fields of the task. The tasks it references are identified by their key
+// and their simple fields, which include the values that running them set, like the ID of the cloud resource.
+// Resources are identified by their contents.
+func (f *taskFingerprinter[T]) hash(task Task[T]) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%T", task)
+	if err := f.writeFields(h, reflect.ValueOf(task).Elem(), 0); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (f *taskFingerprinter[T]) writeFields(h hash.Hash, v reflect.Value, depth int) error {
+	t := v.Type()
+	h.Write([]byte("{"))
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		fmt.Fprintf(h, "%s:", t.Field(i).Name)
+		if err := f.write(h, v.Field(i), depth+1); err != nil {
+			return fmt.Errorf("%s: %w", t.Field(i).Name, err)
+		}
+		h.Write([]byte(","))
+	}
+	h.Write([]byte("}"))
+	return nil
+}
+
+func (f *taskFingerprinter[T]) write(h hash.Hash, v reflect.Value, depth int) error {
+	if depth > maxFingerprintDepth {
+		return fmt.Errorf("value is nested too deeply")
+	}
+	if !v.IsValid() {
+		h.Write([]byte("null"))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			h.Write([]byte("null"))
+			return nil
+		}
+		return f.write(h, v.Elem(), depth+1)
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			h.Write([]byte("null"))
+			return nil
+		}
+		if v.CanInterface() {
+			i := v.Interface()
+			if key, found := f.taskKeys[i]; found {
+				fmt.Fprintf(h, "task(%q)", key)
+				outputs, err := simpleFields(v.Elem())
+				if err != nil {
+					return err
+				}
+				writeSorted(h, outputs)
+				return nil
+			}
+			if r, ok := i.(Resource); ok {
+				b, err := ResourceAsBytes(r)
+				if err != nil {
+					return fmt.Errorf("error reading resource: %w", err)
+				}
+				fmt.Fprintf(h, "resource(%x)", sha256.Sum256(b))
+				return nil
+			}
+		}
+		return f.write(h, v.Elem(), depth+1)
+
+	case reflect.Struct:
+		return f.writeFields(h, v, depth)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			h.Write([]byte("null"))
+			return nil
+		}
+		h.Write([]byte("["))
+		for i := 0; i < v.Len(); i++ {
+			if err := f.write(h, v.Index(i), depth+1); err != nil {
+				return err
+			}
+			h.Write([]byte(","))
+		}
+		h.Write([]byte("]"))
+		return nil
+
+	case reflect.Map:
+		if v.IsNil() {
+			h.Write([]byte("null"))
+			return nil
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		h.Write([]byte("{"))
+		for _, k := range keys {
+			fmt.Fprintf(h, "%q:", fmt.Sprint(k.Interface()))
+			if err := f.write(h, v.MapIndex(k), depth+1); err != nil {
+				return err
+			}
+			h.Write([]byte(","))
+		}
+		h.Write([]byte("}"))
+		return nil
+
+	case reflect.String:
+		fmt.Fprintf(h, "%q", v.String())
+		return nil
+
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		fmt.Fprintf(h, "%v", v)
+		return nil
+
+	default:
+		return fmt.Errorf("cannot fingerprint value of kind %v", v.Kind())
+	}
+}
+
+// isSimpleKind returns true for the kinds of the fields that can be restored from their JSON value.
+func isSimpleKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// simpleFields returns the JSON values of the exported fields of the struct that are scalars, or pointers to scalars.
+func simpleFields(v reflect.Value) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if v.Kind() != reflect.Struct {
+		return fields, nil
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		kind := field.Type.Kind()
+		if kind == reflect.Ptr {
+			kind = field.Type.Elem().Kind()
+		}
+		if !isSimpleKind(kind) {
+			continue
+		}
+		b, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("error serializing field %s: %w", field.Name, err)
+		}
+		fields[field.Name] = b
+	}
+	return fields, nil
+}
+
+// changedFields returns the fields of after whose values differ from before.
+func changedFields(before, after map[string]json.RawMessage) map[string]json.RawMessage {
+	changed := make(map[string]json.RawMessage)
+	for k, v := range after {
+		if string(before[k]) != string(v) {
+			changed[k] = v
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	return changed
+}
+
+// restoreFields sets the fields of the task to the recorded values.
+func restoreFields[T SubContext](task Task[T], fields map[string]json.RawMessage) error {
+	v := reflect.ValueOf(task).Elem()
+	for name, value := range fields {
+		field := v.FieldByName(name)
+		if !field.IsValid() || !field.CanSet() {
+			return fmt.Errorf("field %s not found in %T", name, task)
+		}
+		if err := json.Unmarshal(value, field.Addr().Interface()); err != nil {
+			return fmt.Errorf("error restoring field %s of %T: %w", name, task, err)
+		}
+	}
+	return nil
+}
+
+func writeSorted(h hash.Hash, fields map[string]json.RawMessage) {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h.Write([]byte("{"))
+	for _, k := range keys {
+		fmt.Fprintf(h, "%q:%s,", k, fields[k])
+	}
+	h.Write([]byte("}"))
+}
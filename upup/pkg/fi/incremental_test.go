@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	incrementalTestRunsMutex sync.Mutex
+	incrementalTestRuns      []string
+	// incrementalTestFailures holds the names of the tasks that fail when they run
+	incrementalTestFailures map[string]bool
+)
+
+// incrementalTestTask sets its ID when it runs, from its name and the ID of its parent.
+type incrementalTestTask struct {
+	Name      *string
+	Lifecycle Lifecycle
+	ID        *string
+	CIDR      string
+	Parent    *incrementalTestTask
+	UserData  Resource
+}
+
+func (t *incrementalTestTask) GetLifecycle() Lifecycle {
+	return t.Lifecycle
+}
+
+func (t *incrementalTestTask) Run(*CloudupContext) error {
+	incrementalTestRunsMutex.Lock()
+	defer incrementalTestRunsMutex.Unlock()
+
+	incrementalTestRuns = append(incrementalTestRuns, *t.Name)
+	if incrementalTestFailures[*t.Name] {
+		return fmt.Errorf("%s failed", *t.Name)
+	}
+	id := *t.Name + "-id"
+	if t.Parent != nil {
+		id = *t.Parent.ID + "/" + id
+	}
+	t.ID = &id
+	return nil
+}
+
+func buildIncrementalTestTasks(cidr string, userData string) map[string]CloudupTask {
+	vpc := &incrementalTestTask{Name: PtrTo("vpc"), Lifecycle: LifecycleSync}
+	subnet := &incrementalTestTask{Name: PtrTo("subnet"), Lifecycle: LifecycleSync, Parent: vpc, CIDR: cidr}
+	instance := &incrementalTestTask{Name: PtrTo("instance"), Lifecycle: LifecycleSync, Parent: subnet, UserData: NewStringResource(userData)}
+	return map[string]CloudupTask{
+		"vpc":      vpc,
+		"subnet":   subnet,
+		"instance": instance,
+	}
+}
+
+func runIncrementalTest(t *testing.T, tasks map[string]CloudupTask, previous *IncrementalState) (*IncrementalState, []string) {
+	state, runs, err := runIncrementalTestWithFailures(t, tasks, previous, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return state, runs
+}
+
+func runIncrementalTestWithFailures(t *testing.T, tasks map[string]CloudupTask, previous *IncrementalState, failures map[string]bool) (*IncrementalState, []string, error) {
+	incrementalTestRunsMutex.Lock()
+	incrementalTestRuns = nil
+	incrementalTestFailures = failures
+	incrementalTestRunsMutex.Unlock()
+
+	current := NewIncrementalState("1.0.0")
+	options := RunTasksOptions{
+		MaxTaskDuration:         50 * time.Millisecond,
+		WaitAfterAllTasksFailed: time.Millisecond,
+		Incremental:             &IncrementalOptions{Previous: previous, Current: current},
+	}
+	runErr := runExecutorTest(options, tasks)
+
+	// The state is persisted between applies
+	data, err := json.Marshal(current)
+	if err != nil {
+		t.Fatalf("error serializing state: %v", err)
+	}
+	persisted := &IncrementalState{}
+	if err := json.Unmarshal(data, persisted); err != nil {
+		t.Fatalf("error parsing state: %v", err)
+	}
+
+	runs := append([]string(nil), incrementalTestRuns...)
+	sort.Strings(runs)
+	return persisted, runs, runErr
+}
+
+func TestIncrementalRun(t *testing.T) {
+	state, runs := runIncrementalTest(t, buildIncrementalTestTasks("10.0.0.0/24", "a"), nil)
+	if expected := []string{"instance", "subnet", "vpc"}; !reflect.DeepEqual(runs, expected) {
+		t.Errorf("first run: expected runs %v, got %v", expected, runs)
+	}
+	if state.Len() != 3 {
+		t.Errorf("first run: expected 3 fingerprints, got %d", state.Len())
+	}
+
+	tasks := buildIncrementalTestTasks("10.0.0.0/24", "a")
+	state, runs = runIncrementalTest(t, tasks, state)
+	if len(runs) != 0 {
+		t.Errorf("unchanged run: expected no runs, got %v", runs)
+	}
+	if id := ValueOf(tasks["instance"].(*incrementalTestTask).ID); id != "vpc-id/subnet-id/instance-id" {
+		t.Errorf("unchanged run: expected the ID of skipped tasks to be restored, got %q", id)
+	}
+	if state.Len() != 3 {
+		t.Errorf("unchanged run: expected 3 fingerprints, got %d", state.Len())
+	}
+
+	// The fields of a task are inputs of the tasks that depend on it
+	state, runs = runIncrementalTest(t, buildIncrementalTestTasks("10.0.1.0/24", "a"), state)
+	if expected := []string{"instance", "subnet"}; !reflect.DeepEqual(runs, expected) {
+		t.Errorf("changed field: expected runs %v, got %v", expected, runs)
+	}
+
+	_, runs = runIncrementalTest(t, buildIncrementalTestTasks("10.0.1.0/24", "b"), state)
+	if expected := []string{"instance"}; !reflect.DeepEqual(runs, expected) {
+		t.Errorf("changed resource: expected runs %v, got %v", expected, runs)
+	}
+}
+
+func TestIncrementalRunSkipsOnlySyncTasks(t *testing.T) {
+	state, _ := runIncrementalTest(t, buildIncrementalTestTasks("10.0.0.0/24", "a"), nil)
+
+	tasks := buildIncrementalTestTasks("10.0.0.0/24", "a")
+	tasks["instance"].(*incrementalTestTask).Lifecycle = LifecycleWarnIfInsufficientAccess
+	_, runs := runIncrementalTest(t, tasks, state)
+	if expected := []string{"instance"}; !reflect.DeepEqual(runs, expected) {
+		t.Errorf("expected runs %v, got %v", expected, runs)
+	}
+}
+
+func TestRetryFailedRun(t *testing.T) {
+	tasks := buildIncrementalTestTasks("10.0.0.0/24", "a")
+	tasks["other"] = &incrementalTestTask{Name: PtrTo("other"), Lifecycle: LifecycleSync}
+	state, _, err := runIncrementalTestWithFailures(t, tasks, nil, map[string]bool{"subnet": true})
+	if err == nil {
+		t.Fatalf("expected the run to fail")
+	}
+	if expected := map[string]string{"subnet": "subnet failed"}; !reflect.DeepEqual(state.Failed, expected) {
+		t.Errorf("expected failed tasks %v, got %v", expected, state.Failed)
+	}
+	if expected := []string{"instance"}; !reflect.DeepEqual(state.Blocked, expected) {
+		t.Errorf("expected blocked tasks %v, got %v", expected, state.Blocked)
+	}
+	if state.Len() != 2 {
+		t.Errorf("expected fingerprints of the 2 completed tasks, got %d", state.Len())
+	}
+
+	// The retry runs only the failed task and the tasks it blocked
+	state.Failed, state.Blocked = nil, nil
+	tasks = buildIncrementalTestTasks("10.0.0.0/24", "a")
+	tasks["other"] = &incrementalTestTask{Name: PtrTo("other"), Lifecycle: LifecycleSync}
+	state, runs, err := runIncrementalTestWithFailures(t, tasks, state, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"instance", "subnet"}; !reflect.DeepEqual(runs, expected) {
+		t.Errorf("expected runs %v, got %v", expected, runs)
+	}
+	if id := ValueOf(tasks["instance"].(*incrementalTestTask).ID); id != "vpc-id/subnet-id/instance-id" {
+		t.Errorf("expected the ID of the skipped tasks to be restored, got %q", id)
+	}
+	if state.Len() != 4 || len(state.Failed) != 0 || len(state.Blocked) != 0 {
+		t.Errorf("expected 4 fingerprints and no failures, got %d fingerprints, failed %v and blocked %v", state.Len(), state.Failed, state.Blocked)
+	}
+}
@@ -16,7 +16,13 @@ limitations under the License.
 
 package fi
 
-import "k8s.io/apimachinery/pkg/util/sets"
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
 
 type Lifecycle string
 
@@ -64,3 +70,43 @@ var LifecycleNameMap = map[string]Lifecycle{
 	"ExistsAndValidates":       LifecycleExistsAndValidates,
 	"ExistsAndWarnIfChanges":   LifecycleExistsAndWarnIfChanges,
 }
+
+// FindLifecycleOverride returns the lifecycle override that applies to the task with the given type and name.
+// Overrides are keyed either by task type, such as "SecurityGroupRule", or by task type and a name pattern,
+// such as "SecurityGroupRule:api-elb*", where "*" matches any sequence of characters.
+// Overrides with a name pattern take precedence over the task type, and the longest matching pattern wins.
+func FindLifecycleOverride(overrides map[string]Lifecycle, typeName string, name string) (Lifecycle, bool) {
+	var bestPattern *string
+	var lifecycle Lifecycle
+	for key, value := range overrides {
+		overrideType, pattern, ok := strings.Cut(key, ":")
+		if !ok || overrideType != typeName || !MatchesLifecyclePattern(pattern, name) {
+			continue
+		}
+		// Break ties between patterns of the same length alphabetically, so the result does not depend on map ordering
+		if bestPattern == nil || len(pattern) > len(*bestPattern) || (len(pattern) == len(*bestPattern) && pattern < *bestPattern) {
+			lifecycle = value
+			bestPattern = &pattern
+		}
+	}
+	if bestPattern != nil {
+		return lifecycle, true
+	}
+
+	lifecycle, found := overrides[typeName]
+	return lifecycle, found
+}
+
+// lifecyclePatterns caches the compiled regular expression of each lifecycle override pattern,
+// since the overrides are matched against the name of every task.
+var lifecyclePatterns sync.Map
+
+// MatchesLifecyclePattern returns true if the name matches the pattern of a lifecycle override.
+func MatchesLifecyclePattern(pattern string, name string) bool {
+	if re, found := lifecyclePatterns.Load(pattern); found {
+		return re.(*regexp.Regexp).MatchString(name)
+	}
+	expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	re, _ := lifecyclePatterns.LoadOrStore(pattern, regexp.MustCompile(expr))
+	return re.(*regexp.Regexp).MatchString(name)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import "testing"
+
+func TestFindLifecycleOverride(t *testing.T) {
+	overrides := map[string]Lifecycle{
+		"SecurityGroup":                  LifecycleIgnore,
+		"SecurityGroupRule":              LifecycleExistsAndValidates,
+		"SecurityGroupRule:api-elb*":     LifecycleExistsAndWarnIfChanges,
+		"SecurityGroupRule:api-elb-ipv6": LifecycleSync,
+		"InternetGateway:*.example.com":  LifecycleIgnore,
+	}
+
+	grid := []struct {
+		typeName          string
+		name              string
+		expectedLifecycle Lifecycle
+		expectedFound     bool
+	}{
+		{typeName: "SecurityGroup", name: "nodes.example.com", expectedLifecycle: LifecycleIgnore, expectedFound: true},
+		{typeName: "SecurityGroupRule", name: "from-0.0.0.0/0-ingress-tcp-22to22-nodes", expectedLifecycle: LifecycleExistsAndValidates, expectedFound: true},
+		{typeName: "SecurityGroupRule", name: "api-elb-egress", expectedLifecycle: LifecycleExistsAndWarnIfChanges, expectedFound: true},
+		{typeName: "SecurityGroupRule", name: "api-elb-ipv6", expectedLifecycle: LifecycleSync, expectedFound: true},
+		{typeName: "InternetGateway", name: "minimal.example.com", expectedLifecycle: LifecycleIgnore, expectedFound: true},
+		{typeName: "InternetGateway", name: "minimal.example.org", expectedFound: false},
+		{typeName: "VPC", name: "minimal.example.com", expectedFound: false},
+	}
+	for _, g := range grid {
+		t.Run(g.typeName+"/"+g.name, func(t *testing.T) {
+			lifecycle, found := FindLifecycleOverride(overrides, g.typeName, g.name)
+			if found != g.expectedFound {
+				t.Fatalf("expected found=%v, got %v", g.expectedFound, found)
+			}
+			if lifecycle != g.expectedLifecycle {
+				t.Errorf("expected lifecycle %q, got %q", g.expectedLifecycle, lifecycle)
+			}
+		})
+	}
+}
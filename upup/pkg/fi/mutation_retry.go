@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// ErrCircuitOpen is returned by the cloud mutations once too many of them failed in a row.
+// The executor stops running tasks when a task fails with it, instead of retrying the tasks until they time out.
+var ErrCircuitOpen = errors.New("too many consecutive failures of cloud mutations")
+
+// MutationErrorClass is the class of an error returned by a cloud mutation, which selects the retry policy.
+type MutationErrorClass string
+
+const (
+	// MutationErrorThrottled is the class of the requests rejected by the rate limits of the cloud API.
+	MutationErrorThrottled MutationErrorClass = "throttled"
+	// MutationErrorEventualConsistency is the class of the requests for resources that have not propagated yet.
+	MutationErrorEventualConsistency MutationErrorClass = "eventual-consistency"
+	// MutationErrorInvalid is the class of the requests that fail the same way when retried.
+	MutationErrorInvalid MutationErrorClass = "invalid"
+)
+
+// MutationRetryPolicy is how a mutation that failed with an error of a class is retried.
+type MutationRetryPolicy struct {
+	// MaxAttempts is the number of attempts of the mutation, including the first one; zero or one means no retries.
+	MaxAttempts int
+	// Interval is the wait before the second attempt.
+	Interval time.Duration
+	// Factor multiplies the wait after each attempt; zero or one waits Interval between all the attempts.
+	Factor float64
+	// MaxInterval caps the wait between the attempts; zero means no cap.
+	MaxInterval time.Duration
+	// Jitter is the fraction of the wait that is randomized, so that the tasks throttled together do not retry together.
+	Jitter float64
+}
+
+// backoff returns the wait after the given attempt, counted from 1.
+func (p *MutationRetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.Interval)
+	for i := 1; i < attempt && p.Factor > 1; i++ {
+		d *= p.Factor
+		if p.MaxInterval != 0 && d >= float64(p.MaxInterval) {
+			break
+		}
+	}
+	if p.MaxInterval != 0 && d > float64(p.MaxInterval) {
+		d = float64(p.MaxInterval)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+// CircuitBreaker counts the consecutive failures of the cloud mutations, and opens once they reach its threshold,
+// failing the following mutations with ErrCircuitOpen. It stays open for the rest of the run.
+type CircuitBreaker struct {
+	threshold int
+
+	mutex    sync.Mutex
+	failures int
+}
+
+// NewCircuitBreaker builds a CircuitBreaker that opens after threshold consecutive failures; zero never opens.
+func NewCircuitBreaker(threshold int) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold}
+}
+
+// Allow returns ErrCircuitOpen if the breaker is open. A nil breaker is always closed.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.threshold > 0 && b.failures >= b.threshold {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// Record counts a mutation that failed after its retries, or resets the count after a successful one.
+// Only the errors of the retryable classes are recorded, as the others do not tell that the cloud is failing.
+func (b *CircuitBreaker) Record(err error) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if err == nil {
+		b.failures = 0
+	} else {
+		b.failures++
+		if b.threshold > 0 && b.failures == b.threshold {
+			klog.Warningf("%d consecutive cloud mutations failed, aborting", b.failures)
+		}
+	}
+}
+
+// MutationRetrier retries the cloud mutations with the policy of the class of their errors, and trips its breaker
+// with the mutations that still fail with retryable errors after their retries.
+// A retrier with a breaker is built for each run, so that an open breaker does not outlive it.
+type MutationRetrier struct {
+	// Classify returns the class of an error; the errors of classes without a policy are not retried.
+	Classify func(err error) MutationErrorClass
+	// Policies holds the retry policy of each class of errors.
+	Policies map[MutationErrorClass]MutationRetryPolicy
+	// Breaker, if set, aborts the mutations after too many consecutive failures.
+	Breaker *CircuitBreaker
+
+	// sleep waits between the attempts; it is replaced by tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// Do runs the mutation, retrying it while its errors are retryable. The description names the mutation in the logs and errors.
+func (r *MutationRetrier) Do(ctx context.Context, description string, mutation func() error) error {
+	if err := r.Breaker.Allow(); err != nil {
+		return fmt.Errorf("not %s: %w", description, err)
+	}
+	err, exhausted := r.retry(ctx, description, mutation)
+	if err == nil || exhausted {
+		r.Breaker.Record(err)
+	}
+	return err
+}
+
+// Retry runs the request, retrying it while its errors are retryable, like Do, but without the breaker.
+// It is used for the reads, whose failures must not abort the mutations.
+func (r *MutationRetrier) Retry(ctx context.Context, description string, request func() error) error {
+	err, _ := r.retry(ctx, description, request)
+	return err
+}
+
+// retry runs the mutation until it succeeds, or fails with an error that is not retried.
+// exhausted is true when the mutation still failed with a retryable error after all the attempts of its policy.
+func (r *MutationRetrier) retry(ctx context.Context, description string, mutation func() error) (err error, exhausted bool) {
+	attempts := make(map[MutationErrorClass]int)
+	for {
+		err := mutation()
+		if err == nil {
+			return nil, false
+		}
+
+		class := MutationErrorInvalid
+		if r.Classify != nil {
+			class = r.Classify(err)
+		}
+		policy, found := r.Policies[class]
+		if !found {
+			return err, false
+		}
+		attempts[class]++
+		attempt := attempts[class]
+		if attempt >= policy.MaxAttempts {
+			if attempt == 1 {
+				return err, true
+			}
+			return fmt.Errorf("got %s error while %s, but retried too many times without success: %w", class, description, err), true
+		}
+
+		wait := policy.backoff(attempt)
+		if attempt%10 == 0 {
+			klog.Infof("retrying after %d %s errors while %s", attempt, class, description)
+		}
+		klog.V(2).Infof("will retry in %v after encountering %s error while %s: %v", wait, class, description, err)
+
+		sleep := r.sleep
+		if sleep == nil {
+			sleep = sleepContext
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return err, false
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
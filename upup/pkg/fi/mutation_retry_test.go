@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	errTestThrottled = errors.New("throttled")
+	errTestNotFound  = errors.New("not found")
+	errTestInvalid   = errors.New("invalid")
+)
+
+// newTestRetrier builds a retrier that records its waits instead of sleeping.
+func newTestRetrier(breaker *CircuitBreaker, waits *[]time.Duration) *MutationRetrier {
+	return &MutationRetrier{
+		Classify: func(err error) MutationErrorClass {
+			switch err {
+			case errTestThrottled:
+				return MutationErrorThrottled
+			case errTestNotFound:
+				return MutationErrorEventualConsistency
+			default:
+				return MutationErrorInvalid
+			}
+		},
+		Policies: map[MutationErrorClass]MutationRetryPolicy{
+			MutationErrorThrottled:           {MaxAttempts: 4, Interval: time.Second, Factor: 2, MaxInterval: 3 * time.Second},
+			MutationErrorEventualConsistency: {MaxAttempts: 3, Interval: time.Second},
+		},
+		Breaker: breaker,
+		sleep: func(ctx context.Context, d time.Duration) error {
+			*waits = append(*waits, d)
+			return nil
+		},
+	}
+}
+
+func TestMutationRetrierPolicies(t *testing.T) {
+	grid := []struct {
+		name          string
+		errors        []error
+		expectedWaits []time.Duration
+		expectedError bool
+	}{
+		{
+			name:          "throttled backs off exponentially",
+			errors:        []error{errTestThrottled, errTestThrottled, errTestThrottled},
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		{
+			name:          "throttled too many times",
+			errors:        []error{errTestThrottled, errTestThrottled, errTestThrottled, errTestThrottled},
+			expectedWaits: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+			expectedError: true,
+		},
+		{
+			name:          "eventual consistency retries at a steady pace",
+			errors:        []error{errTestNotFound, errTestNotFound},
+			expectedWaits: []time.Duration{time.Second, time.Second},
+		},
+		{
+			name:          "classes are counted separately",
+			errors:        []error{errTestNotFound, errTestThrottled, errTestNotFound, errTestThrottled},
+			expectedWaits: []time.Duration{time.Second, time.Second, time.Second, 2 * time.Second},
+		},
+		{
+			name:          "invalid is not retried",
+			errors:        []error{errTestInvalid},
+			expectedError: true,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			var waits []time.Duration
+			r := newTestRetrier(nil, &waits)
+			calls := 0
+			err := r.Do(context.Background(), "testing", func() error {
+				calls++
+				if calls <= len(g.errors) {
+					return g.errors[calls-1]
+				}
+				return nil
+			})
+			if g.expectedError != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(waits, g.expectedWaits) {
+				t.Errorf("expected waits %v, got %v", g.expectedWaits, waits)
+			}
+		})
+	}
+}
+
+func TestMutationRetryPolicyJitter(t *testing.T) {
+	p := &MutationRetryPolicy{Interval: 10 * time.Second, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		d := p.backoff(1)
+		if d < 8*time.Second || d > 12*time.Second {
+			t.Fatalf("backoff %v out of the jitter range", d)
+		}
+	}
+}
+
+func TestMutationRetrierCircuitBreaker(t *testing.T) {
+	var waits []time.Duration
+	r := newTestRetrier(NewCircuitBreaker(3), &waits)
+	ctx := context.Background()
+
+	failing := func() error { return errTestThrottled }
+	invalid := func() error { return errTestInvalid }
+	succeeding := func() error { return nil }
+
+	// A success resets the count of consecutive failures
+	for i := 0; i < 2; i++ {
+		if err := r.Do(ctx, "testing", failing); !errors.Is(err, errTestThrottled) {
+			t.Fatalf("expected throttled error, got %v", err)
+		}
+	}
+	if err := r.Do(ctx, "testing", succeeding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Errors that are not retried, and failed reads, are not counted
+	for i := 0; i < 5; i++ {
+		if err := r.Do(ctx, "testing", invalid); !errors.Is(err, errTestInvalid) {
+			t.Fatalf("expected invalid error, got %v", err)
+		}
+		if err := r.Retry(ctx, "testing", failing); !errors.Is(err, errTestThrottled) {
+			t.Fatalf("expected throttled error, got %v", err)
+		}
+	}
+	if err := r.Breaker.Allow(); err != nil {
+		t.Fatalf("expected the breaker to be closed, got %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := r.Do(ctx, "testing", failing); !errors.Is(err, errTestThrottled) {
+			t.Fatalf("expected throttled error, got %v", err)
+		}
+	}
+	calls := 0
+	err := r.Do(ctx, "testing", func() error {
+		calls++
+		return nil
+	})
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected circuit open error, got %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("expected no call once the circuit is open, got %d", calls)
+	}
+	if expected := fmt.Sprintf("not testing: %v", ErrCircuitOpen); err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err.Error())
+	}
+}
@@ -91,6 +91,15 @@ func (e *File) GetDependencies(tasks map[string]fi.NodeupTask) []fi.NodeupTask {
 		deps = append(deps, hasDep.GetDependencies(tasks)...)
 	}
 
+	// Static pods such as etcd and kube-apiserver bind to the address of the static network interface
+	if strings.HasPrefix(e.Path, "/etc/kubernetes/manifests/") {
+		for _, v := range tasks {
+			if _, ok := v.(*StaticNetworkInterface); ok {
+				deps = append(deps, v)
+			}
+		}
+	}
+
 	// Requires other files to be created first
 	for _, f := range e.AfterFiles {
 		for _, v := range tasks {
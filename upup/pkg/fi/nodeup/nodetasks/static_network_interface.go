@@ -0,0 +1,253 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/nodeup/local"
+)
+
+const (
+	// staticNetworkInterfaceDeviceIndex is the device index at which the network interface is attached
+	staticNetworkInterfaceDeviceIndex = 1
+	// staticNetworkInterfaceRouteTable is the routing table for traffic from the address of the network interface,
+	// following the numbering of the routing tables of amazon-ec2-net-utils
+	staticNetworkInterfaceRouteTable = "10001"
+)
+
+// StaticNetworkInterface attaches a network interface that outlives the instance, and configures its address.
+type StaticNetworkInterface struct {
+	Name string
+
+	// ID is the ID of the network interface.
+	// If not set, the network interface is found by the tags set by kOps.
+	ID                string
+	ClusterName       string
+	InstanceGroupName string
+}
+
+var _ fi.HasName = &StaticNetworkInterface{}
+
+func (e *StaticNetworkInterface) GetName() *string {
+	return &e.Name
+}
+
+// String returns a string representation, implementing the Stringer interface
+func (e *StaticNetworkInterface) String() string {
+	return fmt.Sprintf("StaticNetworkInterface: %s", e.Name)
+}
+
+func (e *StaticNetworkInterface) Find(c *fi.NodeupContext) (*StaticNetworkInterface, error) {
+	if c.T.BootConfig.CloudProvider != kops.CloudProviderAWS {
+		return nil, fmt.Errorf("unsupported cloud provider: %s", c.T.BootConfig.CloudProvider)
+	}
+	ctx := c.Context()
+
+	macs, err := getInstanceMetadataList(ctx, "network/interfaces/macs/")
+	if err != nil {
+		return nil, err
+	}
+	for _, mac := range macs {
+		mac = strings.TrimSuffix(mac, "/")
+		deviceNumber, err := getInstanceMetadataFirstValue(ctx, path.Join("network/interfaces/macs/", mac, "/device-number"))
+		if err != nil {
+			return nil, err
+		}
+		if deviceNumber != strconv.Itoa(staticNetworkInterfaceDeviceIndex) {
+			continue
+		}
+
+		ip, err := getInstanceMetadataFirstValue(ctx, path.Join("network/interfaces/macs/", mac, "/local-ipv4s"))
+		if err != nil {
+			return nil, err
+		}
+		configured, err := hasLocalAddress(ip)
+		if err != nil {
+			return nil, err
+		}
+		if !configured {
+			return nil, nil
+		}
+
+		klog.V(2).Infof("found attached network interface with address %s", ip)
+		actual := *e
+		return &actual, nil
+	}
+
+	return nil, nil
+}
+
+func (e *StaticNetworkInterface) Run(c *fi.NodeupContext) error {
+	return fi.NodeupDefaultDeltaRunMethod(e, c)
+}
+
+func (_ *StaticNetworkInterface) CheckChanges(a, e, changes *StaticNetworkInterface) error {
+	return nil
+}
+
+func (_ *StaticNetworkInterface) RenderLocal(c *fi.NodeupContext, t *local.LocalTarget, a, e, changes *StaticNetworkInterface) error {
+	ctx := c.Context()
+	cloud := t.Cloud.(awsup.AWSCloud)
+
+	instanceID, err := getInstanceMetadataFirstValue(ctx, "instance-id")
+	if err != nil {
+		return err
+	}
+
+	eni, err := FindStaticNetworkInterface(ctx, cloud, e.ID, e.ClusterName, e.InstanceGroupName)
+	if err != nil {
+		return err
+	}
+	eniID := aws.ToString(eni.NetworkInterfaceId)
+
+	if eni.Attachment == nil {
+		klog.Infof("attaching network interface %q to instance %q", eniID, instanceID)
+		_, err := cloud.EC2().AttachNetworkInterface(ctx, &ec2.AttachNetworkInterfaceInput{
+			DeviceIndex:        aws.Int32(staticNetworkInterfaceDeviceIndex),
+			InstanceId:         aws.String(instanceID),
+			NetworkInterfaceId: aws.String(eniID),
+		})
+		if err != nil {
+			return fmt.Errorf("error attaching network interface %q: %w", eniID, err)
+		}
+	} else if attachedTo := aws.ToString(eni.Attachment.InstanceId); attachedTo != instanceID {
+		// The previous instance may still be shutting down, we'll retry until it has released the network interface
+		return fmt.Errorf("network interface %q is still attached to instance %q", eniID, attachedTo)
+	}
+
+	mac := aws.ToString(eni.MacAddress)
+	var link string
+	var subnetCIDR string
+	err = wait.PollUntilContextTimeout(ctx, 2*time.Second, 2*time.Minute, true, func(ctx context.Context) (bool, error) {
+		link, err = findLinkByMAC(mac)
+		if err != nil || link == "" {
+			return false, err
+		}
+		subnetCIDR, err = getInstanceMetadataFirstValue(ctx, path.Join("network/interfaces/macs/", mac, "/subnet-ipv4-cidr-block"))
+		return err == nil, nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for network interface %q to be available: %w", eniID, err)
+	}
+
+	_, subnet, err := net.ParseCIDR(subnetCIDR)
+	if err != nil {
+		return fmt.Errorf("error parsing subnet CIDR %q: %w", subnetCIDR, err)
+	}
+	ones, _ := subnet.Mask.Size()
+	gateway := subnet.IP.To4()
+	if gateway == nil {
+		return fmt.Errorf("subnet CIDR %q is not IPv4", subnetCIDR)
+	}
+	gateway = net.IPv4(gateway[0], gateway[1], gateway[2], gateway[3]+1)
+	ip := aws.ToString(eni.PrivateIpAddress)
+
+	// The network interface is in the same subnet as the primary network interface, so we don't add a route
+	// to the main routing table, and route the traffic from its address through the network interface instead
+	commands := [][]string{
+		{"ip", "link", "set", "dev", link, "up"},
+		{"ip", "addr", "replace", ip + "/" + strconv.Itoa(ones), "dev", link, "noprefixroute"},
+		{"ip", "route", "replace", subnet.String(), "dev", link, "src", ip, "table", staticNetworkInterfaceRouteTable},
+		{"ip", "route", "replace", "default", "via", gateway.String(), "dev", link, "table", staticNetworkInterfaceRouteTable},
+	}
+	for _, args := range commands {
+		if output, err := t.CombinedOutput(args); err != nil {
+			return fmt.Errorf("error doing %q: %v: %s", strings.Join(args, " "), err, string(output))
+		}
+	}
+
+	rule := []string{"ip", "rule", "add", "from", ip, "table", staticNetworkInterfaceRouteTable}
+	if output, err := t.CombinedOutput([]string{"ip", "rule", "list", "from", ip}); err != nil {
+		return fmt.Errorf("error listing routing rules: %v: %s", err, string(output))
+	} else if !strings.Contains(string(output), "lookup "+staticNetworkInterfaceRouteTable) {
+		if output, err := t.CombinedOutput(rule); err != nil {
+			return fmt.Errorf("error doing %q: %v: %s", strings.Join(rule, " "), err, string(output))
+		}
+	}
+
+	klog.Infof("configured address %s of network interface %q on %s", ip, eniID, link)
+	return nil
+}
+
+// FindStaticNetworkInterface returns the static network interface of the instance group, by ID or by the tags set by kOps
+func FindStaticNetworkInterface(ctx context.Context, cloud awsup.AWSCloud, id, clusterName, instanceGroupName string) (*ec2types.NetworkInterface, error) {
+	request := &ec2.DescribeNetworkInterfacesInput{}
+	if id != "" {
+		request.NetworkInterfaceIds = []string{id}
+	} else {
+		request.Filters = []ec2types.Filter{
+			awsup.NewEC2Filter("tag:"+awsup.TagClusterName, clusterName),
+			awsup.NewEC2Filter("tag:"+awsup.TagNameStaticNetworkInterface, instanceGroupName),
+		}
+	}
+
+	response, err := cloud.EC2().DescribeNetworkInterfaces(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("error describing network interfaces: %w", err)
+	}
+	switch len(response.NetworkInterfaces) {
+	case 0:
+		return nil, fmt.Errorf("network interface for instance group %q not found", instanceGroupName)
+	case 1:
+		return &response.NetworkInterfaces[0], nil
+	default:
+		return nil, fmt.Errorf("found multiple network interfaces for instance group %q", instanceGroupName)
+	}
+}
+
+// findLinkByMAC returns the name of the local network link with the given MAC address, or "" if there is none
+func findLinkByMAC(mac string) (string, error) {
+	links, err := net.Interfaces()
+	if err != nil {
+		return "", fmt.Errorf("error listing network links: %w", err)
+	}
+	for _, link := range links {
+		if strings.EqualFold(link.HardwareAddr.String(), mac) {
+			return link.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// hasLocalAddress returns true if the IP address is configured on one of the local network links
+func hasLocalAddress(ip string) (bool, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return false, fmt.Errorf("error listing network addresses: %w", err)
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.String() == ip {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -161,7 +161,8 @@ func (c *ModelBuilderContext[T]) EnsureTask(task Task[T]) {
 	c.Tasks[key] = task
 }
 
-// setLifecycleOverride determines if a Lifecycle is in the LifecycleOverrides map for the current task.
+// setLifecycleOverride determines if a Lifecycle is in the LifecycleOverrides map for the current task,
+// either for its task type or for a pattern matching its name.
 // If the lifecycle exist then the task lifecycle is set to the lifecycle provides in LifecycleOverrides.
 // This func allows for lifecycles to be passed in dynamically and have the task lifecycle set accordingly.
 func (c *ModelBuilderContext[T]) setLifecycleOverride(task Task[T]) Task[T] {
@@ -170,8 +171,13 @@ func (c *ModelBuilderContext[T]) setLifecycleOverride(task Task[T]) Task[T] {
 	// certain tasks have not implemented HasLifecycle interface
 	typeName := TypeNameForTask(task)
 
+	var name string
+	if hasName, ok := task.(HasName); ok {
+		name = ValueOf(hasName.GetName())
+	}
+
 	// typeName can be values like "InternetGateway"
-	value, ok := c.LifecycleOverrides[typeName]
+	value, ok := FindLifecycleOverride(c.LifecycleOverrides, typeName, name)
 	if ok {
 		hl, okHL := task.(HasLifecycle)
 		if !okHL {
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	// TaskGraphFormatDot is the format of Graphviz.
+	TaskGraphFormatDot = "dot"
+	// TaskGraphFormatMermaid is the format of Mermaid flowcharts.
+	TaskGraphFormatMermaid = "mermaid"
+)
+
+// TaskGraphFormats are the formats in which a TaskGraph can be written.
+var TaskGraphFormats = []string{TaskGraphFormatDot, TaskGraphFormatMermaid}
+
+// TaskGraph is the dependency graph of the tasks, annotated with the actions that the tasks would take.
+type TaskGraph struct {
+	// Nodes are ordered by key.
+	Nodes []*TaskGraphNode
+}
+
+// TaskGraphNode is a task of a TaskGraph, or an item that would be deleted.
+type TaskGraphNode struct {
+	// Key is the type and name of the task, such as SecurityGroup/nodes.example.com.
+	Key string
+	// Action is one of create, modify or delete, or empty if the task would not change anything.
+	Action string
+	// Dependencies are the keys of the tasks that run before this task, ordered by key.
+	Dependencies []string
+}
+
+// TaskGraph returns the dependency graph of the tasks, annotated with the changes recorded by the target.
+func (t *DryRunTarget[T]) TaskGraph(taskMap map[string]Task[T]) *TaskGraph {
+	actions := make(map[Task[T]]string)
+	for _, r := range t.changes {
+		if r.aIsNil {
+			actions[r.e] = "create"
+		} else {
+			actions[r.e] = "modify"
+		}
+	}
+
+	graph := &TaskGraph{}
+	for key, dependencies := range FindTaskDependencies(taskMap) {
+		dependencies = append([]string(nil), dependencies...)
+		sort.Strings(dependencies)
+		graph.Nodes = append(graph.Nodes, &TaskGraphNode{
+			Key:          key,
+			Action:       actions[taskMap[key]],
+			Dependencies: dependencies,
+		})
+	}
+	for _, d := range t.deletions {
+		graph.Nodes = append(graph.Nodes, &TaskGraphNode{
+			Key:    d.TaskName() + "/" + d.Item(),
+			Action: "delete",
+		})
+	}
+
+	sort.Slice(graph.Nodes, func(i, j int) bool {
+		return graph.Nodes[i].Key < graph.Nodes[j].Key
+	})
+	return graph
+}
+
+// taskGraphColors are the colors of the nodes of each action.
+var taskGraphColors = map[string]string{
+	"create": "#d4edda",
+	"modify": "#fff3cd",
+	"delete": "#f8d7da",
+	"":       "#ffffff",
+}
+
+func (n *TaskGraphNode) label() string {
+	if n.Action == "" {
+		return n.Key
+	}
+	return n.Key + " (" + n.Action + ")"
+}
+
+// Write writes the graph in the format, which must be one of TaskGraphFormats.
+// The edges go from each task to the tasks that depend on it, in the order the tasks run.
+func (g *TaskGraph) Write(out io.Writer, format string) error {
+	switch format {
+	case TaskGraphFormatDot:
+		return g.writeDot(out)
+	case TaskGraphFormatMermaid:
+		return g.writeMermaid(out)
+	default:
+		return fmt.Errorf("unknown graph format %q, available formats: %s", format, strings.Join(TaskGraphFormats, ", "))
+	}
+}
+
+func (g *TaskGraph) writeDot(out io.Writer) error {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "digraph tasks {\n")
+	fmt.Fprintf(b, "  rankdir=LR;\n")
+	fmt.Fprintf(b, "  node [shape=box, style=filled];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(b, "  %q [label=%q, fillcolor=%q];\n", n.Key, n.label(), taskGraphColors[n.Action])
+	}
+	for _, n := range g.Nodes {
+		for _, dependency := range n.Dependencies {
+			fmt.Fprintf(b, "  %q -> %q;\n", dependency, n.Key)
+		}
+	}
+	fmt.Fprintf(b, "}\n")
+
+	_, err := out.Write(b.Bytes())
+	return err
+}
+
+func (g *TaskGraph) writeMermaid(out io.Writer) error {
+	// Mermaid identifiers cannot hold the characters of the task names, so the nodes are numbered
+	ids := make(map[string]string)
+	for i, n := range g.Nodes {
+		ids[n.Key] = fmt.Sprintf("t%d", i)
+	}
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "flowchart LR\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(b, "  %s[\"%s\"]\n", ids[n.Key], strings.ReplaceAll(n.label(), `"`, "#quot;"))
+	}
+	for _, n := range g.Nodes {
+		for _, dependency := range n.Dependencies {
+			fmt.Fprintf(b, "  %s --> %s\n", ids[dependency], ids[n.Key])
+		}
+	}
+	for _, action := range []string{"create", "modify", "delete"} {
+		var nodes []string
+		for _, n := range g.Nodes {
+			if n.Action == action {
+				nodes = append(nodes, ids[n.Key])
+			}
+		}
+		if len(nodes) != 0 {
+			fmt.Fprintf(b, "  classDef %s fill:%s\n", action, taskGraphColors[action])
+			fmt.Fprintf(b, "  class %s %s\n", strings.Join(nodes, ","), action)
+		}
+	}
+
+	_, err := out.Write(b.Bytes())
+	return err
+}
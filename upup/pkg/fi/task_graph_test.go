@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestTaskGraph(t *testing.T) {
+	builder := assets.NewAssetBuilder(vfs.Context, nil, "1.17.3", false)
+	target := newDryRunTarget[CloudupSubContext](builder, &bytes.Buffer{})
+
+	vpc := &executorTestTask{}
+	subnet := &executorTestTask{dependencies: []CloudupTask{vpc}}
+	instance := &executorTestTask{dependencies: []CloudupTask{subnet, vpc}}
+	tasks := map[string]CloudupTask{
+		"VPC/main":      vpc,
+		"Subnet/a":      subnet,
+		"Instance/node": instance,
+	}
+
+	var missing *executorTestTask
+	assert.NoError(t, target.Render(missing, subnet, subnet), "target.Render()")
+	assert.NoError(t, target.Render(instance, instance, &executorTestTask{}), "target.Render()")
+
+	graph := target.TaskGraph(tasks)
+	assert.Equal(t, &TaskGraph{
+		Nodes: []*TaskGraphNode{
+			{Key: "Instance/node", Action: "modify", Dependencies: []string{"Subnet/a", "VPC/main"}},
+			{Key: "Subnet/a", Action: "create", Dependencies: []string{"VPC/main"}},
+			{Key: "VPC/main"},
+		},
+	}, graph)
+
+	var dot bytes.Buffer
+	assert.NoError(t, graph.Write(&dot, TaskGraphFormatDot), "graph.Write(dot)")
+	assert.Equal(t, `digraph tasks {
+  rankdir=LR;
+  node [shape=box, style=filled];
+  "Instance/node" [label="Instance/node (modify)", fillcolor="#fff3cd"];
+  "Subnet/a" [label="Subnet/a (create)", fillcolor="#d4edda"];
+  "VPC/main" [label="VPC/main", fillcolor="#ffffff"];
+  "Subnet/a" -> "Instance/node";
+  "VPC/main" -> "Instance/node";
+  "VPC/main" -> "Subnet/a";
+}
+`, dot.String())
+
+	var mermaid bytes.Buffer
+	assert.NoError(t, graph.Write(&mermaid, TaskGraphFormatMermaid), "graph.Write(mermaid)")
+	assert.Equal(t, `flowchart LR
+  t0["Instance/node (modify)"]
+  t1["Subnet/a (create)"]
+  t2["VPC/main"]
+  t1 --> t0
+  t2 --> t0
+  t2 --> t1
+  classDef create fill:#d4edda
+  class t1 create
+  classDef modify fill:#fff3cd
+  class t0 modify
+`, mermaid.String())
+
+	assert.Error(t, graph.Write(&bytes.Buffer{}, "svg"), "graph.Write(svg)")
+}
@@ -92,7 +92,7 @@ func (c *VFSKeystoreReader) loadKeyset(ctx context.Context, p vfs.Path) (*Keyset
 		return nil, fmt.Errorf("error parsing bundle %q: %v", p, err)
 	}
 
-	keyset, err := parseKeyset(o)
+	keyset, err := ParseKeyset(o)
 	if err != nil {
 		return nil, fmt.Errorf("error mapping bundle %q: %v", p, err)
 	}